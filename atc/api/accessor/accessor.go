@@ -3,6 +3,7 @@ package accessor
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -15,6 +16,7 @@ type Access interface {
 	HasToken() bool
 	IsAuthenticated() bool
 	IsAuthorized(string) bool
+	IsAuthorizedForPipeline(string, atc.PipelineRef) bool
 	IsAdmin() bool
 	IsSystem() bool
 	TeamNames() []string
@@ -23,6 +25,22 @@ type Access interface {
 	UserInfo() atc.UserInfo
 }
 
+// ServiceAccountConnector is the connector ID used in the federated claims of
+// tokens issued to service accounts.
+const ServiceAccountConnector = "serviceaccount"
+
+// ServiceAccountClaim holds the team and role a service account token is
+// bound to.
+const ServiceAccountClaim = "service_account"
+
+// BuildTokenConnector is the connector ID used in the federated claims of
+// tokens issued to builds.
+const BuildTokenConnector = "build"
+
+// BuildTokenClaim holds the team, pipeline, build and role a build token is
+// bound to.
+const BuildTokenClaim = "build"
+
 type Claims struct {
 	Sub               string
 	UserID            string
@@ -36,6 +54,10 @@ type Verification struct {
 	HasToken     bool
 	IsTokenValid bool
 	RawClaims    map[string]interface{}
+
+	// LongestIdle is the longest the token has gone unused, for enforcing
+	// each team's session idle timeout.
+	LongestIdle time.Duration
 }
 
 type access struct {
@@ -44,7 +66,9 @@ type access struct {
 	systemClaimKey         string
 	systemClaimValues      []string
 	teams                  []db.Team
+	groupRoles             map[string]atc.TeamAuth
 	teamRoles              map[string][]string
+	pipelineRoles          map[string]map[string][]string
 	isAdmin                bool
 	displayUserIdGenerator atc.DisplayUserIdGenerator
 }
@@ -55,6 +79,7 @@ func NewAccessor(
 	systemClaimKey string,
 	systemClaimValues []string,
 	teams []db.Team,
+	groupRoles map[string]atc.TeamAuth,
 	displayUserIdGenerator atc.DisplayUserIdGenerator,
 ) *access {
 	a := &access{
@@ -63,6 +88,7 @@ func NewAccessor(
 		systemClaimKey:         systemClaimKey,
 		systemClaimValues:      systemClaimValues,
 		teams:                  teams,
+		groupRoles:             groupRoles,
 		displayUserIdGenerator: displayUserIdGenerator,
 	}
 	a.computeTeamRoles()
@@ -71,15 +97,52 @@ func NewAccessor(
 
 func (a *access) computeTeamRoles() {
 	a.teamRoles = map[string][]string{}
+	a.pipelineRoles = map[string]map[string][]string{}
 
 	for _, team := range a.teams {
+		if a.sessionExpired(team) {
+			continue
+		}
+
 		roles := a.rolesForTeam(team.Auth())
+		for _, role := range a.rolesForTeam(a.groupRoles[team.Name()]) {
+			if !contains(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+		if role := a.serviceAccountRole(team.ID()); role != "" && !contains(roles, role) {
+			roles = append(roles, role)
+		}
 		if len(roles) > 0 {
 			a.teamRoles[team.Name()] = roles
 		}
 		if team.Admin() && contains(roles, "owner") {
 			a.isAdmin = true
 		}
+
+		for _, grant := range team.PipelineAuth() {
+			roles := a.rolesForTeam(grant.Auth)
+			if len(roles) == 0 {
+				continue
+			}
+
+			if a.pipelineRoles[team.Name()] == nil {
+				a.pipelineRoles[team.Name()] = map[string][]string{}
+			}
+
+			a.pipelineRoles[team.Name()][grant.Pipeline.String()] = roles
+		}
+
+		if pipelineRef, role := a.buildTokenRole(team.ID()); role != "" {
+			if a.pipelineRoles[team.Name()] == nil {
+				a.pipelineRoles[team.Name()] = map[string][]string{}
+			}
+
+			roles := a.pipelineRoles[team.Name()][pipelineRef.String()]
+			if !contains(roles, role) {
+				a.pipelineRoles[team.Name()][pipelineRef.String()] = append(roles, role)
+			}
+		}
 	}
 }
 
@@ -140,6 +203,116 @@ func (a *access) rolesForTeam(auth atc.TeamAuth) []string {
 	return roles
 }
 
+// sessionExpired reports whether the team's own limits on token lifetime and
+// session idleness have revoked the token's access to the team.
+func (a *access) sessionExpired(team db.Team) bool {
+	if lifetime := team.AccessTokenLifetime(); lifetime > 0 {
+		issuedAt, ok := a.issuedAt()
+		if !ok || time.Since(issuedAt) > lifetime {
+			return true
+		}
+	}
+
+	if timeout := team.SessionIdleTimeout(); timeout > 0 && a.verification.LongestIdle > timeout {
+		return true
+	}
+
+	return false
+}
+
+func (a *access) issuedAt() (time.Time, bool) {
+	if iat, ok := a.claims()["iat"].(float64); ok {
+		return time.Unix(int64(iat), 0), true
+	}
+	return time.Time{}, false
+}
+
+// serviceAccountRole returns the role bound to a service account token for
+// the given team. Service accounts are not listed in the team's auth config,
+// so their role is carried by the token's claims instead. The binding refers
+// to the team by ID so that it survives the team being renamed.
+func (a *access) serviceAccountRole(teamID int) string {
+	binding, ok := a.binding(ServiceAccountConnector, ServiceAccountClaim, teamID)
+	if !ok {
+		return ""
+	}
+
+	role, _ := binding["role"].(string)
+	return role
+}
+
+// buildTokenRole returns the pipeline and role bound to a build token for the
+// given team. The role only applies to the pipeline of the build the token
+// was issued to, rather than to the whole team.
+func (a *access) buildTokenRole(teamID int) (atc.PipelineRef, string) {
+	binding, ok := a.binding(BuildTokenConnector, BuildTokenClaim, teamID)
+	if !ok {
+		return atc.PipelineRef{}, ""
+	}
+
+	pipelineName, _ := binding["pipeline"].(string)
+	if pipelineName == "" {
+		return atc.PipelineRef{}, ""
+	}
+
+	pipelineRef := atc.PipelineRef{Name: pipelineName}
+	if instanceVars, ok := binding["pipeline_instance_vars"].(map[string]interface{}); ok {
+		pipelineRef.InstanceVars = instanceVars
+	}
+
+	role, _ := binding["role"].(string)
+	return pipelineRef, role
+}
+
+// binding returns the given claim of a token issued through the given
+// connector, provided that it is bound to the given team.
+func (a *access) binding(connectorID string, claim string, teamID int) (map[string]interface{}, bool) {
+	if a.connectorID() != connectorID {
+		return nil, false
+	}
+
+	binding, ok := a.claims()[claim].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	boundTeamID, ok := claimInt(binding["team_id"])
+	if !ok || boundTeamID != teamID {
+		return nil, false
+	}
+
+	return binding, true
+}
+
+// claimInt converts a numeric claim to an int. Claims are decoded from JSON,
+// so numbers usually come back as float64.
+func claimInt(claim interface{}) (int, bool) {
+	switch n := claim.(type) {
+	case float64:
+		return int(n), true
+	case int:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// BuildTokenBuildID returns the ID of the build that a build token's claims
+// are bound to.
+func BuildTokenBuildID(claims map[string]interface{}) (int, bool) {
+	federatedClaims, _ := claims["federated_claims"].(map[string]interface{})
+	if connectorID, _ := federatedClaims["connector_id"].(string); connectorID != BuildTokenConnector {
+		return 0, false
+	}
+
+	binding, ok := claims[BuildTokenClaim].(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+
+	return claimInt(binding["build_id"])
+}
+
 func (a *access) HasToken() bool {
 	return a.verification.HasToken
 }
@@ -152,6 +325,13 @@ func (a *access) IsAuthorized(teamName string) bool {
 	return a.isAdmin || a.hasPermission(a.teamRoles[teamName])
 }
 
+// IsAuthorizedForPipeline only considers the roles granted on the pipeline
+// itself; roles granted for the whole team are checked by IsAuthorized. An
+// instanced pipeline's instance vars must match too.
+func (a *access) IsAuthorizedForPipeline(teamName string, pipelineRef atc.PipelineRef) bool {
+	return a.hasPermission(a.pipelineRoles[teamName][pipelineRef.String()])
+}
+
 func (a *access) TeamNames() []string {
 	teamNames := []string{}
 	for _, team := range a.teams {
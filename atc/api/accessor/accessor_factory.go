@@ -18,18 +18,29 @@ type TeamFetcher interface {
 	GetTeams() ([]db.Team, error)
 }
 
+//counterfeiter:generate . BuildFetcher
+type BuildFetcher interface {
+	Build(int) (db.Build, bool, error)
+}
+
 func NewAccessFactory(
 	tokenVerifier TokenVerifier,
 	teamFetcher TeamFetcher,
 	systemClaimKey string,
 	systemClaimValues []string,
+	groupRoles map[string]atc.TeamAuth,
+	sessionTracker SessionTracker,
 	displayUserIdGenerator atc.DisplayUserIdGenerator,
+	buildFetcher BuildFetcher,
 ) AccessFactory {
 	return &accessFactory{
 		tokenVerifier:          tokenVerifier,
 		teamFetcher:            teamFetcher,
+		buildFetcher:           buildFetcher,
 		systemClaimKey:         systemClaimKey,
 		systemClaimValues:      systemClaimValues,
+		groupRoles:             groupRoles,
+		sessionTracker:         sessionTracker,
 		displayUserIdGenerator: displayUserIdGenerator,
 	}
 }
@@ -37,8 +48,11 @@ func NewAccessFactory(
 type accessFactory struct {
 	tokenVerifier          TokenVerifier
 	teamFetcher            TeamFetcher
+	buildFetcher           BuildFetcher
 	systemClaimKey         string
 	systemClaimValues      []string
+	groupRoles             map[string]atc.TeamAuth
+	sessionTracker         SessionTracker
 	displayUserIdGenerator atc.DisplayUserIdGenerator
 }
 
@@ -47,7 +61,46 @@ func (a *accessFactory) Create(req *http.Request, role string) (Access, error) {
 	if err != nil {
 		return nil, fmt.Errorf("fetch teams: %w", err)
 	}
-	return NewAccessor(a.verifyToken(req), role, a.systemClaimKey, a.systemClaimValues, teams, a.displayUserIdGenerator), nil
+
+	verification := a.verifyToken(req)
+
+	if buildID, ok := BuildTokenBuildID(verification.RawClaims); ok && verification.IsTokenValid {
+		// build tokens are revoked once their build finishes, but may still
+		// be cached by the verifier
+		running, err := a.buildRunning(buildID)
+		if err != nil {
+			return nil, err
+		}
+
+		verification.IsTokenValid = running
+	}
+
+	if verification.IsTokenValid && a.sessionTracker != nil {
+		rawToken, err := bearerToken(req)
+		if err != nil {
+			return nil, err
+		}
+
+		verification.LongestIdle, err = a.sessionTracker.Touch(rawToken)
+		if err != nil {
+			return nil, fmt.Errorf("track session: %w", err)
+		}
+	}
+
+	return NewAccessor(verification, role, a.systemClaimKey, a.systemClaimValues, teams, a.groupRoles, a.displayUserIdGenerator), nil
+}
+
+func (a *accessFactory) buildRunning(buildID int) (bool, error) {
+	if a.buildFetcher == nil {
+		return false, nil
+	}
+
+	build, found, err := a.buildFetcher.Build(buildID)
+	if err != nil {
+		return false, fmt.Errorf("fetch build: %w", err)
+	}
+
+	return found && build.IsRunning(), nil
 }
 
 func (a *accessFactory) verifyToken(req *http.Request) Verification {
@@ -20,9 +20,11 @@ var _ = Describe("AccessorFactory", func() {
 		systemClaimKey    string
 		systemClaimValues []string
 
-		fakeTokenVerifier *accessorfakes.FakeTokenVerifier
-		fakeTeamFetcher   *accessorfakes.FakeTeamFetcher
-		dummyRequest      *http.Request
+		fakeTokenVerifier  *accessorfakes.FakeTokenVerifier
+		fakeTeamFetcher    *accessorfakes.FakeTeamFetcher
+		fakeSessionTracker accessor.SessionTracker
+		fakeBuildFetcher   *accessorfakes.FakeBuildFetcher
+		dummyRequest       *http.Request
 
 		fakeDisplayUserIdGenerator *atcfakes.FakeDisplayUserIdGenerator
 
@@ -35,6 +37,8 @@ var _ = Describe("AccessorFactory", func() {
 
 		fakeTokenVerifier = new(accessorfakes.FakeTokenVerifier)
 		fakeTeamFetcher = new(accessorfakes.FakeTeamFetcher)
+		fakeSessionTracker = nil
+		fakeBuildFetcher = new(accessorfakes.FakeBuildFetcher)
 		dummyRequest, _ = http.NewRequest("GET", "/", nil)
 
 		fakeDisplayUserIdGenerator = new(atcfakes.FakeDisplayUserIdGenerator)
@@ -50,7 +54,7 @@ var _ = Describe("AccessorFactory", func() {
 		)
 
 		JustBeforeEach(func() {
-			factory := accessor.NewAccessFactory(fakeTokenVerifier, fakeTeamFetcher, systemClaimKey, systemClaimValues, fakeDisplayUserIdGenerator)
+			factory := accessor.NewAccessFactory(fakeTokenVerifier, fakeTeamFetcher, systemClaimKey, systemClaimValues, nil, fakeSessionTracker, fakeDisplayUserIdGenerator, fakeBuildFetcher)
 			access, err = factory.Create(dummyRequest, role)
 		})
 
@@ -82,6 +86,94 @@ var _ = Describe("AccessorFactory", func() {
 			It("returns an accessor with the correct teams", func() {
 				Expect(access.TeamNames()).To(ConsistOf("t1", "t3"))
 			})
+
+			Context("when sessions are tracked", func() {
+				var sessionTracker *accessorfakes.FakeSessionTracker
+
+				BeforeEach(func() {
+					sessionTracker = new(accessorfakes.FakeSessionTracker)
+					fakeSessionTracker = sessionTracker
+
+					dummyRequest.Header.Set("Authorization", "Bearer some-token")
+				})
+
+				It("records activity on the token", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sessionTracker.TouchCallCount()).To(Equal(1))
+					Expect(sessionTracker.TouchArgsForCall(0)).To(Equal("some-token"))
+				})
+
+				Context("when tracking the session fails", func() {
+					BeforeEach(func() {
+						sessionTracker.TouchReturns(0, errors.New("nope"))
+					})
+
+					It("returns an error", func() {
+						Expect(err).To(HaveOccurred())
+					})
+				})
+			})
+		})
+
+		Context("when the token was issued to a build", func() {
+			var fakeBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				fakeTokenVerifier.VerifyReturns(map[string]interface{}{
+					"federated_claims": map[string]interface{}{
+						"connector_id": "build",
+					},
+					"build": map[string]interface{}{
+						"build_id": float64(42),
+					},
+				}, nil)
+
+				fakeBuild = new(dbfakes.FakeBuild)
+				fakeBuild.IsRunningReturns(true)
+				fakeBuildFetcher.BuildReturns(fakeBuild, true, nil)
+			})
+
+			It("looks up the build", func() {
+				Expect(fakeBuildFetcher.BuildCallCount()).To(Equal(1))
+				Expect(fakeBuildFetcher.BuildArgsForCall(0)).To(Equal(42))
+			})
+
+			It("is authenticated while the build is running", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(access.IsAuthenticated()).To(BeTrue())
+			})
+
+			Context("when the build has finished", func() {
+				BeforeEach(func() {
+					fakeBuild.IsRunningReturns(false)
+				})
+
+				It("is not authenticated", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(access.IsAuthenticated()).To(BeFalse())
+				})
+			})
+
+			Context("when the build is gone", func() {
+				BeforeEach(func() {
+					fakeBuildFetcher.BuildReturns(nil, false, nil)
+				})
+
+				It("is not authenticated", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(access.IsAuthenticated()).To(BeFalse())
+				})
+			})
+
+			Context("when looking up the build fails", func() {
+				BeforeEach(func() {
+					fakeBuildFetcher.BuildReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns an error", func() {
+					Expect(err).To(HaveOccurred())
+				})
+			})
 		})
 
 		Context("when the team fetcher returns an error", func() {
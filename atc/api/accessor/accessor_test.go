@@ -1,6 +1,8 @@
 package accessor_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc/atcfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -17,6 +19,7 @@ var _ = Describe("Accessor", func() {
 		verification accessor.Verification
 		requiredRole string
 		teams        []db.Team
+		groupRoles   map[string]atc.TeamAuth
 		access       accessor.Access
 
 		fakeTeam1 *dbfakes.FakeTeam
@@ -37,12 +40,13 @@ var _ = Describe("Accessor", func() {
 		verification = accessor.Verification{}
 
 		teams = []db.Team{fakeTeam1, fakeTeam2, fakeTeam3}
+		groupRoles = nil
 
 		fakeDisplayUserIdGenerator = new(atcfakes.FakeDisplayUserIdGenerator)
 	})
 
 	JustBeforeEach(func() {
-		access = accessor.NewAccessor(verification, requiredRole, "sub", []string{"system"}, teams, fakeDisplayUserIdGenerator)
+		access = accessor.NewAccessor(verification, requiredRole, "sub", []string{"system"}, teams, groupRoles, fakeDisplayUserIdGenerator)
 	})
 
 	Describe("HasToken", func() {
@@ -195,7 +199,7 @@ var _ = Describe("Accessor", func() {
 				},
 			})
 
-			access = accessor.NewAccessor(verification, requiredRole, "sub", []string{"system"}, teams, fakeDisplayUserIdGenerator)
+			access = accessor.NewAccessor(verification, requiredRole, "sub", []string{"system"}, teams, groupRoles, fakeDisplayUserIdGenerator)
 			result := access.IsAuthorized("some-team")
 			Expect(expected).Should(Equal(result))
 		},
@@ -242,7 +246,7 @@ var _ = Describe("Accessor", func() {
 				},
 			})
 
-			access = accessor.NewAccessor(verification, requiredRole, "sub", []string{"system"}, teams, fakeDisplayUserIdGenerator)
+			access = accessor.NewAccessor(verification, requiredRole, "sub", []string{"system"}, teams, groupRoles, fakeDisplayUserIdGenerator)
 			result := access.IsAuthorized("some-team")
 			Expect(expected).Should(Equal(result))
 		},
@@ -303,7 +307,7 @@ var _ = Describe("Accessor", func() {
 				})
 			}
 
-			access = accessor.NewAccessor(verification, requiredRole, "sub", []string{"system"}, teams, fakeDisplayUserIdGenerator)
+			access = accessor.NewAccessor(verification, requiredRole, "sub", []string{"system"}, teams, groupRoles, fakeDisplayUserIdGenerator)
 			result := access.IsAuthorized("some-team")
 			Expect(expected).Should(Equal(result))
 		},
@@ -326,6 +330,314 @@ var _ = Describe("Accessor", func() {
 		Entry("user is viewer and group is member attempting viewer action", "viewer", "viewer", "viewer", true),
 	)
 
+	Describe("IsAuthorized with team session lifetimes", func() {
+		BeforeEach(func() {
+			requiredRole = "viewer"
+
+			verification.HasToken = true
+			verification.IsTokenValid = true
+			verification.RawClaims = map[string]interface{}{
+				"iat": float64(time.Now().Add(-2 * time.Hour).Unix()),
+				"federated_claims": map[string]interface{}{
+					"connector_id": "some-connector",
+					"user_id":      "some-user-id",
+				},
+			}
+
+			fakeTeam1.NameReturns("some-team")
+			fakeTeam1.AuthReturns(atc.TeamAuth{
+				"member": map[string][]string{
+					"users": {"some-connector:some-user-id"},
+				},
+			})
+		})
+
+		AfterEach(func() {
+			requiredRole = ""
+		})
+
+		It("returns true when the team sets no limits", func() {
+			Expect(access.IsAuthorized("some-team")).To(BeTrue())
+		})
+
+		Context("when the token is older than the team's access token lifetime", func() {
+			BeforeEach(func() {
+				fakeTeam1.AccessTokenLifetimeReturns(time.Hour)
+			})
+
+			It("returns false", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeFalse())
+				Expect(access.TeamNames()).ToNot(ContainElement("some-team"))
+			})
+		})
+
+		Context("when the token is within the team's access token lifetime", func() {
+			BeforeEach(func() {
+				fakeTeam1.AccessTokenLifetimeReturns(3 * time.Hour)
+			})
+
+			It("returns true", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeTrue())
+			})
+		})
+
+		Context("when the token has been idle for longer than the team allows", func() {
+			BeforeEach(func() {
+				verification.LongestIdle = 20 * time.Minute
+				fakeTeam1.SessionIdleTimeoutReturns(15 * time.Minute)
+			})
+
+			It("returns false", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeFalse())
+			})
+		})
+
+		Context("when the token has not been idle for longer than the team allows", func() {
+			BeforeEach(func() {
+				verification.LongestIdle = 10 * time.Minute
+				fakeTeam1.SessionIdleTimeoutReturns(15 * time.Minute)
+			})
+
+			It("returns true", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("IsAuthorized with group roles", func() {
+		BeforeEach(func() {
+			requiredRole = "member"
+
+			verification.HasToken = true
+			verification.IsTokenValid = true
+			verification.RawClaims = map[string]interface{}{
+				"groups": []interface{}{"some-group"},
+				"federated_claims": map[string]interface{}{
+					"connector_id": "oidc",
+					"user_id":      "some-user-id",
+				},
+			}
+
+			fakeTeam1.NameReturns("some-team")
+			fakeTeam1.AuthReturns(atc.TeamAuth{
+				"viewer": map[string][]string{
+					"groups": {"oidc:some-group"},
+				},
+			})
+		})
+
+		AfterEach(func() {
+			requiredRole = ""
+		})
+
+		Context("when the user's group is granted a sufficient role on the team", func() {
+			BeforeEach(func() {
+				groupRoles = map[string]atc.TeamAuth{
+					"some-team": {
+						"member": map[string][]string{
+							"groups": {"oidc:some-group"},
+						},
+					},
+				}
+			})
+
+			It("returns true", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeTrue())
+			})
+
+			It("includes the granted role alongside the team's own roles", func() {
+				Expect(access.TeamRoles()["some-team"]).To(ConsistOf("viewer", "member"))
+			})
+		})
+
+		Context("when the user's group is granted a role on another team", func() {
+			BeforeEach(func() {
+				groupRoles = map[string]atc.TeamAuth{
+					"some-other-team": {
+						"member": map[string][]string{
+							"groups": {"oidc:some-group"},
+						},
+					},
+				}
+			})
+
+			It("returns false", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeFalse())
+			})
+		})
+
+		Context("when the user's group is granted owner on the admin team", func() {
+			BeforeEach(func() {
+				fakeTeam1.AdminReturns(true)
+
+				groupRoles = map[string]atc.TeamAuth{
+					"some-team": {
+						"owner": map[string][]string{
+							"groups": {"oidc:some-group"},
+						},
+					},
+				}
+			})
+
+			It("makes the user an admin", func() {
+				Expect(access.IsAdmin()).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("IsAuthorizedForPipeline", func() {
+		var result bool
+
+		BeforeEach(func() {
+			requiredRole = "pipeline-operator"
+
+			verification.HasToken = true
+			verification.IsTokenValid = true
+			verification.RawClaims = map[string]interface{}{
+				"groups": []interface{}{"some-group"},
+				"federated_claims": map[string]interface{}{
+					"connector_id": "some-connector",
+					"user_id":      "some-user-id",
+				},
+			}
+
+			fakeTeam1.NameReturns("some-team")
+		})
+
+		JustBeforeEach(func() {
+			result = access.IsAuthorizedForPipeline("some-team", atc.PipelineRef{Name: "some-pipeline"})
+		})
+
+		AfterEach(func() {
+			requiredRole = ""
+		})
+
+		Context("when the user has the required role on the pipeline", func() {
+			BeforeEach(func() {
+				fakeTeam1.PipelineAuthReturns(atc.PipelineAuth{
+					{
+						Pipeline: atc.PipelineRef{Name: "some-pipeline"},
+						Auth: atc.TeamAuth{
+							"pipeline-operator": map[string][]string{
+								"users": {"some-connector:some-user-id"},
+							},
+						},
+					},
+				})
+			})
+
+			It("returns true", func() {
+				Expect(result).To(BeTrue())
+			})
+
+			It("does not authorize the user for the whole team", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeFalse())
+			})
+		})
+
+		Context("when the user's group has the required role on the pipeline", func() {
+			BeforeEach(func() {
+				fakeTeam1.PipelineAuthReturns(atc.PipelineAuth{
+					{
+						Pipeline: atc.PipelineRef{Name: "some-pipeline"},
+						Auth: atc.TeamAuth{
+							"member": map[string][]string{
+								"groups": {"some-connector:some-group"},
+							},
+						},
+					},
+				})
+			})
+
+			It("returns true", func() {
+				Expect(result).To(BeTrue())
+			})
+		})
+
+		Context("when the user's role on the pipeline is insufficient", func() {
+			BeforeEach(func() {
+				fakeTeam1.PipelineAuthReturns(atc.PipelineAuth{
+					{
+						Pipeline: atc.PipelineRef{Name: "some-pipeline"},
+						Auth: atc.TeamAuth{
+							"viewer": map[string][]string{
+								"users": {"some-connector:some-user-id"},
+							},
+						},
+					},
+				})
+			})
+
+			It("returns false", func() {
+				Expect(result).To(BeFalse())
+			})
+		})
+
+		Context("when the user only has a role on another pipeline", func() {
+			BeforeEach(func() {
+				fakeTeam1.PipelineAuthReturns(atc.PipelineAuth{
+					{
+						Pipeline: atc.PipelineRef{Name: "some-other-pipeline"},
+						Auth: atc.TeamAuth{
+							"pipeline-operator": map[string][]string{
+								"users": {"some-connector:some-user-id"},
+							},
+						},
+					},
+				})
+			})
+
+			It("returns false", func() {
+				Expect(result).To(BeFalse())
+			})
+		})
+
+		Context("when the user only has a role on another instance of the pipeline", func() {
+			BeforeEach(func() {
+				fakeTeam1.PipelineAuthReturns(atc.PipelineAuth{
+					{
+						Pipeline: atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "feature"}},
+						Auth: atc.TeamAuth{
+							"pipeline-operator": map[string][]string{
+								"users": {"some-connector:some-user-id"},
+							},
+						},
+					},
+				})
+			})
+
+			It("returns false", func() {
+				Expect(result).To(BeFalse())
+			})
+
+			It("returns true for that instance", func() {
+				Expect(access.IsAuthorizedForPipeline("some-team", atc.PipelineRef{
+					Name:         "some-pipeline",
+					InstanceVars: atc.InstanceVars{"branch": "feature"},
+				})).To(BeTrue())
+			})
+		})
+
+		Context("when the user has a role on the pipeline of another team", func() {
+			BeforeEach(func() {
+				fakeTeam2.PipelineAuthReturns(atc.PipelineAuth{
+					{
+						Pipeline: atc.PipelineRef{Name: "some-pipeline"},
+						Auth: atc.TeamAuth{
+							"pipeline-operator": map[string][]string{
+								"users": {"some-connector:some-user-id"},
+							},
+						},
+					},
+				})
+			})
+
+			It("returns false", func() {
+				Expect(result).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("TeamNames", func() {
 		var result []string
 
@@ -878,5 +1190,171 @@ var _ = Describe("Accessor", func() {
 				})
 			})
 		})
+
+		Context("when the token belongs to a service account", func() {
+			BeforeEach(func() {
+				fakeTeam1.IDReturns(1)
+				fakeTeam2.IDReturns(2)
+				fakeTeam3.IDReturns(3)
+
+				verification.HasToken = true
+				verification.IsTokenValid = true
+				verification.RawClaims = map[string]interface{}{
+					"sub":  "serviceaccount:some-team-2:some-bot",
+					"name": "serviceaccount:some-team-2:some-bot",
+					"federated_claims": map[string]interface{}{
+						"connector_id": "serviceaccount",
+						"user_id":      "some-team-2:some-bot",
+					},
+					"service_account": map[string]interface{}{
+						"team_id": float64(2),
+						"team":    "some-team-2",
+						"role":    "pipeline-operator",
+					},
+				}
+			})
+
+			It("grants the bound role on the owning team only", func() {
+				Expect(result).To(Equal(map[string][]string{
+					"some-team-2": {"pipeline-operator"},
+				}))
+			})
+
+			Context("when the owning team's auth config also matches", func() {
+				BeforeEach(func() {
+					fakeTeam2.AuthReturns(atc.TeamAuth{
+						"pipeline-operator": map[string][]string{
+							"users": {"serviceaccount:some-team-2:some-bot"},
+						},
+					})
+				})
+
+				It("only adds the role once", func() {
+					Expect(result["some-team-2"]).To(ConsistOf("pipeline-operator"))
+				})
+			})
+
+			Context("when the token was issued by another connector", func() {
+				BeforeEach(func() {
+					verification.RawClaims["federated_claims"] = map[string]interface{}{
+						"connector_id": "some-connector",
+						"user_id":      "some-team-2:some-bot",
+					}
+				})
+
+				It("ignores the service account claim", func() {
+					Expect(result).To(Equal(map[string][]string{}))
+				})
+			})
+		})
+
+		Context("when the token was issued to a build", func() {
+			BeforeEach(func() {
+				fakeTeam1.IDReturns(1)
+				fakeTeam2.IDReturns(2)
+				fakeTeam3.IDReturns(3)
+
+				verification.HasToken = true
+				verification.IsTokenValid = true
+				verification.RawClaims = map[string]interface{}{
+					"sub":  "build:some-team-3:42",
+					"name": "build:some-team-3:42",
+					"federated_claims": map[string]interface{}{
+						"connector_id": "build",
+						"user_id":      "some-team-3:42",
+					},
+					"build": map[string]interface{}{
+						"team_id":  float64(3),
+						"team":     "some-team-3",
+						"pipeline": "some-pipeline",
+						"build_id": float64(42),
+						"role":     "pipeline-operator",
+					},
+				}
+			})
+
+			It("grants no role on the build's team", func() {
+				Expect(result).To(Equal(map[string][]string{}))
+			})
+
+			It("grants the bound role on the build's pipeline only", func() {
+				requiredRole = "pipeline-operator"
+				access = accessor.NewAccessor(verification, requiredRole, "sub", []string{"system"}, teams, groupRoles, fakeDisplayUserIdGenerator)
+
+				Expect(access.IsAuthorized("some-team-3")).To(BeFalse())
+				Expect(access.IsAuthorizedForPipeline("some-team-3", atc.PipelineRef{Name: "some-pipeline"})).To(BeTrue())
+				Expect(access.IsAuthorizedForPipeline("some-team-3", atc.PipelineRef{Name: "some-other-pipeline"})).To(BeFalse())
+				Expect(access.IsAuthorizedForPipeline("some-team-2", atc.PipelineRef{Name: "some-pipeline"})).To(BeFalse())
+			})
+
+			Context("when the build belongs to an instanced pipeline", func() {
+				BeforeEach(func() {
+					verification.RawClaims["build"].(map[string]interface{})["pipeline_instance_vars"] = map[string]interface{}{
+						"branch": "main",
+					}
+				})
+
+				It("grants the bound role on that instance only", func() {
+					access = accessor.NewAccessor(verification, "pipeline-operator", "sub", []string{"system"}, teams, groupRoles, fakeDisplayUserIdGenerator)
+
+					Expect(access.IsAuthorizedForPipeline("some-team-3", atc.PipelineRef{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "main"},
+					})).To(BeTrue())
+					Expect(access.IsAuthorizedForPipeline("some-team-3", atc.PipelineRef{Name: "some-pipeline"})).To(BeFalse())
+				})
+			})
+
+			Context("when the token is not bound to a pipeline", func() {
+				BeforeEach(func() {
+					delete(verification.RawClaims["build"].(map[string]interface{}), "pipeline")
+				})
+
+				It("grants nothing", func() {
+					access = accessor.NewAccessor(verification, "viewer", "sub", []string{"system"}, teams, groupRoles, fakeDisplayUserIdGenerator)
+
+					Expect(access.IsAuthorized("some-team-3")).To(BeFalse())
+					Expect(access.IsAuthorizedForPipeline("some-team-3", atc.PipelineRef{})).To(BeFalse())
+				})
+			})
+
+			Context("when the token carries a service account claim instead", func() {
+				BeforeEach(func() {
+					verification.RawClaims["service_account"] = verification.RawClaims["build"]
+					delete(verification.RawClaims, "build")
+				})
+
+				It("ignores it", func() {
+					Expect(result).To(Equal(map[string][]string{}))
+				})
+			})
+		})
+	})
+
+	Describe("BuildTokenBuildID", func() {
+		It("returns the build a build token is bound to", func() {
+			buildID, ok := accessor.BuildTokenBuildID(map[string]interface{}{
+				"federated_claims": map[string]interface{}{
+					"connector_id": "build",
+				},
+				"build": map[string]interface{}{
+					"build_id": float64(42),
+				},
+			})
+			Expect(ok).To(BeTrue())
+			Expect(buildID).To(Equal(42))
+		})
+
+		It("ignores tokens issued by other connectors", func() {
+			_, ok := accessor.BuildTokenBuildID(map[string]interface{}{
+				"federated_claims": map[string]interface{}{
+					"connector_id": "github",
+				},
+				"build": map[string]interface{}{
+					"build_id": float64(42),
+				},
+			})
+			Expect(ok).To(BeFalse())
+		})
 	})
 })
@@ -60,6 +60,18 @@ type FakeAccess struct {
 	isAuthorizedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IsAuthorizedForPipelineStub        func(string, atc.PipelineRef) bool
+	isAuthorizedForPipelineMutex       sync.RWMutex
+	isAuthorizedForPipelineArgsForCall []struct {
+		arg1 string
+		arg2 atc.PipelineRef
+	}
+	isAuthorizedForPipelineReturns struct {
+		result1 bool
+	}
+	isAuthorizedForPipelineReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsSystemStub        func() bool
 	isSystemMutex       sync.RWMutex
 	isSystemArgsForCall []struct {
@@ -377,6 +389,68 @@ func (fake *FakeAccess) IsAuthorizedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeAccess) IsAuthorizedForPipeline(arg1 string, arg2 atc.PipelineRef) bool {
+	fake.isAuthorizedForPipelineMutex.Lock()
+	ret, specificReturn := fake.isAuthorizedForPipelineReturnsOnCall[len(fake.isAuthorizedForPipelineArgsForCall)]
+	fake.isAuthorizedForPipelineArgsForCall = append(fake.isAuthorizedForPipelineArgsForCall, struct {
+		arg1 string
+		arg2 atc.PipelineRef
+	}{arg1, arg2})
+	stub := fake.IsAuthorizedForPipelineStub
+	fakeReturns := fake.isAuthorizedForPipelineReturns
+	fake.recordInvocation("IsAuthorizedForPipeline", []interface{}{arg1, arg2})
+	fake.isAuthorizedForPipelineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAccess) IsAuthorizedForPipelineCallCount() int {
+	fake.isAuthorizedForPipelineMutex.RLock()
+	defer fake.isAuthorizedForPipelineMutex.RUnlock()
+	return len(fake.isAuthorizedForPipelineArgsForCall)
+}
+
+func (fake *FakeAccess) IsAuthorizedForPipelineCalls(stub func(string, atc.PipelineRef) bool) {
+	fake.isAuthorizedForPipelineMutex.Lock()
+	defer fake.isAuthorizedForPipelineMutex.Unlock()
+	fake.IsAuthorizedForPipelineStub = stub
+}
+
+func (fake *FakeAccess) IsAuthorizedForPipelineArgsForCall(i int) (string, atc.PipelineRef) {
+	fake.isAuthorizedForPipelineMutex.RLock()
+	defer fake.isAuthorizedForPipelineMutex.RUnlock()
+	argsForCall := fake.isAuthorizedForPipelineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAccess) IsAuthorizedForPipelineReturns(result1 bool) {
+	fake.isAuthorizedForPipelineMutex.Lock()
+	defer fake.isAuthorizedForPipelineMutex.Unlock()
+	fake.IsAuthorizedForPipelineStub = nil
+	fake.isAuthorizedForPipelineReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeAccess) IsAuthorizedForPipelineReturnsOnCall(i int, result1 bool) {
+	fake.isAuthorizedForPipelineMutex.Lock()
+	defer fake.isAuthorizedForPipelineMutex.Unlock()
+	fake.IsAuthorizedForPipelineStub = nil
+	if fake.isAuthorizedForPipelineReturnsOnCall == nil {
+		fake.isAuthorizedForPipelineReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isAuthorizedForPipelineReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeAccess) IsSystem() bool {
 	fake.isSystemMutex.Lock()
 	ret, specificReturn := fake.isSystemReturnsOnCall[len(fake.isSystemArgsForCall)]
@@ -602,6 +676,8 @@ func (fake *FakeAccess) Invocations() map[string][][]interface{} {
 	defer fake.isAuthenticatedMutex.RUnlock()
 	fake.isAuthorizedMutex.RLock()
 	defer fake.isAuthorizedMutex.RUnlock()
+	fake.isAuthorizedForPipelineMutex.RLock()
+	defer fake.isAuthorizedForPipelineMutex.RUnlock()
 	fake.isSystemMutex.RLock()
 	defer fake.isSystemMutex.RUnlock()
 	fake.teamNamesMutex.RLock()
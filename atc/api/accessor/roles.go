@@ -13,15 +13,19 @@ const (
 
 var DefaultRoles = map[string]string{
 	atc.SaveConfig:                    MemberRole,
+	atc.ValidateConfig:                MemberRole,
 	atc.GetConfig:                     ViewerRole,
 	atc.GetCC:                         ViewerRole,
 	atc.GetBuild:                      ViewerRole,
 	atc.GetBuildPlan:                  ViewerRole,
+	atc.GetBuildPlanStatus:            ViewerRole,
 	atc.CreateBuild:                   MemberRole,
 	atc.ListBuilds:                    ViewerRole,
 	atc.BuildEvents:                   ViewerRole,
 	atc.BuildResources:                ViewerRole,
 	atc.AbortBuild:                    OperatorRole,
+	atc.ApproveBuild:                  MemberRole,
+	atc.RejectBuild:                   MemberRole,
 	atc.GetBuildPreparation:           ViewerRole,
 	atc.GetJob:                        ViewerRole,
 	atc.CreateJobBuild:                OperatorRole,
@@ -33,8 +37,11 @@ var DefaultRoles = map[string]string{
 	atc.GetJobBuild:                   ViewerRole,
 	atc.PauseJob:                      OperatorRole,
 	atc.UnpauseJob:                    OperatorRole,
+	atc.PauseJobs:                     OperatorRole,
+	atc.UnpauseJobs:                   OperatorRole,
 	atc.ScheduleJob:                   OperatorRole,
 	atc.GetVersionsDB:                 ViewerRole,
+	atc.ListPipelineVarSources:        ViewerRole,
 	atc.JobBadge:                      ViewerRole,
 	atc.MainJobBadge:                  ViewerRole,
 	atc.ClearTaskCache:                OperatorRole,
@@ -56,6 +63,7 @@ var DefaultRoles = map[string]string{
 	atc.ListBuildsWithVersionAsOutput: ViewerRole,
 	atc.GetResourceCausality:          ViewerRole,
 	atc.ListAllPipelines:              ViewerRole,
+	atc.Search:                        ViewerRole,
 	atc.ListPipelines:                 ViewerRole,
 	atc.GetPipeline:                   ViewerRole,
 	atc.DeletePipeline:                MemberRole,
@@ -70,11 +78,13 @@ var DefaultRoles = map[string]string{
 	atc.ListPipelineBuilds:            ViewerRole,
 	atc.CreatePipelineBuild:           MemberRole,
 	atc.PipelineBadge:                 ViewerRole,
+	atc.PipelineGroupBadge:            ViewerRole,
 	atc.RegisterWorker:                MemberRole,
 	atc.LandWorker:                    MemberRole,
 	atc.RetireWorker:                  MemberRole,
 	atc.PruneWorker:                   MemberRole,
 	atc.HeartbeatWorker:               MemberRole,
+	atc.ReportWorkerUsage:             MemberRole,
 	atc.ListWorkers:                   ViewerRole,
 	atc.DeleteWorker:                  MemberRole,
 	atc.SetLogLevel:                   MemberRole,
@@ -96,8 +106,20 @@ var DefaultRoles = map[string]string{
 	atc.RenameTeam:                    OwnerRole,
 	atc.DestroyTeam:                   OwnerRole,
 	atc.ListTeamBuilds:                ViewerRole,
+	atc.ListServiceAccounts:           OwnerRole,
+	atc.CreateServiceAccount:          OwnerRole,
+	atc.DeleteServiceAccount:          OwnerRole,
+	atc.ListTeamPrunableState:         OwnerRole,
+	atc.PruneTeamState:                OwnerRole,
+	atc.ListTeamWebhooks:              MemberRole,
+	atc.SetTeamWebhook:                MemberRole,
+	atc.DeleteTeamWebhook:             MemberRole,
+	atc.ListPipelineTemplates:         ViewerRole,
+	atc.SetPipelineTemplate:           MemberRole,
+	atc.DeletePipelineTemplate:        MemberRole,
 	atc.CreateArtifact:                MemberRole,
 	atc.GetArtifact:                   MemberRole,
 	atc.ListBuildArtifacts:            ViewerRole,
+	atc.ListBuildPublishedArtifacts:   ViewerRole,
 	atc.GetWall:                       ViewerRole,
 }
@@ -0,0 +1,78 @@
+package accessor
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+)
+
+//counterfeiter:generate . SessionTracker
+type SessionTracker interface {
+	// Touch records activity on the token, returning the longest the token
+	// has gone unused.
+	Touch(rawToken string) (time.Duration, error)
+}
+
+//counterfeiter:generate . AccessTokenToucher
+type AccessTokenToucher interface {
+	TouchAccessToken(rawToken string) (time.Duration, error)
+}
+
+type sessionActivity struct {
+	touchedAt   time.Time
+	longestIdle time.Duration
+}
+
+type sessionTracker struct {
+	accessTokenToucher AccessTokenToucher
+	interval           time.Duration
+
+	cache *lru.Cache
+	mu    sync.Mutex // lru.Cache is not safe for concurrent access
+}
+
+// NewSessionTracker records token activity at most once per interval for
+// each token, so that busy clients don't turn every request into a write.
+// Idle times are therefore only accurate to within the interval.
+func NewSessionTracker(
+	accessTokenToucher AccessTokenToucher,
+	interval time.Duration,
+	maxSessions int,
+) SessionTracker {
+	return &sessionTracker{
+		accessTokenToucher: accessTokenToucher,
+		interval:           interval,
+		cache:              lru.New(maxSessions),
+	}
+}
+
+func (t *sessionTracker) Touch(rawToken string) (time.Duration, error) {
+	now := time.Now()
+
+	// tokens are cached by their hash so that they aren't kept in memory
+	key := sha256.Sum256([]byte(rawToken))
+
+	t.mu.Lock()
+	cached, found := t.cache.Get(key)
+	t.mu.Unlock()
+
+	if found {
+		activity := cached.(sessionActivity)
+		if now.Sub(activity.touchedAt) < t.interval {
+			return activity.longestIdle, nil
+		}
+	}
+
+	longestIdle, err := t.accessTokenToucher.TouchAccessToken(rawToken)
+	if err != nil {
+		return 0, err
+	}
+
+	t.mu.Lock()
+	t.cache.Add(key, sessionActivity{touchedAt: now, longestIdle: longestIdle})
+	t.mu.Unlock()
+
+	return longestIdle, nil
+}
@@ -0,0 +1,73 @@
+package accessor_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SessionTracker", func() {
+	var (
+		fakeAccessTokenToucher *accessorfakes.FakeAccessTokenToucher
+		interval               time.Duration
+		tracker                accessor.SessionTracker
+	)
+
+	BeforeEach(func() {
+		fakeAccessTokenToucher = new(accessorfakes.FakeAccessTokenToucher)
+		fakeAccessTokenToucher.TouchAccessTokenReturns(5*time.Minute, nil)
+		interval = time.Minute
+	})
+
+	JustBeforeEach(func() {
+		tracker = accessor.NewSessionTracker(fakeAccessTokenToucher, interval, 10)
+	})
+
+	It("records activity and returns the longest idle time", func() {
+		longestIdle, err := tracker.Touch("some-token")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(longestIdle).To(Equal(5 * time.Minute))
+
+		Expect(fakeAccessTokenToucher.TouchAccessTokenCallCount()).To(Equal(1))
+		Expect(fakeAccessTokenToucher.TouchAccessTokenArgsForCall(0)).To(Equal("some-token"))
+	})
+
+	It("records activity at most once per interval for each token", func() {
+		tracker.Touch("some-token")
+		longestIdle, err := tracker.Touch("some-token")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(longestIdle).To(Equal(5 * time.Minute))
+
+		tracker.Touch("some-other-token")
+
+		Expect(fakeAccessTokenToucher.TouchAccessTokenCallCount()).To(Equal(2))
+	})
+
+	Context("when the interval has passed", func() {
+		BeforeEach(func() {
+			interval = 0
+		})
+
+		It("records activity again", func() {
+			tracker.Touch("some-token")
+			tracker.Touch("some-token")
+
+			Expect(fakeAccessTokenToucher.TouchAccessTokenCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when recording activity fails", func() {
+		BeforeEach(func() {
+			fakeAccessTokenToucher.TouchAccessTokenReturns(0, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			_, err := tracker.Touch("some-token")
+			Expect(err).To(MatchError("nope"))
+		})
+	})
+})
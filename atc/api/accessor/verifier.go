@@ -38,17 +38,26 @@ type verifier struct {
 
 func (v *verifier) Verify(r *http.Request) (map[string]interface{}, error) {
 
+	rawToken, err := bearerToken(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.verify(rawToken)
+}
+
+func bearerToken(r *http.Request) (string, error) {
 	header := r.Header.Get("Authorization")
 	if header == "" {
-		return nil, ErrVerificationNoToken
+		return "", ErrVerificationNoToken
 	}
 
 	parts := strings.Split(header, " ")
 	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
-		return nil, ErrVerificationInvalidToken
+		return "", ErrVerificationInvalidToken
 	}
 
-	return v.verify(parts[1])
+	return parts[1], nil
 }
 
 func (v *verifier) verify(rawToken string) (map[string]interface{}, error) {
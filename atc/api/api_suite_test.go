@@ -30,13 +30,14 @@ import (
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/concourse/concourse/logging"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
 var (
-	sink *lager.ReconfigurableSink
+	sink *logging.Sink
 
 	externalURL = "https://example.com"
 	clusterName = "Test Cluster"
@@ -59,6 +60,8 @@ var (
 	build                   *dbfakes.FakeBuild
 	dbBuildFactory          *dbfakes.FakeBuildFactory
 	dbUserFactory           *dbfakes.FakeUserFactory
+	dbApprovalFactory       *dbfakes.FakeApprovalFactory
+	dbPublishedArtifacts    *dbfakes.FakePublishedArtifactFactory
 	dbCheckFactory          *dbfakes.FakeCheckFactory
 	dbTeam                  *dbfakes.FakeTeam
 	dbWall                  *dbfakes.FakeWall
@@ -108,6 +111,8 @@ var _ = BeforeEach(func() {
 	dbResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
 	dbUserFactory = new(dbfakes.FakeUserFactory)
+	dbApprovalFactory = new(dbfakes.FakeApprovalFactory)
+	dbPublishedArtifacts = new(dbfakes.FakePublishedArtifactFactory)
 	dbCheckFactory = new(dbfakes.FakeCheckFactory)
 	dbWall = new(dbfakes.FakeWall)
 
@@ -152,7 +157,7 @@ var _ = BeforeEach(func() {
 
 	logger = lagertest.NewTestLogger("api")
 
-	sink = lager.NewReconfigurableSink(lager.NewPrettySink(GinkgoWriter, lager.DEBUG), lager.DEBUG)
+	sink = logging.NewSink(GinkgoWriter, lager.DEBUG, logging.NewComponentLevels())
 
 	isTLSEnabled = false
 
@@ -200,6 +205,8 @@ var _ = BeforeEach(func() {
 		dbCheckFactory,
 		dbResourceConfigFactory,
 		dbUserFactory,
+		dbApprovalFactory,
+		dbPublishedArtifacts,
 
 		constructedEventHandler.Construct,
 
@@ -217,8 +224,10 @@ var _ = BeforeEach(func() {
 		credsManagers,
 		interceptTimeoutFactory,
 		time.Second,
+		time.Hour,
 		dbWall,
 		fakeClock,
+		24*time.Hour,
 	)
 
 	atc.EnablePipelineInstances = true
@@ -3,6 +3,7 @@ package auth
 import (
 	"net/http"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 )
 
@@ -30,8 +31,23 @@ func (h checkAuthorizationHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 	}
 
 	teamName := r.URL.Query().Get(":team_name")
+	pipelineName := r.URL.Query().Get(":pipeline_name")
+
+	authorized := acc.IsAuthorized(teamName)
+	if !authorized && pipelineName != "" {
+		instanceVars, err := atc.InstanceVarsFromQueryParams(r.URL.Query())
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		authorized = acc.IsAuthorizedForPipeline(teamName, atc.PipelineRef{
+			Name:         pipelineName,
+			InstanceVars: instanceVars,
+		})
+	}
 
-	if !acc.IsAuthorized(teamName) {
+	if !authorized {
 		h.rejector.Forbidden(w, r)
 		return
 	}
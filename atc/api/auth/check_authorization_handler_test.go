@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/api/auth"
@@ -122,6 +123,63 @@ var _ = Describe("CheckAuthorizationHandler", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(string(responseBody)).To(Equal("nope\n"))
 				})
+
+				It("does not consider pipeline roles for requests outside of a pipeline", func() {
+					Expect(fakeaccess.IsAuthorizedForPipelineCallCount()).To(Equal(0))
+				})
+
+				Context("when the request is for a pipeline", func() {
+					BeforeEach(func() {
+						urlValues := url.Values{
+							":team_name":     []string{"some-team"},
+							":pipeline_name": []string{"some-pipeline"},
+						}
+						request.URL.RawQuery = urlValues.Encode()
+					})
+
+					Context("when the bearer token has a role on the pipeline", func() {
+						BeforeEach(func() {
+							fakeaccess.IsAuthorizedForPipelineReturns(true)
+						})
+
+						It("proxies to the handler", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							teamName, pipelineRef := fakeaccess.IsAuthorizedForPipelineArgsForCall(0)
+							Expect(teamName).To(Equal("some-team"))
+							Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+						})
+					})
+
+					Context("when the request is for an instanced pipeline", func() {
+						BeforeEach(func() {
+							urlValues := url.Values{
+								":team_name":     []string{"some-team"},
+								":pipeline_name": []string{"some-pipeline"},
+								"vars.branch":    []string{`"main"`},
+							}
+							request.URL.RawQuery = urlValues.Encode()
+						})
+
+						It("checks the roles on that instance of the pipeline", func() {
+							_, pipelineRef := fakeaccess.IsAuthorizedForPipelineArgsForCall(0)
+							Expect(pipelineRef).To(Equal(atc.PipelineRef{
+								Name:         "some-pipeline",
+								InstanceVars: atc.InstanceVars{"branch": "main"},
+							}))
+						})
+					})
+
+					Context("when the bearer token has no role on the pipeline", func() {
+						BeforeEach(func() {
+							fakeaccess.IsAuthorizedForPipelineReturns(false)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+					})
+				})
 			})
 		})
 
@@ -108,6 +108,10 @@ func (h checkBuildReadAccessHandler) allow(build db.Build, acc accessor.Access)
 		return true, nil
 	}
 
+	if acc.IsAuthenticated() && build.PipelineName() != "" && acc.IsAuthorizedForPipeline(build.TeamName(), build.PipelineRef()) {
+		return true, nil
+	}
+
 	if build.PipelineID() == 0 {
 		return false, nil
 	}
@@ -67,7 +67,12 @@ func (h checkBuildWriteAccessHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	if !acc.IsAuthorized(build.TeamName()) {
+	authorized := acc.IsAuthorized(build.TeamName())
+	if !authorized && build.PipelineName() != "" {
+		authorized = acc.IsAuthorizedForPipeline(build.TeamName(), build.PipelineRef())
+	}
+
+	if !authorized {
 		h.rejector.Forbidden(w, r)
 		return
 	}
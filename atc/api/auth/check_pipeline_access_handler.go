@@ -77,7 +77,7 @@ func (h checkPipelineAccessHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 
 	acc := accessor.GetAccessor(r)
 
-	if acc.IsAuthorized(teamName) || pipeline.Public() {
+	if acc.IsAuthorized(teamName) || acc.IsAuthorizedForPipeline(teamName, atc.PipelineRef{Name: pipeline.Name(), InstanceVars: pipeline.InstanceVars()}) || pipeline.Public() {
 		ctx := context.WithValue(r.Context(), PipelineContextKey, pipeline)
 		h.delegateHandler.ServeHTTP(w, r.WithContext(ctx))
 		return
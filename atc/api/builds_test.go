@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/concourse/concourse/atc/testhelpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -207,7 +208,7 @@ var _ = Describe("Builds API", func() {
 				It("does not set defaults for since and until", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					teamName, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					teamName, page, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						Limit: 100,
 					}))
@@ -223,7 +224,7 @@ var _ = Describe("Builds API", func() {
 				It("passes them through", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					_, page, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						From:  db.NewIntPtr(2),
 						To:    db.NewIntPtr(3),
@@ -237,12 +238,110 @@ var _ = Describe("Builds API", func() {
 					})
 
 					It("calls AllBuilds", func() {
-						_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+						_, page, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
 						Expect(page.UseDate).To(Equal(true))
 					})
 				})
 			})
 
+			Context("when filters are passed", func() {
+				BeforeEach(func() {
+					queryParams = "?status=failed&status=errored&pipeline=some-*&job=unit-?&min_duration=1h30m&worker=some-worker"
+				})
+
+				It("passes them through", func() {
+					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
+
+					_, _, filter := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					Expect(filter).To(Equal(atc.BuildFilter{
+						Statuses:    []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored},
+						Pipeline:    "some-*",
+						Job:         "unit-?",
+						MinDuration: 90 * time.Minute,
+						Worker:      "some-worker",
+					}))
+				})
+
+				Context("when a sort is passed", func() {
+					BeforeEach(func() {
+						queryParams = "?sort_by=duration&reverse=true"
+					})
+
+					It("passes it through", func() {
+						Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
+
+						_, _, filter := dbBuildFactory.VisibleBuildsArgsForCall(0)
+						Expect(filter).To(Equal(atc.BuildFilter{
+							SortBy:  atc.BuildSortByDuration,
+							Reverse: true,
+						}))
+					})
+				})
+
+				Context("when the sort field is invalid", func() {
+					BeforeEach(func() {
+						queryParams = "?sort_by=bogus"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("invalid sort field 'bogus'\n"))
+
+						Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when next/previous pages are available", func() {
+					BeforeEach(func() {
+						dbBuildFactory.VisibleBuildsReturns(returnedBuilds, db.Pagination{
+							Newer: &db.Page{From: db.NewIntPtr(4), Limit: 2},
+							Older: &db.Page{To: db.NewIntPtr(3), Limit: 2},
+						}, nil)
+					})
+
+					It("keeps the filters in the Link headers", func() {
+						filterQuery := "job=unit-%3F&min_duration=1h30m&pipeline=some-%2A&status=failed&status=errored&worker=some-worker"
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							fmt.Sprintf(`<%s/api/v1/builds?from=4&limit=2&%s>; rel="previous"`, externalURL, filterQuery),
+							fmt.Sprintf(`<%s/api/v1/builds?to=3&limit=2&%s>; rel="next"`, externalURL, filterQuery),
+						}))
+					})
+				})
+
+				Context("when a status is invalid", func() {
+					BeforeEach(func() {
+						queryParams = "?status=bogus"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("invalid status 'bogus'\n"))
+
+						Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the min duration is invalid", func() {
+					BeforeEach(func() {
+						queryParams = "?min_duration=bogus"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("invalid min duration 'bogus'\n"))
+					})
+				})
+			})
+
 			Context("when getting the builds succeeds", func() {
 				BeforeEach(func() {
 					dbBuildFactory.VisibleBuildsReturns(returnedBuilds, db.Pagination{}, nil)
@@ -354,7 +453,7 @@ var _ = Describe("Builds API", func() {
 				It("does not set defaults for since and until", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					_, page, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						Limit: 100,
 					}))
@@ -369,7 +468,7 @@ var _ = Describe("Builds API", func() {
 				It("passes them through", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					_, page, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						From:  db.NewIntPtr(2),
 						To:    db.NewIntPtr(3),
@@ -438,7 +537,7 @@ var _ = Describe("Builds API", func() {
 
 				It("returns builds for teams from the token", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
-					teamName, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					teamName, _, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(teamName).To(ConsistOf("some-team"))
 				})
 			})
@@ -1113,6 +1212,209 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/builds/:build_id/approvals/:approval_name/approve", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/approvals/some-approval/approve", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+
+				build.IDReturns(128)
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not decide anything", func() {
+					Expect(dbApprovalFactory.DecideCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the approval is pending", func() {
+					BeforeEach(func() {
+						dbApprovalFactory.FindPendingReturns([]db.Approval{
+							{BuildID: 128, PlanID: "some-plan", Name: "some-approval"},
+							{BuildID: 128, PlanID: "some-other-plan", Name: "some-approval"},
+						}, nil)
+					})
+
+					It("returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+
+					It("looks up the approval by name", func() {
+						Expect(dbApprovalFactory.FindPendingCallCount()).To(Equal(1))
+						buildID, name := dbApprovalFactory.FindPendingArgsForCall(0)
+						Expect(buildID).To(Equal(128))
+						Expect(name).To(Equal("some-approval"))
+					})
+
+					It("approves each of them as the user", func() {
+						Expect(dbApprovalFactory.DecideCallCount()).To(Equal(2))
+
+						buildID, planID, status, user := dbApprovalFactory.DecideArgsForCall(0)
+						Expect(buildID).To(Equal(128))
+						Expect(planID).To(Equal(atc.PlanID("some-plan")))
+						Expect(status).To(Equal(db.ApprovalStatusApproved))
+						Expect(user).To(Equal("some-user"))
+
+						_, planID, _, _ = dbApprovalFactory.DecideArgsForCall(1)
+						Expect(planID).To(Equal(atc.PlanID("some-other-plan")))
+					})
+
+					Context("when deciding fails", func() {
+						BeforeEach(func() {
+							dbApprovalFactory.DecideReturns(false, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the user is one of the approvers", func() {
+					BeforeEach(func() {
+						fakeAccess.UserInfoReturns(atc.UserInfo{
+							Connector:     "github",
+							UserId:        "1234",
+							UserName:      "some-github-user",
+							DisplayUserId: "some-user",
+						})
+
+						dbApprovalFactory.FindPendingReturns([]db.Approval{
+							{BuildID: 128, PlanID: "some-plan", Name: "some-approval", Approvers: []string{"github:some-github-user"}},
+						}, nil)
+					})
+
+					It("approves it as the user", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+						_, _, _, user := dbApprovalFactory.DecideArgsForCall(0)
+						Expect(user).To(Equal("some-user"))
+					})
+				})
+
+				Context("when only the user's display ID is one of the approvers", func() {
+					BeforeEach(func() {
+						dbApprovalFactory.FindPendingReturns([]db.Approval{
+							{BuildID: 128, PlanID: "some-plan", Name: "some-approval", Approvers: []string{"some-user"}},
+						}, nil)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when the user is not one of the approvers", func() {
+					BeforeEach(func() {
+						dbApprovalFactory.FindPendingReturns([]db.Approval{
+							{BuildID: 128, PlanID: "some-plan", Name: "some-approval", Approvers: []string{"some-other-user"}},
+						}, nil)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not decide anything", func() {
+						Expect(dbApprovalFactory.DecideCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when no approval is pending", func() {
+					BeforeEach(func() {
+						dbApprovalFactory.FindPendingReturns([]db.Approval{}, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when finding the approval fails", func() {
+					BeforeEach(func() {
+						dbApprovalFactory.FindPendingReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/approvals/:approval_name/reject", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+			fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+
+			build.IDReturns(128)
+			build.TeamNameReturns("some-team")
+			dbBuildFactory.BuildReturns(build, true, nil)
+
+			dbApprovalFactory.FindPendingReturns([]db.Approval{
+				{BuildID: 128, PlanID: "some-plan", Name: "some-approval"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/approvals/some-approval/reject", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the approval as the user", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+			Expect(dbApprovalFactory.DecideCallCount()).To(Equal(1))
+			buildID, planID, status, user := dbApprovalFactory.DecideArgsForCall(0)
+			Expect(buildID).To(Equal(128))
+			Expect(planID).To(Equal(atc.PlanID("some-plan")))
+			Expect(status).To(Equal(db.ApprovalStatusRejected))
+			Expect(user).To(Equal("some-user"))
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
@@ -1530,4 +1832,219 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/plan/status", func() {
+		var response *http.Response
+
+		envelope := func(ev atc.Event) event.Envelope {
+			payload, err := json.Marshal(ev)
+			Expect(err).NotTo(HaveOccurred())
+
+			data := json.RawMessage(payload)
+			return event.Envelope{
+				Data:    &data,
+				Event:   ev.EventType(),
+				Version: ev.Version(),
+			}
+		}
+
+		BeforeEach(func() {
+			build.IDReturns(42)
+			build.TeamNameReturns("some-team")
+			dbBuildFactory.BuildReturns(build, true, nil)
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/plan/status")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build has a plan", func() {
+			BeforeEach(func() {
+				build.HasPlanReturns(true)
+				build.PrivatePlanReturns(atc.Plan{
+					ID: "do",
+					Do: &atc.DoPlan{
+						{ID: "get", Get: &atc.GetPlan{Name: "some-input"}},
+						{ID: "task", Task: &atc.TaskPlan{Name: "some-task"}},
+						{ID: "put", Put: &atc.PutPlan{Name: "some-output"}},
+					},
+				})
+
+				build.StepEventsReturns([]event.Envelope{
+					envelope(event.InitializeGet{Origin: event.Origin{ID: "get"}, Time: 1}),
+					envelope(event.SelectedWorker{Origin: event.Origin{ID: "get"}, Time: 1, WorkerName: "some-worker"}),
+					envelope(event.StartGet{Origin: event.Origin{ID: "get"}, Time: 2}),
+					envelope(event.FinishGet{Origin: event.Origin{ID: "get"}, Time: 3, ExitStatus: 0}),
+					envelope(event.InitializeTask{Origin: event.Origin{ID: "task"}, Time: 4}),
+					envelope(event.StartTask{Origin: event.Origin{ID: "task"}, Time: 5}),
+				}, nil)
+			})
+
+			Context("when the build is running", func() {
+				BeforeEach(func() {
+					build.IsRunningReturns(true)
+					build.StatusReturns(db.BuildStatusStarted)
+				})
+
+				It("returns the state of each step", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"build_id": 42,
+						"status": "started",
+						"steps": [
+							{
+								"id": "get",
+								"type": "get",
+								"name": "some-input",
+								"state": "succeeded",
+								"worker": "some-worker",
+								"initialize_time": 1,
+								"start_time": 2,
+								"end_time": 3
+							},
+							{
+								"id": "task",
+								"type": "task",
+								"name": "some-task",
+								"state": "running",
+								"initialize_time": 4,
+								"start_time": 5
+							},
+							{
+								"id": "put",
+								"type": "put",
+								"name": "some-output",
+								"state": "pending"
+							}
+						]
+					}`))
+				})
+			})
+
+			Context("when the build has errored", func() {
+				BeforeEach(func() {
+					build.IsRunningReturns(false)
+					build.StatusReturns(db.BuildStatusErrored)
+
+					build.StepEventsReturns([]event.Envelope{
+						envelope(event.FinishGet{Origin: event.Origin{ID: "get"}, Time: 3, ExitStatus: 1}),
+						envelope(event.Error{Origin: event.Origin{ID: "task"}, Time: 6, Message: "oh no"}),
+					}, nil)
+				})
+
+				It("fails errored steps and skips the ones that never ran", func() {
+					var status atc.BuildPlanStatus
+					err := json.NewDecoder(response.Body).Decode(&status)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(status.Status).To(Equal(atc.StatusErrored))
+					Expect(status.Steps).To(HaveLen(3))
+					Expect(status.Steps[0].State).To(Equal(atc.PlanNodeStateFailed))
+					Expect(status.Steps[1].State).To(Equal(atc.PlanNodeStateFailed))
+					Expect(status.Steps[1].Error).To(Equal("oh no"))
+					Expect(status.Steps[1].EndTime).To(Equal(int64(6)))
+					Expect(status.Steps[2].State).To(Equal(atc.PlanNodeStateSkipped))
+				})
+			})
+
+			Context("when fetching the events fails", func() {
+				BeforeEach(func() {
+					build.StepEventsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the build has no plan", func() {
+			BeforeEach(func() {
+				build.HasPlanReturns(false)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/published_artifacts", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			build.IDReturns(42)
+			build.TeamNameReturns("some-team")
+			dbBuildFactory.BuildReturns(build, true, nil)
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/published_artifacts")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build has published artifacts", func() {
+			BeforeEach(func() {
+				dbPublishedArtifacts.ForBuildReturns([]db.PublishedArtifact{
+					{
+						BuildID:     42,
+						PlanID:      "some-plan",
+						Name:        "some-artifact",
+						Location:    "s3://some-bucket/some-team/abc.tgz",
+						Digest:      "sha256:abc",
+						PublishedAt: time.Unix(100, 0),
+					},
+				}, nil)
+			})
+
+			It("looks them up for the build", func() {
+				Expect(dbPublishedArtifacts.ForBuildCallCount()).To(Equal(1))
+				Expect(dbPublishedArtifacts.ForBuildArgsForCall(0)).To(Equal(42))
+			})
+
+			It("returns them", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{
+						"name": "some-artifact",
+						"location": "s3://some-bucket/some-team/abc.tgz",
+						"digest": "sha256:abc",
+						"published_at": 100
+					}
+				]`))
+			})
+		})
+
+		Context("when the build has not published anything", func() {
+			BeforeEach(func() {
+				dbPublishedArtifacts.ForBuildReturns(nil, nil)
+			})
+
+			It("returns an empty list", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[]`))
+			})
+		})
+
+		Context("when looking them up fails", func() {
+			BeforeEach(func() {
+				dbPublishedArtifacts.ForBuildReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
 })
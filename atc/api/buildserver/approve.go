@@ -0,0 +1,67 @@
+package buildserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ApproveBuild(build db.Build) http.Handler {
+	return s.decideApproval(build, db.ApprovalStatusApproved)
+}
+
+func (s *Server) RejectBuild(build db.Build) http.Handler {
+	return s.decideApproval(build, db.ApprovalStatusRejected)
+}
+
+// decideApproval decides the build's pending approvals with the requested
+// name. The user must be one of the approvers of every one of them.
+func (s *Server) decideApproval(build db.Build, status db.ApprovalStatus) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":approval_name")
+		userInfo := accessor.GetAccessor(r).UserInfo()
+		user := userInfo.DisplayUserId
+
+		logger := s.logger.Session("decide-approval", build.LagerData())
+		data := lager.Data{
+			"approval": name,
+			"status":   status,
+			"user":     user,
+		}
+
+		approvals, err := s.approvalFactory.FindPending(build.ID(), name)
+		if err != nil {
+			logger.Error("failed-to-find-pending-approvals", err, data)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if len(approvals) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		for _, approval := range approvals {
+			if !approval.AllowsApprover(userInfo) {
+				logger.Info("not-an-approver", data)
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
+
+		for _, approval := range approvals {
+			_, err := s.approvalFactory.Decide(build.ID(), approval.PlanID, status, user)
+			if err != nil {
+				logger.Error("failed-to-decide-approval", err, data)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		logger.Info("decided", data)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
@@ -46,14 +49,22 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 		page.UseDate = true
 	}
 
+	filter, err := parseBuildFilter(r)
+	if err != nil {
+		logger.Info("invalid-filter", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(w, err)
+		return
+	}
+
 	var builds []db.Build
 	var pagination db.Pagination
 
 	acc := accessor.GetAccessor(r)
 	if acc.IsAdmin() {
-		builds, pagination, err = s.buildFactory.AllBuilds(page)
+		builds, pagination, err = s.buildFactory.AllBuilds(page, filter)
 	} else {
-		builds, pagination, err = s.buildFactory.VisibleBuilds(acc.TeamNames(), page)
+		builds, pagination, err = s.buildFactory.VisibleBuilds(acc.TeamNames(), page, filter)
 	}
 
 	if err != nil {
@@ -62,12 +73,14 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	filterQuery := filterQueryParams(r).Encode()
+
 	if pagination.Older != nil {
-		s.addNextLink(w, *pagination.Older)
+		s.addNextLink(w, *pagination.Older, filterQuery)
 	}
 
 	if pagination.Newer != nil {
-		s.addPreviousLink(w, *pagination.Newer)
+		s.addPreviousLink(w, *pagination.Newer, filterQuery)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -86,26 +99,96 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (s *Server) addNextLink(w http.ResponseWriter, page db.Page) {
+// parseBuildFilter parses the filter from the query params, erroring on
+// unknown statuses, sort fields and malformed durations.
+func parseBuildFilter(r *http.Request) (atc.BuildFilter, error) {
+	filter := atc.BuildFilter{
+		Pipeline: r.FormValue(atc.BuildFilterQueryPipeline),
+		Job:      r.FormValue(atc.BuildFilterQueryJob),
+		Worker:   r.FormValue(atc.BuildFilterQueryWorker),
+		Reverse:  r.FormValue(atc.BuildFilterQueryReverse) == "true",
+	}
+
+	for _, status := range r.Form[atc.BuildFilterQueryStatus] {
+		switch atc.BuildStatus(status) {
+		case atc.StatusPending, atc.StatusStarted, atc.StatusAborted,
+			atc.StatusSucceeded, atc.StatusFailed, atc.StatusErrored:
+			filter.Statuses = append(filter.Statuses, atc.BuildStatus(status))
+		default:
+			return atc.BuildFilter{}, fmt.Errorf("invalid status '%s'", status)
+		}
+	}
+
+	sortBy := r.FormValue(atc.BuildFilterQuerySortBy)
+	switch atc.BuildSortField(sortBy) {
+	case "", atc.BuildSortByID, atc.BuildSortByName, atc.BuildSortByStatus,
+		atc.BuildSortByStart, atc.BuildSortByEnd, atc.BuildSortByDuration:
+		filter.SortBy = atc.BuildSortField(sortBy)
+	default:
+		return atc.BuildFilter{}, fmt.Errorf("invalid sort field '%s'", sortBy)
+	}
+
+	minDuration := r.FormValue(atc.BuildFilterQueryMinDuration)
+	if minDuration != "" {
+		var err error
+		filter.MinDuration, err = time.ParseDuration(minDuration)
+		if err != nil {
+			return atc.BuildFilter{}, fmt.Errorf("invalid min duration '%s'", minDuration)
+		}
+	}
+
+	return filter, nil
+}
+
+// filterQueryParams returns the filter's query params, to be carried over to
+// the pagination links.
+func filterQueryParams(r *http.Request) url.Values {
+	params := url.Values{}
+	for _, name := range []string{
+		atc.BuildFilterQueryStatus,
+		atc.BuildFilterQueryPipeline,
+		atc.BuildFilterQueryJob,
+		atc.BuildFilterQueryMinDuration,
+		atc.BuildFilterQueryWorker,
+	} {
+		for _, value := range r.URL.Query()[name] {
+			params.Add(name, value)
+		}
+	}
+
+	return params
+}
+
+func (s *Server) addNextLink(w http.ResponseWriter, page db.Page, filterQuery string) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		atc.PaginationQueryTo,
 		*page.To,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		linkFilterSuffix(filterQuery),
 		atc.LinkRelNext,
 	))
 }
 
-func (s *Server) addPreviousLink(w http.ResponseWriter, page db.Page) {
+func (s *Server) addPreviousLink(w http.ResponseWriter, page db.Page, filterQuery string) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		atc.PaginationQueryFrom,
 		*page.From,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		linkFilterSuffix(filterQuery),
 		atc.LinkRelPrevious,
 	))
 }
+
+func linkFilterSuffix(filterQuery string) string {
+	if filterQuery == "" {
+		return ""
+	}
+
+	return "&" + filterQuery
+}
@@ -0,0 +1,227 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+func (s *Server) GetBuildPlanStatus(build db.Build) http.Handler {
+	hLog := s.logger.Session("get-build-plan-status")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !build.HasPlan() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		events, err := build.StepEvents()
+		if err != nil {
+			hLog.Error("failed-to-get-step-events", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		steps, err := planNodeStatuses(build.PrivatePlan(), events, build.IsRunning())
+		if err != nil {
+			hLog.Error("failed-to-parse-step-events", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(atc.BuildPlanStatus{
+			BuildID: build.ID(),
+			Status:  atc.BuildStatus(build.Status()),
+			Steps:   steps,
+		})
+		if err != nil {
+			hLog.Error("failed-to-encode-build-plan-status", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+}
+
+// planNodeStatuses replays the build's events onto the steps of its plan, in
+// the order they appear in the plan. Structural steps like do and try are
+// left out as they have no state of their own.
+func planNodeStatuses(plan atc.Plan, envelopes []event.Envelope, running bool) ([]atc.PlanNodeStatus, error) {
+	steps := []atc.PlanNodeStatus{}
+	indexes := map[atc.PlanID]int{}
+
+	plan.Each(func(p *atc.Plan) {
+		stepType, name, ok := planStep(p)
+		if !ok {
+			return
+		}
+
+		indexes[p.ID] = len(steps)
+		steps = append(steps, atc.PlanNodeStatus{
+			ID:    p.ID,
+			Type:  stepType,
+			Name:  name,
+			State: atc.PlanNodeStatePending,
+		})
+	})
+
+	for _, envelope := range envelopes {
+		if envelope.Data == nil {
+			continue
+		}
+
+		ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		origin, ok := eventOrigin(ev)
+		if !ok {
+			continue
+		}
+
+		i, found := indexes[atc.PlanID(origin.ID)]
+		if !found {
+			continue
+		}
+
+		applyEvent(&steps[i], ev)
+	}
+
+	if !running {
+		for i := range steps {
+			switch steps[i].State {
+			case atc.PlanNodeStatePending:
+				steps[i].State = atc.PlanNodeStateSkipped
+			case atc.PlanNodeStateRunning:
+				// the build was aborted or errored while the step was running
+				steps[i].State = atc.PlanNodeStateFailed
+			}
+		}
+	}
+
+	return steps, nil
+}
+
+func planStep(plan *atc.Plan) (string, string, bool) {
+	switch {
+	case plan.Get != nil:
+		return "get", plan.Get.Name, true
+	case plan.Put != nil:
+		return "put", plan.Put.Name, true
+	case plan.Check != nil:
+		return "check", plan.Check.Name, true
+	case plan.Task != nil:
+		return "task", plan.Task.Name, true
+	case plan.SetPipeline != nil:
+		return "set_pipeline", plan.SetPipeline.Name, true
+	case plan.LoadVar != nil:
+		return "load_var", plan.LoadVar.Name, true
+	case plan.LoadVars != nil:
+		return "load_vars", plan.LoadVars.Files, true
+	case plan.Approval != nil:
+		return "approval", plan.Approval.Name, true
+	case plan.Run != nil:
+		return "run", plan.Run.Name, true
+	case plan.Publish != nil:
+		return "publish", plan.Publish.Name, true
+	case plan.ArtifactInput != nil:
+		return "artifact_input", plan.ArtifactInput.Name, true
+	case plan.ArtifactOutput != nil:
+		return "artifact_output", plan.ArtifactOutput.Name, true
+	default:
+		return "", "", false
+	}
+}
+
+func eventOrigin(ev atc.Event) (event.Origin, bool) {
+	switch e := ev.(type) {
+	case event.InitializeTask:
+		return e.Origin, true
+	case event.StartTask:
+		return e.Origin, true
+	case event.FinishTask:
+		return e.Origin, true
+	case event.InitializeGet:
+		return e.Origin, true
+	case event.StartGet:
+		return e.Origin, true
+	case event.FinishGet:
+		return e.Origin, true
+	case event.InitializePut:
+		return e.Origin, true
+	case event.StartPut:
+		return e.Origin, true
+	case event.FinishPut:
+		return e.Origin, true
+	case event.Initialize:
+		return e.Origin, true
+	case event.Start:
+		return e.Origin, true
+	case event.Finish:
+		return e.Origin, true
+	case event.SelectedWorker:
+		return e.Origin, true
+	case event.Error:
+		return e.Origin, true
+	default:
+		return event.Origin{}, false
+	}
+}
+
+func applyEvent(step *atc.PlanNodeStatus, ev atc.Event) {
+	switch e := ev.(type) {
+	case event.InitializeTask:
+		initializeStep(step, e.Time)
+	case event.InitializeGet:
+		initializeStep(step, e.Time)
+	case event.InitializePut:
+		initializeStep(step, e.Time)
+	case event.Initialize:
+		initializeStep(step, e.Time)
+	case event.StartTask:
+		startStep(step, e.Time)
+	case event.StartGet:
+		startStep(step, e.Time)
+	case event.StartPut:
+		startStep(step, e.Time)
+	case event.Start:
+		startStep(step, e.Time)
+	case event.FinishTask:
+		finishStep(step, e.Time, e.ExitStatus == 0)
+	case event.FinishGet:
+		finishStep(step, e.Time, e.ExitStatus == 0)
+	case event.FinishPut:
+		finishStep(step, e.Time, e.ExitStatus == 0)
+	case event.Finish:
+		finishStep(step, e.Time, e.Succeeded)
+	case event.SelectedWorker:
+		step.Worker = e.WorkerName
+	case event.Error:
+		step.Error = e.Message
+		finishStep(step, e.Time, false)
+	}
+}
+
+func initializeStep(step *atc.PlanNodeStatus, t int64) {
+	step.State = atc.PlanNodeStateRunning
+	step.InitializeTime = t
+}
+
+func startStep(step *atc.PlanNodeStatus, t int64) {
+	step.State = atc.PlanNodeStateRunning
+	step.StartTime = t
+}
+
+func finishStep(step *atc.PlanNodeStatus, t int64, succeeded bool) {
+	if succeeded {
+		step.State = atc.PlanNodeStateSucceeded
+	} else {
+		step.State = atc.PlanNodeStateFailed
+	}
+
+	step.EndTime = t
+}
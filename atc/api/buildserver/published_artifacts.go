@@ -0,0 +1,31 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListBuildPublishedArtifacts(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-build-published-artifacts")
+
+		artifacts, err := s.publishedArtifacts.ForBuild(build.ID())
+		if err != nil {
+			logger.Error("failed-to-fetch-published-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(present.PublishedArtifacts(artifacts))
+		if err != nil {
+			logger.Error("failed-to-encode-published-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -17,6 +17,8 @@ type Server struct {
 
 	teamFactory         db.TeamFactory
 	buildFactory        db.BuildFactory
+	approvalFactory     db.ApprovalFactory
+	publishedArtifacts  db.PublishedArtifactFactory
 	eventHandlerFactory EventHandlerFactory
 	rejector            auth.Rejector
 }
@@ -26,6 +28,8 @@ func NewServer(
 	externalURL string,
 	teamFactory db.TeamFactory,
 	buildFactory db.BuildFactory,
+	approvalFactory db.ApprovalFactory,
+	publishedArtifacts db.PublishedArtifactFactory,
 	eventHandlerFactory EventHandlerFactory,
 ) *Server {
 	return &Server{
@@ -35,6 +39,8 @@ func NewServer(
 
 		teamFactory:         teamFactory,
 		buildFactory:        buildFactory,
+		approvalFactory:     approvalFactory,
+		publishedArtifacts:  publishedArtifacts,
 		eventHandlerFactory: eventHandlerFactory,
 
 		rejector: auth.UnauthorizedRejector{},
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/policy"
 	. "github.com/concourse/concourse/atc/testhelpers"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/tedsuo/rata"
@@ -415,6 +416,36 @@ var _ = Describe("Config API", func() {
 
 			})
 
+			Context("when the policy check warns about the config", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
+						Allowed:  true,
+						Warnings: []string{"jobs will soon need timeouts"},
+					}, nil)
+
+					request.Header.Set("Content-Type", "application/json")
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+				})
+
+				It("saves the config and returns the warnings", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+						{
+							"warnings": [
+								{
+									"type": "policy",
+									"message": "jobs will soon need timeouts"
+								}
+							]
+						}`))
+				})
+			})
+
 			Context("when a config version is specified", func() {
 				BeforeEach(func() {
 					request.Header.Set(atc.ConfigVersionHeader, "42")
@@ -581,6 +612,100 @@ var _ = Describe("Config API", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
 							})
 						})
+
+						Context("when the config includes a template", func() {
+							BeforeEach(func() {
+								pipelineConfig.Include = []atc.IncludeConfig{{Template: "extra"}}
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							Context("when the template exists", func() {
+								BeforeEach(func() {
+									dbTeam.PipelineTemplateReturns(atc.PipelineTemplate{
+										Name:   "extra",
+										Config: "groups:\n- name: extra\n  jobs: [extra-job]\njobs:\n- name: extra-job\n  plan:\n  - get: some-resource\n",
+									}, true, nil)
+								})
+
+								It("saves the config with the template merged in", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(dbTeam.PipelineTemplateArgsForCall(0)).To(Equal("extra"))
+
+									_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+									Expect(savedConfig.Include).To(BeEmpty())
+									Expect(savedConfig.Jobs[len(savedConfig.Jobs)-1].Name).To(Equal("extra-job"))
+									Expect(savedConfig.Included).To(HaveLen(1))
+									Expect(savedConfig.Included[0].Template).To(Equal("extra"))
+									Expect(savedConfig.Included[0].Jobs).To(Equal([]string{"extra-job"}))
+								})
+							})
+
+							Context("when the template does not exist", func() {
+								BeforeEach(func() {
+									dbTeam.PipelineTemplateReturns(atc.PipelineTemplate{}, false, nil)
+								})
+
+								It("returns 400 without saving", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+									Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("template not found"))
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								})
+							})
+						})
+
+						Context("when the config uses a step template", func() {
+							BeforeEach(func() {
+								pipelineConfig.StepTemplates = atc.StepTemplateConfigs{
+									{
+										Name:   "load",
+										Params: []atc.StepTemplateParamConfig{{Name: "file"}},
+										Steps: []atc.Step{
+											{
+												Config: &atc.LoadVarStep{
+													Name: "some-var",
+													File: "((file))",
+												},
+											},
+										},
+									},
+								}
+
+								job := &pipelineConfig.Jobs[0]
+								job.PlanSequence = append(job.PlanSequence, atc.Step{
+									Config: &atc.UseStep{
+										Config: atc.UseConfig{
+											Template: "load",
+											Params:   atc.Params{"file": "some-input/some-file"},
+										},
+									},
+								})
+
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							It("saves the config with the template's steps in place of the use step", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+								_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+								Expect(savedConfig.StepTemplates).To(BeEmpty())
+
+								plan := savedConfig.Jobs[0].PlanSequence
+								Expect(plan[len(plan)-1].Config).To(Equal(&atc.DoStep{
+									Steps: []atc.Step{
+										{
+											Config: &atc.LoadVarStep{
+												Name: "some-var",
+												File: "some-input/some-file",
+											},
+										},
+									},
+								}))
+							})
+						})
 					})
 
 					Context("YAML", func() {
@@ -1319,4 +1444,136 @@ jobs:
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:name/config/validate", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.ValidateConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the config is valid", func() {
+				BeforeEach(func() {
+					request.Header.Set("Content-Type", "application/json")
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					expectedHeaderEntries := map[string]string{
+						"Content-Type": "application/json",
+					}
+					Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+				})
+
+				It("returns an empty response", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{}`))
+				})
+
+				It("does not save the config", func() {
+					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the config refers to an undeclared var source", func() {
+				BeforeEach(func() {
+					pipelineConfig.Resources[0].Source["secret"] = "((missing:secret))"
+
+					request.Header.Set("Content-Type", "application/json")
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+				})
+
+				It("returns 200 with a warning", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+						{
+							"warnings": [
+								{
+									"type": "var_sources",
+									"message": "var 'missing:secret' refers to var source 'missing', which is not declared in var_sources"
+								}
+							]
+						}`))
+				})
+			})
+
+			Context("when the config is invalid", func() {
+				BeforeEach(func() {
+					pipelineConfig.Groups[0].Resources = []string{"not-a-resource"}
+
+					request.Header.Set("Content-Type", "application/json")
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+				})
+
+				It("returns 400 with the errors", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					var saveConfigResponse atc.SaveConfigResponse
+					err := json.NewDecoder(response.Body).Decode(&saveConfigResponse)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(saveConfigResponse.Errors).To(ConsistOf(ContainSubstring("not-a-resource")))
+				})
+
+				It("does not save the config", func() {
+					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the Content-Type is unsupported", func() {
+				BeforeEach(func() {
+					request.Header.Set("Content-Type", "application/x-toml")
+					request.Body = gbytes.BufferWithBytes([]byte(`name = "a-pipeline"`))
+				})
+
+				It("returns 415", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnsupportedMediaType))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
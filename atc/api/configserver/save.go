@@ -9,6 +9,8 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/policychecker"
+	"github.com/concourse/concourse/atc/configinclude"
 	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -18,9 +20,68 @@ import (
 	"github.com/tedsuo/rata"
 )
 
+type configRequest struct {
+	config      atc.Config
+	version     db.ConfigVersion
+	teamName    string
+	pipelineRef atc.PipelineRef
+	warnings    []atc.ConfigWarning
+}
+
 func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	session := s.logger.Session("set-config")
 
+	req, ok := s.validateConfigRequest(session, w, r)
+	if !ok {
+		return
+	}
+
+	session.Info("saving")
+
+	team, found, err := s.teamFactory.FindTeam(req.teamName)
+	if err != nil {
+		session.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		session.Debug("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	_, created, err := team.SavePipeline(req.pipelineRef, req.config, req.version, true)
+	if err != nil {
+		session.Error("failed-to-save-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "failed to save config: %s", err)
+		return
+	}
+
+	if !created {
+		if err = s.teamFactory.NotifyResourceScanner(); err != nil {
+			session.Error("failed-to-notify-resource-scanner", err)
+		}
+	}
+
+	session.Info("saved")
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: req.warnings})
+}
+
+// validateConfigRequest parses the config in the request and runs every
+// check that saving it would. On failure it writes the response itself and
+// returns false.
+func (s *Server) validateConfigRequest(session lager.Logger, w http.ResponseWriter, r *http.Request) (configRequest, bool) {
 	query := r.URL.Query()
 
 	checkCredentials := false
@@ -34,7 +95,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			session.Error("malformed-config-version", err)
 			s.handleBadRequest(w, fmt.Sprintf("config version is malformed: %s", err))
-			return
+			return configRequest{}, false
 		}
 	}
 
@@ -44,7 +105,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		body, err := ioutil.ReadAll(r.Body)
 		if err != nil {
 			s.handleBadRequest(w, fmt.Sprintf("read failed: %s", err))
-			return
+			return configRequest{}, false
 		}
 
 		err = atc.UnmarshalConfig(body, &config)
@@ -54,26 +115,40 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 			})
 
 			s.handleBadRequest(w, fmt.Sprintf("malformed config: %s", err))
-			return
+			return configRequest{}, false
 		}
 	default:
 		w.WriteHeader(http.StatusUnsupportedMediaType)
-		return
+		return configRequest{}, false
+	}
+
+	config, ok := s.expandIncludes(session, w, rata.Param(r, "team_name"), config)
+	if !ok {
+		return configRequest{}, false
 	}
 
 	warnings, errorMessages := configvalidate.Validate(config)
 	if len(errorMessages) > 0 {
 		session.Info("ignoring-invalid-config", lager.Data{"errors": errorMessages})
-		s.handleBadRequest(w, errorMessages...)
-		return
+		s.handleBadRequestWithWarnings(w, warnings, errorMessages...)
+		return configRequest{}, false
+	}
+
+	// the templates' steps are saved in place of the `use:` steps, so that
+	// the rest of the ATC sees each job's steps as they will run
+	config, err := atc.ExpandStepTemplates(config)
+	if err != nil {
+		session.Info("failed-to-expand-step-templates", lager.Data{"error": err.Error()})
+		s.handleBadRequestWithWarnings(w, warnings, err.Error())
+		return configRequest{}, false
 	}
 
 	pipelineName := rata.Param(r, "pipeline_name")
 	warning, err := atc.ValidateIdentifier(pipelineName, "pipeline")
 	if err != nil {
 		session.Info("ignoring-pipeline-name", lager.Data{"error": err.Error()})
-		s.handleBadRequest(w, err.Error())
-		return
+		s.handleBadRequestWithWarnings(w, warnings, err.Error())
+		return configRequest{}, false
 	}
 	if warning != nil {
 		warnings = append(warnings, *warning)
@@ -83,8 +158,8 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	warning, err = atc.ValidateIdentifier(teamName, "team")
 	if err != nil {
 		session.Info("ignoring-team-name", lager.Data{"error": err.Error()})
-		s.handleBadRequest(w, err.Error())
-		return
+		s.handleBadRequestWithWarnings(w, warnings, err.Error())
+		return configRequest{}, false
 	}
 	if warning != nil {
 		warnings = append(warnings, *warning)
@@ -95,12 +170,12 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	if atc.EnablePipelineInstances {
 		if err != nil {
 			session.Error("malformed-instance-vars", err)
-			s.handleBadRequest(w, fmt.Sprintf("instance vars are malformed: %v", err))
-			return
+			s.handleBadRequestWithWarnings(w, warnings, fmt.Sprintf("instance vars are malformed: %v", err))
+			return configRequest{}, false
 		}
 	} else if pipelineRef.InstanceVars != nil {
-		s.handleBadRequest(w, "support for `instance vars` is disabled")
-		return
+		s.handleBadRequestWithWarnings(w, warnings, "support for `instance vars` is disabled")
+		return configRequest{}, false
 	}
 
 	if checkCredentials {
@@ -108,51 +183,55 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 		errs := validateCredParams(variables, config, session)
 		if errs != nil {
-			s.handleBadRequest(w, fmt.Sprintf("credential validation failed\n\n%s", errs))
-			return
+			s.handleBadRequestWithWarnings(w, warnings, fmt.Sprintf("credential validation failed\n\n%s", errs))
+			return configRequest{}, false
 		}
 	}
 
-	session.Info("saving")
-
-	team, found, err := s.teamFactory.FindTeam(teamName)
-	if err != nil {
-		session.Error("failed-to-find-team", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	if !found {
-		session.Debug("team-not-found")
-		w.WriteHeader(http.StatusNotFound)
-		return
+	for _, warning := range policychecker.Warnings(r) {
+		warnings = append(warnings, atc.ConfigWarning{
+			Type:    "policy",
+			Message: warning,
+		})
 	}
 
-	_, created, err := team.SavePipeline(pipelineRef, config, version, true)
-	if err != nil {
-		session.Error("failed-to-save-config", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprintf(w, "failed to save config: %s", err)
-		return
-	}
+	return configRequest{
+		config:      config,
+		version:     version,
+		teamName:    teamName,
+		pipelineRef: pipelineRef,
+		warnings:    warnings,
+	}, true
+}
 
-	if !created {
-		if err = s.teamFactory.NotifyResourceScanner(); err != nil {
-			session.Error("failed-to-notify-resource-scanner", err)
+// expandIncludes merges the templates included by the config into it, so
+// that the rest of the request sees the config as it will be saved.
+func (s *Server) expandIncludes(session lager.Logger, w http.ResponseWriter, teamName string, config atc.Config) (atc.Config, bool) {
+	var source configinclude.Source
+	if len(config.Include) > 0 {
+		team, found, err := s.teamFactory.FindTeam(teamName)
+		if err != nil {
+			session.Error("failed-to-find-team", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return atc.Config{}, false
 		}
-	}
 
-	session.Info("saved")
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return atc.Config{}, false
+		}
 
-	w.Header().Set("Content-Type", "application/json")
+		source.Templates = team
+	}
 
-	if created {
-		w.WriteHeader(http.StatusCreated)
-	} else {
-		w.WriteHeader(http.StatusOK)
+	expanded, err := configinclude.Expand(config, source)
+	if err != nil {
+		session.Info("failed-to-expand-includes", lager.Data{"error": err.Error()})
+		s.handleBadRequest(w, err.Error())
+		return atc.Config{}, false
 	}
 
-	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: warnings})
+	return expanded, true
 }
 
 // Simply validate that the credentials exist; don't do anything with the actual secrets
@@ -221,10 +300,15 @@ func validateCredParams(credMgrVars vars.Variables, config atc.Config, session l
 }
 
 func (s *Server) handleBadRequest(w http.ResponseWriter, errorMessages ...string) {
+	s.handleBadRequestWithWarnings(w, nil, errorMessages...)
+}
+
+func (s *Server) handleBadRequestWithWarnings(w http.ResponseWriter, warnings []atc.ConfigWarning, errorMessages ...string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusBadRequest)
 	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{
-		Errors: errorMessages,
+		Errors:   errorMessages,
+		Warnings: warnings,
 	})
 }
 
@@ -0,0 +1,26 @@
+package configserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// ValidateConfig runs the same checks as SaveConfig against the submitted
+// config, but never saves it. This lets a config be checked, e.g. before a
+// change to it is merged, without touching the running pipeline.
+func (s *Server) ValidateConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("validate-config")
+
+	req, ok := s.validateConfigRequest(session, w, r)
+	if !ok {
+		return
+	}
+
+	session.Debug("valid")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: req.warnings})
+}
@@ -47,6 +47,7 @@ var _ = Describe("Containers API", func() {
 		fakeContainer1.HandleReturns("some-handle")
 		fakeContainer1.StateReturns("container-state")
 		fakeContainer1.WorkerNameReturns("some-worker-name")
+		fakeContainer1.CreatedAtReturns(time.Unix(1622213441, 0))
 		fakeContainer1.MetadataReturns(db.ContainerMetadata{
 			Type: stepType,
 
@@ -149,7 +150,8 @@ var _ = Describe("Containers API", func() {
 									"state": "container-state",
 									"build_id": 3333,
 									"working_directory": "/tmp/build/my-favorite-guid",
-									"user": "snoopy"
+									"user": "snoopy",
+									"created_at": 1622213441
 								},
 								{
 									"id": "some-other-handle",
@@ -490,7 +492,8 @@ var _ = Describe("Containers API", func() {
 	 						"job_id": 2222,
 	 						"build_id": 3333,
 	 						"working_directory": "/tmp/build/my-favorite-guid",
-	 						"user": "snoopy"
+	 						"user": "snoopy",
+	 						"created_at": 1622213441
 	 					}
 	 				`))
 					})
@@ -972,6 +975,177 @@ var _ = Describe("Containers API", func() {
 								})
 							})
 
+							Context("when the process spec names a session", func() {
+								BeforeEach(func() {
+									requestPayload = `{"path":"bash","user":"snoopy","session":"debug","tty":{"window_size":{"columns":80,"rows":24}}}`
+
+									fakeContainer.HandleReturns(handle)
+									fakeProcess.IDReturns("some-process")
+								})
+
+								Context("when the session does not exist", func() {
+									It("runs a new process and saves it as the session", func() {
+										Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+
+										_, spec, _ := fakeContainer.RunArgsForCall(0)
+										Expect(spec.ID).To(HavePrefix("intercept-"))
+										Expect(spec.Path).To(Equal("bash"))
+
+										Eventually(fakeContainerRepository.SaveInterceptSessionCallCount).Should(Equal(1))
+										savedHandle, name, processID, ttl := fakeContainerRepository.SaveInterceptSessionArgsForCall(0)
+										Expect(savedHandle).To(Equal(handle))
+										Expect(name).To(Equal("debug"))
+										Expect(processID).To(Equal(spec.ID))
+										Expect(ttl).To(Equal(time.Hour))
+									})
+
+									Context("when saving the session fails", func() {
+										BeforeEach(func() {
+											fakeContainerRepository.SaveInterceptSessionReturns(errors.New("nope"))
+										})
+
+										It("kills the process and returns the error", func() {
+											var hijackOutput atc.HijackOutput
+											err := conn.ReadJSON(&hijackOutput)
+											Expect(err).NotTo(HaveOccurred())
+											Expect(hijackOutput.Error).To(Equal("nope"))
+
+											Expect(fakeProcess.SignalCallCount()).To(Equal(1))
+											Expect(fakeProcess.SignalArgsForCall(0)).To(Equal(garden.SignalKill))
+										})
+									})
+								})
+
+								Context("when the session exists", func() {
+									BeforeEach(func() {
+										fakeContainerRepository.FindInterceptSessionReturns(db.InterceptSession{
+											Name:      "debug",
+											ProcessID: "some-process",
+										}, true, nil)
+									})
+
+									Context("when its process is still running", func() {
+										BeforeEach(func() {
+											fakeContainer.AttachReturns(fakeProcess, nil)
+										})
+
+										It("reattaches to it with the client's window size", func() {
+											Eventually(fakeContainer.AttachCallCount).Should(Equal(1))
+
+											_, processID, io := fakeContainer.AttachArgsForCall(0)
+											Expect(processID).To(Equal("some-process"))
+											Expect(io.Stdin).NotTo(BeNil())
+											Expect(io.Stdout).NotTo(BeNil())
+
+											Expect(fakeContainer.RunCallCount()).To(Equal(0))
+
+											Eventually(fakeProcess.SetTTYCallCount).Should(Equal(1))
+											Expect(fakeProcess.SetTTYArgsForCall(0)).To(Equal(garden.TTYSpec{
+												WindowSize: &garden.WindowSize{
+													Columns: 80,
+													Rows:    24,
+												},
+											}))
+										})
+									})
+
+									Context("when its process has gone", func() {
+										BeforeEach(func() {
+											fakeContainer.AttachReturns(nil, errors.New("process not found"))
+										})
+
+										It("runs a new process in its place", func() {
+											Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+											Eventually(fakeContainerRepository.SaveInterceptSessionCallCount).Should(Equal(1))
+										})
+									})
+								})
+
+								Context("when the process exits", func() {
+									JustBeforeEach(func() {
+										Eventually(processExit).Should(BeSent(0))
+									})
+
+									It("deletes the session", func() {
+										Eventually(fakeContainerRepository.DeleteInterceptSessionCallCount).Should(Equal(1))
+
+										deletedHandle, processID := fakeContainerRepository.DeleteInterceptSessionArgsForCall(0)
+										Expect(deletedHandle).To(Equal(handle))
+										Expect(processID).To(Equal("some-process"))
+									})
+								})
+
+								Context("when the client disconnects", func() {
+									JustBeforeEach(func() {
+										Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+										Eventually(fakeContainerRepository.SaveInterceptSessionCallCount).Should(Equal(1))
+
+										_ = conn.Close()
+									})
+
+									It("leaves the process running with its stdin open", func() {
+										_, _, io := fakeContainer.RunArgsForCall(0)
+
+										_, err := io.Stdin.Read(make([]byte, 10))
+										Expect(err).To(MatchError("client detached"))
+
+										Expect(fakeProcess.SignalCallCount()).To(Equal(0))
+									})
+
+									Context("when nobody reattaches before the session expires", func() {
+										BeforeEach(func() {
+											fakeContainerRepository.FindInterceptSessionStub = func(string, string) (db.InterceptSession, bool, error) {
+												if fakeContainerRepository.SaveInterceptSessionCallCount() == 0 {
+													return db.InterceptSession{}, false, nil
+												}
+
+												return db.InterceptSession{
+													Name:      "debug",
+													ProcessID: "some-process",
+													ExpiresAt: fakeClock.Now(),
+												}, true, nil
+											}
+										})
+
+										It("kills the process and deletes the session", func() {
+											Eventually(func() int {
+												fakeClock.Increment(time.Hour)
+												return fakeProcess.SignalCallCount()
+											}).Should(Equal(1))
+
+											Expect(fakeProcess.SignalArgsForCall(0)).To(Equal(garden.SignalKill))
+
+											Eventually(fakeContainerRepository.DeleteInterceptSessionCallCount).Should(Equal(1))
+										})
+									})
+
+									Context("when the session is replaced by another process", func() {
+										BeforeEach(func() {
+											fakeContainerRepository.FindInterceptSessionStub = func(string, string) (db.InterceptSession, bool, error) {
+												if fakeContainerRepository.SaveInterceptSessionCallCount() == 0 {
+													return db.InterceptSession{}, false, nil
+												}
+
+												return db.InterceptSession{
+													Name:      "debug",
+													ProcessID: "another-process",
+													ExpiresAt: fakeClock.Now(),
+												}, true, nil
+											}
+										})
+
+										It("leaves the process alone", func() {
+											Eventually(func() int {
+												fakeClock.Increment(time.Hour)
+												return fakeContainerRepository.FindInterceptSessionCallCount()
+											}).Should(Equal(2))
+
+											Consistently(fakeProcess.SignalCallCount).Should(Equal(0))
+										})
+									})
+								})
+							})
+
 							Context("when intercept timeout channel sends a value", func() {
 								var (
 									interceptTimeoutChannel chan time.Time
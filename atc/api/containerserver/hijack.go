@@ -2,6 +2,7 @@ package containerserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/gorilla/websocket"
+	uuid "github.com/nu7hatch/gouuid"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -22,6 +24,11 @@ var upgrader = websocket.Upgrader{
 	HandshakeTimeout: 5 * time.Second,
 }
 
+// errDetached is given to a named session's process in place of the end of
+// its stdin when its client goes away, so that stdin stays open for whoever
+// reattaches.
+var errDetached = errors.New("client detached")
+
 type InterceptTimeoutError struct {
 	duration time.Duration
 }
@@ -182,12 +189,13 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 	})
 
 	stdinR, stdinW := io.Pipe()
-	defer db.Close(stdinW)
 
 	inputs := make(chan atc.HijackInput)
 	outputs := make(chan atc.HijackOutput)
 	exited := make(chan int, 1)
 	errs := make(chan error, 1)
+	disconnected := make(chan struct{})
+	processDone := make(chan struct{})
 
 	cleanup := make(chan struct{})
 	defer close(cleanup)
@@ -214,7 +222,7 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 		}
 	}
 
-	process, err := request.Container.Run(context.Background(), garden.ProcessSpec{
+	process, err := s.runOrAttach(hLog, request, garden.ProcessSpec{
 		Path: request.Process.Path,
 		Args: request.Process.Args,
 		Env:  request.Process.Env,
@@ -241,9 +249,28 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 			Error: err.Error(),
 		})
 		hLog.Error("failed-to-hijack", err)
+		_ = stdinW.Close()
 		return
 	}
 
+	session := request.Process.Session
+	handle := request.Container.Handle()
+
+	defer func() {
+		select {
+		case <-processDone:
+		default:
+			if session != "" {
+				hLog.Info("detached")
+				_ = stdinW.CloseWithError(errDetached)
+				go s.reapInterceptSession(hLog, handle, session, process, processDone)
+				return
+			}
+		}
+
+		_ = stdinW.Close()
+	}()
+
 	err = request.Container.UpdateLastHijack()
 	if err != nil {
 		hLog.Error("failed-to-update-container-hijack-time", err)
@@ -260,6 +287,14 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 					return
 				}
 
+				if session != "" {
+					err = s.containerRepository.SaveInterceptSession(handle, session, process.ID(), s.interceptSessionTTL)
+					if err != nil {
+						hLog.Error("failed-to-update-intercept-session", err)
+						return
+					}
+				}
+
 			case <-cleanup:
 				return
 			}
@@ -273,6 +308,7 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 			var input atc.HijackInput
 			err := conn.ReadJSON(&input)
 			if err != nil {
+				close(disconnected)
 				break
 			}
 
@@ -286,6 +322,7 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 
 	go func() {
 		status, err := process.Wait()
+		close(processDone)
 		if err != nil {
 			errs <- err
 		} else {
@@ -321,6 +358,9 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 		case <-idleChan:
 			errs <- idle.Error()
 
+		case <-disconnected:
+			return
+
 		case output := <-outputs:
 			err := conn.WriteJSON(output)
 			if err != nil {
@@ -332,6 +372,13 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 				ExitStatus: &status,
 			})
 
+			if session != "" {
+				err := s.containerRepository.DeleteInterceptSession(handle, process.ID())
+				if err != nil {
+					hLog.Error("failed-to-delete-intercept-session", err)
+				}
+			}
+
 			return
 
 		case err := <-errs:
@@ -344,6 +391,110 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 	}
 }
 
+// runOrAttach runs the process, unless it's for a named session whose process
+// is still running, in which case it attaches to that instead.
+func (s *Server) runOrAttach(logger lager.Logger, request hijackRequest, spec garden.ProcessSpec, pio garden.ProcessIO) (garden.Process, error) {
+	name := request.Process.Session
+	if name == "" {
+		return request.Container.Run(context.Background(), spec, pio)
+	}
+
+	handle := request.Container.Handle()
+
+	session, found, err := s.containerRepository.FindInterceptSession(handle, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		process, err := request.Container.Attach(context.Background(), session.ProcessID, pio)
+		if err == nil {
+			logger.Info("reattached", lager.Data{"session": name})
+
+			if spec.TTY != nil {
+				err = process.SetTTY(*spec.TTY)
+				if err != nil {
+					logger.Error("failed-to-set-tty", err)
+				}
+			}
+
+			return process, nil
+		}
+
+		logger.Info("session-process-gone", lager.Data{"session": name, "error": err.Error()})
+	}
+
+	processID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	spec.ID = "intercept-" + processID.String()
+
+	process, err := request.Container.Run(context.Background(), spec, pio)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.containerRepository.SaveInterceptSession(handle, name, spec.ID, s.interceptSessionTTL)
+	if err != nil {
+		_ = process.Signal(garden.SignalKill)
+		return nil, err
+	}
+
+	return process, nil
+}
+
+// reapInterceptSession kills a detached session's process once the session
+// has expired. Clients attached to it through any ATC keep pushing the expiry
+// back, so this only happens once nobody has reattached for the TTL.
+func (s *Server) reapInterceptSession(logger lager.Logger, handle string, name string, process garden.Process, processDone <-chan struct{}) {
+	logger = logger.Session("reap-intercept-session", lager.Data{"session": name})
+
+	wait := s.interceptSessionTTL
+	for {
+		select {
+		case <-s.clock.After(wait):
+		case <-processDone:
+			err := s.containerRepository.DeleteInterceptSession(handle, process.ID())
+			if err != nil {
+				logger.Error("failed-to-delete-intercept-session", err)
+			}
+
+			return
+		}
+
+		session, found, err := s.containerRepository.FindInterceptSession(handle, name)
+		if err != nil {
+			logger.Error("failed-to-find-intercept-session", err)
+			return
+		}
+
+		if !found || session.ProcessID != process.ID() {
+			return
+		}
+
+		wait = session.ExpiresAt.Sub(s.clock.Now())
+		if wait > 0 {
+			continue
+		}
+
+		logger.Info("expired")
+
+		err = process.Signal(garden.SignalKill)
+		if err != nil {
+			logger.Error("failed-to-kill-process", err)
+		}
+
+		err = s.containerRepository.DeleteInterceptSession(handle, process.ID())
+		if err != nil {
+			logger.Error("failed-to-delete-intercept-session", err)
+		}
+
+		return
+	}
+}
+
 type stdoutWriter struct {
 	outputs chan<- atc.HijackOutput
 	done    chan struct{}
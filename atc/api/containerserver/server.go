@@ -19,6 +19,7 @@ type Server struct {
 	varSourcePool           creds.VarSourcePool
 	interceptTimeoutFactory InterceptTimeoutFactory
 	interceptUpdateInterval time.Duration
+	interceptSessionTTL     time.Duration
 	containerRepository     db.ContainerRepository
 	destroyer               gc.Destroyer
 	clock                   clock.Clock
@@ -31,6 +32,7 @@ func NewServer(
 	varSourcePool creds.VarSourcePool,
 	interceptTimeoutFactory InterceptTimeoutFactory,
 	interceptUpdateInterval time.Duration,
+	interceptSessionTTL time.Duration,
 	containerRepository db.ContainerRepository,
 	destroyer gc.Destroyer,
 	clock clock.Clock,
@@ -42,6 +44,7 @@ func NewServer(
 		varSourcePool:           varSourcePool,
 		interceptTimeoutFactory: interceptTimeoutFactory,
 		interceptUpdateInterval: interceptUpdateInterval,
+		interceptSessionTTL:     interceptSessionTTL,
 		containerRepository:     containerRepository,
 		destroyer:               destroyer,
 		clock:                   clock,
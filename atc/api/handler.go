@@ -20,6 +20,7 @@ import (
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/api/resourceserver"
 	"github.com/concourse/concourse/atc/api/resourceserver/versionserver"
+	"github.com/concourse/concourse/atc/api/searchserver"
 	"github.com/concourse/concourse/atc/api/teamserver"
 	"github.com/concourse/concourse/atc/api/usersserver"
 	"github.com/concourse/concourse/atc/api/volumeserver"
@@ -31,6 +32,7 @@ import (
 	"github.com/concourse/concourse/atc/mainredirect"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/concourse/concourse/logging"
 	"github.com/tedsuo/rata"
 )
 
@@ -55,12 +57,14 @@ func NewHandler(
 	dbCheckFactory db.CheckFactory,
 	dbResourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
+	dbApprovalFactory db.ApprovalFactory,
+	dbPublishedArtifactFactory db.PublishedArtifactFactory,
 
 	eventHandlerFactory buildserver.EventHandlerFactory,
 
 	workerPool worker.Pool,
 
-	sink *lager.ReconfigurableSink,
+	sink *logging.Sink,
 
 	isTLSEnabled bool,
 
@@ -72,8 +76,10 @@ func NewHandler(
 	credsManagers creds.Managers,
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
 	interceptUpdateInterval time.Duration,
+	interceptSessionTTL time.Duration,
 	dbWall db.Wall,
 	clock clock.Clock,
+	maxSessionLifetime time.Duration,
 ) (http.Handler, error) {
 
 	absCLIDownloadsDir, err := filepath.Abs(cliDownloadsDir)
@@ -85,28 +91,30 @@ func NewHandler(
 	buildHandlerFactory := buildserver.NewScopedHandlerFactory(logger)
 	teamHandlerFactory := NewTeamScopedHandlerFactory(logger, dbTeamFactory)
 
-	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory)
+	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, dbApprovalFactory, dbPublishedArtifactFactory, eventHandlerFactory)
 	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, dbCheckFactory)
 	resourceServer := resourceserver.NewServer(logger, secretManager, varSourcePool, dbCheckFactory, dbResourceFactory, dbResourceConfigFactory)
 
 	versionServer := versionserver.NewServer(logger, externalURL)
-	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL)
+	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, secretManager, varSourcePool, externalURL)
 	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager)
 	ccServer := ccserver.NewServer(logger, dbTeamFactory, externalURL)
 	workerServer := workerserver.NewServer(logger, workerTeamFactory, dbWorkerFactory)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
-	containerServer := containerserver.NewServer(logger, workerPool, secretManager, varSourcePool, interceptTimeoutFactory, interceptUpdateInterval, containerRepository, destroyer, clock)
+	containerServer := containerserver.NewServer(logger, workerPool, secretManager, varSourcePool, interceptTimeoutFactory, interceptUpdateInterval, interceptSessionTTL, containerRepository, destroyer, clock)
 	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
-	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
+	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL, maxSessionLifetime)
 	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers)
 	artifactServer := artifactserver.NewServer(logger, workerPool)
 	usersServer := usersserver.NewServer(logger, dbUserFactory)
 	wallServer := wallserver.NewServer(dbWall, logger)
+	searchServer := searchserver.NewServer(logger, dbPipelineFactory, dbJobFactory, dbResourceFactory, dbWorkerFactory)
 
 	handlers := map[string]http.Handler{
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:      http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:     http.HandlerFunc(configServer.SaveConfig),
+		atc.ValidateConfig: http.HandlerFunc(configServer.ValidateConfig),
 
 		atc.GetCC: http.HandlerFunc(ccServer.GetCC),
 
@@ -115,11 +123,16 @@ func NewHandler(
 		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.ApproveBuild:        buildHandlerFactory.HandlerFor(buildServer.ApproveBuild),
+		atc.RejectBuild:         buildHandlerFactory.HandlerFor(buildServer.RejectBuild),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
+		atc.GetBuildPlanStatus:  buildHandlerFactory.HandlerFor(buildServer.GetBuildPlanStatus),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
 
+		atc.ListBuildPublishedArtifacts: buildHandlerFactory.HandlerFor(buildServer.ListBuildPublishedArtifacts),
+
 		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
 		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
@@ -139,6 +152,9 @@ func NewHandler(
 
 		atc.ClearTaskCache: pipelineHandlerFactory.HandlerFor(jobServer.ClearTaskCache),
 
+		atc.PauseJobs:   pipelineHandlerFactory.HandlerFor(jobServer.PauseJobs),
+		atc.UnpauseJobs: pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJobs),
+
 		atc.ListAllPipelines:          http.HandlerFunc(pipelineServer.ListAllPipelines),
 		atc.ListPipelines:             http.HandlerFunc(pipelineServer.ListPipelines),
 		atc.GetPipeline:               pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipeline),
@@ -151,10 +167,12 @@ func NewHandler(
 		atc.ExposePipeline:            pipelineHandlerFactory.HandlerFor(pipelineServer.ExposePipeline),
 		atc.HidePipeline:              pipelineHandlerFactory.HandlerFor(pipelineServer.HidePipeline),
 		atc.GetVersionsDB:             pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
+		atc.ListPipelineVarSources:    pipelineHandlerFactory.HandlerFor(pipelineServer.ListVarSources),
 		atc.RenamePipeline:            teamHandlerFactory.HandlerFor(pipelineServer.RenamePipeline),
 		atc.ListPipelineBuilds:        pipelineHandlerFactory.HandlerFor(pipelineServer.ListPipelineBuilds),
 		atc.CreatePipelineBuild:       pipelineHandlerFactory.HandlerFor(pipelineServer.CreateBuild),
 		atc.PipelineBadge:             pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineBadge),
+		atc.PipelineGroupBadge:        pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineGroupBadge),
 
 		atc.ListAllResources:        http.HandlerFunc(resourceServer.ListAllResources),
 		atc.ListResources:           pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
@@ -175,13 +193,14 @@ func NewHandler(
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 		atc.GetResourceCausality:          pipelineHandlerFactory.HandlerFor(versionServer.GetCausality),
 
-		atc.ListWorkers:     http.HandlerFunc(workerServer.ListWorkers),
-		atc.RegisterWorker:  http.HandlerFunc(workerServer.RegisterWorker),
-		atc.LandWorker:      http.HandlerFunc(workerServer.LandWorker),
-		atc.RetireWorker:    http.HandlerFunc(workerServer.RetireWorker),
-		atc.PruneWorker:     http.HandlerFunc(workerServer.PruneWorker),
-		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
-		atc.DeleteWorker:    http.HandlerFunc(workerServer.DeleteWorker),
+		atc.ListWorkers:       http.HandlerFunc(workerServer.ListWorkers),
+		atc.RegisterWorker:    http.HandlerFunc(workerServer.RegisterWorker),
+		atc.LandWorker:        http.HandlerFunc(workerServer.LandWorker),
+		atc.RetireWorker:      http.HandlerFunc(workerServer.RetireWorker),
+		atc.PruneWorker:       http.HandlerFunc(workerServer.PruneWorker),
+		atc.HeartbeatWorker:   http.HandlerFunc(workerServer.HeartbeatWorker),
+		atc.ReportWorkerUsage: http.HandlerFunc(workerServer.ReportWorkerUsage),
+		atc.DeleteWorker:      http.HandlerFunc(workerServer.DeleteWorker),
 
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
@@ -210,12 +229,29 @@ func NewHandler(
 		atc.DestroyTeam:    teamHandlerFactory.HandlerFor(teamServer.DestroyTeam),
 		atc.ListTeamBuilds: teamHandlerFactory.HandlerFor(teamServer.ListTeamBuilds),
 
+		atc.ListServiceAccounts:  teamHandlerFactory.HandlerFor(teamServer.ListServiceAccounts),
+		atc.CreateServiceAccount: teamHandlerFactory.HandlerFor(teamServer.CreateServiceAccount),
+		atc.DeleteServiceAccount: teamHandlerFactory.HandlerFor(teamServer.DeleteServiceAccount),
+
+		atc.ListTeamPrunableState: teamHandlerFactory.HandlerFor(teamServer.ListPrunableState),
+		atc.PruneTeamState:        teamHandlerFactory.HandlerFor(teamServer.PruneState),
+
+		atc.ListTeamWebhooks:  teamHandlerFactory.HandlerFor(teamServer.ListWebhooks),
+		atc.SetTeamWebhook:    teamHandlerFactory.HandlerFor(teamServer.SetWebhook),
+		atc.DeleteTeamWebhook: teamHandlerFactory.HandlerFor(teamServer.DeleteWebhook),
+
+		atc.ListPipelineTemplates:  teamHandlerFactory.HandlerFor(teamServer.ListPipelineTemplates),
+		atc.SetPipelineTemplate:    teamHandlerFactory.HandlerFor(teamServer.SetPipelineTemplate),
+		atc.DeletePipelineTemplate: teamHandlerFactory.HandlerFor(teamServer.DeletePipelineTemplate),
+
 		atc.CreateArtifact: teamHandlerFactory.HandlerFor(artifactServer.CreateArtifact),
 		atc.GetArtifact:    teamHandlerFactory.HandlerFor(artifactServer.GetArtifact),
 
 		atc.GetWall:   http.HandlerFunc(wallServer.GetWall),
 		atc.SetWall:   http.HandlerFunc(wallServer.SetWall),
 		atc.ClearWall: http.HandlerFunc(wallServer.ClearWall),
+
+		atc.Search: http.HandlerFunc(searchServer.Search),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -106,7 +106,7 @@ var _ = Describe("Pipelines API", func() {
 				fakeAccess.IsAuthenticatedReturns(true)
 				fakeAccess.IsAdminReturns(true)
 
-				ssmAccess := ssm.NewSsm(lager.NewLogger("ssm_test"), &mockService, nil)
+				ssmAccess := ssm.NewSsm(lager.NewLogger("ssm_test"), &mockService, nil, nil)
 				ssmManager := &ssm.SsmManager{
 					AwsAccessKeyID:         "",
 					AwsSecretAccessKey:     "",
@@ -138,7 +138,8 @@ var _ = Describe("Pipelines API", func() {
 							"method": "GetParameter"
 						},
 						"pipeline_secret_template": "pipeline-secret-template",
-						"team_secret_template": "team-secret-template"
+						"team_secret_template": "team-secret-template",
+						"team_roles": null
           }
         }`))
 					})
@@ -163,7 +164,8 @@ var _ = Describe("Pipelines API", func() {
 							"method": "GetParameter"
 						},
 						"pipeline_secret_template": "pipeline-secret-template",
-						"team_secret_template": "team-secret-template"
+						"team_secret_template": "team-secret-template",
+						"team_roles": null
           }
         }`))
 					})
@@ -266,9 +268,11 @@ var _ = Describe("Pipelines API", func() {
             "lookup_templates": ["/{{.Team}}/{{.Pipeline}}/{{.Secret}}", "/{{.Team}}/{{.Secret}}"],
 			"shared_path": "",
 			"namespace": "testnamespace",
+			"team_namespace": "",
             "ca_cert": "",
             "server_name": "server-name",
 						"auth_backend": "backend-server",
+						"auth_mount_path": "",
 						"auth_max_ttl": 20,
 						"auth_retry_max": 5,
 						"auth_retry_initial": 2,
@@ -402,7 +406,7 @@ var _ = Describe("Pipelines API", func() {
 				fakeAccess.IsAuthenticatedReturns(true)
 				fakeAccess.IsAdminReturns(true)
 
-				secretsManagerAccess := secretsmanager.NewSecretsManager(lager.NewLogger("ssm_test"), &mockService, nil)
+				secretsManagerAccess := secretsmanager.NewSecretsManager(lager.NewLogger("ssm_test"), &mockService, nil, nil)
 
 				secretsManager := &secretsmanager.Manager{
 					AwsAccessKeyID:         "",
@@ -432,6 +436,7 @@ var _ = Describe("Pipelines API", func() {
 						"aws_region": "blah",
 						"pipeline_secret_template": "pipeline-secret-template",
 						"team_secret_template": "team-secret-template",
+						"team_roles": null,
 						"health": {
 							"error": "some error occurred",
 							"method": "GetSecretValue"
@@ -457,6 +462,7 @@ var _ = Describe("Pipelines API", func() {
 						"aws_region": "blah",
 						"pipeline_secret_template": "pipeline-secret-template",
 						"team_secret_template": "team-secret-template",
+						"team_roles": null,
 						"health": {
 							"response": {
 								"status": "UP"
@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
+	"github.com/concourse/concourse/vars"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -353,6 +354,9 @@ var _ = Describe("Jobs API", func() {
 					BeforeEach(func() {
 						fakeJob.IDReturns(1)
 						fakeJob.PausedReturns(true)
+						fakeJob.PausedByReturns("some-user")
+						fakeJob.PausedAtReturns(time.Unix(42, 0))
+						fakeJob.PauseReasonReturns("prod freeze")
 						fakeJob.FirstLoggedBuildIDReturns(99)
 						fakeJob.PipelineIDReturns(1)
 						fakeJob.PipelineNameReturns("some-pipeline")
@@ -447,6 +451,9 @@ var _ = Describe("Jobs API", func() {
 							"pipeline_name": "some-pipeline",
 							"team_name": "some-team",
 							"paused": true,
+							"paused_by": "some-user",
+							"paused_at": 42,
+							"pause_reason": "prod freeze",
 							"first_logged_build_id": 99,
 							"next_build": {
 								"id": 3,
@@ -493,11 +500,89 @@ var _ = Describe("Jobs API", func() {
 									"resource": "some-other-output"
 								}
 							],
-							"groups": ["group-1", "group-2"]
+							"groups": ["group-1", "group-2"],
+							"statistics": {
+								"builds": 0,
+								"success_rate": 0,
+								"duration_p50": 0,
+								"duration_p95": 0
+							}
 						}`))
 
 							})
 
+							It("fetches the job's statistics over the default window", func() {
+								Expect(fakeJob.StatisticsCallCount()).To(Equal(1))
+								Expect(fakeJob.StatisticsArgsForCall(0)).To(Equal(db.JobStatisticsWindow))
+							})
+
+							Context("when the job has statistics", func() {
+								BeforeEach(func() {
+									fakeJob.StatisticsReturns(db.JobStatistics{
+										Builds:        20,
+										SuccessRate:   0.75,
+										DurationP50:   90 * time.Second,
+										DurationP95:   5 * time.Minute,
+										LastSucceeded: time.Now().Add(-time.Hour),
+									}, nil)
+								})
+
+								It("returns them", func() {
+									var job atc.Job
+									err := json.NewDecoder(response.Body).Decode(&job)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(job.Statistics).ToNot(BeNil())
+									Expect(job.Statistics.Builds).To(Equal(20))
+									Expect(job.Statistics.SuccessRate).To(Equal(0.75))
+									Expect(job.Statistics.DurationP50).To(Equal(int64(90)))
+									Expect(job.Statistics.DurationP95).To(Equal(int64(300)))
+									Expect(job.Statistics.LastSucceeded).To(BeNumerically("~", time.Now().Add(-time.Hour).Unix(), 5))
+									Expect(job.Statistics.TimeSinceLastSuccess).To(BeNumerically("~", 3600, 5))
+								})
+							})
+
+							Context("when getting the job's statistics fails", func() {
+								BeforeEach(func() {
+									fakeJob.StatisticsReturns(db.JobStatistics{}, errors.New("oh no!"))
+								})
+
+								It("returns 500", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+							})
+
+							Context("when the job has a schedule", func() {
+								BeforeEach(func() {
+									fakeJob.ScheduleReturns(&atc.JobScheduleConfig{Cron: "0 * * * *"})
+									fakeJob.ScheduleAnchorReturns(time.Date(2021, 6, 22, 10, 30, 0, 0, time.UTC))
+								})
+
+								Context("when the job is not paused", func() {
+									BeforeEach(func() {
+										fakeJob.PausedReturns(false)
+									})
+
+									It("returns the next scheduled run", func() {
+										var job atc.Job
+										err := json.NewDecoder(response.Body).Decode(&job)
+										Expect(err).NotTo(HaveOccurred())
+
+										Expect(job.NextScheduledRun).To(Equal(time.Date(2021, 6, 22, 11, 0, 0, 0, time.UTC).Unix()))
+									})
+								})
+
+								Context("when the job is paused", func() {
+									It("does not return a next scheduled run", func() {
+										var job atc.Job
+										err := json.NewDecoder(response.Body).Decode(&job)
+										Expect(err).NotTo(HaveOccurred())
+
+										Expect(job.NextScheduledRun).To(BeZero())
+									})
+								})
+							})
+
 							Context("when there are no running or finished builds", func() {
 								BeforeEach(func() {
 									fakeJob.FinishedAndNextBuildReturns(nil, nil, nil)
@@ -1544,6 +1629,286 @@ var _ = Describe("Jobs API", func() {
 						})
 					})
 
+					Context("when the request has an idempotency key", func() {
+						BeforeEach(func() {
+							fakeJob.IDReturns(1)
+							request.Header.Set("Idempotency-Key", "some-key")
+						})
+
+						Context("when no build has been created with the key", func() {
+							BeforeEach(func() {
+								build := new(dbfakes.FakeBuild)
+								build.IDReturns(42)
+								build.NameReturns("1")
+								build.TeamNameReturns("some-team")
+								build.IdempotencyKeyReturns("some-key")
+
+								fakePipeline.BuildByIdempotencyKeyReturns(nil, false, nil)
+								fakeJob.CreateBuildWithIdempotencyKeyReturns(build, nil)
+							})
+
+							It("creates the build with the key", func() {
+								Expect(fakePipeline.BuildByIdempotencyKeyArgsForCall(0)).To(Equal("some-key"))
+								Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+								Expect(fakeJob.CreateBuildWithIdempotencyKeyCallCount()).To(Equal(1))
+								_, key := fakeJob.CreateBuildWithIdempotencyKeyArgsForCall(0)
+								Expect(key).To(Equal("some-key"))
+							})
+
+							It("returns the key on the build", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+									"id": 42,
+									"name": "1",
+									"team_name": "some-team",
+									"status": "",
+									"api_url": "/api/v1/builds/42",
+									"idempotency_key": "some-key"
+								}`))
+							})
+						})
+
+						Context("when a build of the job has already been created with the key", func() {
+							BeforeEach(func() {
+								build := new(dbfakes.FakeBuild)
+								build.IDReturns(41)
+								build.NameReturns("1")
+								build.JobIDReturns(1)
+								build.TeamNameReturns("some-team")
+								build.IdempotencyKeyReturns("some-key")
+
+								fakePipeline.BuildByIdempotencyKeyReturns(build, true, nil)
+							})
+
+							It("returns the existing build without triggering a new one", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+									"id": 41,
+									"name": "1",
+									"team_name": "some-team",
+									"status": "",
+									"api_url": "/api/v1/builds/41",
+									"idempotency_key": "some-key"
+								}`))
+
+								Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+								Expect(fakeJob.CreateBuildWithIdempotencyKeyCallCount()).To(Equal(0))
+							})
+						})
+
+						Context("when a build of another job has been created with the key", func() {
+							BeforeEach(func() {
+								build := new(dbfakes.FakeBuild)
+								build.JobIDReturns(2)
+
+								fakePipeline.BuildByIdempotencyKeyReturns(build, true, nil)
+							})
+
+							It("returns 409", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusConflict))
+								Expect(fakeJob.CreateBuildWithIdempotencyKeyCallCount()).To(Equal(0))
+							})
+						})
+
+						Context("when the key is used concurrently", func() {
+							var build *dbfakes.FakeBuild
+
+							BeforeEach(func() {
+								build = new(dbfakes.FakeBuild)
+								build.IDReturns(41)
+								build.JobIDReturns(1)
+
+								fakePipeline.BuildByIdempotencyKeyReturnsOnCall(0, nil, false, nil)
+								fakePipeline.BuildByIdempotencyKeyReturnsOnCall(1, build, true, nil)
+								fakeJob.CreateBuildWithIdempotencyKeyReturns(nil, db.ErrIdempotencyKeyConflict)
+							})
+
+							It("returns the build created by the other request", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+								var returned atc.Build
+								err := json.NewDecoder(response.Body).Decode(&returned)
+								Expect(err).ToNot(HaveOccurred())
+								Expect(returned.ID).To(Equal(41))
+							})
+
+							Context("when the other request was for another job", func() {
+								BeforeEach(func() {
+									build.JobIDReturns(2)
+								})
+
+								It("returns 409", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusConflict))
+								})
+							})
+						})
+					})
+
+					Context("when the request has explicit input versions", func() {
+						var fakeResource *dbfakes.FakeResource
+
+						BeforeEach(func() {
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{"inputs":{"some-input":{"ref":"abc"}}}`))
+							Expect(err).NotTo(HaveOccurred())
+
+							fakeJob.InputsReturns([]atc.JobInput{
+								{
+									Name:     "some-input",
+									Resource: "some-resource",
+								},
+							}, nil)
+
+							fakeResource = new(dbfakes.FakeResource)
+							fakeResource.IDReturns(7)
+							fakePipeline.ResourceReturns(fakeResource, true, nil)
+
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(42)
+							fakeJob.CreateBuildWithExplicitInputsReturns(build, nil)
+
+							fakeJob.VersionPassedJobsReturns(true, nil)
+						})
+
+						Context("when the version exists", func() {
+							BeforeEach(func() {
+								fakeResource.VersionsReturns([]atc.ResourceVersion{
+									{
+										Version: atc.Version{"ref": "abc", "branch": "main"},
+										Enabled: true,
+									},
+								}, db.Pagination{}, true, nil)
+							})
+
+							It("looks up the latest matching version of the input's resource", func() {
+								Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("some-resource"))
+								page, filter := fakeResource.VersionsArgsForCall(0)
+								Expect(page).To(Equal(db.Page{Limit: 1}))
+								Expect(filter).To(Equal(atc.Version{"ref": "abc"}))
+							})
+
+							It("creates the build with the resolved versions", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+								Expect(fakeJob.CreateBuildWithExplicitInputsCallCount()).To(Equal(1))
+
+								_, key, inputs := fakeJob.CreateBuildWithExplicitInputsArgsForCall(0)
+								Expect(key).To(BeEmpty())
+								Expect(inputs).To(Equal([]db.ExplicitBuildInput{
+									{
+										Name:       "some-input",
+										ResourceID: 7,
+										Version:    atc.Version{"ref": "abc", "branch": "main"},
+									},
+								}))
+							})
+						})
+
+						Context("when the input has passed constraints", func() {
+							BeforeEach(func() {
+								fakeJob.InputsReturns([]atc.JobInput{
+									{
+										Name:     "some-input",
+										Resource: "some-resource",
+										Passed:   []string{"job-a", "job-b"},
+									},
+								}, nil)
+
+								fakeResource.VersionsReturns([]atc.ResourceVersion{
+									{Version: atc.Version{"ref": "abc"}, Enabled: true},
+								}, db.Pagination{}, true, nil)
+							})
+
+							It("checks the version against them", func() {
+								Expect(fakeJob.VersionPassedJobsCallCount()).To(Equal(1))
+								resourceID, version, jobNames := fakeJob.VersionPassedJobsArgsForCall(0)
+								Expect(resourceID).To(Equal(7))
+								Expect(version).To(Equal(atc.Version{"ref": "abc"}))
+								Expect(jobNames).To(Equal([]string{"job-a", "job-b"}))
+							})
+
+							Context("when the version has passed them", func() {
+								It("creates the build", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(fakeJob.CreateBuildWithExplicitInputsCallCount()).To(Equal(1))
+								})
+							})
+
+							Context("when the version has not passed them", func() {
+								BeforeEach(func() {
+									fakeJob.VersionPassedJobsReturns(false, nil)
+								})
+
+								It("returns 400 without triggering a build", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+									Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("has not passed job-a, job-b"))
+									Expect(fakeJob.CreateBuildWithExplicitInputsCallCount()).To(Equal(0))
+								})
+							})
+
+							Context("when checking the constraints fails", func() {
+								BeforeEach(func() {
+									fakeJob.VersionPassedJobsReturns(false, errors.New("nope"))
+								})
+
+								It("returns 500", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+									Expect(fakeJob.CreateBuildWithExplicitInputsCallCount()).To(Equal(0))
+								})
+							})
+						})
+
+						Context("when no version matches", func() {
+							BeforeEach(func() {
+								fakeResource.VersionsReturns(nil, db.Pagination{}, true, nil)
+							})
+
+							It("returns 400 without triggering a build", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("no version of resource 'some-resource' matches"))
+								Expect(fakeJob.CreateBuildWithExplicitInputsCallCount()).To(Equal(0))
+							})
+						})
+
+						Context("when the matching version is disabled", func() {
+							BeforeEach(func() {
+								fakeResource.VersionsReturns([]atc.ResourceVersion{
+									{Version: atc.Version{"ref": "abc"}, Enabled: false},
+								}, db.Pagination{}, true, nil)
+							})
+
+							It("returns 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								Expect(fakeJob.CreateBuildWithExplicitInputsCallCount()).To(Equal(0))
+							})
+						})
+
+						Context("when the job has no such input", func() {
+							BeforeEach(func() {
+								fakeJob.InputsReturns([]atc.JobInput{}, nil)
+							})
+
+							It("returns 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("job has no input named 'some-input'"))
+								Expect(fakeJob.CreateBuildWithExplicitInputsCallCount()).To(Equal(0))
+							})
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{`))
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+						})
+					})
+
 					Context("when triggering the build succeeds", func() {
 						BeforeEach(func() {
 							build := new(dbfakes.FakeBuild)
@@ -2051,18 +2416,16 @@ var _ = Describe("Jobs API", func() {
 	})
 
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
-		var request *http.Request
+		var requestBody string
 		var response *http.Response
 
 		BeforeEach(func() {
-			var err error
-
-			request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/some-build", nil)
-			Expect(err).NotTo(HaveOccurred())
+			requestBody = ""
 		})
 
 		JustBeforeEach(func() {
-			var err error
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/some-build", strings.NewReader(requestBody))
+			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
@@ -2156,7 +2519,7 @@ var _ = Describe("Jobs API", func() {
 						})
 						Context("when creating the rerun build fails", func() {
 							BeforeEach(func() {
-								fakeJob.RerunBuildReturns(nil, errors.New("nopers"))
+								fakeJob.RerunBuildWithVarsReturns(nil, errors.New("nopers"))
 							})
 
 							It("returns a 500", func() {
@@ -2176,13 +2539,66 @@ var _ = Describe("Jobs API", func() {
 								build.StartTimeReturns(time.Unix(1, 0))
 								build.EndTimeReturns(time.Unix(100, 0))
 
-								fakeJob.RerunBuildReturns(build, nil)
+								fakeJob.RerunBuildWithVarsReturns(build, nil)
 							})
 
 							It("returns 200 OK", func() {
 								Expect(response.StatusCode).To(Equal(http.StatusOK))
 							})
 
+							It("reruns the build without overriding any vars", func() {
+								Expect(fakeJob.RerunBuildWithVarsCallCount()).To(Equal(1))
+								buildToRerun, _, buildVars := fakeJob.RerunBuildWithVarsArgsForCall(0)
+								Expect(buildToRerun).To(Equal(fakeBuild))
+								Expect(buildVars).To(BeEmpty())
+							})
+
+							Context("when vars are given", func() {
+								BeforeEach(func() {
+									requestBody = `{"vars":{"target":"linux","matrix":{"arch":"arm64"}}}`
+									fakePipeline.TeamNameReturns("some-team")
+								})
+
+								Context("when the user is a member of the team", func() {
+									BeforeEach(func() {
+										fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"member"}})
+									})
+
+									It("reruns the build with the vars", func() {
+										Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+										Expect(fakeJob.RerunBuildWithVarsCallCount()).To(Equal(1))
+										_, _, buildVars := fakeJob.RerunBuildWithVarsArgsForCall(0)
+										Expect(buildVars).To(Equal(vars.StaticVariables{
+											"target": "linux",
+											"matrix": map[string]interface{}{"arch": "arm64"},
+										}))
+									})
+								})
+
+								Context("when the user is only a pipeline operator", func() {
+									BeforeEach(func() {
+										fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"pipeline-operator"}})
+									})
+
+									It("returns 403 without rerunning the build", func() {
+										Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+										Expect(fakeJob.RerunBuildWithVarsCallCount()).To(BeZero())
+									})
+								})
+							})
+
+							Context("when the request body is malformed", func() {
+								BeforeEach(func() {
+									requestBody = `{`
+								})
+
+								It("returns 400 without rerunning the build", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+									Expect(fakeJob.RerunBuildWithVarsCallCount()).To(BeZero())
+								})
+							})
+
 							It("returns Content-Type 'application/json'", func() {
 								expectedHeaderEntries := map[string]string{
 									"Content-Type": "application/json",
@@ -2215,11 +2631,16 @@ var _ = Describe("Jobs API", func() {
 
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", func() {
 		var response *http.Response
+		var requestBody string
+
+		BeforeEach(func() {
+			requestBody = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/job-name/pause", nil)
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/job-name/pause", strings.NewReader(requestBody))
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
@@ -2236,6 +2657,7 @@ var _ = Describe("Jobs API", func() {
 
 					fakePipeline.JobReturns(fakeJob, true, nil)
 					fakeJob.PauseReturns(nil)
+					fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
 				})
 
 				It("finds the job on the pipeline and pauses it", func() {
@@ -2243,10 +2665,35 @@ var _ = Describe("Jobs API", func() {
 					Expect(jobName).To(Equal("job-name"))
 
 					Expect(fakeJob.PauseCallCount()).To(Equal(1))
+					pausedBy, reason := fakeJob.PauseArgsForCall(0)
+					Expect(pausedBy).To(Equal("some-user"))
+					Expect(reason).To(BeEmpty())
 
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
 				})
 
+				Context("when a reason is given", func() {
+					BeforeEach(func() {
+						requestBody = `{"reason":"prod freeze until friday"}`
+					})
+
+					It("records the reason", func() {
+						_, reason := fakeJob.PauseArgsForCall(0)
+						Expect(reason).To(Equal("prod freeze until friday"))
+					})
+				})
+
+				Context("when the request body is malformed", func() {
+					BeforeEach(func() {
+						requestBody = `{`
+					})
+
+					It("returns 400 without pausing the job", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeJob.PauseCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when the job is not found", func() {
 					BeforeEach(func() {
 						fakePipeline.JobReturns(nil, false, nil)
@@ -2368,6 +2815,127 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/pause", func() {
+		var response *http.Response
+		var requestBody string
+
+		BeforeEach(func() {
+			requestBody = `{"jobs":["test-unit","test-integration"],"reason":"flaky"}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/pause", strings.NewReader(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+
+				fakePipeline.PauseJobsReturns([]string{"test-unit"}, nil)
+			})
+
+			It("pauses the jobs and returns the ones it found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`["test-unit"]`))
+
+				Expect(fakePipeline.PauseJobsCallCount()).To(Equal(1))
+				jobNames, pausedBy, reason := fakePipeline.PauseJobsArgsForCall(0)
+				Expect(jobNames).To(Equal([]string{"test-unit", "test-integration"}))
+				Expect(pausedBy).To(Equal("some-user"))
+				Expect(reason).To(Equal("flaky"))
+			})
+
+			Context("when the request is malformed", func() {
+				BeforeEach(func() {
+					requestBody = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakePipeline.PauseJobsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when pausing the jobs fails", func() {
+				BeforeEach(func() {
+					fakePipeline.PauseJobsReturns(nil, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Status Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/unpause", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/unpause", strings.NewReader(`{"jobs":["test-unit"]}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				fakePipeline.UnpauseJobsReturns([]string{"test-unit"}, nil)
+			})
+
+			It("unpauses the jobs and returns the ones it found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`["test-unit"]`))
+
+				Expect(fakePipeline.UnpauseJobsCallCount()).To(Equal(1))
+				Expect(fakePipeline.UnpauseJobsArgsForCall(0)).To(Equal([]string{"test-unit"}))
+			})
+
+			Context("when unpausing the jobs fails", func() {
+				BeforeEach(func() {
+					fakePipeline.UnpauseJobsReturns(nil, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakePipeline.UnpauseJobsCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/tasks/:step_name/cache", func() {
 		var (
 			request  *http.Request
@@ -18,11 +18,21 @@ var (
 	badgeErrored = Badge{Width: 88, FillColor: `#fe7d37`, Status: `errored`, Title: `build`}
 )
 
+// BadgeStyle changes how a badge is drawn, following the styles offered by
+// other badge services so that badges look alike side by side in a README.
+type BadgeStyle string
+
+const (
+	BadgeStyleFlat       BadgeStyle = "flat"
+	BadgeStyleFlatSquare BadgeStyle = "flat-square"
+)
+
 type Badge struct {
 	Width     int
 	FillColor string
 	Status    string
 	Title     string
+	Style     BadgeStyle
 }
 
 func (b *Badge) StatusWidth() int {
@@ -33,6 +43,22 @@ func (b *Badge) StatusTextWidth() string {
 	return fmt.Sprintf("%.1f", float64(b.Width)/2+17.5)
 }
 
+func (b *Badge) CornerRadius() int {
+	if b.Style == BadgeStyleFlatSquare {
+		return 0
+	}
+
+	return 3
+}
+
+func (b *Badge) GradientOpacity() string {
+	if b.Style == BadgeStyleFlatSquare {
+		return "0"
+	}
+
+	return ".1"
+}
+
 func (b *Badge) String() string {
 	tmpl, err := template.New("Badge").Parse(badgeTemplate)
 	if err != nil {
@@ -46,10 +72,22 @@ func (b *Badge) String() string {
 	return buffer.String()
 }
 
+// EnrichFromQuery customizes the badge using the query params of the
+// request. 'label' is accepted as an alias of 'title', matching other badge
+// services.
 func (b *Badge) EnrichFromQuery(params url.Values) {
 	if title := params.Get("title"); title != "" {
 		b.Title = title
 	}
+
+	if label := params.Get("label"); label != "" {
+		b.Title = label
+	}
+
+	switch style := BadgeStyle(params.Get("style")); style {
+	case BadgeStyleFlat, BadgeStyleFlatSquare:
+		b.Style = style
+	}
 }
 
 func BadgeForBuild(build db.Build) Badge {
@@ -72,11 +110,11 @@ func BadgeForBuild(build db.Build) Badge {
 const badgeTemplate = `<?xml version="1.0" encoding="UTF-8"?>
 <svg xmlns="http://www.w3.org/2000/svg" width="{{ .Width }}" height="20">
    <linearGradient id="b" x2="0" y2="100%">
-      <stop offset="0" stop-color="#bbb" stop-opacity=".1" />
-      <stop offset="1" stop-opacity=".1" />
+      <stop offset="0" stop-color="#bbb" stop-opacity="{{ .GradientOpacity }}" />
+      <stop offset="1" stop-opacity="{{ .GradientOpacity }}" />
    </linearGradient>
    <mask id="a">
-      <rect width="{{ .Width }}" height="20" rx="3" fill="#fff" />
+      <rect width="{{ .Width }}" height="20" rx="{{ .CornerRadius }}" fill="#fff" />
    </mask>
    <g mask="url(#a)">
       <path fill="#555" d="M0 0h37v20H0z" />
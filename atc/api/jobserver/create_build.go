@@ -3,10 +3,15 @@ package jobserver
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
@@ -36,9 +41,57 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		var reqBody atc.CreateJobBuildRequestBody
+		err = json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		explicitInputs, err := s.resolveExplicitInputs(pipeline, job, reqBody.Inputs)
+		if err != nil {
+			if inputErr, ok := err.(explicitInputError); ok {
+				logger.Info("invalid-explicit-input", lager.Data{"error": inputErr.Error()})
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, inputErr.Error())
+				return
+			}
+
+			logger.Error("failed-to-resolve-explicit-inputs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		idempotencyKey := r.Header.Get(atc.IdempotencyKeyHeader)
+		if replayBuild(logger, w, pipeline, job, idempotencyKey) {
+			return
+		}
+
 		acc := accessor.GetAccessor(r)
-		build, err := job.CreateBuild(acc.UserInfo().DisplayUserId)
+
+		var build db.Build
+		switch {
+		case len(explicitInputs) > 0:
+			build, err = job.CreateBuildWithExplicitInputs(acc.UserInfo().DisplayUserId, idempotencyKey, explicitInputs)
+		case idempotencyKey != "":
+			build, err = job.CreateBuildWithIdempotencyKey(acc.UserInfo().DisplayUserId, idempotencyKey)
+		default:
+			build, err = job.CreateBuild(acc.UserInfo().DisplayUserId)
+		}
 		if err != nil {
+			if err == db.ErrIdempotencyKeyConflict {
+				// a concurrent request with the same key created the build
+				// first, so respond with its build instead
+				if replayBuild(logger, w, pipeline, job, idempotencyKey) {
+					return
+				}
+
+				logger.Info("idempotency-key-already-used")
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
 			logger.Error("failed-to-create-job-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
@@ -89,3 +142,110 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 		}
 	})
 }
+
+type explicitInputError string
+
+func (err explicitInputError) Error() string {
+	return string(err)
+}
+
+// resolveExplicitInputs finds the latest version of each input's resource
+// matching the requested version, which may be partial.
+func (s *Server) resolveExplicitInputs(pipeline db.Pipeline, job db.Job, versions map[string]atc.Version) ([]db.ExplicitBuildInput, error) {
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	inputs, err := job.Inputs()
+	if err != nil {
+		return nil, err
+	}
+
+	jobInputs := map[string]atc.JobInput{}
+	for _, input := range inputs {
+		jobInputs[input.Name] = input
+	}
+
+	var explicitInputs []db.ExplicitBuildInput
+	for name, version := range versions {
+		input, found := jobInputs[name]
+		if !found {
+			return nil, explicitInputError(fmt.Sprintf("job has no input named '%s'", name))
+		}
+
+		resourceName := input.Resource
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, explicitInputError(fmt.Sprintf("resource '%s' not found", resourceName))
+		}
+
+		resourceVersions, _, found, err := resource.Versions(db.Page{Limit: 1}, version)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found || len(resourceVersions) == 0 {
+			return nil, explicitInputError(fmt.Sprintf("no version of resource '%s' matches the version given for input '%s'", resourceName, name))
+		}
+
+		if !resourceVersions[0].Enabled {
+			return nil, explicitInputError(fmt.Sprintf("version of resource '%s' given for input '%s' is disabled", resourceName, name))
+		}
+
+		passed, err := job.VersionPassedJobs(resource.ID(), resourceVersions[0].Version, input.Passed)
+		if err != nil {
+			return nil, err
+		}
+
+		if !passed {
+			return nil, explicitInputError(fmt.Sprintf("version of resource '%s' given for input '%s' has not passed %s", resourceName, name, strings.Join(input.Passed, ", ")))
+		}
+
+		explicitInputs = append(explicitInputs, db.ExplicitBuildInput{
+			Name:       name,
+			ResourceID: resource.ID(),
+			Version:    resourceVersions[0].Version,
+		})
+	}
+
+	return explicitInputs, nil
+}
+
+// replayBuild responds with the build created by an earlier request carrying
+// the same idempotency key, if there is one. It returns true if a response
+// has been written.
+func replayBuild(logger lager.Logger, w http.ResponseWriter, pipeline db.Pipeline, job db.Job, key string) bool {
+	if key == "" {
+		return false
+	}
+
+	build, found, err := pipeline.BuildByIdempotencyKey(key)
+	if err != nil {
+		logger.Error("failed-to-get-build-by-idempotency-key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return true
+	}
+
+	if !found {
+		return false
+	}
+
+	if build.JobID() != job.ID() {
+		logger.Info("idempotency-key-already-used", lager.Data{"build": build.ID()})
+		w.WriteHeader(http.StatusConflict)
+		return true
+	}
+
+	err = json.NewEncoder(w).Encode(present.Build(build))
+	if err != nil {
+		logger.Error("failed-to-encode-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	return true
+}
@@ -3,6 +3,7 @@ package jobserver
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
@@ -46,12 +47,16 @@ func (s *Server) GetJob(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		teamName := r.FormValue(":team_name")
+		stats, err := job.Statistics(db.JobStatisticsWindow)
+		if err != nil {
+			logger.Error("could-not-get-job-statistics", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
+		teamName := r.FormValue(":team_name")
 
-		err = json.NewEncoder(w).Encode(present.Job(
+		presentedJob := present.Job(
 			teamName,
 			job,
 			inputs,
@@ -59,7 +64,13 @@ func (s *Server) GetJob(pipeline db.Pipeline) http.Handler {
 			finished,
 			next,
 			nil,
-		))
+		)
+		presentedJob.Statistics = present.JobStatistics(stats, time.Now())
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(presentedJob)
 		if err != nil {
 			logger.Error("failed-to-encode-job", err)
 			w.WriteHeader(http.StatusInternalServerError)
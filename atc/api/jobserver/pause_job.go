@@ -1,8 +1,12 @@
 package jobserver
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/tedsuo/rata"
 )
@@ -12,6 +16,23 @@ func (s *Server) PauseJob(pipeline db.Pipeline) http.Handler {
 		logger := s.logger.Session("pause-job")
 		jobName := rata.Param(r, "job_name")
 
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			logger.Error("failed-to-read-body", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var req atc.PauseRequest
+		if len(data) > 0 {
+			err = json.Unmarshal(data, &req)
+			if err != nil {
+				logger.Error("malformed-request", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
 		job, found, err := pipeline.Job(jobName)
 		if err != nil {
 			logger.Error("failed-to-get-job", err)
@@ -24,8 +45,11 @@ func (s *Server) PauseJob(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		err = job.Pause()
+		acc := accessor.GetAccessor(r)
+
+		err = job.Pause(acc.UserInfo().DisplayUserId, req.Reason)
 		if err != nil {
+			logger.Error("failed-to-pause-job", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
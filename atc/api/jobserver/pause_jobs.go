@@ -0,0 +1,70 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) PauseJobs(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("pause-jobs")
+
+		var req atc.PauseJobsRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Error("malformed-request", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+
+		paused, err := pipeline.PauseJobs(req.Jobs, acc.UserInfo().DisplayUserId, req.Reason)
+		if err != nil {
+			logger.Error("failed-to-pause-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(paused)
+		if err != nil {
+			logger.Error("failed-to-encode-jobs", err)
+		}
+	})
+}
+
+func (s *Server) UnpauseJobs(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("unpause-jobs")
+
+		var req atc.PauseJobsRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Error("malformed-request", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		unpaused, err := pipeline.UnpauseJobs(req.Jobs)
+		if err != nil {
+			logger.Error("failed-to-unpause-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(unpaused)
+		if err != nil {
+			logger.Error("failed-to-encode-jobs", err)
+		}
+	})
+}
@@ -3,8 +3,11 @@ package jobserver
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
@@ -48,8 +51,23 @@ func (s *Server) RerunJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		var reqBody atc.RerunBuildRequestBody
+		err = json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		acc := accessor.GetAccessor(r)
-		build, err := job.RerunBuild(buildToRerun, acc.UserInfo().DisplayUserId)
+		if len(reqBody.Vars) > 0 && !canOverrideVars(acc, pipeline.TeamName()) {
+			// vars may shadow those from var sources and credential managers,
+			// so overriding them takes more than being able to rerun a build
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		build, err := job.RerunBuildWithVars(buildToRerun, acc.UserInfo().DisplayUserId, reqBody.Vars)
 		if err != nil {
 			logger.Error("failed-to-retrigger-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -63,3 +81,17 @@ func (s *Server) RerunJobBuild(pipeline db.Pipeline) http.Handler {
 		}
 	})
 }
+
+func canOverrideVars(acc accessor.Access, teamName string) bool {
+	if acc.IsAdmin() {
+		return true
+	}
+
+	for _, role := range acc.TeamRoles()[teamName] {
+		if role == accessor.OwnerRole || role == accessor.MemberRole {
+			return true
+		}
+	}
+
+	return false
+}
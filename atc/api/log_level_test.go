@@ -80,6 +80,55 @@ var _ = Describe("Log Level API", func() {
 					})
 				}
 
+				Context("when a component is given", func() {
+					BeforeEach(func() {
+						logLevelPayload = "debug"
+					})
+
+					JustBeforeEach(func() {
+						req, err := http.NewRequest("PUT", server.URL+"/api/v1/log-level?component=gc", bytes.NewBufferString("error"))
+						Expect(err).NotTo(HaveOccurred())
+
+						response, err = client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("sets only the component's level", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(sink.ComponentLevel("gc")).To(Equal(lager.ERROR))
+						Expect(sink.GetMinLevel()).To(Equal(lager.DEBUG))
+					})
+
+					It("returns the component's level", func() {
+						req, err := http.NewRequest("GET", server.URL+"/api/v1/log-level?component=gc", nil)
+						Expect(err).NotTo(HaveOccurred())
+
+						getResponse, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(getResponse.StatusCode).To(Equal(http.StatusOK))
+						Expect(ioutil.ReadAll(getResponse.Body)).To(Equal([]byte("error")))
+					})
+				})
+
+				Context("when the component is unknown", func() {
+					BeforeEach(func() {
+						logLevelPayload = "debug"
+					})
+
+					JustBeforeEach(func() {
+						req, err := http.NewRequest("PUT", server.URL+"/api/v1/log-level?component=bogus", bytes.NewBufferString(logLevelPayload))
+						Expect(err).NotTo(HaveOccurred())
+
+						response, err = client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("returns Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
 				Context("when the level is bogus", func() {
 					BeforeEach(func() {
 						logLevelPayload = "bogus"
@@ -11,9 +11,21 @@ import (
 func (s *Server) GetMinLevel(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("get-min-level")
 
+	minLevel := s.sink.GetMinLevel()
+
+	component := r.URL.Query().Get("component")
+	if component != "" {
+		var err error
+		minLevel, err = s.sink.ComponentLevel(component)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
 	var level atc.LogLevel
 
-	switch s.sink.GetMinLevel() {
+	switch minLevel {
 	case lager.DEBUG:
 		level = atc.LogLevelDebug
 	case lager.INFO:
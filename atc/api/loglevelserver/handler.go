@@ -1,14 +1,17 @@
 package loglevelserver
 
-import "code.cloudfoundry.org/lager"
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/logging"
+)
 
 type Server struct {
 	logger lager.Logger
 
-	sink *lager.ReconfigurableSink
+	sink *logging.Sink
 }
 
-func NewServer(logger lager.Logger, sink *lager.ReconfigurableSink) *Server {
+func NewServer(logger lager.Logger, sink *logging.Sink) *Server {
 	return &Server{
 		logger: logger,
 
@@ -31,5 +31,15 @@ func (s *Server) SetMinLevel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.sink.SetMinLevel(level)
+	component := r.URL.Query().Get("component")
+	if component == "" {
+		s.sink.SetMinLevel(level)
+		return
+	}
+
+	err = s.sink.SetComponentLevel(component, level)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 }
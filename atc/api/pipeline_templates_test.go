@@ -0,0 +1,236 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline Templates API", func() {
+	var (
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		dbTeam.NameReturns("some-team")
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipeline-templates", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipeline-templates")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the team has templates", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineTemplatesReturns([]atc.PipelineTemplate{
+						{Name: "common", Config: "jobs: []\n"},
+					}, nil)
+				})
+
+				It("returns 200 with the templates", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{"name": "common", "config": "jobs: []\n"}
+					]`))
+				})
+			})
+
+			Context("when the team has no templates", func() {
+				It("returns an empty list", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[]`))
+				})
+			})
+
+			Context("when fetching the templates fails", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineTemplatesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipeline-templates/:template_name", func() {
+		var requestBody []byte
+
+		BeforeEach(func() {
+			requestBody = []byte(`{"config": "jobs:\n- name: lint\n  plan: []\n"}`)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/pipeline-templates/common", bytes.NewBuffer(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the template is new", func() {
+				BeforeEach(func() {
+					dbTeam.SetPipelineTemplateReturns(true, nil)
+				})
+
+				It("returns 201", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+
+				It("saves the template under the name in the url", func() {
+					Expect(dbTeam.SetPipelineTemplateCallCount()).To(Equal(1))
+					Expect(dbTeam.SetPipelineTemplateArgsForCall(0)).To(Equal(atc.PipelineTemplate{
+						Name:   "common",
+						Config: "jobs:\n- name: lint\n  plan: []\n",
+					}))
+				})
+			})
+
+			Context("when the template already exists", func() {
+				BeforeEach(func() {
+					dbTeam.SetPipelineTemplateReturns(false, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the config is malformed", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{"config": "jobs: {"}`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("malformed config"))
+					Expect(dbTeam.SetPipelineTemplateCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the template includes another", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{"config": "include:\n- template: other\n"}`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("pipeline templates cannot include others"))
+					Expect(dbTeam.SetPipelineTemplateCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the request is malformed", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when saving the template fails", func() {
+				BeforeEach(func() {
+					dbTeam.SetPipelineTemplateReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbTeam.SetPipelineTemplateCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/pipeline-templates/:template_name", func() {
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/pipeline-templates/common", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the template exists", func() {
+				BeforeEach(func() {
+					dbTeam.DeletePipelineTemplateReturns(true, nil)
+				})
+
+				It("deletes it and returns 204", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(dbTeam.DeletePipelineTemplateCallCount()).To(Equal(1))
+					Expect(dbTeam.DeletePipelineTemplateArgsForCall(0)).To(Equal("common"))
+				})
+			})
+
+			Context("when the template does not exist", func() {
+				BeforeEach(func() {
+					dbTeam.DeletePipelineTemplateReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+})
@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
+	"github.com/concourse/concourse/vars"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -798,6 +799,135 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/groups/:group_name/badge", func() {
+		var response *http.Response
+		var query string
+		var deployJob, testJob *dbfakes.FakeJob
+
+		BeforeEach(func() {
+			query = ""
+
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			dbPipeline.NameReturns("some-pipeline")
+			dbPipeline.GroupsReturns(atc.GroupConfigs{
+				{Name: "deploy", Jobs: []string{"deploy"}},
+				{Name: "test", Jobs: []string{"test"}},
+			})
+			fakeTeam.PipelineReturns(dbPipeline, true, nil)
+
+			succeededBuild := new(dbfakes.FakeBuild)
+			succeededBuild.StatusReturns(db.BuildStatusSucceeded)
+			deployJob = new(dbfakes.FakeJob)
+			deployJob.TagsReturns([]string{"deploy"})
+			deployJob.FinishedAndNextBuildReturns(succeededBuild, nil, nil)
+
+			failedBuild := new(dbfakes.FakeBuild)
+			failedBuild.StatusReturns(db.BuildStatusFailed)
+			testJob = new(dbfakes.FakeJob)
+			testJob.TagsReturns([]string{"test"})
+			testJob.FinishedAndNextBuildReturns(failedBuild, nil, nil)
+
+			dbPipeline.JobsReturns([]db.Job{deployJob, testJob}, nil)
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/groups/deploy/badge" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 200 OK as an svg", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response).Should(IncludeHeaderEntries(map[string]string{
+				"Content-Type": "image/svg+xml",
+			}))
+		})
+
+		It("only reflects the jobs in the group", func() {
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(body)).To(ContainSubstring(">passing</text>"))
+			Expect(testJob.FinishedAndNextBuildCallCount()).To(BeZero())
+		})
+
+		Context("when a label and style are given", func() {
+			BeforeEach(func() {
+				query = "?label=deploy&style=flat-square"
+			})
+
+			It("customizes the badge", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(string(body)).To(ContainSubstring(`<text x="18.5" y="14">deploy</text>`))
+				Expect(string(body)).To(ContainSubstring(`rx="0"`))
+				Expect(string(body)).To(ContainSubstring(`stop-opacity="0"`))
+			})
+		})
+
+		Context("when badging a pipeline instance", func() {
+			BeforeEach(func() {
+				query = "?vars.branch=%22main%22&label=main"
+			})
+
+			It("looks up the instance", func() {
+				Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{
+					Name:         "some-pipeline",
+					InstanceVars: atc.InstanceVars{"branch": "main"},
+				}))
+			})
+		})
+
+		Context("when the style is unknown", func() {
+			BeforeEach(func() {
+				query = "?style=3d"
+			})
+
+			It("falls back to the default style", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(string(body)).To(ContainSubstring(`rx="3"`))
+			})
+		})
+
+		Context("when the group does not exist", func() {
+			BeforeEach(func() {
+				dbPipeline.GroupsReturns(atc.GroupConfigs{{Name: "test", Jobs: []string{"test"}}})
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when getting the jobs fails", func() {
+			BeforeEach(func() {
+				dbPipeline.JobsReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when not authorized and the pipeline is private", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+				dbPipeline.PublicReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
 	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name", func() {
 		var response *http.Response
 
@@ -881,11 +1011,16 @@ var _ = Describe("Pipelines API", func() {
 
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/pause", func() {
 		var response *http.Response
+		var requestBody string
+
+		BeforeEach(func() {
+			requestBody = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/pause", nil)
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/pause", bytes.NewBufferString(requestBody))
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
@@ -917,11 +1052,41 @@ var _ = Describe("Pipelines API", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
 						dbPipeline.PauseReturns(nil)
+						fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
 					})
 
 					It("returns 200", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusOK))
 					})
+
+					It("records who paused the pipeline", func() {
+						Expect(dbPipeline.PauseCallCount()).To(Equal(1))
+						pausedBy, reason := dbPipeline.PauseArgsForCall(0)
+						Expect(pausedBy).To(Equal("some-user"))
+						Expect(reason).To(BeEmpty())
+					})
+
+					Context("when a reason is given", func() {
+						BeforeEach(func() {
+							requestBody = `{"reason":"waiting on the database migration"}`
+						})
+
+						It("records the reason", func() {
+							_, reason := dbPipeline.PauseArgsForCall(0)
+							Expect(reason).To(Equal("waiting on the database migration"))
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							requestBody = `{`
+						})
+
+						It("returns 400 without pausing the pipeline", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(dbPipeline.PauseCallCount()).To(BeZero())
+						})
+					})
 				})
 
 				Context("when pausing the pipeline fails", func() {
@@ -1649,6 +1814,153 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/var-sources", func() {
+		var (
+			response *http.Response
+			query    string
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/var-sources"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.PipelineReturns(dbPipeline, true, nil)
+			})
+
+			Context("when getting the var source statuses works", func() {
+				BeforeEach(func() {
+					dbPipeline.VarSourceStatusesReturns([]atc.VarSourceStatus{
+						{
+							Name:         "some-vault",
+							Type:         "vault",
+							Reachable:    true,
+							LatencyMS:    12,
+							Lookups:      4,
+							CacheHits:    3,
+							CacheHitRate: 0.75,
+						},
+						{
+							Name:          "other-vault",
+							Type:          "vault",
+							Error:         "permission denied",
+							LastError:     "permission denied",
+							LastErrorTime: 1234,
+						},
+					}, nil)
+				})
+
+				It("gets the statuses with the global secrets and the var source pool", func() {
+					Expect(dbPipeline.VarSourceStatusesCallCount()).To(Equal(1))
+					_, secrets, pool, lookups := dbPipeline.VarSourceStatusesArgsForCall(0)
+					Expect(secrets).To(Equal(fakeSecretManager))
+					Expect(pool).To(Equal(fakeVarSourcePool))
+					Expect(lookups).To(BeEmpty())
+				})
+
+				Context("when vars are given", func() {
+					BeforeEach(func() {
+						query = "?var=some-vault:some-secret.some-field&var=other-vault:other-secret"
+					})
+
+					It("looks them up", func() {
+						Expect(dbPipeline.VarSourceStatusesCallCount()).To(Equal(1))
+						_, _, _, lookups := dbPipeline.VarSourceStatusesArgsForCall(0)
+						Expect(lookups).To(Equal([]vars.Reference{
+							{Source: "some-vault", Path: "some-secret", Fields: []string{"some-field"}},
+							{Source: "other-vault", Path: "other-secret", Fields: []string{}},
+						}))
+					})
+				})
+
+				Context("when a var is invalid", func() {
+					BeforeEach(func() {
+						query = "?var=some-vault:some-secret.."
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(dbPipeline.VarSourceStatusesCallCount()).To(Equal(0))
+					})
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns application/json", func() {
+					expectedHeaderEntries := map[string]string{
+						"Content-Type": "application/json",
+					}
+					Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+				})
+
+				It("returns a json representation of the statuses", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"name": "some-vault",
+							"type": "vault",
+							"reachable": true,
+							"latency_ms": 12,
+							"lookups": 4,
+							"cache_hits": 3,
+							"cache_hit_rate": 0.75
+						},
+						{
+							"name": "other-vault",
+							"type": "vault",
+							"reachable": false,
+							"error": "permission denied",
+							"latency_ms": 0,
+							"lookups": 0,
+							"cache_hits": 0,
+							"cache_hit_rate": 0,
+							"last_error": "permission denied",
+							"last_error_time": 1234
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the var source statuses fails", func() {
+				BeforeEach(func() {
+					dbPipeline.VarSourceStatusesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/rename", func() {
 		var response *http.Response
 		var requestBody string
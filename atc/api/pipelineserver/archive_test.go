@@ -33,6 +33,8 @@ var _ = Describe("Archive Handler", func() {
 			fakeLogger,
 			new(dbfakes.FakeTeamFactory),
 			new(dbfakes.FakePipelineFactory),
+			nil,
+			nil,
 			"",
 		)
 		dbPipeline = new(dbfakes.FakePipeline)
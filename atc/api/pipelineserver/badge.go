@@ -10,7 +10,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 )
 
-func badgeForPipeline(pipeline db.Pipeline, logger lager.Logger) (jobserver.Badge, error) {
+func badgeForJobs(jobs []db.Job, logger lager.Logger) (jobserver.Badge, error) {
 	var build db.Build
 
 	jobStatusPrecedence := map[db.BuildStatus]int{
@@ -20,12 +20,6 @@ func badgeForPipeline(pipeline db.Pipeline, logger lager.Logger) (jobserver.Badg
 		db.BuildStatusSucceeded: 4,
 	}
 
-	jobs, err := pipeline.Jobs()
-	if err != nil {
-		logger.Error("could-not-get-jobs", err)
-		return jobserver.Badge{}, err
-	}
-
 	for _, job := range jobs {
 		b, _, err := job.FinishedAndNextBuild()
 		if err != nil {
@@ -45,23 +39,74 @@ func badgeForPipeline(pipeline db.Pipeline, logger lager.Logger) (jobserver.Badg
 	return jobserver.BadgeForBuild(build), nil
 }
 
+func writeBadge(w http.ResponseWriter, r *http.Request, badge jobserver.Badge) {
+	w.Header().Set("Content-type", "image/svg+xml")
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Set("Expires", "0")
+
+	w.WriteHeader(http.StatusOK)
+
+	badge.EnrichFromQuery(r.URL.Query())
+	fmt.Fprint(w, &badge)
+}
+
 func (s *Server) PipelineBadge(pipeline db.Pipeline) http.Handler {
 	logger := s.logger.Session("pipeline-badge")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-type", "image/svg+xml")
+		jobs, err := pipeline.Jobs()
+		if err != nil {
+			logger.Error("could-not-get-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
 
-		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-		w.Header().Set("Expires", "0")
+		badge, err := badgeForJobs(jobs, logger)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
 
-		w.WriteHeader(http.StatusOK)
+		writeBadge(w, r, badge)
+	})
+}
+
+// PipelineGroupBadge reflects the status of only the jobs in the given group
+// of the pipeline.
+func (s *Server) PipelineGroupBadge(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("pipeline-group-badge")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groupName := r.FormValue(":group_name")
+
+		_, _, found := pipeline.Groups().Lookup(groupName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		jobs, err := pipeline.Jobs()
+		if err != nil {
+			logger.Error("could-not-get-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var groupJobs []db.Job
+		for _, job := range jobs {
+			for _, group := range job.Tags() {
+				if group == groupName {
+					groupJobs = append(groupJobs, job)
+					break
+				}
+			}
+		}
 
-		badge, err := badgeForPipeline(pipeline, logger)
-		badge.EnrichFromQuery(r.URL.Query())
+		badge, err := badgeForJobs(groupJobs, logger)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		fmt.Fprint(w, &badge)
+		writeBadge(w, r, badge)
 	})
 }
@@ -0,0 +1,43 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+)
+
+func (s *Server) ListVarSources(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-var-sources")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var lookups []vars.Reference
+		for _, name := range r.URL.Query()["var"] {
+			ref, err := vars.ParseReference(name)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "%s", err)
+				return
+			}
+
+			lookups = append(lookups, ref)
+		}
+
+		statuses, err := pipeline.VarSourceStatuses(logger, s.secretManager, s.varSourcePool, lookups)
+		if err != nil {
+			logger.Error("failed-to-get-var-source-statuses", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(statuses)
+		if err != nil {
+			logger.Error("failed-to-encode-var-source-statuses", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -1,15 +1,38 @@
 package pipelineserver
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/db"
 )
 
 func (s *Server) PausePipeline(pipelineDB db.Pipeline) http.Handler {
 	logger := s.logger.Session("pause-pipeline")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		err := pipelineDB.Pause()
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			logger.Error("failed-to-read-body", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var req atc.PauseRequest
+		if len(data) > 0 {
+			err = json.Unmarshal(data, &req)
+			if err != nil {
+				logger.Error("malformed-request", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		acc := accessor.GetAccessor(r)
+
+		err = pipelineDB.Pause(acc.UserInfo().DisplayUserId, req.Reason)
 		if err != nil {
 			logger.Error("failed-to-pause-pipeline", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -3,6 +3,7 @@ package pipelineserver
 import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/api/auth"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -11,6 +12,8 @@ type Server struct {
 	teamFactory     db.TeamFactory
 	rejector        auth.Rejector
 	pipelineFactory db.PipelineFactory
+	secretManager   creds.Secrets
+	varSourcePool   creds.VarSourcePool
 	externalURL     string
 }
 
@@ -18,6 +21,8 @@ func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	pipelineFactory db.PipelineFactory,
+	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 	externalURL string,
 ) *Server {
 	return &Server{
@@ -25,6 +30,8 @@ func NewServer(
 		teamFactory:     teamFactory,
 		rejector:        auth.UnauthorizedRejector{},
 		pipelineFactory: pipelineFactory,
+		secretManager:   secretManager,
+		varSourcePool:   varSourcePool,
 		externalURL:     externalURL,
 	}
 }
@@ -29,6 +29,8 @@ var _ = Describe("Unpause Handler", func() {
 			fakeLogger,
 			new(dbfakes.FakeTeamFactory),
 			new(dbfakes.FakePipelineFactory),
+			nil,
+			nil,
 			"",
 		)
 		dbPipeline = new(dbfakes.FakePipeline)
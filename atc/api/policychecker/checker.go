@@ -8,6 +8,7 @@ import (
 
 	"sigs.k8s.io/yaml"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/policy"
 )
@@ -74,5 +75,14 @@ func (c *checker) Check(action string, acc accessor.Access, req *http.Request) (
 		}
 	}
 
+	if input.Data != nil {
+		redacted, err := atc.RedactSecretFields(input.Data)
+		if err != nil {
+			return policy.FailedPolicyCheck(), err
+		}
+
+		input.Data = redacted
+	}
+
 	return c.policyChecker.Check(input)
 }
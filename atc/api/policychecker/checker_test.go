@@ -39,7 +39,7 @@ var _ = Describe("PolicyChecker", func() {
 	})
 
 	JustBeforeEach(func() {
-		policyCheck, err := policy.Initialize(testLogger, "some-cluster", "some-version", policyFilter)
+		policyCheck, err := policy.Initialize(testLogger, "some-cluster", "some-version", policyFilter, policy.Decisions{})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(policyCheck).ToNot(BeNil())
 		result, checkErr = policychecker.NewApiPolicyChecker(policyCheck).Check("some-action", fakeAccess, fakeRequest)
@@ -178,6 +178,24 @@ var _ = Describe("PolicyChecker", func() {
 					}))
 				})
 
+				Context("when the body holds credentials", func() {
+					BeforeEach(func() {
+						body := bytes.NewBuffer([]byte("source: {uri: some-repo.git, private_key: some-key}"))
+						fakeRequest = httptest.NewRequest("PUT", "/something?:team_name=some-team&:pipeline_name=some-pipeline", body)
+						fakeRequest.Header.Add("Content-type", "application/x-yaml")
+						fakeRequest.ParseForm()
+					})
+
+					It("redacts them from the input", func() {
+						Expect(fakePolicyAgent.CheckArgsForCall(0).Data).To(Equal(map[string]interface{}{
+							"source": map[string]interface{}{
+								"uri":         "some-repo.git",
+								"private_key": "<redacted>",
+							},
+						}))
+					})
+				})
+
 				It("request body should still be readable", func() {
 					body, err := ioutil.ReadAll(fakeRequest.Body)
 					Expect(err).ToNot(HaveOccurred())
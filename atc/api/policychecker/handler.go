@@ -1,11 +1,13 @@
 package policychecker
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/policy"
 )
@@ -50,5 +52,23 @@ func (h policyCheckingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if len(result.Warnings) > 0 {
+		h.logger.Info("policy-check-warned", lager.Data{
+			"action":   h.action,
+			"warnings": result.Warnings,
+		})
+
+		r = r.WithContext(context.WithValue(r.Context(), warningsContextKey, result.Warnings))
+	}
+
 	h.handler.ServeHTTP(w, r)
 }
+
+const warningsContextKey atc.ContextKey = "policy-warnings"
+
+// Warnings returns the warnings raised by the policy check of the request, so
+// that handlers can pass them on to the user.
+func Warnings(r *http.Request) []string {
+	warnings, _ := r.Context().Value(warningsContextKey).([]string)
+	return warnings
+}
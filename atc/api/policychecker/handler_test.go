@@ -61,6 +61,28 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Context("policy check passes with warnings", func() {
+		var warnings []string
+
+		BeforeEach(func() {
+			fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
+				Allowed:  true,
+				Warnings: []string{"jobs will soon need timeouts"},
+			}, nil)
+
+			dummyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				innerHandlerCalled = true
+				warnings = policychecker.Warnings(r)
+			})
+			policyCheckerHandler = policychecker.NewHandler(logger, dummyHandler, "some-action", fakePolicyChecker)
+		})
+
+		It("passes the warnings on to the inner handler", func() {
+			Expect(innerHandlerCalled).To(BeTrue())
+			Expect(warnings).To(Equal([]string{"jobs will soon need timeouts"}))
+		})
+	})
+
 	Context("policy check doesn't pass", func() {
 		BeforeEach(func() {
 			fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
@@ -37,6 +37,16 @@ func Container(container db.Container, expiresAt time.Time) atc.Container {
 		User:             meta.User,
 	}
 
+	if !container.CreatedAt().IsZero() {
+		atcContainer.CreatedAt = container.CreatedAt().Unix()
+	}
+
+	// only created containers are running on a worker which reports their
+	// size
+	if created, ok := container.(db.CreatedContainer); ok {
+		atcContainer.SizeBytes = created.SizeBytes()
+	}
+
 	if !expiresAt.IsZero() {
 		atcContainer.ExpiresIn = time.Until(expiresAt).Round(time.Second).String()
 	}
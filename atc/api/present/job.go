@@ -1,6 +1,8 @@
 package present
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -49,6 +51,19 @@ func Job(
 		})
 	}
 
+	var pausedAt int64
+	if !job.PausedAt().IsZero() {
+		pausedAt = job.PausedAt().Unix()
+	}
+
+	var nextScheduledRun int64
+	if job.Schedule() != nil && !job.Paused() {
+		next, err := job.Schedule().Next(job.ScheduleAnchor())
+		if err == nil {
+			nextScheduledRun = next.Unix()
+		}
+	}
+
 	return atc.Job{
 		ID: job.ID(),
 
@@ -59,7 +74,11 @@ func Job(
 		TeamName:             teamName,
 		DisableManualTrigger: job.DisableManualTrigger(),
 		Paused:               job.Paused(),
+		PausedBy:             job.PausedBy(),
+		PausedAt:             pausedAt,
+		PauseReason:          job.PauseReason(),
 		FirstLoggedBuildID:   job.FirstLoggedBuildID(),
+		NextScheduledRun:     nextScheduledRun,
 		FinishedBuild:        presentedFinishedBuild,
 		NextBuild:            presentedNextBuild,
 		TransitionBuild:      presentedTransitionBuild,
@@ -71,3 +90,19 @@ func Job(
 		Groups: job.Tags(),
 	}
 }
+
+func JobStatistics(stats db.JobStatistics, now time.Time) *atc.JobStatistics {
+	presented := &atc.JobStatistics{
+		Builds:      stats.Builds,
+		SuccessRate: stats.SuccessRate,
+		DurationP50: int64(stats.DurationP50.Seconds()),
+		DurationP95: int64(stats.DurationP95.Seconds()),
+	}
+
+	if !stats.LastSucceeded.IsZero() {
+		presented.LastSucceeded = stats.LastSucceeded.Unix()
+		presented.TimeSinceLastSuccess = int64(now.Sub(stats.LastSucceeded).Seconds())
+	}
+
+	return presented
+}
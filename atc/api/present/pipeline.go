@@ -6,12 +6,20 @@ import (
 )
 
 func Pipeline(savedPipeline db.Pipeline) atc.Pipeline {
+	var pausedAt int64
+	if !savedPipeline.PausedAt().IsZero() {
+		pausedAt = savedPipeline.PausedAt().Unix()
+	}
+
 	return atc.Pipeline{
 		ID:            savedPipeline.ID(),
 		Name:          savedPipeline.Name(),
 		InstanceVars:  savedPipeline.InstanceVars(),
 		TeamName:      savedPipeline.TeamName(),
 		Paused:        savedPipeline.Paused(),
+		PausedBy:      savedPipeline.PausedBy(),
+		PausedAt:      pausedAt,
+		PauseReason:   savedPipeline.PauseReason(),
 		Public:        savedPipeline.Public(),
 		Archived:      savedPipeline.Archived(),
 		Groups:        savedPipeline.Groups(),
@@ -0,0 +1,19 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func PublishedArtifacts(artifacts []db.PublishedArtifact) []atc.PublishedArtifact {
+	presented := []atc.PublishedArtifact{}
+	for _, a := range artifacts {
+		presented = append(presented, atc.PublishedArtifact{
+			Name:        a.Name,
+			Location:    a.Location,
+			Digest:      a.Digest,
+			PublishedAt: a.PublishedAt.Unix(),
+		})
+	}
+	return presented
+}
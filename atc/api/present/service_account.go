@@ -0,0 +1,18 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func ServiceAccount(account db.ServiceAccount) atc.ServiceAccount {
+	return atc.ServiceAccount{
+		ID:        account.ID(),
+		Name:      account.Name(),
+		TeamName:  account.TeamName(),
+		Role:      account.Role(),
+		CreatedBy: account.CreatedBy(),
+		CreatedAt: account.CreatedAt().Unix(),
+		ExpiresAt: account.ExpiresAt().Unix(),
+	}
+}
@@ -7,8 +7,12 @@ import (
 
 func Team(team db.Team) atc.Team {
 	return atc.Team{
-		ID:   team.ID(),
-		Name: team.Name(),
-		Auth: team.Auth(),
+		ID:           team.ID(),
+		Name:         team.Name(),
+		Auth:         team.Auth(),
+		PipelineAuth: team.PipelineAuth(),
+
+		AccessTokenLifetime: team.AccessTokenLifetime(),
+		SessionIdleTimeout:  team.SessionIdleTimeout(),
 	}
 }
@@ -21,7 +21,7 @@ func Volume(volume db.CreatedVolume) (atc.Volume, error) {
 		return atc.Volume{}, err
 	}
 
-	return atc.Volume{
+	atcVolume := atc.Volume{
 		ID:                   volume.Handle(),
 		Type:                 string(volume.Type()),
 		WorkerName:           volume.WorkerName(),
@@ -35,7 +35,14 @@ func Volume(volume db.CreatedVolume) (atc.Volume, error) {
 		StepName:             stepName,
 		ResourceType:         toVolumeResourceType(resourceType),
 		BaseResourceType:     toVolumeBaseResourceType(baseResourceType),
-	}, nil
+		SizeBytes:            volume.SizeBytes(),
+	}
+
+	if !volume.CreatedAt().IsZero() {
+		atcVolume.CreatedAt = volume.CreatedAt().Unix()
+	}
+
+	return atcVolume, nil
 }
 
 func toVolumeResourceType(dbResourceType *db.VolumeResourceType) *atc.VolumeResourceType {
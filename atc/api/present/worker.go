@@ -32,8 +32,12 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		ActiveContainers: workerInfo.ActiveContainers(),
 		ActiveVolumes:    workerInfo.ActiveVolumes(),
 		ActiveTasks:      activeTasks,
+		CPUCapacity:      workerInfo.CPUCapacity(),
+		MemoryCapacity:   workerInfo.MemoryCapacity(),
 		ResourceTypes:    workerInfo.ResourceTypes(),
 		Platform:         workerInfo.Platform(),
+		Arch:             workerInfo.Arch(),
+		Runtime:          workerInfo.Runtime(),
 		Tags:             workerInfo.Tags(),
 		Name:             workerInfo.Name(),
 		Team:             workerInfo.TeamName(),
@@ -608,10 +608,12 @@ var _ = Describe("Resources API", func() {
 
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", func() {
 		var checkRequestBody atc.CheckRequestBody
+		var idempotencyKey string
 		var response *http.Response
 
 		BeforeEach(func() {
 			checkRequestBody = atc.CheckRequestBody{}
+			idempotencyKey = ""
 		})
 
 		JustBeforeEach(func() {
@@ -621,6 +623,9 @@ var _ = Describe("Resources API", func() {
 			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check", bytes.NewBuffer(reqPayload))
 			Expect(err).NotTo(HaveOccurred())
 			request.Header.Set("Content-Type", "application/json")
+			if idempotencyKey != "" {
+				request.Header.Set("Idempotency-Key", idempotencyKey)
+			}
 
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
@@ -704,6 +709,99 @@ var _ = Describe("Resources API", func() {
 						})
 					})
 
+					Context("when the request has an idempotency key", func() {
+						BeforeEach(func() {
+							idempotencyKey = "some-key"
+						})
+
+						Context("when no check has been created with the key", func() {
+							BeforeEach(func() {
+								fakePipeline.BuildByIdempotencyKeyReturns(nil, false, nil)
+							})
+
+							It("creates the check", func() {
+								Expect(fakePipeline.BuildByIdempotencyKeyArgsForCall(0)).To(Equal("some-key"))
+								Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+							})
+						})
+
+						Context("when a check of the resource has already been created with the key", func() {
+							BeforeEach(func() {
+								fakeBuild := new(dbfakes.FakeBuild)
+								fakeBuild.IDReturns(10)
+								fakeBuild.NameReturns("some-name")
+								fakeBuild.TeamNameReturns("some-team")
+								fakeBuild.ResourceIDReturns(1)
+								fakeBuild.IdempotencyKeyReturns("some-key")
+
+								fakePipeline.BuildByIdempotencyKeyReturns(fakeBuild, true, nil)
+							})
+
+							It("returns 200 with the existing check", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+									"id": 10,
+									"name": "some-name",
+									"team_name": "some-team",
+									"status": "",
+									"api_url": "/api/v1/builds/10",
+									"idempotency_key": "some-key"
+								}`))
+							})
+
+							It("does not create another check", func() {
+								Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+							})
+						})
+
+						Context("when the key has been used for another resource", func() {
+							BeforeEach(func() {
+								fakeBuild := new(dbfakes.FakeBuild)
+								fakeBuild.ResourceIDReturns(2)
+
+								fakePipeline.BuildByIdempotencyKeyReturns(fakeBuild, true, nil)
+							})
+
+							It("returns 409", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusConflict))
+								Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+							})
+						})
+
+						Context("when the key is used concurrently", func() {
+							var fakeBuild *dbfakes.FakeBuild
+
+							BeforeEach(func() {
+								fakeBuild = new(dbfakes.FakeBuild)
+								fakeBuild.IDReturns(10)
+								fakeBuild.ResourceIDReturns(1)
+
+								fakePipeline.BuildByIdempotencyKeyReturnsOnCall(0, nil, false, nil)
+								fakePipeline.BuildByIdempotencyKeyReturnsOnCall(1, fakeBuild, true, nil)
+								dbCheckFactory.TryCreateCheckReturns(nil, false, fmt.Errorf("create build: %w", db.ErrIdempotencyKeyConflict))
+							})
+
+							It("returns 200 with the check created by the other request", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+								var check atc.Build
+								err := json.NewDecoder(response.Body).Decode(&check)
+								Expect(err).ToNot(HaveOccurred())
+								Expect(check.ID).To(Equal(10))
+							})
+
+							Context("when the other request was for another resource", func() {
+								BeforeEach(func() {
+									fakeBuild.ResourceIDReturns(2)
+								})
+
+								It("returns 409", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusConflict))
+								})
+							})
+						})
+					})
+
 					Context("when checking fails", func() {
 						BeforeEach(func() {
 							dbCheckFactory.TryCreateCheckReturns(nil, false, errors.New("nope"))
@@ -3,6 +3,7 @@ package resourceserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -42,6 +43,14 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		createdFor := func(build db.Build) bool {
+			return build.ResourceID() == dbResource.ID()
+		}
+
+		if replayCheck(logger, w, r, dbPipeline, createdFor) {
+			return
+		}
+
 		dbResourceTypes, err := dbPipeline.ResourceTypes()
 		if err != nil {
 			logger.Error("failed-to-get-resource-types", err)
@@ -50,13 +59,28 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 		}
 
 		build, created, err := s.checkFactory.TryCreateCheck(
-			lagerctx.NewContext(context.Background(), logger),
+			db.ContextWithIdempotencyKey(
+				lagerctx.NewContext(context.Background(), logger),
+				r.Header.Get(atc.IdempotencyKeyHeader),
+			),
 			dbResource,
 			dbResourceTypes,
 			reqBody.From,
 			true,
 		)
 		if err != nil {
+			if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+				// a concurrent request with the same key created the check
+				// first, so respond with its check instead
+				if replayCheck(logger, w, r, dbPipeline, createdFor) {
+					return
+				}
+
+				logger.Info("idempotency-key-already-used")
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
 			logger.Error("failed-to-create-check", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(err.Error()))
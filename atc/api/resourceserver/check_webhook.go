@@ -3,10 +3,12 @@ package resourceserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -60,6 +62,14 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		createdFor := func(build db.Build) bool {
+			return build.ResourceID() == dbResource.ID()
+		}
+
+		if replayCheck(logger, w, r, dbPipeline, createdFor) {
+			return
+		}
+
 		dbResourceTypes, err := dbPipeline.ResourceTypes()
 		if err != nil {
 			logger.Error("failed-to-get-resource-types", err)
@@ -68,13 +78,28 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 		}
 
 		build, created, err := s.checkFactory.TryCreateCheck(
-			lagerctx.NewContext(context.Background(), logger),
+			db.ContextWithIdempotencyKey(
+				lagerctx.NewContext(context.Background(), logger),
+				r.Header.Get(atc.IdempotencyKeyHeader),
+			),
 			dbResource,
 			dbResourceTypes,
 			nil,
 			true,
 		)
 		if err != nil {
+			if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+				// a concurrent request with the same key created the check
+				// first, so respond with its check instead
+				if replayCheck(logger, w, r, dbPipeline, createdFor) {
+					return
+				}
+
+				logger.Info("idempotency-key-already-used")
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
 			logger.Error("failed-to-create-check", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(err.Error()))
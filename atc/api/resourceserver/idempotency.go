@@ -0,0 +1,47 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// replayCheck responds with the check build created by an earlier request
+// carrying the same idempotency key, if there is one. It returns true if a
+// response has been written.
+func replayCheck(logger lager.Logger, w http.ResponseWriter, r *http.Request, dbPipeline db.Pipeline, createdFor func(db.Build) bool) bool {
+	key := r.Header.Get(atc.IdempotencyKeyHeader)
+	if key == "" {
+		return false
+	}
+
+	build, found, err := dbPipeline.BuildByIdempotencyKey(key)
+	if err != nil {
+		logger.Error("failed-to-get-build-by-idempotency-key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return true
+	}
+
+	if !found {
+		return false
+	}
+
+	if !createdFor(build) {
+		logger.Info("idempotency-key-already-used", lager.Data{"build": build.ID()})
+		w.WriteHeader(http.StatusConflict)
+		return true
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(present.Build(build))
+	if err != nil {
+		logger.Error("failed-to-encode-check", err)
+	}
+
+	return true
+}
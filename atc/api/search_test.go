@@ -0,0 +1,179 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Search API", func() {
+	var (
+		query    string
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		query = ""
+
+		pipeline := new(dbfakes.FakePipeline)
+		pipeline.NameReturns("deploy")
+		pipeline.TeamNameReturns("main")
+		pipeline.GroupsReturns(atc.GroupConfigs{{Name: "prod"}})
+
+		otherPipeline := new(dbfakes.FakePipeline)
+		otherPipeline.NameReturns("website")
+		otherPipeline.TeamNameReturns("other")
+
+		dbPipelineFactory.VisiblePipelinesReturns([]db.Pipeline{pipeline, otherPipeline}, nil)
+		dbPipelineFactory.AllPipelinesReturns([]db.Pipeline{pipeline, otherPipeline}, nil)
+
+		dbJobFactory.VisibleJobsReturns([]atc.JobSummary{
+			{Name: "deploy-prod", TeamName: "main", PipelineName: "deploy", Groups: []string{"prod"}},
+			{Name: "unit", TeamName: "main", PipelineName: "deploy"},
+		}, nil)
+
+		resource := new(dbfakes.FakeResource)
+		resource.NameReturns("deploy-key")
+		resource.TeamNameReturns("main")
+		resource.PipelineNameReturns("deploy")
+		dbResourceFactory.VisibleResourcesReturns([]db.Resource{resource}, nil)
+
+		worker := new(dbfakes.FakeWorker)
+		worker.NameReturns("deployer-worker")
+		worker.TagsReturns([]string{"prod"})
+		dbWorkerFactory.VisibleWorkersReturns([]db.Worker{worker}, nil)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequest("GET", server.URL+"/api/v1/search", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		req.URL.RawQuery = "q=" + query
+
+		response, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when not authenticated", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(false)
+			query = "deploy"
+		})
+
+		It("searches the public pipelines, jobs and resources, but not workers", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(dbPipelineFactory.VisiblePipelinesCallCount()).To(Equal(1))
+			Expect(dbWorkerFactory.VisibleWorkersCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when authenticated", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.TeamNamesReturns([]string{"main"})
+		})
+
+		Context("when searching for a term", func() {
+			BeforeEach(func() {
+				query = "deploy"
+			})
+
+			It("returns matches ranked by how closely their name matches", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{"kind": "pipeline", "name": "deploy", "team_name": "main", "score": 101},
+					{"kind": "job", "name": "deploy-prod", "team_name": "main", "pipeline_name": "deploy", "score": 51},
+					{"kind": "resource", "name": "deploy-key", "team_name": "main", "pipeline_name": "deploy", "score": 51},
+					{"kind": "worker", "name": "deployer-worker", "score": 51},
+					{"kind": "job", "name": "unit", "team_name": "main", "pipeline_name": "deploy", "score": 2}
+				]`))
+			})
+
+			It("only searches the teams the caller can see", func() {
+				Expect(dbJobFactory.VisibleJobsCallCount()).To(Equal(1))
+				Expect(dbJobFactory.VisibleJobsArgsForCall(0)).To(Equal([]string{"main"}))
+			})
+		})
+
+		Context("when filtering by kind and label", func() {
+			BeforeEach(func() {
+				query = "kind:job+label:prod"
+			})
+
+			It("returns only matching results", func() {
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{"kind": "job", "name": "deploy-prod", "team_name": "main", "pipeline_name": "deploy", "score": 1}
+				]`))
+			})
+
+			It("does not look up other kinds", func() {
+				Expect(dbPipelineFactory.VisiblePipelinesCallCount()).To(Equal(0))
+				Expect(dbResourceFactory.VisibleResourcesCallCount()).To(Equal(0))
+				Expect(dbWorkerFactory.VisibleWorkersCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when filtering by team", func() {
+			BeforeEach(func() {
+				query = "team:other"
+			})
+
+			It("returns only results in the team", func() {
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{"kind": "pipeline", "name": "website", "team_name": "other", "score": 1}
+				]`))
+			})
+		})
+
+		Context("when the query has an unknown filter", func() {
+			BeforeEach(func() {
+				query = "colour:blue"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("unknown filter 'colour:'"))
+			})
+		})
+
+		Context("when the query has an unknown kind", func() {
+			BeforeEach(func() {
+				query = "kind:build"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("unknown kind 'build'"))
+			})
+		})
+
+		Context("when the caller is an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(true)
+				query = "kind:pipeline"
+			})
+
+			It("searches every team", func() {
+				Expect(dbPipelineFactory.AllPipelinesCallCount()).To(Equal(1))
+				Expect(dbPipelineFactory.VisiblePipelinesCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when looking up results fails", func() {
+			BeforeEach(func() {
+				dbJobFactory.VisibleJobsReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
@@ -0,0 +1,111 @@
+package searchserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// query is a parsed search. Free text terms must all appear in a result's
+// name, team or pipeline; filters narrow down results by exact value.
+type query struct {
+	terms  []string
+	kinds  []string
+	teams  []string
+	labels []string
+}
+
+func parseQuery(q string) (query, error) {
+	var parsed query
+
+	for _, field := range strings.Fields(q) {
+		key, value, isFilter := splitFilter(field)
+		if !isFilter {
+			parsed.terms = append(parsed.terms, strings.ToLower(field))
+			continue
+		}
+
+		switch key {
+		case "kind":
+			switch value {
+			case atc.SearchKindPipeline, atc.SearchKindJob, atc.SearchKindResource, atc.SearchKindWorker:
+			default:
+				return query{}, fmt.Errorf("unknown kind '%s'", value)
+			}
+
+			parsed.kinds = append(parsed.kinds, value)
+		case "team":
+			parsed.teams = append(parsed.teams, value)
+		case "label":
+			parsed.labels = append(parsed.labels, value)
+		default:
+			return query{}, fmt.Errorf("unknown filter '%s:'", key)
+		}
+	}
+
+	return parsed, nil
+}
+
+func splitFilter(field string) (string, string, bool) {
+	i := strings.Index(field, ":")
+	if i <= 0 || i == len(field)-1 {
+		return "", "", false
+	}
+
+	return field[:i], field[i+1:], true
+}
+
+func (q query) includesKind(kind string) bool {
+	return len(q.kinds) == 0 || contains(q.kinds, kind)
+}
+
+// match returns the score of a candidate, or false if it doesn't match.
+// Names that equal a term rank above names starting with it, which rank
+// above names only containing it.
+func (q query) match(kind, name, team, pipeline string, labels []string) (int, bool) {
+	if !q.includesKind(kind) {
+		return 0, false
+	}
+
+	if len(q.teams) > 0 && !contains(q.teams, team) {
+		return 0, false
+	}
+
+	for _, label := range q.labels {
+		if !contains(labels, label) {
+			return 0, false
+		}
+	}
+
+	lowerName := strings.ToLower(name)
+	context := strings.ToLower(team + "/" + pipeline)
+
+	score := 1
+	for _, term := range q.terms {
+		switch {
+		case lowerName == term:
+			score += 100
+		case strings.HasPrefix(lowerName, term):
+			score += 50
+		case strings.Contains(lowerName, term):
+			score += 10
+		case strings.Contains(context, term):
+			score += 1
+		default:
+			return 0, false
+		}
+	}
+
+	return score, true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,212 @@
+package searchserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+// Search finds pipelines, jobs, resources and workers across every team the
+// caller can see. Workers are only searched for authenticated callers.
+func (s *Server) Search(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("search")
+
+	q, err := parseQuery(r.FormValue("q"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid query: %s", err)
+		return
+	}
+
+	limit := defaultLimit
+	if limitParam := r.FormValue("limit"); limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "invalid limit '%s'", limitParam)
+			return
+		}
+
+		if limit > maxLimit {
+			limit = maxLimit
+		}
+	}
+
+	results, err := s.search(accessor.GetAccessor(r), q)
+	if err != nil {
+		logger.Error("failed-to-search", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.TeamName != b.TeamName {
+			return a.TeamName < b.TeamName
+		}
+		if a.PipelineName != b.PipelineName {
+			return a.PipelineName < b.PipelineName
+		}
+		return a.Name < b.Name
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(results)
+	if err != nil {
+		logger.Error("failed-to-encode-results", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) search(acc accessor.Access, q query) ([]atc.SearchResult, error) {
+	results := []atc.SearchResult{}
+
+	if q.includesKind(atc.SearchKindPipeline) {
+		var (
+			pipelines []db.Pipeline
+			err       error
+		)
+		if acc.IsAdmin() {
+			pipelines, err = s.pipelineFactory.AllPipelines()
+		} else {
+			pipelines, err = s.pipelineFactory.VisiblePipelines(acc.TeamNames())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pipeline := range pipelines {
+			var groups []string
+			for _, group := range pipeline.Groups() {
+				groups = append(groups, group.Name)
+			}
+
+			score, ok := q.match(atc.SearchKindPipeline, pipeline.Name(), pipeline.TeamName(), "", groups)
+			if !ok {
+				continue
+			}
+
+			results = append(results, atc.SearchResult{
+				Kind:                 atc.SearchKindPipeline,
+				Name:                 pipeline.Name(),
+				TeamName:             pipeline.TeamName(),
+				PipelineInstanceVars: pipeline.InstanceVars(),
+				Score:                score,
+			})
+		}
+	}
+
+	if q.includesKind(atc.SearchKindJob) {
+		var (
+			jobs []atc.JobSummary
+			err  error
+		)
+		if acc.IsAdmin() {
+			jobs, err = s.jobFactory.AllActiveJobs()
+		} else {
+			jobs, err = s.jobFactory.VisibleJobs(acc.TeamNames())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, job := range jobs {
+			score, ok := q.match(atc.SearchKindJob, job.Name, job.TeamName, job.PipelineName, job.Groups)
+			if !ok {
+				continue
+			}
+
+			results = append(results, atc.SearchResult{
+				Kind:                 atc.SearchKindJob,
+				Name:                 job.Name,
+				TeamName:             job.TeamName,
+				PipelineName:         job.PipelineName,
+				PipelineInstanceVars: job.PipelineInstanceVars,
+				Score:                score,
+			})
+		}
+	}
+
+	if q.includesKind(atc.SearchKindResource) {
+		var (
+			resources []db.Resource
+			err       error
+		)
+		if acc.IsAdmin() {
+			resources, err = s.resourceFactory.AllResources()
+		} else {
+			resources, err = s.resourceFactory.VisibleResources(acc.TeamNames())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range resources {
+			score, ok := q.match(atc.SearchKindResource, resource.Name(), resource.TeamName(), resource.PipelineName(), resource.Tags())
+			if !ok {
+				continue
+			}
+
+			results = append(results, atc.SearchResult{
+				Kind:                 atc.SearchKindResource,
+				Name:                 resource.Name(),
+				TeamName:             resource.TeamName(),
+				PipelineName:         resource.PipelineName(),
+				PipelineInstanceVars: resource.PipelineInstanceVars(),
+				Score:                score,
+			})
+		}
+	}
+
+	if q.includesKind(atc.SearchKindWorker) && acc.IsAuthenticated() {
+		var (
+			workers []db.Worker
+			err     error
+		)
+		if acc.IsAdmin() {
+			workers, err = s.workerFactory.Workers()
+		} else {
+			workers, err = s.workerFactory.VisibleWorkers(acc.TeamNames())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, worker := range workers {
+			score, ok := q.match(atc.SearchKindWorker, worker.Name(), worker.TeamName(), "", worker.Tags())
+			if !ok {
+				continue
+			}
+
+			results = append(results, atc.SearchResult{
+				Kind:     atc.SearchKindWorker,
+				Name:     worker.Name(),
+				TeamName: worker.TeamName(),
+				Score:    score,
+			})
+		}
+	}
+
+	return results, nil
+}
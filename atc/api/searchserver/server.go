@@ -0,0 +1,31 @@
+package searchserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	pipelineFactory db.PipelineFactory
+	jobFactory      db.JobFactory
+	resourceFactory db.ResourceFactory
+	workerFactory   db.WorkerFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	pipelineFactory db.PipelineFactory,
+	jobFactory db.JobFactory,
+	resourceFactory db.ResourceFactory,
+	workerFactory db.WorkerFactory,
+) *Server {
+	return &Server{
+		logger:          logger,
+		pipelineFactory: pipelineFactory,
+		jobFactory:      jobFactory,
+		resourceFactory: resourceFactory,
+		workerFactory:   workerFactory,
+	}
+}
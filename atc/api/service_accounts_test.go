@@ -0,0 +1,343 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Service Accounts API", func() {
+	var (
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		dbTeam.NameReturns("some-team")
+	})
+
+	Describe("GET /api/v1/teams/:team_name/service-accounts", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/service-accounts")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the team has service accounts", func() {
+				BeforeEach(func() {
+					account := new(dbfakes.FakeServiceAccount)
+					account.IDReturns(1)
+					account.NameReturns("deployer")
+					account.TeamNameReturns("some-team")
+					account.RoleReturns("pipeline-operator")
+					account.CreatedByReturns("local:admin")
+					account.CreatedAtReturns(time.Unix(100, 0))
+					account.ExpiresAtReturns(time.Unix(200, 0))
+
+					dbTeam.ServiceAccountsReturns([]db.ServiceAccount{account}, nil)
+				})
+
+				It("returns 200 with the service accounts", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{
+							"id": 1,
+							"name": "deployer",
+							"team_name": "some-team",
+							"role": "pipeline-operator",
+							"created_by": "local:admin",
+							"created_at": 100,
+							"expires_at": 200
+						}
+					]`))
+				})
+			})
+
+			Context("when the team has no service accounts", func() {
+				It("returns an empty list", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[]`))
+				})
+			})
+
+			Context("when fetching the service accounts fails", func() {
+				BeforeEach(func() {
+					dbTeam.ServiceAccountsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/service-accounts", func() {
+		var requestBody []byte
+
+		BeforeEach(func() {
+			var err error
+			requestBody, err = json.Marshal(atc.CreateServiceAccountRequest{
+				Name: "deployer",
+				Role: "pipeline-operator",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(
+				server.URL+"/api/v1/teams/some-team/service-accounts",
+				"application/json",
+				bytes.NewBuffer(requestBody),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				account := new(dbfakes.FakeServiceAccount)
+				account.NameReturns("deployer")
+				account.RoleReturns("pipeline-operator")
+				dbTeam.CreateServiceAccountReturns(account, nil)
+			})
+
+			It("returns 201 with the token", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				var created atc.ServiceAccountToken
+				err := json.NewDecoder(response.Body).Decode(&created)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(created.ServiceAccount.Name).To(Equal("deployer"))
+				Expect(created.Token).ToNot(BeEmpty())
+			})
+
+			It("stores the token bound to the team and role", func() {
+				Expect(dbTeam.CreateServiceAccountCallCount()).To(Equal(1))
+				name, role, _, token := dbTeam.CreateServiceAccountArgsForCall(0)
+				Expect(name).To(Equal("deployer"))
+				Expect(role).To(Equal("pipeline-operator"))
+				Expect(token.Token).ToNot(BeEmpty())
+				Expect(token.Claims.Subject).To(Equal("serviceaccount:some-team:deployer"))
+				Expect(token.Claims.Connector).To(Equal("serviceaccount"))
+				Expect(token.Claims.RawClaims["service_account"]).To(Equal(map[string]interface{}{
+					"team_id": 734,
+					"team":    "some-team",
+					"role":    "pipeline-operator",
+				}))
+				Expect(token.Claims.Expiry.Time()).To(BeTemporally("~", time.Now().Add(365*24*time.Hour), time.Minute))
+			})
+
+			Context("when the role is unknown", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{"name":"deployer","role":"superuser"}`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("unknown role 'superuser'"))
+					Expect(dbTeam.CreateServiceAccountCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the name is not a valid identifier", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{"name":"_deployer","role":"member"}`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.CreateServiceAccountCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a ttl is requested", func() {
+				BeforeEach(func() {
+					var err error
+					requestBody, err = json.Marshal(atc.CreateServiceAccountRequest{
+						Name: "deployer",
+						Role: "member",
+						TTL:  24 * time.Hour,
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("issues the token for that long", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+					_, _, _, token := dbTeam.CreateServiceAccountArgsForCall(0)
+					Expect(token.Claims.Expiry.Time()).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
+				})
+			})
+
+			Context("when the requested ttl exceeds the maximum", func() {
+				BeforeEach(func() {
+					var err error
+					requestBody, err = json.Marshal(atc.CreateServiceAccountRequest{
+						Name: "deployer",
+						Role: "member",
+						TTL:  366 * 24 * time.Hour,
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("ttl 8784h0m0s exceeds the maximum of 8760h0m0s"))
+					Expect(dbTeam.CreateServiceAccountCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the team limits the lifetime of access tokens", func() {
+				BeforeEach(func() {
+					dbTeam.AccessTokenLifetimeReturns(12 * time.Hour)
+				})
+
+				It("issues the token for the team's lifetime by default", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+					_, _, _, token := dbTeam.CreateServiceAccountArgsForCall(0)
+					Expect(token.Claims.Expiry.Time()).To(BeTemporally("~", time.Now().Add(12*time.Hour), time.Minute))
+				})
+
+				Context("when the requested ttl exceeds the team's lifetime", func() {
+					BeforeEach(func() {
+						var err error
+						requestBody, err = json.Marshal(atc.CreateServiceAccountRequest{
+							Name: "deployer",
+							Role: "member",
+							TTL:  24 * time.Hour,
+						})
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("ttl 24h0m0s exceeds the maximum of 12h0m0s"))
+						Expect(dbTeam.CreateServiceAccountCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when the service account already exists", func() {
+				BeforeEach(func() {
+					dbTeam.CreateServiceAccountReturns(nil, db.ErrServiceAccountExists)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when creating the service account fails", func() {
+				BeforeEach(func() {
+					dbTeam.CreateServiceAccountReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbTeam.CreateServiceAccountCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/service-accounts/:service_account_name", func() {
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/service-accounts/deployer", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the service account exists", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteServiceAccountReturns(true, nil)
+				})
+
+				It("deletes it and returns 204", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(dbTeam.DeleteServiceAccountCallCount()).To(Equal(1))
+					Expect(dbTeam.DeleteServiceAccountArgsForCall(0)).To(Equal("deployer"))
+				})
+			})
+
+			Context("when the service account does not exist", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteServiceAccountReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when deleting fails", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteServiceAccountReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
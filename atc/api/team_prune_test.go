@@ -0,0 +1,116 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team Prunable State API", func() {
+	var (
+		response *http.Response
+		state    atc.TeamPrunableState
+	)
+
+	BeforeEach(func() {
+		dbTeam.NameReturns("some-team")
+
+		state = atc.TeamPrunableState{
+			Containers: []atc.PrunableObject{
+				{Handle: "some-container", WorkerName: "some-worker", Reason: atc.PruneReasonOrphaned},
+				{Handle: "some-check-container", WorkerName: "some-worker", Reason: atc.PruneReasonStuckCheck},
+			},
+			Volumes: []atc.PrunableObject{
+				{Handle: "some-volume", WorkerName: "other-worker", Reason: atc.PruneReasonDangling},
+			},
+		}
+	})
+
+	expectedJSON := `{
+		"containers": [
+			{"handle": "some-container", "worker_name": "some-worker", "reason": "orphaned"},
+			{"handle": "some-check-container", "worker_name": "some-worker", "reason": "stuck-check"}
+		],
+		"volumes": [
+			{"handle": "some-volume", "worker_name": "other-worker", "reason": "dangling"}
+		]
+	}`
+
+	for _, method := range []string{"GET", "DELETE"} {
+		method := method
+
+		Describe(method+" /api/v1/teams/:team_name/prunable-state", func() {
+			JustBeforeEach(func() {
+				request, err := http.NewRequest(method, server.URL+"/api/v1/teams/some-team/prunable-state", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				response, err = client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when not authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated but not an admin", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+					fakeAccess.IsAdminReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authenticated as an admin", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAdminReturns(true)
+
+					dbTeam.PrunableStateReturns(state, nil)
+					dbTeam.PruneStateReturns(state, nil)
+				})
+
+				It("returns 200 with the team's prunable containers and volumes", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(expectedJSON))
+				})
+
+				if method == "GET" {
+					It("doesn't prune anything", func() {
+						Expect(dbTeam.PrunableStateCallCount()).To(Equal(1))
+						Expect(dbTeam.PruneStateCallCount()).To(Equal(0))
+					})
+				} else {
+					It("prunes the team's state", func() {
+						Expect(dbTeam.PruneStateCallCount()).To(Equal(1))
+					})
+				}
+
+				Context("when the state can't be found", func() {
+					BeforeEach(func() {
+						dbTeam.PrunableStateReturns(atc.TeamPrunableState{}, errors.New("nope"))
+						dbTeam.PruneStateReturns(atc.TeamPrunableState{}, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	}
+})
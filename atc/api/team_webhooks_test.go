@@ -0,0 +1,339 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team Webhooks API", func() {
+	var (
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		dbTeam.NameReturns("some-team")
+	})
+
+	Describe("GET /api/v1/teams/:team_name/webhooks", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/webhooks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the team has webhooks", func() {
+				BeforeEach(func() {
+					dbTeam.WebhooksReturns([]atc.TeamWebhook{
+						{
+							Name:   "chat",
+							URL:    "https://chat.example.com/hook",
+							Secret: "some-secret",
+							Filter: atc.TeamWebhookFilter{
+								Events:   []string{"build-finished"},
+								Statuses: []string{"failed", "errored"},
+							},
+						},
+					}, nil)
+				})
+
+				It("returns 200 with the webhooks, without their secrets", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{
+							"name": "chat",
+							"url": "https://chat.example.com/hook",
+							"filter": {
+								"events": ["build-finished"],
+								"statuses": ["failed", "errored"]
+							}
+						}
+					]`))
+				})
+			})
+
+			Context("when the team has no webhooks", func() {
+				It("returns an empty list", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[]`))
+				})
+			})
+
+			Context("when fetching the webhooks fails", func() {
+				BeforeEach(func() {
+					dbTeam.WebhooksReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/webhooks/:webhook_name", func() {
+		var requestBody []byte
+
+		BeforeEach(func() {
+			requestBody = []byte(`{
+				"url": "https://chat.example.com/hook",
+				"secret": "some-secret",
+				"filter": {"pipelines": ["some-pipeline"]}
+			}`)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/webhooks/chat", bytes.NewBuffer(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the webhook is new", func() {
+				BeforeEach(func() {
+					dbTeam.SetWebhookReturns(true, nil)
+				})
+
+				It("returns 201", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+
+				It("saves the webhook under the name in the url", func() {
+					Expect(dbTeam.SetWebhookCallCount()).To(Equal(1))
+					Expect(dbTeam.SetWebhookArgsForCall(0)).To(Equal(atc.TeamWebhook{
+						Name:   "chat",
+						URL:    "https://chat.example.com/hook",
+						Secret: "some-secret",
+						Filter: atc.TeamWebhookFilter{
+							Pipelines: []string{"some-pipeline"},
+						},
+					}))
+				})
+			})
+
+			Context("when the webhook already exists", func() {
+				BeforeEach(func() {
+					dbTeam.SetWebhookReturns(false, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the url is not http or https", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{"url": "ftp://chat.example.com/hook"}`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("invalid url"))
+					Expect(dbTeam.SetWebhookCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the webhook is for slack", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{
+						"type": "slack",
+						"url": "https://hooks.slack.com/services/some/hook",
+						"channel": "#ci",
+						"template": "{{.JobName}} {{.Status}}"
+					}`)
+				})
+
+				It("saves the channel and template", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbTeam.SetWebhookCallCount()).To(Equal(1))
+					Expect(dbTeam.SetWebhookArgsForCall(0)).To(Equal(atc.TeamWebhook{
+						Name:     "chat",
+						Type:     "slack",
+						URL:      "https://hooks.slack.com/services/some/hook",
+						Channel:  "#ci",
+						Template: "{{.JobName}} {{.Status}}",
+					}))
+				})
+			})
+
+			Context("when the type is unknown", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{"type": "irc", "url": "https://chat.example.com/hook"}`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("unknown webhook type 'irc'"))
+					Expect(dbTeam.SetWebhookCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the template does not parse", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{"type": "teams", "url": "https://chat.example.com/hook", "template": "{{.JobName"}`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("invalid template"))
+					Expect(dbTeam.SetWebhookCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a generic webhook has a template", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{"url": "https://chat.example.com/hook", "template": "{{.JobName}}"}`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.SetWebhookCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a teams webhook has a channel", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{"type": "teams", "url": "https://chat.example.com/hook", "channel": "#ci"}`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("channel is only supported by slack webhooks"))
+					Expect(dbTeam.SetWebhookCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when filtering on an unknown event", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{"url": "https://chat.example.com/hook", "filter": {"events": ["build-exploded"]}}`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("unknown event 'build-exploded'"))
+					Expect(dbTeam.SetWebhookCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the request is malformed", func() {
+				BeforeEach(func() {
+					requestBody = []byte(`{`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when saving the webhook fails", func() {
+				BeforeEach(func() {
+					dbTeam.SetWebhookReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbTeam.SetWebhookCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/webhooks/:webhook_name", func() {
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/webhooks/chat", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the webhook exists", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteWebhookReturns(true, nil)
+				})
+
+				It("deletes it and returns 204", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(dbTeam.DeleteWebhookCallCount()).To(Equal(1))
+					Expect(dbTeam.DeleteWebhookArgsForCall(0)).To(Equal("chat"))
+				})
+			})
+
+			Context("when the webhook does not exist", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteWebhookReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when deleting fails", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteWebhookReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
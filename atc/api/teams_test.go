@@ -255,17 +255,87 @@ var _ = Describe("Teams API", func() {
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 				})
 
-				It("updates provider auth", func() {
+				It("updates the team's auth", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
-					Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+					Expect(fakeTeam.UpdateAuthCallCount()).To(Equal(1))
 
-					updatedProviderAuth := fakeTeam.UpdateProviderAuthArgsForCall(0)
-					Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
+					updatedTeam := fakeTeam.UpdateAuthArgsForCall(0)
+					Expect(updatedTeam.Auth).To(Equal(atcTeam.Auth))
 				})
 
-				Context("when updating provider auth fails", func() {
+				Context("when the team limits its session lifetimes", func() {
 					BeforeEach(func() {
-						fakeTeam.UpdateProviderAuthReturns(errors.New("stop trying to make fetch happen"))
+						atcTeam.AccessTokenLifetime = time.Hour
+						atcTeam.SessionIdleTimeout = 15 * time.Minute
+					})
+
+					It("updates the session lifetimes along with the auth", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateAuthCallCount()).To(Equal(1))
+
+						updatedTeam := fakeTeam.UpdateAuthArgsForCall(0)
+						Expect(updatedTeam.AccessTokenLifetime).To(Equal(time.Hour))
+						Expect(updatedTeam.SessionIdleTimeout).To(Equal(15 * time.Minute))
+					})
+				})
+
+				Context("when the team grants roles on a pipeline", func() {
+					BeforeEach(func() {
+						atcTeam.PipelineAuth = atc.PipelineAuth{
+							{
+								Pipeline: atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "main"}},
+								Auth: atc.TeamAuth{
+									"viewer": {"users": {"local:someone"}},
+								},
+							},
+						}
+					})
+
+					It("updates the pipeline auth along with the team's auth", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateAuthCallCount()).To(Equal(1))
+
+						updatedTeam := fakeTeam.UpdateAuthArgsForCall(0)
+						Expect(updatedTeam.PipelineAuth).To(Equal(atcTeam.PipelineAuth))
+					})
+
+					Context("when the pipeline does not exist", func() {
+						BeforeEach(func() {
+							fakeTeam.UpdateAuthReturns(db.ErrPipelineNotFound{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "main"}})
+						})
+
+						It("returns 400 Bad Request with the reason", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+								"errors": ["pipeline 'some-pipeline/branch:main' not found"],
+								"team": {}
+							}`))
+						})
+
+						It("does not delete the teams in cache", func() {
+							Expect(dbTeamFactory.NotifyCacherCallCount()).To(Equal(0))
+						})
+					})
+				})
+
+				Context("when the team's session lifetimes exceed the cluster maximum", func() {
+					BeforeEach(func() {
+						atcTeam.AccessTokenLifetime = 48 * time.Hour
+					})
+
+					It("returns 400 Bad Request with the reason", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+							"errors": ["access token lifetime 48h0m0s exceeds the cluster maximum of 24h0m0s"],
+							"team": {}
+						}`))
+						Expect(fakeTeam.UpdateAuthCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when updating the team's auth fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateAuthReturns(errors.New("stop trying to make fetch happen"))
 					})
 
 					It("returns 500 Internal Server error", func() {
@@ -280,7 +350,7 @@ var _ = Describe("Teams API", func() {
 
 					It("does not update provider auth", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
-						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+						Expect(fakeTeam.UpdateAuthCallCount()).To(Equal(0))
 					})
 				})
 
@@ -299,7 +369,7 @@ var _ = Describe("Teams API", func() {
 
 					It("does not update provider auth", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
-						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+						Expect(fakeTeam.UpdateAuthCallCount()).To(Equal(0))
 					})
 				})
 			})
@@ -564,6 +634,51 @@ var _ = Describe("Teams API", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
 				})
 
+				It("refreshes the cached teams", func() {
+					Expect(dbTeamFactory.NotifyCacherCallCount()).To(Equal(1))
+				})
+
+				Context("when the team is the main team", func() {
+					BeforeEach(func() {
+						fakeTeam.AdminReturns(true)
+					})
+
+					It("returns 400 without renaming the team", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+							"errors": ["cannot rename the main team"]
+						}`))
+						Expect(fakeTeam.RenameCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when a team with the new name already exists", func() {
+					BeforeEach(func() {
+						fakeTeam.RenameReturns(db.ErrTeamNameTaken)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+							"errors": ["team 'some-new-name' already exists"]
+						}`))
+					})
+
+					It("does not refresh the cached teams", func() {
+						Expect(dbTeamFactory.NotifyCacherCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when renaming the team fails", func() {
+					BeforeEach(func() {
+						fakeTeam.RenameReturns(errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
 				Context("when the new name is an invalid identifier", func() {
 					Context("and is a string", func() {
 						BeforeEach(func() {
@@ -595,6 +710,11 @@ var _ = Describe("Teams API", func() {
 								]
 							}`))
 						})
+
+						It("returns 400 without renaming the team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(fakeTeam.RenameCallCount()).To(Equal(0))
+						})
 					})
 				})
 			})
@@ -0,0 +1,122 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListPipelineTemplates(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-pipeline-templates", lager.Data{"team": team.Name()})
+
+		templates, err := team.PipelineTemplates()
+		if err != nil {
+			logger.Error("failed-to-get-pipeline-templates", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if templates == nil {
+			templates = []atc.PipelineTemplate{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(templates)
+		if err != nil {
+			logger.Error("failed-to-encode-pipeline-templates", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) SetPipelineTemplate(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":template_name")
+
+		logger := s.logger.Session("set-pipeline-template", lager.Data{"team": team.Name(), "name": name})
+
+		var template atc.PipelineTemplate
+		err := json.NewDecoder(r.Body).Decode(&template)
+		if err != nil {
+			logger.Error("malformed-request", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		template.Name = name
+
+		if err := validatePipelineTemplate(template); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "%s", err.Error())
+			return
+		}
+
+		created, err := team.SetPipelineTemplate(template)
+		if err != nil {
+			logger.Error("failed-to-set-pipeline-template", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if created {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+}
+
+func (s *Server) DeletePipelineTemplate(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":template_name")
+
+		logger := s.logger.Session("delete-pipeline-template", lager.Data{"team": team.Name(), "name": name})
+
+		found, err := team.DeletePipelineTemplate(name)
+		if err != nil {
+			logger.Error("failed-to-delete-pipeline-template", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// validatePipelineTemplate only checks that the template parses. It can't be
+// validated as a pipeline on its own, as it may refer to things defined by
+// the pipelines which include it.
+func validatePipelineTemplate(template atc.PipelineTemplate) error {
+	warning, err := atc.ValidateIdentifier(template.Name, "pipeline template")
+	if err != nil {
+		return err
+	}
+
+	if warning != nil {
+		return errors.New(warning.Message)
+	}
+
+	var config atc.Config
+	err = atc.UnmarshalConfig([]byte(template.Config), &config)
+	if err != nil {
+		return fmt.Errorf("malformed config: %w", err)
+	}
+
+	if len(config.Include) > 0 {
+		return errors.New("pipeline templates cannot include others")
+	}
+
+	return nil
+}
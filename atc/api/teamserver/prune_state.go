@@ -0,0 +1,56 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListPrunableState(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-prunable-state", lager.Data{"team": team.Name()})
+
+		state, err := team.PrunableState()
+		if err != nil {
+			logger.Error("failed-to-find-prunable-state", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(state)
+		if err != nil {
+			logger.Error("failed-to-encode-prunable-state", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) PruneState(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("prune-state", lager.Data{"team": team.Name()})
+
+		state, err := team.PruneState()
+		if err != nil {
+			logger.Error("failed-to-prune-state", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("pruned", lager.Data{
+			"containers": len(state.Containers),
+			"volumes":    len(state.Volumes),
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(state)
+		if err != nil {
+			logger.Error("failed-to-encode-pruned-state", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -38,18 +39,50 @@ func (s *Server) RenameTeam(team db.Team) http.Handler {
 			warnings = append(warnings, *warning)
 		}
 
+		// the main team is looked up by name when the web node starts, so
+		// renaming it would result in a new main team being created
+		if team.Admin() {
+			errs = append(errs, "cannot rename the "+atc.DefaultTeamName+" team")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if len(errs) > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			s.writeSaveConfigResponse(logger, w, atc.SaveConfigResponse{Warnings: warnings, Errors: errs})
+			return
+		}
+
 		err = team.Rename(rename.NewName)
 		if err != nil {
+			if err == db.ErrTeamNameTaken {
+				w.WriteHeader(http.StatusConflict)
+				s.writeSaveConfigResponse(logger, w, atc.SaveConfigResponse{
+					Warnings: warnings,
+					Errors:   []string{"team '" + rename.NewName + "' already exists"},
+				})
+				return
+			}
+
 			logger.Error("failed-to-update-team-name", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		err = json.NewEncoder(w).Encode(atc.SaveConfigResponse{Warnings: warnings, Errors: errs})
+		// refresh the teams cached for authorization, otherwise tokens in
+		// flight would not be authorized for the new name until it expires
+		err = s.teamFactory.NotifyCacher()
 		if err != nil {
-			s.logger.Error("failed-to-encode-response", err)
-			w.WriteHeader(http.StatusInternalServerError)
+			logger.Error("failed-to-notify-cacher", err)
 		}
+
+		s.writeSaveConfigResponse(logger, w, atc.SaveConfigResponse{Warnings: warnings})
 	})
 }
+
+func (s *Server) writeSaveConfigResponse(logger lager.Logger, w http.ResponseWriter, response atc.SaveConfigResponse) {
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		logger.Error("failed-to-encode-response", err)
+	}
+}
@@ -1,6 +1,8 @@
 package teamserver
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -9,16 +11,22 @@ type Server struct {
 	logger      lager.Logger
 	teamFactory db.TeamFactory
 	externalURL string
+
+	// maxSessionLifetime bounds the token lifetimes that teams may configure,
+	// as they can only ever shorten the cluster-wide lifetime.
+	maxSessionLifetime time.Duration
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	externalURL string,
+	maxSessionLifetime time.Duration,
 ) *Server {
 	return &Server{
-		logger:      logger,
-		teamFactory: teamFactory,
-		externalURL: externalURL,
+		logger:             logger,
+		teamFactory:        teamFactory,
+		externalURL:        externalURL,
+		maxSessionLifetime: maxSessionLifetime,
 	}
 }
@@ -0,0 +1,229 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/skymarshal/token"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// MaxServiceAccountTTL bounds how long a service account's token is valid
+// for. Tokens are issued for this long unless a shorter TTL is requested.
+const MaxServiceAccountTTL = 365 * 24 * time.Hour
+
+const DefaultServiceAccountTTL = MaxServiceAccountTTL
+
+func (s *Server) ListServiceAccounts(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-service-accounts", lager.Data{"team": team.Name()})
+
+		accounts, err := team.ServiceAccounts()
+		if err != nil {
+			logger.Error("failed-to-get-service-accounts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.ServiceAccount{}
+		for _, account := range accounts {
+			presented = append(presented, present.ServiceAccount(account))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-service-accounts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) CreateServiceAccount(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("create-service-account", lager.Data{"team": team.Name()})
+
+		var req atc.CreateServiceAccountRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Error("malformed-request", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if err := validateServiceAccountRequest(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "%s", err.Error())
+			return
+		}
+
+		ttl, err := serviceAccountTTL(team, req.TTL)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "%s", err.Error())
+			return
+		}
+
+		claims := serviceAccountClaims(team.ID(), team.Name(), req.Name, req.Role, time.Now(), ttl)
+
+		accessToken, err := token.Factory{}.GenerateAccessToken(claims)
+		if err != nil {
+			logger.Error("failed-to-generate-access-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+
+		account, err := team.CreateServiceAccount(
+			req.Name,
+			req.Role,
+			acc.UserInfo().DisplayUserId,
+			db.AccessToken{Token: accessToken, Claims: claims},
+		)
+		if err != nil {
+			if err == db.ErrServiceAccountExists {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
+			logger.Error("failed-to-create-service-account", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(atc.ServiceAccountToken{
+			ServiceAccount: present.ServiceAccount(account),
+			Token:          accessToken,
+		})
+		if err != nil {
+			logger.Error("failed-to-encode-service-account", err)
+		}
+	})
+}
+
+func (s *Server) DeleteServiceAccount(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":service_account_name")
+
+		logger := s.logger.Session("delete-service-account", lager.Data{"team": team.Name(), "name": name})
+
+		found, err := team.DeleteServiceAccount(name)
+		if err != nil {
+			logger.Error("failed-to-delete-service-account", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func validateServiceAccountRequest(req atc.CreateServiceAccountRequest) error {
+	warning, err := atc.ValidateIdentifier(req.Name, "service account")
+	if err != nil {
+		return err
+	}
+
+	// unlike teams and pipelines there are no existing service accounts to
+	// stay compatible with, so invalid identifiers are rejected outright
+	if warning != nil {
+		return errors.New(warning.Message)
+	}
+
+	switch req.Role {
+	case accessor.OwnerRole, accessor.MemberRole, accessor.OperatorRole, accessor.ViewerRole:
+	default:
+		return fmt.Errorf("unknown role '%s'", req.Role)
+	}
+
+	if req.TTL < 0 {
+		return fmt.Errorf("ttl must not be negative")
+	}
+
+	return nil
+}
+
+// serviceAccountTTL returns how long a service account's token is valid for.
+// The team's access token lifetime limits it like any other token, so the
+// token never outlives the access it grants to the team.
+func serviceAccountTTL(team db.Team, requested time.Duration) (time.Duration, error) {
+	maxTTL := MaxServiceAccountTTL
+	if lifetime := team.AccessTokenLifetime(); lifetime > 0 && lifetime < maxTTL {
+		maxTTL = lifetime
+	}
+
+	if requested == 0 {
+		if DefaultServiceAccountTTL > maxTTL {
+			return maxTTL, nil
+		}
+
+		return DefaultServiceAccountTTL, nil
+	}
+
+	if requested > maxTTL {
+		return 0, fmt.Errorf("ttl %s exceeds the maximum of %s", requested, maxTTL)
+	}
+
+	return requested, nil
+}
+
+// serviceAccountClaims builds the claims stored alongside a service
+// account's token. The role binding travels with the token so that the
+// accessor does not need the team's auth config to authorize it.
+func serviceAccountClaims(teamID int, teamName, name, role string, now time.Time, ttl time.Duration) db.Claims {
+	subject := fmt.Sprintf("%s:%s:%s", accessor.ServiceAccountConnector, teamName, name)
+	issuedAt := jwt.NewNumericDate(now)
+	expiry := jwt.NewNumericDate(now.Add(ttl))
+
+	federatedClaims := db.FederatedClaims{
+		UserID:    teamName + ":" + name,
+		Connector: accessor.ServiceAccountConnector,
+	}
+
+	return db.Claims{
+		Claims: jwt.Claims{
+			Subject:  subject,
+			Audience: jwt.Audience{"fly"},
+			IssuedAt: issuedAt,
+			Expiry:   expiry,
+		},
+		FederatedClaims:   federatedClaims,
+		Username:          subject,
+		PreferredUsername: name,
+		RawClaims: map[string]interface{}{
+			"sub":                subject,
+			"aud":                []string{"fly"},
+			"iat":                issuedAt,
+			"exp":                expiry,
+			"name":               subject,
+			"preferred_username": name,
+			"federated_claims": map[string]interface{}{
+				"user_id":      federatedClaims.UserID,
+				"connector_id": federatedClaims.Connector,
+			},
+			accessor.ServiceAccountClaim: map[string]interface{}{
+				"team_id": teamID,
+				"team":    teamName,
+				"role":    role,
+			},
+		},
+	}
+}
@@ -2,6 +2,8 @@ package teamserver
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -9,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
 )
 
 type SetTeamResponse struct {
@@ -40,6 +43,14 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := s.validateSessionLifetimes(atcTeam); err != nil {
+		hLog.Info("session-lifetime-exceeds-maximum", lager.Data{"error": err.Error()})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(SetTeamResponse{Errors: []string{err.Error()}})
+		return
+	}
+
 	atcTeam.Name = teamName
 
 	team, found, err := s.teamFactory.FindTeam(teamName)
@@ -52,10 +63,9 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 	response := SetTeamResponse{}
 	if found {
 		hLog.Debug("updating-credentials")
-		err = team.UpdateProviderAuth(atcTeam.Auth)
+		err = team.UpdateAuth(atcTeam)
 		if err != nil {
-			hLog.Error("failed-to-update-team", err, lager.Data{"teamName": teamName})
-			w.WriteHeader(http.StatusInternalServerError)
+			s.failedToSaveTeam(hLog, w, err, teamName)
 			return
 		}
 
@@ -74,8 +84,7 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 
 		team, err = s.teamFactory.CreateTeam(atcTeam)
 		if err != nil {
-			hLog.Error("failed-to-save-team", err, lager.Data{"teamName": teamName})
-			w.WriteHeader(http.StatusInternalServerError)
+			s.failedToSaveTeam(hLog, w, err, teamName)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -101,3 +110,37 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 	}
 
 }
+
+// failedToSaveTeam responds to an error saving the team. Granting roles on a
+// pipeline which doesn't exist is the client's mistake; anything else is ours.
+func (s *Server) failedToSaveTeam(hLog lager.Logger, w http.ResponseWriter, err error, teamName string) {
+	var pipelineNotFound db.ErrPipelineNotFound
+	if errors.As(err, &pipelineNotFound) {
+		hLog.Info("pipeline-auth-pipeline-not-found", lager.Data{"teamName": teamName, "error": err.Error()})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(SetTeamResponse{Errors: []string{err.Error()}})
+		return
+	}
+
+	hLog.Error("failed-to-save-team", err, lager.Data{"teamName": teamName})
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+// validateSessionLifetimes ensures that teams only ever shorten the
+// cluster-wide token lifetime.
+func (s *Server) validateSessionLifetimes(team atc.Team) error {
+	if s.maxSessionLifetime <= 0 {
+		return nil
+	}
+
+	if team.AccessTokenLifetime > s.maxSessionLifetime {
+		return fmt.Errorf("access token lifetime %s exceeds the cluster maximum of %s", team.AccessTokenLifetime, s.maxSessionLifetime)
+	}
+
+	if team.SessionIdleTimeout > s.maxSessionLifetime {
+		return fmt.Errorf("session idle timeout %s exceeds the cluster maximum of %s", team.SessionIdleTimeout, s.maxSessionLifetime)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/notifier"
+)
+
+func (s *Server) ListWebhooks(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-webhooks", lager.Data{"team": team.Name()})
+
+		webhooks, err := team.Webhooks()
+		if err != nil {
+			logger.Error("failed-to-get-webhooks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.TeamWebhook{}
+		for _, webhook := range webhooks {
+			// the secret is write-only; it's only needed to sign payloads
+			webhook.Secret = ""
+			presented = append(presented, webhook)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-webhooks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) SetWebhook(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":webhook_name")
+
+		logger := s.logger.Session("set-webhook", lager.Data{"team": team.Name(), "name": name})
+
+		var webhook atc.TeamWebhook
+		err := json.NewDecoder(r.Body).Decode(&webhook)
+		if err != nil {
+			logger.Error("malformed-request", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		webhook.Name = name
+
+		if err := validateWebhook(webhook); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "%s", err.Error())
+			return
+		}
+
+		created, err := team.SetWebhook(webhook)
+		if err != nil {
+			logger.Error("failed-to-set-webhook", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if created {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+}
+
+func (s *Server) DeleteWebhook(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":webhook_name")
+
+		logger := s.logger.Session("delete-webhook", lager.Data{"team": team.Name(), "name": name})
+
+		found, err := team.DeleteWebhook(name)
+		if err != nil {
+			logger.Error("failed-to-delete-webhook", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func validateWebhook(webhook atc.TeamWebhook) error {
+	warning, err := atc.ValidateIdentifier(webhook.Name, "webhook")
+	if err != nil {
+		return err
+	}
+
+	if warning != nil {
+		return errors.New(warning.Message)
+	}
+
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url '%s': must be an absolute http or https url", webhook.URL)
+	}
+
+	switch webhook.Type {
+	case atc.TeamWebhookTypeGeneric:
+		if webhook.Channel != "" || webhook.Template != "" {
+			return errors.New("channel and template are only supported by slack and teams webhooks")
+		}
+	case atc.TeamWebhookTypeSlack, atc.TeamWebhookTypeTeams:
+		_, err := notifier.ParseTemplate(webhook.Template)
+		if err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown webhook type '%s'", webhook.Type)
+	}
+
+	if webhook.Channel != "" && webhook.Type != atc.TeamWebhookTypeSlack {
+		return errors.New("channel is only supported by slack webhooks")
+	}
+
+	for _, event := range webhook.Filter.Events {
+		switch event {
+		case atc.TeamWebhookEventBuildStarted, atc.TeamWebhookEventBuildFinished, atc.TeamWebhookEventPipelineChanged:
+		default:
+			return fmt.Errorf("unknown event '%s'", event)
+		}
+	}
+
+	return nil
+}
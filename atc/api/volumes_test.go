@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -102,6 +103,8 @@ var _ = Describe("Volumes API", func() {
 							volume3.ContainerHandleReturns("some-container-handle")
 							volume3.PathReturns("some-path")
 							volume3.ParentHandleReturns("some-parent-handle")
+							volume3.CreatedAtReturns(time.Unix(1622213441, 0))
+							volume3.SizeBytesReturns(4096)
 							volume3.TypeReturns(db.VolumeTypeContainer)
 							volume4 := new(dbfakes.FakeCreatedVolume)
 							volume4.HandleReturns("some-cow-handle")
@@ -197,7 +200,9 @@ var _ = Describe("Volumes API", func() {
 		 						"pipeline_name": "",
 		 						"pipeline_instance_vars": null,
 		 						"job_name": "",
-		 						"step_name": ""
+		 						"step_name": "",
+		 						"created_at": 1622213441,
+		 						"size_bytes": 4096
 		 					},
 		 					{
 		 						"id": "some-cow-handle",
@@ -529,6 +534,25 @@ var _ = Describe("Volumes API", func() {
 					Expect(workerName).To(Equal("some-worker-name"))
 					Expect(handles).To(Equal([]string{"handle1", "handle2"}))
 				})
+
+				It("counts the worker's volumes by type", func() {
+					_, err = client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeVolumeRepository.CountVolumesByTypeCallCount()).To(Equal(1))
+					Expect(fakeVolumeRepository.CountVolumesByTypeArgsForCall(0)).To(Equal("some-worker-name"))
+				})
+
+				Context("when counting volumes by type fails", func() {
+					BeforeEach(func() {
+						fakeVolumeRepository.CountVolumesByTypeReturns(nil, errors.New("some error"))
+					})
+
+					It("still returns 204", func() {
+						response, err = client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+				})
 			})
 		})
 	})
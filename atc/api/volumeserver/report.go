@@ -62,6 +62,21 @@ func (s *Server) ReportWorkerVolumes(w http.ResponseWriter, r *http.Request) {
 		Volumes:    numUnknownVolumes,
 	}.Emit(logger)
 
+	counts, err := s.repository.CountVolumesByType(workerName)
+	if err != nil {
+		logger.Error("failed-to-count-volumes-by-type", err)
+	} else {
+		volumesByType := map[string]int{}
+		for volumeType, count := range counts {
+			volumesByType[string(volumeType)] = count
+		}
+
+		metric.WorkerVolumesByType{
+			WorkerName: workerName,
+			Counts:     volumesByType,
+		}.Emit(logger)
+	}
+
 	err = s.repository.UpdateVolumesMissingSince(workerName, handles)
 	if err != nil {
 		logger.Error("failed-to-update-volumes-missing-since", err)
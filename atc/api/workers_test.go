@@ -916,6 +916,128 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/workers/:worker_name/usage", func() {
+		var (
+			response   *http.Response
+			workerName string
+			payload    string
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			workerName = "some-worker"
+			fakeWorker.NameReturns(workerName)
+			fakeWorker.TeamNameReturns("some-team")
+
+			payload = `{"disk_used_bytes":1024,"disk_free_bytes":2048,"container_scratch_bytes":512,"container_bytes":{"some-container":512},"volume_bytes":{"some-volume":256}}`
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/"+workerName+"/usage", bytes.NewBufferString(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the request is authenticated as system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+			})
+
+			It("returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			})
+
+			It("looks up the worker", func() {
+				Expect(dbWorkerFactory.GetWorkerCallCount()).To(Equal(1))
+				Expect(dbWorkerFactory.GetWorkerArgsForCall(0)).To(Equal(workerName))
+			})
+
+			It("records the container and volume sizes", func() {
+				Expect(fakeWorker.UpdateSizesCallCount()).To(Equal(1))
+
+				containerBytes, volumeBytes := fakeWorker.UpdateSizesArgsForCall(0)
+				Expect(containerBytes).To(Equal(map[string]uint64{"some-container": 512}))
+				Expect(volumeBytes).To(Equal(map[string]uint64{"some-volume": 256}))
+			})
+
+			Context("when recording the sizes fails", func() {
+				BeforeEach(func() {
+					fakeWorker.UpdateSizesReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the payload is malformed", func() {
+				BeforeEach(func() {
+					payload = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when looking up the worker fails", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the request is authorized as the worker's owner", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		Context("when the request is authorized as a different team", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("DELETE /api/v1/workers/:worker_name", func() {
 		var (
 			response   *http.Response
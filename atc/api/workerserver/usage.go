@@ -0,0 +1,55 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+// ReportWorkerUsage provides an API endpoint for workers to report their
+// current disk usage
+func (s *Server) ReportWorkerUsage(w http.ResponseWriter, r *http.Request) {
+	workerName := r.FormValue(":worker_name")
+
+	logger := s.logger.Session("report-worker-usage", lager.Data{"name": workerName})
+
+	var usage atc.WorkerUsage
+	err := json.NewDecoder(r.Body).Decode(&usage)
+	if err != nil {
+		logger.Error("failed-to-decode-usage", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-to-find-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Info("worker-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	metric.WorkerDiskUsage{
+		WorkerName:            workerName,
+		DiskUsedBytes:         usage.DiskUsedBytes,
+		DiskFreeBytes:         usage.DiskFreeBytes,
+		ContainerScratchBytes: usage.ContainerScratchBytes,
+	}.Emit(logger)
+
+	err = worker.UpdateSizes(usage.ContainerBytes, usage.VolumeBytes)
+	if err != nil {
+		logger.Error("failed-to-update-sizes", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -19,6 +19,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/Shopify/sarama"
 	"github.com/concourse/concourse"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api"
@@ -42,14 +43,18 @@ import (
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/lidar"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/notifier"
 	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/publish"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/algorithm"
 	"github.com/concourse/concourse/atc/syslog"
+	"github.com/concourse/concourse/atc/trigger"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/image"
 	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/concourse/concourse/logging"
 	"github.com/concourse/concourse/skymarshal/dexserver"
 	"github.com/concourse/concourse/skymarshal/legacyserver"
 	"github.com/concourse/concourse/skymarshal/skycmd"
@@ -86,6 +91,8 @@ import (
 	_ "github.com/concourse/concourse/atc/creds/conjur"
 	_ "github.com/concourse/concourse/atc/creds/credhub"
 	_ "github.com/concourse/concourse/atc/creds/dummy"
+	_ "github.com/concourse/concourse/atc/creds/file"
+	_ "github.com/concourse/concourse/atc/creds/keyvault"
 	_ "github.com/concourse/concourse/atc/creds/kubernetes"
 	_ "github.com/concourse/concourse/atc/creds/secretsmanager"
 	_ "github.com/concourse/concourse/atc/creds/ssm"
@@ -108,9 +115,10 @@ type ATCCommand struct {
 }
 
 type RunCommand struct {
-	Logger flag.Lager
+	Logger logging.Lager
 
 	varSourcePool creds.VarSourcePool
+	auditSink     auditor.Sink
 
 	BindIP   flag.IP `long:"bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for web traffic."`
 	BindPort uint16  `long:"bind-port" default:"8080"    description:"Port on which to listen for HTTP traffic."`
@@ -133,6 +141,8 @@ type RunCommand struct {
 	APIMaxOpenConnections     int                         `long:"api-max-conns" description:"The maximum number of open connections for the api connection pool." default:"10"`
 	BackendMaxOpenConnections int                         `long:"backend-max-conns" description:"The maximum number of open connections for the backend connection pool." default:"50"`
 
+	APIRateLimits wrappa.RateLimits `group:"API Rate Limiting"`
+
 	CredentialManagement creds.CredentialManagementConfig `group:"Credential Management"`
 	CredentialManagers   creds.Managers
 
@@ -143,6 +153,7 @@ type RunCommand struct {
 	DebugBindPort uint16  `long:"debug-bind-port" default:"8079"      description:"Port on which to listen for the pprof debugger endpoints."`
 
 	InterceptIdleTimeout time.Duration `long:"intercept-idle-timeout" default:"0m" description:"Length of time for a intercepted session to be idle before terminating."`
+	InterceptSessionTTL  time.Duration `long:"intercept-session-ttl"  default:"30m" description:"Length of time a named intercept session keeps running after its last client disconnects, so that it can be reattached to."`
 
 	ComponentRunnerInterval time.Duration `long:"component-runner-interval" default:"10s" description:"Interval on which runners are kicked off for builds, locks, scans, and checks"`
 
@@ -153,8 +164,14 @@ type RunCommand struct {
 	ResourceWithWebhookCheckingInterval time.Duration `long:"resource-with-webhook-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources that has webhook defined."`
 	MaxChecksPerSecond                  int           `long:"max-checks-per-second" description:"Maximum number of checks that can be started per second. If not specified, this will be calculated as (# of resources)/(resource checking interval). -1 value will remove this maximum limit of checks per second."`
 
+	BuildTokenTTL  time.Duration `long:"build-token-ttl"  default:"1h"     description:"How long tokens issued to tasks with 'build_token: true' remain valid."`
+	BuildTokenRole string        `long:"build-token-role" default:"viewer" choice:"viewer" choice:"pipeline-operator" description:"Role granted on the build's pipeline to tokens issued to tasks."`
+
 	ContainerPlacementStrategyOptions worker.ContainerPlacementStrategyOptions `group:"Container Placement Strategy"`
 
+	TeamSchedulingWeights  map[string]int `long:"team-scheduling-weight" description:"The share of the scheduler and of the build capacity given to a team's jobs when starting their pending builds, relative to other teams. Can be specified multiple times. Teams default to a weight of 1." value-name:"TEAM:WEIGHT"`
+	TeamSchedulingCapacity uint64         `long:"team-scheduling-capacity" description:"Number of builds the cluster runs at once before teams are held to their share of them. Once this many builds are running, the jobs of a team running at least its share don't start builds until the team is back under its share. 0 means teams are never held back."`
+
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
 	StreamingArtifactsCompression     string        `long:"streaming-artifacts-compression" default:"gzip" choice:"gzip" choice:"zstd" description:"Compression algorithm for internal streaming."`
 
@@ -172,8 +189,11 @@ type RunCommand struct {
 
 	Tracing tracing.Config `group:"Tracing" namespace:"tracing"`
 
+	Publish publish.Config `group:"Artifact Publishing" namespace:"publish"`
+
 	PolicyCheckers struct {
-		Filter policy.Filter
+		Filter    policy.Filter
+		Decisions policy.Decisions
 	} `group:"Policy Checking"`
 
 	Server struct {
@@ -223,6 +243,16 @@ type RunCommand struct {
 		EnableTeamAuditLog      bool `long:"enable-team-auditing" description:"Enable auditing for all api requests connected to teams."`
 		EnableWorkerAuditLog    bool `long:"enable-worker-auditing" description:"Enable auditing for all api requests connected to workers."`
 		EnableVolumeAuditLog    bool `long:"enable-volume-auditing" description:"Enable auditing for all api requests connected to volumes."`
+
+		SinkWebhookURL      string   `long:"audit-sink-webhook-url" description:"URL to POST a JSON record to for every mutating api request and policy decision."`
+		SinkSyslogAddress   string   `long:"audit-sink-syslog-address" description:"Remote syslog server address with port to send a JSON record to for every mutating api request and policy decision."`
+		SinkSyslogTransport string   `long:"audit-sink-syslog-transport" default:"tcp" choice:"tcp" choice:"udp" choice:"tls" description:"Transport protocol for audit records sent to syslog."`
+		SinkSyslogCACerts   []string `long:"audit-sink-syslog-ca-cert" description:"Paths to PEM-encoded CA cert files to use to verify the audit syslog server SSL cert."`
+		SinkKafkaBrokers    []string `long:"audit-sink-kafka-broker" description:"Kafka broker address to publish a JSON record to for every mutating api request and policy decision. Can be specified multiple times."`
+		SinkKafkaTopic      string   `long:"audit-sink-kafka-topic" default:"concourse-audit" description:"Topic to publish audit records to."`
+		SinkKafkaClientID   string   `long:"audit-sink-kafka-client-id" default:"concourse" description:"Client ID to identify as to the Kafka brokers."`
+		SinkKafkaUseTLS     bool     `long:"audit-sink-kafka-use-tls" description:"Connect to the Kafka brokers over TLS."`
+		SinkBufferSize      int      `long:"audit-sink-buffer-size" default:"1000" description:"Number of audit records to hold while they are being sent. Records are dropped once the buffer is full."`
 	}
 
 	Syslog struct {
@@ -620,6 +650,8 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
+	metric.Metrics.JobFactory = db.NewJobFactory(backendConn, lockFactory)
+
 	members = append(members, grouper.Member{
 		Name: "periodic-metrics",
 		Runner: metric.PeriodicallyEmit(
@@ -648,6 +680,11 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		}
 
 		cmd.varSourcePool.Close()
+
+		err := metric.Metrics.Close()
+		if err != nil {
+			logger.Error("failed-to-close-metrics-emitter", err)
+		}
 	}
 
 	return run(grouper.NewParallel(os.Interrupt, members), onReady, onExit), nil
@@ -655,7 +692,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 
 func (cmd *RunCommand) constructMembers(
 	logger lager.Logger,
-	reconfigurableSink *lager.ReconfigurableSink,
+	reconfigurableSink *logging.Sink,
 	apiConn db.Conn,
 	workerConn db.Conn,
 	backendConn db.Conn,
@@ -674,11 +711,20 @@ func (cmd *RunCommand) constructMembers(
 		}()
 	}
 
-	policyChecker, err := policy.Initialize(logger, cmd.Server.ClusterName, concourse.Version, cmd.PolicyCheckers.Filter)
+	policyChecker, err := policy.Initialize(logger, cmd.Server.ClusterName, concourse.Version, cmd.PolicyCheckers.Filter, cmd.PolicyCheckers.Decisions)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.auditSink, err = cmd.constructAuditSink(logger)
 	if err != nil {
 		return nil, err
 	}
 
+	if cmd.auditSink != nil {
+		policyChecker = auditor.NewPolicyChecker(policyChecker, cmd.auditSink)
+	}
+
 	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, apiConn, workerConn, storage, lockFactory, secretManager, policyChecker)
 	if err != nil {
 		return nil, err
@@ -699,6 +745,13 @@ func (cmd *RunCommand) constructMembers(
 	componentFactory := db.NewComponentFactory(backendConn)
 	bus := backendConn.Bus()
 
+	// attribute the scheduler and collectors' logs to their component so
+	// that their level can be configured independently
+	logComponents := map[string]string{atc.ComponentScheduler: logging.ComponentScheduler}
+	for _, c := range gcComponents {
+		logComponents[c.Component.Name] = logging.ComponentGC
+	}
+
 	members := apiMembers
 	components := append(backendComponents, gcComponents...)
 	for _, c := range components {
@@ -708,6 +761,9 @@ func (cmd *RunCommand) constructMembers(
 		}
 
 		componentLogger := logger.Session(c.Component.Name)
+		if logComponent, found := logComponents[c.Component.Name]; found {
+			componentLogger = componentLogger.WithData(lager.Data{logging.ComponentKey: logComponent})
+		}
 
 		members = append(members, grouper.Member{
 			Name: c.Component.Name,
@@ -740,7 +796,7 @@ func (cmd *RunCommand) constructMembers(
 
 func (cmd *RunCommand) constructAPIMembers(
 	logger lager.Logger,
-	reconfigurableSink *lager.ReconfigurableSink,
+	reconfigurableSink *logging.Sink,
 	dbConn db.Conn,
 	workerConn db.Conn,
 	storage storage.Storage,
@@ -802,7 +858,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		cmd.GardenRequestTimeout,
 	)
 
-	pool := worker.NewPool(workerProvider)
+	pool := worker.NewPool(workerProvider, dbConn.Bus())
 
 	credsManagers := cmd.CredentialManagers
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
@@ -835,12 +891,20 @@ func (cmd *RunCommand) constructAPIMembers(
 		return nil, err
 	}
 
+	groupRoles, err := skycmd.GroupRoles()
+	if err != nil {
+		return nil, err
+	}
+
 	accessFactory := accessor.NewAccessFactory(
 		tokenVerifier,
 		teamsCacher,
 		cmd.SystemClaimKey,
 		cmd.SystemClaimValues,
+		groupRoles,
+		accessor.NewSessionTracker(dbAccessTokenFactory, time.Minute, 10000),
 		displayUserIdGenerator,
+		dbBuildFactory,
 	)
 
 	middleware := token.NewMiddleware(cmd.Auth.AuthFlags.SecureCookies)
@@ -861,6 +925,8 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbCheckFactory,
 		dbResourceConfigFactory,
 		userFactory,
+		db.NewApprovalFactory(dbConn),
+		db.NewPublishedArtifactFactory(dbConn),
 		pool,
 		secretManager,
 		credsManagers,
@@ -890,6 +956,7 @@ func (cmd *RunCommand) constructAPIMembers(
 
 	loginHandler, err := cmd.constructLoginHandler(
 		logger,
+		storage,
 		httpClient,
 		middleware,
 	)
@@ -1060,7 +1127,7 @@ func (cmd *RunCommand) backendComponents(
 		cmd.GardenRequestTimeout,
 	)
 
-	pool := worker.NewPool(workerProvider)
+	pool := worker.NewPool(workerProvider, dbConn.Bus())
 	artifactStreamer := worker.NewArtifactStreamer(pool, compressionLib)
 	artifactSourcer := worker.NewArtifactSourcer(compressionLib, pool, cmd.FeatureFlags.EnableP2PVolumeStreaming, cmd.P2pVolumeStreamingTimeout, dbResourceCacheFactory)
 
@@ -1074,6 +1141,11 @@ func (cmd *RunCommand) backendComponents(
 		return nil, err
 	}
 
+	publishStore, err := cmd.Publish.Store()
+	if err != nil {
+		return nil, err
+	}
+
 	rateLimiter := db.NewResourceCheckRateLimiter(
 		rate.Limit(cmd.MaxChecksPerSecond),
 		cmd.ResourceCheckingInterval,
@@ -1098,6 +1170,12 @@ func (cmd *RunCommand) backendComponents(
 		lockFactory,
 		rateLimiter,
 		policyChecker,
+		db.NewAccessTokenFactory(dbConn),
+		db.NewSemaphoreFactory(dbConn, lockFactory),
+		db.NewTaskMemoFactory(dbConn),
+		db.NewApprovalFactory(dbConn),
+		publishStore,
+		db.NewPublishedArtifactFactory(dbConn),
 	)
 
 	// In case that a user configures resource-checking-interval, but forgets to
@@ -1139,6 +1217,8 @@ func (cmd *RunCommand) backendComponents(
 						alg),
 				},
 				cmd.JobSchedulingMaxInFlight,
+				cmd.TeamSchedulingWeights,
+				cmd.TeamSchedulingCapacity,
 			),
 		},
 		{
@@ -1166,6 +1246,25 @@ func (cmd *RunCommand) backendComponents(
 				syslogDrainConfigured,
 			),
 		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentTeamWebhookNotifier,
+				Interval: 10 * time.Second,
+			},
+			Runnable: notifier.NewNotifier(
+				db.NewTeamWebhookEventQueue(dbConn),
+				&http.Client{Timeout: 30 * time.Second},
+				cmd.ExternalURL.String(),
+				100,
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentCronTrigger,
+				Interval: 10 * time.Second,
+			},
+			Runnable: trigger.NewCronTrigger(dbJobFactory, clock.NewClock()),
+		},
 	}
 
 	if syslogDrainConfigured {
@@ -1565,6 +1664,15 @@ func (cmd *RunCommand) validate() error {
 		errs = multierror.Append(errs, err)
 	}
 
+	for team, weight := range cmd.TeamSchedulingWeights {
+		if weight < 1 {
+			errs = multierror.Append(
+				errs,
+				fmt.Errorf("team scheduling weight for '%s' must be at least 1", team),
+			)
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1678,6 +1786,12 @@ func (cmd *RunCommand) constructEngine(
 	lockFactory lock.LockFactory,
 	rateLimiter engine.RateLimiter,
 	policyChecker policy.Checker,
+	accessTokenFactory db.AccessTokenFactory,
+	semaphoreFactory db.SemaphoreFactory,
+	taskMemoFactory db.TaskMemoFactory,
+	approvalFactory db.ApprovalFactory,
+	publishStore publish.Store,
+	publishedArtifactFactory db.PublishedArtifactFactory,
 ) engine.Engine {
 	return engine.NewEngine(
 		engine.NewStepperFactory(
@@ -1693,6 +1807,16 @@ func (cmd *RunCommand) constructEngine(
 				defaultLimits,
 				strategy,
 				cmd.GlobalResourceCheckTimeout,
+				engine.NewBuildTokenIssuer(
+					token.Factory{},
+					accessTokenFactory,
+					cmd.BuildTokenRole,
+					cmd.BuildTokenTTL,
+				),
+				taskMemoFactory,
+				approvalFactory,
+				publishStore,
+				publishedArtifactFactory,
 			),
 			cmd.ExternalURL.String(),
 			rateLimiter,
@@ -1700,6 +1824,8 @@ func (cmd *RunCommand) constructEngine(
 			artifactSourcer,
 			workerFactory,
 			lockFactory,
+			semaphoreFactory,
+			cmd.auditSink,
 		),
 		secretManager,
 		cmd.varSourcePool,
@@ -1789,19 +1915,58 @@ func (cmd *RunCommand) constructAuthHandler(
 		return nil, err
 	}
 
+	claimsParser, err := cmd.constructClaimsParser(logger)
+	if err != nil {
+		return nil, err
+	}
+
 	return token.StoreAccessToken(
 		logger.Session("dex-server"),
 		dexServer,
 		token.Factory{},
-		token.NewClaimsParser(),
+		claimsParser,
 		accessTokenFactory,
 		userFactory,
 		displayUserIdGenerator,
 	), nil
 }
 
+// constructClaimsParser resolves nested groups for every connector which has
+// been configured to do so.
+func (cmd *RunCommand) constructClaimsParser(logger lager.Logger) (token.ClaimsParser, error) {
+	claimsParser := token.NewClaimsParser()
+
+	for _, connector := range skycmd.GetConnectors() {
+		config, ok := connector.Config().(skycmd.NestedGroupsConfig)
+		if !ok {
+			continue
+		}
+
+		urlTemplate, key, maxDepth := config.NestedGroupsEndpoint()
+		if urlTemplate == "" {
+			continue
+		}
+
+		client, err := config.NestedGroupsClient()
+		if err != nil {
+			return nil, fmt.Errorf("configure nested groups for %s: %w", connector.ID(), err)
+		}
+
+		claimsParser = token.NewNestedGroupsClaimsParser(
+			logger.Session("nested-groups", lager.Data{"connector": connector.ID()}),
+			claimsParser,
+			connector.ID(),
+			token.NewHTTPGroupResolver(client, urlTemplate, key),
+			maxDepth,
+		)
+	}
+
+	return claimsParser, nil
+}
+
 func (cmd *RunCommand) constructLoginHandler(
 	logger lager.Logger,
+	storage storage.Storage,
 	httpClient *http.Client,
 	middleware token.Middleware,
 ) (http.Handler, error) {
@@ -1809,10 +1974,12 @@ func (cmd *RunCommand) constructLoginHandler(
 	authPath, _ := url.Parse("/sky/issuer/auth")
 	tokenPath, _ := url.Parse("/sky/issuer/token")
 	redirectPath, _ := url.Parse("/sky/callback")
+	devicePath, _ := url.Parse("/sky/device")
 
 	authURL := cmd.ExternalURL.URL.ResolveReference(authPath)
 	tokenURL := cmd.ExternalURL.URL.ResolveReference(tokenPath)
 	redirectURL := cmd.ExternalURL.URL.ResolveReference(redirectPath)
+	deviceURL := cmd.ExternalURL.URL.ResolveReference(devicePath)
 
 	endpoint := oauth2.Endpoint{
 		AuthURL:   authURL.String(),
@@ -1834,6 +2001,8 @@ func (cmd *RunCommand) constructLoginHandler(
 		TokenParser:     token.Factory{},
 		OAuthConfig:     oauth2Config,
 		HTTPClient:      httpClient,
+		Storage:         storage,
+		DeviceURL:       deviceURL.String(),
 	})
 	if err != nil {
 		return nil, err
@@ -1857,7 +2026,7 @@ func (cmd *RunCommand) constructTokenVerifier(accessTokenFactory db.AccessTokenF
 
 func (cmd *RunCommand) constructAPIHandler(
 	logger lager.Logger,
-	reconfigurableSink *lager.ReconfigurableSink,
+	reconfigurableSink *logging.Sink,
 	teamFactory db.TeamFactory,
 	workerTeamFactory db.TeamFactory,
 	dbPipelineFactory db.PipelineFactory,
@@ -1871,6 +2040,8 @@ func (cmd *RunCommand) constructAPIHandler(
 	dbCheckFactory db.CheckFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
+	dbApprovalFactory db.ApprovalFactory,
+	dbPublishedArtifactFactory db.PublishedArtifactFactory,
 	workerPool worker.Pool,
 	secretManager creds.Secrets,
 	credsManagers creds.Managers,
@@ -1878,6 +2049,7 @@ func (cmd *RunCommand) constructAPIHandler(
 	dbWall db.Wall,
 	policyChecker policy.Checker,
 ) (http.Handler, error) {
+	logger = logger.WithData(lager.Data{logging.ComponentKey: logging.ComponentAPI})
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(teamFactory)
 	checkBuildReadAccessHandlerFactory := auth.NewCheckBuildReadAccessHandlerFactory(dbBuildFactory)
@@ -1897,6 +2069,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		cmd.Auditor.EnableWorkerAuditLog,
 		cmd.Auditor.EnableVolumeAuditLog,
 		logger,
+		cmd.auditSink,
 	)
 
 	customRoles, err := cmd.parseCustomRoles()
@@ -1919,6 +2092,9 @@ func (cmd *RunCommand) constructAPIHandler(
 		),
 		wrappa.NewRejectArchivedWrappa(rejectArchivedHandlerFactory),
 		wrappa.NewConcourseVersionWrappa(concourse.Version),
+		// wrapped by the accessor, so that clients are limited by their
+		// verified identity
+		wrappa.NewRateLimitWrappa(logger, cmd.APIRateLimits),
 		wrappa.NewAccessorWrappa(
 			logger,
 			accessFactory,
@@ -1947,6 +2123,8 @@ func (cmd *RunCommand) constructAPIHandler(
 		dbCheckFactory,
 		resourceConfigFactory,
 		dbUserFactory,
+		dbApprovalFactory,
+		dbPublishedArtifactFactory,
 
 		buildserver.NewEventHandler,
 
@@ -1964,11 +2142,51 @@ func (cmd *RunCommand) constructAPIHandler(
 		credsManagers,
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
 		time.Minute,
+		cmd.InterceptSessionTTL,
 		dbWall,
 		clock.NewClock(),
+		cmd.Auth.AuthFlags.Expiration,
 	)
 }
 
+func (cmd *RunCommand) constructAuditSink(logger lager.Logger) (auditor.Sink, error) {
+	var sinks []auditor.Sink
+
+	if cmd.Auditor.SinkWebhookURL != "" {
+		sinks = append(sinks, auditor.NewWebhookSink(&http.Client{Timeout: 10 * time.Second}, cmd.Auditor.SinkWebhookURL))
+	}
+
+	if cmd.Auditor.SinkSyslogAddress != "" {
+		writer, err := syslog.Dial(cmd.Auditor.SinkSyslogTransport, cmd.Auditor.SinkSyslogAddress, cmd.Auditor.SinkSyslogCACerts)
+		if err != nil {
+			return nil, fmt.Errorf("dial audit syslog: %w", err)
+		}
+
+		sinks = append(sinks, auditor.NewSyslogSink(writer, cmd.Syslog.Hostname))
+	}
+
+	if len(cmd.Auditor.SinkKafkaBrokers) > 0 {
+		config := sarama.NewConfig()
+		config.ClientID = cmd.Auditor.SinkKafkaClientID
+		config.Net.TLS.Enable = cmd.Auditor.SinkKafkaUseTLS
+		config.Producer.RequiredAcks = sarama.WaitForAll
+		config.Producer.Return.Successes = true
+
+		producer, err := sarama.NewSyncProducer(cmd.Auditor.SinkKafkaBrokers, config)
+		if err != nil {
+			return nil, fmt.Errorf("create audit kafka producer: %w", err)
+		}
+
+		sinks = append(sinks, auditor.NewKafkaSink(producer, cmd.Auditor.SinkKafkaTopic))
+	}
+
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+
+	return auditor.NewAsyncSink(logger.Session("audit-sink"), auditor.NewMultiSink(sinks...), cmd.Auditor.SinkBufferSize), nil
+}
+
 type tlsRedirectHandler struct {
 	matchHostname string
 	externalHost  string
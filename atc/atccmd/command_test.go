@@ -45,6 +45,22 @@ func (s *CommandSuite) TestInvalidConcurrentRequestLimitAction() {
 	)
 }
 
+func (s *CommandSuite) TestTeamSchedulingWeights() {
+	cmd := &atccmd.RunCommand{}
+	parser := flags.NewParser(cmd, flags.None)
+	// other required flags are missing, but the weights are still parsed
+	_, _ = parser.ParseArgs([]string{
+		"--client-secret",
+		"client-secret",
+		"--team-scheduling-weight",
+		"main:3",
+		"--team-scheduling-weight",
+		"other-team:2",
+	})
+
+	s.Equal(map[string]int{"main": 3, "other-team": 2}, cmd.TeamSchedulingWeights)
+}
+
 func TestSuite(t *testing.T) {
 	suite.Run(t, &CommandSuite{
 		Assertions: require.New(t),
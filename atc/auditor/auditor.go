@@ -3,6 +3,7 @@ package auditor
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -22,6 +23,7 @@ func NewAuditor(
 	EnableWorkerAuditLog bool,
 	EnableVolumeAuditLog bool,
 	logger lager.Logger,
+	sink Sink,
 ) *auditor {
 	return &auditor{
 		EnableBuildAuditLog:     EnableBuildAuditLog,
@@ -34,6 +36,7 @@ func NewAuditor(
 		EnableWorkerAuditLog:    EnableWorkerAuditLog,
 		EnableVolumeAuditLog:    EnableVolumeAuditLog,
 		logger:                  logger,
+		sink:                    sink,
 	}
 }
 
@@ -52,24 +55,29 @@ type auditor struct {
 	EnableWorkerAuditLog    bool
 	EnableVolumeAuditLog    bool
 	logger                  lager.Logger
+	sink                    Sink
 }
 
 func (a *auditor) ValidateAction(action string) bool {
 	switch action {
 	case atc.GetBuild,
 		atc.GetBuildPlan,
+		atc.GetBuildPlanStatus,
 		atc.CreateBuild,
 		atc.RerunJobBuild,
 		atc.ListBuilds,
 		atc.BuildEvents,
 		atc.BuildResources,
 		atc.AbortBuild,
+		atc.ApproveBuild,
+		atc.RejectBuild,
 		atc.GetBuildPreparation,
 		atc.ListBuildsWithVersionAsInput,
 		atc.ListBuildsWithVersionAsOutput,
 		atc.CreateArtifact,
 		atc.GetArtifact,
-		atc.ListBuildArtifacts:
+		atc.ListBuildArtifacts,
+		atc.ListBuildPublishedArtifacts:
 		return a.EnableBuildAuditLog
 	case atc.ListContainers,
 		atc.GetContainer,
@@ -86,6 +94,8 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.GetJobBuild,
 		atc.PauseJob,
 		atc.UnpauseJob,
+		atc.PauseJobs,
+		atc.UnpauseJobs,
 		atc.ScheduleJob,
 		atc.JobBadge,
 		atc.MainJobBadge:
@@ -104,7 +114,8 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.RenamePipeline,
 		atc.ListPipelineBuilds,
 		atc.CreatePipelineBuild,
-		atc.PipelineBadge:
+		atc.PipelineBadge,
+		atc.PipelineGroupBadge:
 		return a.EnablePipelineAuditLog
 	case atc.ListAllResources,
 		atc.ListResources,
@@ -124,9 +135,11 @@ func (a *auditor) ValidateAction(action string) bool {
 		return a.EnableResourceAuditLog
 	case
 		atc.SaveConfig,
+		atc.ValidateConfig,
 		atc.GetConfig,
 		atc.GetCC,
 		atc.GetVersionsDB,
+		atc.ListPipelineVarSources,
 		atc.ClearTaskCache,
 		atc.SetLogLevel,
 		atc.GetLogLevel,
@@ -134,6 +147,7 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.GetInfo,
 		atc.GetInfoCreds,
 		atc.ListActiveUsersSince,
+		atc.Search,
 		atc.GetUser,
 		atc.GetWall,
 		atc.SetWall,
@@ -144,13 +158,25 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.RenameTeam,
 		atc.DestroyTeam,
 		atc.ListTeamBuilds,
-		atc.GetTeam:
+		atc.GetTeam,
+		atc.ListServiceAccounts,
+		atc.CreateServiceAccount,
+		atc.DeleteServiceAccount,
+		atc.ListTeamWebhooks,
+		atc.SetTeamWebhook,
+		atc.DeleteTeamWebhook,
+		atc.ListPipelineTemplates,
+		atc.SetPipelineTemplate,
+		atc.DeletePipelineTemplate,
+		atc.ListTeamPrunableState,
+		atc.PruneTeamState:
 		return a.EnableTeamAuditLog
 	case atc.RegisterWorker,
 		atc.LandWorker,
 		atc.RetireWorker,
 		atc.PruneWorker,
 		atc.HeartbeatWorker,
+		atc.ReportWorkerUsage,
 		atc.ListWorkers,
 		atc.DeleteWorker:
 		return a.EnableWorkerAuditLog
@@ -168,4 +194,28 @@ func (a *auditor) Audit(action string, userName string, r *http.Request) {
 	if err == nil && a.ValidateAction(action) {
 		a.logger.Info("audit", lager.Data{"action": action, "user": userName, "parameters": r.Form})
 	}
+
+	if a.sink != nil && isMutating(r.Method) {
+		err := a.sink.Send(Record{
+			Time:       time.Now(),
+			Type:       RecordTypeAPI,
+			Action:     action,
+			User:       userName,
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Parameters: r.Form,
+		})
+		if err != nil {
+			a.logger.Error("failed-to-send-audit-record", err, lager.Data{"action": action})
+		}
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -28,10 +29,12 @@ var _ = Describe("Audit", func() {
 		EnableTeamAuditLog      bool
 		EnableWorkerAuditLog    bool
 		EnableVolumeAuditLog    bool
+		fakeSink                *auditorfakes.FakeSink
 	)
 
 	BeforeEach(func() {
 		userName = "test"
+		fakeSink = new(auditorfakes.FakeSink)
 
 		var err error
 		req, err = http.NewRequest("GET", "localhost:8080", nil)
@@ -52,6 +55,7 @@ var _ = Describe("Audit", func() {
 			EnableWorkerAuditLog,
 			EnableVolumeAuditLog,
 			logger,
+			fakeSink,
 		)
 	})
 
@@ -87,6 +91,37 @@ var _ = Describe("Audit", func() {
 		})
 	})
 
+	Describe("sink", func() {
+		Context("when the request is mutating", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest("PUT", "http://localhost:8080/api/v1/teams/main/pipelines/some-pipeline/pause?reason=maintenance", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("sends a record to the sink regardless of which audit logs are enabled", func() {
+				aud.Audit(atc.PausePipeline, userName, req)
+
+				Expect(fakeSink.SendCallCount()).To(Equal(1))
+				record := fakeSink.SendArgsForCall(0)
+				Expect(record.Type).To(Equal(auditor.RecordTypeAPI))
+				Expect(record.Action).To(Equal(atc.PausePipeline))
+				Expect(record.User).To(Equal(userName))
+				Expect(record.Method).To(Equal("PUT"))
+				Expect(record.Path).To(Equal("/api/v1/teams/main/pipelines/some-pipeline/pause"))
+				Expect(record.Parameters).To(HaveKeyWithValue("reason", []string{"maintenance"}))
+				Expect(record.Time).ToNot(BeZero())
+			})
+		})
+
+		Context("when the request is not mutating", func() {
+			It("does not send a record", func() {
+				aud.Audit(atc.GetPipeline, userName, req)
+				Expect(fakeSink.SendCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("EnableBuildAuditLog", func() {
 
 		Context("When EnableBuildAudit is false with a Build action", func() {
@@ -0,0 +1,48 @@
+package auditor
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/policy"
+)
+
+// NewPolicyChecker wraps a policy.Checker, sending every decision it makes
+// to the sink.
+func NewPolicyChecker(checker policy.Checker, sink Sink) policy.Checker {
+	return policyChecker{
+		Checker: checker,
+		sink:    sink,
+	}
+}
+
+type policyChecker struct {
+	policy.Checker
+	sink Sink
+}
+
+func (c policyChecker) Check(input policy.PolicyCheckInput) (policy.PolicyCheckOutput, error) {
+	output, err := c.Checker.Check(input)
+
+	record := Record{
+		Time:     time.Now(),
+		Type:     RecordTypePolicy,
+		Action:   input.Action,
+		User:     input.User,
+		Method:   input.HttpMethod,
+		Team:     input.Team,
+		Pipeline: input.Pipeline,
+	}
+
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		allowed := output.Allowed
+		record.Allowed = &allowed
+		record.Reasons = output.Reasons
+		record.Warnings = output.Warnings
+	}
+
+	_ = c.sink.Send(record)
+
+	return output, err
+}
@@ -0,0 +1,91 @@
+package auditor_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/auditor/auditorfakes"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PolicyChecker", func() {
+	var (
+		fakeChecker *policyfakes.FakeChecker
+		fakeSink    *auditorfakes.FakeSink
+		checker     policy.Checker
+
+		input  policy.PolicyCheckInput
+		output policy.PolicyCheckOutput
+		err    error
+	)
+
+	BeforeEach(func() {
+		fakeChecker = new(policyfakes.FakeChecker)
+		fakeSink = new(auditorfakes.FakeSink)
+		checker = auditor.NewPolicyChecker(fakeChecker, fakeSink)
+
+		input = policy.PolicyCheckInput{
+			Action:   policy.ActionSetPipeline,
+			User:     "some-user",
+			Team:     "some-team",
+			Pipeline: "some-pipeline",
+		}
+	})
+
+	JustBeforeEach(func() {
+		output, err = checker.Check(input)
+	})
+
+	Context("when the check is decided", func() {
+		BeforeEach(func() {
+			fakeChecker.CheckReturns(policy.PolicyCheckOutput{
+				Allowed: false,
+				Reasons: []string{"not allowed"},
+			}, nil)
+		})
+
+		It("returns the decision", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output.Allowed).To(BeFalse())
+			Expect(fakeChecker.CheckArgsForCall(0)).To(Equal(input))
+		})
+
+		It("sends the decision to the sink", func() {
+			Expect(fakeSink.SendCallCount()).To(Equal(1))
+			record := fakeSink.SendArgsForCall(0)
+			Expect(record.Type).To(Equal(auditor.RecordTypePolicy))
+			Expect(record.Action).To(Equal(policy.ActionSetPipeline))
+			Expect(record.User).To(Equal("some-user"))
+			Expect(record.Team).To(Equal("some-team"))
+			Expect(record.Pipeline).To(Equal("some-pipeline"))
+			Expect(record.Allowed).ToNot(BeNil())
+			Expect(*record.Allowed).To(BeFalse())
+			Expect(record.Reasons).To(ConsistOf("not allowed"))
+		})
+	})
+
+	Context("when the check errors", func() {
+		BeforeEach(func() {
+			fakeChecker.CheckReturns(policy.FailedPolicyCheck(), errors.New("agent down"))
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(MatchError("agent down"))
+		})
+
+		It("sends the error to the sink", func() {
+			record := fakeSink.SendArgsForCall(0)
+			Expect(record.Allowed).To(BeNil())
+			Expect(record.Error).To(Equal("agent down"))
+		})
+	})
+
+	It("delegates the filters to the wrapped checker", func() {
+		fakeChecker.ShouldCheckActionReturns(true)
+		Expect(checker.ShouldCheckAction("some-action")).To(BeTrue())
+		Expect(fakeChecker.ShouldCheckActionArgsForCall(0)).To(Equal("some-action"))
+	})
+})
@@ -0,0 +1,193 @@
+package auditor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Shopify/sarama"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	RecordTypeAPI    = "api"
+	RecordTypePolicy = "policy"
+	RecordTypeBuild  = "build"
+)
+
+// Record is a structured audit record, sent to a Sink for every mutating
+// API request, every policy decision and every write a build makes on
+// behalf of another team.
+type Record struct {
+	Time   time.Time `json:"time"`
+	Type   string    `json:"type"`
+	Action string    `json:"action"`
+	User   string    `json:"user,omitempty"`
+
+	Method     string              `json:"method,omitempty"`
+	Path       string              `json:"path,omitempty"`
+	Parameters map[string][]string `json:"parameters,omitempty"`
+
+	BuildID  int    `json:"build_id,omitempty"`
+	FromTeam string `json:"from_team,omitempty"`
+
+	Team     string   `json:"team,omitempty"`
+	Pipeline string   `json:"pipeline,omitempty"`
+	Allowed  *bool    `json:"allowed,omitempty"`
+	Reasons  []string `json:"reasons,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+//counterfeiter:generate . Sink
+type Sink interface {
+	Send(Record) error
+}
+
+// NewWebhookSink POSTs each record as JSON to the given URL.
+func NewWebhookSink(client *http.Client, url string) Sink {
+	return webhookSink{
+		client: client,
+		url:    url,
+	}
+}
+
+type webhookSink struct {
+	client *http.Client
+	url    string
+}
+
+func (s webhookSink) Send(record Record) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return nil
+}
+
+//counterfeiter:generate . SyslogWriter
+type SyslogWriter interface {
+	Write(hostname, tag string, ts time.Time, msg string, eventID string) error
+}
+
+// NewSyslogSink writes each record as JSON to syslog, tagged 'audit'.
+func NewSyslogSink(writer SyslogWriter, hostname string) Sink {
+	return syslogSink{
+		writer:   writer,
+		hostname: hostname,
+	}
+}
+
+type syslogSink struct {
+	writer   SyslogWriter
+	hostname string
+}
+
+func (s syslogSink) Send(record Record) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.writer.Write(s.hostname, "audit", record.Time, string(payload), record.Action)
+}
+
+// NewKafkaSink publishes each record as JSON to the given topic. Records are
+// keyed by team, so that each team's records are kept in order.
+func NewKafkaSink(producer sarama.SyncProducer, topic string) Sink {
+	return kafkaSink{
+		producer: producer,
+		topic:    topic,
+	}
+}
+
+type kafkaSink struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func (s kafkaSink) Send(record Record) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:     s.topic,
+		Key:       sarama.StringEncoder(record.Team),
+		Value:     sarama.ByteEncoder(payload),
+		Timestamp: record.Time,
+	})
+
+	return err
+}
+
+// NewMultiSink sends each record to every sink, even if some of them fail.
+func NewMultiSink(sinks ...Sink) Sink {
+	return multiSink(sinks)
+}
+
+type multiSink []Sink
+
+func (sinks multiSink) Send(record Record) error {
+	var errs error
+	for _, sink := range sinks {
+		err := sink.Send(record)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// NewAsyncSink sends records to the sink in the background so that slow or
+// unavailable sinks never hold up API requests. Records are dropped, and
+// logged, once more than bufferSize are waiting to be sent.
+func NewAsyncSink(logger lager.Logger, sink Sink, bufferSize int) Sink {
+	records := make(chan Record, bufferSize)
+
+	go func() {
+		for record := range records {
+			err := sink.Send(record)
+			if err != nil {
+				logger.Error("failed-to-send-audit-record", err, lager.Data{"action": record.Action})
+			}
+		}
+	}()
+
+	return asyncSink{
+		logger:  logger,
+		records: records,
+	}
+}
+
+type asyncSink struct {
+	logger  lager.Logger
+	records chan Record
+}
+
+func (s asyncSink) Send(record Record) error {
+	select {
+	case s.records <- record:
+	default:
+		s.logger.Info("dropped-audit-record", lager.Data{"action": record.Action})
+	}
+
+	return nil
+}
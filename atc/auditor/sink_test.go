@@ -0,0 +1,178 @@
+package auditor_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/auditor/auditorfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Sinks", func() {
+	var record auditor.Record
+
+	BeforeEach(func() {
+		record = auditor.Record{
+			Time:   time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+			Type:   auditor.RecordTypeAPI,
+			Action: "SaveConfig",
+			User:   "some-user",
+			Method: "PUT",
+			Path:   "/api/v1/teams/main/pipelines/some-pipeline/config",
+		}
+	})
+
+	Describe("WebhookSink", func() {
+		var (
+			server *ghttp.Server
+			sink   auditor.Sink
+		)
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			sink = auditor.NewWebhookSink(http.DefaultClient, server.URL()+"/audit")
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("posts the record as JSON", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/audit"),
+					ghttp.VerifyContentType("application/json"),
+					ghttp.VerifyJSON(`{
+						"time": "2021-06-01T12:00:00Z",
+						"type": "api",
+						"action": "SaveConfig",
+						"user": "some-user",
+						"method": "PUT",
+						"path": "/api/v1/teams/main/pipelines/some-pipeline/config"
+					}`),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+
+			Expect(sink.Send(record)).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("errors when the webhook fails", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, nil))
+
+			Expect(sink.Send(record)).To(MatchError(ContainSubstring("500")))
+		})
+	})
+
+	Describe("SyslogSink", func() {
+		It("writes the record as JSON", func() {
+			fakeWriter := new(auditorfakes.FakeSyslogWriter)
+			sink := auditor.NewSyslogSink(fakeWriter, "some-host")
+
+			Expect(sink.Send(record)).To(Succeed())
+
+			Expect(fakeWriter.WriteCallCount()).To(Equal(1))
+			hostname, tag, ts, msg, eventID := fakeWriter.WriteArgsForCall(0)
+			Expect(hostname).To(Equal("some-host"))
+			Expect(tag).To(Equal("audit"))
+			Expect(ts).To(Equal(record.Time))
+			Expect(msg).To(MatchJSON(`{
+				"time": "2021-06-01T12:00:00Z",
+				"type": "api",
+				"action": "SaveConfig",
+				"user": "some-user",
+				"method": "PUT",
+				"path": "/api/v1/teams/main/pipelines/some-pipeline/config"
+			}`))
+			Expect(eventID).To(Equal("SaveConfig"))
+		})
+	})
+
+	Describe("KafkaSink", func() {
+		var producer *mocks.SyncProducer
+
+		BeforeEach(func() {
+			record.Team = "some-team"
+			producer = mocks.NewSyncProducer(GinkgoT(), nil)
+		})
+
+		AfterEach(func() {
+			Expect(producer.Close()).To(Succeed())
+		})
+
+		It("publishes the record as JSON", func() {
+			producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(value []byte) error {
+				Expect(value).To(MatchJSON(`{
+					"time": "2021-06-01T12:00:00Z",
+					"type": "api",
+					"action": "SaveConfig",
+					"user": "some-user",
+					"method": "PUT",
+					"path": "/api/v1/teams/main/pipelines/some-pipeline/config",
+					"team": "some-team"
+				}`))
+				return nil
+			})
+
+			Expect(auditor.NewKafkaSink(producer, "audit").Send(record)).To(Succeed())
+		})
+
+		It("errors when publishing fails", func() {
+			producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+
+			Expect(auditor.NewKafkaSink(producer, "audit").Send(record)).To(MatchError(sarama.ErrOutOfBrokers))
+		})
+	})
+
+	Describe("MultiSink", func() {
+		It("sends to every sink even if one fails", func() {
+			failingSink := new(auditorfakes.FakeSink)
+			failingSink.SendReturns(errors.New("nope"))
+			otherSink := new(auditorfakes.FakeSink)
+
+			err := auditor.NewMultiSink(failingSink, otherSink).Send(record)
+			Expect(err).To(MatchError(ContainSubstring("nope")))
+
+			Expect(failingSink.SendCallCount()).To(Equal(1))
+			Expect(otherSink.SendCallCount()).To(Equal(1))
+			Expect(otherSink.SendArgsForCall(0)).To(Equal(record))
+		})
+	})
+
+	Describe("AsyncSink", func() {
+		It("sends records in the background", func() {
+			fakeSink := new(auditorfakes.FakeSink)
+			sink := auditor.NewAsyncSink(lagertest.NewTestLogger("test"), fakeSink, 10)
+
+			Expect(sink.Send(record)).To(Succeed())
+
+			Eventually(fakeSink.SendCallCount).Should(Equal(1))
+			Expect(fakeSink.SendArgsForCall(0)).To(Equal(record))
+		})
+
+		It("drops records rather than blocking once the buffer is full", func() {
+			blocked := make(chan struct{})
+			defer close(blocked)
+
+			fakeSink := new(auditorfakes.FakeSink)
+			fakeSink.SendStub = func(auditor.Record) error {
+				<-blocked
+				return nil
+			}
+
+			sink := auditor.NewAsyncSink(lagertest.NewTestLogger("test"), fakeSink, 1)
+
+			for i := 0; i < 5; i++ {
+				Expect(sink.Send(record)).To(Succeed())
+			}
+		})
+	})
+})
@@ -1,5 +1,10 @@
 package atc
 
+import (
+	"net/url"
+	"time"
+)
+
 type BuildStatus string
 
 const (
@@ -15,6 +20,22 @@ func (status BuildStatus) String() string {
 	return string(status)
 }
 
+// BuildTrigger is what caused a build of a job to be created.
+type BuildTrigger string
+
+const (
+	// BuildTriggerManual builds were created or rerun by a user.
+	BuildTriggerManual BuildTrigger = "manual"
+
+	// BuildTriggerAutomatic builds were created by the scheduler for new
+	// versions of the job's inputs.
+	BuildTriggerAutomatic BuildTrigger = "automatic"
+
+	// BuildTriggerSchedule builds were created at a time matched by the job's
+	// schedule.
+	BuildTriggerSchedule BuildTrigger = "schedule"
+)
+
 type Build struct {
 	ID                   int           `json:"id"`
 	TeamName             string        `json:"team_name"`
@@ -32,6 +53,7 @@ type Build struct {
 	RerunNumber          int           `json:"rerun_number,omitempty"`
 	RerunOf              *RerunOfBuild `json:"rerun_of,omitempty"`
 	CreatedBy            *string       `json:"created_by,omitempty"`
+	IdempotencyKey       string        `json:"idempotency_key,omitempty"`
 }
 
 type RerunOfBuild struct {
@@ -39,6 +61,98 @@ type RerunOfBuild struct {
 	Name string `json:"name,omitempty"`
 }
 
+// RerunBuildRequestBody is the optional body of a request to rerun a build.
+// Vars override those resolved when the build runs.
+type RerunBuildRequestBody struct {
+	Vars map[string]interface{} `json:"vars,omitempty"`
+}
+
+// CreateJobBuildRequestBody is the optional body of a request to trigger a
+// job. Inputs pins the given inputs to the latest version matching each
+// partial version, for this build only.
+type CreateJobBuildRequestBody struct {
+	Inputs map[string]Version `json:"inputs,omitempty"`
+}
+
+const (
+	BuildFilterQueryStatus      = "status"
+	BuildFilterQueryPipeline    = "pipeline"
+	BuildFilterQueryJob         = "job"
+	BuildFilterQueryMinDuration = "min_duration"
+	BuildFilterQueryWorker      = "worker"
+	BuildFilterQuerySortBy      = "sort_by"
+	BuildFilterQueryReverse     = "reverse"
+)
+
+type BuildSortField string
+
+const (
+	BuildSortByID       BuildSortField = "id"
+	BuildSortByName     BuildSortField = "name"
+	BuildSortByStatus   BuildSortField = "status"
+	BuildSortByStart    BuildSortField = "start"
+	BuildSortByEnd      BuildSortField = "end"
+	BuildSortByDuration BuildSortField = "duration"
+)
+
+// BuildFilter narrows down the builds listed across teams. Pipeline and Job
+// are globs matched against the names of the build's pipeline and job.
+// Builds can only be found by Worker while their containers are still around.
+//
+// Builds are listed newest first unless SortBy says otherwise; ids, times and
+// durations sort largest first, names and statuses alphabetically. Reverse
+// flips the order.
+type BuildFilter struct {
+	Statuses    []BuildStatus
+	Pipeline    string
+	Job         string
+	MinDuration time.Duration
+	Worker      string
+
+	SortBy  BuildSortField
+	Reverse bool
+}
+
+// Sorted returns whether the builds are listed in any order other than
+// newest first. Such listings are not paginated.
+func (filter BuildFilter) Sorted() bool {
+	return (filter.SortBy != "" && filter.SortBy != BuildSortByID) || filter.Reverse
+}
+
+func (filter BuildFilter) QueryParams() url.Values {
+	params := url.Values{}
+
+	for _, status := range filter.Statuses {
+		params.Add(BuildFilterQueryStatus, string(status))
+	}
+
+	if filter.Pipeline != "" {
+		params.Set(BuildFilterQueryPipeline, filter.Pipeline)
+	}
+
+	if filter.Job != "" {
+		params.Set(BuildFilterQueryJob, filter.Job)
+	}
+
+	if filter.MinDuration > 0 {
+		params.Set(BuildFilterQueryMinDuration, filter.MinDuration.String())
+	}
+
+	if filter.Worker != "" {
+		params.Set(BuildFilterQueryWorker, filter.Worker)
+	}
+
+	if filter.SortBy != "" && filter.SortBy != BuildSortByID {
+		params.Set(BuildFilterQuerySortBy, string(filter.SortBy))
+	}
+
+	if filter.Reverse {
+		params.Set(BuildFilterQueryReverse, "true")
+	}
+
+	return params
+}
+
 func (b Build) IsRunning() bool {
 	switch BuildStatus(b.Status) {
 	case StatusPending, StatusStarted:
@@ -0,0 +1,31 @@
+package atc
+
+const (
+	PlanNodeStatePending   = "pending"
+	PlanNodeStateRunning   = "running"
+	PlanNodeStateSucceeded = "succeeded"
+	PlanNodeStateFailed    = "failed"
+	PlanNodeStateSkipped   = "skipped"
+)
+
+// BuildPlanStatus is the state of each step in a build's plan, so that
+// tooling doesn't need to reconstruct it from the build's event stream.
+type BuildPlanStatus struct {
+	BuildID int              `json:"build_id"`
+	Status  BuildStatus      `json:"status"`
+	Steps   []PlanNodeStatus `json:"steps"`
+}
+
+type PlanNodeStatus struct {
+	ID    PlanID `json:"id"`
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+
+	Worker string `json:"worker,omitempty"`
+	Error  string `json:"error,omitempty"`
+
+	InitializeTime int64 `json:"initialize_time,omitempty"`
+	StartTime      int64 `json:"start_time,omitempty"`
+	EndTime        int64 `json:"end_time,omitempty"`
+}
@@ -23,3 +23,24 @@ type VersionNotProvidedError struct {
 func (err VersionNotProvidedError) Error() string {
 	return fmt.Sprintf("version for input %s not provided", err.Input)
 }
+
+// AcrossVersionsNotProvidedError is returned when an 'across' step iterates
+// over the versions of a resource which were not provided to the Planner.
+type AcrossVersionsNotProvidedError struct {
+	Resource string
+}
+
+func (err AcrossVersionsNotProvidedError) Error() string {
+	return fmt.Sprintf("versions of resource %s not provided", err.Resource)
+}
+
+// UnexpandedStepTemplateError is returned when a 'use' step is planned. Step
+// templates are expanded when the pipeline is set, so this only happens for
+// configs which were saved some other way.
+type UnexpandedStepTemplateError struct {
+	Template string
+}
+
+func (err UnexpandedStepTemplateError) Error() string {
+	return fmt.Sprintf("step template %s was not expanded", err.Template)
+}
@@ -17,16 +17,26 @@ func NewPlanner(planFactory atc.PlanFactory) Planner {
 
 func (planner Planner) Create(
 	planConfig atc.StepConfig,
+	defaults atc.StepDefaults,
 	resources db.SchedulerResources,
 	resourceTypes atc.VersionedResourceTypes,
 	inputs []db.BuildInput,
+	versions map[string][]atc.Version,
 ) (atc.Plan, error) {
 	visitor := &planVisitor{
 		planFactory: planner.planFactory,
 
+		defaults:      defaults,
 		resources:     resources,
 		resourceTypes: resourceTypes,
 		inputs:        inputs,
+		versions:      versions,
+
+		checks:   map[string]atc.PlanID{},
+		iterated: map[string]atc.Version{},
+		steps:    map[string][]atc.PlanID{},
+		timedOut: map[atc.StepConfig]bool{},
+		retried:  map[atc.StepConfig]bool{},
 	}
 
 	err := planConfig.Visit(visitor)
@@ -40,35 +50,85 @@ func (planner Planner) Create(
 type planVisitor struct {
 	planFactory atc.PlanFactory
 
+	defaults      atc.StepDefaults
 	resources     db.SchedulerResources
 	resourceTypes atc.VersionedResourceTypes
 	inputs        []db.BuildInput
 
+	// the versions of resources iterated over by `across:` steps, by
+	// resource name
+	versions map[string][]atc.Version
+
+	// the check plans which run before the step being visited, by resource
+	// name, so that a get following a check fetches the version it found
+	checks map[string]atc.PlanID
+
+	// the versions being iterated over by the `across:` steps enclosing the
+	// step being visited, by resource name, so that a get fetches the version
+	// of its iteration rather than the build's input
+	iterated map[string]atc.Version
+
+	// the plans of the steps visited so far, by name, so that a `when:`
+	// condition can refer to their statuses
+	steps map[string][]atc.PlanID
+
+	// the steps which are wrapped by their own `timeout:` or `attempts:`, so
+	// that the defaults aren't applied to them as well
+	timedOut map[atc.StepConfig]bool
+	retried  map[atc.StepConfig]bool
+
 	plan atc.Plan
 }
 
 func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
+	return visitor.withDefaultAttempts(step, func() error {
+		return visitor.planTask(step)
+	})
+}
+
+func (visitor *planVisitor) planTask(step *atc.TaskStep) error {
+	config := step.Config
+	if config != nil && config.ImageResource == nil && config.RootfsURI == "" &&
+		step.ImageArtifactName == "" && visitor.defaults.ImageResource != nil {
+		withImage := *config
+		withImage.ImageResource = visitor.defaults.ImageResource
+		config = &withImage
+	}
+
 	visitor.plan = visitor.planFactory.NewPlan(atc.TaskPlan{
 		Name:              step.Name,
 		Privileged:        step.Privileged,
-		Config:            step.Config,
+		Config:            config,
 		Limits:            step.Limits,
 		ConfigPath:        step.ConfigPath,
 		Vars:              step.Vars,
-		Tags:              step.Tags,
+		Tags:              visitor.defaultTags(step.Tags),
+		Arch:              visitor.defaults.Arch,
 		Params:            step.Params,
 		InputMapping:      step.InputMapping,
 		OutputMapping:     step.OutputMapping,
 		ImageArtifactName: step.ImageArtifactName,
-		Timeout:           step.Timeout,
+		Timeout:           visitor.defaultTimeout(step, step.Timeout),
+		BuildToken:        step.BuildToken,
+		Memoize:           step.Memoize,
+		Services:          step.Services,
+		RegistryMirror:    step.RegistryMirror,
 
 		VersionedResourceTypes: visitor.resourceTypes,
 	})
 
+	visitor.recordStep(step.Name, visitor.plan.ID)
+
 	return nil
 }
 
 func (visitor *planVisitor) VisitGet(step *atc.GetStep) error {
+	return visitor.withDefaultAttempts(step, func() error {
+		return visitor.planGet(step)
+	})
+}
+
+func (visitor *planVisitor) planGet(step *atc.GetStep) error {
 	resourceName := step.Resource
 	if resourceName == "" {
 		resourceName = step.Name
@@ -79,38 +139,106 @@ func (visitor *planVisitor) VisitGet(step *atc.GetStep) error {
 		return UnknownResourceError{resourceName}
 	}
 
-	var version atc.Version
-	for _, input := range visitor.inputs {
-		if input.Name == step.Name {
-			version = atc.Version(input.Version)
-			break
+	getPlan := atc.GetPlan{
+		Name: step.Name,
+
+		Resource: resourceName,
+		Params:   step.Params,
+		Tags:     visitor.defaultTags(step.Tags),
+		Arch:     visitor.defaults.Arch,
+		Timeout:  visitor.defaultTimeout(step, step.Timeout),
+		Fresh:    step.Fresh,
+
+		RegistryMirror: step.RegistryMirror,
+
+		VersionedResourceTypes: visitor.resourceTypes,
+	}
+
+	checkID, checked := visitor.checks[resourceName]
+	iteratedVersion, iterated := visitor.iterated[resourceName]
+	if checked {
+		getPlan.VersionFrom = &checkID
+		getPlan.RecordInput = true
+	} else if iterated {
+		getPlan.Version = &iteratedVersion
+	} else {
+		var version atc.Version
+		for _, input := range visitor.inputs {
+			if input.Name == step.Name {
+				version = atc.Version(input.Version)
+				break
+			}
+		}
+
+		if version == nil {
+			return VersionNotProvidedError{step.Name}
+		}
+
+		getPlan.Version = &version
+
+		if step.Version != nil {
+			if step.Version.Since != nil {
+				getPlan.VersionSince = &step.Version.Since
+				getPlan.RecordInput = true
+			} else if step.Version.NewestAfterPassed {
+				getPlan.VersionSince = &version
+				getPlan.RecordInput = true
+			}
 		}
 	}
 
-	if version == nil {
-		return VersionNotProvidedError{step.Name}
+	resource.ApplySourceDefaults(visitor.resourceTypes)
+
+	getPlan.Type = resource.Type
+	getPlan.Source = resource.Source
+
+	visitor.plan = visitor.planFactory.NewPlan(getPlan)
+
+	visitor.recordStep(step.Name, visitor.plan.ID)
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitCheck(step *atc.CheckStep) error {
+	resourceName := step.ResourceName()
+
+	resource, found := visitor.resources.Lookup(resourceName)
+	if !found {
+		return UnknownResourceError{resourceName}
 	}
 
 	resource.ApplySourceDefaults(visitor.resourceTypes)
 
-	visitor.plan = visitor.planFactory.NewPlan(atc.GetPlan{
+	visitor.plan = visitor.planFactory.NewPlan(atc.CheckPlan{
 		Name: step.Name,
 
-		Type:     resource.Type,
-		Resource: resourceName,
-		Source:   resource.Source,
-		Params:   step.Params,
-		Version:  &version,
-		Tags:     step.Tags,
-		Timeout:  step.Timeout,
+		Type:      resource.Type,
+		Source:    resource.Source,
+		Resource:  resourceName,
+		InJobPlan: true,
+		Tags:      visitor.defaultTags(step.Tags),
+		Timeout:   visitor.defaultTimeout(step, step.Timeout),
 
 		VersionedResourceTypes: visitor.resourceTypes,
 	})
 
+	if !visitor.retried[step] && visitor.defaults.Attempts > 1 {
+		visitor.plan.Check.Attempts = visitor.defaults.Attempts
+	}
+
+	visitor.checks[resourceName] = visitor.plan.ID
+	visitor.recordStep(step.Name, visitor.plan.ID)
+
 	return nil
 }
 
 func (visitor *planVisitor) VisitPut(step *atc.PutStep) error {
+	return visitor.withDefaultAttempts(step, func() error {
+		return visitor.planPut(step)
+	})
+}
+
+func (visitor *planVisitor) planPut(step *atc.PutStep) error {
 	logicalName := step.Name
 
 	resourceName := step.Resource
@@ -135,14 +263,23 @@ func (visitor *planVisitor) VisitPut(step *atc.PutStep) error {
 
 		Inputs: step.Inputs,
 
-		Tags:    step.Tags,
-		Timeout: step.Timeout,
+		Tags:           visitor.defaultTags(step.Tags),
+		Arch:           visitor.defaults.Arch,
+		Timeout:        visitor.defaultTimeout(step, step.Timeout),
+		RegistryMirror: step.RegistryMirror,
 
 		VersionedResourceTypes: visitor.resourceTypes,
 	}
 
 	putPlan := visitor.planFactory.NewPlan(atcPutPlan)
 
+	visitor.recordStep(logicalName, putPlan.ID)
+
+	if step.NoGet {
+		visitor.plan = putPlan
+		return nil
+	}
+
 	dependentGetPlan := visitor.planFactory.NewPlan(atc.GetPlan{
 		Name:        logicalName,
 		Resource:    resourceName,
@@ -151,12 +288,18 @@ func (visitor *planVisitor) VisitPut(step *atc.PutStep) error {
 		Params:      step.GetParams,
 		VersionFrom: &putPlan.ID,
 
-		Tags:    step.Tags,
-		Timeout: step.Timeout,
+		Tags:           visitor.defaultTags(step.Tags),
+		Arch:           visitor.defaults.Arch,
+		Timeout:        visitor.defaultTimeout(step, step.Timeout),
+		RegistryMirror: step.RegistryMirror,
 
 		VersionedResourceTypes: visitor.resourceTypes,
 	})
 
+	// the dependent get runs after the put, so it decides the step's status
+	// if it runs
+	visitor.recordStep(logicalName, dependentGetPlan.ID)
+
 	visitor.plan = visitor.planFactory.NewPlan(atc.OnSuccessPlan{
 		Step: putPlan,
 		Next: dependentGetPlan,
@@ -185,7 +328,10 @@ func (visitor *planVisitor) VisitDo(step *atc.DoStep) error {
 func (visitor *planVisitor) VisitInParallel(step *atc.InParallelStep) error {
 	var steps []atc.Plan
 
+	checks := visitor.checks
 	for _, sub := range step.Config.Steps {
+		visitor.checks = copyChecks(checks)
+
 		err := sub.Config.Visit(visitor)
 		if err != nil {
 			return err
@@ -193,6 +339,7 @@ func (visitor *planVisitor) VisitInParallel(step *atc.InParallelStep) error {
 
 		steps = append(steps, visitor.plan)
 	}
+	visitor.checks = checks
 
 	visitor.plan = visitor.planFactory.NewPlan(atc.InParallelPlan{
 		Steps:    steps,
@@ -204,9 +351,19 @@ func (visitor *planVisitor) VisitInParallel(step *atc.InParallelStep) error {
 }
 
 func (visitor *planVisitor) VisitAcross(step *atc.AcrossStep) error {
-	vars := make([]atc.AcrossVar, len(step.Vars))
-	for i, v := range step.Vars {
-		vars[i] = atc.AcrossVar(v)
+	resolved, err := visitor.resolveAcrossVars(step.Vars)
+	if err != nil {
+		return err
+	}
+
+	vars := make([]atc.AcrossVar, len(resolved))
+	for i, v := range resolved {
+		vars[i] = atc.AcrossVar{
+			Var:         v.Var,
+			Values:      v.Values,
+			ValuesFile:  v.ValuesFile,
+			MaxInFlight: v.MaxInFlight,
+		}
 	}
 
 	acrossPlan := atc.AcrossPlan{
@@ -214,7 +371,32 @@ func (visitor *planVisitor) VisitAcross(step *atc.AcrossStep) error {
 		Steps:    []atc.VarScopedPlan{},
 		FailFast: step.FailFast,
 	}
-	for _, vals := range cartesianProduct(step.Vars) {
+	checks := visitor.checks
+	iterated := visitor.iterated
+
+	if hasValuesFile(step.Vars) {
+		// the values aren't known until the step runs, so the substep is
+		// planned once and run for each combination of values
+		visitor.checks = copyChecks(checks)
+
+		err := step.Step.Visit(visitor)
+		if err != nil {
+			return err
+		}
+
+		template := visitor.plan
+		acrossPlan.SubStepTemplate = &template
+
+		visitor.checks = checks
+		visitor.plan = visitor.planFactory.NewPlan(acrossPlan)
+
+		return nil
+	}
+
+	for _, vals := range cartesianProduct(resolved) {
+		visitor.checks = copyChecks(checks)
+		visitor.iterated = iteratedVersions(iterated, resolved, vals)
+
 		err := step.Step.Visit(visitor)
 		if err != nil {
 			return err
@@ -225,11 +407,82 @@ func (visitor *planVisitor) VisitAcross(step *atc.AcrossStep) error {
 		})
 	}
 
+	visitor.checks = checks
+	visitor.iterated = iterated
+
 	visitor.plan = visitor.planFactory.NewPlan(acrossPlan)
 
 	return nil
 }
 
+// resolveAcrossVars fills in the values of the vars which iterate over
+// resource versions.
+func (visitor *planVisitor) resolveAcrossVars(vars []atc.AcrossVarConfig) ([]atc.AcrossVarConfig, error) {
+	resolved := make([]atc.AcrossVarConfig, len(vars))
+	for i, v := range vars {
+		resolved[i] = v
+
+		if v.Versions == nil {
+			continue
+		}
+
+		versions, found := visitor.versions[v.Versions.Resource]
+		if !found {
+			return nil, AcrossVersionsNotProvidedError{v.Versions.Resource}
+		}
+
+		limit := v.Versions.EffectiveLimit()
+		if len(versions) > limit {
+			versions = versions[len(versions)-limit:]
+		}
+
+		resolved[i].Values = make([]interface{}, len(versions))
+		for j, version := range versions {
+			resolved[i].Values[j] = version
+		}
+	}
+
+	return resolved, nil
+}
+
+// iteratedVersions returns the versions being iterated over by the enclosing
+// steps along with those of the given combination of values.
+func iteratedVersions(enclosing map[string]atc.Version, vars []atc.AcrossVarConfig, vals []interface{}) map[string]atc.Version {
+	iterated := make(map[string]atc.Version, len(enclosing))
+	for name, version := range enclosing {
+		iterated[name] = version
+	}
+
+	for i, v := range vars {
+		if v.Versions != nil {
+			iterated[v.Versions.Resource] = vals[i].(atc.Version)
+		}
+	}
+
+	return iterated
+}
+
+func hasValuesFile(vars []atc.AcrossVarConfig) bool {
+	for _, v := range vars {
+		if v.ValuesFile != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// copyChecks is used for steps which may run at the same time, so that a check
+// in one of them is not used by a get in another.
+func copyChecks(checks map[string]atc.PlanID) map[string]atc.PlanID {
+	copied := make(map[string]atc.PlanID, len(checks))
+	for name, id := range checks {
+		copied[name] = id
+	}
+
+	return copied
+}
+
 func cartesianProduct(vars []atc.AcrossVarConfig) [][]interface{} {
 	if len(vars) == 0 {
 		return make([][]interface{}, 1)
@@ -252,8 +505,11 @@ func (visitor *planVisitor) VisitSetPipeline(step *atc.SetPipelineStep) error {
 		Vars:         step.Vars,
 		VarFiles:     step.VarFiles,
 		InstanceVars: step.InstanceVars,
+		DryRun:       step.DryRun,
 	})
 
+	visitor.recordStep(step.Name, visitor.plan.ID)
+
 	return nil
 }
 
@@ -265,9 +521,108 @@ func (visitor *planVisitor) VisitLoadVar(step *atc.LoadVarStep) error {
 		Reveal: step.Reveal,
 	})
 
+	visitor.recordStep(step.Name, visitor.plan.ID)
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitLoadVars(step *atc.LoadVarsStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.LoadVarsPlan{
+		Files:  step.Files,
+		Format: step.Format,
+		Reveal: step.Reveal,
+	})
+
+	visitor.recordStep(step.Files, visitor.plan.ID)
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitApproval(step *atc.ApprovalStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.ApprovalPlan{
+		Name:      step.Name,
+		Approvers: step.Approvers,
+	})
+
+	visitor.recordStep(step.Name, visitor.plan.ID)
+
 	return nil
 }
 
+func (visitor *planVisitor) VisitPublish(step *atc.PublishStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.PublishPlan{
+		Name: step.Name,
+	})
+
+	visitor.recordStep(step.Name, visitor.plan.ID)
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitUse(step *atc.UseStep) error {
+	return UnexpandedStepTemplateError{step.Config.Template}
+}
+
+// withDefaultAttempts plans a step as many times as the default attempts, in
+// the same way as if the step was configured with `attempts:`, unless it
+// already is.
+func (visitor *planVisitor) withDefaultAttempts(step atc.StepConfig, plan func() error) error {
+	attempts := visitor.defaults.Attempts
+	if attempts <= 1 || visitor.retried[step] {
+		return plan()
+	}
+
+	retryPlan := make(atc.RetryPlan, attempts)
+	for i := range retryPlan {
+		err := plan()
+		if err != nil {
+			return err
+		}
+
+		retryPlan[i] = visitor.plan
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(retryPlan)
+
+	return nil
+}
+
+func (visitor *planVisitor) defaultTimeout(step atc.StepConfig, timeout string) string {
+	if timeout != "" || visitor.timedOut[step] {
+		return timeout
+	}
+
+	return visitor.defaults.Timeout
+}
+
+func (visitor *planVisitor) defaultTags(tags atc.Tags) atc.Tags {
+	if len(tags) != 0 {
+		return tags
+	}
+
+	return visitor.defaults.Tags
+}
+
+// coreStep returns the step wrapped by any modifiers, e.g. the task of
+// `task:` configured with `timeout:` and `attempts:`.
+func coreStep(step atc.StepConfig) atc.StepConfig {
+	for {
+		wrapper, isWrapper := step.(atc.StepWrapper)
+		if !isWrapper {
+			return step
+		}
+
+		step = wrapper.Unwrap()
+	}
+}
+
+// recordStep records a plan of the named step. A step may have more than one
+// plan, e.g. when it has attempts, in which case they are recorded in the
+// order they may run.
+func (visitor *planVisitor) recordStep(name string, id atc.PlanID) {
+	visitor.steps[name] = append(visitor.steps[name], id)
+}
+
 func (visitor *planVisitor) VisitTry(step *atc.TryStep) error {
 	err := step.Step.Config.Visit(visitor)
 	if err != nil {
@@ -275,27 +630,64 @@ func (visitor *planVisitor) VisitTry(step *atc.TryStep) error {
 	}
 
 	visitor.plan = visitor.planFactory.NewPlan(atc.TryPlan{
-		Step: visitor.plan,
+		Step:     visitor.plan,
+		ErrorVar: step.ErrorVar,
 	})
 
 	return nil
 }
 
 func (visitor *planVisitor) VisitTimeout(step *atc.TimeoutStep) error {
+	visitor.timedOut[coreStep(step.Step)] = true
+
 	err := step.Step.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	visitor.plan = visitor.planFactory.NewPlan(atc.TimeoutPlan{
-		Duration: step.Duration,
-		Step:     visitor.plan,
-	})
+	plan := atc.TimeoutPlan{
+		Duration:     step.Duration,
+		SoftDuration: step.SoftDuration,
+		Step:         visitor.plan,
+	}
+
+	if step.OnSoftTimeout != nil {
+		err := step.OnSoftTimeout.Config.Visit(visitor)
+		if err != nil {
+			return err
+		}
+
+		hook := visitor.plan
+		plan.OnSoftTimeout = &hook
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(plan)
 
 	return nil
 }
 
 func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
+	visitor.retried[coreStep(step.Step)] = true
+
+	if check, ok := coreStep(step.Step).(*atc.CheckStep); ok {
+		// gets following the check take their version from its plan ID, so the
+		// attempts are run by the check step rather than by a retry plan
+		err := step.Step.Visit(visitor)
+		if err != nil {
+			return err
+		}
+
+		// the check may be wrapped, e.g. in a timeout
+		visitor.plan.Each(func(plan *atc.Plan) {
+			if plan.Check != nil && plan.Check.Name == check.Name {
+				plan.Check.Attempts = step.Attempts
+				plan.Check.RetryBackoff = step.Backoff
+			}
+		})
+
+		return nil
+	}
+
 	retryStep := make(atc.RetryPlan, step.Attempts)
 
 	for i := 0; i < step.Attempts; i++ {
@@ -308,6 +700,53 @@ func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
 	}
 
 	visitor.plan = visitor.planFactory.NewPlan(retryStep)
+	visitor.plan.RetryBackoff = step.Backoff
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitSemaphore(step *atc.SemaphoreStep) error {
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.SemaphorePlan{
+		Step:  visitor.plan,
+		Name:  step.Semaphore.Name,
+		Limit: step.Semaphore.EffectiveLimit(),
+	})
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitWhen(step *atc.WhenStep) error {
+	condition, err := atc.ParseWhenCondition(step.Condition)
+	if err != nil {
+		return err
+	}
+
+	// the steps are looked up before the wrapped step is planned, as the
+	// condition is evaluated before it runs
+	var steps map[string][]atc.PlanID
+	for _, name := range condition.StepNames() {
+		if steps == nil {
+			steps = map[string][]atc.PlanID{}
+		}
+
+		steps[name] = visitor.steps[name]
+	}
+
+	err = step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.WhenPlan{
+		Step:      visitor.plan,
+		Condition: step.Condition,
+		Steps:     steps,
+	})
 
 	return nil
 }
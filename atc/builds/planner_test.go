@@ -25,8 +25,10 @@ func TestPlanner(t *testing.T) {
 type PlannerTest struct {
 	Title string
 
-	Config atc.StepConfig
-	Inputs []db.BuildInput
+	Config   atc.StepConfig
+	Defaults atc.StepDefaults
+	Inputs   []db.BuildInput
+	Versions map[string][]atc.Version
 
 	CompareIDs bool
 	PlanJSON   string
@@ -72,6 +74,9 @@ var factoryTests = []PlannerTest{
 			Version:  &atc.VersionConfig{Pinned: atc.Version{"doesnt": "matter"}},
 			Tags:     atc.Tags{"tag-1", "tag-2"},
 			Timeout:  "1h",
+			Fresh:    true,
+
+			RegistryMirror: &atc.RegistryMirror{Host: "some-mirror", Password: "((mirror-password))"},
 		},
 		Inputs: []db.BuildInput{
 			{
@@ -90,6 +95,8 @@ var factoryTests = []PlannerTest{
 				"version": {"some":"version"},
 				"tags": ["tag-1", "tag-2"],
 				"timeout": "1h",
+				"fresh": true,
+				"registry_mirror": {"host": "some-mirror", "password": "((mirror-password))"},
 				"resource_types": [
 					{
 						"name": "some-resource-type",
@@ -139,6 +146,76 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "get step with a version to fetch the newest since",
+		Config: &atc.GetStep{
+			Name:     "some-name",
+			Resource: "some-base-resource",
+			Version:  &atc.VersionConfig{Since: atc.Version{"some": "boundary"}},
+		},
+		Inputs: []db.BuildInput{
+			{
+				Name:    "some-name",
+				Version: atc.Version{"some": "version"},
+			},
+		},
+		PlanJSON: `{
+			"id": "(unique)",
+			"get": {
+				"name": "some-name",
+				"type": "some-base-resource-type",
+				"resource": "some-base-resource",
+				"source": {"some":"source","default-key":"default-value"},
+				"version": {"some":"version"},
+				"version_since": {"some":"boundary"},
+				"record_input": true,
+				"resource_types": [
+					{
+						"name": "some-resource-type",
+						"type": "some-base-resource-type",
+						"source": {"some": "type-source"},
+						"defaults": {"default-key":"default-value"},
+						"version": {"some": "type-version"}
+					}
+				]
+			}
+		}`,
+	},
+	{
+		Title: "get step fetching the newest version after passed",
+		Config: &atc.GetStep{
+			Name:     "some-name",
+			Resource: "some-base-resource",
+			Version:  &atc.VersionConfig{NewestAfterPassed: true},
+		},
+		Inputs: []db.BuildInput{
+			{
+				Name:    "some-name",
+				Version: atc.Version{"some": "version"},
+			},
+		},
+		PlanJSON: `{
+			"id": "(unique)",
+			"get": {
+				"name": "some-name",
+				"type": "some-base-resource-type",
+				"resource": "some-base-resource",
+				"source": {"some":"source","default-key":"default-value"},
+				"version": {"some":"version"},
+				"version_since": {"some":"version"},
+				"record_input": true,
+				"resource_types": [
+					{
+						"name": "some-resource-type",
+						"type": "some-base-resource-type",
+						"source": {"some": "type-source"},
+						"defaults": {"default-key":"default-value"},
+						"version": {"some": "type-version"}
+					}
+				]
+			}
+		}`,
+	},
 	{
 		Title: "get step with unknown resource",
 		Config: &atc.GetStep{
@@ -156,39 +233,83 @@ var factoryTests = []PlannerTest{
 		Err: builds.VersionNotProvidedError{Input: "some-name"},
 	},
 	{
-		Title: "put step",
-		Config: &atc.PutStep{
-			Name:      "some-name",
-			Resource:  "some-resource",
-			Params:    atc.Params{"some": "params"},
-			Tags:      atc.Tags{"tag-1", "tag-2"},
-			Inputs:    &atc.InputsConfig{All: true},
-			GetParams: atc.Params{"some": "get-params"},
-			Timeout:   "1h",
+		Title: "check step",
+		Config: &atc.CheckStep{
+			Name:     "some-name",
+			Resource: "some-resource",
+			Tags:     atc.Tags{"tag-1", "tag-2"},
+			Timeout:  "1h",
 		},
-		Inputs: []db.BuildInput{
-			{
-				Name:    "some-name",
-				Version: atc.Version{"some": "version"},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"check": {
+				"name": "some-name",
+				"type": "some-resource-type",
+				"resource": "some-resource",
+				"source": {"some":"source","default-key":"default-value"},
+				"tags": ["tag-1", "tag-2"],
+				"timeout": "1h",
+				"in_job_plan": true,
+				"resource_types": [
+					{
+						"name": "some-resource-type",
+						"type": "some-base-resource-type",
+						"source": {"some": "type-source"},
+						"defaults": {"default-key":"default-value"},
+						"version": {"some": "type-version"}
+					}
+				]
+			}
+		}`,
+	},
+	{
+		Title: "check step with unknown resource",
+		Config: &atc.CheckStep{
+			Name: "some-unknown-resource",
+		},
+		Err: builds.UnknownResourceError{Resource: "some-unknown-resource"},
+	},
+	{
+		Title: "check step with attempts",
+		Config: &atc.DoStep{
+			Steps: []atc.Step{
+				{
+					Config: &atc.RetryStep{
+						Step: &atc.CheckStep{
+							Name: "some-base-resource",
+						},
+						Attempts: 3,
+						Backoff: &atc.RetryBackoffConfig{
+							Initial: "10s",
+						},
+					},
+				},
+				{
+					Config: &atc.GetStep{
+						Name:     "some-name",
+						Resource: "some-base-resource",
+					},
+				},
 			},
 		},
 
-		// the ids are significant for versioned_from
+		// the attempts are run by the check itself, so the get's version_from
+		// is its id rather than one of many retry attempts
 		CompareIDs: true,
 		PlanJSON: `{
 			"id": "3",
-			"on_success": {
-				"step": {
+			"do": [
+				{
 					"id": "1",
-					"put": {
-						"name": "some-name",
-						"type": "some-resource-type",
-						"resource": "some-resource",
-						"inputs": "all",
+					"check": {
+						"name": "some-base-resource",
+						"type": "some-base-resource-type",
+						"resource": "some-base-resource",
 						"source": {"some":"source","default-key":"default-value"},
-						"params": {"some":"params"},
-						"tags": ["tag-1", "tag-2"],
-						"timeout": "1h",
+						"in_job_plan": true,
+						"attempts": 3,
+						"retry_backoff": {"initial": "10s"},
 						"resource_types": [
 							{
 								"name": "some-resource-type",
@@ -200,17 +321,15 @@ var factoryTests = []PlannerTest{
 						]
 					}
 				},
-				"on_success": {
+				{
 					"id": "2",
 					"get": {
 						"name": "some-name",
-						"type": "some-resource-type",
-						"resource": "some-resource",
+						"type": "some-base-resource-type",
+						"resource": "some-base-resource",
 						"source": {"some":"source","default-key":"default-value"},
-						"params": {"some":"get-params"},
-						"tags": ["tag-1", "tag-2"],
 						"version_from": "1",
-						"timeout": "1h",
+						"record_input": true,
 						"resource_types": [
 							{
 								"name": "some-resource-type",
@@ -222,376 +341,1094 @@ var factoryTests = []PlannerTest{
 						]
 					}
 				}
-			}
+			]
 		}`,
 	},
 	{
-		Title: "task step",
-
-		Config: &atc.TaskStep{
-			Name:       "some-task",
-			Privileged: true,
-			Config: &atc.TaskConfig{
-				Platform: "linux",
-				Run:      atc.TaskRunConfig{Path: "hello"},
+		Title: "check step with attempts and a soft timeout",
+		Config: &atc.DoStep{
+			Steps: []atc.Step{
+				{
+					Config: &atc.RetryStep{
+						Step: &atc.TimeoutStep{
+							Step: &atc.CheckStep{
+								Name: "some-base-resource",
+							},
+							SoftDuration: "5m",
+						},
+						Attempts: 3,
+					},
+				},
+				{
+					Config: &atc.GetStep{
+						Name:     "some-name",
+						Resource: "some-base-resource",
+					},
+				},
 			},
-			ConfigPath:        "some-task-file",
-			Vars:              atc.Params{"some": "vars"},
-			Params:            atc.TaskEnv{"SOME": "PARAMS"},
-			Tags:              atc.Tags{"tag-1", "tag-2"},
-			InputMapping:      map[string]string{"generic": "specific"},
-			OutputMapping:     map[string]string{"specific": "generic"},
-			ImageArtifactName: "some-image",
-			Timeout:           "1h",
 		},
 
+		// the check is wrapped by the timeout, but still runs the attempts
+		CompareIDs: true,
 		PlanJSON: `{
-			"id": "(unique)",
-			"task": {
-				"name": "some-task",
-				"privileged": true,
-				"config": {
-					"platform": "linux",
-					"run": {"path": "hello"}
+			"id": "4",
+			"do": [
+				{
+					"id": "2",
+					"timeout": {
+						"soft_duration": "5m",
+						"step": {
+							"id": "1",
+							"check": {
+								"name": "some-base-resource",
+								"type": "some-base-resource-type",
+								"resource": "some-base-resource",
+								"source": {"some":"source","default-key":"default-value"},
+								"in_job_plan": true,
+								"attempts": 3,
+								"resource_types": [
+									{
+										"name": "some-resource-type",
+										"type": "some-base-resource-type",
+										"source": {"some": "type-source"},
+										"defaults": {"default-key":"default-value"},
+										"version": {"some": "type-version"}
+									}
+								]
+							}
+						}
+					}
 				},
-				"config_path": "some-task-file",
-				"vars": {"some": "vars"},
-				"params": {"SOME": "PARAMS"},
-				"tags": ["tag-1", "tag-2"],
-				"input_mapping": {"generic": "specific"},
-				"output_mapping": {"specific": "generic"},
-				"image": "some-image",
-				"timeout": "1h",
-				"resource_types": [
-					{
-						"name": "some-resource-type",
+				{
+					"id": "3",
+					"get": {
+						"name": "some-name",
 						"type": "some-base-resource-type",
-						"source": {"some": "type-source"},
-						"defaults": {"default-key":"default-value"},
-						"version": {"some": "type-version"}
+						"resource": "some-base-resource",
+						"source": {"some":"source","default-key":"default-value"},
+						"version_from": "1",
+						"record_input": true,
+						"resource_types": [
+							{
+								"name": "some-resource-type",
+								"type": "some-base-resource-type",
+								"source": {"some": "type-source"},
+								"defaults": {"default-key":"default-value"},
+								"version": {"some": "type-version"}
+							}
+						]
 					}
-				]
-			}
+				}
+			]
 		}`,
 	},
 	{
-		Title: "task step with top level container limits",
-
-		Config: &atc.TaskStep{
-			Name:       "some-task",
-			Privileged: true,
-			Config: &atc.TaskConfig{
-				Platform: "linux",
-				Run:      atc.TaskRunConfig{Path: "hello"},
-			},
-			Limits: &atc.ContainerLimits{
-				CPU:    newCPULimit(456),
-				Memory: newMemoryLimit(2048),
+		Title: "get step following a check step",
+		Config: &atc.DoStep{
+			Steps: []atc.Step{
+				{
+					Config: &atc.CheckStep{
+						Name: "some-base-resource",
+					},
+				},
+				{
+					Config: &atc.GetStep{
+						Name:     "some-name",
+						Resource: "some-base-resource",
+					},
+				},
 			},
-			ConfigPath:        "some-task-file",
-			Vars:              atc.Params{"some": "vars"},
-			Params:            atc.TaskEnv{"SOME": "PARAMS"},
-			Tags:              atc.Tags{"tag-1", "tag-2"},
-			InputMapping:      map[string]string{"generic": "specific"},
-			OutputMapping:     map[string]string{"specific": "generic"},
-			ImageArtifactName: "some-image",
-			Timeout:           "1h",
 		},
 
+		// the ids are significant for version_from
+		CompareIDs: true,
 		PlanJSON: `{
-			"id": "(unique)",
-			"task": {
-				"name": "some-task",
-				"privileged": true,
-				"config": {
-					"platform": "linux",
-					"run": {"path": "hello"}
-				},
-				"config_path": "some-task-file",
-				"vars": {"some": "vars"},
-				"container_limits": {"cpu": 456, "memory": 2048},
-				"params": {"SOME": "PARAMS"},
-				"tags": ["tag-1", "tag-2"],
+			"id": "3",
+			"do": [
+				{
+					"id": "1",
+					"check": {
+						"name": "some-base-resource",
+						"type": "some-base-resource-type",
+						"resource": "some-base-resource",
+						"source": {"some":"source","default-key":"default-value"},
+						"in_job_plan": true,
+						"resource_types": [
+							{
+								"name": "some-resource-type",
+								"type": "some-base-resource-type",
+								"source": {"some": "type-source"},
+								"defaults": {"default-key":"default-value"},
+								"version": {"some": "type-version"}
+							}
+						]
+					}
+				},
+				{
+					"id": "2",
+					"get": {
+						"name": "some-name",
+						"type": "some-base-resource-type",
+						"resource": "some-base-resource",
+						"source": {"some":"source","default-key":"default-value"},
+						"version_from": "1",
+						"record_input": true,
+						"resource_types": [
+							{
+								"name": "some-resource-type",
+								"type": "some-base-resource-type",
+								"source": {"some": "type-source"},
+								"defaults": {"default-key":"default-value"},
+								"version": {"some": "type-version"}
+							}
+						]
+					}
+				}
+			]
+		}`,
+	},
+	{
+		Title: "get step in parallel with a check step",
+		Config: &atc.InParallelStep{
+			Config: atc.InParallelConfig{
+				Steps: []atc.Step{
+					{
+						Config: &atc.CheckStep{
+							Name: "some-base-resource",
+						},
+					},
+					{
+						Config: &atc.GetStep{
+							Name:     "some-name",
+							Resource: "some-base-resource",
+						},
+					},
+				},
+			},
+		},
+		Inputs: []db.BuildInput{
+			{
+				Name:    "some-name",
+				Version: atc.Version{"some": "version"},
+			},
+		},
+
+		CompareIDs: true,
+		PlanJSON: `{
+			"id": "3",
+			"in_parallel": {
+				"steps": [
+					{
+						"id": "1",
+						"check": {
+							"name": "some-base-resource",
+							"type": "some-base-resource-type",
+							"resource": "some-base-resource",
+							"source": {"some":"source","default-key":"default-value"},
+							"in_job_plan": true,
+							"resource_types": [
+								{
+									"name": "some-resource-type",
+									"type": "some-base-resource-type",
+									"source": {"some": "type-source"},
+									"defaults": {"default-key":"default-value"},
+									"version": {"some": "type-version"}
+								}
+							]
+						}
+					},
+					{
+						"id": "2",
+						"get": {
+							"name": "some-name",
+							"type": "some-base-resource-type",
+							"resource": "some-base-resource",
+							"source": {"some":"source","default-key":"default-value"},
+							"version": {"some": "version"},
+							"resource_types": [
+								{
+									"name": "some-resource-type",
+									"type": "some-base-resource-type",
+									"source": {"some": "type-source"},
+									"defaults": {"default-key":"default-value"},
+									"version": {"some": "type-version"}
+								}
+							]
+						}
+					}
+				]
+			}
+		}`,
+	},
+	{
+		Title: "put step",
+		Config: &atc.PutStep{
+			Name:      "some-name",
+			Resource:  "some-resource",
+			Params:    atc.Params{"some": "params"},
+			Tags:      atc.Tags{"tag-1", "tag-2"},
+			Inputs:    &atc.InputsConfig{All: true},
+			GetParams: atc.Params{"some": "get-params"},
+			Timeout:   "1h",
+		},
+		Inputs: []db.BuildInput{
+			{
+				Name:    "some-name",
+				Version: atc.Version{"some": "version"},
+			},
+		},
+
+		// the ids are significant for versioned_from
+		CompareIDs: true,
+		PlanJSON: `{
+			"id": "3",
+			"on_success": {
+				"step": {
+					"id": "1",
+					"put": {
+						"name": "some-name",
+						"type": "some-resource-type",
+						"resource": "some-resource",
+						"inputs": "all",
+						"source": {"some":"source","default-key":"default-value"},
+						"params": {"some":"params"},
+						"tags": ["tag-1", "tag-2"],
+						"timeout": "1h",
+						"resource_types": [
+							{
+								"name": "some-resource-type",
+								"type": "some-base-resource-type",
+								"source": {"some": "type-source"},
+								"defaults": {"default-key":"default-value"},
+								"version": {"some": "type-version"}
+							}
+						]
+					}
+				},
+				"on_success": {
+					"id": "2",
+					"get": {
+						"name": "some-name",
+						"type": "some-resource-type",
+						"resource": "some-resource",
+						"source": {"some":"source","default-key":"default-value"},
+						"params": {"some":"get-params"},
+						"tags": ["tag-1", "tag-2"],
+						"version_from": "1",
+						"timeout": "1h",
+						"resource_types": [
+							{
+								"name": "some-resource-type",
+								"type": "some-base-resource-type",
+								"source": {"some": "type-source"},
+								"defaults": {"default-key":"default-value"},
+								"version": {"some": "type-version"}
+							}
+						]
+					}
+				}
+			}
+		}`,
+	},
+	{
+		Title: "put step without get",
+		Config: &atc.PutStep{
+			Name:      "some-name",
+			Resource:  "some-resource",
+			Params:    atc.Params{"some": "params"},
+			GetParams: atc.Params{"some": "get-params"},
+			NoGet:     true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"put": {
+				"name": "some-name",
+				"type": "some-resource-type",
+				"resource": "some-resource",
+				"source": {"some":"source","default-key":"default-value"},
+				"params": {"some":"params"},
+				"resource_types": [
+					{
+						"name": "some-resource-type",
+						"type": "some-base-resource-type",
+						"source": {"some": "type-source"},
+						"defaults": {"default-key":"default-value"},
+						"version": {"some": "type-version"}
+					}
+				]
+			}
+		}`,
+	},
+	{
+		Title: "task step",
+
+		Config: &atc.TaskStep{
+			Name:       "some-task",
+			Privileged: true,
+			Config: &atc.TaskConfig{
+				Platform: "linux",
+				Run:      atc.TaskRunConfig{Path: "hello"},
+			},
+			ConfigPath:        "some-task-file",
+			Vars:              atc.Params{"some": "vars"},
+			Params:            atc.TaskEnv{"SOME": "PARAMS"},
+			Tags:              atc.Tags{"tag-1", "tag-2"},
+			InputMapping:      map[string]string{"generic": "specific"},
+			OutputMapping:     map[string]string{"specific": "generic"},
+			ImageArtifactName: "some-image",
+			Timeout:           "1h",
+			BuildToken:        true,
+			Memoize:           true,
+			Services: []atc.TaskServiceConfig{
+				{
+					Name:   "some-service",
+					Image:  "some-service-image",
+					Params: atc.TaskEnv{"SERVICE": "PARAMS"},
+					Run:    atc.TaskRunConfig{Path: "serve"},
+				},
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"task": {
+				"name": "some-task",
+				"privileged": true,
+				"config": {
+					"platform": "linux",
+					"run": {"path": "hello"}
+				},
+				"config_path": "some-task-file",
+				"vars": {"some": "vars"},
+				"params": {"SOME": "PARAMS"},
+				"tags": ["tag-1", "tag-2"],
+				"input_mapping": {"generic": "specific"},
+				"output_mapping": {"specific": "generic"},
+				"image": "some-image",
+				"timeout": "1h",
+				"build_token": true,
+				"memoize": true,
+				"services": [
+					{
+						"name": "some-service",
+						"image": "some-service-image",
+						"params": {"SERVICE": "PARAMS"},
+						"run": {"path": "serve"}
+					}
+				],
+				"resource_types": [
+					{
+						"name": "some-resource-type",
+						"type": "some-base-resource-type",
+						"source": {"some": "type-source"},
+						"defaults": {"default-key":"default-value"},
+						"version": {"some": "type-version"}
+					}
+				]
+			}
+		}`,
+	},
+	{
+		Title: "task step with top level container limits",
+
+		Config: &atc.TaskStep{
+			Name:       "some-task",
+			Privileged: true,
+			Config: &atc.TaskConfig{
+				Platform: "linux",
+				Run:      atc.TaskRunConfig{Path: "hello"},
+			},
+			Limits: &atc.ContainerLimits{
+				CPU:    newCPULimit(456),
+				Memory: newMemoryLimit(2048),
+			},
+			ConfigPath:        "some-task-file",
+			Vars:              atc.Params{"some": "vars"},
+			Params:            atc.TaskEnv{"SOME": "PARAMS"},
+			Tags:              atc.Tags{"tag-1", "tag-2"},
+			InputMapping:      map[string]string{"generic": "specific"},
+			OutputMapping:     map[string]string{"specific": "generic"},
+			ImageArtifactName: "some-image",
+			Timeout:           "1h",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"task": {
+				"name": "some-task",
+				"privileged": true,
+				"config": {
+					"platform": "linux",
+					"run": {"path": "hello"}
+				},
+				"config_path": "some-task-file",
+				"vars": {"some": "vars"},
+				"container_limits": {"cpu": 456, "memory": 2048},
+				"params": {"SOME": "PARAMS"},
+				"tags": ["tag-1", "tag-2"],
 				"input_mapping": {"generic": "specific"},
 				"output_mapping": {"specific": "generic"},
 				"image": "some-image",
 				"timeout": "1h",
 				"resource_types": [
 					{
-						"name": "some-resource-type",
-						"type": "some-base-resource-type",
-						"source": {"some": "type-source"},
-						"defaults": {"default-key":"default-value"},
-						"version": {"some": "type-version"}
+						"name": "some-resource-type",
+						"type": "some-base-resource-type",
+						"source": {"some": "type-source"},
+						"defaults": {"default-key":"default-value"},
+						"version": {"some": "type-version"}
+					}
+				]
+			}
+		}`,
+	},
+	{
+		Title: "set_pipeline step",
+
+		Config: &atc.SetPipelineStep{
+			Name:         "some-pipeline",
+			File:         "some-pipeline-file",
+			Vars:         atc.Params{"some": "vars"},
+			VarFiles:     []string{"file-1", "file-2"},
+			InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
+			DryRun:       true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"set_pipeline": {
+				"name": "some-pipeline",
+				"file": "some-pipeline-file",
+				"vars": {"some": "vars"},
+				"var_files": ["file-1", "file-2"],
+				"instance_vars": {"branch": "feature/foo"},
+				"dry_run": true
+			}
+		}`,
+	},
+	{
+		Title: "load_var step",
+
+		Config: &atc.LoadVarStep{
+			Name:   "some-var",
+			File:   "some-var-file",
+			Format: "raw",
+			Reveal: true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"load_var": {
+				"name": "some-var",
+				"file": "some-var-file",
+				"format": "raw",
+				"reveal": true
+			}
+		}`,
+	},
+	{
+		Title: "load_vars step",
+
+		Config: &atc.LoadVarsStep{
+			Files:  "some-artifact/vars/*.yml",
+			Format: "yaml",
+			Reveal: true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"load_vars": {
+				"files": "some-artifact/vars/*.yml",
+				"format": "yaml",
+				"reveal": true
+			}
+		}`,
+	},
+	{
+		Title: "approval step",
+
+		Config: &atc.ApprovalStep{
+			Name:      "deploy-to-prod",
+			Approvers: []string{"some-user"},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"approval": {
+				"name": "deploy-to-prod",
+				"approvers": ["some-user"]
+			}
+		}`,
+	},
+	{
+		Title: "publish step",
+
+		Config: &atc.PublishStep{
+			Name: "some-artifact",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"publish": {
+				"name": "some-artifact"
+			}
+		}`,
+	},
+	{
+		Title: "try step",
+
+		Config: &atc.TryStep{
+			Step: atc.Step{
+				Config: &atc.LoadVarStep{
+					Name: "some-var",
+					File: "some-file",
+				},
+			},
+			ErrorVar: "try_error",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"try": {
+				"step": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				"error_var": "try_error"
+			}
+		}`,
+	},
+	{
+		Title: "do step",
+
+		Config: &atc.DoStep{
+			Steps: []atc.Step{
+				{
+					Config: &atc.LoadVarStep{
+						Name: "some-var",
+						File: "some-file",
+					},
+				},
+				{
+					Config: &atc.LoadVarStep{
+						Name: "some-other-var",
+						File: "some-other-file",
+					},
+				},
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"do": [
+				{
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				{
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-other-var",
+						"file": "some-other-file"
+					}
+				}
+			]
+		}`,
+	},
+	{
+		Title: "in_parallel step",
+
+		Config: &atc.InParallelStep{
+			Config: atc.InParallelConfig{
+				Limit:    "3",
+				FailFast: true,
+				Steps: []atc.Step{
+					{
+						Config: &atc.LoadVarStep{
+							Name: "some-var",
+							File: "some-file",
+						},
+					},
+					{
+						Config: &atc.LoadVarStep{
+							Name: "some-other-var",
+							File: "some-other-file",
+						},
+					},
+				},
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"in_parallel": {
+				"steps": [
+					{
+						"id": "(unique)",
+						"load_var": {
+							"name": "some-var",
+							"file": "some-file"
+						}
+					},
+					{
+						"id": "(unique)",
+						"load_var": {
+							"name": "some-other-var",
+							"file": "some-other-file"
+						}
+					}
+				],
+				"limit": 3,
+				"fail_fast": true
+			}
+		}`,
+	},
+	{
+		Title: "across step",
+
+		Config: &atc.AcrossStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var:         "var1",
+					Values:      []interface{}{"a1", "a2"},
+					MaxInFlight: &atc.MaxInFlightConfig{All: true},
+				},
+				{
+					Var:         "var2",
+					Values:      []interface{}{"b1", "b2"},
+					MaxInFlight: &atc.MaxInFlightConfig{Limit: 1},
+				},
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"across": {
+				"vars": [
+					{
+						"name": "var1",
+						"values": ["a1", "a2"],
+						"max_in_flight": "all"
+					},
+					{
+						"name": "var2",
+						"values": ["b1", "b2"],
+						"max_in_flight": 1
+					}
+				],
+				"steps": [
+					{
+						"values": ["a1", "b1"],
+						"step": {
+							"id": "(unique)",
+							"load_var": {
+								"name": "some-var",
+								"file": "some-file"
+							}
+						}
+					},
+					{
+						"values": ["a1", "b2"],
+						"step": {
+							"id": "(unique)",
+							"load_var": {
+								"name": "some-var",
+								"file": "some-file"
+							}
+						}
+					},
+					{
+						"values": ["a2", "b1"],
+						"step": {
+							"id": "(unique)",
+							"load_var": {
+								"name": "some-var",
+								"file": "some-file"
+							}
+						}
+					},
+					{
+						"values": ["a2", "b2"],
+						"step": {
+							"id": "(unique)",
+							"load_var": {
+								"name": "some-var",
+								"file": "some-file"
+							}
+						}
 					}
 				]
 			}
 		}`,
 	},
 	{
-		Title: "set_pipeline step",
+		Title: "across step with a values file",
 
-		Config: &atc.SetPipelineStep{
-			Name:         "some-pipeline",
-			File:         "some-pipeline-file",
-			Vars:         atc.Params{"some": "vars"},
-			VarFiles:     []string{"file-1", "file-2"},
-			InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
+		Config: &atc.AcrossStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var:    "var1",
+					Values: []interface{}{"a1", "a2"},
+				},
+				{
+					Var:        "var2",
+					ValuesFile: "some-artifact/values.json",
+				},
+			},
 		},
 
 		PlanJSON: `{
 			"id": "(unique)",
-			"set_pipeline": {
-				"name": "some-pipeline",
-				"file": "some-pipeline-file",
-				"vars": {"some": "vars"},
-				"var_files": ["file-1", "file-2"],
-				"instance_vars": {"branch": "feature/foo"}
+			"across": {
+				"vars": [
+					{
+						"name": "var1",
+						"values": ["a1", "a2"]
+					},
+					{
+						"name": "var2",
+						"values": null,
+						"values_file": "some-artifact/values.json"
+					}
+				],
+				"steps": [],
+				"substep_template": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				}
 			}
 		}`,
 	},
 	{
-		Title: "load_var step",
+		Title: "across step over resource versions",
 
-		Config: &atc.LoadVarStep{
-			Name:   "some-var",
-			File:   "some-var-file",
-			Format: "raw",
-			Reveal: true,
+		Config: &atc.AcrossStep{
+			Step: &atc.GetStep{
+				Name:     "some-name",
+				Resource: "some-resource",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var: "version",
+					Versions: &atc.AcrossVersionsConfig{
+						Resource: "some-resource",
+						Limit:    2,
+					},
+				},
+			},
+		},
+		Inputs: []db.BuildInput{
+			{
+				Name:    "some-name",
+				Version: atc.Version{"some": "v3"},
+			},
+		},
+		Versions: map[string][]atc.Version{
+			"some-resource": {
+				{"some": "v1"},
+				{"some": "v2"},
+				{"some": "v3"},
+			},
 		},
 
 		PlanJSON: `{
 			"id": "(unique)",
-			"load_var": {
-				"name": "some-var",
-				"file": "some-var-file",
-				"format": "raw",
-				"reveal": true
+			"across": {
+				"vars": [
+					{
+						"name": "version",
+						"values": [{"some": "v2"}, {"some": "v3"}]
+					}
+				],
+				"steps": [
+					{
+						"values": [{"some": "v2"}],
+						"step": {
+							"id": "(unique)",
+							"get": {
+								"name": "some-name",
+								"type": "some-resource-type",
+								"resource": "some-resource",
+								"source": {"some":"source","default-key":"default-value"},
+								"version": {"some":"v2"},
+								"resource_types": [
+									{
+										"name": "some-resource-type",
+										"type": "some-base-resource-type",
+										"source": {"some": "type-source"},
+										"defaults": {"default-key":"default-value"},
+										"version": {"some": "type-version"}
+									}
+								]
+							}
+						}
+					},
+					{
+						"values": [{"some": "v3"}],
+						"step": {
+							"id": "(unique)",
+							"get": {
+								"name": "some-name",
+								"type": "some-resource-type",
+								"resource": "some-resource",
+								"source": {"some":"source","default-key":"default-value"},
+								"version": {"some":"v3"},
+								"resource_types": [
+									{
+										"name": "some-resource-type",
+										"type": "some-base-resource-type",
+										"source": {"some": "type-source"},
+										"defaults": {"default-key":"default-value"},
+										"version": {"some": "type-version"}
+									}
+								]
+							}
+						}
+					}
+				]
 			}
 		}`,
 	},
 	{
-		Title: "try step",
+		Title: "across step over resource versions which were not provided",
 
-		Config: &atc.TryStep{
-			Step: atc.Step{
-				Config: &atc.LoadVarStep{
-					Name: "some-var",
-					File: "some-file",
+		Config: &atc.AcrossStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var:      "version",
+					Versions: &atc.AcrossVersionsConfig{Resource: "some-resource"},
 				},
 			},
 		},
 
+		Err: builds.AcrossVersionsNotProvidedError{Resource: "some-resource"},
+	},
+	{
+		Title: "timeout modifier",
+
+		Config: &atc.TimeoutStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Duration: "1h",
+		},
+
 		PlanJSON: `{
 			"id": "(unique)",
-			"try": {
+			"timeout": {
 				"step": {
 					"id": "(unique)",
 					"load_var": {
 						"name": "some-var",
 						"file": "some-file"
 					}
-				}
+				},
+				"duration": "1h"
 			}
 		}`,
 	},
 	{
-		Title: "do step",
+		Title: "soft timeout modifier",
 
-		Config: &atc.DoStep{
-			Steps: []atc.Step{
-				{
-					Config: &atc.LoadVarStep{
-						Name: "some-var",
-						File: "some-file",
-					},
-				},
-				{
-					Config: &atc.LoadVarStep{
-						Name: "some-other-var",
-						File: "some-other-file",
-					},
+		Config: &atc.TimeoutStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Duration:     "1h",
+			SoftDuration: "30m",
+			OnSoftTimeout: &atc.Step{
+				Config: &atc.LoadVarStep{
+					Name: "soft-var",
+					File: "soft-file",
 				},
 			},
 		},
 
 		PlanJSON: `{
 			"id": "(unique)",
-			"do": [
-				{
+			"timeout": {
+				"step": {
 					"id": "(unique)",
 					"load_var": {
 						"name": "some-var",
 						"file": "some-file"
 					}
 				},
-				{
+				"duration": "1h",
+				"soft_duration": "30m",
+				"on_soft_timeout": {
 					"id": "(unique)",
 					"load_var": {
-						"name": "some-other-var",
-						"file": "some-other-file"
+						"name": "soft-var",
+						"file": "soft-file"
 					}
 				}
-			]
+			}
 		}`,
 	},
 	{
-		Title: "in_parallel step",
+		Title: "attempts modifier",
 
-		Config: &atc.InParallelStep{
-			Config: atc.InParallelConfig{
-				Limit:    3,
-				FailFast: true,
-				Steps: []atc.Step{
-					{
-						Config: &atc.LoadVarStep{
-							Name: "some-var",
-							File: "some-file",
-						},
-					},
-					{
-						Config: &atc.LoadVarStep{
-							Name: "some-other-var",
-							File: "some-other-file",
-						},
-					},
+		Config: &atc.RetryStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Attempts: 3,
+		},
+
+		CompareIDs: true,
+		PlanJSON: `{
+			"id": "4",
+			"retry": [
+				{
+					"id": "1",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
 				},
+				{
+					"id": "2",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				{
+					"id": "3",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				}
+			]
+		}`,
+	},
+	{
+		Title: "serial_semaphore modifier",
+
+		Config: &atc.SemaphoreStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Semaphore: atc.SemaphoreConfig{
+				Name: "some-semaphore",
 			},
 		},
 
 		PlanJSON: `{
 			"id": "(unique)",
-			"in_parallel": {
-				"steps": [
-					{
-						"id": "(unique)",
-						"load_var": {
-							"name": "some-var",
-							"file": "some-file"
-						}
-					},
-					{
-						"id": "(unique)",
-						"load_var": {
-							"name": "some-other-var",
-							"file": "some-other-file"
-						}
+			"semaphore": {
+				"step": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
 					}
-				],
-				"limit": 3,
-				"fail_fast": true
+				},
+				"name": "some-semaphore",
+				"limit": 1
 			}
 		}`,
 	},
 	{
-		Title: "across step",
+		Title: "when modifier",
 
-		Config: &atc.AcrossStep{
-			Step: &atc.LoadVarStep{
-				Name: "some-var",
-				File: "some-file",
-			},
-			Vars: []atc.AcrossVarConfig{
+		Config: &atc.DoStep{
+			Steps: []atc.Step{
 				{
-					Var:         "var1",
-					Values:      []interface{}{"a1", "a2"},
-					MaxInFlight: &atc.MaxInFlightConfig{All: true},
+					Config: &atc.RetryStep{
+						Step: &atc.LoadVarStep{
+							Name: "some-var",
+							File: "some-file",
+						},
+						Attempts: 2,
+					},
 				},
 				{
-					Var:         "var2",
-					Values:      []interface{}{"b1", "b2"},
-					MaxInFlight: &atc.MaxInFlightConfig{Limit: 1},
+					Config: &atc.WhenStep{
+						Step: &atc.LoadVarStep{
+							Name: "some-other-var",
+							File: "some-other-file",
+						},
+						Condition: `steps.some-var == "failed" || steps.some-unknown-step == null`,
+					},
 				},
 			},
 		},
 
+		// the condition refers to the steps' plans by their ids
+		CompareIDs: true,
 		PlanJSON: `{
-			"id": "(unique)",
-			"across": {
-				"vars": [
-					{
-						"name": "var1",
-						"values": ["a1", "a2"],
-						"max_in_flight": "all"
-					},
-					{
-						"name": "var2",
-						"values": ["b1", "b2"],
-						"max_in_flight": 1
-					}
-				],
-				"steps": [
-					{
-						"values": ["a1", "b1"],
-						"step": {
-							"id": "(unique)",
-							"load_var": {
-								"name": "some-var",
-								"file": "some-file"
-							}
-						}
-					},
-					{
-						"values": ["a1", "b2"],
-						"step": {
-							"id": "(unique)",
+			"id": "6",
+			"do": [
+				{
+					"id": "3",
+					"retry": [
+						{
+							"id": "1",
 							"load_var": {
 								"name": "some-var",
 								"file": "some-file"
 							}
-						}
-					},
-					{
-						"values": ["a2", "b1"],
-						"step": {
-							"id": "(unique)",
+						},
+						{
+							"id": "2",
 							"load_var": {
 								"name": "some-var",
 								"file": "some-file"
 							}
 						}
-					},
-					{
-						"values": ["a2", "b2"],
+					]
+				},
+				{
+					"id": "5",
+					"when": {
 						"step": {
-							"id": "(unique)",
+							"id": "4",
 							"load_var": {
-								"name": "some-var",
-								"file": "some-file"
+								"name": "some-other-var",
+								"file": "some-other-file"
 							}
+						},
+						"condition": "steps.some-var == \"failed\" || steps.some-unknown-step == null",
+						"steps": {
+							"some-var": ["1", "2"],
+							"some-unknown-step": null
 						}
 					}
-				]
-			}
-		}`,
-	},
-	{
-		Title: "timeout modifier",
-
-		Config: &atc.TimeoutStep{
-			Step: &atc.LoadVarStep{
-				Name: "some-var",
-				File: "some-file",
-			},
-			Duration: "1h",
-		},
-
-		PlanJSON: `{
-			"id": "(unique)",
-			"timeout": {
-				"step": {
-					"id": "(unique)",
-					"load_var": {
-						"name": "some-var",
-						"file": "some-file"
-					}
-				},
-				"duration": "1h"
-			}
+				}
+			]
 		}`,
 	},
 	{
-		Title: "attempts modifier",
+		Title: "attempts modifier with backoff",
 
 		Config: &atc.RetryStep{
 			Step: &atc.LoadVarStep{
@@ -599,6 +1436,10 @@ var factoryTests = []PlannerTest{
 				File: "some-file",
 			},
 			Attempts: 3,
+			Backoff: &atc.RetryBackoffConfig{
+				Initial: "10s",
+				Max:     "1m",
+			},
 		},
 
 		CompareIDs: true,
@@ -626,7 +1467,11 @@ var factoryTests = []PlannerTest{
 						"file": "some-file"
 					}
 				}
-			]
+			],
+			"retry_backoff": {
+				"initial": "10s",
+				"max": "1m"
+			}
 		}`,
 	},
 	{
@@ -809,12 +1654,230 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "use step",
+		Config: &atc.UseStep{
+			Config: atc.UseConfig{Template: "some-template"},
+		},
+		Err: builds.UnexpandedStepTemplateError{Template: "some-template"},
+	},
+	{
+		Title: "step defaults",
+
+		Config: &atc.TaskStep{
+			Name: "some-task",
+			Config: &atc.TaskConfig{
+				Platform: "linux",
+				Run:      atc.TaskRunConfig{Path: "hello"},
+			},
+		},
+		Defaults: atc.StepDefaults{
+			Timeout:  "1h",
+			Tags:     atc.Tags{"default-tag"},
+			Attempts: 2,
+			Arch:     "arm64",
+			ImageResource: &atc.ImageResource{
+				Type:   "registry-image",
+				Source: atc.Source{"repository": "some-image"},
+			},
+		},
+
+		CompareIDs: true,
+		PlanJSON: `{
+			"id": "3",
+			"retry": [
+				{
+					"id": "1",
+					"task": {
+						"name": "some-task",
+						"privileged": false,
+						"config": {
+							"platform": "linux",
+							"image_resource": {
+								"name": "",
+								"type": "registry-image",
+								"source": {"repository": "some-image"}
+							},
+							"run": {"path": "hello"}
+						},
+						"tags": ["default-tag"],
+						"arch": "arm64",
+						"timeout": "1h",
+						"resource_types": [
+						{
+							"name": "some-resource-type",
+							"type": "some-base-resource-type",
+							"source": {"some": "type-source"},
+							"defaults": {"default-key":"default-value"},
+							"version": {"some": "type-version"}
+						}
+					]
+					}
+				},
+				{
+					"id": "2",
+					"task": {
+						"name": "some-task",
+						"privileged": false,
+						"config": {
+							"platform": "linux",
+							"image_resource": {
+								"name": "",
+								"type": "registry-image",
+								"source": {"repository": "some-image"}
+							},
+							"run": {"path": "hello"}
+						},
+						"tags": ["default-tag"],
+						"arch": "arm64",
+						"timeout": "1h",
+						"resource_types": [
+						{
+							"name": "some-resource-type",
+							"type": "some-base-resource-type",
+							"source": {"some": "type-source"},
+							"defaults": {"default-key":"default-value"},
+							"version": {"some": "type-version"}
+						}
+					]
+					}
+				}
+			]
+		}`,
+	},
+	{
+		Title: "step defaults with an arch",
+
+		Config: &atc.GetStep{
+			Name:     "some-name",
+			Resource: "some-resource",
+		},
+		Inputs: []db.BuildInput{
+			{
+				Name:    "some-name",
+				Version: atc.Version{"some": "version"},
+			},
+		},
+		Defaults: atc.StepDefaults{
+			Arch: "arm64",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"get": {
+				"name": "some-name",
+				"type": "some-resource-type",
+				"resource": "some-resource",
+				"source": {"some":"source","default-key":"default-value"},
+				"version": {"some":"version"},
+				"arch": "arm64",
+				"resource_types": [
+					{
+						"name": "some-resource-type",
+						"type": "some-base-resource-type",
+						"source": {"some": "type-source"},
+						"defaults": {"default-key":"default-value"},
+						"version": {"some": "type-version"}
+					}
+				]
+			}
+		}`,
+	},
+	{
+		Title: "step defaults with explicit values",
+
+		Config: &atc.DoStep{
+			Steps: []atc.Step{
+				{
+					Config: &atc.TimeoutStep{
+						Step: &atc.CheckStep{
+							Name: "some-base-resource",
+							Tags: atc.Tags{"some-tag"},
+						},
+						Duration: "10m",
+					},
+				},
+				{
+					Config: &atc.RetryStep{
+						Step: &atc.TaskStep{
+							Name:       "some-task",
+							ConfigPath: "some-file",
+							Timeout:    "5m",
+						},
+						Attempts: 1,
+					},
+				},
+			},
+		},
+		Defaults: atc.StepDefaults{
+			Timeout:  "1h",
+			Tags:     atc.Tags{"default-tag"},
+			Attempts: 2,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"do": [
+				{
+					"id": "(unique)",
+					"timeout": {
+						"step": {
+							"id": "(unique)",
+							"check": {
+								"name": "some-base-resource",
+								"type": "some-base-resource-type",
+								"resource": "some-base-resource",
+								"source": {"some":"source","default-key":"default-value"},
+								"tags": ["some-tag"],
+								"in_job_plan": true,
+								"attempts": 2,
+								"resource_types": [
+						{
+							"name": "some-resource-type",
+							"type": "some-base-resource-type",
+							"source": {"some": "type-source"},
+							"defaults": {"default-key":"default-value"},
+							"version": {"some": "type-version"}
+						}
+					]
+							}
+						},
+						"duration": "10m"
+					}
+				},
+				{
+					"id": "(unique)",
+					"retry": [
+						{
+							"id": "(unique)",
+							"task": {
+								"name": "some-task",
+								"privileged": false,
+								"config_path": "some-file",
+								"tags": ["default-tag"],
+								"timeout": "5m",
+								"resource_types": [
+						{
+							"name": "some-resource-type",
+							"type": "some-base-resource-type",
+							"source": {"some": "type-source"},
+							"defaults": {"default-key":"default-value"},
+							"version": {"some": "type-version"}
+						}
+					]
+							}
+						}
+					]
+				}
+			]
+		}`,
+	},
 }
 
 func (test PlannerTest) Run(s *PlannerSuite) {
 	factory := builds.NewPlanner(atc.NewPlanFactory(0))
 
-	actualPlan, actualErr := factory.Create(test.Config, resources, resourceTypes, test.Inputs)
+	actualPlan, actualErr := factory.Create(test.Config, test.Defaults, resources, resourceTypes, test.Inputs, test.Versions)
 
 	if test.Err != nil {
 		s.Equal(test.Err, actualErr)
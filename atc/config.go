@@ -15,6 +15,11 @@ import (
 )
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
+
+// IdempotencyKeyHeader is read from build-trigger and check requests. Repeated
+// requests carrying the same key get the originally created build back.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 const DefaultTeamName = "main"
 
 type Tags []string
@@ -26,6 +31,12 @@ type Config struct {
 	ResourceTypes ResourceTypes    `json:"resource_types,omitempty"`
 	Jobs          JobConfigs       `json:"jobs,omitempty"`
 	Display       *DisplayConfig   `json:"display,omitempty"`
+	Defaults      *StepDefaults    `json:"defaults,omitempty"`
+
+	StepTemplates StepTemplateConfigs `json:"step_templates,omitempty"`
+
+	Include  []IncludeConfig  `json:"include,omitempty"`
+	Included []IncludedConfig `json:"included,omitempty"`
 }
 
 func UnmarshalConfig(payload []byte, config interface{}) error {
@@ -37,6 +48,10 @@ func UnmarshalConfig(payload []byte, config interface{}) error {
 		ResourceTypes interface{} `json:"resource_types,omitempty"`
 		Jobs          interface{} `json:"jobs,omitempty"`
 		Display       interface{} `json:"display,omitempty"`
+		Defaults      interface{} `json:"defaults,omitempty"`
+		StepTemplates interface{} `json:"step_templates,omitempty"`
+		Include       interface{} `json:"include,omitempty"`
+		Included      interface{} `json:"included,omitempty"`
 	}
 
 	var stripped skeletonConfig
@@ -2,9 +2,11 @@ package atc
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/aryann/difflib"
@@ -35,47 +37,53 @@ func name(v interface{}) string {
 }
 
 func (diff Diff) Render(to io.Writer, label string) {
+	diff.render(to, label, DiffOptions{})
+}
 
+func (diff Diff) render(to io.Writer, label string, opts DiffOptions) {
 	if diff.Before != nil && diff.After != nil {
 		fmt.Fprintf(to, ansi.Color("%s %s has changed:", "yellow")+"\n", label, name(diff.Before))
 
-		payloadA, _ := yaml.Marshal(diff.Before)
-		payloadB, _ := yaml.Marshal(diff.After)
-
-		renderDiff(to, string(payloadA), string(payloadB))
+		renderDiff(to, marshalForDiff(diff.Before, opts), marshalForDiff(diff.After, opts))
 	} else if diff.Before != nil {
 		fmt.Fprintf(to, ansi.Color("%s %s has been removed:", "yellow")+"\n", label, name(diff.Before))
 
-		payloadA, _ := yaml.Marshal(diff.Before)
-
-		renderDiff(to, string(payloadA), "")
+		renderDiff(to, marshalForDiff(diff.Before, opts), "")
 	} else {
 		fmt.Fprintf(to, ansi.Color("%s %s has been added:", "yellow")+"\n", label, name(diff.After))
 
-		payloadB, _ := yaml.Marshal(diff.After)
-
-		renderDiff(to, "", string(payloadB))
+		renderDiff(to, "", marshalForDiff(diff.After, opts))
 	}
 }
 
 func (diff DisplayDiff) Render(to io.Writer) {
+	diff.render(to, DiffOptions{})
+}
+
+func (diff DisplayDiff) render(to io.Writer, opts DiffOptions) {
 	label := "display configuration"
 	if diff.Before != nil && diff.After != nil {
 		fmt.Fprintf(to, ansi.Color("%s has changed:", "yellow")+"\n", label)
-		payloadA, _ := yaml.Marshal(diff.Before)
-		payloadB, _ := yaml.Marshal(diff.After)
-		renderDiff(to, string(payloadA), string(payloadB))
+		renderDiff(to, marshalForDiff(diff.Before, opts), marshalForDiff(diff.After, opts))
 	} else if diff.Before != nil {
 		fmt.Fprintf(to, ansi.Color("%s has been removed:", "yellow")+"\n", label)
-		payloadA, _ := yaml.Marshal(diff.Before)
-		renderDiff(to, string(payloadA), "")
+		renderDiff(to, marshalForDiff(diff.Before, opts), "")
 	} else {
 		fmt.Fprintf(to, ansi.Color("%s has been added:", "yellow")+"\n", label)
-		payloadB, _ := yaml.Marshal(diff.After)
-		renderDiff(to, "", string(payloadB))
+		renderDiff(to, "", marshalForDiff(diff.After, opts))
 	}
 }
 
+func marshalForDiff(obj interface{}, opts DiffOptions) string {
+	payload, _ := yaml.Marshal(obj)
+
+	if opts.RedactSecrets {
+		return redactSecrets(string(payload))
+	}
+
+	return string(payload)
+}
+
 type GroupIndex GroupConfigs
 
 func (index GroupIndex) Slice() []interface{} {
@@ -275,65 +283,187 @@ func practicallyDifferent(a, b interface{}) bool {
 }
 
 func (c Config) Diff(out io.Writer, newConfig Config) bool {
-	var diffExists bool
+	sections := c.SectionDiffs(newConfig)
+	for _, section := range sections {
+		section.Render(out, DiffOptions{})
+	}
 
-	indent := gexec.NewPrefixedWriter("  ", out)
+	return len(sections) > 0
+}
 
-	groupDiffs := groupDiffIndices(GroupIndex(c.Groups), GroupIndex(newConfig.Groups))
-	if len(groupDiffs) > 0 {
-		diffExists = true
-		fmt.Fprintln(out, "groups:")
+// DiffOptions controls how a config diff is rendered.
+type DiffOptions struct {
+	// RedactSecrets hides the values of fields that look like credentials,
+	// unless they are ((vars)).
+	RedactSecrets bool
+}
+
+// ConfigSectionDiff is the difference in one top-level section of a config,
+// e.g. its resources or its jobs.
+type ConfigSectionDiff struct {
+	Section string
+
+	render func(io.Writer, DiffOptions)
+	apply  func(*Config, Config)
+}
+
+func (diff ConfigSectionDiff) Render(out io.Writer, opts DiffOptions) {
+	diff.render(out, opts)
+}
 
-		for _, diff := range groupDiffs {
-			diff.Render(indent, "group")
+// Apply replaces the section in config with the one from newConfig.
+func (diff ConfigSectionDiff) Apply(config *Config, newConfig Config) {
+	diff.apply(config, newConfig)
+}
+
+// SectionDiffs returns the diff of each section that differs between the
+// configs, in the order they are displayed.
+func (c Config) SectionDiffs(newConfig Config) []ConfigSectionDiff {
+	var sections []ConfigSectionDiff
+
+	indexedSection := func(section string, label string, diffs Diffs, apply func(*Config, Config)) {
+		if len(diffs) == 0 {
+			return
 		}
+
+		sections = append(sections, ConfigSectionDiff{
+			Section: section,
+			render: func(out io.Writer, opts DiffOptions) {
+				fmt.Fprintln(out, section+":")
+
+				indent := gexec.NewPrefixedWriter("  ", out)
+				for _, diff := range diffs {
+					diff.render(indent, label, opts)
+				}
+			},
+			apply: apply,
+		})
 	}
 
-	varSourceDiffs := diffIndices(VarSourceIndex(c.VarSources), VarSourceIndex(newConfig.VarSources))
-	if len(varSourceDiffs) > 0 {
-		diffExists = true
-		fmt.Println("variable source:")
+	indexedSection("groups", "group",
+		groupDiffIndices(GroupIndex(c.Groups), GroupIndex(newConfig.Groups)),
+		func(config *Config, newConfig Config) { config.Groups = newConfig.Groups })
 
-		for _, diff := range varSourceDiffs {
-			diff.Render(indent, "variable source")
-		}
+	indexedSection("variable source", "variable source",
+		diffIndices(VarSourceIndex(c.VarSources), VarSourceIndex(newConfig.VarSources)),
+		func(config *Config, newConfig Config) { config.VarSources = newConfig.VarSources })
+
+	indexedSection("resources", "resource",
+		diffIndices(ResourceIndex(c.Resources), ResourceIndex(newConfig.Resources)),
+		func(config *Config, newConfig Config) { config.Resources = newConfig.Resources })
+
+	indexedSection("resource types", "resource type",
+		diffIndices(ResourceTypeIndex(c.ResourceTypes), ResourceTypeIndex(newConfig.ResourceTypes)),
+		func(config *Config, newConfig Config) { config.ResourceTypes = newConfig.ResourceTypes })
+
+	indexedSection("jobs", "job",
+		diffIndices(JobIndex(c.Jobs), JobIndex(newConfig.Jobs)),
+		func(config *Config, newConfig Config) { config.Jobs = newConfig.Jobs })
+
+	displayDiff, diff := diffDisplay(c.Display, newConfig.Display)
+	if diff {
+		sections = append(sections, ConfigSectionDiff{
+			Section: "display",
+			render: func(out io.Writer, opts DiffOptions) {
+				displayDiff.render(gexec.NewPrefixedWriter("  ", out), opts)
+			},
+			apply: func(config *Config, newConfig Config) { config.Display = newConfig.Display },
+		})
 	}
 
-	resourceDiffs := diffIndices(ResourceIndex(c.Resources), ResourceIndex(newConfig.Resources))
-	if len(resourceDiffs) > 0 {
-		diffExists = true
-		fmt.Fprintln(out, "resources:")
+	if practicallyDifferent(c.Defaults, newConfig.Defaults) {
+		sections = append(sections, ConfigSectionDiff{
+			Section: "defaults",
+			render: func(out io.Writer, opts DiffOptions) {
+				fmt.Fprintln(out, ansi.Color("step defaults have changed:", "yellow"))
+				renderDiff(gexec.NewPrefixedWriter("  ", out), marshalForDiff(c.Defaults, opts), marshalForDiff(newConfig.Defaults, opts))
+			},
+			apply: func(config *Config, newConfig Config) { config.Defaults = newConfig.Defaults },
+		})
+	}
 
-		for _, diff := range resourceDiffs {
-			diff.Render(indent, "resource")
+	return sections
+}
+
+var secretKeyRegexp = regexp.MustCompile(`(?i)(password|passphrase|secret|token|private_key|access_key|api_key|credential)`)
+
+var yamlKeyRegexp = regexp.MustCompile(`^(\s*(?:- )?)([^\s:]+): ?(.*)$`)
+
+// redactSecrets replaces the values of YAML fields whose keys look like they
+// hold credentials. Values that are ((vars)) are left as-is, since they are
+// resolved from a credential manager.
+func redactSecrets(payload string) string {
+	lines := strings.Split(payload, "\n")
+
+	var redacted []string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		match := yamlKeyRegexp.FindStringSubmatch(line)
+		if match == nil || !secretKeyRegexp.MatchString(match[2]) || match[3] == "" || strings.HasPrefix(match[3], "((") {
+			redacted = append(redacted, line)
+			continue
 		}
-	}
 
-	resourceTypeDiffs := diffIndices(ResourceTypeIndex(c.ResourceTypes), ResourceTypeIndex(newConfig.ResourceTypes))
-	if len(resourceTypeDiffs) > 0 {
-		diffExists = true
-		fmt.Fprintln(out, "resource types:")
+		redacted = append(redacted, match[1]+match[2]+": <redacted>")
 
-		for _, diff := range resourceTypeDiffs {
-			diff.Render(indent, "resource type")
+		// skip over the rest of multi-line values
+		if strings.HasPrefix(match[3], "|") || strings.HasPrefix(match[3], ">") {
+			keyIndent := len(match[1])
+			for i+1 < len(lines) && indentation(lines[i+1]) > keyIndent {
+				i++
+			}
 		}
 	}
 
-	jobDiffs := diffIndices(JobIndex(c.Jobs), JobIndex(newConfig.Jobs))
-	if len(jobDiffs) > 0 {
-		diffExists = true
-		fmt.Fprintln(out, "jobs:")
+	return strings.Join(redacted, "\n")
+}
+
+// RedactSecretFields returns a copy of the given data, e.g. a pipeline config,
+// with the string values of fields whose keys look like they hold credentials
+// replaced. Like redactSecrets, values that are ((vars)) are left as-is.
+func RedactSecretFields(data interface{}) (interface{}, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	err = json.Unmarshal(payload, &generic)
+	if err != nil {
+		return nil, err
+	}
+
+	return redactSecretFields(generic), nil
+}
 
-		for _, diff := range jobDiffs {
-			diff.Render(indent, "job")
+func redactSecretFields(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			if str, ok := val.(string); ok && secretKeyRegexp.MatchString(key) && str != "" && !strings.HasPrefix(str, "((") {
+				redacted[key] = "<redacted>"
+			} else {
+				redacted[key] = redactSecretFields(val)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, val := range v {
+			redacted[i] = redactSecretFields(val)
 		}
+		return redacted
+	default:
+		return v
 	}
+}
 
-	displayDiff, diff := diffDisplay(c.Display, newConfig.Display)
-	if diff {
-		diffExists = true
-		displayDiff.Render(indent)
+func indentation(line string) int {
+	if strings.TrimSpace(line) == "" {
+		return len(line) + 1
 	}
 
-	return diffExists
+	return len(line) - len(strings.TrimLeft(line, " "))
 }
@@ -216,4 +216,131 @@ var _ = Describe("Config diff", func() {
 			})
 		})
 	})
+
+	Describe("step defaults", func() {
+		It("does not print anything when they are unchanged", func() {
+			buffer := NewBuffer()
+			diff := Config{Defaults: &StepDefaults{Timeout: "1h"}}.Diff(buffer, Config{Defaults: &StepDefaults{Timeout: "1h"}})
+			Expect(diff).To(BeFalse())
+			Consistently(buffer).ShouldNot(Say("defaults"))
+		})
+
+		It("says they have changed", func() {
+			oldConfig := Config{
+				Defaults: &StepDefaults{Timeout: "1h"},
+			}
+			newConfig := Config{
+				Defaults: &StepDefaults{Timeout: "2h"},
+			}
+
+			buffer := NewBuffer()
+			diff := oldConfig.Diff(buffer, newConfig)
+			Expect(diff).To(BeTrue())
+			Eventually(buffer).Should(Say("step defaults have changed:"))
+			Eventually(buffer).Should(Say("-.*timeout: 1h"))
+			Eventually(buffer).Should(Say(`\+.*timeout: 2h`))
+		})
+	})
+
+	Describe("section diffs", func() {
+		var oldConfig, newConfig Config
+
+		BeforeEach(func() {
+			oldConfig = Config{
+				Resources: ResourceConfigs{
+					{
+						Name: "some-resource",
+						Type: "git",
+						Source: Source{
+							"uri":         "https://example.com/repo.git",
+							"private_key": "-----BEGIN KEY-----\nold\n-----END KEY-----\n",
+							"password":    "((some-password))",
+						},
+					},
+				},
+				Jobs: JobConfigs{
+					{Name: "some-job"},
+				},
+			}
+
+			newConfig = Config{
+				Resources: ResourceConfigs{
+					{
+						Name: "some-resource",
+						Type: "git",
+						Source: Source{
+							"uri":         "https://example.com/other-repo.git",
+							"private_key": "-----BEGIN KEY-----\nnew\n-----END KEY-----\n",
+							"password":    "((some-password))",
+						},
+					},
+				},
+				Jobs: JobConfigs{
+					{Name: "some-job"},
+					{Name: "some-other-job"},
+				},
+			}
+		})
+
+		It("returns the sections that differ", func() {
+			sections := oldConfig.SectionDiffs(newConfig)
+			Expect(sections).To(HaveLen(2))
+			Expect(sections[0].Section).To(Equal("resources"))
+			Expect(sections[1].Section).To(Equal("jobs"))
+		})
+
+		It("applies a single section", func() {
+			sections := oldConfig.SectionDiffs(newConfig)
+
+			config := oldConfig
+			sections[1].Apply(&config, newConfig)
+
+			Expect(config.Resources).To(Equal(oldConfig.Resources))
+			Expect(config.Jobs).To(Equal(newConfig.Jobs))
+		})
+
+		It("redacts credential-like values, leaving vars alone", func() {
+			buffer := NewBuffer()
+			oldConfig.SectionDiffs(newConfig)[0].Render(buffer, DiffOptions{RedactSecrets: true})
+
+			Expect(buffer).To(Say("resource some-resource has changed:"))
+			Expect(string(buffer.Contents())).To(ContainSubstring("password: ((some-password))"))
+			Expect(string(buffer.Contents())).To(ContainSubstring("private_key: <redacted>"))
+			Expect(string(buffer.Contents())).ToNot(ContainSubstring("BEGIN KEY"))
+			Expect(string(buffer.Contents())).To(ContainSubstring("other-repo.git"))
+		})
+
+		It("shows credential-like values when not redacting", func() {
+			buffer := NewBuffer()
+			oldConfig.SectionDiffs(newConfig)[0].Render(buffer, DiffOptions{})
+
+			Expect(string(buffer.Contents())).To(ContainSubstring("BEGIN KEY"))
+		})
+	})
+
+	Describe("RedactSecretFields", func() {
+		It("redacts credential-like values, leaving vars alone", func() {
+			redacted, err := RedactSecretFields(Config{
+				Resources: ResourceConfigs{
+					{
+						Name: "some-resource",
+						Type: "git",
+						Source: Source{
+							"uri":         "some-repo.git",
+							"password":    "((some-password))",
+							"private_key": "-----BEGIN KEY-----",
+						},
+					},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			resources := redacted.(map[string]interface{})["resources"].([]interface{})
+			Expect(resources[0].(map[string]interface{})["source"]).To(Equal(map[string]interface{}{
+				"uri":         "some-repo.git",
+				"password":    "((some-password))",
+				"private_key": "<redacted>",
+			}))
+		})
+	})
 })
@@ -40,6 +40,36 @@ var _ = Describe("Config", func() {
 				})
 			})
 		})
+
+		Context("when unmarshaling a version to fetch the newest since", func() {
+			It("produces the correct version config without error", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`{ "since": { "some": "version" } }`), &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionConfig).To(Equal(VersionConfig{
+					Since: Version{"some": "version"},
+				}))
+			})
+
+			It("marshals back to the same JSON", func() {
+				payload, err := json.Marshal(&VersionConfig{Since: Version{"some": "version"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload).To(MatchJSON(`{ "since": { "some": "version" } }`))
+			})
+		})
+
+		Context("when unmarshaling newest_after_passed", func() {
+			It("produces the correct version config without error", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`"newest_after_passed"`), &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionConfig).To(Equal(VersionConfig{NewestAfterPassed: true}))
+
+				payload, err := json.Marshal(&versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload).To(MatchJSON(`"newest_after_passed"`))
+			})
+		})
 	})
 
 	Describe("VarSourceConfigs.OrderByDependency", func() {
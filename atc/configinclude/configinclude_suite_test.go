@@ -0,0 +1,13 @@
+package configinclude_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestConfiginclude(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Configinclude Suite")
+}
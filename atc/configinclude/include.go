@@ -0,0 +1,155 @@
+// Package configinclude merges the partial configs referred to by a
+// pipeline's `include:` directives into it when the pipeline is set.
+package configinclude
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+)
+
+// TemplateStore is implemented by db.Team.
+type TemplateStore interface {
+	PipelineTemplate(name string) (atc.PipelineTemplate, bool, error)
+}
+
+// FileReader reads a file from an artifact given a path such as
+// repo/ci/common.yml.
+type FileReader func(path string) ([]byte, error)
+
+// Source fetches the configs included by a pipeline. Files are only
+// available where there are artifacts to read them from, i.e. in the
+// set_pipeline step.
+type Source struct {
+	Templates TemplateStore
+	Files     FileReader
+}
+
+func (source Source) fetch(include atc.IncludeConfig) ([]byte, error) {
+	if include.Template != "" {
+		if source.Templates == nil {
+			return nil, fmt.Errorf("no templates available")
+		}
+
+		template, found, err := source.Templates.PipelineTemplate(include.Template)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, fmt.Errorf("template not found")
+		}
+
+		return []byte(template.Config), nil
+	}
+
+	if source.Files == nil {
+		return nil, fmt.Errorf("files can only be included by the set_pipeline step")
+	}
+
+	return source.Files(include.File)
+}
+
+// Expand fetches each of the config's includes and merges them in, returning
+// the config with its includes replaced by a record of what they merged.
+//
+// Included configs are interpolated with the include's vars only; any other
+// vars are left to be resolved when the pipeline runs. Names defined by more
+// than one of the configs are an error rather than being overridden, and
+// included configs can't include others.
+func Expand(config atc.Config, source Source) (atc.Config, error) {
+	includes := config.Include
+
+	config.Include = nil
+	config.Included = nil
+
+	for _, include := range includes {
+		included, err := expand(&config, include, source)
+		if err != nil {
+			return atc.Config{}, fmt.Errorf("include %s: %w", include, err)
+		}
+
+		config.Included = append(config.Included, included)
+	}
+
+	return config, nil
+}
+
+func expand(config *atc.Config, include atc.IncludeConfig, source Source) (atc.IncludedConfig, error) {
+	payload, err := source.fetch(include)
+	if err != nil {
+		return atc.IncludedConfig{}, err
+	}
+
+	included := atc.IncludedConfig{
+		Template: include.Template,
+		File:     include.File,
+		Digest:   fmt.Sprintf("sha256:%x", sha256.Sum256(payload)),
+	}
+
+	if len(include.Vars) > 0 {
+		payload, err = vars.NewTemplate(payload).Evaluate(vars.StaticVariables(include.Vars), vars.EvaluateOpts{})
+		if err != nil {
+			return atc.IncludedConfig{}, err
+		}
+	}
+
+	var partial atc.Config
+	err = atc.UnmarshalConfig(payload, &partial)
+	if err != nil {
+		return atc.IncludedConfig{}, fmt.Errorf("malformed config: %w", err)
+	}
+
+	if len(partial.Include) > 0 {
+		return atc.IncludedConfig{}, fmt.Errorf("included configs cannot include others")
+	}
+
+	for _, group := range partial.Groups {
+		if _, _, found := config.Groups.Lookup(group.Name); found {
+			return atc.IncludedConfig{}, fmt.Errorf("group '%s' is already defined", group.Name)
+		}
+
+		config.Groups = append(config.Groups, group)
+		included.Groups = append(included.Groups, group.Name)
+	}
+
+	for _, varSource := range partial.VarSources {
+		if _, found := config.VarSources.Lookup(varSource.Name); found {
+			return atc.IncludedConfig{}, fmt.Errorf("var source '%s' is already defined", varSource.Name)
+		}
+
+		config.VarSources = append(config.VarSources, varSource)
+		included.VarSources = append(included.VarSources, varSource.Name)
+	}
+
+	for _, resourceType := range partial.ResourceTypes {
+		if _, found := config.ResourceTypes.Lookup(resourceType.Name); found {
+			return atc.IncludedConfig{}, fmt.Errorf("resource type '%s' is already defined", resourceType.Name)
+		}
+
+		config.ResourceTypes = append(config.ResourceTypes, resourceType)
+		included.ResourceTypes = append(included.ResourceTypes, resourceType.Name)
+	}
+
+	for _, resource := range partial.Resources {
+		if _, found := config.Resources.Lookup(resource.Name); found {
+			return atc.IncludedConfig{}, fmt.Errorf("resource '%s' is already defined", resource.Name)
+		}
+
+		config.Resources = append(config.Resources, resource)
+		included.Resources = append(included.Resources, resource.Name)
+	}
+
+	for _, job := range partial.Jobs {
+		if _, found := config.Jobs.Lookup(job.Name); found {
+			return atc.IncludedConfig{}, fmt.Errorf("job '%s' is already defined", job.Name)
+		}
+
+		config.Jobs = append(config.Jobs, job)
+		included.Jobs = append(included.Jobs, job.Name)
+	}
+
+	return included, nil
+}
@@ -0,0 +1,211 @@
+package configinclude_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configinclude"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Expand", func() {
+	var (
+		fakeTeam *dbfakes.FakeTeam
+		files    map[string]string
+		source   configinclude.Source
+
+		config   atc.Config
+		expanded atc.Config
+		err      error
+	)
+
+	const commonTemplate = `
+resources:
+- name: repo
+  type: git
+  source: {uri: ((uri))}
+jobs:
+- name: lint
+  plan:
+  - get: repo
+  - task: lint
+    file: repo/ci/lint.yml
+    params: {TOKEN: ((token))}
+`
+
+	digest := func(payload string) string {
+		return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(payload)))
+	}
+
+	BeforeEach(func() {
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.PipelineTemplateStub = func(name string) (atc.PipelineTemplate, bool, error) {
+			if name != "common" {
+				return atc.PipelineTemplate{}, false, nil
+			}
+
+			return atc.PipelineTemplate{Name: "common", Config: commonTemplate}, true, nil
+		}
+
+		files = map[string]string{}
+
+		source = configinclude.Source{
+			Templates: fakeTeam,
+			Files: func(path string) ([]byte, error) {
+				payload, found := files[path]
+				if !found {
+					return nil, errors.New("file not found")
+				}
+
+				return []byte(payload), nil
+			},
+		}
+
+		config = atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "unit", PlanSequence: []atc.Step{}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		expanded, err = configinclude.Expand(config, source)
+	})
+
+	Context("when the config has no includes", func() {
+		BeforeEach(func() {
+			config.Included = []atc.IncludedConfig{{Template: "stale"}}
+		})
+
+		It("returns the config without any record of includes", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expanded.Jobs).To(Equal(config.Jobs))
+			Expect(expanded.Included).To(BeNil())
+		})
+	})
+
+	Context("when the config includes a template", func() {
+		BeforeEach(func() {
+			config.Include = []atc.IncludeConfig{
+				{Template: "common", Vars: atc.Params{"uri": "https://example.com/repo.git"}},
+			}
+		})
+
+		It("merges in the template", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expanded.Jobs).To(HaveLen(2))
+			Expect(expanded.Jobs[0].Name).To(Equal("unit"))
+			Expect(expanded.Jobs[1].Name).To(Equal("lint"))
+
+			resource, found := expanded.Resources.Lookup("repo")
+			Expect(found).To(BeTrue())
+			Expect(resource.Source).To(Equal(atc.Source{"uri": "https://example.com/repo.git"}))
+		})
+
+		It("leaves vars not given to the include to be resolved later", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			job, _ := expanded.Jobs.Lookup("lint")
+			Expect(job.PlanSequence[1].Config.(*atc.TaskStep).Params).To(Equal(atc.TaskEnv{"TOKEN": "((token))"}))
+		})
+
+		It("replaces the include with a record of what it merged", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expanded.Include).To(BeNil())
+			Expect(expanded.Included).To(Equal([]atc.IncludedConfig{
+				{
+					Template:  "common",
+					Digest:    digest(commonTemplate),
+					Resources: []string{"repo"},
+					Jobs:      []string{"lint"},
+				},
+			}))
+		})
+
+		Context("when the pipeline already defines something the template does", func() {
+			BeforeEach(func() {
+				config.Jobs = append(config.Jobs, atc.JobConfig{Name: "lint", PlanSequence: []atc.Step{}})
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError("include template 'common': job 'lint' is already defined"))
+			})
+		})
+	})
+
+	Context("when the template does not exist", func() {
+		BeforeEach(func() {
+			config.Include = []atc.IncludeConfig{{Template: "bogus"}}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError("include template 'bogus': template not found"))
+		})
+	})
+
+	Context("when looking up the template fails", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineTemplateStub = nil
+			fakeTeam.PipelineTemplateReturns(atc.PipelineTemplate{}, false, errors.New("disaster"))
+			config.Include = []atc.IncludeConfig{{Template: "common"}}
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(MatchError(ContainSubstring("disaster")))
+		})
+	})
+
+	Context("when the config includes a file", func() {
+		BeforeEach(func() {
+			files["repo/ci/notify.yml"] = "resource_types:\n- name: slack\n  type: registry-image\n  source: {repository: slack}\n"
+			config.Include = []atc.IncludeConfig{{File: "repo/ci/notify.yml"}}
+		})
+
+		It("merges in the file", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expanded.Included).To(Equal([]atc.IncludedConfig{
+				{
+					File:          "repo/ci/notify.yml",
+					Digest:        digest(files["repo/ci/notify.yml"]),
+					ResourceTypes: []string{"slack"},
+				},
+			}))
+		})
+
+		Context("when files can't be read", func() {
+			BeforeEach(func() {
+				source.Files = nil
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError("include file 'repo/ci/notify.yml': files can only be included by the set_pipeline step"))
+			})
+		})
+	})
+
+	Context("when an included config includes another", func() {
+		BeforeEach(func() {
+			files["repo/nested.yml"] = "include:\n- template: common\n"
+			config.Include = []atc.IncludeConfig{{File: "repo/nested.yml"}}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError("include file 'repo/nested.yml': included configs cannot include others"))
+		})
+	})
+
+	Context("when an included config is malformed", func() {
+		BeforeEach(func() {
+			files["repo/bad.yml"] = "jobs: {"
+			config.Include = []atc.IncludeConfig{{File: "repo/bad.yml"}}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("include file 'repo/bad.yml': malformed config")))
+		})
+	})
+})
@@ -1,13 +1,16 @@
 package configvalidate
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/vars"
 	"github.com/gobwas/glob"
 )
 
@@ -49,6 +52,13 @@ func Validate(c atc.Config) ([]atc.ConfigWarning, []string) {
 		errorMessages = append(errorMessages, formatErr("variable sources", varSourcesErr))
 	}
 	warnings = append(warnings, varSourcesWarnings...)
+	warnings = append(warnings, validateVarSourceReferences(c)...)
+
+	stepTemplatesWarnings, stepTemplatesErr := validateStepTemplates(c)
+	if stepTemplatesErr != nil {
+		errorMessages = append(errorMessages, formatErr("step templates", stepTemplatesErr))
+	}
+	warnings = append(warnings, stepTemplatesWarnings...)
 
 	jobWarnings, jobsErr := validateJobs(c)
 	if jobsErr != nil {
@@ -62,6 +72,16 @@ func Validate(c atc.Config) ([]atc.ConfigWarning, []string) {
 	}
 	warnings = append(warnings, displayWarnings...)
 
+	defaultsErr := compositeErr(validateDefaults(c.Defaults, "defaults"))
+	if defaultsErr != nil {
+		errorMessages = append(errorMessages, formatErr("defaults", defaultsErr))
+	}
+
+	includesErr := validateIncludes(c)
+	if includesErr != nil {
+		errorMessages = append(errorMessages, formatErr("includes", includesErr))
+	}
+
 	return warnings, errorMessages
 }
 
@@ -253,6 +273,7 @@ func usedResources(c atc.Config) map[string]bool {
 
 	for _, job := range c.Jobs {
 		_ = job.StepConfig().Visit(atc.StepRecursor{
+			StepTemplates: c.StepTemplates,
 			OnGet: func(step *atc.GetStep) error {
 				usedResources[step.ResourceName()] = true
 				return nil
@@ -261,12 +282,72 @@ func usedResources(c atc.Config) map[string]bool {
 				usedResources[step.ResourceName()] = true
 				return nil
 			},
+			OnCheck: func(step *atc.CheckStep) error {
+				usedResources[step.ResourceName()] = true
+				return nil
+			},
 		})
 	}
 
 	return usedResources
 }
 
+// validateStepTemplates validates the templates' names and params. Their
+// steps are validated where they are used, once their params are known.
+func validateStepTemplates(c atc.Config) ([]atc.ConfigWarning, error) {
+	var errorMessages []string
+	var warnings []atc.ConfigWarning
+
+	names := map[string]int{}
+
+	for i, template := range c.StepTemplates {
+		var identifier string
+		if template.Name == "" {
+			identifier = fmt.Sprintf("step_templates[%d]", i)
+		} else {
+			identifier = fmt.Sprintf("step_templates.%s", template.Name)
+		}
+
+		warning, err := atc.ValidateIdentifier(template.Name, identifier)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+
+		if other, exists := names[template.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"step_templates[%d] and step_templates[%d] have the same name ('%s')",
+					other, i, template.Name))
+		} else if template.Name != "" {
+			names[template.Name] = i
+		}
+
+		if template.Name == "" {
+			errorMessages = append(errorMessages, identifier+" has no name")
+		}
+
+		if len(template.Steps) == 0 {
+			errorMessages = append(errorMessages, identifier+" has no steps")
+		}
+
+		params := map[string]bool{}
+		for j, param := range template.Params {
+			if param.Name == "" {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.params[%d] has no name", identifier, j))
+			} else if params[param.Name] {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.params[%d] repeats param '%s'", identifier, j, param.Name))
+			}
+
+			params[param.Name] = true
+		}
+	}
+
+	return warnings, compositeErr(errorMessages)
+}
+
 func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 	var errorMessages []string
 	var warnings []atc.ConfigWarning
@@ -346,6 +427,17 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 			}
 		}
 
+		errorMessages = append(errorMessages, validateDefaults(job.Defaults, identifier+".defaults")...)
+
+		if job.Schedule != nil {
+			_, err := job.Schedule.Next(time.Now())
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.schedule is invalid: %s", identifier, err))
+			}
+		}
+
+		errorMessages = append(errorMessages, validateAfter(c, job, identifier+".after")...)
+
 		step := job.Step()
 
 		validator := atc.NewStepValidator(c, []string{identifier, ".plan"})
@@ -355,11 +447,156 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 		warnings = append(warnings, validator.Warnings...)
 
 		errorMessages = append(errorMessages, validator.Errors...)
+
+		errorMessages = append(errorMessages, validateCheckedResources(c, job, identifier)...)
 	}
 
+	errorMessages = append(errorMessages, validateAfterCycles(c)...)
+
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateAfter(c atc.Config, job atc.JobConfig, identifier string) []string {
+	var errorMessages []string
+
+	seen := map[string]bool{}
+	for i, after := range job.After {
+		switch {
+		case after.Job == "":
+			errorMessages = append(errorMessages, fmt.Sprintf("%s[%d] has no job", identifier, i))
+		case after.Job == job.Name:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s[%d] refers to the job itself", identifier, i))
+		case seen[after.Job]:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s[%d] repeats job '%s'", identifier, i, after.Job))
+		default:
+			if _, found := c.Jobs.Lookup(after.Job); !found {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s[%d] refers to a job that does not exist ('%s')", identifier, i, after.Job))
+			}
+		}
+
+		seen[after.Job] = true
+	}
+
+	return errorMessages
+}
+
+// validateAfterCycles ensures that jobs don't trigger each other through
+// their after config, as their builds would then never stop being created.
+func validateAfterCycles(c atc.Config) []string {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	var errorMessages []string
+
+	state := map[string]int{}
+	path := []string{}
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		path = append(path, name)
+
+		job, _ := c.Jobs.Lookup(name)
+		for _, after := range job.After {
+			if after.Job == name {
+				// reported by validateAfter
+				continue
+			}
+
+			switch state[after.Job] {
+			case visiting:
+				var start int
+				for start = range path {
+					if path[start] == after.Job {
+						break
+					}
+				}
+
+				cycle := append(append([]string{}, path[start:]...), after.Job)
+				errorMessages = append(errorMessages, fmt.Sprintf("jobs.%s.after forms a cycle: %s", name, strings.Join(cycle, " -> ")))
+			case 0:
+				visit(after.Job)
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+	}
+
+	for _, job := range c.Jobs {
+		if state[job.Name] == 0 {
+			visit(job.Name)
+		}
+	}
+
+	return errorMessages
+}
+
+func validateDefaults(defaults *atc.StepDefaults, identifier string) []string {
+	if defaults == nil {
+		return nil
+	}
+
+	var errorMessages []string
+
+	if defaults.Timeout != "" {
+		_, err := time.ParseDuration(defaults.Timeout)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.timeout has invalid duration '%s'", identifier, defaults.Timeout))
+		}
+	}
+
+	if defaults.Attempts < 0 {
+		errorMessages = append(errorMessages, fmt.Sprintf("%s.attempts must not be negative: %d", identifier, defaults.Attempts))
+	}
+
+	if defaults.ImageResource != nil && defaults.ImageResource.Type == "" {
+		errorMessages = append(errorMessages, identifier+".image_resource has no type")
+	}
+
+	return errorMessages
+}
+
+// validateCheckedResources ensures that each resource checked by a job is
+// also fetched or pushed by it, as a job's builds are only given the
+// resources that it gets or puts.
+func validateCheckedResources(c atc.Config, job atc.JobConfig, identifier string) []string {
+	var checked []string
+	usedResources := map[string]bool{}
+
+	_ = job.StepConfig().Visit(atc.StepRecursor{
+		StepTemplates: c.StepTemplates,
+		OnGet: func(step *atc.GetStep) error {
+			usedResources[step.ResourceName()] = true
+			return nil
+		},
+		OnPut: func(step *atc.PutStep) error {
+			usedResources[step.ResourceName()] = true
+			return nil
+		},
+		OnCheck: func(step *atc.CheckStep) error {
+			checked = append(checked, step.ResourceName())
+			return nil
+		},
+	})
+
+	var errorMessages []string
+	for _, resourceName := range checked {
+		if usedResources[resourceName] {
+			continue
+		}
+
+		errorMessages = append(errorMessages, fmt.Sprintf("%s checks resource '%s' but does not get or put it", identifier, resourceName))
+
+		// only report each resource once
+		usedResources[resourceName] = true
+	}
+
+	return errorMessages
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -394,7 +631,7 @@ func validateVarSources(c atc.Config) ([]atc.ConfigWarning, error) {
 			// TODO: this check should eventually be removed once all credential managers
 			// are supported in pipeline. - @evanchaoli
 			switch cm.Type {
-			case "vault", "dummy", "ssm":
+			case "vault", "dummy", "ssm", "file":
 			default:
 				errorMessages = append(errorMessages, fmt.Sprintf("credential manager type %s is not supported in pipeline yet", cm.Type))
 			}
@@ -424,6 +661,43 @@ func validateVarSources(c atc.Config) ([]atc.ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+// validateVarSourceReferences warns about vars like ((source:path)) whose
+// source is not one of the pipeline's var_sources, as they will fail to
+// resolve once a build runs.
+func validateVarSourceReferences(c atc.Config) []atc.ConfigWarning {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+
+	declared := map[string]bool{}
+	for _, varSource := range c.VarSources {
+		declared[varSource.Name] = true
+	}
+
+	var warnings []atc.ConfigWarning
+
+	warned := map[string]bool{}
+	for _, name := range vars.NewTemplate(payload).ExtraVarNames() {
+		ref, err := vars.ParseReference(name)
+		if err != nil {
+			continue
+		}
+
+		if ref.Source == "" || ref.Source == "." || declared[ref.Source] || warned[ref.Source] {
+			continue
+		}
+
+		warned[ref.Source] = true
+		warnings = append(warnings, atc.ConfigWarning{
+			Type:    "var_sources",
+			Message: fmt.Sprintf("var '%s' refers to var source '%s', which is not declared in var_sources", name, ref.Source),
+		})
+	}
+
+	return warnings
+}
+
 func validateDisplay(c atc.Config) ([]atc.ConfigWarning, error) {
 	var warnings []atc.ConfigWarning
 
@@ -448,3 +722,19 @@ func validateDisplay(c atc.Config) ([]atc.ConfigWarning, error) {
 
 	return warnings, nil
 }
+
+func validateIncludes(c atc.Config) error {
+	var errorMessages []string
+
+	for i, include := range c.Include {
+		if (include.Template == "") == (include.File == "") {
+			errorMessages = append(errorMessages, fmt.Sprintf("include[%d] must specify exactly one of template or file", i))
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
@@ -467,6 +467,54 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("var source references", func() {
+		BeforeEach(func() {
+			config.VarSources = append(config.VarSources, atc.VarSourceConfig{
+				Name: "some-source",
+				Type: "dummy",
+				Config: map[string]interface{}{
+					"vars": map[string]interface{}{"k": "v"},
+				},
+			})
+		})
+
+		Context("when every var refers to a declared var source", func() {
+			BeforeEach(func() {
+				config.Resources[0].Source["declared"] = "((some-source:k))"
+				config.Resources[0].Source["local"] = "((.:k))"
+				config.Resources[0].Source["global"] = "((k))"
+			})
+
+			It("does not warn", func() {
+				Expect(errorMessages).To(BeEmpty())
+				for _, warning := range warnings {
+					Expect(warning.Type).ToNot(Equal("var_sources"))
+				}
+			})
+		})
+
+		Context("when a var refers to an undeclared var source", func() {
+			BeforeEach(func() {
+				config.Resources[0].Source["a"] = "((missing:k))"
+				config.Resources[0].Source["b"] = "((missing:other))"
+			})
+
+			It("warns once per var source without erroring", func() {
+				Expect(errorMessages).To(BeEmpty())
+
+				var varSourceWarnings []atc.ConfigWarning
+				for _, warning := range warnings {
+					if warning.Type == "var_sources" {
+						varSourceWarnings = append(varSourceWarnings, warning)
+					}
+				}
+
+				Expect(varSourceWarnings).To(HaveLen(1))
+				Expect(varSourceWarnings[0].Message).To(ContainSubstring("var source 'missing'"))
+			})
+		})
+	})
+
 	Describe("invalid resources", func() {
 		Context("when a resource has no name", func() {
 			BeforeEach(func() {
@@ -641,7 +689,7 @@ var _ = Describe("ValidateConfig", func() {
 												},
 											},
 										},
-										Limit:    1,
+										Limit:    "1",
 										FailFast: true,
 									},
 								},
@@ -1003,6 +1051,65 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task plan has invalid services", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "some-task",
+							ConfigPath: "task.yml",
+							Services: []atc.TaskServiceConfig{
+								{
+									Name:  "some-service",
+									Image: "some-image",
+								},
+								{
+									Name: "some-service",
+									Run:  atc.TaskRunConfig{Path: "serve"},
+								},
+								{
+									Name:  "some.service",
+									Image: "some-image",
+									Run:   atc.TaskRunConfig{Path: "serve"},
+									Ready: &atc.TaskRunConfig{},
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-task).services[0]: missing path to executable to run"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-task).services[1]: repeated name"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-task).services[1]: no image specified"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-task).services[2]: invalid name 'some.service'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-task).services[2].ready: missing path to executable to run"))
+				})
+			})
+
+			Context("when a put plan has get_params but no get", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.PutStep{
+							Name:      "some-resource",
+							GetParams: atc.Params{"skip_download": true},
+							NoGet:     true,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns a warning", func() {
+					Expect(errorMessages).To(HaveLen(0))
+					Expect(warnings).To(HaveLen(1))
+					Expect(warnings[0].Message).To(ContainSubstring("jobs.some-other-job.plan.do[0].put(some-resource): specifies get_params: but also no_get:"))
+				})
+			})
+
 			Context("when a put plan has refers to a resource that does exist", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1621,6 +1728,72 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has a soft timeout without a timeout", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TimeoutStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							SoftDuration: "30m",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a plan has a soft timeout which is not less than the timeout", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TimeoutStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Duration:     "1h",
+							SoftDuration: "1h",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].soft_timeout: must be less than the timeout"))
+				})
+			})
+
+			Context("when a plan has an invalid soft timeout", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TimeoutStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							SoftDuration: "nope",
+							OnSoftTimeout: &atc.Step{
+								Config: &atc.PutStep{
+									Name: "some-missing-resource",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("validates the duration and the hook", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].soft_timeout: invalid duration 'nope'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].on_soft_timeout.put(some-missing-resource): unknown resource 'some-missing-resource'"))
+				})
+			})
+
 			Context("when a retry plan has a negative attempts number", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1641,6 +1814,149 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a retry plan has an invalid backoff", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.RetryStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Attempts: 3,
+							Backoff: &atc.RetryBackoffConfig{
+								Initial:    "nope",
+								Multiplier: 0.5,
+								Jitter:     2,
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error for each field", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].backoff.initial: invalid duration 'nope'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].backoff.multiplier: must be at least 1"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].backoff.jitter: must be between 0 and 1"))
+				})
+			})
+
+			Context("when a serial_semaphore has no name or a negative limit", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.SemaphoreStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Semaphore: atc.SemaphoreConfig{
+								Limit: -1,
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error for each field", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].serial_semaphore: no name specified"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].serial_semaphore.limit: must not be negative"))
+				})
+			})
+
+			Context("when a serial_semaphore has a limit of 0", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.SemaphoreStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Semaphore: atc.SemaphoreConfig{
+								Name:  "some-semaphore",
+								Limit: 0,
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error, as the limit defaults to 1", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+			})
+
+			Context("when an in_parallel step's limit is not a number or a var", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.InParallelStep{
+							Config: atc.InParallelConfig{
+								Steps: []atc.Step{
+									{
+										Config: &atc.PutStep{
+											Name: "some-resource",
+										},
+									},
+								},
+								Limit: "lots",
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].in_parallel.limit: invalid limit 'lots': must be a number no less than 0"))
+				})
+			})
+
+			Context("when an in_parallel step's limit is a var", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.InParallelStep{
+							Config: atc.InParallelConfig{
+								Steps: []atc.Step{
+									{
+										Config: &atc.PutStep{
+											Name: "some-resource",
+										},
+									},
+								},
+								Limit: "((.:width))",
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+			})
+
+			Context("when a step's when condition is invalid", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.WhenStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Condition: "vault:deploy == true",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].when: invalid condition: invalid reference 'vault:deploy': only local vars (.:name) may be used"))
+				})
+			})
+
 			Context("when a set_pipeline step has no name or file configured", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1752,6 +2068,42 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a get fetches the newest version after passed without any passed constraints", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:    "some-resource",
+							Version: &atc.VersionConfig{NewestAfterPassed: true},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource): version 'newest_after_passed' requires passed constraints"))
+				})
+			})
+
+			Context("when a get's registry mirror has no host", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:           "some-resource",
+							RegistryMirror: &atc.RegistryMirror{Username: "some-user"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource): registry_mirror must specify a host"))
+				})
+			})
+
 			Context("when a load_var has no name or file defined", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1791,14 +2143,13 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
-			Context("when a step has unknown fields", func() {
+			Context("when a load_var declares the var set for error hooks", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.TaskStep{
-							Name:       "task",
-							ConfigPath: "some-file",
+						Config: &atc.LoadVarStep{
+							Name: "error",
+							File: "file1",
 						},
-						UnknownFields: map[string]*json.RawMessage{"bogus": nil},
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -1806,17 +2157,233 @@ var _ = Describe("ValidateConfig", func() {
 
 				It("returns an error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring(`jobs.some-other-job.plan.do[0]: unknown fields ["bogus"]`))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_var(error): var name 'error' is reserved for on_error and on_failure hooks"))
 				})
 			})
 
-			Context("when an across step is valid", func() {
+			Context("when a try step's error var has the same name as a load_var", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.AcrossStep{
-							Step: &atc.PutStep{
-								Name: "some-resource",
-							},
+						Config: &atc.LoadVarStep{
+							Name: "a-var",
+							File: "file1",
+						},
+					}, atc.Step{
+						Config: &atc.TryStep{
+							Step: atc.Step{
+								Config: &atc.LoadVarStep{
+									Name: "another-var",
+									File: "file2",
+								},
+							},
+							ErrorVar: "a-var",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[1].try: repeated var name"))
+				})
+			})
+
+			Context("when a check step is followed by a get of the resource", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.CheckStep{
+							Name: "some-resource",
+						},
+					}, atc.Step{
+						Config: &atc.GetStep{
+							Name: "some-resource",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a check step refers to an unknown resource", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.CheckStep{
+							Name: "some-nonexistent-resource",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].check(some-nonexistent-resource): unknown resource 'some-nonexistent-resource'"))
+				})
+			})
+
+			Context("when a check step's resource is not fetched or pushed by the job", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.CheckStep{
+							Name:     "some-check",
+							Resource: "some-resource",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job checks resource 'some-resource' but does not get or put it"))
+				})
+			})
+
+			Context("when a load_vars has no files defined", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.LoadVarsStep{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_vars(): no files specified"))
+				})
+			})
+
+			Context("when a load_vars glob is not in an artifact", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.LoadVarsStep{
+							Files: "*.yml",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_vars(*.yml): files must be in an artifact"))
+				})
+			})
+
+			Context("when a load_vars glob is malformed", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.LoadVarsStep{
+							Files: "some-artifact/[vars",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_vars(some-artifact/[vars): invalid glob"))
+				})
+			})
+
+			Context("when a load_vars glob has wildcards in its directories", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.LoadVarsStep{
+							Files: "some-artifact/*/vars.yml",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_vars(some-artifact/*/vars.yml): wildcards are only allowed in the file name"))
+				})
+			})
+
+			Context("when an approval has no name", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.ApprovalStep{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].approval(): identifier cannot be an empty string"))
+				})
+			})
+
+			Context("when an approval has an approver without a connector", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.ApprovalStep{
+							Name:      "some-approval",
+							Approvers: []string{"github:some-user", "some-user"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].approval(some-approval).approvers[1]: invalid approver 'some-user'"))
+				})
+			})
+
+			Context("when a publish step has no artifact", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.PublishStep{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].publish(): identifier cannot be an empty string"))
+				})
+			})
+
+			Context("when a step has unknown fields", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "task",
+							ConfigPath: "some-file",
+						},
+						UnknownFields: map[string]*json.RawMessage{"bogus": nil},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring(`jobs.some-other-job.plan.do[0]: unknown fields ["bogus"]`))
+				})
+			})
+
+			Context("when an across step is valid", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
 							Vars: []atc.AcrossVarConfig{
 								{
 									Var:    "var1",
@@ -1844,6 +2411,162 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when an across step reads its values from a file", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:        "var1",
+									ValuesFile: "some-artifact/values.json",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("succeeds", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when an across step has both values and a values file", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:        "var1",
+									Values:     []interface{}{"v1"},
+									ValuesFile: "some-artifact/values.json",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across[0].values_file: cannot be specified alongside values"))
+				})
+			})
+
+			Context("when an across step iterates over versions of an unknown resource", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:      "var1",
+									Versions: &atc.AcrossVersionsConfig{Resource: "bogus-resource"},
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across[0].versions: unknown resource 'bogus-resource'"))
+				})
+			})
+
+			Context("when an across step has both versions and values", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:      "var1",
+									Values:   []interface{}{"v1"},
+									Versions: &atc.AcrossVersionsConfig{Resource: "some-resource", Limit: -1},
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across[0].versions: cannot be specified alongside values or values_file"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across[0].versions: limit must not be negative"))
+				})
+			})
+
+			Context("when an across step combines versions with a values file", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:      "var1",
+									Versions: &atc.AcrossVersionsConfig{Resource: "some-resource"},
+								},
+								{
+									Var:        "var2",
+									ValuesFile: "some-artifact/values.json",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across[0].versions: cannot be combined with vars from a values_file"))
+				})
+			})
+
+			Context("when an across step's values file is not in an artifact", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:        "var1",
+									ValuesFile: "values.json",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across[0].values_file: file must be in an artifact, e.g. some-artifact/values.json"))
+				})
+			})
+
 			Context("when an across step has no vars", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -2111,6 +2834,281 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("validating step templates", func() {
+		var useStep *atc.UseStep
+
+		BeforeEach(func() {
+			config.Resources = append(config.Resources, atc.ResourceConfig{
+				Name: "some-other-resource",
+				Type: "some-type",
+			})
+
+			config.StepTemplates = atc.StepTemplateConfigs{
+				{
+					Name:   "some-template",
+					Params: []atc.StepTemplateParamConfig{{Name: "resource"}},
+					Steps: []atc.Step{
+						{
+							Config: &atc.GetStep{
+								Name: "((resource))",
+							},
+						},
+					},
+				},
+			}
+
+			useStep = &atc.UseStep{
+				Config: atc.UseConfig{
+					Template: "some-template",
+					Params:   atc.Params{"resource": "some-other-resource"},
+				},
+			}
+
+			config.Jobs[0].PlanSequence = append(config.Jobs[0].PlanSequence, atc.Step{
+				Config: useStep,
+			})
+		})
+
+		Context("when the templates are used correctly", func() {
+			It("validates the resources used by their steps", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a template is unknown", func() {
+			BeforeEach(func() {
+				useStep.Config.Template = "bogus-template"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(2))
+				Expect(errorMessages[0]).To(ContainSubstring("resource 'some-other-resource' is not used"))
+				Expect(errorMessages[1]).To(ContainSubstring("jobs.some-job.plan.do[5].use(bogus-template): unknown step template 'bogus-template'"))
+			})
+		})
+
+		Context("when a param is missing", func() {
+			BeforeEach(func() {
+				useStep.Config.Params = nil
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(ContainElement(ContainSubstring("jobs.some-job.plan.do[5].use(some-template): missing param 'resource'")))
+			})
+		})
+
+		Context("when a template's steps are invalid", func() {
+			BeforeEach(func() {
+				config.StepTemplates[0].Steps = append(config.StepTemplates[0].Steps, atc.Step{
+					Config: &atc.TaskStep{Name: "some-task"},
+				})
+			})
+
+			It("returns an error locating the step where the template is used", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.plan.do[5].use(some-template).steps[1].task(some-task): must specify either `file:` or `config:`"))
+			})
+		})
+
+		Context("when a template uses another template", func() {
+			BeforeEach(func() {
+				config.StepTemplates[0].Steps = append(config.StepTemplates[0].Steps, atc.Step{
+					Config: &atc.UseStep{
+						Config: atc.UseConfig{Template: "some-template"},
+					},
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.plan.do[5].use(some-template).steps[1].use(some-template): step templates cannot use other templates"))
+			})
+		})
+
+		Context("when templates are misconfigured", func() {
+			BeforeEach(func() {
+				config.StepTemplates = append(config.StepTemplates,
+					atc.StepTemplateConfig{
+						Name: "some-template",
+						Params: []atc.StepTemplateParamConfig{
+							{Name: "some-param"},
+							{Name: "some-param"},
+						},
+					},
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid step templates:"))
+				Expect(errorMessages[0]).To(ContainSubstring("step_templates[0] and step_templates[1] have the same name ('some-template')"))
+				Expect(errorMessages[0]).To(ContainSubstring("step_templates.some-template has no steps"))
+				Expect(errorMessages[0]).To(ContainSubstring("step_templates.some-template.params[1] repeats param 'some-param'"))
+			})
+		})
+	})
+
+	Describe("validating step defaults", func() {
+		Context("when the defaults are valid", func() {
+			BeforeEach(func() {
+				config.Defaults = &atc.StepDefaults{
+					Timeout:  "1h",
+					Attempts: 3,
+				}
+				config.Jobs[0].Defaults = &atc.StepDefaults{
+					ImageResource: &atc.ImageResource{Type: "registry-image"},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when the pipeline's default timeout is invalid", func() {
+			BeforeEach(func() {
+				config.Defaults = &atc.StepDefaults{Timeout: "nope"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid defaults:"))
+				Expect(errorMessages[0]).To(ContainSubstring("defaults.timeout has invalid duration 'nope'"))
+			})
+		})
+
+		Context("when a job's defaults are invalid", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Defaults = &atc.StepDefaults{
+					Attempts:      -1,
+					ImageResource: &atc.ImageResource{},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.defaults.attempts must not be negative: -1"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.defaults.image_resource has no type"))
+			})
+		})
+	})
+
+	Describe("validating job schedules", func() {
+		Context("when the schedule is valid", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Schedule = &atc.JobScheduleConfig{Cron: "*/15 9-17 * * mon-fri", Location: "Europe/London"}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when the cron expression is invalid", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Schedule = &atc.JobScheduleConfig{Cron: "61 * * * *"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.schedule is invalid:"))
+			})
+		})
+
+		Context("when the location is unknown", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Schedule = &atc.JobScheduleConfig{Cron: "0 * * * *", Location: "Nowhere/Special"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.schedule is invalid: unknown time zone Nowhere/Special"))
+			})
+		})
+	})
+
+	Describe("validating after", func() {
+		Context("when the jobs exist", func() {
+			BeforeEach(func() {
+				config.Jobs[1].After = []atc.JobAfterConfig{{Job: "some-job", SucceededOnly: true}}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when the jobs are invalid", func() {
+			BeforeEach(func() {
+				config.Jobs[0].After = []atc.JobAfterConfig{
+					{Job: "some-job"},
+					{Job: "some-empty-job"},
+					{Job: "some-empty-job"},
+					{Job: "bogus-job"},
+					{},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.after[0] refers to the job itself"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.after[2] repeats job 'some-empty-job'"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.after[3] refers to a job that does not exist ('bogus-job')"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.after[4] has no job"))
+			})
+		})
+
+		Context("when the jobs trigger each other", func() {
+			BeforeEach(func() {
+				config.Jobs[0].After = []atc.JobAfterConfig{{Job: "some-empty-job"}}
+				config.Jobs[1].After = []atc.JobAfterConfig{{Job: "some-job", SucceededOnly: true}}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-empty-job.after forms a cycle: some-job -> some-empty-job -> some-job"))
+			})
+		})
+	})
+
+	Describe("validating includes", func() {
+		Context("when an include refers to a template", func() {
+			BeforeEach(func() {
+				config.Include = []atc.IncludeConfig{{Template: "common"}}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when an include refers to both a template and a file", func() {
+			BeforeEach(func() {
+				config.Include = []atc.IncludeConfig{{Template: "common", File: "repo/common.yml"}}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid includes:"))
+				Expect(errorMessages[0]).To(ContainSubstring("include[0] must specify exactly one of template or file"))
+			})
+		})
+
+		Context("when an include refers to nothing", func() {
+			BeforeEach(func() {
+				config.Include = []atc.IncludeConfig{{}}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("include[0] must specify exactly one of template or file"))
+			})
+		})
+	})
+
 	Describe("invalid pipeline", func() {
 		Context("contains zero jobs", func() {
 			BeforeEach(func() {
@@ -27,7 +27,9 @@ type Container struct {
 	User             string `json:"user,omitempty"`
 	WorkingDirectory string `json:"working_directory,omitempty"`
 
+	CreatedAt int64  `json:"created_at,omitempty"`
 	ExpiresIn string `json:"expires_in,omitempty"`
+	SizeBytes uint64 `json:"size_bytes,omitempty"`
 }
 
 const (
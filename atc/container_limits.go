@@ -13,6 +13,26 @@ var memoryRegex = regexp.MustCompile(`^([0-9]+)([GMK]?[B])?$`)
 type ContainerLimits struct {
 	CPU    *CPULimit    `json:"cpu,omitempty"`
 	Memory *MemoryLimit `json:"memory,omitempty"`
+
+	// Requests are reserved on the worker the container is placed on for as
+	// long as the step runs, and a worker is only chosen if it has enough
+	// capacity left to satisfy them.
+	CPURequest    *CPULimit    `json:"cpu_request,omitempty"`
+	MemoryRequest *MemoryLimit `json:"memory_request,omitempty"`
+}
+
+func (limits ContainerLimits) Validate() []string {
+	var errors []string
+
+	if limits.CPU != nil && limits.CPURequest != nil && *limits.CPURequest > *limits.CPU {
+		errors = append(errors, "cpu_request must not be greater than the cpu limit")
+	}
+
+	if limits.Memory != nil && limits.MemoryRequest != nil && *limits.MemoryRequest > *limits.Memory {
+		errors = append(errors, "memory_request must not be greater than the memory limit")
+	}
+
+	return errors
 }
 
 type CPULimit uint64
@@ -46,6 +66,12 @@ func (m *MemoryLimit) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (m *MemoryLimit) UnmarshalFlag(value string) error {
+	var err error
+	*m, err = ParseMemoryLimit(value)
+	return err
+}
+
 func ParseMemoryLimit(limit string) (MemoryLimit, error) {
 	limit = strings.ToUpper(limit)
 	matches := memoryRegex.FindStringSubmatch(limit)
@@ -1,9 +1,13 @@
 package creds
 
 import (
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/patrickmn/go-cache"
+	"golang.org/x/sync/singleflight"
 )
 
 type SecretCacheConfig struct {
@@ -11,12 +15,18 @@ type SecretCacheConfig struct {
 	Duration         time.Duration `long:"secret-cache-duration" default:"1m" description:"If the cache is enabled, secret values will be cached for not longer than this duration (it can be less, if underlying secret lease time is smaller)"`
 	DurationNotFound time.Duration `long:"secret-cache-duration-notfound" default:"10s" description:"If the cache is enabled, secret not found responses will be cached for this duration"`
 	PurgeInterval    time.Duration `long:"secret-cache-purge-interval" default:"10m" description:"If the cache is enabled, expired items will be removed on this interval"`
+
+	PathDurations map[string]time.Duration `long:"secret-cache-path-duration" value-name:"PATH_PREFIX:DURATION" description:"If the cache is enabled, secrets whose path starts with the prefix will be cached for this duration instead, and not found responses for no longer than it. The longest matching prefix applies, and a duration of 0 disables caching under the prefix. Can be specified multiple times."`
 }
 
 type CachedSecrets struct {
 	secrets     Secrets
 	cacheConfig SecretCacheConfig
 	cache       *cache.Cache
+
+	// lookups collapses concurrent lookups of the same path into one
+	// request to the underlying secret manager.
+	lookups singleflight.Group
 }
 
 type CacheEntry struct {
@@ -37,6 +47,11 @@ func NewCachedSecrets(secrets Secrets, cacheConfig SecretCacheConfig) *CachedSec
 }
 
 func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	duration, durationNotFound, cacheable := cs.durationsFor(secretPath)
+	if !cacheable {
+		return cs.secrets.Get(secretPath)
+	}
+
 	// if there is a corresponding entry in the cache, return it
 	entry, found := cs.cache.Get(secretPath)
 	if found {
@@ -45,33 +60,100 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 	}
 
 	// otherwise, let's make a request to the underlying secret manager
-	value, expiration, found, err := cs.secrets.Get(secretPath)
+	entry, err, _ := cs.lookups.Do(secretPath, func() (interface{}, error) {
+		value, expiration, found, err := cs.secrets.Get(secretPath)
 
-	// we don't want to cache errors, let the errors be retried the next time around
+		// we don't want to cache errors, let the errors be retried the next time around
+		if err != nil {
+			return nil, err
+		}
+
+		// here we want to cache secret value, expiration, and found flag too
+		// meaning that "secret not found" responses will be cached too!
+		entry := CacheEntry{value: value, expiration: expiration, found: found}
+
+		if found {
+			if expiration != nil {
+				// if secret lease time expires sooner, make duration smaller than default duration
+				itemDuration := time.Until(*expiration)
+				if itemDuration < duration {
+					duration = itemDuration
+				}
+			}
+		} else {
+			duration = durationNotFound
+		}
+
+		// a non-positive duration would make the entry never expire
+		if duration > 0 {
+			cs.cache.Set(secretPath, entry, duration)
+		}
+
+		return entry, nil
+	})
 	if err != nil {
 		return nil, nil, false, err
 	}
 
-	// here we want to cache secret value, expiration, and found flag too
-	// meaning that "secret not found" responses will be cached too!
-	entry = CacheEntry{value: value, expiration: expiration, found: found}
+	result := entry.(CacheEntry)
+	return result.value, result.expiration, result.found, nil
+}
+
+// durationsFor returns how long to cache the path's secret and a not found
+// response for it, or false if the path should not be cached at all.
+func (cs *CachedSecrets) durationsFor(secretPath string) (time.Duration, time.Duration, bool) {
+	duration := cs.cacheConfig.Duration
+	durationNotFound := cs.cacheConfig.DurationNotFound
 
-	if found {
-		// take default cache ttl
-		duration := cs.cacheConfig.Duration
-		if expiration != nil {
-			// if secret lease time expires sooner, make duration smaller than default duration
-			itemDuration := time.Until(*expiration)
-			if itemDuration < duration {
-				duration = itemDuration
-			}
+	if len(cs.cacheConfig.PathDurations) == 0 {
+		return duration, durationNotFound, true
+	}
+
+	decodedPath := decodeSecretPath(secretPath)
+
+	longestPrefix := -1
+	for prefix, prefixDuration := range cs.cacheConfig.PathDurations {
+		if strings.HasPrefix(decodedPath, prefix) && len(prefix) > longestPrefix {
+			longestPrefix = len(prefix)
+			duration = prefixDuration
 		}
-		cs.cache.Set(secretPath, entry, duration)
-	} else {
-		cs.cache.Set(secretPath, entry, cs.cacheConfig.DurationNotFound)
 	}
 
-	return value, expiration, found, nil
+	if longestPrefix < 0 {
+		return duration, durationNotFound, true
+	}
+
+	if duration <= 0 {
+		return 0, 0, false
+	}
+
+	if duration < durationNotFound {
+		durationNotFound = duration
+	}
+
+	return duration, durationNotFound, true
+}
+
+// decodeSecretPath returns the path a credential manager will read the secret
+// from. Escaped characters are decoded and dot segments resolved, as they
+// are e.g. by Vault, so that a var like ((..%2Frotated%2Ffoo)) cannot dodge
+// the duration of the prefix it ends up under.
+func decodeSecretPath(secretPath string) string {
+	decoded, err := url.PathUnescape(secretPath)
+	if err != nil {
+		decoded = secretPath
+	}
+
+	if strings.Contains(decoded, "/") {
+		cleaned := path.Clean(decoded)
+		if strings.HasSuffix(decoded, "/") && cleaned != "/" {
+			cleaned += "/"
+		}
+
+		decoded = cleaned
+	}
+
+	return decoded
 }
 
 func (cs *CachedSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
@@ -2,6 +2,7 @@ package creds_test
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/concourse/concourse/atc/creds"
@@ -162,4 +163,117 @@ var _ = Describe("Caching of secrets", func() {
 		Expect(underlyingMisses).To(BeIdenticalTo(4))
 	})
 
+	It("should look up concurrently requested secrets only once", func() {
+		release := make(chan struct{})
+		var readsL sync.Mutex
+		reads := 0
+		secretManager.GetStub = func(secretPath string) (interface{}, *time.Time, bool, error) {
+			readsL.Lock()
+			reads++
+			readsL.Unlock()
+			<-release
+			return "value", nil, true, nil
+		}
+
+		wg := new(sync.WaitGroup)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				value, _, found, err := cachedSecretManager.Get("foo")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("value"))
+			}()
+		}
+
+		Eventually(secretManager.GetCallCount).Should(Equal(1))
+		Consistently(secretManager.GetCallCount, 100*time.Millisecond).Should(Equal(1))
+		close(release)
+		wg.Wait()
+
+		Expect(reads).To(Equal(1))
+	})
+
+	Context("with path durations", func() {
+		BeforeEach(func() {
+			cacheConfig.PathDurations = map[string]time.Duration{
+				"/concourse/main/":        500 * time.Millisecond,
+				"/concourse/main/rotated": 0,
+				"/concourse/other/":       5 * time.Second,
+			}
+			cachedSecretManager = creds.NewCachedSecrets(secretManager, cacheConfig)
+		})
+
+		It("should cache secrets under a prefix for its duration", func() {
+			secretManager.GetStub = makeGetStub("/concourse/main/foo", "value", nil, true, nil, &underlyingReads, &underlyingMisses)
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/main/foo")
+			_, _, _, _ = cachedSecretManager.Get("/concourse/main/foo")
+			Expect(underlyingReads).To(BeIdenticalTo(1))
+
+			time.Sleep(500*time.Millisecond + time.Millisecond)
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/main/foo")
+			Expect(underlyingReads).To(BeIdenticalTo(2))
+		})
+
+		It("should not cache not found responses for longer than the prefix's duration", func() {
+			secretManager.GetStub = makeGetStub("/concourse/main/foo", "value", nil, true, nil, &underlyingReads, &underlyingMisses)
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/main/bar")
+			_, _, _, _ = cachedSecretManager.Get("/concourse/main/bar")
+			Expect(underlyingMisses).To(BeIdenticalTo(1))
+
+			time.Sleep(500*time.Millisecond + time.Millisecond)
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/main/bar")
+			Expect(underlyingMisses).To(BeIdenticalTo(2))
+		})
+
+		It("should still cache not found responses for the not found duration", func() {
+			secretManager.GetStub = makeGetStub("/concourse/other/foo", "value", nil, true, nil, &underlyingReads, &underlyingMisses)
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/other/bar")
+			time.Sleep(cacheConfig.DurationNotFound + time.Millisecond)
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/other/bar")
+			Expect(underlyingMisses).To(BeIdenticalTo(2))
+		})
+
+		It("should not cache secrets under a prefix with a zero duration", func() {
+			secretManager.GetStub = makeGetStub("/concourse/main/rotated/foo", "value", nil, true, nil, &underlyingReads, &underlyingMisses)
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/main/rotated/foo")
+			_, _, _, _ = cachedSecretManager.Get("/concourse/main/rotated/foo")
+			Expect(underlyingReads).To(BeIdenticalTo(2))
+		})
+
+		It("should match the prefixes against the decoded path", func() {
+			for _, secretPath := range []string{
+				"/concourse/main/other/../rotated/foo",
+				"/concourse/main/%72otated/foo",
+				"/concourse/main//rotated/foo",
+			} {
+				secretManager.GetStub = makeGetStub(secretPath, "value", nil, true, nil, &underlyingReads, &underlyingMisses)
+				underlyingReads = 0
+
+				_, _, _, _ = cachedSecretManager.Get(secretPath)
+				_, _, _, _ = cachedSecretManager.Get(secretPath)
+				Expect(underlyingReads).To(BeIdenticalTo(2), secretPath)
+			}
+		})
+
+		It("should cache other secrets for the default duration", func() {
+			secretManager.GetStub = makeGetStub("/concourse/third/foo", "value", nil, true, nil, &underlyingReads, &underlyingMisses)
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/third/foo")
+			time.Sleep(500*time.Millisecond + time.Millisecond)
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/third/foo")
+			Expect(underlyingReads).To(BeIdenticalTo(1))
+		})
+	})
 })
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 )
 
@@ -38,6 +39,21 @@ type FakeVarSourcePool struct {
 	sizeReturnsOnCall map[int]struct {
 		result1 int
 	}
+	StatusStub        func(lager.Logger, map[string]interface{}, creds.ManagerFactory) (atc.VarSourceStatus, error)
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 map[string]interface{}
+		arg3 creds.ManagerFactory
+	}
+	statusReturns struct {
+		result1 atc.VarSourceStatus
+		result2 error
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 atc.VarSourceStatus
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -185,6 +201,72 @@ func (fake *FakeVarSourcePool) SizeReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeVarSourcePool) Status(arg1 lager.Logger, arg2 map[string]interface{}, arg3 creds.ManagerFactory) (atc.VarSourceStatus, error) {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 map[string]interface{}
+		arg3 creds.ManagerFactory
+	}{arg1, arg2, arg3})
+	stub := fake.StatusStub
+	fakeReturns := fake.statusReturns
+	fake.recordInvocation("Status", []interface{}{arg1, arg2, arg3})
+	fake.statusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVarSourcePool) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *FakeVarSourcePool) StatusCalls(stub func(lager.Logger, map[string]interface{}, creds.ManagerFactory) (atc.VarSourceStatus, error)) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = stub
+}
+
+func (fake *FakeVarSourcePool) StatusArgsForCall(i int) (lager.Logger, map[string]interface{}, creds.ManagerFactory) {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	argsForCall := fake.statusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeVarSourcePool) StatusReturns(result1 atc.VarSourceStatus, result2 error) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 atc.VarSourceStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVarSourcePool) StatusReturnsOnCall(i int, result1 atc.VarSourceStatus, result2 error) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 atc.VarSourceStatus
+			result2 error
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 atc.VarSourceStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVarSourcePool) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -194,6 +276,8 @@ func (fake *FakeVarSourcePool) Invocations() map[string][][]interface{} {
 	defer fake.findOrCreateMutex.RUnlock()
 	fake.sizeMutex.RLock()
 	defer fake.sizeMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
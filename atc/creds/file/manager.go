@@ -0,0 +1,132 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// Manager reads vars from a file or directory on the web node. It is meant
+// for local and demo environments, which can then use ((vars)) without
+// running a real credential manager.
+type Manager struct {
+	Path        string   `long:"path" description:"Path to a YAML or JSON file, or a directory of files, to read vars from."`
+	AllowedDirs []string `long:"allowed-dir" description:"Directory which the file var_sources of pipelines may read from. A team's var_sources may only read vars from the TEAM subdirectory. Can be specified multiple times. File var_sources are disabled unless one is given."`
+
+	// restricted is set for the managers of pipelines' var_sources, which
+	// may only read from the allowed dirs
+	restricted bool
+}
+
+func (manager *Manager) Init(log lager.Logger) error {
+	if !manager.restricted {
+		return nil
+	}
+
+	if len(manager.AllowedDirs) == 0 {
+		return errors.New("file var_sources are disabled, use --file-creds-allowed-dir to enable them")
+	}
+
+	allowed, err := isWithinDirs(manager.Path, manager.AllowedDirs)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return fmt.Errorf("path %s is not within an allowed dir", manager.Path)
+	}
+
+	return nil
+}
+
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]interface{}{
+		"path":         manager.Path,
+		"allowed_dirs": manager.AllowedDirs,
+		"health":       health,
+	})
+}
+
+func (manager Manager) IsConfigured() bool {
+	return manager.Path != ""
+}
+
+func (manager Manager) Validate() error {
+	if manager.Path == "" {
+		return errors.New("must provide a path")
+	}
+
+	return nil
+}
+
+func (manager Manager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: "stat",
+	}
+
+	_, err := os.Stat(manager.Path)
+	if err != nil {
+		health.Error = err.Error()
+	}
+
+	return health, nil
+}
+
+func (manager Manager) Close(logger lager.Logger) {
+}
+
+func (manager Manager) NewSecretsFactory(logger lager.Logger) (creds.SecretsFactory, error) {
+	if manager.restricted {
+		return NewSecretsFactory(manager.Path, manager.AllowedDirs), nil
+	}
+
+	return NewSecretsFactory(manager.Path, nil), nil
+}
+
+// isWithinDirs returns whether the path, once symlinks are resolved, is
+// within any of the dirs.
+func isWithinDirs(path string, dirs []string) (bool, error) {
+	resolved, err := resolvePath(path)
+	if err != nil {
+		return false, err
+	}
+
+	for _, dir := range dirs {
+		resolvedDir, err := resolvePath(dir)
+		if err != nil {
+			return false, err
+		}
+
+		rel, err := filepath.Rel(resolvedDir, resolved)
+		if err != nil {
+			continue
+		}
+
+		if rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func resolvePath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.EvalSymlinks(abs)
+}
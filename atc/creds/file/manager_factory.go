@@ -0,0 +1,60 @@
+package file
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+)
+
+type managerFactory struct {
+	// the manager configured by flags, whose allowed dirs restrict where
+	// pipelines' var_sources may read from
+	config *Manager
+}
+
+func init() {
+	creds.Register("file", NewManagerFactory())
+}
+
+func NewManagerFactory() creds.ManagerFactory {
+	return &managerFactory{}
+}
+
+func (factory *managerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &Manager{}
+
+	subGroup, err := group.AddGroup("File Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "file-creds"
+
+	factory.config = manager
+
+	return manager
+}
+
+func (factory *managerFactory) NewInstance(config interface{}) (creds.Manager, error) {
+	configMap, ok := config.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid file credential manager config: %T", config)
+	}
+
+	path, ok := configMap["path"].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid path config: %T", configMap["path"])
+	}
+
+	var allowedDirs []string
+	if factory.config != nil {
+		allowedDirs = factory.config.AllowedDirs
+	}
+
+	return &Manager{
+		Path:        path,
+		AllowedDirs: allowedDirs,
+		restricted:  true,
+	}, nil
+}
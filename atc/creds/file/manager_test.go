@@ -0,0 +1,111 @@
+package file_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/file"
+	flags "github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var tmpDir string
+	var factory creds.ManagerFactory
+	var config *file.Manager
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "file-creds")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(os.MkdirAll(filepath.Join(tmpDir, "allowed"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(tmpDir, "allowed", "vars.yml"), []byte("foo: bar"), 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(tmpDir, "other.yml"), []byte("foo: bar"), 0600)).To(Succeed())
+
+		factory = file.NewManagerFactory()
+
+		parser := flags.NewParser(&struct{}{}, flags.None)
+		config = factory.AddConfig(parser.Group).(*file.Manager)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	Describe("as a var_source", func() {
+		var path string
+		var initErr error
+
+		BeforeEach(func() {
+			path = filepath.Join(tmpDir, "allowed", "vars.yml")
+		})
+
+		JustBeforeEach(func() {
+			manager, err := factory.NewInstance(map[string]interface{}{"path": path})
+			Expect(err).ToNot(HaveOccurred())
+
+			initErr = manager.Init(lagertest.NewTestLogger("test"))
+		})
+
+		Context("when no dirs are allowed", func() {
+			It("is disabled", func() {
+				Expect(initErr).To(MatchError(ContainSubstring("file var_sources are disabled")))
+			})
+		})
+
+		Context("when dirs are allowed", func() {
+			BeforeEach(func() {
+				config.AllowedDirs = []string{filepath.Join(tmpDir, "allowed")}
+			})
+
+			It("reads from a path within them", func() {
+				Expect(initErr).ToNot(HaveOccurred())
+			})
+
+			Context("when the path is outside of them", func() {
+				BeforeEach(func() {
+					path = filepath.Join(tmpDir, "allowed", "..", "other.yml")
+				})
+
+				It("fails", func() {
+					Expect(initErr).To(MatchError(ContainSubstring("not within an allowed dir")))
+				})
+			})
+
+			Context("when the path is a symlink to outside of them", func() {
+				BeforeEach(func() {
+					path = filepath.Join(tmpDir, "allowed", "link.yml")
+					Expect(os.Symlink(filepath.Join(tmpDir, "other.yml"), path)).To(Succeed())
+				})
+
+				It("fails", func() {
+					Expect(initErr).To(MatchError(ContainSubstring("not within an allowed dir")))
+				})
+			})
+		})
+	})
+
+	Describe("as the cluster's credential manager", func() {
+		It("is configured by a path", func() {
+			Expect(config.IsConfigured()).To(BeFalse())
+
+			config.Path = filepath.Join(tmpDir, "other.yml")
+			Expect(config.IsConfigured()).To(BeTrue())
+			Expect(config.Validate()).To(Succeed())
+			Expect(config.Init(lagertest.NewTestLogger("test"))).To(Succeed())
+		})
+
+		It("reports the path as unhealthy when it does not exist", func() {
+			config.Path = filepath.Join(tmpDir, "missing.yml")
+
+			health, err := config.Health()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(health.Error).ToNot(BeEmpty())
+		})
+	})
+})
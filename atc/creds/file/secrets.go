@@ -0,0 +1,164 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+	yaml "sigs.k8s.io/yaml"
+)
+
+// varFileExtensions are the extensions of the files in a directory which are
+// parsed as YAML, in the order they are looked for. The content of a file
+// without one of these extensions is used as a string.
+var varFileExtensions = []string{".yml", ".yaml", ".json"}
+
+// Secrets reads vars from the file or directory at Path. The file, or a file
+// in the directory, is read on every lookup, so changes are picked up
+// without restarting.
+//
+// A file maps each secret path to its value, e.g. 'main/some-pipeline/foo'.
+// In a directory, the secret path is the path of the file relative to it,
+// e.g. 'main/some-pipeline/foo.yml'.
+//
+// When AllowedDirs is set, as it is for pipelines' var_sources, vars are only
+// looked up for the team whose dir, within one of the allowed dirs, contains
+// Path. The secret paths are then relative to the team's dir, e.g.
+// 'some-pipeline/foo', so that a team cannot read another team's vars.
+type Secrets struct {
+	Path        string
+	AllowedDirs []string
+}
+
+func (secrets *Secrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+
+	if len(pipelineName) > 0 {
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(path.Join(teamName, pipelineName)+"/"))
+	}
+
+	lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(teamName+"/"))
+
+	// the root of a team's dir is already looked up through the team's
+	// prefix, and anything above it belongs to other teams
+	if allowRootPath && len(secrets.AllowedDirs) == 0 {
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(""))
+	}
+
+	return lookupPaths
+}
+
+func (secrets *Secrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	// a var name must not be able to reach the secrets of another team or
+	// files outside of the directory
+	if secretPath == "" || path.Clean(secretPath) != secretPath || strings.HasPrefix(secretPath, "/") || strings.HasPrefix(secretPath, "../") {
+		return nil, nil, false, nil
+	}
+
+	if len(secrets.AllowedDirs) > 0 {
+		parts := strings.SplitN(secretPath, "/", 2)
+		if len(parts) != 2 {
+			return nil, nil, false, nil
+		}
+
+		teamName := parts[0]
+		teamDirs := make([]string, len(secrets.AllowedDirs))
+		for i, dir := range secrets.AllowedDirs {
+			teamDirs[i] = filepath.Join(dir, teamName)
+		}
+
+		allowed, err := isWithinDirs(secrets.Path, teamDirs)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		if !allowed {
+			return nil, nil, false, fmt.Errorf("path %s is not within the dir of team %s in an allowed dir", secrets.Path, teamName)
+		}
+
+		secretPath = parts[1]
+	}
+
+	info, err := os.Stat(secrets.Path)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	if info.IsDir() {
+		return secrets.getFromDir(secretPath)
+	}
+
+	return secrets.getFromFile(secretPath)
+}
+
+func (secrets *Secrets) getFromFile(secretPath string) (interface{}, *time.Time, bool, error) {
+	payload, err := ioutil.ReadFile(secrets.Path)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	var vars map[string]interface{}
+	err = yaml.Unmarshal(payload, &vars)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("parse %s: %w", secrets.Path, err)
+	}
+
+	val, found := vars[secretPath]
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	return val, nil, true, nil
+}
+
+func (secrets *Secrets) getFromDir(secretPath string) (interface{}, *time.Time, bool, error) {
+	filePath := filepath.Join(secrets.Path, filepath.FromSlash(secretPath))
+
+	for _, ext := range varFileExtensions {
+		payload, err := ioutil.ReadFile(filePath + ext)
+		if isNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		var val interface{}
+		err = yaml.Unmarshal(payload, &val)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("parse %s: %w", filePath+ext, err)
+		}
+
+		return val, nil, true, nil
+	}
+
+	info, err := os.Stat(filePath)
+	if isNotExist(err) || (err == nil && info.IsDir()) {
+		return nil, nil, false, nil
+	}
+
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	payload, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return strings.TrimSuffix(string(payload), "\n"), nil, true, nil
+}
+
+// isNotExist returns whether the error is from a file which does not exist,
+// including when part of its path is a file rather than a directory.
+func isNotExist(err error) bool {
+	return os.IsNotExist(err) || errors.Is(err, syscall.ENOTDIR)
+}
@@ -0,0 +1,24 @@
+package file
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type SecretsFactory struct {
+	path        string
+	allowedDirs []string
+}
+
+func NewSecretsFactory(path string, allowedDirs []string) *SecretsFactory {
+	return &SecretsFactory{
+		path:        path,
+		allowedDirs: allowedDirs,
+	}
+}
+
+func (factory *SecretsFactory) NewSecrets() creds.Secrets {
+	return &Secrets{
+		Path:        factory.path,
+		AllowedDirs: factory.allowedDirs,
+	}
+}
@@ -0,0 +1,195 @@
+package file_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/file"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secrets", func() {
+	var tmpDir string
+	var secrets *file.Secrets
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "file-creds")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	writeFile := func(path, content string) {
+		fullPath := filepath.Join(tmpDir, filepath.FromSlash(path))
+		Expect(os.MkdirAll(filepath.Dir(fullPath), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(fullPath, []byte(content), 0600)).To(Succeed())
+	}
+
+	get := func(teamName, pipelineName string, allowRootPath bool, ref vars.Reference) (interface{}, bool, error) {
+		return creds.NewVariables(secrets, teamName, pipelineName, allowRootPath).Get(ref)
+	}
+
+	lookup := func(teamName, pipelineName string, allowRootPath bool, ref vars.Reference) interface{} {
+		val, found, err := get(teamName, pipelineName, allowRootPath, ref)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return val
+	}
+
+	Context("when the path is a file", func() {
+		BeforeEach(func() {
+			writeFile("vars.yml", `
+main/some-pipeline/foo: pipeline-foo
+main/foo: team-foo
+other/foo: other-foo
+bar: root-bar
+creds:
+  user: admin
+`)
+
+			secrets = &file.Secrets{Path: filepath.Join(tmpDir, "vars.yml")}
+		})
+
+		It("looks up the pipeline's var first", func() {
+			Expect(lookup("main", "some-pipeline", false, vars.Reference{Path: "foo"})).To(Equal("pipeline-foo"))
+		})
+
+		It("falls back to the team's var", func() {
+			Expect(lookup("main", "other-pipeline", false, vars.Reference{Path: "foo"})).To(Equal("team-foo"))
+		})
+
+		It("only looks up vars at the root when allowed", func() {
+			_, found, err := get("main", "some-pipeline", false, vars.Reference{Path: "bar"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			Expect(lookup("main", "some-pipeline", true, vars.Reference{Path: "bar"})).To(Equal("root-bar"))
+		})
+
+		It("looks up fields of a var", func() {
+			Expect(lookup("main", "", true, vars.Reference{Path: "creds", Fields: []string{"user"}})).To(Equal("admin"))
+		})
+
+		It("does not allow reaching another team's vars", func() {
+			_, found, err := get("main", "", false, vars.Reference{Path: "../other/foo"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("picks up changes to the file", func() {
+			writeFile("vars.yml", `main/foo: changed`)
+			Expect(lookup("main", "", false, vars.Reference{Path: "foo"})).To(Equal("changed"))
+		})
+
+		Context("when the file is invalid", func() {
+			BeforeEach(func() {
+				writeFile("vars.yml", `[not a map`)
+			})
+
+			It("returns an error", func() {
+				_, _, err := get("main", "", false, vars.Reference{Path: "foo"})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("when the path is a directory", func() {
+		BeforeEach(func() {
+			writeFile("vars/main/some-pipeline/foo.yml", "pipeline-foo")
+			writeFile("vars/main/creds.json", `{"user": "admin"}`)
+			writeFile("vars/main/key", "-----BEGIN KEY-----\nsome-key\n-----END KEY-----\n")
+			writeFile("vars/other/foo.yml", "other-foo")
+			writeFile("outside.yml", "outside")
+
+			secrets = &file.Secrets{Path: filepath.Join(tmpDir, "vars")}
+		})
+
+		It("reads the var from the file at its path", func() {
+			Expect(lookup("main", "some-pipeline", false, vars.Reference{Path: "foo"})).To(Equal("pipeline-foo"))
+		})
+
+		It("parses JSON files", func() {
+			Expect(lookup("main", "some-pipeline", false, vars.Reference{Path: "creds", Fields: []string{"user"}})).To(Equal("admin"))
+		})
+
+		It("reads files without an extension as strings", func() {
+			Expect(lookup("main", "", false, vars.Reference{Path: "key"})).To(Equal("-----BEGIN KEY-----\nsome-key\n-----END KEY-----"))
+		})
+
+		It("does not find vars which are directories", func() {
+			_, found, err := get("main", "", true, vars.Reference{Path: "main"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not find vars below a file", func() {
+			_, found, err := get("main", "", false, vars.Reference{Path: "key/foo"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not allow reaching another team's vars or files outside the directory", func() {
+			for _, path := range []string{"../other/foo", "../../outside", "/other/foo"} {
+				_, found, err := get("main", "", true, vars.Reference{Path: path})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			}
+		})
+	})
+
+	Context("when dirs are allowed", func() {
+		BeforeEach(func() {
+			writeFile("allowed/main/vars.yml", `
+some-pipeline/foo: pipeline-foo
+foo: team-foo
+`)
+			writeFile("allowed/main/vars/some-pipeline/foo.yml", "pipeline-foo")
+			writeFile("allowed/main/vars/foo.yml", "team-foo")
+			writeFile("allowed/other/vars.yml", `foo: other-foo`)
+			writeFile("allowed/vars.yml", `main/foo: shared-foo`)
+		})
+
+		setPath := func(path string) {
+			secrets = &file.Secrets{
+				Path:        filepath.Join(tmpDir, filepath.FromSlash(path)),
+				AllowedDirs: []string{filepath.Join(tmpDir, "allowed")},
+			}
+		}
+
+		It("looks up vars relative to the team's dir", func() {
+			setPath("allowed/main/vars.yml")
+			Expect(lookup("main", "some-pipeline", true, vars.Reference{Path: "foo"})).To(Equal("pipeline-foo"))
+			Expect(lookup("main", "other-pipeline", true, vars.Reference{Path: "foo"})).To(Equal("team-foo"))
+
+			setPath("allowed/main/vars")
+			Expect(lookup("main", "some-pipeline", true, vars.Reference{Path: "foo"})).To(Equal("pipeline-foo"))
+			Expect(lookup("main", "other-pipeline", true, vars.Reference{Path: "foo"})).To(Equal("team-foo"))
+		})
+
+		It("does not allow reading another team's vars", func() {
+			setPath("allowed/other/vars.yml")
+			_, _, err := get("main", "", true, vars.Reference{Path: "foo"})
+			Expect(err).To(MatchError(ContainSubstring("not within the dir of team main")))
+		})
+
+		It("does not allow reading vars shared by all teams", func() {
+			setPath("allowed/vars.yml")
+			_, _, err := get("main", "", true, vars.Reference{Path: "foo"})
+			Expect(err).To(MatchError(ContainSubstring("not within the dir of team main")))
+		})
+
+		It("does not allow reaching above the team's dir", func() {
+			setPath("allowed/main/vars")
+			_, found, err := get("main", "", true, vars.Reference{Path: "../../other/vars"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,28 @@
+package creds
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+)
+
+type InParallelLimit struct {
+	variablesResolver vars.Variables
+	rawLimit          atc.InParallelLimit
+}
+
+func NewInParallelLimit(variables vars.Variables, limit atc.InParallelLimit) InParallelLimit {
+	return InParallelLimit{
+		variablesResolver: variables,
+		rawLimit:          limit,
+	}
+}
+
+func (l InParallelLimit) Evaluate() (int, error) {
+	var limit atc.InParallelLimit
+	err := evaluate(l.variablesResolver, l.rawLimit, &limit)
+	if err != nil {
+		return 0, err
+	}
+
+	return limit.Int()
+}
@@ -0,0 +1,49 @@
+package creds_test
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InParallelLimit", func() {
+	var variables vars.StaticVariables
+
+	BeforeEach(func() {
+		variables = vars.StaticVariables{
+			"number": 3,
+			"string": "4",
+			"bogus":  "lots",
+		}
+	})
+
+	It("returns a static limit", func() {
+		limit, err := creds.NewInParallelLimit(variables, "2").Evaluate()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limit).To(Equal(2))
+	})
+
+	It("resolves a var holding a number", func() {
+		limit, err := creds.NewInParallelLimit(variables, "((number))").Evaluate()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limit).To(Equal(3))
+	})
+
+	It("resolves a var holding a string", func() {
+		limit, err := creds.NewInParallelLimit(variables, "((string))").Evaluate()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limit).To(Equal(4))
+	})
+
+	It("errors when the var is not a number", func() {
+		_, err := creds.NewInParallelLimit(variables, "((bogus))").Evaluate()
+		Expect(err).To(MatchError("invalid limit 'lots': must be a number no less than 0"))
+	})
+
+	It("errors when the var is missing", func() {
+		_, err := creds.NewInParallelLimit(variables, "((missing))").Evaluate()
+		Expect(err).To(HaveOccurred())
+	})
+})
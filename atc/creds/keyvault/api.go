@@ -0,0 +1,174 @@
+package keyvault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	apiVersion = "7.2"
+
+	// vaultResource is the resource for which access tokens are requested
+	vaultResource = "https://vault.azure.net"
+
+	defaultAuthorityURL = "https://login.microsoftonline.com"
+
+	// defaultIdentityEndpoint is the Azure Instance Metadata Service endpoint
+	// handing out access tokens for the VM's managed identity
+	defaultIdentityEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+)
+
+// Secret is the subset of a Key Vault secret bundle used by Concourse.
+type Secret struct {
+	Value       string `json:"value"`
+	ContentType string `json:"contentType"`
+	Attributes  struct {
+		Expires *int64 `json:"exp"`
+	} `json:"attributes"`
+}
+
+// ErrSecretNotFound is returned when the vault has no (enabled) secret by the
+// given name.
+type ErrSecretNotFound struct {
+	Name string
+}
+
+func (err ErrSecretNotFound) Error() string {
+	return fmt.Sprintf("secret '%s' not found", err.Name)
+}
+
+type KeyVaultAPI interface {
+	GetSecret(name string) (*Secret, error)
+}
+
+type api struct {
+	vaultURL string
+	client   *http.Client
+}
+
+// NewAPI returns a KeyVaultAPI which authenticates its requests to the vault
+// with access tokens from the given source.
+func NewAPI(vaultURL string, tokenSource oauth2.TokenSource) KeyVaultAPI {
+	return &api{
+		vaultURL: strings.TrimSuffix(vaultURL, "/"),
+		client:   oauth2.NewClient(context.Background(), oauth2.ReuseTokenSource(nil, tokenSource)),
+	}
+}
+
+func (a *api) GetSecret(name string) (*Secret, error) {
+	secretURL := fmt.Sprintf("%s/secrets/%s?api-version=%s", a.vaultURL, url.PathEscape(name), apiVersion)
+
+	response, err := a.client.Get(secretURL)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrSecretNotFound{Name: name}
+	default:
+		var body struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+
+		_ = json.NewDecoder(response.Body).Decode(&body)
+
+		return nil, fmt.Errorf("key vault responded with %d: %s %s", response.StatusCode, body.Error.Code, body.Error.Message)
+	}
+
+	var secret Secret
+	err = json.NewDecoder(response.Body).Decode(&secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
+// servicePrincipalTokenSource authenticates as an Azure AD application using
+// its client secret.
+func servicePrincipalTokenSource(authorityURL, tenantID, clientID, clientSecret string) oauth2.TokenSource {
+	config := clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     fmt.Sprintf("%s/%s/oauth2/v2.0/token", strings.TrimSuffix(authorityURL, "/"), tenantID),
+		Scopes:       []string{vaultResource + "/.default"},
+	}
+
+	return config.TokenSource(context.Background())
+}
+
+// managedIdentityTokenSource fetches tokens for the managed identity of the
+// machine Concourse runs on. The client ID selects a user-assigned identity
+// and may be left empty for the system-assigned one.
+type managedIdentityTokenSource struct {
+	endpoint string
+	clientID string
+	client   *http.Client
+}
+
+func (source managedIdentityTokenSource) Token() (*oauth2.Token, error) {
+	query := url.Values{
+		"api-version": []string{"2018-02-01"},
+		"resource":    []string{vaultResource},
+	}
+
+	if source.clientID != "" {
+		query.Set("client_id", source.clientID)
+	}
+
+	request, err := http.NewRequest("GET", source.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Metadata", "true")
+
+	response, err := source.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("managed identity endpoint responded with %d", response.StatusCode)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresOn   string `json:"expires_on"`
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&body)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &oauth2.Token{
+		AccessToken: body.AccessToken,
+		TokenType:   body.TokenType,
+	}
+
+	var expiresOn int64
+	if _, err := fmt.Sscan(body.ExpiresOn, &expiresOn); err == nil {
+		token.Expiry = time.Unix(expiresOn, 0)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,119 @@
+package keyvault
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("API", func() {
+	var server *ghttp.Server
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("GetSecret", func() {
+		var keyVaultAPI KeyVaultAPI
+
+		BeforeEach(func() {
+			keyVaultAPI = NewAPI(server.URL()+"/", oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "some-token"}))
+		})
+
+		It("fetches the latest version of the secret with the access token", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/secrets/some-secret", "api-version=7.2"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+				ghttp.RespondWith(http.StatusOK, `{"value":"some-value","contentType":"text/plain","attributes":{"exp":1600000000}}`),
+			))
+
+			secret, err := keyVaultAPI.GetSecret("some-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value).To(Equal("some-value"))
+			Expect(secret.ContentType).To(Equal("text/plain"))
+			Expect(*secret.Attributes.Expires).To(Equal(int64(1600000000)))
+		})
+
+		It("returns ErrSecretNotFound when the secret does not exist", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, `{"error":{"code":"SecretNotFound"}}`))
+
+			_, err := keyVaultAPI.GetSecret("some-secret")
+			Expect(err).To(Equal(ErrSecretNotFound{Name: "some-secret"}))
+		})
+
+		It("returns the vault's error otherwise", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, `{"error":{"code":"Forbidden","message":"no access"}}`))
+
+			_, err := keyVaultAPI.GetSecret("some-secret")
+			Expect(err).To(MatchError("key vault responded with 403: Forbidden no access"))
+		})
+	})
+
+	Describe("servicePrincipalTokenSource", func() {
+		It("requests a token for the vault with the client credentials", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/some-tenant/oauth2/v2.0/token"),
+				ghttp.VerifyBasicAuth("some-client", "some-secret"),
+				ghttp.VerifyFormKV("grant_type", "client_credentials"),
+				ghttp.VerifyFormKV("scope", "https://vault.azure.net/.default"),
+				ghttp.RespondWith(http.StatusOK, `{"access_token":"some-token","token_type":"Bearer","expires_in":3600}`, http.Header{
+					"Content-Type": []string{"application/json"},
+				}),
+			))
+
+			token, err := servicePrincipalTokenSource(server.URL(), "some-tenant", "some-client", "some-secret").Token()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(token.AccessToken).To(Equal("some-token"))
+		})
+	})
+
+	Describe("managedIdentityTokenSource", func() {
+		var source managedIdentityTokenSource
+
+		BeforeEach(func() {
+			source = managedIdentityTokenSource{
+				endpoint: server.URL() + "/metadata/identity/oauth2/token",
+				client:   http.DefaultClient,
+			}
+		})
+
+		It("requests a token for the vault from the metadata service", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/metadata/identity/oauth2/token", "api-version=2018-02-01&resource=https%3A%2F%2Fvault.azure.net"),
+				ghttp.VerifyHeaderKV("Metadata", "true"),
+				ghttp.RespondWith(http.StatusOK, `{"access_token":"some-token","token_type":"Bearer","expires_on":"1600000000"}`),
+			))
+
+			token, err := source.Token()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(token.AccessToken).To(Equal("some-token"))
+			Expect(token.Expiry.Unix()).To(Equal(int64(1600000000)))
+		})
+
+		It("selects the user-assigned identity by client id", func() {
+			source.clientID = "some-client"
+
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/metadata/identity/oauth2/token", "api-version=2018-02-01&client_id=some-client&resource=https%3A%2F%2Fvault.azure.net"),
+				ghttp.RespondWith(http.StatusOK, `{"access_token":"some-token","token_type":"Bearer","expires_on":"1600000000"}`),
+			))
+
+			_, err := source.Token()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("fails when the metadata service does", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadRequest, ""))
+
+			_, err := source.Token()
+			Expect(err).To(MatchError("managed identity endpoint responded with 400"))
+		})
+	})
+})
@@ -0,0 +1,138 @@
+package keyvault
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// secretName matches the names Key Vault allows secrets to have
+var secretName = regexp.MustCompile(`^[0-9a-zA-Z-]{1,127}$`)
+
+type KeyVault struct {
+	log             lager.Logger
+	api             KeyVaultAPI
+	secretTemplates []*creds.SecretTemplate
+}
+
+func NewKeyVault(log lager.Logger, api KeyVaultAPI, secretTemplates []*creds.SecretTemplate) *KeyVault {
+	return &KeyVault{
+		log:             log,
+		api:             api,
+		secretTemplates: secretTemplates,
+	}
+}
+
+// NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
+func (k *KeyVault) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+
+	teamName, ok := escapeNamePart(teamName)
+	if !ok {
+		return lookupPaths
+	}
+
+	if pipelineName != "" {
+		pipelineName, ok = escapeNamePart(pipelineName)
+		if !ok {
+			pipelineName = ""
+		}
+	}
+
+	for _, tmpl := range k.secretTemplates {
+		if lPath := creds.NewSecretLookupWithTemplate(tmpl, teamName, pipelineName); lPath != nil {
+			lookupPaths = append(lookupPaths, escapedLookupPath{lPath})
+		}
+	}
+	return lookupPaths
+}
+
+// escapedLookupPath escapes the secret's name before filling it in to the
+// template, as the team and pipeline names are.
+type escapedLookupPath struct {
+	creds.SecretLookupPath
+}
+
+// VariableToSecretPath returns an empty name, which is never looked up, for
+// secrets whose names cannot be escaped.
+func (path escapedLookupPath) VariableToSecretPath(secret string) (string, error) {
+	secret, ok := escapeNamePart(secret)
+	if !ok {
+		return "", nil
+	}
+
+	return path.SecretLookupPath.VariableToSecretPath(secret)
+}
+
+// escapeNamePart doubles the dashes in a team, pipeline or secret name, so
+// that they cannot be confused with the single dashes joining the names in
+// the templates. Otherwise team "a-b" and pipeline "c" would share their
+// secrets with team "a" and pipeline "b-c". A name starting or ending with a
+// dash would still be ambiguous next to a joining dash, so it is rejected.
+func escapeNamePart(name string) (string, bool) {
+	if strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") {
+		return "", false
+	}
+
+	return strings.ReplaceAll(name, "-", "--"), true
+}
+
+// Get retrieves the value and expiration of an individual secret
+func (k *KeyVault) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, expiration, found, err := k.getSecret(secretPath)
+	if err != nil {
+		k.log.Error("failed-to-fetch-key-vault-secret", err, lager.Data{
+			"secret-path": secretPath,
+		})
+		return nil, nil, false, err
+	}
+	if found {
+		return value, expiration, true, nil
+	}
+	return nil, nil, false, nil
+}
+
+// getSecret looks up a secret by name. Secrets with a content type of
+// application/json are decoded into a map[string]interface{} so that their
+// fields can be referenced; all others are returned as a string.
+//
+// Key Vault only allows alphanumerics and dashes in secret names, so names
+// with any other character (e.g. from a team or pipeline name) are never
+// found. Dashes in the team, pipeline and secret names are doubled by the
+// lookup paths; see escapeNamePart.
+func (k *KeyVault) getSecret(name string) (interface{}, *time.Time, bool, error) {
+	if !secretName.MatchString(name) {
+		return nil, nil, false, nil
+	}
+
+	secret, err := k.api.GetSecret(name)
+	if err != nil {
+		if _, ok := err.(ErrSecretNotFound); ok {
+			return nil, nil, false, nil
+		}
+
+		return nil, nil, false, err
+	}
+
+	var expiration *time.Time
+	if secret.Attributes.Expires != nil {
+		exp := time.Unix(*secret.Attributes.Expires, 0)
+		expiration = &exp
+	}
+
+	if secret.ContentType == "application/json" {
+		var values map[string]interface{}
+		err := json.Unmarshal([]byte(secret.Value), &values)
+		if err != nil {
+			return nil, nil, true, err
+		}
+
+		return values, expiration, true, nil
+	}
+
+	return secret.Value, expiration, true, nil
+}
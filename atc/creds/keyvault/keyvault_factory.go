@@ -0,0 +1,24 @@
+package keyvault
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type keyVaultFactory struct {
+	log             lager.Logger
+	api             KeyVaultAPI
+	secretTemplates []*creds.SecretTemplate
+}
+
+func NewKeyVaultFactory(log lager.Logger, api KeyVaultAPI, secretTemplates []*creds.SecretTemplate) *keyVaultFactory {
+	return &keyVaultFactory{
+		log:             log,
+		api:             api,
+		secretTemplates: secretTemplates,
+	}
+}
+
+func (factory *keyVaultFactory) NewSecrets() creds.Secrets {
+	return NewKeyVault(factory.log, factory.api, factory.secretTemplates)
+}
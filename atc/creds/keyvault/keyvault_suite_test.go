@@ -0,0 +1,13 @@
+package keyvault_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestKeyVault(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Key Vault Creds Suite")
+}
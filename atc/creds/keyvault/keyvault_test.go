@@ -0,0 +1,160 @@
+package keyvault_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	. "github.com/concourse/concourse/atc/creds/keyvault"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type MockKeyVaultAPI struct {
+	stubGetSecret func(name string) (*Secret, error)
+	requested     []string
+}
+
+func (mock *MockKeyVaultAPI) GetSecret(name string) (*Secret, error) {
+	mock.requested = append(mock.requested, name)
+	if mock.stubGetSecret == nil {
+		return nil, errors.New("stubGetSecret is not defined")
+	}
+	return mock.stubGetSecret(name)
+}
+
+var _ = Describe("KeyVault", func() {
+	var keyVault *KeyVault
+	var variables vars.Variables
+	var varRef vars.Reference
+	var mockAPI *MockKeyVaultAPI
+
+	BeforeEach(func() {
+		mockAPI = &MockKeyVaultAPI{}
+		mockAPI.stubGetSecret = func(name string) (*Secret, error) {
+			if name == "concourse-alpha-bogus-cheery" {
+				return &Secret{Value: "pipeline value"}, nil
+			}
+			return nil, ErrSecretNotFound{Name: name}
+		}
+	})
+
+	JustBeforeEach(func() {
+		varRef = vars.Reference{Path: "cheery"}
+		t1, err := creds.BuildSecretTemplate("t1", DefaultPipelineSecretTemplate)
+		Expect(err).To(BeNil())
+		t2, err := creds.BuildSecretTemplate("t2", DefaultTeamSecretTemplate)
+		Expect(err).To(BeNil())
+		keyVault = NewKeyVault(lagertest.NewTestLogger("keyvault_test"), mockAPI, []*creds.SecretTemplate{t1, t2})
+		variables = creds.NewVariables(keyVault, "alpha", "bogus", false)
+	})
+
+	Describe("Get()", func() {
+		It("should get pipeline secret if exists", func() {
+			value, found, err := variables.Get(varRef)
+			Expect(value).To(BeEquivalentTo("pipeline value"))
+			Expect(found).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+
+		It("should get team secret if exists", func() {
+			mockAPI.stubGetSecret = func(name string) (*Secret, error) {
+				if name == "concourse-alpha-cheery" {
+					return &Secret{Value: "team value"}, nil
+				}
+				return nil, ErrSecretNotFound{Name: name}
+			}
+			value, found, err := variables.Get(varRef)
+			Expect(value).To(BeEquivalentTo("team value"))
+			Expect(found).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+
+		It("should decode JSON secrets so their fields can be referenced", func() {
+			mockAPI.stubGetSecret = func(name string) (*Secret, error) {
+				return &Secret{Value: `{"name":"yours","pass":"truly"}`, ContentType: "application/json"}, nil
+			}
+			value, found, err := variables.Get(vars.Reference{Path: "user", Fields: []string{"pass"}})
+			Expect(value).To(BeEquivalentTo("truly"))
+			Expect(found).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+
+		It("should not find secrets which do not exist", func() {
+			mockAPI.stubGetSecret = func(name string) (*Secret, error) {
+				return nil, ErrSecretNotFound{Name: name}
+			}
+			value, found, err := variables.Get(varRef)
+			Expect(value).To(BeNil())
+			Expect(found).To(BeFalse())
+			Expect(err).To(BeNil())
+		})
+
+		It("should return errors from the vault", func() {
+			mockAPI.stubGetSecret = nil
+			value, found, err := variables.Get(varRef)
+			Expect(value).To(BeNil())
+			Expect(found).To(BeFalse())
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should not look up names Key Vault does not allow", func() {
+			variables = creds.NewVariables(keyVault, "alpha", "some_pipeline", false)
+			mockAPI.stubGetSecret = func(name string) (*Secret, error) {
+				return &Secret{Value: "team value"}, nil
+			}
+			value, found, err := variables.Get(varRef)
+			Expect(value).To(BeEquivalentTo("team value"))
+			Expect(found).To(BeTrue())
+			Expect(err).To(BeNil())
+			Expect(mockAPI.requested).To(Equal([]string{"concourse-alpha-cheery"}))
+		})
+
+		It("should double the dashes in names so that they do not collide across teams", func() {
+			mockAPI.stubGetSecret = func(name string) (*Secret, error) {
+				return nil, ErrSecretNotFound{Name: name}
+			}
+
+			_, _, err := creds.NewVariables(keyVault, "a-b", "c", false).Get(vars.Reference{Path: "some-secret"})
+			Expect(err).To(BeNil())
+
+			_, _, err = creds.NewVariables(keyVault, "a", "b-c", false).Get(vars.Reference{Path: "some-secret"})
+			Expect(err).To(BeNil())
+
+			Expect(mockAPI.requested).To(Equal([]string{
+				"concourse-a--b-c-some--secret",
+				"concourse-a--b-some--secret",
+				"concourse-a-b--c-some--secret",
+				"concourse-a-some--secret",
+			}))
+		})
+
+		It("should not look up names starting or ending with a dash", func() {
+			mockAPI.stubGetSecret = func(name string) (*Secret, error) {
+				return &Secret{Value: "some value"}, nil
+			}
+
+			_, found, err := variables.Get(vars.Reference{Path: "-cheery"})
+			Expect(err).To(BeNil())
+			Expect(found).To(BeFalse())
+			Expect(mockAPI.requested).To(BeEmpty())
+		})
+	})
+
+	Describe("Get() expiration", func() {
+		It("returns the secret's expiry so that cached values expire with it", func() {
+			expires := int64(1600000000)
+			mockAPI.stubGetSecret = func(name string) (*Secret, error) {
+				secret := &Secret{Value: "some value"}
+				secret.Attributes.Expires = &expires
+				return secret, nil
+			}
+			_, expiration, found, err := keyVault.Get("concourse-alpha-cheery")
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(expiration.Unix()).To(Equal(expires))
+		})
+	})
+})
@@ -0,0 +1,147 @@
+package keyvault
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+	"golang.org/x/oauth2"
+)
+
+const DefaultPipelineSecretTemplate = "concourse-{{.Team}}-{{.Pipeline}}-{{.Secret}}"
+const DefaultTeamSecretTemplate = "concourse-{{.Team}}-{{.Secret}}"
+
+const (
+	authMethodManagedIdentity  = "managed-identity"
+	authMethodServicePrincipal = "service-principal"
+)
+
+type Manager struct {
+	VaultURL               string `long:"url" description:"Azure Key Vault URL, e.g. https://my-vault.vault.azure.net"`
+	TenantID               string `long:"tenant-id" description:"Azure AD tenant of the service principal"`
+	ClientID               string `long:"client-id" description:"Client ID of the service principal, or of a user-assigned managed identity"`
+	ClientSecret           string `long:"client-secret" description:"Client secret of the service principal. If not set, the managed identity of the machine will be used"`
+	PipelineSecretTemplate string `long:"pipeline-secret-template" description:"Azure Key Vault secret name template used for pipeline specific parameter. Dashes in the team, pipeline and secret names are doubled" default:"concourse-{{.Team}}-{{.Pipeline}}-{{.Secret}}"`
+	TeamSecretTemplate     string `long:"team-secret-template" description:"Azure Key Vault secret name template used for team specific parameter. Dashes in the team and secret names are doubled" default:"concourse-{{.Team}}-{{.Secret}}"`
+	KeyVault               *KeyVault
+}
+
+func (manager *Manager) Init(log lager.Logger) error {
+	manager.KeyVault = &KeyVault{
+		log: log,
+		api: manager.newAPI(),
+	}
+
+	return nil
+}
+
+func (manager *Manager) newAPI() KeyVaultAPI {
+	return NewAPI(manager.VaultURL, manager.tokenSource())
+}
+
+func (manager *Manager) tokenSource() oauth2.TokenSource {
+	if manager.authMethod() == authMethodServicePrincipal {
+		return servicePrincipalTokenSource(defaultAuthorityURL, manager.TenantID, manager.ClientID, manager.ClientSecret)
+	}
+
+	return managedIdentityTokenSource{
+		endpoint: defaultIdentityEndpoint,
+		clientID: manager.ClientID,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (manager *Manager) authMethod() string {
+	if manager.ClientSecret != "" {
+		return authMethodServicePrincipal
+	}
+
+	return authMethodManagedIdentity
+}
+
+func (manager *Manager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: "GetSecret",
+	}
+
+	_, _, _, err := manager.KeyVault.getSecret("concourse-health-check")
+	if err != nil {
+		health.Error = err.Error()
+		return health, nil
+	}
+
+	health.Response = map[string]string{
+		"status": "UP",
+	}
+
+	return health, nil
+}
+
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]interface{}{
+		"url":                      manager.VaultURL,
+		"auth_method":              manager.authMethod(),
+		"pipeline_secret_template": manager.PipelineSecretTemplate,
+		"team_secret_template":     manager.TeamSecretTemplate,
+		"health":                   health,
+	})
+}
+
+func (manager *Manager) IsConfigured() bool {
+	return manager.VaultURL != ""
+}
+
+func (manager *Manager) Validate() error {
+	if _, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate); err != nil {
+		return err
+	}
+	if _, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate); err != nil {
+		return err
+	}
+
+	// Without a client secret the machine's managed identity is used, for which
+	// a client ID is optional. A service principal needs all three.
+	if manager.ClientSecret == "" {
+		if manager.TenantID != "" {
+			return errors.New("must provide client secret when providing tenant id")
+		}
+
+		return nil
+	}
+
+	if manager.TenantID == "" {
+		return errors.New("must provide tenant id when using a client secret")
+	}
+
+	if manager.ClientID == "" {
+		return errors.New("must provide client id when using a client secret")
+	}
+
+	return nil
+}
+
+func (manager *Manager) NewSecretsFactory(log lager.Logger) (creds.SecretsFactory, error) {
+	pipelineSecretTemplate, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	teamSecretTemplate, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeyVaultFactory(log, manager.newAPI(), []*creds.SecretTemplate{pipelineSecretTemplate, teamSecretTemplate}), nil
+}
+
+func (manager Manager) Close(logger lager.Logger) {
+	// nothing to close
+}
@@ -0,0 +1,79 @@
+package keyvault_test
+
+import (
+	"github.com/concourse/concourse/atc/creds/keyvault"
+	flags "github.com/jessevdk/go-flags"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyVaultManager", func() {
+	var manager keyvault.Manager
+
+	Describe("IsConfigured()", func() {
+		JustBeforeEach(func() {
+			_, err := flags.ParseArgs(&manager, []string{})
+			Expect(err).To(BeNil())
+		})
+
+		It("fails on empty Manager", func() {
+			Expect(manager.IsConfigured()).To(BeFalse())
+		})
+
+		It("passes if VaultURL is set", func() {
+			manager.VaultURL = "https://some-vault.vault.azure.net"
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+	})
+
+	Describe("Validate()", func() {
+		JustBeforeEach(func() {
+			manager = keyvault.Manager{VaultURL: "https://some-vault.vault.azure.net"}
+			_, err := flags.ParseArgs(&manager, []string{})
+			Expect(err).To(BeNil())
+			Expect(manager.PipelineSecretTemplate).To(Equal(keyvault.DefaultPipelineSecretTemplate))
+			Expect(manager.TeamSecretTemplate).To(Equal(keyvault.DefaultTeamSecretTemplate))
+		})
+
+		It("passes on default parameters", func() {
+			Expect(manager.Validate()).To(BeNil())
+		})
+
+		DescribeTable("passes on complete credentials",
+			func(tenantID, clientID, clientSecret string) {
+				manager.TenantID = tenantID
+				manager.ClientID = clientID
+				manager.ClientSecret = clientSecret
+				Expect(manager.Validate()).To(BeNil())
+			},
+			Entry("service principal", "tenant", "client", "secret"),
+			Entry("user-assigned managed identity", "", "client", ""),
+		)
+
+		DescribeTable("fails on partial service principal credentials",
+			func(tenantID, clientID, clientSecret string) {
+				manager.TenantID = tenantID
+				manager.ClientID = clientID
+				manager.ClientSecret = clientSecret
+				Expect(manager.Validate()).ToNot(BeNil())
+			},
+			Entry("only tenant", "tenant", "", ""),
+			Entry("tenant & client", "tenant", "client", ""),
+			Entry("only secret", "", "", "secret"),
+			Entry("client & secret", "", "client", "secret"),
+			Entry("tenant & secret", "tenant", "", "secret"),
+		)
+
+		It("fails on empty pipe secret template", func() {
+			manager.PipelineSecretTemplate = ""
+			Expect(manager.Validate()).ToNot(BeNil())
+		})
+
+		It("fails on team secret template containing invalid parameters", func() {
+			manager.TeamSecretTemplate = "{{.Teams}}"
+			Expect(manager.Validate()).ToNot(BeNil())
+		})
+	})
+})
@@ -37,6 +37,20 @@ func (c CredentialManagementConfig) NewSecrets(secretsFactory SecretsFactory) Se
 	return result
 }
 
+// NewSecretsWithStats is like NewSecrets, but also returns the stats of the
+// lookups made through the returned Secrets.
+func (c CredentialManagementConfig) NewSecretsWithStats(secretsFactory SecretsFactory) (Secrets, *SecretsStats) {
+	stats := &SecretsStats{}
+
+	result := secretsFactory.NewSecrets()
+	result = NewRetryableSecrets(result, c.RetryConfig)
+	result = readCountingSecrets{result, stats}
+	if c.CacheConfig.Enabled {
+		result = NewCachedSecrets(result, c.CacheConfig)
+	}
+	return lookupCountingSecrets{result, stats}, stats
+}
+
 type HealthResponse struct {
 	Response interface{} `json:"response,omitempty"`
 	Error    string      `json:"error,omitempty"`
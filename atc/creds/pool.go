@@ -8,6 +8,7 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -15,6 +16,12 @@ import (
 //counterfeiter:generate . VarSourcePool
 type VarSourcePool interface {
 	FindOrCreate(lager.Logger, map[string]interface{}, ManagerFactory) (Secrets, error)
+
+	// Status checks the health of the credential manager with the config,
+	// creating it if needed, and reports the stats of its lookups. The name
+	// and type of the returned status are left for the caller to fill in.
+	Status(lager.Logger, map[string]interface{}, ManagerFactory) (atc.VarSourceStatus, error)
+
 	Size() int
 	Close()
 }
@@ -22,6 +29,7 @@ type VarSourcePool interface {
 type inPoolManager struct {
 	manager     Manager
 	secrets     Secrets
+	stats       *SecretsStats
 	lastUseTime time.Time
 	clock       clock.Clock
 }
@@ -30,9 +38,9 @@ func (m *inPoolManager) close(logger lager.Logger) {
 	m.manager.Close(logger)
 }
 
-func (m *inPoolManager) getSecrets() Secrets {
+func (m *inPoolManager) use() *inPoolManager {
 	m.lastUseTime = m.clock.Now()
-	return m.secrets
+	return m
 }
 
 type varSourcePool struct {
@@ -80,6 +88,54 @@ func (pool *varSourcePool) Size() int {
 }
 
 func (pool *varSourcePool) FindOrCreate(logger lager.Logger, config map[string]interface{}, factory ManagerFactory) (Secrets, error) {
+	m, err := pool.findOrCreate(logger, config, factory)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.secrets, nil
+}
+
+func (pool *varSourcePool) Status(logger lager.Logger, config map[string]interface{}, factory ManagerFactory) (atc.VarSourceStatus, error) {
+	m, err := pool.findOrCreate(logger, config, factory)
+	if err != nil {
+		return atc.VarSourceStatus{}, err
+	}
+
+	status := atc.VarSourceStatus{
+		Lookups:   m.stats.Lookups(),
+		CacheHits: m.stats.CacheHits(),
+	}
+
+	if status.Lookups > 0 {
+		status.CacheHitRate = float64(status.CacheHits) / float64(status.Lookups)
+	}
+
+	lastError, lastErrorTime := m.stats.LastError()
+	if lastError != "" {
+		status.LastError = lastError
+		status.LastErrorTime = lastErrorTime.Unix()
+	}
+
+	// the health check talks to the credential manager, so it is done
+	// without holding the pool's lock
+	start := time.Now()
+	health, err := m.manager.Health()
+	status.LatencyMS = time.Since(start).Milliseconds()
+
+	switch {
+	case err != nil:
+		status.Error = err.Error()
+	case health != nil && health.Error != "":
+		status.Error = health.Error
+	default:
+		status.Reachable = true
+	}
+
+	return status, nil
+}
+
+func (pool *varSourcePool) findOrCreate(logger lager.Logger, config map[string]interface{}, factory ManagerFactory) (*inPoolManager, error) {
 	b, err := json.Marshal(config)
 	if err != nil {
 		return nil, err
@@ -104,16 +160,19 @@ func (pool *varSourcePool) FindOrCreate(logger lager.Logger, config map[string]i
 			return nil, err
 		}
 
+		secrets, stats := pool.credentialManagement.NewSecretsWithStats(secretsFactory)
+
 		pool.pool[key] = &inPoolManager{
 			clock:   pool.clock,
 			manager: manager,
-			secrets: pool.credentialManagement.NewSecrets(secretsFactory),
+			secrets: secrets,
+			stats:   stats,
 		}
 	} else {
 		logger.Debug("found-existing-credential-manager")
 	}
 
-	return pool.pool[key].getSecrets(), nil
+	return pool.pool[key].use(), nil
 }
 
 func (pool *varSourcePool) Close() {
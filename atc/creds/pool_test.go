@@ -182,6 +182,47 @@ var _ = Context("pool", func() {
 		})
 	})
 
+	Describe("Status", func() {
+		BeforeEach(func() {
+			varSourcePool = creds.NewVarSourcePool(logger, credentialManagement, 5*time.Minute, time.Minute, fakeClock)
+		})
+
+		AfterEach(func() {
+			varSourcePool.Close()
+		})
+
+		It("creates the var source and reports it as reachable", func() {
+			status, err := varSourcePool.Status(logger, config1, factory)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Reachable).To(BeTrue())
+			Expect(status.Error).To(BeEmpty())
+			Expect(status.Lookups).To(BeZero())
+			Expect(varSourcePool.Size()).To(Equal(1))
+		})
+
+		It("reports the lookups made and how many the cache answered", func() {
+			secrets, err := varSourcePool.FindOrCreate(logger, config1, factory)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := 0; i < 4; i++ {
+				_, _, _, err := secrets.Get("k1")
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			status, err := varSourcePool.Status(logger, config1, factory)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Lookups).To(Equal(int64(4)))
+			Expect(status.CacheHits).To(Equal(int64(3)))
+			Expect(status.CacheHitRate).To(Equal(0.75))
+			Expect(status.LastError).To(BeEmpty())
+		})
+
+		It("fails when the var source cannot be created", func() {
+			_, err := varSourcePool.Status(logger, map[string]interface{}{"bogus": "config"}, factory)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("Close", func() {
 		var err error
 
@@ -0,0 +1,32 @@
+package creds
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+)
+
+type RegistryMirror struct {
+	variablesResolver vars.Variables
+	rawMirror         *atc.RegistryMirror
+}
+
+func NewRegistryMirror(variables vars.Variables, mirror *atc.RegistryMirror) RegistryMirror {
+	return RegistryMirror{
+		variablesResolver: variables,
+		rawMirror:         mirror,
+	}
+}
+
+func (m RegistryMirror) Evaluate() (*atc.RegistryMirror, error) {
+	if m.rawMirror == nil {
+		return nil, nil
+	}
+
+	var mirror atc.RegistryMirror
+	err := evaluate(m.variablesResolver, m.rawMirror, &mirror)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mirror, nil
+}
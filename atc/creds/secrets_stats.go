@@ -0,0 +1,76 @@
+package creds
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SecretsStats accumulates the outcome of the lookups made through a
+// Secrets, telling apart those which reached the credential manager from
+// those answered by the cache.
+type SecretsStats struct {
+	lookups int64
+	reads   int64
+
+	lastErrorL    sync.Mutex
+	lastError     string
+	lastErrorTime time.Time
+}
+
+func (stats *SecretsStats) Lookups() int64 {
+	return atomic.LoadInt64(&stats.lookups)
+}
+
+// CacheHits is the number of lookups which did not need to read from the
+// credential manager.
+func (stats *SecretsStats) CacheHits() int64 {
+	hits := stats.Lookups() - atomic.LoadInt64(&stats.reads)
+	if hits < 0 {
+		return 0
+	}
+
+	return hits
+}
+
+// LastError returns the error of the latest failed lookup and when it
+// happened, or an empty string if no lookup failed.
+func (stats *SecretsStats) LastError() (string, time.Time) {
+	stats.lastErrorL.Lock()
+	defer stats.lastErrorL.Unlock()
+
+	return stats.lastError, stats.lastErrorTime
+}
+
+func (stats *SecretsStats) recordError(err error) {
+	stats.lastErrorL.Lock()
+	stats.lastError = err.Error()
+	stats.lastErrorTime = time.Now()
+	stats.lastErrorL.Unlock()
+}
+
+type lookupCountingSecrets struct {
+	Secrets
+	stats *SecretsStats
+}
+
+func (s lookupCountingSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	atomic.AddInt64(&s.stats.lookups, 1)
+
+	value, expiration, found, err := s.Secrets.Get(secretPath)
+	if err != nil {
+		s.stats.recordError(err)
+	}
+
+	return value, expiration, found, err
+}
+
+type readCountingSecrets struct {
+	Secrets
+	stats *SecretsStats
+}
+
+func (s readCountingSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	atomic.AddInt64(&s.stats.reads, 1)
+	return s.Secrets.Get(secretPath)
+}
@@ -3,9 +3,11 @@ package secretsmanager
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
@@ -16,12 +18,13 @@ const DefaultPipelineSecretTemplate = "/concourse/{{.Team}}/{{.Pipeline}}/{{.Sec
 const DefaultTeamSecretTemplate = "/concourse/{{.Team}}/{{.Secret}}"
 
 type Manager struct {
-	AwsAccessKeyID         string `long:"access-key" description:"AWS Access key ID"`
-	AwsSecretAccessKey     string `long:"secret-key" description:"AWS Secret Access Key"`
-	AwsSessionToken        string `long:"session-token" description:"AWS Session Token"`
-	AwsRegion              string `long:"region" description:"AWS region to send requests to"`
-	PipelineSecretTemplate string `long:"pipeline-secret-template" description:"AWS Secrets Manager secret identifier template used for pipeline specific parameter" default:"/concourse/{{.Team}}/{{.Pipeline}}/{{.Secret}}"`
-	TeamSecretTemplate     string `long:"team-secret-template" description:"AWS Secrets Manager secret identifier  template used for team specific parameter" default:"/concourse/{{.Team}}/{{.Secret}}"`
+	AwsAccessKeyID         string            `long:"access-key" description:"AWS Access key ID"`
+	AwsSecretAccessKey     string            `long:"secret-key" description:"AWS Secret Access Key"`
+	AwsSessionToken        string            `long:"session-token" description:"AWS Session Token"`
+	AwsRegion              string            `long:"region" description:"AWS region to send requests to"`
+	PipelineSecretTemplate string            `long:"pipeline-secret-template" description:"AWS Secrets Manager secret identifier template used for pipeline specific parameter" default:"/concourse/{{.Team}}/{{.Pipeline}}/{{.Secret}}"`
+	TeamSecretTemplate     string            `long:"team-secret-template" description:"AWS Secrets Manager secret identifier  template used for team specific parameter" default:"/concourse/{{.Team}}/{{.Secret}}"`
+	TeamRoles              map[string]string `long:"team-role" value-name:"TEAM:ROLE_ARN" description:"ARN of an IAM role to assume when looking up a team's secrets. Can be specified multiple times."`
 	SecretManager          *SecretsManager
 }
 
@@ -49,7 +52,7 @@ func (manager *Manager) Health() (*creds.HealthResponse, error) {
 		Method: "GetSecretValue",
 	}
 
-	_, _, _, err := manager.SecretManager.getSecretById("__concourse-health-check")
+	_, _, _, err := getSecretById(manager.SecretManager.api, "__concourse-health-check")
 	if err != nil {
 		health.Error = err.Error()
 		return health, nil
@@ -72,6 +75,7 @@ func (manager *Manager) MarshalJSON() ([]byte, error) {
 		"aws_region":               manager.AwsRegion,
 		"pipeline_secret_template": manager.PipelineSecretTemplate,
 		"team_secret_template":     manager.TeamSecretTemplate,
+		"team_roles":               manager.TeamRoles,
 		"health":                   health,
 	})
 }
@@ -88,6 +92,12 @@ func (manager *Manager) Validate() error {
 		return err
 	}
 
+	for team, role := range manager.TeamRoles {
+		if _, err := arn.Parse(role); err != nil {
+			return fmt.Errorf("invalid role of team '%s': %s", team, err)
+		}
+	}
+
 	// All of the AWS credential variables may be empty since credentials may be obtained via environemnt variables
 	// or other means. However, if one of them is provided, then all of them (except session token) must be provided.
 	if manager.AwsAccessKeyID == "" && manager.AwsSecretAccessKey == "" && manager.AwsSessionToken == "" {
@@ -127,7 +137,7 @@ func (manager *Manager) NewSecretsFactory(log lager.Logger) (creds.SecretsFactor
 		return nil, err
 	}
 
-	return NewSecretsManagerFactory(log, sess, []*creds.SecretTemplate{pipelineSecretTemplate, teamSecretTemplate}), nil
+	return NewSecretsManagerFactory(log, sess, []*creds.SecretTemplate{pipelineSecretTemplate, teamSecretTemplate}, manager.TeamRoles), nil
 }
 
 func (manager Manager) Close(logger lager.Logger) {
@@ -1,6 +1,8 @@
 package secretsmanager
 
 import (
+	"errors"
+
 	"github.com/concourse/concourse/atc/creds"
 	flags "github.com/jessevdk/go-flags"
 )
@@ -28,6 +30,14 @@ func (factory *managerFactory) AddConfig(group *flags.Group) creds.Manager {
 	return manager
 }
 
-func (factory *managerFactory) NewInstance(interface{}) (creds.Manager, error) {
+// NewInstance creates a manager for a var_source. Team roles grant access to
+// other teams' secrets, so they can only be configured for the cluster.
+func (factory *managerFactory) NewInstance(config interface{}) (creds.Manager, error) {
+	if params, ok := config.(map[string]interface{}); ok {
+		if _, found := params["team_roles"]; found {
+			return nil, errors.New("team_roles cannot be configured in a var_source, only with --aws-secretsmanager-team-role")
+		}
+	}
+
 	return &Manager{}, nil
 }
@@ -28,6 +28,15 @@ var _ = Describe("Manager", func() {
 		})
 	})
 
+	Describe("NewInstance()", func() {
+		It("rejects team roles", func() {
+			_, err := secretsmanager.NewManagerFactory().NewInstance(map[string]interface{}{
+				"team_roles": map[string]interface{}{"other-team": "arn:aws:iam::123456789012:role/concourse-other-team"},
+			})
+			Expect(err).To(MatchError(ContainSubstring("team_roles cannot be configured in a var_source")))
+		})
+	})
+
 	Describe("Validate()", func() {
 		JustBeforeEach(func() {
 			manager = secretsmanager.Manager{AwsRegion: "test-region"}
@@ -105,5 +114,15 @@ var _ = Describe("Manager", func() {
 			manager.TeamSecretTemplate = "{{.Teams}}"
 			Expect(manager.Validate()).ToNot(BeNil())
 		})
+
+		It("passes on team roles", func() {
+			manager.TeamRoles = map[string]string{"main": "arn:aws:iam::123456789012:role/concourse-main"}
+			Expect(manager.Validate()).To(BeNil())
+		})
+
+		It("fails on a team role which is not an ARN", func() {
+			manager.TeamRoles = map[string]string{"main": "concourse-main"}
+			Expect(manager.Validate()).ToNot(BeNil())
+		})
 	})
 })
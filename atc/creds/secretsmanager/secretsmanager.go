@@ -2,6 +2,8 @@ package secretsmanager
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/concourse/concourse/atc/creds"
@@ -12,16 +14,24 @@ import (
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 )
 
+// teamSeparator separates the team from the secret id in the secret paths
+// of a SecretsManager with team APIs.
+const teamSeparator = ":"
+
 type SecretsManager struct {
 	log             lager.Logger
 	api             secretsmanageriface.SecretsManagerAPI
+	teamAPIs        map[string]secretsmanageriface.SecretsManagerAPI
 	secretTemplates []*creds.SecretTemplate
 }
 
-func NewSecretsManager(log lager.Logger, api secretsmanageriface.SecretsManagerAPI, secretTemplates []*creds.SecretTemplate) *SecretsManager {
+// NewSecretsManager looks up secrets using api, or for the teams in
+// teamAPIs, using the team's own API, e.g. one assuming the team's role.
+func NewSecretsManager(log lager.Logger, api secretsmanageriface.SecretsManagerAPI, teamAPIs map[string]secretsmanageriface.SecretsManagerAPI, secretTemplates []*creds.SecretTemplate) *SecretsManager {
 	return &SecretsManager{
 		log:             log,
 		api:             api,
+		teamAPIs:        teamAPIs,
 		secretTemplates: secretTemplates,
 	}
 }
@@ -31,15 +41,60 @@ func (s *SecretsManager) NewSecretLookupPaths(teamName string, pipelineName stri
 	lookupPaths := []creds.SecretLookupPath{}
 	for _, tmpl := range s.secretTemplates {
 		if lPath := creds.NewSecretLookupWithTemplate(tmpl, teamName, pipelineName); lPath != nil {
+			if len(s.teamAPIs) > 0 {
+				lPath = teamLookupPath{lPath, teamName}
+			}
 			lookupPaths = append(lookupPaths, lPath)
 		}
 	}
 	return lookupPaths
 }
 
+// teamLookupPath prefixes secret ids with the team they are looked up for.
+// Every path is prefixed, so that a secret id can never be mistaken for
+// the path of another team.
+type teamLookupPath struct {
+	lookupPath creds.SecretLookupPath
+	teamName   string
+}
+
+func (tl teamLookupPath) VariableToSecretPath(varName string) (string, error) {
+	if strings.Contains(tl.teamName, teamSeparator) {
+		return "", fmt.Errorf("team name '%s' must not contain '%s'", tl.teamName, teamSeparator)
+	}
+
+	secretID, err := tl.lookupPath.VariableToSecretPath(varName)
+	if err != nil {
+		return "", err
+	}
+
+	return tl.teamName + teamSeparator + secretID, nil
+}
+
+// apiFor returns the API with which to look up the secret path, along
+// with the secret id.
+func (s *SecretsManager) apiFor(secretPath string) (secretsmanageriface.SecretsManagerAPI, string) {
+	if len(s.teamAPIs) == 0 {
+		return s.api, secretPath
+	}
+
+	parts := strings.SplitN(secretPath, teamSeparator, 2)
+	if len(parts) != 2 {
+		return s.api, secretPath
+	}
+
+	if api, found := s.teamAPIs[parts[0]]; found {
+		return api, parts[1]
+	}
+
+	return s.api, parts[1]
+}
+
 // Get retrieves the value and expiration of an individual secret
 func (s *SecretsManager) Get(secretPath string) (interface{}, *time.Time, bool, error) {
-	value, expiration, found, err := s.getSecretById(secretPath)
+	api, secretID := s.apiFor(secretPath)
+
+	value, expiration, found, err := getSecretById(api, secretID)
 	if err != nil {
 		s.log.Error("failed-to-fetch-aws-secret", err, lager.Data{
 			"secret-path": secretPath,
@@ -58,8 +113,8 @@ func (s *SecretsManager) Get(secretPath string) (interface{}, *time.Time, bool,
 
 	In case SecretBinary is set, it is expected to be a valid JSON object or it will error.
 */
-func (s *SecretsManager) getSecretById(path string) (interface{}, *time.Time, bool, error) {
-	value, err := s.api.GetSecretValue(&secretsmanager.GetSecretValueInput{
+func getSecretById(api secretsmanageriface.SecretsManagerAPI, path string) (interface{}, *time.Time, bool, error) {
+	value, err := api.GetSecretValue(&secretsmanager.GetSecretValueInput{
 		SecretId: &path,
 	})
 	if err == nil {
@@ -2,25 +2,39 @@ package secretsmanager
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/concourse/concourse/atc/creds"
 )
 
 type secretsManagerFactory struct {
 	log             lager.Logger
 	api             *secretsmanager.SecretsManager
+	teamAPIs        map[string]secretsmanageriface.SecretsManagerAPI
 	secretTemplates []*creds.SecretTemplate
 }
 
-func NewSecretsManagerFactory(log lager.Logger, session *session.Session, secretTemplates []*creds.SecretTemplate) *secretsManagerFactory {
+// NewSecretsManagerFactory looks up each team's secrets with the session's
+// credentials or, if the team is in teamRoles, by assuming the team's role.
+func NewSecretsManagerFactory(log lager.Logger, session *session.Session, secretTemplates []*creds.SecretTemplate, teamRoles map[string]string) *secretsManagerFactory {
+	teamAPIs := map[string]secretsmanageriface.SecretsManagerAPI{}
+	for team, role := range teamRoles {
+		teamAPIs[team] = secretsmanager.New(session, &aws.Config{
+			Credentials: stscreds.NewCredentials(session, role),
+		})
+	}
+
 	return &secretsManagerFactory{
 		log:             log,
 		api:             secretsmanager.New(session),
+		teamAPIs:        teamAPIs,
 		secretTemplates: secretTemplates,
 	}
 }
 
 func (factory *secretsManagerFactory) NewSecrets() creds.Secrets {
-	return NewSecretsManager(factory.log, factory.api, factory.secretTemplates)
+	return NewSecretsManager(factory.log, factory.api, factory.teamAPIs, factory.secretTemplates)
 }
@@ -51,7 +51,7 @@ var _ = Describe("SecretsManager", func() {
 		t2, err := creds.BuildSecretTemplate("t2", DefaultTeamSecretTemplate)
 		Expect(t2).NotTo(BeNil())
 		Expect(err).To(BeNil())
-		secretAccess = NewSecretsManager(lagertest.NewTestLogger("secretsmanager_test"), &mockService, []*creds.SecretTemplate{t1, t2})
+		secretAccess = NewSecretsManager(lagertest.NewTestLogger("secretsmanager_test"), &mockService, nil, []*creds.SecretTemplate{t1, t2})
 		variables = creds.NewVariables(secretAccess, "alpha", "bogus", false)
 		Expect(secretAccess).NotTo(BeNil())
 		mockService.stubGetParameter = func(input string) (*secretsmanager.GetSecretValueOutput, error) {
@@ -118,4 +118,51 @@ var _ = Describe("SecretsManager", func() {
 			Expect(err).To(BeNil())
 		})
 	})
+
+	Context("with a team API", func() {
+		var teamService MockSecretsManagerService
+
+		JustBeforeEach(func() {
+			t, err := creds.BuildSecretTemplate("t", "{{.Secret}}")
+			Expect(err).To(BeNil())
+
+			secretAccess = NewSecretsManager(
+				lagertest.NewTestLogger("secretsmanager_test"),
+				&mockService,
+				map[string]secretsmanageriface.SecretsManagerAPI{"alpha": &teamService},
+				[]*creds.SecretTemplate{t},
+			)
+
+			mockService.stubGetParameter = func(input string) (*secretsmanager.GetSecretValueOutput, error) {
+				return &secretsmanager.GetSecretValueOutput{SecretString: aws.String("default " + input)}, nil
+			}
+			teamService.stubGetParameter = func(input string) (*secretsmanager.GetSecretValueOutput, error) {
+				return &secretsmanager.GetSecretValueOutput{SecretString: aws.String("alpha " + input)}, nil
+			}
+		})
+
+		It("should get the team's secrets with the team API", func() {
+			variables := creds.NewVariables(secretAccess, "alpha", "bogus", false)
+			value, found, err := variables.Get(varRef)
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(value).To(BeEquivalentTo("alpha cheery"))
+		})
+
+		It("should get other teams' secrets with the default API", func() {
+			variables := creds.NewVariables(secretAccess, "beta", "bogus", false)
+			value, found, err := variables.Get(varRef)
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(value).To(BeEquivalentTo("default cheery"))
+		})
+
+		It("should not let other teams use the team API through the secret name", func() {
+			variables := creds.NewVariables(secretAccess, "beta", "bogus", false)
+			value, found, err := variables.Get(vars.Reference{Path: "alpha:cheery"})
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(value).To(BeEquivalentTo("default alpha:cheery"))
+		})
+	})
 })
@@ -3,10 +3,12 @@ package ssm
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -18,12 +20,13 @@ const DefaultPipelineSecretTemplate = "/concourse/{{.Team}}/{{.Pipeline}}/{{.Sec
 const DefaultTeamSecretTemplate = "/concourse/{{.Team}}/{{.Secret}}"
 
 type SsmManager struct {
-	AwsAccessKeyID         string `mapstructure:"access_key" long:"access-key" description:"AWS Access key ID"`
-	AwsSecretAccessKey     string `mapstructure:"secret_key" long:"secret-key" description:"AWS Secret Access Key"`
-	AwsSessionToken        string `mapstructure:"session_token" long:"session-token" description:"AWS Session Token"`
-	AwsRegion              string `mapstructure:"region" long:"region" description:"AWS region to send requests to"`
-	PipelineSecretTemplate string `mapstructure:"pipeline_secret_template" long:"pipeline-secret-template" description:"AWS SSM parameter name template used for pipeline specific parameter" default:"/concourse/{{.Team}}/{{.Pipeline}}/{{.Secret}}"`
-	TeamSecretTemplate     string `mapstructure:"team_secret_template" long:"team-secret-template" description:"AWS SSM parameter name template used for team specific parameter" default:"/concourse/{{.Team}}/{{.Secret}}"`
+	AwsAccessKeyID         string            `mapstructure:"access_key" long:"access-key" description:"AWS Access key ID"`
+	AwsSecretAccessKey     string            `mapstructure:"secret_key" long:"secret-key" description:"AWS Secret Access Key"`
+	AwsSessionToken        string            `mapstructure:"session_token" long:"session-token" description:"AWS Session Token"`
+	AwsRegion              string            `mapstructure:"region" long:"region" description:"AWS region to send requests to"`
+	PipelineSecretTemplate string            `mapstructure:"pipeline_secret_template" long:"pipeline-secret-template" description:"AWS SSM parameter name template used for pipeline specific parameter" default:"/concourse/{{.Team}}/{{.Pipeline}}/{{.Secret}}"`
+	TeamSecretTemplate     string            `mapstructure:"team_secret_template" long:"team-secret-template" description:"AWS SSM parameter name template used for team specific parameter" default:"/concourse/{{.Team}}/{{.Secret}}"`
+	TeamRoles              map[string]string `mapstructure:"-" long:"team-role" value-name:"TEAM:ROLE_ARN" description:"ARN of an IAM role to assume when looking up a team's parameters. Can be specified multiple times."`
 	Ssm                    *Ssm
 }
 
@@ -37,6 +40,7 @@ func (manager *SsmManager) MarshalJSON() ([]byte, error) {
 		"aws_region":               manager.AwsRegion,
 		"pipeline_secret_template": manager.PipelineSecretTemplate,
 		"team_secret_template":     manager.TeamSecretTemplate,
+		"team_roles":               manager.TeamRoles,
 		"health":                   health,
 	})
 }
@@ -70,7 +74,7 @@ func (manager *SsmManager) Health() (*creds.HealthResponse, error) {
 		Method: "GetParameter",
 	}
 
-	_, _, _, err := manager.Ssm.getParameterByName("__concourse-health-check")
+	_, _, _, err := manager.Ssm.getParameterByName(manager.Ssm.api, "__concourse-health-check")
 	if err != nil {
 		if errObj, ok := err.(awserr.Error); ok && strings.Contains(errObj.Code(), "AccessDenied") {
 			health.Response = map[string]string{
@@ -104,6 +108,12 @@ func (manager *SsmManager) Validate() error {
 		return err
 	}
 
+	for team, role := range manager.TeamRoles {
+		if _, err := arn.Parse(role); err != nil {
+			return fmt.Errorf("invalid role of team '%s': %s", team, err)
+		}
+	}
+
 	// All of the AWS credential variables may be empty since credentials may be obtained via environemnt variables
 	// or other means. However, if one of them is provided, then all of them (except session token) must be provided.
 	if manager.AwsAccessKeyID == "" && manager.AwsSecretAccessKey == "" && manager.AwsSessionToken == "" {
@@ -139,7 +149,7 @@ func (manager *SsmManager) NewSecretsFactory(log lager.Logger) (creds.SecretsFac
 		return nil, err
 	}
 
-	return NewSsmFactory(log, session, []*creds.SecretTemplate{pipelineSecretTemplate, teamSecretTemplate}), nil
+	return NewSsmFactory(log, session, []*creds.SecretTemplate{pipelineSecretTemplate, teamSecretTemplate}, manager.TeamRoles), nil
 }
 
 func (manager *SsmManager) Close(logger lager.Logger) {
@@ -1,6 +1,8 @@
 package ssm
 
 import (
+	"errors"
+
 	"github.com/concourse/concourse/atc/creds"
 	flags "github.com/jessevdk/go-flags"
 	"github.com/mitchellh/mapstructure"
@@ -27,7 +29,15 @@ func (factory *ssmManagerFactory) AddConfig(group *flags.Group) creds.Manager {
 	return manager
 }
 
+// NewInstance creates a manager for a var_source. Team roles grant access to
+// other teams' parameters, so they can only be configured for the cluster.
 func (factory *ssmManagerFactory) NewInstance(config interface{}) (creds.Manager, error) {
+	if params, ok := config.(map[string]interface{}); ok {
+		if _, found := params["team_roles"]; found {
+			return nil, errors.New("team_roles cannot be configured in a var_source, only with --aws-ssm-team-role")
+		}
+	}
+
 	manager := &SsmManager{
 		TeamSecretTemplate:     DefaultTeamSecretTemplate,
 		PipelineSecretTemplate: DefaultPipelineSecretTemplate,
@@ -31,6 +31,24 @@ var _ = Describe("SsmManager", func() {
 	Describe("Health()", func() {
 	})
 
+	Describe("NewInstance()", func() {
+		It("creates a manager from a var_source config", func() {
+			instance, err := ssm.NewSsmManagerFactory().NewInstance(map[string]interface{}{
+				"region": "test-region",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.(*ssm.SsmManager).AwsRegion).To(Equal("test-region"))
+		})
+
+		It("rejects team roles", func() {
+			_, err := ssm.NewSsmManagerFactory().NewInstance(map[string]interface{}{
+				"region":     "test-region",
+				"team_roles": map[string]interface{}{"other-team": "arn:aws:iam::123456789012:role/concourse-other-team"},
+			})
+			Expect(err).To(MatchError(ContainSubstring("team_roles cannot be configured in a var_source")))
+		})
+	})
+
 	Describe("Validate()", func() {
 		JustBeforeEach(func() {
 			manager = ssm.SsmManager{AwsRegion: "test-region"}
@@ -108,5 +126,15 @@ var _ = Describe("SsmManager", func() {
 			manager.TeamSecretTemplate = "{{.Teams}}"
 			Expect(manager.Validate()).ToNot(BeNil())
 		})
+
+		It("passes on team roles", func() {
+			manager.TeamRoles = map[string]string{"main": "arn:aws:iam::123456789012:role/concourse-main"}
+			Expect(manager.Validate()).To(BeNil())
+		})
+
+		It("fails on a team role which is not an ARN", func() {
+			manager.TeamRoles = map[string]string{"main": "concourse-main"}
+			Expect(manager.Validate()).ToNot(BeNil())
+		})
 	})
 })
@@ -1,6 +1,7 @@
 package ssm
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -13,16 +14,24 @@ import (
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 )
 
+// teamSeparator separates the team from the parameter name in the secret
+// paths of an Ssm with team APIs. It is not allowed in parameter names.
+const teamSeparator = ":"
+
 type Ssm struct {
 	log             lager.Logger
 	api             ssmiface.SSMAPI
+	teamAPIs        map[string]ssmiface.SSMAPI
 	secretTemplates []*creds.SecretTemplate
 }
 
-func NewSsm(log lager.Logger, api ssmiface.SSMAPI, secretTemplates []*creds.SecretTemplate) *Ssm {
+// NewSsm looks up parameters using api or, for the teams in teamAPIs, the
+// team's own API.
+func NewSsm(log lager.Logger, api ssmiface.SSMAPI, teamAPIs map[string]ssmiface.SSMAPI, secretTemplates []*creds.SecretTemplate) *Ssm {
 	return &Ssm{
 		log:             log,
 		api:             api,
+		teamAPIs:        teamAPIs,
 		secretTemplates: secretTemplates,
 	}
 }
@@ -32,16 +41,61 @@ func (s *Ssm) NewSecretLookupPaths(teamName string, pipelineName string, allowRo
 	lookupPaths := []creds.SecretLookupPath{}
 	for _, tmpl := range s.secretTemplates {
 		if lPath := creds.NewSecretLookupWithTemplate(tmpl, teamName, pipelineName); lPath != nil {
+			if len(s.teamAPIs) > 0 {
+				lPath = teamLookupPath{lPath, teamName}
+			}
 			lookupPaths = append(lookupPaths, lPath)
 		}
 	}
 	return lookupPaths
 }
 
+// teamLookupPath prefixes parameter names with the team they are looked up
+// for. All teams' paths are prefixed when there are team APIs, so a team
+// can't pick another team's API by naming a parameter after it.
+type teamLookupPath struct {
+	lookupPath creds.SecretLookupPath
+	teamName   string
+}
+
+func (tl teamLookupPath) VariableToSecretPath(varName string) (string, error) {
+	if strings.Contains(tl.teamName, teamSeparator) {
+		return "", fmt.Errorf("team name '%s' must not contain '%s'", tl.teamName, teamSeparator)
+	}
+
+	name, err := tl.lookupPath.VariableToSecretPath(varName)
+	if err != nil {
+		return "", err
+	}
+
+	return tl.teamName + teamSeparator + name, nil
+}
+
+// apiFor returns the API with which to look up the secret path, along
+// with the parameter name.
+func (s *Ssm) apiFor(secretPath string) (ssmiface.SSMAPI, string) {
+	if len(s.teamAPIs) == 0 {
+		return s.api, secretPath
+	}
+
+	parts := strings.SplitN(secretPath, teamSeparator, 2)
+	if len(parts) != 2 {
+		return s.api, secretPath
+	}
+
+	if api, found := s.teamAPIs[parts[0]]; found {
+		return api, parts[1]
+	}
+
+	return s.api, parts[1]
+}
+
 // Get retrieves the value and expiration of an individual secret
 func (s *Ssm) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	api, name := s.apiFor(secretPath)
+
 	// Try to get the parameter as string value, by name
-	value, expiration, found, err := s.getParameterByName(secretPath)
+	value, expiration, found, err := s.getParameterByName(api, name)
 	if err != nil {
 		s.log.Error("unable to retrieve aws ssm secret by name", err, lager.Data{
 			"secretPath": secretPath,
@@ -52,7 +106,7 @@ func (s *Ssm) Get(secretPath string) (interface{}, *time.Time, bool, error) {
 		return value, expiration, true, nil
 	}
 	// Parameter may exist as a complex value so try again using parameter name as root path
-	value, expiration, found, err = s.getParameterByPath(secretPath)
+	value, expiration, found, err = s.getParameterByPath(api, name)
 	if err != nil {
 		s.log.Error("unable to retrieve aws ssm secret by path", err, lager.Data{
 			"secretPath": secretPath,
@@ -65,8 +119,8 @@ func (s *Ssm) Get(secretPath string) (interface{}, *time.Time, bool, error) {
 	return nil, nil, false, nil
 }
 
-func (s *Ssm) getParameterByName(name string) (interface{}, *time.Time, bool, error) {
-	param, err := s.api.GetParameter(&ssm.GetParameterInput{
+func (s *Ssm) getParameterByName(api ssmiface.SSMAPI, name string) (interface{}, *time.Time, bool, error) {
+	param, err := api.GetParameter(&ssm.GetParameterInput{
 		Name:           &name,
 		WithDecryption: aws.Bool(true),
 	})
@@ -79,7 +133,7 @@ func (s *Ssm) getParameterByName(name string) (interface{}, *time.Time, bool, er
 	return nil, nil, false, err
 }
 
-func (s *Ssm) getParameterByPath(path string) (interface{}, *time.Time, bool, error) {
+func (s *Ssm) getParameterByPath(api ssmiface.SSMAPI, path string) (interface{}, *time.Time, bool, error) {
 	path = strings.TrimRight(path, "/")
 	if path == "" {
 		path = "/"
@@ -87,7 +141,7 @@ func (s *Ssm) getParameterByPath(path string) (interface{}, *time.Time, bool, er
 	value := make(map[string]interface{})
 	pathQuery := &ssm.GetParametersByPathInput{}
 	pathQuery = pathQuery.SetPath(path).SetRecursive(true).SetWithDecryption(true).SetMaxResults(10)
-	err := s.api.GetParametersByPathPages(pathQuery, func(page *ssm.GetParametersByPathOutput, lastPage bool) bool {
+	err := api.GetParametersByPathPages(pathQuery, func(page *ssm.GetParametersByPathOutput, lastPage bool) bool {
 		for _, param := range page.Parameters {
 			value[(*param.Name)[len(path)+1:]] = *param.Value
 		}
@@ -2,25 +2,40 @@ package ssm
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/concourse/concourse/atc/creds"
 )
 
 type ssmFactory struct {
 	log             lager.Logger
 	api             *ssm.SSM
+	teamAPIs        map[string]ssmiface.SSMAPI
 	secretTemplates []*creds.SecretTemplate
 }
 
-func NewSsmFactory(log lager.Logger, session *session.Session, secretTemplates []*creds.SecretTemplate) *ssmFactory {
+// NewSsmFactory reads the parameters of the teams in teamRoles with
+// credentials obtained by assuming the team's role, and those of other
+// teams with the session's own credentials.
+func NewSsmFactory(log lager.Logger, session *session.Session, secretTemplates []*creds.SecretTemplate, teamRoles map[string]string) *ssmFactory {
+	teamAPIs := map[string]ssmiface.SSMAPI{}
+	for team, role := range teamRoles {
+		teamAPIs[team] = ssm.New(session, &aws.Config{
+			Credentials: stscreds.NewCredentials(session, role),
+		})
+	}
+
 	return &ssmFactory{
 		log:             log,
 		api:             ssm.New(session),
+		teamAPIs:        teamAPIs,
 		secretTemplates: secretTemplates,
 	}
 }
 
 func (factory *ssmFactory) NewSecrets() creds.Secrets {
-	return NewSsm(factory.log, factory.api, factory.secretTemplates)
+	return NewSsm(factory.log, factory.api, factory.teamAPIs, factory.secretTemplates)
 }
@@ -98,7 +98,7 @@ var _ = Describe("Ssm", func() {
 		t2, err := creds.BuildSecretTemplate("t2", DefaultTeamSecretTemplate)
 		Expect(t2).NotTo(BeNil())
 		Expect(err).To(BeNil())
-		ssmAccess = NewSsm(lagertest.NewTestLogger("ssm_test"), &mockService, []*creds.SecretTemplate{t1, t2})
+		ssmAccess = NewSsm(lagertest.NewTestLogger("ssm_test"), &mockService, nil, []*creds.SecretTemplate{t1, t2})
 		variables = creds.NewVariables(ssmAccess, "alpha", "bogus", false)
 		Expect(ssmAccess).NotTo(BeNil())
 		mockService.stubGetParameter = func(input string) (string, error) {
@@ -184,4 +184,43 @@ var _ = Describe("Ssm", func() {
 			Expect(err).To(BeNil())
 		})
 	})
+
+	Context("with a team API", func() {
+		var teamService MockSsmService
+
+		JustBeforeEach(func() {
+			t, err := creds.BuildSecretTemplate("t", DefaultTeamSecretTemplate)
+			Expect(err).To(BeNil())
+
+			ssmAccess = NewSsm(
+				lagertest.NewTestLogger("ssm_test"),
+				&mockService,
+				map[string]ssmiface.SSMAPI{"alpha": &teamService},
+				[]*creds.SecretTemplate{t},
+			)
+
+			mockService.stubGetParameter = func(input string) (string, error) {
+				return "default " + input, nil
+			}
+			teamService.stubGetParameter = func(input string) (string, error) {
+				return "alpha " + input, nil
+			}
+		})
+
+		It("should get the team's parameters with the team API", func() {
+			variables := creds.NewVariables(ssmAccess, "alpha", "bogus", false)
+			value, found, err := variables.Get(varRef)
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(value).To(BeEquivalentTo("alpha /concourse/alpha/cheery"))
+		})
+
+		It("should get other teams' parameters with the default API", func() {
+			variables := creds.NewVariables(ssmAccess, "beta", "bogus", false)
+			value, found, err := variables.Get(varRef)
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(value).To(BeEquivalentTo("default /concourse/beta/cheery"))
+		})
+	})
 })
@@ -60,9 +60,29 @@ func NewAPIClient(logger lager.Logger, apiURL string, tlsConfig TLSConfig, authC
 // Read must be called after a successful login has occurred or an
 // un-authorized client will be used.
 func (ac *APIClient) Read(path string) (*vaultapi.Secret, error) {
+	return ac.read(ac.client(), path)
+}
+
+// ReadInNamespace reads the path from a namespace nested under the
+// client's own namespace. Like Read, it must be called after a successful
+// login has occurred.
+func (ac *APIClient) ReadInNamespace(namespace string, path string) (*vaultapi.Secret, error) {
+	if namespace == "" {
+		return ac.Read(path)
+	}
+
+	client, err := ac.namespacedClient(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return ac.read(client, path)
+}
+
+func (ac *APIClient) read(client *vaultapi.Client, path string) (*vaultapi.Secret, error) {
 	// Check if path is kv1 or kv2
 	path = sanitizePath(path)
-	mountPath, kv2, err := isKVv2(path, ac.client())
+	mountPath, kv2, err := isKVv2(path, client)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +92,7 @@ func (ac *APIClient) Read(path string) (*vaultapi.Secret, error) {
 		path = addPrefixToVKVPath(path, mountPath, "data")
 	}
 
-	secret, err := ac.client().Logical().Read(path)
+	secret, err := client.Logical().Read(path)
 	if err != nil || secret == nil {
 		return secret, err
 	}
@@ -90,13 +110,31 @@ func (ac *APIClient) Read(path string) (*vaultapi.Secret, error) {
 	return secret, err
 }
 
-func (ac *APIClient) loginParams() map[string]interface{} {
+func (ac *APIClient) loginParams() (map[string]interface{}, error) {
 	loginParams := make(map[string]interface{})
 	for k, v := range ac.authConfig.Params {
 		loginParams[k] = v
 	}
 
-	return loginParams
+	if ac.authConfig.Backend == "kubernetes" || ac.authConfig.Backend == "jwt" {
+		jwtPath := ac.authConfig.JWTPath
+		if jwtPath == "" && ac.authConfig.Backend == "kubernetes" {
+			jwtPath = DefaultKubernetesJWTPath
+		}
+
+		// The token is read on every login as it is typically a projected
+		// service account token which gets rotated.
+		if jwtPath != "" {
+			jwt, err := ioutil.ReadFile(jwtPath)
+			if err != nil {
+				return nil, err
+			}
+
+			loginParams["jwt"] = strings.TrimSpace(string(jwt))
+		}
+	}
+
+	return loginParams, nil
 }
 
 // Login the APIClient using the credentials passed at
@@ -121,19 +159,31 @@ func (ac *APIClient) Login() (time.Duration, error) {
 	}
 
 	client := ac.client()
-	loginPath := path.Join("auth", ac.authConfig.Backend, "login")
+
+	mountPath := ac.authConfig.MountPath
+	if mountPath == "" {
+		mountPath = ac.authConfig.Backend
+	}
+
+	loginPath := path.Join("auth", mountPath, "login")
+
+	loginParams, err := ac.loginParams()
+	if err != nil {
+		logger.Error("failed-to-build-params", err)
+		return time.Second, err
+	}
 
 	if ac.authConfig.Backend == "ldap" || ac.authConfig.Backend == "okta" {
-		username, ok := ac.loginParams()["username"].(string)
+		username, ok := loginParams["username"].(string)
 		if !ok {
 			err := fmt.Errorf("failed to assert username as string")
 			logger.Error("failed", err)
 			return time.Second, err
 		}
-		loginPath = path.Join("auth", ac.authConfig.Backend, "login", username)
+		loginPath = path.Join("auth", mountPath, "login", username)
 	}
 
-	secret, err := client.Logical().Write(loginPath, ac.loginParams())
+	secret, err := client.Logical().Write(loginPath, loginParams)
 	if err != nil {
 		logger.Error("failed", err)
 		return time.Second, err
@@ -142,9 +192,12 @@ func (ac *APIClient) Login() (time.Duration, error) {
 	logger.Info("succeeded", lager.Data{
 		"token-accessor": secret.Auth.Accessor,
 		"lease-duration": secret.Auth.LeaseDuration,
+		"renewable":      secret.Auth.Renewable,
 		"policies":       secret.Auth.Policies,
 	})
 
+	ac.renewable = secret.Auth.Renewable
+
 	newClient, err := ac.clientWithToken(secret.Auth.ClientToken)
 	if err != nil {
 		logger.Error("failed-to-create-client", err)
@@ -158,10 +211,11 @@ func (ac *APIClient) Login() (time.Duration, error) {
 
 // Renew the APIClient login using the credentials passed at
 // construction. Must be called after a successful login. Returns a
-// duration after which renew must be called again.
+// duration after which renew must be called again. A zero duration
+// means the token cannot be renewed and a new login is required.
 func (ac *APIClient) Renew() (time.Duration, error) {
 	if !ac.renewable {
-		return time.Second, nil
+		return ac.notRenewable(), nil
 	}
 
 	logger := ac.logger.Session("renew")
@@ -173,7 +227,7 @@ func (ac *APIClient) Renew() (time.Duration, error) {
 		// When tests with a Vault dev server, renew is not allowed.
 		if strings.Index(err.Error(), "lease is not renewable") > 0 {
 			ac.renewable = false
-			return time.Second, nil
+			return ac.notRenewable(), nil
 		}
 		logger.Error("failed", err)
 		return time.Second, err
@@ -196,6 +250,18 @@ func (ac *APIClient) Renew() (time.Duration, error) {
 	return time.Duration(secret.Auth.LeaseDuration) * time.Second, nil
 }
 
+// notRenewable returns the duration after which to retry once the token
+// turned out not to be renewable. A configured client token is all we
+// have, so it is kept; a token obtained by logging in, e.g. a batch
+// token, is replaced by logging in again right away.
+func (ac *APIClient) notRenewable() time.Duration {
+	if ac.authConfig.ClientToken != "" {
+		return time.Second
+	}
+
+	return 0
+}
+
 func (ac *APIClient) client() *vaultapi.Client {
 	return ac.clientValue.Load().(*vaultapi.Client)
 }
@@ -232,6 +298,29 @@ func (ac *APIClient) baseClient() (*vaultapi.Client, error) {
 	return client, nil
 }
 
+// namespacedClient returns a copy of the current client, sharing its
+// token and HTTP client, which sends requests to the given namespace
+// relative to the client's own.
+func (ac *APIClient) namespacedClient(namespace string) (*vaultapi.Client, error) {
+	current := ac.client()
+
+	client, err := current.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	// The address is not part of the cloned configuration.
+	err = client.SetAddress(ac.apiURL)
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetToken(current.Token())
+	client.SetNamespace(path.Join(ac.namespace, namespace))
+
+	return client, nil
+}
+
 func (ac *APIClient) configureTLS(config *tls.Config) error {
 	if ac.tlsConfig.CACert != "" || ac.tlsConfig.CACertFile != "" || ac.tlsConfig.CAPath != "" {
 		rootConfig := &rootcerts.Config{
@@ -0,0 +1,220 @@
+package vault_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds/vault"
+	vaultapi "github.com/hashicorp/vault/api"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+func verifyLoginParams(expected map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&params)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(params).To(Equal(expected))
+	}
+}
+
+var _ = Describe("APIClient", func() {
+	var server *ghttp.Server
+	var authConfig vault.AuthConfig
+	var namespace string
+	var tmpDir string
+
+	var apiClient *vault.APIClient
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "vault-api-client")
+		Expect(err).ToNot(HaveOccurred())
+
+		jwtPath := filepath.Join(tmpDir, "token")
+		err = ioutil.WriteFile(jwtPath, []byte("some-jwt\n"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+
+		authConfig = vault.AuthConfig{
+			Backend: "kubernetes",
+			JWTPath: jwtPath,
+			Params:  map[string]string{"role": "concourse"},
+		}
+
+		namespace = ""
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		apiClient, err = vault.NewAPIClient(lagertest.NewTestLogger("test"), server.URL(), vault.TLSConfig{}, authConfig, namespace, 0)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	loginResponse := func(renewable bool) *vaultapi.Secret {
+		return &vaultapi.Secret{
+			Auth: &vaultapi.SecretAuth{
+				ClientToken:   "some-token",
+				LeaseDuration: 60,
+				Renewable:     renewable,
+			},
+		}
+	}
+
+	Describe("Login", func() {
+		Context("with the kubernetes backend", func() {
+			It("logs in with the role and the JWT read from the file", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/v1/auth/kubernetes/login"),
+						verifyLoginParams(map[string]interface{}{"role": "concourse", "jwt": "some-jwt"}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, loginResponse(true)),
+					),
+				)
+
+				lease, err := apiClient.Login()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lease).To(Equal(time.Minute))
+			})
+
+			Context("when the backend is mounted elsewhere", func() {
+				BeforeEach(func() {
+					authConfig.MountPath = "k8s/prod"
+				})
+
+				It("logs in at the mount path", func() {
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/v1/auth/k8s/prod/login"),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, loginResponse(true)),
+						),
+					)
+
+					_, err := apiClient.Login()
+					Expect(err).ToNot(HaveOccurred())
+				})
+			})
+
+			Context("when the JWT file does not exist", func() {
+				BeforeEach(func() {
+					authConfig.JWTPath = filepath.Join(tmpDir, "missing")
+				})
+
+				It("fails without logging in", func() {
+					_, err := apiClient.Login()
+					Expect(err).To(HaveOccurred())
+					Expect(server.ReceivedRequests()).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("with the jwt backend", func() {
+			BeforeEach(func() {
+				authConfig.Backend = "jwt"
+			})
+
+			It("logs in with the JWT read from the file", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/v1/auth/jwt/login"),
+						verifyLoginParams(map[string]interface{}{"role": "concourse", "jwt": "some-jwt"}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, loginResponse(true)),
+					),
+				)
+
+				_, err := apiClient.Login()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Renew", func() {
+		Context("when the token is renewable", func() {
+			JustBeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWithJSONEncoded(http.StatusOK, loginResponse(true)),
+				)
+
+				_, err := apiClient.Login()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("renews the token", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/v1/auth/token/renew-self"),
+						ghttp.VerifyHeaderKV("X-Vault-Token", "some-token"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, loginResponse(true)),
+					),
+				)
+
+				lease, err := apiClient.Renew()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lease).To(Equal(time.Minute))
+			})
+		})
+
+		Context("when the token is not renewable", func() {
+			JustBeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWithJSONEncoded(http.StatusOK, loginResponse(false)),
+				)
+
+				_, err := apiClient.Login()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("asks for a new login without renewing", func() {
+				lease, err := apiClient.Renew()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lease).To(BeZero())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("ReadInNamespace", func() {
+		BeforeEach(func() {
+			authConfig = vault.AuthConfig{ClientToken: "some-token"}
+			namespace = "some-namespace"
+		})
+
+		JustBeforeEach(func() {
+			_, err := apiClient.Login()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("reads from the namespace nested under the client's", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/sys/internal/ui/mounts/concourse/team/foo"),
+					ghttp.VerifyHeaderKV("X-Vault-Namespace", "some-namespace/teams/team"),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/team/foo"),
+					ghttp.VerifyHeaderKV("X-Vault-Namespace", "some-namespace/teams/team"),
+					ghttp.VerifyHeaderKV("X-Vault-Token", "some-token"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, createMockV1Secret("bar")),
+				),
+			)
+
+			secret, err := apiClient.ReadInNamespace("teams/team", "/concourse/team/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data["value"]).To(Equal("bar"))
+		})
+	})
+})
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
+	"text/template"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -21,6 +23,7 @@ type VaultManager struct {
 	LookupTemplates []string      `mapstructure:"lookup_templates" long:"lookup-templates" default:"/{{.Team}}/{{.Pipeline}}/{{.Secret}}" default:"/{{.Team}}/{{.Secret}}" description:"Path templates for credential lookup"`
 	SharedPath      string        `mapstructure:"shared_path" long:"shared-path" description:"Path under which to lookup shared credentials."`
 	Namespace       string        `mapstructure:"namespace" long:"namespace"   description:"Vault namespace to use for authentication and secret lookup."`
+	TeamNamespace   string        `mapstructure:"team_namespace" long:"team-namespace" description:"Template for a Vault Enterprise namespace, relative to --namespace, in which to look up each team's secrets, e.g. 'teams/{{.Team}}'. Shared and root secrets are still looked up in --namespace."`
 	LoginTimeout    time.Duration `mapstructure:"login_timeout" long:"login-timeout" default:"60s" description:"Timeout value for Vault login."`
 	QueryTimeout    time.Duration `mapstructure:"query_timeout" long:"query-timeout" default:"60s" description:"Timeout value for Vault query."`
 
@@ -47,10 +50,16 @@ type TLSConfig struct {
 	Insecure   bool   `mapstructure:"insecure_skip_verify" long:"insecure-skip-verify" description:"Enable insecure SSL verification."`
 }
 
+// DefaultKubernetesJWTPath is where Kubernetes mounts a pod's service
+// account token.
+const DefaultKubernetesJWTPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
 type AuthConfig struct {
 	ClientToken string `mapstructure:"client_token" long:"client-token" description:"Client token for accessing secrets within the Vault server."`
 
 	Backend       string        `mapstructure:"auth_backend" long:"auth-backend"               description:"Auth backend to use for logging in to Vault."`
+	MountPath     string        `mapstructure:"-" long:"auth-mount-path"         description:"Path at which the auth backend is mounted, if not at its name."`
+	JWTPath       string        `mapstructure:"-" long:"auth-jwt-path"             description:"Path to a file containing the JWT to log in with via the kubernetes or jwt auth backend. It is read on every login. Defaults to the pod's service account token for the kubernetes backend."`
 	BackendMaxTTL time.Duration `mapstructure:"auth_backend_max_ttl" long:"auth-backend-max-ttl"       description:"Time after which to force a re-login. If not set, the token will just be continuously renewed."`
 	RetryMax      time.Duration `mapstructure:"auth_retry_max" long:"retry-max"     default:"5m" description:"The maximum time between retries when logging in or re-authing a secret."`
 	RetryInitial  time.Duration `mapstructure:"auth_retry_initial" long:"retry-initial" default:"1s" description:"The initial time between retries when logging in or re-authing a secret."`
@@ -81,9 +90,11 @@ func (manager *VaultManager) MarshalJSON() ([]byte, error) {
 		"lookup_templates":   manager.LookupTemplates,
 		"shared_path":        manager.SharedPath,
 		"namespace":          manager.Namespace,
+		"team_namespace":     manager.TeamNamespace,
 		"ca_cert":            manager.TLS.CACert,
 		"server_name":        manager.TLS.ServerName,
 		"auth_backend":       manager.Auth.Backend,
+		"auth_mount_path":    manager.Auth.MountPath,
 		"auth_max_ttl":       manager.Auth.BackendMaxTTL,
 		"auth_retry_max":     manager.Auth.RetryMax,
 		"auth_retry_initial": manager.Auth.RetryInitial,
@@ -91,7 +102,21 @@ func (manager *VaultManager) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// Config decodes a var_source config. The web node reads the JWT for the
+// kubernetes and jwt auth backends from its own disk and sends it to the
+// configured URL, so those backends and their paths can only be configured
+// for the cluster.
 func (manager *VaultManager) Config(config map[string]interface{}) error {
+	if backend, _ := config["auth_backend"].(string); backend == "kubernetes" || backend == "jwt" {
+		return fmt.Errorf("the %s auth backend cannot be configured in a var_source, only with --vault-auth-backend", backend)
+	}
+
+	for _, key := range []string{"auth_jwt_path", "auth_mount_path"} {
+		if _, found := config[key]; found {
+			return fmt.Errorf("%s cannot be configured in a var_source, only with --vault-%s", key, strings.ReplaceAll(key, "_", "-"))
+		}
+	}
+
 	// apply defaults
 	manager.PathPrefix = "/concourse"
 	manager.Auth.RetryMax = 5 * time.Minute
@@ -147,15 +172,30 @@ func (manager VaultManager) Validate() error {
 		}
 	}
 
+	if manager.TeamNamespace != "" {
+		if _, err := BuildNamespaceTemplate(manager.TeamNamespace); err != nil {
+			return fmt.Errorf("invalid team namespace: %s", err)
+		}
+	}
+
 	if manager.Auth.ClientToken != "" {
 		return nil
 	}
 
-	if manager.Auth.Backend != "" {
-		return nil
+	switch manager.Auth.Backend {
+	case "":
+		return errors.New("must configure client token or auth backend")
+	case "kubernetes":
+		if manager.Auth.Params["role"] == "" {
+			return errors.New("must configure a role auth param for the kubernetes auth backend")
+		}
+	case "jwt":
+		if manager.Auth.JWTPath == "" && manager.Auth.Params["jwt"] == "" {
+			return errors.New("must configure a JWT path or a jwt auth param for the jwt auth backend")
+		}
 	}
 
-	return errors.New("must configure client token or auth backend")
+	return nil
 }
 
 func (manager VaultManager) Health() (*creds.HealthResponse, error) {
@@ -187,6 +227,15 @@ func (manager *VaultManager) NewSecretsFactory(logger lager.Logger) (creds.Secre
 			}
 		}
 
+		var teamNamespace *template.Template
+		if manager.TeamNamespace != "" {
+			var err error
+			teamNamespace, err = BuildNamespaceTemplate(manager.TeamNamespace)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		manager.ReAuther = NewReAuther(
 			logger,
 			manager.Client,
@@ -202,6 +251,7 @@ func (manager *VaultManager) NewSecretsFactory(logger lager.Logger) (creds.Secre
 			manager.PathPrefix,
 			templates,
 			manager.SharedPath,
+			teamNamespace,
 		)
 	}
 
@@ -70,6 +70,30 @@ var _ = Describe("VaultManager", func() {
 			manager.Auth = vault.AuthConfig{}
 			Expect(manager.Validate()).ToNot(BeNil())
 		})
+
+		It("fails on the kubernetes auth backend without a role", func() {
+			manager.Auth = vault.AuthConfig{Backend: "kubernetes"}
+			Expect(manager.Validate()).ToNot(BeNil())
+
+			manager.Auth.Params = map[string]string{"role": "concourse"}
+			Expect(manager.Validate()).To(BeNil())
+		})
+
+		It("fails on the jwt auth backend without a JWT", func() {
+			manager.Auth = vault.AuthConfig{Backend: "jwt"}
+			Expect(manager.Validate()).ToNot(BeNil())
+
+			manager.Auth.JWTPath = "/some/token"
+			Expect(manager.Validate()).To(BeNil())
+		})
+
+		It("fails on an invalid team namespace", func() {
+			manager.TeamNamespace = "teams/{{.Pipeline}}"
+			Expect(manager.Validate()).ToNot(BeNil())
+
+			manager.TeamNamespace = "teams/{{.Team}}"
+			Expect(manager.Validate()).To(BeNil())
+		})
 	})
 
 	Describe("Config", func() {
@@ -236,5 +260,45 @@ var _ = Describe("VaultManager", func() {
 				Expect(configErr.Error()).To(ContainSubstring("unknown_key"))
 			})
 		})
+
+		for _, backend := range []string{"kubernetes", "jwt"} {
+			backend := backend
+
+			Context("with the "+backend+" auth backend", func() {
+				BeforeEach(func() {
+					config["auth_backend"] = backend
+				})
+
+				It("returns an error", func() {
+					Expect(configErr).To(MatchError(ContainSubstring("the " + backend + " auth backend cannot be configured in a var_source")))
+				})
+			})
+		}
+
+		for _, key := range []string{"auth_jwt_path", "auth_mount_path"} {
+			key := key
+
+			Context("with "+key+" set", func() {
+				BeforeEach(func() {
+					config[key] = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+				})
+
+				It("returns an error", func() {
+					Expect(configErr).To(MatchError(ContainSubstring(key + " cannot be configured in a var_source")))
+				})
+			})
+		}
+	})
+
+	Describe("NewInstance", func() {
+		It("fails to build a manager for a var_source which reads a JWT from disk", func() {
+			_, err := vault.NewVaultManagerFactory().NewInstance(map[string]interface{}{
+				"url":           "https://vault.example.com",
+				"auth_backend":  "kubernetes",
+				"auth_jwt_path": "/var/run/secrets/kubernetes.io/serviceaccount/token",
+				"auth_params":   map[string]string{"role": "some-role"},
+			})
+			Expect(err).To(HaveOccurred())
+		})
 	})
 })
@@ -1,7 +1,11 @@
 package vault
 
 import (
+	"bytes"
+	"fmt"
 	"path"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/concourse/concourse/atc/creds"
@@ -15,10 +19,71 @@ func (e VaultLoginTimeout) Error() string {
 	return "timed out to login to vault"
 }
 
-// A SecretReader reads a vault secret from the given path. It should
-// be thread safe!
+// A SecretReader reads a vault secret from the given path, optionally
+// within a namespace relative to the reader's own. It should be thread
+// safe!
 type SecretReader interface {
 	Read(path string) (*vaultapi.Secret, error)
+	ReadInNamespace(namespace string, path string) (*vaultapi.Secret, error)
+}
+
+// namespaceSeparator separates the team namespace from the path within it
+// in the secret paths of a Vault with a TeamNamespace.
+const namespaceSeparator = ":"
+
+// BuildNamespaceTemplate parses a template for a team's namespace, which
+// may only refer to the team.
+func BuildNamespaceTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("team-namespace").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := executeNamespaceTemplate(t, "team"); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func executeNamespaceTemplate(t *template.Template, teamName string) (string, error) {
+	var buf bytes.Buffer
+	err := t.Execute(&buf, struct{ Team string }{teamName})
+	if err != nil {
+		return "", err
+	}
+
+	namespace := strings.Trim(buf.String(), "/")
+	if namespace == "" {
+		return "", fmt.Errorf("namespace of team '%s' is empty", teamName)
+	}
+
+	if strings.Contains(namespace, namespaceSeparator) {
+		return "", fmt.Errorf("namespace '%s' must not contain '%s'", namespace, namespaceSeparator)
+	}
+
+	return namespace, nil
+}
+
+// namespacedLookupPath prefixes the paths of a lookup with the namespace
+// they are to be read from.
+type namespacedLookupPath struct {
+	lookupPath   creds.SecretLookupPath
+	namespace    string
+	namespaceErr error
+}
+
+func (nl namespacedLookupPath) VariableToSecretPath(varName string) (string, error) {
+	if nl.namespaceErr != nil {
+		return "", nl.namespaceErr
+	}
+
+	secretPath, err := nl.lookupPath.VariableToSecretPath(varName)
+	if err != nil {
+		return "", err
+	}
+
+	return nl.namespace + namespaceSeparator + secretPath, nil
 }
 
 // Vault converts a vault secret to our completely untyped secret
@@ -30,6 +95,10 @@ type Vault struct {
 	SharedPath      string
 	LoggedIn        <-chan struct{}
 	LoginTimeout    time.Duration
+
+	// TeamNamespace, if set, is the template of the namespace in which
+	// each team's secrets are looked up.
+	TeamNamespace *template.Template
 }
 
 // NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
@@ -46,9 +115,28 @@ func (v Vault) NewSecretLookupPaths(teamName string, pipelineName string, allowR
 	if allowRootPath {
 		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(v.Prefix+"/"))
 	}
+	if v.TeamNamespace != nil {
+		lookupPaths = v.namespaceLookupPaths(teamName, lookupPaths)
+	}
 	return lookupPaths
 }
 
+// namespaceLookupPaths places the team's lookup paths in the team's
+// namespace, and the shared and root ones in the reader's own.
+func (v Vault) namespaceLookupPaths(teamName string, lookupPaths []creds.SecretLookupPath) []creds.SecretLookupPath {
+	namespace, err := executeNamespaceTemplate(v.TeamNamespace, teamName)
+
+	namespaced := []creds.SecretLookupPath{}
+	for _, lookupPath := range lookupPaths {
+		if _, isTeamPath := lookupPath.(*creds.SecretLookupWithTemplate); isTeamPath {
+			namespaced = append(namespaced, namespacedLookupPath{lookupPath, namespace, err})
+		} else {
+			namespaced = append(namespaced, namespacedLookupPath{lookupPath: lookupPath})
+		}
+	}
+	return namespaced
+}
+
 // Get retrieves the value and expiration of an individual secret
 func (v Vault) Get(secretPath string) (interface{}, *time.Time, bool, error) {
 	if v.LoggedIn != nil {
@@ -75,13 +163,18 @@ func (v Vault) Get(secretPath string) (interface{}, *time.Time, bool, error) {
 	return secret.Data, expiration, true, nil
 }
 
-func (v Vault) findSecret(path string) (*vaultapi.Secret, *time.Time, bool, error) {
-	secret, err := v.SecretReader.Read(path)
+func (v Vault) findSecret(secretPath string) (*vaultapi.Secret, *time.Time, bool, error) {
+	secret, err := v.read(secretPath)
 	if err != nil {
 		return nil, nil, false, err
 	}
 
 	if secret != nil {
+		// Secrets without a lease, e.g. from a KV v2 mount, never expire.
+		if secret.LeaseDuration == 0 {
+			return secret, nil, true, nil
+		}
+
 		// The lease duration is TTL: the time in seconds for which the lease is valid
 		// A consumer of this secret must renew the lease within that time.
 		duration := time.Duration(secret.LeaseDuration) * time.Second / 2
@@ -91,3 +184,16 @@ func (v Vault) findSecret(path string) (*vaultapi.Secret, *time.Time, bool, erro
 
 	return nil, nil, false, nil
 }
+
+func (v Vault) read(secretPath string) (*vaultapi.Secret, error) {
+	if v.TeamNamespace == nil {
+		return v.SecretReader.Read(secretPath)
+	}
+
+	parts := strings.SplitN(secretPath, namespaceSeparator, 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("secret path '%s' has no namespace", secretPath)
+	}
+
+	return v.SecretReader.ReadInNamespace(parts[0], parts[1])
+}
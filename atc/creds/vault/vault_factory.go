@@ -1,6 +1,7 @@
 package vault
 
 import (
+	"text/template"
 	"time"
 
 	"github.com/concourse/concourse/atc/creds"
@@ -12,16 +13,18 @@ type vaultFactory struct {
 	prefix          string
 	sharedPath      string
 	lookupTemplates []*creds.SecretTemplate
+	teamNamespace   *template.Template
 	loggedIn        <-chan struct{}
 	loginTimeout    time.Duration
 }
 
-func NewVaultFactory(sr SecretReader, loginTimeout time.Duration, loggedIn <-chan struct{}, prefix string, lookupTemplates []*creds.SecretTemplate, sharedPath string) *vaultFactory {
+func NewVaultFactory(sr SecretReader, loginTimeout time.Duration, loggedIn <-chan struct{}, prefix string, lookupTemplates []*creds.SecretTemplate, sharedPath string, teamNamespace *template.Template) *vaultFactory {
 	factory := &vaultFactory{
 		sr:              sr,
 		prefix:          prefix,
 		lookupTemplates: lookupTemplates,
 		sharedPath:      sharedPath,
+		teamNamespace:   teamNamespace,
 		loggedIn:        loggedIn,
 		loginTimeout:    loginTimeout,
 	}
@@ -35,6 +38,7 @@ func (factory *vaultFactory) NewSecrets() creds.Secrets {
 		Prefix:          factory.prefix,
 		LookupTemplates: factory.lookupTemplates,
 		SharedPath:      factory.sharedPath,
+		TeamNamespace:   factory.teamNamespace,
 		LoginTimeout:    factory.loginTimeout,
 		LoggedIn:        factory.loggedIn,
 	}
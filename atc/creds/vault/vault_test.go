@@ -16,8 +16,9 @@ import (
 )
 
 type MockSecret struct {
-	path   string
-	secret *vaultapi.Secret
+	namespace string
+	path      string
+	secret    *vaultapi.Secret
 }
 
 type MockSecretReader struct {
@@ -25,10 +26,14 @@ type MockSecretReader struct {
 }
 
 func (msr *MockSecretReader) Read(lookupPath string) (*vaultapi.Secret, error) {
+	return msr.ReadInNamespace("", lookupPath)
+}
+
+func (msr *MockSecretReader) ReadInNamespace(namespace string, lookupPath string) (*vaultapi.Secret, error) {
 	Expect(lookupPath).ToNot(BeNil())
 
 	for _, secret := range *msr.secrets {
-		if lookupPath == secret.path {
+		if namespace == secret.namespace && lookupPath == secret.path {
 			return secret.secret, nil
 		}
 	}
@@ -233,6 +238,99 @@ var _ = Describe("Vault", func() {
 				})
 			})
 
+			It("should expire secrets halfway through their lease", func() {
+				v.SecretReader = &MockSecretReader{&[]MockSecret{
+					{
+						path: "/concourse/team/pipeline/foo",
+						secret: &vaultapi.Secret{
+							LeaseDuration: 60,
+							Data:          map[string]interface{}{"value": "bar"},
+						},
+					}},
+				}
+
+				_, expiration, found, err := v.Get("/concourse/team/pipeline/foo")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(*expiration).To(BeTemporally("~", time.Now().Add(30*time.Second), time.Second))
+			})
+
+			It("should not expire secrets without a lease", func() {
+				v.SecretReader = &MockSecretReader{&[]MockSecret{
+					{
+						path: "/concourse/team/pipeline/foo",
+						secret: &vaultapi.Secret{
+							Data: map[string]interface{}{"value": "bar"},
+						},
+					}},
+				}
+
+				_, expiration, found, err := v.Get("/concourse/team/pipeline/foo")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(expiration).To(BeNil())
+			})
+
+			Context("with a team namespace", func() {
+				BeforeEach(func() {
+					tmpl, err := vault.BuildNamespaceTemplate("teams/{{.Team}}")
+					Expect(err).ToNot(HaveOccurred())
+
+					v.TeamNamespace = tmpl
+					v.SecretReader = &MockSecretReader{&[]MockSecret{
+						{
+							namespace: "teams/team",
+							path:      "/concourse/team/pipeline/foo",
+							secret: &vaultapi.Secret{
+								Data: map[string]interface{}{"value": "pipeline"},
+							},
+						},
+						{
+							namespace: "teams/team",
+							path:      "/concourse/team/bar",
+							secret: &vaultapi.Secret{
+								Data: map[string]interface{}{"value": "team"},
+							},
+						},
+						{
+							path: "/concourse/team/baz",
+							secret: &vaultapi.Secret{
+								Data: map[string]interface{}{"value": "outside"},
+							},
+						},
+						{
+							path: "/concourse/shared/baz",
+							secret: &vaultapi.Secret{
+								Data: map[string]interface{}{"value": "shared"},
+							},
+						}},
+					}
+
+					variables = creds.NewVariables(v, "team", "pipeline", false)
+				})
+
+				It("should look up pipeline secrets in the team's namespace", func() {
+					value, found, err := variables.Get(varFoo)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(value).To(BeEquivalentTo("pipeline"))
+				})
+
+				It("should look up team secrets in the team's namespace", func() {
+					value, found, err := variables.Get(vars.Reference{Path: "bar"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(value).To(BeEquivalentTo("team"))
+				})
+
+				It("should look up shared secrets outside of the team's namespace", func() {
+					value, found, err := variables.Get(vars.Reference{Path: "baz"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(value).To(BeEquivalentTo("shared"))
+				})
+			})
+
 			Context("without shared", func() {
 				BeforeEach(func() {
 					p, _ := creds.BuildSecretTemplate("p", "/concourse/{{.Team}}/{{.Pipeline}}/{{.Secret}}")
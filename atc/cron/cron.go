@@ -0,0 +1,247 @@
+// Package cron parses standard five field cron expressions and finds the
+// times they match.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minutes fieldSet
+	hours   fieldSet
+	days    fieldSet
+	months  fieldSet
+	weekday fieldSet
+
+	// whether the day of the month and day of the week fields were
+	// restricted, in which case a day matching either of them matches
+	daysRestricted    bool
+	weekdayRestricted bool
+}
+
+// a set of values, where bit n is set if n is in the set
+type fieldSet uint64
+
+func (set fieldSet) has(value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	dayField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+
+	// 7 is accepted for Sunday as well as 0
+	weekdayField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses an expression of the form "minute hour day-of-month month
+// day-of-week". Each field may be a `*`, a value, a range such as `1-5`, any
+// of these with a step such as `*/15`, or a comma separated list of them.
+// Months and days of the week may be given by their first three letters.
+// The descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight
+// and @hourly are also accepted.
+func Parse(expression string) (Schedule, error) {
+	expression = strings.TrimSpace(expression)
+	if expanded, found := descriptors[strings.ToLower(expression)]; found {
+		expression = expanded
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	var schedule Schedule
+	var err error
+
+	schedule.minutes, err = minuteField.parse(fields[0])
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.hours, err = hourField.parse(fields[1])
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.days, err = dayField.parse(fields[2])
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.months, err = monthField.parse(fields[3])
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.weekday, err = weekdayField.parse(fields[4])
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	if schedule.weekday.has(7) {
+		schedule.weekday |= 1 << 0
+	}
+
+	schedule.daysRestricted = !strings.HasPrefix(fields[2], "*")
+	schedule.weekdayRestricted = !strings.HasPrefix(fields[4], "*")
+
+	return schedule, nil
+}
+
+func (f field) parse(expression string) (fieldSet, error) {
+	var set fieldSet
+
+	for _, part := range strings.Split(expression, ",") {
+		rangeExpression, step := part, 1
+
+		if i := strings.Index(part, "/"); i != -1 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in %s field: '%s'", f.name, part)
+			}
+
+			rangeExpression = part[:i]
+		}
+
+		start, end := f.min, f.max
+		if rangeExpression != "*" {
+			var err error
+			if i := strings.Index(rangeExpression, "-"); i != -1 {
+				start, err = f.value(rangeExpression[:i])
+				if err != nil {
+					return 0, err
+				}
+
+				end, err = f.value(rangeExpression[i+1:])
+				if err != nil {
+					return 0, err
+				}
+			} else {
+				start, err = f.value(rangeExpression)
+				if err != nil {
+					return 0, err
+				}
+
+				// a value with a step, such as 5/15, runs to the end of the field
+				end = start
+				if strings.Contains(part, "/") {
+					end = f.max
+				}
+			}
+		}
+
+		if start > end {
+			return 0, fmt.Errorf("invalid range in %s field: '%s'", f.name, part)
+		}
+
+		for value := start; value <= end; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+
+	return set, nil
+}
+
+func (f field) value(expression string) (int, error) {
+	if value, found := f.names[strings.ToLower(expression)]; found {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(expression)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: '%s'", f.name, expression)
+	}
+
+	if value < f.min || value > f.max {
+		return 0, fmt.Errorf("%s must be between %d and %d: '%s'", f.name, f.min, f.max, expression)
+	}
+
+	return value, nil
+}
+
+// searchYears bounds the search for the next matching time, so that
+// expressions which can never match, such as "0 0 30 2 *", don't loop
+// forever.
+const searchYears = 5
+
+// Next returns the first time after the given time which matches the
+// schedule, in the given time's location. The zero time is returned if the
+// schedule never matches.
+func (schedule Schedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + searchYears
+
+	for t.Year() <= limit {
+		if !schedule.months.has(int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !schedule.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !schedule.hours.has(t.Hour()) {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// the next hour is repeated when the clocks go back
+				next = t.Truncate(time.Hour).Add(time.Hour)
+			}
+
+			t = next
+			continue
+		}
+
+		if !schedule.minutes.has(t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (schedule Schedule) dayMatches(t time.Time) bool {
+	day := schedule.days.has(t.Day())
+	weekday := schedule.weekday.has(int(t.Weekday()))
+
+	if schedule.daysRestricted && schedule.weekdayRestricted {
+		return day || weekday
+	}
+
+	return day && weekday
+}
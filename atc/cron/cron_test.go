@@ -0,0 +1,127 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/concourse/concourse/atc/cron"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		title      string
+		expression string
+		after      time.Time
+		next       time.Time
+	}{
+		{
+			title:      "every minute",
+			expression: "* * * * *",
+			after:      time.Date(2021, 6, 1, 12, 30, 15, 0, time.UTC),
+			next:       time.Date(2021, 6, 1, 12, 31, 0, 0, time.UTC),
+		},
+		{
+			title:      "exactly on a matching minute",
+			expression: "30 12 * * *",
+			after:      time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC),
+			next:       time.Date(2021, 6, 2, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			title:      "steps",
+			expression: "*/15 * * * *",
+			after:      time.Date(2021, 6, 1, 12, 31, 0, 0, time.UTC),
+			next:       time.Date(2021, 6, 1, 12, 45, 0, 0, time.UTC),
+		},
+		{
+			title:      "a value with a step",
+			expression: "5/20 * * * *",
+			after:      time.Date(2021, 6, 1, 12, 46, 0, 0, time.UTC),
+			next:       time.Date(2021, 6, 1, 13, 5, 0, 0, time.UTC),
+		},
+		{
+			title:      "ranges and lists",
+			expression: "0 9-11,15 * * *",
+			after:      time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC),
+			next:       time.Date(2021, 6, 1, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			title:      "named weekdays",
+			expression: "0 9 * * mon-fri",
+			after:      time.Date(2021, 6, 4, 10, 0, 0, 0, time.UTC), // a Friday
+			next:       time.Date(2021, 6, 7, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			title:      "sunday as 7",
+			expression: "0 0 * * 7",
+			after:      time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			next:       time.Date(2021, 6, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			title:      "named months across a year",
+			expression: "0 0 1 jan *",
+			after:      time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			next:       time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			title:      "day of month or day of week when both are restricted",
+			expression: "0 0 15 * fri",
+			after:      time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			next:       time.Date(2021, 6, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			title:      "descriptors",
+			expression: "@daily",
+			after:      time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+			next:       time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			title:      "in the given time's location",
+			expression: "0 9 * * *",
+			after:      time.Date(2021, 6, 1, 10, 0, 0, 0, newYork),
+			next:       time.Date(2021, 6, 2, 9, 0, 0, 0, newYork),
+		},
+		{
+			title:      "an hour skipped when the clocks go forward",
+			expression: "30 * * * *",
+			after:      time.Date(2021, 3, 14, 1, 45, 0, 0, newYork),
+			next:       time.Date(2021, 3, 14, 3, 30, 0, 0, newYork),
+		},
+		{
+			title:      "an hour repeated when the clocks go back",
+			expression: "0 * * * *",
+			after:      time.Date(2021, 11, 7, 0, 30, 0, 0, newYork),
+			next:       time.Date(2021, 11, 7, 1, 0, 0, 0, newYork),
+		},
+		{
+			title:      "never matching",
+			expression: "0 0 30 feb *",
+			after:      time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			next:       time.Time{},
+		},
+	} {
+		t.Run(test.title, func(t *testing.T) {
+			schedule, err := cron.Parse(test.expression)
+			require.NoError(t, err)
+			require.True(t, test.next.Equal(schedule.Next(test.after)), "expected %s, got %s", test.next, schedule.Next(test.after))
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for expression, message := range map[string]string{
+		"* * * *":       "expected 5 fields, got 4",
+		"60 * * * *":    "minute must be between 0 and 59: '60'",
+		"* * 0 * *":     "day of month must be between 1 and 31: '0'",
+		"* * * foo *":   "invalid month: 'foo'",
+		"*/0 * * * *":   "invalid step in minute field: '*/0'",
+		"* 5-1 * * *":   "invalid range in hour field: '5-1'",
+		"* * * * mon-x": "invalid day of week: 'x'",
+	} {
+		_, err := cron.Parse(expression)
+		require.EqualError(t, err, message, expression)
+	}
+}
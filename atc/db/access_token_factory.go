@@ -13,6 +13,8 @@ import (
 type AccessTokenFactory interface {
 	CreateAccessToken(token string, claims Claims) error
 	GetAccessToken(token string) (AccessToken, bool, error)
+	TouchAccessToken(token string) (time.Duration, error)
+	DeleteAccessToken(token string) error
 }
 
 func NewAccessTokenFactory(conn Conn) AccessTokenFactory {
@@ -56,3 +58,33 @@ func (a *accessTokenFactory) GetAccessToken(token string) (AccessToken, bool, er
 	}
 	return accessToken, true, nil
 }
+
+// TouchAccessToken records that the token has just been used, returning the
+// longest the token has ever gone unused, including up until now.
+func (a *accessTokenFactory) TouchAccessToken(token string) (time.Duration, error) {
+	var longestIdleSeconds int64
+	err := psql.Update("access_tokens").
+		Set("last_used_at", sq.Expr("now()")).
+		Set("longest_idle_seconds", sq.Expr("GREATEST(longest_idle_seconds, COALESCE(EXTRACT(EPOCH FROM now() - last_used_at), 0)::bigint)")).
+		Where(sq.Eq{"token": token}).
+		Suffix("RETURNING longest_idle_seconds").
+		RunWith(a.conn).
+		QueryRow().
+		Scan(&longestIdleSeconds)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return time.Duration(longestIdleSeconds) * time.Second, nil
+}
+
+func (a *accessTokenFactory) DeleteAccessToken(token string) error {
+	_, err := psql.Delete("access_tokens").
+		Where(sq.Eq{"token": token}).
+		RunWith(a.conn).
+		Exec()
+	return err
+}
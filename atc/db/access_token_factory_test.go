@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc/db"
 	"gopkg.in/square/go-jose.v2/jwt"
 
@@ -76,4 +78,41 @@ var _ = Describe("Access Token Factory", func() {
 			},
 		}))
 	})
+
+	Describe("TouchAccessToken", func() {
+		BeforeEach(func() {
+			err := factory.CreateAccessToken("some-token", db.Claims{
+				RawClaims: map[string]interface{}{"sub": "subject"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("reports no idle time on first use", func() {
+			longestIdle, err := factory.TouchAccessToken("some-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(longestIdle).To(BeZero())
+		})
+
+		It("reports the longest time between uses", func() {
+			_, err := factory.TouchAccessToken("some-token")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE access_tokens SET last_used_at = now() - interval '1 hour' WHERE token = 'some-token'`)
+			Expect(err).ToNot(HaveOccurred())
+
+			longestIdle, err := factory.TouchAccessToken("some-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(longestIdle).To(BeNumerically("~", time.Hour, time.Second))
+
+			longestIdle, err = factory.TouchAccessToken("some-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(longestIdle).To(BeNumerically("~", time.Hour, time.Second))
+		})
+
+		It("does nothing for unknown tokens", func() {
+			longestIdle, err := factory.TouchAccessToken("bogus-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(longestIdle).To(BeZero())
+		})
+	})
 })
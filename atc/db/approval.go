@@ -0,0 +1,209 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/concourse/concourse/atc"
+)
+
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "pending"
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+)
+
+// Approval is requested by an approval step, identified by its build and plan
+// ID, and stays pending until a user approves or rejects it.
+type Approval struct {
+	BuildID   int
+	PlanID    atc.PlanID
+	Name      string
+	Approvers []string
+
+	Status    ApprovalStatus
+	DecidedBy string
+}
+
+// AllowsApprover returns whether the user may decide the approval. Any user
+// may if no approvers were configured. Approvers are given as
+// <connector>:<user>, and match the user's ID or username as given by the
+// connector they logged in with, in the same way as the users of a team's
+// auth config.
+func (approval Approval) AllowsApprover(user atc.UserInfo) bool {
+	if len(approval.Approvers) == 0 {
+		return true
+	}
+
+	userName := user.UserName
+	if userName == "" {
+		userName = user.Name
+	}
+
+	for _, approver := range approval.Approvers {
+		if user.UserId != "" && strings.EqualFold(approver, user.Connector+":"+user.UserId) {
+			return true
+		}
+
+		if userName != "" && strings.EqualFold(approver, user.Connector+":"+userName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//counterfeiter:generate . ApprovalFactory
+type ApprovalFactory interface {
+	Request(buildID int, planID atc.PlanID, name string, approvers []string) error
+	Find(buildID int, planID atc.PlanID) (Approval, bool, error)
+	FindPending(buildID int, name string) ([]Approval, error)
+	Decide(buildID int, planID atc.PlanID, status ApprovalStatus, decidedBy string) (bool, error)
+}
+
+type approvalFactory struct {
+	conn Conn
+}
+
+func NewApprovalFactory(conn Conn) ApprovalFactory {
+	return &approvalFactory{
+		conn: conn,
+	}
+}
+
+var approvalsQuery = psql.Select("build_id", "plan_id", "name", "approvers", "status", "decided_by").
+	From("build_approvals")
+
+// Request records a pending approval for the step. Requesting an approval
+// which was already requested leaves it as it is, so that a step which is
+// run again after the web node restarts keeps its decision.
+func (f *approvalFactory) Request(buildID int, planID atc.PlanID, name string, approvers []string) error {
+	var payload interface{}
+	if len(approvers) > 0 {
+		encoded, err := json.Marshal(approvers)
+		if err != nil {
+			return err
+		}
+
+		payload = encoded
+	}
+
+	_, err := psql.Insert("build_approvals").
+		Columns("build_id", "plan_id", "name", "approvers").
+		Values(buildID, string(planID), name, payload).
+		Suffix("ON CONFLICT (build_id, plan_id) DO NOTHING").
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func (f *approvalFactory) Find(buildID int, planID atc.PlanID) (Approval, bool, error) {
+	row := approvalsQuery.
+		Where(sq.Eq{
+			"build_id": buildID,
+			"plan_id":  string(planID),
+		}).
+		RunWith(f.conn).
+		QueryRow()
+
+	approval, err := scanApproval(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Approval{}, false, nil
+		}
+
+		return Approval{}, false, err
+	}
+
+	return approval, true, nil
+}
+
+// FindPending returns the build's pending approvals with the given name.
+// There may be more than one if the approval step is run across values.
+func (f *approvalFactory) FindPending(buildID int, name string) ([]Approval, error) {
+	rows, err := approvalsQuery.
+		Where(sq.Eq{
+			"build_id": buildID,
+			"name":     name,
+			"status":   string(ApprovalStatusPending),
+		}).
+		OrderBy("created_at").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	approvals := []Approval{}
+	for rows.Next() {
+		approval, err := scanApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, rows.Err()
+}
+
+// Decide approves or rejects the approval, returning false if it has already
+// been decided or was never requested.
+func (f *approvalFactory) Decide(buildID int, planID atc.PlanID, status ApprovalStatus, decidedBy string) (bool, error) {
+	result, err := psql.Update("build_approvals").
+		Set("status", string(status)).
+		Set("decided_by", decidedBy).
+		Set("decided_at", sq.Expr("now()")).
+		Where(sq.Eq{
+			"build_id": buildID,
+			"plan_id":  string(planID),
+			"status":   string(ApprovalStatusPending),
+		}).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func scanApproval(row scannable) (Approval, error) {
+	var (
+		approval  Approval
+		planID    string
+		status    string
+		approvers []byte
+		decidedBy sql.NullString
+	)
+
+	err := row.Scan(&approval.BuildID, &planID, &approval.Name, &approvers, &status, &decidedBy)
+	if err != nil {
+		return Approval{}, err
+	}
+
+	if approvers != nil {
+		err = json.Unmarshal(approvers, &approval.Approvers)
+		if err != nil {
+			return Approval{}, err
+		}
+	}
+
+	approval.PlanID = atc.PlanID(planID)
+	approval.Status = ApprovalStatus(status)
+	approval.DecidedBy = decidedBy.String
+
+	return approval, nil
+}
@@ -0,0 +1,137 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ApprovalFactory", func() {
+	var (
+		approvalFactory db.ApprovalFactory
+		build           db.Build
+	)
+
+	BeforeEach(func() {
+		approvalFactory = db.NewApprovalFactory(dbConn)
+
+		var err error
+		build, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("when no approval has been requested", func() {
+		It("does not find one", func() {
+			_, found, err := approvalFactory.Find(build.ID(), "some-plan")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not decide one", func() {
+			decided, err := approvalFactory.Decide(build.ID(), "some-plan", db.ApprovalStatusApproved, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decided).To(BeFalse())
+		})
+	})
+
+	Context("when an approval has been requested", func() {
+		BeforeEach(func() {
+			err := approvalFactory.Request(build.ID(), "some-plan", "some-approval", []string{"some-user"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("is pending", func() {
+			approval, found, err := approvalFactory.Find(build.ID(), "some-plan")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(approval).To(Equal(db.Approval{
+				BuildID:   build.ID(),
+				PlanID:    "some-plan",
+				Name:      "some-approval",
+				Approvers: []string{"some-user"},
+				Status:    db.ApprovalStatusPending,
+			}))
+
+			pending, err := approvalFactory.FindPending(build.ID(), "some-approval")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(Equal([]db.Approval{approval}))
+		})
+
+		Context("when it is decided", func() {
+			BeforeEach(func() {
+				decided, err := approvalFactory.Decide(build.ID(), "some-plan", db.ApprovalStatusRejected, "some-user")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decided).To(BeTrue())
+			})
+
+			It("records the decision", func() {
+				approval, found, err := approvalFactory.Find(build.ID(), "some-plan")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(approval.Status).To(Equal(db.ApprovalStatusRejected))
+				Expect(approval.DecidedBy).To(Equal("some-user"))
+			})
+
+			It("is no longer pending", func() {
+				pending, err := approvalFactory.FindPending(build.ID(), "some-approval")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pending).To(BeEmpty())
+			})
+
+			It("can not be decided again", func() {
+				decided, err := approvalFactory.Decide(build.ID(), "some-plan", db.ApprovalStatusApproved, "some-other-user")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decided).To(BeFalse())
+			})
+
+			It("keeps the decision when it is requested again", func() {
+				err := approvalFactory.Request(build.ID(), "some-plan", "some-approval", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				approval, _, err := approvalFactory.Find(build.ID(), "some-plan")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(approval.Status).To(Equal(db.ApprovalStatusRejected))
+			})
+		})
+	})
+
+	Describe("AllowsApprover", func() {
+		var user atc.UserInfo
+
+		BeforeEach(func() {
+			user = atc.UserInfo{
+				Connector:     "github",
+				UserId:        "1234",
+				UserName:      "some-user",
+				Name:          "Some User",
+				DisplayUserId: "some-display-id",
+			}
+		})
+
+		It("allows anyone when there are no approvers", func() {
+			Expect(db.Approval{}.AllowsApprover(user)).To(BeTrue())
+		})
+
+		It("allows an approver matching the user's connector and ID", func() {
+			approval := db.Approval{Approvers: []string{"github:1234"}}
+			Expect(approval.AllowsApprover(user)).To(BeTrue())
+		})
+
+		It("allows an approver matching the user's connector and username", func() {
+			approval := db.Approval{Approvers: []string{"GitHub:Some-User"}}
+			Expect(approval.AllowsApprover(user)).To(BeTrue())
+		})
+
+		It("does not allow users of another connector", func() {
+			approval := db.Approval{Approvers: []string{"local:some-user"}}
+			Expect(approval.AllowsApprover(user)).To(BeFalse())
+		})
+
+		It("does not match the user's display ID", func() {
+			approval := db.Approval{Approvers: []string{"some-display-id"}}
+			Expect(approval.AllowsApprover(user)).To(BeFalse())
+		})
+	})
+})
@@ -82,6 +82,7 @@ var buildsQuery = psql.Select(`
 		b.end_time,
 		b.reap_time,
 		j.name,
+		j.priority,
 		r.name,
 		rt.name,
 		b.pipeline_id,
@@ -96,7 +97,10 @@ var buildsQuery = psql.Select(`
 		b.rerun_of,
 		rb.name,
 		b.rerun_number,
-		b.span_context
+		b.span_context,
+		rb.span_context,
+		b.idempotency_key,
+		b.trigger_type
 	`).
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
@@ -125,6 +129,7 @@ type Build interface {
 
 	JobID() int
 	JobName() string
+	JobPriority() int
 
 	ResourceID() int
 	ResourceName() string
@@ -151,6 +156,8 @@ type Build interface {
 	RerunOfName() string
 	RerunNumber() int
 	CreatedBy() *string
+	IdempotencyKey() string
+	Trigger() atc.BuildTrigger
 
 	LagerData() lager.Data
 	TracingAttrs() tracing.Attrs
@@ -170,16 +177,21 @@ type Build interface {
 	Finish(BuildStatus) error
 
 	Variables(lager.Logger, creds.Secrets, creds.VarSourcePool) (vars.Variables, error)
+	Vars() (vars.StaticVariables, error)
 
 	SetInterceptible(bool) error
 
 	Events(uint) (EventSource, error)
+	StepEvents() ([]event.Envelope, error)
 	SaveEvent(event atc.Event) error
 
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
 
 	SaveOutput(string, atc.Source, atc.VersionedResourceTypes, atc.Version, ResourceConfigMetadataFields, string, string) error
+	SaveInput(string, string, atc.Version) error
+	SaveIteratedInputs(string, []atc.Version) error
+	UpdatePlan(atc.Plan) error
 	AdoptInputsAndPipes() ([]BuildInput, bool, error)
 	AdoptRerunInputsAndPipes() ([]BuildInput, bool, error)
 
@@ -195,6 +207,7 @@ type Build interface {
 	SetDrained(bool) error
 
 	SpanContext() propagation.TextMapCarrier
+	RerunOfSpanContext() propagation.TextMapCarrier
 
 	SavePipeline(
 		pipelineRef atc.PipelineRef,
@@ -217,8 +230,9 @@ type build struct {
 	teamID   int
 	teamName string
 
-	jobID   int
-	jobName string
+	jobID       int
+	jobName     string
+	jobPriority int
 
 	resourceID   int
 	resourceName string
@@ -230,6 +244,10 @@ type build struct {
 
 	createdBy *string
 
+	idempotencyKey string
+
+	trigger atc.BuildTrigger
+
 	rerunOf     int
 	rerunOfName string
 	rerunNumber int
@@ -247,7 +265,8 @@ type build struct {
 	aborted   bool
 	completed bool
 
-	spanContext SpanContext
+	spanContext        SpanContext
+	rerunOfSpanContext SpanContext
 }
 
 func newEmptyBuild(conn Conn, lockFactory lock.LockFactory) *build {
@@ -352,6 +371,7 @@ func (b *build) ID() int                      { return b.id }
 func (b *build) Name() string                 { return b.name }
 func (b *build) JobID() int                   { return b.jobID }
 func (b *build) JobName() string              { return b.jobName }
+func (b *build) JobPriority() int             { return b.jobPriority }
 func (b *build) ResourceID() int              { return b.resourceID }
 func (b *build) ResourceName() string         { return b.resourceName }
 func (b *build) ResourceTypeID() int          { return b.resourceTypeID }
@@ -366,21 +386,24 @@ func (b *build) HasPlan() bool                { return string(*b.publicPlan) !=
 func (b *build) IsNewerThanLastCheckOf(input Resource) bool {
 	return b.createTime.After(input.LastCheckEndTime())
 }
-func (b *build) CreateTime() time.Time { return b.createTime }
-func (b *build) StartTime() time.Time  { return b.startTime }
-func (b *build) EndTime() time.Time    { return b.endTime }
-func (b *build) ReapTime() time.Time   { return b.reapTime }
-func (b *build) Status() BuildStatus   { return b.status }
-func (b *build) IsScheduled() bool     { return b.scheduled }
-func (b *build) IsDrained() bool       { return b.drained }
-func (b *build) IsRunning() bool       { return !b.completed }
-func (b *build) IsAborted() bool       { return b.aborted }
-func (b *build) IsCompleted() bool     { return b.completed }
-func (b *build) InputsReady() bool     { return b.inputsReady }
-func (b *build) RerunOf() int          { return b.rerunOf }
-func (b *build) RerunOfName() string   { return b.rerunOfName }
-func (b *build) RerunNumber() int      { return b.rerunNumber }
-func (b *build) CreatedBy() *string    { return b.createdBy }
+func (b *build) CreateTime() time.Time  { return b.createTime }
+func (b *build) StartTime() time.Time   { return b.startTime }
+func (b *build) EndTime() time.Time     { return b.endTime }
+func (b *build) ReapTime() time.Time    { return b.reapTime }
+func (b *build) Status() BuildStatus    { return b.status }
+func (b *build) IsScheduled() bool      { return b.scheduled }
+func (b *build) IsDrained() bool        { return b.drained }
+func (b *build) IsRunning() bool        { return !b.completed }
+func (b *build) IsAborted() bool        { return b.aborted }
+func (b *build) IsCompleted() bool      { return b.completed }
+func (b *build) InputsReady() bool      { return b.inputsReady }
+func (b *build) RerunOf() int           { return b.rerunOf }
+func (b *build) RerunOfName() string    { return b.rerunOfName }
+func (b *build) RerunNumber() int       { return b.rerunNumber }
+func (b *build) CreatedBy() *string     { return b.createdBy }
+func (b *build) IdempotencyKey() string { return b.idempotencyKey }
+
+func (b *build) Trigger() atc.BuildTrigger { return b.trigger }
 
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
@@ -498,6 +521,78 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 	return true, nil
 }
 
+// UpdatePlan replaces the plan with the same ID in the build's plan, e.g.
+// with the substeps of an across step once its values are known.
+func (b *build) UpdatePlan(plan atc.Plan) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var privatePlan, nonce sql.NullString
+	err = psql.Select("private_plan", "nonce").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&privatePlan, &nonce)
+	if err != nil {
+		return err
+	}
+
+	decryptedPlan := []byte(privatePlan.String)
+	if nonce.Valid {
+		decryptedPlan, err = b.conn.EncryptionStrategy().Decrypt(privatePlan.String, &nonce.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	var buildPlan atc.Plan
+	err = json.Unmarshal(decryptedPlan, &buildPlan)
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	buildPlan.Each(func(p *atc.Plan) {
+		if p.ID == plan.ID {
+			*p = plan
+			found = true
+		}
+	})
+
+	if !found {
+		return fmt.Errorf("plan %s not found in build %d", plan.ID, b.id)
+	}
+
+	metadata, err := json.Marshal(buildPlan)
+	if err != nil {
+		return err
+	}
+
+	encryptedPlan, newNonce, err := b.conn.EncryptionStrategy().Encrypt(metadata)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("builds").
+		Set("private_plan", encryptedPlan).
+		Set("public_plan", buildPlan.Public()).
+		Set("nonce", newNonce).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (b *build) start(tx Tx, plan atc.Plan) (bool, error) {
 	metadata, err := json.Marshal(plan)
 	if err != nil {
@@ -541,9 +636,27 @@ func (b *build) start(tx Tx, plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
+	err = enqueueTeamWebhookEvent(tx, b.teamID, b.webhookEvent(atc.TeamWebhookEventBuildStarted, atc.StatusStarted, startTime))
+	if err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
+func (b *build) webhookEvent(eventType string, status atc.BuildStatus, t time.Time) atc.TeamWebhookEvent {
+	return atc.TeamWebhookEvent{
+		Type:                 eventType,
+		Time:                 t.Unix(),
+		PipelineName:         b.pipelineName,
+		PipelineInstanceVars: b.pipelineInstanceVars,
+		JobName:              b.jobName,
+		BuildID:              b.id,
+		BuildName:            b.name,
+		Status:               string(status),
+	}
+}
+
 func (b *build) Finish(status BuildStatus) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -577,6 +690,11 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
+	err = enqueueTeamWebhookEvent(tx, b.teamID, b.webhookEvent(atc.TeamWebhookEventBuildFinished, atc.BuildStatus(status), endTime))
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(fmt.Sprintf(`
 		DROP SEQUENCE %s
 	`, buildEventSeq(b.id)))
@@ -724,6 +842,11 @@ WITH RECURSIVE pipelines_to_archive AS (
 			return err
 		}
 
+		err = requestScheduleOnJobsAfter(tx, b.pipelineID, b.jobName, status)
+		if err != nil {
+			return err
+		}
+
 		err = updateTransitionBuildForJob(tx, b.jobID, b.id, status, b.rerunOf)
 		if err != nil {
 			return err
@@ -774,7 +897,64 @@ func (b *build) Variables(logger lager.Logger, globalSecrets creds.Secrets, varS
 		return nil, errors.New("pipeline not found")
 	}
 
-	return pipeline.Variables(logger, globalSecrets, varSourcePool)
+	pipelineVars, err := pipeline.Variables(logger, globalSecrets, varSourcePool)
+	if err != nil {
+		return nil, err
+	}
+
+	// only job builds are ever given vars; save checks the extra query
+	if b.jobID == 0 {
+		return pipelineVars, nil
+	}
+
+	buildVars, err := b.Vars()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buildVars) == 0 {
+		return pipelineVars, nil
+	}
+
+	// vars given for the build take precedence over the pipeline's
+	return vars.NewMultiVars([]vars.Variables{buildVars, pipelineVars}), nil
+}
+
+// Vars returns the vars the build was created with, e.g. when rerunning a
+// build with overrides.
+func (b *build) Vars() (vars.StaticVariables, error) {
+	var encryptedVars, nonce sql.NullString
+	err := psql.Select("vars", "vars_nonce").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&encryptedVars, &nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if !encryptedVars.Valid {
+		return nil, nil
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedVars, err := b.conn.EncryptionStrategy().Decrypt(encryptedVars.String, noncense)
+	if err != nil {
+		return nil, err
+	}
+
+	var buildVars vars.StaticVariables
+	err = json.Unmarshal(decryptedVars, &buildVars)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildVars, nil
 }
 
 func (b *build) SetDrained(drained bool) error {
@@ -1084,6 +1264,48 @@ func (b *build) Events(from uint) (EventSource, error) {
 	), nil
 }
 
+// StepEvents returns the events saved so far for the build, leaving out its
+// logs. Unlike Events, it returns right away rather than waiting for more
+// events until the build completes.
+func (b *build) StepEvents() ([]event.Envelope, error) {
+	rows, err := psql.Select("event_id", "type", "version", "payload").
+		From(b.eventsTable()).
+		Where(sq.Or{
+			sq.Eq{"build_id": b.id},
+			sq.Eq{"build_id_old": b.id},
+		}).
+		Where(sq.NotEq{"type": string(event.EventTypeLog)}).
+		OrderBy("event_id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var events []event.Envelope
+	for rows.Next() {
+		var id int
+		var t, v, p string
+		err := rows.Scan(&id, &t, &v, &p)
+		if err != nil {
+			return nil, err
+		}
+
+		data := json.RawMessage(p)
+
+		events = append(events, event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+			EventID: strconv.Itoa(id),
+		})
+	}
+
+	return events, nil
+}
+
 func (b *build) SaveEvent(event atc.Event) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -1260,6 +1482,123 @@ func (b *build) SaveOutput(
 	return nil
 }
 
+// SaveInput records the version of the resource that the build fetched as its
+// named input, in place of the version chosen when the build was scheduled,
+// e.g. when the build fetched the version found by a check step.
+func (b *build) SaveInput(inputName string, resourceName string, version atc.Version) error {
+	theResource, err := b.inputResource(resourceName)
+	if err != nil {
+		return err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("build_resource_config_version_inputs").
+		Where(sq.Eq{
+			"build_id": b.id,
+			"name":     inputName,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = b.insertInput(tx, inputName, theResource.ID(), version)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SaveIteratedInputs records the versions of the resource iterated over by
+// the build's across steps as inputs named after the resource, alongside the
+// inputs it was scheduled with. Once the build succeeds, the versions count
+// as having been used by it, so that later builds iterate over newer ones.
+func (b *build) SaveIteratedInputs(resourceName string, versions []atc.Version) error {
+	theResource, err := b.inputResource(resourceName)
+	if err != nil {
+		return err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	for _, version := range versions {
+		err = b.insertInput(tx, resourceName, theResource.ID(), version)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (b *build) inputResource(resourceName string) (Resource, error) {
+	if b.pipelineID == 0 {
+		return nil, ErrBuildHasNoPipeline
+	}
+
+	pipeline, found, err := b.Pipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrBuildHasNoPipeline
+	}
+
+	theResource, found, err := pipeline.Resource(resourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ResourceNotFoundInPipeline{resourceName, b.pipelineName}
+	}
+
+	return theResource, nil
+}
+
+func (b *build) insertInput(tx Tx, inputName string, resourceID int, version atc.Version) error {
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("build_resource_config_version_inputs").
+		Columns("resource_id", "build_id", "version_md5", "name", "first_occurrence").
+		Values(
+			resourceID,
+			b.id,
+			sq.Expr("md5(?)", string(versionJSON)),
+			inputName,
+			sq.Expr(`NOT EXISTS (
+				SELECT 1
+				FROM build_resource_config_version_inputs i
+				JOIN builds b ON b.id = i.build_id
+				WHERE b.job_id = ?
+				AND i.resource_id = ?
+				AND i.version_md5 = md5(?)
+				AND i.build_id != ?
+			)`, b.jobID, resourceID, string(versionJSON), b.id),
+		).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(tx).
+		Exec()
+	return err
+}
+
 func (b *build) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -1293,12 +1632,16 @@ func (b *build) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 		return nil, false, err
 	}
 
+	// inputs given explicitly when the build was triggered take the place of
+	// the ones chosen by the scheduler
 	rows, err := psql.Insert("build_resource_config_version_inputs").
 		Columns("resource_id", "version_md5", "name", "first_occurrence", "build_id").
 		Select(psql.Select("i.resource_id", "i.version_md5", "i.input_name", "i.first_occurrence").
 			Column("?", b.id).
 			From("next_build_inputs i").
-			Where(sq.Eq{"i.job_id": b.jobID})).
+			Where(sq.Eq{"i.job_id": b.jobID}).
+			Where(sq.Expr("NOT EXISTS (SELECT 1 FROM build_explicit_inputs e WHERE e.build_id = ? AND e.input_name = i.input_name)", b.id)).
+			Suffix("UNION ALL SELECT e.resource_id, e.version_md5, e.input_name, false, ? FROM build_explicit_inputs e WHERE e.build_id = ?", b.id, b.id)).
 		Suffix("ON CONFLICT (build_id, resource_id, version_md5, name) DO UPDATE SET first_occurrence = EXCLUDED.first_occurrence").
 		Suffix("RETURNING name, resource_id, version_md5, first_occurrence").
 		RunWith(tx).
@@ -1704,6 +2047,12 @@ func (b *build) SpanContext() propagation.TextMapCarrier {
 	return b.spanContext
 }
 
+// RerunOfSpanContext returns the span context of the build this build is a
+// rerun of, so that the two traces can be linked.
+func (b *build) RerunOfSpanContext() propagation.TextMapCarrier {
+	return b.rerunOfSpanContext
+}
+
 func (b *build) SavePipeline(
 	pipelineRef atc.PipelineRef,
 	teamID int,
@@ -1745,6 +2094,13 @@ func (b *build) SavePipeline(
 	return pipeline, isNewPipeline, nil
 }
 
+func newNullString(s string) sql.NullString {
+	return sql.NullString{
+		Valid:  s != "",
+		String: s,
+	}
+}
+
 func newNullInt64(i int) sql.NullInt64 {
 	return sql.NullInt64{
 		Valid: true,
@@ -1765,10 +2121,10 @@ func buildEventSeq(buildid int) string {
 
 func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy) error {
 	var (
-		jobID, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber                                 sql.NullInt64
+		jobID, jobPriority, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber                    sql.NullInt64
 		schema, privatePlan, jobName, resourceName, resourceTypeName, pipelineName, publicPlan, rerunOfName sql.NullString
 		createTime, startTime, endTime, reapTime                                                            pq.NullTime
-		nonce, spanContext, createdBy                                                                       sql.NullString
+		nonce, spanContext, rerunOfSpanContext, createdBy, idempotencyKey, triggerType                      sql.NullString
 		drained, aborted, completed                                                                         bool
 		status                                                                                              string
 		pipelineInstanceVars                                                                                sql.NullString
@@ -1793,6 +2149,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&endTime,
 		&reapTime,
 		&jobName,
+		&jobPriority,
 		&resourceName,
 		&resourceTypeName,
 		&pipelineID,
@@ -1808,6 +2165,9 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&rerunOfName,
 		&rerunNumber,
 		&spanContext,
+		&rerunOfSpanContext,
+		&idempotencyKey,
+		&triggerType,
 	)
 	if err != nil {
 		return err
@@ -1816,6 +2176,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.status = BuildStatus(status)
 	b.jobID = int(jobID.Int64)
 	b.jobName = jobName.String
+	b.jobPriority = int(jobPriority.Int64)
 	b.resourceID = int(resourceID.Int64)
 	b.resourceName = resourceName.String
 	b.resourceTypeID = int(resourceTypeID.Int64)
@@ -1833,6 +2194,16 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.rerunOf = int(rerunOf.Int64)
 	b.rerunOfName = rerunOfName.String
 	b.rerunNumber = int(rerunNumber.Int64)
+	b.idempotencyKey = idempotencyKey.String
+
+	if triggerType.Valid {
+		b.trigger = atc.BuildTrigger(triggerType.String)
+	} else if b.isManuallyTriggered || createdBy.Valid {
+		// builds created before their trigger was recorded
+		b.trigger = atc.BuildTriggerManual
+	} else {
+		b.trigger = atc.BuildTriggerAutomatic
+	}
 
 	var (
 		noncense      *string
@@ -1870,6 +2241,13 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		}
 	}
 
+	if rerunOfSpanContext.Valid {
+		err = json.Unmarshal([]byte(rerunOfSpanContext.String), &b.rerunOfSpanContext)
+		if err != nil {
+			return err
+		}
+	}
+
 	if pipelineInstanceVars.Valid {
 		err = json.Unmarshal([]byte(pipelineInstanceVars.String), &b.pipelineInstanceVars)
 		if err != nil {
@@ -1929,6 +2307,9 @@ func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
 		QueryRow().
 		Scan(&buildID)
 	if err != nil {
+		if isIdempotencyKeyConflict(err) {
+			return ErrIdempotencyKeyConflict
+		}
 		return err
 	}
 
@@ -1952,6 +2333,7 @@ type startedBuildArgs struct {
 	Plan              atc.Plan
 	ManuallyTriggered bool
 	SpanContext       SpanContext
+	IdempotencyKey    string
 	ExtraValues       map[string]interface{}
 }
 
@@ -1985,6 +2367,10 @@ func createStartedBuild(tx Tx, build *build, args startedBuildArgs) error {
 	buildVals["start_time"] = sq.Expr("now()")
 	buildVals["schema"] = schema
 
+	if args.IdempotencyKey != "" {
+		buildVals["idempotency_key"] = args.IdempotencyKey
+	}
+
 	for name, value := range args.ExtraValues {
 		buildVals[name] = value
 	}
@@ -4,17 +4,19 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
 )
 
 //counterfeiter:generate . BuildFactory
 type BuildFactory interface {
 	Build(int) (Build, bool, error)
-	VisibleBuilds([]string, Page) ([]Build, Pagination, error)
-	AllBuilds(Page) ([]Build, Pagination, error)
+	VisibleBuilds([]string, Page, atc.BuildFilter) ([]Build, Pagination, error)
+	AllBuilds(Page, atc.BuildFilter) ([]Build, Pagination, error)
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
 	GetDrainableBuilds() ([]Build, error)
@@ -22,6 +24,119 @@ type BuildFactory interface {
 	MarkNonInterceptibleBuilds() error
 }
 
+func applyBuildFilter(query sq.SelectBuilder, filter atc.BuildFilter) sq.SelectBuilder {
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+
+		query = query.Where(sq.Eq{"b.status": statuses})
+	}
+
+	if filter.Pipeline != "" {
+		query = query.Where(sq.Expr("p.name LIKE ?", globToLikePattern(filter.Pipeline)))
+	}
+
+	if filter.Job != "" {
+		query = query.Where(sq.Expr("j.name LIKE ?", globToLikePattern(filter.Job)))
+	}
+
+	if filter.MinDuration > 0 {
+		query = query.Where(sq.Expr(
+			"COALESCE(b.end_time, now()) - b.start_time >= make_interval(secs => ?)",
+			filter.MinDuration.Seconds(),
+		))
+	}
+
+	if filter.Worker != "" {
+		query = query.Where(sq.Expr(
+			"EXISTS (SELECT 1 FROM containers c WHERE c.build_id = b.id AND c.worker_name = ?)",
+			filter.Worker,
+		))
+	}
+
+	return query
+}
+
+// globToLikePattern converts a glob using * and ? into a LIKE pattern.
+func globToLikePattern(glob string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		"%", `\%`,
+		"_", `\_`,
+		"*", "%",
+		"?", "_",
+	).Replace(glob)
+}
+
+// buildSortOrders maps each sort field to the expression it orders by and
+// whether the largest values come first.
+var buildSortOrders = map[atc.BuildSortField]struct {
+	expr string
+	desc bool
+}{
+	atc.BuildSortByID:       {"b.id", true},
+	atc.BuildSortByName:     {"concat_ws('/', p.name, j.name, r.name, b.name)", false},
+	atc.BuildSortByStatus:   {"b.status::text", false},
+	atc.BuildSortByStart:    {"b.start_time", true},
+	atc.BuildSortByEnd:      {"b.end_time", true},
+	atc.BuildSortByDuration: {"COALESCE(b.end_time, now()) - b.start_time", true},
+}
+
+// getSortedBuilds lists the first page of builds in the filter's sort order.
+// The page's bounds still narrow down the builds, but as the order isn't by
+// id the result can't be paginated any further.
+func getSortedBuilds(buildsQuery sq.SelectBuilder, filter atc.BuildFilter, page Page, conn Conn, lockFactory lock.LockFactory) ([]Build, Pagination, error) {
+	sortBy := filter.SortBy
+	if sortBy == "" {
+		sortBy = atc.BuildSortByID
+	}
+
+	order, found := buildSortOrders[sortBy]
+	if !found {
+		return nil, Pagination{}, fmt.Errorf("unknown sort field '%s'", sortBy)
+	}
+
+	direction := "ASC NULLS FIRST"
+	tieBreak := "b.id DESC"
+	if order.desc != filter.Reverse {
+		direction = "DESC NULLS LAST"
+	}
+	if filter.Reverse {
+		tieBreak = "b.id ASC"
+	}
+
+	if page.From != nil {
+		if page.UseDate {
+			buildsQuery = buildsQuery.Where(sq.Expr("b.start_time >= to_timestamp(?)", *page.From))
+		} else {
+			buildsQuery = buildsQuery.Where(sq.GtOrEq{"b.id": *page.From})
+		}
+	}
+
+	if page.To != nil {
+		if page.UseDate {
+			buildsQuery = buildsQuery.Where(sq.Expr("b.start_time <= to_timestamp(?)", *page.To))
+		} else {
+			buildsQuery = buildsQuery.Where(sq.LtOrEq{"b.id": *page.To})
+		}
+	}
+
+	builds, err := getBuilds(
+		buildsQuery.
+			OrderBy(order.expr+" "+direction, tieBreak).
+			Limit(uint64(page.Limit)),
+		conn,
+		lockFactory,
+	)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return builds, Pagination{}, nil
+}
+
 type buildFactory struct {
 	conn              Conn
 	lockFactory       lock.LockFactory
@@ -56,13 +171,17 @@ func (f *buildFactory) Build(buildID int) (Build, bool, error) {
 	return build, true, nil
 }
 
-func (f *buildFactory) VisibleBuilds(teamNames []string, page Page) ([]Build, Pagination, error) {
-	newBuildsQuery := buildsQuery.
+func (f *buildFactory) VisibleBuilds(teamNames []string, page Page, filter atc.BuildFilter) ([]Build, Pagination, error) {
+	newBuildsQuery := applyBuildFilter(buildsQuery, filter).
 		Where(sq.Or{
 			sq.Eq{"p.public": true},
 			sq.Eq{"t.name": teamNames},
 		})
 
+	if filter.Sorted() {
+		return getSortedBuilds(newBuildsQuery, filter, page, f.conn, f.lockFactory)
+	}
+
 	if page.UseDate {
 		return getBuildsWithDates(newBuildsQuery, minMaxIdQuery, page, f.conn,
 			f.lockFactory)
@@ -71,12 +190,18 @@ func (f *buildFactory) VisibleBuilds(teamNames []string, page Page) ([]Build, Pa
 		f.lockFactory)
 }
 
-func (f *buildFactory) AllBuilds(page Page) ([]Build, Pagination, error) {
+func (f *buildFactory) AllBuilds(page Page, filter atc.BuildFilter) ([]Build, Pagination, error) {
+	newBuildsQuery := applyBuildFilter(buildsQuery, filter)
+
+	if filter.Sorted() {
+		return getSortedBuilds(newBuildsQuery, filter, page, f.conn, f.lockFactory)
+	}
+
 	if page.UseDate {
-		return getBuildsWithDates(buildsQuery, minMaxIdQuery, page, f.conn,
+		return getBuildsWithDates(newBuildsQuery, minMaxIdQuery, page, f.conn,
 			f.lockFactory)
 	}
-	return getBuildsWithPagination(buildsQuery, minMaxIdQuery,
+	return getBuildsWithPagination(newBuildsQuery, minMaxIdQuery,
 		page, f.conn, f.lockFactory)
 }
 
@@ -13,7 +13,7 @@ import (
 
 var _ = Describe("BuildFactory", func() {
 	var (
-		team      db.Team
+		team db.Team
 	)
 
 	BeforeEach(func() {
@@ -286,7 +286,7 @@ var _ = Describe("BuildFactory", func() {
 		})
 
 		It("returns visible builds for the given teams", func() {
-			builds, _, err := buildFactory.VisibleBuilds([]string{"some-team"}, db.Page{Limit: 10})
+			builds, _, err := buildFactory.VisibleBuilds([]string{"some-team"}, db.Page{Limit: 10}, atc.BuildFilter{})
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(builds).To(HaveLen(4))
@@ -342,12 +342,98 @@ var _ = Describe("BuildFactory", func() {
 		})
 
 		It("returns all builds from all teams private and public pipelines", func() {
-			builds, _, err := buildFactory.AllBuilds(db.Page{Limit: 10})
+			builds, _, err := buildFactory.AllBuilds(db.Page{Limit: 10}, atc.BuildFilter{})
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(builds).To(HaveLen(4))
 			Expect(builds).To(ConsistOf(build1, build2, build3, build4))
 		})
+
+		Context("with a filter", func() {
+			buildIDs := func(builds []db.Build) []int {
+				ids := []int{}
+				for _, build := range builds {
+					ids = append(ids, build.ID())
+				}
+				return ids
+			}
+
+			BeforeEach(func() {
+				err = build2.Finish(db.BuildStatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build3.Finish(db.BuildStatusErrored)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("filters by status", func() {
+				builds, _, err := buildFactory.AllBuilds(db.Page{Limit: 10}, atc.BuildFilter{
+					Statuses: []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buildIDs(builds)).To(ConsistOf(build2.ID(), build3.ID()))
+			})
+
+			It("filters by pipeline and job globs", func() {
+				builds, _, err := buildFactory.AllBuilds(db.Page{Limit: 10}, atc.BuildFilter{
+					Pipeline: "pub*-pipeline",
+					Job:      "some-?ob",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buildIDs(builds)).To(ConsistOf(build3.ID()))
+			})
+
+			It("matches '_' in globs literally", func() {
+				builds, _, err := buildFactory.AllBuilds(db.Page{Limit: 10}, atc.BuildFilter{
+					Pipeline: "public_pipeline",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+
+			It("filters by duration", func() {
+				_, err = dbConn.Exec(`UPDATE builds SET start_time = end_time - interval '1 hour' WHERE id = $1`, build2.ID())
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = dbConn.Exec(`UPDATE builds SET start_time = end_time - interval '1 minute' WHERE id = $1`, build3.ID())
+				Expect(err).NotTo(HaveOccurred())
+
+				builds, _, err := buildFactory.AllBuilds(db.Page{Limit: 10}, atc.BuildFilter{
+					MinDuration: 30 * time.Minute,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buildIDs(builds)).To(ConsistOf(build2.ID()))
+			})
+
+			Context("when sorting", func() {
+				BeforeEach(func() {
+					_, err = dbConn.Exec(`UPDATE builds SET start_time = end_time - interval '1 minute' WHERE id = $1`, build2.ID())
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = dbConn.Exec(`UPDATE builds SET start_time = end_time - interval '1 hour' WHERE id = $1`, build3.ID())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("sorts all builds before limiting them", func() {
+					builds, pagination, err := buildFactory.AllBuilds(db.Page{Limit: 1}, atc.BuildFilter{
+						SortBy: atc.BuildSortByDuration,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(buildIDs(builds)).To(Equal([]int{build3.ID()}))
+					Expect(pagination).To(Equal(db.Pagination{}))
+				})
+
+				It("sorts in reverse", func() {
+					builds, _, err := buildFactory.AllBuilds(db.Page{Limit: 10}, atc.BuildFilter{
+						Statuses: []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored},
+						SortBy:   atc.BuildSortByDuration,
+						Reverse:  true,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(buildIDs(builds)).To(Equal([]int{build2.ID(), build3.ID()}))
+				})
+			})
+		})
 	})
 
 	Describe("PublicBuilds", func() {
@@ -536,7 +622,7 @@ var _ = Describe("BuildFactory", func() {
 				To:      db.NewIntPtr(int(time.Now().Unix() + 10)),
 				UseDate: true,
 			}
-			builds, _, err := buildFactory.AllBuilds(page)
+			builds, _, err := buildFactory.AllBuilds(page, atc.BuildFilter{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(len(builds)).To(Equal(2))
 		})
@@ -548,7 +634,7 @@ var _ = Describe("BuildFactory", func() {
 					From:    db.NewIntPtr(int(time.Now().Unix() + 10)),
 					UseDate: true,
 				}
-				builds, _, err := buildFactory.AllBuilds(page)
+				builds, _, err := buildFactory.AllBuilds(page, atc.BuildFilter{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(builds)).To(Equal(0))
 			})
@@ -561,7 +647,7 @@ var _ = Describe("BuildFactory", func() {
 					To:      db.NewIntPtr(int(time.Now().Unix() - 10000)),
 					UseDate: true,
 				}
-				builds, _, err := buildFactory.AllBuilds(page)
+				builds, _, err := buildFactory.AllBuilds(page, atc.BuildFilter{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(builds)).To(Equal(0))
 			})
@@ -440,6 +440,70 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("UpdatePlan", func() {
+		var plan atc.Plan
+
+		BeforeEach(func() {
+			plan = atc.Plan{
+				ID: "some-do",
+				Do: &atc.DoPlan{
+					{
+						ID: "some-across",
+						Across: &atc.AcrossPlan{
+							SubStepTemplate: &atc.Plan{
+								ID:   "some-task",
+								Task: &atc.TaskPlan{Name: "some-task"},
+							},
+						},
+					},
+				},
+			}
+
+			started, err := build.Start(plan)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		It("replaces the plan with the same ID in the private and public plans", func() {
+			expanded := atc.Plan{
+				ID: "some-across",
+				Across: &atc.AcrossPlan{
+					Vars: []atc.AcrossVar{{Var: "v", Values: []interface{}{"a"}}},
+					Steps: []atc.VarScopedPlan{
+						{
+							Step: atc.Plan{
+								ID:   "some-task/0",
+								Task: &atc.TaskPlan{Name: "some-task"},
+							},
+							Values: []interface{}{"a"},
+						},
+					},
+				},
+			}
+
+			err := build.UpdatePlan(expanded)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedPlan := atc.Plan{
+				ID: "some-do",
+				Do: &atc.DoPlan{expanded},
+			}
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.PrivatePlan()).To(Equal(expectedPlan))
+			Expect(build.PublicPlan()).To(Equal(expectedPlan.Public()))
+		})
+
+		Context("when the plan is not in the build's plan", func() {
+			It("errors", func() {
+				err := build.UpdatePlan(atc.Plan{ID: "bogus", Task: &atc.TaskPlan{}})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("Finish", func() {
 		var scenario *dbtest.Scenario
 		var build db.Build
@@ -511,6 +575,14 @@ var _ = Describe("Build", func() {
 							},
 						},
 					},
+					{
+						Name:  "after-job",
+						After: []atc.JobAfterConfig{{Job: "some-job"}},
+					},
+					{
+						Name:  "after-success-job",
+						After: []atc.JobAfterConfig{{Job: "some-job", SucceededOnly: true}},
+					},
 				},
 				Resources: atc.ResourceConfigs{
 					{
@@ -821,6 +893,34 @@ var _ = Describe("Build", func() {
 
 				Expect(noRequestJob.ScheduleRequestedTime()).Should(BeTemporally("==", requestedSchedule))
 			})
+
+			It("records the finished build on the jobs that run after it", func() {
+				newBuild, err := scenario.Job("some-job").CreateBuild(defaultBuildCreatedBy)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = newBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, name := range []string{"after-job", "after-success-job"} {
+					afterJob := scenario.Job(name)
+					Expect(afterJob.AfterFinishedTime()).Should(BeTemporally(">", afterJob.AfterTriggeredTime()))
+					Expect(afterJob.ScheduleRequestedTime()).Should(BeTemporally("~", time.Now(), time.Second))
+				}
+			})
+
+			It("only records failed builds on the jobs that run after any build", func() {
+				newBuild, err := scenario.Job("some-job").CreateBuild(defaultBuildCreatedBy)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = newBuild.Finish(db.BuildStatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+
+				afterJob := scenario.Job("after-job")
+				Expect(afterJob.AfterFinishedTime()).Should(BeTemporally(">", afterJob.AfterTriggeredTime()))
+
+				afterSuccessJob := scenario.Job("after-success-job")
+				Expect(afterSuccessJob.AfterFinishedTime()).Should(BeTemporally("==", afterSuccessJob.AfterTriggeredTime()))
+			})
 		})
 
 		Context("archiving pipelines", func() {
@@ -982,6 +1082,24 @@ var _ = Describe("Build", func() {
 				Expect(found).To(BeTrue())
 				Expect(val).To(Equal("caz"))
 			})
+
+			Context("when the build was rerun with vars", func() {
+				BeforeEach(func() {
+					var err error
+					build, err = defaultJob.RerunBuildWithVars(build, defaultBuildCreatedBy, vars.StaticVariables{"foo": "override"})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("prefers the build's vars", func() {
+					v, err := build.Variables(logger, globalSecrets, varSourcePool)
+					Expect(err).ToNot(HaveOccurred())
+
+					val, found, err := v.Get(vars.Reference{Path: "foo"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(val).To(Equal("override"))
+				})
+			})
 		})
 	})
 
@@ -1361,6 +1479,71 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SaveInput", func() {
+		var scenario *dbtest.Scenario
+		var build db.Build
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name:     "some-input",
+										Resource: "some-resource",
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   dbtest.BaseResourceType,
+							Source: atc.Source{"some": "source"},
+						},
+					},
+				}),
+				builder.WithResourceVersions(
+					"some-resource",
+					atc.Version{"ver": "1"},
+					atc.Version{"ver": "2"},
+				),
+				builder.WithJobBuild(&build, "some-job", dbtest.JobInputs{
+					{
+						Name:            "some-input",
+						Version:         atc.Version{"ver": "1"},
+						FirstOccurrence: true,
+					},
+				}, dbtest.JobOutputs{}),
+			)
+		})
+
+		It("replaces the input with the fetched version", func() {
+			err := build.SaveInput("some-input", "some-resource", atc.Version{"ver": "2"})
+			Expect(err).ToNot(HaveOccurred())
+
+			inputs, _, err := build.Resources()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inputs).To(ConsistOf(db.BuildInput{
+				Name:            "some-input",
+				Version:         atc.Version{"ver": "2"},
+				ResourceID:      scenario.Resource("some-resource").ID(),
+				FirstOccurrence: true,
+			}))
+		})
+
+		Context("when the resource is not in the pipeline", func() {
+			It("errors", func() {
+				err := build.SaveInput("some-input", "bogus-resource", atc.Version{"ver": "2"})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("Resources", func() {
 		var (
 			scenario      *dbtest.Scenario
@@ -1678,7 +1861,7 @@ var _ = Describe("Build", func() {
 
 				Context("when pipeline is paused", func() {
 					BeforeEach(func() {
-						err := scenario.Pipeline.Pause()
+						err := scenario.Pipeline.Pause("", "")
 						Expect(err).NotTo(HaveOccurred())
 
 						expectedBuildPrep.PausedPipeline = db.BuildPreparationStatusBlocking
@@ -1694,7 +1877,7 @@ var _ = Describe("Build", func() {
 
 				Context("when job is paused", func() {
 					BeforeEach(func() {
-						err := scenario.Job("some-job").Pause()
+						err := scenario.Job("some-job").Pause("", "")
 						Expect(err).NotTo(HaveOccurred())
 
 						expectedBuildPrep.PausedJob = db.BuildPreparationStatusBlocking
@@ -2107,6 +2290,56 @@ var _ = Describe("Build", func() {
 				})
 			})
 		})
+
+		Describe("adopting inputs for a build with explicit inputs", func() {
+			var explicitBuild db.Build
+
+			BeforeEach(func() {
+				var err error
+				explicitBuild, err = scenario.Job("downstream-job").CreateBuildWithExplicitInputs(defaultBuildCreatedBy, "", []db.ExplicitBuildInput{
+					{
+						Name:       "some-input",
+						ResourceID: scenario.Resource("some-resource").ID(),
+						Version:    atc.Version{"version": "v1"},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				scenario.Run(
+					builder.WithNextInputMapping("downstream-job", dbtest.JobInputs{
+						{
+							Name:    "some-input",
+							Version: atc.Version{"version": "v3"},
+						},
+						{
+							Name:            "some-other-input",
+							Version:         atc.Version{"version": "v1"},
+							FirstOccurrence: true,
+						},
+					}),
+				)
+			})
+
+			It("uses the explicit versions in place of the next inputs", func() {
+				inputs, adopted, err := explicitBuild.AdoptInputsAndPipes()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(adopted).To(BeTrue())
+				Expect(inputs).To(ConsistOf([]db.BuildInput{
+					{
+						Name:            "some-input",
+						ResourceID:      scenario.Resource("some-resource").ID(),
+						Version:         atc.Version{"version": "v1"},
+						FirstOccurrence: false,
+					},
+					{
+						Name:            "some-other-input",
+						ResourceID:      scenario.Resource("some-other-resource").ID(),
+						Version:         atc.Version{"version": "v1"},
+						FirstOccurrence: true,
+					},
+				}))
+			})
+		})
 	})
 
 	Describe("AdoptRerunInputsAndPipes", func() {
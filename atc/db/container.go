@@ -19,6 +19,7 @@ type Container interface {
 	Handle() string
 	WorkerName() string
 	Metadata() ContainerMetadata
+	CreatedAt() time.Time
 }
 
 //counterfeiter:generate . CreatingContainer
@@ -34,6 +35,7 @@ type creatingContainer struct {
 	handle     string
 	workerName string
 	metadata   ContainerMetadata
+	createdAt  time.Time
 	conn       Conn
 }
 
@@ -42,6 +44,7 @@ func newCreatingContainer(
 	handle string,
 	workerName string,
 	metadata ContainerMetadata,
+	createdAt time.Time,
 	conn Conn,
 ) *creatingContainer {
 	return &creatingContainer{
@@ -49,6 +52,7 @@ func newCreatingContainer(
 		handle:     handle,
 		workerName: workerName,
 		metadata:   metadata,
+		createdAt:  createdAt,
 		conn:       conn,
 	}
 }
@@ -58,6 +62,7 @@ func (container *creatingContainer) State() string               { return atc.Co
 func (container *creatingContainer) Handle() string              { return container.handle }
 func (container *creatingContainer) WorkerName() string          { return container.workerName }
 func (container *creatingContainer) Metadata() ContainerMetadata { return container.metadata }
+func (container *creatingContainer) CreatedAt() time.Time        { return container.createdAt }
 
 func (container *creatingContainer) Created() (CreatedContainer, error) {
 	rows, err := psql.Update("containers").
@@ -89,7 +94,9 @@ func (container *creatingContainer) Created() (CreatedContainer, error) {
 		container.handle,
 		container.workerName,
 		container.metadata,
+		container.createdAt,
 		time.Time{},
+		0,
 		container.conn,
 	), nil
 }
@@ -124,6 +131,7 @@ func (container *creatingContainer) Failed() (FailedContainer, error) {
 		container.handle,
 		container.workerName,
 		container.metadata,
+		container.createdAt,
 		container.conn,
 	), nil
 }
@@ -135,6 +143,10 @@ type CreatedContainer interface {
 	Destroying() (DestroyingContainer, error)
 	LastHijack() time.Time
 	UpdateLastHijack() error
+
+	// SizeBytes is the size last reported by the container's worker, or 0 if
+	// it hasn't been reported yet.
+	SizeBytes() uint64
 }
 
 type createdContainer struct {
@@ -142,8 +154,10 @@ type createdContainer struct {
 	handle     string
 	workerName string
 	metadata   ContainerMetadata
+	createdAt  time.Time
 
 	lastHijack time.Time
+	sizeBytes  uint64
 
 	conn Conn
 }
@@ -153,7 +167,9 @@ func newCreatedContainer(
 	handle string,
 	workerName string,
 	metadata ContainerMetadata,
+	createdAt time.Time,
 	lastHijack time.Time,
+	sizeBytes uint64,
 	conn Conn,
 ) *createdContainer {
 	return &createdContainer{
@@ -161,7 +177,9 @@ func newCreatedContainer(
 		handle:     handle,
 		workerName: workerName,
 		metadata:   metadata,
+		createdAt:  createdAt,
 		lastHijack: lastHijack,
+		sizeBytes:  sizeBytes,
 		conn:       conn,
 	}
 }
@@ -171,8 +189,10 @@ func (container *createdContainer) State() string               { return atc.Con
 func (container *createdContainer) Handle() string              { return container.handle }
 func (container *createdContainer) WorkerName() string          { return container.workerName }
 func (container *createdContainer) Metadata() ContainerMetadata { return container.metadata }
+func (container *createdContainer) CreatedAt() time.Time        { return container.createdAt }
 
 func (container *createdContainer) LastHijack() time.Time { return container.lastHijack }
+func (container *createdContainer) SizeBytes() uint64     { return container.sizeBytes }
 
 func (container *createdContainer) Destroying() (DestroyingContainer, error) {
 
@@ -205,6 +225,7 @@ func (container *createdContainer) Destroying() (DestroyingContainer, error) {
 		container.handle,
 		container.workerName,
 		container.metadata,
+		container.createdAt,
 		container.conn,
 	), nil
 }
@@ -247,6 +268,7 @@ type destroyingContainer struct {
 	handle     string
 	workerName string
 	metadata   ContainerMetadata
+	createdAt  time.Time
 
 	conn Conn
 }
@@ -256,6 +278,7 @@ func newDestroyingContainer(
 	handle string,
 	workerName string,
 	metadata ContainerMetadata,
+	createdAt time.Time,
 	conn Conn,
 ) *destroyingContainer {
 	return &destroyingContainer{
@@ -263,6 +286,7 @@ func newDestroyingContainer(
 		handle:     handle,
 		workerName: workerName,
 		metadata:   metadata,
+		createdAt:  createdAt,
 		conn:       conn,
 	}
 }
@@ -272,6 +296,7 @@ func (container *destroyingContainer) State() string               { return atc.
 func (container *destroyingContainer) Handle() string              { return container.handle }
 func (container *destroyingContainer) WorkerName() string          { return container.workerName }
 func (container *destroyingContainer) Metadata() ContainerMetadata { return container.metadata }
+func (container *destroyingContainer) CreatedAt() time.Time        { return container.createdAt }
 
 func (container *destroyingContainer) Destroy() (bool, error) {
 	rows, err := psql.Delete("containers").
@@ -309,6 +334,7 @@ type failedContainer struct {
 	handle     string
 	workerName string
 	metadata   ContainerMetadata
+	createdAt  time.Time
 	conn       Conn
 }
 
@@ -317,6 +343,7 @@ func newFailedContainer(
 	handle string,
 	workerName string,
 	metadata ContainerMetadata,
+	createdAt time.Time,
 	conn Conn,
 ) *failedContainer {
 	return &failedContainer{
@@ -324,6 +351,7 @@ func newFailedContainer(
 		handle:     handle,
 		workerName: workerName,
 		metadata:   metadata,
+		createdAt:  createdAt,
 		conn:       conn,
 	}
 }
@@ -333,6 +361,7 @@ func (container *failedContainer) State() string               { return atc.Cont
 func (container *failedContainer) Handle() string              { return container.handle }
 func (container *failedContainer) WorkerName() string          { return container.workerName }
 func (container *failedContainer) Metadata() ContainerMetadata { return container.metadata }
+func (container *failedContainer) CreatedAt() time.Time        { return container.createdAt }
 
 func (container *failedContainer) Destroy() (bool, error) {
 	rows, err := psql.Delete("containers").
@@ -18,6 +18,10 @@ type ContainerRepository interface {
 	UpdateContainersMissingSince(workerName string, handles []string) error
 	RemoveMissingContainers(time.Duration) (int, error)
 	DestroyUnknownContainers(workerName string, reportedHandles []string) (int, error)
+
+	FindInterceptSession(handle string, name string) (InterceptSession, bool, error)
+	SaveInterceptSession(handle string, name string, processID string, ttl time.Duration) error
+	DeleteInterceptSession(handle string, processID string) error
 }
 
 type containerRepository struct {
@@ -220,31 +224,44 @@ func (repository *containerRepository) RemoveDestroyingContainers(workerName str
 	return int(affected), nil
 }
 
+// orphanedContainers matches containers (c) which are no longer used by their
+// owner. It expects their build (b) and image containers (icc, igc) to be
+// joined.
+var orphanedContainers = sq.Or{
+	sq.Eq{
+		"c.build_id":                         nil,
+		"c.image_check_container_id":         nil,
+		"c.image_get_container_id":           nil,
+		"c.resource_config_check_session_id": nil,
+	},
+	sq.And{
+		sq.NotEq{"c.build_id": nil},
+		sq.Eq{"b.interceptible": false},
+	},
+	sq.And{
+		sq.NotEq{"c.image_check_container_id": nil},
+		sq.NotEq{"icc.state": atc.ContainerStateCreating},
+	},
+	sq.And{
+		sq.NotEq{"c.image_get_container_id": nil},
+		sq.NotEq{"igc.state": atc.ContainerStateCreating},
+	},
+}
+
+// notIntercepted excludes containers with a live intercept session.
+var notIntercepted = sq.Expr(`NOT EXISTS (
+	SELECT 1 FROM intercept_sessions s
+	WHERE s.container_id = c.id
+	AND s.expires_at > now()
+)`)
+
 func (repository *containerRepository) FindOrphanedContainers() ([]CreatingContainer, []CreatedContainer, []DestroyingContainer, error) {
 	query, args, err := selectContainers("c").
 		LeftJoin("builds b ON b.id = c.build_id").
 		LeftJoin("containers icc ON icc.id = c.image_check_container_id").
 		LeftJoin("containers igc ON igc.id = c.image_get_container_id").
-		Where(sq.Or{
-			sq.Eq{
-				"c.build_id":                         nil,
-				"c.image_check_container_id":         nil,
-				"c.image_get_container_id":           nil,
-				"c.resource_config_check_session_id": nil,
-			},
-			sq.And{
-				sq.NotEq{"c.build_id": nil},
-				sq.Eq{"b.interceptible": false},
-			},
-			sq.And{
-				sq.NotEq{"c.image_check_container_id": nil},
-				sq.NotEq{"icc.state": atc.ContainerStateCreating},
-			},
-			sq.And{
-				sq.NotEq{"c.image_get_container_id": nil},
-				sq.NotEq{"igc.state": atc.ContainerStateCreating},
-			},
-		}).
+		Where(orphanedContainers).
+		Where(notIntercepted).
 		ToSql()
 	if err != nil {
 		return nil, nil, nil, err
@@ -295,7 +312,7 @@ func (repository *containerRepository) FindOrphanedContainers() ([]CreatingConta
 }
 
 func selectContainers(asOptional ...string) sq.SelectBuilder {
-	columns := []string{"id", "handle", "worker_name", "last_hijack", "state"}
+	columns := []string{"id", "handle", "worker_name", "last_hijack", "state", "created_at", "size_bytes"}
 	columns = append(columns, containerMetadataColumns...)
 
 	table := "containers"
@@ -318,11 +335,13 @@ func scanContainer(row sq.RowScanner, conn Conn) (CreatingContainer, CreatedCont
 		workerName string
 		lastHijack pq.NullTime
 		state      string
+		createdAt  time.Time
+		sizeBytes  uint64
 
 		metadata ContainerMetadata
 	)
 
-	columns := []interface{}{&id, &handle, &workerName, &lastHijack, &state}
+	columns := []interface{}{&id, &handle, &workerName, &lastHijack, &state, &createdAt, &sizeBytes}
 	columns = append(columns, metadata.ScanTargets()...)
 
 	err := row.Scan(columns...)
@@ -337,6 +356,7 @@ func scanContainer(row sq.RowScanner, conn Conn) (CreatingContainer, CreatedCont
 			handle,
 			workerName,
 			metadata,
+			createdAt,
 			conn,
 		), nil, nil, nil, nil
 	case atc.ContainerStateCreated:
@@ -345,7 +365,9 @@ func scanContainer(row sq.RowScanner, conn Conn) (CreatingContainer, CreatedCont
 			handle,
 			workerName,
 			metadata,
+			createdAt,
 			lastHijack.Time,
+			sizeBytes,
 			conn,
 		), nil, nil, nil
 	case atc.ContainerStateDestroying:
@@ -354,6 +376,7 @@ func scanContainer(row sq.RowScanner, conn Conn) (CreatingContainer, CreatedCont
 			handle,
 			workerName,
 			metadata,
+			createdAt,
 			conn,
 		), nil, nil
 	case atc.ContainerStateFailed:
@@ -362,6 +385,7 @@ func scanContainer(row sq.RowScanner, conn Conn) (CreatingContainer, CreatedCont
 			handle,
 			workerName,
 			metadata,
+			createdAt,
 			conn,
 		), nil
 	}
@@ -267,6 +267,39 @@ var _ = Describe("ContainerRepository", func() {
 						Expect(createdContainers[0].Handle()).To(Equal(creatingContainer.Handle()))
 						Expect(destroyingContainers).To(BeEmpty())
 					})
+
+					Context("when the container has an intercept session", func() {
+						var ttl time.Duration
+
+						JustBeforeEach(func() {
+							err := containerRepository.SaveInterceptSession(creatingContainer.Handle(), "debug", "some-process", ttl)
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						Context("which has not expired", func() {
+							BeforeEach(func() {
+								ttl = time.Hour
+							})
+
+							It("does not find container for deletion", func() {
+								_, createdContainers, _, err := containerRepository.FindOrphanedContainers()
+								Expect(err).NotTo(HaveOccurred())
+								Expect(createdContainers).To(BeEmpty())
+							})
+						})
+
+						Context("which has expired", func() {
+							BeforeEach(func() {
+								ttl = -time.Hour
+							})
+
+							It("finds container for deletion", func() {
+								_, createdContainers, _, err := containerRepository.FindOrphanedContainers()
+								Expect(err).NotTo(HaveOccurred())
+								Expect(createdContainers).To(HaveLen(1))
+							})
+						})
+					})
 				})
 
 				Context("when the container is destroying", func() {
@@ -1153,4 +1186,73 @@ var _ = Describe("ContainerRepository", func() {
 			})
 		})
 	})
+
+	Describe("intercept sessions", func() {
+		var createdContainer db.CreatedContainer
+
+		BeforeEach(func() {
+			build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+
+			creatingContainer, err := defaultWorker.CreateContainer(
+				db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()),
+				fullMetadata,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			createdContainer, err = creatingContainer.Created()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves, replaces and deletes sessions by name", func() {
+			_, found, err := containerRepository.FindInterceptSession(createdContainer.Handle(), "debug")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			err = containerRepository.SaveInterceptSession(createdContainer.Handle(), "debug", "process-1", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			session, found, err := containerRepository.FindInterceptSession(createdContainer.Handle(), "debug")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(session.Name).To(Equal("debug"))
+			Expect(session.ProcessID).To(Equal("process-1"))
+			Expect(session.ExpiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+
+			err = containerRepository.SaveInterceptSession(createdContainer.Handle(), "debug", "process-2", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			session, found, err = containerRepository.FindInterceptSession(createdContainer.Handle(), "debug")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(session.ProcessID).To(Equal("process-2"))
+
+			By("ignoring deletes for a replaced process")
+			err = containerRepository.DeleteInterceptSession(createdContainer.Handle(), "process-1")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err = containerRepository.FindInterceptSession(createdContainer.Handle(), "debug")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = containerRepository.DeleteInterceptSession(createdContainer.Handle(), "process-2")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err = containerRepository.FindInterceptSession(createdContainer.Handle(), "debug")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the container is not created", func() {
+			BeforeEach(func() {
+				_, err := createdContainer.Destroying()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not save the session", func() {
+				err := containerRepository.SaveInterceptSession(createdContainer.Handle(), "debug", "process-1", time.Hour)
+				Expect(err).To(Equal(db.ErrContainerDisappeared))
+			})
+		})
+	})
 })
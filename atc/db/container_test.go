@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -42,6 +44,12 @@ var _ = Describe("Container", func() {
 		})
 	})
 
+	Describe("CreatedAt", func() {
+		It("returns when the container was created", func() {
+			Expect(creatingContainer.CreatedAt()).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+	})
+
 	Describe("Created", func() {
 		Context("when the container is already created", func() {
 			var createdContainer db.CreatedContainer
@@ -88,6 +96,12 @@ var _ = Describe("Container", func() {
 					Expect(createdContainer.Metadata()).To(Equal(fullMetadata))
 				})
 			})
+
+			Describe("CreatedAt", func() {
+				It("keeps the time the container was created", func() {
+					Expect(createdContainer.CreatedAt()).To(Equal(creatingContainer.CreatedAt()))
+				})
+			})
 		})
 	})
 
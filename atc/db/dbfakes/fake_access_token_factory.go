@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 )
@@ -20,6 +21,17 @@ type FakeAccessTokenFactory struct {
 	createAccessTokenReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteAccessTokenStub        func(string) error
+	deleteAccessTokenMutex       sync.RWMutex
+	deleteAccessTokenArgsForCall []struct {
+		arg1 string
+	}
+	deleteAccessTokenReturns struct {
+		result1 error
+	}
+	deleteAccessTokenReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetAccessTokenStub        func(string) (db.AccessToken, bool, error)
 	getAccessTokenMutex       sync.RWMutex
 	getAccessTokenArgsForCall []struct {
@@ -35,6 +47,19 @@ type FakeAccessTokenFactory struct {
 		result2 bool
 		result3 error
 	}
+	TouchAccessTokenStub        func(string) (time.Duration, error)
+	touchAccessTokenMutex       sync.RWMutex
+	touchAccessTokenArgsForCall []struct {
+		arg1 string
+	}
+	touchAccessTokenReturns struct {
+		result1 time.Duration
+		result2 error
+	}
+	touchAccessTokenReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -101,6 +126,67 @@ func (fake *FakeAccessTokenFactory) CreateAccessTokenReturnsOnCall(i int, result
 	}{result1}
 }
 
+func (fake *FakeAccessTokenFactory) DeleteAccessToken(arg1 string) error {
+	fake.deleteAccessTokenMutex.Lock()
+	ret, specificReturn := fake.deleteAccessTokenReturnsOnCall[len(fake.deleteAccessTokenArgsForCall)]
+	fake.deleteAccessTokenArgsForCall = append(fake.deleteAccessTokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteAccessTokenStub
+	fakeReturns := fake.deleteAccessTokenReturns
+	fake.recordInvocation("DeleteAccessToken", []interface{}{arg1})
+	fake.deleteAccessTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAccessTokenFactory) DeleteAccessTokenCallCount() int {
+	fake.deleteAccessTokenMutex.RLock()
+	defer fake.deleteAccessTokenMutex.RUnlock()
+	return len(fake.deleteAccessTokenArgsForCall)
+}
+
+func (fake *FakeAccessTokenFactory) DeleteAccessTokenCalls(stub func(string) error) {
+	fake.deleteAccessTokenMutex.Lock()
+	defer fake.deleteAccessTokenMutex.Unlock()
+	fake.DeleteAccessTokenStub = stub
+}
+
+func (fake *FakeAccessTokenFactory) DeleteAccessTokenArgsForCall(i int) string {
+	fake.deleteAccessTokenMutex.RLock()
+	defer fake.deleteAccessTokenMutex.RUnlock()
+	argsForCall := fake.deleteAccessTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAccessTokenFactory) DeleteAccessTokenReturns(result1 error) {
+	fake.deleteAccessTokenMutex.Lock()
+	defer fake.deleteAccessTokenMutex.Unlock()
+	fake.DeleteAccessTokenStub = nil
+	fake.deleteAccessTokenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAccessTokenFactory) DeleteAccessTokenReturnsOnCall(i int, result1 error) {
+	fake.deleteAccessTokenMutex.Lock()
+	defer fake.deleteAccessTokenMutex.Unlock()
+	fake.DeleteAccessTokenStub = nil
+	if fake.deleteAccessTokenReturnsOnCall == nil {
+		fake.deleteAccessTokenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAccessTokenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeAccessTokenFactory) GetAccessToken(arg1 string) (db.AccessToken, bool, error) {
 	fake.getAccessTokenMutex.Lock()
 	ret, specificReturn := fake.getAccessTokenReturnsOnCall[len(fake.getAccessTokenArgsForCall)]
@@ -168,13 +254,81 @@ func (fake *FakeAccessTokenFactory) GetAccessTokenReturnsOnCall(i int, result1 d
 	}{result1, result2, result3}
 }
 
+func (fake *FakeAccessTokenFactory) TouchAccessToken(arg1 string) (time.Duration, error) {
+	fake.touchAccessTokenMutex.Lock()
+	ret, specificReturn := fake.touchAccessTokenReturnsOnCall[len(fake.touchAccessTokenArgsForCall)]
+	fake.touchAccessTokenArgsForCall = append(fake.touchAccessTokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.TouchAccessTokenStub
+	fakeReturns := fake.touchAccessTokenReturns
+	fake.recordInvocation("TouchAccessToken", []interface{}{arg1})
+	fake.touchAccessTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAccessTokenFactory) TouchAccessTokenCallCount() int {
+	fake.touchAccessTokenMutex.RLock()
+	defer fake.touchAccessTokenMutex.RUnlock()
+	return len(fake.touchAccessTokenArgsForCall)
+}
+
+func (fake *FakeAccessTokenFactory) TouchAccessTokenCalls(stub func(string) (time.Duration, error)) {
+	fake.touchAccessTokenMutex.Lock()
+	defer fake.touchAccessTokenMutex.Unlock()
+	fake.TouchAccessTokenStub = stub
+}
+
+func (fake *FakeAccessTokenFactory) TouchAccessTokenArgsForCall(i int) string {
+	fake.touchAccessTokenMutex.RLock()
+	defer fake.touchAccessTokenMutex.RUnlock()
+	argsForCall := fake.touchAccessTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAccessTokenFactory) TouchAccessTokenReturns(result1 time.Duration, result2 error) {
+	fake.touchAccessTokenMutex.Lock()
+	defer fake.touchAccessTokenMutex.Unlock()
+	fake.TouchAccessTokenStub = nil
+	fake.touchAccessTokenReturns = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAccessTokenFactory) TouchAccessTokenReturnsOnCall(i int, result1 time.Duration, result2 error) {
+	fake.touchAccessTokenMutex.Lock()
+	defer fake.touchAccessTokenMutex.Unlock()
+	fake.TouchAccessTokenStub = nil
+	if fake.touchAccessTokenReturnsOnCall == nil {
+		fake.touchAccessTokenReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 error
+		})
+	}
+	fake.touchAccessTokenReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAccessTokenFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.createAccessTokenMutex.RLock()
 	defer fake.createAccessTokenMutex.RUnlock()
+	fake.deleteAccessTokenMutex.RLock()
+	defer fake.deleteAccessTokenMutex.RUnlock()
 	fake.getAccessTokenMutex.RLock()
 	defer fake.getAccessTokenMutex.RUnlock()
+	fake.touchAccessTokenMutex.RLock()
+	defer fake.touchAccessTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -185,6 +185,16 @@ type FakeBuild struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	IdempotencyKeyStub        func() string
+	idempotencyKeyMutex       sync.RWMutex
+	idempotencyKeyArgsForCall []struct {
+	}
+	idempotencyKeyReturns struct {
+		result1 string
+	}
+	idempotencyKeyReturnsOnCall map[int]struct {
+		result1 string
+	}
 	InputsReadyStub        func() bool
 	inputsReadyMutex       sync.RWMutex
 	inputsReadyArgsForCall []struct {
@@ -298,6 +308,16 @@ type FakeBuild struct {
 	jobNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	JobPriorityStub        func() int
+	jobPriorityMutex       sync.RWMutex
+	jobPriorityArgsForCall []struct {
+	}
+	jobPriorityReturns struct {
+		result1 int
+	}
+	jobPriorityReturnsOnCall map[int]struct {
+		result1 int
+	}
 	LagerDataStub        func() lager.Data
 	lagerDataMutex       sync.RWMutex
 	lagerDataArgsForCall []struct {
@@ -468,6 +488,16 @@ type FakeBuild struct {
 	rerunOfNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	RerunOfSpanContextStub        func() propagation.TextMapCarrier
+	rerunOfSpanContextMutex       sync.RWMutex
+	rerunOfSpanContextArgsForCall []struct {
+	}
+	rerunOfSpanContextReturns struct {
+		result1 propagation.TextMapCarrier
+	}
+	rerunOfSpanContextReturnsOnCall map[int]struct {
+		result1 propagation.TextMapCarrier
+	}
 	ResourceIDStub        func() int
 	resourceIDMutex       sync.RWMutex
 	resourceIDArgsForCall []struct {
@@ -556,6 +586,31 @@ type FakeBuild struct {
 	saveImageResourceVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveInputStub        func(string, string, atc.Version) error
+	saveInputMutex       sync.RWMutex
+	saveInputArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 atc.Version
+	}
+	saveInputReturns struct {
+		result1 error
+	}
+	saveInputReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveIteratedInputsStub        func(string, []atc.Version) error
+	saveIteratedInputsMutex       sync.RWMutex
+	saveIteratedInputsArgsForCall []struct {
+		arg1 string
+		arg2 []atc.Version
+	}
+	saveIteratedInputsReturns struct {
+		result1 error
+	}
+	saveIteratedInputsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveOutputStub        func(string, atc.Source, atc.VersionedResourceTypes, atc.Version, db.ResourceConfigMetadataFields, string, string) error
 	saveOutputMutex       sync.RWMutex
 	saveOutputArgsForCall []struct {
@@ -667,6 +722,18 @@ type FakeBuild struct {
 	statusReturnsOnCall map[int]struct {
 		result1 db.BuildStatus
 	}
+	StepEventsStub        func() ([]event.Envelope, error)
+	stepEventsMutex       sync.RWMutex
+	stepEventsArgsForCall []struct {
+	}
+	stepEventsReturns struct {
+		result1 []event.Envelope
+		result2 error
+	}
+	stepEventsReturnsOnCall map[int]struct {
+		result1 []event.Envelope
+		result2 error
+	}
 	SyslogTagStub        func(event.OriginID) string
 	syslogTagMutex       sync.RWMutex
 	syslogTagArgsForCall []struct {
@@ -708,6 +775,27 @@ type FakeBuild struct {
 	tracingAttrsReturnsOnCall map[int]struct {
 		result1 tracing.Attrs
 	}
+	TriggerStub        func() atc.BuildTrigger
+	triggerMutex       sync.RWMutex
+	triggerArgsForCall []struct {
+	}
+	triggerReturns struct {
+		result1 atc.BuildTrigger
+	}
+	triggerReturnsOnCall map[int]struct {
+		result1 atc.BuildTrigger
+	}
+	UpdatePlanStub        func(atc.Plan) error
+	updatePlanMutex       sync.RWMutex
+	updatePlanArgsForCall []struct {
+		arg1 atc.Plan
+	}
+	updatePlanReturns struct {
+		result1 error
+	}
+	updatePlanReturnsOnCall map[int]struct {
+		result1 error
+	}
 	VariablesStub        func(lager.Logger, creds.Secrets, creds.VarSourcePool) (vars.Variables, error)
 	variablesMutex       sync.RWMutex
 	variablesArgsForCall []struct {
@@ -723,6 +811,18 @@ type FakeBuild struct {
 		result1 vars.Variables
 		result2 error
 	}
+	VarsStub        func() (vars.StaticVariables, error)
+	varsMutex       sync.RWMutex
+	varsArgsForCall []struct {
+	}
+	varsReturns struct {
+		result1 vars.StaticVariables
+		result2 error
+	}
+	varsReturnsOnCall map[int]struct {
+		result1 vars.StaticVariables
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1535,6 +1635,59 @@ func (fake *FakeBuild) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeBuild) IdempotencyKey() string {
+	fake.idempotencyKeyMutex.Lock()
+	ret, specificReturn := fake.idempotencyKeyReturnsOnCall[len(fake.idempotencyKeyArgsForCall)]
+	fake.idempotencyKeyArgsForCall = append(fake.idempotencyKeyArgsForCall, struct {
+	}{})
+	stub := fake.IdempotencyKeyStub
+	fakeReturns := fake.idempotencyKeyReturns
+	fake.recordInvocation("IdempotencyKey", []interface{}{})
+	fake.idempotencyKeyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) IdempotencyKeyCallCount() int {
+	fake.idempotencyKeyMutex.RLock()
+	defer fake.idempotencyKeyMutex.RUnlock()
+	return len(fake.idempotencyKeyArgsForCall)
+}
+
+func (fake *FakeBuild) IdempotencyKeyCalls(stub func() string) {
+	fake.idempotencyKeyMutex.Lock()
+	defer fake.idempotencyKeyMutex.Unlock()
+	fake.IdempotencyKeyStub = stub
+}
+
+func (fake *FakeBuild) IdempotencyKeyReturns(result1 string) {
+	fake.idempotencyKeyMutex.Lock()
+	defer fake.idempotencyKeyMutex.Unlock()
+	fake.IdempotencyKeyStub = nil
+	fake.idempotencyKeyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) IdempotencyKeyReturnsOnCall(i int, result1 string) {
+	fake.idempotencyKeyMutex.Lock()
+	defer fake.idempotencyKeyMutex.Unlock()
+	fake.IdempotencyKeyStub = nil
+	if fake.idempotencyKeyReturnsOnCall == nil {
+		fake.idempotencyKeyReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.idempotencyKeyReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeBuild) InputsReady() bool {
 	fake.inputsReadyMutex.Lock()
 	ret, specificReturn := fake.inputsReadyReturnsOnCall[len(fake.inputsReadyArgsForCall)]
@@ -2129,6 +2282,59 @@ func (fake *FakeBuild) JobNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) JobPriority() int {
+	fake.jobPriorityMutex.Lock()
+	ret, specificReturn := fake.jobPriorityReturnsOnCall[len(fake.jobPriorityArgsForCall)]
+	fake.jobPriorityArgsForCall = append(fake.jobPriorityArgsForCall, struct {
+	}{})
+	stub := fake.JobPriorityStub
+	fakeReturns := fake.jobPriorityReturns
+	fake.recordInvocation("JobPriority", []interface{}{})
+	fake.jobPriorityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) JobPriorityCallCount() int {
+	fake.jobPriorityMutex.RLock()
+	defer fake.jobPriorityMutex.RUnlock()
+	return len(fake.jobPriorityArgsForCall)
+}
+
+func (fake *FakeBuild) JobPriorityCalls(stub func() int) {
+	fake.jobPriorityMutex.Lock()
+	defer fake.jobPriorityMutex.Unlock()
+	fake.JobPriorityStub = stub
+}
+
+func (fake *FakeBuild) JobPriorityReturns(result1 int) {
+	fake.jobPriorityMutex.Lock()
+	defer fake.jobPriorityMutex.Unlock()
+	fake.JobPriorityStub = nil
+	fake.jobPriorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) JobPriorityReturnsOnCall(i int, result1 int) {
+	fake.jobPriorityMutex.Lock()
+	defer fake.jobPriorityMutex.Unlock()
+	fake.JobPriorityStub = nil
+	if fake.jobPriorityReturnsOnCall == nil {
+		fake.jobPriorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.jobPriorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) LagerData() lager.Data {
 	fake.lagerDataMutex.Lock()
 	ret, specificReturn := fake.lagerDataReturnsOnCall[len(fake.lagerDataArgsForCall)]
@@ -2992,6 +3198,59 @@ func (fake *FakeBuild) RerunOfNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) RerunOfSpanContext() propagation.TextMapCarrier {
+	fake.rerunOfSpanContextMutex.Lock()
+	ret, specificReturn := fake.rerunOfSpanContextReturnsOnCall[len(fake.rerunOfSpanContextArgsForCall)]
+	fake.rerunOfSpanContextArgsForCall = append(fake.rerunOfSpanContextArgsForCall, struct {
+	}{})
+	stub := fake.RerunOfSpanContextStub
+	fakeReturns := fake.rerunOfSpanContextReturns
+	fake.recordInvocation("RerunOfSpanContext", []interface{}{})
+	fake.rerunOfSpanContextMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RerunOfSpanContextCallCount() int {
+	fake.rerunOfSpanContextMutex.RLock()
+	defer fake.rerunOfSpanContextMutex.RUnlock()
+	return len(fake.rerunOfSpanContextArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfSpanContextCalls(stub func() propagation.TextMapCarrier) {
+	fake.rerunOfSpanContextMutex.Lock()
+	defer fake.rerunOfSpanContextMutex.Unlock()
+	fake.RerunOfSpanContextStub = stub
+}
+
+func (fake *FakeBuild) RerunOfSpanContextReturns(result1 propagation.TextMapCarrier) {
+	fake.rerunOfSpanContextMutex.Lock()
+	defer fake.rerunOfSpanContextMutex.Unlock()
+	fake.RerunOfSpanContextStub = nil
+	fake.rerunOfSpanContextReturns = struct {
+		result1 propagation.TextMapCarrier
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfSpanContextReturnsOnCall(i int, result1 propagation.TextMapCarrier) {
+	fake.rerunOfSpanContextMutex.Lock()
+	defer fake.rerunOfSpanContextMutex.Unlock()
+	fake.RerunOfSpanContextStub = nil
+	if fake.rerunOfSpanContextReturnsOnCall == nil {
+		fake.rerunOfSpanContextReturnsOnCall = make(map[int]struct {
+			result1 propagation.TextMapCarrier
+		})
+	}
+	fake.rerunOfSpanContextReturnsOnCall[i] = struct {
+		result1 propagation.TextMapCarrier
+	}{result1}
+}
+
 func (fake *FakeBuild) ResourceID() int {
 	fake.resourceIDMutex.Lock()
 	ret, specificReturn := fake.resourceIDReturnsOnCall[len(fake.resourceIDArgsForCall)]
@@ -3441,6 +3700,136 @@ func (fake *FakeBuild) SaveImageResourceVersionReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeBuild) SaveInput(arg1 string, arg2 string, arg3 atc.Version) error {
+	fake.saveInputMutex.Lock()
+	ret, specificReturn := fake.saveInputReturnsOnCall[len(fake.saveInputArgsForCall)]
+	fake.saveInputArgsForCall = append(fake.saveInputArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	stub := fake.SaveInputStub
+	fakeReturns := fake.saveInputReturns
+	fake.recordInvocation("SaveInput", []interface{}{arg1, arg2, arg3})
+	fake.saveInputMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveInputCallCount() int {
+	fake.saveInputMutex.RLock()
+	defer fake.saveInputMutex.RUnlock()
+	return len(fake.saveInputArgsForCall)
+}
+
+func (fake *FakeBuild) SaveInputCalls(stub func(string, string, atc.Version) error) {
+	fake.saveInputMutex.Lock()
+	defer fake.saveInputMutex.Unlock()
+	fake.SaveInputStub = stub
+}
+
+func (fake *FakeBuild) SaveInputArgsForCall(i int) (string, string, atc.Version) {
+	fake.saveInputMutex.RLock()
+	defer fake.saveInputMutex.RUnlock()
+	argsForCall := fake.saveInputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) SaveInputReturns(result1 error) {
+	fake.saveInputMutex.Lock()
+	defer fake.saveInputMutex.Unlock()
+	fake.SaveInputStub = nil
+	fake.saveInputReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveInputReturnsOnCall(i int, result1 error) {
+	fake.saveInputMutex.Lock()
+	defer fake.saveInputMutex.Unlock()
+	fake.SaveInputStub = nil
+	if fake.saveInputReturnsOnCall == nil {
+		fake.saveInputReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveInputReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveIteratedInputs(arg1 string, arg2 []atc.Version) error {
+	var arg2Copy []atc.Version
+	if arg2 != nil {
+		arg2Copy = make([]atc.Version, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.saveIteratedInputsMutex.Lock()
+	ret, specificReturn := fake.saveIteratedInputsReturnsOnCall[len(fake.saveIteratedInputsArgsForCall)]
+	fake.saveIteratedInputsArgsForCall = append(fake.saveIteratedInputsArgsForCall, struct {
+		arg1 string
+		arg2 []atc.Version
+	}{arg1, arg2Copy})
+	stub := fake.SaveIteratedInputsStub
+	fakeReturns := fake.saveIteratedInputsReturns
+	fake.recordInvocation("SaveIteratedInputs", []interface{}{arg1, arg2Copy})
+	fake.saveIteratedInputsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveIteratedInputsCallCount() int {
+	fake.saveIteratedInputsMutex.RLock()
+	defer fake.saveIteratedInputsMutex.RUnlock()
+	return len(fake.saveIteratedInputsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveIteratedInputsCalls(stub func(string, []atc.Version) error) {
+	fake.saveIteratedInputsMutex.Lock()
+	defer fake.saveIteratedInputsMutex.Unlock()
+	fake.SaveIteratedInputsStub = stub
+}
+
+func (fake *FakeBuild) SaveIteratedInputsArgsForCall(i int) (string, []atc.Version) {
+	fake.saveIteratedInputsMutex.RLock()
+	defer fake.saveIteratedInputsMutex.RUnlock()
+	argsForCall := fake.saveIteratedInputsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SaveIteratedInputsReturns(result1 error) {
+	fake.saveIteratedInputsMutex.Lock()
+	defer fake.saveIteratedInputsMutex.Unlock()
+	fake.SaveIteratedInputsStub = nil
+	fake.saveIteratedInputsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveIteratedInputsReturnsOnCall(i int, result1 error) {
+	fake.saveIteratedInputsMutex.Lock()
+	defer fake.saveIteratedInputsMutex.Unlock()
+	fake.SaveIteratedInputsStub = nil
+	if fake.saveIteratedInputsReturnsOnCall == nil {
+		fake.saveIteratedInputsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveIteratedInputsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SaveOutput(arg1 string, arg2 atc.Source, arg3 atc.VersionedResourceTypes, arg4 atc.Version, arg5 db.ResourceConfigMetadataFields, arg6 string, arg7 string) error {
 	fake.saveOutputMutex.Lock()
 	ret, specificReturn := fake.saveOutputReturnsOnCall[len(fake.saveOutputArgsForCall)]
@@ -3977,6 +4366,62 @@ func (fake *FakeBuild) StatusReturnsOnCall(i int, result1 db.BuildStatus) {
 	}{result1}
 }
 
+func (fake *FakeBuild) StepEvents() ([]event.Envelope, error) {
+	fake.stepEventsMutex.Lock()
+	ret, specificReturn := fake.stepEventsReturnsOnCall[len(fake.stepEventsArgsForCall)]
+	fake.stepEventsArgsForCall = append(fake.stepEventsArgsForCall, struct {
+	}{})
+	stub := fake.StepEventsStub
+	fakeReturns := fake.stepEventsReturns
+	fake.recordInvocation("StepEvents", []interface{}{})
+	fake.stepEventsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) StepEventsCallCount() int {
+	fake.stepEventsMutex.RLock()
+	defer fake.stepEventsMutex.RUnlock()
+	return len(fake.stepEventsArgsForCall)
+}
+
+func (fake *FakeBuild) StepEventsCalls(stub func() ([]event.Envelope, error)) {
+	fake.stepEventsMutex.Lock()
+	defer fake.stepEventsMutex.Unlock()
+	fake.StepEventsStub = stub
+}
+
+func (fake *FakeBuild) StepEventsReturns(result1 []event.Envelope, result2 error) {
+	fake.stepEventsMutex.Lock()
+	defer fake.stepEventsMutex.Unlock()
+	fake.StepEventsStub = nil
+	fake.stepEventsReturns = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StepEventsReturnsOnCall(i int, result1 []event.Envelope, result2 error) {
+	fake.stepEventsMutex.Lock()
+	defer fake.stepEventsMutex.Unlock()
+	fake.StepEventsStub = nil
+	if fake.stepEventsReturnsOnCall == nil {
+		fake.stepEventsReturnsOnCall = make(map[int]struct {
+			result1 []event.Envelope
+			result2 error
+		})
+	}
+	fake.stepEventsReturnsOnCall[i] = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) SyslogTag(arg1 event.OriginID) string {
 	fake.syslogTagMutex.Lock()
 	ret, specificReturn := fake.syslogTagReturnsOnCall[len(fake.syslogTagArgsForCall)]
@@ -4197,6 +4642,120 @@ func (fake *FakeBuild) TracingAttrsReturnsOnCall(i int, result1 tracing.Attrs) {
 	}{result1}
 }
 
+func (fake *FakeBuild) Trigger() atc.BuildTrigger {
+	fake.triggerMutex.Lock()
+	ret, specificReturn := fake.triggerReturnsOnCall[len(fake.triggerArgsForCall)]
+	fake.triggerArgsForCall = append(fake.triggerArgsForCall, struct {
+	}{})
+	stub := fake.TriggerStub
+	fakeReturns := fake.triggerReturns
+	fake.recordInvocation("Trigger", []interface{}{})
+	fake.triggerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) TriggerCallCount() int {
+	fake.triggerMutex.RLock()
+	defer fake.triggerMutex.RUnlock()
+	return len(fake.triggerArgsForCall)
+}
+
+func (fake *FakeBuild) TriggerCalls(stub func() atc.BuildTrigger) {
+	fake.triggerMutex.Lock()
+	defer fake.triggerMutex.Unlock()
+	fake.TriggerStub = stub
+}
+
+func (fake *FakeBuild) TriggerReturns(result1 atc.BuildTrigger) {
+	fake.triggerMutex.Lock()
+	defer fake.triggerMutex.Unlock()
+	fake.TriggerStub = nil
+	fake.triggerReturns = struct {
+		result1 atc.BuildTrigger
+	}{result1}
+}
+
+func (fake *FakeBuild) TriggerReturnsOnCall(i int, result1 atc.BuildTrigger) {
+	fake.triggerMutex.Lock()
+	defer fake.triggerMutex.Unlock()
+	fake.TriggerStub = nil
+	if fake.triggerReturnsOnCall == nil {
+		fake.triggerReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildTrigger
+		})
+	}
+	fake.triggerReturnsOnCall[i] = struct {
+		result1 atc.BuildTrigger
+	}{result1}
+}
+
+func (fake *FakeBuild) UpdatePlan(arg1 atc.Plan) error {
+	fake.updatePlanMutex.Lock()
+	ret, specificReturn := fake.updatePlanReturnsOnCall[len(fake.updatePlanArgsForCall)]
+	fake.updatePlanArgsForCall = append(fake.updatePlanArgsForCall, struct {
+		arg1 atc.Plan
+	}{arg1})
+	stub := fake.UpdatePlanStub
+	fakeReturns := fake.updatePlanReturns
+	fake.recordInvocation("UpdatePlan", []interface{}{arg1})
+	fake.updatePlanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) UpdatePlanCallCount() int {
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
+	return len(fake.updatePlanArgsForCall)
+}
+
+func (fake *FakeBuild) UpdatePlanCalls(stub func(atc.Plan) error) {
+	fake.updatePlanMutex.Lock()
+	defer fake.updatePlanMutex.Unlock()
+	fake.UpdatePlanStub = stub
+}
+
+func (fake *FakeBuild) UpdatePlanArgsForCall(i int) atc.Plan {
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
+	argsForCall := fake.updatePlanArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) UpdatePlanReturns(result1 error) {
+	fake.updatePlanMutex.Lock()
+	defer fake.updatePlanMutex.Unlock()
+	fake.UpdatePlanStub = nil
+	fake.updatePlanReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) UpdatePlanReturnsOnCall(i int, result1 error) {
+	fake.updatePlanMutex.Lock()
+	defer fake.updatePlanMutex.Unlock()
+	fake.UpdatePlanStub = nil
+	if fake.updatePlanReturnsOnCall == nil {
+		fake.updatePlanReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePlanReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Variables(arg1 lager.Logger, arg2 creds.Secrets, arg3 creds.VarSourcePool) (vars.Variables, error) {
 	fake.variablesMutex.Lock()
 	ret, specificReturn := fake.variablesReturnsOnCall[len(fake.variablesArgsForCall)]
@@ -4263,6 +4822,62 @@ func (fake *FakeBuild) VariablesReturnsOnCall(i int, result1 vars.Variables, res
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) Vars() (vars.StaticVariables, error) {
+	fake.varsMutex.Lock()
+	ret, specificReturn := fake.varsReturnsOnCall[len(fake.varsArgsForCall)]
+	fake.varsArgsForCall = append(fake.varsArgsForCall, struct {
+	}{})
+	stub := fake.VarsStub
+	fakeReturns := fake.varsReturns
+	fake.recordInvocation("Vars", []interface{}{})
+	fake.varsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) VarsCallCount() int {
+	fake.varsMutex.RLock()
+	defer fake.varsMutex.RUnlock()
+	return len(fake.varsArgsForCall)
+}
+
+func (fake *FakeBuild) VarsCalls(stub func() (vars.StaticVariables, error)) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = stub
+}
+
+func (fake *FakeBuild) VarsReturns(result1 vars.StaticVariables, result2 error) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = nil
+	fake.varsReturns = struct {
+		result1 vars.StaticVariables
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) VarsReturnsOnCall(i int, result1 vars.StaticVariables, result2 error) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = nil
+	if fake.varsReturnsOnCall == nil {
+		fake.varsReturnsOnCall = make(map[int]struct {
+			result1 vars.StaticVariables
+			result2 error
+		})
+	}
+	fake.varsReturnsOnCall[i] = struct {
+		result1 vars.StaticVariables
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -4294,6 +4909,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.hasPlanMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.idempotencyKeyMutex.RLock()
+	defer fake.idempotencyKeyMutex.RUnlock()
 	fake.inputsReadyMutex.RLock()
 	defer fake.inputsReadyMutex.RUnlock()
 	fake.interceptibleMutex.RLock()
@@ -4316,6 +4933,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.jobIDMutex.RUnlock()
 	fake.jobNameMutex.RLock()
 	defer fake.jobNameMutex.RUnlock()
+	fake.jobPriorityMutex.RLock()
+	defer fake.jobPriorityMutex.RUnlock()
 	fake.lagerDataMutex.RLock()
 	defer fake.lagerDataMutex.RUnlock()
 	fake.markAsAbortedMutex.RLock()
@@ -4348,6 +4967,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.rerunOfMutex.RUnlock()
 	fake.rerunOfNameMutex.RLock()
 	defer fake.rerunOfNameMutex.RUnlock()
+	fake.rerunOfSpanContextMutex.RLock()
+	defer fake.rerunOfSpanContextMutex.RUnlock()
 	fake.resourceIDMutex.RLock()
 	defer fake.resourceIDMutex.RUnlock()
 	fake.resourceNameMutex.RLock()
@@ -4364,6 +4985,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.saveEventMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
 	defer fake.saveImageResourceVersionMutex.RUnlock()
+	fake.saveInputMutex.RLock()
+	defer fake.saveInputMutex.RUnlock()
+	fake.saveIteratedInputsMutex.RLock()
+	defer fake.saveIteratedInputsMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
@@ -4382,6 +5007,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
+	fake.stepEventsMutex.RLock()
+	defer fake.stepEventsMutex.RUnlock()
 	fake.syslogTagMutex.RLock()
 	defer fake.syslogTagMutex.RUnlock()
 	fake.teamIDMutex.RLock()
@@ -4390,8 +5017,14 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.teamNameMutex.RUnlock()
 	fake.tracingAttrsMutex.RLock()
 	defer fake.tracingAttrsMutex.RUnlock()
+	fake.triggerMutex.RLock()
+	defer fake.triggerMutex.RUnlock()
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
 	fake.variablesMutex.RLock()
 	defer fake.variablesMutex.RUnlock()
+	fake.varsMutex.RLock()
+	defer fake.varsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
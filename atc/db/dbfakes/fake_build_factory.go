@@ -4,14 +4,16 @@ package dbfakes
 import (
 	"sync"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
 type FakeBuildFactory struct {
-	AllBuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
+	AllBuildsStub        func(db.Page, atc.BuildFilter) ([]db.Build, db.Pagination, error)
 	allBuildsMutex       sync.RWMutex
 	allBuildsArgsForCall []struct {
 		arg1 db.Page
+		arg2 atc.BuildFilter
 	}
 	allBuildsReturns struct {
 		result1 []db.Build
@@ -87,11 +89,12 @@ type FakeBuildFactory struct {
 		result2 db.Pagination
 		result3 error
 	}
-	VisibleBuildsStub        func([]string, db.Page) ([]db.Build, db.Pagination, error)
+	VisibleBuildsStub        func([]string, db.Page, atc.BuildFilter) ([]db.Build, db.Pagination, error)
 	visibleBuildsMutex       sync.RWMutex
 	visibleBuildsArgsForCall []struct {
 		arg1 []string
 		arg2 db.Page
+		arg3 atc.BuildFilter
 	}
 	visibleBuildsReturns struct {
 		result1 []db.Build
@@ -107,18 +110,19 @@ type FakeBuildFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBuildFactory) AllBuilds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
+func (fake *FakeBuildFactory) AllBuilds(arg1 db.Page, arg2 atc.BuildFilter) ([]db.Build, db.Pagination, error) {
 	fake.allBuildsMutex.Lock()
 	ret, specificReturn := fake.allBuildsReturnsOnCall[len(fake.allBuildsArgsForCall)]
 	fake.allBuildsArgsForCall = append(fake.allBuildsArgsForCall, struct {
 		arg1 db.Page
-	}{arg1})
+		arg2 atc.BuildFilter
+	}{arg1, arg2})
 	stub := fake.AllBuildsStub
 	fakeReturns := fake.allBuildsReturns
-	fake.recordInvocation("AllBuilds", []interface{}{arg1})
+	fake.recordInvocation("AllBuilds", []interface{}{arg1, arg2})
 	fake.allBuildsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -132,17 +136,17 @@ func (fake *FakeBuildFactory) AllBuildsCallCount() int {
 	return len(fake.allBuildsArgsForCall)
 }
 
-func (fake *FakeBuildFactory) AllBuildsCalls(stub func(db.Page) ([]db.Build, db.Pagination, error)) {
+func (fake *FakeBuildFactory) AllBuildsCalls(stub func(db.Page, atc.BuildFilter) ([]db.Build, db.Pagination, error)) {
 	fake.allBuildsMutex.Lock()
 	defer fake.allBuildsMutex.Unlock()
 	fake.AllBuildsStub = stub
 }
 
-func (fake *FakeBuildFactory) AllBuildsArgsForCall(i int) db.Page {
+func (fake *FakeBuildFactory) AllBuildsArgsForCall(i int) (db.Page, atc.BuildFilter) {
 	fake.allBuildsMutex.RLock()
 	defer fake.allBuildsMutex.RUnlock()
 	argsForCall := fake.allBuildsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeBuildFactory) AllBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
@@ -473,7 +477,7 @@ func (fake *FakeBuildFactory) PublicBuildsReturnsOnCall(i int, result1 []db.Buil
 	}{result1, result2, result3}
 }
 
-func (fake *FakeBuildFactory) VisibleBuilds(arg1 []string, arg2 db.Page) ([]db.Build, db.Pagination, error) {
+func (fake *FakeBuildFactory) VisibleBuilds(arg1 []string, arg2 db.Page, arg3 atc.BuildFilter) ([]db.Build, db.Pagination, error) {
 	var arg1Copy []string
 	if arg1 != nil {
 		arg1Copy = make([]string, len(arg1))
@@ -484,13 +488,14 @@ func (fake *FakeBuildFactory) VisibleBuilds(arg1 []string, arg2 db.Page) ([]db.B
 	fake.visibleBuildsArgsForCall = append(fake.visibleBuildsArgsForCall, struct {
 		arg1 []string
 		arg2 db.Page
-	}{arg1Copy, arg2})
+		arg3 atc.BuildFilter
+	}{arg1Copy, arg2, arg3})
 	stub := fake.VisibleBuildsStub
 	fakeReturns := fake.visibleBuildsReturns
-	fake.recordInvocation("VisibleBuilds", []interface{}{arg1Copy, arg2})
+	fake.recordInvocation("VisibleBuilds", []interface{}{arg1Copy, arg2, arg3})
 	fake.visibleBuildsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -504,17 +509,17 @@ func (fake *FakeBuildFactory) VisibleBuildsCallCount() int {
 	return len(fake.visibleBuildsArgsForCall)
 }
 
-func (fake *FakeBuildFactory) VisibleBuildsCalls(stub func([]string, db.Page) ([]db.Build, db.Pagination, error)) {
+func (fake *FakeBuildFactory) VisibleBuildsCalls(stub func([]string, db.Page, atc.BuildFilter) ([]db.Build, db.Pagination, error)) {
 	fake.visibleBuildsMutex.Lock()
 	defer fake.visibleBuildsMutex.Unlock()
 	fake.VisibleBuildsStub = stub
 }
 
-func (fake *FakeBuildFactory) VisibleBuildsArgsForCall(i int) ([]string, db.Page) {
+func (fake *FakeBuildFactory) VisibleBuildsArgsForCall(i int) ([]string, db.Page, atc.BuildFilter) {
 	fake.visibleBuildsMutex.RLock()
 	defer fake.visibleBuildsMutex.RUnlock()
 	argsForCall := fake.visibleBuildsArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeBuildFactory) VisibleBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
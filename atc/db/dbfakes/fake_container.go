@@ -3,11 +3,22 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 )
 
 type FakeContainer struct {
+	CreatedAtStub        func() time.Time
+	createdAtMutex       sync.RWMutex
+	createdAtArgsForCall []struct {
+	}
+	createdAtReturns struct {
+		result1 time.Time
+	}
+	createdAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	HandleStub        func() string
 	handleMutex       sync.RWMutex
 	handleArgsForCall []struct {
@@ -62,6 +73,59 @@ type FakeContainer struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeContainer) CreatedAt() time.Time {
+	fake.createdAtMutex.Lock()
+	ret, specificReturn := fake.createdAtReturnsOnCall[len(fake.createdAtArgsForCall)]
+	fake.createdAtArgsForCall = append(fake.createdAtArgsForCall, struct {
+	}{})
+	stub := fake.CreatedAtStub
+	fakeReturns := fake.createdAtReturns
+	fake.recordInvocation("CreatedAt", []interface{}{})
+	fake.createdAtMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeContainer) CreatedAtCallCount() int {
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
+	return len(fake.createdAtArgsForCall)
+}
+
+func (fake *FakeContainer) CreatedAtCalls(stub func() time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = stub
+}
+
+func (fake *FakeContainer) CreatedAtReturns(result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	fake.createdAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeContainer) CreatedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	if fake.createdAtReturnsOnCall == nil {
+		fake.createdAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.createdAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeContainer) Handle() string {
 	fake.handleMutex.Lock()
 	ret, specificReturn := fake.handleReturnsOnCall[len(fake.handleArgsForCall)]
@@ -330,6 +394,8 @@ func (fake *FakeContainer) WorkerNameReturnsOnCall(i int, result1 string) {
 func (fake *FakeContainer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
 	fake.handleMutex.RLock()
 	defer fake.handleMutex.RUnlock()
 	fake.iDMutex.RLock()
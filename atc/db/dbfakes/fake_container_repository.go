@@ -9,6 +9,18 @@ import (
 )
 
 type FakeContainerRepository struct {
+	DeleteInterceptSessionStub        func(string, string) error
+	deleteInterceptSessionMutex       sync.RWMutex
+	deleteInterceptSessionArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	deleteInterceptSessionReturns struct {
+		result1 error
+	}
+	deleteInterceptSessionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DestroyFailedContainersStub        func() (int, error)
 	destroyFailedContainersMutex       sync.RWMutex
 	destroyFailedContainersArgsForCall []struct {
@@ -48,6 +60,22 @@ type FakeContainerRepository struct {
 		result1 []string
 		result2 error
 	}
+	FindInterceptSessionStub        func(string, string) (db.InterceptSession, bool, error)
+	findInterceptSessionMutex       sync.RWMutex
+	findInterceptSessionArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	findInterceptSessionReturns struct {
+		result1 db.InterceptSession
+		result2 bool
+		result3 error
+	}
+	findInterceptSessionReturnsOnCall map[int]struct {
+		result1 db.InterceptSession
+		result2 bool
+		result3 error
+	}
 	FindOrphanedContainersStub        func() ([]db.CreatingContainer, []db.CreatedContainer, []db.DestroyingContainer, error)
 	findOrphanedContainersMutex       sync.RWMutex
 	findOrphanedContainersArgsForCall []struct {
@@ -91,6 +119,20 @@ type FakeContainerRepository struct {
 		result1 int
 		result2 error
 	}
+	SaveInterceptSessionStub        func(string, string, string, time.Duration) error
+	saveInterceptSessionMutex       sync.RWMutex
+	saveInterceptSessionArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}
+	saveInterceptSessionReturns struct {
+		result1 error
+	}
+	saveInterceptSessionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateContainersMissingSinceStub        func(string, []string) error
 	updateContainersMissingSinceMutex       sync.RWMutex
 	updateContainersMissingSinceArgsForCall []struct {
@@ -107,6 +149,68 @@ type FakeContainerRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeContainerRepository) DeleteInterceptSession(arg1 string, arg2 string) error {
+	fake.deleteInterceptSessionMutex.Lock()
+	ret, specificReturn := fake.deleteInterceptSessionReturnsOnCall[len(fake.deleteInterceptSessionArgsForCall)]
+	fake.deleteInterceptSessionArgsForCall = append(fake.deleteInterceptSessionArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteInterceptSessionStub
+	fakeReturns := fake.deleteInterceptSessionReturns
+	fake.recordInvocation("DeleteInterceptSession", []interface{}{arg1, arg2})
+	fake.deleteInterceptSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeContainerRepository) DeleteInterceptSessionCallCount() int {
+	fake.deleteInterceptSessionMutex.RLock()
+	defer fake.deleteInterceptSessionMutex.RUnlock()
+	return len(fake.deleteInterceptSessionArgsForCall)
+}
+
+func (fake *FakeContainerRepository) DeleteInterceptSessionCalls(stub func(string, string) error) {
+	fake.deleteInterceptSessionMutex.Lock()
+	defer fake.deleteInterceptSessionMutex.Unlock()
+	fake.DeleteInterceptSessionStub = stub
+}
+
+func (fake *FakeContainerRepository) DeleteInterceptSessionArgsForCall(i int) (string, string) {
+	fake.deleteInterceptSessionMutex.RLock()
+	defer fake.deleteInterceptSessionMutex.RUnlock()
+	argsForCall := fake.deleteInterceptSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeContainerRepository) DeleteInterceptSessionReturns(result1 error) {
+	fake.deleteInterceptSessionMutex.Lock()
+	defer fake.deleteInterceptSessionMutex.Unlock()
+	fake.DeleteInterceptSessionStub = nil
+	fake.deleteInterceptSessionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerRepository) DeleteInterceptSessionReturnsOnCall(i int, result1 error) {
+	fake.deleteInterceptSessionMutex.Lock()
+	defer fake.deleteInterceptSessionMutex.Unlock()
+	fake.DeleteInterceptSessionStub = nil
+	if fake.deleteInterceptSessionReturnsOnCall == nil {
+		fake.deleteInterceptSessionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteInterceptSessionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeContainerRepository) DestroyFailedContainers() (int, error) {
 	fake.destroyFailedContainersMutex.Lock()
 	ret, specificReturn := fake.destroyFailedContainersReturnsOnCall[len(fake.destroyFailedContainersArgsForCall)]
@@ -297,6 +401,74 @@ func (fake *FakeContainerRepository) FindDestroyingContainersReturnsOnCall(i int
 	}{result1, result2}
 }
 
+func (fake *FakeContainerRepository) FindInterceptSession(arg1 string, arg2 string) (db.InterceptSession, bool, error) {
+	fake.findInterceptSessionMutex.Lock()
+	ret, specificReturn := fake.findInterceptSessionReturnsOnCall[len(fake.findInterceptSessionArgsForCall)]
+	fake.findInterceptSessionArgsForCall = append(fake.findInterceptSessionArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FindInterceptSessionStub
+	fakeReturns := fake.findInterceptSessionReturns
+	fake.recordInvocation("FindInterceptSession", []interface{}{arg1, arg2})
+	fake.findInterceptSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeContainerRepository) FindInterceptSessionCallCount() int {
+	fake.findInterceptSessionMutex.RLock()
+	defer fake.findInterceptSessionMutex.RUnlock()
+	return len(fake.findInterceptSessionArgsForCall)
+}
+
+func (fake *FakeContainerRepository) FindInterceptSessionCalls(stub func(string, string) (db.InterceptSession, bool, error)) {
+	fake.findInterceptSessionMutex.Lock()
+	defer fake.findInterceptSessionMutex.Unlock()
+	fake.FindInterceptSessionStub = stub
+}
+
+func (fake *FakeContainerRepository) FindInterceptSessionArgsForCall(i int) (string, string) {
+	fake.findInterceptSessionMutex.RLock()
+	defer fake.findInterceptSessionMutex.RUnlock()
+	argsForCall := fake.findInterceptSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeContainerRepository) FindInterceptSessionReturns(result1 db.InterceptSession, result2 bool, result3 error) {
+	fake.findInterceptSessionMutex.Lock()
+	defer fake.findInterceptSessionMutex.Unlock()
+	fake.FindInterceptSessionStub = nil
+	fake.findInterceptSessionReturns = struct {
+		result1 db.InterceptSession
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeContainerRepository) FindInterceptSessionReturnsOnCall(i int, result1 db.InterceptSession, result2 bool, result3 error) {
+	fake.findInterceptSessionMutex.Lock()
+	defer fake.findInterceptSessionMutex.Unlock()
+	fake.FindInterceptSessionStub = nil
+	if fake.findInterceptSessionReturnsOnCall == nil {
+		fake.findInterceptSessionReturnsOnCall = make(map[int]struct {
+			result1 db.InterceptSession
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findInterceptSessionReturnsOnCall[i] = struct {
+		result1 db.InterceptSession
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeContainerRepository) FindOrphanedContainers() ([]db.CreatingContainer, []db.CreatedContainer, []db.DestroyingContainer, error) {
 	fake.findOrphanedContainersMutex.Lock()
 	ret, specificReturn := fake.findOrphanedContainersReturnsOnCall[len(fake.findOrphanedContainersArgsForCall)]
@@ -493,6 +665,70 @@ func (fake *FakeContainerRepository) RemoveMissingContainersReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeContainerRepository) SaveInterceptSession(arg1 string, arg2 string, arg3 string, arg4 time.Duration) error {
+	fake.saveInterceptSessionMutex.Lock()
+	ret, specificReturn := fake.saveInterceptSessionReturnsOnCall[len(fake.saveInterceptSessionArgsForCall)]
+	fake.saveInterceptSessionArgsForCall = append(fake.saveInterceptSessionArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SaveInterceptSessionStub
+	fakeReturns := fake.saveInterceptSessionReturns
+	fake.recordInvocation("SaveInterceptSession", []interface{}{arg1, arg2, arg3, arg4})
+	fake.saveInterceptSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeContainerRepository) SaveInterceptSessionCallCount() int {
+	fake.saveInterceptSessionMutex.RLock()
+	defer fake.saveInterceptSessionMutex.RUnlock()
+	return len(fake.saveInterceptSessionArgsForCall)
+}
+
+func (fake *FakeContainerRepository) SaveInterceptSessionCalls(stub func(string, string, string, time.Duration) error) {
+	fake.saveInterceptSessionMutex.Lock()
+	defer fake.saveInterceptSessionMutex.Unlock()
+	fake.SaveInterceptSessionStub = stub
+}
+
+func (fake *FakeContainerRepository) SaveInterceptSessionArgsForCall(i int) (string, string, string, time.Duration) {
+	fake.saveInterceptSessionMutex.RLock()
+	defer fake.saveInterceptSessionMutex.RUnlock()
+	argsForCall := fake.saveInterceptSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeContainerRepository) SaveInterceptSessionReturns(result1 error) {
+	fake.saveInterceptSessionMutex.Lock()
+	defer fake.saveInterceptSessionMutex.Unlock()
+	fake.SaveInterceptSessionStub = nil
+	fake.saveInterceptSessionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerRepository) SaveInterceptSessionReturnsOnCall(i int, result1 error) {
+	fake.saveInterceptSessionMutex.Lock()
+	defer fake.saveInterceptSessionMutex.Unlock()
+	fake.SaveInterceptSessionStub = nil
+	if fake.saveInterceptSessionReturnsOnCall == nil {
+		fake.saveInterceptSessionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveInterceptSessionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeContainerRepository) UpdateContainersMissingSince(arg1 string, arg2 []string) error {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -563,18 +799,24 @@ func (fake *FakeContainerRepository) UpdateContainersMissingSinceReturnsOnCall(i
 func (fake *FakeContainerRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.deleteInterceptSessionMutex.RLock()
+	defer fake.deleteInterceptSessionMutex.RUnlock()
 	fake.destroyFailedContainersMutex.RLock()
 	defer fake.destroyFailedContainersMutex.RUnlock()
 	fake.destroyUnknownContainersMutex.RLock()
 	defer fake.destroyUnknownContainersMutex.RUnlock()
 	fake.findDestroyingContainersMutex.RLock()
 	defer fake.findDestroyingContainersMutex.RUnlock()
+	fake.findInterceptSessionMutex.RLock()
+	defer fake.findInterceptSessionMutex.RUnlock()
 	fake.findOrphanedContainersMutex.RLock()
 	defer fake.findOrphanedContainersMutex.RUnlock()
 	fake.removeDestroyingContainersMutex.RLock()
 	defer fake.removeDestroyingContainersMutex.RUnlock()
 	fake.removeMissingContainersMutex.RLock()
 	defer fake.removeMissingContainersMutex.RUnlock()
+	fake.saveInterceptSessionMutex.RLock()
+	defer fake.saveInterceptSessionMutex.RUnlock()
 	fake.updateContainersMissingSinceMutex.RLock()
 	defer fake.updateContainersMissingSinceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
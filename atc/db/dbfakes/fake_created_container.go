@@ -9,6 +9,16 @@ import (
 )
 
 type FakeCreatedContainer struct {
+	CreatedAtStub        func() time.Time
+	createdAtMutex       sync.RWMutex
+	createdAtArgsForCall []struct {
+	}
+	createdAtReturns struct {
+		result1 time.Time
+	}
+	createdAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	DestroyingStub        func() (db.DestroyingContainer, error)
 	destroyingMutex       sync.RWMutex
 	destroyingArgsForCall []struct {
@@ -61,6 +71,16 @@ type FakeCreatedContainer struct {
 	metadataReturnsOnCall map[int]struct {
 		result1 db.ContainerMetadata
 	}
+	SizeBytesStub        func() uint64
+	sizeBytesMutex       sync.RWMutex
+	sizeBytesArgsForCall []struct {
+	}
+	sizeBytesReturns struct {
+		result1 uint64
+	}
+	sizeBytesReturnsOnCall map[int]struct {
+		result1 uint64
+	}
 	StateStub        func() string
 	stateMutex       sync.RWMutex
 	stateArgsForCall []struct {
@@ -95,6 +115,59 @@ type FakeCreatedContainer struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCreatedContainer) CreatedAt() time.Time {
+	fake.createdAtMutex.Lock()
+	ret, specificReturn := fake.createdAtReturnsOnCall[len(fake.createdAtArgsForCall)]
+	fake.createdAtArgsForCall = append(fake.createdAtArgsForCall, struct {
+	}{})
+	stub := fake.CreatedAtStub
+	fakeReturns := fake.createdAtReturns
+	fake.recordInvocation("CreatedAt", []interface{}{})
+	fake.createdAtMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedContainer) CreatedAtCallCount() int {
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
+	return len(fake.createdAtArgsForCall)
+}
+
+func (fake *FakeCreatedContainer) CreatedAtCalls(stub func() time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = stub
+}
+
+func (fake *FakeCreatedContainer) CreatedAtReturns(result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	fake.createdAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCreatedContainer) CreatedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	if fake.createdAtReturnsOnCall == nil {
+		fake.createdAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.createdAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeCreatedContainer) Destroying() (db.DestroyingContainer, error) {
 	fake.destroyingMutex.Lock()
 	ret, specificReturn := fake.destroyingReturnsOnCall[len(fake.destroyingArgsForCall)]
@@ -363,6 +436,59 @@ func (fake *FakeCreatedContainer) MetadataReturnsOnCall(i int, result1 db.Contai
 	}{result1}
 }
 
+func (fake *FakeCreatedContainer) SizeBytes() uint64 {
+	fake.sizeBytesMutex.Lock()
+	ret, specificReturn := fake.sizeBytesReturnsOnCall[len(fake.sizeBytesArgsForCall)]
+	fake.sizeBytesArgsForCall = append(fake.sizeBytesArgsForCall, struct {
+	}{})
+	stub := fake.SizeBytesStub
+	fakeReturns := fake.sizeBytesReturns
+	fake.recordInvocation("SizeBytes", []interface{}{})
+	fake.sizeBytesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedContainer) SizeBytesCallCount() int {
+	fake.sizeBytesMutex.RLock()
+	defer fake.sizeBytesMutex.RUnlock()
+	return len(fake.sizeBytesArgsForCall)
+}
+
+func (fake *FakeCreatedContainer) SizeBytesCalls(stub func() uint64) {
+	fake.sizeBytesMutex.Lock()
+	defer fake.sizeBytesMutex.Unlock()
+	fake.SizeBytesStub = stub
+}
+
+func (fake *FakeCreatedContainer) SizeBytesReturns(result1 uint64) {
+	fake.sizeBytesMutex.Lock()
+	defer fake.sizeBytesMutex.Unlock()
+	fake.SizeBytesStub = nil
+	fake.sizeBytesReturns = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *FakeCreatedContainer) SizeBytesReturnsOnCall(i int, result1 uint64) {
+	fake.sizeBytesMutex.Lock()
+	defer fake.sizeBytesMutex.Unlock()
+	fake.SizeBytesStub = nil
+	if fake.sizeBytesReturnsOnCall == nil {
+		fake.sizeBytesReturnsOnCall = make(map[int]struct {
+			result1 uint64
+		})
+	}
+	fake.sizeBytesReturnsOnCall[i] = struct {
+		result1 uint64
+	}{result1}
+}
+
 func (fake *FakeCreatedContainer) State() string {
 	fake.stateMutex.Lock()
 	ret, specificReturn := fake.stateReturnsOnCall[len(fake.stateArgsForCall)]
@@ -525,6 +651,8 @@ func (fake *FakeCreatedContainer) WorkerNameReturnsOnCall(i int, result1 string)
 func (fake *FakeCreatedContainer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
 	fake.destroyingMutex.RLock()
 	defer fake.destroyingMutex.RUnlock()
 	fake.handleMutex.RLock()
@@ -535,6 +663,8 @@ func (fake *FakeCreatedContainer) Invocations() map[string][][]interface{} {
 	defer fake.lastHijackMutex.RUnlock()
 	fake.metadataMutex.RLock()
 	defer fake.metadataMutex.RUnlock()
+	fake.sizeBytesMutex.RLock()
+	defer fake.sizeBytesMutex.RUnlock()
 	fake.stateMutex.RLock()
 	defer fake.stateMutex.RUnlock()
 	fake.updateLastHijackMutex.RLock()
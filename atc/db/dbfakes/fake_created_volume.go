@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -45,6 +46,16 @@ type FakeCreatedVolume struct {
 		result1 db.CreatingVolume
 		result2 error
 	}
+	CreatedAtStub        func() time.Time
+	createdAtMutex       sync.RWMutex
+	createdAtArgsForCall []struct {
+	}
+	createdAtReturns struct {
+		result1 time.Time
+	}
+	createdAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	DestroyingStub        func() (db.DestroyingVolume, error)
 	destroyingMutex       sync.RWMutex
 	destroyingArgsForCall []struct {
@@ -159,6 +170,16 @@ type FakeCreatedVolume struct {
 		result1 *db.VolumeResourceType
 		result2 error
 	}
+	SizeBytesStub        func() uint64
+	sizeBytesMutex       sync.RWMutex
+	sizeBytesArgsForCall []struct {
+	}
+	sizeBytesReturns struct {
+		result1 uint64
+	}
+	sizeBytesReturnsOnCall map[int]struct {
+		result1 uint64
+	}
 	TaskIdentifierStub        func() (int, atc.PipelineRef, string, string, error)
 	taskIdentifierMutex       sync.RWMutex
 	taskIdentifierArgsForCall []struct {
@@ -395,6 +416,59 @@ func (fake *FakeCreatedVolume) CreateChildForContainerReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *FakeCreatedVolume) CreatedAt() time.Time {
+	fake.createdAtMutex.Lock()
+	ret, specificReturn := fake.createdAtReturnsOnCall[len(fake.createdAtArgsForCall)]
+	fake.createdAtArgsForCall = append(fake.createdAtArgsForCall, struct {
+	}{})
+	stub := fake.CreatedAtStub
+	fakeReturns := fake.createdAtReturns
+	fake.recordInvocation("CreatedAt", []interface{}{})
+	fake.createdAtMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedVolume) CreatedAtCallCount() int {
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
+	return len(fake.createdAtArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) CreatedAtCalls(stub func() time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = stub
+}
+
+func (fake *FakeCreatedVolume) CreatedAtReturns(result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	fake.createdAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) CreatedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	if fake.createdAtReturnsOnCall == nil {
+		fake.createdAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.createdAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeCreatedVolume) Destroying() (db.DestroyingVolume, error) {
 	fake.destroyingMutex.Lock()
 	ret, specificReturn := fake.destroyingReturnsOnCall[len(fake.destroyingArgsForCall)]
@@ -970,6 +1044,59 @@ func (fake *FakeCreatedVolume) ResourceTypeReturnsOnCall(i int, result1 *db.Volu
 	}{result1, result2}
 }
 
+func (fake *FakeCreatedVolume) SizeBytes() uint64 {
+	fake.sizeBytesMutex.Lock()
+	ret, specificReturn := fake.sizeBytesReturnsOnCall[len(fake.sizeBytesArgsForCall)]
+	fake.sizeBytesArgsForCall = append(fake.sizeBytesArgsForCall, struct {
+	}{})
+	stub := fake.SizeBytesStub
+	fakeReturns := fake.sizeBytesReturns
+	fake.recordInvocation("SizeBytes", []interface{}{})
+	fake.sizeBytesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedVolume) SizeBytesCallCount() int {
+	fake.sizeBytesMutex.RLock()
+	defer fake.sizeBytesMutex.RUnlock()
+	return len(fake.sizeBytesArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) SizeBytesCalls(stub func() uint64) {
+	fake.sizeBytesMutex.Lock()
+	defer fake.sizeBytesMutex.Unlock()
+	fake.SizeBytesStub = stub
+}
+
+func (fake *FakeCreatedVolume) SizeBytesReturns(result1 uint64) {
+	fake.sizeBytesMutex.Lock()
+	defer fake.sizeBytesMutex.Unlock()
+	fake.SizeBytesStub = nil
+	fake.sizeBytesReturns = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) SizeBytesReturnsOnCall(i int, result1 uint64) {
+	fake.sizeBytesMutex.Lock()
+	defer fake.sizeBytesMutex.Unlock()
+	fake.SizeBytesStub = nil
+	if fake.sizeBytesReturnsOnCall == nil {
+		fake.sizeBytesReturnsOnCall = make(map[int]struct {
+			result1 uint64
+		})
+	}
+	fake.sizeBytesReturnsOnCall[i] = struct {
+		result1 uint64
+	}{result1}
+}
+
 func (fake *FakeCreatedVolume) TaskIdentifier() (int, atc.PipelineRef, string, string, error) {
 	fake.taskIdentifierMutex.Lock()
 	ret, specificReturn := fake.taskIdentifierReturnsOnCall[len(fake.taskIdentifierArgsForCall)]
@@ -1256,6 +1383,8 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.containerHandleMutex.RUnlock()
 	fake.createChildForContainerMutex.RLock()
 	defer fake.createChildForContainerMutex.RUnlock()
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
 	fake.destroyingMutex.RLock()
 	defer fake.destroyingMutex.RUnlock()
 	fake.getResourceCacheIDMutex.RLock()
@@ -1276,6 +1405,8 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.pathMutex.RUnlock()
 	fake.resourceTypeMutex.RLock()
 	defer fake.resourceTypeMutex.RUnlock()
+	fake.sizeBytesMutex.RLock()
+	defer fake.sizeBytesMutex.RUnlock()
 	fake.taskIdentifierMutex.RLock()
 	defer fake.taskIdentifierMutex.RUnlock()
 	fake.teamIDMutex.RLock()
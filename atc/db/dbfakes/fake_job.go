@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/vars"
 )
 
 type FakeJob struct {
@@ -28,6 +29,26 @@ type FakeJob struct {
 		result2 bool
 		result3 error
 	}
+	AfterFinishedTimeStub        func() time.Time
+	afterFinishedTimeMutex       sync.RWMutex
+	afterFinishedTimeArgsForCall []struct {
+	}
+	afterFinishedTimeReturns struct {
+		result1 time.Time
+	}
+	afterFinishedTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	AfterTriggeredTimeStub        func() time.Time
+	afterTriggeredTimeMutex       sync.RWMutex
+	afterTriggeredTimeArgsForCall []struct {
+	}
+	afterTriggeredTimeReturns struct {
+		result1 time.Time
+	}
+	afterTriggeredTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	AlgorithmInputsStub        func() (db.InputConfigs, error)
 	algorithmInputsMutex       sync.RWMutex
 	algorithmInputsArgsForCall []struct {
@@ -124,6 +145,48 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateBuildWithExplicitInputsStub        func(string, string, []db.ExplicitBuildInput) (db.Build, error)
+	createBuildWithExplicitInputsMutex       sync.RWMutex
+	createBuildWithExplicitInputsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []db.ExplicitBuildInput
+	}
+	createBuildWithExplicitInputsReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithExplicitInputsReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	CreateBuildWithIdempotencyKeyStub        func(string, string) (db.Build, error)
+	createBuildWithIdempotencyKeyMutex       sync.RWMutex
+	createBuildWithIdempotencyKeyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	createBuildWithIdempotencyKeyReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithIdempotencyKeyReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	CreateScheduledBuildStub        func(string) (db.Build, error)
+	createScheduledBuildMutex       sync.RWMutex
+	createScheduledBuildArgsForCall []struct {
+		arg1 string
+	}
+	createScheduledBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createScheduledBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	DisableManualTriggerStub        func() bool
 	disableManualTriggerMutex       sync.RWMutex
 	disableManualTriggerArgsForCall []struct {
@@ -271,9 +334,11 @@ type FakeJob struct {
 		result1 []atc.JobOutput
 		result2 error
 	}
-	PauseStub        func() error
+	PauseStub        func(string, string) error
 	pauseMutex       sync.RWMutex
 	pauseArgsForCall []struct {
+		arg1 string
+		arg2 string
 	}
 	pauseReturns struct {
 		result1 error
@@ -281,6 +346,16 @@ type FakeJob struct {
 	pauseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PauseReasonStub        func() string
+	pauseReasonMutex       sync.RWMutex
+	pauseReasonArgsForCall []struct {
+	}
+	pauseReasonReturns struct {
+		result1 string
+	}
+	pauseReasonReturnsOnCall map[int]struct {
+		result1 string
+	}
 	PausedStub        func() bool
 	pausedMutex       sync.RWMutex
 	pausedArgsForCall []struct {
@@ -291,6 +366,26 @@ type FakeJob struct {
 	pausedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	PausedAtStub        func() time.Time
+	pausedAtMutex       sync.RWMutex
+	pausedAtArgsForCall []struct {
+	}
+	pausedAtReturns struct {
+		result1 time.Time
+	}
+	pausedAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	PausedByStub        func() string
+	pausedByMutex       sync.RWMutex
+	pausedByArgsForCall []struct {
+	}
+	pausedByReturns struct {
+		result1 string
+	}
+	pausedByReturnsOnCall map[int]struct {
+		result1 string
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -345,6 +440,16 @@ type FakeJob struct {
 	pipelineRefReturnsOnCall map[int]struct {
 		result1 atc.PipelineRef
 	}
+	PriorityStub        func() int
+	priorityMutex       sync.RWMutex
+	priorityArgsForCall []struct {
+	}
+	priorityReturns struct {
+		result1 int
+	}
+	priorityReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PublicStub        func() bool
 	publicMutex       sync.RWMutex
 	publicArgsForCall []struct {
@@ -391,6 +496,21 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	RerunBuildWithVarsStub        func(db.Build, string, vars.StaticVariables) (db.Build, error)
+	rerunBuildWithVarsMutex       sync.RWMutex
+	rerunBuildWithVarsArgsForCall []struct {
+		arg1 db.Build
+		arg2 string
+		arg3 vars.StaticVariables
+	}
+	rerunBuildWithVarsReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	rerunBuildWithVarsReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	SaveNextInputMappingStub        func(db.InputMapping, bool) error
 	saveNextInputMappingMutex       sync.RWMutex
 	saveNextInputMappingArgsForCall []struct {
@@ -403,6 +523,26 @@ type FakeJob struct {
 	saveNextInputMappingReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ScheduleStub        func() *atc.JobScheduleConfig
+	scheduleMutex       sync.RWMutex
+	scheduleArgsForCall []struct {
+	}
+	scheduleReturns struct {
+		result1 *atc.JobScheduleConfig
+	}
+	scheduleReturnsOnCall map[int]struct {
+		result1 *atc.JobScheduleConfig
+	}
+	ScheduleAnchorStub        func() time.Time
+	scheduleAnchorMutex       sync.RWMutex
+	scheduleAnchorArgsForCall []struct {
+	}
+	scheduleAnchorReturns struct {
+		result1 time.Time
+	}
+	scheduleAnchorReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	ScheduleBuildStub        func(db.Build) (bool, error)
 	scheduleBuildMutex       sync.RWMutex
 	scheduleBuildArgsForCall []struct {
@@ -437,6 +577,19 @@ type FakeJob struct {
 	setHasNewInputsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StatisticsStub        func(int) (db.JobStatistics, error)
+	statisticsMutex       sync.RWMutex
+	statisticsArgsForCall []struct {
+		arg1 int
+	}
+	statisticsReturns struct {
+		result1 db.JobStatistics
+		result2 error
+	}
+	statisticsReturnsOnCall map[int]struct {
+		result1 db.JobStatistics
+		result2 error
+	}
 	TagsStub        func() []string
 	tagsMutex       sync.RWMutex
 	tagsArgsForCall []struct {
@@ -477,6 +630,17 @@ type FakeJob struct {
 	unpauseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateAfterTriggeredStub        func(time.Time) error
+	updateAfterTriggeredMutex       sync.RWMutex
+	updateAfterTriggeredArgsForCall []struct {
+		arg1 time.Time
+	}
+	updateAfterTriggeredReturns struct {
+		result1 error
+	}
+	updateAfterTriggeredReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateFirstLoggedBuildIDStub        func(int) error
 	updateFirstLoggedBuildIDMutex       sync.RWMutex
 	updateFirstLoggedBuildIDArgsForCall []struct {
@@ -499,6 +663,46 @@ type FakeJob struct {
 	updateLastScheduledReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateScheduleAnchorStub        func(time.Time) error
+	updateScheduleAnchorMutex       sync.RWMutex
+	updateScheduleAnchorArgsForCall []struct {
+		arg1 time.Time
+	}
+	updateScheduleAnchorReturns struct {
+		result1 error
+	}
+	updateScheduleAnchorReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VersionPassedJobsStub        func(int, atc.Version, []string) (bool, error)
+	versionPassedJobsMutex       sync.RWMutex
+	versionPassedJobsArgsForCall []struct {
+		arg1 int
+		arg2 atc.Version
+		arg3 []string
+	}
+	versionPassedJobsReturns struct {
+		result1 bool
+		result2 error
+	}
+	versionPassedJobsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	VersionsSinceLastSuccessStub        func(string, int) ([]atc.Version, error)
+	versionsSinceLastSuccessMutex       sync.RWMutex
+	versionsSinceLastSuccessArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	versionsSinceLastSuccessReturns struct {
+		result1 []atc.Version
+		result2 error
+	}
+	versionsSinceLastSuccessReturnsOnCall map[int]struct {
+		result1 []atc.Version
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -570,6 +774,112 @@ func (fake *FakeJob) AcquireSchedulingLockReturnsOnCall(i int, result1 lock.Lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJob) AfterFinishedTime() time.Time {
+	fake.afterFinishedTimeMutex.Lock()
+	ret, specificReturn := fake.afterFinishedTimeReturnsOnCall[len(fake.afterFinishedTimeArgsForCall)]
+	fake.afterFinishedTimeArgsForCall = append(fake.afterFinishedTimeArgsForCall, struct {
+	}{})
+	stub := fake.AfterFinishedTimeStub
+	fakeReturns := fake.afterFinishedTimeReturns
+	fake.recordInvocation("AfterFinishedTime", []interface{}{})
+	fake.afterFinishedTimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) AfterFinishedTimeCallCount() int {
+	fake.afterFinishedTimeMutex.RLock()
+	defer fake.afterFinishedTimeMutex.RUnlock()
+	return len(fake.afterFinishedTimeArgsForCall)
+}
+
+func (fake *FakeJob) AfterFinishedTimeCalls(stub func() time.Time) {
+	fake.afterFinishedTimeMutex.Lock()
+	defer fake.afterFinishedTimeMutex.Unlock()
+	fake.AfterFinishedTimeStub = stub
+}
+
+func (fake *FakeJob) AfterFinishedTimeReturns(result1 time.Time) {
+	fake.afterFinishedTimeMutex.Lock()
+	defer fake.afterFinishedTimeMutex.Unlock()
+	fake.AfterFinishedTimeStub = nil
+	fake.afterFinishedTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) AfterFinishedTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.afterFinishedTimeMutex.Lock()
+	defer fake.afterFinishedTimeMutex.Unlock()
+	fake.AfterFinishedTimeStub = nil
+	if fake.afterFinishedTimeReturnsOnCall == nil {
+		fake.afterFinishedTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.afterFinishedTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) AfterTriggeredTime() time.Time {
+	fake.afterTriggeredTimeMutex.Lock()
+	ret, specificReturn := fake.afterTriggeredTimeReturnsOnCall[len(fake.afterTriggeredTimeArgsForCall)]
+	fake.afterTriggeredTimeArgsForCall = append(fake.afterTriggeredTimeArgsForCall, struct {
+	}{})
+	stub := fake.AfterTriggeredTimeStub
+	fakeReturns := fake.afterTriggeredTimeReturns
+	fake.recordInvocation("AfterTriggeredTime", []interface{}{})
+	fake.afterTriggeredTimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) AfterTriggeredTimeCallCount() int {
+	fake.afterTriggeredTimeMutex.RLock()
+	defer fake.afterTriggeredTimeMutex.RUnlock()
+	return len(fake.afterTriggeredTimeArgsForCall)
+}
+
+func (fake *FakeJob) AfterTriggeredTimeCalls(stub func() time.Time) {
+	fake.afterTriggeredTimeMutex.Lock()
+	defer fake.afterTriggeredTimeMutex.Unlock()
+	fake.AfterTriggeredTimeStub = stub
+}
+
+func (fake *FakeJob) AfterTriggeredTimeReturns(result1 time.Time) {
+	fake.afterTriggeredTimeMutex.Lock()
+	defer fake.afterTriggeredTimeMutex.Unlock()
+	fake.AfterTriggeredTimeStub = nil
+	fake.afterTriggeredTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) AfterTriggeredTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.afterTriggeredTimeMutex.Lock()
+	defer fake.afterTriggeredTimeMutex.Unlock()
+	fake.AfterTriggeredTimeStub = nil
+	if fake.afterTriggeredTimeReturnsOnCall == nil {
+		fake.afterTriggeredTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.afterTriggeredTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeJob) AlgorithmInputs() (db.InputConfigs, error) {
 	fake.algorithmInputsMutex.Lock()
 	ret, specificReturn := fake.algorithmInputsReturnsOnCall[len(fake.algorithmInputsArgsForCall)]
@@ -1012,6 +1322,206 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateBuildWithExplicitInputs(arg1 string, arg2 string, arg3 []db.ExplicitBuildInput) (db.Build, error) {
+	var arg3Copy []db.ExplicitBuildInput
+	if arg3 != nil {
+		arg3Copy = make([]db.ExplicitBuildInput, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.createBuildWithExplicitInputsMutex.Lock()
+	ret, specificReturn := fake.createBuildWithExplicitInputsReturnsOnCall[len(fake.createBuildWithExplicitInputsArgsForCall)]
+	fake.createBuildWithExplicitInputsArgsForCall = append(fake.createBuildWithExplicitInputsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []db.ExplicitBuildInput
+	}{arg1, arg2, arg3Copy})
+	stub := fake.CreateBuildWithExplicitInputsStub
+	fakeReturns := fake.createBuildWithExplicitInputsReturns
+	fake.recordInvocation("CreateBuildWithExplicitInputs", []interface{}{arg1, arg2, arg3Copy})
+	fake.createBuildWithExplicitInputsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithExplicitInputsCallCount() int {
+	fake.createBuildWithExplicitInputsMutex.RLock()
+	defer fake.createBuildWithExplicitInputsMutex.RUnlock()
+	return len(fake.createBuildWithExplicitInputsArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithExplicitInputsCalls(stub func(string, string, []db.ExplicitBuildInput) (db.Build, error)) {
+	fake.createBuildWithExplicitInputsMutex.Lock()
+	defer fake.createBuildWithExplicitInputsMutex.Unlock()
+	fake.CreateBuildWithExplicitInputsStub = stub
+}
+
+func (fake *FakeJob) CreateBuildWithExplicitInputsArgsForCall(i int) (string, string, []db.ExplicitBuildInput) {
+	fake.createBuildWithExplicitInputsMutex.RLock()
+	defer fake.createBuildWithExplicitInputsMutex.RUnlock()
+	argsForCall := fake.createBuildWithExplicitInputsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeJob) CreateBuildWithExplicitInputsReturns(result1 db.Build, result2 error) {
+	fake.createBuildWithExplicitInputsMutex.Lock()
+	defer fake.createBuildWithExplicitInputsMutex.Unlock()
+	fake.CreateBuildWithExplicitInputsStub = nil
+	fake.createBuildWithExplicitInputsReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithExplicitInputsReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createBuildWithExplicitInputsMutex.Lock()
+	defer fake.createBuildWithExplicitInputsMutex.Unlock()
+	fake.CreateBuildWithExplicitInputsStub = nil
+	if fake.createBuildWithExplicitInputsReturnsOnCall == nil {
+		fake.createBuildWithExplicitInputsReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithExplicitInputsReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithIdempotencyKey(arg1 string, arg2 string) (db.Build, error) {
+	fake.createBuildWithIdempotencyKeyMutex.Lock()
+	ret, specificReturn := fake.createBuildWithIdempotencyKeyReturnsOnCall[len(fake.createBuildWithIdempotencyKeyArgsForCall)]
+	fake.createBuildWithIdempotencyKeyArgsForCall = append(fake.createBuildWithIdempotencyKeyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CreateBuildWithIdempotencyKeyStub
+	fakeReturns := fake.createBuildWithIdempotencyKeyReturns
+	fake.recordInvocation("CreateBuildWithIdempotencyKey", []interface{}{arg1, arg2})
+	fake.createBuildWithIdempotencyKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithIdempotencyKeyCallCount() int {
+	fake.createBuildWithIdempotencyKeyMutex.RLock()
+	defer fake.createBuildWithIdempotencyKeyMutex.RUnlock()
+	return len(fake.createBuildWithIdempotencyKeyArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithIdempotencyKeyCalls(stub func(string, string) (db.Build, error)) {
+	fake.createBuildWithIdempotencyKeyMutex.Lock()
+	defer fake.createBuildWithIdempotencyKeyMutex.Unlock()
+	fake.CreateBuildWithIdempotencyKeyStub = stub
+}
+
+func (fake *FakeJob) CreateBuildWithIdempotencyKeyArgsForCall(i int) (string, string) {
+	fake.createBuildWithIdempotencyKeyMutex.RLock()
+	defer fake.createBuildWithIdempotencyKeyMutex.RUnlock()
+	argsForCall := fake.createBuildWithIdempotencyKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) CreateBuildWithIdempotencyKeyReturns(result1 db.Build, result2 error) {
+	fake.createBuildWithIdempotencyKeyMutex.Lock()
+	defer fake.createBuildWithIdempotencyKeyMutex.Unlock()
+	fake.CreateBuildWithIdempotencyKeyStub = nil
+	fake.createBuildWithIdempotencyKeyReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithIdempotencyKeyReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createBuildWithIdempotencyKeyMutex.Lock()
+	defer fake.createBuildWithIdempotencyKeyMutex.Unlock()
+	fake.CreateBuildWithIdempotencyKeyStub = nil
+	if fake.createBuildWithIdempotencyKeyReturnsOnCall == nil {
+		fake.createBuildWithIdempotencyKeyReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithIdempotencyKeyReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateScheduledBuild(arg1 string) (db.Build, error) {
+	fake.createScheduledBuildMutex.Lock()
+	ret, specificReturn := fake.createScheduledBuildReturnsOnCall[len(fake.createScheduledBuildArgsForCall)]
+	fake.createScheduledBuildArgsForCall = append(fake.createScheduledBuildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CreateScheduledBuildStub
+	fakeReturns := fake.createScheduledBuildReturns
+	fake.recordInvocation("CreateScheduledBuild", []interface{}{arg1})
+	fake.createScheduledBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateScheduledBuildCallCount() int {
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	return len(fake.createScheduledBuildArgsForCall)
+}
+
+func (fake *FakeJob) CreateScheduledBuildCalls(stub func(string) (db.Build, error)) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = stub
+}
+
+func (fake *FakeJob) CreateScheduledBuildArgsForCall(i int) string {
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	argsForCall := fake.createScheduledBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) CreateScheduledBuildReturns(result1 db.Build, result2 error) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = nil
+	fake.createScheduledBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateScheduledBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = nil
+	if fake.createScheduledBuildReturnsOnCall == nil {
+		fake.createScheduledBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createScheduledBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) DisableManualTrigger() bool {
 	fake.disableManualTriggerMutex.Lock()
 	ret, specificReturn := fake.disableManualTriggerReturnsOnCall[len(fake.disableManualTriggerArgsForCall)]
@@ -1733,17 +2243,19 @@ func (fake *FakeJob) OutputsReturnsOnCall(i int, result1 []atc.JobOutput, result
 	}{result1, result2}
 }
 
-func (fake *FakeJob) Pause() error {
+func (fake *FakeJob) Pause(arg1 string, arg2 string) error {
 	fake.pauseMutex.Lock()
 	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
 	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
-	}{})
-	stub := fake.PauseStub
-	fakeReturns := fake.pauseReturns
-	fake.recordInvocation("Pause", []interface{}{})
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.PauseStub
+	fakeReturns := fake.pauseReturns
+	fake.recordInvocation("Pause", []interface{}{arg1, arg2})
 	fake.pauseMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1757,12 +2269,19 @@ func (fake *FakeJob) PauseCallCount() int {
 	return len(fake.pauseArgsForCall)
 }
 
-func (fake *FakeJob) PauseCalls(stub func() error) {
+func (fake *FakeJob) PauseCalls(stub func(string, string) error) {
 	fake.pauseMutex.Lock()
 	defer fake.pauseMutex.Unlock()
 	fake.PauseStub = stub
 }
 
+func (fake *FakeJob) PauseArgsForCall(i int) (string, string) {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	argsForCall := fake.pauseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeJob) PauseReturns(result1 error) {
 	fake.pauseMutex.Lock()
 	defer fake.pauseMutex.Unlock()
@@ -1786,6 +2305,59 @@ func (fake *FakeJob) PauseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) PauseReason() string {
+	fake.pauseReasonMutex.Lock()
+	ret, specificReturn := fake.pauseReasonReturnsOnCall[len(fake.pauseReasonArgsForCall)]
+	fake.pauseReasonArgsForCall = append(fake.pauseReasonArgsForCall, struct {
+	}{})
+	stub := fake.PauseReasonStub
+	fakeReturns := fake.pauseReasonReturns
+	fake.recordInvocation("PauseReason", []interface{}{})
+	fake.pauseReasonMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) PauseReasonCallCount() int {
+	fake.pauseReasonMutex.RLock()
+	defer fake.pauseReasonMutex.RUnlock()
+	return len(fake.pauseReasonArgsForCall)
+}
+
+func (fake *FakeJob) PauseReasonCalls(stub func() string) {
+	fake.pauseReasonMutex.Lock()
+	defer fake.pauseReasonMutex.Unlock()
+	fake.PauseReasonStub = stub
+}
+
+func (fake *FakeJob) PauseReasonReturns(result1 string) {
+	fake.pauseReasonMutex.Lock()
+	defer fake.pauseReasonMutex.Unlock()
+	fake.PauseReasonStub = nil
+	fake.pauseReasonReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeJob) PauseReasonReturnsOnCall(i int, result1 string) {
+	fake.pauseReasonMutex.Lock()
+	defer fake.pauseReasonMutex.Unlock()
+	fake.PauseReasonStub = nil
+	if fake.pauseReasonReturnsOnCall == nil {
+		fake.pauseReasonReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.pauseReasonReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeJob) Paused() bool {
 	fake.pausedMutex.Lock()
 	ret, specificReturn := fake.pausedReturnsOnCall[len(fake.pausedArgsForCall)]
@@ -1839,6 +2411,112 @@ func (fake *FakeJob) PausedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeJob) PausedAt() time.Time {
+	fake.pausedAtMutex.Lock()
+	ret, specificReturn := fake.pausedAtReturnsOnCall[len(fake.pausedAtArgsForCall)]
+	fake.pausedAtArgsForCall = append(fake.pausedAtArgsForCall, struct {
+	}{})
+	stub := fake.PausedAtStub
+	fakeReturns := fake.pausedAtReturns
+	fake.recordInvocation("PausedAt", []interface{}{})
+	fake.pausedAtMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) PausedAtCallCount() int {
+	fake.pausedAtMutex.RLock()
+	defer fake.pausedAtMutex.RUnlock()
+	return len(fake.pausedAtArgsForCall)
+}
+
+func (fake *FakeJob) PausedAtCalls(stub func() time.Time) {
+	fake.pausedAtMutex.Lock()
+	defer fake.pausedAtMutex.Unlock()
+	fake.PausedAtStub = stub
+}
+
+func (fake *FakeJob) PausedAtReturns(result1 time.Time) {
+	fake.pausedAtMutex.Lock()
+	defer fake.pausedAtMutex.Unlock()
+	fake.PausedAtStub = nil
+	fake.pausedAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) PausedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.pausedAtMutex.Lock()
+	defer fake.pausedAtMutex.Unlock()
+	fake.PausedAtStub = nil
+	if fake.pausedAtReturnsOnCall == nil {
+		fake.pausedAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.pausedAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) PausedBy() string {
+	fake.pausedByMutex.Lock()
+	ret, specificReturn := fake.pausedByReturnsOnCall[len(fake.pausedByArgsForCall)]
+	fake.pausedByArgsForCall = append(fake.pausedByArgsForCall, struct {
+	}{})
+	stub := fake.PausedByStub
+	fakeReturns := fake.pausedByReturns
+	fake.recordInvocation("PausedBy", []interface{}{})
+	fake.pausedByMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) PausedByCallCount() int {
+	fake.pausedByMutex.RLock()
+	defer fake.pausedByMutex.RUnlock()
+	return len(fake.pausedByArgsForCall)
+}
+
+func (fake *FakeJob) PausedByCalls(stub func() string) {
+	fake.pausedByMutex.Lock()
+	defer fake.pausedByMutex.Unlock()
+	fake.PausedByStub = stub
+}
+
+func (fake *FakeJob) PausedByReturns(result1 string) {
+	fake.pausedByMutex.Lock()
+	defer fake.pausedByMutex.Unlock()
+	fake.PausedByStub = nil
+	fake.pausedByReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeJob) PausedByReturnsOnCall(i int, result1 string) {
+	fake.pausedByMutex.Lock()
+	defer fake.pausedByMutex.Unlock()
+	fake.PausedByStub = nil
+	if fake.pausedByReturnsOnCall == nil {
+		fake.pausedByReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.pausedByReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeJob) Pipeline() (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
@@ -2110,6 +2788,59 @@ func (fake *FakeJob) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRef) {
 	}{result1}
 }
 
+func (fake *FakeJob) Priority() int {
+	fake.priorityMutex.Lock()
+	ret, specificReturn := fake.priorityReturnsOnCall[len(fake.priorityArgsForCall)]
+	fake.priorityArgsForCall = append(fake.priorityArgsForCall, struct {
+	}{})
+	stub := fake.PriorityStub
+	fakeReturns := fake.priorityReturns
+	fake.recordInvocation("Priority", []interface{}{})
+	fake.priorityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) PriorityCallCount() int {
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
+	return len(fake.priorityArgsForCall)
+}
+
+func (fake *FakeJob) PriorityCalls(stub func() int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = stub
+}
+
+func (fake *FakeJob) PriorityReturns(result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	fake.priorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeJob) PriorityReturnsOnCall(i int, result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	if fake.priorityReturnsOnCall == nil {
+		fake.priorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.priorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeJob) Public() bool {
 	fake.publicMutex.Lock()
 	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
@@ -2337,6 +3068,72 @@ func (fake *FakeJob) RerunBuildReturnsOnCall(i int, result1 db.Build, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeJob) RerunBuildWithVars(arg1 db.Build, arg2 string, arg3 vars.StaticVariables) (db.Build, error) {
+	fake.rerunBuildWithVarsMutex.Lock()
+	ret, specificReturn := fake.rerunBuildWithVarsReturnsOnCall[len(fake.rerunBuildWithVarsArgsForCall)]
+	fake.rerunBuildWithVarsArgsForCall = append(fake.rerunBuildWithVarsArgsForCall, struct {
+		arg1 db.Build
+		arg2 string
+		arg3 vars.StaticVariables
+	}{arg1, arg2, arg3})
+	stub := fake.RerunBuildWithVarsStub
+	fakeReturns := fake.rerunBuildWithVarsReturns
+	fake.recordInvocation("RerunBuildWithVars", []interface{}{arg1, arg2, arg3})
+	fake.rerunBuildWithVarsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) RerunBuildWithVarsCallCount() int {
+	fake.rerunBuildWithVarsMutex.RLock()
+	defer fake.rerunBuildWithVarsMutex.RUnlock()
+	return len(fake.rerunBuildWithVarsArgsForCall)
+}
+
+func (fake *FakeJob) RerunBuildWithVarsCalls(stub func(db.Build, string, vars.StaticVariables) (db.Build, error)) {
+	fake.rerunBuildWithVarsMutex.Lock()
+	defer fake.rerunBuildWithVarsMutex.Unlock()
+	fake.RerunBuildWithVarsStub = stub
+}
+
+func (fake *FakeJob) RerunBuildWithVarsArgsForCall(i int) (db.Build, string, vars.StaticVariables) {
+	fake.rerunBuildWithVarsMutex.RLock()
+	defer fake.rerunBuildWithVarsMutex.RUnlock()
+	argsForCall := fake.rerunBuildWithVarsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeJob) RerunBuildWithVarsReturns(result1 db.Build, result2 error) {
+	fake.rerunBuildWithVarsMutex.Lock()
+	defer fake.rerunBuildWithVarsMutex.Unlock()
+	fake.RerunBuildWithVarsStub = nil
+	fake.rerunBuildWithVarsReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) RerunBuildWithVarsReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.rerunBuildWithVarsMutex.Lock()
+	defer fake.rerunBuildWithVarsMutex.Unlock()
+	fake.RerunBuildWithVarsStub = nil
+	if fake.rerunBuildWithVarsReturnsOnCall == nil {
+		fake.rerunBuildWithVarsReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.rerunBuildWithVarsReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) SaveNextInputMapping(arg1 db.InputMapping, arg2 bool) error {
 	fake.saveNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
@@ -2399,6 +3196,112 @@ func (fake *FakeJob) SaveNextInputMappingReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) Schedule() *atc.JobScheduleConfig {
+	fake.scheduleMutex.Lock()
+	ret, specificReturn := fake.scheduleReturnsOnCall[len(fake.scheduleArgsForCall)]
+	fake.scheduleArgsForCall = append(fake.scheduleArgsForCall, struct {
+	}{})
+	stub := fake.ScheduleStub
+	fakeReturns := fake.scheduleReturns
+	fake.recordInvocation("Schedule", []interface{}{})
+	fake.scheduleMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) ScheduleCallCount() int {
+	fake.scheduleMutex.RLock()
+	defer fake.scheduleMutex.RUnlock()
+	return len(fake.scheduleArgsForCall)
+}
+
+func (fake *FakeJob) ScheduleCalls(stub func() *atc.JobScheduleConfig) {
+	fake.scheduleMutex.Lock()
+	defer fake.scheduleMutex.Unlock()
+	fake.ScheduleStub = stub
+}
+
+func (fake *FakeJob) ScheduleReturns(result1 *atc.JobScheduleConfig) {
+	fake.scheduleMutex.Lock()
+	defer fake.scheduleMutex.Unlock()
+	fake.ScheduleStub = nil
+	fake.scheduleReturns = struct {
+		result1 *atc.JobScheduleConfig
+	}{result1}
+}
+
+func (fake *FakeJob) ScheduleReturnsOnCall(i int, result1 *atc.JobScheduleConfig) {
+	fake.scheduleMutex.Lock()
+	defer fake.scheduleMutex.Unlock()
+	fake.ScheduleStub = nil
+	if fake.scheduleReturnsOnCall == nil {
+		fake.scheduleReturnsOnCall = make(map[int]struct {
+			result1 *atc.JobScheduleConfig
+		})
+	}
+	fake.scheduleReturnsOnCall[i] = struct {
+		result1 *atc.JobScheduleConfig
+	}{result1}
+}
+
+func (fake *FakeJob) ScheduleAnchor() time.Time {
+	fake.scheduleAnchorMutex.Lock()
+	ret, specificReturn := fake.scheduleAnchorReturnsOnCall[len(fake.scheduleAnchorArgsForCall)]
+	fake.scheduleAnchorArgsForCall = append(fake.scheduleAnchorArgsForCall, struct {
+	}{})
+	stub := fake.ScheduleAnchorStub
+	fakeReturns := fake.scheduleAnchorReturns
+	fake.recordInvocation("ScheduleAnchor", []interface{}{})
+	fake.scheduleAnchorMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) ScheduleAnchorCallCount() int {
+	fake.scheduleAnchorMutex.RLock()
+	defer fake.scheduleAnchorMutex.RUnlock()
+	return len(fake.scheduleAnchorArgsForCall)
+}
+
+func (fake *FakeJob) ScheduleAnchorCalls(stub func() time.Time) {
+	fake.scheduleAnchorMutex.Lock()
+	defer fake.scheduleAnchorMutex.Unlock()
+	fake.ScheduleAnchorStub = stub
+}
+
+func (fake *FakeJob) ScheduleAnchorReturns(result1 time.Time) {
+	fake.scheduleAnchorMutex.Lock()
+	defer fake.scheduleAnchorMutex.Unlock()
+	fake.ScheduleAnchorStub = nil
+	fake.scheduleAnchorReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) ScheduleAnchorReturnsOnCall(i int, result1 time.Time) {
+	fake.scheduleAnchorMutex.Lock()
+	defer fake.scheduleAnchorMutex.Unlock()
+	fake.ScheduleAnchorStub = nil
+	if fake.scheduleAnchorReturnsOnCall == nil {
+		fake.scheduleAnchorReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.scheduleAnchorReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeJob) ScheduleBuild(arg1 db.Build) (bool, error) {
 	fake.scheduleBuildMutex.Lock()
 	ret, specificReturn := fake.scheduleBuildReturnsOnCall[len(fake.scheduleBuildArgsForCall)]
@@ -2577,6 +3480,70 @@ func (fake *FakeJob) SetHasNewInputsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) Statistics(arg1 int) (db.JobStatistics, error) {
+	fake.statisticsMutex.Lock()
+	ret, specificReturn := fake.statisticsReturnsOnCall[len(fake.statisticsArgsForCall)]
+	fake.statisticsArgsForCall = append(fake.statisticsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.StatisticsStub
+	fakeReturns := fake.statisticsReturns
+	fake.recordInvocation("Statistics", []interface{}{arg1})
+	fake.statisticsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) StatisticsCallCount() int {
+	fake.statisticsMutex.RLock()
+	defer fake.statisticsMutex.RUnlock()
+	return len(fake.statisticsArgsForCall)
+}
+
+func (fake *FakeJob) StatisticsCalls(stub func(int) (db.JobStatistics, error)) {
+	fake.statisticsMutex.Lock()
+	defer fake.statisticsMutex.Unlock()
+	fake.StatisticsStub = stub
+}
+
+func (fake *FakeJob) StatisticsArgsForCall(i int) int {
+	fake.statisticsMutex.RLock()
+	defer fake.statisticsMutex.RUnlock()
+	argsForCall := fake.statisticsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) StatisticsReturns(result1 db.JobStatistics, result2 error) {
+	fake.statisticsMutex.Lock()
+	defer fake.statisticsMutex.Unlock()
+	fake.StatisticsStub = nil
+	fake.statisticsReturns = struct {
+		result1 db.JobStatistics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) StatisticsReturnsOnCall(i int, result1 db.JobStatistics, result2 error) {
+	fake.statisticsMutex.Lock()
+	defer fake.statisticsMutex.Unlock()
+	fake.StatisticsStub = nil
+	if fake.statisticsReturnsOnCall == nil {
+		fake.statisticsReturnsOnCall = make(map[int]struct {
+			result1 db.JobStatistics
+			result2 error
+		})
+	}
+	fake.statisticsReturnsOnCall[i] = struct {
+		result1 db.JobStatistics
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Tags() []string {
 	fake.tagsMutex.Lock()
 	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
@@ -2789,6 +3756,67 @@ func (fake *FakeJob) UnpauseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) UpdateAfterTriggered(arg1 time.Time) error {
+	fake.updateAfterTriggeredMutex.Lock()
+	ret, specificReturn := fake.updateAfterTriggeredReturnsOnCall[len(fake.updateAfterTriggeredArgsForCall)]
+	fake.updateAfterTriggeredArgsForCall = append(fake.updateAfterTriggeredArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	stub := fake.UpdateAfterTriggeredStub
+	fakeReturns := fake.updateAfterTriggeredReturns
+	fake.recordInvocation("UpdateAfterTriggered", []interface{}{arg1})
+	fake.updateAfterTriggeredMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) UpdateAfterTriggeredCallCount() int {
+	fake.updateAfterTriggeredMutex.RLock()
+	defer fake.updateAfterTriggeredMutex.RUnlock()
+	return len(fake.updateAfterTriggeredArgsForCall)
+}
+
+func (fake *FakeJob) UpdateAfterTriggeredCalls(stub func(time.Time) error) {
+	fake.updateAfterTriggeredMutex.Lock()
+	defer fake.updateAfterTriggeredMutex.Unlock()
+	fake.UpdateAfterTriggeredStub = stub
+}
+
+func (fake *FakeJob) UpdateAfterTriggeredArgsForCall(i int) time.Time {
+	fake.updateAfterTriggeredMutex.RLock()
+	defer fake.updateAfterTriggeredMutex.RUnlock()
+	argsForCall := fake.updateAfterTriggeredArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) UpdateAfterTriggeredReturns(result1 error) {
+	fake.updateAfterTriggeredMutex.Lock()
+	defer fake.updateAfterTriggeredMutex.Unlock()
+	fake.UpdateAfterTriggeredStub = nil
+	fake.updateAfterTriggeredReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) UpdateAfterTriggeredReturnsOnCall(i int, result1 error) {
+	fake.updateAfterTriggeredMutex.Lock()
+	defer fake.updateAfterTriggeredMutex.Unlock()
+	fake.UpdateAfterTriggeredStub = nil
+	if fake.updateAfterTriggeredReturnsOnCall == nil {
+		fake.updateAfterTriggeredReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateAfterTriggeredReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeJob) UpdateFirstLoggedBuildID(arg1 int) error {
 	fake.updateFirstLoggedBuildIDMutex.Lock()
 	ret, specificReturn := fake.updateFirstLoggedBuildIDReturnsOnCall[len(fake.updateFirstLoggedBuildIDArgsForCall)]
@@ -2911,11 +3939,212 @@ func (fake *FakeJob) UpdateLastScheduledReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) UpdateScheduleAnchor(arg1 time.Time) error {
+	fake.updateScheduleAnchorMutex.Lock()
+	ret, specificReturn := fake.updateScheduleAnchorReturnsOnCall[len(fake.updateScheduleAnchorArgsForCall)]
+	fake.updateScheduleAnchorArgsForCall = append(fake.updateScheduleAnchorArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	stub := fake.UpdateScheduleAnchorStub
+	fakeReturns := fake.updateScheduleAnchorReturns
+	fake.recordInvocation("UpdateScheduleAnchor", []interface{}{arg1})
+	fake.updateScheduleAnchorMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) UpdateScheduleAnchorCallCount() int {
+	fake.updateScheduleAnchorMutex.RLock()
+	defer fake.updateScheduleAnchorMutex.RUnlock()
+	return len(fake.updateScheduleAnchorArgsForCall)
+}
+
+func (fake *FakeJob) UpdateScheduleAnchorCalls(stub func(time.Time) error) {
+	fake.updateScheduleAnchorMutex.Lock()
+	defer fake.updateScheduleAnchorMutex.Unlock()
+	fake.UpdateScheduleAnchorStub = stub
+}
+
+func (fake *FakeJob) UpdateScheduleAnchorArgsForCall(i int) time.Time {
+	fake.updateScheduleAnchorMutex.RLock()
+	defer fake.updateScheduleAnchorMutex.RUnlock()
+	argsForCall := fake.updateScheduleAnchorArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) UpdateScheduleAnchorReturns(result1 error) {
+	fake.updateScheduleAnchorMutex.Lock()
+	defer fake.updateScheduleAnchorMutex.Unlock()
+	fake.UpdateScheduleAnchorStub = nil
+	fake.updateScheduleAnchorReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) UpdateScheduleAnchorReturnsOnCall(i int, result1 error) {
+	fake.updateScheduleAnchorMutex.Lock()
+	defer fake.updateScheduleAnchorMutex.Unlock()
+	fake.UpdateScheduleAnchorStub = nil
+	if fake.updateScheduleAnchorReturnsOnCall == nil {
+		fake.updateScheduleAnchorReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateScheduleAnchorReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) VersionPassedJobs(arg1 int, arg2 atc.Version, arg3 []string) (bool, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.versionPassedJobsMutex.Lock()
+	ret, specificReturn := fake.versionPassedJobsReturnsOnCall[len(fake.versionPassedJobsArgsForCall)]
+	fake.versionPassedJobsArgsForCall = append(fake.versionPassedJobsArgsForCall, struct {
+		arg1 int
+		arg2 atc.Version
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.VersionPassedJobsStub
+	fakeReturns := fake.versionPassedJobsReturns
+	fake.recordInvocation("VersionPassedJobs", []interface{}{arg1, arg2, arg3Copy})
+	fake.versionPassedJobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) VersionPassedJobsCallCount() int {
+	fake.versionPassedJobsMutex.RLock()
+	defer fake.versionPassedJobsMutex.RUnlock()
+	return len(fake.versionPassedJobsArgsForCall)
+}
+
+func (fake *FakeJob) VersionPassedJobsCalls(stub func(int, atc.Version, []string) (bool, error)) {
+	fake.versionPassedJobsMutex.Lock()
+	defer fake.versionPassedJobsMutex.Unlock()
+	fake.VersionPassedJobsStub = stub
+}
+
+func (fake *FakeJob) VersionPassedJobsArgsForCall(i int) (int, atc.Version, []string) {
+	fake.versionPassedJobsMutex.RLock()
+	defer fake.versionPassedJobsMutex.RUnlock()
+	argsForCall := fake.versionPassedJobsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeJob) VersionPassedJobsReturns(result1 bool, result2 error) {
+	fake.versionPassedJobsMutex.Lock()
+	defer fake.versionPassedJobsMutex.Unlock()
+	fake.VersionPassedJobsStub = nil
+	fake.versionPassedJobsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) VersionPassedJobsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.versionPassedJobsMutex.Lock()
+	defer fake.versionPassedJobsMutex.Unlock()
+	fake.VersionPassedJobsStub = nil
+	if fake.versionPassedJobsReturnsOnCall == nil {
+		fake.versionPassedJobsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.versionPassedJobsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) VersionsSinceLastSuccess(arg1 string, arg2 int) ([]atc.Version, error) {
+	fake.versionsSinceLastSuccessMutex.Lock()
+	ret, specificReturn := fake.versionsSinceLastSuccessReturnsOnCall[len(fake.versionsSinceLastSuccessArgsForCall)]
+	fake.versionsSinceLastSuccessArgsForCall = append(fake.versionsSinceLastSuccessArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.VersionsSinceLastSuccessStub
+	fakeReturns := fake.versionsSinceLastSuccessReturns
+	fake.recordInvocation("VersionsSinceLastSuccess", []interface{}{arg1, arg2})
+	fake.versionsSinceLastSuccessMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) VersionsSinceLastSuccessCallCount() int {
+	fake.versionsSinceLastSuccessMutex.RLock()
+	defer fake.versionsSinceLastSuccessMutex.RUnlock()
+	return len(fake.versionsSinceLastSuccessArgsForCall)
+}
+
+func (fake *FakeJob) VersionsSinceLastSuccessCalls(stub func(string, int) ([]atc.Version, error)) {
+	fake.versionsSinceLastSuccessMutex.Lock()
+	defer fake.versionsSinceLastSuccessMutex.Unlock()
+	fake.VersionsSinceLastSuccessStub = stub
+}
+
+func (fake *FakeJob) VersionsSinceLastSuccessArgsForCall(i int) (string, int) {
+	fake.versionsSinceLastSuccessMutex.RLock()
+	defer fake.versionsSinceLastSuccessMutex.RUnlock()
+	argsForCall := fake.versionsSinceLastSuccessArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) VersionsSinceLastSuccessReturns(result1 []atc.Version, result2 error) {
+	fake.versionsSinceLastSuccessMutex.Lock()
+	defer fake.versionsSinceLastSuccessMutex.Unlock()
+	fake.VersionsSinceLastSuccessStub = nil
+	fake.versionsSinceLastSuccessReturns = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) VersionsSinceLastSuccessReturnsOnCall(i int, result1 []atc.Version, result2 error) {
+	fake.versionsSinceLastSuccessMutex.Lock()
+	defer fake.versionsSinceLastSuccessMutex.Unlock()
+	fake.VersionsSinceLastSuccessStub = nil
+	if fake.versionsSinceLastSuccessReturnsOnCall == nil {
+		fake.versionsSinceLastSuccessReturnsOnCall = make(map[int]struct {
+			result1 []atc.Version
+			result2 error
+		})
+	}
+	fake.versionsSinceLastSuccessReturnsOnCall[i] = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.acquireSchedulingLockMutex.RLock()
 	defer fake.acquireSchedulingLockMutex.RUnlock()
+	fake.afterFinishedTimeMutex.RLock()
+	defer fake.afterFinishedTimeMutex.RUnlock()
+	fake.afterTriggeredTimeMutex.RLock()
+	defer fake.afterTriggeredTimeMutex.RUnlock()
 	fake.algorithmInputsMutex.RLock()
 	defer fake.algorithmInputsMutex.RUnlock()
 	fake.buildMutex.RLock()
@@ -2930,6 +4159,12 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createBuildWithExplicitInputsMutex.RLock()
+	defer fake.createBuildWithExplicitInputsMutex.RUnlock()
+	fake.createBuildWithIdempotencyKeyMutex.RLock()
+	defer fake.createBuildWithIdempotencyKeyMutex.RUnlock()
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
 	fake.disableManualTriggerMutex.RLock()
 	defer fake.disableManualTriggerMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
@@ -2958,8 +4193,14 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.outputsMutex.RUnlock()
 	fake.pauseMutex.RLock()
 	defer fake.pauseMutex.RUnlock()
+	fake.pauseReasonMutex.RLock()
+	defer fake.pauseReasonMutex.RUnlock()
 	fake.pausedMutex.RLock()
 	defer fake.pausedMutex.RUnlock()
+	fake.pausedAtMutex.RLock()
+	defer fake.pausedAtMutex.RUnlock()
+	fake.pausedByMutex.RLock()
+	defer fake.pausedByMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
@@ -2970,6 +4211,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.pipelineNameMutex.RUnlock()
 	fake.pipelineRefMutex.RLock()
 	defer fake.pipelineRefMutex.RUnlock()
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -2978,14 +4221,22 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.requestScheduleMutex.RUnlock()
 	fake.rerunBuildMutex.RLock()
 	defer fake.rerunBuildMutex.RUnlock()
+	fake.rerunBuildWithVarsMutex.RLock()
+	defer fake.rerunBuildWithVarsMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.scheduleMutex.RLock()
+	defer fake.scheduleMutex.RUnlock()
+	fake.scheduleAnchorMutex.RLock()
+	defer fake.scheduleAnchorMutex.RUnlock()
 	fake.scheduleBuildMutex.RLock()
 	defer fake.scheduleBuildMutex.RUnlock()
 	fake.scheduleRequestedTimeMutex.RLock()
 	defer fake.scheduleRequestedTimeMutex.RUnlock()
 	fake.setHasNewInputsMutex.RLock()
 	defer fake.setHasNewInputsMutex.RUnlock()
+	fake.statisticsMutex.RLock()
+	defer fake.statisticsMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
@@ -2994,10 +4245,18 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.teamNameMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
+	fake.updateAfterTriggeredMutex.RLock()
+	defer fake.updateAfterTriggeredMutex.RUnlock()
 	fake.updateFirstLoggedBuildIDMutex.RLock()
 	defer fake.updateFirstLoggedBuildIDMutex.RUnlock()
 	fake.updateLastScheduledMutex.RLock()
 	defer fake.updateLastScheduledMutex.RUnlock()
+	fake.updateScheduleAnchorMutex.RLock()
+	defer fake.updateScheduleAnchorMutex.RUnlock()
+	fake.versionPassedJobsMutex.RLock()
+	defer fake.versionPassedJobsMutex.RUnlock()
+	fake.versionsSinceLastSuccessMutex.RLock()
+	defer fake.versionsSinceLastSuccessMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -33,6 +33,30 @@ type FakeJobFactory struct {
 		result1 db.SchedulerJobs
 		result2 error
 	}
+	RunningBuildsByTeamStub        func() (map[string]int, error)
+	runningBuildsByTeamMutex       sync.RWMutex
+	runningBuildsByTeamArgsForCall []struct {
+	}
+	runningBuildsByTeamReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	runningBuildsByTeamReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
+	ScheduledJobsStub        func() (db.Jobs, error)
+	scheduledJobsMutex       sync.RWMutex
+	scheduledJobsArgsForCall []struct {
+	}
+	scheduledJobsReturns struct {
+		result1 db.Jobs
+		result2 error
+	}
+	scheduledJobsReturnsOnCall map[int]struct {
+		result1 db.Jobs
+		result2 error
+	}
 	VisibleJobsStub        func([]string) ([]atc.JobSummary, error)
 	visibleJobsMutex       sync.RWMutex
 	visibleJobsArgsForCall []struct {
@@ -162,6 +186,118 @@ func (fake *FakeJobFactory) JobsToScheduleReturnsOnCall(i int, result1 db.Schedu
 	}{result1, result2}
 }
 
+func (fake *FakeJobFactory) RunningBuildsByTeam() (map[string]int, error) {
+	fake.runningBuildsByTeamMutex.Lock()
+	ret, specificReturn := fake.runningBuildsByTeamReturnsOnCall[len(fake.runningBuildsByTeamArgsForCall)]
+	fake.runningBuildsByTeamArgsForCall = append(fake.runningBuildsByTeamArgsForCall, struct {
+	}{})
+	stub := fake.RunningBuildsByTeamStub
+	fakeReturns := fake.runningBuildsByTeamReturns
+	fake.recordInvocation("RunningBuildsByTeam", []interface{}{})
+	fake.runningBuildsByTeamMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJobFactory) RunningBuildsByTeamCallCount() int {
+	fake.runningBuildsByTeamMutex.RLock()
+	defer fake.runningBuildsByTeamMutex.RUnlock()
+	return len(fake.runningBuildsByTeamArgsForCall)
+}
+
+func (fake *FakeJobFactory) RunningBuildsByTeamCalls(stub func() (map[string]int, error)) {
+	fake.runningBuildsByTeamMutex.Lock()
+	defer fake.runningBuildsByTeamMutex.Unlock()
+	fake.RunningBuildsByTeamStub = stub
+}
+
+func (fake *FakeJobFactory) RunningBuildsByTeamReturns(result1 map[string]int, result2 error) {
+	fake.runningBuildsByTeamMutex.Lock()
+	defer fake.runningBuildsByTeamMutex.Unlock()
+	fake.RunningBuildsByTeamStub = nil
+	fake.runningBuildsByTeamReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobFactory) RunningBuildsByTeamReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.runningBuildsByTeamMutex.Lock()
+	defer fake.runningBuildsByTeamMutex.Unlock()
+	fake.RunningBuildsByTeamStub = nil
+	if fake.runningBuildsByTeamReturnsOnCall == nil {
+		fake.runningBuildsByTeamReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.runningBuildsByTeamReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobFactory) ScheduledJobs() (db.Jobs, error) {
+	fake.scheduledJobsMutex.Lock()
+	ret, specificReturn := fake.scheduledJobsReturnsOnCall[len(fake.scheduledJobsArgsForCall)]
+	fake.scheduledJobsArgsForCall = append(fake.scheduledJobsArgsForCall, struct {
+	}{})
+	stub := fake.ScheduledJobsStub
+	fakeReturns := fake.scheduledJobsReturns
+	fake.recordInvocation("ScheduledJobs", []interface{}{})
+	fake.scheduledJobsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJobFactory) ScheduledJobsCallCount() int {
+	fake.scheduledJobsMutex.RLock()
+	defer fake.scheduledJobsMutex.RUnlock()
+	return len(fake.scheduledJobsArgsForCall)
+}
+
+func (fake *FakeJobFactory) ScheduledJobsCalls(stub func() (db.Jobs, error)) {
+	fake.scheduledJobsMutex.Lock()
+	defer fake.scheduledJobsMutex.Unlock()
+	fake.ScheduledJobsStub = stub
+}
+
+func (fake *FakeJobFactory) ScheduledJobsReturns(result1 db.Jobs, result2 error) {
+	fake.scheduledJobsMutex.Lock()
+	defer fake.scheduledJobsMutex.Unlock()
+	fake.ScheduledJobsStub = nil
+	fake.scheduledJobsReturns = struct {
+		result1 db.Jobs
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobFactory) ScheduledJobsReturnsOnCall(i int, result1 db.Jobs, result2 error) {
+	fake.scheduledJobsMutex.Lock()
+	defer fake.scheduledJobsMutex.Unlock()
+	fake.ScheduledJobsStub = nil
+	if fake.scheduledJobsReturnsOnCall == nil {
+		fake.scheduledJobsReturnsOnCall = make(map[int]struct {
+			result1 db.Jobs
+			result2 error
+		})
+	}
+	fake.scheduledJobsReturnsOnCall[i] = struct {
+		result1 db.Jobs
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJobFactory) VisibleJobs(arg1 []string) ([]atc.JobSummary, error) {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -238,6 +374,10 @@ func (fake *FakeJobFactory) Invocations() map[string][][]interface{} {
 	defer fake.allActiveJobsMutex.RUnlock()
 	fake.jobsToScheduleMutex.RLock()
 	defer fake.jobsToScheduleMutex.RUnlock()
+	fake.runningBuildsByTeamMutex.RLock()
+	defer fake.runningBuildsByTeamMutex.RUnlock()
+	fake.scheduledJobsMutex.RLock()
+	defer fake.scheduledJobsMutex.RUnlock()
 	fake.visibleJobsMutex.RLock()
 	defer fake.visibleJobsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
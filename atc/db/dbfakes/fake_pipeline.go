@@ -33,6 +33,21 @@ type FakePipeline struct {
 	archivedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	BuildByIdempotencyKeyStub        func(string) (db.Build, bool, error)
+	buildByIdempotencyKeyMutex       sync.RWMutex
+	buildByIdempotencyKeyArgsForCall []struct {
+		arg1 string
+	}
+	buildByIdempotencyKeyReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	buildByIdempotencyKeyReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
 	BuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -147,6 +162,16 @@ type FakePipeline struct {
 		result1 []atc.JobSummary
 		result2 error
 	}
+	DefaultsStub        func() *atc.StepDefaults
+	defaultsMutex       sync.RWMutex
+	defaultsArgsForCall []struct {
+	}
+	defaultsReturns struct {
+		result1 *atc.StepDefaults
+	}
+	defaultsReturnsOnCall map[int]struct {
+		result1 *atc.StepDefaults
+	}
 	DeleteBuildEventsByBuildIDsStub        func([]int) error
 	deleteBuildEventsByBuildIDsMutex       sync.RWMutex
 	deleteBuildEventsByBuildIDsArgsForCall []struct {
@@ -335,9 +360,11 @@ type FakePipeline struct {
 	parentJobIDReturnsOnCall map[int]struct {
 		result1 int
 	}
-	PauseStub        func() error
+	PauseStub        func(string, string) error
 	pauseMutex       sync.RWMutex
 	pauseArgsForCall []struct {
+		arg1 string
+		arg2 string
 	}
 	pauseReturns struct {
 		result1 error
@@ -345,6 +372,31 @@ type FakePipeline struct {
 	pauseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PauseJobsStub        func([]string, string, string) ([]string, error)
+	pauseJobsMutex       sync.RWMutex
+	pauseJobsArgsForCall []struct {
+		arg1 []string
+		arg2 string
+		arg3 string
+	}
+	pauseJobsReturns struct {
+		result1 []string
+		result2 error
+	}
+	pauseJobsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	PauseReasonStub        func() string
+	pauseReasonMutex       sync.RWMutex
+	pauseReasonArgsForCall []struct {
+	}
+	pauseReasonReturns struct {
+		result1 string
+	}
+	pauseReasonReturnsOnCall map[int]struct {
+		result1 string
+	}
 	PausedStub        func() bool
 	pausedMutex       sync.RWMutex
 	pausedArgsForCall []struct {
@@ -355,6 +407,26 @@ type FakePipeline struct {
 	pausedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	PausedAtStub        func() time.Time
+	pausedAtMutex       sync.RWMutex
+	pausedAtArgsForCall []struct {
+	}
+	pausedAtReturns struct {
+		result1 time.Time
+	}
+	pausedAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	PausedByStub        func() string
+	pausedByMutex       sync.RWMutex
+	pausedByArgsForCall []struct {
+	}
+	pausedByReturns struct {
+		result1 string
+	}
+	pausedByReturnsOnCall map[int]struct {
+		result1 string
+	}
 	PublicStub        func() bool
 	publicMutex       sync.RWMutex
 	publicArgsForCall []struct {
@@ -518,6 +590,35 @@ type FakePipeline struct {
 	unpauseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UnpauseJobsStub        func([]string) ([]string, error)
+	unpauseJobsMutex       sync.RWMutex
+	unpauseJobsArgsForCall []struct {
+		arg1 []string
+	}
+	unpauseJobsReturns struct {
+		result1 []string
+		result2 error
+	}
+	unpauseJobsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	VarSourceStatusesStub        func(lager.Logger, creds.Secrets, creds.VarSourcePool, []vars.Reference) ([]atc.VarSourceStatus, error)
+	varSourceStatusesMutex       sync.RWMutex
+	varSourceStatusesArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 creds.Secrets
+		arg3 creds.VarSourcePool
+		arg4 []vars.Reference
+	}
+	varSourceStatusesReturns struct {
+		result1 []atc.VarSourceStatus
+		result2 error
+	}
+	varSourceStatusesReturnsOnCall map[int]struct {
+		result1 []atc.VarSourceStatus
+		result2 error
+	}
 	VarSourcesStub        func() atc.VarSourceConfigs
 	varSourcesMutex       sync.RWMutex
 	varSourcesArgsForCall []struct {
@@ -653,6 +754,73 @@ func (fake *FakePipeline) ArchivedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakePipeline) BuildByIdempotencyKey(arg1 string) (db.Build, bool, error) {
+	fake.buildByIdempotencyKeyMutex.Lock()
+	ret, specificReturn := fake.buildByIdempotencyKeyReturnsOnCall[len(fake.buildByIdempotencyKeyArgsForCall)]
+	fake.buildByIdempotencyKeyArgsForCall = append(fake.buildByIdempotencyKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.BuildByIdempotencyKeyStub
+	fakeReturns := fake.buildByIdempotencyKeyReturns
+	fake.recordInvocation("BuildByIdempotencyKey", []interface{}{arg1})
+	fake.buildByIdempotencyKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) BuildByIdempotencyKeyCallCount() int {
+	fake.buildByIdempotencyKeyMutex.RLock()
+	defer fake.buildByIdempotencyKeyMutex.RUnlock()
+	return len(fake.buildByIdempotencyKeyArgsForCall)
+}
+
+func (fake *FakePipeline) BuildByIdempotencyKeyCalls(stub func(string) (db.Build, bool, error)) {
+	fake.buildByIdempotencyKeyMutex.Lock()
+	defer fake.buildByIdempotencyKeyMutex.Unlock()
+	fake.BuildByIdempotencyKeyStub = stub
+}
+
+func (fake *FakePipeline) BuildByIdempotencyKeyArgsForCall(i int) string {
+	fake.buildByIdempotencyKeyMutex.RLock()
+	defer fake.buildByIdempotencyKeyMutex.RUnlock()
+	argsForCall := fake.buildByIdempotencyKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) BuildByIdempotencyKeyReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.buildByIdempotencyKeyMutex.Lock()
+	defer fake.buildByIdempotencyKeyMutex.Unlock()
+	fake.BuildByIdempotencyKeyStub = nil
+	fake.buildByIdempotencyKeyReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) BuildByIdempotencyKeyReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.buildByIdempotencyKeyMutex.Lock()
+	defer fake.buildByIdempotencyKeyMutex.Unlock()
+	fake.BuildByIdempotencyKeyStub = nil
+	if fake.buildByIdempotencyKeyReturnsOnCall == nil {
+		fake.buildByIdempotencyKeyReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildByIdempotencyKeyReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipeline) Builds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -1192,6 +1360,59 @@ func (fake *FakePipeline) DashboardReturnsOnCall(i int, result1 []atc.JobSummary
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) Defaults() *atc.StepDefaults {
+	fake.defaultsMutex.Lock()
+	ret, specificReturn := fake.defaultsReturnsOnCall[len(fake.defaultsArgsForCall)]
+	fake.defaultsArgsForCall = append(fake.defaultsArgsForCall, struct {
+	}{})
+	stub := fake.DefaultsStub
+	fakeReturns := fake.defaultsReturns
+	fake.recordInvocation("Defaults", []interface{}{})
+	fake.defaultsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) DefaultsCallCount() int {
+	fake.defaultsMutex.RLock()
+	defer fake.defaultsMutex.RUnlock()
+	return len(fake.defaultsArgsForCall)
+}
+
+func (fake *FakePipeline) DefaultsCalls(stub func() *atc.StepDefaults) {
+	fake.defaultsMutex.Lock()
+	defer fake.defaultsMutex.Unlock()
+	fake.DefaultsStub = stub
+}
+
+func (fake *FakePipeline) DefaultsReturns(result1 *atc.StepDefaults) {
+	fake.defaultsMutex.Lock()
+	defer fake.defaultsMutex.Unlock()
+	fake.DefaultsStub = nil
+	fake.defaultsReturns = struct {
+		result1 *atc.StepDefaults
+	}{result1}
+}
+
+func (fake *FakePipeline) DefaultsReturnsOnCall(i int, result1 *atc.StepDefaults) {
+	fake.defaultsMutex.Lock()
+	defer fake.defaultsMutex.Unlock()
+	fake.DefaultsStub = nil
+	if fake.defaultsReturnsOnCall == nil {
+		fake.defaultsReturnsOnCall = make(map[int]struct {
+			result1 *atc.StepDefaults
+		})
+	}
+	fake.defaultsReturnsOnCall[i] = struct {
+		result1 *atc.StepDefaults
+	}{result1}
+}
+
 func (fake *FakePipeline) DeleteBuildEventsByBuildIDs(arg1 []int) error {
 	var arg1Copy []int
 	if arg1 != nil {
@@ -2150,17 +2371,19 @@ func (fake *FakePipeline) ParentJobIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
-func (fake *FakePipeline) Pause() error {
+func (fake *FakePipeline) Pause(arg1 string, arg2 string) error {
 	fake.pauseMutex.Lock()
 	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
 	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
-	}{})
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.PauseStub
 	fakeReturns := fake.pauseReturns
-	fake.recordInvocation("Pause", []interface{}{})
+	fake.recordInvocation("Pause", []interface{}{arg1, arg2})
 	fake.pauseMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -2174,12 +2397,19 @@ func (fake *FakePipeline) PauseCallCount() int {
 	return len(fake.pauseArgsForCall)
 }
 
-func (fake *FakePipeline) PauseCalls(stub func() error) {
+func (fake *FakePipeline) PauseCalls(stub func(string, string) error) {
 	fake.pauseMutex.Lock()
 	defer fake.pauseMutex.Unlock()
 	fake.PauseStub = stub
 }
 
+func (fake *FakePipeline) PauseArgsForCall(i int) (string, string) {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	argsForCall := fake.pauseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakePipeline) PauseReturns(result1 error) {
 	fake.pauseMutex.Lock()
 	defer fake.pauseMutex.Unlock()
@@ -2203,6 +2433,130 @@ func (fake *FakePipeline) PauseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) PauseJobs(arg1 []string, arg2 string, arg3 string) ([]string, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.pauseJobsMutex.Lock()
+	ret, specificReturn := fake.pauseJobsReturnsOnCall[len(fake.pauseJobsArgsForCall)]
+	fake.pauseJobsArgsForCall = append(fake.pauseJobsArgsForCall, struct {
+		arg1 []string
+		arg2 string
+		arg3 string
+	}{arg1Copy, arg2, arg3})
+	stub := fake.PauseJobsStub
+	fakeReturns := fake.pauseJobsReturns
+	fake.recordInvocation("PauseJobs", []interface{}{arg1Copy, arg2, arg3})
+	fake.pauseJobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) PauseJobsCallCount() int {
+	fake.pauseJobsMutex.RLock()
+	defer fake.pauseJobsMutex.RUnlock()
+	return len(fake.pauseJobsArgsForCall)
+}
+
+func (fake *FakePipeline) PauseJobsCalls(stub func([]string, string, string) ([]string, error)) {
+	fake.pauseJobsMutex.Lock()
+	defer fake.pauseJobsMutex.Unlock()
+	fake.PauseJobsStub = stub
+}
+
+func (fake *FakePipeline) PauseJobsArgsForCall(i int) ([]string, string, string) {
+	fake.pauseJobsMutex.RLock()
+	defer fake.pauseJobsMutex.RUnlock()
+	argsForCall := fake.pauseJobsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePipeline) PauseJobsReturns(result1 []string, result2 error) {
+	fake.pauseJobsMutex.Lock()
+	defer fake.pauseJobsMutex.Unlock()
+	fake.PauseJobsStub = nil
+	fake.pauseJobsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) PauseJobsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.pauseJobsMutex.Lock()
+	defer fake.pauseJobsMutex.Unlock()
+	fake.PauseJobsStub = nil
+	if fake.pauseJobsReturnsOnCall == nil {
+		fake.pauseJobsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.pauseJobsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) PauseReason() string {
+	fake.pauseReasonMutex.Lock()
+	ret, specificReturn := fake.pauseReasonReturnsOnCall[len(fake.pauseReasonArgsForCall)]
+	fake.pauseReasonArgsForCall = append(fake.pauseReasonArgsForCall, struct {
+	}{})
+	stub := fake.PauseReasonStub
+	fakeReturns := fake.pauseReasonReturns
+	fake.recordInvocation("PauseReason", []interface{}{})
+	fake.pauseReasonMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) PauseReasonCallCount() int {
+	fake.pauseReasonMutex.RLock()
+	defer fake.pauseReasonMutex.RUnlock()
+	return len(fake.pauseReasonArgsForCall)
+}
+
+func (fake *FakePipeline) PauseReasonCalls(stub func() string) {
+	fake.pauseReasonMutex.Lock()
+	defer fake.pauseReasonMutex.Unlock()
+	fake.PauseReasonStub = stub
+}
+
+func (fake *FakePipeline) PauseReasonReturns(result1 string) {
+	fake.pauseReasonMutex.Lock()
+	defer fake.pauseReasonMutex.Unlock()
+	fake.PauseReasonStub = nil
+	fake.pauseReasonReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipeline) PauseReasonReturnsOnCall(i int, result1 string) {
+	fake.pauseReasonMutex.Lock()
+	defer fake.pauseReasonMutex.Unlock()
+	fake.PauseReasonStub = nil
+	if fake.pauseReasonReturnsOnCall == nil {
+		fake.pauseReasonReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.pauseReasonReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakePipeline) Paused() bool {
 	fake.pausedMutex.Lock()
 	ret, specificReturn := fake.pausedReturnsOnCall[len(fake.pausedArgsForCall)]
@@ -2256,6 +2610,112 @@ func (fake *FakePipeline) PausedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakePipeline) PausedAt() time.Time {
+	fake.pausedAtMutex.Lock()
+	ret, specificReturn := fake.pausedAtReturnsOnCall[len(fake.pausedAtArgsForCall)]
+	fake.pausedAtArgsForCall = append(fake.pausedAtArgsForCall, struct {
+	}{})
+	stub := fake.PausedAtStub
+	fakeReturns := fake.pausedAtReturns
+	fake.recordInvocation("PausedAt", []interface{}{})
+	fake.pausedAtMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) PausedAtCallCount() int {
+	fake.pausedAtMutex.RLock()
+	defer fake.pausedAtMutex.RUnlock()
+	return len(fake.pausedAtArgsForCall)
+}
+
+func (fake *FakePipeline) PausedAtCalls(stub func() time.Time) {
+	fake.pausedAtMutex.Lock()
+	defer fake.pausedAtMutex.Unlock()
+	fake.PausedAtStub = stub
+}
+
+func (fake *FakePipeline) PausedAtReturns(result1 time.Time) {
+	fake.pausedAtMutex.Lock()
+	defer fake.pausedAtMutex.Unlock()
+	fake.PausedAtStub = nil
+	fake.pausedAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakePipeline) PausedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.pausedAtMutex.Lock()
+	defer fake.pausedAtMutex.Unlock()
+	fake.PausedAtStub = nil
+	if fake.pausedAtReturnsOnCall == nil {
+		fake.pausedAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.pausedAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakePipeline) PausedBy() string {
+	fake.pausedByMutex.Lock()
+	ret, specificReturn := fake.pausedByReturnsOnCall[len(fake.pausedByArgsForCall)]
+	fake.pausedByArgsForCall = append(fake.pausedByArgsForCall, struct {
+	}{})
+	stub := fake.PausedByStub
+	fakeReturns := fake.pausedByReturns
+	fake.recordInvocation("PausedBy", []interface{}{})
+	fake.pausedByMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) PausedByCallCount() int {
+	fake.pausedByMutex.RLock()
+	defer fake.pausedByMutex.RUnlock()
+	return len(fake.pausedByArgsForCall)
+}
+
+func (fake *FakePipeline) PausedByCalls(stub func() string) {
+	fake.pausedByMutex.Lock()
+	defer fake.pausedByMutex.Unlock()
+	fake.PausedByStub = stub
+}
+
+func (fake *FakePipeline) PausedByReturns(result1 string) {
+	fake.pausedByMutex.Lock()
+	defer fake.pausedByMutex.Unlock()
+	fake.PausedByStub = nil
+	fake.pausedByReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipeline) PausedByReturnsOnCall(i int, result1 string) {
+	fake.pausedByMutex.Lock()
+	defer fake.pausedByMutex.Unlock()
+	fake.PausedByStub = nil
+	if fake.pausedByReturnsOnCall == nil {
+		fake.pausedByReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.pausedByReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakePipeline) Public() bool {
 	fake.publicMutex.Lock()
 	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
@@ -3033,6 +3493,147 @@ func (fake *FakePipeline) UnpauseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) UnpauseJobs(arg1 []string) ([]string, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.unpauseJobsMutex.Lock()
+	ret, specificReturn := fake.unpauseJobsReturnsOnCall[len(fake.unpauseJobsArgsForCall)]
+	fake.unpauseJobsArgsForCall = append(fake.unpauseJobsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.UnpauseJobsStub
+	fakeReturns := fake.unpauseJobsReturns
+	fake.recordInvocation("UnpauseJobs", []interface{}{arg1Copy})
+	fake.unpauseJobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) UnpauseJobsCallCount() int {
+	fake.unpauseJobsMutex.RLock()
+	defer fake.unpauseJobsMutex.RUnlock()
+	return len(fake.unpauseJobsArgsForCall)
+}
+
+func (fake *FakePipeline) UnpauseJobsCalls(stub func([]string) ([]string, error)) {
+	fake.unpauseJobsMutex.Lock()
+	defer fake.unpauseJobsMutex.Unlock()
+	fake.UnpauseJobsStub = stub
+}
+
+func (fake *FakePipeline) UnpauseJobsArgsForCall(i int) []string {
+	fake.unpauseJobsMutex.RLock()
+	defer fake.unpauseJobsMutex.RUnlock()
+	argsForCall := fake.unpauseJobsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) UnpauseJobsReturns(result1 []string, result2 error) {
+	fake.unpauseJobsMutex.Lock()
+	defer fake.unpauseJobsMutex.Unlock()
+	fake.UnpauseJobsStub = nil
+	fake.unpauseJobsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) UnpauseJobsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.unpauseJobsMutex.Lock()
+	defer fake.unpauseJobsMutex.Unlock()
+	fake.UnpauseJobsStub = nil
+	if fake.unpauseJobsReturnsOnCall == nil {
+		fake.unpauseJobsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.unpauseJobsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) VarSourceStatuses(arg1 lager.Logger, arg2 creds.Secrets, arg3 creds.VarSourcePool, arg4 []vars.Reference) ([]atc.VarSourceStatus, error) {
+	var arg4Copy []vars.Reference
+	if arg4 != nil {
+		arg4Copy = make([]vars.Reference, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.varSourceStatusesMutex.Lock()
+	ret, specificReturn := fake.varSourceStatusesReturnsOnCall[len(fake.varSourceStatusesArgsForCall)]
+	fake.varSourceStatusesArgsForCall = append(fake.varSourceStatusesArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 creds.Secrets
+		arg3 creds.VarSourcePool
+		arg4 []vars.Reference
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.VarSourceStatusesStub
+	fakeReturns := fake.varSourceStatusesReturns
+	fake.recordInvocation("VarSourceStatuses", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.varSourceStatusesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) VarSourceStatusesCallCount() int {
+	fake.varSourceStatusesMutex.RLock()
+	defer fake.varSourceStatusesMutex.RUnlock()
+	return len(fake.varSourceStatusesArgsForCall)
+}
+
+func (fake *FakePipeline) VarSourceStatusesCalls(stub func(lager.Logger, creds.Secrets, creds.VarSourcePool, []vars.Reference) ([]atc.VarSourceStatus, error)) {
+	fake.varSourceStatusesMutex.Lock()
+	defer fake.varSourceStatusesMutex.Unlock()
+	fake.VarSourceStatusesStub = stub
+}
+
+func (fake *FakePipeline) VarSourceStatusesArgsForCall(i int) (lager.Logger, creds.Secrets, creds.VarSourcePool, []vars.Reference) {
+	fake.varSourceStatusesMutex.RLock()
+	defer fake.varSourceStatusesMutex.RUnlock()
+	argsForCall := fake.varSourceStatusesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePipeline) VarSourceStatusesReturns(result1 []atc.VarSourceStatus, result2 error) {
+	fake.varSourceStatusesMutex.Lock()
+	defer fake.varSourceStatusesMutex.Unlock()
+	fake.VarSourceStatusesStub = nil
+	fake.varSourceStatusesReturns = struct {
+		result1 []atc.VarSourceStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) VarSourceStatusesReturnsOnCall(i int, result1 []atc.VarSourceStatus, result2 error) {
+	fake.varSourceStatusesMutex.Lock()
+	defer fake.varSourceStatusesMutex.Unlock()
+	fake.VarSourceStatusesStub = nil
+	if fake.varSourceStatusesReturnsOnCall == nil {
+		fake.varSourceStatusesReturnsOnCall = make(map[int]struct {
+			result1 []atc.VarSourceStatus
+			result2 error
+		})
+	}
+	fake.varSourceStatusesReturnsOnCall[i] = struct {
+		result1 []atc.VarSourceStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) VarSources() atc.VarSourceConfigs {
 	fake.varSourcesMutex.Lock()
 	ret, specificReturn := fake.varSourcesReturnsOnCall[len(fake.varSourcesArgsForCall)]
@@ -3159,6 +3760,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.archiveMutex.RUnlock()
 	fake.archivedMutex.RLock()
 	defer fake.archivedMutex.RUnlock()
+	fake.buildByIdempotencyKeyMutex.RLock()
+	defer fake.buildByIdempotencyKeyMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.buildsWithTimeMutex.RLock()
@@ -3177,6 +3780,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.createStartedBuildMutex.RUnlock()
 	fake.dashboardMutex.RLock()
 	defer fake.dashboardMutex.RUnlock()
+	fake.defaultsMutex.RLock()
+	defer fake.defaultsMutex.RUnlock()
 	fake.deleteBuildEventsByBuildIDsMutex.RLock()
 	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
 	fake.destroyMutex.RLock()
@@ -3213,8 +3818,16 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.parentJobIDMutex.RUnlock()
 	fake.pauseMutex.RLock()
 	defer fake.pauseMutex.RUnlock()
+	fake.pauseJobsMutex.RLock()
+	defer fake.pauseJobsMutex.RUnlock()
+	fake.pauseReasonMutex.RLock()
+	defer fake.pauseReasonMutex.RUnlock()
 	fake.pausedMutex.RLock()
 	defer fake.pausedMutex.RUnlock()
+	fake.pausedAtMutex.RLock()
+	defer fake.pausedAtMutex.RUnlock()
+	fake.pausedByMutex.RLock()
+	defer fake.pausedByMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -3241,6 +3854,10 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.teamNameMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
+	fake.unpauseJobsMutex.RLock()
+	defer fake.unpauseJobsMutex.RUnlock()
+	fake.varSourceStatusesMutex.RLock()
+	defer fake.varSourceStatusesMutex.RUnlock()
 	fake.varSourcesMutex.RLock()
 	defer fake.varSourcesMutex.RUnlock()
 	fake.variablesMutex.RLock()
@@ -209,6 +209,21 @@ type FakeResource struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NewestVersionSinceStub        func(atc.Version) (db.ResourceConfigVersion, bool, error)
+	newestVersionSinceMutex       sync.RWMutex
+	newestVersionSinceArgsForCall []struct {
+		arg1 atc.Version
+	}
+	newestVersionSinceReturns struct {
+		result1 db.ResourceConfigVersion
+		result2 bool
+		result3 error
+	}
+	newestVersionSinceReturnsOnCall map[int]struct {
+		result1 db.ResourceConfigVersion
+		result2 bool
+		result3 error
+	}
 	NotifyScanStub        func() error
 	notifyScanMutex       sync.RWMutex
 	notifyScanArgsForCall []struct {
@@ -1477,6 +1492,73 @@ func (fake *FakeResource) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) NewestVersionSince(arg1 atc.Version) (db.ResourceConfigVersion, bool, error) {
+	fake.newestVersionSinceMutex.Lock()
+	ret, specificReturn := fake.newestVersionSinceReturnsOnCall[len(fake.newestVersionSinceArgsForCall)]
+	fake.newestVersionSinceArgsForCall = append(fake.newestVersionSinceArgsForCall, struct {
+		arg1 atc.Version
+	}{arg1})
+	stub := fake.NewestVersionSinceStub
+	fakeReturns := fake.newestVersionSinceReturns
+	fake.recordInvocation("NewestVersionSince", []interface{}{arg1})
+	fake.newestVersionSinceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResource) NewestVersionSinceCallCount() int {
+	fake.newestVersionSinceMutex.RLock()
+	defer fake.newestVersionSinceMutex.RUnlock()
+	return len(fake.newestVersionSinceArgsForCall)
+}
+
+func (fake *FakeResource) NewestVersionSinceCalls(stub func(atc.Version) (db.ResourceConfigVersion, bool, error)) {
+	fake.newestVersionSinceMutex.Lock()
+	defer fake.newestVersionSinceMutex.Unlock()
+	fake.NewestVersionSinceStub = stub
+}
+
+func (fake *FakeResource) NewestVersionSinceArgsForCall(i int) atc.Version {
+	fake.newestVersionSinceMutex.RLock()
+	defer fake.newestVersionSinceMutex.RUnlock()
+	argsForCall := fake.newestVersionSinceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) NewestVersionSinceReturns(result1 db.ResourceConfigVersion, result2 bool, result3 error) {
+	fake.newestVersionSinceMutex.Lock()
+	defer fake.newestVersionSinceMutex.Unlock()
+	fake.NewestVersionSinceStub = nil
+	fake.newestVersionSinceReturns = struct {
+		result1 db.ResourceConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) NewestVersionSinceReturnsOnCall(i int, result1 db.ResourceConfigVersion, result2 bool, result3 error) {
+	fake.newestVersionSinceMutex.Lock()
+	defer fake.newestVersionSinceMutex.Unlock()
+	fake.NewestVersionSinceStub = nil
+	if fake.newestVersionSinceReturnsOnCall == nil {
+		fake.newestVersionSinceReturnsOnCall = make(map[int]struct {
+			result1 db.ResourceConfigVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.newestVersionSinceReturnsOnCall[i] = struct {
+		result1 db.ResourceConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeResource) NotifyScan() error {
 	fake.notifyScanMutex.Lock()
 	ret, specificReturn := fake.notifyScanReturnsOnCall[len(fake.notifyScanArgsForCall)]
@@ -2801,6 +2883,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.lastCheckStartTimeMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.newestVersionSinceMutex.RLock()
+	defer fake.newestVersionSinceMutex.RUnlock()
 	fake.notifyScanMutex.RLock()
 	defer fake.notifyScanMutex.RUnlock()
 	fake.pinCommentMutex.RLock()
@@ -42,6 +42,17 @@ type FakeResourceCacheFactory struct {
 		result2 bool
 		result3 error
 	}
+	InvalidateResourceCacheStub        func(db.UsedResourceCache) error
+	invalidateResourceCacheMutex       sync.RWMutex
+	invalidateResourceCacheArgsForCall []struct {
+		arg1 db.UsedResourceCache
+	}
+	invalidateResourceCacheReturns struct {
+		result1 error
+	}
+	invalidateResourceCacheReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ResourceCacheMetadataStub        func(db.UsedResourceCache) (db.ResourceConfigMetadataFields, error)
 	resourceCacheMetadataMutex       sync.RWMutex
 	resourceCacheMetadataArgsForCall []struct {
@@ -207,6 +218,67 @@ func (fake *FakeResourceCacheFactory) FindResourceCacheByIDReturnsOnCall(i int,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeResourceCacheFactory) InvalidateResourceCache(arg1 db.UsedResourceCache) error {
+	fake.invalidateResourceCacheMutex.Lock()
+	ret, specificReturn := fake.invalidateResourceCacheReturnsOnCall[len(fake.invalidateResourceCacheArgsForCall)]
+	fake.invalidateResourceCacheArgsForCall = append(fake.invalidateResourceCacheArgsForCall, struct {
+		arg1 db.UsedResourceCache
+	}{arg1})
+	stub := fake.InvalidateResourceCacheStub
+	fakeReturns := fake.invalidateResourceCacheReturns
+	fake.recordInvocation("InvalidateResourceCache", []interface{}{arg1})
+	fake.invalidateResourceCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceCacheFactory) InvalidateResourceCacheCallCount() int {
+	fake.invalidateResourceCacheMutex.RLock()
+	defer fake.invalidateResourceCacheMutex.RUnlock()
+	return len(fake.invalidateResourceCacheArgsForCall)
+}
+
+func (fake *FakeResourceCacheFactory) InvalidateResourceCacheCalls(stub func(db.UsedResourceCache) error) {
+	fake.invalidateResourceCacheMutex.Lock()
+	defer fake.invalidateResourceCacheMutex.Unlock()
+	fake.InvalidateResourceCacheStub = stub
+}
+
+func (fake *FakeResourceCacheFactory) InvalidateResourceCacheArgsForCall(i int) db.UsedResourceCache {
+	fake.invalidateResourceCacheMutex.RLock()
+	defer fake.invalidateResourceCacheMutex.RUnlock()
+	argsForCall := fake.invalidateResourceCacheArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceCacheFactory) InvalidateResourceCacheReturns(result1 error) {
+	fake.invalidateResourceCacheMutex.Lock()
+	defer fake.invalidateResourceCacheMutex.Unlock()
+	fake.InvalidateResourceCacheStub = nil
+	fake.invalidateResourceCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceCacheFactory) InvalidateResourceCacheReturnsOnCall(i int, result1 error) {
+	fake.invalidateResourceCacheMutex.Lock()
+	defer fake.invalidateResourceCacheMutex.Unlock()
+	fake.InvalidateResourceCacheStub = nil
+	if fake.invalidateResourceCacheReturnsOnCall == nil {
+		fake.invalidateResourceCacheReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.invalidateResourceCacheReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResourceCacheFactory) ResourceCacheMetadata(arg1 db.UsedResourceCache) (db.ResourceConfigMetadataFields, error) {
 	fake.resourceCacheMetadataMutex.Lock()
 	ret, specificReturn := fake.resourceCacheMetadataReturnsOnCall[len(fake.resourceCacheMetadataArgsForCall)]
@@ -345,6 +417,8 @@ func (fake *FakeResourceCacheFactory) Invocations() map[string][][]interface{} {
 	defer fake.findOrCreateResourceCacheMutex.RUnlock()
 	fake.findResourceCacheByIDMutex.RLock()
 	defer fake.findResourceCacheByIDMutex.RUnlock()
+	fake.invalidateResourceCacheMutex.RLock()
+	defer fake.invalidateResourceCacheMutex.RUnlock()
 	fake.resourceCacheMetadataMutex.RLock()
 	defer fake.resourceCacheMetadataMutex.RUnlock()
 	fake.updateResourceCacheMetadataMutex.RLock()
@@ -12,6 +12,16 @@ import (
 )
 
 type FakeTeam struct {
+	AccessTokenLifetimeStub        func() time.Duration
+	accessTokenLifetimeMutex       sync.RWMutex
+	accessTokenLifetimeArgsForCall []struct {
+	}
+	accessTokenLifetimeReturns struct {
+		result1 time.Duration
+	}
+	accessTokenLifetimeReturnsOnCall map[int]struct {
+		result1 time.Duration
+	}
 	AdminStub        func() bool
 	adminMutex       sync.RWMutex
 	adminArgsForCall []struct {
@@ -86,6 +96,22 @@ type FakeTeam struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateServiceAccountStub        func(string, string, string, db.AccessToken) (db.ServiceAccount, error)
+	createServiceAccountMutex       sync.RWMutex
+	createServiceAccountArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 db.AccessToken
+	}
+	createServiceAccountReturns struct {
+		result1 db.ServiceAccount
+		result2 error
+	}
+	createServiceAccountReturnsOnCall map[int]struct {
+		result1 db.ServiceAccount
+		result2 error
+	}
 	CreateStartedBuildStub        func(atc.Plan) (db.Build, error)
 	createStartedBuildMutex       sync.RWMutex
 	createStartedBuildArgsForCall []struct {
@@ -109,6 +135,45 @@ type FakeTeam struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeletePipelineTemplateStub        func(string) (bool, error)
+	deletePipelineTemplateMutex       sync.RWMutex
+	deletePipelineTemplateArgsForCall []struct {
+		arg1 string
+	}
+	deletePipelineTemplateReturns struct {
+		result1 bool
+		result2 error
+	}
+	deletePipelineTemplateReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	DeleteServiceAccountStub        func(string) (bool, error)
+	deleteServiceAccountMutex       sync.RWMutex
+	deleteServiceAccountArgsForCall []struct {
+		arg1 string
+	}
+	deleteServiceAccountReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteServiceAccountReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	DeleteWebhookStub        func(string) (bool, error)
+	deleteWebhookMutex       sync.RWMutex
+	deleteWebhookArgsForCall []struct {
+		arg1 string
+	}
+	deleteWebhookReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteWebhookReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	FindCheckContainersStub        func(lager.Logger, atc.PipelineRef, string, creds.Secrets, creds.VarSourcePool) ([]db.Container, map[int]time.Time, error)
 	findCheckContainersMutex       sync.RWMutex
 	findCheckContainersArgsForCall []struct {
@@ -314,6 +379,43 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	PipelineAuthStub        func() atc.PipelineAuth
+	pipelineAuthMutex       sync.RWMutex
+	pipelineAuthArgsForCall []struct {
+	}
+	pipelineAuthReturns struct {
+		result1 atc.PipelineAuth
+	}
+	pipelineAuthReturnsOnCall map[int]struct {
+		result1 atc.PipelineAuth
+	}
+	PipelineTemplateStub        func(string) (atc.PipelineTemplate, bool, error)
+	pipelineTemplateMutex       sync.RWMutex
+	pipelineTemplateArgsForCall []struct {
+		arg1 string
+	}
+	pipelineTemplateReturns struct {
+		result1 atc.PipelineTemplate
+		result2 bool
+		result3 error
+	}
+	pipelineTemplateReturnsOnCall map[int]struct {
+		result1 atc.PipelineTemplate
+		result2 bool
+		result3 error
+	}
+	PipelineTemplatesStub        func() ([]atc.PipelineTemplate, error)
+	pipelineTemplatesMutex       sync.RWMutex
+	pipelineTemplatesArgsForCall []struct {
+	}
+	pipelineTemplatesReturns struct {
+		result1 []atc.PipelineTemplate
+		result2 error
+	}
+	pipelineTemplatesReturnsOnCall map[int]struct {
+		result1 []atc.PipelineTemplate
+		result2 error
+	}
 	PipelinesStub        func() ([]db.Pipeline, error)
 	pipelinesMutex       sync.RWMutex
 	pipelinesArgsForCall []struct {
@@ -341,6 +443,30 @@ type FakeTeam struct {
 		result2 db.Pagination
 		result3 error
 	}
+	PrunableStateStub        func() (atc.TeamPrunableState, error)
+	prunableStateMutex       sync.RWMutex
+	prunableStateArgsForCall []struct {
+	}
+	prunableStateReturns struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}
+	prunableStateReturnsOnCall map[int]struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}
+	PruneStateStub        func() (atc.TeamPrunableState, error)
+	pruneStateMutex       sync.RWMutex
+	pruneStateArgsForCall []struct {
+	}
+	pruneStateReturns struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}
+	pruneStateReturnsOnCall map[int]struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}
 	PublicPipelinesStub        func() ([]db.Pipeline, error)
 	publicPipelinesMutex       sync.RWMutex
 	publicPipelinesArgsForCall []struct {
@@ -410,6 +536,65 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	ServiceAccountsStub        func() ([]db.ServiceAccount, error)
+	serviceAccountsMutex       sync.RWMutex
+	serviceAccountsArgsForCall []struct {
+	}
+	serviceAccountsReturns struct {
+		result1 []db.ServiceAccount
+		result2 error
+	}
+	serviceAccountsReturnsOnCall map[int]struct {
+		result1 []db.ServiceAccount
+		result2 error
+	}
+	SessionIdleTimeoutStub        func() time.Duration
+	sessionIdleTimeoutMutex       sync.RWMutex
+	sessionIdleTimeoutArgsForCall []struct {
+	}
+	sessionIdleTimeoutReturns struct {
+		result1 time.Duration
+	}
+	sessionIdleTimeoutReturnsOnCall map[int]struct {
+		result1 time.Duration
+	}
+	SetPipelineTemplateStub        func(atc.PipelineTemplate) (bool, error)
+	setPipelineTemplateMutex       sync.RWMutex
+	setPipelineTemplateArgsForCall []struct {
+		arg1 atc.PipelineTemplate
+	}
+	setPipelineTemplateReturns struct {
+		result1 bool
+		result2 error
+	}
+	setPipelineTemplateReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SetWebhookStub        func(atc.TeamWebhook) (bool, error)
+	setWebhookMutex       sync.RWMutex
+	setWebhookArgsForCall []struct {
+		arg1 atc.TeamWebhook
+	}
+	setWebhookReturns struct {
+		result1 bool
+		result2 error
+	}
+	setWebhookReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UpdateAuthStub        func(atc.Team) error
+	updateAuthMutex       sync.RWMutex
+	updateAuthArgsForCall []struct {
+		arg1 atc.Team
+	}
+	updateAuthReturns struct {
+		result1 error
+	}
+	updateAuthReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -421,6 +606,18 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WebhooksStub        func() ([]atc.TeamWebhook, error)
+	webhooksMutex       sync.RWMutex
+	webhooksArgsForCall []struct {
+	}
+	webhooksReturns struct {
+		result1 []atc.TeamWebhook
+		result2 error
+	}
+	webhooksReturnsOnCall map[int]struct {
+		result1 []atc.TeamWebhook
+		result2 error
+	}
 	WorkersStub        func() ([]db.Worker, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct {
@@ -437,6 +634,59 @@ type FakeTeam struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTeam) AccessTokenLifetime() time.Duration {
+	fake.accessTokenLifetimeMutex.Lock()
+	ret, specificReturn := fake.accessTokenLifetimeReturnsOnCall[len(fake.accessTokenLifetimeArgsForCall)]
+	fake.accessTokenLifetimeArgsForCall = append(fake.accessTokenLifetimeArgsForCall, struct {
+	}{})
+	stub := fake.AccessTokenLifetimeStub
+	fakeReturns := fake.accessTokenLifetimeReturns
+	fake.recordInvocation("AccessTokenLifetime", []interface{}{})
+	fake.accessTokenLifetimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) AccessTokenLifetimeCallCount() int {
+	fake.accessTokenLifetimeMutex.RLock()
+	defer fake.accessTokenLifetimeMutex.RUnlock()
+	return len(fake.accessTokenLifetimeArgsForCall)
+}
+
+func (fake *FakeTeam) AccessTokenLifetimeCalls(stub func() time.Duration) {
+	fake.accessTokenLifetimeMutex.Lock()
+	defer fake.accessTokenLifetimeMutex.Unlock()
+	fake.AccessTokenLifetimeStub = stub
+}
+
+func (fake *FakeTeam) AccessTokenLifetimeReturns(result1 time.Duration) {
+	fake.accessTokenLifetimeMutex.Lock()
+	defer fake.accessTokenLifetimeMutex.Unlock()
+	fake.AccessTokenLifetimeStub = nil
+	fake.accessTokenLifetimeReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeTeam) AccessTokenLifetimeReturnsOnCall(i int, result1 time.Duration) {
+	fake.accessTokenLifetimeMutex.Lock()
+	defer fake.accessTokenLifetimeMutex.Unlock()
+	fake.AccessTokenLifetimeStub = nil
+	if fake.accessTokenLifetimeReturnsOnCall == nil {
+		fake.accessTokenLifetimeReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+		})
+	}
+	fake.accessTokenLifetimeReturnsOnCall[i] = struct {
+		result1 time.Duration
+	}{result1}
+}
+
 func (fake *FakeTeam) Admin() bool {
 	fake.adminMutex.Lock()
 	ret, specificReturn := fake.adminReturnsOnCall[len(fake.adminArgsForCall)]
@@ -789,6 +1039,73 @@ func (fake *FakeTeam) CreateOneOffBuildReturnsOnCall(i int, result1 db.Build, re
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateServiceAccount(arg1 string, arg2 string, arg3 string, arg4 db.AccessToken) (db.ServiceAccount, error) {
+	fake.createServiceAccountMutex.Lock()
+	ret, specificReturn := fake.createServiceAccountReturnsOnCall[len(fake.createServiceAccountArgsForCall)]
+	fake.createServiceAccountArgsForCall = append(fake.createServiceAccountArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 db.AccessToken
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CreateServiceAccountStub
+	fakeReturns := fake.createServiceAccountReturns
+	fake.recordInvocation("CreateServiceAccount", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createServiceAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateServiceAccountCallCount() int {
+	fake.createServiceAccountMutex.RLock()
+	defer fake.createServiceAccountMutex.RUnlock()
+	return len(fake.createServiceAccountArgsForCall)
+}
+
+func (fake *FakeTeam) CreateServiceAccountCalls(stub func(string, string, string, db.AccessToken) (db.ServiceAccount, error)) {
+	fake.createServiceAccountMutex.Lock()
+	defer fake.createServiceAccountMutex.Unlock()
+	fake.CreateServiceAccountStub = stub
+}
+
+func (fake *FakeTeam) CreateServiceAccountArgsForCall(i int) (string, string, string, db.AccessToken) {
+	fake.createServiceAccountMutex.RLock()
+	defer fake.createServiceAccountMutex.RUnlock()
+	argsForCall := fake.createServiceAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTeam) CreateServiceAccountReturns(result1 db.ServiceAccount, result2 error) {
+	fake.createServiceAccountMutex.Lock()
+	defer fake.createServiceAccountMutex.Unlock()
+	fake.CreateServiceAccountStub = nil
+	fake.createServiceAccountReturns = struct {
+		result1 db.ServiceAccount
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateServiceAccountReturnsOnCall(i int, result1 db.ServiceAccount, result2 error) {
+	fake.createServiceAccountMutex.Lock()
+	defer fake.createServiceAccountMutex.Unlock()
+	fake.CreateServiceAccountStub = nil
+	if fake.createServiceAccountReturnsOnCall == nil {
+		fake.createServiceAccountReturnsOnCall = make(map[int]struct {
+			result1 db.ServiceAccount
+			result2 error
+		})
+	}
+	fake.createServiceAccountReturnsOnCall[i] = struct {
+		result1 db.ServiceAccount
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateStartedBuild(arg1 atc.Plan) (db.Build, error) {
 	fake.createStartedBuildMutex.Lock()
 	ret, specificReturn := fake.createStartedBuildReturnsOnCall[len(fake.createStartedBuildArgsForCall)]
@@ -906,6 +1223,198 @@ func (fake *FakeTeam) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) DeletePipelineTemplate(arg1 string) (bool, error) {
+	fake.deletePipelineTemplateMutex.Lock()
+	ret, specificReturn := fake.deletePipelineTemplateReturnsOnCall[len(fake.deletePipelineTemplateArgsForCall)]
+	fake.deletePipelineTemplateArgsForCall = append(fake.deletePipelineTemplateArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeletePipelineTemplateStub
+	fakeReturns := fake.deletePipelineTemplateReturns
+	fake.recordInvocation("DeletePipelineTemplate", []interface{}{arg1})
+	fake.deletePipelineTemplateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DeletePipelineTemplateCallCount() int {
+	fake.deletePipelineTemplateMutex.RLock()
+	defer fake.deletePipelineTemplateMutex.RUnlock()
+	return len(fake.deletePipelineTemplateArgsForCall)
+}
+
+func (fake *FakeTeam) DeletePipelineTemplateCalls(stub func(string) (bool, error)) {
+	fake.deletePipelineTemplateMutex.Lock()
+	defer fake.deletePipelineTemplateMutex.Unlock()
+	fake.DeletePipelineTemplateStub = stub
+}
+
+func (fake *FakeTeam) DeletePipelineTemplateArgsForCall(i int) string {
+	fake.deletePipelineTemplateMutex.RLock()
+	defer fake.deletePipelineTemplateMutex.RUnlock()
+	argsForCall := fake.deletePipelineTemplateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) DeletePipelineTemplateReturns(result1 bool, result2 error) {
+	fake.deletePipelineTemplateMutex.Lock()
+	defer fake.deletePipelineTemplateMutex.Unlock()
+	fake.DeletePipelineTemplateStub = nil
+	fake.deletePipelineTemplateReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeletePipelineTemplateReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deletePipelineTemplateMutex.Lock()
+	defer fake.deletePipelineTemplateMutex.Unlock()
+	fake.DeletePipelineTemplateStub = nil
+	if fake.deletePipelineTemplateReturnsOnCall == nil {
+		fake.deletePipelineTemplateReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deletePipelineTemplateReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteServiceAccount(arg1 string) (bool, error) {
+	fake.deleteServiceAccountMutex.Lock()
+	ret, specificReturn := fake.deleteServiceAccountReturnsOnCall[len(fake.deleteServiceAccountArgsForCall)]
+	fake.deleteServiceAccountArgsForCall = append(fake.deleteServiceAccountArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteServiceAccountStub
+	fakeReturns := fake.deleteServiceAccountReturns
+	fake.recordInvocation("DeleteServiceAccount", []interface{}{arg1})
+	fake.deleteServiceAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DeleteServiceAccountCallCount() int {
+	fake.deleteServiceAccountMutex.RLock()
+	defer fake.deleteServiceAccountMutex.RUnlock()
+	return len(fake.deleteServiceAccountArgsForCall)
+}
+
+func (fake *FakeTeam) DeleteServiceAccountCalls(stub func(string) (bool, error)) {
+	fake.deleteServiceAccountMutex.Lock()
+	defer fake.deleteServiceAccountMutex.Unlock()
+	fake.DeleteServiceAccountStub = stub
+}
+
+func (fake *FakeTeam) DeleteServiceAccountArgsForCall(i int) string {
+	fake.deleteServiceAccountMutex.RLock()
+	defer fake.deleteServiceAccountMutex.RUnlock()
+	argsForCall := fake.deleteServiceAccountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) DeleteServiceAccountReturns(result1 bool, result2 error) {
+	fake.deleteServiceAccountMutex.Lock()
+	defer fake.deleteServiceAccountMutex.Unlock()
+	fake.DeleteServiceAccountStub = nil
+	fake.deleteServiceAccountReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteServiceAccountReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteServiceAccountMutex.Lock()
+	defer fake.deleteServiceAccountMutex.Unlock()
+	fake.DeleteServiceAccountStub = nil
+	if fake.deleteServiceAccountReturnsOnCall == nil {
+		fake.deleteServiceAccountReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteServiceAccountReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteWebhook(arg1 string) (bool, error) {
+	fake.deleteWebhookMutex.Lock()
+	ret, specificReturn := fake.deleteWebhookReturnsOnCall[len(fake.deleteWebhookArgsForCall)]
+	fake.deleteWebhookArgsForCall = append(fake.deleteWebhookArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteWebhookStub
+	fakeReturns := fake.deleteWebhookReturns
+	fake.recordInvocation("DeleteWebhook", []interface{}{arg1})
+	fake.deleteWebhookMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DeleteWebhookCallCount() int {
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
+	return len(fake.deleteWebhookArgsForCall)
+}
+
+func (fake *FakeTeam) DeleteWebhookCalls(stub func(string) (bool, error)) {
+	fake.deleteWebhookMutex.Lock()
+	defer fake.deleteWebhookMutex.Unlock()
+	fake.DeleteWebhookStub = stub
+}
+
+func (fake *FakeTeam) DeleteWebhookArgsForCall(i int) string {
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
+	argsForCall := fake.deleteWebhookArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) DeleteWebhookReturns(result1 bool, result2 error) {
+	fake.deleteWebhookMutex.Lock()
+	defer fake.deleteWebhookMutex.Unlock()
+	fake.DeleteWebhookStub = nil
+	fake.deleteWebhookReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteWebhookReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteWebhookMutex.Lock()
+	defer fake.deleteWebhookMutex.Unlock()
+	fake.DeleteWebhookStub = nil
+	if fake.deleteWebhookReturnsOnCall == nil {
+		fake.deleteWebhookReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteWebhookReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) FindCheckContainers(arg1 lager.Logger, arg2 atc.PipelineRef, arg3 string, arg4 creds.Secrets, arg5 creds.VarSourcePool) ([]db.Container, map[int]time.Time, error) {
 	fake.findCheckContainersMutex.Lock()
 	ret, specificReturn := fake.findCheckContainersReturnsOnCall[len(fake.findCheckContainersArgsForCall)]
@@ -1875,26 +2384,202 @@ func (fake *FakeTeam) PipelineReturnsOnCall(i int, result1 db.Pipeline, result2
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) Pipelines() ([]db.Pipeline, error) {
-	fake.pipelinesMutex.Lock()
-	ret, specificReturn := fake.pipelinesReturnsOnCall[len(fake.pipelinesArgsForCall)]
-	fake.pipelinesArgsForCall = append(fake.pipelinesArgsForCall, struct {
+func (fake *FakeTeam) PipelineAuth() atc.PipelineAuth {
+	fake.pipelineAuthMutex.Lock()
+	ret, specificReturn := fake.pipelineAuthReturnsOnCall[len(fake.pipelineAuthArgsForCall)]
+	fake.pipelineAuthArgsForCall = append(fake.pipelineAuthArgsForCall, struct {
 	}{})
-	stub := fake.PipelinesStub
-	fakeReturns := fake.pipelinesReturns
-	fake.recordInvocation("Pipelines", []interface{}{})
-	fake.pipelinesMutex.Unlock()
+	stub := fake.PipelineAuthStub
+	fakeReturns := fake.pipelineAuthReturns
+	fake.recordInvocation("PipelineAuth", []interface{}{})
+	fake.pipelineAuthMutex.Unlock()
 	if stub != nil {
 		return stub()
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *FakeTeam) PipelinesCallCount() int {
-	fake.pipelinesMutex.RLock()
+func (fake *FakeTeam) PipelineAuthCallCount() int {
+	fake.pipelineAuthMutex.RLock()
+	defer fake.pipelineAuthMutex.RUnlock()
+	return len(fake.pipelineAuthArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineAuthCalls(stub func() atc.PipelineAuth) {
+	fake.pipelineAuthMutex.Lock()
+	defer fake.pipelineAuthMutex.Unlock()
+	fake.PipelineAuthStub = stub
+}
+
+func (fake *FakeTeam) PipelineAuthReturns(result1 atc.PipelineAuth) {
+	fake.pipelineAuthMutex.Lock()
+	defer fake.pipelineAuthMutex.Unlock()
+	fake.PipelineAuthStub = nil
+	fake.pipelineAuthReturns = struct {
+		result1 atc.PipelineAuth
+	}{result1}
+}
+
+func (fake *FakeTeam) PipelineAuthReturnsOnCall(i int, result1 atc.PipelineAuth) {
+	fake.pipelineAuthMutex.Lock()
+	defer fake.pipelineAuthMutex.Unlock()
+	fake.PipelineAuthStub = nil
+	if fake.pipelineAuthReturnsOnCall == nil {
+		fake.pipelineAuthReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineAuth
+		})
+	}
+	fake.pipelineAuthReturnsOnCall[i] = struct {
+		result1 atc.PipelineAuth
+	}{result1}
+}
+
+func (fake *FakeTeam) PipelineTemplate(arg1 string) (atc.PipelineTemplate, bool, error) {
+	fake.pipelineTemplateMutex.Lock()
+	ret, specificReturn := fake.pipelineTemplateReturnsOnCall[len(fake.pipelineTemplateArgsForCall)]
+	fake.pipelineTemplateArgsForCall = append(fake.pipelineTemplateArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.PipelineTemplateStub
+	fakeReturns := fake.pipelineTemplateReturns
+	fake.recordInvocation("PipelineTemplate", []interface{}{arg1})
+	fake.pipelineTemplateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineTemplateCallCount() int {
+	fake.pipelineTemplateMutex.RLock()
+	defer fake.pipelineTemplateMutex.RUnlock()
+	return len(fake.pipelineTemplateArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineTemplateCalls(stub func(string) (atc.PipelineTemplate, bool, error)) {
+	fake.pipelineTemplateMutex.Lock()
+	defer fake.pipelineTemplateMutex.Unlock()
+	fake.PipelineTemplateStub = stub
+}
+
+func (fake *FakeTeam) PipelineTemplateArgsForCall(i int) string {
+	fake.pipelineTemplateMutex.RLock()
+	defer fake.pipelineTemplateMutex.RUnlock()
+	argsForCall := fake.pipelineTemplateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) PipelineTemplateReturns(result1 atc.PipelineTemplate, result2 bool, result3 error) {
+	fake.pipelineTemplateMutex.Lock()
+	defer fake.pipelineTemplateMutex.Unlock()
+	fake.PipelineTemplateStub = nil
+	fake.pipelineTemplateReturns = struct {
+		result1 atc.PipelineTemplate
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineTemplateReturnsOnCall(i int, result1 atc.PipelineTemplate, result2 bool, result3 error) {
+	fake.pipelineTemplateMutex.Lock()
+	defer fake.pipelineTemplateMutex.Unlock()
+	fake.PipelineTemplateStub = nil
+	if fake.pipelineTemplateReturnsOnCall == nil {
+		fake.pipelineTemplateReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineTemplate
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineTemplateReturnsOnCall[i] = struct {
+		result1 atc.PipelineTemplate
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineTemplates() ([]atc.PipelineTemplate, error) {
+	fake.pipelineTemplatesMutex.Lock()
+	ret, specificReturn := fake.pipelineTemplatesReturnsOnCall[len(fake.pipelineTemplatesArgsForCall)]
+	fake.pipelineTemplatesArgsForCall = append(fake.pipelineTemplatesArgsForCall, struct {
+	}{})
+	stub := fake.PipelineTemplatesStub
+	fakeReturns := fake.pipelineTemplatesReturns
+	fake.recordInvocation("PipelineTemplates", []interface{}{})
+	fake.pipelineTemplatesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PipelineTemplatesCallCount() int {
+	fake.pipelineTemplatesMutex.RLock()
+	defer fake.pipelineTemplatesMutex.RUnlock()
+	return len(fake.pipelineTemplatesArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineTemplatesCalls(stub func() ([]atc.PipelineTemplate, error)) {
+	fake.pipelineTemplatesMutex.Lock()
+	defer fake.pipelineTemplatesMutex.Unlock()
+	fake.PipelineTemplatesStub = stub
+}
+
+func (fake *FakeTeam) PipelineTemplatesReturns(result1 []atc.PipelineTemplate, result2 error) {
+	fake.pipelineTemplatesMutex.Lock()
+	defer fake.pipelineTemplatesMutex.Unlock()
+	fake.PipelineTemplatesStub = nil
+	fake.pipelineTemplatesReturns = struct {
+		result1 []atc.PipelineTemplate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PipelineTemplatesReturnsOnCall(i int, result1 []atc.PipelineTemplate, result2 error) {
+	fake.pipelineTemplatesMutex.Lock()
+	defer fake.pipelineTemplatesMutex.Unlock()
+	fake.PipelineTemplatesStub = nil
+	if fake.pipelineTemplatesReturnsOnCall == nil {
+		fake.pipelineTemplatesReturnsOnCall = make(map[int]struct {
+			result1 []atc.PipelineTemplate
+			result2 error
+		})
+	}
+	fake.pipelineTemplatesReturnsOnCall[i] = struct {
+		result1 []atc.PipelineTemplate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Pipelines() ([]db.Pipeline, error) {
+	fake.pipelinesMutex.Lock()
+	ret, specificReturn := fake.pipelinesReturnsOnCall[len(fake.pipelinesArgsForCall)]
+	fake.pipelinesArgsForCall = append(fake.pipelinesArgsForCall, struct {
+	}{})
+	stub := fake.PipelinesStub
+	fakeReturns := fake.pipelinesReturns
+	fake.recordInvocation("Pipelines", []interface{}{})
+	fake.pipelinesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PipelinesCallCount() int {
+	fake.pipelinesMutex.RLock()
 	defer fake.pipelinesMutex.RUnlock()
 	return len(fake.pipelinesArgsForCall)
 }
@@ -1998,6 +2683,118 @@ func (fake *FakeTeam) PrivateAndPublicBuildsReturnsOnCall(i int, result1 []db.Bu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) PrunableState() (atc.TeamPrunableState, error) {
+	fake.prunableStateMutex.Lock()
+	ret, specificReturn := fake.prunableStateReturnsOnCall[len(fake.prunableStateArgsForCall)]
+	fake.prunableStateArgsForCall = append(fake.prunableStateArgsForCall, struct {
+	}{})
+	stub := fake.PrunableStateStub
+	fakeReturns := fake.prunableStateReturns
+	fake.recordInvocation("PrunableState", []interface{}{})
+	fake.prunableStateMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PrunableStateCallCount() int {
+	fake.prunableStateMutex.RLock()
+	defer fake.prunableStateMutex.RUnlock()
+	return len(fake.prunableStateArgsForCall)
+}
+
+func (fake *FakeTeam) PrunableStateCalls(stub func() (atc.TeamPrunableState, error)) {
+	fake.prunableStateMutex.Lock()
+	defer fake.prunableStateMutex.Unlock()
+	fake.PrunableStateStub = stub
+}
+
+func (fake *FakeTeam) PrunableStateReturns(result1 atc.TeamPrunableState, result2 error) {
+	fake.prunableStateMutex.Lock()
+	defer fake.prunableStateMutex.Unlock()
+	fake.PrunableStateStub = nil
+	fake.prunableStateReturns = struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PrunableStateReturnsOnCall(i int, result1 atc.TeamPrunableState, result2 error) {
+	fake.prunableStateMutex.Lock()
+	defer fake.prunableStateMutex.Unlock()
+	fake.PrunableStateStub = nil
+	if fake.prunableStateReturnsOnCall == nil {
+		fake.prunableStateReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamPrunableState
+			result2 error
+		})
+	}
+	fake.prunableStateReturnsOnCall[i] = struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PruneState() (atc.TeamPrunableState, error) {
+	fake.pruneStateMutex.Lock()
+	ret, specificReturn := fake.pruneStateReturnsOnCall[len(fake.pruneStateArgsForCall)]
+	fake.pruneStateArgsForCall = append(fake.pruneStateArgsForCall, struct {
+	}{})
+	stub := fake.PruneStateStub
+	fakeReturns := fake.pruneStateReturns
+	fake.recordInvocation("PruneState", []interface{}{})
+	fake.pruneStateMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PruneStateCallCount() int {
+	fake.pruneStateMutex.RLock()
+	defer fake.pruneStateMutex.RUnlock()
+	return len(fake.pruneStateArgsForCall)
+}
+
+func (fake *FakeTeam) PruneStateCalls(stub func() (atc.TeamPrunableState, error)) {
+	fake.pruneStateMutex.Lock()
+	defer fake.pruneStateMutex.Unlock()
+	fake.PruneStateStub = stub
+}
+
+func (fake *FakeTeam) PruneStateReturns(result1 atc.TeamPrunableState, result2 error) {
+	fake.pruneStateMutex.Lock()
+	defer fake.pruneStateMutex.Unlock()
+	fake.PruneStateStub = nil
+	fake.pruneStateReturns = struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PruneStateReturnsOnCall(i int, result1 atc.TeamPrunableState, result2 error) {
+	fake.pruneStateMutex.Lock()
+	defer fake.pruneStateMutex.Unlock()
+	fake.PruneStateStub = nil
+	if fake.pruneStateReturnsOnCall == nil {
+		fake.pruneStateReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamPrunableState
+			result2 error
+		})
+	}
+	fake.pruneStateReturnsOnCall[i] = struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) PublicPipelines() ([]db.Pipeline, error) {
 	fake.publicPipelinesMutex.Lock()
 	ret, specificReturn := fake.publicPipelinesReturnsOnCall[len(fake.publicPipelinesArgsForCall)]
@@ -2315,6 +3112,304 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ServiceAccounts() ([]db.ServiceAccount, error) {
+	fake.serviceAccountsMutex.Lock()
+	ret, specificReturn := fake.serviceAccountsReturnsOnCall[len(fake.serviceAccountsArgsForCall)]
+	fake.serviceAccountsArgsForCall = append(fake.serviceAccountsArgsForCall, struct {
+	}{})
+	stub := fake.ServiceAccountsStub
+	fakeReturns := fake.serviceAccountsReturns
+	fake.recordInvocation("ServiceAccounts", []interface{}{})
+	fake.serviceAccountsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ServiceAccountsCallCount() int {
+	fake.serviceAccountsMutex.RLock()
+	defer fake.serviceAccountsMutex.RUnlock()
+	return len(fake.serviceAccountsArgsForCall)
+}
+
+func (fake *FakeTeam) ServiceAccountsCalls(stub func() ([]db.ServiceAccount, error)) {
+	fake.serviceAccountsMutex.Lock()
+	defer fake.serviceAccountsMutex.Unlock()
+	fake.ServiceAccountsStub = stub
+}
+
+func (fake *FakeTeam) ServiceAccountsReturns(result1 []db.ServiceAccount, result2 error) {
+	fake.serviceAccountsMutex.Lock()
+	defer fake.serviceAccountsMutex.Unlock()
+	fake.ServiceAccountsStub = nil
+	fake.serviceAccountsReturns = struct {
+		result1 []db.ServiceAccount
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ServiceAccountsReturnsOnCall(i int, result1 []db.ServiceAccount, result2 error) {
+	fake.serviceAccountsMutex.Lock()
+	defer fake.serviceAccountsMutex.Unlock()
+	fake.ServiceAccountsStub = nil
+	if fake.serviceAccountsReturnsOnCall == nil {
+		fake.serviceAccountsReturnsOnCall = make(map[int]struct {
+			result1 []db.ServiceAccount
+			result2 error
+		})
+	}
+	fake.serviceAccountsReturnsOnCall[i] = struct {
+		result1 []db.ServiceAccount
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SessionIdleTimeout() time.Duration {
+	fake.sessionIdleTimeoutMutex.Lock()
+	ret, specificReturn := fake.sessionIdleTimeoutReturnsOnCall[len(fake.sessionIdleTimeoutArgsForCall)]
+	fake.sessionIdleTimeoutArgsForCall = append(fake.sessionIdleTimeoutArgsForCall, struct {
+	}{})
+	stub := fake.SessionIdleTimeoutStub
+	fakeReturns := fake.sessionIdleTimeoutReturns
+	fake.recordInvocation("SessionIdleTimeout", []interface{}{})
+	fake.sessionIdleTimeoutMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SessionIdleTimeoutCallCount() int {
+	fake.sessionIdleTimeoutMutex.RLock()
+	defer fake.sessionIdleTimeoutMutex.RUnlock()
+	return len(fake.sessionIdleTimeoutArgsForCall)
+}
+
+func (fake *FakeTeam) SessionIdleTimeoutCalls(stub func() time.Duration) {
+	fake.sessionIdleTimeoutMutex.Lock()
+	defer fake.sessionIdleTimeoutMutex.Unlock()
+	fake.SessionIdleTimeoutStub = stub
+}
+
+func (fake *FakeTeam) SessionIdleTimeoutReturns(result1 time.Duration) {
+	fake.sessionIdleTimeoutMutex.Lock()
+	defer fake.sessionIdleTimeoutMutex.Unlock()
+	fake.SessionIdleTimeoutStub = nil
+	fake.sessionIdleTimeoutReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeTeam) SessionIdleTimeoutReturnsOnCall(i int, result1 time.Duration) {
+	fake.sessionIdleTimeoutMutex.Lock()
+	defer fake.sessionIdleTimeoutMutex.Unlock()
+	fake.SessionIdleTimeoutStub = nil
+	if fake.sessionIdleTimeoutReturnsOnCall == nil {
+		fake.sessionIdleTimeoutReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+		})
+	}
+	fake.sessionIdleTimeoutReturnsOnCall[i] = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeTeam) SetPipelineTemplate(arg1 atc.PipelineTemplate) (bool, error) {
+	fake.setPipelineTemplateMutex.Lock()
+	ret, specificReturn := fake.setPipelineTemplateReturnsOnCall[len(fake.setPipelineTemplateArgsForCall)]
+	fake.setPipelineTemplateArgsForCall = append(fake.setPipelineTemplateArgsForCall, struct {
+		arg1 atc.PipelineTemplate
+	}{arg1})
+	stub := fake.SetPipelineTemplateStub
+	fakeReturns := fake.setPipelineTemplateReturns
+	fake.recordInvocation("SetPipelineTemplate", []interface{}{arg1})
+	fake.setPipelineTemplateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SetPipelineTemplateCallCount() int {
+	fake.setPipelineTemplateMutex.RLock()
+	defer fake.setPipelineTemplateMutex.RUnlock()
+	return len(fake.setPipelineTemplateArgsForCall)
+}
+
+func (fake *FakeTeam) SetPipelineTemplateCalls(stub func(atc.PipelineTemplate) (bool, error)) {
+	fake.setPipelineTemplateMutex.Lock()
+	defer fake.setPipelineTemplateMutex.Unlock()
+	fake.SetPipelineTemplateStub = stub
+}
+
+func (fake *FakeTeam) SetPipelineTemplateArgsForCall(i int) atc.PipelineTemplate {
+	fake.setPipelineTemplateMutex.RLock()
+	defer fake.setPipelineTemplateMutex.RUnlock()
+	argsForCall := fake.setPipelineTemplateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SetPipelineTemplateReturns(result1 bool, result2 error) {
+	fake.setPipelineTemplateMutex.Lock()
+	defer fake.setPipelineTemplateMutex.Unlock()
+	fake.SetPipelineTemplateStub = nil
+	fake.setPipelineTemplateReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetPipelineTemplateReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.setPipelineTemplateMutex.Lock()
+	defer fake.setPipelineTemplateMutex.Unlock()
+	fake.SetPipelineTemplateStub = nil
+	if fake.setPipelineTemplateReturnsOnCall == nil {
+		fake.setPipelineTemplateReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.setPipelineTemplateReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetWebhook(arg1 atc.TeamWebhook) (bool, error) {
+	fake.setWebhookMutex.Lock()
+	ret, specificReturn := fake.setWebhookReturnsOnCall[len(fake.setWebhookArgsForCall)]
+	fake.setWebhookArgsForCall = append(fake.setWebhookArgsForCall, struct {
+		arg1 atc.TeamWebhook
+	}{arg1})
+	stub := fake.SetWebhookStub
+	fakeReturns := fake.setWebhookReturns
+	fake.recordInvocation("SetWebhook", []interface{}{arg1})
+	fake.setWebhookMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SetWebhookCallCount() int {
+	fake.setWebhookMutex.RLock()
+	defer fake.setWebhookMutex.RUnlock()
+	return len(fake.setWebhookArgsForCall)
+}
+
+func (fake *FakeTeam) SetWebhookCalls(stub func(atc.TeamWebhook) (bool, error)) {
+	fake.setWebhookMutex.Lock()
+	defer fake.setWebhookMutex.Unlock()
+	fake.SetWebhookStub = stub
+}
+
+func (fake *FakeTeam) SetWebhookArgsForCall(i int) atc.TeamWebhook {
+	fake.setWebhookMutex.RLock()
+	defer fake.setWebhookMutex.RUnlock()
+	argsForCall := fake.setWebhookArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SetWebhookReturns(result1 bool, result2 error) {
+	fake.setWebhookMutex.Lock()
+	defer fake.setWebhookMutex.Unlock()
+	fake.SetWebhookStub = nil
+	fake.setWebhookReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetWebhookReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.setWebhookMutex.Lock()
+	defer fake.setWebhookMutex.Unlock()
+	fake.SetWebhookStub = nil
+	if fake.setWebhookReturnsOnCall == nil {
+		fake.setWebhookReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.setWebhookReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UpdateAuth(arg1 atc.Team) error {
+	fake.updateAuthMutex.Lock()
+	ret, specificReturn := fake.updateAuthReturnsOnCall[len(fake.updateAuthArgsForCall)]
+	fake.updateAuthArgsForCall = append(fake.updateAuthArgsForCall, struct {
+		arg1 atc.Team
+	}{arg1})
+	stub := fake.UpdateAuthStub
+	fakeReturns := fake.updateAuthReturns
+	fake.recordInvocation("UpdateAuth", []interface{}{arg1})
+	fake.updateAuthMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateAuthCallCount() int {
+	fake.updateAuthMutex.RLock()
+	defer fake.updateAuthMutex.RUnlock()
+	return len(fake.updateAuthArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateAuthCalls(stub func(atc.Team) error) {
+	fake.updateAuthMutex.Lock()
+	defer fake.updateAuthMutex.Unlock()
+	fake.UpdateAuthStub = stub
+}
+
+func (fake *FakeTeam) UpdateAuthArgsForCall(i int) atc.Team {
+	fake.updateAuthMutex.RLock()
+	defer fake.updateAuthMutex.RUnlock()
+	argsForCall := fake.updateAuthArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateAuthReturns(result1 error) {
+	fake.updateAuthMutex.Lock()
+	defer fake.updateAuthMutex.Unlock()
+	fake.UpdateAuthStub = nil
+	fake.updateAuthReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateAuthReturnsOnCall(i int, result1 error) {
+	fake.updateAuthMutex.Lock()
+	defer fake.updateAuthMutex.Unlock()
+	fake.UpdateAuthStub = nil
+	if fake.updateAuthReturnsOnCall == nil {
+		fake.updateAuthReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateAuthReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2376,6 +3471,62 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) Webhooks() ([]atc.TeamWebhook, error) {
+	fake.webhooksMutex.Lock()
+	ret, specificReturn := fake.webhooksReturnsOnCall[len(fake.webhooksArgsForCall)]
+	fake.webhooksArgsForCall = append(fake.webhooksArgsForCall, struct {
+	}{})
+	stub := fake.WebhooksStub
+	fakeReturns := fake.webhooksReturns
+	fake.recordInvocation("Webhooks", []interface{}{})
+	fake.webhooksMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) WebhooksCallCount() int {
+	fake.webhooksMutex.RLock()
+	defer fake.webhooksMutex.RUnlock()
+	return len(fake.webhooksArgsForCall)
+}
+
+func (fake *FakeTeam) WebhooksCalls(stub func() ([]atc.TeamWebhook, error)) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = stub
+}
+
+func (fake *FakeTeam) WebhooksReturns(result1 []atc.TeamWebhook, result2 error) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = nil
+	fake.webhooksReturns = struct {
+		result1 []atc.TeamWebhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) WebhooksReturnsOnCall(i int, result1 []atc.TeamWebhook, result2 error) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = nil
+	if fake.webhooksReturnsOnCall == nil {
+		fake.webhooksReturnsOnCall = make(map[int]struct {
+			result1 []atc.TeamWebhook
+			result2 error
+		})
+	}
+	fake.webhooksReturnsOnCall[i] = struct {
+		result1 []atc.TeamWebhook
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Workers() ([]db.Worker, error) {
 	fake.workersMutex.Lock()
 	ret, specificReturn := fake.workersReturnsOnCall[len(fake.workersArgsForCall)]
@@ -2435,6 +3586,8 @@ func (fake *FakeTeam) WorkersReturnsOnCall(i int, result1 []db.Worker, result2 e
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.accessTokenLifetimeMutex.RLock()
+	defer fake.accessTokenLifetimeMutex.RUnlock()
 	fake.adminMutex.RLock()
 	defer fake.adminMutex.RUnlock()
 	fake.authMutex.RLock()
@@ -2447,10 +3600,18 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.containersMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
+	fake.createServiceAccountMutex.RLock()
+	defer fake.createServiceAccountMutex.RUnlock()
 	fake.createStartedBuildMutex.RLock()
 	defer fake.createStartedBuildMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.deletePipelineTemplateMutex.RLock()
+	defer fake.deletePipelineTemplateMutex.RUnlock()
+	fake.deleteServiceAccountMutex.RLock()
+	defer fake.deleteServiceAccountMutex.RUnlock()
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
 	fake.findCheckContainersMutex.RLock()
 	defer fake.findCheckContainersMutex.RUnlock()
 	fake.findContainerByHandleMutex.RLock()
@@ -2481,10 +3642,20 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.orderPipelinesWithinGroupMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
+	fake.pipelineAuthMutex.RLock()
+	defer fake.pipelineAuthMutex.RUnlock()
+	fake.pipelineTemplateMutex.RLock()
+	defer fake.pipelineTemplateMutex.RUnlock()
+	fake.pipelineTemplatesMutex.RLock()
+	defer fake.pipelineTemplatesMutex.RUnlock()
 	fake.pipelinesMutex.RLock()
 	defer fake.pipelinesMutex.RUnlock()
 	fake.privateAndPublicBuildsMutex.RLock()
 	defer fake.privateAndPublicBuildsMutex.RUnlock()
+	fake.prunableStateMutex.RLock()
+	defer fake.prunableStateMutex.RUnlock()
+	fake.pruneStateMutex.RLock()
+	defer fake.pruneStateMutex.RUnlock()
 	fake.publicPipelinesMutex.RLock()
 	defer fake.publicPipelinesMutex.RUnlock()
 	fake.renameMutex.RLock()
@@ -2495,8 +3666,20 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.savePipelineMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.serviceAccountsMutex.RLock()
+	defer fake.serviceAccountsMutex.RUnlock()
+	fake.sessionIdleTimeoutMutex.RLock()
+	defer fake.sessionIdleTimeoutMutex.RUnlock()
+	fake.setPipelineTemplateMutex.RLock()
+	defer fake.setPipelineTemplateMutex.RUnlock()
+	fake.setWebhookMutex.RLock()
+	defer fake.setWebhookMutex.RUnlock()
+	fake.updateAuthMutex.RLock()
+	defer fake.updateAuthMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.webhooksMutex.RLock()
+	defer fake.webhooksMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
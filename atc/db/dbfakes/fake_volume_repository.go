@@ -9,6 +9,19 @@ import (
 )
 
 type FakeVolumeRepository struct {
+	CountVolumesByTypeStub        func(string) (map[db.VolumeType]int, error)
+	countVolumesByTypeMutex       sync.RWMutex
+	countVolumesByTypeArgsForCall []struct {
+		arg1 string
+	}
+	countVolumesByTypeReturns struct {
+		result1 map[db.VolumeType]int
+		result2 error
+	}
+	countVolumesByTypeReturnsOnCall map[int]struct {
+		result1 map[db.VolumeType]int
+		result2 error
+	}
 	CreateBaseResourceTypeVolumeStub        func(*db.UsedWorkerBaseResourceType) (db.CreatingVolume, error)
 	createBaseResourceTypeVolumeMutex       sync.RWMutex
 	createBaseResourceTypeVolumeArgsForCall []struct {
@@ -298,6 +311,70 @@ type FakeVolumeRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeVolumeRepository) CountVolumesByType(arg1 string) (map[db.VolumeType]int, error) {
+	fake.countVolumesByTypeMutex.Lock()
+	ret, specificReturn := fake.countVolumesByTypeReturnsOnCall[len(fake.countVolumesByTypeArgsForCall)]
+	fake.countVolumesByTypeArgsForCall = append(fake.countVolumesByTypeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CountVolumesByTypeStub
+	fakeReturns := fake.countVolumesByTypeReturns
+	fake.recordInvocation("CountVolumesByType", []interface{}{arg1})
+	fake.countVolumesByTypeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolumeRepository) CountVolumesByTypeCallCount() int {
+	fake.countVolumesByTypeMutex.RLock()
+	defer fake.countVolumesByTypeMutex.RUnlock()
+	return len(fake.countVolumesByTypeArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) CountVolumesByTypeCalls(stub func(string) (map[db.VolumeType]int, error)) {
+	fake.countVolumesByTypeMutex.Lock()
+	defer fake.countVolumesByTypeMutex.Unlock()
+	fake.CountVolumesByTypeStub = stub
+}
+
+func (fake *FakeVolumeRepository) CountVolumesByTypeArgsForCall(i int) string {
+	fake.countVolumesByTypeMutex.RLock()
+	defer fake.countVolumesByTypeMutex.RUnlock()
+	argsForCall := fake.countVolumesByTypeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVolumeRepository) CountVolumesByTypeReturns(result1 map[db.VolumeType]int, result2 error) {
+	fake.countVolumesByTypeMutex.Lock()
+	defer fake.countVolumesByTypeMutex.Unlock()
+	fake.CountVolumesByTypeStub = nil
+	fake.countVolumesByTypeReturns = struct {
+		result1 map[db.VolumeType]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeRepository) CountVolumesByTypeReturnsOnCall(i int, result1 map[db.VolumeType]int, result2 error) {
+	fake.countVolumesByTypeMutex.Lock()
+	defer fake.countVolumesByTypeMutex.Unlock()
+	fake.CountVolumesByTypeStub = nil
+	if fake.countVolumesByTypeReturnsOnCall == nil {
+		fake.countVolumesByTypeReturnsOnCall = make(map[int]struct {
+			result1 map[db.VolumeType]int
+			result2 error
+		})
+	}
+	fake.countVolumesByTypeReturnsOnCall[i] = struct {
+		result1 map[db.VolumeType]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeRepository) CreateBaseResourceTypeVolume(arg1 *db.UsedWorkerBaseResourceType) (db.CreatingVolume, error) {
 	fake.createBaseResourceTypeVolumeMutex.Lock()
 	ret, specificReturn := fake.createBaseResourceTypeVolumeReturnsOnCall[len(fake.createBaseResourceTypeVolumeArgsForCall)]
@@ -1612,6 +1689,8 @@ func (fake *FakeVolumeRepository) UpdateVolumesMissingSinceReturnsOnCall(i int,
 func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.countVolumesByTypeMutex.RLock()
+	defer fake.countVolumesByTypeMutex.RUnlock()
 	fake.createBaseResourceTypeVolumeMutex.RLock()
 	defer fake.createBaseResourceTypeVolumeMutex.RUnlock()
 	fake.createContainerVolumeMutex.RLock()
@@ -42,6 +42,16 @@ type FakeWorker struct {
 	activeVolumesReturnsOnCall map[int]struct {
 		result1 int
 	}
+	ArchStub        func() string
+	archMutex       sync.RWMutex
+	archArgsForCall []struct {
+	}
+	archReturns struct {
+		result1 string
+	}
+	archReturnsOnCall map[int]struct {
+		result1 string
+	}
 	BaggageclaimURLStub        func() *string
 	baggageclaimURLMutex       sync.RWMutex
 	baggageclaimURLArgsForCall []struct {
@@ -52,6 +62,16 @@ type FakeWorker struct {
 	baggageclaimURLReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	CPUCapacityStub        func() uint64
+	cPUCapacityMutex       sync.RWMutex
+	cPUCapacityArgsForCall []struct {
+	}
+	cPUCapacityReturns struct {
+		result1 uint64
+	}
+	cPUCapacityReturnsOnCall map[int]struct {
+		result1 uint64
+	}
 	CertsPathStub        func() *string
 	certsPathMutex       sync.RWMutex
 	certsPathArgsForCall []struct {
@@ -185,6 +205,16 @@ type FakeWorker struct {
 	landReturnsOnCall map[int]struct {
 		result1 error
 	}
+	MemoryCapacityStub        func() uint64
+	memoryCapacityMutex       sync.RWMutex
+	memoryCapacityArgsForCall []struct {
+	}
+	memoryCapacityReturns struct {
+		result1 uint64
+	}
+	memoryCapacityReturnsOnCall map[int]struct {
+		result1 uint64
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -225,6 +255,18 @@ type FakeWorker struct {
 	pruneReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ReleaseResourcesStub        func(int, atc.PlanID) error
+	releaseResourcesMutex       sync.RWMutex
+	releaseResourcesArgsForCall []struct {
+		arg1 int
+		arg2 atc.PlanID
+	}
+	releaseResourcesReturns struct {
+		result1 error
+	}
+	releaseResourcesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -237,6 +279,36 @@ type FakeWorker struct {
 		result1 bool
 		result2 error
 	}
+	ReserveResourcesStub        func(int, atc.PlanID, uint64, uint64) (bool, error)
+	reserveResourcesMutex       sync.RWMutex
+	reserveResourcesArgsForCall []struct {
+		arg1 int
+		arg2 atc.PlanID
+		arg3 uint64
+		arg4 uint64
+	}
+	reserveResourcesReturns struct {
+		result1 bool
+		result2 error
+	}
+	reserveResourcesReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ReservedResourcesStub        func() (uint64, uint64, error)
+	reservedResourcesMutex       sync.RWMutex
+	reservedResourcesArgsForCall []struct {
+	}
+	reservedResourcesReturns struct {
+		result1 uint64
+		result2 uint64
+		result3 error
+	}
+	reservedResourcesReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 uint64
+		result3 error
+	}
 	ResourceCertsStub        func() (*db.UsedWorkerResourceCerts, bool, error)
 	resourceCertsMutex       sync.RWMutex
 	resourceCertsArgsForCall []struct {
@@ -271,6 +343,16 @@ type FakeWorker struct {
 	retireReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RuntimeStub        func() string
+	runtimeMutex       sync.RWMutex
+	runtimeArgsForCall []struct {
+	}
+	runtimeReturns struct {
+		result1 string
+	}
+	runtimeReturnsOnCall map[int]struct {
+		result1 string
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -321,6 +403,18 @@ type FakeWorker struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	UpdateSizesStub        func(map[string]uint64, map[string]uint64) error
+	updateSizesMutex       sync.RWMutex
+	updateSizesArgsForCall []struct {
+		arg1 map[string]uint64
+		arg2 map[string]uint64
+	}
+	updateSizesReturns struct {
+		result1 error
+	}
+	updateSizesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	VersionStub        func() *string
 	versionMutex       sync.RWMutex
 	versionArgsForCall []struct {
@@ -497,6 +591,59 @@ func (fake *FakeWorker) ActiveVolumesReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Arch() string {
+	fake.archMutex.Lock()
+	ret, specificReturn := fake.archReturnsOnCall[len(fake.archArgsForCall)]
+	fake.archArgsForCall = append(fake.archArgsForCall, struct {
+	}{})
+	stub := fake.ArchStub
+	fakeReturns := fake.archReturns
+	fake.recordInvocation("Arch", []interface{}{})
+	fake.archMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ArchCallCount() int {
+	fake.archMutex.RLock()
+	defer fake.archMutex.RUnlock()
+	return len(fake.archArgsForCall)
+}
+
+func (fake *FakeWorker) ArchCalls(stub func() string) {
+	fake.archMutex.Lock()
+	defer fake.archMutex.Unlock()
+	fake.ArchStub = stub
+}
+
+func (fake *FakeWorker) ArchReturns(result1 string) {
+	fake.archMutex.Lock()
+	defer fake.archMutex.Unlock()
+	fake.ArchStub = nil
+	fake.archReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) ArchReturnsOnCall(i int, result1 string) {
+	fake.archMutex.Lock()
+	defer fake.archMutex.Unlock()
+	fake.ArchStub = nil
+	if fake.archReturnsOnCall == nil {
+		fake.archReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.archReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeWorker) BaggageclaimURL() *string {
 	fake.baggageclaimURLMutex.Lock()
 	ret, specificReturn := fake.baggageclaimURLReturnsOnCall[len(fake.baggageclaimURLArgsForCall)]
@@ -550,6 +697,59 @@ func (fake *FakeWorker) BaggageclaimURLReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) CPUCapacity() uint64 {
+	fake.cPUCapacityMutex.Lock()
+	ret, specificReturn := fake.cPUCapacityReturnsOnCall[len(fake.cPUCapacityArgsForCall)]
+	fake.cPUCapacityArgsForCall = append(fake.cPUCapacityArgsForCall, struct {
+	}{})
+	stub := fake.CPUCapacityStub
+	fakeReturns := fake.cPUCapacityReturns
+	fake.recordInvocation("CPUCapacity", []interface{}{})
+	fake.cPUCapacityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CPUCapacityCallCount() int {
+	fake.cPUCapacityMutex.RLock()
+	defer fake.cPUCapacityMutex.RUnlock()
+	return len(fake.cPUCapacityArgsForCall)
+}
+
+func (fake *FakeWorker) CPUCapacityCalls(stub func() uint64) {
+	fake.cPUCapacityMutex.Lock()
+	defer fake.cPUCapacityMutex.Unlock()
+	fake.CPUCapacityStub = stub
+}
+
+func (fake *FakeWorker) CPUCapacityReturns(result1 uint64) {
+	fake.cPUCapacityMutex.Lock()
+	defer fake.cPUCapacityMutex.Unlock()
+	fake.CPUCapacityStub = nil
+	fake.cPUCapacityReturns = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *FakeWorker) CPUCapacityReturnsOnCall(i int, result1 uint64) {
+	fake.cPUCapacityMutex.Lock()
+	defer fake.cPUCapacityMutex.Unlock()
+	fake.CPUCapacityStub = nil
+	if fake.cPUCapacityReturnsOnCall == nil {
+		fake.cPUCapacityReturnsOnCall = make(map[int]struct {
+			result1 uint64
+		})
+	}
+	fake.cPUCapacityReturnsOnCall[i] = struct {
+		result1 uint64
+	}{result1}
+}
+
 func (fake *FakeWorker) CertsPath() *string {
 	fake.certsPathMutex.Lock()
 	ret, specificReturn := fake.certsPathReturnsOnCall[len(fake.certsPathArgsForCall)]
@@ -1218,6 +1418,59 @@ func (fake *FakeWorker) LandReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) MemoryCapacity() uint64 {
+	fake.memoryCapacityMutex.Lock()
+	ret, specificReturn := fake.memoryCapacityReturnsOnCall[len(fake.memoryCapacityArgsForCall)]
+	fake.memoryCapacityArgsForCall = append(fake.memoryCapacityArgsForCall, struct {
+	}{})
+	stub := fake.MemoryCapacityStub
+	fakeReturns := fake.memoryCapacityReturns
+	fake.recordInvocation("MemoryCapacity", []interface{}{})
+	fake.memoryCapacityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) MemoryCapacityCallCount() int {
+	fake.memoryCapacityMutex.RLock()
+	defer fake.memoryCapacityMutex.RUnlock()
+	return len(fake.memoryCapacityArgsForCall)
+}
+
+func (fake *FakeWorker) MemoryCapacityCalls(stub func() uint64) {
+	fake.memoryCapacityMutex.Lock()
+	defer fake.memoryCapacityMutex.Unlock()
+	fake.MemoryCapacityStub = stub
+}
+
+func (fake *FakeWorker) MemoryCapacityReturns(result1 uint64) {
+	fake.memoryCapacityMutex.Lock()
+	defer fake.memoryCapacityMutex.Unlock()
+	fake.MemoryCapacityStub = nil
+	fake.memoryCapacityReturns = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *FakeWorker) MemoryCapacityReturnsOnCall(i int, result1 uint64) {
+	fake.memoryCapacityMutex.Lock()
+	defer fake.memoryCapacityMutex.Unlock()
+	fake.MemoryCapacityStub = nil
+	if fake.memoryCapacityReturnsOnCall == nil {
+		fake.memoryCapacityReturnsOnCall = make(map[int]struct {
+			result1 uint64
+		})
+	}
+	fake.memoryCapacityReturnsOnCall[i] = struct {
+		result1 uint64
+	}{result1}
+}
+
 func (fake *FakeWorker) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1430,6 +1683,68 @@ func (fake *FakeWorker) PruneReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ReleaseResources(arg1 int, arg2 atc.PlanID) error {
+	fake.releaseResourcesMutex.Lock()
+	ret, specificReturn := fake.releaseResourcesReturnsOnCall[len(fake.releaseResourcesArgsForCall)]
+	fake.releaseResourcesArgsForCall = append(fake.releaseResourcesArgsForCall, struct {
+		arg1 int
+		arg2 atc.PlanID
+	}{arg1, arg2})
+	stub := fake.ReleaseResourcesStub
+	fakeReturns := fake.releaseResourcesReturns
+	fake.recordInvocation("ReleaseResources", []interface{}{arg1, arg2})
+	fake.releaseResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ReleaseResourcesCallCount() int {
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
+	return len(fake.releaseResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) ReleaseResourcesCalls(stub func(int, atc.PlanID) error) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = stub
+}
+
+func (fake *FakeWorker) ReleaseResourcesArgsForCall(i int) (int, atc.PlanID) {
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
+	argsForCall := fake.releaseResourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorker) ReleaseResourcesReturns(result1 error) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = nil
+	fake.releaseResourcesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) ReleaseResourcesReturnsOnCall(i int, result1 error) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = nil
+	if fake.releaseResourcesReturnsOnCall == nil {
+		fake.releaseResourcesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseResourcesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorker) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -1486,6 +1801,132 @@ func (fake *FakeWorker) ReloadReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) ReserveResources(arg1 int, arg2 atc.PlanID, arg3 uint64, arg4 uint64) (bool, error) {
+	fake.reserveResourcesMutex.Lock()
+	ret, specificReturn := fake.reserveResourcesReturnsOnCall[len(fake.reserveResourcesArgsForCall)]
+	fake.reserveResourcesArgsForCall = append(fake.reserveResourcesArgsForCall, struct {
+		arg1 int
+		arg2 atc.PlanID
+		arg3 uint64
+		arg4 uint64
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ReserveResourcesStub
+	fakeReturns := fake.reserveResourcesReturns
+	fake.recordInvocation("ReserveResources", []interface{}{arg1, arg2, arg3, arg4})
+	fake.reserveResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) ReserveResourcesCallCount() int {
+	fake.reserveResourcesMutex.RLock()
+	defer fake.reserveResourcesMutex.RUnlock()
+	return len(fake.reserveResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) ReserveResourcesCalls(stub func(int, atc.PlanID, uint64, uint64) (bool, error)) {
+	fake.reserveResourcesMutex.Lock()
+	defer fake.reserveResourcesMutex.Unlock()
+	fake.ReserveResourcesStub = stub
+}
+
+func (fake *FakeWorker) ReserveResourcesArgsForCall(i int) (int, atc.PlanID, uint64, uint64) {
+	fake.reserveResourcesMutex.RLock()
+	defer fake.reserveResourcesMutex.RUnlock()
+	argsForCall := fake.reserveResourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeWorker) ReserveResourcesReturns(result1 bool, result2 error) {
+	fake.reserveResourcesMutex.Lock()
+	defer fake.reserveResourcesMutex.Unlock()
+	fake.ReserveResourcesStub = nil
+	fake.reserveResourcesReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReserveResourcesReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.reserveResourcesMutex.Lock()
+	defer fake.reserveResourcesMutex.Unlock()
+	fake.ReserveResourcesStub = nil
+	if fake.reserveResourcesReturnsOnCall == nil {
+		fake.reserveResourcesReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.reserveResourcesReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReservedResources() (uint64, uint64, error) {
+	fake.reservedResourcesMutex.Lock()
+	ret, specificReturn := fake.reservedResourcesReturnsOnCall[len(fake.reservedResourcesArgsForCall)]
+	fake.reservedResourcesArgsForCall = append(fake.reservedResourcesArgsForCall, struct {
+	}{})
+	stub := fake.ReservedResourcesStub
+	fakeReturns := fake.reservedResourcesReturns
+	fake.recordInvocation("ReservedResources", []interface{}{})
+	fake.reservedResourcesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorker) ReservedResourcesCallCount() int {
+	fake.reservedResourcesMutex.RLock()
+	defer fake.reservedResourcesMutex.RUnlock()
+	return len(fake.reservedResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) ReservedResourcesCalls(stub func() (uint64, uint64, error)) {
+	fake.reservedResourcesMutex.Lock()
+	defer fake.reservedResourcesMutex.Unlock()
+	fake.ReservedResourcesStub = stub
+}
+
+func (fake *FakeWorker) ReservedResourcesReturns(result1 uint64, result2 uint64, result3 error) {
+	fake.reservedResourcesMutex.Lock()
+	defer fake.reservedResourcesMutex.Unlock()
+	fake.ReservedResourcesStub = nil
+	fake.reservedResourcesReturns = struct {
+		result1 uint64
+		result2 uint64
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) ReservedResourcesReturnsOnCall(i int, result1 uint64, result2 uint64, result3 error) {
+	fake.reservedResourcesMutex.Lock()
+	defer fake.reservedResourcesMutex.Unlock()
+	fake.ReservedResourcesStub = nil
+	if fake.reservedResourcesReturnsOnCall == nil {
+		fake.reservedResourcesReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 uint64
+			result3 error
+		})
+	}
+	fake.reservedResourcesReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 uint64
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeWorker) ResourceCerts() (*db.UsedWorkerResourceCerts, bool, error) {
 	fake.resourceCertsMutex.Lock()
 	ret, specificReturn := fake.resourceCertsReturnsOnCall[len(fake.resourceCertsArgsForCall)]
@@ -1651,6 +2092,59 @@ func (fake *FakeWorker) RetireReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Runtime() string {
+	fake.runtimeMutex.Lock()
+	ret, specificReturn := fake.runtimeReturnsOnCall[len(fake.runtimeArgsForCall)]
+	fake.runtimeArgsForCall = append(fake.runtimeArgsForCall, struct {
+	}{})
+	stub := fake.RuntimeStub
+	fakeReturns := fake.runtimeReturns
+	fake.recordInvocation("Runtime", []interface{}{})
+	fake.runtimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) RuntimeCallCount() int {
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
+	return len(fake.runtimeArgsForCall)
+}
+
+func (fake *FakeWorker) RuntimeCalls(stub func() string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = stub
+}
+
+func (fake *FakeWorker) RuntimeReturns(result1 string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = nil
+	fake.runtimeReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) RuntimeReturnsOnCall(i int, result1 string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = nil
+	if fake.runtimeReturnsOnCall == nil {
+		fake.runtimeReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.runtimeReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeWorker) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -1916,6 +2410,68 @@ func (fake *FakeWorker) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) UpdateSizes(arg1 map[string]uint64, arg2 map[string]uint64) error {
+	fake.updateSizesMutex.Lock()
+	ret, specificReturn := fake.updateSizesReturnsOnCall[len(fake.updateSizesArgsForCall)]
+	fake.updateSizesArgsForCall = append(fake.updateSizesArgsForCall, struct {
+		arg1 map[string]uint64
+		arg2 map[string]uint64
+	}{arg1, arg2})
+	stub := fake.UpdateSizesStub
+	fakeReturns := fake.updateSizesReturns
+	fake.recordInvocation("UpdateSizes", []interface{}{arg1, arg2})
+	fake.updateSizesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) UpdateSizesCallCount() int {
+	fake.updateSizesMutex.RLock()
+	defer fake.updateSizesMutex.RUnlock()
+	return len(fake.updateSizesArgsForCall)
+}
+
+func (fake *FakeWorker) UpdateSizesCalls(stub func(map[string]uint64, map[string]uint64) error) {
+	fake.updateSizesMutex.Lock()
+	defer fake.updateSizesMutex.Unlock()
+	fake.UpdateSizesStub = stub
+}
+
+func (fake *FakeWorker) UpdateSizesArgsForCall(i int) (map[string]uint64, map[string]uint64) {
+	fake.updateSizesMutex.RLock()
+	defer fake.updateSizesMutex.RUnlock()
+	argsForCall := fake.updateSizesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorker) UpdateSizesReturns(result1 error) {
+	fake.updateSizesMutex.Lock()
+	defer fake.updateSizesMutex.Unlock()
+	fake.UpdateSizesStub = nil
+	fake.updateSizesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) UpdateSizesReturnsOnCall(i int, result1 error) {
+	fake.updateSizesMutex.Lock()
+	defer fake.updateSizesMutex.Unlock()
+	fake.UpdateSizesStub = nil
+	if fake.updateSizesReturnsOnCall == nil {
+		fake.updateSizesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateSizesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorker) Version() *string {
 	fake.versionMutex.Lock()
 	ret, specificReturn := fake.versionReturnsOnCall[len(fake.versionArgsForCall)]
@@ -1978,8 +2534,12 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.activeTasksMutex.RUnlock()
 	fake.activeVolumesMutex.RLock()
 	defer fake.activeVolumesMutex.RUnlock()
+	fake.archMutex.RLock()
+	defer fake.archMutex.RUnlock()
 	fake.baggageclaimURLMutex.RLock()
 	defer fake.baggageclaimURLMutex.RUnlock()
+	fake.cPUCapacityMutex.RLock()
+	defer fake.cPUCapacityMutex.RUnlock()
 	fake.certsPathMutex.RLock()
 	defer fake.certsPathMutex.RUnlock()
 	fake.createContainerMutex.RLock()
@@ -2004,6 +2564,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.increaseActiveTasksMutex.RUnlock()
 	fake.landMutex.RLock()
 	defer fake.landMutex.RUnlock()
+	fake.memoryCapacityMutex.RLock()
+	defer fake.memoryCapacityMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.noProxyMutex.RLock()
@@ -2012,14 +2574,22 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.platformMutex.RUnlock()
 	fake.pruneMutex.RLock()
 	defer fake.pruneMutex.RUnlock()
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.reserveResourcesMutex.RLock()
+	defer fake.reserveResourcesMutex.RUnlock()
+	fake.reservedResourcesMutex.RLock()
+	defer fake.reservedResourcesMutex.RUnlock()
 	fake.resourceCertsMutex.RLock()
 	defer fake.resourceCertsMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.retireMutex.RLock()
 	defer fake.retireMutex.RUnlock()
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.stateMutex.RLock()
@@ -2030,6 +2600,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.updateSizesMutex.RLock()
+	defer fake.updateSizesMutex.RUnlock()
 	fake.versionMutex.RLock()
 	defer fake.versionMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
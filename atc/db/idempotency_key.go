@@ -0,0 +1,30 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+var ErrIdempotencyKeyConflict = errors.New("idempotency key has already been used")
+
+const idempotencyKeyIndex = "builds_pipeline_id_idempotency_key_uniq"
+
+type idempotencyKeyContextKey struct{}
+
+// ContextWithIdempotencyKey returns a context which causes check builds
+// created with it to be recorded under the given idempotency key.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+func isIdempotencyKeyConflict(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == pqUniqueViolationErrCode && pqErr.Constraint == idempotencyKeyIndex
+}
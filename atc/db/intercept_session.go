@@ -0,0 +1,81 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// InterceptSession is a named process in a container which clients can
+// detach from and reattach to until it expires.
+type InterceptSession struct {
+	Name      string
+	ProcessID string
+	ExpiresAt time.Time
+}
+
+func (repository *containerRepository) FindInterceptSession(handle string, name string) (InterceptSession, bool, error) {
+	session := InterceptSession{Name: name}
+
+	err := psql.Select("s.process_id", "s.expires_at").
+		From("intercept_sessions s").
+		Join("containers c ON c.id = s.container_id").
+		Where(sq.Eq{
+			"c.handle": handle,
+			"s.name":   name,
+		}).
+		RunWith(repository.conn).
+		QueryRow().
+		Scan(&session.ProcessID, &session.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return InterceptSession{}, false, nil
+		}
+
+		return InterceptSession{}, false, err
+	}
+
+	return session, true, nil
+}
+
+// SaveInterceptSession creates the session, or replaces the one with the same
+// name, so that it expires once the ttl has passed. While the container holds
+// sessions which haven't expired it's not garbage collected.
+func (repository *containerRepository) SaveInterceptSession(handle string, name string, processID string, ttl time.Duration) error {
+	result, err := repository.conn.Exec(`
+		INSERT INTO intercept_sessions (container_id, name, process_id, expires_at)
+		SELECT id, $2, $3, now() + make_interval(secs => $4)
+		FROM containers
+		WHERE handle = $1 AND state = $5
+		ON CONFLICT (container_id, name) DO UPDATE SET
+			process_id = EXCLUDED.process_id,
+			expires_at = EXCLUDED.expires_at
+	`, handle, name, processID, int(ttl.Seconds()), atc.ContainerStateCreated)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrContainerDisappeared
+	}
+
+	return nil
+}
+
+func (repository *containerRepository) DeleteInterceptSession(handle string, processID string) error {
+	_, err := repository.conn.Exec(`
+		DELETE FROM intercept_sessions s
+		USING containers c
+		WHERE c.id = s.container_id
+		AND c.handle = $1
+		AND s.process_id = $2
+	`, handle, processID)
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/vars"
 	"github.com/lib/pq"
 )
 
@@ -58,13 +60,21 @@ type Job interface {
 	ID() int
 	Name() string
 	Paused() bool
+	PausedBy() string
+	PausedAt() time.Time
+	PauseReason() string
 	FirstLoggedBuildID() int
 	TeamID() int
 	TeamName() string
 	Tags() []string
 	Public() bool
 	ScheduleRequestedTime() time.Time
+	AfterFinishedTime() time.Time
+	AfterTriggeredTime() time.Time
 	MaxInFlight() int
+	Priority() int
+	Schedule() *atc.JobScheduleConfig
+	ScheduleAnchor() time.Time
 	DisableManualTrigger() bool
 
 	Config() (atc.JobConfig, error)
@@ -74,20 +84,29 @@ type Job interface {
 
 	Reload() (bool, error)
 
-	Pause() error
+	Pause(pausedBy string, reason string) error
 	Unpause() error
 
 	ScheduleBuild(Build) (bool, error)
 	CreateBuild(createdBy string) (Build, error)
+	CreateBuildWithIdempotencyKey(createdBy string, key string) (Build, error)
+	CreateBuildWithExplicitInputs(createdBy string, key string, inputs []ExplicitBuildInput) (Build, error)
+	CreateScheduledBuild(key string) (Build, error)
+	VersionPassedJobs(resourceID int, version atc.Version, jobNames []string) (bool, error)
 	RerunBuild(build Build, createdBy string) (Build, error)
+	RerunBuildWithVars(build Build, createdBy string, buildVars vars.StaticVariables) (Build, error)
 
 	RequestSchedule() error
 	UpdateLastScheduled(time.Time) error
+	UpdateScheduleAnchor(time.Time) error
+	UpdateAfterTriggered(time.Time) error
 
 	Builds(page Page) ([]Build, Pagination, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
 	Build(name string) (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
+	Statistics(window int) (JobStatistics, error)
+	VersionsSinceLastSuccess(resourceName string, limit int) ([]atc.Version, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
 	EnsurePendingBuildExists(context.Context) error
 	GetPendingBuilds() ([]Build, error)
@@ -104,11 +123,28 @@ type Job interface {
 	HasNewInputs() bool
 }
 
-var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.public", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.instance_vars", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.schedule_requested", "j.max_in_flight", "j.disable_manual_trigger").
+var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.public", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.instance_vars", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.schedule_requested", "j.after_finished", "j.after_triggered", "j.max_in_flight", "j.priority", "j.schedule", "j.schedule_anchor", "j.disable_manual_trigger", "j.paused_by", "j.paused_at", "j.pause_reason").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
 
+// JobStatisticsWindow is the number of most recent completed builds from
+// which a job's statistics are computed.
+const JobStatisticsWindow = 50
+
+// JobStatistics summarizes the health of a job over its most recent
+// completed builds. Aborted builds are not counted.
+type JobStatistics struct {
+	Builds      int
+	SuccessRate float64
+	DurationP50 time.Duration
+	DurationP95 time.Duration
+
+	// LastSucceeded is the end time of the job's latest successful build,
+	// regardless of whether it falls within the window.
+	LastSucceeded time.Time
+}
+
 type FirstLoggedBuildIDDecreasedError struct {
 	Job   string
 	OldID int
@@ -125,6 +161,9 @@ type job struct {
 	id                    int
 	name                  string
 	paused                bool
+	pausedBy              string
+	pausedAt              time.Time
+	pauseReason           string
 	public                bool
 	firstLoggedBuildID    int
 	teamID                int
@@ -132,7 +171,12 @@ type job struct {
 	tags                  []string
 	hasNewInputs          bool
 	scheduleRequestedTime time.Time
+	afterFinishedTime     time.Time
+	afterTriggeredTime    time.Time
 	maxInFlight           int
+	priority              int
+	schedule              *atc.JobScheduleConfig
+	scheduleAnchor        time.Time
 	disableManualTrigger  bool
 
 	config    *atc.JobConfig
@@ -186,6 +230,9 @@ func (jobs Jobs) Configs() (atc.JobConfigs, error) {
 func (j *job) ID() int                          { return j.id }
 func (j *job) Name() string                     { return j.name }
 func (j *job) Paused() bool                     { return j.paused }
+func (j *job) PausedBy() string                 { return j.pausedBy }
+func (j *job) PausedAt() time.Time              { return j.pausedAt }
+func (j *job) PauseReason() string              { return j.pauseReason }
 func (j *job) Public() bool                     { return j.public }
 func (j *job) FirstLoggedBuildID() int          { return j.firstLoggedBuildID }
 func (j *job) TeamID() int                      { return j.teamID }
@@ -193,7 +240,12 @@ func (j *job) TeamName() string                 { return j.teamName }
 func (j *job) Tags() []string                   { return j.tags }
 func (j *job) HasNewInputs() bool               { return j.hasNewInputs }
 func (j *job) ScheduleRequestedTime() time.Time { return j.scheduleRequestedTime }
+func (j *job) AfterFinishedTime() time.Time     { return j.afterFinishedTime }
+func (j *job) AfterTriggeredTime() time.Time    { return j.afterTriggeredTime }
 func (j *job) MaxInFlight() int                 { return j.maxInFlight }
+func (j *job) Priority() int                    { return j.priority }
+func (j *job) Schedule() *atc.JobScheduleConfig { return j.schedule }
+func (j *job) ScheduleAnchor() time.Time        { return j.scheduleAnchor }
 func (j *job) DisableManualTrigger() bool       { return j.disableManualTrigger }
 
 func (j *job) Config() (atc.JobConfig, error) {
@@ -401,12 +453,14 @@ func (j *job) Reload() (bool, error) {
 	return true, nil
 }
 
-func (j *job) Pause() error {
-	return j.updatePausedJob(true)
+// Pause stops new builds of the job from being scheduled, recording who
+// paused it and why so that others can tell whether it is safe to unpause.
+func (j *job) Pause(pausedBy string, reason string) error {
+	return j.updatePausedJob(true, pausedBy, reason)
 }
 
 func (j *job) Unpause() error {
-	return j.updatePausedJob(false)
+	return j.updatePausedJob(false, "", "")
 }
 
 func (j *job) FinishedAndNextBuild() (Build, Build, error) {
@@ -445,6 +499,179 @@ func (j *job) FinishedAndNextBuild() (Build, Build, error) {
 	return finished, next, nil
 }
 
+func (j *job) Statistics(window int) (JobStatistics, error) {
+	rows, err := psql.Select("b.status", "b.start_time", "b.end_time").
+		From("builds b").
+		Where(sq.Eq{
+			"b.job_id": j.id,
+			"b.status": []string{
+				string(BuildStatusSucceeded),
+				string(BuildStatusFailed),
+				string(BuildStatusErrored),
+			},
+		}).
+		OrderBy("b.id DESC").
+		Limit(uint64(window)).
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return JobStatistics{}, err
+	}
+
+	defer Close(rows)
+
+	var (
+		stats     JobStatistics
+		succeeded int
+		durations []time.Duration
+	)
+
+	for rows.Next() {
+		var (
+			status             string
+			startTime, endTime pq.NullTime
+		)
+
+		err = rows.Scan(&status, &startTime, &endTime)
+		if err != nil {
+			return JobStatistics{}, err
+		}
+
+		stats.Builds++
+
+		if BuildStatus(status) == BuildStatusSucceeded {
+			succeeded++
+		}
+
+		// builds which errored before starting have no duration
+		if startTime.Valid && endTime.Valid {
+			durations = append(durations, endTime.Time.Sub(startTime.Time))
+		}
+	}
+
+	if stats.Builds > 0 {
+		stats.SuccessRate = float64(succeeded) / float64(stats.Builds)
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	stats.DurationP50 = percentile(durations, 0.5)
+	stats.DurationP95 = percentile(durations, 0.95)
+
+	var lastSucceeded pq.NullTime
+	err = psql.Select("max(end_time)").
+		From("builds").
+		Where(sq.Eq{
+			"job_id": j.id,
+			"status": string(BuildStatusSucceeded),
+		}).
+		RunWith(j.conn).
+		QueryRow().
+		Scan(&lastSucceeded)
+	if err != nil {
+		return JobStatistics{}, err
+	}
+
+	if lastSucceeded.Valid {
+		stats.LastSucceeded = lastSucceeded.Time
+	}
+
+	return stats, nil
+}
+
+// VersionsSinceLastSuccess returns the newest enabled versions of the
+// resource, up to the limit, which are newer than any version used by a
+// succeeded build of the job, oldest first. If no succeeded build used the
+// resource, only its newest version is returned.
+func (j *job) VersionsSinceLastSuccess(resourceName string, limit int) ([]atc.Version, error) {
+	var lastCheckOrder sql.NullInt64
+	err := psql.Select("max(v.check_order)").
+		From("build_resource_config_version_inputs i").
+		Join("builds b ON b.id = i.build_id").
+		Join("resources r ON r.id = i.resource_id").
+		Join("resource_config_versions v ON v.version_md5 = i.version_md5 AND v.resource_config_scope_id = r.resource_config_scope_id").
+		Where(sq.Eq{
+			"b.job_id":      j.id,
+			"b.status":      string(BuildStatusSucceeded),
+			"r.name":        resourceName,
+			"r.pipeline_id": j.pipelineID,
+		}).
+		RunWith(j.conn).
+		QueryRow().
+		Scan(&lastCheckOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	query := psql.Select("v.version").
+		From("resource_config_versions v").
+		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
+		Where(sq.Eq{
+			"r.name":        resourceName,
+			"r.pipeline_id": j.pipelineID,
+		}).
+		Where(sq.Expr("(r.id, v.version_md5) NOT IN (SELECT resource_id, version_md5 FROM resource_disabled_versions)")).
+		OrderBy("v.check_order DESC")
+
+	if lastCheckOrder.Valid {
+		query = query.
+			Where(sq.Gt{"v.check_order": lastCheckOrder.Int64}).
+			Limit(uint64(limit))
+	} else {
+		query = query.Limit(1)
+	}
+
+	rows, err := query.RunWith(j.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	versions := []atc.Version{}
+	for rows.Next() {
+		var payload []byte
+		err = rows.Scan(&payload)
+		if err != nil {
+			return nil, err
+		}
+
+		var version atc.Version
+		err = json.Unmarshal(payload, &version)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// the newest versions were selected, but they're iterated over oldest
+	// first
+	for i, j := 0, len(versions)-1; i < j; i, j = i+1, j-1 {
+		versions[i], versions[j] = versions[j], versions[i]
+	}
+
+	return versions, nil
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
 func (j *job) UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error {
 	if j.firstLoggedBuildID > newFirstLoggedBuildID {
 		return FirstLoggedBuildIDDecreasedError{
@@ -684,12 +911,12 @@ func (j *job) EnsurePendingBuildExists(ctx context.Context) error {
 	}
 
 	rows, err := tx.Query(`
-		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, needs_v6_migration, span_context)
-		SELECT $1, $2, $3, $4, 'pending', false, $5
+		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, needs_v6_migration, span_context, trigger_type)
+		SELECT $1, $2, $3, $4, 'pending', false, $5, $6
 		WHERE NOT EXISTS
 			(SELECT id FROM builds WHERE job_id = $2 AND status = 'pending')
 		RETURNING id
-	`, buildName, j.id, j.pipelineID, j.teamID, string(spanContextJSON))
+	`, buildName, j.id, j.pipelineID, j.teamID, string(spanContextJSON), atc.BuildTriggerAutomatic)
 	if err != nil {
 		return err
 	}
@@ -772,6 +999,75 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 }
 
 func (j *job) CreateBuild(createdBy string) (Build, error) {
+	return j.createManualBuild(atc.BuildTriggerManual, createdBy, "")
+}
+
+// CreateBuildWithIdempotencyKey creates a build just like CreateBuild, but
+// records the given key on it. ErrIdempotencyKeyConflict is returned if a
+// build in the pipeline has already been created with the same key.
+func (j *job) CreateBuildWithIdempotencyKey(createdBy string, key string) (Build, error) {
+	return j.createManualBuild(atc.BuildTriggerManual, createdBy, key)
+}
+
+// ExplicitBuildInput is a version chosen for an input of a manually triggered
+// build, used instead of the version the scheduler would otherwise pick.
+type ExplicitBuildInput struct {
+	Name       string
+	ResourceID int
+	Version    atc.Version
+}
+
+// CreateBuildWithExplicitInputs creates a build just like
+// CreateBuildWithIdempotencyKey, except that the given inputs will be run
+// with the given versions. The key may be empty.
+func (j *job) CreateBuildWithExplicitInputs(createdBy string, key string, inputs []ExplicitBuildInput) (Build, error) {
+	return j.createManualBuild(atc.BuildTriggerManual, createdBy, key, inputs...)
+}
+
+// CreateScheduledBuild creates a build for a run of the job's schedule. It
+// is run like a manually triggered build, but isn't created by anyone. The
+// key is handled as by CreateBuildWithIdempotencyKey.
+func (j *job) CreateScheduledBuild(key string) (Build, error) {
+	return j.createManualBuild(atc.BuildTriggerSchedule, "", key)
+}
+
+// VersionPassedJobs reports whether the version of the resource has been an
+// input or output of a successful build of each of the given jobs in the
+// pipeline, i.e. whether it satisfies an input's passed constraints.
+func (j *job) VersionPassedJobs(resourceID int, version atc.Version, jobNames []string) (bool, error) {
+	if len(jobNames) == 0 {
+		return true, nil
+	}
+
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return false, err
+	}
+
+	var passed int
+	err = psql.Select("COUNT(DISTINCT j.id)").
+		From("jobs j").
+		Join("builds b ON b.job_id = j.id").
+		Where(sq.Eq{
+			"j.pipeline_id": j.pipelineID,
+			"j.name":        jobNames,
+			"b.status":      BuildStatusSucceeded,
+		}).
+		Where(sq.Or{
+			sq.Expr("EXISTS (SELECT 1 FROM build_resource_config_version_inputs i WHERE i.build_id = b.id AND i.resource_id = ? AND i.version_md5 = md5(?))", resourceID, versionJSON),
+			sq.Expr("EXISTS (SELECT 1 FROM build_resource_config_version_outputs o WHERE o.build_id = b.id AND o.resource_id = ? AND o.version_md5 = md5(?))", resourceID, versionJSON),
+		}).
+		RunWith(j.conn).
+		QueryRow().
+		Scan(&passed)
+	if err != nil {
+		return false, err
+	}
+
+	return passed == len(jobNames), nil
+}
+
+func (j *job) createManualBuild(trigger atc.BuildTrigger, createdBy string, idempotencyKey string, explicitInputs ...ExplicitBuildInput) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -784,20 +1080,46 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 		return nil, err
 	}
 
-	build := newEmptyBuild(j.conn, j.lockFactory)
-	err = createBuild(tx, build, map[string]interface{}{
+	vals := map[string]interface{}{
 		"name":               buildName,
 		"job_id":             j.id,
 		"pipeline_id":        j.pipelineID,
 		"team_id":            j.teamID,
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
-		"created_by":         createdBy,
-	})
+		"trigger_type":       trigger,
+	}
+
+	if trigger != atc.BuildTriggerSchedule {
+		vals["created_by"] = createdBy
+	}
+
+	if idempotencyKey != "" {
+		vals["idempotency_key"] = idempotencyKey
+	}
+
+	build := newEmptyBuild(j.conn, j.lockFactory)
+	err = createBuild(tx, build, vals)
 	if err != nil {
 		return nil, err
 	}
 
+	for _, input := range explicitInputs {
+		versionJSON, err := json.Marshal(input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = psql.Insert("build_explicit_inputs").
+			Columns("build_id", "input_name", "resource_id", "version_md5").
+			Values(build.ID(), input.Name, input.ResourceID, sq.Expr("md5(?)", versionJSON)).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	latestNonRerunID, err := latestCompletedNonRerunBuild(tx, j.id)
 	if err != nil {
 		return nil, err
@@ -822,8 +1144,16 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 }
 
 func (j *job) RerunBuild(buildToRerun Build, createdBy string) (Build, error) {
+	return j.RerunBuildWithVars(buildToRerun, createdBy, nil)
+}
+
+// RerunBuildWithVars reruns a build just like RerunBuild, with the given vars
+// overriding those of the build being rerun. They are only used for vars left
+// to be resolved when the build runs; vars interpolated when the pipeline was
+// set can't be overridden.
+func (j *job) RerunBuildWithVars(buildToRerun Build, createdBy string, buildVars vars.StaticVariables) (Build, error) {
 	for {
-		rerunBuild, err := j.tryRerunBuild(buildToRerun, createdBy)
+		rerunBuild, err := j.tryRerunBuild(buildToRerun, createdBy, buildVars)
 		if err != nil {
 			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
 				continue
@@ -836,7 +1166,25 @@ func (j *job) RerunBuild(buildToRerun Build, createdBy string) (Build, error) {
 	}
 }
 
-func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error) {
+func (j *job) tryRerunBuild(buildToRerun Build, createdBy string, overrides vars.StaticVariables) (Build, error) {
+	buildVars, err := buildToRerun.Vars()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(overrides) > 0 {
+		merged := vars.StaticVariables{}
+		for name, val := range buildVars {
+			merged[name] = val
+		}
+
+		for name, val := range overrides {
+			merged[name] = val
+		}
+
+		buildVars = merged
+	}
+
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -854,8 +1202,7 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		return nil, err
 	}
 
-	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
-	err = createBuild(tx, rerunBuild, map[string]interface{}{
+	vals := map[string]interface{}{
 		"name":         rerunBuildName,
 		"job_id":       j.id,
 		"pipeline_id":  j.pipelineID,
@@ -864,7 +1211,26 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		"rerun_of":     buildToRerunID,
 		"rerun_number": rerunNumber,
 		"created_by":   createdBy,
-	})
+		"trigger_type": atc.BuildTriggerManual,
+	}
+
+	if len(buildVars) > 0 {
+		payload, err := json.Marshal(buildVars)
+		if err != nil {
+			return nil, err
+		}
+
+		encryptedVars, nonce, err := j.conn.EncryptionStrategy().Encrypt(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		vals["vars"] = encryptedVars
+		vals["vars_nonce"] = nonce
+	}
+
+	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
+	err = createBuild(tx, rerunBuild, vals)
 	if err != nil {
 		return nil, err
 	}
@@ -1026,6 +1392,40 @@ func (j *job) UpdateLastScheduled(requestedTime time.Time) error {
 	return err
 }
 
+func (j *job) UpdateScheduleAnchor(anchor time.Time) error {
+	_, err := psql.Update("jobs").
+		Set("schedule_anchor", anchor).
+		Where(sq.Eq{
+			"id": j.id,
+		}).
+		RunWith(j.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	j.scheduleAnchor = anchor
+
+	return nil
+}
+
+func (j *job) UpdateAfterTriggered(finishedTime time.Time) error {
+	_, err := psql.Update("jobs").
+		Set("after_triggered", finishedTime).
+		Where(sq.Eq{
+			"id": j.id,
+		}).
+		RunWith(j.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	j.afterTriggeredTime = finishedTime
+
+	return nil
+}
+
 func (j *job) getRunningBuildsBySerialGroup(tx Tx, serialGroups []string) ([]Build, error) {
 	rows, err := buildsQuery.Options(`DISTINCT ON (b.id)`).
 		Join(`jobs_serial_groups jsg ON j.id = jsg.job_id`).
@@ -1088,9 +1488,24 @@ func (j *job) getNextPendingBuildBySerialGroup(tx Tx, serialGroups []string) (Bu
 	return build, true, nil
 }
 
-func (j *job) updatePausedJob(pause bool) error {
-	result, err := psql.Update("jobs").
+func (j *job) updatePausedJob(pause bool, pausedBy string, reason string) error {
+	var pausedAt interface{}
+	if pause {
+		pausedAt = sq.Expr("now()")
+	}
+
+	update := psql.Update("jobs").
 		Set("paused", pause).
+		Set("paused_by", newNullString(pausedBy)).
+		Set("paused_at", pausedAt).
+		Set("pause_reason", newNullString(reason))
+
+	if !pause {
+		// runs of the schedule missed while paused are skipped
+		update = update.Set("schedule_anchor", sq.Expr("now()"))
+	}
+
+	result, err := update.
 		Where(sq.Eq{"id": j.id}).
 		RunWith(j.conn).
 		Exec()
@@ -1378,13 +1793,21 @@ func scanJob(j *job, row scannable) error {
 		config               sql.NullString
 		nonce                sql.NullString
 		pipelineInstanceVars sql.NullString
+		pausedBy             sql.NullString
+		pausedAt             pq.NullTime
+		pauseReason          sql.NullString
+		schedule             sql.NullString
 	)
 
-	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.disableManualTrigger)
+	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.afterFinishedTime, &j.afterTriggeredTime, &j.maxInFlight, &j.priority, &schedule, &j.scheduleAnchor, &j.disableManualTrigger, &pausedBy, &pausedAt, &pauseReason)
 	if err != nil {
 		return err
 	}
 
+	j.pausedBy = pausedBy.String
+	j.pausedAt = pausedAt.Time
+	j.pauseReason = pauseReason.String
+
 	if nonce.Valid {
 		j.nonce = &nonce.String
 	}
@@ -1393,6 +1816,13 @@ func scanJob(j *job, row scannable) error {
 		j.rawConfig = &config.String
 	}
 
+	if schedule.Valid {
+		err = json.Unmarshal([]byte(schedule.String), &j.schedule)
+		if err != nil {
+			return err
+		}
+	}
+
 	if pipelineInstanceVars.Valid {
 		err = json.Unmarshal([]byte(pipelineInstanceVars.String), &j.pipelineInstanceVars)
 		if err != nil {
@@ -1445,6 +1875,63 @@ func requestSchedule(tx Tx, jobID int) error {
 	return nil
 }
 
+// requestScheduleOnJobsAfter records that a build of the job has finished on
+// the jobs configured to run after it, and requests them to be scheduled so
+// that they get a new build.
+func requestScheduleOnJobsAfter(tx Tx, pipelineID int, jobName string, status BuildStatus) error {
+	rows, err := psql.Select("j.id").
+		From("jobs j").
+		Where(sq.Eq{
+			"j.pipeline_id": pipelineID,
+			"j.active":      true,
+		}).
+		Where(sq.Expr(`EXISTS (
+			SELECT 1 FROM jsonb_array_elements(CASE WHEN jsonb_typeof(j.after_jobs) = 'array' THEN j.after_jobs ELSE '[]' END) a
+			WHERE a->>'job' = ?
+			AND (? OR NOT COALESCE((a->>'succeeded_only')::boolean, false))
+		)`, jobName, status == BuildStatusSucceeded)).
+		OrderBy("j.id DESC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	var jobIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		jobIDs = append(jobIDs, id)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	for _, jID := range jobIDs {
+		_, err := psql.Update("jobs").
+			Set("after_finished", sq.Expr("now()")).
+			Set("schedule_requested", sq.Expr("now()")).
+			Where(sq.Eq{
+				"id": jID,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // The SELECT query orders the jobs for updating to prevent deadlocking.
 // Updating multiple rows using a SELECT subquery does not preserve the same
 // order for the updates, which can lead to deadlocking.
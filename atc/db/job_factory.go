@@ -21,6 +21,12 @@ type JobFactory interface {
 	VisibleJobs([]string) ([]atc.JobSummary, error)
 	AllActiveJobs() ([]atc.JobSummary, error)
 	JobsToSchedule() (SchedulerJobs, error)
+	ScheduledJobs() (Jobs, error)
+
+	// RunningBuildsByTeam returns the number of builds each team has running
+	// across the cluster, by team name. Builds which have been scheduled but
+	// not started yet are counted, and check builds are not.
+	RunningBuildsByTeam() (map[string]int, error)
 }
 
 type jobFactory struct {
@@ -41,6 +47,10 @@ type SchedulerJob struct {
 	Job
 	Resources     SchedulerResources
 	ResourceTypes atc.VersionedResourceTypes
+
+	// PipelineDefaults are the step defaults configured for the job's
+	// pipeline, which the job's own defaults are merged with.
+	PipelineDefaults *atc.StepDefaults
 }
 
 type SchedulerResources []SchedulerResource
@@ -89,6 +99,9 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 			"j.paused": false,
 			"p.paused": false,
 		}).
+		// higher priority jobs are scheduled first, so that they're the first
+		// to start their pending builds when the scheduler is busy
+		OrderBy("j.priority DESC", "j.id ASC").
 		RunWith(tx).
 		Query()
 	if err != nil {
@@ -102,6 +115,7 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 
 	var schedulerJobs SchedulerJobs
 	pipelineResourceTypes := make(map[int]ResourceTypes)
+	pipelineDefaults := make(map[int]*atc.StepDefaults)
 	for _, job := range jobs {
 		rows, err := tx.Query(`WITH inputs AS (
 				SELECT ji.resource_id from job_inputs ji where ji.job_id = $1
@@ -182,10 +196,34 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 			pipelineResourceTypes[job.PipelineID()] = resourceTypes
 		}
 
+		defaults, found := pipelineDefaults[job.PipelineID()]
+		if !found {
+			var defaultsBlob sql.NullString
+			err = psql.Select("defaults").
+				From("pipelines").
+				Where(sq.Eq{"id": job.PipelineID()}).
+				RunWith(tx).
+				QueryRow().
+				Scan(&defaultsBlob)
+			if err != nil {
+				return nil, err
+			}
+
+			if defaultsBlob.Valid {
+				err = json.Unmarshal([]byte(defaultsBlob.String), &defaults)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			pipelineDefaults[job.PipelineID()] = defaults
+		}
+
 		schedulerJobs = append(schedulerJobs, SchedulerJob{
-			Job:           job,
-			Resources:     schedulerResources,
-			ResourceTypes: resourceTypes.Deserialize(),
+			Job:              job,
+			Resources:        schedulerResources,
+			ResourceTypes:    resourceTypes.Deserialize(),
+			PipelineDefaults: defaults,
 		})
 	}
 
@@ -197,6 +235,64 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 	return schedulerJobs, nil
 }
 
+// ScheduledJobs returns the active jobs of unpaused pipelines which have a
+// schedule configured.
+func (j *jobFactory) ScheduledJobs() (Jobs, error) {
+	rows, err := jobsQuery.
+		Where(sq.NotEq{"j.schedule": nil}).
+		Where(sq.Expr("j.schedule != 'null'::jsonb")).
+		Where(sq.Eq{
+			"j.active": true,
+			"j.paused": false,
+			"p.paused": false,
+		}).
+		OrderBy("j.id ASC").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanJobs(j.conn, j.lockFactory, rows)
+}
+
+func (j *jobFactory) RunningBuildsByTeam() (map[string]int, error) {
+	rows, err := psql.Select("t.name", "COUNT(*)").
+		From("builds b").
+		Join("teams t ON t.id = b.team_id").
+		Where(sq.Eq{
+			"b.completed":        false,
+			"b.resource_id":      nil,
+			"b.resource_type_id": nil,
+		}).
+		Where(sq.Or{
+			sq.Eq{"b.scheduled": true},
+			sq.Eq{"b.status": BuildStatusStarted},
+		}).
+		GroupBy("t.name").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	running := map[string]int{}
+	for rows.Next() {
+		var teamName string
+		var count int
+		err := rows.Scan(&teamName, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		running[teamName] = count
+	}
+
+	return running, nil
+}
+
 func (j *jobFactory) VisibleJobs(teamNames []string) ([]atc.JobSummary, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
@@ -346,6 +348,104 @@ var _ = Describe("JobFactory", func() {
 		})
 	})
 
+	Describe("ScheduledJobs", func() {
+		var pipeline db.Pipeline
+
+		BeforeEach(func() {
+			err := defaultPipeline.Destroy()
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "scheduled-job", Schedule: &atc.JobScheduleConfig{Cron: "0 * * * *", Location: "Europe/Berlin"}},
+					{Name: "unscheduled-job"},
+				},
+			}, db.ConfigVersion(1), false)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("fetches the jobs with a schedule", func() {
+			jobs, err := jobFactory.ScheduledJobs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(jobs)).To(Equal(1))
+			Expect(jobs[0].Name()).To(Equal("scheduled-job"))
+			Expect(jobs[0].Schedule()).To(Equal(&atc.JobScheduleConfig{Cron: "0 * * * *", Location: "Europe/Berlin"}))
+			Expect(jobs[0].ScheduleAnchor()).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		Context("when the job is paused", func() {
+			BeforeEach(func() {
+				job, found, err := pipeline.Job("scheduled-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = job.Pause("", "")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not fetch that job", func() {
+				jobs, err := jobFactory.ScheduledJobs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobs).To(BeEmpty())
+			})
+		})
+
+		Context("when the pipeline is paused", func() {
+			BeforeEach(func() {
+				err := pipeline.Pause("", "")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not fetch that job", func() {
+				jobs, err := jobFactory.ScheduledJobs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobs).To(BeEmpty())
+			})
+		})
+
+		Context("when the schedule anchor is updated", func() {
+			var anchor time.Time
+
+			BeforeEach(func() {
+				job, found, err := pipeline.Job("scheduled-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				anchor = time.Now().Add(-time.Hour)
+				err = job.UpdateScheduleAnchor(anchor)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps the anchor while the schedule is unchanged", func() {
+				_, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "scheduled-job", Schedule: &atc.JobScheduleConfig{Cron: "0 * * * *", Location: "Europe/Berlin"}},
+					},
+				}, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				jobs, err := jobFactory.ScheduledJobs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(jobs)).To(Equal(1))
+				Expect(jobs[0].ScheduleAnchor()).To(BeTemporally("~", anchor, time.Second))
+			})
+
+			It("resets the anchor when the schedule changes", func() {
+				_, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "scheduled-job", Schedule: &atc.JobScheduleConfig{Cron: "30 * * * *"}},
+					},
+				}, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				jobs, err := jobFactory.ScheduledJobs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(jobs)).To(Equal(1))
+				Expect(jobs[0].ScheduleAnchor()).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+		})
+	})
+
 	Describe("JobsToSchedule", func() {
 		var (
 			job1 db.Job
@@ -384,6 +484,72 @@ var _ = Describe("JobFactory", func() {
 			})
 		})
 
+		Context("when the job's pipeline has step defaults", func() {
+			BeforeEach(func() {
+				pipeline1, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "job-name"},
+					},
+					Defaults: &atc.StepDefaults{
+						Timeout: "1h",
+						Tags:    atc.Tags{"some-tag"},
+					},
+				}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				job1, found, err = pipeline1.Job("job-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = job1.RequestSchedule()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("fetches the job with the pipeline's defaults", func() {
+				jobs, err := jobFactory.JobsToSchedule()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(jobs)).To(Equal(1))
+				Expect(jobs[0].PipelineDefaults).To(Equal(&atc.StepDefaults{
+					Timeout: "1h",
+					Tags:    atc.Tags{"some-tag"},
+				}))
+			})
+		})
+
+		Context("when the jobs have priorities", func() {
+			BeforeEach(func() {
+				pipeline1, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "low-job", Priority: atc.JobPriorityLow},
+						{Name: "normal-job"},
+						{Name: "high-job", Priority: atc.JobPriorityHigh},
+					},
+				}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, name := range []string{"low-job", "normal-job", "high-job"} {
+					job, found, err := pipeline1.Job(name)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = job.RequestSchedule()
+					Expect(err).ToNot(HaveOccurred())
+				}
+			})
+
+			It("fetches the higher priority jobs first", func() {
+				jobs, err := jobFactory.JobsToSchedule()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(jobs)).To(Equal(3))
+				Expect(jobs[0].Name()).To(Equal("high-job"))
+				Expect(jobs[0].Priority()).To(Equal(1))
+				Expect(jobs[1].Name()).To(Equal("normal-job"))
+				Expect(jobs[2].Name()).To(Equal("low-job"))
+				Expect(jobs[2].Priority()).To(Equal(-1))
+			})
+		})
+
 		Context("when the job has a requested schedule time earlier than the last scheduled", func() {
 			BeforeEach(func() {
 				pipeline1, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
@@ -516,7 +682,7 @@ var _ = Describe("JobFactory", func() {
 				err = job1.RequestSchedule()
 				Expect(err).ToNot(HaveOccurred())
 
-				err = job1.Pause()
+				err = job1.Pause("", "")
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -572,7 +738,7 @@ var _ = Describe("JobFactory", func() {
 				err = job1.RequestSchedule()
 				Expect(err).ToNot(HaveOccurred())
 
-				err = pipeline1.Pause()
+				err = pipeline1.Pause("", "")
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -1066,6 +1232,45 @@ var _ = Describe("JobFactory", func() {
 			})
 		})
 	})
+
+	Describe("RunningBuildsByTeam", func() {
+		BeforeEach(func() {
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			scheduledBuild, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			scheduled, err := defaultJob.ScheduleBuild(scheduledBuild)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scheduled).To(BeTrue())
+
+			_, err = defaultTeam.CreateStartedBuild(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+
+			finishedBuild, err := defaultTeam.CreateStartedBuild(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = finishedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			// pending builds which haven't been scheduled aren't running
+			_, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = otherTeam.CreateStartedBuild(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts the builds each team is running", func() {
+			running, err := jobFactory.RunningBuildsByTeam()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(running).To(Equal(map[string]int{
+				"default-team": 2,
+				"other-team":   1,
+			}))
+		})
+	})
 })
 
 var _ = Context("SchedulerResource", func() {
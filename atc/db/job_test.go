@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbtest"
 	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/vars"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"go.opentelemetry.io/otel/oteltest"
@@ -166,7 +167,7 @@ var _ = Describe("Job", func() {
 			BeforeEach(func() {
 				initialRequestedTime = job.ScheduleRequestedTime()
 
-				err := job.Pause()
+				err := job.Pause("", "")
 				Expect(err).ToNot(HaveOccurred())
 
 				found, err := job.Reload()
@@ -178,6 +179,35 @@ var _ = Describe("Job", func() {
 				Expect(job.Paused()).To(BeTrue())
 			})
 
+			Context("when the pause is attributed", func() {
+				BeforeEach(func() {
+					err := job.Pause("some-user", "prod freeze")
+					Expect(err).ToNot(HaveOccurred())
+
+					found, err := job.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+
+				It("records who paused the job and why", func() {
+					Expect(job.PausedBy()).To(Equal("some-user"))
+					Expect(job.PauseReason()).To(Equal("prod freeze"))
+					Expect(job.PausedAt()).To(BeTemporally("~", time.Now(), time.Minute))
+				})
+
+				It("clears them when the job is unpaused", func() {
+					Expect(job.Unpause()).To(Succeed())
+
+					found, err := job.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(job.PausedBy()).To(BeEmpty())
+					Expect(job.PauseReason()).To(BeEmpty())
+					Expect(job.PausedAt()).To(BeZero())
+				})
+			})
+
 			It("does not request schedule on job", func() {
 				Expect(job.ScheduleRequestedTime()).Should(BeTemporally("==", initialRequestedTime))
 			})
@@ -187,7 +217,10 @@ var _ = Describe("Job", func() {
 			BeforeEach(func() {
 				initialRequestedTime = job.ScheduleRequestedTime()
 
-				err := job.Unpause()
+				err := job.UpdateScheduleAnchor(time.Now().Add(-24 * time.Hour))
+				Expect(err).ToNot(HaveOccurred())
+
+				err = job.Unpause()
 				Expect(err).ToNot(HaveOccurred())
 
 				found, err := job.Reload()
@@ -202,6 +235,10 @@ var _ = Describe("Job", func() {
 			It("requests schedule on job", func() {
 				Expect(job.ScheduleRequestedTime()).Should(BeTemporally(">", initialRequestedTime))
 			})
+
+			It("counts the job's schedule from now", func() {
+				Expect(job.ScheduleAnchor()).To(BeTemporally("~", time.Now(), time.Minute))
+			})
 		})
 
 	})
@@ -302,6 +339,281 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("Statistics", func() {
+		var statsJob db.Job
+
+		BeforeEach(func() {
+			var found bool
+			var err error
+			statsJob, found, err = pipeline.Job("some-other-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		finishBuild := func(status db.BuildStatus, duration time.Duration, end time.Time) {
+			build, err := statsJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(status)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec("UPDATE builds SET start_time = to_timestamp($1), end_time = to_timestamp($2) WHERE id = $3", end.Add(-duration).Unix(), end.Unix(), build.ID())
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("is empty when the job has no completed builds", func() {
+			_, err := statsJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			stats, err := statsJob.Statistics(db.JobStatisticsWindow)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats).To(Equal(db.JobStatistics{}))
+		})
+
+		It("summarizes the most recent completed builds", func() {
+			end := time.Unix(1600000000, 0)
+
+			finishBuild(db.BuildStatusSucceeded, 100*time.Second, end)
+			finishBuild(db.BuildStatusSucceeded, 10*time.Second, end.Add(time.Minute))
+			finishBuild(db.BuildStatusAborted, time.Hour, end.Add(2*time.Minute))
+			finishBuild(db.BuildStatusFailed, 20*time.Second, end.Add(3*time.Minute))
+			finishBuild(db.BuildStatusSucceeded, 30*time.Second, end.Add(4*time.Minute))
+			finishBuild(db.BuildStatusErrored, 40*time.Second, end.Add(5*time.Minute))
+
+			By("ignoring builds outside of the window and aborted builds")
+			stats, err := statsJob.Statistics(4)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats.Builds).To(Equal(4))
+			Expect(stats.SuccessRate).To(Equal(0.5))
+			Expect(stats.DurationP50).To(Equal(20 * time.Second))
+			Expect(stats.DurationP95).To(Equal(40 * time.Second))
+
+			By("reporting the last success regardless of the window")
+			Expect(stats.LastSucceeded.Unix()).To(Equal(end.Add(4 * time.Minute).Unix()))
+
+			stats, err = statsJob.Statistics(db.JobStatisticsWindow)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats.Builds).To(Equal(5))
+			Expect(stats.SuccessRate).To(Equal(0.6))
+			Expect(stats.DurationP95).To(Equal(100 * time.Second))
+		})
+	})
+
+	Describe("VersionsSinceLastSuccess", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name:     "some-input",
+										Resource: "some-resource",
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "some-resource",
+							Type: "some-base-resource-type",
+						},
+					},
+				}),
+				builder.WithResourceVersions(
+					"some-resource",
+					atc.Version{"version": "v1"},
+					atc.Version{"version": "v2"},
+					atc.Version{"version": "v3"},
+					atc.Version{"version": "v4"},
+					atc.Version{"version": "v5"},
+				),
+			)
+		})
+
+		It("returns the newest version when the job has never succeeded", func() {
+			versions, err := scenario.Job("some-job").VersionsSinceLastSuccess("some-resource", 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal([]atc.Version{{"version": "v5"}}))
+		})
+
+		It("returns the enabled versions since the last successful build, oldest first", func() {
+			var succeededBuild, failedBuild db.Build
+			scenario.Run(
+				builder.WithJobBuild(&succeededBuild, "some-job", dbtest.JobInputs{
+					{
+						Name:    "some-input",
+						Version: atc.Version{"version": "v2"},
+					},
+				}, dbtest.JobOutputs{}),
+				builder.WithJobBuild(&failedBuild, "some-job", dbtest.JobInputs{
+					{
+						Name:    "some-input",
+						Version: atc.Version{"version": "v3"},
+					},
+				}, dbtest.JobOutputs{}),
+				builder.WithDisabledVersion("some-resource", atc.Version{"version": "v4"}),
+			)
+
+			err := succeededBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = failedBuild.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, err := scenario.Job("some-job").VersionsSinceLastSuccess("some-resource", 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal([]atc.Version{
+				{"version": "v3"},
+				{"version": "v5"},
+			}))
+		})
+
+		It("returns only the newest versions, up to the limit", func() {
+			var succeededBuild db.Build
+			scenario.Run(
+				builder.WithJobBuild(&succeededBuild, "some-job", dbtest.JobInputs{
+					{
+						Name:    "some-input",
+						Version: atc.Version{"version": "v1"},
+					},
+				}, dbtest.JobOutputs{}),
+			)
+
+			err := succeededBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, err := scenario.Job("some-job").VersionsSinceLastSuccess("some-resource", 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal([]atc.Version{
+				{"version": "v4"},
+				{"version": "v5"},
+			}))
+		})
+
+		It("counts the versions iterated over by a successful build as used", func() {
+			var succeededBuild db.Build
+			scenario.Run(
+				builder.WithJobBuild(&succeededBuild, "some-job", dbtest.JobInputs{
+					{
+						Name:    "some-input",
+						Version: atc.Version{"version": "v1"},
+					},
+				}, dbtest.JobOutputs{}),
+			)
+
+			err := succeededBuild.SaveIteratedInputs("some-resource", []atc.Version{
+				{"version": "v2"},
+				{"version": "v3"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = succeededBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, err := scenario.Job("some-job").VersionsSinceLastSuccess("some-resource", 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal([]atc.Version{
+				{"version": "v4"},
+				{"version": "v5"},
+			}))
+		})
+	})
+
+	Describe("VersionPassedJobs", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			getStep := func(name string) atc.Step {
+				return atc.Step{
+					Config: &atc.GetStep{
+						Name:     name,
+						Resource: "some-resource",
+					},
+				}
+			}
+
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:         "upstream-job",
+							PlanSequence: []atc.Step{getStep("some-input")},
+						},
+						{
+							Name:         "other-upstream-job",
+							PlanSequence: []atc.Step{getStep("some-input")},
+						},
+						{
+							Name:         "downstream-job",
+							PlanSequence: []atc.Step{getStep("some-input")},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "some-resource",
+							Type: "some-base-resource-type",
+						},
+					},
+				}),
+				builder.WithResourceVersions(
+					"some-resource",
+					atc.Version{"version": "v1"},
+					atc.Version{"version": "v2"},
+				),
+			)
+
+			var succeededBuild, failedBuild db.Build
+			scenario.Run(
+				builder.WithJobBuild(&succeededBuild, "upstream-job", dbtest.JobInputs{
+					{
+						Name:    "some-input",
+						Version: atc.Version{"version": "v1"},
+					},
+				}, dbtest.JobOutputs{}),
+				builder.WithJobBuild(&failedBuild, "upstream-job", dbtest.JobInputs{
+					{
+						Name:    "some-input",
+						Version: atc.Version{"version": "v2"},
+					},
+				}, dbtest.JobOutputs{}),
+			)
+
+			err := succeededBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = failedBuild.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		passed := func(version atc.Version, jobNames ...string) bool {
+			passed, err := scenario.Job("downstream-job").VersionPassedJobs(scenario.Resource("some-resource").ID(), version, jobNames)
+			Expect(err).ToNot(HaveOccurred())
+			return passed
+		}
+
+		It("is true for a version used by a successful build of the job", func() {
+			Expect(passed(atc.Version{"version": "v1"}, "upstream-job")).To(BeTrue())
+		})
+
+		It("is false for a version only used by a failed build of the job", func() {
+			Expect(passed(atc.Version{"version": "v2"}, "upstream-job")).To(BeFalse())
+		})
+
+		It("is false unless the version has passed every job", func() {
+			Expect(passed(atc.Version{"version": "v1"}, "upstream-job", "other-upstream-job")).To(BeFalse())
+		})
+
+		It("is true when there are no constraints", func() {
+			Expect(passed(atc.Version{"version": "v2"})).To(BeTrue())
+		})
+	})
+
 	Describe("UpdateFirstLoggedBuildID", func() {
 		It("updates FirstLoggedBuildID on a job", func() {
 			By("starting out as 0")
@@ -619,6 +931,15 @@ var _ = Describe("Job", func() {
 				Expect(build.Status()).To(Equal(rerunBuild.Status()))
 			})
 
+			It("carries the span context of the build being rerun", func() {
+				Expect(rerunErr).ToNot(HaveOccurred())
+
+				build, found, err := job.Build(rerunBuild.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.RerunOfSpanContext()).To(Equal(firstBuild.SpanContext()))
+			})
+
 			It("requests schedule on the job", func() {
 				requestedSchedule := job.ScheduleRequestedTime()
 
@@ -669,6 +990,46 @@ var _ = Describe("Job", func() {
 					Expect(rerunBuild.RerunNumber()).To(Equal(rerun1.RerunNumber() + 1))
 				})
 			})
+
+			It("has no vars", func() {
+				buildVars, err := rerunBuild.Vars()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buildVars).To(BeEmpty())
+			})
+
+			Context("when the build to rerun was rerun with vars", func() {
+				BeforeEach(func() {
+					var err error
+					buildToRerun, err = job.RerunBuildWithVars(buildToRerun, defaultBuildCreatedBy, vars.StaticVariables{
+						"target": "linux",
+						"arch":   "amd64",
+					})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("keeps the vars", func() {
+					buildVars, err := rerunBuild.Vars()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(buildVars).To(Equal(vars.StaticVariables{
+						"target": "linux",
+						"arch":   "amd64",
+					}))
+				})
+
+				It("lets new vars override them", func() {
+					rerunWithVars, err := job.RerunBuildWithVars(buildToRerun, defaultBuildCreatedBy, vars.StaticVariables{
+						"arch": "arm64",
+					})
+					Expect(err).ToNot(HaveOccurred())
+
+					buildVars, err := rerunWithVars.Vars()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(buildVars).To(Equal(vars.StaticVariables{
+						"target": "linux",
+						"arch":   "arm64",
+					}))
+				})
+			})
 		})
 	})
 
@@ -891,7 +1252,7 @@ var _ = Describe("Job", func() {
 				Context("when build exists", func() {
 					Context("when the pipeline is paused", func() {
 						BeforeEach(func() {
-							err := pipeline.Pause()
+							err := pipeline.Pause("", "")
 							Expect(err).ToNot(HaveOccurred())
 						})
 
@@ -905,7 +1266,7 @@ var _ = Describe("Job", func() {
 
 					Context("when the job is paused", func() {
 						BeforeEach(func() {
-							err := job.Pause()
+							err := job.Pause("", "")
 							Expect(err).ToNot(HaveOccurred())
 						})
 
@@ -1205,7 +1566,7 @@ var _ = Describe("Job", func() {
 					err = otherSerialJob.SaveNextInputMapping(nil, true)
 					Expect(err).NotTo(HaveOccurred())
 
-					err = job.Pause()
+					err = job.Pause("", "")
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -1924,6 +2285,81 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("CreateBuildWithIdempotencyKey", func() {
+		It("records the key on the build", func() {
+			build, err := job.CreateBuildWithIdempotencyKey(defaultBuildCreatedBy, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.IdempotencyKey()).To(Equal("some-key"))
+
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.IdempotencyKey()).To(Equal("some-key"))
+		})
+
+		It("can be looked up by the key within the pipeline", func() {
+			build, err := job.CreateBuildWithIdempotencyKey(defaultBuildCreatedBy, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+
+			foundBuild, found, err := pipeline.BuildByIdempotencyKey("some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundBuild.ID()).To(Equal(build.ID()))
+
+			_, found, err = pipeline.BuildByIdempotencyKey("some-other-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not allow the key to be used twice within the pipeline", func() {
+			_, err := job.CreateBuildWithIdempotencyKey(defaultBuildCreatedBy, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+
+			otherJob, found, err := pipeline.Job("some-other-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, err = otherJob.CreateBuildWithIdempotencyKey(defaultBuildCreatedBy, "some-key")
+			Expect(err).To(Equal(db.ErrIdempotencyKeyConflict))
+		})
+
+		It("does not record a key for builds created without one", func() {
+			build, err := job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.IdempotencyKey()).To(BeEmpty())
+		})
+
+		It("records that the build was triggered manually", func() {
+			build, err := job.CreateBuildWithIdempotencyKey(defaultBuildCreatedBy, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.Trigger()).To(Equal(atc.BuildTriggerManual))
+		})
+	})
+
+	Describe("CreateScheduledBuild", func() {
+		It("records that the build was triggered by the schedule", func() {
+			build, err := job.CreateScheduledBuild("some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.Trigger()).To(Equal(atc.BuildTriggerSchedule))
+			Expect(build.IsManuallyTriggered()).To(BeTrue())
+			Expect(build.IdempotencyKey()).To(Equal("some-key"))
+		})
+
+		It("does not record anyone as its creator", func() {
+			build, err := job.CreateScheduledBuild("some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.CreatedBy()).To(BeNil())
+		})
+
+		It("does not allow a run to be triggered twice", func() {
+			_, err := job.CreateScheduledBuild("some-key")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = job.CreateScheduledBuild("some-key")
+			Expect(err).To(Equal(db.ErrIdempotencyKeyConflict))
+		})
+	})
+
 	Describe("EnsurePendingBuildExists", func() {
 		Context("when only a started build exists", func() {
 			It("creates a build and updates the next build for the job", func() {
@@ -1939,6 +2375,16 @@ var _ = Describe("Job", func() {
 				Expect(pendingBuilds[0].ID()).To(Equal(nextBuild.ID()))
 			})
 
+			It("records that the build was triggered automatically", func() {
+				err := job.EnsurePendingBuildExists(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+				Expect(pendingBuilds[0].Trigger()).To(Equal(atc.BuildTriggerAutomatic))
+			})
+
 			Context("when tracing is configured", func() {
 				BeforeEach(func() {
 					tracing.ConfigureTraceProvider(oteltest.NewTracerProvider())
@@ -0,0 +1,12 @@
+CREATE TABLE service_accounts (
+    id serial PRIMARY KEY,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    role text NOT NULL,
+    token text REFERENCES access_tokens (token) ON DELETE SET NULL,
+    created_by text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    expires_at timestamp with time zone NOT NULL
+);
+
+CREATE UNIQUE INDEX service_accounts_team_id_name_key ON service_accounts (team_id, name);
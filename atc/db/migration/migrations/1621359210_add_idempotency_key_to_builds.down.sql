@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS builds_pipeline_id_idempotency_key_uniq;
+
+ALTER TABLE builds
+    DROP COLUMN idempotency_key;
@@ -0,0 +1,8 @@
+ALTER TABLE builds
+    ADD COLUMN idempotency_key text;
+
+-- a key can only be used once per pipeline; clients retrying a trigger or
+-- check request get the build created by their first attempt back
+CREATE UNIQUE INDEX builds_pipeline_id_idempotency_key_uniq
+    ON builds (pipeline_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
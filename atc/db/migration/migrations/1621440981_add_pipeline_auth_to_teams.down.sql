@@ -0,0 +1,2 @@
+ALTER TABLE teams
+    DROP COLUMN pipeline_auth;
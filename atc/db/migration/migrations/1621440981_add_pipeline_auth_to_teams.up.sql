@@ -0,0 +1,2 @@
+ALTER TABLE teams
+    ADD COLUMN pipeline_auth text;
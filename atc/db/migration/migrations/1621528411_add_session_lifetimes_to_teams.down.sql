@@ -0,0 +1,7 @@
+ALTER TABLE access_tokens
+    DROP COLUMN last_used_at,
+    DROP COLUMN longest_idle_seconds;
+
+ALTER TABLE teams
+    DROP COLUMN access_token_lifetime,
+    DROP COLUMN session_idle_timeout;
@@ -0,0 +1,7 @@
+ALTER TABLE teams
+    ADD COLUMN access_token_lifetime text,
+    ADD COLUMN session_idle_timeout text;
+
+ALTER TABLE access_tokens
+    ADD COLUMN last_used_at timestamp with time zone,
+    ADD COLUMN longest_idle_seconds bigint NOT NULL DEFAULT 0;
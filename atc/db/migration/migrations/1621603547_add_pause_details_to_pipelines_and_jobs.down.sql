@@ -0,0 +1,9 @@
+ALTER TABLE jobs
+    DROP COLUMN paused_by,
+    DROP COLUMN paused_at,
+    DROP COLUMN pause_reason;
+
+ALTER TABLE pipelines
+    DROP COLUMN paused_by,
+    DROP COLUMN paused_at,
+    DROP COLUMN pause_reason;
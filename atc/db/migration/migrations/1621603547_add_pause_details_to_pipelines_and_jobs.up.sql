@@ -0,0 +1,9 @@
+ALTER TABLE pipelines
+    ADD COLUMN paused_by text,
+    ADD COLUMN paused_at timestamp with time zone,
+    ADD COLUMN pause_reason text;
+
+ALTER TABLE jobs
+    ADD COLUMN paused_by text,
+    ADD COLUMN paused_at timestamp with time zone,
+    ADD COLUMN pause_reason text;
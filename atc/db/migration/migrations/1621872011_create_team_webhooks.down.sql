@@ -0,0 +1,2 @@
+DROP TABLE team_webhook_events;
+DROP TABLE team_webhooks;
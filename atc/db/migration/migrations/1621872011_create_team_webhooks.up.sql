@@ -0,0 +1,15 @@
+CREATE TABLE team_webhooks (
+    id serial PRIMARY KEY,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    config text NOT NULL,
+    nonce text
+);
+
+CREATE UNIQUE INDEX team_webhooks_team_id_name_key ON team_webhooks (team_id, name);
+
+CREATE TABLE team_webhook_events (
+    id bigserial PRIMARY KEY,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    payload jsonb NOT NULL
+);
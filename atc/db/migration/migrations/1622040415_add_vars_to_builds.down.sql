@@ -0,0 +1,3 @@
+ALTER TABLE builds
+    DROP COLUMN vars,
+    DROP COLUMN vars_nonce;
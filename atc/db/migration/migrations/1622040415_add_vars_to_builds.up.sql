@@ -0,0 +1,3 @@
+ALTER TABLE builds
+    ADD COLUMN vars text,
+    ADD COLUMN vars_nonce text;
@@ -0,0 +1,7 @@
+CREATE TABLE build_explicit_inputs (
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    input_name text NOT NULL,
+    resource_id integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    version_md5 text NOT NULL,
+    PRIMARY KEY (build_id, input_name)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE containers
+    DROP COLUMN created_at;
+
+ALTER TABLE volumes
+    DROP COLUMN created_at;
@@ -0,0 +1,5 @@
+ALTER TABLE containers
+    ADD COLUMN created_at timestamp with time zone NOT NULL DEFAULT now();
+
+ALTER TABLE volumes
+    ADD COLUMN created_at timestamp with time zone NOT NULL DEFAULT now();
@@ -0,0 +1 @@
+DROP TABLE intercept_sessions;
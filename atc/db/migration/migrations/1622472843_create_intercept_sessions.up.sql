@@ -0,0 +1,7 @@
+CREATE TABLE intercept_sessions (
+    container_id integer NOT NULL REFERENCES containers (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    process_id text NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (container_id, name)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE workers
+    ADD COLUMN arch text;
@@ -0,0 +1,9 @@
+CREATE TABLE pipeline_templates (
+    id serial PRIMARY KEY,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    config text NOT NULL,
+    nonce text
+);
+
+CREATE UNIQUE INDEX pipeline_templates_team_id_name_key ON pipeline_templates (team_id, name);
@@ -0,0 +1,3 @@
+DROP TABLE semaphore_holders;
+
+DROP TABLE semaphores;
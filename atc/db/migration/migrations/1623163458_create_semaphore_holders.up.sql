@@ -0,0 +1,16 @@
+CREATE TABLE semaphores (
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    "limit" integer NOT NULL,
+    PRIMARY KEY (team_id, name)
+);
+
+CREATE TABLE semaphore_holders (
+    team_id integer NOT NULL,
+    name text NOT NULL,
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    acquired_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, name, build_id, plan_id),
+    FOREIGN KEY (team_id, name) REFERENCES semaphores (team_id, name) ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+CREATE TABLE task_memos (
+    job_id integer NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    step_name text NOT NULL,
+    key text NOT NULL,
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    outputs jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (job_id, step_name)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE build_approvals (
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    name text NOT NULL,
+    approvers jsonb,
+    status text NOT NULL DEFAULT 'pending',
+    decided_by text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    decided_at timestamp with time zone,
+    PRIMARY KEY (build_id, plan_id)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE build_published_artifacts (
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    name text NOT NULL,
+    location text NOT NULL,
+    digest text NOT NULL,
+    published_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (build_id, plan_id)
+);
@@ -0,0 +1,5 @@
+DROP TABLE worker_resource_reservations;
+
+ALTER TABLE workers
+    DROP COLUMN cpu_capacity,
+    DROP COLUMN memory_capacity;
@@ -0,0 +1,12 @@
+ALTER TABLE workers
+    ADD COLUMN cpu_capacity bigint NOT NULL DEFAULT 0,
+    ADD COLUMN memory_capacity bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE worker_resource_reservations (
+    worker_name text NOT NULL REFERENCES workers (name) ON DELETE CASCADE,
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    cpu bigint NOT NULL,
+    memory bigint NOT NULL,
+    PRIMARY KEY (worker_name, build_id, plan_id)
+);
@@ -0,0 +1 @@
+ALTER TABLE pipelines ADD COLUMN defaults jsonb;
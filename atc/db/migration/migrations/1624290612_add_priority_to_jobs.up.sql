@@ -0,0 +1 @@
+ALTER TABLE jobs ADD COLUMN priority integer NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE jobs
+  DROP COLUMN schedule,
+  DROP COLUMN schedule_anchor;
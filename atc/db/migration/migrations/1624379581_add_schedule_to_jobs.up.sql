@@ -0,0 +1,3 @@
+ALTER TABLE jobs
+  ADD COLUMN schedule jsonb,
+  ADD COLUMN schedule_anchor timestamp with time zone NOT NULL DEFAULT now();
@@ -0,0 +1,4 @@
+ALTER TABLE jobs
+  DROP COLUMN after_jobs,
+  DROP COLUMN after_finished,
+  DROP COLUMN after_triggered;
@@ -0,0 +1,4 @@
+ALTER TABLE jobs
+  ADD COLUMN after_jobs jsonb,
+  ADD COLUMN after_finished timestamp with time zone DEFAULT '1970-01-01 00:00:00'::timestamp with time zone NOT NULL,
+  ADD COLUMN after_triggered timestamp with time zone DEFAULT '1970-01-01 00:00:00'::timestamp with time zone NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE containers
+    DROP COLUMN size_bytes;
+
+ALTER TABLE volumes
+    DROP COLUMN size_bytes;
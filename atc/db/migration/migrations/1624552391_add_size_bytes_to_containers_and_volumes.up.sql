@@ -0,0 +1,5 @@
+ALTER TABLE containers
+    ADD COLUMN size_bytes bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE volumes
+    ADD COLUMN size_bytes bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE builds
+  ADD COLUMN trigger_type text;
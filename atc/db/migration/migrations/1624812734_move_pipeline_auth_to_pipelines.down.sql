@@ -0,0 +1,12 @@
+ALTER TABLE teams
+    ADD COLUMN pipeline_auth text;
+
+UPDATE teams t
+SET pipeline_auth = (
+    SELECT json_object_agg(p.name, pa.auth::json)::text
+    FROM pipeline_auth pa
+    JOIN pipelines p ON p.id = pa.pipeline_id
+    WHERE p.team_id = t.id AND p.instance_vars IS NULL
+);
+
+DROP TABLE pipeline_auth;
@@ -0,0 +1,14 @@
+CREATE TABLE pipeline_auth (
+    pipeline_id integer PRIMARY KEY REFERENCES pipelines (id) ON DELETE CASCADE,
+    auth text NOT NULL
+);
+
+INSERT INTO pipeline_auth (pipeline_id, auth)
+SELECT p.id, a.value::text
+FROM teams t
+CROSS JOIN json_each(t.pipeline_auth::json) a
+JOIN pipelines p ON p.team_id = t.id AND p.name = a.key AND p.instance_vars IS NULL
+WHERE t.pipeline_auth IS NOT NULL;
+
+ALTER TABLE teams
+    DROP COLUMN pipeline_auth;
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -45,10 +46,14 @@ type Pipeline interface {
 	Groups() atc.GroupConfigs
 	VarSources() atc.VarSourceConfigs
 	Display() *atc.DisplayConfig
+	Defaults() *atc.StepDefaults
 	ConfigVersion() ConfigVersion
 	Config() (atc.Config, error)
 	Public() bool
 	Paused() bool
+	PausedBy() string
+	PausedAt() time.Time
+	PauseReason() string
 	Archived() bool
 	LastUpdated() time.Time
 
@@ -61,6 +66,7 @@ type Pipeline interface {
 	GetBuildsWithVersionAsInput(int, int) ([]Build, error)
 	GetBuildsWithVersionAsOutput(int, int) ([]Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
+	BuildByIdempotencyKey(key string) (Build, bool, error)
 
 	CreateOneOffBuild() (Build, error)
 	CreateStartedBuild(plan atc.Plan) (Build, error)
@@ -86,14 +92,18 @@ type Pipeline interface {
 	Expose() error
 	Hide() error
 
-	Pause() error
+	Pause(pausedBy string, reason string) error
 	Unpause() error
 
+	PauseJobs(jobNames []string, pausedBy string, reason string) ([]string, error)
+	UnpauseJobs(jobNames []string) ([]string, error)
+
 	Archive() error
 
 	Destroy() error
 
 	Variables(lager.Logger, creds.Secrets, creds.VarSourcePool) (vars.Variables, error)
+	VarSourceStatuses(lager.Logger, creds.Secrets, creds.VarSourcePool, []vars.Reference) ([]atc.VarSourceStatus, error)
 
 	SetParentIDs(jobID, buildID int) error
 }
@@ -109,8 +119,12 @@ type pipeline struct {
 	groups        atc.GroupConfigs
 	varSources    atc.VarSourceConfigs
 	display       *atc.DisplayConfig
+	defaults      *atc.StepDefaults
 	configVersion ConfigVersion
 	paused        bool
+	pausedBy      string
+	pausedAt      time.Time
+	pauseReason   string
 	public        bool
 	archived      bool
 	lastUpdated   time.Time
@@ -128,6 +142,7 @@ var pipelinesQuery = psql.Select(`
 		p.groups,
 		p.var_sources,
 		p.display,
+		p.defaults,
 		p.nonce,
 		p.version,
 		p.team_id,
@@ -138,7 +153,10 @@ var pipelinesQuery = psql.Select(`
 		p.last_updated,
 		p.parent_job_id,
 		p.parent_build_id,
-		p.instance_vars
+		p.instance_vars,
+		p.paused_by,
+		p.paused_at,
+		p.pause_reason
 	`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
@@ -161,9 +179,13 @@ func (p *pipeline) Groups() atc.GroupConfigs       { return p.groups }
 
 func (p *pipeline) VarSources() atc.VarSourceConfigs { return p.varSources }
 func (p *pipeline) Display() *atc.DisplayConfig      { return p.display }
+func (p *pipeline) Defaults() *atc.StepDefaults      { return p.defaults }
 func (p *pipeline) ConfigVersion() ConfigVersion     { return p.configVersion }
 func (p *pipeline) Public() bool                     { return p.public }
 func (p *pipeline) Paused() bool                     { return p.paused }
+func (p *pipeline) PausedBy() string                 { return p.pausedBy }
+func (p *pipeline) PausedAt() time.Time              { return p.pausedAt }
+func (p *pipeline) PauseReason() string              { return p.pauseReason }
 func (p *pipeline) Archived() bool                   { return p.archived }
 func (p *pipeline) LastUpdated() time.Time           { return p.lastUpdated }
 
@@ -275,11 +297,35 @@ func (p *pipeline) Config() (atc.Config, error) {
 		ResourceTypes: resourceTypes.Configs(),
 		Jobs:          jobConfigs,
 		Display:       p.Display(),
+		Defaults:      p.Defaults(),
 	}
 
 	return config, nil
 }
 
+// BuildByIdempotencyKey returns the build, if any, that was created within
+// the pipeline by a request carrying the given idempotency key.
+func (p *pipeline) BuildByIdempotencyKey(key string) (Build, bool, error) {
+	build := newEmptyBuild(p.conn, p.lockFactory)
+	err := scanBuild(build, buildsQuery.
+		Where(sq.Eq{
+			"b.pipeline_id":     p.id,
+			"b.idempotency_key": key,
+		}).
+		RunWith(p.conn).
+		QueryRow(),
+		p.conn.EncryptionStrategy(),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return build, true, nil
+}
+
 func (p *pipeline) CreateJobBuild(jobName string) (Build, error) {
 	tx, err := p.conn.Begin()
 	if err != nil {
@@ -604,9 +650,14 @@ func (p *pipeline) Dashboard() ([]atc.JobSummary, error) {
 	return dashboard, nil
 }
 
-func (p *pipeline) Pause() error {
+// Pause stops the pipeline's jobs from being scheduled and its resources
+// from being checked, recording who paused it and why.
+func (p *pipeline) Pause(pausedBy string, reason string) error {
 	_, err := psql.Update("pipelines").
 		Set("paused", true).
+		Set("paused_by", newNullString(pausedBy)).
+		Set("paused_at", sq.Expr("now()")).
+		Set("pause_reason", newNullString(reason)).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
@@ -626,6 +677,9 @@ func (p *pipeline) Unpause() error {
 
 	_, err = psql.Update("pipelines").
 		Set("paused", false).
+		Set("paused_by", nil).
+		Set("paused_at", nil).
+		Set("pause_reason", nil).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
@@ -636,6 +690,18 @@ func (p *pipeline) Unpause() error {
 		return err
 	}
 
+	// runs of the jobs' schedules missed while paused are skipped
+	_, err = psql.Update("jobs").
+		Set("schedule_anchor", sq.Expr("now()")).
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
 	err = requestScheduleForJobsInPipeline(tx, p.id)
 	if err != nil {
 		return err
@@ -644,6 +710,69 @@ func (p *pipeline) Unpause() error {
 	return tx.Commit()
 }
 
+// PauseJobs pauses the pipeline's jobs with the given names in one go,
+// returning the names of the jobs which were found.
+func (p *pipeline) PauseJobs(jobNames []string, pausedBy string, reason string) ([]string, error) {
+	return p.updatePausedJobs(psql.Update("jobs").
+		Set("paused", true).
+		Set("paused_by", newNullString(pausedBy)).
+		Set("paused_at", sq.Expr("now()")).
+		Set("pause_reason", newNullString(reason)),
+		jobNames,
+	)
+}
+
+// UnpauseJobs unpauses the pipeline's jobs with the given names in one go,
+// requesting them to be scheduled, and returns the names of the jobs which
+// were found.
+func (p *pipeline) UnpauseJobs(jobNames []string) ([]string, error) {
+	return p.updatePausedJobs(psql.Update("jobs").
+		Set("paused", false).
+		Set("paused_by", nil).
+		Set("paused_at", nil).
+		Set("pause_reason", nil).
+		Set("schedule_requested", sq.Expr("now()")).
+		Set("schedule_anchor", sq.Expr("now()")),
+		jobNames,
+	)
+}
+
+func (p *pipeline) updatePausedJobs(update sq.UpdateBuilder, jobNames []string) ([]string, error) {
+	if len(jobNames) == 0 {
+		return []string{}, nil
+	}
+
+	rows, err := update.
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+			"name":        jobNames,
+			"active":      true,
+		}).
+		Suffix("RETURNING name").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	updated := []string{}
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		updated = append(updated, name)
+	}
+
+	sort.Strings(updated)
+
+	return updated, nil
+}
+
 func (p *pipeline) Archive() error {
 	tx, err := p.conn.Begin()
 	if err != nil {
@@ -719,8 +848,13 @@ func (p *pipeline) Destroy() error {
 		}).
 		RunWith(p.conn).
 		Exec()
+	if err != nil {
+		return err
+	}
 
-	return err
+	// the roles granted on the pipeline went with it, so they must not linger
+	// in the cached teams either
+	return p.conn.Bus().Notify(atc.TeamCacheChannel)
 }
 
 func (p *pipeline) LoadDebugVersionsDB() (*atc.DebugVersionsDB, error) {
@@ -1077,21 +1211,11 @@ func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, v
 	}
 
 	for _, cm := range orderedVarSources {
-		factory := creds.ManagerFactories()[cm.Type]
-		if factory == nil {
-			return nil, fmt.Errorf("unknown credential manager type: %s", cm.Type)
-		}
-
-		// Interpolate variables in pipeline credential manager's config
-		newConfig, err := creds.NewParams(allVars, atc.Params{"config": cm.Config}).Evaluate()
+		factory, config, err := evaluateVarSource(cm, allVars)
 		if err != nil {
-			return nil, errors.Wrapf(err, "evaluate var_source '%s' error", cm.Name)
+			return nil, err
 		}
 
-		config, ok := newConfig["config"].(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("var_source '%s' invalid config", cm.Name)
-		}
 		secrets, err := varSourcePool.FindOrCreate(logger, config, factory)
 		if err != nil {
 			return nil, errors.Wrapf(err, "create var_source '%s' error", cm.Name)
@@ -1108,6 +1232,107 @@ func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, v
 	return allVars, nil
 }
 
+// VarSourceStatuses reports the status of each of the pipeline's
+// var_sources, in the order they are configured. A var_source which cannot
+// be created, e.g. because its config refers to a var which cannot be
+// found, is reported as unreachable with the reason as its error.
+//
+// Each of the given vars is looked up in the var_source it refers to, and
+// reported with its status. Vars of var_sources which cannot be created are
+// reported with the same error.
+func (p *pipeline) VarSourceStatuses(logger lager.Logger, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool, lookups []vars.Reference) ([]atc.VarSourceStatus, error) {
+	globalVars := creds.NewVariables(globalSecrets, p.TeamName(), p.Name(), false)
+	namedVarsMap := vars.NamedVariables{}
+	allVars := vars.NewMultiVars([]vars.Variables{namedVarsMap, globalVars})
+
+	orderedVarSources, err := p.varSources.OrderByDependency()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := map[string]atc.VarSourceStatus{}
+	for _, cm := range orderedVarSources {
+		status, secrets, err := varSourceStatus(logger, cm, allVars, varSourcePool)
+		if err != nil {
+			status.Error = err.Error()
+		} else {
+			namedVarsMap[cm.Name] = creds.NewVariables(secrets, p.TeamName(), p.Name(), true)
+		}
+
+		for _, ref := range lookups {
+			if ref.Source != cm.Name {
+				continue
+			}
+
+			lookup := atc.VarLookupStatus{Name: ref.String()}
+			if err != nil {
+				lookup.Error = err.Error()
+			} else {
+				_, found, getErr := namedVarsMap.Get(ref)
+				if getErr != nil {
+					lookup.Error = getErr.Error()
+				}
+
+				lookup.Found = found
+			}
+
+			status.Vars = append(status.Vars, lookup)
+		}
+
+		status.Name = cm.Name
+		status.Type = cm.Type
+		statuses[cm.Name] = status
+	}
+
+	result := []atc.VarSourceStatus{}
+	for _, cm := range p.varSources {
+		result = append(result, statuses[cm.Name])
+	}
+
+	return result, nil
+}
+
+func varSourceStatus(logger lager.Logger, cm atc.VarSourceConfig, allVars vars.Variables, varSourcePool creds.VarSourcePool) (atc.VarSourceStatus, creds.Secrets, error) {
+	factory, config, err := evaluateVarSource(cm, allVars)
+	if err != nil {
+		return atc.VarSourceStatus{}, nil, err
+	}
+
+	secrets, err := varSourcePool.FindOrCreate(logger, config, factory)
+	if err != nil {
+		return atc.VarSourceStatus{}, nil, errors.Wrapf(err, "create var_source '%s' error", cm.Name)
+	}
+
+	status, err := varSourcePool.Status(logger, config, factory)
+	if err != nil {
+		return atc.VarSourceStatus{}, nil, errors.Wrapf(err, "create var_source '%s' error", cm.Name)
+	}
+
+	return status, secrets, nil
+}
+
+// evaluateVarSource interpolates the var_source's config and finds the
+// factory of its credential manager.
+func evaluateVarSource(cm atc.VarSourceConfig, allVars vars.Variables) (creds.ManagerFactory, map[string]interface{}, error) {
+	factory := creds.ManagerFactories()[cm.Type]
+	if factory == nil {
+		return nil, nil, fmt.Errorf("unknown credential manager type: %s", cm.Type)
+	}
+
+	// Interpolate variables in pipeline credential manager's config
+	newConfig, err := creds.NewParams(allVars, atc.Params{"config": cm.Config}).Evaluate()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "evaluate var_source '%s' error", cm.Name)
+	}
+
+	config, ok := newConfig["config"].(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("var_source '%s' invalid config", cm.Name)
+	}
+
+	return factory, config, nil
+}
+
 func (p *pipeline) SetParentIDs(jobID, buildID int) error {
 	if jobID <= 0 || buildID <= 0 {
 		return errors.New("job and build id cannot be negative or zero-value")
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+func (t *team) PipelineTemplates() ([]atc.PipelineTemplate, error) {
+	rows, err := psql.Select("name", "config", "nonce").
+		From("pipeline_templates").
+		Where(sq.Eq{"team_id": t.id}).
+		OrderBy("name").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var templates []atc.PipelineTemplate
+	for rows.Next() {
+		template, err := scanPipelineTemplate(t.conn, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+func (t *team) PipelineTemplate(name string) (atc.PipelineTemplate, bool, error) {
+	row := psql.Select("name", "config", "nonce").
+		From("pipeline_templates").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		}).
+		RunWith(t.conn).
+		QueryRow()
+
+	template, err := scanPipelineTemplate(t.conn, row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.PipelineTemplate{}, false, nil
+		}
+
+		return atc.PipelineTemplate{}, false, err
+	}
+
+	return template, true, nil
+}
+
+// SetPipelineTemplate creates the template, or replaces the one with the same
+// name. Pipelines which include it are not affected until they're next set.
+// The config is encrypted like pipeline configs are.
+func (t *team) SetPipelineTemplate(template atc.PipelineTemplate) (bool, error) {
+	encryptedConfig, nonce, err := t.conn.EncryptionStrategy().Encrypt([]byte(template.Config))
+	if err != nil {
+		return false, err
+	}
+
+	var created bool
+	err = psql.Insert("pipeline_templates").
+		Columns("team_id", "name", "config", "nonce").
+		Values(t.id, template.Name, encryptedConfig, nonce).
+		Suffix(`
+			ON CONFLICT (team_id, name) DO UPDATE SET
+				config = EXCLUDED.config,
+				nonce = EXCLUDED.nonce
+			RETURNING xmax = 0
+		`).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&created)
+	if err != nil {
+		return false, err
+	}
+
+	return created, nil
+}
+
+func (t *team) DeletePipelineTemplate(name string) (bool, error) {
+	result, err := psql.Delete("pipeline_templates").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func scanPipelineTemplate(conn Conn, row scannable) (atc.PipelineTemplate, error) {
+	var name, config string
+	var nonce sql.NullString
+
+	err := row.Scan(&name, &config, &nonce)
+	if err != nil {
+		return atc.PipelineTemplate{}, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := conn.EncryptionStrategy().Decrypt(config, noncense)
+	if err != nil {
+		return atc.PipelineTemplate{}, err
+	}
+
+	return atc.PipelineTemplate{
+		Name:   name,
+		Config: string(decrypted),
+	}, nil
+}
@@ -201,7 +201,7 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when the pipeline is paused", func() {
 			BeforeEach(func() {
-				Expect(pipeline.Pause()).To(Succeed())
+				Expect(pipeline.Pause("", "")).To(Succeed())
 			})
 
 			It("returns the pipeline is paused", func() {
@@ -212,7 +212,7 @@ var _ = Describe("Pipeline", func() {
 
 	Describe("Pause", func() {
 		JustBeforeEach(func() {
-			Expect(pipeline.Pause()).To(Succeed())
+			Expect(pipeline.Pause("some-user", "waiting on the database migration")).To(Succeed())
 
 			found, err := pipeline.Reload()
 			Expect(err).ToNot(HaveOccurred())
@@ -227,6 +227,12 @@ var _ = Describe("Pipeline", func() {
 			It("pauses the pipeline", func() {
 				Expect(pipeline.Paused()).To(BeTrue())
 			})
+
+			It("records who paused the pipeline and why", func() {
+				Expect(pipeline.PausedBy()).To(Equal("some-user"))
+				Expect(pipeline.PauseReason()).To(Equal("waiting on the database migration"))
+				Expect(pipeline.PausedAt()).To(BeTemporally("~", time.Now(), time.Minute))
+			})
 		})
 	})
 
@@ -303,12 +309,40 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when the pipeline is paused", func() {
 			BeforeEach(func() {
-				Expect(pipeline.Pause()).To(Succeed())
+				Expect(pipeline.Pause("", "")).To(Succeed())
 			})
 
 			It("unpauses the pipeline", func() {
 				Expect(pipeline.Paused()).To(BeFalse())
 			})
+
+			It("clears who paused the pipeline and why", func() {
+				Expect(pipeline.PausedBy()).To(BeEmpty())
+				Expect(pipeline.PauseReason()).To(BeEmpty())
+				Expect(pipeline.PausedAt()).To(BeZero())
+			})
+
+			Context("when a job's schedule was last counted before the pipeline was paused", func() {
+				var job db.Job
+
+				BeforeEach(func() {
+					var found bool
+					var err error
+					job, found, err = pipeline.Job("job-name")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = job.UpdateScheduleAnchor(time.Now().Add(-24 * time.Hour))
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("counts the job's schedule from now", func() {
+					found, err := job.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(job.ScheduleAnchor()).To(BeTemporally("~", time.Now(), time.Minute))
+				})
+			})
 		})
 
 		Context("when requesting schedule for unpausing pipeline", func() {
@@ -414,6 +448,58 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("PauseJobs", func() {
+		It("pauses the named jobs and returns the ones it found", func() {
+			paused, err := pipeline.PauseJobs([]string{"job-name", "some-other-job", "bogus-job"}, "some-user", "flaky")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(paused).To(Equal([]string{"job-name", "some-other-job"}))
+
+			job, found, err := pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.Paused()).To(BeTrue())
+			Expect(job.PausedBy()).To(Equal("some-user"))
+			Expect(job.PauseReason()).To(Equal("flaky"))
+
+			job, found, err = pipeline.Job("a-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.Paused()).To(BeFalse())
+		})
+	})
+
+	Describe("UnpauseJobs", func() {
+		It("unpauses the named jobs and requests their schedule", func() {
+			_, err := pipeline.PauseJobs([]string{"job-name", "a-job"}, "some-user", "flaky")
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found, err := pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			requestedTime := job.ScheduleRequestedTime()
+
+			err = job.UpdateScheduleAnchor(time.Now().Add(-24 * time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			unpaused, err := pipeline.UnpauseJobs([]string{"job-name"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unpaused).To(Equal([]string{"job-name"}))
+
+			found, err = job.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.Paused()).To(BeFalse())
+			Expect(job.PausedBy()).To(BeEmpty())
+			Expect(job.ScheduleRequestedTime()).Should(BeTemporally(">", requestedTime))
+			Expect(job.ScheduleAnchor()).To(BeTemporally("~", time.Now(), time.Minute))
+
+			job, found, err = pipeline.Job("a-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.Paused()).To(BeTrue())
+		})
+	})
+
 	Describe("Resource Config Versions", func() {
 		resourceName := "some-resource"
 		otherResourceName := "some-other-resource"
@@ -2109,6 +2195,41 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("VarSourceStatuses", func() {
+		var (
+			pool     creds.VarSourcePool
+			statuses []atc.VarSourceStatus
+		)
+
+		BeforeEach(func() {
+			pool = creds.NewVarSourcePool(logger, creds.CredentialManagementConfig{}, 1*time.Minute, 1*time.Second, clock.NewClock())
+		})
+
+		AfterEach(func() {
+			pool.Close()
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			statuses, err = pipeline.VarSourceStatuses(logger, new(credsfakes.FakeSecrets), pool, []vars.Reference{
+				{Source: "some-var-source", Path: "pk"},
+				{Source: "some-var-source", Path: "missing"},
+				{Path: "gk"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("looks up the vars in the var_source they refer to", func() {
+			Expect(statuses).To(HaveLen(1))
+			Expect(statuses[0].Name).To(Equal("some-var-source"))
+			Expect(statuses[0].Reachable).To(BeTrue())
+			Expect(statuses[0].Vars).To(Equal([]atc.VarLookupStatus{
+				{Name: "some-var-source:pk", Found: true},
+				{Name: "some-var-source:missing", Found: false},
+			}))
+		})
+	})
+
 	Describe("SetParentIDs", func() {
 		It("sets the parent_job_id and parent_build_id fields", func() {
 			jobID := 123
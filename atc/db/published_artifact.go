@@ -0,0 +1,86 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// PublishedArtifact records where a publish step uploaded a build's artifact
+// to.
+type PublishedArtifact struct {
+	BuildID  int
+	PlanID   atc.PlanID
+	Name     string
+	Location string
+	Digest   string
+
+	PublishedAt time.Time
+}
+
+//counterfeiter:generate . PublishedArtifactFactory
+type PublishedArtifactFactory interface {
+	Save(PublishedArtifact) error
+	ForBuild(buildID int) ([]PublishedArtifact, error)
+}
+
+type publishedArtifactFactory struct {
+	conn Conn
+}
+
+func NewPublishedArtifactFactory(conn Conn) PublishedArtifactFactory {
+	return &publishedArtifactFactory{
+		conn: conn,
+	}
+}
+
+// Save records the published artifact. A step which publishes again, e.g.
+// after the web node restarts, replaces what it published before.
+func (f *publishedArtifactFactory) Save(artifact PublishedArtifact) error {
+	_, err := psql.Insert("build_published_artifacts").
+		Columns("build_id", "plan_id", "name", "location", "digest").
+		Values(artifact.BuildID, string(artifact.PlanID), artifact.Name, artifact.Location, artifact.Digest).
+		Suffix(`ON CONFLICT (build_id, plan_id) DO UPDATE SET
+			location = EXCLUDED.location,
+			digest = EXCLUDED.digest,
+			published_at = now()`).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+// ForBuild returns the artifacts published by the build, in the order they
+// were published.
+func (f *publishedArtifactFactory) ForBuild(buildID int) ([]PublishedArtifact, error) {
+	rows, err := psql.Select("build_id", "plan_id", "name", "location", "digest", "published_at").
+		From("build_published_artifacts").
+		Where(sq.Eq{"build_id": buildID}).
+		OrderBy("published_at", "plan_id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	artifacts := []PublishedArtifact{}
+	for rows.Next() {
+		var (
+			artifact PublishedArtifact
+			planID   string
+		)
+
+		err := rows.Scan(&artifact.BuildID, &planID, &artifact.Name, &artifact.Location, &artifact.Digest, &artifact.PublishedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		artifact.PlanID = atc.PlanID(planID)
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, rows.Err()
+}
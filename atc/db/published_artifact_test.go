@@ -0,0 +1,83 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PublishedArtifactFactory", func() {
+	var (
+		publishedArtifactFactory db.PublishedArtifactFactory
+		build                    db.Build
+	)
+
+	BeforeEach(func() {
+		publishedArtifactFactory = db.NewPublishedArtifactFactory(dbConn)
+
+		var err error
+		build, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("has none for a build which has not published anything", func() {
+		artifacts, err := publishedArtifactFactory.ForBuild(build.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(artifacts).To(BeEmpty())
+	})
+
+	Context("when artifacts have been published", func() {
+		BeforeEach(func() {
+			err := publishedArtifactFactory.Save(db.PublishedArtifact{
+				BuildID:  build.ID(),
+				PlanID:   "some-plan",
+				Name:     "some-artifact",
+				Location: "s3://some-bucket/some-digest.tgz",
+				Digest:   "sha256:some-digest",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = publishedArtifactFactory.Save(db.PublishedArtifact{
+				BuildID:  build.ID(),
+				PlanID:   "some-other-plan",
+				Name:     "some-other-artifact",
+				Location: "s3://some-bucket/some-other-digest.tgz",
+				Digest:   "sha256:some-other-digest",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns them in the order they were published", func() {
+			artifacts, err := publishedArtifactFactory.ForBuild(build.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(artifacts).To(HaveLen(2))
+
+			Expect(artifacts[0].BuildID).To(Equal(build.ID()))
+			Expect(artifacts[0].PlanID).To(BeEquivalentTo("some-plan"))
+			Expect(artifacts[0].Name).To(Equal("some-artifact"))
+			Expect(artifacts[0].Location).To(Equal("s3://some-bucket/some-digest.tgz"))
+			Expect(artifacts[0].Digest).To(Equal("sha256:some-digest"))
+			Expect(artifacts[0].PublishedAt).ToNot(BeZero())
+
+			Expect(artifacts[1].Name).To(Equal("some-other-artifact"))
+		})
+
+		It("replaces an artifact when its step publishes again", func() {
+			err := publishedArtifactFactory.Save(db.PublishedArtifact{
+				BuildID:  build.ID(),
+				PlanID:   "some-plan",
+				Name:     "some-artifact",
+				Location: "s3://some-bucket/some-new-digest.tgz",
+				Digest:   "sha256:some-new-digest",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			artifacts, err := publishedArtifactFactory.ForBuild(build.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(artifacts).To(HaveLen(2))
+			Expect(artifacts[1].Name).To(Equal("some-artifact"))
+			Expect(artifacts[1].Location).To(Equal("s3://some-bucket/some-new-digest.tgz"))
+		})
+	})
+})
@@ -54,6 +54,7 @@ type Resource interface {
 
 	Versions(page Page, versionFilter atc.Version) ([]atc.ResourceVersion, Pagination, bool, error)
 	FindVersion(filter atc.Version) (ResourceConfigVersion, bool, error) // Only used in tests!!
+	NewestVersionSince(atc.Version) (ResourceConfigVersion, bool, error)
 	UpdateMetadata(atc.Version, ResourceConfigMetadataFields) (bool, error)
 
 	EnableVersion(rcvID int) error
@@ -307,6 +308,7 @@ func (r *resource) CreateBuild(ctx context.Context, manuallyTriggered bool, plan
 		Plan:              plan,
 		ManuallyTriggered: manuallyTriggered,
 		SpanContext:       NewSpanContext(ctx),
+		IdempotencyKey:    idempotencyKeyFromContext(ctx),
 		ExtraValues: map[string]interface{}{
 			"resource_id": r.id,
 		},
@@ -407,6 +409,55 @@ func (r *resource) FindVersion(v atc.Version) (ResourceConfigVersion, bool, erro
 	return ver, true, nil
 }
 
+// NewestVersionSince returns the newest enabled version of the resource that
+// was checked no earlier than the given version. Nothing is found if the given
+// version does not exist.
+func (r *resource) NewestVersionSince(since atc.Version) (ResourceConfigVersion, bool, error) {
+	if r.resourceConfigScopeID == 0 {
+		return nil, false, nil
+	}
+
+	ver := &resourceConfigVersion{
+		conn: r.conn,
+	}
+
+	sinceByte, err := json.Marshal(since)
+	if err != nil {
+		return nil, false, err
+	}
+
+	row := resourceConfigVersionQuery.
+		Where(sq.Eq{
+			"v.resource_config_scope_id": r.resourceConfigScopeID,
+		}).
+		Where(sq.Expr(`v.check_order >= (
+			SELECT s.check_order
+			FROM resource_config_versions s
+			WHERE s.resource_config_scope_id = ?
+			AND s.version_md5 = md5(?)
+		)`, r.resourceConfigScopeID, sinceByte)).
+		Where(sq.Expr(`NOT EXISTS (
+			SELECT 1
+			FROM resource_disabled_versions d
+			WHERE d.resource_id = ?
+			AND d.version_md5 = v.version_md5
+		)`, r.id)).
+		OrderBy("v.check_order DESC").
+		Limit(1).
+		RunWith(r.conn).
+		QueryRow()
+
+	err = scanResourceConfigVersion(ver, row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return ver, true, nil
+}
+
 func (r *resource) SetPinComment(comment string) error {
 	_, err := psql.Update("resource_pins").
 		Set("comment_text", comment).
@@ -28,6 +28,11 @@ type ResourceCacheFactory interface {
 	ResourceCacheMetadata(UsedResourceCache) (ResourceConfigMetadataFields, error)
 
 	FindResourceCacheByID(id int) (UsedResourceCache, bool, error)
+
+	// InvalidateResourceCache removes the resource cache from every worker,
+	// so that the next get of it re-fetches it rather than reusing a volume.
+	// The volumes that held it are garbage collected once nothing uses them.
+	InvalidateResourceCache(UsedResourceCache) error
 }
 
 type resourceCacheFactory struct {
@@ -122,6 +127,14 @@ func (f *resourceCacheFactory) ResourceCacheMetadata(resourceCache UsedResourceC
 	return metadata, nil
 }
 
+func (f *resourceCacheFactory) InvalidateResourceCache(resourceCache UsedResourceCache) error {
+	_, err := psql.Delete("worker_resource_caches").
+		Where(sq.Eq{"resource_cache_id": resourceCache.ID()}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
 func (f *resourceCacheFactory) FindResourceCacheByID(id int) (UsedResourceCache, bool, error) {
 	tx, err := f.conn.Begin()
 	if err != nil {
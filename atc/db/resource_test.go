@@ -594,6 +594,66 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Context("NewestVersionSince", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-base-resource-type",
+							Source: atc.Source{"some": "repository"},
+						},
+					},
+				}),
+				builder.WithResourceVersions(
+					"some-resource",
+					atc.Version{"ref": "v0"},
+					atc.Version{"ref": "v1"},
+					atc.Version{"ref": "v2"},
+				),
+			)
+		})
+
+		It("returns the newest version", func() {
+			rcv, found, err := scenario.Resource("some-resource").NewestVersionSince(atc.Version{"ref": "v1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(rcv.Version()).To(Equal(db.Version{"ref": "v2"}))
+		})
+
+		Context("when the newest version is disabled", func() {
+			BeforeEach(func() {
+				rcv := scenario.ResourceVersion("some-resource", atc.Version{"ref": "v2"})
+				err := scenario.Resource("some-resource").DisableVersion(rcv.ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the newest enabled version", func() {
+				rcv, found, err := scenario.Resource("some-resource").NewestVersionSince(atc.Version{"ref": "v0"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(rcv.Version()).To(Equal(db.Version{"ref": "v1"}))
+			})
+
+			It("does not find anything since it", func() {
+				_, found, err := scenario.Resource("some-resource").NewestVersionSince(atc.Version{"ref": "v2"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the version does not exist", func() {
+			It("does not find anything", func() {
+				_, found, err := scenario.Resource("some-resource").NewestVersionSince(atc.Version{"ref": "bogus"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Context("Versions", func() {
 		var (
 			scenario *dbtest.Scenario
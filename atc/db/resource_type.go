@@ -301,6 +301,7 @@ func (r *resourceType) CreateBuild(ctx context.Context, manuallyTriggered bool,
 		Plan:              plan,
 		ManuallyTriggered: manuallyTriggered,
 		SpanContext:       NewSpanContext(ctx),
+		IdempotencyKey:    idempotencyKeyFromContext(ctx),
 		ExtraValues: map[string]interface{}{
 			"resource_type_id": r.id,
 		},
@@ -0,0 +1,225 @@
+package db
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+//counterfeiter:generate . SemaphoreFactory
+type SemaphoreFactory interface {
+	Semaphore(teamID int, name string, limit int) Semaphore
+}
+
+// SemaphoreLimitMismatchError is returned when acquiring a semaphore with a
+// different limit than the one it is held with.
+type SemaphoreLimitMismatchError struct {
+	Name          string
+	Limit         int
+	ExistingLimit int
+}
+
+func (e SemaphoreLimitMismatchError) Error() string {
+	return fmt.Sprintf("semaphore '%s' is held with a limit of %d, not %d", e.Name, e.ExistingLimit, e.Limit)
+}
+
+// Semaphore is a named semaphore shared by every build of a team. It is held
+// by steps, identified by their build and plan ID, so that at most its limit
+// of them run at once.
+//
+//counterfeiter:generate . Semaphore
+type Semaphore interface {
+	Name() string
+	Limit() int
+
+	TryAcquire(logger lager.Logger, buildID int, planID atc.PlanID) (bool, error)
+	Release(buildID int, planID atc.PlanID) error
+}
+
+type semaphoreFactory struct {
+	conn        Conn
+	lockFactory lock.LockFactory
+}
+
+func NewSemaphoreFactory(conn Conn, lockFactory lock.LockFactory) SemaphoreFactory {
+	return &semaphoreFactory{
+		conn:        conn,
+		lockFactory: lockFactory,
+	}
+}
+
+func (factory *semaphoreFactory) Semaphore(teamID int, name string, limit int) Semaphore {
+	return &semaphore{
+		teamID:      teamID,
+		name:        name,
+		limit:       limit,
+		conn:        factory.conn,
+		lockFactory: factory.lockFactory,
+	}
+}
+
+type semaphore struct {
+	teamID int
+	name   string
+	limit  int
+
+	conn        Conn
+	lockFactory lock.LockFactory
+}
+
+func (s *semaphore) Name() string { return s.name }
+func (s *semaphore) Limit() int   { return s.limit }
+
+// TryAcquire records the step as a holder of the semaphore if fewer than its
+// limit of steps hold it, returning false otherwise. Steps in builds which
+// have completed no longer count as holders, so that a semaphore is not held
+// forever by a build that was never cleaned up after.
+//
+// Acquiring a semaphore which the step already holds succeeds.
+//
+// The limit is stored along with the semaphore, so that every step agrees on
+// it. While the semaphore is held, acquiring it with a different limit
+// returns a SemaphoreLimitMismatchError; once it is no longer held, the limit
+// is replaced.
+func (s *semaphore) TryAcquire(logger lager.Logger, buildID int, planID atc.PlanID) (bool, error) {
+	lock, acquired, err := s.lockFactory.Acquire(
+		logger.Session("lock", lager.Data{
+			"team-id":   s.teamID,
+			"semaphore": s.name,
+		}),
+		lock.NewSemaphoreLockID(s.teamID, s.name),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if !acquired {
+		// another step is acquiring the semaphore; try again later
+		return false, nil
+	}
+
+	defer lock.Release()
+
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	var held bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM semaphore_holders
+			WHERE team_id = $1 AND name = $2 AND build_id = $3 AND plan_id = $4
+		)
+	`, s.teamID, s.name, buildID, string(planID)).Scan(&held)
+	if err != nil {
+		return false, err
+	}
+
+	if held {
+		return true, nil
+	}
+
+	_, err = psql.Insert("semaphores").
+		Columns("team_id", "name", `"limit"`).
+		Values(s.teamID, s.name, s.limit).
+		Suffix("ON CONFLICT (team_id, name) DO NOTHING").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	var limit int
+	err = psql.Select(`"limit"`).
+		From("semaphores").
+		Where(sq.Eq{
+			"team_id": s.teamID,
+			"name":    s.name,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&limit)
+	if err != nil {
+		return false, err
+	}
+
+	var holders int
+	err = psql.Select("COUNT(*)").
+		From("semaphore_holders h").
+		Join("builds b ON b.id = h.build_id").
+		Where(sq.Eq{
+			"h.team_id":   s.teamID,
+			"h.name":      s.name,
+			"b.completed": false,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&holders)
+	if err != nil {
+		return false, err
+	}
+
+	if limit != s.limit {
+		if holders > 0 {
+			return false, SemaphoreLimitMismatchError{
+				Name:          s.name,
+				Limit:         s.limit,
+				ExistingLimit: limit,
+			}
+		}
+
+		_, err = psql.Update("semaphores").
+			Set(`"limit"`, s.limit).
+			Where(sq.Eq{
+				"team_id": s.teamID,
+				"name":    s.name,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if holders >= s.limit {
+		return false, nil
+	}
+
+	_, err = psql.Insert("semaphore_holders").
+		Columns("team_id", "name", "build_id", "plan_id").
+		Values(s.teamID, s.name, buildID, string(planID)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Release removes the step as a holder of the semaphore. Releasing a
+// semaphore which the step does not hold does nothing.
+func (s *semaphore) Release(buildID int, planID atc.PlanID) error {
+	_, err := psql.Delete("semaphore_holders").
+		Where(sq.Eq{
+			"team_id":  s.teamID,
+			"name":     s.name,
+			"build_id": buildID,
+			"plan_id":  string(planID),
+		}).
+		RunWith(s.conn).
+		Exec()
+	return err
+}
@@ -0,0 +1,127 @@
+package db_test
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Semaphore", func() {
+	var (
+		logger           *lagertest.TestLogger
+		semaphoreFactory db.SemaphoreFactory
+		semaphore        db.Semaphore
+
+		build1, build2 db.Build
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("semaphore-test")
+		semaphoreFactory = db.NewSemaphoreFactory(dbConn, lockFactory)
+		semaphore = semaphoreFactory.Semaphore(defaultTeam.ID(), "some-semaphore", 1)
+
+		var err error
+		build1, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		build2, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("TryAcquire", func() {
+		It("acquires the semaphore when it is not held", func() {
+			acquired, err := semaphore.TryAcquire(logger, build1.ID(), "some-plan")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+		})
+
+		Context("when the semaphore is held up to its limit", func() {
+			BeforeEach(func() {
+				acquired, err := semaphore.TryAcquire(logger, build1.ID(), "some-plan")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("does not acquire it for another step", func() {
+				acquired, err := semaphore.TryAcquire(logger, build2.ID(), "some-plan")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeFalse())
+			})
+
+			It("acquires it again for the step holding it", func() {
+				acquired, err := semaphore.TryAcquire(logger, build1.ID(), "some-plan")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("acquires a semaphore with a different name", func() {
+				acquired, err := semaphoreFactory.Semaphore(defaultTeam.ID(), "some-other-semaphore", 1).TryAcquire(logger, build2.ID(), "some-plan")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("acquires a semaphore with the same name in another team", func() {
+				otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-semaphore-team"})
+				Expect(err).ToNot(HaveOccurred())
+
+				otherBuild, err := otherTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				acquired, err := semaphoreFactory.Semaphore(otherTeam.ID(), "some-semaphore", 1).TryAcquire(logger, otherBuild.ID(), "some-plan")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("errors when acquiring it with a different limit", func() {
+				_, err := semaphoreFactory.Semaphore(defaultTeam.ID(), "some-semaphore", 2).TryAcquire(logger, build2.ID(), "some-plan")
+				Expect(err).To(Equal(db.SemaphoreLimitMismatchError{
+					Name:          "some-semaphore",
+					Limit:         2,
+					ExistingLimit: 1,
+				}))
+			})
+
+			Context("when the holder releases it", func() {
+				BeforeEach(func() {
+					err := semaphore.Release(build1.ID(), "some-plan")
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("acquires it for another step", func() {
+					acquired, err := semaphore.TryAcquire(logger, build2.ID(), "some-plan")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(acquired).To(BeTrue())
+				})
+			})
+
+			Context("when the holder's build has completed", func() {
+				BeforeEach(func() {
+					err := build1.Finish(db.BuildStatusErrored)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("acquires it for another step", func() {
+					acquired, err := semaphore.TryAcquire(logger, build2.ID(), "some-plan")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(acquired).To(BeTrue())
+				})
+
+				It("acquires it with a different limit", func() {
+					acquired, err := semaphoreFactory.Semaphore(defaultTeam.ID(), "some-semaphore", 2).TryAcquire(logger, build2.ID(), "some-plan")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(acquired).To(BeTrue())
+
+					By("keeping the new limit")
+					build3, err := defaultTeam.CreateOneOffBuild()
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = semaphore.TryAcquire(logger, build3.ID(), "some-plan")
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+	})
+})
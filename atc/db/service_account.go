@@ -0,0 +1,215 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+var ErrServiceAccountExists = errors.New("service account already exists")
+
+//counterfeiter:generate . ServiceAccount
+type ServiceAccount interface {
+	ID() int
+	TeamID() int
+	TeamName() string
+	Name() string
+	Role() string
+	CreatedBy() string
+	CreatedAt() time.Time
+	ExpiresAt() time.Time
+}
+
+type serviceAccount struct {
+	id        int
+	teamID    int
+	teamName  string
+	name      string
+	role      string
+	createdBy string
+	createdAt time.Time
+	expiresAt time.Time
+}
+
+func (s serviceAccount) ID() int              { return s.id }
+func (s serviceAccount) TeamID() int          { return s.teamID }
+func (s serviceAccount) TeamName() string     { return s.teamName }
+func (s serviceAccount) Name() string         { return s.name }
+func (s serviceAccount) Role() string         { return s.role }
+func (s serviceAccount) CreatedBy() string    { return s.createdBy }
+func (s serviceAccount) CreatedAt() time.Time { return s.createdAt }
+func (s serviceAccount) ExpiresAt() time.Time { return s.expiresAt }
+
+var serviceAccountsQuery = psql.Select(
+	"sa.id",
+	"sa.team_id",
+	"t.name",
+	"sa.name",
+	"sa.role",
+	"sa.created_by",
+	"sa.created_at",
+	"sa.expires_at",
+).
+	From("service_accounts sa").
+	Join("teams t ON sa.team_id = t.id")
+
+func scanServiceAccount(sa *serviceAccount, row scannable) error {
+	var createdBy sql.NullString
+
+	err := row.Scan(
+		&sa.id,
+		&sa.teamID,
+		&sa.teamName,
+		&sa.name,
+		&sa.role,
+		&createdBy,
+		&sa.createdAt,
+		&sa.expiresAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	sa.createdBy = createdBy.String
+
+	return nil
+}
+
+func (t *team) ServiceAccounts() ([]ServiceAccount, error) {
+	rows, err := serviceAccountsQuery.
+		Where(sq.Eq{"sa.team_id": t.id}).
+		OrderBy("sa.name").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var accounts []ServiceAccount
+	for rows.Next() {
+		sa := serviceAccount{}
+		err = scanServiceAccount(&sa, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, sa)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// CreateServiceAccount stores the service account along with its access
+// token in a single transaction so that a token is never valid for an
+// account which failed to be created.
+func (t *team) CreateServiceAccount(name string, role string, createdBy string, token AccessToken) (ServiceAccount, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	var expiresAt time.Time
+	if token.Claims.Expiry != nil {
+		expiresAt = token.Claims.Expiry.Time()
+	}
+
+	_, err = psql.Insert("access_tokens").
+		Columns("token", "sub", "expires_at", "claims").
+		Values(token.Token, token.Claims.Subject, expiresAt, token.Claims).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	var id int
+	err = psql.Insert("service_accounts").
+		Columns("team_id", "name", "role", "token", "created_by", "expires_at").
+		Values(t.id, name, role, token.Token, sql.NullString{String: createdBy, Valid: createdBy != ""}, expiresAt).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return nil, ErrServiceAccountExists
+		}
+		return nil, err
+	}
+
+	sa := serviceAccount{}
+	err = scanServiceAccount(&sa, serviceAccountsQuery.
+		Where(sq.Eq{"sa.id": id}).
+		RunWith(tx).
+		QueryRow())
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
+
+// DeleteServiceAccount removes the service account and revokes its access
+// token.
+func (t *team) DeleteServiceAccount(name string) (bool, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	query, args, err := psql.Delete("service_accounts").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		}).
+		Suffix("RETURNING token").
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var token sql.NullString
+	err = tx.QueryRow(query, args...).Scan(&token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if token.Valid {
+		_, err = psql.Delete("access_tokens").
+			Where(sq.Eq{"token": token.String}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// TaskMemo records the outputs of a successful run of a memoized task step,
+// keyed on a digest of the task's config and inputs. Outputs maps each of the
+// task's output names to the handle of the volume holding it.
+type TaskMemo struct {
+	JobID    int
+	StepName string
+	Key      string
+
+	BuildID   int
+	BuildName string
+	Outputs   map[string]string
+}
+
+//counterfeiter:generate . TaskMemoFactory
+type TaskMemoFactory interface {
+	Find(jobID int, stepName string, key string) (TaskMemo, bool, error)
+	Save(TaskMemo) error
+}
+
+type taskMemoFactory struct {
+	conn Conn
+}
+
+func NewTaskMemoFactory(conn Conn) TaskMemoFactory {
+	return &taskMemoFactory{
+		conn: conn,
+	}
+}
+
+// Find returns the memo of the job's step if it was saved with the given key.
+func (f *taskMemoFactory) Find(jobID int, stepName string, key string) (TaskMemo, bool, error) {
+	memo := TaskMemo{
+		JobID:    jobID,
+		StepName: stepName,
+		Key:      key,
+	}
+
+	var outputs []byte
+	err := psql.Select("m.build_id", "b.name", "m.outputs").
+		From("task_memos m").
+		Join("builds b ON b.id = m.build_id").
+		Where(sq.Eq{
+			"m.job_id":    jobID,
+			"m.step_name": stepName,
+			"m.key":       key,
+		}).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&memo.BuildID, &memo.BuildName, &outputs)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return TaskMemo{}, false, nil
+		}
+
+		return TaskMemo{}, false, err
+	}
+
+	err = json.Unmarshal(outputs, &memo.Outputs)
+	if err != nil {
+		return TaskMemo{}, false, err
+	}
+
+	return memo, true, nil
+}
+
+// Save records the memo, replacing any memo previously saved for the job's
+// step. Only the latest memo of each step is kept.
+func (f *taskMemoFactory) Save(memo TaskMemo) error {
+	outputs, err := json.Marshal(memo.Outputs)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("task_memos").
+		Columns("job_id", "step_name", "key", "build_id", "outputs").
+		Values(memo.JobID, memo.StepName, memo.Key, memo.BuildID, outputs).
+		Suffix(`
+			ON CONFLICT (job_id, step_name) DO UPDATE SET
+				key = EXCLUDED.key,
+				build_id = EXCLUDED.build_id,
+				outputs = EXCLUDED.outputs,
+				created_at = now()
+		`).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
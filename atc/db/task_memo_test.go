@@ -0,0 +1,92 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskMemoFactory", func() {
+	var (
+		taskMemoFactory db.TaskMemoFactory
+		build           db.Build
+	)
+
+	BeforeEach(func() {
+		taskMemoFactory = db.NewTaskMemoFactory(dbConn)
+
+		var err error
+		build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("when no memo has been saved", func() {
+		It("does not find one", func() {
+			_, found, err := taskMemoFactory.Find(defaultJob.ID(), "some-step", "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when a memo has been saved", func() {
+		BeforeEach(func() {
+			err := taskMemoFactory.Save(db.TaskMemo{
+				JobID:    defaultJob.ID(),
+				StepName: "some-step",
+				Key:      "some-key",
+				BuildID:  build.ID(),
+				Outputs:  map[string]string{"some-output": "some-handle"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("finds it by its key", func() {
+			memo, found, err := taskMemoFactory.Find(defaultJob.ID(), "some-step", "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(memo).To(Equal(db.TaskMemo{
+				JobID:     defaultJob.ID(),
+				StepName:  "some-step",
+				Key:       "some-key",
+				BuildID:   build.ID(),
+				BuildName: build.Name(),
+				Outputs:   map[string]string{"some-output": "some-handle"},
+			}))
+		})
+
+		It("does not find it by another key or step", func() {
+			_, found, err := taskMemoFactory.Find(defaultJob.ID(), "some-step", "some-other-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = taskMemoFactory.Find(defaultJob.ID(), "some-other-step", "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when another memo is saved for the step", func() {
+			BeforeEach(func() {
+				err := taskMemoFactory.Save(db.TaskMemo{
+					JobID:    defaultJob.ID(),
+					StepName: "some-step",
+					Key:      "some-other-key",
+					BuildID:  build.ID(),
+					Outputs:  map[string]string{"some-output": "some-other-handle"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("replaces the previous memo", func() {
+				_, found, err := taskMemoFactory.Find(defaultJob.ID(), "some-step", "some-key")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				memo, found, err := taskMemoFactory.Find(defaultJob.ID(), "some-step", "some-other-key")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(memo.Outputs).To(Equal(map[string]string{"some-output": "some-other-handle"}))
+			})
+		})
+	})
+})
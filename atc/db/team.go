@@ -20,6 +20,7 @@ import (
 )
 
 var ErrConfigComparisonFailed = errors.New("comparison with existing config failed during save")
+var ErrTeamNameTaken = errors.New("a team with that name already exists")
 
 type ErrPipelineNotFound atc.PipelineRef
 
@@ -34,6 +35,9 @@ type Team interface {
 	Admin() bool
 
 	Auth() atc.TeamAuth
+	PipelineAuth() atc.PipelineAuth
+	AccessTokenLifetime() time.Duration
+	SessionIdleTimeout() time.Duration
 
 	Delete() error
 	Rename(string) error
@@ -76,6 +80,23 @@ type Team interface {
 	FindWorkersForResourceCache(rcId int) ([]Worker, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+	UpdateAuth(atc.Team) error
+
+	ServiceAccounts() ([]ServiceAccount, error)
+	CreateServiceAccount(name string, role string, createdBy string, token AccessToken) (ServiceAccount, error)
+	DeleteServiceAccount(name string) (bool, error)
+
+	PrunableState() (atc.TeamPrunableState, error)
+	PruneState() (atc.TeamPrunableState, error)
+
+	Webhooks() ([]atc.TeamWebhook, error)
+	SetWebhook(atc.TeamWebhook) (bool, error)
+	DeleteWebhook(name string) (bool, error)
+
+	PipelineTemplates() ([]atc.PipelineTemplate, error)
+	PipelineTemplate(name string) (atc.PipelineTemplate, bool, error)
+	SetPipelineTemplate(atc.PipelineTemplate) (bool, error)
+	DeletePipelineTemplate(name string) (bool, error)
 }
 
 type team struct {
@@ -86,7 +107,11 @@ type team struct {
 	name  string
 	admin bool
 
-	auth atc.TeamAuth
+	auth         atc.TeamAuth
+	pipelineAuth atc.PipelineAuth
+
+	accessTokenLifetime time.Duration
+	sessionIdleTimeout  time.Duration
 }
 
 func (t *team) ID() int      { return t.id }
@@ -95,6 +120,11 @@ func (t *team) Admin() bool  { return t.admin }
 
 func (t *team) Auth() atc.TeamAuth { return t.auth }
 
+func (t *team) PipelineAuth() atc.PipelineAuth { return t.pipelineAuth }
+
+func (t *team) AccessTokenLifetime() time.Duration { return t.accessTokenLifetime }
+func (t *team) SessionIdleTimeout() time.Duration  { return t.sessionIdleTimeout }
+
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
 		Where(sq.Eq{
@@ -106,6 +136,9 @@ func (t *team) Delete() error {
 	return err
 }
 
+// Rename changes the name of the team. Everything owned by the team refers
+// to it by ID, so pipelines, builds, workers and issued tokens carry over.
+// ErrTeamNameTaken is returned if another team already has the name.
 func (t *team) Rename(name string) error {
 	_, err := psql.Update("teams").
 		Set("name", name).
@@ -114,8 +147,16 @@ func (t *team) Rename(name string) error {
 		}).
 		RunWith(t.conn).
 		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return ErrTeamNameTaken
+		}
+		return err
+	}
 
-	return err
+	t.name = name
+
+	return nil
 }
 
 func (t *team) Workers() ([]Worker, error) {
@@ -423,6 +464,11 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	defaultsPayload, err := json.Marshal(config.Defaults)
+	if err != nil {
+		return 0, false, err
+	}
+
 	var pipelineID int
 	if !existingConfig {
 		values := map[string]interface{}{
@@ -430,6 +476,7 @@ func savePipeline(
 			"groups":          groupsPayload,
 			"var_sources":     encryptedVarSourcesPayload,
 			"display":         displayPayload,
+			"defaults":        defaultsPayload,
 			"nonce":           nonce,
 			"version":         sq.Expr("nextval('config_version_seq')"),
 			"paused":          initiallyPaused,
@@ -475,6 +522,7 @@ func savePipeline(
 			Set("groups", groupsPayload).
 			Set("var_sources", encryptedVarSourcesPayload).
 			Set("display", displayPayload).
+			Set("defaults", defaultsPayload).
 			Set("nonce", nonce).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Set("last_updated", sq.Expr("now()")).
@@ -572,6 +620,16 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	err = enqueueTeamWebhookEvent(tx, teamID, atc.TeamWebhookEvent{
+		Type:                 atc.TeamWebhookEventPipelineChanged,
+		Time:                 time.Now().Unix(),
+		PipelineName:         pipelineRef.Name,
+		PipelineInstanceVars: pipelineRef.InstanceVars,
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
 	return pipelineID, !existingConfig, nil
 }
 
@@ -631,7 +689,18 @@ func (t *team) RenamePipeline(oldName, newName string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	return rowsAffected > 0, nil
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	// the cached teams name the pipeline when listing the roles granted on it
+	err = t.conn.Bus().Notify(atc.TeamCacheChannel)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (t *team) Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error) {
@@ -938,6 +1007,121 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
+// UpdateAuth replaces the team's roles, the roles granted on its pipelines
+// and its session lifetimes together, so that they are never left half
+// updated. It returns ErrPipelineNotFound if a role is granted on a pipeline
+// which doesn't exist.
+func (t *team) UpdateAuth(atcTeam atc.Team) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer Rollback(tx)
+
+	jsonEncodedProviderAuth, err := json.Marshal(atcTeam.Auth)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("auth", jsonEncodedProviderAuth).
+		Set("legacy_auth", nil).
+		Set("nonce", nil).
+		Set("access_token_lifetime", durationOrNull(atcTeam.AccessTokenLifetime)).
+		Set("session_idle_timeout", durationOrNull(atcTeam.SessionIdleTimeout)).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = savePipelineAuth(tx, t.id, atcTeam.PipelineAuth)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	t.auth = atcTeam.Auth
+	t.pipelineAuth = atcTeam.PipelineAuth
+	t.accessTokenLifetime = atcTeam.AccessTokenLifetime
+	t.sessionIdleTimeout = atcTeam.SessionIdleTimeout
+
+	return nil
+}
+
+// savePipelineAuth replaces the roles granted on the team's pipelines. Grants
+// are stored against the pipeline's ID, so they follow the pipeline if it is
+// renamed and go away with it when it is destroyed.
+func savePipelineAuth(tx Tx, teamID int, auth atc.PipelineAuth) error {
+	_, err := psql.Delete("pipeline_auth").
+		Where(sq.Expr("pipeline_id IN (SELECT id FROM pipelines WHERE team_id = ?)", teamID)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	for _, grant := range auth {
+		var instanceVars sql.NullString
+		if grant.Pipeline.InstanceVars != nil {
+			bytes, err := json.Marshal(grant.Pipeline.InstanceVars)
+			if err != nil {
+				return err
+			}
+
+			instanceVars = sql.NullString{String: string(bytes), Valid: true}
+		}
+
+		var pipelineID int
+		err := psql.Select("id").
+			From("pipelines").
+			Where(sq.Eq{
+				"team_id":       teamID,
+				"name":          grant.Pipeline.Name,
+				"instance_vars": instanceVars,
+			}).
+			RunWith(tx).
+			QueryRow().
+			Scan(&pipelineID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrPipelineNotFound(grant.Pipeline)
+			}
+
+			return err
+		}
+
+		payload, err := json.Marshal(grant.Auth)
+		if err != nil {
+			return err
+		}
+
+		_, err = psql.Insert("pipeline_auth").
+			Columns("pipeline_id", "auth").
+			Values(pipelineID, payload).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func durationOrNull(d time.Duration) sql.NullString {
+	if d == 0 {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: d.String(), Valid: true}
+}
+
 func (t *team) FindCheckContainers(logger lager.Logger, pipelineRef atc.PipelineRef, resourceName string, secretManager creds.Secrets, varSourcePool creds.VarSourcePool) ([]Container, map[int]time.Time, error) {
 	pipeline, found, err := t.Pipeline(pipelineRef)
 	if err != nil {
@@ -1205,6 +1389,16 @@ func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) (int, er
 		return 0, err
 	}
 
+	schedulePayload, err := json.Marshal(job.Schedule)
+	if err != nil {
+		return 0, err
+	}
+
+	afterPayload, err := json.Marshal(job.After)
+	if err != nil {
+		return 0, err
+	}
+
 	es := tx.EncryptionStrategy()
 	encryptedPayload, nonce, err := es.Encrypt(configPayload)
 	if err != nil {
@@ -1213,9 +1407,11 @@ func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) (int, er
 
 	var jobID int
 	err = psql.Insert("jobs").
-		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "disable_manual_trigger", "interruptible", "active", "nonce", "tags").
-		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), job.DisableManualTrigger, job.Interruptible, true, nonce, pq.Array(groups)).
-		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, disable_manual_trigger = EXCLUDED.disable_manual_trigger, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags").
+		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "priority", "schedule", "after_jobs", "disable_manual_trigger", "interruptible", "active", "nonce", "tags").
+		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), int(job.Priority), schedulePayload, afterPayload, job.DisableManualTrigger, job.Interruptible, true, nonce, pq.Array(groups)).
+		// a changed schedule starts counting from now, rather than from when
+		// the previous schedule last ran
+		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, priority = EXCLUDED.priority, schedule_anchor = CASE WHEN jobs.schedule IS DISTINCT FROM EXCLUDED.schedule THEN now() ELSE jobs.schedule_anchor END, schedule = EXCLUDED.schedule, after_jobs = EXCLUDED.after_jobs, disable_manual_trigger = EXCLUDED.disable_manual_trigger, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags").
 		Suffix("RETURNING id").
 		RunWith(tx).
 		QueryRow().
@@ -1359,18 +1555,26 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		groups        sql.NullString
 		varSources    sql.NullString
 		display       sql.NullString
+		defaults      sql.NullString
 		nonce         sql.NullString
 		nonceStr      *string
 		lastUpdated   pq.NullTime
 		parentJobID   sql.NullInt64
 		parentBuildID sql.NullInt64
 		instanceVars  sql.NullString
+		pausedBy      sql.NullString
+		pausedAt      pq.NullTime
+		pauseReason   sql.NullString
 	)
-	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars)
+	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &defaults, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars, &pausedBy, &pausedAt, &pauseReason)
 	if err != nil {
 		return err
 	}
 
+	p.pausedBy = pausedBy.String
+	p.pausedAt = pausedAt.Time
+	p.pauseReason = pauseReason.String
+
 	p.lastUpdated = lastUpdated.Time
 	p.parentJobID = int(parentJobID.Int64)
 	p.parentBuildID = int(parentBuildID.Int64)
@@ -1399,6 +1603,16 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.display = displayConfig
 	}
 
+	if defaults.Valid {
+		var stepDefaults *atc.StepDefaults
+		err = json.Unmarshal([]byte(defaults.String), &stepDefaults)
+		if err != nil {
+			return err
+		}
+
+		p.defaults = stepDefaults
+	}
+
 	if varSources.Valid {
 		var pipelineVarSources atc.VarSourceConfigs
 		decryptedVarSource, err := p.conn.EncryptionStrategy().Decrypt(varSources.String, nonceStr)
@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"strings"
+	"time"
 
 	"encoding/json"
 
@@ -11,6 +12,18 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 )
 
+// teamColumns gathers the roles granted on each of the team's pipelines along
+// with the team, naming each pipeline by its current ref.
+const teamColumns = `id, name, admin, auth, (
+		SELECT json_agg(json_build_object(
+			'pipeline', json_build_object('name', p.name, 'instance_vars', p.instance_vars),
+			'auth', pa.auth::json
+		) ORDER BY p.id)
+		FROM pipeline_auth pa
+		JOIN pipelines p ON p.id = pa.pipeline_id
+		WHERE p.team_id = teams.id
+	), access_token_lifetime, session_idle_timeout`
+
 //counterfeiter:generate . TeamFactory
 type TeamFactory interface {
 	CreateTeam(atc.Team) (Team, error)
@@ -52,9 +65,9 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 	}
 
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, access_token_lifetime, session_idle_timeout, admin").
+		Values(t.Name, auth, durationOrNull(t.AccessTokenLifetime), durationOrNull(t.SessionIdleTimeout), admin).
+		Suffix("RETURNING " + teamColumns).
 		RunWith(tx).
 		QueryRow()
 
@@ -68,6 +81,12 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	// a new team has no pipelines, so this only fails if any were named
+	err = savePipelineAuth(tx, team.id, t.PipelineAuth)
+	if err != nil {
+		return nil, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -90,7 +109,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select(teamColumns).
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -109,7 +128,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select(teamColumns).
 		From("teams").
 		OrderBy("name ASC").
 		RunWith(factory.conn).
@@ -175,14 +194,20 @@ func (factory *teamFactory) NotifyCacher() error {
 }
 
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
-	var providerAuth sql.NullString
+	var providerAuth, pipelineAuth, accessTokenLifetime, sessionIdleTimeout sql.NullString
 
 	err := rows.Scan(
 		&t.id,
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&pipelineAuth,
+		&accessTokenLifetime,
+		&sessionIdleTimeout,
 	)
+	if err != nil {
+		return err
+	}
 
 	if providerAuth.Valid {
 		err = json.Unmarshal([]byte(providerAuth.String), &t.auth)
@@ -191,5 +216,26 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		}
 	}
 
-	return err
+	if pipelineAuth.Valid {
+		err = json.Unmarshal([]byte(pipelineAuth.String), &t.pipelineAuth)
+		if err != nil {
+			return err
+		}
+	}
+
+	if accessTokenLifetime.Valid {
+		t.accessTokenLifetime, err = time.ParseDuration(accessTokenLifetime.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	if sessionIdleTimeout.Valid {
+		t.sessionIdleTimeout, err = time.ParseDuration(sessionIdleTimeout.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,174 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+func (t *team) PrunableState() (atc.TeamPrunableState, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return atc.TeamPrunableState{}, err
+	}
+
+	defer Rollback(tx)
+
+	state, _, _, err := t.findPrunableState(tx)
+	if err != nil {
+		return atc.TeamPrunableState{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return atc.TeamPrunableState{}, err
+	}
+
+	return state, nil
+}
+
+// PruneState marks the team's prunable containers and volumes as destroying,
+// regardless of the state of their worker, and returns what was pruned. They
+// are removed from their worker once it reports back, or along with the
+// worker when it is pruned.
+func (t *team) PruneState() (atc.TeamPrunableState, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return atc.TeamPrunableState{}, err
+	}
+
+	defer Rollback(tx)
+
+	state, containerIDs, volumeIDs, err := t.findPrunableState(tx)
+	if err != nil {
+		return atc.TeamPrunableState{}, err
+	}
+
+	if len(containerIDs) > 0 {
+		_, err = psql.Update("containers").
+			Set("state", atc.ContainerStateDestroying).
+			Where(sq.Eq{
+				"id":    containerIDs,
+				"state": atc.ContainerStateCreated,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return atc.TeamPrunableState{}, err
+		}
+	}
+
+	if len(volumeIDs) > 0 {
+		_, err = psql.Update("volumes").
+			Set("state", string(VolumeStateDestroying)).
+			Where(sq.Eq{
+				"id":    volumeIDs,
+				"state": string(VolumeStateCreated),
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return atc.TeamPrunableState{}, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return atc.TeamPrunableState{}, err
+	}
+
+	return state, nil
+}
+
+// findPrunableState finds the team's created containers which are orphaned or
+// belong to an expired check session, and its created volumes which are
+// orphaned. Unlike the garbage collector, it doesn't skip those on stalled
+// workers.
+func (t *team) findPrunableState(tx Tx) (atc.TeamPrunableState, []int, []int, error) {
+	state := atc.TeamPrunableState{
+		Containers: []atc.PrunableObject{},
+		Volumes:    []atc.PrunableObject{},
+	}
+
+	rows, err := psql.Select("c.id", "c.handle", "c.worker_name", "rccs.id IS NOT NULL").
+		From("containers c").
+		LeftJoin("builds b ON b.id = c.build_id").
+		LeftJoin("containers icc ON icc.id = c.image_check_container_id").
+		LeftJoin("containers igc ON igc.id = c.image_get_container_id").
+		LeftJoin("resource_config_check_sessions rccs ON rccs.id = c.resource_config_check_session_id").
+		Where(sq.Eq{"c.state": atc.ContainerStateCreated}).
+		Where(sq.Or{
+			sq.And{
+				sq.Eq{"c.team_id": t.id},
+				orphanedContainers,
+			},
+			sq.And{
+				sq.Expr("rccs.expires_at < now()"),
+				sq.Expr(`EXISTS (
+					SELECT 1 FROM resources r
+					JOIN pipelines p ON p.id = r.pipeline_id
+					WHERE r.resource_config_id = rccs.resource_config_id
+					AND p.team_id = ?
+				)`, t.id),
+			},
+		}).
+		Where(notIntercepted).
+		OrderBy("c.id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return atc.TeamPrunableState{}, nil, nil, err
+	}
+
+	defer Close(rows)
+
+	var containerIDs []int
+	for rows.Next() {
+		var id int
+		var container atc.PrunableObject
+		var stuckCheck bool
+		err = rows.Scan(&id, &container.Handle, &container.WorkerName, &stuckCheck)
+		if err != nil {
+			return atc.TeamPrunableState{}, nil, nil, err
+		}
+
+		container.Reason = atc.PruneReasonOrphaned
+		if stuckCheck {
+			container.Reason = atc.PruneReasonStuckCheck
+		}
+
+		containerIDs = append(containerIDs, id)
+		state.Containers = append(state.Containers, container)
+	}
+
+	rows, err = psql.Select("v.id", "v.handle", "v.worker_name").
+		From("volumes v").
+		LeftJoin("volumes cv ON cv.parent_id = v.id").
+		Where(orphanedVolumes).
+		Where(sq.Eq{
+			"v.team_id": t.id,
+			"v.state":   string(VolumeStateCreated),
+		}).
+		OrderBy("v.id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return atc.TeamPrunableState{}, nil, nil, err
+	}
+
+	defer Close(rows)
+
+	var volumeIDs []int
+	for rows.Next() {
+		var id int
+		volume := atc.PrunableObject{Reason: atc.PruneReasonDangling}
+		err = rows.Scan(&id, &volume.Handle, &volume.WorkerName)
+		if err != nil {
+			return atc.TeamPrunableState{}, nil, nil, err
+		}
+
+		volumeIDs = append(volumeIDs, id)
+		state.Volumes = append(state.Volumes, volume)
+	}
+
+	return state, containerIDs, volumeIDs, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"gopkg.in/square/go-jose.v2/jwt"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
@@ -74,6 +75,317 @@ var _ = Describe("Team", func() {
 			_, found, _ := teamFactory.FindTeam("oopsies")
 			Expect(found).To(BeTrue())
 		})
+
+		It("keeps the team's identity", func() {
+			renamed, found, err := teamFactory.FindTeam("oopsies")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(renamed.ID()).To(Equal(team.ID()))
+			Expect(team.Name()).To(Equal("oopsies"))
+		})
+	})
+
+	Describe("Rename to an existing team's name", func() {
+		It("returns ErrTeamNameTaken", func() {
+			err := team.Rename(otherTeam.Name())
+			Expect(err).To(Equal(db.ErrTeamNameTaken))
+
+			_, found, err := teamFactory.FindTeam(team.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
+	Describe("Service accounts", func() {
+		var (
+			expiry jwt.NumericDate
+			token  db.AccessToken
+		)
+
+		BeforeEach(func() {
+			expiry = jwt.NumericDate(time.Now().Add(time.Hour).Unix())
+			token = db.AccessToken{
+				Token: "some-token",
+				Claims: db.Claims{
+					Claims: jwt.Claims{
+						Subject: "serviceaccount:some-team:deployer",
+						Expiry:  &expiry,
+					},
+					RawClaims: map[string]interface{}{
+						"sub": "serviceaccount:some-team:deployer",
+						"exp": expiry,
+					},
+				},
+			}
+		})
+
+		It("creates, lists and deletes service accounts", func() {
+			created, err := team.CreateServiceAccount("deployer", "member", "local:admin", token)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created.Name()).To(Equal("deployer"))
+			Expect(created.TeamName()).To(Equal("some-team"))
+			Expect(created.Role()).To(Equal("member"))
+			Expect(created.CreatedBy()).To(Equal("local:admin"))
+			Expect(created.ExpiresAt().Unix()).To(Equal(int64(expiry)))
+
+			accounts, err := team.ServiceAccounts()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accounts).To(HaveLen(1))
+			Expect(accounts[0].Name()).To(Equal("deployer"))
+
+			otherAccounts, err := otherTeam.ServiceAccounts()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherAccounts).To(BeEmpty())
+
+			_, found, err := db.NewAccessTokenFactory(dbConn).GetAccessToken("some-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			deleted, err := team.DeleteServiceAccount("deployer")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			_, found, err = db.NewAccessTokenFactory(dbConn).GetAccessToken("some-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			accounts, err = team.ServiceAccounts()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accounts).To(BeEmpty())
+		})
+
+		It("does not allow duplicate names within a team", func() {
+			_, err := team.CreateServiceAccount("deployer", "member", "", token)
+			Expect(err).ToNot(HaveOccurred())
+
+			token.Token = "some-other-token"
+			_, err = team.CreateServiceAccount("deployer", "viewer", "", token)
+			Expect(err).To(Equal(db.ErrServiceAccountExists))
+
+			_, found, err := db.NewAccessTokenFactory(dbConn).GetAccessToken("some-other-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns false when deleting an unknown service account", func() {
+			deleted, err := team.DeleteServiceAccount("bogus")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		})
+	})
+
+	Describe("PrunableState", func() {
+		var (
+			container db.CreatedContainer
+			volume    db.CreatedVolume
+		)
+
+		BeforeEach(func() {
+			build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SetInterceptible(false)
+			Expect(err).NotTo(HaveOccurred())
+
+			creatingContainer, err := defaultWorker.CreateContainer(
+				db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()),
+				db.ContainerMetadata{},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			container, err = creatingContainer.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateVolume(defaultTeam.ID(), defaultWorker.Name(), db.VolumeTypeContainer)
+			Expect(err).NotTo(HaveOccurred())
+
+			volume, err = creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the team's orphaned containers and dangling volumes", func() {
+			state, err := defaultTeam.PrunableState()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state).To(Equal(atc.TeamPrunableState{
+				Containers: []atc.PrunableObject{
+					{Handle: container.Handle(), WorkerName: defaultWorker.Name(), Reason: atc.PruneReasonOrphaned},
+				},
+				Volumes: []atc.PrunableObject{
+					{Handle: volume.Handle(), WorkerName: defaultWorker.Name(), Reason: atc.PruneReasonDangling},
+				},
+			}))
+		})
+
+		It("doesn't list other teams' state", func() {
+			state, err := team.PrunableState()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.Containers).To(BeEmpty())
+			Expect(state.Volumes).To(BeEmpty())
+		})
+
+		Context("when the worker has stalled", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE workers SET state = 'stalled' WHERE name = $1`, defaultWorker.Name())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("still lists them", func() {
+				state, err := defaultTeam.PrunableState()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state.Containers).To(HaveLen(1))
+				Expect(state.Volumes).To(HaveLen(1))
+			})
+		})
+
+		Context("when the state is pruned", func() {
+			It("marks the containers and volumes as destroying", func() {
+				pruned, err := defaultTeam.PruneState()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pruned.Containers).To(HaveLen(1))
+				Expect(pruned.Volumes).To(HaveLen(1))
+
+				destroying, err := containerRepository.FindDestroyingContainers(defaultWorker.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(destroying).To(ConsistOf(container.Handle()))
+
+				destroyingVolumes, err := volumeRepository.GetDestroyingVolumes(defaultWorker.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(destroyingVolumes).To(ConsistOf(volume.Handle()))
+
+				state, err := defaultTeam.PrunableState()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state.Containers).To(BeEmpty())
+				Expect(state.Volumes).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Webhooks", func() {
+		var webhook atc.TeamWebhook
+
+		BeforeEach(func() {
+			webhook = atc.TeamWebhook{
+				Name:   "chat",
+				URL:    "https://chat.example.com/hook",
+				Secret: "some-secret",
+				Filter: atc.TeamWebhookFilter{
+					Statuses: []string{"failed"},
+				},
+			}
+		})
+
+		It("sets, lists and deletes webhooks", func() {
+			created, err := team.SetWebhook(webhook)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			webhook.URL = "https://chat.example.com/other-hook"
+			created, err = team.SetWebhook(webhook)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeFalse())
+
+			webhooks, err := team.Webhooks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(webhooks).To(Equal([]atc.TeamWebhook{webhook}))
+
+			otherWebhooks, err := otherTeam.Webhooks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherWebhooks).To(BeEmpty())
+
+			deleted, err := team.DeleteWebhook("chat")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			webhooks, err = team.Webhooks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(webhooks).To(BeEmpty())
+		})
+
+		It("returns false when deleting an unknown webhook", func() {
+			deleted, err := team.DeleteWebhook("bogus")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		})
+
+		Describe("event queue", func() {
+			var queue db.TeamWebhookEventQueue
+
+			BeforeEach(func() {
+				queue = db.NewTeamWebhookEventQueue(dbConn)
+			})
+
+			It("queues pipeline changes for teams with webhooks", func() {
+				_, err := team.SetWebhook(webhook)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				notifications, err := queue.Dequeue(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(notifications).To(HaveLen(1))
+				Expect(notifications[0].Event.Type).To(Equal(atc.TeamWebhookEventPipelineChanged))
+				Expect(notifications[0].Event.TeamName).To(Equal("some-team"))
+				Expect(notifications[0].Event.PipelineName).To(Equal("some-pipeline"))
+				Expect(notifications[0].Webhooks).To(Equal([]atc.TeamWebhook{webhook}))
+
+				notifications, err = queue.Dequeue(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(notifications).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("PipelineTemplates", func() {
+		var template atc.PipelineTemplate
+
+		BeforeEach(func() {
+			template = atc.PipelineTemplate{
+				Name:   "common",
+				Config: "jobs:\n- name: lint\n  plan: []\n",
+			}
+		})
+
+		It("sets, finds, lists and deletes templates", func() {
+			created, err := team.SetPipelineTemplate(template)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			template.Config = "jobs:\n- name: test\n  plan: []\n"
+			created, err = team.SetPipelineTemplate(template)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeFalse())
+
+			found, exists, err := team.PipelineTemplate("common")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(found).To(Equal(template))
+
+			templates, err := team.PipelineTemplates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(templates).To(Equal([]atc.PipelineTemplate{template}))
+
+			_, exists, err = otherTeam.PipelineTemplate("common")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			deleted, err := team.DeletePipelineTemplate("common")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			templates, err = team.PipelineTemplates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(templates).To(BeEmpty())
+		})
+
+		It("returns false when deleting an unknown template", func() {
+			deleted, err := team.DeletePipelineTemplate("bogus")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		})
 	})
 
 	Describe("SaveWorker", func() {
@@ -1043,6 +1355,137 @@ var _ = Describe("Team", func() {
 				})
 			})
 		})
+
+		Describe("UpdateAuth", func() {
+			var (
+				atcTeam      atc.Team
+				pipelineAuth atc.PipelineAuth
+				instanceRef  atc.PipelineRef
+			)
+
+			BeforeEach(func() {
+				instanceRef = atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "main"}}
+
+				_, _, err := team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = team.SavePipeline(instanceRef, atc.Config{}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				pipelineAuth = atc.PipelineAuth{
+					{
+						Pipeline: instanceRef,
+						Auth: atc.TeamAuth{
+							"pipeline-operator": {"users": []string{"local:someone"}},
+						},
+					},
+				}
+
+				atcTeam = atc.Team{
+					Auth: atc.TeamAuth{
+						"owner": {"users": []string{"local:username"}},
+					},
+					PipelineAuth:        pipelineAuth,
+					AccessTokenLifetime: time.Hour,
+					SessionIdleTimeout:  15 * time.Minute,
+				}
+			})
+
+			It("saves the team's auth, pipeline roles and session lifetimes", func() {
+				err := team.UpdateAuth(atcTeam)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.Auth()).To(Equal(atcTeam.Auth))
+				Expect(team.PipelineAuth()).To(Equal(pipelineAuth))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.Auth()).To(Equal(atcTeam.Auth))
+				Expect(reloaded.PipelineAuth()).To(Equal(pipelineAuth))
+				Expect(reloaded.AccessTokenLifetime()).To(Equal(time.Hour))
+				Expect(reloaded.SessionIdleTimeout()).To(Equal(15 * time.Minute))
+			})
+
+			It("clears the pipeline roles and session lifetimes when given none", func() {
+				err := team.UpdateAuth(atcTeam)
+				Expect(err).ToNot(HaveOccurred())
+
+				atcTeam.PipelineAuth = nil
+				atcTeam.AccessTokenLifetime = 0
+				atcTeam.SessionIdleTimeout = 0
+
+				err = team.UpdateAuth(atcTeam)
+				Expect(err).ToNot(HaveOccurred())
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.PipelineAuth()).To(BeEmpty())
+				Expect(reloaded.AccessTokenLifetime()).To(BeZero())
+				Expect(reloaded.SessionIdleTimeout()).To(BeZero())
+			})
+
+			It("follows the pipeline when it is renamed", func() {
+				err := team.UpdateAuth(atcTeam)
+				Expect(err).ToNot(HaveOccurred())
+
+				pipeline, found, err := team.Pipeline(instanceRef)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = dbConn.Exec("UPDATE pipelines SET name = 'renamed-pipeline' WHERE id = $1", pipeline.ID())
+				Expect(err).ToNot(HaveOccurred())
+
+				reloaded, _, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reloaded.PipelineAuth()).To(ConsistOf(atc.PipelineGrant{
+					Pipeline: atc.PipelineRef{Name: "renamed-pipeline", InstanceVars: instanceRef.InstanceVars},
+					Auth:     pipelineAuth[0].Auth,
+				}))
+			})
+
+			It("does not grant the roles on a pipeline recreated in its place", func() {
+				err := team.UpdateAuth(atcTeam)
+				Expect(err).ToNot(HaveOccurred())
+
+				pipeline, _, err := team.Pipeline(instanceRef)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pipeline.Destroy()).To(Succeed())
+
+				_, _, err = team.SavePipeline(instanceRef, atc.Config{}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				reloaded, _, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reloaded.PipelineAuth()).To(BeEmpty())
+			})
+
+			Context("when a role is granted on a pipeline which does not exist", func() {
+				BeforeEach(func() {
+					atcTeam.PipelineAuth = append(atcTeam.PipelineAuth, atc.PipelineGrant{
+						Pipeline: atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "other"}},
+						Auth: atc.TeamAuth{
+							"viewer": {"users": []string{"local:someone"}},
+						},
+					})
+				})
+
+				It("returns an error and saves nothing", func() {
+					err := team.UpdateAuth(atcTeam)
+					Expect(err).To(MatchError(db.ErrPipelineNotFound{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "other"},
+					}))
+
+					reloaded, _, err := teamFactory.FindTeam(team.Name())
+					Expect(err).ToNot(HaveOccurred())
+					Expect(reloaded.Auth()).ToNot(Equal(atcTeam.Auth))
+					Expect(reloaded.PipelineAuth()).To(BeEmpty())
+					Expect(reloaded.AccessTokenLifetime()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Describe("Pipelines", func() {
@@ -1772,7 +2215,7 @@ var _ = Describe("Team", func() {
 					Name:         "fake-pipeline",
 					InstanceVars: atc.InstanceVars{"branch": "feature"},
 				}
-				instancedPipeline, _, err = team.SavePipeline(instancedPipelineRef, atc.Config{}, db.ConfigVersion(0), false)
+				instancedPipeline, _, err = team.SavePipeline(instancedPipelineRef, atc.Config{}, db.ConfigVersion(1), false)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -1799,7 +2242,7 @@ var _ = Describe("Team", func() {
 				BeforeEach(func() {
 					var err error
 					namedPipelineRef = atc.PipelineRef{Name: "fake-pipeline"}
-					namedPipeline, _, err = team.SavePipeline(namedPipelineRef, atc.Config{}, db.ConfigVersion(0), false)
+					namedPipeline, _, err = team.SavePipeline(namedPipelineRef, atc.Config{}, db.ConfigVersion(1), false)
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -0,0 +1,237 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+func (t *team) Webhooks() ([]atc.TeamWebhook, error) {
+	return teamWebhooks(t.conn, t.conn, t.id)
+}
+
+// SetWebhook creates the webhook, or replaces the one with the same name.
+// The config is encrypted as it includes the secret used to sign payloads.
+func (t *team) SetWebhook(webhook atc.TeamWebhook) (bool, error) {
+	payload, err := json.Marshal(webhook)
+	if err != nil {
+		return false, err
+	}
+
+	encryptedPayload, nonce, err := t.conn.EncryptionStrategy().Encrypt(payload)
+	if err != nil {
+		return false, err
+	}
+
+	var created bool
+	err = psql.Insert("team_webhooks").
+		Columns("team_id", "name", "config", "nonce").
+		Values(t.id, webhook.Name, encryptedPayload, nonce).
+		Suffix(`
+			ON CONFLICT (team_id, name) DO UPDATE SET
+				config = EXCLUDED.config,
+				nonce = EXCLUDED.nonce
+			RETURNING xmax = 0
+		`).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&created)
+	if err != nil {
+		return false, err
+	}
+
+	return created, nil
+}
+
+func (t *team) DeleteWebhook(name string) (bool, error) {
+	result, err := psql.Delete("team_webhooks").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func scanTeamWebhook(conn Conn, row scannable) (atc.TeamWebhook, error) {
+	var config string
+	var nonce sql.NullString
+
+	err := row.Scan(&config, &nonce)
+	if err != nil {
+		return atc.TeamWebhook{}, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := conn.EncryptionStrategy().Decrypt(config, noncense)
+	if err != nil {
+		return atc.TeamWebhook{}, err
+	}
+
+	var webhook atc.TeamWebhook
+	err = json.Unmarshal(decrypted, &webhook)
+	if err != nil {
+		return atc.TeamWebhook{}, err
+	}
+
+	return webhook, nil
+}
+
+// enqueueTeamWebhookEvent queues the event to be sent to the team's webhooks
+// as part of the transaction that caused it, so that it's only sent if the
+// transaction commits. Nothing is queued for teams without webhooks.
+func enqueueTeamWebhookEvent(tx Tx, teamID int, event atc.TeamWebhookEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO team_webhook_events (team_id, payload)
+		SELECT $1, $2
+		WHERE EXISTS (SELECT 1 FROM team_webhooks WHERE team_id = $1)
+	`, teamID, payload)
+	return err
+}
+
+// TeamWebhookNotification is a queued event along with the webhooks of its
+// team at the time it was dequeued.
+type TeamWebhookNotification struct {
+	Event    atc.TeamWebhookEvent
+	Webhooks []atc.TeamWebhook
+}
+
+//counterfeiter:generate . TeamWebhookEventQueue
+type TeamWebhookEventQueue interface {
+	Dequeue(limit int) ([]TeamWebhookNotification, error)
+}
+
+func NewTeamWebhookEventQueue(conn Conn) TeamWebhookEventQueue {
+	return &teamWebhookEventQueue{conn: conn}
+}
+
+type teamWebhookEventQueue struct {
+	conn Conn
+}
+
+// Dequeue removes up to limit events from the queue, oldest first. Events
+// are delivered at most once: if the ATC goes away before sending them, they
+// are lost.
+func (q *teamWebhookEventQueue) Dequeue(limit int) ([]TeamWebhookNotification, error) {
+	tx, err := q.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := tx.Query(`
+		DELETE FROM team_webhook_events
+		WHERE id IN (
+			SELECT id FROM team_webhook_events
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING team_id, (SELECT name FROM teams WHERE id = team_id), payload
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	type queuedEvent struct {
+		teamID int
+		event  atc.TeamWebhookEvent
+	}
+
+	var queued []queuedEvent
+	for rows.Next() {
+		var e queuedEvent
+		var teamName string
+		var payload []byte
+
+		err = rows.Scan(&e.teamID, &teamName, &payload)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(payload, &e.event)
+		if err != nil {
+			return nil, err
+		}
+
+		e.event.TeamName = teamName
+
+		queued = append(queued, e)
+	}
+
+	webhooksByTeam := map[int][]atc.TeamWebhook{}
+	notifications := []TeamWebhookNotification{}
+	for _, e := range queued {
+		webhooks, found := webhooksByTeam[e.teamID]
+		if !found {
+			webhooks, err = teamWebhooks(tx, q.conn, e.teamID)
+			if err != nil {
+				return nil, err
+			}
+
+			webhooksByTeam[e.teamID] = webhooks
+		}
+
+		notifications = append(notifications, TeamWebhookNotification{
+			Event:    e.event,
+			Webhooks: webhooks,
+		})
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func teamWebhooks(runner sq.BaseRunner, conn Conn, teamID int) ([]atc.TeamWebhook, error) {
+	rows, err := psql.Select("config", "nonce").
+		From("team_webhooks").
+		Where(sq.Eq{"team_id": teamID}).
+		OrderBy("name").
+		RunWith(runner).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var webhooks []atc.TeamWebhook
+	for rows.Next() {
+		webhook, err := scanTeamWebhook(conn, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
@@ -78,6 +79,7 @@ type creatingVolume struct {
 	workerTaskCacheID        int
 	workerResourceCertsID    int
 	workerArtifactID         int
+	createdAt                time.Time
 	conn                     Conn
 }
 
@@ -113,6 +115,7 @@ func (volume *creatingVolume) Created() (CreatedVolume, error) {
 		workerBaseResourceTypeID: volume.workerBaseResourceTypeID,
 		workerTaskCacheID:        volume.workerTaskCacheID,
 		workerResourceCertsID:    volume.workerResourceCertsID,
+		createdAt:                volume.createdAt,
 	}, nil
 }
 
@@ -161,6 +164,11 @@ type CreatedVolume interface {
 	ResourceType() (*VolumeResourceType, error)
 	BaseResourceType() (*UsedWorkerBaseResourceType, error)
 	TaskIdentifier() (int, atc.PipelineRef, string, string, error)
+	CreatedAt() time.Time
+
+	// SizeBytes is the size last reported by the volume's worker, or 0 if it
+	// hasn't been reported yet.
+	SizeBytes() uint64
 }
 
 type createdVolume struct {
@@ -177,6 +185,8 @@ type createdVolume struct {
 	workerTaskCacheID        int
 	workerResourceCertsID    int
 	workerArtifactID         int
+	createdAt                time.Time
+	sizeBytes                uint64
 	conn                     Conn
 }
 
@@ -194,6 +204,8 @@ func (volume *createdVolume) TeamID() int             { return volume.teamID }
 func (volume *createdVolume) ContainerHandle() string { return volume.containerHandle }
 func (volume *createdVolume) ParentHandle() string    { return volume.parentHandle }
 func (volume *createdVolume) WorkerArtifactID() int   { return volume.workerArtifactID }
+func (volume *createdVolume) CreatedAt() time.Time    { return volume.createdAt }
+func (volume *createdVolume) SizeBytes() uint64       { return volume.sizeBytes }
 
 func (volume *createdVolume) ResourceType() (*VolumeResourceType, error) {
 	if volume.resourceCacheID == 0 {
@@ -43,6 +43,8 @@ type VolumeRepository interface {
 	RemoveMissingVolumes(gracePeriod time.Duration) (removed int, err error)
 
 	DestroyUnknownVolumes(workerName string, handles []string) (int, error)
+
+	CountVolumesByType(workerName string) (map[VolumeType]int, error)
 }
 
 const noTeam = 0
@@ -467,6 +469,18 @@ func (repository *volumeRepository) FindCreatedVolume(handle string) (CreatedVol
 }
 
 // GetOrphanedVolumes returns all volumes that not used by Concourse artifacts such as containers and caches and has no child volume.
+// orphanedVolumes matches volumes (v) which have no owner and no child
+// volumes (cv).
+var orphanedVolumes = sq.Eq{
+	"cv.id":                          nil,
+	"v.worker_resource_cache_id":     nil,
+	"v.worker_base_resource_type_id": nil,
+	"v.container_id":                 nil,
+	"v.worker_task_cache_id":         nil,
+	"v.worker_resource_certs_id":     nil,
+	"v.worker_artifact_id":           nil,
+}
+
 func (repository *volumeRepository) GetOrphanedVolumes() ([]CreatedVolume, error) {
 	query, args, err := psql.Select(volumeColumns...).
 		From("volumes v").
@@ -475,17 +489,7 @@ func (repository *volumeRepository) GetOrphanedVolumes() ([]CreatedVolume, error
 		LeftJoin("volumes pv ON v.parent_id = pv.id").
 		LeftJoin("volumes cv ON cv.parent_id = v.id").
 		LeftJoin("worker_resource_caches wrc ON wrc.id = v.worker_resource_cache_id").
-		Where(
-			sq.Eq{
-				"cv.id":                          nil,
-				"v.worker_resource_cache_id":     nil,
-				"v.worker_base_resource_type_id": nil,
-				"v.container_id":                 nil,
-				"v.worker_task_cache_id":         nil,
-				"v.worker_resource_certs_id":     nil,
-				"v.worker_artifact_id":           nil,
-			},
-		).
+		Where(orphanedVolumes).
 		Where(sq.Eq{"v.state": string(VolumeStateCreated)}).
 		Where(sq.Or{
 			sq.Eq{"w.state": string(WorkerStateRunning)},
@@ -626,6 +630,48 @@ func (repository *volumeRepository) DestroyUnknownVolumes(workerName string, rep
 	return len(unknownHandles), nil
 }
 
+// CountVolumesByType returns the number of created volumes on the worker,
+// keyed by what each volume is used for.
+func (repository *volumeRepository) CountVolumesByType(workerName string) (map[VolumeType]int, error) {
+	rows, err := psql.Select(volumeTypeColumn, "COUNT(*)").
+		From("volumes v").
+		Where(sq.Eq{
+			"v.worker_name": workerName,
+			"v.state":       VolumeStateCreated,
+		}).
+		GroupBy("1").
+		RunWith(repository.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	// report every type so that counts drop back to zero
+	counts := map[VolumeType]int{
+		VolumeTypeContainer:     0,
+		VolumeTypeResource:      0,
+		VolumeTypeResourceType:  0,
+		VolumeTypeResourceCerts: 0,
+		VolumeTypeTaskCache:     0,
+		VolumeTypeArtifact:      0,
+		VolumeTypeUknown:        0,
+	}
+	for rows.Next() {
+		var typ string
+		var count int
+		err = rows.Scan(&typ, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		counts[VolumeType(typ)] = count
+	}
+
+	return counts, nil
+}
+
 // 1. open tx
 // 2. lookup worker resource type id
 //   * if not found, fail; worker must have new version or no longer supports type
@@ -731,7 +777,12 @@ var volumeColumns = []string{
 	"v.worker_task_cache_id",
 	"v.worker_resource_certs_id",
 	"v.worker_artifact_id",
-	`case
+	"v.created_at",
+	"v.size_bytes",
+	volumeTypeColumn,
+}
+
+const volumeTypeColumn = `case
 	when v.worker_base_resource_type_id is not NULL then 'resource-type'
 	when v.worker_resource_cache_id is not NULL then 'resource'
 	when v.container_id is not NULL then 'container'
@@ -739,8 +790,7 @@ var volumeColumns = []string{
 	when v.worker_resource_certs_id is not NULL then 'resource-certs'
 	when v.worker_artifact_id is not NULL then 'artifact'
 	else 'unknown'
-end`,
-}
+end`
 
 func scanVolume(row sq.RowScanner, conn Conn) (CreatingVolume, CreatedVolume, DestroyingVolume, FailedVolume, error) {
 	var id int
@@ -756,6 +806,8 @@ func scanVolume(row sq.RowScanner, conn Conn) (CreatingVolume, CreatedVolume, De
 	var sqWorkerTaskCacheID sql.NullInt64
 	var sqWorkerResourceCertsID sql.NullInt64
 	var sqWorkerArtifactID sql.NullInt64
+	var createdAt time.Time
+	var sizeBytes uint64
 	var volumeType VolumeType
 
 	err := row.Scan(
@@ -772,6 +824,8 @@ func scanVolume(row sq.RowScanner, conn Conn) (CreatingVolume, CreatedVolume, De
 		&sqWorkerTaskCacheID,
 		&sqWorkerResourceCertsID,
 		&sqWorkerArtifactID,
+		&createdAt,
+		&sizeBytes,
 		&volumeType,
 	)
 	if err != nil {
@@ -839,6 +893,8 @@ func scanVolume(row sq.RowScanner, conn Conn) (CreatingVolume, CreatedVolume, De
 			workerTaskCacheID:        workerTaskCacheID,
 			workerResourceCertsID:    workerResourceCertsID,
 			workerArtifactID:         workerArtifactID,
+			createdAt:                createdAt,
+			sizeBytes:                sizeBytes,
 			conn:                     conn,
 		}, nil, nil, nil
 	case VolumeStateCreating:
@@ -856,6 +912,7 @@ func scanVolume(row sq.RowScanner, conn Conn) (CreatingVolume, CreatedVolume, De
 			workerTaskCacheID:        workerTaskCacheID,
 			workerResourceCertsID:    workerResourceCertsID,
 			workerArtifactID:         workerArtifactID,
+			createdAt:                createdAt,
 			conn:                     conn,
 		}, nil, nil, nil, nil
 	case VolumeStateDestroying:
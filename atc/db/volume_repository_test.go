@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"fmt"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -1070,4 +1071,50 @@ var _ = Describe("VolumeRepository", func() {
 			})
 		})
 	})
+	Describe("CountVolumesByType", func() {
+		BeforeEach(func() {
+			for i, state := range []db.VolumeState{db.VolumeStateCreated, db.VolumeStateCreated, db.VolumeStateDestroying} {
+				_, err := psql.Insert("volumes").SetMap(map[string]interface{}{
+					"state":       state,
+					"handle":      fmt.Sprintf("some-handle%d", i),
+					"worker_name": defaultWorker.Name(),
+				}).RunWith(dbConn).Exec()
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{
+				Type:     "task",
+				StepName: "some-task",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, "some-path")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts the worker's created volumes by type", func() {
+			counts, err := volumeRepository.CountVolumesByType(defaultWorker.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(counts).To(Equal(map[db.VolumeType]int{
+				db.VolumeTypeContainer:     1,
+				db.VolumeTypeResource:      0,
+				db.VolumeTypeResourceType:  0,
+				db.VolumeTypeResourceCerts: 0,
+				db.VolumeTypeTaskCache:     0,
+				db.VolumeTypeArtifact:      0,
+				db.VolumeTypeUknown:        2,
+			}))
+		})
+
+		It("does not count volumes on other workers", func() {
+			counts, err := volumeRepository.CountVolumesByType("some-other-worker")
+			Expect(err).ToNot(HaveOccurred())
+			for _, count := range counts {
+				Expect(count).To(BeZero())
+			}
+		})
+	})
 })
@@ -270,6 +270,31 @@ var _ = Describe("Volume", func() {
 			})
 		})
 
+		Context("when the resource cache is invalidated", func() {
+			BeforeEach(func() {
+				err := resourceCacheFactory.InvalidateResourceCache(resourceCache)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("no longer finds the volume for the resource cache", func() {
+				_, found, err := volumeRepository.FindResourceCacheVolume(scenario.Workers[0].Name(), resourceCache)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("allows another volume to become the resource cache", func() {
+				freshVolume := volumeOnWorker(scenario.Workers[0])
+				err := freshVolume.InitializeResourceCache(resourceCache)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(freshVolume.Type()).To(Equal(db.VolumeTypeResource))
+
+				foundVolume, found, err := volumeRepository.FindResourceCacheVolume(scenario.Workers[0].Name(), resourceCache)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundVolume.Handle()).To(Equal(freshVolume.Handle()))
+			})
+		})
+
 		Context("when the same resource cache is initialized from another source worker", func() {
 			It("leaves the volume owned by the container", func() {
 				scenario.Run(builder.WithBaseWorker())
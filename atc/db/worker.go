@@ -60,8 +60,12 @@ type Worker interface {
 	NoProxy() string
 	ActiveContainers() int
 	ActiveVolumes() int
+	CPUCapacity() uint64
+	MemoryCapacity() uint64
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
+	Arch() string
+	Runtime() string
 	Tags() []string
 	TeamID() int
 	TeamName() string
@@ -80,6 +84,12 @@ type Worker interface {
 	IncreaseActiveTasks() (int, error)
 	DecreaseActiveTasks() (int, error)
 
+	ReservedResources() (uint64, uint64, error)
+	ReserveResources(buildID int, planID atc.PlanID, cpu uint64, memory uint64) (bool, error)
+	ReleaseResources(buildID int, planID atc.PlanID) error
+
+	UpdateSizes(containerBytes map[string]uint64, volumeBytes map[string]uint64) error
+
 	FindContainer(owner ContainerOwner) (CreatingContainer, CreatedContainer, error)
 	CreateContainer(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
 }
@@ -98,8 +108,12 @@ type worker struct {
 	activeContainers int
 	activeVolumes    int
 	activeTasks      int
+	cpuCapacity      uint64
+	memoryCapacity   uint64
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
+	arch             string
+	runtime          string
 	tags             []string
 	teamID           int
 	teamName         string
@@ -121,8 +135,12 @@ func (worker *worker) HTTPSProxyURL() string                   { return worker.h
 func (worker *worker) NoProxy() string                         { return worker.noProxy }
 func (worker *worker) ActiveContainers() int                   { return worker.activeContainers }
 func (worker *worker) ActiveVolumes() int                      { return worker.activeVolumes }
+func (worker *worker) CPUCapacity() uint64                     { return worker.cpuCapacity }
+func (worker *worker) MemoryCapacity() uint64                  { return worker.memoryCapacity }
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
+func (worker *worker) Arch() string                            { return worker.arch }
+func (worker *worker) Runtime() string                         { return worker.runtime }
 func (worker *worker) Tags() []string                          { return worker.tags }
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
@@ -298,7 +316,8 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 	}
 
 	var containerID int
-	cols := []interface{}{&containerID}
+	var createdAt time.Time
+	cols := []interface{}{&containerID, &createdAt}
 
 	metadata := &ContainerMetadata{}
 	cols = append(cols, metadata.ScanTargets()...)
@@ -325,7 +344,7 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 
 	err = psql.Insert("containers").
 		SetMap(insMap).
-		Suffix("RETURNING id, " + strings.Join(containerMetadataColumns, ", ")).
+		Suffix("RETURNING id, created_at, " + strings.Join(containerMetadataColumns, ", ")).
 		RunWith(tx).
 		QueryRow().
 		Scan(cols...)
@@ -347,6 +366,7 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 		handle.String(),
 		worker.name,
 		*metadata,
+		createdAt,
 		worker.conn,
 	), nil
 }
@@ -411,3 +431,148 @@ func (worker *worker) DecreaseActiveTasks() (int, error) {
 	}
 	return worker.activeTasks, nil
 }
+
+// ReservedResources returns the CPU shares and bytes of memory currently
+// reserved on the worker. Reservations of builds which have completed no
+// longer count, so that resources are not reserved forever by a build that
+// was never cleaned up after, e.g. because the web node running it crashed.
+func (worker *worker) ReservedResources() (uint64, uint64, error) {
+	return reservedResources(worker.conn, worker.name, 0, "")
+}
+
+// ReserveResources reserves the given CPU shares and bytes of memory on the
+// worker for the step, unless that would exceed its capacity. It returns
+// false if the resources could not be reserved.
+//
+// Reserving resources for a step which already has a reservation on the
+// worker replaces it.
+func (worker *worker) ReserveResources(buildID int, planID atc.PlanID, cpu uint64, memory uint64) (bool, error) {
+	tx, err := worker.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	// lock the worker so that concurrent reservations see each other
+	var cpuCapacity, memoryCapacity uint64
+	err = psql.Select("cpu_capacity", "memory_capacity").
+		From("workers").
+		Where(sq.Eq{"name": worker.name}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&cpuCapacity, &memoryCapacity)
+	if err != nil {
+		return false, err
+	}
+
+	reservedCPU, reservedMemory, err := reservedResources(tx, worker.name, buildID, planID)
+	if err != nil {
+		return false, err
+	}
+
+	if (cpuCapacity != 0 && reservedCPU+cpu > cpuCapacity) ||
+		(memoryCapacity != 0 && reservedMemory+memory > memoryCapacity) {
+		return false, nil
+	}
+
+	_, err = psql.Insert("worker_resource_reservations").
+		Columns("worker_name", "build_id", "plan_id", "cpu", "memory").
+		Values(worker.name, buildID, string(planID), cpu, memory).
+		Suffix("ON CONFLICT (worker_name, build_id, plan_id) DO UPDATE SET cpu = EXCLUDED.cpu, memory = EXCLUDED.memory").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReleaseResources releases the step's reservation on the worker. Releasing
+// resources which the step has not reserved does nothing.
+func (worker *worker) ReleaseResources(buildID int, planID atc.PlanID) error {
+	_, err := psql.Delete("worker_resource_reservations").
+		Where(sq.Eq{
+			"worker_name": worker.name,
+			"build_id":    buildID,
+			"plan_id":     string(planID),
+		}).
+		RunWith(worker.conn).
+		Exec()
+	return err
+}
+
+// reservedResources sums the reservations of running builds on the worker,
+// leaving out the given step's own reservation.
+func reservedResources(runner sq.BaseRunner, workerName string, buildID int, planID atc.PlanID) (uint64, uint64, error) {
+	var cpu, memory uint64
+	err := psql.Select("COALESCE(SUM(r.cpu), 0)", "COALESCE(SUM(r.memory), 0)").
+		From("worker_resource_reservations r").
+		Join("builds b ON b.id = r.build_id").
+		Where(sq.Eq{
+			"r.worker_name": workerName,
+			"b.completed":   false,
+		}).
+		Where(sq.Expr("NOT (r.build_id = ? AND r.plan_id = ?)", buildID, string(planID))).
+		RunWith(runner).
+		QueryRow().
+		Scan(&cpu, &memory)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return cpu, memory, nil
+}
+
+// UpdateSizes records the sizes reported by the worker for its containers
+// and volumes, keyed by handle. Handles the worker doesn't know about are
+// ignored.
+func (worker *worker) UpdateSizes(containerBytes map[string]uint64, volumeBytes map[string]uint64) error {
+	tx, err := worker.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = updateSizes(tx, "containers", worker.name, containerBytes)
+	if err != nil {
+		return err
+	}
+
+	err = updateSizes(tx, "volumes", worker.name, volumeBytes)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func updateSizes(tx Tx, table string, workerName string, sizes map[string]uint64) error {
+	if len(sizes) == 0 {
+		return nil
+	}
+
+	handles := make([]string, 0, len(sizes))
+	bytes := make([]int64, 0, len(sizes))
+	for handle, size := range sizes {
+		handles = append(handles, handle)
+		bytes = append(bytes, int64(size))
+	}
+
+	_, err := tx.Exec(`
+		UPDATE `+table+` t
+		SET size_bytes = s.size_bytes
+		FROM unnest($1::text[], $2::bigint[]) AS s(handle, size_bytes)
+		WHERE t.handle = s.handle
+		AND t.worker_name = $3
+	`, pq.Array(handles), pq.Array(bytes), workerName)
+	return err
+}
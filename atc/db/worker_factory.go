@@ -46,8 +46,12 @@ var workersQuery = psql.Select(`
 		w.no_proxy,
 		w.active_containers,
 		w.active_volumes,
+		w.cpu_capacity,
+		w.memory_capacity,
 		w.resource_types,
 		w.platform,
+		w.arch,
+		w.runtime,
 		w.tags,
 		t.name,
 		w.team_id,
@@ -133,6 +137,8 @@ func scanWorker(worker *worker, row scannable) error {
 		noProxy       sql.NullString
 		resourceTypes []byte
 		platform      sql.NullString
+		arch          sql.NullString
+		runtime       sql.NullString
 		tags          []byte
 		teamName      sql.NullString
 		teamID        sql.NullInt64
@@ -153,8 +159,12 @@ func scanWorker(worker *worker, row scannable) error {
 		&noProxy,
 		&worker.activeContainers,
 		&worker.activeVolumes,
+		&worker.cpuCapacity,
+		&worker.memoryCapacity,
 		&resourceTypes,
 		&platform,
+		&arch,
+		&runtime,
 		&tags,
 		&teamName,
 		&teamID,
@@ -210,6 +220,14 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.platform = platform.String
 	}
 
+	if arch.Valid {
+		worker.arch = arch.String
+	}
+
+	if runtime.Valid {
+		worker.runtime = runtime.String
+	}
+
 	if ephemeral.Valid {
 		worker.ephemeral = ephemeral.Bool
 	}
@@ -397,9 +415,13 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		atcWorker.GardenAddr,
 		atcWorker.ActiveContainers,
 		atcWorker.ActiveVolumes,
+		atcWorker.CPUCapacity,
+		atcWorker.MemoryCapacity,
 		resourceTypes,
 		tags,
 		atcWorker.Platform,
+		atcWorker.Arch,
+		atcWorker.Runtime,
 		atcWorker.BaggageclaimURL,
 		atcWorker.CertsPath,
 		atcWorker.HTTPProxyURL,
@@ -428,9 +450,13 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"addr",
 			"active_containers",
 			"active_volumes",
+			"cpu_capacity",
+			"memory_capacity",
 			"resource_types",
 			"tags",
 			"platform",
+			"arch",
+			"runtime",
 			"baggageclaim_url",
 			"certs_path",
 			"http_proxy_url",
@@ -453,9 +479,13 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				addr = ?,
 				active_containers = ?,
 				active_volumes = ?,
+				cpu_capacity = ?,
+				memory_capacity = ?,
 				resource_types = ?,
 				tags = ?,
 				platform = ?,
+				arch = ?,
+				runtime = ?,
 				baggageclaim_url = ?,
 				certs_path = ?,
 				http_proxy_url = ?,
@@ -501,8 +531,12 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		noProxy:          atcWorker.NoProxy,
 		activeContainers: atcWorker.ActiveContainers,
 		activeVolumes:    atcWorker.ActiveVolumes,
+		cpuCapacity:      atcWorker.CPUCapacity,
+		memoryCapacity:   atcWorker.MemoryCapacity,
 		resourceTypes:    atcWorker.ResourceTypes,
 		platform:         atcWorker.Platform,
+		arch:             atcWorker.Arch,
+		runtime:          atcWorker.Runtime,
 		tags:             atcWorker.Tags,
 		teamName:         atcWorker.Team,
 		teamID:           workerTeamID,
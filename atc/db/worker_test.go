@@ -441,4 +441,207 @@ var _ = Describe("Worker", func() {
 			})
 		})
 	})
+
+	Describe("Reserved resources", func() {
+		BeforeEach(func() {
+			atcWorker.CPUCapacity = 1024
+			atcWorker.MemoryCapacity = 4096
+
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("has the capacity the worker registered with", func() {
+			Expect(worker.CPUCapacity()).To(Equal(uint64(1024)))
+			Expect(worker.MemoryCapacity()).To(Equal(uint64(4096)))
+
+			found, err := worker.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(worker.CPUCapacity()).To(Equal(uint64(1024)))
+			Expect(worker.MemoryCapacity()).To(Equal(uint64(4096)))
+		})
+
+		Context("when the worker registers", func() {
+			It("has nothing reserved", func() {
+				cpu, memory, err := worker.ReservedResources()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cpu).To(BeZero())
+				Expect(memory).To(BeZero())
+			})
+		})
+
+		Context("when resources are reserved", func() {
+			var build Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				reserved, err := worker.ReserveResources(build.ID(), "some-plan", 512, 3072)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reserved).To(BeTrue())
+			})
+
+			It("adds them to the reserved resources", func() {
+				cpu, memory, err := worker.ReservedResources()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cpu).To(Equal(uint64(512)))
+				Expect(memory).To(Equal(uint64(3072)))
+			})
+
+			It("does not reserve more than the capacity", func() {
+				reserved, err := worker.ReserveResources(build.ID(), "other-plan", 512, 2048)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reserved).To(BeFalse())
+
+				cpu, memory, err := worker.ReservedResources()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cpu).To(Equal(uint64(512)))
+				Expect(memory).To(Equal(uint64(3072)))
+			})
+
+			It("replaces the reservation when the step reserves them again", func() {
+				reserved, err := worker.ReserveResources(build.ID(), "some-plan", 1024, 4096)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reserved).To(BeTrue())
+
+				cpu, memory, err := worker.ReservedResources()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cpu).To(Equal(uint64(1024)))
+				Expect(memory).To(Equal(uint64(4096)))
+			})
+
+			Context("when they are released", func() {
+				BeforeEach(func() {
+					err := worker.ReleaseResources(build.ID(), "some-plan")
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("leaves nothing reserved", func() {
+					cpu, memory, err := worker.ReservedResources()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(cpu).To(BeZero())
+					Expect(memory).To(BeZero())
+				})
+			})
+
+			Context("when the build completes without releasing them", func() {
+				BeforeEach(func() {
+					err := build.Finish(BuildStatusErrored)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("no longer counts them", func() {
+					cpu, memory, err := worker.ReservedResources()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(cpu).To(BeZero())
+					Expect(memory).To(BeZero())
+
+					otherBuild, err := defaultTeam.CreateOneOffBuild()
+					Expect(err).ToNot(HaveOccurred())
+
+					reserved, err := worker.ReserveResources(otherBuild.ID(), "some-plan", 1024, 4096)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(reserved).To(BeTrue())
+				})
+			})
+
+			Context("when the worker is deleted", func() {
+				BeforeEach(func() {
+					err := worker.Delete()
+					Expect(err).ToNot(HaveOccurred())
+
+					worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("drops them", func() {
+					cpu, memory, err := worker.ReservedResources()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(cpu).To(BeZero())
+					Expect(memory).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the worker's capacity is unknown", func() {
+			BeforeEach(func() {
+				atcWorker.CPUCapacity = 0
+				atcWorker.MemoryCapacity = 0
+
+				var err error
+				worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reserves any amount", func() {
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				reserved, err := worker.ReserveResources(build.ID(), "some-plan", 1<<40, 1<<40)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reserved).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("UpdateSizes", func() {
+		var (
+			owner            ContainerOwner
+			createdContainer CreatedContainer
+			createdVolume    CreatedVolume
+		)
+
+		BeforeEach(func() {
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			owner = NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID())
+
+			creatingContainer, err := defaultWorker.CreateContainer(owner, ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+
+			createdContainer, err = creatingContainer.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateVolume(defaultTeam.ID(), defaultWorker.Name(), VolumeTypeArtifact)
+			Expect(err).ToNot(HaveOccurred())
+
+			createdVolume, err = creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("records the sizes of the worker's containers and volumes", func() {
+			err := defaultWorker.UpdateSizes(
+				map[string]uint64{createdContainer.Handle(): 1024},
+				map[string]uint64{createdVolume.Handle(): 2048},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, foundContainer, err := defaultWorker.FindContainer(owner)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foundContainer.SizeBytes()).To(Equal(uint64(1024)))
+
+			foundVolume, found, err := volumeRepository.FindCreatedVolume(createdVolume.Handle())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundVolume.SizeBytes()).To(Equal(uint64(2048)))
+		})
+
+		It("ignores handles on other workers", func() {
+			err := otherWorker.UpdateSizes(
+				map[string]uint64{createdContainer.Handle(): 1024},
+				map[string]uint64{createdVolume.Handle(): 2048},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			foundVolume, found, err := volumeRepository.FindCreatedVolume(createdVolume.Handle())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundVolume.SizeBytes()).To(BeZero())
+		})
+	})
 })
@@ -2,9 +2,12 @@ package engine
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,6 +22,7 @@ import (
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -31,6 +35,7 @@ type buildStepDelegate struct {
 	stdout          io.Writer
 	policyChecker   policy.Checker
 	artifactSourcer worker.ArtifactSourcer
+	imageFetches    *ImageFetches
 }
 
 func NewBuildStepDelegate(
@@ -40,6 +45,7 @@ func NewBuildStepDelegate(
 	clock clock.Clock,
 	policyChecker policy.Checker,
 	artifactSourcer worker.ArtifactSourcer,
+	imageFetches *ImageFetches,
 ) *buildStepDelegate {
 	return &buildStepDelegate{
 		build:           build,
@@ -50,6 +56,7 @@ func NewBuildStepDelegate(
 		stderr:          nil,
 		policyChecker:   policyChecker,
 		artifactSourcer: artifactSourcer,
+		imageFetches:    imageFetches,
 	}
 }
 
@@ -58,7 +65,7 @@ func (delegate *buildStepDelegate) StartSpan(
 	component string,
 	extraAttrs tracing.Attrs,
 ) (context.Context, trace.Span) {
-	attrs := delegate.build.TracingAttrs()
+	attrs := spanAttrs(delegate.build)
 	for k, v := range extraAttrs {
 		attrs[k] = v
 	}
@@ -71,13 +78,52 @@ type credVarsIterator struct {
 }
 
 func (it *credVarsIterator) YieldCred(name, value string) {
-	for _, lineValue := range strings.Split(value, "\n") {
-		lineValue = strings.TrimSpace(lineValue)
+	for _, redactable := range redactableValues(value) {
+		it.line = strings.Replace(it.line, redactable, "((redacted))", -1)
+	}
+}
+
+// redactableValues returns the forms in which a secret may show up in build
+// output: each of its lines, as a multiline secret is printed one line at a
+// time, and its base64 and URL encodings. They are ordered longest first so
+// that no form is partially redacted by a shorter one.
+func redactableValues(value string) []string {
+	seen := map[string]bool{}
+	var values []string
+	add := func(v string) {
 		// Don't consider a single char as a secret.
-		if len(lineValue) > 1 {
-			it.line = strings.Replace(it.line, lineValue, "((redacted))", -1)
+		if len(v) > 1 && !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+
+	for _, lineValue := range strings.Split(value, "\n") {
+		add(strings.TrimSpace(lineValue))
+	}
+
+	// files are often stored with a trailing newline, which may or may not
+	// have been encoded along with the secret
+	for _, whole := range []string{value, strings.TrimSpace(value)} {
+		if len(whole) <= 1 {
+			continue
+		}
+
+		for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding} {
+			encoded := encoding.EncodeToString([]byte(whole))
+			add(encoded)
+			add(strings.TrimRight(encoded, "="))
 		}
+
+		add(url.QueryEscape(whole))
+		add(url.PathEscape(whole))
 	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	return values
 }
 
 func (delegate *buildStepDelegate) Stdout() io.Writer {
@@ -169,6 +215,8 @@ func (delegate *buildStepDelegate) Finished(logger lager.Logger, succeeded bool)
 	delegate.Stdout().(io.Closer).Close()
 	delegate.Stderr().(io.Closer).Close()
 
+	storeStepStatus(delegate.state, delegate.planID, succeeded)
+
 	err := delegate.build.SaveEvent(event.Finish{
 		Origin: event.Origin{
 			ID: event.OriginID(delegate.planID),
@@ -184,6 +232,16 @@ func (delegate *buildStepDelegate) Finished(logger lager.Logger, succeeded bool)
 	logger.Info("finished")
 }
 
+// storeStepStatus records the status of a finished step, for `when:`
+// conditions which refer to it.
+func storeStepStatus(state exec.RunState, planID atc.PlanID, succeeded bool) {
+	if succeeded {
+		state.StoreStepStatus(planID, atc.StatusSucceeded)
+	} else {
+		state.StoreStepStatus(planID, atc.StatusFailed)
+	}
+}
+
 func (delegate *buildStepDelegate) WaitingForWorker(logger lager.Logger) {
 	err := delegate.build.SaveEvent(event.WaitingForWorker{
 		Time: time.Now().Unix(),
@@ -212,7 +270,85 @@ func (delegate *buildStepDelegate) SelectedWorker(logger lager.Logger, worker st
 	}
 }
 
+func (delegate *buildStepDelegate) WaitingToRetry(logger lager.Logger, attempt int, delay time.Duration) {
+	err := delegate.build.SaveEvent(event.WaitingToRetry{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Attempt: attempt,
+		Delay:   delay.String(),
+	})
+
+	if err != nil {
+		logger.Error("failed-to-save-waiting-to-retry-event", err)
+		return
+	}
+}
+
+func (delegate *buildStepDelegate) WaitingForSemaphore(logger lager.Logger, name string, limit int) {
+	err := delegate.build.SaveEvent(event.WaitingForSemaphore{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Name:  name,
+		Limit: limit,
+	})
+
+	if err != nil {
+		logger.Error("failed-to-save-waiting-for-semaphore-event", err)
+		return
+	}
+}
+
+func (delegate *buildStepDelegate) Skipped(logger lager.Logger, condition string) {
+	err := delegate.build.SaveEvent(event.Skipped{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Condition: condition,
+	})
+
+	if err != nil {
+		logger.Error("failed-to-save-skipped-event", err)
+		return
+	}
+}
+
+func (delegate *buildStepDelegate) SoftTimedOut(logger lager.Logger, duration time.Duration) {
+	err := delegate.build.SaveEvent(event.SoftTimeout{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Duration: duration.String(),
+	})
+
+	if err != nil {
+		logger.Error("failed-to-save-soft-timeout-event", err)
+		return
+	}
+}
+
+func (delegate *buildStepDelegate) UpdatePlan(logger lager.Logger, plan atc.Plan) {
+	plan.ID = delegate.planID
+
+	err := delegate.build.UpdatePlan(plan)
+	if err != nil {
+		logger.Error("failed-to-update-plan", err)
+		return
+	}
+}
+
 func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string) {
+	if message == exec.AbortedLogMessage {
+		delegate.state.StoreStepStatus(delegate.planID, atc.StatusAborted)
+	} else {
+		delegate.state.StoreStepStatus(delegate.planID, atc.StatusErrored)
+	}
+
 	err := delegate.build.SaveEvent(event.Error{
 		Message: message,
 		Origin: event.Origin{
@@ -240,6 +376,22 @@ func (delegate *buildStepDelegate) FetchImage(
 		return worker.ImageSpec{}, err
 	}
 
+	key, err := imageFetchKey(image, types, privileged)
+	if err != nil {
+		return worker.ImageSpec{}, fmt.Errorf("image fetch key: %w", err)
+	}
+
+	return delegate.imageFetches.Fetch(ctx, key, func() (worker.ImageSpec, error) {
+		return delegate.fetchImage(ctx, image, types, privileged)
+	})
+}
+
+func (delegate *buildStepDelegate) fetchImage(
+	ctx context.Context,
+	image atc.ImageResource,
+	types atc.VersionedResourceTypes,
+	privileged bool,
+) (worker.ImageSpec, error) {
 	fetchState := delegate.state.NewLocalScope()
 
 	imageName := defaultImageName
@@ -289,6 +441,11 @@ func (delegate *buildStepDelegate) FetchImage(
 		}
 	}
 
+	err := delegate.checkImageFetchPolicy(image, version, privileged)
+	if err != nil {
+		return worker.ImageSpec{}, err
+	}
+
 	getID := delegate.planID + "/image-get"
 
 	getPlan := atc.Plan{
@@ -346,9 +503,17 @@ func (delegate *buildStepDelegate) FetchImage(
 		return worker.ImageSpec{}, fmt.Errorf("wire image: %w", err)
 	}
 
+	imageAttrs := []attribute.KeyValue{attribute.String("image.type", image.Type)}
+	if digest, ok := version["digest"]; ok {
+		imageAttrs = append(imageAttrs, attribute.String("image.digest", digest))
+	}
+
+	tracing.FromContext(ctx).SetAttributes(imageAttrs...)
+
 	return worker.ImageSpec{
 		ImageArtifactSource: source,
 		Privileged:          privileged,
+		ResourceCacheID:     cache.ID(),
 	}, nil
 }
 
@@ -382,9 +547,91 @@ func (delegate *buildStepDelegate) checkImagePolicy(image atc.ImageResource, pri
 		}
 	}
 
+	delegate.warnPolicy(result)
+
+	return nil
+}
+
+// checkImageFetchPolicy checks the image once its version is resolved, with
+// the repository qualified by its registry, so that policies can allow or
+// deny registries without having to know each resource type's defaults.
+func (delegate *buildStepDelegate) checkImageFetchPolicy(image atc.ImageResource, version atc.Version, privileged bool) error {
+	repository, ok := image.Source["repository"].(string)
+	if !ok || repository == "" {
+		return nil
+	}
+
+	if !delegate.policyChecker.ShouldCheckAction(policy.ActionFetchImage) {
+		return nil
+	}
+
+	redactedSource, err := delegate.redactImageSource(image.Source)
+	if err != nil {
+		return fmt.Errorf("redact source: %w", err)
+	}
+
+	registry, repository := qualifyImageRepository(delegate.buildOutputFilter(repository))
+
+	result, err := delegate.policyChecker.Check(policy.PolicyCheckInput{
+		Action:   policy.ActionFetchImage,
+		Team:     delegate.build.TeamName(),
+		Pipeline: delegate.build.PipelineName(),
+		Data: map[string]interface{}{
+			"image_type":    image.Type,
+			"image_source":  redactedSource,
+			"image_version": version,
+			"registry":      registry,
+			"repository":    repository,
+			"privileged":    privileged,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("perform check: %w", err)
+	}
+
+	if !result.Allowed {
+		return policy.PolicyCheckNotPass{
+			Reasons: result.Reasons,
+		}
+	}
+
+	delegate.warnPolicy(result)
+
 	return nil
 }
 
+// warnPolicy writes the warnings of rules in "warn" mode to the build log, so
+// that they are recorded with the build without failing it.
+func (delegate *buildStepDelegate) warnPolicy(result policy.PolicyCheckOutput) {
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(delegate.Stderr(), "\x1b[1;33mWARNING: policy check: %s\x1b[0m\n", warning)
+	}
+}
+
+const defaultImageRegistry = "docker.io"
+
+// qualifyImageRepository splits a repository into its registry and fully
+// qualified name, following the same rules as the Docker CLI, e.g.
+// 'busybox' is 'docker.io/library/busybox'.
+func qualifyImageRepository(repository string) (string, string) {
+	registry := defaultImageRegistry
+	path := repository
+
+	if i := strings.Index(repository, "/"); i != -1 {
+		host := repository[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			registry = host
+			path = repository[i+1:]
+		}
+	}
+
+	if registry == defaultImageRegistry && !strings.Contains(path, "/") {
+		path = "library/" + path
+	}
+
+	return registry, registry + "/" + path
+}
+
 func (delegate *buildStepDelegate) buildOutputFilter(str string) string {
 	it := &credVarsIterator{line: str}
 	delegate.state.IterateInterpolatedCreds(it)
@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/skymarshal/token"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// NewBuildTokenIssuer returns an issuer of tokens bound to a single build.
+// The token grants the given role on the build's pipeline only, and is only
+// accepted while the build is running. It also expires after the TTL in case
+// it is never revoked.
+func NewBuildTokenIssuer(
+	generator token.Generator,
+	accessTokenFactory db.AccessTokenFactory,
+	role string,
+	ttl time.Duration,
+) exec.BuildTokenIssuer {
+	return &buildTokenIssuer{
+		generator:          generator,
+		accessTokenFactory: accessTokenFactory,
+		role:               role,
+		ttl:                ttl,
+	}
+}
+
+type buildTokenIssuer struct {
+	generator          token.Generator
+	accessTokenFactory db.AccessTokenFactory
+	role               string
+	ttl                time.Duration
+}
+
+func (issuer *buildTokenIssuer) IssueBuildToken(metadata exec.StepMetadata) (string, error) {
+	if metadata.PipelineName == "" {
+		return "", errors.New("build tokens are only issued to pipeline builds")
+	}
+
+	claims := buildTokenClaims(metadata, issuer.role, time.Now(), issuer.ttl)
+
+	accessToken, err := issuer.generator.GenerateAccessToken(claims)
+	if err != nil {
+		return "", fmt.Errorf("generate access token: %w", err)
+	}
+
+	err = issuer.accessTokenFactory.CreateAccessToken(accessToken, claims)
+	if err != nil {
+		return "", fmt.Errorf("store access token: %w", err)
+	}
+
+	return accessToken, nil
+}
+
+func (issuer *buildTokenIssuer) RevokeBuildToken(accessToken string) error {
+	err := issuer.accessTokenFactory.DeleteAccessToken(accessToken)
+	if err != nil {
+		return fmt.Errorf("delete access token: %w", err)
+	}
+
+	return nil
+}
+
+func buildTokenClaims(metadata exec.StepMetadata, role string, now time.Time, ttl time.Duration) db.Claims {
+	buildID := strconv.Itoa(metadata.BuildID)
+	subject := fmt.Sprintf("%s:%s:%s", accessor.BuildTokenConnector, metadata.TeamName, buildID)
+	issuedAt := jwt.NewNumericDate(now)
+	expiry := jwt.NewNumericDate(now.Add(ttl))
+
+	binding := map[string]interface{}{
+		"team_id":  metadata.TeamID,
+		"team":     metadata.TeamName,
+		"pipeline": metadata.PipelineName,
+		"build_id": metadata.BuildID,
+		"role":     role,
+	}
+	if metadata.PipelineInstanceVars != nil {
+		binding["pipeline_instance_vars"] = metadata.PipelineInstanceVars
+	}
+
+	federatedClaims := db.FederatedClaims{
+		UserID:    metadata.TeamName + ":" + buildID,
+		Connector: accessor.BuildTokenConnector,
+	}
+
+	return db.Claims{
+		Claims: jwt.Claims{
+			Subject:  subject,
+			Audience: jwt.Audience{"fly"},
+			IssuedAt: issuedAt,
+			Expiry:   expiry,
+		},
+		FederatedClaims:   federatedClaims,
+		Username:          subject,
+		PreferredUsername: subject,
+		RawClaims: map[string]interface{}{
+			"sub":                subject,
+			"aud":                []string{"fly"},
+			"iat":                issuedAt,
+			"exp":                expiry,
+			"name":               subject,
+			"preferred_username": subject,
+			"federated_claims": map[string]interface{}{
+				"user_id":      federatedClaims.UserID,
+				"connector_id": federatedClaims.Connector,
+			},
+			accessor.BuildTokenClaim: binding,
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package engine_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/skymarshal/token/tokenfakes"
+)
+
+var _ = Describe("BuildTokenIssuer", func() {
+	var (
+		fakeGenerator          *tokenfakes.FakeGenerator
+		fakeAccessTokenFactory *dbfakes.FakeAccessTokenFactory
+
+		issuer exec.BuildTokenIssuer
+
+		buildToken string
+		err        error
+	)
+
+	BeforeEach(func() {
+		fakeGenerator = new(tokenfakes.FakeGenerator)
+		fakeGenerator.GenerateAccessTokenReturns("some-token", nil)
+
+		fakeAccessTokenFactory = new(dbfakes.FakeAccessTokenFactory)
+
+		issuer = engine.NewBuildTokenIssuer(fakeGenerator, fakeAccessTokenFactory, "viewer", time.Hour)
+	})
+
+	var metadata exec.StepMetadata
+
+	BeforeEach(func() {
+		metadata = exec.StepMetadata{
+			TeamID:       1,
+			TeamName:     "some-team",
+			PipelineID:   2,
+			PipelineName: "some-pipeline",
+			BuildID:      42,
+			BuildName:    "7",
+		}
+	})
+
+	JustBeforeEach(func() {
+		buildToken, err = issuer.IssueBuildToken(metadata)
+	})
+
+	It("returns the generated token", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildToken).To(Equal("some-token"))
+	})
+
+	It("binds the token to the build's pipeline and role", func() {
+		claims := fakeGenerator.GenerateAccessTokenArgsForCall(0)
+		Expect(claims.Subject).To(Equal("build:some-team:42"))
+		Expect(claims.Connector).To(Equal("build"))
+		Expect(claims.RawClaims["build"]).To(Equal(map[string]interface{}{
+			"team_id":  1,
+			"team":     "some-team",
+			"pipeline": "some-pipeline",
+			"build_id": 42,
+			"role":     "viewer",
+		}))
+		Expect(claims.Expiry.Time()).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+	})
+
+	Context("when the build belongs to an instanced pipeline", func() {
+		BeforeEach(func() {
+			metadata.PipelineInstanceVars = map[string]interface{}{"branch": "main"}
+		})
+
+		It("binds the token to that instance of the pipeline", func() {
+			claims := fakeGenerator.GenerateAccessTokenArgsForCall(0)
+			Expect(claims.RawClaims["build"]).To(HaveKeyWithValue(
+				"pipeline_instance_vars",
+				map[string]interface{}{"branch": "main"},
+			))
+		})
+	})
+
+	It("stores the token so that it can be verified", func() {
+		Expect(fakeAccessTokenFactory.CreateAccessTokenCallCount()).To(Equal(1))
+		storedToken, storedClaims := fakeAccessTokenFactory.CreateAccessTokenArgsForCall(0)
+		Expect(storedToken).To(Equal("some-token"))
+		Expect(storedClaims).To(Equal(fakeGenerator.GenerateAccessTokenArgsForCall(0)))
+	})
+
+	It("revokes the token by deleting it", func() {
+		Expect(issuer.RevokeBuildToken(buildToken)).To(Succeed())
+		Expect(fakeAccessTokenFactory.DeleteAccessTokenCallCount()).To(Equal(1))
+		Expect(fakeAccessTokenFactory.DeleteAccessTokenArgsForCall(0)).To(Equal("some-token"))
+	})
+
+	Context("when the build is not a pipeline build", func() {
+		BeforeEach(func() {
+			metadata.PipelineID = 0
+			metadata.PipelineName = ""
+		})
+
+		It("errors without issuing a token", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(fakeGenerator.GenerateAccessTokenCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when generating the token fails", func() {
+		BeforeEach(func() {
+			fakeGenerator.GenerateAccessTokenReturns("", errors.New("nope"))
+		})
+
+		It("errors without storing anything", func() {
+			Expect(err).To(MatchError(ContainSubstring("nope")))
+			Expect(fakeAccessTokenFactory.CreateAccessTokenCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when storing the token fails", func() {
+		BeforeEach(func() {
+			fakeAccessTokenFactory.CreateAccessTokenReturns(errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(err).To(MatchError(ContainSubstring("nope")))
+		})
+	})
+})
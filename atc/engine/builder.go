@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/exec"
@@ -24,6 +25,11 @@ type CoreStepFactory interface {
 	CheckStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, DelegateFactory) exec.Step
 	SetPipelineStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
 	LoadVarStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	LoadVarsStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	ApprovalStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	RunStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, DelegateFactory) exec.Step
+	PublishStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	DynamicAcrossStep(atc.Plan, exec.AcrossSubStepBuilder, exec.StepMetadata, DelegateFactory) exec.Step
 	ArtifactInputStep(atc.Plan, db.Build) exec.Step
 	ArtifactOutputStep(atc.Plan, db.Build) exec.Step
 }
@@ -41,26 +47,35 @@ func NewStepperFactory(
 	artifactSourcer worker.ArtifactSourcer,
 	dbWorkerFactory db.WorkerFactory,
 	lockFactory lock.LockFactory,
+	semaphoreFactory db.SemaphoreFactory,
+	auditSink auditor.Sink,
 ) StepperFactory {
 	return &stepperFactory{
-		coreFactory:     coreFactory,
-		externalURL:     externalURL,
-		rateLimiter:     rateLimiter,
-		policyChecker:   policyChecker,
-		artifactSourcer: artifactSourcer,
-		dbWorkerFactory: dbWorkerFactory,
-		lockFactory:     lockFactory,
+		coreFactory:      coreFactory,
+		externalURL:      externalURL,
+		rateLimiter:      rateLimiter,
+		policyChecker:    policyChecker,
+		artifactSourcer:  artifactSourcer,
+		dbWorkerFactory:  dbWorkerFactory,
+		lockFactory:      lockFactory,
+		semaphoreFactory: semaphoreFactory,
+		auditSink:        auditSink,
 	}
 }
 
 type stepperFactory struct {
-	coreFactory     CoreStepFactory
-	externalURL     string
-	rateLimiter     RateLimiter
-	policyChecker   policy.Checker
-	artifactSourcer worker.ArtifactSourcer
-	dbWorkerFactory db.WorkerFactory
-	lockFactory     lock.LockFactory
+	coreFactory      CoreStepFactory
+	externalURL      string
+	rateLimiter      RateLimiter
+	policyChecker    policy.Checker
+	artifactSourcer  worker.ArtifactSourcer
+	dbWorkerFactory  db.WorkerFactory
+	lockFactory      lock.LockFactory
+	semaphoreFactory db.SemaphoreFactory
+	auditSink        auditor.Sink
+
+	// set per build by StepperForBuild
+	imageFetches *ImageFetches
 }
 
 func (factory *stepperFactory) StepperForBuild(build db.Build) (exec.Stepper, error) {
@@ -68,8 +83,13 @@ func (factory *stepperFactory) StepperForBuild(build db.Build) (exec.Stepper, er
 		return nil, errors.New("schema not supported")
 	}
 
+	// images are shared between the steps of the build, so every step is
+	// built by a copy of the factory holding the build's image fetches
+	buildFactory := *factory
+	buildFactory.imageFetches = NewImageFetches()
+
 	return func(plan atc.Plan) exec.Step {
-		return factory.buildStep(build, plan)
+		return buildFactory.buildStep(build, plan)
 	}, nil
 }
 
@@ -82,10 +102,16 @@ func (factory *stepperFactory) buildDelegateFactory(build db.Build, plan atc.Pla
 		artifactSourcer: factory.artifactSourcer,
 		dbWorkerFactory: factory.dbWorkerFactory,
 		lockFactory:     factory.lockFactory,
+		auditSink:       factory.auditSink,
+		imageFetches:    factory.imageFetches,
 	}
 }
 
 func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Step {
+	if plan.When != nil {
+		return factory.buildWhenStep(build, plan)
+	}
+
 	if plan.InParallel != nil {
 		return factory.buildParallelStep(build, plan)
 	}
@@ -106,6 +132,10 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildTryStep(build, plan)
 	}
 
+	if plan.Semaphore != nil {
+		return factory.buildSemaphoreStep(build, plan)
+	}
+
 	if plan.OnAbort != nil {
 		return factory.buildOnAbortStep(build, plan)
 	}
@@ -138,6 +168,22 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildLoadVarStep(build, plan)
 	}
 
+	if plan.LoadVars != nil {
+		return factory.buildLoadVarsStep(build, plan)
+	}
+
+	if plan.Approval != nil {
+		return factory.buildApprovalStep(build, plan)
+	}
+
+	if plan.Run != nil {
+		return factory.buildRunStep(build, plan)
+	}
+
+	if plan.Publish != nil {
+		return factory.buildPublishStep(build, plan)
+	}
+
 	if plan.Check != nil {
 		return factory.buildCheckStep(build, plan)
 	}
@@ -171,6 +217,7 @@ func (factory *stepperFactory) buildParallelStep(build db.Build, plan atc.Plan)
 
 	for _, innerPlan := range plan.InParallel.Steps {
 		innerPlan.Attempts = plan.Attempts
+		innerPlan.TotalAttempts = plan.TotalAttempts
 		step := factory.buildStep(build, innerPlan)
 		steps = append(steps, step)
 	}
@@ -181,10 +228,22 @@ func (factory *stepperFactory) buildParallelStep(build db.Build, plan atc.Plan)
 func (factory *stepperFactory) buildAcrossStep(build db.Build, plan atc.Plan) exec.Step {
 	stepMetadata := factory.stepMetadata(
 		build,
+		plan,
 		factory.externalURL,
 		false,
 	)
 
+	if plan.Across.SubStepTemplate != nil {
+		return factory.coreFactory.DynamicAcrossStep(
+			plan,
+			func(subPlan atc.Plan) exec.Step {
+				return factory.buildStep(build, subPlan)
+			},
+			stepMetadata,
+			factory.buildDelegateFactory(build, plan),
+		)
+	}
+
 	steps := make([]exec.ScopedStep, len(plan.Across.Steps))
 	for i, s := range plan.Across.Steps {
 		steps[i] = exec.ScopedStep{
@@ -208,6 +267,7 @@ func (factory *stepperFactory) buildDoStep(build db.Build, plan atc.Plan) exec.S
 	for i := len(*plan.Do) - 1; i >= 0; i-- {
 		innerPlan := (*plan.Do)[i]
 		innerPlan.Attempts = plan.Attempts
+		innerPlan.TotalAttempts = plan.TotalAttempts
 		previous := factory.buildStep(build, innerPlan)
 		step = exec.OnSuccess(previous, step)
 	}
@@ -218,53 +278,132 @@ func (factory *stepperFactory) buildDoStep(build db.Build, plan atc.Plan) exec.S
 func (factory *stepperFactory) buildTimeoutStep(build db.Build, plan atc.Plan) exec.Step {
 	innerPlan := plan.Timeout.Step
 	innerPlan.Attempts = plan.Attempts
+	innerPlan.TotalAttempts = plan.TotalAttempts
 	step := factory.buildStep(build, innerPlan)
-	return exec.Timeout(step, plan.Timeout.Duration)
+
+	if plan.Timeout.SoftDuration == "" {
+		return exec.Timeout(step, plan.Timeout.Duration)
+	}
+
+	var hook exec.Step
+	if plan.Timeout.OnSoftTimeout != nil {
+		hookPlan := *plan.Timeout.OnSoftTimeout
+		hookPlan.Attempts = plan.Attempts
+		hookPlan.TotalAttempts = plan.TotalAttempts
+		hook = factory.buildStep(build, hookPlan)
+	}
+
+	return exec.SoftTimeout(
+		step,
+		plan.Timeout.Duration,
+		plan.Timeout.SoftDuration,
+		hook,
+		factory.buildDelegateFactory(build, plan),
+	)
 }
 
 func (factory *stepperFactory) buildTryStep(build db.Build, plan atc.Plan) exec.Step {
 	innerPlan := plan.Try.Step
 	innerPlan.Attempts = plan.Attempts
+	innerPlan.TotalAttempts = plan.TotalAttempts
 	step := factory.buildStep(build, innerPlan)
-	return exec.Try(step)
+	return exec.Try(step, plan.Try.ErrorVar)
 }
 
 func (factory *stepperFactory) buildOnAbortStep(build db.Build, plan atc.Plan) exec.Step {
 	plan.OnAbort.Step.Attempts = plan.Attempts
+	plan.OnAbort.Step.TotalAttempts = plan.TotalAttempts
 	step := factory.buildStep(build, plan.OnAbort.Step)
 	plan.OnAbort.Next.Attempts = plan.Attempts
+	plan.OnAbort.Next.TotalAttempts = plan.TotalAttempts
 	next := factory.buildStep(build, plan.OnAbort.Next)
 	return exec.OnAbort(step, next)
 }
 
 func (factory *stepperFactory) buildOnErrorStep(build db.Build, plan atc.Plan) exec.Step {
 	plan.OnError.Step.Attempts = plan.Attempts
+	plan.OnError.Step.TotalAttempts = plan.TotalAttempts
 	step := factory.buildStep(build, plan.OnError.Step)
 	plan.OnError.Next.Attempts = plan.Attempts
+	plan.OnError.Next.TotalAttempts = plan.TotalAttempts
 	next := factory.buildStep(build, plan.OnError.Next)
-	return exec.OnError(step, next)
+	return exec.OnError(step, next, hookedStepName(plan.OnError.Step))
 }
 
 func (factory *stepperFactory) buildOnSuccessStep(build db.Build, plan atc.Plan) exec.Step {
 	plan.OnSuccess.Step.Attempts = plan.Attempts
+	plan.OnSuccess.Step.TotalAttempts = plan.TotalAttempts
 	step := factory.buildStep(build, plan.OnSuccess.Step)
 	plan.OnSuccess.Next.Attempts = plan.Attempts
+	plan.OnSuccess.Next.TotalAttempts = plan.TotalAttempts
 	next := factory.buildStep(build, plan.OnSuccess.Next)
 	return exec.OnSuccess(step, next)
 }
 
 func (factory *stepperFactory) buildOnFailureStep(build db.Build, plan atc.Plan) exec.Step {
 	plan.OnFailure.Step.Attempts = plan.Attempts
+	plan.OnFailure.Step.TotalAttempts = plan.TotalAttempts
 	step := factory.buildStep(build, plan.OnFailure.Step)
 	plan.OnFailure.Next.Attempts = plan.Attempts
+	plan.OnFailure.Next.TotalAttempts = plan.TotalAttempts
 	next := factory.buildStep(build, plan.OnFailure.Next)
-	return exec.OnFailure(step, next)
+	return exec.OnFailure(step, next, hookedStepName(plan.OnFailure.Step))
+}
+
+// hookedStepName returns the name of the step a hook is attached to, looking
+// through any modifiers. It returns an empty string for steps such as do and
+// in_parallel which do not have a single name.
+func hookedStepName(plan atc.Plan) string {
+	switch {
+	case plan.Get != nil:
+		return plan.Get.Name
+	case plan.Put != nil:
+		return plan.Put.Name
+	case plan.Check != nil:
+		return plan.Check.Name
+	case plan.Task != nil:
+		return plan.Task.Name
+	case plan.SetPipeline != nil:
+		return plan.SetPipeline.Name
+	case plan.LoadVar != nil:
+		return plan.LoadVar.Name
+	case plan.LoadVars != nil:
+		return plan.LoadVars.Files
+	case plan.Approval != nil:
+		return plan.Approval.Name
+	case plan.Run != nil:
+		return plan.Run.Name
+	case plan.Publish != nil:
+		return plan.Publish.Name
+	case plan.When != nil:
+		return hookedStepName(plan.When.Step)
+	case plan.Timeout != nil:
+		return hookedStepName(plan.Timeout.Step)
+	case plan.Try != nil:
+		return hookedStepName(plan.Try.Step)
+	case plan.Retry != nil && len(*plan.Retry) > 0:
+		return hookedStepName((*plan.Retry)[0])
+	case plan.OnSuccess != nil:
+		return hookedStepName(plan.OnSuccess.Step)
+	case plan.OnFailure != nil:
+		return hookedStepName(plan.OnFailure.Step)
+	case plan.OnAbort != nil:
+		return hookedStepName(plan.OnAbort.Step)
+	case plan.OnError != nil:
+		return hookedStepName(plan.OnError.Step)
+	case plan.Ensure != nil:
+		return hookedStepName(plan.Ensure.Step)
+	default:
+		return ""
+	}
 }
 
 func (factory *stepperFactory) buildEnsureStep(build db.Build, plan atc.Plan) exec.Step {
 	plan.Ensure.Step.Attempts = plan.Attempts
+	plan.Ensure.Step.TotalAttempts = plan.TotalAttempts
 	step := factory.buildStep(build, plan.Ensure.Step)
 	plan.Ensure.Next.Attempts = plan.Attempts
+	plan.Ensure.Next.TotalAttempts = plan.TotalAttempts
 	next := factory.buildStep(build, plan.Ensure.Next)
 	return exec.Ensure(step, next)
 }
@@ -274,14 +413,62 @@ func (factory *stepperFactory) buildRetryStep(build db.Build, plan atc.Plan) exe
 
 	for index, innerPlan := range *plan.Retry {
 		innerPlan.Attempts = append(plan.Attempts, index+1)
+		innerPlan.TotalAttempts = append(plan.TotalAttempts, len(*plan.Retry))
 
 		step := factory.buildStep(build, innerPlan)
 		steps = append(steps, step)
 	}
 
+	if plan.RetryBackoff != nil {
+		return exec.RetryWithBackoff(*plan.RetryBackoff, factory.buildDelegateFactory(build, plan), steps...)
+	}
+
 	return exec.Retry(steps...)
 }
 
+func (factory *stepperFactory) buildSemaphoreStep(build db.Build, plan atc.Plan) exec.Step {
+	plan.Semaphore.Step.Attempts = plan.Attempts
+	plan.Semaphore.Step.TotalAttempts = plan.TotalAttempts
+	step := factory.buildStep(build, plan.Semaphore.Step)
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		plan,
+		factory.externalURL,
+		false,
+	)
+
+	return exec.Semaphore(
+		plan.ID,
+		step,
+		factory.semaphoreFactory.Semaphore(build.TeamID(), plan.Semaphore.Name, plan.Semaphore.Limit),
+		factory.buildDelegateFactory(build, plan),
+		stepMetadata,
+	)
+}
+
+func (factory *stepperFactory) buildWhenStep(build db.Build, plan atc.Plan) exec.Step {
+	plan.When.Step.Attempts = plan.Attempts
+	plan.When.Step.TotalAttempts = plan.TotalAttempts
+	step := factory.buildStep(build, plan.When.Step)
+
+	// the condition may refer to who created the build, which is not exposed
+	// to anything outside of the ATC
+	stepMetadata := factory.stepMetadata(
+		build,
+		plan,
+		factory.externalURL,
+		true,
+	)
+
+	return exec.NewWhenStep(
+		*plan.When,
+		step,
+		factory.buildDelegateFactory(build, plan),
+		stepMetadata,
+	)
+}
+
 func (factory *stepperFactory) buildGetStep(build db.Build, plan atc.Plan) exec.Step {
 
 	containerMetadata := factory.containerMetadata(
@@ -293,6 +480,7 @@ func (factory *stepperFactory) buildGetStep(build db.Build, plan atc.Plan) exec.
 
 	stepMetadata := factory.stepMetadata(
 		build,
+		plan,
 		factory.externalURL,
 		false,
 	)
@@ -316,6 +504,7 @@ func (factory *stepperFactory) buildPutStep(build db.Build, plan atc.Plan) exec.
 
 	stepMetadata := factory.stepMetadata(
 		build,
+		plan,
 		factory.externalURL,
 		plan.Put.ExposeBuildCreatedBy,
 	)
@@ -338,6 +527,7 @@ func (factory *stepperFactory) buildCheckStep(build db.Build, plan atc.Plan) exe
 
 	stepMetadata := factory.stepMetadata(
 		build,
+		plan,
 		factory.externalURL,
 		false,
 	)
@@ -361,6 +551,7 @@ func (factory *stepperFactory) buildTaskStep(build db.Build, plan atc.Plan) exec
 
 	stepMetadata := factory.stepMetadata(
 		build,
+		plan,
 		factory.externalURL,
 		false,
 	)
@@ -377,6 +568,7 @@ func (factory *stepperFactory) buildSetPipelineStep(build db.Build, plan atc.Pla
 
 	stepMetadata := factory.stepMetadata(
 		build,
+		plan,
 		factory.externalURL,
 		false,
 	)
@@ -392,6 +584,7 @@ func (factory *stepperFactory) buildLoadVarStep(build db.Build, plan atc.Plan) e
 
 	stepMetadata := factory.stepMetadata(
 		build,
+		plan,
 		factory.externalURL,
 		false,
 	)
@@ -403,6 +596,75 @@ func (factory *stepperFactory) buildLoadVarStep(build db.Build, plan atc.Plan) e
 	)
 }
 
+func (factory *stepperFactory) buildLoadVarsStep(build db.Build, plan atc.Plan) exec.Step {
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		plan,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.LoadVarsStep(
+		plan,
+		stepMetadata,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
+func (factory *stepperFactory) buildApprovalStep(build db.Build, plan atc.Plan) exec.Step {
+	stepMetadata := factory.stepMetadata(
+		build,
+		plan,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.ApprovalStep(
+		plan,
+		stepMetadata,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
+func (factory *stepperFactory) buildRunStep(build db.Build, plan atc.Plan) exec.Step {
+	containerMetadata := factory.containerMetadata(
+		build,
+		db.ContainerTypeRun,
+		plan.Run.Name,
+		plan.Attempts,
+	)
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		plan,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.RunStep(
+		plan,
+		stepMetadata,
+		containerMetadata,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
+func (factory *stepperFactory) buildPublishStep(build db.Build, plan atc.Plan) exec.Step {
+	stepMetadata := factory.stepMetadata(
+		build,
+		plan,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.PublishStep(
+		plan,
+		stepMetadata,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
 func (factory *stepperFactory) buildArtifactInputStep(build db.Build, plan atc.Plan) exec.Step {
 	return factory.coreFactory.ArtifactInputStep(
 		plan,
@@ -453,6 +715,7 @@ func (factory *stepperFactory) containerMetadata(
 
 func (factory *stepperFactory) stepMetadata(
 	build db.Build,
+	plan atc.Plan,
 	externalURL string,
 	exposeBuildCreatedBy bool,
 ) exec.StepMetadata {
@@ -467,6 +730,10 @@ func (factory *stepperFactory) stepMetadata(
 		PipelineName:         build.PipelineName(),
 		PipelineInstanceVars: build.PipelineInstanceVars(),
 		ExternalURL:          externalURL,
+		Priority:             build.JobPriority(),
+		Trigger:              build.Trigger(),
+		Attempts:             plan.Attempts,
+		TotalAttempts:        plan.TotalAttempts,
 	}
 	if exposeBuildCreatedBy && build.CreatedBy() != nil {
 		meta.CreatedBy = *build.CreatedBy()
@@ -2,6 +2,7 @@ package engine_test
 
 import (
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 	"github.com/concourse/concourse/atc/builds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -29,6 +30,9 @@ var _ = Describe("Builder", func() {
 			fakeWorkerFactory   *dbfakes.FakeWorkerFactory
 			fakeLockFactory     *lockfakes.FakeLockFactory
 
+			fakeSemaphoreFactory *dbfakes.FakeSemaphoreFactory
+			fakeAuditSink        *auditorfakes.FakeSink
+
 			planFactory    atc.PlanFactory
 			stepperFactory engine.StepperFactory
 		)
@@ -40,6 +44,8 @@ var _ = Describe("Builder", func() {
 			fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
 			fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
 			fakeLockFactory = new(lockfakes.FakeLockFactory)
+			fakeSemaphoreFactory = new(dbfakes.FakeSemaphoreFactory)
+			fakeAuditSink = new(auditorfakes.FakeSink)
 
 			stepperFactory = engine.NewStepperFactory(
 				fakeCoreStepFactory,
@@ -49,6 +55,8 @@ var _ = Describe("Builder", func() {
 				fakeArtifactSourcer,
 				fakeWorkerFactory,
 				fakeLockFactory,
+				fakeSemaphoreFactory,
+				fakeAuditSink,
 			)
 
 			planFactory = atc.NewPlanFactory(123)
@@ -74,6 +82,7 @@ var _ = Describe("Builder", func() {
 				fakeBuild.IDReturns(4444)
 				fakeBuild.NameReturns("42")
 				fakeBuild.JobNameReturns("some-job")
+				fakeBuild.JobPriorityReturns(5)
 				fakeBuild.JobIDReturns(3333)
 				fakeBuild.PipelineIDReturns(fakePipeline.ID())
 				fakeBuild.PipelineNameReturns(fakePipeline.Name())
@@ -83,6 +92,7 @@ var _ = Describe("Builder", func() {
 				fakeBuild.TeamIDReturns(1111)
 				someUser := "some-user"
 				fakeBuild.CreatedByReturns(&someUser)
+				fakeBuild.TriggerReturns(atc.BuildTriggerManual)
 
 				expectedMetadataWithCreatedBy = exec.StepMetadata{
 					BuildID:              4444,
@@ -95,6 +105,8 @@ var _ = Describe("Builder", func() {
 					PipelineName:         "some-pipeline",
 					PipelineInstanceVars: atc.InstanceVars{"branch": "master"},
 					ExternalURL:          "http://example.com",
+					Priority:             5,
+					Trigger:              atc.BuildTriggerManual,
 					CreatedBy:            "some-user",
 				}
 
@@ -109,6 +121,8 @@ var _ = Describe("Builder", func() {
 					PipelineName:         "some-pipeline",
 					PipelineInstanceVars: atc.InstanceVars{"branch": "master"},
 					ExternalURL:          "http://example.com",
+					Priority:             5,
+					Trigger:              atc.BuildTriggerManual,
 				}
 			})
 
@@ -251,7 +265,7 @@ var _ = Describe("Builder", func() {
 									Next: otherDependentGetPlan,
 								}),
 							},
-							Limit:    1,
+							Limit:    "1",
 							FailFast: true,
 						})
 					})
@@ -327,7 +341,7 @@ var _ = Describe("Builder", func() {
 
 						parallelPlan = planFactory.NewPlan(atc.InParallelPlan{
 							Steps:    []atc.Plan{inParallelPlan},
-							Limit:    1,
+							Limit:    "1",
 							FailFast: true,
 						})
 
@@ -353,8 +367,9 @@ var _ = Describe("Builder", func() {
 						plan, stepMetadata, containerMetadata, _ := fakeCoreStepFactory.GetStepArgsForCall(0)
 						expectedPlan := getPlan
 						expectedPlan.Attempts = []int{1}
+						expectedPlan.TotalAttempts = []int{3}
 						Expect(plan).To(Equal(expectedPlan))
-						Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						Expect(stepMetadata).To(Equal(withAttempts(expectedMetadataWithoutCreatedBy, []int{1}, []int{3})))
 						Expect(containerMetadata).To(Equal(db.ContainerMetadata{
 							Type:                 db.ContainerTypeGet,
 							StepName:             "some-get",
@@ -373,8 +388,9 @@ var _ = Describe("Builder", func() {
 						plan, stepMetadata, containerMetadata, _ := fakeCoreStepFactory.GetStepArgsForCall(1)
 						expectedPlan := getPlan
 						expectedPlan.Attempts = []int{3}
+						expectedPlan.TotalAttempts = []int{3}
 						Expect(plan).To(Equal(expectedPlan))
-						Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						Expect(stepMetadata).To(Equal(withAttempts(expectedMetadataWithoutCreatedBy, []int{3}, []int{3})))
 						Expect(containerMetadata).To(Equal(db.ContainerMetadata{
 							Type:                 db.ContainerTypeGet,
 							StepName:             "some-get",
@@ -397,8 +413,9 @@ var _ = Describe("Builder", func() {
 						plan, stepMetadata, containerMetadata, _ := fakeCoreStepFactory.TaskStepArgsForCall(0)
 						expectedPlan := taskPlan
 						expectedPlan.Attempts = []int{2, 1}
+						expectedPlan.TotalAttempts = []int{3, 2}
 						Expect(plan).To(Equal(expectedPlan))
-						Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						Expect(stepMetadata).To(Equal(withAttempts(expectedMetadataWithoutCreatedBy, []int{2, 1}, []int{3, 2})))
 						Expect(containerMetadata).To(Equal(db.ContainerMetadata{
 							Type:                 db.ContainerTypeTask,
 							StepName:             "some-task",
@@ -415,8 +432,9 @@ var _ = Describe("Builder", func() {
 						plan, stepMetadata, containerMetadata, _ = fakeCoreStepFactory.TaskStepArgsForCall(1)
 						expectedPlan = taskPlan
 						expectedPlan.Attempts = []int{2, 2}
+						expectedPlan.TotalAttempts = []int{3, 2}
 						Expect(plan).To(Equal(expectedPlan))
-						Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						Expect(stepMetadata).To(Equal(withAttempts(expectedMetadataWithoutCreatedBy, []int{2, 2}, []int{3, 2})))
 						Expect(containerMetadata).To(Equal(db.ContainerMetadata{
 							Type:                 db.ContainerTypeTask,
 							StepName:             "some-task",
@@ -589,6 +607,118 @@ var _ = Describe("Builder", func() {
 						})
 					})
 
+					Context("that contains a load_vars step", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.LoadVarsPlan{
+								Files: "some-input/vars/*.yml",
+							})
+						})
+
+						It("constructs load_vars correctly", func() {
+							plan, stepMetadata, _ := fakeCoreStepFactory.LoadVarsStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						})
+					})
+
+					Context("that contains a serial_semaphore", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.SemaphorePlan{
+								Step: planFactory.NewPlan(atc.LoadVarPlan{
+									Name: "some-var",
+									File: "some-input/some-file.yml",
+								}),
+								Name:  "some-semaphore",
+								Limit: 2,
+							})
+						})
+
+						It("constructs the semaphore and its step", func() {
+							Expect(fakeSemaphoreFactory.SemaphoreCallCount()).To(Equal(1))
+							teamID, name, limit := fakeSemaphoreFactory.SemaphoreArgsForCall(0)
+							Expect(teamID).To(Equal(1111))
+							Expect(name).To(Equal("some-semaphore"))
+							Expect(limit).To(Equal(2))
+
+							plan, _, _ := fakeCoreStepFactory.LoadVarStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan.Semaphore.Step))
+						})
+					})
+
+					Context("that contains an approval step", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.ApprovalPlan{
+								Name:      "some-approval",
+								Approvers: []string{"some-user"},
+							})
+						})
+
+						It("constructs approval correctly", func() {
+							plan, stepMetadata, _ := fakeCoreStepFactory.ApprovalStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						})
+					})
+
+					Context("that contains a run step", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.RunPlan{
+								Name:    "some-run",
+								Message: "run",
+								Type:    "some-prototype",
+							})
+						})
+
+						It("constructs the step correctly", func() {
+							plan, stepMetadata, containerMetadata, _ := fakeCoreStepFactory.RunStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
+								Type:                 db.ContainerTypeRun,
+								StepName:             "some-run",
+								PipelineID:           2222,
+								PipelineName:         "some-pipeline",
+								PipelineInstanceVars: `{"branch":"master"}`,
+								JobID:                3333,
+								JobName:              "some-job",
+								BuildID:              4444,
+								BuildName:            "42",
+							}))
+						})
+					})
+
+					Context("that contains a publish step", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.PublishPlan{
+								Name: "some-artifact",
+							})
+						})
+
+						It("constructs publish correctly", func() {
+							plan, stepMetadata, _ := fakeCoreStepFactory.PublishStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						})
+					})
+
+					Context("that contains a when modifier", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.WhenPlan{
+								Step: planFactory.NewPlan(atc.LoadVarPlan{
+									Name: "some-var",
+									File: "some-input/some-file.yml",
+								}),
+								Condition: ".:deploy",
+							})
+						})
+
+						It("constructs the step", func() {
+							Expect(fakeCoreStepFactory.LoadVarStepCallCount()).To(Equal(1))
+							plan, _, _ := fakeCoreStepFactory.LoadVarStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan.When.Step))
+						})
+					})
+
 					Context("that contains a check step", func() {
 						BeforeEach(func() {
 							expectedPlan = planFactory.NewPlan(atc.CheckPlan{
@@ -875,7 +1005,7 @@ var _ = Describe("Builder", func() {
 							},
 						}
 
-						expectedPlan, err = planner.Create(step, nil, nil, nil)
+						expectedPlan, err = planner.Create(step, atc.StepDefaults{}, nil, nil, nil, nil)
 						Expect(err).ToNot(HaveOccurred())
 					})
 
@@ -897,7 +1027,54 @@ var _ = Describe("Builder", func() {
 						}))
 					})
 				})
+
+				Context("running across steps with a values file", func() {
+					BeforeEach(func() {
+						planner := builds.NewPlanner(planFactory)
+
+						step := &atc.AcrossStep{
+							Step: &atc.TaskStep{Name: "some-task"},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:        "var1",
+									ValuesFile: "some-artifact/values.json",
+								},
+							},
+						}
+
+						expectedPlan, err = planner.Create(step, atc.StepDefaults{}, nil, nil, nil, nil)
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("constructs a dynamic across step", func() {
+						Expect(fakeCoreStepFactory.DynamicAcrossStepCallCount()).To(Equal(1))
+						plan, _, stepMetadata, _ := fakeCoreStepFactory.DynamicAcrossStepArgsForCall(0)
+						Expect(plan).To(Equal(expectedPlan))
+						Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						Expect(fakeCoreStepFactory.TaskStepCallCount()).To(BeZero())
+					})
+
+					It("builds the substeps from their plans", func() {
+						_, buildSubStep, _, _ := fakeCoreStepFactory.DynamicAcrossStepArgsForCall(0)
+
+						subPlan := atc.Plan{
+							ID:   "some-id/0",
+							Task: &atc.TaskPlan{Name: "some-task"},
+						}
+						buildSubStep(subPlan)
+
+						Expect(fakeCoreStepFactory.TaskStepCallCount()).To(Equal(1))
+						plan, _, _, _ := fakeCoreStepFactory.TaskStepArgsForCall(0)
+						Expect(plan).To(Equal(subPlan))
+					})
+				})
 			})
 		})
 	})
 })
+
+func withAttempts(metadata exec.StepMetadata, attempts []int, totalAttempts []int) exec.StepMetadata {
+	metadata.Attempts = attempts
+	metadata.TotalAttempts = totalAttempts
+	return metadata
+}
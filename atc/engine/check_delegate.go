@@ -29,9 +29,10 @@ func NewCheckDelegate(
 	limiter RateLimiter,
 	policyChecker policy.Checker,
 	artifactSourcer worker.ArtifactSourcer,
+	imageFetches *ImageFetches,
 ) exec.CheckDelegate {
 	return &checkDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, plan.ID, state, clock, policyChecker, artifactSourcer),
+		BuildStepDelegate: NewBuildStepDelegate(build, plan.ID, state, clock, policyChecker, artifactSourcer, imageFetches),
 
 		build:       build,
 		plan:        plan.Check,
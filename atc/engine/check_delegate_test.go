@@ -63,7 +63,7 @@ var _ = Describe("CheckDelegate", func() {
 		fakeBuild.NameReturns(db.CheckBuildName)
 		fakeBuild.ResourceIDReturns(88)
 
-		delegate = engine.NewCheckDelegate(fakeBuild, plan, state, fakeClock, fakeRateLimiter, fakePolicyChecker, fakeArtifactSourcer)
+		delegate = engine.NewCheckDelegate(fakeBuild, plan, state, fakeClock, fakeRateLimiter, fakePolicyChecker, fakeArtifactSourcer, nil)
 
 		fakeResourceConfig = new(dbfakes.FakeResourceConfig)
 		fakeResourceConfigScope = new(dbfakes.FakeResourceConfigScope)
@@ -322,6 +322,51 @@ var _ = Describe("CheckDelegate", func() {
 			})
 		})
 
+		Context("when running for a resource in a job's plan", func() {
+			BeforeEach(func() {
+				plan.Check.Resource = "some-resource"
+				plan.Check.InJobPlan = true
+			})
+
+			It("does not rate limit", func() {
+				Expect(fakeRateLimiter.WaitCallCount()).To(Equal(0))
+			})
+
+			It("does not acquire a lock", func() {
+				Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(Equal(0))
+			})
+
+			Context("when last check succeeds after build starts", func() {
+				BeforeEach(func() {
+					fakeResourceConfigScope.LastCheckReturns(db.LastCheck{
+						StartTime: now.Add(-10 * time.Second),
+						EndTime:   now.Add(-time.Second),
+						Succeeded: true,
+					}, nil)
+					fakeBuild.StartTimeReturns(now.Add(-5 * time.Second))
+				})
+
+				It("returns false", func() {
+					Expect(run).To(BeFalse())
+				})
+			})
+
+			Context("when last check ended before build start time", func() {
+				BeforeEach(func() {
+					fakeResourceConfigScope.LastCheckReturns(db.LastCheck{
+						StartTime: now.Add(-10 * time.Second),
+						EndTime:   now.Add(-time.Second),
+						Succeeded: true,
+					}, nil)
+					fakeBuild.StartTimeReturns(now.Add(time.Second))
+				})
+
+				It("returns true", func() {
+					Expect(run).To(BeTrue())
+				})
+			})
+		})
+
 		Context("when not running for a resource", func() {
 			BeforeEach(func() {
 				plan.Check.Resource = ""
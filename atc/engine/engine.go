@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -161,7 +162,7 @@ func (b *engineBuild) Run(ctx context.Context) {
 
 	defer notifier.Close()
 
-	ctx, span := tracing.StartSpanFollowing(ctx, b.build, "build", b.build.TracingAttrs())
+	ctx, span := tracing.StartSpanFollowingLinked(ctx, b.build, b.build.RerunOfSpanContext(), "build", spanAttrs(b.build))
 	defer span.End()
 
 	stepper, err := b.builder.StepperForBuild(b.build)
@@ -288,6 +289,33 @@ func (b *engineBuild) trackStarted(logger lager.Logger) {
 	}
 }
 
+// spanAttrs extends the build's tracing attributes with the IDs needed to
+// find the build from a trace. They are kept off TracingAttrs, which is also
+// used for metrics, to avoid adding high-cardinality tags there.
+func spanAttrs(build db.Build) tracing.Attrs {
+	attrs := tracing.Attrs{
+		"team_id": strconv.Itoa(build.TeamID()),
+	}
+
+	for k, v := range build.TracingAttrs() {
+		attrs[k] = v
+	}
+
+	if build.PipelineID() != 0 {
+		attrs["pipeline_id"] = strconv.Itoa(build.PipelineID())
+	}
+
+	if build.JobID() != 0 {
+		attrs["job_id"] = strconv.Itoa(build.JobID())
+	}
+
+	if build.RerunOf() != 0 {
+		attrs["rerun_of"] = strconv.Itoa(build.RerunOf())
+	}
+
+	return attrs
+}
+
 func (b *engineBuild) trackFinished(logger lager.Logger) {
 	found, err := b.build.Reload()
 	if err != nil {
@@ -305,6 +333,10 @@ func (b *engineBuild) trackFinished(logger lager.Logger) {
 			metric.BuildFinished{
 				Build: b.build,
 			}.Emit(logger)
+
+			if b.build.JobID() != 0 && metric.Metrics.IsConfigured() {
+				b.emitJobStatistics(logger)
+			}
 		} else {
 			metric.CheckBuildFinished{
 				Build: b.build,
@@ -313,6 +345,33 @@ func (b *engineBuild) trackFinished(logger lager.Logger) {
 	}
 }
 
+func (b *engineBuild) emitJobStatistics(logger lager.Logger) {
+	pipeline, found, err := b.build.Pipeline()
+	if err != nil || !found {
+		logger.Error("failed-to-find-pipeline", err)
+		return
+	}
+
+	job, found, err := pipeline.Job(b.build.JobName())
+	if err != nil || !found {
+		logger.Error("failed-to-find-job", err)
+		return
+	}
+
+	stats, err := job.Statistics(db.JobStatisticsWindow)
+	if err != nil {
+		logger.Error("failed-to-get-job-statistics", err)
+		return
+	}
+
+	metric.JobStatistics{
+		TeamName:     b.build.TeamName(),
+		PipelineName: b.build.PipelineName(),
+		JobName:      b.build.JobName(),
+		Statistics:   stats,
+	}.Emit(logger)
+}
+
 func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.RunState, error) {
 	id := fmt.Sprintf("build:%v", b.build.ID())
 	existingState, ok := b.trackedStates.Load(id)
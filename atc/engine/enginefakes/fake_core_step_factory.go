@@ -11,6 +11,19 @@ import (
 )
 
 type FakeCoreStepFactory struct {
+	ApprovalStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	approvalStepMutex       sync.RWMutex
+	approvalStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}
+	approvalStepReturns struct {
+		result1 exec.Step
+	}
+	approvalStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	ArtifactInputStepStub        func(atc.Plan, db.Build) exec.Step
 	artifactInputStepMutex       sync.RWMutex
 	artifactInputStepArgsForCall []struct {
@@ -49,6 +62,20 @@ type FakeCoreStepFactory struct {
 	checkStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	DynamicAcrossStepStub        func(atc.Plan, exec.AcrossSubStepBuilder, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	dynamicAcrossStepMutex       sync.RWMutex
+	dynamicAcrossStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.AcrossSubStepBuilder
+		arg3 exec.StepMetadata
+		arg4 engine.DelegateFactory
+	}
+	dynamicAcrossStepReturns struct {
+		result1 exec.Step
+	}
+	dynamicAcrossStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	GetStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, engine.DelegateFactory) exec.Step
 	getStepMutex       sync.RWMutex
 	getStepArgsForCall []struct {
@@ -76,6 +103,32 @@ type FakeCoreStepFactory struct {
 	loadVarStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	LoadVarsStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	loadVarsStepMutex       sync.RWMutex
+	loadVarsStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}
+	loadVarsStepReturns struct {
+		result1 exec.Step
+	}
+	loadVarsStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
+	PublishStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	publishStepMutex       sync.RWMutex
+	publishStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}
+	publishStepReturns struct {
+		result1 exec.Step
+	}
+	publishStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	PutStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, engine.DelegateFactory) exec.Step
 	putStepMutex       sync.RWMutex
 	putStepArgsForCall []struct {
@@ -90,6 +143,20 @@ type FakeCoreStepFactory struct {
 	putStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	RunStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, engine.DelegateFactory) exec.Step
+	runStepMutex       sync.RWMutex
+	runStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 db.ContainerMetadata
+		arg4 engine.DelegateFactory
+	}
+	runStepReturns struct {
+		result1 exec.Step
+	}
+	runStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	SetPipelineStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
 	setPipelineStepMutex       sync.RWMutex
 	setPipelineStepArgsForCall []struct {
@@ -121,6 +188,69 @@ type FakeCoreStepFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCoreStepFactory) ApprovalStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
+	fake.approvalStepMutex.Lock()
+	ret, specificReturn := fake.approvalStepReturnsOnCall[len(fake.approvalStepArgsForCall)]
+	fake.approvalStepArgsForCall = append(fake.approvalStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}{arg1, arg2, arg3})
+	stub := fake.ApprovalStepStub
+	fakeReturns := fake.approvalStepReturns
+	fake.recordInvocation("ApprovalStep", []interface{}{arg1, arg2, arg3})
+	fake.approvalStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepCallCount() int {
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
+	return len(fake.approvalStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepCalls(stub func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
+	argsForCall := fake.approvalStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepReturns(result1 exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = nil
+	fake.approvalStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = nil
+	if fake.approvalStepReturnsOnCall == nil {
+		fake.approvalStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.approvalStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) ArtifactInputStep(arg1 atc.Plan, arg2 db.Build) exec.Step {
 	fake.artifactInputStepMutex.Lock()
 	ret, specificReturn := fake.artifactInputStepReturnsOnCall[len(fake.artifactInputStepArgsForCall)]
@@ -309,6 +439,70 @@ func (fake *FakeCoreStepFactory) CheckStepReturnsOnCall(i int, result1 exec.Step
 	}{result1}
 }
 
+func (fake *FakeCoreStepFactory) DynamicAcrossStep(arg1 atc.Plan, arg2 exec.AcrossSubStepBuilder, arg3 exec.StepMetadata, arg4 engine.DelegateFactory) exec.Step {
+	fake.dynamicAcrossStepMutex.Lock()
+	ret, specificReturn := fake.dynamicAcrossStepReturnsOnCall[len(fake.dynamicAcrossStepArgsForCall)]
+	fake.dynamicAcrossStepArgsForCall = append(fake.dynamicAcrossStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.AcrossSubStepBuilder
+		arg3 exec.StepMetadata
+		arg4 engine.DelegateFactory
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DynamicAcrossStepStub
+	fakeReturns := fake.dynamicAcrossStepReturns
+	fake.recordInvocation("DynamicAcrossStep", []interface{}{arg1, arg2, arg3, arg4})
+	fake.dynamicAcrossStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) DynamicAcrossStepCallCount() int {
+	fake.dynamicAcrossStepMutex.RLock()
+	defer fake.dynamicAcrossStepMutex.RUnlock()
+	return len(fake.dynamicAcrossStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) DynamicAcrossStepCalls(stub func(atc.Plan, exec.AcrossSubStepBuilder, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+	fake.dynamicAcrossStepMutex.Lock()
+	defer fake.dynamicAcrossStepMutex.Unlock()
+	fake.DynamicAcrossStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) DynamicAcrossStepArgsForCall(i int) (atc.Plan, exec.AcrossSubStepBuilder, exec.StepMetadata, engine.DelegateFactory) {
+	fake.dynamicAcrossStepMutex.RLock()
+	defer fake.dynamicAcrossStepMutex.RUnlock()
+	argsForCall := fake.dynamicAcrossStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeCoreStepFactory) DynamicAcrossStepReturns(result1 exec.Step) {
+	fake.dynamicAcrossStepMutex.Lock()
+	defer fake.dynamicAcrossStepMutex.Unlock()
+	fake.DynamicAcrossStepStub = nil
+	fake.dynamicAcrossStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) DynamicAcrossStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.dynamicAcrossStepMutex.Lock()
+	defer fake.dynamicAcrossStepMutex.Unlock()
+	fake.DynamicAcrossStepStub = nil
+	if fake.dynamicAcrossStepReturnsOnCall == nil {
+		fake.dynamicAcrossStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.dynamicAcrossStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) GetStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 engine.DelegateFactory) exec.Step {
 	fake.getStepMutex.Lock()
 	ret, specificReturn := fake.getStepReturnsOnCall[len(fake.getStepArgsForCall)]
@@ -436,6 +630,132 @@ func (fake *FakeCoreStepFactory) LoadVarStepReturnsOnCall(i int, result1 exec.St
 	}{result1}
 }
 
+func (fake *FakeCoreStepFactory) LoadVarsStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
+	fake.loadVarsStepMutex.Lock()
+	ret, specificReturn := fake.loadVarsStepReturnsOnCall[len(fake.loadVarsStepArgsForCall)]
+	fake.loadVarsStepArgsForCall = append(fake.loadVarsStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}{arg1, arg2, arg3})
+	stub := fake.LoadVarsStepStub
+	fakeReturns := fake.loadVarsStepReturns
+	fake.recordInvocation("LoadVarsStep", []interface{}{arg1, arg2, arg3})
+	fake.loadVarsStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) LoadVarsStepCallCount() int {
+	fake.loadVarsStepMutex.RLock()
+	defer fake.loadVarsStepMutex.RUnlock()
+	return len(fake.loadVarsStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) LoadVarsStepCalls(stub func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+	fake.loadVarsStepMutex.Lock()
+	defer fake.loadVarsStepMutex.Unlock()
+	fake.LoadVarsStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) LoadVarsStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
+	fake.loadVarsStepMutex.RLock()
+	defer fake.loadVarsStepMutex.RUnlock()
+	argsForCall := fake.loadVarsStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoreStepFactory) LoadVarsStepReturns(result1 exec.Step) {
+	fake.loadVarsStepMutex.Lock()
+	defer fake.loadVarsStepMutex.Unlock()
+	fake.LoadVarsStepStub = nil
+	fake.loadVarsStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) LoadVarsStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.loadVarsStepMutex.Lock()
+	defer fake.loadVarsStepMutex.Unlock()
+	fake.LoadVarsStepStub = nil
+	if fake.loadVarsStepReturnsOnCall == nil {
+		fake.loadVarsStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.loadVarsStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) PublishStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
+	fake.publishStepMutex.Lock()
+	ret, specificReturn := fake.publishStepReturnsOnCall[len(fake.publishStepArgsForCall)]
+	fake.publishStepArgsForCall = append(fake.publishStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}{arg1, arg2, arg3})
+	stub := fake.PublishStepStub
+	fakeReturns := fake.publishStepReturns
+	fake.recordInvocation("PublishStep", []interface{}{arg1, arg2, arg3})
+	fake.publishStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) PublishStepCallCount() int {
+	fake.publishStepMutex.RLock()
+	defer fake.publishStepMutex.RUnlock()
+	return len(fake.publishStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) PublishStepCalls(stub func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+	fake.publishStepMutex.Lock()
+	defer fake.publishStepMutex.Unlock()
+	fake.PublishStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) PublishStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
+	fake.publishStepMutex.RLock()
+	defer fake.publishStepMutex.RUnlock()
+	argsForCall := fake.publishStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoreStepFactory) PublishStepReturns(result1 exec.Step) {
+	fake.publishStepMutex.Lock()
+	defer fake.publishStepMutex.Unlock()
+	fake.PublishStepStub = nil
+	fake.publishStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) PublishStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.publishStepMutex.Lock()
+	defer fake.publishStepMutex.Unlock()
+	fake.PublishStepStub = nil
+	if fake.publishStepReturnsOnCall == nil {
+		fake.publishStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.publishStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) PutStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 engine.DelegateFactory) exec.Step {
 	fake.putStepMutex.Lock()
 	ret, specificReturn := fake.putStepReturnsOnCall[len(fake.putStepArgsForCall)]
@@ -500,6 +820,70 @@ func (fake *FakeCoreStepFactory) PutStepReturnsOnCall(i int, result1 exec.Step)
 	}{result1}
 }
 
+func (fake *FakeCoreStepFactory) RunStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 engine.DelegateFactory) exec.Step {
+	fake.runStepMutex.Lock()
+	ret, specificReturn := fake.runStepReturnsOnCall[len(fake.runStepArgsForCall)]
+	fake.runStepArgsForCall = append(fake.runStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 db.ContainerMetadata
+		arg4 engine.DelegateFactory
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.RunStepStub
+	fakeReturns := fake.runStepReturns
+	fake.recordInvocation("RunStep", []interface{}{arg1, arg2, arg3, arg4})
+	fake.runStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) RunStepCallCount() int {
+	fake.runStepMutex.RLock()
+	defer fake.runStepMutex.RUnlock()
+	return len(fake.runStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) RunStepCalls(stub func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, engine.DelegateFactory) exec.Step) {
+	fake.runStepMutex.Lock()
+	defer fake.runStepMutex.Unlock()
+	fake.RunStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) RunStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, db.ContainerMetadata, engine.DelegateFactory) {
+	fake.runStepMutex.RLock()
+	defer fake.runStepMutex.RUnlock()
+	argsForCall := fake.runStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeCoreStepFactory) RunStepReturns(result1 exec.Step) {
+	fake.runStepMutex.Lock()
+	defer fake.runStepMutex.Unlock()
+	fake.RunStepStub = nil
+	fake.runStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) RunStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.runStepMutex.Lock()
+	defer fake.runStepMutex.Unlock()
+	fake.RunStepStub = nil
+	if fake.runStepReturnsOnCall == nil {
+		fake.runStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.runStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) SetPipelineStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
 	fake.setPipelineStepMutex.Lock()
 	ret, specificReturn := fake.setPipelineStepReturnsOnCall[len(fake.setPipelineStepArgsForCall)]
@@ -630,18 +1014,28 @@ func (fake *FakeCoreStepFactory) TaskStepReturnsOnCall(i int, result1 exec.Step)
 func (fake *FakeCoreStepFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
 	fake.artifactInputStepMutex.RLock()
 	defer fake.artifactInputStepMutex.RUnlock()
 	fake.artifactOutputStepMutex.RLock()
 	defer fake.artifactOutputStepMutex.RUnlock()
 	fake.checkStepMutex.RLock()
 	defer fake.checkStepMutex.RUnlock()
+	fake.dynamicAcrossStepMutex.RLock()
+	defer fake.dynamicAcrossStepMutex.RUnlock()
 	fake.getStepMutex.RLock()
 	defer fake.getStepMutex.RUnlock()
 	fake.loadVarStepMutex.RLock()
 	defer fake.loadVarStepMutex.RUnlock()
+	fake.loadVarsStepMutex.RLock()
+	defer fake.loadVarsStepMutex.RUnlock()
+	fake.publishStepMutex.RLock()
+	defer fake.publishStepMutex.RUnlock()
 	fake.putStepMutex.RLock()
 	defer fake.putStepMutex.RUnlock()
+	fake.runStepMutex.RLock()
+	defer fake.runStepMutex.RUnlock()
 	fake.setPipelineStepMutex.RLock()
 	defer fake.setPipelineStepMutex.RUnlock()
 	fake.taskStepMutex.RLock()
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"fmt"
 	"io"
 	"time"
 
@@ -22,10 +23,13 @@ func NewGetDelegate(
 	clock clock.Clock,
 	policyChecker policy.Checker,
 	artifactSourcer worker.ArtifactSourcer,
+	imageFetches *ImageFetches,
 ) exec.GetDelegate {
 	return &getDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer),
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer, imageFetches),
 
+		planID:      planID,
+		state:       state,
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
 		build:       build,
 		clock:       clock,
@@ -35,6 +39,8 @@ func NewGetDelegate(
 type getDelegate struct {
 	exec.BuildStepDelegate
 
+	planID      atc.PlanID
+	state       exec.RunState
 	build       db.Build
 	eventOrigin event.Origin
 	clock       clock.Clock
@@ -71,6 +77,8 @@ func (d *getDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus,
 	d.Stdout().(io.Closer).Close()
 	d.Stderr().(io.Closer).Close()
 
+	storeStepStatus(d.state, d.planID, exitStatus == 0)
+
 	err := d.build.SaveEvent(event.FinishGet{
 		Origin:          d.eventOrigin,
 		Time:            d.clock.Now().Unix(),
@@ -86,6 +94,62 @@ func (d *getDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus,
 	logger.Info("finished", lager.Data{"exit-status": exitStatus})
 }
 
+// NewestVersionSince resolves the newest version of the plan's resource since
+// the given one, so that it is recorded as the version the step fetched.
+func (d *getDelegate) NewestVersionSince(logger lager.Logger, plan atc.GetPlan, since atc.Version) (atc.Version, bool, error) {
+	pipeline, found, err := d.build.Pipeline()
+	if err != nil {
+		return nil, false, fmt.Errorf("find pipeline: %w", err)
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	resource, found, err := pipeline.Resource(plan.Resource)
+	if err != nil {
+		return nil, false, fmt.Errorf("find resource: %w", err)
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	rcv, found, err := resource.NewestVersionSince(since)
+	if err != nil {
+		return nil, false, fmt.Errorf("find newest version: %w", err)
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	version := atc.Version(rcv.Version())
+
+	logger.Info("resolved-newest-version", lager.Data{
+		"since":   since,
+		"version": version,
+	})
+
+	return version, true, nil
+}
+
+// SaveInput records the version the step fetched as the build's input, since
+// it may not be the version the build was scheduled with.
+func (d *getDelegate) SaveInput(log lager.Logger, plan atc.GetPlan, info runtime.VersionResult) {
+	logger := log.WithData(lager.Data{
+		"step":     plan.Name,
+		"resource": plan.Resource,
+		"version":  info.Version,
+	})
+
+	err := d.build.SaveInput(plan.Name, plan.Resource, info.Version)
+	if err != nil {
+		logger.Error("failed-to-save-input", err)
+		return
+	}
+}
+
 func (d *getDelegate) UpdateVersion(log lager.Logger, plan atc.GetPlan, info runtime.VersionResult) {
 	logger := log.WithData(lager.Data{
 		"pipeline-name": d.build.PipelineName(),
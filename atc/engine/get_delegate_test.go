@@ -60,7 +60,7 @@ var _ = Describe("GetDelegate", func() {
 		fakePolicyChecker = new(policyfakes.FakeChecker)
 		fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
 
-		delegate = engine.NewGetDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, fakeArtifactSourcer)
+		delegate = engine.NewGetDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, fakeArtifactSourcer, nil)
 	})
 
 	Describe("Finished", func() {
@@ -78,6 +78,91 @@ var _ = Describe("GetDelegate", func() {
 				FetchedMetadata: info.Metadata,
 			}))
 		})
+
+		It("stores the step's status", func() {
+			status, found := state.StepStatus("some-plan-id")
+			Expect(found).To(BeTrue())
+			Expect(status).To(Equal(atc.StatusSucceeded))
+		})
+	})
+
+	Describe("NewestVersionSince", func() {
+		var (
+			version atc.Version
+			found   bool
+			err     error
+		)
+
+		JustBeforeEach(func() {
+			plan := atc.GetPlan{Resource: "some-resource"}
+			version, found, err = delegate.NewestVersionSince(logger, plan, atc.Version{"some": "boundary"})
+		})
+
+		BeforeEach(func() {
+			fakeBuild.PipelineReturns(fakePipeline, true, nil)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		Context("when the resource has a newer version", func() {
+			BeforeEach(func() {
+				fakeVersion := new(dbfakes.FakeResourceConfigVersion)
+				fakeVersion.VersionReturns(db.Version{"some": "newest-version"})
+				fakeResource.NewestVersionSinceReturns(fakeVersion, true, nil)
+			})
+
+			It("returns it", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(version).To(Equal(atc.Version{"some": "newest-version"}))
+
+				Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("some-resource"))
+				Expect(fakeResource.NewestVersionSinceArgsForCall(0)).To(Equal(atc.Version{"some": "boundary"}))
+			})
+		})
+
+		Context("when the version is not found", func() {
+			BeforeEach(func() {
+				fakeResource.NewestVersionSinceReturns(nil, false, nil)
+			})
+
+			It("does not find anything", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the resource is not found", func() {
+			BeforeEach(func() {
+				fakePipeline.ResourceReturns(nil, false, nil)
+			})
+
+			It("does not find anything", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when finding the version fails", func() {
+			BeforeEach(func() {
+				fakeResource.NewestVersionSinceReturns(nil, false, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError("find newest version: nope"))
+			})
+		})
+	})
+
+	Describe("SaveInput", func() {
+		It("saves the fetched version as the build's input", func() {
+			delegate.SaveInput(logger, atc.GetPlan{Name: "some-input", Resource: "some-resource"}, info)
+
+			Expect(fakeBuild.SaveInputCallCount()).To(Equal(1))
+			inputName, resourceName, version := fakeBuild.SaveInputArgsForCall(0)
+			Expect(inputName).To(Equal("some-input"))
+			Expect(resourceName).To(Equal("some-resource"))
+			Expect(version).To(Equal(info.Version))
+		})
 	})
 
 	Describe("UpdateVersion", func() {
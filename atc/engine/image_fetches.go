@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// ImageFetches shares the images fetched by the steps of a build. An image
+// needed by several steps, or by both the check and the get of an image of a
+// custom resource type, is only fetched once; steps needing an image which is
+// still being fetched wait for that fetch rather than starting their own.
+//
+// A nil *ImageFetches shares nothing.
+type ImageFetches struct {
+	lock    sync.Mutex
+	fetches map[string]*imageFetch
+}
+
+type imageFetch struct {
+	done chan struct{}
+
+	spec worker.ImageSpec
+	err  error
+}
+
+func NewImageFetches() *ImageFetches {
+	return &ImageFetches{
+		fetches: map[string]*imageFetch{},
+	}
+}
+
+// Fetch returns the image fetched for the key, calling fetch if it has not
+// been fetched yet. Failed fetches are not shared: when the fetch being
+// waited on fails, the image is fetched again.
+func (f *ImageFetches) Fetch(ctx context.Context, key string, fetch func() (worker.ImageSpec, error)) (worker.ImageSpec, error) {
+	if f == nil {
+		return fetch()
+	}
+
+	for {
+		f.lock.Lock()
+		existing, found := f.fetches[key]
+		if !found {
+			started := &imageFetch{done: make(chan struct{})}
+			f.fetches[key] = started
+			f.lock.Unlock()
+
+			started.spec, started.err = fetch()
+			if started.err != nil {
+				f.lock.Lock()
+				delete(f.fetches, key)
+				f.lock.Unlock()
+			}
+
+			close(started.done)
+
+			return started.spec, started.err
+		}
+		f.lock.Unlock()
+
+		select {
+		case <-existing.done:
+			if existing.err == nil {
+				return existing.spec, nil
+			}
+		case <-ctx.Done():
+			return worker.ImageSpec{}, ctx.Err()
+		}
+	}
+}
+
+// imageFetchKey identifies an image by everything that goes into fetching it.
+func imageFetchKey(image atc.ImageResource, types atc.VersionedResourceTypes, privileged bool) (string, error) {
+	payload, err := json.Marshal(struct {
+		Image      atc.ImageResource          `json:"image"`
+		Types      atc.VersionedResourceTypes `json:"types"`
+		Privileged bool                       `json:"privileged"`
+	}{
+		Image:      image,
+		Types:      types,
+		Privileged: privileged,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256(payload)
+	return hex.EncodeToString(digest[:]), nil
+}
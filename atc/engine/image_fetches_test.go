@@ -0,0 +1,152 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ImageFetches", func() {
+	var (
+		imageFetches *engine.ImageFetches
+		fetched      worker.ImageSpec
+	)
+
+	BeforeEach(func() {
+		imageFetches = engine.NewImageFetches()
+		fetched = worker.ImageSpec{
+			ImageArtifactSource: new(workerfakes.FakeStreamableArtifactSource),
+		}
+	})
+
+	It("fetches each image once", func() {
+		fetches := 0
+		fetch := func() (worker.ImageSpec, error) {
+			fetches++
+			return fetched, nil
+		}
+
+		spec, err := imageFetches.Fetch(context.Background(), "some-key", fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(spec).To(Equal(fetched))
+
+		spec, err = imageFetches.Fetch(context.Background(), "some-key", fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(spec).To(Equal(fetched))
+		Expect(fetches).To(Equal(1))
+
+		_, err = imageFetches.Fetch(context.Background(), "some-other-key", fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetches).To(Equal(2))
+	})
+
+	It("has concurrent fetches of an image wait for the one in flight", func() {
+		release := make(chan struct{})
+
+		var lock sync.Mutex
+		fetches := 0
+		fetch := func() (worker.ImageSpec, error) {
+			lock.Lock()
+			fetches++
+			lock.Unlock()
+
+			<-release
+			return fetched, nil
+		}
+
+		results := make(chan worker.ImageSpec, 3)
+		for i := 0; i < 3; i++ {
+			go func() {
+				defer GinkgoRecover()
+
+				spec, err := imageFetches.Fetch(context.Background(), "some-key", fetch)
+				Expect(err).ToNot(HaveOccurred())
+				results <- spec
+			}()
+		}
+
+		Consistently(results).ShouldNot(Receive())
+		close(release)
+
+		for i := 0; i < 3; i++ {
+			Eventually(results).Should(Receive(Equal(fetched)))
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		Expect(fetches).To(Equal(1))
+	})
+
+	It("fetches the image again after a failed fetch", func() {
+		disaster := errors.New("nope")
+
+		_, err := imageFetches.Fetch(context.Background(), "some-key", func() (worker.ImageSpec, error) {
+			return worker.ImageSpec{}, disaster
+		})
+		Expect(err).To(Equal(disaster))
+
+		spec, err := imageFetches.Fetch(context.Background(), "some-key", func() (worker.ImageSpec, error) {
+			return fetched, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(spec).To(Equal(fetched))
+	})
+
+	It("stops waiting for a fetch in flight when the context is done", func() {
+		fetches := imageFetches
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+
+		go fetches.Fetch(context.Background(), "some-key", func() (worker.ImageSpec, error) {
+			close(started)
+			<-release
+			return worker.ImageSpec{}, nil
+		})
+
+		<-started
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		errs := make(chan error, 1)
+		go func() {
+			_, err := fetches.Fetch(ctx, "some-key", func() (worker.ImageSpec, error) {
+				return worker.ImageSpec{}, nil
+			})
+			errs <- err
+		}()
+
+		Consistently(errs).ShouldNot(Receive())
+		cancel()
+		Eventually(errs).Should(Receive(Equal(context.Canceled)))
+	})
+
+	Context("when nil", func() {
+		BeforeEach(func() {
+			imageFetches = nil
+		})
+
+		It("fetches the image every time", func() {
+			fetches := 0
+			fetch := func() (worker.ImageSpec, error) {
+				fetches++
+				return fetched, nil
+			}
+
+			_, err := imageFetches.Fetch(context.Background(), "some-key", fetch)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = imageFetches.Fetch(context.Background(), "some-key", fetch)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fetches).To(Equal(2))
+		})
+	})
+})
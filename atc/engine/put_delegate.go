@@ -22,10 +22,13 @@ func NewPutDelegate(
 	clock clock.Clock,
 	policyChecker policy.Checker,
 	artifactSourcer worker.ArtifactSourcer,
+	imageFetches *ImageFetches,
 ) exec.PutDelegate {
 	return &putDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer),
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer, imageFetches),
 
+		planID:      planID,
+		state:       state,
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
 		build:       build,
 		clock:       clock,
@@ -35,6 +38,8 @@ func NewPutDelegate(
 type putDelegate struct {
 	exec.BuildStepDelegate
 
+	planID      atc.PlanID
+	state       exec.RunState
 	build       db.Build
 	eventOrigin event.Origin
 	clock       clock.Clock
@@ -71,6 +76,8 @@ func (d *putDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus,
 	d.Stdout().(io.Closer).Close()
 	d.Stderr().(io.Closer).Close()
 
+	storeStepStatus(d.state, d.planID, exitStatus == 0)
+
 	err := d.build.SaveEvent(event.FinishPut{
 		Origin:          d.eventOrigin,
 		Time:            d.clock.Now().Unix(),
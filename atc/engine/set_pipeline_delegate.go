@@ -1,9 +1,12 @@
 package engine
 
 import (
+	"fmt"
+
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
@@ -14,6 +17,7 @@ func NewSetPipelineStepDelegate(
 	planID atc.PlanID,
 	state exec.RunState,
 	clock clock.Clock,
+	auditSink auditor.Sink,
 ) *setPipelineStepDelegate {
 	return &setPipelineStepDelegate{
 		buildStepDelegate{
@@ -24,11 +28,14 @@ func NewSetPipelineStepDelegate(
 			stdout: nil,
 			stderr: nil,
 		},
+		auditSink,
 	}
 }
 
 type setPipelineStepDelegate struct {
 	buildStepDelegate
+
+	auditSink auditor.Sink
 }
 
 func (delegate *setPipelineStepDelegate) SetPipelineChanged(logger lager.Logger, changed bool) {
@@ -45,3 +52,37 @@ func (delegate *setPipelineStepDelegate) SetPipelineChanged(logger lager.Logger,
 
 	logger.Debug("set pipeline changed")
 }
+
+// AuthorizeTeam permits a team to set its own pipelines, and admin teams
+// to set the pipelines of any team.
+func (delegate *setPipelineStepDelegate) AuthorizeTeam(logger lager.Logger, currentTeam db.Team, targetTeam db.Team) error {
+	if targetTeam.ID() == currentTeam.ID() || currentTeam.Admin() {
+		return nil
+	}
+
+	logger.Info("unauthorized-to-set-pipeline-for-team", lager.Data{
+		"team":        currentTeam.Name(),
+		"target-team": targetTeam.Name(),
+	})
+
+	return fmt.Errorf("only %s team can set another team's pipeline", atc.DefaultTeamName)
+}
+
+func (delegate *setPipelineStepDelegate) AuditCrossTeamWrite(logger lager.Logger, currentTeam db.Team, targetTeam db.Team, pipelineRef atc.PipelineRef) {
+	if delegate.auditSink == nil {
+		return
+	}
+
+	err := delegate.auditSink.Send(auditor.Record{
+		Time:     delegate.clock.Now(),
+		Type:     auditor.RecordTypeBuild,
+		Action:   atc.SaveConfig,
+		BuildID:  delegate.build.ID(),
+		FromTeam: currentTeam.Name(),
+		Team:     targetTeam.Name(),
+		Pipeline: pipelineRef.String(),
+	})
+	if err != nil {
+		logger.Error("failed-to-audit-cross-team-write", err)
+	}
+}
@@ -8,6 +8,9 @@ import (
 
 	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/event"
@@ -17,9 +20,10 @@ import (
 
 var _ = Describe("SetPipelineStepDelegate", func() {
 	var (
-		logger    *lagertest.TestLogger
-		fakeBuild *dbfakes.FakeBuild
-		fakeClock *fakeclock.FakeClock
+		logger        *lagertest.TestLogger
+		fakeBuild     *dbfakes.FakeBuild
+		fakeClock     *fakeclock.FakeClock
+		fakeAuditSink *auditorfakes.FakeSink
 
 		state exec.RunState
 
@@ -32,13 +36,14 @@ var _ = Describe("SetPipelineStepDelegate", func() {
 
 		fakeBuild = new(dbfakes.FakeBuild)
 		fakeClock = fakeclock.NewFakeClock(now)
+		fakeAuditSink = new(auditorfakes.FakeSink)
 		credVars := vars.StaticVariables{
 			"source-param": "super-secret-source",
 			"git-key":      "{\n123\n456\n789\n}\n",
 		}
 		state = exec.NewRunState(noopStepper, credVars, true)
 
-		delegate = engine.NewSetPipelineStepDelegate(fakeBuild, "some-plan-id", state, fakeClock)
+		delegate = engine.NewSetPipelineStepDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakeAuditSink)
 	})
 
 	Describe("SetPipelineChanged", func() {
@@ -54,4 +59,78 @@ var _ = Describe("SetPipelineStepDelegate", func() {
 			}))
 		})
 	})
+
+	Describe("AuthorizeTeam", func() {
+		var (
+			currentTeam *dbfakes.FakeTeam
+			targetTeam  *dbfakes.FakeTeam
+		)
+
+		BeforeEach(func() {
+			currentTeam = new(dbfakes.FakeTeam)
+			currentTeam.IDReturns(1)
+			currentTeam.NameReturns("some-team")
+
+			targetTeam = new(dbfakes.FakeTeam)
+			targetTeam.IDReturns(2)
+			targetTeam.NameReturns("some-other-team")
+		})
+
+		It("permits a team to set its own pipelines", func() {
+			Expect(delegate.AuthorizeTeam(logger, currentTeam, currentTeam)).To(Succeed())
+		})
+
+		It("does not permit a team to set another team's pipelines", func() {
+			Expect(delegate.AuthorizeTeam(logger, currentTeam, targetTeam)).To(MatchError("only main team can set another team's pipeline"))
+		})
+
+		Context("when the team is an admin team", func() {
+			BeforeEach(func() {
+				currentTeam.AdminReturns(true)
+			})
+
+			It("permits it to set another team's pipelines", func() {
+				Expect(delegate.AuthorizeTeam(logger, currentTeam, targetTeam)).To(Succeed())
+			})
+		})
+	})
+
+	Describe("AuditCrossTeamWrite", func() {
+		BeforeEach(func() {
+			fakeBuild.IDReturns(42)
+		})
+
+		JustBeforeEach(func() {
+			currentTeam := new(dbfakes.FakeTeam)
+			currentTeam.NameReturns("main")
+
+			targetTeam := new(dbfakes.FakeTeam)
+			targetTeam.NameReturns("some-team")
+
+			delegate.AuditCrossTeamWrite(logger, currentTeam, targetTeam, atc.PipelineRef{Name: "some-pipeline"})
+		})
+
+		It("sends an audit record of the write", func() {
+			Expect(fakeAuditSink.SendCallCount()).To(Equal(1))
+			Expect(fakeAuditSink.SendArgsForCall(0)).To(Equal(auditor.Record{
+				Time:     now,
+				Type:     auditor.RecordTypeBuild,
+				Action:   atc.SaveConfig,
+				BuildID:  42,
+				FromTeam: "main",
+				Team:     "some-team",
+				Pipeline: "some-pipeline",
+			}))
+		})
+
+		Context("when no audit sink is configured", func() {
+			BeforeEach(func() {
+				delegate = engine.NewSetPipelineStepDelegate(fakeBuild, "some-plan-id", state, fakeClock, nil)
+			})
+
+			It("does not send a record", func() {
+				Expect(fakeAuditSink.SendCallCount()).To(BeZero())
+			})
+		})
+	})
 })
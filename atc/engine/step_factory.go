@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/publish"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 )
@@ -25,6 +26,11 @@ type coreStepFactory struct {
 	defaultLimits         atc.ContainerLimits
 	strategy              worker.ContainerPlacementStrategy
 	defaultCheckTimeout   time.Duration
+	buildTokenIssuer      exec.BuildTokenIssuer
+	taskMemoFactory       db.TaskMemoFactory
+	approvalFactory       db.ApprovalFactory
+	publishStore          publish.Store
+	publishedArtifacts    db.PublishedArtifactFactory
 }
 
 func NewCoreStepFactory(
@@ -39,6 +45,11 @@ func NewCoreStepFactory(
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	defaultCheckTimeout time.Duration,
+	buildTokenIssuer exec.BuildTokenIssuer,
+	taskMemoFactory db.TaskMemoFactory,
+	approvalFactory db.ApprovalFactory,
+	publishStore publish.Store,
+	publishedArtifacts db.PublishedArtifactFactory,
 ) CoreStepFactory {
 	return &coreStepFactory{
 		pool:                  pool,
@@ -52,6 +63,11 @@ func NewCoreStepFactory(
 		defaultLimits:         defaultLimits,
 		strategy:              strategy,
 		defaultCheckTimeout:   defaultCheckTimeout,
+		buildTokenIssuer:      buildTokenIssuer,
+		taskMemoFactory:       taskMemoFactory,
+		approvalFactory:       approvalFactory,
+		publishStore:          publishStore,
+		publishedArtifacts:    publishedArtifacts,
 	}
 }
 
@@ -75,6 +91,7 @@ func (factory *coreStepFactory) GetStep(
 		factory.pool,
 	)
 
+	getStep = exec.MeasureDuration(getStep, "get")
 	getStep = exec.LogError(getStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		getStep = exec.RetryError(getStep, delegateFactory)
@@ -103,6 +120,7 @@ func (factory *coreStepFactory) PutStep(
 		delegateFactory,
 	)
 
+	putStep = exec.MeasureDuration(putStep, "put")
 	putStep = exec.LogError(putStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		putStep = exec.RetryError(putStep, delegateFactory)
@@ -131,6 +149,7 @@ func (factory *coreStepFactory) CheckStep(
 		factory.defaultCheckTimeout,
 	)
 
+	checkStep = exec.MeasureDuration(checkStep, "check")
 	checkStep = exec.LogError(checkStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		checkStep = exec.RetryError(checkStep, delegateFactory)
@@ -158,8 +177,11 @@ func (factory *coreStepFactory) TaskStep(
 		factory.artifactStreamer,
 		factory.artifactSourcer,
 		delegateFactory,
+		factory.buildTokenIssuer,
+		factory.taskMemoFactory,
 	)
 
+	taskStep = exec.MeasureDuration(taskStep, "task")
 	taskStep = exec.LogError(taskStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		taskStep = exec.RetryError(taskStep, delegateFactory)
@@ -183,6 +205,7 @@ func (factory *coreStepFactory) SetPipelineStep(
 		delegateFactory.policyChecker,
 	)
 
+	spStep = exec.MeasureDuration(spStep, "set_pipeline")
 	spStep = exec.LogError(spStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		spStep = exec.RetryError(spStep, delegateFactory)
@@ -203,6 +226,7 @@ func (factory *coreStepFactory) LoadVarStep(
 		factory.artifactStreamer,
 	)
 
+	loadVarStep = exec.MeasureDuration(loadVarStep, "load_var")
 	loadVarStep = exec.LogError(loadVarStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		loadVarStep = exec.RetryError(loadVarStep, delegateFactory)
@@ -210,6 +234,113 @@ func (factory *coreStepFactory) LoadVarStep(
 	return loadVarStep
 }
 
+func (factory *coreStepFactory) LoadVarsStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	loadVarsStep := exec.NewLoadVarsStep(
+		plan.ID,
+		*plan.LoadVars,
+		stepMetadata,
+		delegateFactory,
+		factory.artifactStreamer,
+	)
+
+	loadVarsStep = exec.MeasureDuration(loadVarsStep, "load_vars")
+	loadVarsStep = exec.LogError(loadVarsStep, delegateFactory)
+	if atc.EnableBuildRerunWhenWorkerDisappears {
+		loadVarsStep = exec.RetryError(loadVarsStep, delegateFactory)
+	}
+	return loadVarsStep
+}
+
+func (factory *coreStepFactory) ApprovalStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	approvalStep := exec.NewApprovalStep(
+		plan.ID,
+		*plan.Approval,
+		stepMetadata,
+		factory.approvalFactory,
+		delegateFactory,
+	)
+
+	approvalStep = exec.MeasureDuration(approvalStep, "approval")
+	approvalStep = exec.LogError(approvalStep, delegateFactory)
+	return approvalStep
+}
+
+func (factory *coreStepFactory) RunStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	containerMetadata db.ContainerMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	sum := sha1.Sum([]byte(plan.Run.Name))
+	containerMetadata.WorkingDirectory = filepath.Join("/tmp", "build", fmt.Sprintf("%x", sum[:4]))
+
+	runStep := exec.NewRunStep(
+		plan.ID,
+		*plan.Run,
+		stepMetadata,
+		containerMetadata,
+		factory.strategy,
+		factory.pool,
+		factory.artifactSourcer,
+		delegateFactory,
+	)
+
+	runStep = exec.MeasureDuration(runStep, "run")
+	runStep = exec.LogError(runStep, delegateFactory)
+	if atc.EnableBuildRerunWhenWorkerDisappears {
+		runStep = exec.RetryError(runStep, delegateFactory)
+	}
+	return runStep
+}
+
+func (factory *coreStepFactory) PublishStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	publishStep := exec.NewPublishStep(
+		plan.ID,
+		*plan.Publish,
+		stepMetadata,
+		factory.artifactStreamer,
+		factory.publishStore,
+		factory.publishedArtifacts,
+		delegateFactory,
+	)
+
+	publishStep = exec.MeasureDuration(publishStep, "publish")
+	publishStep = exec.LogError(publishStep, delegateFactory)
+	if atc.EnableBuildRerunWhenWorkerDisappears {
+		publishStep = exec.RetryError(publishStep, delegateFactory)
+	}
+	return publishStep
+}
+
+func (factory *coreStepFactory) DynamicAcrossStep(
+	plan atc.Plan,
+	buildSubStep exec.AcrossSubStepBuilder,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	return exec.DynamicAcross(
+		plan.Across.Vars,
+		*plan.Across.SubStepTemplate,
+		buildSubStep,
+		plan.Across.FailFast,
+		delegateFactory,
+		stepMetadata,
+		factory.artifactStreamer,
+	)
+}
+
 func (factory *coreStepFactory) ArtifactInputStep(
 	plan atc.Plan,
 	build db.Build,
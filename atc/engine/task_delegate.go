@@ -23,10 +23,13 @@ func NewTaskDelegate(
 	artifactSourcer worker.ArtifactSourcer,
 	dbWorkerFactory db.WorkerFactory,
 	lockFactory lock.LockFactory,
+	imageFetches *ImageFetches,
 ) exec.TaskDelegate {
 	return &taskDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer),
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer, imageFetches),
 
+		planID:      planID,
+		state:       state,
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
 		build:       build,
 		clock:       clock,
@@ -40,6 +43,8 @@ type taskDelegate struct {
 	exec.BuildStepDelegate
 
 	config      atc.TaskConfig
+	planID      atc.PlanID
+	state       exec.RunState
 	build       db.Build
 	eventOrigin event.Origin
 	clock       clock.Clock
@@ -90,6 +95,8 @@ func (d *taskDelegate) Finished(
 	d.Stdout().(io.Closer).Close()
 	d.Stderr().(io.Closer).Close()
 
+	storeStepStatus(d.state, d.planID, exitStatus == 0)
+
 	err := d.build.SaveEvent(event.FinishTask{
 		ExitStatus: int(exitStatus),
 		Time:       d.clock.Now().Unix(),
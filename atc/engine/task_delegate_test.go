@@ -60,7 +60,7 @@ var _ = Describe("TaskDelegate", func() {
 		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
 		fakeLockFactory = new(lockfakes.FakeLockFactory)
 
-		delegate = NewTaskDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, fakeArtifactSourcer, fakeWorkerFactory, fakeLockFactory).(*taskDelegate)
+		delegate = NewTaskDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, fakeArtifactSourcer, fakeWorkerFactory, fakeLockFactory, nil).(*taskDelegate)
 
 		delegate.SetTaskConfig(atc.TaskConfig{
 			Platform: "some-platform",
@@ -131,6 +131,37 @@ var _ = Describe("TaskDelegate", func() {
 				}
 			}`))
 		})
+
+		Context("when the task is hermetic", func() {
+			BeforeEach(func() {
+				delegate.SetTaskConfig(atc.TaskConfig{
+					Platform: "some-platform",
+					Run: atc.TaskRunConfig{
+						Path: "some-foo-path",
+					},
+					Hermetic: true,
+				})
+			})
+
+			It("records it in the event", func() {
+				event := fakeBuild.SaveEventArgsForCall(0)
+				Expect(json.Marshal(event)).To(MatchJSON(`{
+					"time": 675927000,
+					"origin": {"id": "some-plan-id"},
+					"config": {
+						"platform": "some-platform",
+						"image":"",
+						"run": {
+							"path": "some-foo-path",
+							"args": null,
+							"dir": ""
+						},
+						"inputs":null,
+						"hermetic": true
+					}
+				}`))
+			})
+		})
 	})
 
 	Describe("Finished", func() {
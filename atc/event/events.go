@@ -40,6 +40,8 @@ type TaskConfig struct {
 
 	Run    TaskRunConfig     `json:"run"`
 	Inputs []TaskInputConfig `json:"inputs"`
+
+	Hermetic bool `json:"hermetic,omitempty"`
 }
 
 type TaskRunConfig struct {
@@ -71,7 +73,8 @@ func ShadowTaskConfig(config atc.TaskConfig) TaskConfig {
 			Args: config.Run.Args,
 			Dir:  config.Run.Dir,
 		},
-		Inputs: inputConfigs,
+		Inputs:   inputConfigs,
+		Hermetic: config.Hermetic,
 	}
 }
 
@@ -109,6 +112,44 @@ type SelectedWorker struct {
 func (SelectedWorker) EventType() atc.EventType  { return EventTypeSelectedWorker }
 func (SelectedWorker) Version() atc.EventVersion { return "1.0" }
 
+type WaitingToRetry struct {
+	Time    int64  `json:"time"`
+	Origin  Origin `json:"origin"`
+	Attempt int    `json:"attempt"`
+	Delay   string `json:"delay"`
+}
+
+func (WaitingToRetry) EventType() atc.EventType  { return EventTypeWaitingToRetry }
+func (WaitingToRetry) Version() atc.EventVersion { return "1.0" }
+
+type WaitingForSemaphore struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Name   string `json:"name"`
+	Limit  int    `json:"limit"`
+}
+
+func (WaitingForSemaphore) EventType() atc.EventType  { return EventTypeWaitingForSemaphore }
+func (WaitingForSemaphore) Version() atc.EventVersion { return "1.0" }
+
+type Skipped struct {
+	Time      int64  `json:"time"`
+	Origin    Origin `json:"origin"`
+	Condition string `json:"condition"`
+}
+
+func (Skipped) EventType() atc.EventType  { return EventTypeSkipped }
+func (Skipped) Version() atc.EventVersion { return "1.0" }
+
+type SoftTimeout struct {
+	Time     int64  `json:"time"`
+	Origin   Origin `json:"origin"`
+	Duration string `json:"duration"`
+}
+
+func (SoftTimeout) EventType() atc.EventType  { return EventTypeSoftTimeout }
+func (SoftTimeout) Version() atc.EventVersion { return "1.0" }
+
 type Log struct {
 	Time    int64  `json:"time"`
 	Origin  Origin `json:"origin"`
@@ -47,6 +47,10 @@ func init() {
 	RegisterEvent(Status{})
 	RegisterEvent(WaitingForWorker{})
 	RegisterEvent(SelectedWorker{})
+	RegisterEvent(WaitingToRetry{})
+	RegisterEvent(WaitingForSemaphore{})
+	RegisterEvent(Skipped{})
+	RegisterEvent(SoftTimeout{})
 	RegisterEvent(Log{})
 	RegisterEvent(Error{})
 	RegisterEvent(ImageCheck{})
@@ -68,6 +68,8 @@ var _ = Describe("ParseEvent", func() {
 		Entry("SetPipelineChanged", event.SetPipelineChanged{}),
 		Entry("Status", event.Status{}),
 		Entry("WaitingForWorker", event.WaitingForWorker{}),
+		Entry("WaitingForSemaphore", event.WaitingForSemaphore{}),
+		Entry("Skipped", event.Skipped{}),
 		Entry("SelectedWorker", event.SelectedWorker{}),
 		Entry("Log", event.Log{}),
 		Entry("Error", event.Error{}),
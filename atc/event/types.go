@@ -15,6 +15,18 @@ const (
 	// a step (get/put/task) selected worker
 	EventTypeSelectedWorker atc.EventType = "selected-worker"
 
+	// a step with attempts is waiting before retrying
+	EventTypeWaitingToRetry atc.EventType = "waiting-to-retry"
+
+	// a step is waiting to acquire a semaphore held by other steps
+	EventTypeWaitingForSemaphore atc.EventType = "waiting-for-semaphore"
+
+	// a step was skipped as its `when:` condition did not hold
+	EventTypeSkipped atc.EventType = "skipped"
+
+	// a step has been running for longer than its soft timeout
+	EventTypeSoftTimeout atc.EventType = "soft-timeout"
+
 	// task execution started
 	EventTypeStartTask atc.EventType = "start-task"
 
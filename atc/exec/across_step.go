@@ -2,11 +2,16 @@ package exec
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -23,10 +28,28 @@ type AcrossStep struct {
 	steps    []ScopedStep
 	failFast bool
 
+	// set when the values of any of the vars are read from a file, in which
+	// case the steps are built from the template once the values are known
+	template         *atc.Plan
+	buildSubStep     AcrossSubStepBuilder
+	artifactStreamer worker.ArtifactStreamer
+
 	delegateFactory BuildStepDelegateFactory
 	metadata        StepMetadata
 }
 
+// AcrossSubStepBuilder builds the substep to run for a copy of the template.
+type AcrossSubStepBuilder func(atc.Plan) Step
+
+type AcrossValuesFileNotListError struct {
+	File string
+}
+
+// Error returns a human-friendly error message.
+func (err AcrossValuesFileNotListError) Error() string {
+	return fmt.Sprintf("values file '%s' does not contain a list", err.File)
+}
+
 // Across constructs an AcrossStep.
 func Across(
 	vars []atc.AcrossVar,
@@ -44,6 +67,29 @@ func Across(
 	}
 }
 
+// DynamicAcross constructs an AcrossStep whose values are read from files when
+// it runs. A substep is then built from the template for each combination of
+// values.
+func DynamicAcross(
+	vars []atc.AcrossVar,
+	template atc.Plan,
+	buildSubStep AcrossSubStepBuilder,
+	failFast bool,
+	delegateFactory BuildStepDelegateFactory,
+	metadata StepMetadata,
+	artifactStreamer worker.ArtifactStreamer,
+) AcrossStep {
+	return AcrossStep{
+		vars:             vars,
+		failFast:         failFast,
+		template:         &template,
+		buildSubStep:     buildSubStep,
+		artifactStreamer: artifactStreamer,
+		delegateFactory:  delegateFactory,
+		metadata:         metadata,
+	}
+}
+
 // Run calls out to InParallelStep.Run after logging a warning to stderr. It also emits
 // step lifecycle build events (Initializing, Starting, and Finished).
 func (step AcrossStep) Run(ctx context.Context, state RunState) (bool, error) {
@@ -72,17 +118,205 @@ func (step AcrossStep) Run(ctx context.Context, state RunState) (bool, error) {
 
 	delegate.Starting(logger)
 
+	if step.template != nil {
+		var (
+			plans []atc.VarScopedPlan
+			err   error
+		)
+
+		step.vars, plans, err = step.resolveValues(lagerctx.NewContext(ctx, logger), state, delegate)
+		if err != nil {
+			delegate.Errored(logger, err.Error())
+			return false, err
+		}
+
+		// the build's plan is updated with the substeps so that their events
+		// can be shown
+		delegate.UpdatePlan(logger, atc.Plan{
+			Across: &atc.AcrossPlan{
+				Vars:     step.vars,
+				Steps:    plans,
+				FailFast: step.failFast,
+			},
+		})
+
+		step.steps = make([]ScopedStep, len(plans))
+		for i, plan := range plans {
+			step.steps[i] = ScopedStep{
+				Step:   step.buildSubStep(plan.Step),
+				Values: plan.Values,
+			}
+		}
+	}
+
 	exec := step.acrossStepExecutor(state, 0, step.steps)
 	succeeded, err := exec.run(ctx)
 	if err != nil {
 		return false, err
 	}
 
+	if step.template != nil {
+		step.storeTemplateStatuses(state)
+	}
+
 	delegate.Finished(logger, succeeded)
 
 	return succeeded, nil
 }
 
+// resolveValues reads the values of any vars from their files and plans the
+// substep for each combination of values, in the order expected by
+// acrossStepExecutor.
+func (step AcrossStep) resolveValues(ctx context.Context, state RunState, delegate BuildStepDelegate) ([]atc.AcrossVar, []atc.VarScopedPlan, error) {
+	resolved := make([]atc.AcrossVar, len(step.vars))
+	for i, v := range step.vars {
+		resolved[i] = v
+		if v.ValuesFile == "" {
+			continue
+		}
+
+		values, err := step.readValuesFile(ctx, state, v.ValuesFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		resolved[i].Values = values
+		fmt.Fprintf(delegate.Stdout(), "%d values for var %s fetched from %s.\n", len(values), v.Var, v.ValuesFile)
+	}
+
+	combinations := [][]interface{}{{}}
+	for _, v := range resolved {
+		var next [][]interface{}
+		for _, combination := range combinations {
+			for _, val := range v.Values {
+				values := make([]interface{}, len(combination), len(combination)+1)
+				copy(values, combination)
+				next = append(next, append(values, val))
+			}
+		}
+		combinations = next
+	}
+
+	plans := make([]atc.VarScopedPlan, len(combinations))
+	for i, values := range combinations {
+		plan, err := acrossSubStepPlan(*step.template, i)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		plans[i] = atc.VarScopedPlan{
+			Step:   plan,
+			Values: values,
+		}
+	}
+
+	return resolved, plans, nil
+}
+
+// acrossSubStepPlan returns a copy of the template for the combination of
+// values at the given index. Each plan ID in it is suffixed by the index so
+// that the substeps' events can be told apart, along with the references to
+// them from within the template.
+func acrossSubStepPlan(template atc.Plan, index int) (atc.Plan, error) {
+	payload, err := json.Marshal(template)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	var subPlan atc.Plan
+	err = json.Unmarshal(payload, &subPlan)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	ids := map[atc.PlanID]bool{}
+	template.Each(func(p *atc.Plan) {
+		ids[p.ID] = true
+	})
+
+	subPlanID := func(id atc.PlanID) atc.PlanID {
+		if !ids[id] {
+			return id
+		}
+
+		return atc.PlanID(fmt.Sprintf("%s/%d", id, index))
+	}
+
+	subPlan.Each(func(p *atc.Plan) {
+		p.ID = subPlanID(p.ID)
+
+		if p.Get != nil && p.Get.VersionFrom != nil {
+			versionFrom := subPlanID(*p.Get.VersionFrom)
+			p.Get.VersionFrom = &versionFrom
+		}
+
+		if p.When != nil {
+			for name, stepIDs := range p.When.Steps {
+				for i, id := range stepIDs {
+					p.When.Steps[name][i] = subPlanID(id)
+				}
+			}
+		}
+	})
+
+	return subPlan, nil
+}
+
+// storeTemplateStatuses stores the status of the last substep which ran each
+// plan of the template as the status of that plan, which is what a `when:`
+// condition following the step refers to.
+func (step AcrossStep) storeTemplateStatuses(state RunState) {
+	step.template.Each(func(p *atc.Plan) {
+		for i := len(step.steps) - 1; i >= 0; i-- {
+			status, found := state.StepStatus(atc.PlanID(fmt.Sprintf("%s/%d", p.ID, i)))
+			if found {
+				state.StoreStepStatus(p.ID, status)
+				return
+			}
+		}
+	})
+}
+
+func (step AcrossStep) readValuesFile(ctx context.Context, state RunState, file string) ([]interface{}, error) {
+	segs := strings.SplitN(file, "/", 2)
+	if len(segs) != 2 {
+		return nil, UnspecifiedLoadVarStepFileError{file}
+	}
+
+	artifactName := segs[0]
+	filePath := segs[1]
+
+	art, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName(artifactName))
+	if !found {
+		return nil, artifact.UnknownArtifactSourceError{
+			Name: artifactName,
+			Path: filePath,
+		}
+	}
+
+	content, err := readArtifactFile(ctx, step.artifactStreamer, art, artifactName, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := varFileFormat("", file)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := parseVarFile(file, format, content)
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := parsed.([]interface{})
+	if !ok {
+		return nil, AcrossValuesFileNotListError{file}
+	}
+
+	return values, nil
+}
+
 func (step AcrossStep) acrossStepExecutor(state RunState, varIndex int, steps []ScopedStep) parallelExecutor {
 	if varIndex == len(step.vars)-1 {
 		return step.acrossStepLeafExecutor(state, steps)
@@ -2,11 +2,19 @@ package exec_test
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
 
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/exec/build/buildfakes"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
 	"github.com/concourse/concourse/vars"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -279,6 +287,190 @@ var _ = Describe("AcrossStep", func() {
 		})
 	})
 
+	Describe("reading values from a file", func() {
+		var (
+			fakeArtifactStreamer *workerfakes.FakeArtifactStreamer
+			fakeArtifact         *buildfakes.FakeRegisterableArtifact
+			valuesFile           string
+			template             atc.Plan
+			builtSubPlans        []atc.Plan
+			stdout               *gbytes.Buffer
+		)
+
+		BeforeEach(func() {
+			acrossVars[1] = atc.AcrossVar{
+				Var:        "var2",
+				ValuesFile: "some-artifact/values.json",
+			}
+
+			fakeArtifact = new(buildfakes.FakeRegisterableArtifact)
+			state.ArtifactRepository().RegisterArtifact("some-artifact", fakeArtifact)
+
+			valuesFile = `["b1", "b2"]`
+			fakeArtifactStreamer = new(workerfakes.FakeArtifactStreamer)
+			fakeArtifactStreamer.StreamFileFromArtifactStub = func(context.Context, runtime.Artifact, string) (io.ReadCloser, error) {
+				return &fakeReadCloser{str: valuesFile}, nil
+			}
+
+			versionFrom := atc.PlanID("some-check")
+			outsideVersionFrom := atc.PlanID("some-outside-check")
+			template = atc.Plan{
+				ID: "some-template",
+				Do: &atc.DoPlan{
+					{
+						ID:    "some-check",
+						Check: &atc.CheckPlan{Name: "some-resource"},
+					},
+					{
+						ID:  "some-get",
+						Get: &atc.GetPlan{Name: "some-resource", VersionFrom: &versionFrom},
+					},
+					{
+						ID:  "some-other-get",
+						Get: &atc.GetPlan{Name: "some-other-resource", VersionFrom: &outsideVersionFrom},
+					},
+					{
+						ID: "some-when",
+						When: &atc.WhenPlan{
+							Condition: "steps.some-resource.status == 'succeeded'",
+							Steps: map[string][]atc.PlanID{
+								"some-resource": {"some-outside-check", "some-get"},
+							},
+							Step: atc.Plan{ID: "some-task", Task: &atc.TaskPlan{Name: "some-task"}},
+						},
+					},
+				},
+			}
+
+			builtSubPlans = nil
+
+			stdout = gbytes.NewBuffer()
+			fakeDelegate.StdoutReturns(stdout)
+		})
+
+		JustBeforeEach(func() {
+			step = exec.DynamicAcross(
+				acrossVars,
+				template,
+				func(plan atc.Plan) exec.Step {
+					builtSubPlans = append(builtSubPlans, plan)
+					index, err := strconv.Atoi(strings.TrimPrefix(string(plan.ID), "some-template/"))
+					Expect(err).ToNot(HaveOccurred())
+					return steps[index].Step
+				},
+				failFast,
+				fakeDelegateFactory,
+				stepMetadata,
+				fakeArtifactStreamer,
+			)
+		})
+
+		It("reads the file from the artifact", func() {
+			_, err := step.Run(ctx, state)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeArtifactStreamer.StreamFileFromArtifactCallCount()).To(Equal(1))
+			_, art, filePath := fakeArtifactStreamer.StreamFileFromArtifactArgsForCall(0)
+			Expect(art).To(Equal(fakeArtifact))
+			Expect(filePath).To(Equal("values.json"))
+
+			Expect(stdout).To(gbytes.Say("2 values for var var2 fetched from some-artifact/values.json."))
+		})
+
+		It("runs a substep for each combination of values", func() {
+			ok, err := step.Run(ctx, state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+
+			Expect(builtSubPlans).To(HaveLen(8))
+			for i, plan := range builtSubPlans {
+				Expect(plan.ID).To(Equal(atc.PlanID(fmt.Sprintf("some-template/%d", i))))
+			}
+			Expect(started).To(HaveLen(8))
+		})
+
+		It("suffixes the plan IDs in the template and the references to them", func() {
+			_, err := step.Run(ctx, state)
+			Expect(err).ToNot(HaveOccurred())
+
+			plan := builtSubPlans[1]
+			do := *plan.Do
+			Expect(do[0].ID).To(Equal(atc.PlanID("some-check/1")))
+			Expect(do[1].ID).To(Equal(atc.PlanID("some-get/1")))
+			Expect(*do[1].Get.VersionFrom).To(Equal(atc.PlanID("some-check/1")))
+			Expect(*do[2].Get.VersionFrom).To(Equal(atc.PlanID("some-outside-check")))
+			Expect(do[3].When.Steps).To(Equal(map[string][]atc.PlanID{
+				"some-resource": {"some-outside-check", "some-get/1"},
+			}))
+			Expect(do[3].When.Step.ID).To(Equal(atc.PlanID("some-task/1")))
+
+			By("leaving the template alone")
+			Expect(*(*template.Do)[1].Get.VersionFrom).To(Equal(atc.PlanID("some-check")))
+		})
+
+		It("updates the build's plan with the substeps", func() {
+			_, err := step.Run(ctx, state)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeDelegate.UpdatePlanCallCount()).To(Equal(1))
+			_, plan := fakeDelegate.UpdatePlanArgsForCall(0)
+			Expect(plan.Across.Vars[1].Values).To(Equal([]interface{}{"b1", "b2"}))
+			Expect(plan.Across.Steps).To(HaveLen(8))
+			Expect(plan.Across.Steps[1].Values).To(Equal([]interface{}{"a1", "b1", "c2"}))
+			Expect(plan.Across.Steps[1].Step).To(Equal(builtSubPlans[1]))
+			Expect(plan.Across.SubStepTemplate).To(BeNil())
+		})
+
+		It("stores the status of the last substep to run a plan of the template as its status", func() {
+			state.StoreStepStatus("some-get/6", atc.StatusSucceeded)
+			state.StoreStepStatus("some-get/7", atc.StatusFailed)
+			state.StoreStepStatus("some-task/2", atc.StatusSucceeded)
+
+			_, err := step.Run(ctx, state)
+			Expect(err).ToNot(HaveOccurred())
+
+			status, found := state.StepStatus("some-get")
+			Expect(found).To(BeTrue())
+			Expect(status).To(Equal(atc.StatusFailed))
+
+			status, found = state.StepStatus("some-task")
+			Expect(found).To(BeTrue())
+			Expect(status).To(Equal(atc.StatusSucceeded))
+
+			_, found = state.StepStatus("some-check")
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the file does not contain a list", func() {
+			BeforeEach(func() {
+				valuesFile = `{"b1": "b2"}`
+			})
+
+			It("errors without running any substeps", func() {
+				_, err := step.Run(ctx, state)
+				Expect(err).To(Equal(exec.AcrossValuesFileNotListError{File: "some-artifact/values.json"}))
+
+				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+				Expect(builtSubPlans).To(BeEmpty())
+				Expect(started).To(BeEmpty())
+			})
+		})
+
+		Context("when the artifact is not registered", func() {
+			BeforeEach(func() {
+				acrossVars[1].ValuesFile = "some-other-artifact/values.json"
+			})
+
+			It("errors", func() {
+				_, err := step.Run(ctx, state)
+				Expect(err).To(Equal(artifact.UnknownArtifactSourceError{
+					Name: "some-other-artifact",
+					Path: "values.json",
+				}))
+			})
+		})
+	})
+
 	Describe("panic recovery", func() {
 		Context("when one step panics", func() {
 			BeforeEach(func() {
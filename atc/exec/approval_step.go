@@ -0,0 +1,123 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
+)
+
+// ApprovalPollingInterval is how often an ApprovalStep checks whether its
+// approval has been decided.
+var ApprovalPollingInterval = 5 * time.Second
+
+// ApprovalStep pauses the build until a user approves or rejects it through
+// the API. It succeeds if approved and fails if rejected.
+type ApprovalStep struct {
+	planID          atc.PlanID
+	plan            atc.ApprovalPlan
+	metadata        StepMetadata
+	approvalFactory db.ApprovalFactory
+	delegateFactory BuildStepDelegateFactory
+}
+
+func NewApprovalStep(
+	planID atc.PlanID,
+	plan atc.ApprovalPlan,
+	metadata StepMetadata,
+	approvalFactory db.ApprovalFactory,
+	delegateFactory BuildStepDelegateFactory,
+) Step {
+	return &ApprovalStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		approvalFactory: approvalFactory,
+		delegateFactory: delegateFactory,
+	}
+}
+
+func (step *ApprovalStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.BuildStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "approval", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, state, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *ApprovalStep) run(ctx context.Context, state RunState, delegate BuildStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("approval-step", lager.Data{
+		"approval": step.plan.Name,
+		"build-id": step.metadata.BuildID,
+	})
+
+	delegate.Initializing(logger)
+
+	err := step.approvalFactory.Request(step.metadata.BuildID, step.planID, step.plan.Name, step.plan.Approvers)
+	if err != nil {
+		return false, fmt.Errorf("request approval: %w", err)
+	}
+
+	delegate.Starting(logger)
+
+	stdout := delegate.Stdout()
+	if len(step.plan.Approvers) > 0 {
+		fmt.Fprintf(stdout, "waiting for approval from %s\n", strings.Join(step.plan.Approvers, ", "))
+	} else {
+		fmt.Fprintf(stdout, "waiting for approval\n")
+	}
+
+	approval, err := step.waitForDecision(ctx, logger)
+	if err != nil {
+		return false, err
+	}
+
+	approved := approval.Status == db.ApprovalStatusApproved
+	if approved {
+		fmt.Fprintf(stdout, "approved by %s\n", approval.DecidedBy)
+	} else {
+		fmt.Fprintf(stdout, "rejected by %s\n", approval.DecidedBy)
+	}
+
+	delegate.Finished(logger, approved)
+
+	return approved, nil
+}
+
+func (step *ApprovalStep) waitForDecision(ctx context.Context, logger lager.Logger) (db.Approval, error) {
+	pollingTicker := time.NewTicker(ApprovalPollingInterval)
+	defer pollingTicker.Stop()
+
+	for {
+		approval, found, err := step.approvalFactory.Find(step.metadata.BuildID, step.planID)
+		if err != nil {
+			return db.Approval{}, fmt.Errorf("find approval: %w", err)
+		}
+
+		if !found {
+			return db.Approval{}, fmt.Errorf("approval %s disappeared", step.plan.Name)
+		}
+
+		if approval.Status != db.ApprovalStatusPending {
+			return approval, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("aborted-waiting-for-approval")
+			return db.Approval{}, ctx.Err()
+		case <-pollingTicker.C:
+		}
+	}
+}
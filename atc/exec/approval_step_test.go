@@ -0,0 +1,213 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("ApprovalStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeApprovalFactory *dbfakes.FakeApprovalFactory
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+		state               *execfakes.FakeRunState
+
+		stdout *gbytes.Buffer
+
+		approvalPlan atc.ApprovalPlan
+		stepMetadata = exec.StepMetadata{
+			BuildID:   42,
+			BuildName: "some-build",
+		}
+
+		planID = atc.PlanID("some-plan-id")
+
+		stepOk  bool
+		stepErr error
+
+		originalPollingInterval time.Duration
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("approval-step-test"))
+
+		originalPollingInterval = exec.ApprovalPollingInterval
+		exec.ApprovalPollingInterval = time.Millisecond
+
+		fakeApprovalFactory = new(dbfakes.FakeApprovalFactory)
+		fakeApprovalFactory.FindReturns(db.Approval{
+			Status:    db.ApprovalStatusApproved,
+			DecidedBy: "some-user",
+		}, true, nil)
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanStub = func(ctx context.Context, _ string, _ tracing.Attrs) (context.Context, trace.Span) {
+			return ctx, tracing.NoopSpan
+		}
+
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		state = new(execfakes.FakeRunState)
+
+		approvalPlan = atc.ApprovalPlan{
+			Name: "some-approval",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+		exec.ApprovalPollingInterval = originalPollingInterval
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewApprovalStep(planID, approvalPlan, stepMetadata, fakeApprovalFactory, fakeDelegateFactory)
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("requests the approval", func() {
+		Expect(fakeApprovalFactory.RequestCallCount()).To(Equal(1))
+		buildID, requestedPlanID, name, approvers := fakeApprovalFactory.RequestArgsForCall(0)
+		Expect(buildID).To(Equal(42))
+		Expect(requestedPlanID).To(Equal(planID))
+		Expect(name).To(Equal("some-approval"))
+		Expect(approvers).To(BeEmpty())
+	})
+
+	It("says that it is waiting", func() {
+		Expect(stdout).To(gbytes.Say("waiting for approval\n"))
+	})
+
+	Context("when the approval has approvers", func() {
+		BeforeEach(func() {
+			approvalPlan.Approvers = []string{"some-user", "some-other-user"}
+		})
+
+		It("requests the approval from them", func() {
+			_, _, _, approvers := fakeApprovalFactory.RequestArgsForCall(0)
+			Expect(approvers).To(Equal([]string{"some-user", "some-other-user"}))
+		})
+
+		It("says who it is waiting for", func() {
+			Expect(stdout).To(gbytes.Say("waiting for approval from some-user, some-other-user\n"))
+		})
+	})
+
+	Context("when the approval is approved", func() {
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("says who approved it", func() {
+			Expect(stdout).To(gbytes.Say("approved by some-user\n"))
+		})
+
+		It("emits a successful Finished event", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeTrue())
+		})
+	})
+
+	Context("when the approval is rejected", func() {
+		BeforeEach(func() {
+			fakeApprovalFactory.FindReturns(db.Approval{
+				Status:    db.ApprovalStatusRejected,
+				DecidedBy: "some-user",
+			}, true, nil)
+		})
+
+		It("fails without error", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+		})
+
+		It("says who rejected it", func() {
+			Expect(stdout).To(gbytes.Say("rejected by some-user\n"))
+		})
+
+		It("emits a failed Finished event", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when the approval is pending", func() {
+		BeforeEach(func() {
+			fakeApprovalFactory.FindReturnsOnCall(0, db.Approval{Status: db.ApprovalStatusPending}, true, nil)
+			fakeApprovalFactory.FindReturnsOnCall(1, db.Approval{Status: db.ApprovalStatusPending}, true, nil)
+			fakeApprovalFactory.FindReturnsOnCall(2, db.Approval{
+				Status:    db.ApprovalStatusApproved,
+				DecidedBy: "some-user",
+			}, true, nil)
+		})
+
+		It("waits until it is decided", func() {
+			Expect(fakeApprovalFactory.FindCallCount()).To(Equal(3))
+			Expect(stepOk).To(BeTrue())
+		})
+
+		Context("when the build is aborted while waiting", func() {
+			BeforeEach(func() {
+				fakeApprovalFactory.FindStub = func(int, atc.PlanID) (db.Approval, bool, error) {
+					cancel()
+					return db.Approval{Status: db.ApprovalStatusPending}, true, nil
+				}
+			})
+
+			It("returns the context's error", func() {
+				Expect(stepErr).To(Equal(context.Canceled))
+				Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when requesting the approval fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeApprovalFactory.RequestReturns(disaster)
+		})
+
+		It("returns the error without waiting", func() {
+			Expect(errors.Is(stepErr, disaster)).To(BeTrue())
+			Expect(fakeApprovalFactory.FindCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when finding the approval fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeApprovalFactory.FindReturns(db.Approval{}, false, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(errors.Is(stepErr, disaster)).To(BeTrue())
+		})
+	})
+})
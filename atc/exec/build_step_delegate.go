@@ -3,11 +3,13 @@ package exec
 import (
 	"context"
 	"io"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 )
@@ -33,6 +35,15 @@ type BuildStepDelegate interface {
 
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
+
+	WaitingToRetry(lager.Logger, int, time.Duration)
+	WaitingForSemaphore(lager.Logger, string, int)
+	Skipped(lager.Logger, string)
+	SoftTimedOut(lager.Logger, time.Duration)
+
+	// UpdatePlan replaces the step's plan in the build's plan, for steps
+	// whose plan is only known once they run.
+	UpdatePlan(lager.Logger, atc.Plan)
 }
 
 //counterfeiter:generate . SetPipelineStepDelegateFactory
@@ -44,4 +55,12 @@ type SetPipelineStepDelegateFactory interface {
 type SetPipelineStepDelegate interface {
 	BuildStepDelegate
 	SetPipelineChanged(lager.Logger, bool)
+
+	// AuthorizeTeam returns an error unless the build's team may set
+	// pipelines for the target team.
+	AuthorizeTeam(logger lager.Logger, currentTeam db.Team, targetTeam db.Team) error
+
+	// AuditCrossTeamWrite records that the build's team set a pipeline for
+	// another team.
+	AuditCrossTeamWrite(logger lager.Logger, currentTeam db.Team, targetTeam db.Team, pipelineRef atc.PipelineRef)
 }
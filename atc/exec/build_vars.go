@@ -77,6 +77,12 @@ func (b *buildVariables) AddLocalVar(name string, val interface{}, redact bool)
 	}
 }
 
+// TrackDerived tracks a value computed from vars by a function, e.g. a
+// decoded secret, so that it is redacted like the vars themselves.
+func (b *buildVariables) TrackDerived(name string, val interface{}) {
+	b.tracker.Track(vars.Reference{Path: name}, val)
+}
+
 func (b *buildVariables) RedactionEnabled() bool {
 	return b.tracker.Enabled
 }
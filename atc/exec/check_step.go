@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -168,7 +169,15 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 			return false, fmt.Errorf("update check end time: %w", err)
 		}
 
-		result, runErr := step.runCheck(ctx, logger, delegate, timeout, resourceConfig, source, resourceTypes, fromVersion)
+		checkStart := time.Now()
+		result, runErr := step.runCheckAttempts(ctx, logger, delegate, timeout, resourceConfig, source, resourceTypes, fromVersion)
+
+		metric.CheckFinished{
+			ResourceType: step.plan.Type,
+			Succeeded:    runErr == nil,
+			Duration:     time.Since(checkStart),
+		}.Emit(logger)
+
 		if runErr != nil {
 			metric.Metrics.ChecksFinishedWithError.Inc()
 
@@ -229,6 +238,48 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 	return true, nil
 }
 
+// runCheckAttempts runs the check until it succeeds or the plan's attempts
+// are used up, returning the result of the last run.
+func (step *CheckStep) runCheckAttempts(
+	ctx context.Context,
+	logger lager.Logger,
+	delegate CheckDelegate,
+	timeout time.Duration,
+	resourceConfig db.ResourceConfig,
+	source atc.Source,
+	resourceTypes atc.VersionedResourceTypes,
+	fromVersion atc.Version,
+) (worker.CheckResult, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := step.runCheck(ctx, logger, delegate, timeout, resourceConfig, source, resourceTypes, fromVersion)
+		if err == nil || attempt >= step.plan.Attempts || ctx.Err() != nil {
+			return result, err
+		}
+
+		logger.Info("retrying", lager.Data{"attempt": attempt + 1, "error": err.Error()})
+
+		// a failing check script has already explained itself on stderr
+		if !errors.As(err, &runtime.ErrResourceScriptFailed{}) {
+			fmt.Fprintf(delegate.Stderr(), "check failed: %s\n", err)
+		}
+
+		if step.plan.RetryBackoff != nil {
+			delay, err := RetryBackoffDelay(*step.plan.RetryBackoff, attempt, rand.Float64())
+			if err != nil {
+				return worker.CheckResult{}, err
+			}
+
+			delegate.WaitingToRetry(logger, attempt+1, delay)
+
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return worker.CheckResult{}, ctx.Err()
+			}
+		}
+	}
+}
+
 func (step *CheckStep) runCheck(
 	ctx context.Context,
 	logger lager.Logger,
@@ -243,6 +294,7 @@ func (step *CheckStep) runCheck(
 		Tags:         step.plan.Tags,
 		TeamID:       step.metadata.TeamID,
 		ResourceType: step.plan.VersionedResourceTypes.Base(step.plan.Type),
+		Priority:     step.metadata.Priority,
 	}
 
 	var imageSpec worker.ImageSpec
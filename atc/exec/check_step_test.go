@@ -769,6 +769,80 @@ var _ = Describe("CheckStep", func() {
 						Expect(succeeded).To(BeFalse())
 					})
 				})
+
+				Context("when the plan has attempts", func() {
+					BeforeEach(func() {
+						checkPlan.Attempts = 3
+					})
+
+					It("runs the check for each attempt", func() {
+						Expect(fakeClient.RunCheckStepCallCount()).To(Equal(3))
+						Expect(errors.Is(stepErr, expectedErr)).To(BeTrue())
+					})
+
+					It("updates the scope's last check end time once", func() {
+						Expect(fakeResourceConfigScope.UpdateLastCheckEndTimeCallCount()).To(Equal(1))
+					})
+
+					It("writes the errors of the failed attempts to stderr", func() {
+						Expect(fakeStderr.(*bytes.Buffer).String()).To(ContainSubstring("check failed: run-check-step-err\n"))
+					})
+
+					Context("when a retry succeeds", func() {
+						BeforeEach(func() {
+							fakeClient.RunCheckStepReturnsOnCall(1, worker.CheckResult{
+								Versions: []atc.Version{{"version": "1"}},
+							}, nil)
+						})
+
+						It("succeeds without running the remaining attempts", func() {
+							Expect(fakeClient.RunCheckStepCallCount()).To(Equal(2))
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(stepOk).To(BeTrue())
+						})
+
+						It("stores the version as the step result", func() {
+							Expect(fakeRunState.StoreResultCallCount()).To(Equal(1))
+							id, version := fakeRunState.StoreResultArgsForCall(0)
+							Expect(id).To(Equal(planID))
+							Expect(version).To(Equal(atc.Version{"version": "1"}))
+						})
+					})
+
+					Context("with a backoff", func() {
+						BeforeEach(func() {
+							checkPlan.RetryBackoff = &atc.RetryBackoffConfig{
+								Initial:    "1ms",
+								Multiplier: 2,
+							}
+						})
+
+						It("emits a waiting event before each retry", func() {
+							Expect(fakeDelegate.WaitingToRetryCallCount()).To(Equal(2))
+
+							_, attempt, delay := fakeDelegate.WaitingToRetryArgsForCall(0)
+							Expect(attempt).To(Equal(2))
+							Expect(delay).To(Equal(time.Millisecond))
+
+							_, attempt, delay = fakeDelegate.WaitingToRetryArgsForCall(1)
+							Expect(attempt).To(Equal(3))
+							Expect(delay).To(Equal(2 * time.Millisecond))
+						})
+					})
+
+					Context("with a script failure", func() {
+						BeforeEach(func() {
+							fakeClient.RunCheckStepReturns(worker.CheckResult{}, runtime.ErrResourceScriptFailed{
+								ExitStatus: 42,
+							})
+						})
+
+						It("retries without writing the error to stderr", func() {
+							Expect(fakeClient.RunCheckStepCallCount()).To(Equal(3))
+							Expect(fakeStderr.(*bytes.Buffer).String()).ToNot(ContainSubstring("check failed"))
+						})
+					})
+				})
 			})
 
 			Context("having SaveVersions failing", func() {
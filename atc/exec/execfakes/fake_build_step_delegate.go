@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -54,6 +55,18 @@ type FakeBuildStepDelegate struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	SkippedStub        func(lager.Logger, string)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	SoftTimedOutStub        func(lager.Logger, time.Duration)
+	softTimedOutMutex       sync.RWMutex
+	softTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -94,11 +107,31 @@ type FakeBuildStepDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	UpdatePlanStub        func(lager.Logger, atc.Plan)
+	updatePlanMutex       sync.RWMutex
+	updatePlanArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}
+	WaitingForSemaphoreStub        func(lager.Logger, string, int)
+	waitingForSemaphoreMutex       sync.RWMutex
+	waitingForSemaphoreArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int
+	}
 	WaitingForWorkerStub        func(lager.Logger)
 	waitingForWorkerMutex       sync.RWMutex
 	waitingForWorkerArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	WaitingToRetryStub        func(lager.Logger, int, time.Duration)
+	waitingToRetryMutex       sync.RWMutex
+	waitingToRetryArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 time.Duration
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -301,6 +334,72 @@ func (fake *FakeBuildStepDelegate) SelectedWorkerArgsForCall(i int) (lager.Logge
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeBuildStepDelegate) Skipped(arg1 lager.Logger, arg2 string) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SkippedStub
+	fake.recordInvocation("Skipped", []interface{}{arg1, arg2})
+	fake.skippedMutex.Unlock()
+	if stub != nil {
+		fake.SkippedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) SkippedCalls(stub func(lager.Logger, string)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) SkippedArgsForCall(i int) (lager.Logger, string) {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildStepDelegate) SoftTimedOut(arg1 lager.Logger, arg2 time.Duration) {
+	fake.softTimedOutMutex.Lock()
+	fake.softTimedOutArgsForCall = append(fake.softTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.SoftTimedOutStub
+	fake.recordInvocation("SoftTimedOut", []interface{}{arg1, arg2})
+	fake.softTimedOutMutex.Unlock()
+	if stub != nil {
+		fake.SoftTimedOutStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) SoftTimedOutCallCount() int {
+	fake.softTimedOutMutex.RLock()
+	defer fake.softTimedOutMutex.RUnlock()
+	return len(fake.softTimedOutArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) SoftTimedOutCalls(stub func(lager.Logger, time.Duration)) {
+	fake.softTimedOutMutex.Lock()
+	defer fake.softTimedOutMutex.Unlock()
+	fake.SoftTimedOutStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) SoftTimedOutArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.softTimedOutMutex.RLock()
+	defer fake.softTimedOutMutex.RUnlock()
+	argsForCall := fake.softTimedOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -505,6 +604,73 @@ func (fake *FakeBuildStepDelegate) StdoutReturnsOnCall(i int, result1 io.Writer)
 	}{result1}
 }
 
+func (fake *FakeBuildStepDelegate) UpdatePlan(arg1 lager.Logger, arg2 atc.Plan) {
+	fake.updatePlanMutex.Lock()
+	fake.updatePlanArgsForCall = append(fake.updatePlanArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}{arg1, arg2})
+	stub := fake.UpdatePlanStub
+	fake.recordInvocation("UpdatePlan", []interface{}{arg1, arg2})
+	fake.updatePlanMutex.Unlock()
+	if stub != nil {
+		fake.UpdatePlanStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) UpdatePlanCallCount() int {
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
+	return len(fake.updatePlanArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) UpdatePlanCalls(stub func(lager.Logger, atc.Plan)) {
+	fake.updatePlanMutex.Lock()
+	defer fake.updatePlanMutex.Unlock()
+	fake.UpdatePlanStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) UpdatePlanArgsForCall(i int) (lager.Logger, atc.Plan) {
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
+	argsForCall := fake.updatePlanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForSemaphore(arg1 lager.Logger, arg2 string, arg3 int) {
+	fake.waitingForSemaphoreMutex.Lock()
+	fake.waitingForSemaphoreArgsForCall = append(fake.waitingForSemaphoreArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.WaitingForSemaphoreStub
+	fake.recordInvocation("WaitingForSemaphore", []interface{}{arg1, arg2, arg3})
+	fake.waitingForSemaphoreMutex.Unlock()
+	if stub != nil {
+		fake.WaitingForSemaphoreStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForSemaphoreCallCount() int {
+	fake.waitingForSemaphoreMutex.RLock()
+	defer fake.waitingForSemaphoreMutex.RUnlock()
+	return len(fake.waitingForSemaphoreArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForSemaphoreCalls(stub func(lager.Logger, string, int)) {
+	fake.waitingForSemaphoreMutex.Lock()
+	defer fake.waitingForSemaphoreMutex.Unlock()
+	fake.WaitingForSemaphoreStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForSemaphoreArgsForCall(i int) (lager.Logger, string, int) {
+	fake.waitingForSemaphoreMutex.RLock()
+	defer fake.waitingForSemaphoreMutex.RUnlock()
+	argsForCall := fake.waitingForSemaphoreArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeBuildStepDelegate) WaitingForWorker(arg1 lager.Logger) {
 	fake.waitingForWorkerMutex.Lock()
 	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
@@ -537,6 +703,40 @@ func (fake *FakeBuildStepDelegate) WaitingForWorkerArgsForCall(i int) lager.Logg
 	return argsForCall.arg1
 }
 
+func (fake *FakeBuildStepDelegate) WaitingToRetry(arg1 lager.Logger, arg2 int, arg3 time.Duration) {
+	fake.waitingToRetryMutex.Lock()
+	fake.waitingToRetryArgsForCall = append(fake.waitingToRetryArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.WaitingToRetryStub
+	fake.recordInvocation("WaitingToRetry", []interface{}{arg1, arg2, arg3})
+	fake.waitingToRetryMutex.Unlock()
+	if stub != nil {
+		fake.WaitingToRetryStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) WaitingToRetryCallCount() int {
+	fake.waitingToRetryMutex.RLock()
+	defer fake.waitingToRetryMutex.RUnlock()
+	return len(fake.waitingToRetryArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) WaitingToRetryCalls(stub func(lager.Logger, int, time.Duration)) {
+	fake.waitingToRetryMutex.Lock()
+	defer fake.waitingToRetryMutex.Unlock()
+	fake.WaitingToRetryStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) WaitingToRetryArgsForCall(i int) (lager.Logger, int, time.Duration) {
+	fake.waitingToRetryMutex.RLock()
+	defer fake.waitingToRetryMutex.RUnlock()
+	argsForCall := fake.waitingToRetryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -550,6 +750,10 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.initializingMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	fake.softTimedOutMutex.RLock()
+	defer fake.softTimedOutMutex.RUnlock()
 	fake.startSpanMutex.RLock()
 	defer fake.startSpanMutex.RUnlock()
 	fake.startingMutex.RLock()
@@ -558,8 +762,14 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
+	fake.waitingForSemaphoreMutex.RLock()
+	defer fake.waitingForSemaphoreMutex.RUnlock()
 	fake.waitingForWorkerMutex.RLock()
 	defer fake.waitingForWorkerMutex.RUnlock()
+	fake.waitingToRetryMutex.RLock()
+	defer fake.waitingToRetryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
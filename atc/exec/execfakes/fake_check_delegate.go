@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -80,6 +81,18 @@ type FakeCheckDelegate struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	SkippedStub        func(lager.Logger, string)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	SoftTimedOutStub        func(lager.Logger, time.Duration)
+	softTimedOutMutex       sync.RWMutex
+	softTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -120,6 +133,12 @@ type FakeCheckDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	UpdatePlanStub        func(lager.Logger, atc.Plan)
+	updatePlanMutex       sync.RWMutex
+	updatePlanArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}
 	WaitToRunStub        func(context.Context, db.ResourceConfigScope) (lock.Lock, bool, error)
 	waitToRunMutex       sync.RWMutex
 	waitToRunArgsForCall []struct {
@@ -136,11 +155,25 @@ type FakeCheckDelegate struct {
 		result2 bool
 		result3 error
 	}
+	WaitingForSemaphoreStub        func(lager.Logger, string, int)
+	waitingForSemaphoreMutex       sync.RWMutex
+	waitingForSemaphoreArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int
+	}
 	WaitingForWorkerStub        func(lager.Logger)
 	waitingForWorkerMutex       sync.RWMutex
 	waitingForWorkerArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	WaitingToRetryStub        func(lager.Logger, int, time.Duration)
+	waitingToRetryMutex       sync.RWMutex
+	waitingToRetryArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 time.Duration
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -468,6 +501,72 @@ func (fake *FakeCheckDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, s
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeCheckDelegate) Skipped(arg1 lager.Logger, arg2 string) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SkippedStub
+	fake.recordInvocation("Skipped", []interface{}{arg1, arg2})
+	fake.skippedMutex.Unlock()
+	if stub != nil {
+		fake.SkippedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) SkippedCalls(stub func(lager.Logger, string)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeCheckDelegate) SkippedArgsForCall(i int) (lager.Logger, string) {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCheckDelegate) SoftTimedOut(arg1 lager.Logger, arg2 time.Duration) {
+	fake.softTimedOutMutex.Lock()
+	fake.softTimedOutArgsForCall = append(fake.softTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.SoftTimedOutStub
+	fake.recordInvocation("SoftTimedOut", []interface{}{arg1, arg2})
+	fake.softTimedOutMutex.Unlock()
+	if stub != nil {
+		fake.SoftTimedOutStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) SoftTimedOutCallCount() int {
+	fake.softTimedOutMutex.RLock()
+	defer fake.softTimedOutMutex.RUnlock()
+	return len(fake.softTimedOutArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) SoftTimedOutCalls(stub func(lager.Logger, time.Duration)) {
+	fake.softTimedOutMutex.Lock()
+	defer fake.softTimedOutMutex.Unlock()
+	fake.SoftTimedOutStub = stub
+}
+
+func (fake *FakeCheckDelegate) SoftTimedOutArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.softTimedOutMutex.RLock()
+	defer fake.softTimedOutMutex.RUnlock()
+	argsForCall := fake.softTimedOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -672,6 +771,39 @@ func (fake *FakeCheckDelegate) StdoutReturnsOnCall(i int, result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakeCheckDelegate) UpdatePlan(arg1 lager.Logger, arg2 atc.Plan) {
+	fake.updatePlanMutex.Lock()
+	fake.updatePlanArgsForCall = append(fake.updatePlanArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}{arg1, arg2})
+	stub := fake.UpdatePlanStub
+	fake.recordInvocation("UpdatePlan", []interface{}{arg1, arg2})
+	fake.updatePlanMutex.Unlock()
+	if stub != nil {
+		fake.UpdatePlanStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) UpdatePlanCallCount() int {
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
+	return len(fake.updatePlanArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) UpdatePlanCalls(stub func(lager.Logger, atc.Plan)) {
+	fake.updatePlanMutex.Lock()
+	defer fake.updatePlanMutex.Unlock()
+	fake.UpdatePlanStub = stub
+}
+
+func (fake *FakeCheckDelegate) UpdatePlanArgsForCall(i int) (lager.Logger, atc.Plan) {
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
+	argsForCall := fake.updatePlanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) WaitToRun(arg1 context.Context, arg2 db.ResourceConfigScope) (lock.Lock, bool, error) {
 	fake.waitToRunMutex.Lock()
 	ret, specificReturn := fake.waitToRunReturnsOnCall[len(fake.waitToRunArgsForCall)]
@@ -740,6 +872,40 @@ func (fake *FakeCheckDelegate) WaitToRunReturnsOnCall(i int, result1 lock.Lock,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeCheckDelegate) WaitingForSemaphore(arg1 lager.Logger, arg2 string, arg3 int) {
+	fake.waitingForSemaphoreMutex.Lock()
+	fake.waitingForSemaphoreArgsForCall = append(fake.waitingForSemaphoreArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.WaitingForSemaphoreStub
+	fake.recordInvocation("WaitingForSemaphore", []interface{}{arg1, arg2, arg3})
+	fake.waitingForSemaphoreMutex.Unlock()
+	if stub != nil {
+		fake.WaitingForSemaphoreStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeCheckDelegate) WaitingForSemaphoreCallCount() int {
+	fake.waitingForSemaphoreMutex.RLock()
+	defer fake.waitingForSemaphoreMutex.RUnlock()
+	return len(fake.waitingForSemaphoreArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) WaitingForSemaphoreCalls(stub func(lager.Logger, string, int)) {
+	fake.waitingForSemaphoreMutex.Lock()
+	defer fake.waitingForSemaphoreMutex.Unlock()
+	fake.WaitingForSemaphoreStub = stub
+}
+
+func (fake *FakeCheckDelegate) WaitingForSemaphoreArgsForCall(i int) (lager.Logger, string, int) {
+	fake.waitingForSemaphoreMutex.RLock()
+	defer fake.waitingForSemaphoreMutex.RUnlock()
+	argsForCall := fake.waitingForSemaphoreArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeCheckDelegate) WaitingForWorker(arg1 lager.Logger) {
 	fake.waitingForWorkerMutex.Lock()
 	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
@@ -772,6 +938,40 @@ func (fake *FakeCheckDelegate) WaitingForWorkerArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeCheckDelegate) WaitingToRetry(arg1 lager.Logger, arg2 int, arg3 time.Duration) {
+	fake.waitingToRetryMutex.Lock()
+	fake.waitingToRetryArgsForCall = append(fake.waitingToRetryArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.WaitingToRetryStub
+	fake.recordInvocation("WaitingToRetry", []interface{}{arg1, arg2, arg3})
+	fake.waitingToRetryMutex.Unlock()
+	if stub != nil {
+		fake.WaitingToRetryStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeCheckDelegate) WaitingToRetryCallCount() int {
+	fake.waitingToRetryMutex.RLock()
+	defer fake.waitingToRetryMutex.RUnlock()
+	return len(fake.waitingToRetryArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) WaitingToRetryCalls(stub func(lager.Logger, int, time.Duration)) {
+	fake.waitingToRetryMutex.Lock()
+	defer fake.waitingToRetryMutex.Unlock()
+	fake.WaitingToRetryStub = stub
+}
+
+func (fake *FakeCheckDelegate) WaitingToRetryArgsForCall(i int) (lager.Logger, int, time.Duration) {
+	fake.waitingToRetryMutex.RLock()
+	defer fake.waitingToRetryMutex.RUnlock()
+	argsForCall := fake.waitingToRetryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -789,6 +989,10 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.pointToCheckedConfigMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	fake.softTimedOutMutex.RLock()
+	defer fake.softTimedOutMutex.RUnlock()
 	fake.startSpanMutex.RLock()
 	defer fake.startSpanMutex.RUnlock()
 	fake.startingMutex.RLock()
@@ -797,10 +1001,16 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
 	fake.waitToRunMutex.RLock()
 	defer fake.waitToRunMutex.RUnlock()
+	fake.waitingForSemaphoreMutex.RLock()
+	defer fake.waitingForSemaphoreMutex.RUnlock()
 	fake.waitingForWorkerMutex.RLock()
 	defer fake.waitingForWorkerMutex.RUnlock()
+	fake.waitingToRetryMutex.RLock()
+	defer fake.waitingToRetryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
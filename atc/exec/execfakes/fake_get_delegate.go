@@ -50,6 +50,30 @@ type FakeGetDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	NewestVersionSinceStub        func(lager.Logger, atc.GetPlan, atc.Version) (atc.Version, bool, error)
+	newestVersionSinceMutex       sync.RWMutex
+	newestVersionSinceArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.GetPlan
+		arg3 atc.Version
+	}
+	newestVersionSinceReturns struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
+	newestVersionSinceReturnsOnCall map[int]struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
+	SaveInputStub        func(lager.Logger, atc.GetPlan, runtime.VersionResult)
+	saveInputMutex       sync.RWMutex
+	saveInputArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.GetPlan
+		arg3 runtime.VersionResult
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -278,6 +302,109 @@ func (fake *FakeGetDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeGetDelegate) NewestVersionSince(arg1 lager.Logger, arg2 atc.GetPlan, arg3 atc.Version) (atc.Version, bool, error) {
+	fake.newestVersionSinceMutex.Lock()
+	ret, specificReturn := fake.newestVersionSinceReturnsOnCall[len(fake.newestVersionSinceArgsForCall)]
+	fake.newestVersionSinceArgsForCall = append(fake.newestVersionSinceArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.GetPlan
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	stub := fake.NewestVersionSinceStub
+	fakeReturns := fake.newestVersionSinceReturns
+	fake.recordInvocation("NewestVersionSince", []interface{}{arg1, arg2, arg3})
+	fake.newestVersionSinceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeGetDelegate) NewestVersionSinceCallCount() int {
+	fake.newestVersionSinceMutex.RLock()
+	defer fake.newestVersionSinceMutex.RUnlock()
+	return len(fake.newestVersionSinceArgsForCall)
+}
+
+func (fake *FakeGetDelegate) NewestVersionSinceCalls(stub func(lager.Logger, atc.GetPlan, atc.Version) (atc.Version, bool, error)) {
+	fake.newestVersionSinceMutex.Lock()
+	defer fake.newestVersionSinceMutex.Unlock()
+	fake.NewestVersionSinceStub = stub
+}
+
+func (fake *FakeGetDelegate) NewestVersionSinceArgsForCall(i int) (lager.Logger, atc.GetPlan, atc.Version) {
+	fake.newestVersionSinceMutex.RLock()
+	defer fake.newestVersionSinceMutex.RUnlock()
+	argsForCall := fake.newestVersionSinceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeGetDelegate) NewestVersionSinceReturns(result1 atc.Version, result2 bool, result3 error) {
+	fake.newestVersionSinceMutex.Lock()
+	defer fake.newestVersionSinceMutex.Unlock()
+	fake.NewestVersionSinceStub = nil
+	fake.newestVersionSinceReturns = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeGetDelegate) NewestVersionSinceReturnsOnCall(i int, result1 atc.Version, result2 bool, result3 error) {
+	fake.newestVersionSinceMutex.Lock()
+	defer fake.newestVersionSinceMutex.Unlock()
+	fake.NewestVersionSinceStub = nil
+	if fake.newestVersionSinceReturnsOnCall == nil {
+		fake.newestVersionSinceReturnsOnCall = make(map[int]struct {
+			result1 atc.Version
+			result2 bool
+			result3 error
+		})
+	}
+	fake.newestVersionSinceReturnsOnCall[i] = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeGetDelegate) SaveInput(arg1 lager.Logger, arg2 atc.GetPlan, arg3 runtime.VersionResult) {
+	fake.saveInputMutex.Lock()
+	fake.saveInputArgsForCall = append(fake.saveInputArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.GetPlan
+		arg3 runtime.VersionResult
+	}{arg1, arg2, arg3})
+	stub := fake.SaveInputStub
+	fake.recordInvocation("SaveInput", []interface{}{arg1, arg2, arg3})
+	fake.saveInputMutex.Unlock()
+	if stub != nil {
+		fake.SaveInputStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeGetDelegate) SaveInputCallCount() int {
+	fake.saveInputMutex.RLock()
+	defer fake.saveInputMutex.RUnlock()
+	return len(fake.saveInputArgsForCall)
+}
+
+func (fake *FakeGetDelegate) SaveInputCalls(stub func(lager.Logger, atc.GetPlan, runtime.VersionResult)) {
+	fake.saveInputMutex.Lock()
+	defer fake.saveInputMutex.Unlock()
+	fake.SaveInputStub = stub
+}
+
+func (fake *FakeGetDelegate) SaveInputArgsForCall(i int) (lager.Logger, atc.GetPlan, runtime.VersionResult) {
+	fake.saveInputMutex.RLock()
+	defer fake.saveInputMutex.RUnlock()
+	argsForCall := fake.saveInputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeGetDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -592,6 +719,10 @@ func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.newestVersionSinceMutex.RLock()
+	defer fake.newestVersionSinceMutex.RUnlock()
+	fake.saveInputMutex.RLock()
+	defer fake.saveInputMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startSpanMutex.RLock()
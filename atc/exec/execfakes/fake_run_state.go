@@ -29,6 +29,18 @@ type FakeRunState struct {
 	artifactRepositoryReturnsOnCall map[int]struct {
 		result1 *build.Repository
 	}
+	FailureReasonStub        func() (string, bool)
+	failureReasonMutex       sync.RWMutex
+	failureReasonArgsForCall []struct {
+	}
+	failureReasonReturns struct {
+		result1 string
+		result2 bool
+	}
+	failureReasonReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+	}
 	GetStub        func(vars.Reference) (interface{}, bool, error)
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
@@ -117,12 +129,42 @@ type FakeRunState struct {
 		result1 bool
 		result2 error
 	}
+	StepStatusStub        func(atc.PlanID) (atc.BuildStatus, bool)
+	stepStatusMutex       sync.RWMutex
+	stepStatusArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	stepStatusReturns struct {
+		result1 atc.BuildStatus
+		result2 bool
+	}
+	stepStatusReturnsOnCall map[int]struct {
+		result1 atc.BuildStatus
+		result2 bool
+	}
+	StoreFailureReasonStub        func(string)
+	storeFailureReasonMutex       sync.RWMutex
+	storeFailureReasonArgsForCall []struct {
+		arg1 string
+	}
 	StoreResultStub        func(atc.PlanID, interface{})
 	storeResultMutex       sync.RWMutex
 	storeResultArgsForCall []struct {
 		arg1 atc.PlanID
 		arg2 interface{}
 	}
+	StoreStepStatusStub        func(atc.PlanID, atc.BuildStatus)
+	storeStepStatusMutex       sync.RWMutex
+	storeStepStatusArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 atc.BuildStatus
+	}
+	TrackDerivedStub        func(string, interface{})
+	trackDerivedMutex       sync.RWMutex
+	trackDerivedArgsForCall []struct {
+		arg1 string
+		arg2 interface{}
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -214,6 +256,62 @@ func (fake *FakeRunState) ArtifactRepositoryReturnsOnCall(i int, result1 *build.
 	}{result1}
 }
 
+func (fake *FakeRunState) FailureReason() (string, bool) {
+	fake.failureReasonMutex.Lock()
+	ret, specificReturn := fake.failureReasonReturnsOnCall[len(fake.failureReasonArgsForCall)]
+	fake.failureReasonArgsForCall = append(fake.failureReasonArgsForCall, struct {
+	}{})
+	stub := fake.FailureReasonStub
+	fakeReturns := fake.failureReasonReturns
+	fake.recordInvocation("FailureReason", []interface{}{})
+	fake.failureReasonMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRunState) FailureReasonCallCount() int {
+	fake.failureReasonMutex.RLock()
+	defer fake.failureReasonMutex.RUnlock()
+	return len(fake.failureReasonArgsForCall)
+}
+
+func (fake *FakeRunState) FailureReasonCalls(stub func() (string, bool)) {
+	fake.failureReasonMutex.Lock()
+	defer fake.failureReasonMutex.Unlock()
+	fake.FailureReasonStub = stub
+}
+
+func (fake *FakeRunState) FailureReasonReturns(result1 string, result2 bool) {
+	fake.failureReasonMutex.Lock()
+	defer fake.failureReasonMutex.Unlock()
+	fake.FailureReasonStub = nil
+	fake.failureReasonReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeRunState) FailureReasonReturnsOnCall(i int, result1 string, result2 bool) {
+	fake.failureReasonMutex.Lock()
+	defer fake.failureReasonMutex.Unlock()
+	fake.FailureReasonStub = nil
+	if fake.failureReasonReturnsOnCall == nil {
+		fake.failureReasonReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+		})
+	}
+	fake.failureReasonReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeRunState) Get(arg1 vars.Reference) (interface{}, bool, error) {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
@@ -655,6 +753,102 @@ func (fake *FakeRunState) RunReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeRunState) StepStatus(arg1 atc.PlanID) (atc.BuildStatus, bool) {
+	fake.stepStatusMutex.Lock()
+	ret, specificReturn := fake.stepStatusReturnsOnCall[len(fake.stepStatusArgsForCall)]
+	fake.stepStatusArgsForCall = append(fake.stepStatusArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	stub := fake.StepStatusStub
+	fakeReturns := fake.stepStatusReturns
+	fake.recordInvocation("StepStatus", []interface{}{arg1})
+	fake.stepStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRunState) StepStatusCallCount() int {
+	fake.stepStatusMutex.RLock()
+	defer fake.stepStatusMutex.RUnlock()
+	return len(fake.stepStatusArgsForCall)
+}
+
+func (fake *FakeRunState) StepStatusCalls(stub func(atc.PlanID) (atc.BuildStatus, bool)) {
+	fake.stepStatusMutex.Lock()
+	defer fake.stepStatusMutex.Unlock()
+	fake.StepStatusStub = stub
+}
+
+func (fake *FakeRunState) StepStatusArgsForCall(i int) atc.PlanID {
+	fake.stepStatusMutex.RLock()
+	defer fake.stepStatusMutex.RUnlock()
+	argsForCall := fake.stepStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRunState) StepStatusReturns(result1 atc.BuildStatus, result2 bool) {
+	fake.stepStatusMutex.Lock()
+	defer fake.stepStatusMutex.Unlock()
+	fake.StepStatusStub = nil
+	fake.stepStatusReturns = struct {
+		result1 atc.BuildStatus
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeRunState) StepStatusReturnsOnCall(i int, result1 atc.BuildStatus, result2 bool) {
+	fake.stepStatusMutex.Lock()
+	defer fake.stepStatusMutex.Unlock()
+	fake.StepStatusStub = nil
+	if fake.stepStatusReturnsOnCall == nil {
+		fake.stepStatusReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildStatus
+			result2 bool
+		})
+	}
+	fake.stepStatusReturnsOnCall[i] = struct {
+		result1 atc.BuildStatus
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeRunState) StoreFailureReason(arg1 string) {
+	fake.storeFailureReasonMutex.Lock()
+	fake.storeFailureReasonArgsForCall = append(fake.storeFailureReasonArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.StoreFailureReasonStub
+	fake.recordInvocation("StoreFailureReason", []interface{}{arg1})
+	fake.storeFailureReasonMutex.Unlock()
+	if stub != nil {
+		fake.StoreFailureReasonStub(arg1)
+	}
+}
+
+func (fake *FakeRunState) StoreFailureReasonCallCount() int {
+	fake.storeFailureReasonMutex.RLock()
+	defer fake.storeFailureReasonMutex.RUnlock()
+	return len(fake.storeFailureReasonArgsForCall)
+}
+
+func (fake *FakeRunState) StoreFailureReasonCalls(stub func(string)) {
+	fake.storeFailureReasonMutex.Lock()
+	defer fake.storeFailureReasonMutex.Unlock()
+	fake.StoreFailureReasonStub = stub
+}
+
+func (fake *FakeRunState) StoreFailureReasonArgsForCall(i int) string {
+	fake.storeFailureReasonMutex.RLock()
+	defer fake.storeFailureReasonMutex.RUnlock()
+	argsForCall := fake.storeFailureReasonArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeRunState) StoreResult(arg1 atc.PlanID, arg2 interface{}) {
 	fake.storeResultMutex.Lock()
 	fake.storeResultArgsForCall = append(fake.storeResultArgsForCall, struct {
@@ -688,6 +882,72 @@ func (fake *FakeRunState) StoreResultArgsForCall(i int) (atc.PlanID, interface{}
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeRunState) StoreStepStatus(arg1 atc.PlanID, arg2 atc.BuildStatus) {
+	fake.storeStepStatusMutex.Lock()
+	fake.storeStepStatusArgsForCall = append(fake.storeStepStatusArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 atc.BuildStatus
+	}{arg1, arg2})
+	stub := fake.StoreStepStatusStub
+	fake.recordInvocation("StoreStepStatus", []interface{}{arg1, arg2})
+	fake.storeStepStatusMutex.Unlock()
+	if stub != nil {
+		fake.StoreStepStatusStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeRunState) StoreStepStatusCallCount() int {
+	fake.storeStepStatusMutex.RLock()
+	defer fake.storeStepStatusMutex.RUnlock()
+	return len(fake.storeStepStatusArgsForCall)
+}
+
+func (fake *FakeRunState) StoreStepStatusCalls(stub func(atc.PlanID, atc.BuildStatus)) {
+	fake.storeStepStatusMutex.Lock()
+	defer fake.storeStepStatusMutex.Unlock()
+	fake.StoreStepStatusStub = stub
+}
+
+func (fake *FakeRunState) StoreStepStatusArgsForCall(i int) (atc.PlanID, atc.BuildStatus) {
+	fake.storeStepStatusMutex.RLock()
+	defer fake.storeStepStatusMutex.RUnlock()
+	argsForCall := fake.storeStepStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRunState) TrackDerived(arg1 string, arg2 interface{}) {
+	fake.trackDerivedMutex.Lock()
+	fake.trackDerivedArgsForCall = append(fake.trackDerivedArgsForCall, struct {
+		arg1 string
+		arg2 interface{}
+	}{arg1, arg2})
+	stub := fake.TrackDerivedStub
+	fake.recordInvocation("TrackDerived", []interface{}{arg1, arg2})
+	fake.trackDerivedMutex.Unlock()
+	if stub != nil {
+		fake.TrackDerivedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeRunState) TrackDerivedCallCount() int {
+	fake.trackDerivedMutex.RLock()
+	defer fake.trackDerivedMutex.RUnlock()
+	return len(fake.trackDerivedArgsForCall)
+}
+
+func (fake *FakeRunState) TrackDerivedCalls(stub func(string, interface{})) {
+	fake.trackDerivedMutex.Lock()
+	defer fake.trackDerivedMutex.Unlock()
+	fake.TrackDerivedStub = stub
+}
+
+func (fake *FakeRunState) TrackDerivedArgsForCall(i int) (string, interface{}) {
+	fake.trackDerivedMutex.RLock()
+	defer fake.trackDerivedMutex.RUnlock()
+	argsForCall := fake.trackDerivedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -695,6 +955,8 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.addLocalVarMutex.RUnlock()
 	fake.artifactRepositoryMutex.RLock()
 	defer fake.artifactRepositoryMutex.RUnlock()
+	fake.failureReasonMutex.RLock()
+	defer fake.failureReasonMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
 	fake.iterateInterpolatedCredsMutex.RLock()
@@ -711,8 +973,16 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.resultMutex.RUnlock()
 	fake.runMutex.RLock()
 	defer fake.runMutex.RUnlock()
+	fake.stepStatusMutex.RLock()
+	defer fake.stepStatusMutex.RUnlock()
+	fake.storeFailureReasonMutex.RLock()
+	defer fake.storeFailureReasonMutex.RUnlock()
 	fake.storeResultMutex.RLock()
 	defer fake.storeResultMutex.RUnlock()
+	fake.storeStepStatusMutex.RLock()
+	defer fake.storeStepStatusMutex.RUnlock()
+	fake.trackDerivedMutex.RLock()
+	defer fake.trackDerivedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
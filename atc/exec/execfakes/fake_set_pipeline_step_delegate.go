@@ -5,9 +5,11 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
@@ -15,6 +17,27 @@ import (
 )
 
 type FakeSetPipelineStepDelegate struct {
+	AuditCrossTeamWriteStub        func(lager.Logger, db.Team, db.Team, atc.PipelineRef)
+	auditCrossTeamWriteMutex       sync.RWMutex
+	auditCrossTeamWriteArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Team
+		arg3 db.Team
+		arg4 atc.PipelineRef
+	}
+	AuthorizeTeamStub        func(lager.Logger, db.Team, db.Team) error
+	authorizeTeamMutex       sync.RWMutex
+	authorizeTeamArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Team
+		arg3 db.Team
+	}
+	authorizeTeamReturns struct {
+		result1 error
+	}
+	authorizeTeamReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ErroredStub        func(lager.Logger, string)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
@@ -60,6 +83,18 @@ type FakeSetPipelineStepDelegate struct {
 		arg1 lager.Logger
 		arg2 bool
 	}
+	SkippedStub        func(lager.Logger, string)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	SoftTimedOutStub        func(lager.Logger, time.Duration)
+	softTimedOutMutex       sync.RWMutex
+	softTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -100,15 +135,133 @@ type FakeSetPipelineStepDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	UpdatePlanStub        func(lager.Logger, atc.Plan)
+	updatePlanMutex       sync.RWMutex
+	updatePlanArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}
+	WaitingForSemaphoreStub        func(lager.Logger, string, int)
+	waitingForSemaphoreMutex       sync.RWMutex
+	waitingForSemaphoreArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int
+	}
 	WaitingForWorkerStub        func(lager.Logger)
 	waitingForWorkerMutex       sync.RWMutex
 	waitingForWorkerArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	WaitingToRetryStub        func(lager.Logger, int, time.Duration)
+	waitingToRetryMutex       sync.RWMutex
+	waitingToRetryArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 time.Duration
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeSetPipelineStepDelegate) AuditCrossTeamWrite(arg1 lager.Logger, arg2 db.Team, arg3 db.Team, arg4 atc.PipelineRef) {
+	fake.auditCrossTeamWriteMutex.Lock()
+	fake.auditCrossTeamWriteArgsForCall = append(fake.auditCrossTeamWriteArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Team
+		arg3 db.Team
+		arg4 atc.PipelineRef
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AuditCrossTeamWriteStub
+	fake.recordInvocation("AuditCrossTeamWrite", []interface{}{arg1, arg2, arg3, arg4})
+	fake.auditCrossTeamWriteMutex.Unlock()
+	if stub != nil {
+		fake.AuditCrossTeamWriteStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) AuditCrossTeamWriteCallCount() int {
+	fake.auditCrossTeamWriteMutex.RLock()
+	defer fake.auditCrossTeamWriteMutex.RUnlock()
+	return len(fake.auditCrossTeamWriteArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) AuditCrossTeamWriteCalls(stub func(lager.Logger, db.Team, db.Team, atc.PipelineRef)) {
+	fake.auditCrossTeamWriteMutex.Lock()
+	defer fake.auditCrossTeamWriteMutex.Unlock()
+	fake.AuditCrossTeamWriteStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) AuditCrossTeamWriteArgsForCall(i int) (lager.Logger, db.Team, db.Team, atc.PipelineRef) {
+	fake.auditCrossTeamWriteMutex.RLock()
+	defer fake.auditCrossTeamWriteMutex.RUnlock()
+	argsForCall := fake.auditCrossTeamWriteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeSetPipelineStepDelegate) AuthorizeTeam(arg1 lager.Logger, arg2 db.Team, arg3 db.Team) error {
+	fake.authorizeTeamMutex.Lock()
+	ret, specificReturn := fake.authorizeTeamReturnsOnCall[len(fake.authorizeTeamArgsForCall)]
+	fake.authorizeTeamArgsForCall = append(fake.authorizeTeamArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Team
+		arg3 db.Team
+	}{arg1, arg2, arg3})
+	stub := fake.AuthorizeTeamStub
+	fakeReturns := fake.authorizeTeamReturns
+	fake.recordInvocation("AuthorizeTeam", []interface{}{arg1, arg2, arg3})
+	fake.authorizeTeamMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSetPipelineStepDelegate) AuthorizeTeamCallCount() int {
+	fake.authorizeTeamMutex.RLock()
+	defer fake.authorizeTeamMutex.RUnlock()
+	return len(fake.authorizeTeamArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) AuthorizeTeamCalls(stub func(lager.Logger, db.Team, db.Team) error) {
+	fake.authorizeTeamMutex.Lock()
+	defer fake.authorizeTeamMutex.Unlock()
+	fake.AuthorizeTeamStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) AuthorizeTeamArgsForCall(i int) (lager.Logger, db.Team, db.Team) {
+	fake.authorizeTeamMutex.RLock()
+	defer fake.authorizeTeamMutex.RUnlock()
+	argsForCall := fake.authorizeTeamArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSetPipelineStepDelegate) AuthorizeTeamReturns(result1 error) {
+	fake.authorizeTeamMutex.Lock()
+	defer fake.authorizeTeamMutex.Unlock()
+	fake.AuthorizeTeamStub = nil
+	fake.authorizeTeamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSetPipelineStepDelegate) AuthorizeTeamReturnsOnCall(i int, result1 error) {
+	fake.authorizeTeamMutex.Lock()
+	defer fake.authorizeTeamMutex.Unlock()
+	fake.AuthorizeTeamStub = nil
+	if fake.authorizeTeamReturnsOnCall == nil {
+		fake.authorizeTeamReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.authorizeTeamReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSetPipelineStepDelegate) Errored(arg1 lager.Logger, arg2 string) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
@@ -340,6 +493,72 @@ func (fake *FakeSetPipelineStepDelegate) SetPipelineChangedArgsForCall(i int) (l
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeSetPipelineStepDelegate) Skipped(arg1 lager.Logger, arg2 string) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SkippedStub
+	fake.recordInvocation("Skipped", []interface{}{arg1, arg2})
+	fake.skippedMutex.Unlock()
+	if stub != nil {
+		fake.SkippedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) SkippedCalls(stub func(lager.Logger, string)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) SkippedArgsForCall(i int) (lager.Logger, string) {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSetPipelineStepDelegate) SoftTimedOut(arg1 lager.Logger, arg2 time.Duration) {
+	fake.softTimedOutMutex.Lock()
+	fake.softTimedOutArgsForCall = append(fake.softTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.SoftTimedOutStub
+	fake.recordInvocation("SoftTimedOut", []interface{}{arg1, arg2})
+	fake.softTimedOutMutex.Unlock()
+	if stub != nil {
+		fake.SoftTimedOutStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) SoftTimedOutCallCount() int {
+	fake.softTimedOutMutex.RLock()
+	defer fake.softTimedOutMutex.RUnlock()
+	return len(fake.softTimedOutArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) SoftTimedOutCalls(stub func(lager.Logger, time.Duration)) {
+	fake.softTimedOutMutex.Lock()
+	defer fake.softTimedOutMutex.Unlock()
+	fake.SoftTimedOutStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) SoftTimedOutArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.softTimedOutMutex.RLock()
+	defer fake.softTimedOutMutex.RUnlock()
+	argsForCall := fake.softTimedOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeSetPipelineStepDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -544,6 +763,73 @@ func (fake *FakeSetPipelineStepDelegate) StdoutReturnsOnCall(i int, result1 io.W
 	}{result1}
 }
 
+func (fake *FakeSetPipelineStepDelegate) UpdatePlan(arg1 lager.Logger, arg2 atc.Plan) {
+	fake.updatePlanMutex.Lock()
+	fake.updatePlanArgsForCall = append(fake.updatePlanArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}{arg1, arg2})
+	stub := fake.UpdatePlanStub
+	fake.recordInvocation("UpdatePlan", []interface{}{arg1, arg2})
+	fake.updatePlanMutex.Unlock()
+	if stub != nil {
+		fake.UpdatePlanStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) UpdatePlanCallCount() int {
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
+	return len(fake.updatePlanArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) UpdatePlanCalls(stub func(lager.Logger, atc.Plan)) {
+	fake.updatePlanMutex.Lock()
+	defer fake.updatePlanMutex.Unlock()
+	fake.UpdatePlanStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) UpdatePlanArgsForCall(i int) (lager.Logger, atc.Plan) {
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
+	argsForCall := fake.updatePlanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSetPipelineStepDelegate) WaitingForSemaphore(arg1 lager.Logger, arg2 string, arg3 int) {
+	fake.waitingForSemaphoreMutex.Lock()
+	fake.waitingForSemaphoreArgsForCall = append(fake.waitingForSemaphoreArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.WaitingForSemaphoreStub
+	fake.recordInvocation("WaitingForSemaphore", []interface{}{arg1, arg2, arg3})
+	fake.waitingForSemaphoreMutex.Unlock()
+	if stub != nil {
+		fake.WaitingForSemaphoreStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) WaitingForSemaphoreCallCount() int {
+	fake.waitingForSemaphoreMutex.RLock()
+	defer fake.waitingForSemaphoreMutex.RUnlock()
+	return len(fake.waitingForSemaphoreArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) WaitingForSemaphoreCalls(stub func(lager.Logger, string, int)) {
+	fake.waitingForSemaphoreMutex.Lock()
+	defer fake.waitingForSemaphoreMutex.Unlock()
+	fake.WaitingForSemaphoreStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) WaitingForSemaphoreArgsForCall(i int) (lager.Logger, string, int) {
+	fake.waitingForSemaphoreMutex.RLock()
+	defer fake.waitingForSemaphoreMutex.RUnlock()
+	argsForCall := fake.waitingForSemaphoreArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeSetPipelineStepDelegate) WaitingForWorker(arg1 lager.Logger) {
 	fake.waitingForWorkerMutex.Lock()
 	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
@@ -576,9 +862,47 @@ func (fake *FakeSetPipelineStepDelegate) WaitingForWorkerArgsForCall(i int) lage
 	return argsForCall.arg1
 }
 
+func (fake *FakeSetPipelineStepDelegate) WaitingToRetry(arg1 lager.Logger, arg2 int, arg3 time.Duration) {
+	fake.waitingToRetryMutex.Lock()
+	fake.waitingToRetryArgsForCall = append(fake.waitingToRetryArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.WaitingToRetryStub
+	fake.recordInvocation("WaitingToRetry", []interface{}{arg1, arg2, arg3})
+	fake.waitingToRetryMutex.Unlock()
+	if stub != nil {
+		fake.WaitingToRetryStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) WaitingToRetryCallCount() int {
+	fake.waitingToRetryMutex.RLock()
+	defer fake.waitingToRetryMutex.RUnlock()
+	return len(fake.waitingToRetryArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) WaitingToRetryCalls(stub func(lager.Logger, int, time.Duration)) {
+	fake.waitingToRetryMutex.Lock()
+	defer fake.waitingToRetryMutex.Unlock()
+	fake.WaitingToRetryStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) WaitingToRetryArgsForCall(i int) (lager.Logger, int, time.Duration) {
+	fake.waitingToRetryMutex.RLock()
+	defer fake.waitingToRetryMutex.RUnlock()
+	argsForCall := fake.waitingToRetryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeSetPipelineStepDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.auditCrossTeamWriteMutex.RLock()
+	defer fake.auditCrossTeamWriteMutex.RUnlock()
+	fake.authorizeTeamMutex.RLock()
+	defer fake.authorizeTeamMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	fake.fetchImageMutex.RLock()
@@ -591,6 +915,10 @@ func (fake *FakeSetPipelineStepDelegate) Invocations() map[string][][]interface{
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.setPipelineChangedMutex.RLock()
 	defer fake.setPipelineChangedMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	fake.softTimedOutMutex.RLock()
+	defer fake.softTimedOutMutex.RUnlock()
 	fake.startSpanMutex.RLock()
 	defer fake.startSpanMutex.RUnlock()
 	fake.startingMutex.RLock()
@@ -599,8 +927,14 @@ func (fake *FakeSetPipelineStepDelegate) Invocations() map[string][][]interface{
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.updatePlanMutex.RLock()
+	defer fake.updatePlanMutex.RUnlock()
+	fake.waitingForSemaphoreMutex.RLock()
+	defer fake.waitingForSemaphoreMutex.RUnlock()
 	fake.waitingForWorkerMutex.RLock()
 	defer fake.waitingForWorkerMutex.RUnlock()
+	fake.waitingToRetryMutex.RLock()
+	defer fake.waitingToRetryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -37,6 +37,17 @@ func (e ErrResourceNotFound) Error() string {
 	return fmt.Sprintf("resource '%s' not found", e.ResourceName)
 }
 
+// VersionSinceNotFoundError is returned when a get step is to fetch the newest
+// version since one which does not exist.
+type VersionSinceNotFoundError struct {
+	ResourceName string
+	Version      atc.Version
+}
+
+func (e VersionSinceNotFoundError) Error() string {
+	return fmt.Sprintf("version %v of resource '%s' not found", e.Version, e.ResourceName)
+}
+
 //counterfeiter:generate . GetDelegateFactory
 type GetDelegateFactory interface {
 	GetDelegate(state RunState) GetDelegate
@@ -60,6 +71,8 @@ type GetDelegate interface {
 	SelectedWorker(lager.Logger, string)
 
 	UpdateVersion(lager.Logger, atc.GetPlan, runtime.VersionResult)
+	SaveInput(lager.Logger, atc.GetPlan, runtime.VersionResult)
+	NewestVersionSince(lager.Logger, atc.GetPlan, atc.Version) (atc.Version, bool, error)
 }
 
 // GetStep will fetch a version of a resource on a worker that supports the
@@ -133,13 +146,20 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 
 	workerSpec := worker.WorkerSpec{
 		Tags:         step.plan.Tags,
+		Arch:         step.plan.Arch,
 		TeamID:       step.metadata.TeamID,
 		ResourceType: step.plan.VersionedResourceTypes.Base(step.plan.Type),
+		Priority:     step.metadata.Priority,
 	}
 
 	var imageSpec worker.ImageSpec
 	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
 	if found {
+		mirror, err := creds.NewRegistryMirror(state, step.plan.RegistryMirror).Evaluate()
+		if err != nil {
+			return false, err
+		}
+
 		image := atc.ImageResource{
 			Name:    resourceType.Name,
 			Type:    resourceType.Type,
@@ -154,7 +174,11 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 
 		types := step.plan.VersionedResourceTypes.Without(step.plan.Type)
 
-		var err error
+		types, err = image.ApplyRegistryMirror(mirror, types)
+		if err != nil {
+			return false, err
+		}
+
 		imageSpec, err = delegate.FetchImage(ctx, image, types, resourceType.Privileged)
 		if err != nil {
 			return false, err
@@ -173,6 +197,22 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 		return false, err
 	}
 
+	if step.plan.VersionSince != nil {
+		newest, found, err := delegate.NewestVersionSince(logger, step.plan, *step.plan.VersionSince)
+		if err != nil {
+			return false, err
+		}
+
+		if !found {
+			return false, VersionSinceNotFoundError{
+				ResourceName: step.plan.Resource,
+				Version:      *step.plan.VersionSince,
+			}
+		}
+
+		version = newest
+	}
+
 	containerSpec := worker.ContainerSpec{
 		ImageSpec: imageSpec,
 		TeamID:    step.metadata.TeamID,
@@ -195,10 +235,23 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 		return false, err
 	}
 
+	if step.plan.Fresh {
+		// drop the cache from every worker so that the version is fetched
+		// again, with the result becoming the new cache
+		err = step.resourceCacheFactory.InvalidateResourceCache(resourceCache)
+		if err != nil {
+			logger.Error("failed-to-invalidate-resource-cache", err)
+			return false, err
+		}
+
+		fmt.Fprintln(delegate.Stderr(), "\x1b[1;36mINFO: fetching a fresh copy, ignoring the resource cache\x1b[0m")
+		fmt.Fprintln(delegate.Stderr(), "")
+	}
+
 	// Only get from local cache if caching streamed volumes is enabled -
 	// otherwise, we'd need to stream volumes between workers much more
 	// frequently.
-	if atc.EnableCacheStreamedVolumes {
+	if atc.EnableCacheStreamedVolumes && !step.plan.Fresh {
 		getResult, found, err := step.getFromLocalCache(logger, step.metadata.TeamID, resourceCache, workerSpec)
 		if err != nil {
 			return false, err
@@ -219,6 +272,10 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 				delegate.UpdateVersion(logger, step.plan, getResult.VersionResult)
 			}
 
+			if step.plan.RecordInput {
+				delegate.SaveInput(logger, step.plan, getResult.VersionResult)
+			}
+
 			delegate.Finished(
 				logger,
 				ExitStatus(getResult.ExitStatus),
@@ -289,6 +346,7 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			delegate.Errored(logger, TimeoutLogMessage)
+			state.StoreFailureReason(TimeoutLogMessage)
 			return false, nil
 		}
 
@@ -308,7 +366,13 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 			delegate.UpdateVersion(logger, step.plan, getResult.VersionResult)
 		}
 
+		if step.plan.RecordInput {
+			delegate.SaveInput(logger, step.plan, getResult.VersionResult)
+		}
+
 		succeeded = true
+	} else {
+		state.StoreFailureReason(exitStatusReason(getResult.ExitStatus))
 	}
 
 	delegate.Finished(
@@ -223,6 +223,76 @@ var _ = Describe("GetStep", func() {
 		}))
 	})
 
+	Context("when the version comes from a check step", func() {
+		BeforeEach(func() {
+			checkPlanID := atc.PlanID("some-check")
+
+			getPlan.Version = nil
+			getPlan.VersionFrom = &checkPlanID
+
+			fakeState.ResultStub = func(id atc.PlanID, to interface{}) bool {
+				version, ok := to.(*atc.Version)
+				if id != checkPlanID || !ok {
+					return false
+				}
+
+				*version = atc.Version{"some": "checked-version"}
+				return true
+			}
+		})
+
+		It("fetches the checked version", func() {
+			_, _, ver, _, _, _ := fakeResourceCacheFactory.FindOrCreateResourceCacheArgsForCall(0)
+			Expect(ver).To(Equal(atc.Version{"some": "checked-version"}))
+		})
+	})
+
+	Context("when the plan fetches the newest version since another", func() {
+		BeforeEach(func() {
+			getPlan.Resource = "some-resource"
+			getPlan.VersionSince = &atc.Version{"some": "boundary"}
+
+			fakeDelegate.NewestVersionSinceReturns(atc.Version{"some": "newest-version"}, true, nil)
+		})
+
+		It("resolves the newest version via the delegate", func() {
+			Expect(fakeDelegate.NewestVersionSinceCallCount()).To(Equal(1))
+			_, plan, since := fakeDelegate.NewestVersionSinceArgsForCall(0)
+			Expect(plan).To(Equal(*getPlan))
+			Expect(since).To(Equal(atc.Version{"some": "boundary"}))
+		})
+
+		It("fetches the newest version", func() {
+			_, _, ver, _, _, _ := fakeResourceCacheFactory.FindOrCreateResourceCacheArgsForCall(0)
+			Expect(ver).To(Equal(atc.Version{"some": "newest-version"}))
+		})
+
+		Context("when the version is not found", func() {
+			BeforeEach(func() {
+				fakeDelegate.NewestVersionSinceReturns(nil, false, nil)
+				shouldRunGetStep = false
+			})
+
+			It("returns an error", func() {
+				Expect(stepErr).To(Equal(exec.VersionSinceNotFoundError{
+					ResourceName: "some-resource",
+					Version:      atc.Version{"some": "boundary"},
+				}))
+			})
+		})
+
+		Context("when resolving the version fails", func() {
+			BeforeEach(func() {
+				fakeDelegate.NewestVersionSinceReturns(nil, false, errors.New("nope"))
+				shouldRunGetStep = false
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(MatchError("nope"))
+			})
+		})
+	})
+
 	Context("when tracing is enabled", func() {
 		var buildSpan trace.Span
 
@@ -351,10 +421,40 @@ var _ = Describe("GetStep", func() {
 						// Do nothing here, JustBeforeEach() will check shouldRunGetStep
 					})
 				})
+
+				Context("when the plan asks for a fresh fetch", func() {
+					BeforeEach(func() {
+						getPlan.Fresh = true
+						shouldRunGetStep = true
+					})
+
+					It("invalidates the resource cache and runs the get step", func() {
+						Expect(fakeResourceCacheFactory.InvalidateResourceCacheCallCount()).To(Equal(1))
+						Expect(fakeResourceCacheFactory.InvalidateResourceCacheArgsForCall(0)).To(Equal(fakeResourceCache))
+						Expect(fakePool.FindWorkersForResourceCacheCallCount()).To(BeZero())
+						Expect(stderrBuf).To(gbytes.Say("fetching a fresh copy"))
+					})
+				})
 			})
 		})
 	})
 
+	It("does not invalidate the resource cache", func() {
+		Expect(fakeResourceCacheFactory.InvalidateResourceCacheCallCount()).To(BeZero())
+	})
+
+	Context("when invalidating the resource cache for a fresh fetch fails", func() {
+		BeforeEach(func() {
+			getPlan.Fresh = true
+			fakeResourceCacheFactory.InvalidateResourceCacheReturns(errors.New("nope"))
+			shouldRunGetStep = false
+		})
+
+		It("returns the error without running the get step", func() {
+			Expect(stepErr).To(MatchError("nope"))
+		})
+	})
+
 	It("calls RunGetStep with the correct ContainerOwner", func() {
 		Expect(owner).To(Equal(db.NewBuildStepContainerOwner(
 			stepMetadata.BuildID,
@@ -415,6 +515,16 @@ var _ = Describe("GetStep", func() {
 			})
 		})
 
+		Context("when the plan specifies an arch", func() {
+			BeforeEach(func() {
+				getPlan.Arch = "arm64"
+			})
+
+			It("sets it in the WorkerSpec", func() {
+				Expect(workerSpec.Arch).To(Equal("arm64"))
+			})
+		})
+
 		Context("when selecting a worker fails", func() {
 			BeforeEach(func() {
 				fakePool.SelectWorkerReturns(nil, 0, errors.New("nope"))
@@ -513,6 +623,58 @@ var _ = Describe("GetStep", func() {
 			Expect(privileged).To(BeFalse())
 		})
 
+		Context("when the plan configures a registry mirror", func() {
+			BeforeEach(func() {
+				getPlan.RegistryMirror = &atc.RegistryMirror{
+					Host:     "some-mirror",
+					Username: "some-user",
+					Password: "((source-var))",
+				}
+			})
+
+			It("fetches the image of the type fetched with registry-image from the mirror", func() {
+				Expect(fakeDelegate.FetchImageCallCount()).To(Equal(1))
+				_, imageResource, types, _ := fakeDelegate.FetchImageArgsForCall(0)
+				Expect(imageResource.Source).To(Equal(atc.Source{"some-custom": "((source-var))"}))
+
+				anotherType, found := types.Lookup("another-custom-type")
+				Expect(found).To(BeTrue())
+				Expect(anotherType.Source).To(Equal(atc.Source{
+					"another-custom": "((source-var))",
+					"registry_mirror": map[string]interface{}{
+						"host":     "some-mirror",
+						"username": "some-user",
+						"password": "super-secret-source",
+					},
+				}))
+			})
+
+			It("does not modify the plan's resource types", func() {
+				anotherType, found := getPlan.VersionedResourceTypes.Lookup("another-custom-type")
+				Expect(found).To(BeTrue())
+				Expect(anotherType.Source).To(Equal(atc.Source{"another-custom": "((source-var))"}))
+			})
+
+			Context("when the type is not fetched with registry-image", func() {
+				BeforeEach(func() {
+					getPlan.VersionedResourceTypes = atc.VersionedResourceTypes{
+						{
+							ResourceType: atc.ResourceType{
+								Name: "some-custom-type",
+								Type: "docker-image",
+							},
+						},
+					}
+					shouldRunGetStep = false
+				})
+
+				It("errors without fetching the image", func() {
+					Expect(stepErr).To(Equal(atc.RegistryMirrorNotSupportedError{Type: "docker-image"}))
+					Expect(fakeDelegate.FetchImageCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when the plan configures tags", func() {
 			BeforeEach(func() {
 				getPlan.Tags = atc.Tags{"plan", "tags"}
@@ -683,6 +845,23 @@ var _ = Describe("GetStep", func() {
 				Expect(actualVersionResult.Version).To(Equal(atc.Version{"some": "version"}))
 				Expect(actualVersionResult.Metadata).To(Equal([]atc.MetadataField{{Name: "some", Value: "metadata"}}))
 			})
+
+			It("does not save the version as the build's input", func() {
+				Expect(fakeDelegate.SaveInputCallCount()).To(BeZero())
+			})
+
+			Context("when the plan records its input", func() {
+				BeforeEach(func() {
+					getPlan.RecordInput = true
+				})
+
+				It("saves the fetched version as the build's input", func() {
+					Expect(fakeDelegate.SaveInputCallCount()).To(Equal(1))
+					_, actualPlan, actualVersionResult := fakeDelegate.SaveInputArgsForCall(0)
+					Expect(actualPlan.Resource).To(Equal("some-pipeline-resource"))
+					Expect(actualVersionResult.Version).To(Equal(atc.Version{"some": "version"}))
+				})
+			})
 		})
 
 		Context("when getting an anonymous resource", func() {
@@ -6,27 +6,24 @@ import (
 	"sync/atomic"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/util"
 	"github.com/hashicorp/go-multierror"
 )
 
 // InParallelStep is a step of steps to run in parallel.
 type InParallelStep struct {
-	steps       []Step
-	maxInFlight atc.MaxInFlightConfig
-	failFast    bool
+	steps    []Step
+	limit    atc.InParallelLimit
+	failFast bool
 }
 
 // InParallel constructs an InParallelStep.
-func InParallel(steps []Step, limit int, failFast bool) InParallelStep {
-	maxInFlight := atc.MaxInFlightConfig{Limit: limit}
-	if limit < 1 {
-		maxInFlight.All = true
-	}
+func InParallel(steps []Step, limit atc.InParallelLimit, failFast bool) InParallelStep {
 	return InParallelStep{
-		steps:       steps,
-		maxInFlight: maxInFlight,
-		failFast:    failFast,
+		steps:    steps,
+		limit:    limit,
+		failFast: failFast,
 	}
 }
 
@@ -40,11 +37,25 @@ func InParallel(steps []Step, limit int, failFast bool) InParallelStep {
 // Cancelling a parallel step means that any outstanding steps will not be scheduled to run.
 // After all steps finish, their errors (if any) will be collected and returned as a
 // single error.
+//
+// The limit may be a var, which is resolved when the step runs.
 func (step InParallelStep) Run(ctx context.Context, state RunState) (bool, error) {
+	maxInFlight := atc.MaxInFlightConfig{All: true}
+	if step.limit != "" {
+		limit, err := creds.NewInParallelLimit(state, step.limit).Evaluate()
+		if err != nil {
+			return false, err
+		}
+
+		if limit > 0 {
+			maxInFlight = atc.MaxInFlightConfig{Limit: limit}
+		}
+	}
+
 	return parallelExecutor{
 		stepName: "in_parallel",
 
-		maxInFlight: &step.maxInFlight,
+		maxInFlight: &maxInFlight,
 		failFast:    step.failFast,
 		count:       len(step.steps),
 
@@ -10,6 +10,7 @@ import (
 	. "github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -38,7 +39,7 @@ var _ = Describe("Parallel", func() {
 		fakeStepB = new(execfakes.FakeStep)
 		fakeSteps = []Step{fakeStepA, fakeStepB}
 
-		step = InParallel(fakeSteps, len(fakeSteps), false)
+		step = InParallel(fakeSteps, "2", false)
 
 		repo = build.NewRepository()
 		state = new(execfakes.FakeRunState)
@@ -94,7 +95,7 @@ var _ = Describe("Parallel", func() {
 
 		Context("when parallel limit is 1", func() {
 			BeforeEach(func() {
-				step = InParallel(fakeSteps, 1, false)
+				step = InParallel(fakeSteps, "1", false)
 				ch := make(chan struct{}, 1)
 
 				fakeStepA.RunStub = func(context.Context, RunState) (bool, error) {
@@ -119,6 +120,42 @@ var _ = Describe("Parallel", func() {
 				Expect(fakeStepA.RunCallCount()).To(Equal(1))
 				Expect(fakeStepB.RunCallCount()).To(Equal(1))
 			})
+
+			Context("when the limit is a var", func() {
+				BeforeEach(func() {
+					step = InParallel(fakeSteps, "((.:limit))", false)
+
+					state.GetStub = func(ref vars.Reference) (interface{}, bool, error) {
+						if ref.Source == "." && ref.Path == "limit" {
+							return 1, true, nil
+						}
+
+						return nil, false, nil
+					}
+				})
+
+				It("resolves the var", func() {
+					Expect(state.GetCallCount()).To(Equal(1))
+				})
+
+				It("happens sequentially", func() {
+					Expect(fakeStepA.RunCallCount()).To(Equal(1))
+					Expect(fakeStepB.RunCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when the limit is a var which is not a number", func() {
+			BeforeEach(func() {
+				step = InParallel(fakeSteps, "((.:limit))", false)
+				state.GetReturns("lots", true, nil)
+			})
+
+			It("errors without running the steps", func() {
+				Expect(stepErr).To(MatchError("invalid limit 'lots': must be a number no less than 0"))
+				Expect(fakeStepA.RunCallCount()).To(BeZero())
+				Expect(fakeStepB.RunCallCount()).To(BeZero())
+			})
 		})
 	})
 
@@ -153,7 +190,7 @@ var _ = Describe("Parallel", func() {
 
 		Context("when there are steps pending execution", func() {
 			BeforeEach(func() {
-				step = InParallel(fakeSteps, 1, false)
+				step = InParallel(fakeSteps, "1", false)
 
 				fakeStepA.RunStub = func(context.Context, RunState) (bool, error) {
 					cancel()
@@ -190,7 +227,7 @@ var _ = Describe("Parallel", func() {
 
 			Context("and fail fast is false", func() {
 				BeforeEach(func() {
-					step = InParallel(fakeSteps, 1, false)
+					step = InParallel(fakeSteps, "1", false)
 				})
 				It("lets all steps finish before exiting", func() {
 					Expect(fakeStepA.RunCallCount()).To(Equal(1))
@@ -204,7 +241,7 @@ var _ = Describe("Parallel", func() {
 
 			Context("and fail fast is true", func() {
 				BeforeEach(func() {
-					step = InParallel(fakeSteps, 1, true)
+					step = InParallel(fakeSteps, "1", true)
 				})
 				It("it cancels remaining steps", func() {
 					Expect(fakeStepA.RunCallCount()).To(Equal(1))
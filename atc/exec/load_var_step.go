@@ -3,6 +3,7 @@ package exec
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
@@ -10,12 +11,15 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/ini.v1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 )
@@ -123,7 +127,7 @@ func (step *LoadVarStep) fetchVars(
 	artifactName := segs[0]
 	filePath := segs[1]
 
-	format, err := step.fileFormat(file)
+	format, err := varFileFormat(step.plan.Format, file)
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +141,22 @@ func (step *LoadVarStep) fetchVars(
 		}
 	}
 
-	stream, err := step.artifactStreamer.StreamFileFromArtifact(lagerctx.NewContext(ctx, logger), art, filePath)
+	fileContent, err := readArtifactFile(lagerctx.NewContext(ctx, logger), step.artifactStreamer, art, artifactName, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseVarFile(file, format, fileContent)
+}
+
+func readArtifactFile(
+	ctx context.Context,
+	artifactStreamer worker.ArtifactStreamer,
+	art runtime.Artifact,
+	artifactName string,
+	filePath string,
+) ([]byte, error) {
+	stream, err := artifactStreamer.StreamFileFromArtifact(ctx, art, filePath)
 	if err != nil {
 		if err == baggageclaim.ErrFileNotFound {
 			return nil, artifact.FileNotFoundError{
@@ -148,13 +167,16 @@ func (step *LoadVarStep) fetchVars(
 
 		return nil, err
 	}
+	defer stream.Close()
 
-	fileContent, err := ioutil.ReadAll(stream)
-	if err != nil {
-		return nil, err
-	}
+	return ioutil.ReadAll(stream)
+}
 
+// parseVarFile parses the content of the file in the given format, as
+// returned by varFileFormat.
+func parseVarFile(file string, format string, fileContent []byte) (interface{}, error) {
 	var value interface{}
+	var err error
 	switch format {
 	case "json":
 		value = map[string]interface{}{}
@@ -168,6 +190,22 @@ func (step *LoadVarStep) fetchVars(
 		if err != nil {
 			return nil, InvalidLocalVarFile{file, "yaml", err}
 		}
+	case "dotenv", "env":
+		value, err = parseDotenv(fileContent)
+		if err != nil {
+			return nil, InvalidLocalVarFile{file, "dotenv", err}
+		}
+	case "toml":
+		value = map[string]interface{}{}
+		_, err = toml.Decode(string(fileContent), &value)
+		if err != nil {
+			return nil, InvalidLocalVarFile{file, "toml", err}
+		}
+	case "ini":
+		value, err = parseINI(fileContent)
+		if err != nil {
+			return nil, InvalidLocalVarFile{file, "ini", err}
+		}
 	case "trim":
 		value = strings.TrimSpace(string(fileContent))
 	case "raw":
@@ -179,26 +217,135 @@ func (step *LoadVarStep) fetchVars(
 	return value, nil
 }
 
-func (step *LoadVarStep) fileFormat(file string) (string, error) {
-	if step.isValidFormat(step.plan.Format) {
-		return step.plan.Format, nil
-	} else if step.plan.Format != "" {
-		return "", fmt.Errorf("invalid format %s", step.plan.Format)
+// varFileFormat returns the configured format if set, otherwise it is
+// inferred from the file's extension, defaulting to trim.
+func varFileFormat(configuredFormat string, file string) (string, error) {
+	if isValidVarFileFormat(configuredFormat) {
+		return configuredFormat, nil
+	} else if configuredFormat != "" {
+		return "", fmt.Errorf("invalid format %s", configuredFormat)
 	}
 
 	fileExt := filepath.Ext(file)
 	format := strings.TrimPrefix(fileExt, ".")
-	if step.isValidFormat(format) {
+	if isValidVarFileFormat(format) {
 		return format, nil
 	}
 
 	return "trim", nil
 }
 
-func (step *LoadVarStep) isValidFormat(format string) bool {
+func isValidVarFileFormat(format string) bool {
 	switch format {
-	case "raw", "trim", "yml", "yaml", "json":
+	case "raw", "trim", "yml", "yaml", "json", "dotenv", "env", "toml", "ini":
 		return true
 	}
 	return false
 }
+
+// parseDotenv parses KEY=VALUE lines, as written for `source` or docker's
+// --env-file. Lines may be prefixed with `export`, and values may be quoted;
+// double-quoted values support \n, \" and \\ escapes.
+func parseDotenv(content []byte) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		segs := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(segs[0])
+		if len(segs) != 2 || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", i+1)
+		}
+
+		value, err := dotenvValue(strings.TrimSpace(segs[1]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+func dotenvValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	switch raw[0] {
+	case '\'':
+		end := strings.IndexByte(raw[1:], '\'')
+		if end == -1 {
+			return "", errors.New("unterminated single-quoted value")
+		}
+
+		return raw[1 : end+1], nil
+
+	case '"':
+		var value strings.Builder
+		for i := 1; i < len(raw); i++ {
+			switch raw[i] {
+			case '"':
+				return value.String(), nil
+			case '\\':
+				i++
+				if i == len(raw) {
+					break
+				}
+
+				switch raw[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(raw[i])
+				}
+			default:
+				value.WriteByte(raw[i])
+			}
+		}
+
+		return "", errors.New("unterminated double-quoted value")
+	}
+
+	if comment := strings.Index(raw, " #"); comment != -1 {
+		raw = strings.TrimSpace(raw[:comment])
+	}
+
+	return raw, nil
+}
+
+// parseINI returns the keys of the default section at the top level, with
+// each named section's keys nested under its name.
+func parseINI(content []byte) (map[string]interface{}, error) {
+	file, err := ini.Load(content)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	for _, section := range file.Sections() {
+		if section.Name() == ini.DefaultSection {
+			for _, key := range section.Keys() {
+				values[key.Name()] = key.String()
+			}
+
+			continue
+		}
+
+		sectionValues := map[string]interface{}{}
+		for _, key := range section.Keys() {
+			sectionValues[key.Name()] = key.String()
+		}
+
+		values[section.Name()] = sectionValues
+	}
+
+	return values, nil
+}
@@ -33,6 +33,29 @@ const jsonString = `
 }
 `
 
+const dotenvString = `
+# some comment
+export K1=dv1
+K2="dv2 \"quoted\"\nnext"
+K3='dv3 $literal'
+K4=dv4 # trailing comment
+`
+
+const tomlString = `
+k1 = "tv1"
+k2 = 2
+
+[section]
+k3 = "tv3"
+`
+
+const iniString = `
+k1 = iv1
+
+[section]
+k2 = iv2
+`
+
 var _ = Describe("LoadVarStep", func() {
 
 	var (
@@ -249,6 +272,81 @@ var _ = Describe("LoadVarStep", func() {
 		})
 	})
 
+	Context("when format is dotenv", func() {
+		BeforeEach(func() {
+			loadVarPlan = &atc.LoadVarPlan{
+				Name:   "some-var",
+				File:   "some-resource/a.diff",
+				Format: "dotenv",
+			}
+
+			fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: dotenvString}, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("should var parsed correctly", func() {
+			expectLocalVarAdded("some-var", map[string]interface{}{
+				"K1": "dv1",
+				"K2": "dv2 \"quoted\"\nnext",
+				"K3": "dv3 $literal",
+				"K4": "dv4",
+			}, true)
+		})
+	})
+
+	Context("when format is toml", func() {
+		BeforeEach(func() {
+			loadVarPlan = &atc.LoadVarPlan{
+				Name:   "some-var",
+				File:   "some-resource/a.diff",
+				Format: "toml",
+			}
+
+			fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: tomlString}, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("should var parsed correctly", func() {
+			expectLocalVarAdded("some-var", map[string]interface{}{
+				"k1":      "tv1",
+				"k2":      int64(2),
+				"section": map[string]interface{}{"k3": "tv3"},
+			}, true)
+		})
+	})
+
+	Context("when format is ini", func() {
+		BeforeEach(func() {
+			loadVarPlan = &atc.LoadVarPlan{
+				Name:   "some-var",
+				File:   "some-resource/a.diff",
+				Format: "ini",
+			}
+
+			fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: iniString}, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("should var parsed correctly", func() {
+			expectLocalVarAdded("some-var", map[string]interface{}{
+				"k1":      "iv1",
+				"section": map[string]interface{}{"k2": "iv2"},
+			}, true)
+		})
+	})
+
 	Context("when format is not specified", func() {
 		Context("when file extension is other than json, yml and yaml", func() {
 			BeforeEach(func() {
@@ -310,6 +408,21 @@ var _ = Describe("LoadVarStep", func() {
 			})
 		})
 
+		Context("when the file is a .env file", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name: "some-var",
+					File: "some-resource/a.env",
+				}
+
+				fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: "K=v"}, nil)
+			})
+
+			It("should var parsed as dotenv", func() {
+				expectLocalVarAdded("some-var", map[string]interface{}{"K": "v"}, true)
+			})
+		})
+
 		Context("when format is yaml", func() {
 			BeforeEach(func() {
 				loadVarPlan = &atc.LoadVarPlan{
@@ -348,6 +461,54 @@ var _ = Describe("LoadVarStep", func() {
 			})
 		})
 
+		Context("when dotenv file is bad", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name: "some-var",
+					File: "some-resource/a.env",
+				}
+
+				fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: "K=\"unterminated"}, nil)
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(HaveOccurred())
+				Expect(stepErr).To(MatchError(ContainSubstring("failed to parse some-resource/a.env in format dotenv: line 1: unterminated double-quoted value")))
+			})
+		})
+
+		Context("when toml file is bad", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name: "some-var",
+					File: "some-resource/a.toml",
+				}
+
+				fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: "k ="}, nil)
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(HaveOccurred())
+				Expect(stepErr).To(MatchError(ContainSubstring("failed to parse some-resource/a.toml in format toml")))
+			})
+		})
+
+		Context("when ini file is bad", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name: "some-var",
+					File: "some-resource/a.ini",
+				}
+
+				fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: "[unclosed"}, nil)
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(HaveOccurred())
+				Expect(stepErr).To(MatchError(ContainSubstring("failed to parse some-resource/a.ini in format ini")))
+			})
+		})
+
 		Context("when yaml file is bad", func() {
 			BeforeEach(func() {
 				loadVarPlan = &atc.LoadVarPlan{
@@ -0,0 +1,171 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/tracing"
+)
+
+// LoadVarsStep loads every file matching a glob within an artifact, setting
+// each as a build-local var named after the file without its extension.
+type LoadVarsStep struct {
+	planID           atc.PlanID
+	plan             atc.LoadVarsPlan
+	metadata         StepMetadata
+	delegateFactory  BuildStepDelegateFactory
+	artifactStreamer worker.ArtifactStreamer
+}
+
+func NewLoadVarsStep(
+	planID atc.PlanID,
+	plan atc.LoadVarsPlan,
+	metadata StepMetadata,
+	delegateFactory BuildStepDelegateFactory,
+	artifactStreamer worker.ArtifactStreamer,
+) Step {
+	return &LoadVarsStep{
+		planID:           planID,
+		plan:             plan,
+		metadata:         metadata,
+		delegateFactory:  delegateFactory,
+		artifactStreamer: artifactStreamer,
+	}
+}
+
+type NoLoadVarsFilesMatchedError struct {
+	Files string
+}
+
+// Error returns a human-friendly error message.
+func (err NoLoadVarsFilesMatchedError) Error() string {
+	return fmt.Sprintf("no files match '%s'", err.Files)
+}
+
+type DuplicateLoadVarsNameError struct {
+	Name  string
+	Files []string
+}
+
+// Error returns a human-friendly error message.
+func (err DuplicateLoadVarsNameError) Error() string {
+	return fmt.Sprintf("files %s would all set var %s", strings.Join(err.Files, ", "), err.Name)
+}
+
+func (step *LoadVarsStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.BuildStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "load_vars", tracing.Attrs{
+		"files": step.plan.Files,
+	})
+
+	ok, err := step.run(ctx, state, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *LoadVarsStep) run(ctx context.Context, state RunState, delegate BuildStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("load-vars-step", lager.Data{
+		"files":  step.plan.Files,
+		"job-id": step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+	stdout := delegate.Stdout()
+	stderr := delegate.Stderr()
+
+	fmt.Fprintln(stderr, "\x1b[1;33mWARNING: the load_vars step is experimental and subject to change!\x1b[0m")
+	fmt.Fprintln(stderr, "")
+
+	delegate.Starting(logger)
+
+	segs := strings.SplitN(step.plan.Files, "/", 2)
+	if len(segs) != 2 {
+		return false, UnspecifiedLoadVarStepFileError{step.plan.Files}
+	}
+
+	artifactName := segs[0]
+	pattern := segs[1]
+
+	art, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName(artifactName))
+	if !found {
+		return false, artifact.UnknownArtifactSourceError{
+			Name: artifactName,
+			Path: pattern,
+		}
+	}
+
+	streamCtx := lagerctx.NewContext(ctx, logger)
+
+	filePaths, err := step.artifactStreamer.GlobFilesInArtifact(streamCtx, art, pattern)
+	if err != nil {
+		return false, err
+	}
+
+	if len(filePaths) == 0 {
+		return false, NoLoadVarsFilesMatchedError{step.plan.Files}
+	}
+
+	filesByName := map[string][]string{}
+	for _, filePath := range filePaths {
+		name := varNameForFile(filePath)
+		filesByName[name] = append(filesByName[name], filePath)
+	}
+
+	for _, filePath := range filePaths {
+		name := varNameForFile(filePath)
+		if len(filesByName[name]) > 1 {
+			return false, DuplicateLoadVarsNameError{name, filesByName[name]}
+		}
+	}
+
+	values := make([]interface{}, len(filePaths))
+	for i, filePath := range filePaths {
+		file := path.Join(artifactName, filePath)
+
+		format, err := varFileFormat(step.plan.Format, file)
+		if err != nil {
+			return false, err
+		}
+
+		fileContent, err := readArtifactFile(streamCtx, step.artifactStreamer, art, artifactName, filePath)
+		if err != nil {
+			return false, err
+		}
+
+		values[i], err = parseVarFile(file, format, fileContent)
+		if err != nil {
+			return false, err
+		}
+
+		fmt.Fprintf(stdout, "var %s fetched from %s.\n", varNameForFile(filePath), file)
+	}
+
+	for i, filePath := range filePaths {
+		name := varNameForFile(filePath)
+
+		state.AddLocalVar(name, values[i], !step.plan.Reveal)
+		fmt.Fprintf(stdout, "added var %s to build.\n", name)
+	}
+
+	delegate.Finished(logger, true)
+
+	return true, nil
+}
+
+// varNameForFile returns the file's name without its directory or extension,
+// e.g. vars/version.yml is loaded as the var version.
+func varNameForFile(filePath string) string {
+	base := path.Base(filePath)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
@@ -0,0 +1,280 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/baggageclaim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/build/buildfakes"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/tracing"
+)
+
+var _ = Describe("LoadVarsStep", func() {
+
+	var (
+		ctx        context.Context
+		cancel     func()
+		testLogger *lagertest.TestLogger
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		fakeArtifactStreamer *workerfakes.FakeArtifactStreamer
+		files                map[string]string
+
+		loadVarsPlan       *atc.LoadVarsPlan
+		artifactRepository *build.Repository
+		state              *execfakes.FakeRunState
+		fakeSource         *buildfakes.FakeRegisterableArtifact
+
+		spStep  exec.Step
+		stepOk  bool
+		stepErr error
+
+		stepMetadata = exec.StepMetadata{
+			TeamID:       123,
+			TeamName:     "some-team",
+			BuildID:      42,
+			BuildName:    "some-build",
+			PipelineID:   4567,
+			PipelineName: "some-pipeline",
+		}
+
+		stdout, stderr *gbytes.Buffer
+
+		planID = "56"
+	)
+
+	BeforeEach(func() {
+		testLogger = lagertest.NewTestLogger("vars-step-test")
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, testLogger)
+
+		artifactRepository = build.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactRepositoryReturns(artifactRepository)
+
+		fakeSource = new(buildfakes.FakeRegisterableArtifact)
+		artifactRepository.RegisterArtifact("some-resource", fakeSource)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StderrReturns(stderr)
+		fakeDelegate.StartSpanReturns(context.Background(), tracing.NoopSpan)
+
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		files = map[string]string{
+			"vars/a.yml":   yamlString,
+			"vars/b.json":  jsonString,
+			"vars/version": plainString,
+		}
+
+		fakeArtifactStreamer = new(workerfakes.FakeArtifactStreamer)
+		fakeArtifactStreamer.GlobFilesInArtifactStub = func(_ context.Context, _ runtime.Artifact, pattern string) ([]string, error) {
+			switch pattern {
+			case "vars/*":
+				return []string{"vars/a.yml", "vars/b.json", "vars/version"}, nil
+			case "vars/*.yml":
+				return []string{"vars/a.yml"}, nil
+			default:
+				return nil, nil
+			}
+		}
+		fakeArtifactStreamer.StreamFileFromArtifactStub = func(_ context.Context, _ runtime.Artifact, filePath string) (io.ReadCloser, error) {
+			content, found := files[filePath]
+			if !found {
+				return nil, baggageclaim.ErrFileNotFound
+			}
+
+			return &fakeReadCloser{str: content}, nil
+		}
+
+		loadVarsPlan = &atc.LoadVarsPlan{
+			Files: "some-resource/vars/*",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		plan := atc.Plan{
+			ID:       atc.PlanID(planID),
+			LoadVars: loadVarsPlan,
+		}
+
+		spStep = exec.NewLoadVarsStep(
+			plan.ID,
+			*plan.LoadVars,
+			stepMetadata,
+			fakeDelegateFactory,
+			fakeArtifactStreamer,
+		)
+
+		stepOk, stepErr = spStep.Run(ctx, state)
+	})
+
+	It("globs the files in the artifact", func() {
+		Expect(fakeArtifactStreamer.GlobFilesInArtifactCallCount()).To(Equal(1))
+		_, art, pattern := fakeArtifactStreamer.GlobFilesInArtifactArgsForCall(0)
+		Expect(art).To(Equal(fakeSource))
+		Expect(pattern).To(Equal("vars/*"))
+	})
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(stepOk).To(BeTrue())
+	})
+
+	It("adds a var for each file, named after the file and parsed by its extension", func() {
+		Expect(state.AddLocalVarCallCount()).To(Equal(3))
+
+		k, v, redact := state.AddLocalVarArgsForCall(0)
+		Expect(k).To(Equal("a"))
+		Expect(v).To(Equal(map[string]interface{}{"k1": "yv1", "k2": "yv2"}))
+		Expect(redact).To(BeTrue())
+
+		k, v, redact = state.AddLocalVarArgsForCall(1)
+		Expect(k).To(Equal("b"))
+		Expect(v).To(Equal(map[string]interface{}{"k1": "jv1", "k2": "jv2"}))
+		Expect(redact).To(BeTrue())
+
+		k, v, redact = state.AddLocalVarArgsForCall(2)
+		Expect(k).To(Equal("version"))
+		Expect(v).To(Equal("pv"))
+		Expect(redact).To(BeTrue())
+	})
+
+	It("prints the vars it loaded", func() {
+		Expect(stdout).To(gbytes.Say("var a fetched from some-resource/vars/a.yml."))
+		Expect(stdout).To(gbytes.Say("var b fetched from some-resource/vars/b.json."))
+		Expect(stdout).To(gbytes.Say("var version fetched from some-resource/vars/version."))
+		Expect(stdout).To(gbytes.Say("added var a to build."))
+	})
+
+	It("finishes the step via the delegate", func() {
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+	})
+
+	Context("when format is specified", func() {
+		BeforeEach(func() {
+			loadVarsPlan.Files = "some-resource/vars/*.yml"
+			loadVarsPlan.Format = "raw"
+		})
+
+		It("parses every file in that format", func() {
+			Expect(state.AddLocalVarCallCount()).To(Equal(1))
+			k, v, _ := state.AddLocalVarArgsForCall(0)
+			Expect(k).To(Equal("a"))
+			Expect(v).To(Equal(yamlString))
+		})
+	})
+
+	Context("when reveal is true", func() {
+		BeforeEach(func() {
+			loadVarsPlan.Reveal = true
+		})
+
+		It("does not redact the vars", func() {
+			for i := 0; i < state.AddLocalVarCallCount(); i++ {
+				_, _, redact := state.AddLocalVarArgsForCall(i)
+				Expect(redact).To(BeFalse())
+			}
+		})
+	})
+
+	Context("when no files match", func() {
+		BeforeEach(func() {
+			loadVarsPlan.Files = "some-resource/missing/*"
+		})
+
+		It("step should fail", func() {
+			Expect(stepErr).To(MatchError("no files match 'some-resource/missing/*'"))
+			Expect(state.AddLocalVarCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when two files would set the same var", func() {
+		BeforeEach(func() {
+			fakeArtifactStreamer.GlobFilesInArtifactReturns([]string{"vars/a.json", "vars/a.yml"}, nil)
+			fakeArtifactStreamer.GlobFilesInArtifactStub = nil
+		})
+
+		It("step should fail", func() {
+			Expect(stepErr).To(Equal(exec.DuplicateLoadVarsNameError{
+				Name:  "a",
+				Files: []string{"vars/a.json", "vars/a.yml"},
+			}))
+			Expect(state.AddLocalVarCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a file is bad", func() {
+		BeforeEach(func() {
+			files["vars/b.json"] = "{"
+		})
+
+		It("step should fail without adding any vars", func() {
+			Expect(stepErr).To(MatchError(ContainSubstring("failed to parse some-resource/vars/b.json in format json")))
+			Expect(state.AddLocalVarCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when globbing fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeArtifactStreamer.GlobFilesInArtifactStub = nil
+			fakeArtifactStreamer.GlobFilesInArtifactReturns(nil, disaster)
+		})
+
+		It("step should fail", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when the files are not in an artifact", func() {
+		BeforeEach(func() {
+			loadVarsPlan.Files = "*.yml"
+		})
+
+		It("step should fail", func() {
+			Expect(stepErr).To(Equal(exec.UnspecifiedLoadVarStepFileError{File: "*.yml"}))
+		})
+	})
+
+	Context("when the artifact is not registered", func() {
+		BeforeEach(func() {
+			loadVarsPlan.Files = "some-resource-not-in-the-registry/vars/*"
+		})
+
+		It("step should fail", func() {
+			Expect(stepErr).To(Equal(artifact.UnknownArtifactSourceError{
+				Name: "some-resource-not-in-the-registry",
+				Path: "vars/*",
+			}))
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+type MeasureStep struct {
+	Step
+
+	stepType string
+}
+
+// MeasureDuration wraps a step so that its duration and outcome are
+// emitted as a "step finished" metric labelled with the given step type.
+func MeasureDuration(step Step, stepType string) Step {
+	return MeasureStep{
+		Step: step,
+
+		stepType: stepType,
+	}
+}
+
+func (step MeasureStep) Run(ctx context.Context, state RunState) (bool, error) {
+	start := time.Now()
+
+	runOk, runErr := step.Step.Run(ctx, state)
+
+	status := "succeeded"
+	switch {
+	case errors.Is(runErr, context.Canceled):
+		status = "aborted"
+	case runErr != nil:
+		status = "errored"
+	case !runOk:
+		status = "failed"
+	}
+
+	metric.StepFinished{
+		StepType: step.stepType,
+		Status:   status,
+		Duration: time.Since(start),
+	}.Emit(lagerctx.FromContext(ctx))
+
+	return runOk, runErr
+}
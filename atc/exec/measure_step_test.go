@@ -0,0 +1,55 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MeasureStep", func() {
+	var (
+		fakeStep *execfakes.FakeStep
+		state    *execfakes.FakeRunState
+
+		step Step
+	)
+
+	BeforeEach(func() {
+		fakeStep = new(execfakes.FakeStep)
+		state = new(execfakes.FakeRunState)
+
+		step = MeasureDuration(fakeStep, "task")
+	})
+
+	It("runs the inner step", func() {
+		fakeStep.RunReturns(true, nil)
+
+		ok, err := step.Run(context.Background(), state)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		Expect(fakeStep.RunCallCount()).To(Equal(1))
+		_, actualState := fakeStep.RunArgsForCall(0)
+		Expect(actualState).To(Equal(state))
+	})
+
+	It("returns the inner step's failure", func() {
+		fakeStep.RunReturns(false, nil)
+
+		ok, err := step.Run(context.Background(), state)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("returns the inner step's error", func() {
+		disaster := errors.New("nope")
+		fakeStep.RunReturns(false, disaster)
+
+		_, err := step.Run(context.Background(), state)
+		Expect(err).To(Equal(disaster))
+	})
+})
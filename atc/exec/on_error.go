@@ -4,21 +4,25 @@ import (
 	"context"
 	"errors"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/hashicorp/go-multierror"
 )
 
 // OnErrorStep will run one step, and then a second step if the first step
 // errors.
 type OnErrorStep struct {
-	step Step
-	hook Step
+	step     Step
+	hook     Step
+	stepName string
 }
 
-// OnError constructs an OnErrorStep factory.
-func OnError(step Step, hook Step) OnErrorStep {
+// OnError constructs an OnErrorStep factory. The stepName identifies the step
+// in the error var made available to the hook, and may be empty.
+func OnError(step Step, hook Step, stepName string) OnErrorStep {
 	return OnErrorStep{
-		step: step,
-		hook: hook,
+		step:     step,
+		hook:     hook,
+		stepName: stepName,
 	}
 }
 
@@ -26,8 +30,9 @@ func OnError(step Step, hook Step) OnErrorStep {
 // first step errors, Run returns the error. OnErrorStep is ready as soon as
 // the first step is ready.
 //
-// If the first step errors, the second
-// step is executed. If the second step errors, nothing is returned.
+// If the first step errors, the second step is executed with the error set
+// as the build-local var "error" (see ErrorVar). If the second step errors,
+// nothing is returned.
 func (o OnErrorStep) Run(ctx context.Context, state RunState) (bool, error) {
 	var errs error
 	stepRunOk, stepRunErr := o.step.Run(ctx, state)
@@ -39,6 +44,8 @@ func (o OnErrorStep) Run(ctx context.Context, state RunState) (bool, error) {
 
 	// for all errors that aren't caused by an Abort, run the hook
 	if !errors.Is(stepRunErr, context.Canceled) {
+		state.AddLocalVar(ErrorVarName, ErrorVar(o.stepName, errorMessage(stepRunErr)), false)
+
 		_, err := o.hook.Run(context.Background(), state)
 		if err != nil {
 			// This causes to return both the errors as expected.
@@ -48,3 +55,33 @@ func (o OnErrorStep) Run(ctx context.Context, state RunState) (bool, error) {
 
 	return stepRunOk, errs
 }
+
+// ErrorVarName is the name of the build-local var set before running an
+// on_error or on_failure hook, e.g. ((.:error.message)). Pipelines are not
+// allowed to declare a var with the same name.
+const ErrorVarName = atc.ErrorVarName
+
+// ErrorVar returns the value of the error var for a step which errored or
+// failed with the given message.
+func ErrorVar(stepName string, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"step":    stepName,
+		"message": message,
+	}
+}
+
+// errorMessage returns the message shown for an error, matching the message
+// logged by LogErrorStep. When errors from nested steps have been combined,
+// the first one is the error which caused the others.
+func errorMessage(err error) string {
+	var merr *multierror.Error
+	if errors.As(err, &merr) && len(merr.Errors) > 0 {
+		err = merr.Errors[0]
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return TimeoutLogMessage
+	}
+
+	return err.Error()
+}
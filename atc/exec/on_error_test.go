@@ -41,7 +41,7 @@ var _ = Describe("On Error Step", func() {
 		state = new(execfakes.FakeRunState)
 		state.ArtifactRepositoryReturns(repo)
 
-		onErrorStep = exec.OnError(step, hook)
+		onErrorStep = exec.OnError(step, hook, "some-step")
 
 		stepErr = nil
 
@@ -66,6 +66,28 @@ var _ = Describe("On Error Step", func() {
 			Expect(hook.RunCallCount()).To(Equal(1))
 			Expect(step.RunCallCount()).To(Equal(1))
 		})
+
+		It("sets the error var for the hook", func() {
+			Expect(state.AddLocalVarCallCount()).To(Equal(1))
+			name, val, redact := state.AddLocalVarArgsForCall(0)
+			Expect(name).To(Equal("error"))
+			Expect(val).To(Equal(map[string]interface{}{
+				"step":    "some-step",
+				"message": "disaster",
+			}))
+			Expect(redact).To(BeFalse())
+		})
+
+		Context("when the step times out", func() {
+			BeforeEach(func() {
+				step.RunReturns(false, context.DeadlineExceeded)
+			})
+
+			It("sets the timeout message", func() {
+				_, val, _ := state.AddLocalVarArgsForCall(0)
+				Expect(val).To(HaveKeyWithValue("message", "timeout exceeded"))
+			})
+		})
 	})
 
 	Context("when the step succeeds", func() {
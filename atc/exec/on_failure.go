@@ -2,20 +2,24 @@ package exec
 
 import (
 	"context"
+	"fmt"
 )
 
 // OnFailureStep will run one step, and then a second step if the first step
 // fails (but not errors).
 type OnFailureStep struct {
-	step Step
-	hook Step
+	step     Step
+	hook     Step
+	stepName string
 }
 
-// OnFailure constructs an OnFailureStep factory.
-func OnFailure(firstStep Step, secondStep Step) OnFailureStep {
+// OnFailure constructs an OnFailureStep factory. The stepName identifies the
+// step in the error var made available to the hook, and may be empty.
+func OnFailure(firstStep Step, secondStep Step, stepName string) OnFailureStep {
 	return OnFailureStep{
-		step: firstStep,
-		hook: secondStep,
+		step:     firstStep,
+		hook:     secondStep,
+		stepName: stepName,
 	}
 }
 
@@ -24,14 +28,21 @@ func OnFailure(firstStep Step, secondStep Step) OnFailureStep {
 // the first step is ready.
 //
 // If the first step fails (that is, its Success result is false), the second
-// step is executed. If the second step errors, its error is returned.
+// step is executed with the build-local var "error" set (see ErrorVar). If
+// the second step errors, its error is returned.
 func (o OnFailureStep) Run(ctx context.Context, state RunState) (bool, error) {
+	// forget any earlier failure so it isn't blamed for this one
+	state.StoreFailureReason("")
+
 	ok, err := o.step.Run(ctx, state)
 	if err != nil {
 		return false, err
 	}
 
 	if !ok {
+		reason, _ := state.FailureReason()
+		state.AddLocalVar(ErrorVarName, ErrorVar(o.stepName, o.failureMessage(reason)), false)
+
 		_, err := o.hook.Run(ctx, state)
 		if err != nil {
 			return false, err
@@ -40,3 +51,24 @@ func (o OnFailureStep) Run(ctx context.Context, state RunState) (bool, error) {
 
 	return ok, nil
 }
+
+// failureMessage describes the failure, including the reason given by the
+// step which failed (such as a task's exit status) if there is one.
+func (o OnFailureStep) failureMessage(reason string) string {
+	message := "step failed"
+	if o.stepName != "" {
+		message = fmt.Sprintf("%s failed", o.stepName)
+	}
+
+	if reason != "" {
+		message += ": " + reason
+	}
+
+	return message
+}
+
+// exitStatusReason is the failure reason given by a step whose process exited
+// with a non-zero status.
+func exitStatusReason(status int) string {
+	return fmt.Sprintf("exit status %d", status)
+}
@@ -38,7 +38,7 @@ var _ = Describe("On Failure Step", func() {
 		state = new(execfakes.FakeRunState)
 		state.ArtifactRepositoryReturns(repo)
 
-		onFailureStep = exec.OnFailure(step, hook)
+		onFailureStep = exec.OnFailure(step, hook, "some-step")
 	})
 
 	AfterEach(func() {
@@ -71,6 +71,36 @@ var _ = Describe("On Failure Step", func() {
 			Expect(runCtx).To(Equal(ctx))
 		})
 
+		It("sets the error var for the hook", func() {
+			Expect(state.AddLocalVarCallCount()).To(Equal(1))
+			name, val, redact := state.AddLocalVarArgsForCall(0)
+			Expect(name).To(Equal("error"))
+			Expect(val).To(Equal(map[string]interface{}{
+				"step":    "some-step",
+				"message": "some-step failed",
+			}))
+			Expect(redact).To(BeFalse())
+		})
+
+		It("forgets any earlier failure before running the step", func() {
+			Expect(state.StoreFailureReasonCallCount()).To(Equal(1))
+			Expect(state.StoreFailureReasonArgsForCall(0)).To(BeEmpty())
+		})
+
+		Context("when the step gave a reason for failing", func() {
+			BeforeEach(func() {
+				state.FailureReasonReturns("exit status 1", true)
+			})
+
+			It("includes the reason in the error var", func() {
+				_, val, _ := state.AddLocalVarArgsForCall(0)
+				Expect(val).To(Equal(map[string]interface{}{
+					"step":    "some-step",
+					"message": "some-step failed: exit status 1",
+				}))
+			})
+		})
+
 		It("does not error", func() {
 			Expect(stepErr).ToNot(HaveOccurred())
 		})
@@ -88,6 +118,10 @@ var _ = Describe("On Failure Step", func() {
 			Expect(hook.RunCallCount()).To(Equal(0))
 		})
 
+		It("does not set the error var", func() {
+			Expect(state.AddLocalVarCallCount()).To(BeZero())
+		})
+
 		It("returns the error", func() {
 			Expect(stepErr).To(Equal(disaster))
 		})
@@ -0,0 +1,158 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/publish"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/tracing"
+)
+
+// ErrPublishingNotConfigured is returned by a publish step when there is no
+// object store to publish to.
+var ErrPublishingNotConfigured = errors.New("no object store is configured for publishing artifacts")
+
+// PublishStep uploads an artifact to the object store as a gzipped tarball.
+// The tarball is named after the digest of its contents, so that publishing
+// the same contents again does not store them again.
+type PublishStep struct {
+	planID                   atc.PlanID
+	plan                     atc.PublishPlan
+	metadata                 StepMetadata
+	artifactStreamer         worker.ArtifactStreamer
+	store                    publish.Store
+	publishedArtifactFactory db.PublishedArtifactFactory
+	delegateFactory          BuildStepDelegateFactory
+}
+
+func NewPublishStep(
+	planID atc.PlanID,
+	plan atc.PublishPlan,
+	metadata StepMetadata,
+	artifactStreamer worker.ArtifactStreamer,
+	store publish.Store,
+	publishedArtifactFactory db.PublishedArtifactFactory,
+	delegateFactory BuildStepDelegateFactory,
+) Step {
+	return &PublishStep{
+		planID:                   planID,
+		plan:                     plan,
+		metadata:                 metadata,
+		artifactStreamer:         artifactStreamer,
+		store:                    store,
+		publishedArtifactFactory: publishedArtifactFactory,
+		delegateFactory:          delegateFactory,
+	}
+}
+
+func (step *PublishStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.BuildStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "publish", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, state, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *PublishStep) run(ctx context.Context, state RunState, delegate BuildStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("publish-step", lager.Data{
+		"artifact": step.plan.Name,
+	})
+
+	delegate.Initializing(logger)
+
+	if step.store == nil {
+		return false, ErrPublishingNotConfigured
+	}
+
+	art, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName(step.plan.Name))
+	if !found {
+		return false, ArtifactNotFoundError{step.plan.Name}
+	}
+
+	delegate.Starting(logger)
+
+	contents, err := step.artifactStreamer.StreamArtifact(lagerctx.NewContext(ctx, logger), art)
+	if err != nil {
+		return false, fmt.Errorf("stream artifact: %w", err)
+	}
+
+	defer contents.Close()
+
+	// the artifact is named after its digest, which is only known once it
+	// has been streamed, so it is digested while it is uploaded to a
+	// temporary key and then moved into place
+	tmpKey := path.Join(step.metadata.TeamName, "tmp", fmt.Sprintf("%d-%s.tgz", step.metadata.BuildID, step.planID))
+
+	digestReader, digestWriter := io.Pipe()
+
+	digested := make(chan publishDigest, 1)
+	go func() {
+		sum, err := publish.Digest(digestReader)
+
+		// drain anything left over so that the upload is never blocked
+		_, _ = io.Copy(ioutil.Discard, digestReader)
+
+		digested <- publishDigest{sum, err}
+	}()
+
+	err = step.store.Upload(ctx, tmpKey, io.TeeReader(contents, digestWriter))
+	digestWriter.CloseWithError(err)
+
+	digest := <-digested
+
+	if err != nil {
+		return false, fmt.Errorf("upload artifact: %w", err)
+	}
+
+	if digest.err != nil {
+		removeErr := step.store.Remove(ctx, tmpKey)
+		if removeErr != nil {
+			logger.Error("failed-to-remove-upload", removeErr)
+		}
+
+		return false, fmt.Errorf("digest artifact: %w", digest.err)
+	}
+
+	key := path.Join(step.metadata.TeamName, digest.sum+".tgz")
+
+	location, err := step.store.Publish(ctx, tmpKey, key)
+	if err != nil {
+		return false, fmt.Errorf("publish artifact: %w", err)
+	}
+
+	err = step.publishedArtifactFactory.Save(db.PublishedArtifact{
+		BuildID:  step.metadata.BuildID,
+		PlanID:   step.planID,
+		Name:     step.plan.Name,
+		Location: location,
+		Digest:   "sha256:" + digest.sum,
+	})
+	if err != nil {
+		return false, fmt.Errorf("save published artifact: %w", err)
+	}
+
+	fmt.Fprintf(delegate.Stdout(), "published %s to %s\n", step.plan.Name, location)
+
+	delegate.Finished(logger, true)
+
+	return true, nil
+}
+
+type publishDigest struct {
+	sum string
+	err error
+}
@@ -0,0 +1,241 @@
+package exec_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/concourse/concourse/tracing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/publish"
+	"github.com/concourse/concourse/atc/publish/publishfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimefakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+)
+
+var _ = Describe("PublishStep", func() {
+	const tmpKey = "some-team/tmp/42-some-plan-id.tgz"
+
+	var (
+		ctx context.Context
+
+		fakeArtifactStreamer         *workerfakes.FakeArtifactStreamer
+		fakeStore                    *publishfakes.FakeStore
+		fakePublishedArtifactFactory *dbfakes.FakePublishedArtifactFactory
+		fakeDelegate                 *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory          *execfakes.FakeBuildStepDelegateFactory
+
+		fakeArtifact *runtimefakes.FakeArtifact
+
+		store publish.Store
+
+		stepMetadata = exec.StepMetadata{
+			TeamID:   123,
+			TeamName: "some-team",
+			BuildID:  42,
+		}
+
+		repo  *build.Repository
+		state *execfakes.FakeRunState
+
+		stdout *gbytes.Buffer
+
+		contents       []byte
+		contentsDigest string
+		uploaded       []byte
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		buf := new(bytes.Buffer)
+		gzWriter := gzip.NewWriter(buf)
+		tarWriter := tar.NewWriter(gzWriter)
+		Expect(tarWriter.WriteHeader(&tar.Header{Name: "./some-file", Mode: 0644, Size: 13})).To(Succeed())
+		_, err := tarWriter.Write([]byte("some-contents"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tarWriter.Close()).To(Succeed())
+		Expect(gzWriter.Close()).To(Succeed())
+		contents = buf.Bytes()
+
+		contentsDigest, err = publish.Digest(bytes.NewReader(contents))
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeArtifactStreamer = new(workerfakes.FakeArtifactStreamer)
+		fakeArtifactStreamer.StreamArtifactStub = func(context.Context, runtime.Artifact) (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(contents)), nil
+		}
+
+		uploaded = nil
+		fakeStore = new(publishfakes.FakeStore)
+		fakeStore.UploadStub = func(_ context.Context, _ string, contents io.Reader) error {
+			var err error
+			uploaded, err = ioutil.ReadAll(contents)
+			Expect(err).ToNot(HaveOccurred())
+			return nil
+		}
+		fakeStore.PublishStub = func(_ context.Context, _ string, key string) (string, error) {
+			return "s3://some-bucket/" + key, nil
+		}
+		store = fakeStore
+
+		fakePublishedArtifactFactory = new(dbfakes.FakePublishedArtifactFactory)
+
+		stdout = gbytes.NewBuffer()
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanReturns(context.Background(), tracing.NoopSpan)
+
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		repo = build.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactRepositoryReturns(repo)
+
+		fakeArtifact = new(runtimefakes.FakeArtifact)
+		repo.RegisterArtifact("some-artifact", fakeArtifact)
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewPublishStep(
+			"some-plan-id",
+			atc.PublishPlan{Name: "some-artifact"},
+			stepMetadata,
+			fakeArtifactStreamer,
+			store,
+			fakePublishedArtifactFactory,
+			fakeDelegateFactory,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("streams the artifact", func() {
+		Expect(fakeArtifactStreamer.StreamArtifactCallCount()).To(Equal(1))
+		_, art := fakeArtifactStreamer.StreamArtifactArgsForCall(0)
+		Expect(art).To(Equal(fakeArtifact))
+	})
+
+	It("uploads it to a temporary key", func() {
+		Expect(fakeStore.UploadCallCount()).To(Equal(1))
+		_, key, _ := fakeStore.UploadArgsForCall(0)
+		Expect(key).To(Equal(tmpKey))
+		Expect(uploaded).To(Equal(contents))
+	})
+
+	It("publishes it under the team, named after its digest", func() {
+		Expect(fakeStore.PublishCallCount()).To(Equal(1))
+		_, from, key := fakeStore.PublishArgsForCall(0)
+		Expect(from).To(Equal(tmpKey))
+		Expect(key).To(Equal("some-team/" + contentsDigest + ".tgz"))
+	})
+
+	It("records where it was published", func() {
+		Expect(fakePublishedArtifactFactory.SaveCallCount()).To(Equal(1))
+		Expect(fakePublishedArtifactFactory.SaveArgsForCall(0)).To(Equal(db.PublishedArtifact{
+			BuildID:  42,
+			PlanID:   "some-plan-id",
+			Name:     "some-artifact",
+			Location: "s3://some-bucket/some-team/" + contentsDigest + ".tgz",
+			Digest:   "sha256:" + contentsDigest,
+		}))
+	})
+
+	It("prints where it was published and succeeds", func() {
+		Expect(stdout).To(gbytes.Say("published some-artifact to s3://some-bucket/some-team/" + contentsDigest + ".tgz"))
+
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(stepOk).To(BeTrue())
+
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+	})
+
+	Context("when publishing is not configured", func() {
+		BeforeEach(func() {
+			store = nil
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(Equal(exec.ErrPublishingNotConfigured))
+			Expect(fakeArtifactStreamer.StreamArtifactCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the artifact is not found", func() {
+		BeforeEach(func() {
+			repo = build.NewRepository()
+			state.ArtifactRepositoryReturns(repo)
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(Equal(exec.ArtifactNotFoundError{ArtifactName: "some-artifact"}))
+			Expect(fakeStore.PublishCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when uploading fails", func() {
+		BeforeEach(func() {
+			fakeStore.UploadStub = nil
+			fakeStore.UploadReturns(errors.New("nope"))
+		})
+
+		It("errors without publishing anything", func() {
+			Expect(stepErr).To(MatchError("upload artifact: nope"))
+			Expect(fakeStore.PublishCallCount()).To(BeZero())
+			Expect(fakePublishedArtifactFactory.SaveCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the artifact cannot be digested", func() {
+		BeforeEach(func() {
+			contents = []byte("some-contents")
+		})
+
+		It("uploads it all the same", func() {
+			Expect(uploaded).To(Equal(contents))
+		})
+
+		It("removes the upload and errors without publishing it", func() {
+			Expect(stepErr).To(MatchError(ContainSubstring("digest artifact")))
+
+			Expect(fakeStore.RemoveCallCount()).To(Equal(1))
+			_, key := fakeStore.RemoveArgsForCall(0)
+			Expect(key).To(Equal(tmpKey))
+
+			Expect(fakeStore.PublishCallCount()).To(BeZero())
+			Expect(fakePublishedArtifactFactory.SaveCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when publishing fails", func() {
+		BeforeEach(func() {
+			fakeStore.PublishStub = nil
+			fakeStore.PublishReturns("", errors.New("nope"))
+		})
+
+		It("errors without recording anything", func() {
+			Expect(stepErr).To(MatchError("publish artifact: nope"))
+			Expect(fakePublishedArtifactFactory.SaveCallCount()).To(BeZero())
+		})
+	})
+})
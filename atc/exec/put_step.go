@@ -155,13 +155,20 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 
 	workerSpec := worker.WorkerSpec{
 		Tags:         step.plan.Tags,
+		Arch:         step.plan.Arch,
 		TeamID:       step.metadata.TeamID,
 		ResourceType: step.plan.VersionedResourceTypes.Base(step.plan.Type),
+		Priority:     step.metadata.Priority,
 	}
 
 	var imageSpec worker.ImageSpec
 	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
 	if found {
+		mirror, err := creds.NewRegistryMirror(state, step.plan.RegistryMirror).Evaluate()
+		if err != nil {
+			return false, err
+		}
+
 		image := atc.ImageResource{
 			Name:    resourceType.Name,
 			Type:    resourceType.Type,
@@ -176,7 +183,11 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 
 		types := step.plan.VersionedResourceTypes.Without(step.plan.Type)
 
-		var err error
+		types, err = image.ApplyRegistryMirror(mirror, types)
+		if err != nil {
+			return false, err
+		}
+
 		imageSpec, err = delegate.FetchImage(ctx, image, types, resourceType.Privileged)
 		if err != nil {
 			return false, err
@@ -254,6 +265,7 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			delegate.Errored(logger, TimeoutLogMessage)
+			state.StoreFailureReason(TimeoutLogMessage)
 			return false, nil
 		}
 
@@ -262,6 +274,7 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 
 	if result.ExitStatus != 0 {
 		delegate.Finished(logger, ExitStatus(result.ExitStatus), runtime.VersionResult{})
+		state.StoreFailureReason(exitStatusReason(result.ExitStatus))
 		return false, nil
 	}
 
@@ -293,6 +293,16 @@ var _ = Describe("PutStep", func() {
 			})
 		})
 
+		Context("when the plan specifies an arch", func() {
+			BeforeEach(func() {
+				putPlan.Arch = "arm64"
+			})
+
+			It("sets it in the WorkerSpec", func() {
+				Expect(workerSpec.Arch).To(Equal("arm64"))
+			})
+		})
+
 		Context("when selecting a worker fails", func() {
 			BeforeEach(func() {
 				fakePool.SelectWorkerReturns(nil, 0, errors.New("nope"))
@@ -2,6 +2,12 @@ package exec
 
 import (
 	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 )
 
 // RetryStep is a step that will run the steps in order until one of them
@@ -9,6 +15,10 @@ import (
 type RetryStep struct {
 	Attempts    []Step
 	LastAttempt Step
+
+	// Backoff, if set, delays each attempt after the first.
+	Backoff         *atc.RetryBackoffConfig
+	DelegateFactory BuildStepDelegateFactory
 }
 
 func Retry(attempts ...Step) Step {
@@ -17,13 +27,31 @@ func Retry(attempts ...Step) Step {
 	}
 }
 
+// RetryWithBackoff constructs a RetryStep which waits before each retry,
+// emitting an event through the delegate so that the build shows why it's
+// waiting.
+func RetryWithBackoff(backoff atc.RetryBackoffConfig, delegateFactory BuildStepDelegateFactory, attempts ...Step) Step {
+	return &RetryStep{
+		Attempts:        attempts,
+		Backoff:         &backoff,
+		DelegateFactory: delegateFactory,
+	}
+}
+
 // Run iterates through each step, stopping once a step succeeds. If all steps
 // fail, the RetryStep will fail.
 func (step *RetryStep) Run(ctx context.Context, state RunState) (bool, error) {
 	var attemptOk bool
 	var attemptErr error
 
-	for _, attempt := range step.Attempts {
+	for i, attempt := range step.Attempts {
+		if i > 0 && step.Backoff != nil {
+			err := step.wait(ctx, state, i)
+			if err != nil {
+				return false, err
+			}
+		}
+
 		step.LastAttempt = attempt
 
 		attemptOk, attemptErr = attempt.Run(ctx, state)
@@ -42,3 +70,57 @@ func (step *RetryStep) Run(ctx context.Context, state RunState) (bool, error) {
 
 	return attemptOk, attemptErr
 }
+
+// wait sleeps for the backoff delay before the given retry, where 1 is the
+// first retry (i.e. the second attempt).
+func (step *RetryStep) wait(ctx context.Context, state RunState, retry int) error {
+	delay, err := RetryBackoffDelay(*step.Backoff, retry, rand.Float64())
+	if err != nil {
+		return err
+	}
+
+	logger := lagerctx.FromContext(ctx)
+	step.DelegateFactory.BuildStepDelegate(state).WaitingToRetry(logger, retry+1, delay)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RetryBackoffDelay returns how long to wait before the given retry, where 1
+// is the first retry. The jitter is applied using random, which must be in
+// [0, 1).
+func RetryBackoffDelay(backoff atc.RetryBackoffConfig, retry int, random float64) (time.Duration, error) {
+	initial, err := time.ParseDuration(backoff.Initial)
+	if err != nil {
+		return 0, err
+	}
+
+	maxDelay := time.Duration(math.MaxInt64)
+	if backoff.Max != "" {
+		maxDelay, err = time.ParseDuration(backoff.Max)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	multiplier := backoff.Multiplier
+	if multiplier == 0 {
+		multiplier = atc.DefaultRetryBackoffMultiplier
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(retry-1))
+	delay *= 1 + backoff.Jitter*(2*random-1)
+
+	if delay >= float64(maxDelay) {
+		return maxDelay, nil
+	}
+
+	return time.Duration(delay), nil
+}
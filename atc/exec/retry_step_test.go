@@ -3,7 +3,10 @@ package exec_test
 import (
 	"context"
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
@@ -212,5 +215,103 @@ var _ = Describe("Retry Step", func() {
 				Expect(stepOk).To(BeFalse())
 			})
 		})
+
+		Context("with a backoff", func() {
+			var fakeDelegate *execfakes.FakeBuildStepDelegate
+
+			BeforeEach(func() {
+				fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+				fakeDelegateFactory := new(execfakes.FakeBuildStepDelegateFactory)
+				fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+				step = RetryWithBackoff(atc.RetryBackoffConfig{
+					Initial:    "1ms",
+					Multiplier: 3,
+				}, fakeDelegateFactory, attempt1, attempt2, attempt3)
+			})
+
+			Context("when attempt 1 fails, attempt 2 fails, and attempt 3 succeeds", func() {
+				BeforeEach(func() {
+					attempt1.RunReturns(false, nil)
+					attempt2.RunReturns(false, nil)
+					attempt3.RunReturns(true, nil)
+				})
+
+				It("emits the delay before each retry", func() {
+					Expect(stepOk).To(BeTrue())
+
+					Expect(fakeDelegate.WaitingToRetryCallCount()).To(Equal(2))
+
+					_, attempt, delay := fakeDelegate.WaitingToRetryArgsForCall(0)
+					Expect(attempt).To(Equal(2))
+					Expect(delay).To(Equal(time.Millisecond))
+
+					_, attempt, delay = fakeDelegate.WaitingToRetryArgsForCall(1)
+					Expect(attempt).To(Equal(3))
+					Expect(delay).To(Equal(3 * time.Millisecond))
+				})
+			})
+
+			Context("when attempt 1 succeeds", func() {
+				BeforeEach(func() {
+					attempt1.RunReturns(true, nil)
+				})
+
+				It("does not wait", func() {
+					Expect(fakeDelegate.WaitingToRetryCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when interrupted while waiting", func() {
+				BeforeEach(func() {
+					attempt1.RunReturns(false, nil)
+					fakeDelegate.WaitingToRetryStub = func(lager.Logger, int, time.Duration) {
+						cancel()
+					}
+				})
+
+				It("returns the context error without running the next attempt", func() {
+					Expect(stepErr).To(Equal(context.Canceled))
+					Expect(attempt2.RunCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the delay is invalid", func() {
+				BeforeEach(func() {
+					step = RetryWithBackoff(atc.RetryBackoffConfig{Initial: "nope"}, new(execfakes.FakeBuildStepDelegateFactory), attempt1, attempt2)
+					attempt1.RunReturns(false, nil)
+				})
+
+				It("errors", func() {
+					Expect(stepErr).To(HaveOccurred())
+					Expect(attempt2.RunCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
+	Describe("RetryBackoffDelay", func() {
+		var backoff atc.RetryBackoffConfig
+
+		BeforeEach(func() {
+			backoff = atc.RetryBackoffConfig{Initial: "10s"}
+		})
+
+		It("doubles the delay by default", func() {
+			Expect(RetryBackoffDelay(backoff, 1, 0.5)).To(Equal(10 * time.Second))
+			Expect(RetryBackoffDelay(backoff, 2, 0.5)).To(Equal(20 * time.Second))
+			Expect(RetryBackoffDelay(backoff, 3, 0.5)).To(Equal(40 * time.Second))
+		})
+
+		It("caps the delay at the max", func() {
+			backoff.Max = "30s"
+			Expect(RetryBackoffDelay(backoff, 3, 0.5)).To(Equal(30 * time.Second))
+		})
+
+		It("randomizes the delay by the jitter", func() {
+			backoff.Jitter = 0.5
+			Expect(RetryBackoffDelay(backoff, 1, 0)).To(Equal(5 * time.Second))
+			Expect(RetryBackoffDelay(backoff, 1, 0.75)).To(Equal(12500 * time.Millisecond))
+		})
 	})
 })
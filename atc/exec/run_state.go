@@ -17,10 +17,17 @@ type runState struct {
 
 	artifacts *build.Repository
 	results   *sync.Map
+	statuses  *sync.Map
+	failure   *failureReason
 
 	parent RunState
 }
 
+type failureReason struct {
+	sync.Mutex
+	reason string
+}
+
 type Stepper func(atc.Plan) Step
 
 func NewRunState(
@@ -35,6 +42,8 @@ func NewRunState(
 
 		artifacts: build.NewRepository(),
 		results:   &sync.Map{},
+		statuses:  &sync.Map{},
+		failure:   &failureReason{},
 	}
 }
 
@@ -60,6 +69,36 @@ func (state *runState) StoreResult(id atc.PlanID, val interface{}) {
 	state.results.Store(id, val)
 }
 
+// StepStatus returns the status of the step with the given plan ID, if it has
+// finished.
+func (state *runState) StepStatus(id atc.PlanID) (atc.BuildStatus, bool) {
+	val, ok := state.statuses.Load(id)
+	if !ok {
+		return "", false
+	}
+
+	return val.(atc.BuildStatus), true
+}
+
+func (state *runState) StoreStepStatus(id atc.PlanID, status atc.BuildStatus) {
+	state.statuses.Store(id, status)
+}
+
+// FailureReason returns why the most recent step to fail did so, e.g. its
+// exit status, if it said.
+func (state *runState) FailureReason() (string, bool) {
+	state.failure.Lock()
+	defer state.failure.Unlock()
+
+	return state.failure.reason, state.failure.reason != ""
+}
+
+func (state *runState) StoreFailureReason(reason string) {
+	state.failure.Lock()
+	state.failure.reason = reason
+	state.failure.Unlock()
+}
+
 func (state *runState) Get(ref vars.Reference) (interface{}, bool, error) {
 	return state.vars.Get(ref)
 }
@@ -88,6 +127,10 @@ func (state *runState) AddLocalVar(name string, val interface{}, redact bool) {
 	state.vars.AddLocalVar(name, val, redact)
 }
 
+func (state *runState) TrackDerived(name string, val interface{}) {
+	state.vars.TrackDerived(name, val)
+}
+
 func (state *runState) RedactionEnabled() bool {
 	return state.vars.RedactionEnabled()
 }
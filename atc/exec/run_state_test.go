@@ -164,6 +164,29 @@ var _ = Describe("RunState", func() {
 		})
 	})
 
+	Describe("StepStatus", func() {
+		It("returns false when the step has not finished", func() {
+			_, found := state.StepStatus("some-id")
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns the stored status", func() {
+			state.StoreStepStatus("some-id", atc.StatusFailed)
+
+			status, found := state.StepStatus("some-id")
+			Expect(found).To(BeTrue())
+			Expect(status).To(Equal(atc.StatusFailed))
+		})
+
+		It("shares statuses between scopes", func() {
+			state.NewLocalScope().StoreStepStatus("some-id", atc.StatusSucceeded)
+
+			status, found := state.StepStatus("some-id")
+			Expect(found).To(BeTrue())
+			Expect(status).To(Equal(atc.StatusSucceeded))
+		})
+	})
+
 	Describe("Get", func() {
 		BeforeEach(func() {
 			state = exec.NewRunState(stepper, credVars, false)
@@ -199,6 +222,14 @@ var _ = Describe("RunState", func() {
 				// "k3" has not been Get, thus should not be tracked.
 				Expect(mapit).ToNot(HaveKey("k3"))
 			})
+
+			It("values computed from variables by functions are tracked", func() {
+				_, err := vars.NewTemplate([]byte("((concat(k1, '-', k2)))")).Evaluate(state, vars.EvaluateOpts{})
+				Expect(err).ToNot(HaveOccurred())
+				mapit := vars.TrackedVarsMap{}
+				state.IterateInterpolatedCreds(mapit)
+				Expect(mapit["concat(k1, '-', k2)"]).To(Equal("v1-v2"))
+			})
 		})
 
 		Context("when redaction is not enabled", func() {
@@ -0,0 +1,308 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/tracing"
+)
+
+// RunStep sends a message to a prototype by running the message's executable
+// in a container of the prototype's image. The message's object is sent on
+// stdin, and the objects the prototype responds with on stdout are stored as a
+// local var named after the step.
+type RunStep struct {
+	planID            atc.PlanID
+	plan              atc.RunPlan
+	metadata          StepMetadata
+	containerMetadata db.ContainerMetadata
+	strategy          worker.ContainerPlacementStrategy
+	workerPool        worker.Pool
+	artifactSourcer   worker.ArtifactSourcer
+	delegateFactory   BuildStepDelegateFactory
+}
+
+// InvalidRunMessageError is returned when a RunPlan's message is not a plain
+// name, and so could refer to an executable outside of /usr/bin.
+type InvalidRunMessageError struct {
+	Message string
+}
+
+// Error returns a human-friendly error message.
+func (err InvalidRunMessageError) Error() string {
+	return fmt.Sprintf("invalid message '%s': must be a plain name", err.Message)
+}
+
+func NewRunStep(
+	planID atc.PlanID,
+	plan atc.RunPlan,
+	metadata StepMetadata,
+	containerMetadata db.ContainerMetadata,
+	strategy worker.ContainerPlacementStrategy,
+	workerPool worker.Pool,
+	artifactSourcer worker.ArtifactSourcer,
+	delegateFactory BuildStepDelegateFactory,
+) Step {
+	return &RunStep{
+		planID:            planID,
+		plan:              plan,
+		metadata:          metadata,
+		containerMetadata: containerMetadata,
+		strategy:          strategy,
+		workerPool:        workerPool,
+		artifactSourcer:   artifactSourcer,
+		delegateFactory:   delegateFactory,
+	}
+}
+
+// Run chooses a worker that supports the prototype and creates a container
+// from its image, with the step's inputs and outputs mounted under the
+// working directory.
+//
+// The message's executable, /usr/bin/<message>, is then run with the working
+// directory as its only argument. If the context is canceled, the process
+// will be interrupted.
+//
+// If the process exits successfully, its outputs are registered with the
+// artifact.Repository and its response is added to the build's local vars.
+func (step *RunStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.BuildStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "run", tracing.Attrs{
+		"name":    step.plan.Name,
+		"type":    step.plan.Type,
+		"message": step.plan.Message,
+	})
+
+	ok, err := step.run(ctx, state, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *RunStep) run(ctx context.Context, state RunState, delegate BuildStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("run-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+
+	if !validRunMessage(step.plan.Message) {
+		return false, InvalidRunMessageError{Message: step.plan.Message}
+	}
+
+	object, err := creds.NewParams(state, step.plan.Object).Evaluate()
+	if err != nil {
+		return false, err
+	}
+
+	containerSpec, err := step.containerSpec(logger, state)
+	if err != nil {
+		return false, err
+	}
+
+	containerSpec.ImageSpec, err = step.imageSpec(ctx, delegate)
+	if err != nil {
+		return false, err
+	}
+	tracing.Inject(ctx, &containerSpec)
+
+	workerSpec := worker.WorkerSpec{
+		Tags:         step.plan.Tags,
+		TeamID:       step.metadata.TeamID,
+		ResourceType: step.plan.VersionedResourceTypes.Base(step.plan.Type),
+		Priority:     step.metadata.Priority,
+	}
+
+	processSpec := runtime.ProcessSpec{
+		Path:         path.Join("/usr/bin", step.plan.Message),
+		Args:         []string{step.containerMetadata.WorkingDirectory},
+		StdoutWriter: delegate.Stdout(),
+		StderrWriter: delegate.Stderr(),
+	}
+
+	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
+
+	chosenWorker, _, err := step.workerPool.SelectWorker(
+		lagerctx.NewContext(ctx, logger),
+		owner,
+		containerSpec,
+		workerSpec,
+		step.strategy,
+		delegate,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	delegate.SelectedWorker(logger, chosenWorker.Name())
+
+	defer func() {
+		step.workerPool.ReleaseWorker(
+			lagerctx.NewContext(ctx, logger),
+			containerSpec,
+			chosenWorker,
+			step.strategy,
+		)
+	}()
+
+	processCtx, cancel, err := MaybeTimeout(ctx, step.plan.Timeout)
+	if err != nil {
+		return false, err
+	}
+
+	defer cancel()
+
+	result, err := chosenWorker.RunRunStep(
+		lagerctx.NewContext(processCtx, logger),
+		owner,
+		containerSpec,
+		step.containerMetadata,
+		processSpec,
+		delegate,
+		runtime.RunRequest{Object: object},
+	)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			delegate.Errored(logger, TimeoutLogMessage)
+			state.StoreFailureReason(TimeoutLogMessage)
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if result.ExitStatus != 0 {
+		delegate.Finished(logger, false)
+		state.StoreFailureReason(exitStatusReason(result.ExitStatus))
+		return false, nil
+	}
+
+	step.registerOutputs(logger, state.ArtifactRepository(), result.VolumeMounts)
+
+	// the response is stored as plain maps so that vars can traverse it
+	response := make([]interface{}, len(result.Response))
+	for i, obj := range result.Response {
+		response[i] = map[string]interface{}(obj)
+	}
+
+	// the response may carry credentials, e.g. tokens minted by the prototype
+	state.AddLocalVar(step.plan.Name, response, true)
+
+	delegate.Finished(logger, true)
+
+	return true, nil
+}
+
+func validRunMessage(message string) bool {
+	return message != "" &&
+		message != "." &&
+		message != ".." &&
+		!strings.ContainsAny(message, `/\`)
+}
+
+func (step *RunStep) containerSpec(logger lager.Logger, state RunState) (worker.ContainerSpec, error) {
+	inputs := map[string]runtime.Artifact{}
+
+	var missingInputs []string
+	for _, input := range step.plan.Inputs {
+		art, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName(input))
+		if !found {
+			missingInputs = append(missingInputs, input)
+			continue
+		}
+
+		inputs[filepath.Join(step.containerMetadata.WorkingDirectory, input)] = art
+	}
+
+	if len(missingInputs) > 0 {
+		return worker.ContainerSpec{}, MissingInputsError{missingInputs}
+	}
+
+	containerInputs, err := step.artifactSourcer.SourceInputsAndCaches(logger, step.metadata.TeamID, inputs)
+	if err != nil {
+		return worker.ContainerSpec{}, err
+	}
+
+	containerSpec := worker.ContainerSpec{
+		TeamID: step.metadata.TeamID,
+		Type:   step.containerMetadata.Type,
+
+		Dir: step.containerMetadata.WorkingDirectory,
+		Env: step.metadata.Env(),
+
+		Inputs:  containerInputs,
+		Outputs: worker.OutputPaths{},
+
+		BindMounts: []worker.BindMountSource{
+			&worker.CertsVolumeMount{Logger: logger},
+		},
+	}
+
+	for _, output := range step.plan.Outputs {
+		containerSpec.Outputs[output] = step.outputPath(output)
+	}
+
+	return containerSpec, nil
+}
+
+// imageSpec fetches the image of a prototype configured like a custom
+// resource type, or else uses the worker's base type of the same name.
+func (step *RunStep) imageSpec(ctx context.Context, delegate BuildStepDelegate) (worker.ImageSpec, error) {
+	prototype, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
+	if !found {
+		return worker.ImageSpec{
+			ResourceType: step.plan.Type,
+			Privileged:   step.plan.Privileged,
+		}, nil
+	}
+
+	image := atc.ImageResource{
+		Name:    prototype.Name,
+		Type:    prototype.Type,
+		Source:  prototype.Source,
+		Params:  prototype.Params,
+		Version: prototype.Version,
+		Tags:    prototype.Tags,
+	}
+	if len(image.Tags) == 0 {
+		image.Tags = step.plan.Tags
+	}
+
+	types := step.plan.VersionedResourceTypes.Without(step.plan.Type)
+
+	return delegate.FetchImage(ctx, image, types, step.plan.Privileged)
+}
+
+func (step *RunStep) outputPath(output string) string {
+	return path.Join(step.containerMetadata.WorkingDirectory, output) + "/"
+}
+
+func (step *RunStep) registerOutputs(logger lager.Logger, repository *build.Repository, volumeMounts []worker.VolumeMount) {
+	logger.Debug("registering-outputs", lager.Data{"outputs": step.plan.Outputs})
+
+	for _, output := range step.plan.Outputs {
+		outputPath := step.outputPath(output)
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) == filepath.Clean(outputPath) {
+				repository.RegisterArtifact(build.ArtifactName(output), &runtime.TaskArtifact{
+					VolumeHandle: mount.Volume.Handle(),
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/concourse/concourse/tracing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimefakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("RunStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakePool            *workerfakes.FakePool
+		fakeClient          *workerfakes.FakeClient
+		fakeArtifactSourcer *workerfakes.FakeArtifactSourcer
+		fakeStrategy        *workerfakes.FakeContainerPlacementStrategy
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		expectedInputs []worker.InputSource
+		fakeArtifact   *runtimefakes.FakeArtifact
+
+		runPlan *atc.RunPlan
+
+		containerMetadata = db.ContainerMetadata{
+			WorkingDirectory: "/tmp/build/run",
+			Type:             db.ContainerTypeRun,
+			StepName:         "some-step",
+		}
+
+		stepMetadata = exec.StepMetadata{
+			TeamID:       123,
+			TeamName:     "some-team",
+			BuildID:      42,
+			BuildName:    "some-build",
+			PipelineID:   4567,
+			PipelineName: "some-pipeline",
+		}
+
+		repo  *build.Repository
+		state *execfakes.FakeRunState
+
+		stepOk  bool
+		stepErr error
+
+		stdoutBuf *gbytes.Buffer
+		stderrBuf *gbytes.Buffer
+
+		planID atc.PlanID
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		planID = atc.PlanID("some-plan-id")
+
+		fakeClient = new(workerfakes.FakeClient)
+		fakeClient.NameReturns("some-worker")
+		fakePool = new(workerfakes.FakePool)
+		fakePool.SelectWorkerReturns(fakeClient, 0, nil)
+
+		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+		fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
+
+		expectedInputs = []worker.InputSource{new(workerfakes.FakeInputSource)}
+		fakeArtifactSourcer.SourceInputsAndCachesReturns(expectedInputs, nil)
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		stdoutBuf = gbytes.NewBuffer()
+		stderrBuf = gbytes.NewBuffer()
+		fakeDelegate.StdoutReturns(stdoutBuf)
+		fakeDelegate.StderrReturns(stderrBuf)
+		fakeDelegate.StartSpanReturns(context.Background(), tracing.NoopSpan)
+
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		repo = build.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactRepositoryReturns(repo)
+		state.GetStub = vars.StaticVariables{
+			"object-var": "super-secret-object",
+		}.Get
+
+		fakeArtifact = new(runtimefakes.FakeArtifact)
+		repo.RegisterArtifact("some-input", fakeArtifact)
+
+		runPlan = &atc.RunPlan{
+			Name:    "some-name",
+			Message: "run",
+			Type:    "some-prototype",
+			Object:  atc.Params{"some": "((object-var))"},
+			Inputs:  []string{"some-input"},
+			Outputs: []string{"some-output"},
+			Tags:    []string{"some", "tags"},
+		}
+
+		fakeVolume := new(workerfakes.FakeVolume)
+		fakeVolume.HandleReturns("some-output-handle")
+
+		fakeClient.RunRunStepReturns(worker.RunResult{
+			ExitStatus: 0,
+			Response: runtime.RunResponse{
+				{"some": "object"},
+				{"other": "object"},
+			},
+			VolumeMounts: []worker.VolumeMount{
+				{Volume: fakeVolume, MountPath: "/tmp/build/run/some-output"},
+			},
+		}, nil)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewRunStep(
+			planID,
+			*runPlan,
+			stepMetadata,
+			containerMetadata,
+			fakeStrategy,
+			fakePool,
+			fakeArtifactSourcer,
+			fakeDelegateFactory,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("selects a worker which supports the prototype", func() {
+		Expect(fakePool.SelectWorkerCallCount()).To(Equal(1))
+		_, owner, _, workerSpec, strategy, _ := fakePool.SelectWorkerArgsForCall(0)
+		Expect(owner).To(Equal(db.NewBuildStepContainerOwner(42, planID, 123)))
+		Expect(workerSpec).To(Equal(worker.WorkerSpec{
+			Tags:         []string{"some", "tags"},
+			TeamID:       123,
+			ResourceType: "some-prototype",
+		}))
+		Expect(strategy).To(Equal(fakeStrategy))
+	})
+
+	It("releases the worker", func() {
+		Expect(fakePool.ReleaseWorkerCallCount()).To(Equal(1))
+	})
+
+	It("runs the message in a container of the prototype", func() {
+		Expect(fakeClient.RunRunStepCallCount()).To(Equal(1))
+		_, owner, containerSpec, metadata, processSpec, delegate, request := fakeClient.RunRunStepArgsForCall(0)
+		Expect(owner).To(Equal(db.NewBuildStepContainerOwner(42, planID, 123)))
+		Expect(metadata).To(Equal(containerMetadata))
+		Expect(delegate).To(Equal(fakeDelegate))
+
+		Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
+			ResourceType: "some-prototype",
+		}))
+		Expect(containerSpec.TeamID).To(Equal(123))
+		Expect(containerSpec.Type).To(Equal(db.ContainerTypeRun))
+		Expect(containerSpec.Dir).To(Equal("/tmp/build/run"))
+		Expect(containerSpec.Env).To(Equal(stepMetadata.Env()))
+		Expect(containerSpec.Inputs).To(Equal(expectedInputs))
+		Expect(containerSpec.Outputs).To(Equal(worker.OutputPaths{
+			"some-output": "/tmp/build/run/some-output/",
+		}))
+
+		Expect(processSpec.Path).To(Equal("/usr/bin/run"))
+		Expect(processSpec.Args).To(Equal([]string{"/tmp/build/run"}))
+		Expect(processSpec.StdoutWriter).To(Equal(stdoutBuf))
+		Expect(processSpec.StderrWriter).To(Equal(stderrBuf))
+
+		Expect(request).To(Equal(runtime.RunRequest{
+			Object: atc.Params{"some": "super-secret-object"},
+		}))
+	})
+
+	It("mounts the inputs under the working directory", func() {
+		Expect(fakeArtifactSourcer.SourceInputsAndCachesCallCount()).To(Equal(1))
+		_, teamID, inputs := fakeArtifactSourcer.SourceInputsAndCachesArgsForCall(0)
+		Expect(teamID).To(Equal(123))
+		Expect(inputs).To(Equal(map[string]runtime.Artifact{
+			"/tmp/build/run/some-input": fakeArtifact,
+		}))
+	})
+
+	It("registers the outputs", func() {
+		art, found := repo.ArtifactFor("some-output")
+		Expect(found).To(BeTrue())
+		Expect(art).To(Equal(&runtime.TaskArtifact{VolumeHandle: "some-output-handle"}))
+	})
+
+	It("stores the response as a local var", func() {
+		Expect(state.AddLocalVarCallCount()).To(Equal(1))
+		name, val, redact := state.AddLocalVarArgsForCall(0)
+		Expect(name).To(Equal("some-name"))
+		Expect(val).To(Equal([]interface{}{
+			map[string]interface{}{"some": "object"},
+			map[string]interface{}{"other": "object"},
+		}))
+		Expect(redact).To(BeTrue())
+	})
+
+	It("finishes successfully", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(stepOk).To(BeTrue())
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+	})
+
+	Context("when the prototype is configured like a custom resource type", func() {
+		var imageSpec worker.ImageSpec
+
+		BeforeEach(func() {
+			runPlan.Privileged = true
+			runPlan.VersionedResourceTypes = atc.VersionedResourceTypes{
+				{
+					ResourceType: atc.ResourceType{
+						Name:   "some-prototype",
+						Type:   "registry-image",
+						Source: atc.Source{"repository": "some-prototype"},
+					},
+					Version: atc.Version{"digest": "some-digest"},
+				},
+			}
+
+			imageSpec = worker.ImageSpec{
+				ImageArtifactSource: new(workerfakes.FakeStreamableArtifactSource),
+				Privileged:          true,
+			}
+			fakeDelegate.FetchImageReturns(imageSpec, nil)
+		})
+
+		It("fetches the prototype's image", func() {
+			Expect(fakeDelegate.FetchImageCallCount()).To(Equal(1))
+			_, image, types, privileged := fakeDelegate.FetchImageArgsForCall(0)
+			Expect(image).To(Equal(atc.ImageResource{
+				Name:    "some-prototype",
+				Type:    "registry-image",
+				Source:  atc.Source{"repository": "some-prototype"},
+				Version: atc.Version{"digest": "some-digest"},
+				Tags:    []string{"some", "tags"},
+			}))
+			Expect(types).To(BeEmpty())
+			Expect(privileged).To(BeTrue())
+		})
+
+		It("runs the message with the fetched image", func() {
+			_, _, containerSpec, _, _, _, _ := fakeClient.RunRunStepArgsForCall(0)
+			Expect(containerSpec.ImageSpec).To(Equal(imageSpec))
+		})
+
+		It("uses the base type of the prototype to select a worker", func() {
+			_, _, _, workerSpec, _, _ := fakePool.SelectWorkerArgsForCall(0)
+			Expect(workerSpec.ResourceType).To(Equal("registry-image"))
+		})
+	})
+
+	for _, message := range []string{"", ".", "..", "../../bin/sh", "some/message"} {
+		message := message
+
+		Context("when the message is "+strconv.Quote(message), func() {
+			BeforeEach(func() {
+				runPlan.Message = message
+			})
+
+			It("errors without running the message", func() {
+				Expect(stepErr).To(Equal(exec.InvalidRunMessageError{Message: message}))
+				Expect(fakePool.SelectWorkerCallCount()).To(BeZero())
+				Expect(fakeClient.RunRunStepCallCount()).To(BeZero())
+			})
+		})
+	}
+
+	Context("when an input is missing", func() {
+		BeforeEach(func() {
+			runPlan.Inputs = []string{"some-input", "missing-input"}
+		})
+
+		It("errors without running the message", func() {
+			Expect(stepErr).To(Equal(exec.MissingInputsError{Inputs: []string{"missing-input"}}))
+			Expect(fakeClient.RunRunStepCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the message exits nonzero", func() {
+		BeforeEach(func() {
+			fakeClient.RunRunStepReturns(worker.RunResult{ExitStatus: 1}, nil)
+		})
+
+		It("fails without storing a var", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+			Expect(state.AddLocalVarCallCount()).To(BeZero())
+
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when the message times out", func() {
+		BeforeEach(func() {
+			fakeClient.RunRunStepReturns(worker.RunResult{}, context.DeadlineExceeded)
+		})
+
+		It("logs an error and fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+			Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+			_, message := fakeDelegate.ErroredArgsForCall(0)
+			Expect(message).To(Equal(exec.TimeoutLogMessage))
+		})
+	})
+
+	Context("when running the message errors", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeClient.RunRunStepReturns(worker.RunResult{}, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when selecting a worker errors", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakePool.SelectWorkerReturns(nil, 0, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(fakeClient.RunRunStepCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,97 @@
+package exec
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// SemaphorePollingInterval is how often a SemaphoreStep tries to acquire its
+// semaphore while other steps hold it.
+var SemaphorePollingInterval = 5 * time.Second
+
+// SemaphoreStep runs its step while holding a semaphore shared by every
+// build of the team, waiting for it if its limit of holders is reached.
+type SemaphoreStep struct {
+	planID          atc.PlanID
+	step            Step
+	semaphore       db.Semaphore
+	delegateFactory BuildStepDelegateFactory
+	metadata        StepMetadata
+}
+
+func Semaphore(
+	planID atc.PlanID,
+	step Step,
+	semaphore db.Semaphore,
+	delegateFactory BuildStepDelegateFactory,
+	metadata StepMetadata,
+) SemaphoreStep {
+	return SemaphoreStep{
+		planID:          planID,
+		step:            step,
+		semaphore:       semaphore,
+		delegateFactory: delegateFactory,
+		metadata:        metadata,
+	}
+}
+
+// Run acquires the semaphore, runs the step and then releases the semaphore.
+// While waiting for the semaphore a build event is emitted once, so that the
+// build shows why it isn't making progress.
+func (step SemaphoreStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("semaphore-step", lager.Data{
+		"semaphore": step.semaphore.Name(),
+		"build-id":  step.metadata.BuildID,
+	})
+
+	err := step.acquire(ctx, logger, state)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		err := step.semaphore.Release(step.metadata.BuildID, step.planID)
+		if err != nil {
+			logger.Error("failed-to-release-semaphore", err)
+		}
+	}()
+
+	return step.step.Run(ctx, state)
+}
+
+func (step SemaphoreStep) acquire(ctx context.Context, logger lager.Logger, state RunState) error {
+	var pollingTicker *time.Ticker
+
+	for {
+		acquired, err := step.semaphore.TryAcquire(logger, step.metadata.BuildID, step.planID)
+		if err != nil {
+			return err
+		}
+
+		if acquired {
+			return nil
+		}
+
+		if pollingTicker == nil {
+			pollingTicker = time.NewTicker(SemaphorePollingInterval)
+			defer pollingTicker.Stop()
+
+			logger.Debug("waiting-for-semaphore")
+
+			step.delegateFactory.BuildStepDelegate(state).WaitingForSemaphore(logger, step.semaphore.Name(), step.semaphore.Limit())
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("aborted-waiting-for-semaphore")
+			return ctx.Err()
+		case <-pollingTicker.C:
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SemaphoreStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStep            *execfakes.FakeStep
+		fakeSemaphore       *dbfakes.FakeSemaphore
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+		state               *execfakes.FakeRunState
+
+		stepMetadata = exec.StepMetadata{
+			BuildID:   42,
+			BuildName: "some-build",
+		}
+
+		planID = atc.PlanID("some-plan-id")
+
+		step exec.Step
+
+		stepOk  bool
+		stepErr error
+
+		originalPollingInterval time.Duration
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("semaphore-step-test"))
+
+		originalPollingInterval = exec.SemaphorePollingInterval
+		exec.SemaphorePollingInterval = time.Millisecond
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeStep.RunReturns(true, nil)
+
+		fakeSemaphore = new(dbfakes.FakeSemaphore)
+		fakeSemaphore.NameReturns("some-semaphore")
+		fakeSemaphore.LimitReturns(2)
+		fakeSemaphore.TryAcquireReturns(true, nil)
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		state = new(execfakes.FakeRunState)
+	})
+
+	AfterEach(func() {
+		cancel()
+		exec.SemaphorePollingInterval = originalPollingInterval
+	})
+
+	JustBeforeEach(func() {
+		step = exec.Semaphore(planID, fakeStep, fakeSemaphore, fakeDelegateFactory, stepMetadata)
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("acquires the semaphore for the step", func() {
+		Expect(fakeSemaphore.TryAcquireCallCount()).To(Equal(1))
+		_, buildID, acquiredPlanID := fakeSemaphore.TryAcquireArgsForCall(0)
+		Expect(buildID).To(Equal(42))
+		Expect(acquiredPlanID).To(Equal(planID))
+	})
+
+	It("runs the step and returns its result", func() {
+		Expect(fakeStep.RunCallCount()).To(Equal(1))
+		Expect(stepOk).To(BeTrue())
+		Expect(stepErr).ToNot(HaveOccurred())
+	})
+
+	It("releases the semaphore", func() {
+		Expect(fakeSemaphore.ReleaseCallCount()).To(Equal(1))
+		buildID, releasedPlanID := fakeSemaphore.ReleaseArgsForCall(0)
+		Expect(buildID).To(Equal(42))
+		Expect(releasedPlanID).To(Equal(planID))
+	})
+
+	It("does not emit a waiting event", func() {
+		Expect(fakeDelegate.WaitingForSemaphoreCallCount()).To(BeZero())
+	})
+
+	Context("when the step fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStep.RunReturns(false, disaster)
+		})
+
+		It("still releases the semaphore", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(fakeSemaphore.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the semaphore is held by other steps", func() {
+		BeforeEach(func() {
+			fakeSemaphore.TryAcquireReturnsOnCall(0, false, nil)
+			fakeSemaphore.TryAcquireReturnsOnCall(1, false, nil)
+			fakeSemaphore.TryAcquireReturnsOnCall(2, true, nil)
+		})
+
+		It("emits a waiting event once", func() {
+			Expect(fakeDelegate.WaitingForSemaphoreCallCount()).To(Equal(1))
+			_, name, limit := fakeDelegate.WaitingForSemaphoreArgsForCall(0)
+			Expect(name).To(Equal("some-semaphore"))
+			Expect(limit).To(Equal(2))
+		})
+
+		It("runs the step once the semaphore is acquired", func() {
+			Expect(fakeSemaphore.TryAcquireCallCount()).To(Equal(3))
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+			Expect(stepOk).To(BeTrue())
+		})
+
+		Context("when the build is aborted while waiting", func() {
+			BeforeEach(func() {
+				fakeSemaphore.TryAcquireStub = func(_ lager.Logger, _ int, _ atc.PlanID) (bool, error) {
+					cancel()
+					return false, nil
+				}
+			})
+
+			It("returns the context's error without running the step", func() {
+				Expect(stepErr).To(Equal(context.Canceled))
+				Expect(fakeStep.RunCallCount()).To(BeZero())
+				Expect(fakeSemaphore.ReleaseCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when acquiring the semaphore fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeSemaphore.TryAcquireReturns(false, disaster)
+		})
+
+		It("returns the error without running the step", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+			Expect(fakeSemaphore.ReleaseCallCount()).To(BeZero())
+		})
+	})
+})
@@ -14,6 +14,7 @@ import (
 
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configinclude"
 	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -25,7 +26,7 @@ import (
 	"github.com/concourse/concourse/vars"
 )
 
-const ActionRunSetPipeline = "SetPipeline"
+const ActionRunSetPipeline = policy.ActionSetPipeline
 
 // SetPipelineStep sets a pipeline to current team. This step takes pipeline
 // configure file and var files from some resource in the pipeline, like git.
@@ -127,6 +128,16 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		return false, err
 	}
 
+	// templates are included from the team running the step, which may not
+	// be the team the pipeline is set for
+	atcConfig, err = configinclude.Expand(atcConfig, configinclude.Source{
+		Templates: step.teamFactory.GetByID(step.metadata.TeamID),
+		Files:     source.fetchPipelineBits,
+	})
+	if err != nil {
+		return false, err
+	}
+
 	delegate.Starting(logger)
 
 	warnings, errors := configvalidate.Validate(atcConfig)
@@ -145,7 +156,12 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		return false, nil
 	}
 
-	var team db.Team
+	atcConfig, err = atc.ExpandStepTemplates(atcConfig)
+	if err != nil {
+		return false, err
+	}
+
+	var team, currentTeam db.Team
 	if step.plan.Team == "" {
 		team = step.teamFactory.GetByID(step.metadata.TeamID)
 	} else {
@@ -154,7 +170,8 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		fmt.Fprintln(stderr, "\x1b[33mcontribute to discussion #5731 with feedback: https://github.com/concourse/concourse/discussions/5731\x1b[0m")
 		fmt.Fprintln(stderr, "")
 
-		currentTeam, found, err := step.teamFactory.FindTeam(step.metadata.TeamName)
+		var found bool
+		currentTeam, found, err = step.teamFactory.FindTeam(step.metadata.TeamName)
 		if err != nil {
 			return false, err
 		}
@@ -170,18 +187,9 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 			return false, fmt.Errorf("team %s not found", step.plan.Team)
 		}
 
-		permitted := false
-		if targetTeam.ID() == currentTeam.ID() {
-			permitted = true
-		}
-		if currentTeam.Admin() {
-			permitted = true
-		}
-		if !permitted {
-			return false, fmt.Errorf(
-				"only %s team can set another team's pipeline",
-				atc.DefaultTeamName,
-			)
+		err = delegate.AuthorizeTeam(logger, currentTeam, targetTeam)
+		if err != nil {
+			return false, err
 		}
 
 		team = targetTeam
@@ -214,7 +222,7 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 
 		fmt.Fprintf(stdout, "no changes to apply.\n")
 
-		if found {
+		if found && !step.plan.DryRun {
 			err := pipeline.SetParentIDs(step.metadata.JobID, step.metadata.BuildID)
 			if err != nil {
 				return false, err
@@ -228,11 +236,17 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 
 	// conditionally check step
 	if step.policyChecker != nil && step.policyChecker.ShouldCheckAction(ActionRunSetPipeline) {
+		// the config has been interpolated, so it may hold credentials
+		data, err := atc.RedactSecretFields(atcConfig)
+		if err != nil {
+			return false, err
+		}
+
 		input := policy.PolicyCheckInput{
 			Action:   ActionRunSetPipeline,
 			Team:     team.Name(),
 			Pipeline: step.plan.Name,
-			Data:     &atcConfig,
+			Data:     data,
 		}
 		result, err := step.policyChecker.Check(input)
 		if err != nil {
@@ -241,9 +255,21 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		if !result.Allowed {
 			return false, fmt.Errorf("policy check failed for set_pipeline: %s", strings.Join(result.Reasons, ", "))
 		}
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(stderr, "\x1b[1;33mWARNING: policy check: %s\x1b[0m\n", warning)
+		}
 		logger.Debug("policy check passed for set_pipeline")
 	}
 
+	if step.plan.DryRun {
+		logger.Debug("dry-run")
+
+		fmt.Fprintf(stdout, "dry run: not setting pipeline: %s\n", pipelineRef.String())
+		delegate.SetPipelineChanged(logger, false)
+		delegate.Finished(logger, true)
+		return true, nil
+	}
+
 	fmt.Fprintf(stdout, "setting pipeline: %s\n", pipelineRef.String())
 	delegate.SetPipelineChanged(logger, true)
 
@@ -266,6 +292,10 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		return false, err
 	}
 
+	if currentTeam != nil && currentTeam.ID() != team.ID() {
+		delegate.AuditCrossTeamWrite(logger, currentTeam, team, pipelineRef)
+	}
+
 	fmt.Fprintf(stdout, "done\n")
 	logger.Info("saved-pipeline", lager.Data{"team": team.Name(), "pipeline": pipeline.Name()})
 	delegate.Finished(logger, true)
@@ -187,7 +187,7 @@ jobs:
 		fakeAgent.CheckReturns(policy.PassedPolicyCheck(), nil)
 		fakePolicyAgentFactory.NewAgentReturns(fakeAgent, nil)
 
-		fakeChecker, _ = policy.Initialize(testLogger, "some-cluster", "some-version", filter)
+		fakeChecker, _ = policy.Initialize(testLogger, "some-cluster", "some-version", filter, policy.Decisions{})
 
 		fakeArtifactStreamer = new(workerfakes.FakeArtifactStreamer)
 
@@ -286,6 +286,49 @@ jobs:
 			})
 		})
 
+		Context("when the pipeline includes a template", func() {
+			BeforeEach(func() {
+				fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: pipelineContent + "include:\n- template: common\n"}, nil)
+				fakeTeam.PipelineReturns(nil, false, nil)
+				fakeBuild.SavePipelineReturns(fakePipeline, true, nil)
+			})
+
+			Context("when the template exists", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineTemplateReturns(atc.PipelineTemplate{
+						Name:   "common",
+						Config: "jobs:\n- name: lint\n  plan:\n  - task: lint\n    file: some-resource/lint.yml\n",
+					}, true, nil)
+				})
+
+				It("looks the template up in the build's team", func() {
+					Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(stepMetadata.TeamID))
+					Expect(fakeTeam.PipelineTemplateCallCount()).To(Equal(1))
+					Expect(fakeTeam.PipelineTemplateArgsForCall(0)).To(Equal("common"))
+				})
+
+				It("saves the pipeline with the template merged in", func() {
+					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
+					_, _, config, _, _ := fakeBuild.SavePipelineArgsForCall(0)
+
+					Expect(config.Jobs).To(HaveLen(2))
+					Expect(config.Jobs[1].Name).To(Equal("lint"))
+
+					Expect(config.Include).To(BeNil())
+					Expect(config.Included).To(HaveLen(1))
+					Expect(config.Included[0].Template).To(Equal("common"))
+					Expect(config.Included[0].Jobs).To(Equal([]string{"lint"}))
+				})
+			})
+
+			Context("when the template does not exist", func() {
+				It("fails without saving the pipeline", func() {
+					Expect(stepErr).To(MatchError("include template 'common': template not found"))
+					Expect(fakeBuild.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when pipeline file is good", func() {
 			BeforeEach(func() {
 				fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: pipelineContent}, nil)
@@ -353,6 +396,56 @@ jobs:
 					})
 				})
 
+				Context("when running dry", func() {
+					BeforeEach(func() {
+						spPlan.DryRun = true
+					})
+
+					Context("when no diff", func() {
+						BeforeEach(func() {
+							fakePipeline.ConfigReturns(pipelineObject, nil)
+						})
+
+						It("should log 'no changes to apply'", func() {
+							Expect(stdout).To(gbytes.Say("no changes to apply."))
+						})
+
+						It("should not update the job and build id", func() {
+							Expect(fakePipeline.SetParentIDsCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when there are some diff", func() {
+						BeforeEach(func() {
+							pipelineObject.Jobs[0].PlanSequence[0].Config.(*atc.TaskStep).Config.Run.Args = []string{"hello world"}
+							fakePipeline.ConfigReturns(pipelineObject, nil)
+						})
+
+						It("should log diff", func() {
+							Expect(stdout).To(gbytes.Say("job some-job has changed:"))
+							Expect(stdout).To(gbytes.Say("dry run: not setting pipeline: some-pipeline"))
+						})
+
+						It("should not save the pipeline", func() {
+							Expect(fakeBuild.SavePipelineCallCount()).To(BeZero())
+						})
+
+						It("should send an unchanged set pipeline changed event", func() {
+							Expect(fakeDelegate.SetPipelineChangedCallCount()).To(Equal(1))
+							_, changed := fakeDelegate.SetPipelineChangedArgsForCall(0)
+							Expect(changed).To(BeFalse())
+						})
+
+						It("should finish successfully", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(stepOk).To(BeTrue())
+							Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+							_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+							Expect(succeeded).To(BeTrue())
+						})
+					})
+				})
+
 				Context("when there are some diff", func() {
 					BeforeEach(func() {
 						pipelineObject.Jobs[0].PlanSequence[0].Config.(*atc.TaskStep).Config.Run.Args = []string{"hello world"}
@@ -523,6 +616,10 @@ jobs:
 							Expect(stderr).To(gbytes.Say("contribute to discussion #5731"))
 							Expect(stderr).To(gbytes.Say("discussions/5731"))
 						})
+
+						It("should not audit a cross-team write", func() {
+							Expect(fakeDelegate.AuditCrossTeamWriteCallCount()).To(BeZero())
+						})
 					})
 
 					Context("when the team is not the current team", func() {
@@ -534,9 +631,9 @@ jobs:
 							)
 						})
 
-						Context("when the current team is an admin team", func() {
+						Context("when the current team is authorized", func() {
 							BeforeEach(func() {
-								fakeUserCurrentTeam.AdminReturns(true)
+								fakeDelegate.AuthorizeTeamReturns(nil)
 
 								fakeBuild.PipelineReturns(fakePipeline, true, nil)
 								fakeBuild.SavePipelineReturns(fakePipeline, false, nil)
@@ -549,16 +646,42 @@ jobs:
 								_, succeeded := fakeDelegate.FinishedArgsForCall(0)
 								Expect(succeeded).To(BeTrue())
 							})
+
+							It("should authorize the current team to set the team's pipeline", func() {
+								Expect(fakeDelegate.AuthorizeTeamCallCount()).To(Equal(1))
+								_, currentTeam, targetTeam := fakeDelegate.AuthorizeTeamArgsForCall(0)
+								Expect(currentTeam).To(Equal(fakeUserCurrentTeam))
+								Expect(targetTeam).To(Equal(fakeTeam))
+							})
+
+							It("should audit the cross-team write", func() {
+								Expect(fakeDelegate.AuditCrossTeamWriteCallCount()).To(Equal(1))
+								_, currentTeam, targetTeam, ref := fakeDelegate.AuditCrossTeamWriteArgsForCall(0)
+								Expect(currentTeam).To(Equal(fakeUserCurrentTeam))
+								Expect(targetTeam).To(Equal(fakeTeam))
+								Expect(ref).To(Equal(atc.PipelineRef{
+									Name:         "some-pipeline",
+									InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
+								}))
+							})
 						})
 
-						Context("when the current team is not an admin team", func() {
-							It("should return error", func() {
+						Context("when the current team is not authorized", func() {
+							BeforeEach(func() {
+								fakeDelegate.AuthorizeTeamReturns(errors.New("only main team can set another team's pipeline"))
+							})
 
+							It("should return error", func() {
 								Expect(stepErr).To(HaveOccurred())
 								Expect(stepErr.Error()).To(Equal(
 									"only main team can set another team's pipeline",
 								))
 							})
+
+							It("should not save the pipeline", func() {
+								Expect(fakeBuild.SavePipelineCallCount()).To(BeZero())
+								Expect(fakeDelegate.AuditCrossTeamWriteCallCount()).To(BeZero())
+							})
 						})
 					})
 				})
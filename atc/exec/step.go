@@ -42,6 +42,7 @@ type RunState interface {
 	AddLocalVar(name string, val interface{}, redact bool)
 
 	IterateInterpolatedCreds(vars.TrackedVarsIterator)
+	TrackDerived(name string, val interface{})
 	RedactionEnabled() bool
 
 	ArtifactRepository() *build.Repository
@@ -49,6 +50,12 @@ type RunState interface {
 	Result(atc.PlanID, interface{}) bool
 	StoreResult(atc.PlanID, interface{})
 
+	StepStatus(atc.PlanID) (atc.BuildStatus, bool)
+	StoreStepStatus(atc.PlanID, atc.BuildStatus)
+
+	FailureReason() (string, bool)
+	StoreFailureReason(string)
+
 	Run(context.Context, atc.Plan) (bool, error)
 
 	Parent() RunState
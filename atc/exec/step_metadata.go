@@ -3,6 +3,10 @@ package exec
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
 )
 
 type StepMetadata struct {
@@ -17,6 +21,38 @@ type StepMetadata struct {
 	PipelineInstanceVars map[string]interface{}
 	ExternalURL          string
 	CreatedBy            string
+
+	// The priority of the build's job, which orders the step ahead of those
+	// of lower priority jobs when waiting for a worker.
+	Priority int
+
+	// What caused the build to be created.
+	Trigger atc.BuildTrigger
+
+	// The attempt numbers and total attempts of each retry the step is
+	// nested in, outermost first.
+	Attempts      []int
+	TotalAttempts []int
+}
+
+// Attempt returns the attempt number of the innermost retry the step is
+// nested in, or 1 if it is not being retried.
+func (metadata StepMetadata) Attempt() int {
+	if len(metadata.Attempts) == 0 {
+		return 1
+	}
+
+	return metadata.Attempts[len(metadata.Attempts)-1]
+}
+
+// TotalAttempt returns the number of attempts of the innermost retry the
+// step is nested in, or 1 if it is not being retried.
+func (metadata StepMetadata) TotalAttempt() int {
+	if len(metadata.TotalAttempts) == 0 {
+		return 1
+	}
+
+	return metadata.TotalAttempts[len(metadata.TotalAttempts)-1]
 }
 
 func (metadata StepMetadata) Env() []string {
@@ -67,5 +103,18 @@ func (metadata StepMetadata) Env() []string {
 		env = append(env, "BUILD_CREATED_BY="+metadata.CreatedBy)
 	}
 
+	if len(metadata.Attempts) != 0 {
+		path := make([]string, len(metadata.Attempts))
+		for i, attempt := range metadata.Attempts {
+			path[i] = strconv.Itoa(attempt)
+		}
+
+		env = append(env,
+			fmt.Sprintf("BUILD_ATTEMPT=%d", metadata.Attempt()),
+			fmt.Sprintf("BUILD_TOTAL_ATTEMPTS=%d", metadata.TotalAttempt()),
+			"BUILD_ATTEMPT_PATH="+strings.Join(path, "."),
+		)
+	}
+
 	return env
 }
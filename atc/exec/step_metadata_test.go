@@ -43,6 +43,25 @@ var _ = Describe("StepMetadata", func() {
 			})
 		})
 
+		Context("when the step is being retried", func() {
+			BeforeEach(func() {
+				stepMetadata = exec.StepMetadata{
+					BuildID:       1,
+					Attempts:      []int{2, 1},
+					TotalAttempts: []int{3, 5},
+				}
+			})
+
+			It("includes the innermost attempt and the path to it", func() {
+				Expect(stepMetadata.Env()).To(Equal([]string{
+					"BUILD_ID=1",
+					"BUILD_ATTEMPT=1",
+					"BUILD_TOTAL_ATTEMPTS=5",
+					"BUILD_ATTEMPT_PATH=2.1",
+				}))
+			})
+		})
+
 		Context("when fields are empty", func() {
 			BeforeEach(func() {
 				stepMetadata = exec.StepMetadata{
@@ -156,6 +156,14 @@ func (configSource *OverrideContainerLimitsSource) FetchConfig(ctx context.Conte
 		taskConfig.Limits.Memory = configSource.Limits.Memory
 	}
 
+	if configSource.Limits.CPURequest != nil {
+		taskConfig.Limits.CPURequest = configSource.Limits.CPURequest
+	}
+
+	if configSource.Limits.MemoryRequest != nil {
+		taskConfig.Limits.MemoryRequest = configSource.Limits.MemoryRequest
+	}
+
 	return taskConfig, nil
 }
 
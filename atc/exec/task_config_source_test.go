@@ -423,6 +423,31 @@ run: {path: a/file}
 				}))
 			})
 		})
+
+		Context("when override resource requests are specified", func() {
+			BeforeEach(func() {
+				overrideLimits = atc.ContainerLimits{CPURequest: newCPULimit(512), MemoryRequest: newMemoryLimit(1024)}
+
+				configSource = &OverrideContainerLimitsSource{
+					ConfigSource: StaticConfigSource{Config: &config},
+					Limits:       &overrideLimits,
+				}
+			})
+
+			JustBeforeEach(func() {
+				fetchedConfig, fetchErr = configSource.FetchConfig(context.TODO(), logger, repo)
+			})
+
+			It("adds them to the config's limits", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(*fetchedConfig.Limits).To(Equal(atc.ContainerLimits{
+					CPU:           newCPULimit(1024),
+					Memory:        newMemoryLimit(209715200),
+					CPURequest:    newCPULimit(512),
+					MemoryRequest: newMemoryLimit(1024),
+				}))
+			})
+		})
 	})
 
 	Describe("ValidatingConfigSource", func() {
@@ -2,6 +2,9 @@ package exec
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -13,6 +16,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/runtime"
@@ -76,6 +80,15 @@ type TaskDelegate interface {
 	SelectedWorker(lager.Logger, string)
 }
 
+// BuildTokenIssuer issues short-lived tokens which let a build's tasks call
+// the API on behalf of the build's pipeline.
+//
+//counterfeiter:generate . BuildTokenIssuer
+type BuildTokenIssuer interface {
+	IssueBuildToken(StepMetadata) (string, error)
+	RevokeBuildToken(string) error
+}
+
 // TaskStep executes a TaskConfig, whose inputs will be fetched from the
 // artifact.Repository and outputs will be added to the artifact.Repository.
 type TaskStep struct {
@@ -89,6 +102,8 @@ type TaskStep struct {
 	artifactSourcer   worker.ArtifactSourcer
 	artifactStreamer  worker.ArtifactStreamer
 	delegateFactory   TaskDelegateFactory
+	buildTokenIssuer  BuildTokenIssuer
+	taskMemoFactory   db.TaskMemoFactory
 }
 
 func NewTaskStep(
@@ -102,6 +117,8 @@ func NewTaskStep(
 	artifactStreamer worker.ArtifactStreamer,
 	artifactSourcer worker.ArtifactSourcer,
 	delegateFactory TaskDelegateFactory,
+	buildTokenIssuer BuildTokenIssuer,
+	taskMemoFactory db.TaskMemoFactory,
 ) Step {
 	return &TaskStep{
 		planID:            planID,
@@ -114,6 +131,8 @@ func NewTaskStep(
 		artifactStreamer:  artifactStreamer,
 		artifactSourcer:   artifactSourcer,
 		delegateFactory:   delegateFactory,
+		buildTokenIssuer:  buildTokenIssuer,
+		taskMemoFactory:   taskMemoFactory,
 	}
 }
 
@@ -132,6 +151,10 @@ func NewTaskStep(
 // are registered with the artifact.Repository. If no outputs are specified, the
 // task's entire working directory is registered as an StreamableArtifactSource under the
 // name of the task.
+//
+// If the plan asks for the task to be memoized, the task is only run if it
+// has not already succeeded with the same config and inputs. Otherwise the
+// outputs of that run are registered with the artifact.Repository instead.
 func (step *TaskStep) Run(ctx context.Context, state RunState) (bool, error) {
 	delegate := step.delegateFactory.TaskDelegate(state)
 	ctx, span := delegate.StartSpan(ctx, "task", tracing.Attrs{
@@ -229,12 +252,67 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		return false, err
 	}
 
+	var memoKey string
+	memoizing := step.memoizing()
+	if memoizing {
+		memoKey, memoizing, err = step.memoKey(logger, repository, config, imageSpec)
+		if err != nil {
+			return false, err
+		}
+
+		if !memoizing {
+			fmt.Fprintln(delegate.Stderr(), "[WARNING] not memoizing the task, as its rootfs_uri is not pinned to a digest")
+		}
+	}
+
+	if memoizing {
+		reused, err := step.reuseMemoizedOutputs(logger, repository, delegate, config, memoKey)
+		if err != nil {
+			return false, err
+		}
+
+		if reused {
+			return true, nil
+		}
+	}
+
 	containerSpec, err := step.containerSpec(logger, state, imageSpec, config, step.containerMetadata)
 	if err != nil {
 		return false, err
 	}
 	tracing.Inject(ctx, &containerSpec)
 
+	containerSpec.Services, err = step.serviceSpecs(logger, state, delegate)
+	if err != nil {
+		return false, err
+	}
+
+	for _, service := range step.plan.Services {
+		containerSpec.Env = append(containerSpec.Env, serviceHostEnv(service.Name)+"=localhost")
+	}
+
+	if step.plan.BuildToken {
+		buildToken, err := step.buildTokenIssuer.IssueBuildToken(step.metadata)
+		if err != nil {
+			return false, fmt.Errorf("issue build token: %w", err)
+		}
+
+		defer func() {
+			err := step.buildTokenIssuer.RevokeBuildToken(buildToken)
+			if err != nil {
+				logger.Error("failed-to-revoke-build-token", err)
+			}
+		}()
+
+		state.TrackDerived("build_token", buildToken)
+
+		containerSpec.Env = append(containerSpec.Env,
+			"CONCOURSE_URL="+step.metadata.ExternalURL,
+			"CONCOURSE_TEAM="+step.metadata.TeamName,
+			"CONCOURSE_TOKEN="+buildToken,
+		)
+	}
+
 	processSpec := runtime.ProcessSpec{
 		Path:         config.Run.Path,
 		Args:         config.Run.Args,
@@ -301,15 +379,29 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	if runErr != nil {
 		if errors.Is(runErr, context.DeadlineExceeded) {
 			delegate.Errored(logger, TimeoutLogMessage)
+			state.StoreFailureReason(TimeoutLogMessage)
 			return false, nil
 		}
 
 		return false, runErr
 	}
 
+	if memoizing && result.ExitStatus == 0 {
+		err := step.memoize(logger, chosenWorker, config, result.VolumeMounts, memoKey)
+		if err != nil {
+			// the task itself succeeded; it just won't be skipped next time
+			logger.Error("failed-to-memoize-outputs", err)
+		}
+	}
+
 	delegate.Finished(logger, ExitStatus(result.ExitStatus), step.strategy, chosenWorker)
 
-	return result.ExitStatus == 0, nil
+	if result.ExitStatus != 0 {
+		state.StoreFailureReason(exitStatusReason(result.ExitStatus))
+		return false, nil
+	}
+
+	return true, nil
 }
 
 func (step *TaskStep) imageSpec(ctx context.Context, logger lager.Logger, state RunState, delegate TaskDelegate, config atc.TaskConfig) (worker.ImageSpec, error) {
@@ -317,6 +409,11 @@ func (step *TaskStep) imageSpec(ctx context.Context, logger lager.Logger, state
 		Privileged: bool(step.plan.Privileged),
 	}
 
+	mirror, err := creds.NewRegistryMirror(state, step.plan.RegistryMirror).Evaluate()
+	if err != nil {
+		return worker.ImageSpec{}, err
+	}
+
 	// Determine the source of the container image
 	// a reference to an artifact (get step, task output) ?
 	if step.plan.ImageArtifactName != "" {
@@ -337,16 +434,26 @@ func (step *TaskStep) imageSpec(ctx context.Context, logger lager.Logger, state
 			image.Tags = step.plan.Tags
 		}
 
+		// the task only runs on workers of its arch, so a multi-arch image
+		// resolves to it
+		image.SetPlatform(config.Platform, step.arch(config))
+
+		types, err := image.ApplyRegistryMirror(mirror, step.plan.VersionedResourceTypes)
+		if err != nil {
+			return worker.ImageSpec{}, err
+		}
+
 		return delegate.FetchImage(
 			ctx,
 			image,
-			step.plan.VersionedResourceTypes,
+			types,
 			step.plan.Privileged,
 		)
 
 		// a rootfs_uri
 	} else if config.RootfsURI != "" {
 		imageSpec.ImageURL = config.RootfsURI
+		imageSpec.RegistryMirror = mirror
 	}
 
 	return imageSpec, nil
@@ -408,9 +515,21 @@ func (step *TaskStep) containerInputs(logger lager.Logger, repository *build.Rep
 
 func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSpec worker.ImageSpec, config atc.TaskConfig, metadata db.ContainerMetadata) (worker.ContainerSpec, error) {
 	var limits worker.ContainerLimits
+	var requests worker.ContainerRequests
 	if config.Limits != nil {
 		limits.CPU = (*uint64)(config.Limits.CPU)
 		limits.Memory = (*uint64)(config.Limits.Memory)
+
+		if config.Limits.CPURequest != nil {
+			requests.CPU = uint64(*config.Limits.CPURequest)
+		}
+
+		if config.Limits.MemoryRequest != nil {
+			requests.Memory = uint64(*config.Limits.MemoryRequest)
+		}
+
+		requests.BuildID = step.metadata.BuildID
+		requests.PlanID = step.planID
 	}
 
 	containerSpec := worker.ContainerSpec{
@@ -418,10 +537,13 @@ func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSp
 		TeamID:    step.metadata.TeamID,
 		Type:      metadata.Type,
 
-		Dir:    metadata.WorkingDirectory,
-		Env:    config.Params.Env(),
-		Limits: limits,
-		User:   config.Run.User,
+		Dir:      metadata.WorkingDirectory,
+		Env:      append(config.Params.Env(), step.metadata.Env()...),
+		Limits:   limits,
+		Requests: requests,
+		User:     config.Run.User,
+
+		Hermetic: config.Hermetic,
 
 		Outputs: worker.OutputPaths{},
 	}
@@ -440,11 +562,94 @@ func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSp
 	return containerSpec, nil
 }
 
+// serviceSpecs describes the containers of the services which run alongside
+// the task. Each service's image is an artifact in the build plan.
+func (step *TaskStep) serviceSpecs(logger lager.Logger, state RunState, delegate TaskDelegate) ([]worker.ServiceSpec, error) {
+	var services []worker.ServiceSpec
+
+	for _, service := range step.plan.Services {
+		art, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName(service.Image))
+		if !found {
+			return nil, MissingTaskImageSourceError{service.Image}
+		}
+
+		source, err := step.artifactSourcer.SourceImage(logger, art)
+		if err != nil {
+			return nil, err
+		}
+
+		var ready *runtime.ProcessSpec
+		if service.Ready != nil {
+			ready = &runtime.ProcessSpec{
+				Path:         service.Ready.Path,
+				Args:         service.Ready.Args,
+				Dir:          service.Ready.Dir,
+				StdoutWriter: delegate.Stderr(),
+				StderrWriter: delegate.Stderr(),
+			}
+		}
+
+		services = append(services, worker.ServiceSpec{
+			Name: service.Name,
+
+			Owner: db.NewBuildStepContainerOwner(
+				step.metadata.BuildID,
+				atc.PlanID(fmt.Sprintf("%s/services/%s", step.planID, service.Name)),
+				step.metadata.TeamID,
+			),
+			Metadata: step.containerMetadata,
+
+			ContainerSpec: worker.ContainerSpec{
+				TeamID: step.metadata.TeamID,
+				Type:   step.containerMetadata.Type,
+
+				ImageSpec: worker.ImageSpec{
+					ImageArtifactSource: source,
+				},
+
+				Env:  service.Params.Env(),
+				User: service.Run.User,
+			},
+
+			ProcessSpec: runtime.ProcessSpec{
+				Path:         service.Run.Path,
+				Args:         service.Run.Args,
+				Dir:          service.Run.Dir,
+				StdoutWriter: delegate.Stderr(),
+				StderrWriter: delegate.Stderr(),
+			},
+			Ready: ready,
+		})
+	}
+
+	return services, nil
+}
+
+// serviceHostEnv is the name of the environment variable telling the task
+// where to reach the named service.
+func serviceHostEnv(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_HOST"
+}
+
+// arch returns the arch the task must run on, which its config may set in
+// place of the arch of the job.
+func (step *TaskStep) arch(config atc.TaskConfig) string {
+	if config.Arch != "" {
+		return config.Arch
+	}
+
+	return step.plan.Arch
+}
+
 func (step *TaskStep) workerSpec(config atc.TaskConfig) worker.WorkerSpec {
 	return worker.WorkerSpec{
 		Platform: config.Platform,
+		Arch:     step.arch(config),
 		Tags:     step.plan.Tags,
+		Hermetic: config.Hermetic,
+		Services: len(step.plan.Services) > 0,
 		TeamID:   step.metadata.TeamID,
+		Priority: step.metadata.Priority,
 	}
 }
 
@@ -497,6 +702,214 @@ func (step *TaskStep) registerCaches(logger lager.Logger, repository *build.Repo
 	return nil
 }
 
+// memoizing returns whether the task's outputs are to be reused by later runs
+// with the same config and inputs. Like caches, memoized outputs belong to a
+// job's step, so tasks in one-off builds are never memoized.
+func (step *TaskStep) memoizing() bool {
+	return step.plan.Memoize && step.metadata.JobID != 0
+}
+
+type taskMemoKey struct {
+	Config     atc.TaskConfig    `json:"config"`
+	Privileged bool              `json:"privileged"`
+	Inputs     map[string]string `json:"inputs"`
+	Image      string            `json:"image,omitempty"`
+}
+
+// memoKey digests everything the task's outputs depend on: its config, the
+// artifacts given to it as inputs, and its image. An image_resource is
+// identified by the resource cache it was fetched into, so that a new version
+// of the image changes the key.
+//
+// A docker:// rootfs_uri can point to a different image on every run, so the
+// task is only memoized if it is pinned to a digest; otherwise false is
+// returned.
+func (step *TaskStep) memoKey(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, imageSpec worker.ImageSpec) (string, bool, error) {
+	key := taskMemoKey{
+		Config:     config,
+		Privileged: bool(step.plan.Privileged),
+		Inputs:     map[string]string{},
+	}
+
+	for _, input := range config.Inputs {
+		inputName := input.Name
+		if sourceName, ok := step.plan.InputMapping[inputName]; ok {
+			inputName = sourceName
+		}
+
+		art, found := repository.ArtifactFor(build.ArtifactName(inputName))
+		if !found {
+			continue
+		}
+
+		digest, err := step.artifactDigest(logger, art)
+		if err != nil {
+			return "", false, err
+		}
+
+		key.Inputs[input.Name] = digest
+	}
+
+	if step.plan.ImageArtifactName != "" {
+		art, found := repository.ArtifactFor(build.ArtifactName(step.plan.ImageArtifactName))
+		if found {
+			digest, err := step.artifactDigest(logger, art)
+			if err != nil {
+				return "", false, err
+			}
+
+			key.Image = digest
+		}
+	} else if imageSpec.ResourceCacheID != 0 {
+		key.Image = fmt.Sprintf("resource-cache:%d", imageSpec.ResourceCacheID)
+	} else if strings.HasPrefix(imageSpec.ImageURL, "docker:") && !strings.Contains(imageSpec.ImageURL, "@") {
+		logger.Debug("rootfs-uri-not-pinned", lager.Data{"rootfs-uri": imageSpec.ImageURL})
+		return "", false, nil
+	}
+
+	payload, err := json.Marshal(key)
+	if err != nil {
+		return "", false, err
+	}
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), true, nil
+}
+
+// artifactDigest identifies the contents of an artifact. Artifacts fetched by
+// get steps are identified by their resource cache, so that fetching the same
+// version again yields the same digest. Anything else can only be identified
+// by its volume.
+func (step *TaskStep) artifactDigest(logger lager.Logger, art runtime.Artifact) (string, error) {
+	volume, found, err := step.workerPool.FindVolume(logger, step.metadata.TeamID, art.ID())
+	if err != nil {
+		return "", err
+	}
+
+	if found && volume.GetResourceCacheID() != 0 {
+		return fmt.Sprintf("resource-cache:%d", volume.GetResourceCacheID()), nil
+	}
+
+	return "volume:" + art.ID(), nil
+}
+
+// reuseMemoizedOutputs registers the outputs of a prior run of the task with
+// the same memo key. It returns false if there was no such run, or if any of
+// its outputs has since been garbage collected.
+func (step *TaskStep) reuseMemoizedOutputs(logger lager.Logger, repository *build.Repository, delegate TaskDelegate, config atc.TaskConfig, memoKey string) (bool, error) {
+	memo, found, err := step.taskMemoFactory.Find(step.metadata.JobID, step.plan.Name, memoKey)
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		logger.Debug("no-memoized-outputs", lager.Data{"key": memoKey})
+		return false, nil
+	}
+
+	artifacts := map[build.ArtifactName]runtime.Artifact{}
+	for _, output := range config.Outputs {
+		handle, found := memo.Outputs[output.Name]
+		if !found {
+			return false, nil
+		}
+
+		_, found, err := step.workerPool.FindVolume(logger, step.metadata.TeamID, handle)
+		if err != nil {
+			return false, err
+		}
+
+		if !found {
+			logger.Info("memoized-output-volume-not-found", lager.Data{
+				"output": output.Name,
+				"handle": handle,
+			})
+			return false, nil
+		}
+
+		outputName := output.Name
+		if destinationName, ok := step.plan.OutputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		artifacts[build.ArtifactName(outputName)] = &runtime.TaskArtifact{
+			VolumeHandle: handle,
+		}
+	}
+
+	for name, art := range artifacts {
+		repository.RegisterArtifact(name, art)
+	}
+
+	fmt.Fprintf(delegate.Stdout(), "skipping task: reusing outputs of build #%s, which ran it with the same config and inputs\n", memo.BuildName)
+
+	delegate.Finished(logger, ExitStatus(0), step.strategy, nil)
+
+	return true, nil
+}
+
+// memoize keeps the task's outputs around as task caches of the step and
+// records them under the memo key. Each output is cached under the same path
+// on every run, so that memoizing a new run releases the volumes of the last.
+func (step *TaskStep) memoize(logger lager.Logger, chosenWorker worker.Client, config atc.TaskConfig, volumeMounts []worker.VolumeMount, memoKey string) error {
+	outputs := map[string]string{}
+
+	for _, output := range config.Outputs {
+		outputPath := artifactsPath(output, step.containerMetadata.WorkingDirectory)
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) != filepath.Clean(outputPath) {
+				continue
+			}
+
+			cachePath := path.Join(memoizedOutputsCachePath, output.Name)
+
+			err := mount.Volume.InitializeTaskCache(
+				logger,
+				step.metadata.JobID,
+				step.plan.Name,
+				cachePath,
+				bool(step.plan.Privileged),
+			)
+			if err != nil {
+				return err
+			}
+
+			// outputs nested within an input are imported into a new volume
+			// rather than becoming the cache themselves
+			volume, found, err := chosenWorker.Worker().FindVolumeForTaskCache(
+				logger,
+				step.metadata.TeamID,
+				step.metadata.JobID,
+				step.plan.Name,
+				cachePath,
+			)
+			if err != nil {
+				return err
+			}
+
+			if !found {
+				return fmt.Errorf("volume for memoized output '%s' not found", output.Name)
+			}
+
+			outputs[output.Name] = volume.Handle()
+			break
+		}
+	}
+
+	return step.taskMemoFactory.Save(db.TaskMemo{
+		JobID:    step.metadata.JobID,
+		StepName: step.plan.Name,
+		Key:      memoKey,
+		BuildID:  step.metadata.BuildID,
+		Outputs:  outputs,
+	})
+}
+
+// memoizedOutputsCachePath is the task cache path under which the outputs of
+// memoized tasks are kept.
+const memoizedOutputsCachePath = ".memoized-outputs"
+
 type taskInput struct {
 	config        atc.TaskInputConfig
 	artifact      runtime.Artifact
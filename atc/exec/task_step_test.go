@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
@@ -45,6 +46,10 @@ var _ = Describe("TaskStep", func() {
 
 		fakeDelegateFactory *execfakes.FakeTaskDelegateFactory
 
+		fakeBuildTokenIssuer *execfakes.FakeBuildTokenIssuer
+
+		fakeTaskMemoFactory *dbfakes.FakeTaskMemoFactory
+
 		taskPlan *atc.TaskPlan
 
 		repo       *build.Repository
@@ -64,9 +69,11 @@ var _ = Describe("TaskStep", func() {
 		}
 
 		stepMetadata = exec.StepMetadata{
-			TeamID:  123,
-			BuildID: 1234,
-			JobID:   12345,
+			TeamID:      123,
+			TeamName:    "some-team",
+			BuildID:     1234,
+			JobID:       12345,
+			ExternalURL: "https://concourse.example.com",
 		}
 
 		planID = atc.PlanID("42")
@@ -99,6 +106,11 @@ var _ = Describe("TaskStep", func() {
 		fakeDelegateFactory = new(execfakes.FakeTaskDelegateFactory)
 		fakeDelegateFactory.TaskDelegateReturns(fakeDelegate)
 
+		fakeBuildTokenIssuer = new(execfakes.FakeBuildTokenIssuer)
+		fakeBuildTokenIssuer.IssueBuildTokenReturns("some-build-token", nil)
+
+		fakeTaskMemoFactory = new(dbfakes.FakeTaskMemoFactory)
+
 		repo = build.NewRepository()
 		state = new(execfakes.FakeRunState)
 		state.ArtifactRepositoryReturns(repo)
@@ -146,6 +158,8 @@ var _ = Describe("TaskStep", func() {
 			fakeArtifactStreamer,
 			fakeArtifactSourcer,
 			fakeDelegateFactory,
+			fakeBuildTokenIssuer,
+			fakeTaskMemoFactory,
 		)
 
 		stepOk, stepErr = taskStep.Run(ctx, state)
@@ -234,6 +248,46 @@ var _ = Describe("TaskStep", func() {
 				})
 			})
 
+			Context("when an arch is configured", func() {
+				BeforeEach(func() {
+					taskPlan.Config.Arch = "arm64"
+				})
+
+				It("creates a worker spec with the arch", func() {
+					Expect(workerSpec.Arch).To(Equal("arm64"))
+				})
+			})
+
+			Context("when the job is constrained to an arch", func() {
+				BeforeEach(func() {
+					taskPlan.Arch = "amd64"
+				})
+
+				It("creates a worker spec with the arch", func() {
+					Expect(workerSpec.Arch).To(Equal("amd64"))
+				})
+
+				Context("when the task config sets an arch", func() {
+					BeforeEach(func() {
+						taskPlan.Config.Arch = "arm64"
+					})
+
+					It("creates a worker spec with the task's arch", func() {
+						Expect(workerSpec.Arch).To(Equal("arm64"))
+					})
+				})
+			})
+
+			Context("when the build's job has a priority", func() {
+				BeforeEach(func() {
+					stepMetadata.Priority = 1
+				})
+
+				It("creates a worker spec with the priority", func() {
+					Expect(workerSpec.Priority).To(Equal(1))
+				})
+			})
+
 			Context("when selecting a worker fails", func() {
 				BeforeEach(func() {
 					fakePool.SelectWorkerReturns(nil, 0, errors.New("nope"))
@@ -286,6 +340,113 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when the config is hermetic", func() {
+			BeforeEach(func() {
+				taskPlan.Config.Hermetic = true
+			})
+
+			It("runs the task in a hermetic container", func() {
+				Expect(containerSpec.Hermetic).To(BeTrue())
+			})
+
+			It("records the hermetic config with the delegate", func() {
+				Expect(fakeDelegate.SetTaskConfigCallCount()).To(Equal(1))
+				Expect(fakeDelegate.SetTaskConfigArgsForCall(0).Hermetic).To(BeTrue())
+			})
+
+			It("requires a worker which can run hermetic containers", func() {
+				_, _, _, workerSpec, _, _ := fakePool.SelectWorkerArgsForCall(0)
+				Expect(workerSpec.Hermetic).To(BeTrue())
+			})
+		})
+
+		Context("when the plan has services", func() {
+			var serviceSource *workerfakes.FakeStreamableArtifactSource
+
+			BeforeEach(func() {
+				taskPlan.Services = []atc.TaskServiceConfig{
+					{
+						Name:   "some-db",
+						Image:  "some-db-image",
+						Params: atc.TaskEnv{"SOME": "db-param"},
+						Run: atc.TaskRunConfig{
+							Path: "postgres",
+							Args: []string{"-D", "/data"},
+							User: "postgres",
+						},
+					},
+				}
+
+				repo.RegisterArtifact("some-db-image", new(runtimefakes.FakeArtifact))
+
+				serviceSource = new(workerfakes.FakeStreamableArtifactSource)
+				fakeArtifactSourcer.SourceImageReturns(serviceSource, nil)
+			})
+
+			It("runs each service alongside the task", func() {
+				Expect(containerSpec.Services).To(Equal([]worker.ServiceSpec{
+					{
+						Name:     "some-db",
+						Owner:    db.NewBuildStepContainerOwner(1234, atc.PlanID(planID+"/services/some-db"), 123),
+						Metadata: containerMetadata,
+						ContainerSpec: worker.ContainerSpec{
+							TeamID: 123,
+							Type:   containerMetadata.Type,
+							ImageSpec: worker.ImageSpec{
+								ImageArtifactSource: serviceSource,
+							},
+							Env:  []string{"SOME=db-param"},
+							User: "postgres",
+						},
+						ProcessSpec: runtime.ProcessSpec{
+							Path:         "postgres",
+							Args:         []string{"-D", "/data"},
+							StdoutWriter: stderrBuf,
+							StderrWriter: stderrBuf,
+						},
+					},
+				}))
+			})
+
+			It("tells the task where to reach each service", func() {
+				Expect(containerSpec.Env).To(ContainElement("SOME_DB_HOST=localhost"))
+			})
+
+			It("requires a worker which can run services", func() {
+				_, _, _, workerSpec, _, _ := fakePool.SelectWorkerArgsForCall(0)
+				Expect(workerSpec.Services).To(BeTrue())
+			})
+
+			Context("when a service has a readiness command", func() {
+				BeforeEach(func() {
+					taskPlan.Services[0].Ready = &atc.TaskRunConfig{
+						Path: "pg_isready",
+						Args: []string{"-q"},
+					}
+				})
+
+				It("waits for the readiness command to succeed", func() {
+					Expect(containerSpec.Services[0].Ready).To(Equal(&runtime.ProcessSpec{
+						Path:         "pg_isready",
+						Args:         []string{"-q"},
+						StdoutWriter: stderrBuf,
+						StderrWriter: stderrBuf,
+					}))
+				})
+			})
+
+			Context("when a service's image artifact is missing", func() {
+				BeforeEach(func() {
+					taskPlan.Services[0].Image = "some-missing-image"
+					shouldRunTaskStep = false
+				})
+
+				It("returns an error", func() {
+					Expect(stepErr).To(Equal(exec.MissingTaskImageSourceError{"some-missing-image"}))
+				})
+			})
+		})
+
 		It("uses the correct container limits", func() {
 			Expect(atc.CPULimit(*containerSpec.Limits.CPU)).To(Equal(atc.CPULimit(1024)))
 			Expect(atc.MemoryLimit(*containerSpec.Limits.Memory)).To(Equal(atc.MemoryLimit(1024)))
@@ -307,6 +468,31 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when resource requests are set", func() {
+			BeforeEach(func() {
+				cpu := atc.CPULimit(512)
+				memory := atc.MemoryLimit(256)
+				taskPlan.Limits = &atc.ContainerLimits{
+					CPURequest:    &cpu,
+					MemoryRequest: &memory,
+				}
+			})
+
+			It("requests them for the container, on behalf of the step", func() {
+				Expect(containerSpec.Requests).To(Equal(worker.ContainerRequests{
+					CPU:     512,
+					Memory:  256,
+					BuildID: 1234,
+					PlanID:  "42",
+				}))
+			})
+
+			It("selects a worker with them", func() {
+				_, _, selectSpec, _, _, _ := fakePool.SelectWorkerArgsForCall(0)
+				Expect(selectSpec.Requests).To(Equal(containerSpec.Requests))
+			})
+		})
+
 		Context("when a timeout is configured", func() {
 			BeforeEach(func() {
 				taskPlan.Timeout = "1h"
@@ -361,6 +547,27 @@ var _ = Describe("TaskStep", func() {
 					Privileged: false,
 				}))
 			})
+
+			Context("when the plan configures a registry mirror", func() {
+				BeforeEach(func() {
+					taskPlan.RegistryMirror = &atc.RegistryMirror{
+						Host:     "some-mirror",
+						Username: "some-user",
+						Password: "((source-param))",
+					}
+				})
+
+				It("pulls the image from the mirror", func() {
+					Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
+						ImageURL: "some-image",
+						RegistryMirror: &atc.RegistryMirror{
+							Host:     "some-mirror",
+							Username: "some-user",
+							Password: "super-secret-source",
+						},
+					}))
+				})
+			})
 		})
 
 		Context("when tracing is enabled", func() {
@@ -386,6 +593,67 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		It("does not issue a build token", func() {
+			Expect(fakeBuildTokenIssuer.IssueBuildTokenCallCount()).To(BeZero())
+			Expect(containerSpec.Env).ToNot(ContainElement(HavePrefix("CONCOURSE_TOKEN=")))
+		})
+
+		It("provides the build metadata to the task", func() {
+			Expect(containerSpec.Env).To(ContainElement("BUILD_ID=1234"))
+		})
+
+		Context("when the step is within a retry", func() {
+			BeforeEach(func() {
+				stepMetadata.Attempts = []int{1, 2}
+				stepMetadata.TotalAttempts = []int{3, 4}
+			})
+
+			It("tells the task which attempt it is", func() {
+				Expect(containerSpec.Env).To(ContainElement("BUILD_ATTEMPT=2"))
+				Expect(containerSpec.Env).To(ContainElement("BUILD_TOTAL_ATTEMPTS=4"))
+				Expect(containerSpec.Env).To(ContainElement("BUILD_ATTEMPT_PATH=1.2"))
+			})
+		})
+
+		Context("when the plan asks for a build token", func() {
+			BeforeEach(func() {
+				taskPlan.BuildToken = true
+			})
+
+			It("issues a token for the build", func() {
+				Expect(fakeBuildTokenIssuer.IssueBuildTokenCallCount()).To(Equal(1))
+				Expect(fakeBuildTokenIssuer.IssueBuildTokenArgsForCall(0)).To(Equal(stepMetadata))
+			})
+
+			It("provides the token and where to use it to the task", func() {
+				Expect(containerSpec.Env).To(ContainElement("CONCOURSE_TOKEN=some-build-token"))
+				Expect(containerSpec.Env).To(ContainElement("CONCOURSE_TEAM=some-team"))
+				Expect(containerSpec.Env).To(ContainElement("CONCOURSE_URL=https://concourse.example.com"))
+			})
+
+			It("redacts the token from the build's output", func() {
+				Expect(state.TrackDerivedCallCount()).To(Equal(1))
+				_, val := state.TrackDerivedArgsForCall(0)
+				Expect(val).To(Equal("some-build-token"))
+			})
+
+			It("revokes the token once the task has finished", func() {
+				Expect(fakeBuildTokenIssuer.RevokeBuildTokenCallCount()).To(Equal(1))
+				Expect(fakeBuildTokenIssuer.RevokeBuildTokenArgsForCall(0)).To(Equal("some-build-token"))
+			})
+
+			Context("when issuing the token fails", func() {
+				BeforeEach(func() {
+					fakeBuildTokenIssuer.IssueBuildTokenReturns("", errors.New("nope"))
+					shouldRunTaskStep = false
+				})
+
+				It("returns an err", func() {
+					Expect(stepErr).To(MatchError(ContainSubstring("nope")))
+				})
+			})
+		})
+
 		Context("when the configuration specifies paths for inputs", func() {
 			var inputArtifact *runtimefakes.FakeArtifact
 			var otherInputArtifact *runtimefakes.FakeArtifact
@@ -879,6 +1147,107 @@ var _ = Describe("TaskStep", func() {
 				Expect(containerSpec.ImageSpec).To(Equal(fakeImageSpec))
 			})
 
+			Context("when fetching the image fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeDelegate.FetchImageReturns(worker.ImageSpec{}, disaster)
+					shouldRunTaskStep = false
+				})
+
+				It("does not select a worker", func() {
+					Expect(stepErr).To(Equal(disaster))
+					Expect(fakePool.SelectWorkerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the image is a registry-image", func() {
+				BeforeEach(func() {
+					taskPlan.Config.ImageResource.Type = "registry-image"
+				})
+
+				It("fetches the image as configured", func() {
+					Expect(fakeDelegate.FetchImageCallCount()).To(Equal(1))
+					_, imageResource, _, _ := fakeDelegate.FetchImageArgsForCall(0)
+					Expect(imageResource.Source).To(Equal(atc.Source{"some": "super-secret-source"}))
+				})
+
+				Context("when the task is constrained to an arch", func() {
+					BeforeEach(func() {
+						taskPlan.Config.Arch = "arm64"
+					})
+
+					It("fetches the image for the arch", func() {
+						Expect(fakeDelegate.FetchImageCallCount()).To(Equal(1))
+						_, imageResource, _, _ := fakeDelegate.FetchImageArgsForCall(0)
+						Expect(imageResource.Source).To(Equal(atc.Source{
+							"some": "super-secret-source",
+							"platform": map[string]interface{}{
+								"os":           "some-platform",
+								"architecture": "arm64",
+							},
+						}))
+					})
+
+					It("does not modify the task config", func() {
+						Expect(taskPlan.Config.ImageResource.Source).To(Equal(atc.Source{"some": "super-secret-source"}))
+					})
+				})
+
+				Context("when the job is constrained to an arch", func() {
+					BeforeEach(func() {
+						taskPlan.Arch = "arm64"
+					})
+
+					It("fetches the image for the arch", func() {
+						Expect(fakeDelegate.FetchImageCallCount()).To(Equal(1))
+						_, imageResource, _, _ := fakeDelegate.FetchImageArgsForCall(0)
+						Expect(imageResource.Source).To(HaveKeyWithValue("platform", map[string]interface{}{
+							"os":           "some-platform",
+							"architecture": "arm64",
+						}))
+					})
+				})
+			})
+
+			Context("when the plan configures a registry mirror", func() {
+				BeforeEach(func() {
+					taskPlan.Config.ImageResource.Type = "registry-image"
+					taskPlan.RegistryMirror = &atc.RegistryMirror{
+						Host:     "some-mirror",
+						Password: "((source-param))",
+					}
+				})
+
+				It("fetches the image from the mirror", func() {
+					Expect(fakeDelegate.FetchImageCallCount()).To(Equal(1))
+					_, imageResource, _, _ := fakeDelegate.FetchImageArgsForCall(0)
+					Expect(imageResource.Source).To(Equal(atc.Source{
+						"some": "super-secret-source",
+						"registry_mirror": map[string]interface{}{
+							"host":     "some-mirror",
+							"password": "super-secret-source",
+						},
+					}))
+				})
+
+				It("does not modify the task config", func() {
+					Expect(taskPlan.Config.ImageResource.Source).To(Equal(atc.Source{"some": "super-secret-source"}))
+				})
+
+				Context("when the image is not fetched with registry-image", func() {
+					BeforeEach(func() {
+						taskPlan.Config.ImageResource.Type = "docker-image"
+						shouldRunTaskStep = false
+					})
+
+					It("errors without fetching the image", func() {
+						Expect(stepErr).To(Equal(atc.RegistryMirrorNotSupportedError{Type: "docker-image"}))
+						Expect(fakeDelegate.FetchImageCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			Context("when tags are specified on the task plan", func() {
 				BeforeEach(func() {
 					taskPlan.Tags = atc.Tags{"plan", "tags"}
@@ -1084,6 +1453,12 @@ var _ = Describe("TaskStep", func() {
 				It("returns successfully", func() {
 					Expect(stepErr).ToNot(HaveOccurred())
 				})
+
+				It("gives the exit status as the reason it failed", func() {
+					Expect(stepOk).To(BeFalse())
+					Expect(state.StoreFailureReasonCallCount()).To(Equal(1))
+					Expect(state.StoreFailureReasonArgsForCall(0)).To(Equal("exit status 5"))
+				})
 			})
 		})
 
@@ -1224,6 +1599,284 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when the plan asks for the task to be memoized", func() {
+			var (
+				fakeWorker      *workerfakes.FakeWorker
+				fakeInputVolume *workerfakes.FakeVolume
+				fakeOutputMount *workerfakes.FakeVolume
+				fakeCacheVolume *workerfakes.FakeVolume
+			)
+
+			BeforeEach(func() {
+				stepMetadata.JobID = 12
+				taskPlan.Memoize = true
+				taskPlan.Config = &atc.TaskConfig{
+					Platform:  "some-platform",
+					RootfsURI: "some-image",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Inputs: []atc.TaskInputConfig{
+						{Name: "some-input"},
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{Name: "some-output"},
+					},
+				}
+
+				repo.RegisterArtifact("some-input", &runtime.GetArtifact{VolumeHandle: "some-input-handle"})
+
+				fakeInputVolume = new(workerfakes.FakeVolume)
+				fakeInputVolume.GetResourceCacheIDReturns(7)
+				fakePool.FindVolumeStub = func(_ lager.Logger, _ int, handle string) (worker.Volume, bool, error) {
+					switch handle {
+					case "some-input-handle":
+						return fakeInputVolume, true, nil
+					case "some-memoized-handle":
+						return new(workerfakes.FakeVolume), true, nil
+					default:
+						return nil, false, nil
+					}
+				}
+
+				fakeOutputMount = new(workerfakes.FakeVolume)
+				fakeOutputMount.HandleReturns("some-output-handle")
+				fakeClient.RunTaskStepReturns(worker.TaskResult{
+					ExitStatus: 0,
+					VolumeMounts: []worker.VolumeMount{
+						{
+							Volume:    fakeOutputMount,
+							MountPath: "some-artifact-root/some-output/",
+						},
+					},
+				}, nil)
+
+				fakeCacheVolume = new(workerfakes.FakeVolume)
+				fakeCacheVolume.HandleReturns("some-cache-handle")
+				fakeWorker = new(workerfakes.FakeWorker)
+				fakeWorker.FindVolumeForTaskCacheReturns(fakeCacheVolume, true, nil)
+				fakeClient.WorkerReturns(fakeWorker)
+			})
+
+			memoKey := func() string {
+				Expect(fakeTaskMemoFactory.FindCallCount()).To(Equal(1))
+				jobID, stepName, key := fakeTaskMemoFactory.FindArgsForCall(0)
+				Expect(jobID).To(Equal(stepMetadata.JobID))
+				Expect(stepName).To(Equal("some-task"))
+				return key
+			}
+
+			Context("when the task has not been memoized with the same key", func() {
+				It("runs the task", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+				})
+
+				It("keeps its outputs as task caches", func() {
+					Expect(fakeOutputMount.InitializeTaskCacheCallCount()).To(Equal(1))
+					_, jobID, stepName, cachePath, _ := fakeOutputMount.InitializeTaskCacheArgsForCall(0)
+					Expect(jobID).To(Equal(stepMetadata.JobID))
+					Expect(stepName).To(Equal("some-task"))
+					Expect(cachePath).To(Equal(".memoized-outputs/some-output"))
+
+					_, teamID, jobID, stepName, cachePath := fakeWorker.FindVolumeForTaskCacheArgsForCall(0)
+					Expect(teamID).To(Equal(stepMetadata.TeamID))
+					Expect(jobID).To(Equal(stepMetadata.JobID))
+					Expect(stepName).To(Equal("some-task"))
+					Expect(cachePath).To(Equal(".memoized-outputs/some-output"))
+				})
+
+				It("saves a memo of the outputs under the key", func() {
+					Expect(fakeTaskMemoFactory.SaveCallCount()).To(Equal(1))
+					Expect(fakeTaskMemoFactory.SaveArgsForCall(0)).To(Equal(db.TaskMemo{
+						JobID:    stepMetadata.JobID,
+						StepName: "some-task",
+						Key:      memoKey(),
+						BuildID:  stepMetadata.BuildID,
+						Outputs:  map[string]string{"some-output": "some-cache-handle"},
+					}))
+				})
+
+				Context("when the task exits nonzero", func() {
+					BeforeEach(func() {
+						fakeClient.RunTaskStepReturns(worker.TaskResult{ExitStatus: 1}, nil)
+					})
+
+					It("does not save a memo", func() {
+						Expect(fakeTaskMemoFactory.SaveCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when saving the memo fails", func() {
+					BeforeEach(func() {
+						fakeTaskMemoFactory.SaveReturns(errors.New("nope"))
+					})
+
+					It("still succeeds", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(stepOk).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when the task has been memoized with the same key", func() {
+				BeforeEach(func() {
+					fakeTaskMemoFactory.FindReturns(db.TaskMemo{
+						BuildName: "41",
+						Outputs:   map[string]string{"some-output": "some-memoized-handle"},
+					}, true, nil)
+
+					shouldRunTaskStep = false
+				})
+
+				It("succeeds without running the task", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+					Expect(fakePool.SelectWorkerCallCount()).To(BeZero())
+				})
+
+				It("registers the memoized outputs", func() {
+					art, found := repo.ArtifactFor("some-output")
+					Expect(found).To(BeTrue())
+					Expect(art.ID()).To(Equal("some-memoized-handle"))
+				})
+
+				It("says which build's outputs it reused", func() {
+					Expect(stdoutBuf).To(gbytes.Say("reusing outputs of build #41"))
+				})
+
+				It("finishes the task via the delegate", func() {
+					Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+					_, status, _, _ := fakeDelegate.FinishedArgsForCall(0)
+					Expect(status).To(Equal(exec.ExitStatus(0)))
+				})
+
+				Context("when a memoized output has been garbage collected", func() {
+					BeforeEach(func() {
+						fakeTaskMemoFactory.FindReturns(db.TaskMemo{
+							Outputs: map[string]string{"some-output": "some-collected-handle"},
+						}, true, nil)
+
+						shouldRunTaskStep = true
+					})
+
+					It("runs the task", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(fakeTaskMemoFactory.SaveCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Describe("the memo key", func() {
+				var otherKey func() string
+
+				BeforeEach(func() {
+					otherKey = func() string {
+						otherMemoFactory := new(dbfakes.FakeTaskMemoFactory)
+						_, err := exec.NewTaskStep(
+							planID,
+							*taskPlan,
+							atc.ContainerLimits{},
+							stepMetadata,
+							containerMetadata,
+							fakeStrategy,
+							fakePool,
+							fakeArtifactStreamer,
+							fakeArtifactSourcer,
+							fakeDelegateFactory,
+							fakeBuildTokenIssuer,
+							otherMemoFactory,
+						).Run(ctx, state)
+						Expect(err).ToNot(HaveOccurred())
+
+						_, _, key := otherMemoFactory.FindArgsForCall(0)
+						return key
+					}
+				})
+
+				It("is the same for the same config and inputs", func() {
+					Expect(otherKey()).To(Equal(memoKey()))
+				})
+
+				It("changes when an input's resource cache changes", func() {
+					fakeInputVolume.GetResourceCacheIDReturns(8)
+					Expect(otherKey()).ToNot(Equal(memoKey()))
+				})
+
+				It("changes when the config changes", func() {
+					taskPlan.Config.Run.Path = "pwd"
+					Expect(otherKey()).ToNot(Equal(memoKey()))
+				})
+
+				Context("when the task uses an image_resource", func() {
+					BeforeEach(func() {
+						taskPlan.Config.RootfsURI = ""
+						taskPlan.Config.ImageResource = &atc.ImageResource{
+							Type:   "registry-image",
+							Source: atc.Source{"repository": "some-image", "tag": "latest"},
+						}
+
+						fakeDelegate.FetchImageReturns(worker.ImageSpec{
+							ImageArtifactSource: new(workerfakes.FakeStreamableArtifactSource),
+							ResourceCacheID:     1,
+						}, nil)
+					})
+
+					It("is the same for the same version of the image", func() {
+						Expect(otherKey()).To(Equal(memoKey()))
+					})
+
+					It("changes when a new version of the image is fetched", func() {
+						fakeDelegate.FetchImageReturns(worker.ImageSpec{
+							ImageArtifactSource: new(workerfakes.FakeStreamableArtifactSource),
+							ResourceCacheID:     2,
+						}, nil)
+
+						Expect(otherKey()).ToNot(Equal(memoKey()))
+					})
+				})
+			})
+
+			Context("when the task's rootfs_uri is a docker image not pinned to a digest", func() {
+				BeforeEach(func() {
+					taskPlan.Config.RootfsURI = "docker:///some-image:latest"
+				})
+
+				It("runs the task without memoizing it", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(fakeTaskMemoFactory.FindCallCount()).To(BeZero())
+					Expect(fakeTaskMemoFactory.SaveCallCount()).To(BeZero())
+				})
+
+				It("warns that the task is not memoized", func() {
+					Expect(stderrBuf).To(gbytes.Say("not memoizing the task"))
+				})
+			})
+
+			Context("when the task's rootfs_uri is pinned to a digest", func() {
+				BeforeEach(func() {
+					taskPlan.Config.RootfsURI = "docker:///some-image@sha256:some-digest"
+				})
+
+				It("memoizes the task", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(fakeTaskMemoFactory.SaveCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the task does not belong to a job (one-off build)", func() {
+				BeforeEach(func() {
+					stepMetadata.JobID = 0
+				})
+
+				It("runs the task without memoizing it", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(fakeTaskMemoFactory.FindCallCount()).To(BeZero())
+					Expect(fakeTaskMemoFactory.SaveCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when output is remapped", func() {
 			var (
 				fakeMountPath string = "some-artifact-root/generic-remapped-output/"
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
 )
 
 // TimeoutStep applies a fixed timeout to a step's Run.
@@ -11,6 +13,10 @@ type TimeoutStep struct {
 	step     Step
 	duration string
 	timedOut bool
+
+	softDuration    string
+	onSoftTimeout   Step
+	delegateFactory BuildStepDelegateFactory
 }
 
 // Timeout constructs a TimeoutStep factory.
@@ -22,6 +28,20 @@ func Timeout(step Step, duration string) *TimeoutStep {
 	}
 }
 
+// SoftTimeout constructs a TimeoutStep which, once the nested step has run for
+// longer than the soft duration, emits a warning through the delegate and runs
+// the hook alongside it. The duration is optional, and if empty the nested
+// step is never interrupted. The hook may be nil.
+func SoftTimeout(step Step, duration string, softDuration string, onSoftTimeout Step, delegateFactory BuildStepDelegateFactory) *TimeoutStep {
+	return &TimeoutStep{
+		step:            step,
+		duration:        duration,
+		softDuration:    softDuration,
+		onSoftTimeout:   onSoftTimeout,
+		delegateFactory: delegateFactory,
+	}
+}
+
 // Run parses the timeout duration and invokes the nested step.
 //
 // If the nested step takes longer than the duration, it is sent the Interrupt
@@ -30,18 +50,74 @@ func Timeout(step Step, duration string) *TimeoutStep {
 //
 // The result of the nested step's Run is returned.
 func (ts *TimeoutStep) Run(ctx context.Context, state RunState) (bool, error) {
-	parsedDuration, err := time.ParseDuration(ts.duration)
-	if err != nil {
-		return false, err
+	timeoutCtx := ctx
+	if ts.duration != "" || ts.softDuration == "" {
+		parsedDuration, err := time.ParseDuration(ts.duration)
+		if err != nil {
+			return false, err
+		}
+
+		var cancel context.CancelFunc
+		timeoutCtx, cancel = context.WithTimeout(ctx, parsedDuration)
+		defer cancel()
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(ctx, parsedDuration)
-	defer cancel()
+	var ok bool
+	var err error
+	if ts.softDuration == "" {
+		ok, err = ts.step.Run(timeoutCtx, state)
+	} else {
+		ok, err = ts.runWithSoftTimeout(timeoutCtx, state)
+	}
 
-	ok, err := ts.step.Run(timeoutCtx, state)
 	if errors.Is(err, context.DeadlineExceeded) {
 		return false, nil
 	}
 
 	return ok, err
 }
+
+// runWithSoftTimeout runs the nested step, running the hook alongside it if
+// the soft timeout elapses first. It waits for the hook to finish before
+// returning, and the hook's error is only returned if the step succeeded.
+func (ts *TimeoutStep) runWithSoftTimeout(ctx context.Context, state RunState) (bool, error) {
+	softDuration, err := time.ParseDuration(ts.softDuration)
+	if err != nil {
+		return false, err
+	}
+
+	stepDone := make(chan struct{})
+	hookErr := make(chan error, 1)
+
+	go func() {
+		timer := time.NewTimer(softDuration)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-stepDone:
+			hookErr <- nil
+			return
+		}
+
+		logger := lagerctx.FromContext(ctx)
+		ts.delegateFactory.BuildStepDelegate(state).SoftTimedOut(logger, softDuration)
+
+		if ts.onSoftTimeout == nil {
+			hookErr <- nil
+			return
+		}
+
+		_, err := ts.onSoftTimeout.Run(ctx, state)
+		hookErr <- err
+	}()
+
+	ok, err := ts.step.Run(ctx, state)
+	close(stepDone)
+
+	if herr := <-hookErr; err == nil && herr != nil && !errors.Is(herr, context.DeadlineExceeded) {
+		return false, herr
+	}
+
+	return ok, err
+}
@@ -129,4 +129,131 @@ var _ = Describe("Timeout Step", func() {
 			Expect(fakeStep.RunCallCount()).To(BeZero())
 		})
 	})
+	Describe("SoftTimeout", func() {
+		var (
+			fakeHook            *execfakes.FakeStep
+			fakeDelegate        *execfakes.FakeBuildStepDelegate
+			fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+			softTimeoutDuration string
+			hook                Step
+		)
+
+		BeforeEach(func() {
+			fakeHook = new(execfakes.FakeStep)
+			hook = fakeHook
+
+			fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+			fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+			fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+			timeoutDuration = ""
+			softTimeoutDuration = "10ms"
+		})
+
+		JustBeforeEach(func() {
+			step = SoftTimeout(fakeStep, timeoutDuration, softTimeoutDuration, hook, fakeDelegateFactory)
+			stepOk, stepErr = step.Run(ctx, state)
+		})
+
+		Context("when the step finishes before the soft timeout", func() {
+			BeforeEach(func() {
+				softTimeoutDuration = "1h"
+				fakeStep.RunReturns(true, nil)
+			})
+
+			It("succeeds without a deadline", func() {
+				Expect(stepOk).To(BeTrue())
+				Expect(stepErr).ToNot(HaveOccurred())
+
+				runCtx, _ := fakeStep.RunArgsForCall(0)
+				_, hasDeadline := runCtx.Deadline()
+				Expect(hasDeadline).To(BeFalse())
+			})
+
+			It("neither warns nor runs the hook", func() {
+				Expect(fakeDelegate.SoftTimedOutCallCount()).To(BeZero())
+				Expect(fakeHook.RunCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the step runs for longer than the soft timeout", func() {
+			BeforeEach(func() {
+				fakeStep.RunStub = func(context.Context, RunState) (bool, error) {
+					Eventually(fakeHook.RunCallCount).Should(Equal(1))
+					return true, nil
+				}
+			})
+
+			It("warns through the delegate", func() {
+				Expect(fakeDelegate.SoftTimedOutCallCount()).To(Equal(1))
+				_, duration := fakeDelegate.SoftTimedOutArgsForCall(0)
+				Expect(duration).To(Equal(10 * time.Millisecond))
+			})
+
+			It("runs the hook without interrupting the step", func() {
+				Expect(fakeHook.RunCallCount()).To(Equal(1))
+				Expect(stepOk).To(BeTrue())
+				Expect(stepErr).ToNot(HaveOccurred())
+			})
+
+			Context("when the hook errors", func() {
+				someError := errors.New("some error")
+
+				BeforeEach(func() {
+					fakeHook.RunReturns(false, someError)
+				})
+
+				It("returns the error", func() {
+					Expect(stepErr).To(Equal(someError))
+				})
+			})
+
+			Context("when there is no hook", func() {
+				BeforeEach(func() {
+					hook = nil
+					fakeStep.RunStub = func(context.Context, RunState) (bool, error) {
+						Eventually(fakeDelegate.SoftTimedOutCallCount).Should(Equal(1))
+						return true, nil
+					}
+				})
+
+				It("only warns", func() {
+					Expect(fakeDelegate.SoftTimedOutCallCount()).To(Equal(1))
+					Expect(stepOk).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when a timeout is also given", func() {
+			BeforeEach(func() {
+				timeoutDuration = "1h"
+				softTimeoutDuration = "30m"
+				fakeStep.RunReturns(false, context.DeadlineExceeded)
+			})
+
+			It("runs the step with a deadline", func() {
+				runCtx, _ := fakeStep.RunArgsForCall(0)
+				deadline, ok := runCtx.Deadline()
+				Expect(ok).To(BeTrue())
+				Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Hour), 10*time.Second))
+			})
+
+			It("is not successful once the timeout is exceeded", func() {
+				Expect(stepOk).To(BeFalse())
+				Expect(stepErr).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when the soft duration is invalid", func() {
+			BeforeEach(func() {
+				softTimeoutDuration = "nope"
+			})
+
+			It("errors without running the step", func() {
+				Expect(stepErr).To(HaveOccurred())
+				Expect(fakeStep.RunCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -7,26 +7,44 @@ import (
 
 // TryStep wraps another step, ignores its errors, and always succeeds.
 type TryStep struct {
-	step    Step
-	aborted bool
+	step     Step
+	errorVar string
+	aborted  bool
 }
 
-// Try constructs a TryStep.
-func Try(step Step) Step {
+// Try constructs a TryStep. If errorVar is given, the nested step's failure is
+// recorded in a local var of that name.
+func Try(step Step, errorVar string) Step {
 	return &TryStep{
-		step:    step,
-		aborted: false,
+		step:     step,
+		errorVar: errorVar,
+		aborted:  false,
 	}
 }
 
 // Run runs the nested step, and always returns nil, ignoring the nested step's
 // error.
+//
+// The error's message, or "failed" if the nested step failed without an
+// error, is stored in the error var. The var is empty if the nested step
+// succeeded.
 func (ts *TryStep) Run(ctx context.Context, state RunState) (bool, error) {
-	_, err := ts.step.Run(ctx, state)
+	ok, err := ts.step.Run(ctx, state)
 	if errors.Is(err, context.Canceled) {
 		// propagate aborts errors, but not timeouts
 		return false, err
 	}
 
+	if ts.errorVar != "" {
+		var message string
+		if err != nil {
+			message = err.Error()
+		} else if !ok {
+			message = "failed"
+		}
+
+		state.AddLocalVar(ts.errorVar, message, false)
+	}
+
 	return true, nil
 }
@@ -36,7 +36,7 @@ var _ = Describe("Try Step", func() {
 		state = new(execfakes.FakeRunState)
 		state.ArtifactRepositoryReturns(repo)
 
-		step = Try(runStep)
+		step = Try(runStep, "")
 	})
 
 	JustBeforeEach(func() {
@@ -78,5 +78,67 @@ var _ = Describe("Try Step", func() {
 			Expect(stepErr).NotTo(HaveOccurred())
 			Expect(stepOk).To(BeTrue())
 		})
+
+		It("does not record it", func() {
+			Expect(state.AddLocalVarCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when an error var is given", func() {
+		BeforeEach(func() {
+			step = Try(runStep, "try_error")
+		})
+
+		Context("when the inner step succeeds", func() {
+			BeforeEach(func() {
+				runStep.RunReturns(true, nil)
+			})
+
+			It("leaves the var empty", func() {
+				Expect(state.AddLocalVarCallCount()).To(Equal(1))
+				name, val, redact := state.AddLocalVarArgsForCall(0)
+				Expect(name).To(Equal("try_error"))
+				Expect(val).To(Equal(""))
+				Expect(redact).To(BeFalse())
+			})
+		})
+
+		Context("when the inner step fails", func() {
+			BeforeEach(func() {
+				runStep.RunReturns(false, nil)
+			})
+
+			It("records the failure", func() {
+				Expect(state.AddLocalVarCallCount()).To(Equal(1))
+				_, val, _ := state.AddLocalVarArgsForCall(0)
+				Expect(val).To(Equal("failed"))
+			})
+		})
+
+		Context("when the inner step errors", func() {
+			BeforeEach(func() {
+				runStep.RunReturns(false, errors.New("some error"))
+			})
+
+			It("records the error and succeeds", func() {
+				Expect(stepErr).NotTo(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+
+				Expect(state.AddLocalVarCallCount()).To(Equal(1))
+				_, val, _ := state.AddLocalVarArgsForCall(0)
+				Expect(val).To(Equal("some error"))
+			})
+		})
+
+		Context("when interrupted", func() {
+			BeforeEach(func() {
+				runStep.RunReturns(false, context.Canceled)
+			})
+
+			It("does not record anything", func() {
+				Expect(stepErr).To(Equal(context.Canceled))
+				Expect(state.AddLocalVarCallCount()).To(BeZero())
+			})
+		})
 	})
 })
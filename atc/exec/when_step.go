@@ -0,0 +1,136 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+)
+
+// WhenStep runs its step only if its condition holds. A skipped step
+// succeeds, so that the build carries on.
+type WhenStep struct {
+	plan            atc.WhenPlan
+	step            Step
+	delegateFactory BuildStepDelegateFactory
+	metadata        StepMetadata
+}
+
+func NewWhenStep(
+	plan atc.WhenPlan,
+	step Step,
+	delegateFactory BuildStepDelegateFactory,
+	metadata StepMetadata,
+) WhenStep {
+	return WhenStep{
+		plan:            plan,
+		step:            step,
+		delegateFactory: delegateFactory,
+		metadata:        metadata,
+	}
+}
+
+func (step WhenStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("when-step", lager.Data{
+		"condition": step.plan.Condition,
+	})
+
+	condition, err := atc.ParseWhenCondition(step.plan.Condition)
+	if err != nil {
+		return false, fmt.Errorf("parse condition: %w", err)
+	}
+
+	holds, err := condition.Evaluate(func(ref vars.Reference) (interface{}, error) {
+		return step.resolve(state, ref)
+	})
+	if err != nil {
+		return false, fmt.Errorf("evaluate condition: %w", err)
+	}
+
+	if !holds {
+		logger.Debug("skipped")
+
+		step.delegateFactory.BuildStepDelegate(state).Skipped(logger, step.plan.Condition)
+
+		return true, nil
+	}
+
+	return step.step.Run(ctx, state)
+}
+
+func (step WhenStep) resolve(state RunState, ref vars.Reference) (interface{}, error) {
+	if ref.Source == "." {
+		val, found, err := state.Get(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, nil
+		}
+
+		return val, nil
+	}
+
+	switch ref.Path {
+	case "build":
+		return step.buildField(ref.Fields), nil
+	case "steps":
+		return step.stepStatus(state, ref.Fields[0]), nil
+	default:
+		return nil, fmt.Errorf("unknown reference: %s", ref.Path)
+	}
+}
+
+func (step WhenStep) buildField(fields []string) interface{} {
+	switch fields[0] {
+	case "team":
+		return step.metadata.TeamName
+	case "pipeline":
+		return step.metadata.PipelineName
+	case "job":
+		return step.metadata.JobName
+	case "name":
+		return step.metadata.BuildName
+	case "created_by":
+		return step.metadata.CreatedBy
+	case "trigger":
+		return string(step.metadata.Trigger)
+	case "attempt":
+		return step.metadata.Attempt()
+	case "total_attempts":
+		return step.metadata.TotalAttempt()
+	case "instance_vars":
+		var val interface{} = step.metadata.PipelineInstanceVars
+		for _, field := range fields[1:] {
+			m, ok := val.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+
+			val = m[field]
+		}
+
+		return val
+	default:
+		return nil
+	}
+}
+
+// stepStatus returns the status of the last of the step's plans which
+// finished, or nil if none of them did.
+func (step WhenStep) stepStatus(state RunState, name string) interface{} {
+	ids := step.plan.Steps[name]
+	for i := len(ids) - 1; i >= 0; i-- {
+		status, found := state.StepStatus(ids[i])
+		if found {
+			return string(status)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+package exec_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WhenStep", func() {
+	var (
+		ctx context.Context
+
+		fakeStep            *execfakes.FakeStep
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+		state               *execfakes.FakeRunState
+
+		stepMetadata exec.StepMetadata
+		whenPlan     atc.WhenPlan
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("when-step-test"))
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeStep.RunReturns(false, nil)
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		state = new(execfakes.FakeRunState)
+
+		stepMetadata = exec.StepMetadata{
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			PipelineInstanceVars: map[string]interface{}{
+				"branch": "main",
+			},
+			JobName:   "some-job",
+			BuildName: "42",
+		}
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewWhenStep(whenPlan, fakeStep, fakeDelegateFactory, stepMetadata)
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	itRuns := func() {
+		It("runs the step and returns its result", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+			Expect(stepOk).To(BeFalse())
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+	}
+
+	itSkips := func() {
+		It("skips the step without failing", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("emits a skipped event", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(Equal(1))
+			_, condition := fakeDelegate.SkippedArgsForCall(0)
+			Expect(condition).To(Equal(whenPlan.Condition))
+		})
+	}
+
+	Context("when the condition refers to local vars", func() {
+		BeforeEach(func() {
+			whenPlan = atc.WhenPlan{Condition: `.:config.env == "prod"`}
+
+			state.GetStub = func(ref vars.Reference) (interface{}, bool, error) {
+				if ref.Source == "." && ref.Path == "config" {
+					return "prod", true, nil
+				}
+
+				return nil, false, nil
+			}
+		})
+
+		It("looks up the var", func() {
+			Expect(state.GetCallCount()).To(Equal(1))
+			Expect(state.GetArgsForCall(0)).To(Equal(vars.Reference{
+				Source: ".",
+				Path:   "config",
+				Fields: []string{"env"},
+			}))
+		})
+
+		itRuns()
+
+		Context("when the var is not found", func() {
+			BeforeEach(func() {
+				state.GetReturns(nil, false, nil)
+				state.GetStub = nil
+			})
+
+			itSkips()
+		})
+	})
+
+	Context("when the condition refers to the build", func() {
+		BeforeEach(func() {
+			whenPlan = atc.WhenPlan{
+				Condition: `build.team == "some-team" && build.pipeline == "some-pipeline" && build.job == "some-job" && build.name == "42" && build.instance_vars.branch == "main"`,
+			}
+		})
+
+		itRuns()
+
+		Context("when the build was triggered automatically", func() {
+			BeforeEach(func() {
+				stepMetadata.Trigger = atc.BuildTriggerAutomatic
+				whenPlan = atc.WhenPlan{Condition: `build.trigger == "manual"`}
+			})
+
+			itSkips()
+		})
+
+		Context("when the build was triggered manually", func() {
+			BeforeEach(func() {
+				stepMetadata.Trigger = atc.BuildTriggerManual
+				stepMetadata.CreatedBy = "some-user"
+				whenPlan = atc.WhenPlan{Condition: `build.trigger == "manual" && build.created_by == "some-user"`}
+			})
+
+			itRuns()
+		})
+
+		Context("when the build was triggered by the job's schedule", func() {
+			BeforeEach(func() {
+				stepMetadata.Trigger = atc.BuildTriggerSchedule
+				whenPlan = atc.WhenPlan{Condition: `build.trigger == "schedule"`}
+			})
+
+			itRuns()
+		})
+
+		Context("when the build is not being retried", func() {
+			BeforeEach(func() {
+				whenPlan = atc.WhenPlan{Condition: `build.attempt == 1 && build.total_attempts == 1`}
+			})
+
+			itRuns()
+		})
+
+		Context("when the build is on its last attempt", func() {
+			BeforeEach(func() {
+				stepMetadata.Attempts = []int{2, 3}
+				stepMetadata.TotalAttempts = []int{2, 3}
+				whenPlan = atc.WhenPlan{Condition: `build.attempt == build.total_attempts`}
+			})
+
+			itRuns()
+		})
+
+		Context("when the build is not on its last attempt", func() {
+			BeforeEach(func() {
+				stepMetadata.Attempts = []int{2, 1}
+				stepMetadata.TotalAttempts = []int{2, 3}
+				whenPlan = atc.WhenPlan{Condition: `build.attempt == build.total_attempts`}
+			})
+
+			itSkips()
+		})
+	})
+
+	Context("when the condition refers to steps", func() {
+		BeforeEach(func() {
+			whenPlan = atc.WhenPlan{
+				Condition: `steps.unit == "failed"`,
+				Steps: map[string][]atc.PlanID{
+					"unit": {"1", "2", "3"},
+				},
+			}
+
+			state.StepStatusStub = func(id atc.PlanID) (atc.BuildStatus, bool) {
+				switch id {
+				case "1":
+					return atc.StatusSucceeded, true
+				case "2":
+					return atc.StatusFailed, true
+				default:
+					return "", false
+				}
+			}
+		})
+
+		It("uses the status of the last of its plans which finished", func() {
+			Expect(state.StepStatusCallCount()).To(Equal(2))
+			Expect(state.StepStatusArgsForCall(0)).To(Equal(atc.PlanID("3")))
+			Expect(state.StepStatusArgsForCall(1)).To(Equal(atc.PlanID("2")))
+		})
+
+		itRuns()
+
+		Context("when none of its plans finished", func() {
+			BeforeEach(func() {
+				state.StepStatusStub = nil
+				state.StepStatusReturns("", false)
+
+				whenPlan.Condition = "steps.unit"
+			})
+
+			itSkips()
+		})
+	})
+
+	Context("when the condition is invalid", func() {
+		BeforeEach(func() {
+			whenPlan = atc.WhenPlan{Condition: "build.foo"}
+		})
+
+		It("errors without running the step", func() {
+			Expect(stepErr).To(MatchError(ContainSubstring("unknown build field 'foo'")))
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+	})
+})
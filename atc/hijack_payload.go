@@ -10,6 +10,10 @@ type HijackProcessSpec struct {
 	User       string `json:"user"`
 
 	TTY *HijackTTYSpec `json:"tty"`
+
+	// Session names a process which outlives the connection, so that clients
+	// can reattach to it. The rest of the spec is ignored when reattaching.
+	Session string `json:"session,omitempty"`
 }
 
 type HijackTTYSpec struct {
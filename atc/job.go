@@ -13,17 +13,39 @@ type Job struct {
 	Paused       bool `json:"paused,omitempty"`
 	HasNewInputs bool `json:"has_new_inputs,omitempty"`
 
+	PausedBy    string `json:"paused_by,omitempty"`
+	PausedAt    int64  `json:"paused_at,omitempty"`
+	PauseReason string `json:"pause_reason,omitempty"`
+
 	Groups []string `json:"groups,omitempty"`
 
 	FirstLoggedBuildID   int  `json:"first_logged_build_id,omitempty"`
 	DisableManualTrigger bool `json:"disable_manual_trigger,omitempty"`
 
+	// NextScheduledRun is the Unix timestamp of the next build the job's
+	// schedule will trigger.
+	NextScheduledRun int64 `json:"next_scheduled_run,omitempty"`
+
 	NextBuild       *Build `json:"next_build"`
 	FinishedBuild   *Build `json:"finished_build"`
 	TransitionBuild *Build `json:"transition_build,omitempty"`
 
 	Inputs  []JobInput  `json:"inputs,omitempty"`
 	Outputs []JobOutput `json:"outputs,omitempty"`
+
+	Statistics *JobStatistics `json:"statistics,omitempty"`
+}
+
+// JobStatistics summarizes a job's most recent completed builds. Durations
+// are in seconds and LastSucceeded is a Unix timestamp.
+type JobStatistics struct {
+	Builds      int     `json:"builds"`
+	SuccessRate float64 `json:"success_rate"`
+	DurationP50 int64   `json:"duration_p50"`
+	DurationP95 int64   `json:"duration_p95"`
+
+	LastSucceeded        int64 `json:"last_succeeded,omitempty"`
+	TimeSinceLastSuccess int64 `json:"time_since_last_success,omitempty"`
 }
 
 type JobInput struct {
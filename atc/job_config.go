@@ -1,5 +1,15 @@
 package atc
 
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/concourse/concourse/atc/cron"
+)
+
 type JobConfig struct {
 	Name    string `json:"name"`
 	OldName string `json:"old_name,omitempty"`
@@ -12,8 +22,27 @@ type JobConfig struct {
 	RawMaxInFlight       int      `json:"max_in_flight,omitempty"`
 	BuildLogsToRetain    int      `json:"build_logs_to_retain,omitempty"`
 
+	// Priority orders the job's pending builds, and the steps waiting for a
+	// worker, ahead of those of lower priority jobs.
+	Priority JobPriority `json:"priority,omitempty"`
+
+	// Schedule creates builds of the job at the times it matches.
+	Schedule *JobScheduleConfig `json:"schedule,omitempty"`
+
+	// After creates a build of the job whenever a build of any of the given
+	// jobs finishes.
+	After []JobAfterConfig `json:"after,omitempty"`
+
 	BuildLogRetention *BuildLogRetention `json:"build_log_retention,omitempty"`
 
+	// SerialSemaphore is held while the job's plan runs, not including its
+	// hooks.
+	SerialSemaphore *SemaphoreConfig `json:"serial_semaphore,omitempty"`
+
+	// Defaults are applied to the job's steps, taking precedence over the
+	// pipeline's defaults.
+	Defaults *StepDefaults `json:"defaults,omitempty"`
+
 	OnSuccess *Step `json:"on_success,omitempty"`
 	OnFailure *Step `json:"on_failure,omitempty"`
 	OnAbort   *Step `json:"on_abort,omitempty"`
@@ -38,6 +67,13 @@ func (config JobConfig) StepConfig() StepConfig {
 		Steps: config.PlanSequence,
 	}
 
+	if config.SerialSemaphore != nil {
+		step = &SemaphoreStep{
+			Step:      step,
+			Semaphore: *config.SerialSemaphore,
+		}
+	}
+
 	if config.OnSuccess != nil {
 		step = &OnSuccessStep{
 			Step: step,
@@ -128,3 +164,116 @@ func (config JobConfig) Outputs() []JobOutput {
 
 	return outputs
 }
+
+// JobPriority is configured either as one of the named priorities or as a
+// number. Higher priorities go first.
+type JobPriority int
+
+const (
+	JobPriorityLow    JobPriority = -1
+	JobPriorityNormal JobPriority = 0
+	JobPriorityHigh   JobPriority = 1
+)
+
+var jobPriorityNames = map[string]JobPriority{
+	"low":    JobPriorityLow,
+	"normal": JobPriorityNormal,
+	"high":   JobPriorityHigh,
+}
+
+func (priority *JobPriority) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte{'"'}) {
+		var name string
+		err := json.Unmarshal(data, &name)
+		if err != nil {
+			return err
+		}
+
+		named, found := jobPriorityNames[name]
+		if !found {
+			return fmt.Errorf("invalid priority %q", name)
+		}
+
+		*priority = named
+		return nil
+	}
+
+	var number int
+	err := json.Unmarshal(data, &number)
+	if err != nil {
+		return err
+	}
+
+	*priority = JobPriority(number)
+	return nil
+}
+
+func (priority JobPriority) MarshalJSON() ([]byte, error) {
+	for name, named := range jobPriorityNames {
+		if priority == named {
+			return json.Marshal(name)
+		}
+	}
+
+	return json.Marshal(int(priority))
+}
+
+// JobScheduleConfig matches the times described by a cron expression, in the
+// given location, or in UTC if none is given.
+type JobScheduleConfig struct {
+	Cron     string `json:"cron"`
+	Location string `json:"location,omitempty"`
+}
+
+var ErrScheduleNeverMatches = errors.New("schedule never matches")
+
+// Next returns the first time after the given time which matches the
+// schedule.
+func (config JobScheduleConfig) Next(after time.Time) (time.Time, error) {
+	schedule, err := cron.Parse(config.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	location := time.UTC
+	if config.Location != "" {
+		location, err = time.LoadLocation(config.Location)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	next := schedule.Next(after.In(location))
+	if next.IsZero() {
+		return time.Time{}, ErrScheduleNeverMatches
+	}
+
+	return next, nil
+}
+
+// JobAfterConfig names a job whose finished builds trigger another job. It
+// can be configured as just the job's name.
+type JobAfterConfig struct {
+	Job string `json:"job"`
+
+	// SucceededOnly only triggers on the job's succeeded builds, rather than
+	// on any finished build.
+	SucceededOnly bool `json:"succeeded_only,omitempty"`
+}
+
+func (config *JobAfterConfig) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte{'"'}) {
+		return json.Unmarshal(data, &config.Job)
+	}
+
+	type target JobAfterConfig
+
+	var t target
+	err := json.Unmarshal(data, &t)
+	if err != nil {
+		return err
+	}
+
+	*config = JobAfterConfig(t)
+	return nil
+}
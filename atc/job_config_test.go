@@ -1,6 +1,9 @@
 package atc_test
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/concourse/concourse/atc"
 
 	. "github.com/onsi/ginkgo"
@@ -671,4 +674,99 @@ var _ = Describe("JobConfig", func() {
 			})
 		})
 	})
+
+	Describe("StepConfig", func() {
+		It("holds the serial_semaphore around the plan but not the hooks", func() {
+			jobConfig := atc.JobConfig{
+				SerialSemaphore: &atc.SemaphoreConfig{Name: "some-semaphore"},
+				PlanSequence: []atc.Step{
+					{Config: &atc.GetStep{Name: "a"}},
+				},
+				Ensure: &atc.Step{
+					Config: &atc.PutStep{Name: "b"},
+				},
+			}
+
+			Expect(jobConfig.StepConfig()).To(Equal(&atc.EnsureStep{
+				Step: &atc.SemaphoreStep{
+					Step: &atc.DoStep{
+						Steps: []atc.Step{
+							{Config: &atc.GetStep{Name: "a"}},
+						},
+					},
+					Semaphore: atc.SemaphoreConfig{Name: "some-semaphore"},
+				},
+				Hook: atc.Step{
+					Config: &atc.PutStep{Name: "b"},
+				},
+			}))
+		})
+	})
+
+	Describe("Priority", func() {
+		It("can be configured by name or as a number", func() {
+			var jobConfig atc.JobConfig
+			err := json.Unmarshal([]byte(`{"name":"some-job","priority":"high"}`), &jobConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jobConfig.Priority).To(Equal(atc.JobPriorityHigh))
+
+			err = json.Unmarshal([]byte(`{"name":"some-job","priority":-5}`), &jobConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jobConfig.Priority).To(Equal(atc.JobPriority(-5)))
+		})
+
+		It("errors for an unknown name", func() {
+			var jobConfig atc.JobConfig
+			err := json.Unmarshal([]byte(`{"name":"some-job","priority":"urgent"}`), &jobConfig)
+			Expect(err).To(MatchError(`invalid priority "urgent"`))
+		})
+
+		It("marshals named priorities by name", func() {
+			payload, err := json.Marshal(atc.JobConfig{Name: "some-job", Priority: atc.JobPriorityLow})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(payload).To(MatchJSON(`{"name":"some-job","priority":"low","plan":null}`))
+
+			payload, err = json.Marshal(atc.JobConfig{Name: "some-job", Priority: 7})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(payload).To(MatchJSON(`{"name":"some-job","priority":7,"plan":null}`))
+		})
+	})
+
+	Describe("After", func() {
+		It("can be configured by job name or in full", func() {
+			var jobConfig atc.JobConfig
+			err := json.Unmarshal([]byte(`{"name":"some-job","after":["job-a",{"job":"job-b","succeeded_only":true}]}`), &jobConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jobConfig.After).To(Equal([]atc.JobAfterConfig{
+				{Job: "job-a"},
+				{Job: "job-b", SucceededOnly: true},
+			}))
+		})
+	})
+
+	Describe("JobScheduleConfig", func() {
+		after := time.Date(2021, 6, 22, 10, 30, 0, 0, time.UTC)
+
+		It("returns the next matching time in UTC by default", func() {
+			next, err := atc.JobScheduleConfig{Cron: "0 9 * * *"}.Next(after)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next).To(BeTemporally("==", time.Date(2021, 6, 23, 9, 0, 0, 0, time.UTC)))
+		})
+
+		It("returns the next matching time in the configured location", func() {
+			next, err := atc.JobScheduleConfig{Cron: "0 9 * * *", Location: "America/New_York"}.Next(after)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next).To(BeTemporally("==", time.Date(2021, 6, 22, 13, 0, 0, 0, time.UTC)))
+		})
+
+		It("errors for an unknown location", func() {
+			_, err := atc.JobScheduleConfig{Cron: "0 9 * * *", Location: "Nowhere/Special"}.Next(after)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("errors when the schedule never matches", func() {
+			_, err := atc.JobScheduleConfig{Cron: "0 0 30 2 *"}.Next(after)
+			Expect(err).To(Equal(atc.ErrScheduleNeverMatches))
+		})
+	})
 })
@@ -2,6 +2,7 @@ package metric
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -26,6 +27,20 @@ type Emitter interface {
 	Emit(lager.Logger, Event)
 }
 
+// JobLabels identify a job in the events about it.
+type JobLabels struct {
+	TeamName     string
+	PipelineName string
+	JobName      string
+}
+
+// JobPruner is implemented by emitters which keep a series for each job, so
+// that the series of jobs which have since been removed, along with their
+// pipeline or team, can be dropped.
+type JobPruner interface {
+	PruneJobs(active map[JobLabels]bool)
+}
+
 //counterfeiter:generate . EmitterFactory
 type EmitterFactory interface {
 	Description() string
@@ -43,6 +58,11 @@ type Monitor struct {
 	Databases       []db.Conn
 	DatabaseQueries Counter
 
+	// JobFactory lists the active jobs for emitters which keep a series for
+	// each job. See JobPruner.
+	JobFactory   db.JobFactory
+	lastJobPrune time.Time
+
 	ContainersCreated Counter
 	VolumesCreated    Counter
 
@@ -81,7 +101,10 @@ type Monitor struct {
 	ConcurrentRequests         map[string]*Gauge
 	ConcurrentRequestsLimitHit map[string]*Counter
 
-	VolumesStreamed Counter
+	RateLimitHit map[string]*Counter
+
+	VolumesStreamed     Counter
+	VolumesStreamedSize Counter
 
 	GetStepCacheHits       Counter
 	StreamedResourceCaches Counter
@@ -94,6 +117,7 @@ func NewMonitor() *Monitor {
 		StepsWaiting:               map[StepsWaitingLabels]*Gauge{},
 		ConcurrentRequests:         map[string]*Gauge{},
 		ConcurrentRequestsLimitHit: map[string]*Counter{},
+		RateLimitHit:               map[string]*Counter{},
 	}
 }
 
@@ -110,6 +134,12 @@ func (m *Monitor) WireEmitters(group *flags.Group) {
 	}
 }
 
+// IsConfigured reports whether an emitter has been initialized, allowing
+// callers to skip gathering data for events nobody will receive.
+func (m *Monitor) IsConfigured() bool {
+	return m.emitter != nil
+}
+
 type eventEmission struct {
 	event  Event
 	logger lager.Logger
@@ -161,6 +191,17 @@ func (m *Monitor) Initialize(logger lager.Logger, host string, attributes map[st
 	return nil
 }
 
+// Close stops the emitter, for emitters which have to be stopped, e.g. to
+// flush the metrics they have buffered.
+func (m *Monitor) Close() error {
+	closer, ok := m.emitter.(io.Closer)
+	if !ok {
+		return nil
+	}
+
+	return closer.Close()
+}
+
 func (m *Monitor) emit(logger lager.Logger, event Event) {
 	if m.emitter == nil {
 		return
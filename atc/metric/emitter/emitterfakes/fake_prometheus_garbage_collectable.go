@@ -9,6 +9,16 @@ import (
 )
 
 type FakePrometheusGarbageCollectable struct {
+	WorkerContainerScratchStub        func() *prometheus.GaugeVec
+	workerContainerScratchMutex       sync.RWMutex
+	workerContainerScratchArgsForCall []struct {
+	}
+	workerContainerScratchReturns struct {
+		result1 *prometheus.GaugeVec
+	}
+	workerContainerScratchReturnsOnCall map[int]struct {
+		result1 *prometheus.GaugeVec
+	}
 	WorkerContainersStub        func() *prometheus.GaugeVec
 	workerContainersMutex       sync.RWMutex
 	workerContainersArgsForCall []struct {
@@ -29,6 +39,26 @@ type FakePrometheusGarbageCollectable struct {
 	workerContainersLabelsReturnsOnCall map[int]struct {
 		result1 map[string]map[string]prometheus.Labels
 	}
+	WorkerDiskFreeStub        func() *prometheus.GaugeVec
+	workerDiskFreeMutex       sync.RWMutex
+	workerDiskFreeArgsForCall []struct {
+	}
+	workerDiskFreeReturns struct {
+		result1 *prometheus.GaugeVec
+	}
+	workerDiskFreeReturnsOnCall map[int]struct {
+		result1 *prometheus.GaugeVec
+	}
+	WorkerDiskUsedStub        func() *prometheus.GaugeVec
+	workerDiskUsedMutex       sync.RWMutex
+	workerDiskUsedArgsForCall []struct {
+	}
+	workerDiskUsedReturns struct {
+		result1 *prometheus.GaugeVec
+	}
+	workerDiskUsedReturnsOnCall map[int]struct {
+		result1 *prometheus.GaugeVec
+	}
 	WorkerTasksStub        func() *prometheus.GaugeVec
 	workerTasksMutex       sync.RWMutex
 	workerTasksArgsForCall []struct {
@@ -49,6 +79,16 @@ type FakePrometheusGarbageCollectable struct {
 	workerTasksLabelsReturnsOnCall map[int]struct {
 		result1 map[string]map[string]prometheus.Labels
 	}
+	WorkerUsageLabelsStub        func() map[string]map[string]prometheus.Labels
+	workerUsageLabelsMutex       sync.RWMutex
+	workerUsageLabelsArgsForCall []struct {
+	}
+	workerUsageLabelsReturns struct {
+		result1 map[string]map[string]prometheus.Labels
+	}
+	workerUsageLabelsReturnsOnCall map[int]struct {
+		result1 map[string]map[string]prometheus.Labels
+	}
 	WorkerVolumesStub        func() *prometheus.GaugeVec
 	workerVolumesMutex       sync.RWMutex
 	workerVolumesArgsForCall []struct {
@@ -59,6 +99,26 @@ type FakePrometheusGarbageCollectable struct {
 	workerVolumesReturnsOnCall map[int]struct {
 		result1 *prometheus.GaugeVec
 	}
+	WorkerVolumesByTypeStub        func() *prometheus.GaugeVec
+	workerVolumesByTypeMutex       sync.RWMutex
+	workerVolumesByTypeArgsForCall []struct {
+	}
+	workerVolumesByTypeReturns struct {
+		result1 *prometheus.GaugeVec
+	}
+	workerVolumesByTypeReturnsOnCall map[int]struct {
+		result1 *prometheus.GaugeVec
+	}
+	WorkerVolumesByTypeLabelsStub        func() map[string]map[string]prometheus.Labels
+	workerVolumesByTypeLabelsMutex       sync.RWMutex
+	workerVolumesByTypeLabelsArgsForCall []struct {
+	}
+	workerVolumesByTypeLabelsReturns struct {
+		result1 map[string]map[string]prometheus.Labels
+	}
+	workerVolumesByTypeLabelsReturnsOnCall map[int]struct {
+		result1 map[string]map[string]prometheus.Labels
+	}
 	WorkerVolumesLabelsStub        func() map[string]map[string]prometheus.Labels
 	workerVolumesLabelsMutex       sync.RWMutex
 	workerVolumesLabelsArgsForCall []struct {
@@ -73,6 +133,59 @@ type FakePrometheusGarbageCollectable struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakePrometheusGarbageCollectable) WorkerContainerScratch() *prometheus.GaugeVec {
+	fake.workerContainerScratchMutex.Lock()
+	ret, specificReturn := fake.workerContainerScratchReturnsOnCall[len(fake.workerContainerScratchArgsForCall)]
+	fake.workerContainerScratchArgsForCall = append(fake.workerContainerScratchArgsForCall, struct {
+	}{})
+	stub := fake.WorkerContainerScratchStub
+	fakeReturns := fake.workerContainerScratchReturns
+	fake.recordInvocation("WorkerContainerScratch", []interface{}{})
+	fake.workerContainerScratchMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerContainerScratchCallCount() int {
+	fake.workerContainerScratchMutex.RLock()
+	defer fake.workerContainerScratchMutex.RUnlock()
+	return len(fake.workerContainerScratchArgsForCall)
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerContainerScratchCalls(stub func() *prometheus.GaugeVec) {
+	fake.workerContainerScratchMutex.Lock()
+	defer fake.workerContainerScratchMutex.Unlock()
+	fake.WorkerContainerScratchStub = stub
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerContainerScratchReturns(result1 *prometheus.GaugeVec) {
+	fake.workerContainerScratchMutex.Lock()
+	defer fake.workerContainerScratchMutex.Unlock()
+	fake.WorkerContainerScratchStub = nil
+	fake.workerContainerScratchReturns = struct {
+		result1 *prometheus.GaugeVec
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerContainerScratchReturnsOnCall(i int, result1 *prometheus.GaugeVec) {
+	fake.workerContainerScratchMutex.Lock()
+	defer fake.workerContainerScratchMutex.Unlock()
+	fake.WorkerContainerScratchStub = nil
+	if fake.workerContainerScratchReturnsOnCall == nil {
+		fake.workerContainerScratchReturnsOnCall = make(map[int]struct {
+			result1 *prometheus.GaugeVec
+		})
+	}
+	fake.workerContainerScratchReturnsOnCall[i] = struct {
+		result1 *prometheus.GaugeVec
+	}{result1}
+}
+
 func (fake *FakePrometheusGarbageCollectable) WorkerContainers() *prometheus.GaugeVec {
 	fake.workerContainersMutex.Lock()
 	ret, specificReturn := fake.workerContainersReturnsOnCall[len(fake.workerContainersArgsForCall)]
@@ -179,6 +292,112 @@ func (fake *FakePrometheusGarbageCollectable) WorkerContainersLabelsReturnsOnCal
 	}{result1}
 }
 
+func (fake *FakePrometheusGarbageCollectable) WorkerDiskFree() *prometheus.GaugeVec {
+	fake.workerDiskFreeMutex.Lock()
+	ret, specificReturn := fake.workerDiskFreeReturnsOnCall[len(fake.workerDiskFreeArgsForCall)]
+	fake.workerDiskFreeArgsForCall = append(fake.workerDiskFreeArgsForCall, struct {
+	}{})
+	stub := fake.WorkerDiskFreeStub
+	fakeReturns := fake.workerDiskFreeReturns
+	fake.recordInvocation("WorkerDiskFree", []interface{}{})
+	fake.workerDiskFreeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerDiskFreeCallCount() int {
+	fake.workerDiskFreeMutex.RLock()
+	defer fake.workerDiskFreeMutex.RUnlock()
+	return len(fake.workerDiskFreeArgsForCall)
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerDiskFreeCalls(stub func() *prometheus.GaugeVec) {
+	fake.workerDiskFreeMutex.Lock()
+	defer fake.workerDiskFreeMutex.Unlock()
+	fake.WorkerDiskFreeStub = stub
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerDiskFreeReturns(result1 *prometheus.GaugeVec) {
+	fake.workerDiskFreeMutex.Lock()
+	defer fake.workerDiskFreeMutex.Unlock()
+	fake.WorkerDiskFreeStub = nil
+	fake.workerDiskFreeReturns = struct {
+		result1 *prometheus.GaugeVec
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerDiskFreeReturnsOnCall(i int, result1 *prometheus.GaugeVec) {
+	fake.workerDiskFreeMutex.Lock()
+	defer fake.workerDiskFreeMutex.Unlock()
+	fake.WorkerDiskFreeStub = nil
+	if fake.workerDiskFreeReturnsOnCall == nil {
+		fake.workerDiskFreeReturnsOnCall = make(map[int]struct {
+			result1 *prometheus.GaugeVec
+		})
+	}
+	fake.workerDiskFreeReturnsOnCall[i] = struct {
+		result1 *prometheus.GaugeVec
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerDiskUsed() *prometheus.GaugeVec {
+	fake.workerDiskUsedMutex.Lock()
+	ret, specificReturn := fake.workerDiskUsedReturnsOnCall[len(fake.workerDiskUsedArgsForCall)]
+	fake.workerDiskUsedArgsForCall = append(fake.workerDiskUsedArgsForCall, struct {
+	}{})
+	stub := fake.WorkerDiskUsedStub
+	fakeReturns := fake.workerDiskUsedReturns
+	fake.recordInvocation("WorkerDiskUsed", []interface{}{})
+	fake.workerDiskUsedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerDiskUsedCallCount() int {
+	fake.workerDiskUsedMutex.RLock()
+	defer fake.workerDiskUsedMutex.RUnlock()
+	return len(fake.workerDiskUsedArgsForCall)
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerDiskUsedCalls(stub func() *prometheus.GaugeVec) {
+	fake.workerDiskUsedMutex.Lock()
+	defer fake.workerDiskUsedMutex.Unlock()
+	fake.WorkerDiskUsedStub = stub
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerDiskUsedReturns(result1 *prometheus.GaugeVec) {
+	fake.workerDiskUsedMutex.Lock()
+	defer fake.workerDiskUsedMutex.Unlock()
+	fake.WorkerDiskUsedStub = nil
+	fake.workerDiskUsedReturns = struct {
+		result1 *prometheus.GaugeVec
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerDiskUsedReturnsOnCall(i int, result1 *prometheus.GaugeVec) {
+	fake.workerDiskUsedMutex.Lock()
+	defer fake.workerDiskUsedMutex.Unlock()
+	fake.WorkerDiskUsedStub = nil
+	if fake.workerDiskUsedReturnsOnCall == nil {
+		fake.workerDiskUsedReturnsOnCall = make(map[int]struct {
+			result1 *prometheus.GaugeVec
+		})
+	}
+	fake.workerDiskUsedReturnsOnCall[i] = struct {
+		result1 *prometheus.GaugeVec
+	}{result1}
+}
+
 func (fake *FakePrometheusGarbageCollectable) WorkerTasks() *prometheus.GaugeVec {
 	fake.workerTasksMutex.Lock()
 	ret, specificReturn := fake.workerTasksReturnsOnCall[len(fake.workerTasksArgsForCall)]
@@ -285,6 +504,59 @@ func (fake *FakePrometheusGarbageCollectable) WorkerTasksLabelsReturnsOnCall(i i
 	}{result1}
 }
 
+func (fake *FakePrometheusGarbageCollectable) WorkerUsageLabels() map[string]map[string]prometheus.Labels {
+	fake.workerUsageLabelsMutex.Lock()
+	ret, specificReturn := fake.workerUsageLabelsReturnsOnCall[len(fake.workerUsageLabelsArgsForCall)]
+	fake.workerUsageLabelsArgsForCall = append(fake.workerUsageLabelsArgsForCall, struct {
+	}{})
+	stub := fake.WorkerUsageLabelsStub
+	fakeReturns := fake.workerUsageLabelsReturns
+	fake.recordInvocation("WorkerUsageLabels", []interface{}{})
+	fake.workerUsageLabelsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerUsageLabelsCallCount() int {
+	fake.workerUsageLabelsMutex.RLock()
+	defer fake.workerUsageLabelsMutex.RUnlock()
+	return len(fake.workerUsageLabelsArgsForCall)
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerUsageLabelsCalls(stub func() map[string]map[string]prometheus.Labels) {
+	fake.workerUsageLabelsMutex.Lock()
+	defer fake.workerUsageLabelsMutex.Unlock()
+	fake.WorkerUsageLabelsStub = stub
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerUsageLabelsReturns(result1 map[string]map[string]prometheus.Labels) {
+	fake.workerUsageLabelsMutex.Lock()
+	defer fake.workerUsageLabelsMutex.Unlock()
+	fake.WorkerUsageLabelsStub = nil
+	fake.workerUsageLabelsReturns = struct {
+		result1 map[string]map[string]prometheus.Labels
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerUsageLabelsReturnsOnCall(i int, result1 map[string]map[string]prometheus.Labels) {
+	fake.workerUsageLabelsMutex.Lock()
+	defer fake.workerUsageLabelsMutex.Unlock()
+	fake.WorkerUsageLabelsStub = nil
+	if fake.workerUsageLabelsReturnsOnCall == nil {
+		fake.workerUsageLabelsReturnsOnCall = make(map[int]struct {
+			result1 map[string]map[string]prometheus.Labels
+		})
+	}
+	fake.workerUsageLabelsReturnsOnCall[i] = struct {
+		result1 map[string]map[string]prometheus.Labels
+	}{result1}
+}
+
 func (fake *FakePrometheusGarbageCollectable) WorkerVolumes() *prometheus.GaugeVec {
 	fake.workerVolumesMutex.Lock()
 	ret, specificReturn := fake.workerVolumesReturnsOnCall[len(fake.workerVolumesArgsForCall)]
@@ -338,6 +610,112 @@ func (fake *FakePrometheusGarbageCollectable) WorkerVolumesReturnsOnCall(i int,
 	}{result1}
 }
 
+func (fake *FakePrometheusGarbageCollectable) WorkerVolumesByType() *prometheus.GaugeVec {
+	fake.workerVolumesByTypeMutex.Lock()
+	ret, specificReturn := fake.workerVolumesByTypeReturnsOnCall[len(fake.workerVolumesByTypeArgsForCall)]
+	fake.workerVolumesByTypeArgsForCall = append(fake.workerVolumesByTypeArgsForCall, struct {
+	}{})
+	stub := fake.WorkerVolumesByTypeStub
+	fakeReturns := fake.workerVolumesByTypeReturns
+	fake.recordInvocation("WorkerVolumesByType", []interface{}{})
+	fake.workerVolumesByTypeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerVolumesByTypeCallCount() int {
+	fake.workerVolumesByTypeMutex.RLock()
+	defer fake.workerVolumesByTypeMutex.RUnlock()
+	return len(fake.workerVolumesByTypeArgsForCall)
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerVolumesByTypeCalls(stub func() *prometheus.GaugeVec) {
+	fake.workerVolumesByTypeMutex.Lock()
+	defer fake.workerVolumesByTypeMutex.Unlock()
+	fake.WorkerVolumesByTypeStub = stub
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerVolumesByTypeReturns(result1 *prometheus.GaugeVec) {
+	fake.workerVolumesByTypeMutex.Lock()
+	defer fake.workerVolumesByTypeMutex.Unlock()
+	fake.WorkerVolumesByTypeStub = nil
+	fake.workerVolumesByTypeReturns = struct {
+		result1 *prometheus.GaugeVec
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerVolumesByTypeReturnsOnCall(i int, result1 *prometheus.GaugeVec) {
+	fake.workerVolumesByTypeMutex.Lock()
+	defer fake.workerVolumesByTypeMutex.Unlock()
+	fake.WorkerVolumesByTypeStub = nil
+	if fake.workerVolumesByTypeReturnsOnCall == nil {
+		fake.workerVolumesByTypeReturnsOnCall = make(map[int]struct {
+			result1 *prometheus.GaugeVec
+		})
+	}
+	fake.workerVolumesByTypeReturnsOnCall[i] = struct {
+		result1 *prometheus.GaugeVec
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerVolumesByTypeLabels() map[string]map[string]prometheus.Labels {
+	fake.workerVolumesByTypeLabelsMutex.Lock()
+	ret, specificReturn := fake.workerVolumesByTypeLabelsReturnsOnCall[len(fake.workerVolumesByTypeLabelsArgsForCall)]
+	fake.workerVolumesByTypeLabelsArgsForCall = append(fake.workerVolumesByTypeLabelsArgsForCall, struct {
+	}{})
+	stub := fake.WorkerVolumesByTypeLabelsStub
+	fakeReturns := fake.workerVolumesByTypeLabelsReturns
+	fake.recordInvocation("WorkerVolumesByTypeLabels", []interface{}{})
+	fake.workerVolumesByTypeLabelsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerVolumesByTypeLabelsCallCount() int {
+	fake.workerVolumesByTypeLabelsMutex.RLock()
+	defer fake.workerVolumesByTypeLabelsMutex.RUnlock()
+	return len(fake.workerVolumesByTypeLabelsArgsForCall)
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerVolumesByTypeLabelsCalls(stub func() map[string]map[string]prometheus.Labels) {
+	fake.workerVolumesByTypeLabelsMutex.Lock()
+	defer fake.workerVolumesByTypeLabelsMutex.Unlock()
+	fake.WorkerVolumesByTypeLabelsStub = stub
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerVolumesByTypeLabelsReturns(result1 map[string]map[string]prometheus.Labels) {
+	fake.workerVolumesByTypeLabelsMutex.Lock()
+	defer fake.workerVolumesByTypeLabelsMutex.Unlock()
+	fake.WorkerVolumesByTypeLabelsStub = nil
+	fake.workerVolumesByTypeLabelsReturns = struct {
+		result1 map[string]map[string]prometheus.Labels
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerVolumesByTypeLabelsReturnsOnCall(i int, result1 map[string]map[string]prometheus.Labels) {
+	fake.workerVolumesByTypeLabelsMutex.Lock()
+	defer fake.workerVolumesByTypeLabelsMutex.Unlock()
+	fake.WorkerVolumesByTypeLabelsStub = nil
+	if fake.workerVolumesByTypeLabelsReturnsOnCall == nil {
+		fake.workerVolumesByTypeLabelsReturnsOnCall = make(map[int]struct {
+			result1 map[string]map[string]prometheus.Labels
+		})
+	}
+	fake.workerVolumesByTypeLabelsReturnsOnCall[i] = struct {
+		result1 map[string]map[string]prometheus.Labels
+	}{result1}
+}
+
 func (fake *FakePrometheusGarbageCollectable) WorkerVolumesLabels() map[string]map[string]prometheus.Labels {
 	fake.workerVolumesLabelsMutex.Lock()
 	ret, specificReturn := fake.workerVolumesLabelsReturnsOnCall[len(fake.workerVolumesLabelsArgsForCall)]
@@ -394,16 +772,28 @@ func (fake *FakePrometheusGarbageCollectable) WorkerVolumesLabelsReturnsOnCall(i
 func (fake *FakePrometheusGarbageCollectable) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.workerContainerScratchMutex.RLock()
+	defer fake.workerContainerScratchMutex.RUnlock()
 	fake.workerContainersMutex.RLock()
 	defer fake.workerContainersMutex.RUnlock()
 	fake.workerContainersLabelsMutex.RLock()
 	defer fake.workerContainersLabelsMutex.RUnlock()
+	fake.workerDiskFreeMutex.RLock()
+	defer fake.workerDiskFreeMutex.RUnlock()
+	fake.workerDiskUsedMutex.RLock()
+	defer fake.workerDiskUsedMutex.RUnlock()
 	fake.workerTasksMutex.RLock()
 	defer fake.workerTasksMutex.RUnlock()
 	fake.workerTasksLabelsMutex.RLock()
 	defer fake.workerTasksLabelsMutex.RUnlock()
+	fake.workerUsageLabelsMutex.RLock()
+	defer fake.workerUsageLabelsMutex.RUnlock()
 	fake.workerVolumesMutex.RLock()
 	defer fake.workerVolumesMutex.RUnlock()
+	fake.workerVolumesByTypeMutex.RLock()
+	defer fake.workerVolumesByTypeMutex.RUnlock()
+	fake.workerVolumesByTypeLabelsMutex.RLock()
+	defer fake.workerVolumesByTypeLabelsMutex.RUnlock()
 	fake.workerVolumesLabelsMutex.RLock()
 	defer fake.workerVolumesLabelsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
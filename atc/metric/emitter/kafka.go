@@ -0,0 +1,275 @@
+package emitter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Shopify/sarama"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/pkg/errors"
+)
+
+type KafkaEmitter struct {
+	producer     sarama.AsyncProducer
+	metricsTopic string
+	buildsTopic  string
+	serializer   kafkaSerializer
+
+	// the events which failed to send since they were last logged, and the
+	// last error they failed with
+	failedL   sync.Mutex
+	failed    int
+	lastError error
+
+	// closed once the producer has shut down and every error is drained
+	drained chan struct{}
+}
+
+type KafkaConfig struct {
+	Brokers       []string `long:"kafka-broker" description:"Kafka broker address to publish to. Can be specified multiple times."`
+	ClientID      string   `long:"kafka-client-id" default:"concourse" description:"Client ID to identify as to the Kafka brokers"`
+	MetricsTopic  string   `long:"kafka-metrics-topic" default:"concourse-metrics" description:"Topic to publish metrics to"`
+	BuildsTopic   string   `long:"kafka-builds-topic" default:"concourse-builds" description:"Topic to publish build lifecycle events (build started, build finished) to"`
+	Serialization string   `long:"kafka-serialization" default:"json" choice:"json" choice:"avro" description:"Encoding of published messages"`
+	UseTLS        bool     `long:"kafka-use-tls" description:"Connect to the brokers over TLS"`
+}
+
+func init() {
+	metric.Metrics.RegisterEmitter(&KafkaConfig{})
+}
+
+func (config *KafkaConfig) Description() string { return "Kafka" }
+
+func (config *KafkaConfig) IsConfigured() bool { return len(config.Brokers) > 0 }
+
+func (config *KafkaConfig) NewEmitter() (metric.Emitter, error) {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.ClientID = config.ClientID
+	saramaConfig.Net.TLS.Enable = config.UseTLS
+	saramaConfig.Producer.Return.Errors = true
+
+	producer, err := sarama.NewAsyncProducer(config.Brokers, saramaConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create kafka producer")
+	}
+
+	return NewKafkaEmitter(producer, config.MetricsTopic, config.BuildsTopic, config.Serialization)
+}
+
+func NewKafkaEmitter(producer sarama.AsyncProducer, metricsTopic string, buildsTopic string, serialization string) (*KafkaEmitter, error) {
+	var serializer kafkaSerializer
+	switch serialization {
+	case "", "json":
+		serializer = kafkaJSON
+	case "avro":
+		serializer = kafkaAvro
+	default:
+		return nil, fmt.Errorf("unknown kafka serialization: %s", serialization)
+	}
+
+	emitter := &KafkaEmitter{
+		producer:     producer,
+		metricsTopic: metricsTopic,
+		buildsTopic:  buildsTopic,
+		serializer:   serializer,
+		drained:      make(chan struct{}),
+	}
+
+	go emitter.drainErrors()
+
+	return emitter, nil
+}
+
+// drainErrors reads every error as soon as the producer returns it. Otherwise
+// the producer stalls once its errors channel fills up, and sending an event
+// blocks forever.
+func (emitter *KafkaEmitter) drainErrors() {
+	defer close(emitter.drained)
+
+	for err := range emitter.producer.Errors() {
+		emitter.failedL.Lock()
+		emitter.failed++
+		emitter.lastError = err
+		emitter.failedL.Unlock()
+	}
+}
+
+// Close flushes the events which are still buffered and shuts down the
+// producer.
+func (emitter *KafkaEmitter) Close() error {
+	emitter.producer.AsyncClose()
+	<-emitter.drained
+
+	failed, err := emitter.takeFailures()
+	if failed > 0 {
+		return errors.Wrapf(err, "failed to send %d events", failed)
+	}
+
+	return nil
+}
+
+func (emitter *KafkaEmitter) takeFailures() (int, error) {
+	emitter.failedL.Lock()
+	defer emitter.failedL.Unlock()
+
+	failed, err := emitter.failed, emitter.lastError
+	emitter.failed = 0
+	emitter.lastError = nil
+
+	return failed, err
+}
+
+var kafkaBuildEvents = map[string]bool{
+	"build started":        true,
+	"build finished":       true,
+	"check build started":  true,
+	"check build finished": true,
+}
+
+func (emitter *KafkaEmitter) Emit(logger lager.Logger, event metric.Event) {
+	// errors are reported asynchronously; surface any that have come back
+	// since the last event rather than blocking on each send
+	if failed, err := emitter.takeFailures(); failed > 0 {
+		logger.Error("failed-to-send-events",
+			errors.Wrap(metric.ErrFailedToEmit, err.Error()),
+			lager.Data{"failed": failed})
+	}
+
+	payload, err := emitter.serializer(event)
+	if err != nil {
+		logger.Error("failed-to-serialize-event",
+			errors.Wrap(metric.ErrFailedToEmit, err.Error()))
+		return
+	}
+
+	topic := emitter.metricsTopic
+	key := event.Name
+	if kafkaBuildEvents[event.Name] {
+		// keep each build's events in order by sending them to one partition
+		topic = emitter.buildsTopic
+		key = event.Attributes["build_id"]
+	}
+
+	emitter.producer.Input() <- &sarama.ProducerMessage{
+		Topic:     topic,
+		Key:       sarama.StringEncoder(key),
+		Value:     sarama.ByteEncoder(payload),
+		Timestamp: event.Time,
+	}
+}
+
+type kafkaSerializer func(metric.Event) ([]byte, error)
+
+type kafkaEvent struct {
+	Name       string            `json:"name"`
+	Value      float64           `json:"value"`
+	Host       string            `json:"host"`
+	Time       int64             `json:"time"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+func newKafkaEvent(event metric.Event) kafkaEvent {
+	attributes := event.Attributes
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+
+	return kafkaEvent{
+		Name:       event.Name,
+		Value:      event.Value,
+		Host:       event.Host,
+		Time:       event.Time.UnixNano() / int64(time.Millisecond),
+		Attributes: attributes,
+	}
+}
+
+func kafkaJSON(event metric.Event) ([]byte, error) {
+	return json.Marshal(newKafkaEvent(event))
+}
+
+// KafkaAvroSchema is the schema of events published with the avro
+// serialization, in Parsing Canonical Form. Messages use Avro's single-object
+// encoding, so consumers can identify the schema from its fingerprint.
+const KafkaAvroSchema = `{"name":"org.concourse.Event","type":"record","fields":[{"name":"name","type":"string"},{"name":"value","type":"double"},{"name":"host","type":"string"},{"name":"time","type":"long"},{"name":"attributes","type":{"type":"map","values":"string"}}]}`
+
+var kafkaAvroHeader = func() []byte {
+	header := []byte{0xc3, 0x01}
+
+	fingerprint := make([]byte, 8)
+	binary.LittleEndian.PutUint64(fingerprint, avroFingerprint([]byte(KafkaAvroSchema)))
+
+	return append(header, fingerprint...)
+}()
+
+func kafkaAvro(event metric.Event) ([]byte, error) {
+	e := newKafkaEvent(event)
+
+	buf := bytes.NewBuffer(append([]byte{}, kafkaAvroHeader...))
+	avroString(buf, e.Name)
+	avroDouble(buf, e.Value)
+	avroString(buf, e.Host)
+	avroLong(buf, e.Time)
+
+	keys := make([]string, 0, len(e.Attributes))
+	for k := range e.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if len(keys) > 0 {
+		avroLong(buf, int64(len(keys)))
+		for _, k := range keys {
+			avroString(buf, k)
+			avroString(buf, e.Attributes[k])
+		}
+	}
+	avroLong(buf, 0)
+
+	return buf.Bytes(), nil
+}
+
+func avroLong(buf *bytes.Buffer, n int64) {
+	varint := make([]byte, binary.MaxVarintLen64)
+	buf.Write(varint[:binary.PutVarint(varint, n)])
+}
+
+func avroDouble(buf *bytes.Buffer, f float64) {
+	double := make([]byte, 8)
+	binary.LittleEndian.PutUint64(double, math.Float64bits(f))
+	buf.Write(double)
+}
+
+func avroString(buf *bytes.Buffer, s string) {
+	avroLong(buf, int64(len(s)))
+	buf.WriteString(s)
+}
+
+const avroEmptyFingerprint uint64 = 0xc15d213aa4d7a795
+
+var avroFingerprintTable = func() [256]uint64 {
+	var table [256]uint64
+	for i := range table {
+		fp := uint64(i)
+		for j := 0; j < 8; j++ {
+			fp = (fp >> 1) ^ (avroEmptyFingerprint & -(fp & 1))
+		}
+		table[i] = fp
+	}
+	return table
+}()
+
+// avroFingerprint computes the CRC-64-AVRO (Rabin) fingerprint of a schema.
+func avroFingerprint(schema []byte) uint64 {
+	fp := avroEmptyFingerprint
+	for _, b := range schema {
+		fp = (fp >> 8) ^ avroFingerprintTable[byte(fp)^b]
+	}
+	return fp
+}
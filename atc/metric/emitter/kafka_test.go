@@ -0,0 +1,165 @@
+package emitter_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/emitter"
+)
+
+var _ = Describe("KafkaEmitter", func() {
+	var (
+		producer      *mocks.AsyncProducer
+		serialization string
+		kafkaEmitter  *emitter.KafkaEmitter
+		logger        *lagertest.TestLogger
+		closed        bool
+
+		eventTime = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		config := sarama.NewConfig()
+		config.Producer.Return.Successes = true
+
+		producer = mocks.NewAsyncProducer(GinkgoT(), config)
+		serialization = "json"
+		logger = lagertest.NewTestLogger("kafka")
+		closed = false
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		kafkaEmitter, err = emitter.NewKafkaEmitter(producer, "metrics", "builds", serialization)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if !closed {
+			Expect(kafkaEmitter.Close()).To(Succeed())
+		}
+	})
+
+	sent := func() *sarama.ProducerMessage {
+		var msg *sarama.ProducerMessage
+		Eventually(producer.Successes()).Should(Receive(&msg))
+		return msg
+	}
+
+	It("publishes metrics to the metrics topic as JSON", func() {
+		producer.ExpectInputWithCheckerFunctionAndSucceed(func(value []byte) error {
+			var payload map[string]interface{}
+			err := json.Unmarshal(value, &payload)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(payload).To(Equal(map[string]interface{}{
+				"name":       "worker containers",
+				"value":      float64(3),
+				"host":       "web-1",
+				"time":       float64(eventTime.UnixNano() / int64(time.Millisecond)),
+				"attributes": map[string]interface{}{"worker": "some-worker"},
+			}))
+			return nil
+		})
+
+		kafkaEmitter.Emit(logger, metric.Event{
+			Name:       "worker containers",
+			Value:      3,
+			Host:       "web-1",
+			Time:       eventTime,
+			Attributes: map[string]string{"worker": "some-worker"},
+		})
+
+		msg := sent()
+		Expect(msg.Topic).To(Equal("metrics"))
+		Expect(msg.Key).To(Equal(sarama.StringEncoder("worker containers")))
+	})
+
+	It("publishes build events to the builds topic keyed by build", func() {
+		producer.ExpectInputAndSucceed()
+
+		kafkaEmitter.Emit(logger, metric.Event{
+			Name:       "build finished",
+			Value:      1000,
+			Time:       eventTime,
+			Attributes: map[string]string{"build_id": "42", "build_status": "succeeded"},
+		})
+
+		msg := sent()
+		Expect(msg.Topic).To(Equal("builds"))
+		Expect(msg.Key).To(Equal(sarama.StringEncoder("42")))
+	})
+
+	Context("with avro serialization", func() {
+		BeforeEach(func() {
+			serialization = "avro"
+		})
+
+		It("uses the single-object encoding", func() {
+			producer.ExpectInputWithCheckerFunctionAndSucceed(func(value []byte) error {
+				Expect(value[:2]).To(Equal([]byte{0xc3, 0x01}))
+
+				datum := value[10:]
+
+				// name
+				length, n := binary.Varint(datum)
+				Expect(string(datum[n : n+int(length)])).To(Equal("builds started"))
+				datum = datum[n+int(length):]
+
+				// value
+				Expect(binary.LittleEndian.Uint64(datum[:8])).To(Equal(uint64(0x4000000000000000)))
+
+				return nil
+			})
+
+			kafkaEmitter.Emit(logger, metric.Event{
+				Name:  "builds started",
+				Value: 2,
+				Time:  eventTime,
+			})
+
+			sent()
+		})
+	})
+
+	Context("when events fail to send", func() {
+		It("keeps emitting and reports the failures on close", func() {
+			// more failures than the producer can buffer errors for
+			for i := 0; i < 300; i++ {
+				producer.ExpectInputAndFail(sarama.ErrOutOfBrokers)
+			}
+
+			done := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(done)
+
+				for i := 0; i < 300; i++ {
+					kafkaEmitter.Emit(logger, metric.Event{
+						Name:  "some-metric",
+						Value: 1,
+						Time:  eventTime,
+					})
+				}
+			}()
+
+			Eventually(done).Should(BeClosed())
+
+			closed = true
+			Expect(kafkaEmitter.Close()).To(MatchError(ContainSubstring("failed to send")))
+		})
+	})
+
+	It("rejects unknown serializations", func() {
+		_, err := emitter.NewKafkaEmitter(producer, "metrics", "builds", "xml")
+		Expect(err).To(MatchError("unknown kafka serialization: xml"))
+	})
+})
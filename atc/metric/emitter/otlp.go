@@ -0,0 +1,203 @@
+package emitter
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	selector "go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/semconv"
+	"google.golang.org/grpc/credentials"
+)
+
+type OTLPEmitter struct {
+	controller *controller.Controller
+	exporter   *otlp.Exporter
+	meter      otelmetric.Meter
+
+	recordersL sync.Mutex
+	recorders  map[string]otelmetric.Float64ValueRecorder
+}
+
+const otlpShutdownTimeout = 10 * time.Second
+
+type OTLPConfig struct {
+	Address            string            `long:"otlp-metrics-address" description:"OTLP (gRPC) address to export metrics to"`
+	Headers            map[string]string `long:"otlp-metrics-header" description:"Header to attach to each export request. Can be specified multiple times." value-name:"NAME:VALUE"`
+	UseTLS             bool              `long:"otlp-metrics-use-tls" description:"Use TLS when connecting to the OTLP endpoint"`
+	Interval           time.Duration     `long:"otlp-metrics-interval" default:"30s" description:"Interval on which to export metrics"`
+	ServiceName        string            `long:"otlp-metrics-service-name" default:"concourse" description:"Value of the service.name resource attribute"`
+	ResourceAttributes map[string]string `long:"otlp-metrics-resource-attribute" description:"A resource attribute to attach to exported metrics, e.g. concourse.cluster:prod. Can be specified multiple times." value-name:"NAME:VALUE"`
+}
+
+func init() {
+	metric.Metrics.RegisterEmitter(&OTLPConfig{})
+}
+
+func (config *OTLPConfig) Description() string { return "OpenTelemetry (OTLP)" }
+
+func (config *OTLPConfig) IsConfigured() bool { return config.Address != "" }
+
+func (config *OTLPConfig) NewEmitter() (metric.Emitter, error) {
+	security := otlpgrpc.WithInsecure()
+	if config.UseTLS {
+		security = otlpgrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, ""))
+	}
+
+	driver := otlpgrpc.NewDriver(
+		otlpgrpc.WithEndpoint(config.Address),
+		otlpgrpc.WithHeaders(config.Headers),
+		security,
+	)
+
+	exporter, err := otlp.NewExporter(context.Background(), driver)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create otlp exporter")
+	}
+
+	pusher := controller.New(
+		processor.New(selector.NewWithInexpensiveDistribution(), exporter),
+		controller.WithExporter(exporter),
+		controller.WithCollectPeriod(config.Interval),
+		controller.WithResource(config.resource()),
+	)
+
+	err = pusher.Start(context.Background())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start otlp metrics controller")
+	}
+
+	emitter := NewOTLPEmitter(pusher)
+	emitter.exporter = exporter
+
+	return emitter, nil
+}
+
+// resource describes the node exporting the metrics. The host name is
+// included so that series from each web node can be told apart; anything
+// cluster-wide is left to the configured resource attributes.
+func (config *OTLPConfig) resource() *resource.Resource {
+	attrs := []attribute.KeyValue{
+		semconv.ServiceNameKey.String(config.ServiceName),
+	}
+
+	hostname, err := os.Hostname()
+	if err == nil {
+		attrs = append(attrs, semconv.HostNameKey.String(hostname))
+	}
+
+	return resource.NewWithAttributes(append(attrs, keyValues(config.ResourceAttributes)...)...)
+}
+
+func NewOTLPEmitter(pusher *controller.Controller) *OTLPEmitter {
+	return &OTLPEmitter{
+		controller: pusher,
+		meter:      pusher.MeterProvider().Meter("github.com/concourse/concourse/atc/metric"),
+		recorders:  map[string]otelmetric.Float64ValueRecorder{},
+	}
+}
+
+// otlpUnboundedAttributes are the event attributes which take a new value
+// for (nearly) every build or request. Each value would start a new series
+// which the collector has to keep, so they are not used as labels.
+var otlpUnboundedAttributes = map[string]bool{
+	"build_id": true,
+	"build":    true,
+	"job_id":   true,
+	"path":     true,
+}
+
+func (emitter *OTLPEmitter) Emit(logger lager.Logger, event metric.Event) {
+	recorder, err := emitter.recorder(otlpMetricName(event.Name))
+	if err != nil {
+		logger.Error("failed-to-create-instrument",
+			errors.Wrap(metric.ErrFailedToEmit, err.Error()))
+		return
+	}
+
+	attrs := map[string]string{}
+	for k, v := range event.Attributes {
+		if !otlpUnboundedAttributes[k] {
+			attrs[k] = v
+		}
+	}
+
+	labels := append(keyValues(attrs), attribute.String("host", event.Host))
+
+	recorder.Record(context.Background(), event.Value, labels...)
+}
+
+// Close stops collecting metrics, exporting the ones recorded since the last
+// export, and disconnects from the collector.
+func (emitter *OTLPEmitter) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), otlpShutdownTimeout)
+	defer cancel()
+
+	err := emitter.controller.Stop(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to stop otlp metrics controller")
+	}
+
+	if emitter.exporter != nil {
+		err = emitter.exporter.Shutdown(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to shut down otlp exporter")
+		}
+	}
+
+	return nil
+}
+
+func (emitter *OTLPEmitter) recorder(name string) (otelmetric.Float64ValueRecorder, error) {
+	emitter.recordersL.Lock()
+	defer emitter.recordersL.Unlock()
+
+	recorder, found := emitter.recorders[name]
+	if found {
+		return recorder, nil
+	}
+
+	recorder, err := emitter.meter.NewFloat64ValueRecorder(name)
+	if err != nil {
+		return otelmetric.Float64ValueRecorder{}, err
+	}
+
+	emitter.recorders[name] = recorder
+
+	return recorder, nil
+}
+
+// otlpMetricName turns an event name such as "build finished" into
+// "concourse.build_finished", matching the OpenTelemetry naming convention.
+func otlpMetricName(name string) string {
+	return "concourse." + specialChars.ReplaceAllString(strings.Replace(strings.ToLower(name), " ", "_", -1), "")
+}
+
+func keyValues(attrs map[string]string) []attribute.KeyValue {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	kvs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, attribute.String(k, attrs[k]))
+	}
+
+	return kvs
+}
@@ -0,0 +1,168 @@
+package emitter_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	selector "go.opentelemetry.io/otel/sdk/metric/selector/simple"
+
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/emitter"
+)
+
+var _ = Describe("OTLPEmitter", func() {
+	type recorded struct {
+		count  uint64
+		sum    float64
+		labels map[string]string
+	}
+
+	var (
+		pusher      *controller.Controller
+		otlpEmitter *emitter.OTLPEmitter
+		logger      *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		pusher = controller.New(
+			processor.New(selector.NewWithInexpensiveDistribution(), export.CumulativeExportKindSelector()),
+		)
+
+		otlpEmitter = emitter.NewOTLPEmitter(pusher)
+		logger = lagertest.NewTestLogger("otlp")
+	})
+
+	collect := func() map[string]recorded {
+		Expect(pusher.Collect(context.Background())).To(Succeed())
+
+		records := map[string]recorded{}
+		err := pusher.ForEach(export.CumulativeExportKindSelector(), func(record export.Record) error {
+			agg := record.Aggregation()
+
+			count, err := agg.(aggregation.Count).Count()
+			Expect(err).NotTo(HaveOccurred())
+
+			sum, err := agg.(aggregation.Sum).Sum()
+			Expect(err).NotTo(HaveOccurred())
+
+			labels := map[string]string{}
+			iter := record.Labels().Iter()
+			for iter.Next() {
+				kv := iter.Attribute()
+				labels[string(kv.Key)] = kv.Value.Emit()
+			}
+
+			records[record.Descriptor().Name()] = recorded{
+				count:  count,
+				sum:    sum.CoerceToFloat64(number.Float64Kind),
+				labels: labels,
+			}
+
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return records
+	}
+
+	It("records events under a sanitized, namespaced name", func() {
+		otlpEmitter.Emit(logger, metric.Event{
+			Name:  "build finished",
+			Value: 12,
+			Host:  "web-1",
+			Attributes: map[string]string{
+				"team_name": "main",
+			},
+		})
+		otlpEmitter.Emit(logger, metric.Event{
+			Name:  "build finished",
+			Value: 30,
+			Host:  "web-1",
+			Attributes: map[string]string{
+				"team_name": "main",
+			},
+		})
+
+		records := collect()
+		Expect(records).To(HaveKey("concourse.build_finished"))
+
+		record := records["concourse.build_finished"]
+		Expect(record.count).To(Equal(uint64(2)))
+		Expect(record.sum).To(Equal(42.0))
+		Expect(record.labels).To(Equal(map[string]string{
+			"host":      "web-1",
+			"team_name": "main",
+		}))
+	})
+
+	It("leaves out the attributes that would start a new series for every build", func() {
+		otlpEmitter.Emit(logger, metric.Event{
+			Name:  "build finished",
+			Value: 12,
+			Host:  "web-1",
+			Attributes: map[string]string{
+				"build_id":     "42",
+				"build":        "7",
+				"team_name":    "main",
+				"pipeline":     "some-pipeline",
+				"job":          "some-job",
+				"build_status": "succeeded",
+			},
+		})
+
+		records := collect()
+		Expect(records["concourse.build_finished"].labels).To(Equal(map[string]string{
+			"host":         "web-1",
+			"team_name":    "main",
+			"pipeline":     "some-pipeline",
+			"job":          "some-job",
+			"build_status": "succeeded",
+		}))
+	})
+
+	It("stops the controller when closed", func() {
+		Expect(pusher.Start(context.Background())).To(Succeed())
+
+		otlpEmitter.Emit(logger, metric.Event{Name: "worker containers", Value: 3, Host: "web-1"})
+
+		Expect(otlpEmitter.Close()).To(Succeed())
+		Expect(pusher.IsRunning()).To(BeFalse())
+
+		records := map[string]float64{}
+		err := pusher.ForEach(export.CumulativeExportKindSelector(), func(record export.Record) error {
+			sum, err := record.Aggregation().(aggregation.Sum).Sum()
+			Expect(err).NotTo(HaveOccurred())
+
+			records[record.Descriptor().Name()] = sum.CoerceToFloat64(number.Float64Kind)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(Equal(map[string]float64{"concourse.worker_containers": 3}))
+	})
+
+	It("keeps separate instruments for separate events", func() {
+		otlpEmitter.Emit(logger, metric.Event{Name: "worker containers", Value: 3, Host: "web-1"})
+		otlpEmitter.Emit(logger, metric.Event{Name: "worker volumes", Value: 5, Host: "web-1"})
+
+		records := collect()
+		Expect(records).To(HaveLen(2))
+		Expect(records["concourse.worker_containers"].sum).To(Equal(3.0))
+		Expect(records["concourse.worker_volumes"].sum).To(Equal(5.0))
+	})
+
+	It("is only configured once an address is given", func() {
+		config := &emitter.OTLPConfig{}
+		Expect(config.IsConfigured()).To(BeFalse())
+
+		config.Address = "collector:4317"
+		Expect(config.IsConfigured()).To(BeTrue())
+	})
+})
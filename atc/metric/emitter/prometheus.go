@@ -34,6 +34,7 @@ type PrometheusEmitter struct {
 
 	stepsWaiting         *prometheus.GaugeVec
 	stepsWaitingDuration *prometheus.HistogramVec
+	stepsDuration        *prometheus.HistogramVec
 
 	buildDurationsVec *prometheus.HistogramVec
 	buildsAborted     prometheus.Counter
@@ -63,8 +64,20 @@ type PrometheusEmitter struct {
 	checksStarted  prometheus.Counter
 
 	checksEnqueued prometheus.Counter
+	checksDuration *prometheus.HistogramVec
 
-	volumesStreamed prometheus.Counter
+	schedulingTickDuration prometheus.Histogram
+
+	jobsSuccessRate          *prometheus.GaugeVec
+	jobsDurationQuantiles    *prometheus.GaugeVec
+	jobsTimeSinceLastSuccess *jobFreshnessCollector
+
+	// the jobs with statistics series, to drop them once the job is removed
+	jobsL sync.Mutex
+	jobs  map[metric.JobLabels]bool
+
+	volumesStreamed      prometheus.Counter
+	volumesStreamedBytes prometheus.Counter
 
 	getStepCacheHits       prometheus.Counter
 	streamedResourceCaches prometheus.Counter
@@ -75,17 +88,54 @@ type PrometheusEmitter struct {
 	workerUnknownVolumes    *prometheus.GaugeVec
 	workerTasks             *prometheus.GaugeVec
 	workersRegistered       *prometheus.GaugeVec
-
-	workerContainersLabels map[string]map[string]prometheus.Labels
-	workerVolumesLabels    map[string]map[string]prometheus.Labels
-	workerTasksLabels      map[string]map[string]prometheus.Labels
-	workerLastSeen         map[string]time.Time
-	mu                     sync.Mutex
+	workerDiskUsed          *prometheus.GaugeVec
+	workerDiskFree          *prometheus.GaugeVec
+	workerContainerScratch  *prometheus.GaugeVec
+	workerVolumesByType     *prometheus.GaugeVec
+
+	workerContainersLabels    map[string]map[string]prometheus.Labels
+	workerVolumesLabels       map[string]map[string]prometheus.Labels
+	workerTasksLabels         map[string]map[string]prometheus.Labels
+	workerUsageLabels         map[string]map[string]prometheus.Labels
+	workerVolumesByTypeLabels map[string]map[string]prometheus.Labels
+	workerLastSeen            map[string]time.Time
+	mu                        sync.Mutex
+
+	labelLimiter *labelLimiter
 }
 
 type PrometheusConfig struct {
 	BindIP   string `long:"prometheus-bind-ip" description:"IP to listen on to expose Prometheus metrics."`
 	BindPort string `long:"prometheus-bind-port" description:"Port to listen on to expose Prometheus metrics."`
+
+	BuildDurationBuckets      []float64 `long:"prometheus-build-duration-buckets" description:"Histogram bucket (in seconds) for build durations. Can be specified multiple times."`
+	StepDurationBuckets       []float64 `long:"prometheus-step-duration-buckets" description:"Histogram bucket (in seconds) for step durations. Can be specified multiple times."`
+	StepWaitDurationBuckets   []float64 `long:"prometheus-step-wait-duration-buckets" description:"Histogram bucket (in seconds) for the time steps spend waiting for a worker. Can be specified multiple times."`
+	CheckDurationBuckets      []float64 `long:"prometheus-check-duration-buckets" description:"Histogram bucket (in seconds) for check durations. Can be specified multiple times."`
+	HTTPDurationBuckets       []float64 `long:"prometheus-http-duration-buckets" description:"Histogram bucket (in seconds) for HTTP response times. Can be specified multiple times."`
+	SchedulingDurationBuckets []float64 `long:"prometheus-scheduling-duration-buckets" description:"Histogram bucket (in seconds) for scheduler tick durations. Can be specified multiple times."`
+
+	MaxLabelValues int `long:"prometheus-max-label-values" default:"100" description:"Maximum number of distinct values recorded for unbounded labels such as resource_type. Further values are reported as 'other'. 0 means no limit."`
+}
+
+var (
+	defaultBuildDurationBuckets      = []float64{1, 60, 180, 300, 600, 900, 1200, 1800, 2700, 3600, 7200, 18000, 36000}
+	defaultStepDurationBuckets       = []float64{1, 10, 30, 60, 120, 300, 600, 1800, 3600, 7200}
+	defaultStepWaitDurationBuckets   = []float64{10, 30, 60, 120, 300, 600, 1800, 2400, 3000, 3600}
+	defaultCheckDurationBuckets      = []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+	defaultHTTPDurationBuckets       = prometheus.DefBuckets
+	defaultSchedulingDurationBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+)
+
+func buckets(configured []float64, defaults []float64) []float64 {
+	if len(configured) == 0 {
+		return defaults
+	}
+
+	sorted := append([]float64{}, configured...)
+	sort.Float64s(sorted)
+
+	return sorted
 }
 
 // The most natural data type to hold the labels is a set because each worker can have multiple but
@@ -218,10 +268,19 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		Subsystem: "steps",
 		Name:      "wait_duration",
 		Help:      "Elapsed time waiting for execution",
-		Buckets:   []float64{10, 30, 60, 120, 300, 600, 1800, 2400, 3000, 3600},
+		Buckets:   buckets(config.StepWaitDurationBuckets, defaultStepWaitDurationBuckets),
 	}, []string{"platform", "teamId", "type", "workerTags"})
 	prometheus.MustRegister(stepsWaitingDuration)
 
+	stepsDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "concourse",
+		Subsystem: "steps",
+		Name:      "duration_seconds",
+		Help:      "Step execution time in seconds, by step type and outcome",
+		Buckets:   buckets(config.StepDurationBuckets, defaultStepDurationBuckets),
+	}, []string{"type", "status"})
+	prometheus.MustRegister(stepsDuration)
+
 	buildsFinished := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "concourse",
 		Subsystem: "builds",
@@ -279,7 +338,7 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 			Subsystem: "builds",
 			Name:      "duration_seconds",
 			Help:      "Build time in seconds",
-			Buckets:   []float64{1, 60, 180, 300, 600, 900, 1200, 1800, 2700, 3600, 7200, 18000, 36000},
+			Buckets:   buckets(config.BuildDurationBuckets, defaultBuildDurationBuckets),
 		},
 		[]string{"team", "pipeline", "job"},
 	)
@@ -392,6 +451,50 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(workersRegistered)
 
+	workerDiskUsed := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "disk_used_bytes",
+			Help:      "Bytes used on the disk holding the worker's volumes",
+		},
+		[]string{"worker"},
+	)
+	prometheus.MustRegister(workerDiskUsed)
+
+	workerDiskFree := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "disk_free_bytes",
+			Help:      "Bytes free on the disk holding the worker's volumes",
+		},
+		[]string{"worker"},
+	)
+	prometheus.MustRegister(workerDiskFree)
+
+	workerContainerScratch := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "container_scratch_bytes",
+			Help:      "Bytes written by the worker's containers outside of their volumes",
+		},
+		[]string{"worker"},
+	)
+	prometheus.MustRegister(workerContainerScratch)
+
+	workerVolumesByType := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "volumes_by_type",
+			Help:      "Number of volumes per worker by what they are used for",
+		},
+		[]string{"worker", "type"},
+	)
+	prometheus.MustRegister(workerVolumesByType)
+
 	// http metrics
 	httpRequestsDuration := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -399,6 +502,7 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 			Subsystem: "http_responses",
 			Name:      "duration_seconds",
 			Help:      "Response time in seconds",
+			Buckets:   buckets(config.HTTPDurationBuckets, defaultHTTPDurationBuckets),
 		},
 		[]string{"method", "route", "status"},
 	)
@@ -454,6 +558,29 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(checksEnqueued)
 
+	checksDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "lidar",
+			Name:      "check_duration_seconds",
+			Help:      "Time spent running checks in seconds, by resource type and outcome",
+			Buckets:   buckets(config.CheckDurationBuckets, defaultCheckDurationBuckets),
+		},
+		[]string{"resource_type", "status"},
+	)
+	prometheus.MustRegister(checksDuration)
+
+	schedulingTickDuration := prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduler",
+			Name:      "tick_duration_seconds",
+			Help:      "Time taken by each scheduler tick to find and dispatch jobs to schedule",
+			Buckets:   buckets(config.SchedulingDurationBuckets, defaultSchedulingDurationBuckets),
+		},
+	)
+	prometheus.MustRegister(schedulingTickDuration)
+
 	volumesStreamed := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace: "concourse",
@@ -464,6 +591,16 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(volumesStreamed)
 
+	volumesStreamedBytes := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "volumes",
+			Name:      "streamed_bytes_total",
+			Help:      "Total number of bytes streamed from one worker to the other through the web node",
+		},
+	)
+	prometheus.MustRegister(volumesStreamedBytes)
+
 	getStepCacheHits := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace: "concourse",
@@ -484,6 +621,31 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(streamedResourceCaches)
 
+	jobsSuccessRate := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "jobs",
+			Name:      "success_rate",
+			Help:      "Fraction of the job's recent completed builds that succeeded",
+		},
+		[]string{"team", "pipeline", "job"},
+	)
+	prometheus.MustRegister(jobsSuccessRate)
+
+	jobsDurationQuantiles := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "jobs",
+			Name:      "duration_seconds",
+			Help:      "Duration quantiles of the job's recent completed builds",
+		},
+		[]string{"team", "pipeline", "job", "quantile"},
+	)
+	prometheus.MustRegister(jobsDurationQuantiles)
+
+	jobsTimeSinceLastSuccess := newJobFreshnessCollector()
+	prometheus.MustRegister(jobsTimeSinceLastSuccess)
+
 	listener, err := net.Listen("tcp", config.bind())
 	if err != nil {
 		return nil, err
@@ -506,6 +668,7 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 
 		stepsWaiting:         stepsWaiting,
 		stepsWaitingDuration: stepsWaitingDuration,
+		stepsDuration:        stepsDuration,
 
 		buildDurationsVec: buildDurationsVec,
 		buildsAborted:     buildsAborted,
@@ -535,22 +698,39 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		checksStarted:  checksStarted,
 
 		checksEnqueued: checksEnqueued,
-
-		workerContainers:        workerContainers,
-		workersRegistered:       workersRegistered,
-		workerContainersLabels:  map[string]map[string]prometheus.Labels{},
-		workerVolumesLabels:     map[string]map[string]prometheus.Labels{},
-		workerTasksLabels:       map[string]map[string]prometheus.Labels{},
-		workerLastSeen:          map[string]time.Time{},
-		workerVolumes:           workerVolumes,
-		workerTasks:             workerTasks,
-		workerUnknownContainers: workerUnknownContainers,
-		workerUnknownVolumes:    workerUnknownVolumes,
-
-		volumesStreamed: volumesStreamed,
+		checksDuration: checksDuration,
+
+		schedulingTickDuration: schedulingTickDuration,
+
+		jobsSuccessRate:          jobsSuccessRate,
+		jobsDurationQuantiles:    jobsDurationQuantiles,
+		jobsTimeSinceLastSuccess: jobsTimeSinceLastSuccess,
+		jobs:                     map[metric.JobLabels]bool{},
+
+		workerContainers:          workerContainers,
+		workersRegistered:         workersRegistered,
+		workerContainersLabels:    map[string]map[string]prometheus.Labels{},
+		workerVolumesLabels:       map[string]map[string]prometheus.Labels{},
+		workerTasksLabels:         map[string]map[string]prometheus.Labels{},
+		workerUsageLabels:         map[string]map[string]prometheus.Labels{},
+		workerVolumesByTypeLabels: map[string]map[string]prometheus.Labels{},
+		workerDiskUsed:            workerDiskUsed,
+		workerDiskFree:            workerDiskFree,
+		workerContainerScratch:    workerContainerScratch,
+		workerVolumesByType:       workerVolumesByType,
+		workerLastSeen:            map[string]time.Time{},
+		workerVolumes:             workerVolumes,
+		workerTasks:               workerTasks,
+		workerUnknownContainers:   workerUnknownContainers,
+		workerUnknownVolumes:      workerUnknownVolumes,
+
+		volumesStreamed:      volumesStreamed,
+		volumesStreamedBytes: volumesStreamedBytes,
 
 		getStepCacheHits:       getStepCacheHits,
 		streamedResourceCaches: streamedResourceCaches,
+
+		labelLimiter: newLabelLimiter(config.MaxLabelValues),
 	}
 	go emitter.periodicMetricGC()
 
@@ -620,6 +800,14 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.workerTasksMetric(logger, event)
 	case "worker state":
 		emitter.workersRegisteredMetric(logger, event)
+	case "worker disk used (bytes)":
+		emitter.workerUsageMetric(logger, emitter.workerDiskUsed, event)
+	case "worker disk free (bytes)":
+		emitter.workerUsageMetric(logger, emitter.workerDiskFree, event)
+	case "worker container scratch (bytes)":
+		emitter.workerUsageMetric(logger, emitter.workerContainerScratch, event)
+	case "worker volumes by type":
+		emitter.workerVolumesByTypeMetric(logger, event)
 	case "http response time":
 		emitter.httpResponseTimeMetrics(logger, event)
 	case "database queries":
@@ -636,6 +824,47 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.checksEnqueued.Add(event.Value)
 	case "volumes streamed":
 		emitter.volumesStreamed.Add(event.Value)
+	case "volumes streamed bytes":
+		emitter.volumesStreamedBytes.Add(event.Value)
+	case "step finished":
+		emitter.stepsDuration.
+			WithLabelValues(
+				event.Attributes["step_type"],
+				event.Attributes["status"],
+			).Observe(event.Value / 1000)
+	case "check finished":
+		emitter.checksDuration.
+			WithLabelValues(
+				emitter.labelLimiter.value("resource_type", event.Attributes["resource_type"]),
+				event.Attributes["status"],
+			).Observe(event.Value / 1000)
+	case "scheduling: tick duration (ms)":
+		emitter.schedulingTickDuration.Observe(event.Value / 1000)
+	case "job success rate":
+		emitter.jobsL.Lock()
+		emitter.jobs[metric.JobLabels{
+			TeamName:     event.Attributes["team_name"],
+			PipelineName: event.Attributes["pipeline"],
+			JobName:      event.Attributes["job"],
+		}] = true
+		emitter.jobsL.Unlock()
+
+		emitter.jobsSuccessRate.
+			WithLabelValues(event.Attributes["team_name"], event.Attributes["pipeline"], event.Attributes["job"]).
+			Set(event.Value)
+	case "job duration p50 (ms)":
+		emitter.jobsDurationQuantiles.
+			WithLabelValues(event.Attributes["team_name"], event.Attributes["pipeline"], event.Attributes["job"], "0.5").
+			Set(event.Value / 1000)
+	case "job duration p95 (ms)":
+		emitter.jobsDurationQuantiles.
+			WithLabelValues(event.Attributes["team_name"], event.Attributes["pipeline"], event.Attributes["job"], "0.95").
+			Set(event.Value / 1000)
+	case "job time since last success (ms)":
+		emitter.jobsTimeSinceLastSuccess.observe(
+			[]string{event.Attributes["team_name"], event.Attributes["pipeline"], event.Attributes["job"]},
+			event.Time.Add(-time.Duration(event.Value)*time.Millisecond),
+		)
 	case "get step cache hits":
 		emitter.getStepCacheHits.Add(event.Value)
 	case "streamed resource caches":
@@ -645,6 +874,81 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 	}
 }
 
+// jobFreshnessCollector reports the time since each job last succeeded. It
+// is computed on every scrape rather than when the event is emitted so that
+// it keeps growing while a job isn't succeeding, which is what alerts on
+// stale pipelines need.
+type jobFreshnessCollector struct {
+	desc *prometheus.Desc
+
+	lastSucceededL sync.Mutex
+	lastSucceeded  map[[3]string]time.Time
+}
+
+func newJobFreshnessCollector() *jobFreshnessCollector {
+	return &jobFreshnessCollector{
+		desc: prometheus.NewDesc(
+			"concourse_jobs_time_since_last_success_seconds",
+			"Time since the job's latest build succeeded",
+			[]string{"team", "pipeline", "job"},
+			nil,
+		),
+		lastSucceeded: map[[3]string]time.Time{},
+	}
+}
+
+func (collector *jobFreshnessCollector) observe(labels []string, lastSucceeded time.Time) {
+	collector.lastSucceededL.Lock()
+	collector.lastSucceeded[[3]string{labels[0], labels[1], labels[2]}] = lastSucceeded
+	collector.lastSucceededL.Unlock()
+}
+
+func (collector *jobFreshnessCollector) forget(labels []string) {
+	collector.lastSucceededL.Lock()
+	delete(collector.lastSucceeded, [3]string{labels[0], labels[1], labels[2]})
+	collector.lastSucceededL.Unlock()
+}
+
+func (collector *jobFreshnessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.desc
+}
+
+func (collector *jobFreshnessCollector) Collect(ch chan<- prometheus.Metric) {
+	collector.lastSucceededL.Lock()
+	defer collector.lastSucceededL.Unlock()
+
+	for labels, lastSucceeded := range collector.lastSucceeded {
+		ch <- prometheus.MustNewConstMetric(
+			collector.desc,
+			prometheus.GaugeValue,
+			time.Since(lastSucceeded).Seconds(),
+			labels[:]...,
+		)
+	}
+}
+
+// PruneJobs drops the statistics series of the jobs which are not active
+// anymore, e.g. because they, their pipeline or their team were removed.
+func (emitter *PrometheusEmitter) PruneJobs(active map[metric.JobLabels]bool) {
+	emitter.jobsL.Lock()
+	defer emitter.jobsL.Unlock()
+
+	for job := range emitter.jobs {
+		if active[job] {
+			continue
+		}
+
+		labels := []string{job.TeamName, job.PipelineName, job.JobName}
+
+		emitter.jobsSuccessRate.DeleteLabelValues(labels...)
+		emitter.jobsDurationQuantiles.DeleteLabelValues(append(labels, "0.5")...)
+		emitter.jobsDurationQuantiles.DeleteLabelValues(append(labels, "0.95")...)
+		emitter.jobsTimeSinceLastSuccess.forget(labels)
+
+		delete(emitter.jobs, job)
+	}
+}
+
 func (emitter *PrometheusEmitter) lock(logger lager.Logger, event metric.Event) {
 	lockType, exists := event.Attributes["type"]
 	if !exists {
@@ -860,6 +1164,50 @@ func (emitter *PrometheusEmitter) workerUnknownVolumesMetric(logger lager.Logger
 	emitter.workerUnknownVolumes.With(emitter.workerVolumesLabels[worker][key]).Set(event.Value)
 }
 
+func (emitter *PrometheusEmitter) workerUsageMetric(logger lager.Logger, gauge *prometheus.GaugeVec, event metric.Event) {
+	worker, exists := event.Attributes["worker"]
+	if !exists {
+		logger.Error("failed-to-find-worker-in-event", fmt.Errorf("expected worker to exist in event.Attributes"))
+		return
+	}
+
+	labels := prometheus.Labels{
+		"worker": worker,
+	}
+
+	key := serializeLabels(&labels)
+	if emitter.workerUsageLabels[worker] == nil {
+		emitter.workerUsageLabels[worker] = make(map[string]prometheus.Labels)
+	}
+	emitter.workerUsageLabels[worker][key] = labels
+	gauge.With(emitter.workerUsageLabels[worker][key]).Set(event.Value)
+}
+
+func (emitter *PrometheusEmitter) workerVolumesByTypeMetric(logger lager.Logger, event metric.Event) {
+	worker, exists := event.Attributes["worker"]
+	if !exists {
+		logger.Error("failed-to-find-worker-in-event", fmt.Errorf("expected worker to exist in event.Attributes"))
+		return
+	}
+	volumeType, exists := event.Attributes["type"]
+	if !exists {
+		logger.Error("failed-to-find-type-in-event", fmt.Errorf("expected type to exist in event.Attributes"))
+		return
+	}
+
+	labels := prometheus.Labels{
+		"worker": worker,
+		"type":   volumeType,
+	}
+
+	key := serializeLabels(&labels)
+	if emitter.workerVolumesByTypeLabels[worker] == nil {
+		emitter.workerVolumesByTypeLabels[worker] = make(map[string]prometheus.Labels)
+	}
+	emitter.workerVolumesByTypeLabels[worker][key] = labels
+	emitter.workerVolumesByType.With(emitter.workerVolumesByTypeLabels[worker][key]).Set(event.Value)
+}
+
 func (emitter *PrometheusEmitter) workerTasksMetric(logger lager.Logger, event metric.Event) {
 	worker, exists := event.Attributes["worker"]
 	if !exists {
@@ -961,9 +1309,21 @@ func DoGarbageCollection(emitter PrometheusGarbageCollectable, worker string) {
 		emitter.WorkerTasks().Delete(labels)
 	}
 
+	for _, labels := range emitter.WorkerUsageLabels()[worker] {
+		emitter.WorkerDiskUsed().Delete(labels)
+		emitter.WorkerDiskFree().Delete(labels)
+		emitter.WorkerContainerScratch().Delete(labels)
+	}
+
+	for _, labels := range emitter.WorkerVolumesByTypeLabels()[worker] {
+		emitter.WorkerVolumesByType().Delete(labels)
+	}
+
 	delete(emitter.WorkerContainersLabels(), worker)
 	delete(emitter.WorkerVolumesLabels(), worker)
 	delete(emitter.WorkerTasksLabels(), worker)
+	delete(emitter.WorkerUsageLabels(), worker)
+	delete(emitter.WorkerVolumesByTypeLabels(), worker)
 }
 
 //counterfeiter:generate . PrometheusGarbageCollectable
@@ -971,10 +1331,16 @@ type PrometheusGarbageCollectable interface {
 	WorkerContainers() *prometheus.GaugeVec
 	WorkerVolumes() *prometheus.GaugeVec
 	WorkerTasks() *prometheus.GaugeVec
+	WorkerDiskUsed() *prometheus.GaugeVec
+	WorkerDiskFree() *prometheus.GaugeVec
+	WorkerContainerScratch() *prometheus.GaugeVec
+	WorkerVolumesByType() *prometheus.GaugeVec
 
 	WorkerContainersLabels() map[string]map[string]prometheus.Labels
 	WorkerVolumesLabels() map[string]map[string]prometheus.Labels
 	WorkerTasksLabels() map[string]map[string]prometheus.Labels
+	WorkerUsageLabels() map[string]map[string]prometheus.Labels
+	WorkerVolumesByTypeLabels() map[string]map[string]prometheus.Labels
 }
 
 func (emitter *PrometheusEmitter) WorkerContainers() *prometheus.GaugeVec {
@@ -989,6 +1355,22 @@ func (emitter *PrometheusEmitter) WorkerTasks() *prometheus.GaugeVec {
 	return emitter.workerTasks
 }
 
+func (emitter *PrometheusEmitter) WorkerDiskUsed() *prometheus.GaugeVec {
+	return emitter.workerDiskUsed
+}
+
+func (emitter *PrometheusEmitter) WorkerDiskFree() *prometheus.GaugeVec {
+	return emitter.workerDiskFree
+}
+
+func (emitter *PrometheusEmitter) WorkerContainerScratch() *prometheus.GaugeVec {
+	return emitter.workerContainerScratch
+}
+
+func (emitter *PrometheusEmitter) WorkerVolumesByType() *prometheus.GaugeVec {
+	return emitter.workerVolumesByType
+}
+
 func (emitter *PrometheusEmitter) WorkerContainersLabels() map[string]map[string]prometheus.Labels {
 	return emitter.workerContainersLabels
 }
@@ -1000,3 +1382,56 @@ func (emitter *PrometheusEmitter) WorkerVolumesLabels() map[string]map[string]pr
 func (emitter *PrometheusEmitter) WorkerTasksLabels() map[string]map[string]prometheus.Labels {
 	return emitter.workerTasksLabels
 }
+
+func (emitter *PrometheusEmitter) WorkerUsageLabels() map[string]map[string]prometheus.Labels {
+	return emitter.workerUsageLabels
+}
+
+func (emitter *PrometheusEmitter) WorkerVolumesByTypeLabels() map[string]map[string]prometheus.Labels {
+	return emitter.workerVolumesByTypeLabels
+}
+
+// labelLimiter bounds the number of distinct values a label may take so that
+// user-controlled values (e.g. custom resource type names) cannot grow the
+// number of time series without bound. Values seen after the limit is
+// reached are collapsed into "other".
+type labelLimiter struct {
+	max int
+
+	mu   sync.Mutex
+	seen map[string]map[string]struct{}
+}
+
+func newLabelLimiter(max int) *labelLimiter {
+	return &labelLimiter{
+		max:  max,
+		seen: map[string]map[string]struct{}{},
+	}
+}
+
+func (limiter *labelLimiter) value(label string, value string) string {
+	if limiter.max <= 0 {
+		return value
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	values, found := limiter.seen[label]
+	if !found {
+		values = map[string]struct{}{}
+		limiter.seen[label] = values
+	}
+
+	if _, found := values[value]; found {
+		return value
+	}
+
+	if len(values) >= limiter.max {
+		return "other"
+	}
+
+	values[value] = struct{}{}
+
+	return value
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -184,13 +185,35 @@ var _ = Describe("PrometheusEmitter", func() {
 		prometheusConfig = &emitter.PrometheusConfig{
 			BindIP:   "localhost",
 			BindPort: "9090",
+
+			CheckDurationBuckets: []float64{5, 1},
+			MaxLabelValues:       1,
 		}
 	})
 
 	JustBeforeEach(func() {
-		prometheusEmitter, err = prometheusConfig.NewEmitter()
+		// collectors are registered globally, so the emitter can only be
+		// created once per test run
+		if sharedPrometheusEmitter == nil {
+			sharedPrometheusEmitter, err = prometheusConfig.NewEmitter()
+		}
+
+		prometheusEmitter = sharedPrometheusEmitter
 	})
 
+	scrape := func() string {
+		res, err := http.Get(fmt.Sprintf("http://%s:%s/metrics", prometheusConfig.BindIP, prometheusConfig.BindPort))
+		Expect(err).ToNot(HaveOccurred())
+		defer res.Body.Close()
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		body, err := ioutil.ReadAll(res.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		return string(body)
+	}
+
 	It("emits step waiting metric", func() {
 		prometheusEmitter.Emit(logger, metric.Event{
 			Name:  "steps waiting",
@@ -211,4 +234,137 @@ var _ = Describe("PrometheusEmitter", func() {
 		Expect(string(body)).To(ContainSubstring("concourse_steps_waiting{platform=\"darwin\",teamId=\"42\",type=\"get\",workerTags=\"tester\"} 4"))
 		Expect(err).To(BeNil())
 	})
+
+	It("emits step duration metric", func() {
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "step finished",
+			Value: 1500,
+			Attributes: map[string]string{
+				"step_type": "task",
+				"status":    "succeeded",
+			},
+		})
+
+		Expect(scrape()).To(ContainSubstring("concourse_steps_duration_seconds_sum{status=\"succeeded\",type=\"task\"} 1.5"))
+	})
+
+	It("emits scheduler tick metric", func() {
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "scheduling: tick duration (ms)",
+			Value: 20,
+		})
+
+		Expect(scrape()).To(ContainSubstring("concourse_scheduler_tick_duration_seconds_count 1"))
+	})
+
+	It("emits volume streaming bytes metric", func() {
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "volumes streamed bytes",
+			Value: 2048,
+		})
+
+		Expect(scrape()).To(ContainSubstring("concourse_volumes_streamed_bytes_total 2048"))
+	})
+
+	It("emits check duration metric using the configured buckets and bounded resource types", func() {
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "check finished",
+			Value: 2000,
+			Attributes: map[string]string{
+				"resource_type": "git",
+				"status":        "succeeded",
+			},
+		})
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "check finished",
+			Value: 500,
+			Attributes: map[string]string{
+				"resource_type": "my-custom-type",
+				"status":        "succeeded",
+			},
+		})
+
+		body := scrape()
+		Expect(body).To(ContainSubstring("concourse_lidar_check_duration_seconds_bucket{resource_type=\"git\",status=\"succeeded\",le=\"1\"} 0"))
+		Expect(body).To(ContainSubstring("concourse_lidar_check_duration_seconds_bucket{resource_type=\"git\",status=\"succeeded\",le=\"5\"} 1"))
+		Expect(body).To(ContainSubstring("concourse_lidar_check_duration_seconds_bucket{resource_type=\"other\",status=\"succeeded\",le=\"1\"} 1"))
+		Expect(body).ToNot(ContainSubstring("my-custom-type"))
+	})
+
+	It("emits job statistics metrics", func() {
+		attrs := map[string]string{
+			"team_name": "main",
+			"pipeline":  "some-pipeline",
+			"job":       "some-job",
+		}
+
+		prometheusEmitter.Emit(logger, metric.Event{Name: "job success rate", Value: 0.75, Attributes: attrs})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "job duration p50 (ms)", Value: 90000, Attributes: attrs})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "job duration p95 (ms)", Value: 300000, Attributes: attrs})
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:       "job time since last success (ms)",
+			Value:      3600000,
+			Time:       time.Now(),
+			Attributes: attrs,
+		})
+
+		body := scrape()
+		Expect(body).To(ContainSubstring("concourse_jobs_success_rate{job=\"some-job\",pipeline=\"some-pipeline\",team=\"main\"} 0.75"))
+		Expect(body).To(ContainSubstring("concourse_jobs_duration_seconds{job=\"some-job\",pipeline=\"some-pipeline\",quantile=\"0.5\",team=\"main\"} 90"))
+		Expect(body).To(ContainSubstring("concourse_jobs_duration_seconds{job=\"some-job\",pipeline=\"some-pipeline\",quantile=\"0.95\",team=\"main\"} 300"))
+		Expect(body).To(MatchRegexp(`concourse_jobs_time_since_last_success_seconds{job="some-job",pipeline="some-pipeline",team="main"} 360\d`))
+	})
+
+	It("drops the job statistics of jobs which are no longer active", func() {
+		for _, job := range []string{"kept-job", "removed-job"} {
+			attrs := map[string]string{
+				"team_name": "main",
+				"pipeline":  "some-pipeline",
+				"job":       job,
+			}
+
+			prometheusEmitter.Emit(logger, metric.Event{Name: "job success rate", Value: 0.75, Attributes: attrs})
+			prometheusEmitter.Emit(logger, metric.Event{Name: "job duration p50 (ms)", Value: 90000, Attributes: attrs})
+			prometheusEmitter.Emit(logger, metric.Event{Name: "job duration p95 (ms)", Value: 300000, Attributes: attrs})
+			prometheusEmitter.Emit(logger, metric.Event{
+				Name:       "job time since last success (ms)",
+				Value:      3600000,
+				Time:       time.Now(),
+				Attributes: attrs,
+			})
+		}
+
+		prometheusEmitter.(metric.JobPruner).PruneJobs(map[metric.JobLabels]bool{
+			{TeamName: "main", PipelineName: "some-pipeline", JobName: "kept-job"}: true,
+		})
+
+		body := scrape()
+		Expect(body).To(ContainSubstring(`concourse_jobs_success_rate{job="kept-job"`))
+		Expect(body).To(ContainSubstring(`concourse_jobs_time_since_last_success_seconds{job="kept-job"`))
+		Expect(body).NotTo(ContainSubstring("removed-job"))
+	})
+
+	It("emits worker disk usage metrics", func() {
+		attrs := map[string]string{"worker": "some-worker"}
+
+		prometheusEmitter.Emit(logger, metric.Event{Name: "worker disk used (bytes)", Value: 1024, Attributes: attrs})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "worker disk free (bytes)", Value: 2048, Attributes: attrs})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "worker container scratch (bytes)", Value: 512, Attributes: attrs})
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "worker volumes by type",
+			Value: 3,
+			Attributes: map[string]string{
+				"worker": "some-worker",
+				"type":   "resource",
+			},
+		})
+
+		body := scrape()
+		Expect(body).To(ContainSubstring("concourse_workers_disk_used_bytes{worker=\"some-worker\"} 1024"))
+		Expect(body).To(ContainSubstring("concourse_workers_disk_free_bytes{worker=\"some-worker\"} 2048"))
+		Expect(body).To(ContainSubstring("concourse_workers_container_scratch_bytes{worker=\"some-worker\"} 512"))
+		Expect(body).To(ContainSubstring("concourse_workers_volumes_by_type{type=\"resource\",worker=\"some-worker\"} 3"))
+	})
 })
+
+var sharedPrometheusEmitter metric.Emitter
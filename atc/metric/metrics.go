@@ -187,6 +187,20 @@ func (event SchedulingJobDuration) Emit(logger lager.Logger) {
 	)
 }
 
+type SchedulingTickDuration struct {
+	Duration time.Duration
+}
+
+func (event SchedulingTickDuration) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("scheduling-tick-duration"),
+		Event{
+			Name:  "scheduling: tick duration (ms)",
+			Value: ms(event.Duration),
+		},
+	)
+}
+
 type WorkerContainers struct {
 	WorkerName string
 	Platform   string
@@ -271,6 +285,67 @@ func (event WorkerUnknownVolumes) Emit(logger lager.Logger) {
 	)
 }
 
+type WorkerVolumesByType struct {
+	WorkerName string
+	Counts     map[string]int
+}
+
+func (event WorkerVolumesByType) Emit(logger lager.Logger) {
+	for volumeType, count := range event.Counts {
+		Metrics.emit(
+			logger.Session("worker-volumes-by-type"),
+			Event{
+				Name:  "worker volumes by type",
+				Value: float64(count),
+				Attributes: map[string]string{
+					"worker": event.WorkerName,
+					"type":   volumeType,
+				},
+			},
+		)
+	}
+}
+
+type WorkerDiskUsage struct {
+	WorkerName            string
+	DiskUsedBytes         uint64
+	DiskFreeBytes         uint64
+	ContainerScratchBytes uint64
+}
+
+func (event WorkerDiskUsage) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"worker": event.WorkerName,
+	}
+
+	Metrics.emit(
+		logger.Session("worker-disk-used"),
+		Event{
+			Name:       "worker disk used (bytes)",
+			Value:      float64(event.DiskUsedBytes),
+			Attributes: attributes,
+		},
+	)
+
+	Metrics.emit(
+		logger.Session("worker-disk-free"),
+		Event{
+			Name:       "worker disk free (bytes)",
+			Value:      float64(event.DiskFreeBytes),
+			Attributes: attributes,
+		},
+	)
+
+	Metrics.emit(
+		logger.Session("worker-container-scratch"),
+		Event{
+			Name:       "worker container scratch (bytes)",
+			Value:      float64(event.ContainerScratchBytes),
+			Attributes: attributes,
+		},
+	)
+}
+
 type WorkerTasks struct {
 	WorkerName string
 	Platform   string
@@ -494,6 +569,104 @@ func (event CheckBuildFinished) Emit(logger lager.Logger) {
 	)
 }
 
+type StepFinished struct {
+	StepType string
+	Status   string
+	Duration time.Duration
+}
+
+func (event StepFinished) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("step-finished"),
+		Event{
+			Name:  "step finished",
+			Value: ms(event.Duration),
+			Attributes: map[string]string{
+				"step_type": event.StepType,
+				"status":    event.Status,
+			},
+		},
+	)
+}
+
+type CheckFinished struct {
+	ResourceType string
+	Succeeded    bool
+	Duration     time.Duration
+}
+
+func (event CheckFinished) Emit(logger lager.Logger) {
+	status := "succeeded"
+	if !event.Succeeded {
+		status = "errored"
+	}
+
+	Metrics.emit(
+		logger.Session("check-finished"),
+		Event{
+			Name:  "check finished",
+			Value: ms(event.Duration),
+			Attributes: map[string]string{
+				"resource_type": event.ResourceType,
+				"status":        status,
+			},
+		},
+	)
+}
+
+type JobStatistics struct {
+	TeamName     string
+	PipelineName string
+	JobName      string
+	Statistics   db.JobStatistics
+}
+
+func (event JobStatistics) Emit(logger lager.Logger) {
+	attrs := map[string]string{
+		"team_name": event.TeamName,
+		"pipeline":  event.PipelineName,
+		"job":       event.JobName,
+	}
+
+	Metrics.emit(
+		logger.Session("job-success-rate"),
+		Event{
+			Name:       "job success rate",
+			Value:      event.Statistics.SuccessRate,
+			Attributes: attrs,
+		},
+	)
+
+	Metrics.emit(
+		logger.Session("job-duration-p50"),
+		Event{
+			Name:       "job duration p50 (ms)",
+			Value:      ms(event.Statistics.DurationP50),
+			Attributes: attrs,
+		},
+	)
+
+	Metrics.emit(
+		logger.Session("job-duration-p95"),
+		Event{
+			Name:       "job duration p95 (ms)",
+			Value:      ms(event.Statistics.DurationP95),
+			Attributes: attrs,
+		},
+	)
+
+	if !event.Statistics.LastSucceeded.IsZero() {
+		Metrics.emit(
+			logger.Session("job-time-since-last-success"),
+			Event{
+				Name:       "job time since last success (ms)",
+				Value:      ms(time.Since(event.Statistics.LastSucceeded)),
+				Attributes: attrs,
+			},
+		)
+	}
+}
+
 func ms(duration time.Duration) float64 {
 	return float64(duration) / 1000000
 }
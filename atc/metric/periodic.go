@@ -75,6 +75,14 @@ func tick(logger lager.Logger, m *Monitor) {
 		},
 	)
 
+	m.emit(
+		logger.Session("volumes-streamed-bytes"),
+		Event{
+			Name:  "volumes streamed bytes",
+			Value: m.VolumesStreamedSize.Delta(),
+		},
+	)
+
 	m.emit(
 		logger.Session("get-step-cache-hits"),
 		Event{
@@ -197,6 +205,19 @@ func tick(logger lager.Logger, m *Monitor) {
 		)
 	}
 
+	for action, counter := range m.RateLimitHit {
+		m.emit(
+			logger.Session("rate-limit-hit"),
+			Event{
+				Name:  "rate limit hit",
+				Value: counter.Delta(),
+				Attributes: map[string]string{
+					"action": action,
+				},
+			},
+		)
+	}
+
 	for labels, gauge := range m.StepsWaiting {
 		m.emit(
 			logger.Session("steps-waiting"),
@@ -287,4 +308,44 @@ func tick(logger lager.Logger, m *Monitor) {
 			Value: float64(runtime.NumGoroutine()),
 		},
 	)
+
+	pruneJobs(logger.Session("prune-jobs"), m)
+}
+
+// jobPruneInterval is how often the emitter is told which jobs are active.
+// Listing them is a heavier query than the rest of the tick, so it is done
+// less often.
+const jobPruneInterval = time.Minute
+
+// pruneJobs tells the emitter which jobs are active, if it keeps a series for
+// each job, so that it can drop the series of the jobs that have been
+// removed. Each ATC does this for its own emitter.
+func pruneJobs(logger lager.Logger, m *Monitor) {
+	pruner, ok := m.emitter.(JobPruner)
+	if !ok || m.JobFactory == nil {
+		return
+	}
+
+	if time.Since(m.lastJobPrune) < jobPruneInterval {
+		return
+	}
+
+	jobs, err := m.JobFactory.AllActiveJobs()
+	if err != nil {
+		logger.Error("failed-to-list-active-jobs", err)
+		return
+	}
+
+	m.lastJobPrune = time.Now()
+
+	active := map[JobLabels]bool{}
+	for _, job := range jobs {
+		active[JobLabels{
+			TeamName:     job.TeamName,
+			PipelineName: job.PipelineName,
+			JobName:      job.Name,
+		}] = true
+	}
+
+	pruner.PruneJobs(active)
 }
@@ -2,9 +2,11 @@ package metric_test
 
 import (
 	"os"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/metric"
@@ -171,4 +173,59 @@ var _ = Describe("Periodic emission of metrics", func() {
 			)
 		})
 	})
+
+	Context("when the emitter keeps a series for each job", func() {
+		var (
+			pruner     *pruningEmitter
+			jobFactory *dbfakes.FakeJobFactory
+		)
+
+		BeforeEach(func() {
+			pruner = &pruningEmitter{FakeEmitter: &metricfakes.FakeEmitter{}}
+
+			emitterFactory := &metricfakes.FakeEmitterFactory{}
+			emitterFactory.IsConfiguredReturns(true)
+			emitterFactory.NewEmitterReturns(pruner, nil)
+
+			monitor = metric.NewMonitor()
+			monitor.RegisterEmitter(emitterFactory)
+			monitor.Initialize(testLogger, "test", map[string]string{}, 1000)
+
+			jobFactory = new(dbfakes.FakeJobFactory)
+			jobFactory.AllActiveJobsReturns([]atc.JobSummary{
+				{Name: "some-job", TeamName: "main", PipelineName: "some-pipeline"},
+			}, nil)
+			monitor.JobFactory = jobFactory
+		})
+
+		It("tells it which jobs are active", func() {
+			Eventually(pruner.prunes).Should(ContainElement(map[metric.JobLabels]bool{
+				{TeamName: "main", PipelineName: "some-pipeline", JobName: "some-job"}: true,
+			}))
+		})
+
+		It("does not list the jobs on every tick", func() {
+			Eventually(pruner.prunes).Should(HaveLen(1))
+			Consistently(jobFactory.AllActiveJobsCallCount, time.Second).Should(Equal(1))
+		})
+	})
 })
+
+type pruningEmitter struct {
+	*metricfakes.FakeEmitter
+
+	prunedL sync.Mutex
+	pruned  []map[metric.JobLabels]bool
+}
+
+func (emitter *pruningEmitter) PruneJobs(active map[metric.JobLabels]bool) {
+	emitter.prunedL.Lock()
+	emitter.pruned = append(emitter.pruned, active)
+	emitter.prunedL.Unlock()
+}
+
+func (emitter *pruningEmitter) prunes() []map[metric.JobLabels]bool {
+	emitter.prunedL.Lock()
+	defer emitter.prunedL.Unlock()
+	return emitter.pruned
+}
@@ -0,0 +1,112 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"text/template"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// DefaultTemplate is used to render messages for Slack and Microsoft Teams
+// webhooks that don't configure their own.
+const DefaultTemplate = `{{if .BuildID}}{{.TeamName}}/{{.PipelineName}}/{{.JobName}} #{{.BuildName}} {{.Status}}{{else}}pipeline {{.TeamName}}/{{.PipelineName}} changed{{end}}: {{.URL}}`
+
+// Message is the data available to message templates: every field of the
+// event, plus a URL linking to the build or pipeline in the web UI.
+type Message struct {
+	atc.TeamWebhookEvent
+	URL string
+}
+
+// ParseTemplate parses a message template, falling back to DefaultTemplate
+// if it is empty.
+func ParseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultTemplate
+	}
+
+	return template.New("message").Option("missingkey=error").Parse(text)
+}
+
+type slackPayload struct {
+	Channel string `json:"channel,omitempty"`
+	Text    string `json:"text"`
+}
+
+type teamsPayload struct {
+	Type    string `json:"@type"`
+	Context string `json:"@context"`
+	Summary string `json:"summary"`
+	Text    string `json:"text"`
+}
+
+func (n *Notifier) payload(webhook atc.TeamWebhook, event atc.TeamWebhookEvent) ([]byte, error) {
+	switch webhook.Type {
+	case atc.TeamWebhookTypeGeneric:
+		return json.Marshal(event)
+
+	case atc.TeamWebhookTypeSlack:
+		text, err := n.render(webhook.Template, event)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(slackPayload{
+			Channel: webhook.Channel,
+			Text:    text,
+		})
+
+	case atc.TeamWebhookTypeTeams:
+		text, err := n.render(webhook.Template, event)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(teamsPayload{
+			Type:    "MessageCard",
+			Context: "https://schema.org/extensions",
+			Summary: text,
+			Text:    text,
+		})
+
+	default:
+		return nil, fmt.Errorf("unknown webhook type '%s'", webhook.Type)
+	}
+}
+
+func (n *Notifier) render(text string, event atc.TeamWebhookEvent) (string, error) {
+	tmpl, err := ParseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, Message{
+		TeamWebhookEvent: event,
+		URL:              n.eventURL(event),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (n *Notifier) eventURL(event atc.TeamWebhookEvent) string {
+	if event.BuildID != 0 {
+		return n.externalURL + "/builds/" + strconv.Itoa(event.BuildID)
+	}
+
+	pipelineURL := n.externalURL + "/teams/" + url.PathEscape(event.TeamName) + "/pipelines/" + url.PathEscape(event.PipelineName)
+
+	pipelineRef := atc.PipelineRef{Name: event.PipelineName, InstanceVars: event.PipelineInstanceVars}
+	if params := pipelineRef.QueryParams(); params != nil {
+		pipelineURL += "?" + params.Encode()
+	}
+
+	return pipelineURL
+}
@@ -0,0 +1,121 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const (
+	EventHeader     = "X-Concourse-Event"
+	SignatureHeader = "X-Concourse-Signature"
+)
+
+// NewNotifier returns a component that sends queued build and pipeline events
+// to the webhooks registered by their team. The external URL is used to link
+// to builds and pipelines in Slack and Microsoft Teams messages.
+func NewNotifier(queue db.TeamWebhookEventQueue, client *http.Client, externalURL string, batchSize int) *Notifier {
+	return &Notifier{
+		queue:       queue,
+		client:      client,
+		externalURL: strings.TrimSuffix(externalURL, "/"),
+		batchSize:   batchSize,
+	}
+}
+
+type Notifier struct {
+	queue       db.TeamWebhookEventQueue
+	client      *http.Client
+	externalURL string
+	batchSize   int
+}
+
+func (n *Notifier) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("notifier")
+
+	for {
+		notifications, err := n.queue.Dequeue(n.batchSize)
+		if err != nil {
+			logger.Error("failed-to-dequeue-events", err)
+			return err
+		}
+
+		for _, notification := range notifications {
+			n.notify(ctx, logger, notification)
+		}
+
+		if len(notifications) < n.batchSize {
+			return nil
+		}
+	}
+}
+
+func (n *Notifier) notify(ctx context.Context, logger lager.Logger, notification db.TeamWebhookNotification) {
+	for _, webhook := range notification.Webhooks {
+		if !webhook.Filter.Matches(notification.Event) {
+			continue
+		}
+
+		logData := lager.Data{
+			"team":    notification.Event.TeamName,
+			"webhook": webhook.Name,
+			"event":   notification.Event.Type,
+		}
+
+		payload, err := n.payload(webhook, notification.Event)
+		if err != nil {
+			logger.Error("failed-to-build-payload", err, logData)
+			continue
+		}
+
+		err = n.send(ctx, webhook, notification.Event.Type, payload)
+		if err != nil {
+			logger.Error("failed-to-send-event", err, logData)
+		}
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, webhook atc.TeamWebhook, eventType string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+
+	if webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, payload))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Sign returns the signature sent along with a payload, so that receivers
+// sharing the secret can verify that it came from Concourse.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
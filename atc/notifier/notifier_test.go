@@ -0,0 +1,271 @@
+package notifier_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/notifier"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type receivedRequest struct {
+	path    string
+	headers http.Header
+	body    string
+}
+
+var _ = Describe("Notifier", func() {
+	var (
+		fakeQueue *dbfakes.FakeTeamWebhookEventQueue
+		server    *httptest.Server
+
+		lock     sync.Mutex
+		received []receivedRequest
+
+		event atc.TeamWebhookEvent
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		received = nil
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+
+			lock.Lock()
+			received = append(received, receivedRequest{
+				path:    r.URL.Path,
+				headers: r.Header,
+				body:    string(body),
+			})
+			lock.Unlock()
+
+			if r.URL.Path == "/broken" {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}))
+
+		event = atc.TeamWebhookEvent{
+			Type:         atc.TeamWebhookEventBuildFinished,
+			Time:         1234,
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			BuildID:      42,
+			BuildName:    "7",
+			Status:       "failed",
+		}
+
+		fakeQueue = new(dbfakes.FakeTeamWebhookEventQueue)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		runErr = notifier.NewNotifier(fakeQueue, server.Client(), "https://ci.example.com/", 2).Run(ctx)
+	})
+
+	Context("when there are events for webhooks", func() {
+		BeforeEach(func() {
+			fakeQueue.DequeueReturns([]db.TeamWebhookNotification{
+				{
+					Event: event,
+					Webhooks: []atc.TeamWebhook{
+						{Name: "unsigned", URL: server.URL + "/unsigned"},
+						{Name: "signed", URL: server.URL + "/signed", Secret: "some-secret"},
+						{
+							Name:   "filtered",
+							URL:    server.URL + "/filtered",
+							Filter: atc.TeamWebhookFilter{Statuses: []string{"errored"}},
+						},
+						{Name: "broken", URL: server.URL + "/broken"},
+					},
+				},
+			}, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+		})
+
+		It("sends the event to every webhook whose filter matches", func() {
+			var paths []string
+			for _, req := range received {
+				paths = append(paths, req.path)
+			}
+
+			Expect(paths).To(ConsistOf("/unsigned", "/signed", "/broken"))
+		})
+
+		It("sends the event as JSON with its type in a header", func() {
+			req := received[0]
+			Expect(req.body).To(MatchJSON(`{
+				"type": "build-finished",
+				"time": 1234,
+				"team_name": "some-team",
+				"pipeline_name": "some-pipeline",
+				"job_name": "some-job",
+				"build_id": 42,
+				"build_name": "7",
+				"status": "failed"
+			}`))
+			Expect(req.headers.Get("Content-Type")).To(Equal("application/json"))
+			Expect(req.headers.Get(notifier.EventHeader)).To(Equal("build-finished"))
+		})
+
+		It("signs the payload only for webhooks with a secret", func() {
+			for _, req := range received {
+				switch req.path {
+				case "/signed":
+					Expect(req.headers.Get(notifier.SignatureHeader)).To(Equal(notifier.Sign("some-secret", []byte(req.body))))
+				default:
+					Expect(req.headers.Get(notifier.SignatureHeader)).To(BeEmpty())
+				}
+			}
+		})
+
+		It("dequeues a single batch when it is not full", func() {
+			Expect(fakeQueue.DequeueCallCount()).To(Equal(1))
+			Expect(fakeQueue.DequeueArgsForCall(0)).To(Equal(2))
+		})
+	})
+
+	Context("when there are events for Slack and Microsoft Teams webhooks", func() {
+		BeforeEach(func() {
+			pipelineEvent := atc.TeamWebhookEvent{
+				Type:                 atc.TeamWebhookEventPipelineChanged,
+				TeamName:             "some-team",
+				PipelineName:         "some-pipeline",
+				PipelineInstanceVars: atc.InstanceVars{"branch": "main"},
+			}
+
+			fakeQueue.DequeueReturnsOnCall(0, []db.TeamWebhookNotification{
+				{
+					Event: event,
+					Webhooks: []atc.TeamWebhook{
+						{Name: "slack", Type: atc.TeamWebhookTypeSlack, URL: server.URL + "/slack", Channel: "#ci"},
+						{Name: "teams", Type: atc.TeamWebhookTypeTeams, URL: server.URL + "/teams"},
+						{
+							Name:     "custom",
+							Type:     atc.TeamWebhookTypeSlack,
+							URL:      server.URL + "/custom",
+							Template: "{{.JobName}} went {{.Status}}, see {{.URL}}",
+						},
+					},
+				},
+				{
+					Event: pipelineEvent,
+					Webhooks: []atc.TeamWebhook{
+						{Name: "slack", Type: atc.TeamWebhookTypeSlack, URL: server.URL + "/pipeline-slack"},
+					},
+				},
+			}, nil)
+		})
+
+		bodyFor := func(path string) string {
+			for _, req := range received {
+				if req.path == path {
+					return req.body
+				}
+			}
+
+			Fail("no request to " + path)
+			return ""
+		}
+
+		It("sends Slack a message rendered from the default template", func() {
+			Expect(bodyFor("/slack")).To(MatchJSON(`{
+				"channel": "#ci",
+				"text": "some-team/some-pipeline/some-job #7 failed: https://ci.example.com/builds/42"
+			}`))
+		})
+
+		It("sends Microsoft Teams a message card", func() {
+			Expect(bodyFor("/teams")).To(MatchJSON(`{
+				"@type": "MessageCard",
+				"@context": "https://schema.org/extensions",
+				"summary": "some-team/some-pipeline/some-job #7 failed: https://ci.example.com/builds/42",
+				"text": "some-team/some-pipeline/some-job #7 failed: https://ci.example.com/builds/42"
+			}`))
+		})
+
+		It("renders custom templates", func() {
+			Expect(bodyFor("/custom")).To(MatchJSON(`{
+				"text": "some-job went failed, see https://ci.example.com/builds/42"
+			}`))
+		})
+
+		It("links pipeline events to the pipeline", func() {
+			Expect(bodyFor("/pipeline-slack")).To(MatchJSON(`{
+				"text": "pipeline some-team/some-pipeline changed: https://ci.example.com/teams/some-team/pipelines/some-pipeline?vars.branch=%22main%22"
+			}`))
+		})
+	})
+
+	Context("when a template fails to render", func() {
+		BeforeEach(func() {
+			fakeQueue.DequeueReturns([]db.TeamWebhookNotification{
+				{
+					Event: event,
+					Webhooks: []atc.TeamWebhook{
+						{Name: "bad", Type: atc.TeamWebhookTypeSlack, URL: server.URL + "/bad", Template: "{{.Bogus}}"},
+						{Name: "good", URL: server.URL + "/good"},
+					},
+				},
+			}, nil)
+		})
+
+		It("skips the webhook and carries on", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(received).To(HaveLen(1))
+			Expect(received[0].path).To(Equal("/good"))
+		})
+	})
+
+	Context("when a batch is full", func() {
+		BeforeEach(func() {
+			notification := db.TeamWebhookNotification{
+				Event:    event,
+				Webhooks: []atc.TeamWebhook{{Name: "some-webhook", URL: server.URL}},
+			}
+
+			fakeQueue.DequeueReturnsOnCall(0, []db.TeamWebhookNotification{notification, notification}, nil)
+			fakeQueue.DequeueReturnsOnCall(1, []db.TeamWebhookNotification{notification}, nil)
+		})
+
+		It("keeps dequeueing until the queue is drained", func() {
+			Expect(fakeQueue.DequeueCallCount()).To(Equal(2))
+			Expect(received).To(HaveLen(3))
+		})
+	})
+
+	Context("when dequeueing fails", func() {
+		BeforeEach(func() {
+			fakeQueue.DequeueReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+
+	Describe("Sign", func() {
+		It("returns the hex-encoded HMAC-SHA256 of the payload", func() {
+			Expect(notifier.Sign("secret", []byte("payload"))).To(Equal("sha256=b82fcb791acec57859b989b430a826488ce2e479fdf92326bd0a2e8375a42ba4"))
+		})
+	})
+})
@@ -16,6 +16,9 @@ type Pipeline struct {
 	Name          string         `json:"name"`
 	InstanceVars  InstanceVars   `json:"instance_vars,omitempty"`
 	Paused        bool           `json:"paused"`
+	PausedBy      string         `json:"paused_by,omitempty"`
+	PausedAt      int64          `json:"paused_at,omitempty"`
+	PauseReason   string         `json:"pause_reason,omitempty"`
 	Public        bool           `json:"public"`
 	Archived      bool           `json:"archived"`
 	Groups        GroupConfigs   `json:"groups,omitempty"`
@@ -37,6 +40,18 @@ type RenameRequest struct {
 	NewName string `json:"name"`
 }
 
+// PauseRequest is the optional body of a request to pause a pipeline or job.
+type PauseRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// PauseJobsRequest is the body of a request to pause or unpause several of a
+// pipeline's jobs at once. Reason is only used when pausing.
+type PauseJobsRequest struct {
+	Jobs   []string `json:"jobs"`
+	Reason string   `json:"reason,omitempty"`
+}
+
 type InstanceVars map[string]interface{}
 
 func (iv InstanceVars) String() string {
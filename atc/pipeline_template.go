@@ -0,0 +1,48 @@
+package atc
+
+// PipelineTemplate is a partial pipeline config registered with a team. The
+// team's pipelines merge it into their own config with an `include:`
+// directive, so that shared jobs and resources are defined in one place.
+type PipelineTemplate struct {
+	Name   string `json:"name"`
+	Config string `json:"config"`
+}
+
+// IncludeConfig refers to a partial pipeline config whose groups, var
+// sources, resource types, resources and jobs are merged into the pipeline
+// when it is set. Exactly one of Template and File is set.
+type IncludeConfig struct {
+	// Name of a pipeline template registered with the team.
+	Template string `json:"template,omitempty"`
+
+	// Path to a file in an artifact, e.g. repo/ci/common.yml. Only available
+	// to the set_pipeline step.
+	File string `json:"file,omitempty"`
+
+	// Vars interpolated into the included config before it's merged.
+	Vars Params `json:"vars,omitempty"`
+}
+
+// IncludedConfig records what an include merged into a pipeline, so that the
+// saved config shows where each part of it came from.
+type IncludedConfig struct {
+	Template string `json:"template,omitempty"`
+	File     string `json:"file,omitempty"`
+
+	// Digest of the included config as fetched, before interpolation.
+	Digest string `json:"digest"`
+
+	Groups        []string `json:"groups,omitempty"`
+	VarSources    []string `json:"var_sources,omitempty"`
+	ResourceTypes []string `json:"resource_types,omitempty"`
+	Resources     []string `json:"resources,omitempty"`
+	Jobs          []string `json:"jobs,omitempty"`
+}
+
+func (include IncludeConfig) String() string {
+	if include.Template != "" {
+		return "template '" + include.Template + "'"
+	}
+
+	return "file '" + include.File + "'"
+}
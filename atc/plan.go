@@ -1,8 +1,9 @@
 package atc
 
 type Plan struct {
-	ID       PlanID `json:"id"`
-	Attempts []int  `json:"attempts,omitempty"`
+	ID            PlanID `json:"id"`
+	Attempts      []int  `json:"attempts,omitempty"`
+	TotalAttempts []int  `json:"total_attempts,omitempty"`
 
 	Get         *GetPlan         `json:"get,omitempty"`
 	Put         *PutPlan         `json:"put,omitempty"`
@@ -10,6 +11,10 @@ type Plan struct {
 	Task        *TaskPlan        `json:"task,omitempty"`
 	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
 	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+	LoadVars    *LoadVarsPlan    `json:"load_vars,omitempty"`
+	Approval    *ApprovalPlan    `json:"approval,omitempty"`
+	Run         *RunPlan         `json:"run,omitempty"`
+	Publish     *PublishPlan     `json:"publish,omitempty"`
 
 	Do         *DoPlan         `json:"do,omitempty"`
 	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
@@ -25,6 +30,12 @@ type Plan struct {
 	Timeout *TimeoutPlan `json:"timeout,omitempty"`
 	Retry   *RetryPlan   `json:"retry,omitempty"`
 
+	Semaphore *SemaphorePlan `json:"semaphore,omitempty"`
+	When      *WhenPlan      `json:"when,omitempty"`
+
+	// set alongside Retry when the attempts wait before retrying
+	RetryBackoff *RetryBackoffConfig `json:"retry_backoff,omitempty"`
+
 	// used for 'fly execute'
 	ArtifactInput  *ArtifactInputPlan  `json:"artifact_input,omitempty"`
 	ArtifactOutput *ArtifactOutputPlan `json:"artifact_output,omitempty"`
@@ -55,6 +66,10 @@ func (plan *Plan) Each(f func(*Plan)) {
 			p.Step.Each(f)
 			plan.Across.Steps[i] = p
 		}
+
+		if plan.Across.SubStepTemplate != nil {
+			plan.Across.SubStepTemplate.Each(f)
+		}
 	}
 
 	if plan.OnSuccess != nil {
@@ -88,6 +103,10 @@ func (plan *Plan) Each(f func(*Plan)) {
 
 	if plan.Timeout != nil {
 		plan.Timeout.Step.Each(f)
+
+		if plan.Timeout.OnSoftTimeout != nil {
+			plan.Timeout.OnSoftTimeout.Each(f)
+		}
 	}
 
 	if plan.Retry != nil {
@@ -96,6 +115,14 @@ func (plan *Plan) Each(f func(*Plan)) {
 			(*plan.Retry)[i] = p
 		}
 	}
+
+	if plan.Semaphore != nil {
+		plan.Semaphore.Step.Each(f)
+	}
+
+	if plan.When != nil {
+		plan.When.Step.Each(f)
+	}
 }
 
 type PlanID string
@@ -140,28 +167,57 @@ type OnSuccessPlan struct {
 
 type TimeoutPlan struct {
 	Step     Plan   `json:"step"`
-	Duration string `json:"duration"`
+	Duration string `json:"duration,omitempty"`
+
+	SoftDuration  string `json:"soft_duration,omitempty"`
+	OnSoftTimeout *Plan  `json:"on_soft_timeout,omitempty"`
+}
+
+type SemaphorePlan struct {
+	Step  Plan   `json:"step"`
+	Name  string `json:"name"`
+	Limit int    `json:"limit"`
+}
+
+type WhenPlan struct {
+	Step      Plan   `json:"step"`
+	Condition string `json:"condition"`
+
+	// the plans of the steps whose statuses the condition refers to, by
+	// name, in the order they may run; the status of a step is that of the
+	// last of its plans which ran
+	Steps map[string][]PlanID `json:"steps,omitempty"`
 }
 
 type TryPlan struct {
 	Step Plan `json:"step"`
+
+	// The local var to record the step's failure in.
+	ErrorVar string `json:"error_var,omitempty"`
 }
 
 type InParallelPlan struct {
-	Steps    []Plan `json:"steps"`
-	Limit    int    `json:"limit,omitempty"`
-	FailFast bool   `json:"fail_fast,omitempty"`
+	Steps    []Plan          `json:"steps"`
+	Limit    InParallelLimit `json:"limit,omitempty"`
+	FailFast bool            `json:"fail_fast,omitempty"`
 }
 
 type AcrossPlan struct {
-	Vars     []AcrossVar     `json:"vars"`
-	Steps    []VarScopedPlan `json:"steps"`
-	FailFast bool            `json:"fail_fast,omitempty"`
+	Vars  []AcrossVar     `json:"vars"`
+	Steps []VarScopedPlan `json:"steps"`
+
+	// SubStepTemplate is set instead of Steps when any of the values are read
+	// from a file, and is run for each combination of values once they are
+	// known.
+	SubStepTemplate *Plan `json:"substep_template,omitempty"`
+
+	FailFast bool `json:"fail_fast,omitempty"`
 }
 
 type AcrossVar struct {
 	Var         string             `json:"name"`
 	Values      []interface{}      `json:"values"`
+	ValuesFile  string             `json:"values_file,omitempty"`
 	MaxInFlight *MaxInFlightConfig `json:"max_in_flight,omitempty"`
 }
 
@@ -185,6 +241,14 @@ type GetPlan struct {
 	Version     *Version `json:"version,omitempty"`
 	VersionFrom *PlanID  `json:"version_from,omitempty"`
 
+	// Fetch the newest version since this one instead, resolved when the step
+	// runs.
+	VersionSince *Version `json:"version_since,omitempty"`
+
+	// Record the version fetched as the build's input, for when it's only
+	// known once the step runs.
+	RecordInput bool `json:"record_input,omitempty"`
+
 	// Params to pass to the get operation.
 	Params Params `json:"params,omitempty"`
 
@@ -194,9 +258,19 @@ type GetPlan struct {
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
 
+	// The CPU architecture of the workers to place the container on.
+	Arch string `json:"arch,omitempty"`
+
 	// A timeout to enforce on the resource `get` process. Note that fetching the
 	// resource's image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
+
+	// Ignore any cached copy of the version and fetch it again, replacing the
+	// cache.
+	Fresh bool `json:"fresh,omitempty"`
+
+	// A registry to fetch the resource type's image from instead.
+	RegistryMirror *RegistryMirror `json:"registry_mirror,omitempty"`
 }
 
 type PutPlan struct {
@@ -220,12 +294,18 @@ type PutPlan struct {
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
 
+	// The CPU architecture of the workers to place the container on.
+	Arch string `json:"arch,omitempty"`
+
 	// A timeout to enforce on the resource `put` process. Note that fetching the
 	// resource's image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
 
 	// If or not expose BUILD_CREATED_BY to build metadata
 	ExposeBuildCreatedBy bool `json:"expose_build_created_by,omitempty"`
+
+	// A registry to fetch the resource type's image from instead.
+	RegistryMirror *RegistryMirror `json:"registry_mirror,omitempty"`
 }
 
 type CheckPlan struct {
@@ -256,10 +336,20 @@ type CheckPlan struct {
 
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
+
+	// Set for check steps in a job's plan. These run as part of the job's
+	// build rather than on an interval, even though they're for a resource.
+	InJobPlan bool `json:"in_job_plan,omitempty"`
+
+	// The number of times to run the check before giving up, and how long to
+	// wait between runs. Unlike other steps, a check's attempts are run by the
+	// step itself so that the versions it finds are saved under its plan ID.
+	Attempts     int                 `json:"attempts,omitempty"`
+	RetryBackoff *RetryBackoffConfig `json:"retry_backoff,omitempty"`
 }
 
 func (plan CheckPlan) IsPeriodic() bool {
-	return plan.Resource != "" || plan.ResourceType != ""
+	return !plan.InJobPlan && (plan.Resource != "" || plan.ResourceType != "")
 }
 
 type TaskPlan struct {
@@ -274,6 +364,9 @@ type TaskPlan struct {
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
 
+	// The CPU architecture of the workers to place the container on.
+	Arch string `json:"arch,omitempty"`
+
 	// The task config to execute - either fetched from a path at runtime, or
 	// provided statically.
 	ConfigPath string      `json:"config_path,omitempty"`
@@ -286,6 +379,9 @@ type TaskPlan struct {
 	// image set in the task's config.
 	ImageArtifactName string `json:"image,omitempty"`
 
+	// A registry to fetch the task's image from instead.
+	RegistryMirror *RegistryMirror `json:"registry_mirror,omitempty"`
+
 	// Vars to use to parameterize the task config.
 	Vars Params `json:"vars,omitempty"`
 
@@ -301,6 +397,17 @@ type TaskPlan struct {
 	// image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
 
+	// Provide the task with a short-lived token for calling the API on behalf
+	// of the build's team.
+	BuildToken bool `json:"build_token,omitempty"`
+
+	// Skip running the task if it already succeeded with the same config,
+	// inputs and image, reusing the outputs it produced then.
+	Memoize bool `json:"memoize,omitempty"`
+
+	// Services to run alongside the task, sharing its network.
+	Services []TaskServiceConfig `json:"services,omitempty"`
+
 	// Resource types to have available for use when fetching the task's image.
 	//
 	// XXX(check-refactor): Eliminating this would be great - if we can replace
@@ -319,6 +426,7 @@ type SetPipelinePlan struct {
 	Vars         map[string]interface{} `json:"vars,omitempty"`
 	VarFiles     []string               `json:"var_files,omitempty"`
 	InstanceVars map[string]interface{} `json:"instance_vars,omitempty"`
+	DryRun       bool                   `json:"dry_run,omitempty"`
 }
 
 type LoadVarPlan struct {
@@ -328,6 +436,54 @@ type LoadVarPlan struct {
 	Reveal bool   `json:"reveal,omitempty"`
 }
 
+type LoadVarsPlan struct {
+	Files  string `json:"files"`
+	Format string `json:"format,omitempty"`
+	Reveal bool   `json:"reveal,omitempty"`
+}
+
+type ApprovalPlan struct {
+	Name      string   `json:"name"`
+	Approvers []string `json:"approvers,omitempty"`
+}
+
+type PublishPlan struct {
+	// The artifact to publish.
+	Name string `json:"name"`
+}
+
+type RunPlan struct {
+	// The name of the step. The prototype's response is stored as a local var
+	// of the same name.
+	Name string `json:"name"`
+
+	// The message to send to the prototype, e.g. "run".
+	Message string `json:"message"`
+
+	// The prototype to send the message to. Prototypes are configured and
+	// fetched like resource types.
+	Type                   string                 `json:"type"`
+	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
+
+	// The object to send along with the message.
+	Object Params `json:"object,omitempty"`
+
+	// Artifacts in the build plan to provide to the prototype, and artifacts
+	// the prototype will produce.
+	Inputs  []string `json:"inputs,omitempty"`
+	Outputs []string `json:"outputs,omitempty"`
+
+	// Run the prototype's container in 'privileged' mode.
+	Privileged bool `json:"privileged,omitempty"`
+
+	// Worker tags to influence placement of the container.
+	Tags Tags `json:"tags,omitempty"`
+
+	// A timeout to enforce on the message's process. Note that fetching the
+	// prototype's image does not count towards the timeout.
+	Timeout string `json:"timeout,omitempty"`
+}
+
 type RetryPlan []Plan
 
 type DependentGetPlan struct {
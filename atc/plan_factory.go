@@ -41,6 +41,14 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.SetPipeline = &t
 	case LoadVarPlan:
 		plan.LoadVar = &t
+	case LoadVarsPlan:
+		plan.LoadVars = &t
+	case ApprovalPlan:
+		plan.Approval = &t
+	case RunPlan:
+		plan.Run = &t
+	case PublishPlan:
+		plan.Publish = &t
 	case CheckPlan:
 		plan.Check = &t
 	case OnAbortPlan:
@@ -59,6 +67,10 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.Timeout = &t
 	case RetryPlan:
 		plan.Retry = &t
+	case SemaphorePlan:
+		plan.Semaphore = &t
+	case WhenPlan:
+		plan.When = &t
 	case ArtifactInputPlan:
 		plan.ArtifactInput = &t
 	case ArtifactOutputPlan:
@@ -1,8 +1,11 @@
 package policy
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/jessevdk/go-flags"
@@ -10,7 +13,11 @@ import (
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
-const ActionUseImage = "UseImage"
+const (
+	ActionUseImage    = "UseImage"
+	ActionFetchImage  = "FetchImage"
+	ActionSetPipeline = "SetPipeline"
+)
 
 type PolicyCheckNotPass struct {
 	Reasons []string
@@ -26,6 +33,11 @@ type Filter struct {
 	ActionsToSkip []string `long:"policy-check-filter-action-skip" description:"Actions the list will not go through policy check"`
 }
 
+type Decisions struct {
+	CacheTTL        time.Duration `long:"policy-check-cache-ttl" description:"How long to reuse the decision for an identical policy check. Disabled by default."`
+	FailOpenActions []string      `long:"policy-check-fail-open-action" description:"Actions that are allowed, with a warning, when the policy agent cannot be reached. All other actions are denied."`
+}
+
 type PolicyCheckInput struct {
 	Service        string      `json:"service"`
 	ClusterName    string      `json:"cluster_name"`
@@ -42,6 +54,11 @@ type PolicyCheckInput struct {
 type PolicyCheckOutput struct {
 	Allowed bool
 	Reasons []string
+
+	// Warnings are reported by rules in "warn" mode. They do not affect
+	// whether the action is allowed, which lets a new rule be rolled out
+	// gradually before it is enforced.
+	Warnings []string
 }
 
 // FailedPolicyCheck creates a generic failed check
@@ -105,7 +122,7 @@ type Checker interface {
 	Check(input PolicyCheckInput) (PolicyCheckOutput, error)
 }
 
-func Initialize(logger lager.Logger, cluster string, version string, filter Filter) (Checker, error) {
+func Initialize(logger lager.Logger, cluster string, version string, filter Filter, decisions Decisions) (Checker, error) {
 	logger.Debug("policy-checker-initialize")
 
 	clusterName = cluster
@@ -132,8 +149,11 @@ func Initialize(logger lager.Logger, cluster string, version string, filter Filt
 				lager.Data{"rfc": "https://github.com/concourse/rfcs/pull/41"})
 
 			return &AgentChecker{
-				filter: filter,
-				agent:  agent,
+				logger:    logger.Session("policy-checker"),
+				filter:    filter,
+				decisions: decisions,
+				agent:     agent,
+				cache:     map[string]cachedDecision{},
 			}, nil
 		}
 	}
@@ -143,10 +163,23 @@ func Initialize(logger lager.Logger, cluster string, version string, filter Filt
 }
 
 type AgentChecker struct {
-	filter Filter
-	agent  Agent
+	logger    lager.Logger
+	filter    Filter
+	decisions Decisions
+	agent     Agent
+
+	cacheLock sync.Mutex
+	cache     map[string]cachedDecision
 }
 
+type cachedDecision struct {
+	output    PolicyCheckOutput
+	expiresAt time.Time
+}
+
+// maxCachedDecisions bounds the cache between sweeps of expired decisions.
+const maxCachedDecisions = 10000
+
 func (c *AgentChecker) ShouldCheckHttpMethod(method string) bool {
 	return inArray(c.filter.HttpMethods, method)
 }
@@ -174,7 +207,80 @@ func (c *AgentChecker) Check(input PolicyCheckInput) (PolicyCheckOutput, error)
 	input.Service = "concourse"
 	input.ClusterName = clusterName
 	input.ClusterVersion = clusterVersion
-	return c.agent.Check(input)
+
+	var cacheKey string
+	if c.decisions.CacheTTL > 0 {
+		payload, err := json.Marshal(input)
+		if err == nil {
+			cacheKey = string(payload)
+
+			if output, found := c.cachedDecision(cacheKey); found {
+				return output, nil
+			}
+		}
+	}
+
+	output, err := c.agent.Check(input)
+	if err != nil {
+		if !inArray(c.decisions.FailOpenActions, input.Action) {
+			return output, err
+		}
+
+		c.logger.Error("failing-open", err, lager.Data{"action": input.Action})
+
+		return PolicyCheckOutput{
+			Allowed:  true,
+			Reasons:  []string{},
+			Warnings: []string{fmt.Sprintf("policy check skipped: %s", err)},
+		}, nil
+	}
+
+	if cacheKey != "" {
+		c.cacheDecision(cacheKey, output)
+	}
+
+	return output, nil
+}
+
+func (c *AgentChecker) cachedDecision(key string) (PolicyCheckOutput, bool) {
+	c.cacheLock.Lock()
+	defer c.cacheLock.Unlock()
+
+	decision, found := c.cache[key]
+	if !found {
+		return PolicyCheckOutput{}, false
+	}
+
+	if time.Now().After(decision.expiresAt) {
+		delete(c.cache, key)
+		return PolicyCheckOutput{}, false
+	}
+
+	return decision.output, true
+}
+
+func (c *AgentChecker) cacheDecision(key string, output PolicyCheckOutput) {
+	c.cacheLock.Lock()
+	defer c.cacheLock.Unlock()
+
+	now := time.Now()
+
+	if len(c.cache) >= maxCachedDecisions {
+		for k, decision := range c.cache {
+			if now.After(decision.expiresAt) {
+				delete(c.cache, k)
+			}
+		}
+
+		if len(c.cache) >= maxCachedDecisions {
+			return
+		}
+	}
+
+	c.cache[key] = cachedDecision{
+		output:    output,
+		expiresAt: now.Add(c.decisions.CacheTTL),
+	}
 }
 
 type NoopChecker struct{}
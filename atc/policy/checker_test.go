@@ -2,6 +2,7 @@ package policy_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
@@ -12,9 +13,10 @@ import (
 
 var _ = Describe("Policy checker", func() {
 	var (
-		checker policy.Checker
-		filter  policy.Filter
-		err     error
+		checker   policy.Checker
+		filter    policy.Filter
+		decisions policy.Decisions
+		err       error
 	)
 
 	BeforeEach(func() {
@@ -24,12 +26,14 @@ var _ = Describe("Policy checker", func() {
 			ActionsToSkip: []string{"skip_1", "skip_2"},
 		}
 
+		decisions = policy.Decisions{}
+
 		fakeAgent = new(policyfakes.FakeAgent)
 		fakeAgentFactory.NewAgentReturns(fakeAgent, nil)
 	})
 
 	JustBeforeEach(func() {
-		checker, err = policy.Initialize(testLogger, "some-cluster", "some-version", filter)
+		checker, err = policy.Initialize(testLogger, "some-cluster", "some-version", filter, decisions)
 	})
 
 	// fakeAgent is configured in BeforeSuite.
@@ -145,6 +149,76 @@ var _ = Describe("Policy checker", func() {
 						Expect(checkErr.Error()).To(Equal("some-error"))
 						Expect(output.Allowed).To(BeFalse())
 					})
+
+					Context("when the action fails open", func() {
+						BeforeEach(func() {
+							input.Action = "some-action"
+							decisions.FailOpenActions = []string{"some-action"}
+						})
+
+						It("should pass with a warning", func() {
+							Expect(checkErr).ToNot(HaveOccurred())
+							Expect(output.Allowed).To(BeTrue())
+							Expect(output.Warnings).To(ConsistOf("policy check skipped: some-error"))
+						})
+					})
+
+					Context("when another action fails open", func() {
+						BeforeEach(func() {
+							input.Action = "some-action"
+							decisions.FailOpenActions = []string{"some-other-action"}
+						})
+
+						It("should not pass", func() {
+							Expect(checkErr).To(HaveOccurred())
+							Expect(output.Allowed).To(BeFalse())
+						})
+					})
+				})
+
+				Context("when decisions are cached", func() {
+					BeforeEach(func() {
+						decisions.CacheTTL = time.Hour
+						fakeAgent.CheckReturns(policy.PassedPolicyCheck(), nil)
+					})
+
+					It("reuses the decision for an identical check", func() {
+						secondOutput, err := checker.Check(input)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(secondOutput).To(Equal(output))
+						Expect(fakeAgent.CheckCallCount()).To(Equal(1))
+					})
+
+					It("does not reuse the decision for a different check", func() {
+						_, err := checker.Check(policy.PolicyCheckInput{Action: "some-other-action"})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(fakeAgent.CheckCallCount()).To(Equal(2))
+					})
+
+					Context("when the agent errors", func() {
+						BeforeEach(func() {
+							fakeAgent.CheckReturns(policy.FailedPolicyCheck(), errors.New("some-error"))
+						})
+
+						It("does not cache the error", func() {
+							_, err := checker.Check(input)
+							Expect(err).To(HaveOccurred())
+							Expect(fakeAgent.CheckCallCount()).To(Equal(2))
+						})
+					})
+
+					Context("when the decision expires", func() {
+						BeforeEach(func() {
+							decisions.CacheTTL = time.Millisecond
+						})
+
+						It("asks the agent again", func() {
+							Eventually(func() int {
+								_, _ = checker.Check(input)
+								return fakeAgent.CheckCallCount()
+							}).Should(BeNumerically(">", 1))
+						})
+					})
 				})
 			})
 		})
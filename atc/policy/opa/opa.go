@@ -34,8 +34,9 @@ type opaInput struct {
 }
 
 type opaOuptut struct {
-	Allowed *bool    `json:"allowed,omitempty"`
-	Reasons []string `json:"reasons,omitempty"`
+	Allowed  *bool    `json:"allowed,omitempty"`
+	Reasons  []string `json:"reasons,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 type opaResult struct {
@@ -91,7 +92,8 @@ func (c opa) Check(input policy.PolicyCheckInput) (policy.PolicyCheckOutput, err
 	}
 
 	return policy.PolicyCheckOutput{
-		Allowed: *result.Result.Allowed,
-		Reasons: result.Result.Reasons,
+		Allowed:  *result.Result.Allowed,
+		Reasons:  result.Result.Reasons,
+		Warnings: result.Result.Warnings,
 	}, nil
 }
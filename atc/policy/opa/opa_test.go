@@ -122,6 +122,21 @@ var _ = Describe("Policy checker", func() {
 		})
 	})
 
+	Context("when OPA returns allowed with warnings", func() {
+		BeforeEach(func() {
+			fakeOpa = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"result": {"allowed": true, "warnings": ["a policy will soon say you can't do that"]}}`)
+			}))
+		})
+
+		It("should be allowed and return warnings", func() {
+			result, err := agent.Check(policy.PolicyCheckInput{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Allowed).To(BeTrue())
+			Expect(result.Warnings).To(ConsistOf("a policy will soon say you can't do that"))
+		})
+	})
+
 	Context("when OPA is unreachable", func() {
 		BeforeEach(func() {
 			fakeOpa = httptest.NewUnstartedServer(http.NotFoundHandler())
@@ -15,6 +15,10 @@ func (plan Plan) Public() *json.RawMessage {
 		Task           *json.RawMessage `json:"task,omitempty"`
 		SetPipeline    *json.RawMessage `json:"set_pipeline,omitempty"`
 		LoadVar        *json.RawMessage `json:"load_var,omitempty"`
+		LoadVars       *json.RawMessage `json:"load_vars,omitempty"`
+		Approval       *json.RawMessage `json:"approval,omitempty"`
+		Run            *json.RawMessage `json:"run,omitempty"`
+		Publish        *json.RawMessage `json:"publish,omitempty"`
 		OnAbort        *json.RawMessage `json:"on_abort,omitempty"`
 		OnError        *json.RawMessage `json:"on_error,omitempty"`
 		Ensure         *json.RawMessage `json:"ensure,omitempty"`
@@ -24,6 +28,8 @@ func (plan Plan) Public() *json.RawMessage {
 		DependentGet   *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout        *json.RawMessage `json:"timeout,omitempty"`
 		Retry          *json.RawMessage `json:"retry,omitempty"`
+		Semaphore      *json.RawMessage `json:"semaphore,omitempty"`
+		When           *json.RawMessage `json:"when,omitempty"`
 		ArtifactInput  *json.RawMessage `json:"artifact_input,omitempty"`
 		ArtifactOutput *json.RawMessage `json:"artifact_output,omitempty"`
 	}
@@ -66,6 +72,22 @@ func (plan Plan) Public() *json.RawMessage {
 		public.LoadVar = plan.LoadVar.Public()
 	}
 
+	if plan.LoadVars != nil {
+		public.LoadVars = plan.LoadVars.Public()
+	}
+
+	if plan.Approval != nil {
+		public.Approval = plan.Approval.Public()
+	}
+
+	if plan.Run != nil {
+		public.Run = plan.Run.Public()
+	}
+
+	if plan.Publish != nil {
+		public.Publish = plan.Publish.Public()
+	}
+
 	if plan.OnAbort != nil {
 		public.OnAbort = plan.OnAbort.Public()
 	}
@@ -98,6 +120,14 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.Semaphore != nil {
+		public.Semaphore = plan.Semaphore.Public()
+	}
+
+	if plan.When != nil {
+		public.When = plan.When.Public()
+	}
+
 	if plan.ArtifactInput != nil {
 		public.ArtifactInput = plan.ArtifactInput.Public()
 	}
@@ -122,7 +152,7 @@ func (plan InParallelPlan) Public() *json.RawMessage {
 
 	return enc(struct {
 		Steps    []*json.RawMessage `json:"steps"`
-		Limit    int                `json:"limit,omitempty"`
+		Limit    InParallelLimit    `json:"limit,omitempty"`
 		FailFast bool               `json:"fail_fast,omitempty"`
 	}{
 		Steps:    steps,
@@ -145,14 +175,21 @@ func (plan AcrossPlan) Public() *json.RawMessage {
 		})
 	}
 
+	var template *json.RawMessage
+	if plan.SubStepTemplate != nil {
+		template = plan.SubStepTemplate.Public()
+	}
+
 	return enc(struct {
-		Vars     []AcrossVar  `json:"vars"`
-		Steps    []scopedStep `json:"steps"`
-		FailFast bool         `json:"fail_fast,omitempty"`
+		Vars            []AcrossVar      `json:"vars"`
+		Steps           []scopedStep     `json:"steps"`
+		SubStepTemplate *json.RawMessage `json:"substep_template,omitempty"`
+		FailFast        bool             `json:"fail_fast,omitempty"`
 	}{
-		Vars:     plan.Vars,
-		Steps:    steps,
-		FailFast: plan.FailFast,
+		Vars:            plan.Vars,
+		Steps:           steps,
+		SubStepTemplate: template,
+		FailFast:        plan.FailFast,
 	})
 }
 
@@ -178,15 +215,17 @@ func (plan EnsurePlan) Public() *json.RawMessage {
 
 func (plan GetPlan) Public() *json.RawMessage {
 	return enc(struct {
-		Name     string   `json:"name"`
-		Type     string   `json:"type"`
-		Resource string   `json:"resource,omitempty"`
-		Version  *Version `json:"version,omitempty"`
+		Name         string   `json:"name"`
+		Type         string   `json:"type"`
+		Resource     string   `json:"resource,omitempty"`
+		Version      *Version `json:"version,omitempty"`
+		VersionSince *Version `json:"version_since,omitempty"`
 	}{
-		Type:     plan.Type,
-		Name:     plan.Name,
-		Resource: plan.Resource,
-		Version:  plan.Version,
+		Type:         plan.Type,
+		Name:         plan.Name,
+		Resource:     plan.Resource,
+		Version:      plan.Version,
+		VersionSince: plan.VersionSince,
 	})
 }
 
@@ -294,21 +333,68 @@ func (plan LoadVarPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan LoadVarsPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Files string `json:"files"`
+	}{
+		Files: plan.Files,
+	})
+}
+
+func (plan ApprovalPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
+func (plan PublishPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
+func (plan RunPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name    string `json:"name"`
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	}{
+		Name:    plan.Name,
+		Message: plan.Message,
+		Type:    plan.Type,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
+	var onSoftTimeout *json.RawMessage
+	if plan.OnSoftTimeout != nil {
+		onSoftTimeout = plan.OnSoftTimeout.Public()
+	}
+
 	return enc(struct {
-		Step     *json.RawMessage `json:"step"`
-		Duration string           `json:"duration"`
+		Step          *json.RawMessage `json:"step"`
+		Duration      string           `json:"duration,omitempty"`
+		SoftDuration  string           `json:"soft_duration,omitempty"`
+		OnSoftTimeout *json.RawMessage `json:"on_soft_timeout,omitempty"`
 	}{
-		Step:     plan.Step.Public(),
-		Duration: plan.Duration,
+		Step:          plan.Step.Public(),
+		Duration:      plan.Duration,
+		SoftDuration:  plan.SoftDuration,
+		OnSoftTimeout: onSoftTimeout,
 	})
 }
 
 func (plan TryPlan) Public() *json.RawMessage {
 	return enc(struct {
-		Step *json.RawMessage `json:"step"`
+		Step     *json.RawMessage `json:"step"`
+		ErrorVar string           `json:"error_var,omitempty"`
 	}{
-		Step: plan.Step.Public(),
+		Step:     plan.Step.Public(),
+		ErrorVar: plan.ErrorVar,
 	})
 }
 
@@ -322,6 +408,28 @@ func (plan RetryPlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan SemaphorePlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step  *json.RawMessage `json:"step"`
+		Name  string           `json:"name"`
+		Limit int              `json:"limit"`
+	}{
+		Step:  plan.Step.Public(),
+		Name:  plan.Name,
+		Limit: plan.Limit,
+	})
+}
+
+func (plan WhenPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step      *json.RawMessage `json:"step"`
+		Condition string           `json:"condition"`
+	}{
+		Step:      plan.Step.Public(),
+		Condition: plan.Condition,
+	})
+}
+
 func (plan ArtifactInputPlan) Public() *json.RawMessage {
 	return enc(plan)
 }
@@ -337,7 +337,7 @@ var _ = Describe("Plan", func() {
 						{
 							ID: "36",
 							InParallel: &atc.InParallelPlan{
-								Limit:    1,
+								Limit:    "1",
 								FailFast: true,
 								Steps: []atc.Plan{
 									{
@@ -397,6 +397,17 @@ var _ = Describe("Plan", func() {
 								FailFast: true,
 							},
 						},
+						{
+							ID: "41",
+							Run: &atc.RunPlan{
+								Name:    "some-run",
+								Message: "run",
+								Type:    "some-prototype",
+								Object:  atc.Params{"some": "secret"},
+								Inputs:  []string{"some-input"},
+								Outputs: []string{"some-output"},
+							},
+						},
 					},
 				},
 			}
@@ -706,6 +717,14 @@ var _ = Describe("Plan", func() {
           ],
           "fail_fast": true
         }
+      },
+      {
+        "id": "41",
+        "run": {
+          "name": "some-run",
+          "message": "run",
+          "type": "some-prototype"
+        }
       }
     ]
   }
@@ -0,0 +1,69 @@
+package publish
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Digest returns the sha256 digest of the contents of a gzipped tarball.
+//
+// Only the paths, permissions, types, link targets and file contents of its
+// entries are digested, in order of their paths. Modification times,
+// ownership and the order the entries were archived in are ignored, so that
+// the same files produced by different builds have the same digest.
+func Digest(tgz io.Reader) (string, error) {
+	gzReader, err := gzip.NewReader(tgz)
+	if err != nil {
+		return "", fmt.Errorf("read gzip: %w", err)
+	}
+
+	defer gzReader.Close()
+
+	entries := []string{}
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("read tar: %w", err)
+		}
+
+		contents := sha256.New()
+
+		_, err = io.Copy(contents, tarReader)
+		if err != nil {
+			return "", fmt.Errorf("read tar: %w", err)
+		}
+
+		entries = append(entries, fmt.Sprintf(
+			"%q %o %c %q %x\n",
+			path.Clean(header.Name),
+			header.Mode&07777,
+			header.Typeflag,
+			header.Linkname,
+			contents.Sum(nil),
+		))
+	}
+
+	sort.Strings(entries)
+
+	hash := sha256.New()
+
+	_, err = io.WriteString(hash, strings.Join(entries, ""))
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
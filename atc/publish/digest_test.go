@@ -0,0 +1,95 @@
+package publish_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc/publish"
+)
+
+var _ = Describe("Digest", func() {
+	type file struct {
+		name     string
+		contents string
+		mode     int64
+		modTime  time.Time
+	}
+
+	tgz := func(files ...file) *bytes.Buffer {
+		buf := new(bytes.Buffer)
+
+		gzWriter := gzip.NewWriter(buf)
+		tarWriter := tar.NewWriter(gzWriter)
+
+		for _, f := range files {
+			err := tarWriter.WriteHeader(&tar.Header{
+				Name:     f.name,
+				Typeflag: tar.TypeReg,
+				Mode:     f.mode,
+				Size:     int64(len(f.contents)),
+				ModTime:  f.modTime,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = tarWriter.Write([]byte(f.contents))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		Expect(tarWriter.Close()).To(Succeed())
+		Expect(gzWriter.Close()).To(Succeed())
+
+		return buf
+	}
+
+	digest := func(files ...file) string {
+		sum, err := publish.Digest(tgz(files...))
+		Expect(err).ToNot(HaveOccurred())
+		return sum
+	}
+
+	someFile := file{name: "./some-file", contents: "some-contents", mode: 0644, modTime: time.Unix(1, 0)}
+	otherFile := file{name: "./other-file", contents: "other-contents", mode: 0755, modTime: time.Unix(1, 0)}
+
+	It("ignores modification times", func() {
+		touched := someFile
+		touched.modTime = time.Unix(2, 0)
+
+		Expect(digest(someFile, otherFile)).To(Equal(digest(touched, otherFile)))
+	})
+
+	It("ignores the order the files were archived in", func() {
+		Expect(digest(someFile, otherFile)).To(Equal(digest(otherFile, someFile)))
+	})
+
+	It("changes with the contents of a file", func() {
+		changed := someFile
+		changed.contents = "changed-contents"
+
+		Expect(digest(someFile)).ToNot(Equal(digest(changed)))
+	})
+
+	It("changes with the permissions of a file", func() {
+		changed := someFile
+		changed.mode = 0755
+
+		Expect(digest(someFile)).ToNot(Equal(digest(changed)))
+	})
+
+	It("changes with the name of a file", func() {
+		changed := someFile
+		changed.name = "./changed-file"
+
+		Expect(digest(someFile)).ToNot(Equal(digest(changed)))
+	})
+
+	It("errors when the contents are not a gzipped tarball", func() {
+		_, err := publish.Digest(strings.NewReader("some-contents"))
+		Expect(err).To(MatchError(ContainSubstring("read gzip")))
+	})
+})
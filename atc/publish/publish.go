@@ -0,0 +1,40 @@
+package publish
+
+import (
+	"context"
+	"io"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Store is an object store which build artifacts are published to.
+//
+//counterfeiter:generate . Store
+type Store interface {
+	// Upload streams the contents to a temporary key, as the key they are
+	// published under is only known once they have been read.
+	Upload(ctx context.Context, tmpKey string, contents io.Reader) error
+
+	// Publish moves the object uploaded to the temporary key to the given key
+	// and returns its location. As keys are derived from the contents, the
+	// object is not copied if one already exists under the key.
+	Publish(ctx context.Context, tmpKey string, key string) (string, error)
+
+	// Remove removes the object uploaded to the temporary key, e.g. when its
+	// contents could not be digested.
+	Remove(ctx context.Context, tmpKey string) error
+}
+
+type Config struct {
+	S3 S3
+}
+
+// Store returns the configured object store, or nil if there is none.
+func (c Config) Store() (Store, error) {
+	switch {
+	case c.S3.IsConfigured():
+		return c.S3.Store()
+	default:
+		return nil, nil
+	}
+}
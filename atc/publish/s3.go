@@ -0,0 +1,134 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3 publishes artifacts to an S3 bucket. Other object stores with an
+// S3-compatible API, such as Google Cloud Storage, can be used by setting
+// the endpoint.
+type S3 struct {
+	Bucket          string `long:"s3-bucket" description:"Bucket to publish artifacts to."`
+	Prefix          string `long:"s3-prefix" description:"Prefix of the keys of published artifacts."`
+	Region          string `long:"s3-region" default:"us-east-1" description:"Region of the bucket."`
+	Endpoint        string `long:"s3-endpoint" description:"Endpoint of an S3-compatible object store, e.g. https://storage.googleapis.com."`
+	ForcePathStyle  bool   `long:"s3-force-path-style" description:"Address the bucket as part of the path rather than the host name."`
+	AccessKeyID     string `long:"s3-access-key-id" description:"Access key ID to publish with. Defaults to the credentials of the environment."`
+	SecretAccessKey string `long:"s3-secret-access-key" description:"Secret access key to publish with."`
+	SessionToken    string `long:"s3-session-token" description:"Session token to publish with."`
+}
+
+func (s S3) IsConfigured() bool {
+	return s.Bucket != ""
+}
+
+func (s S3) Store() (Store, error) {
+	config := &aws.Config{
+		Region:           aws.String(s.Region),
+		S3ForcePathStyle: aws.Bool(s.ForcePathStyle),
+	}
+
+	if s.Endpoint != "" {
+		config.Endpoint = aws.String(s.Endpoint)
+	}
+
+	if s.AccessKeyID != "" {
+		config.Credentials = credentials.NewStaticCredentials(s.AccessKeyID, s.SecretAccessKey, s.SessionToken)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("create s3 session: %w", err)
+	}
+
+	return NewS3Store(s3.New(sess), s.Bucket, s.Prefix), nil
+}
+
+type s3Store struct {
+	client s3iface.S3API
+	bucket string
+	prefix string
+}
+
+func NewS3Store(client s3iface.S3API, bucket string, prefix string) Store {
+	return &s3Store{
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+	}
+}
+
+func (store *s3Store) Upload(ctx context.Context, tmpKey string, contents io.Reader) error {
+	// the uploader buffers one part at a time, so the contents are never held
+	// in full
+	_, err := s3manager.NewUploaderWithClient(store.client).UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(path.Join(store.prefix, tmpKey)),
+		Body:   contents,
+	})
+	if err != nil {
+		return fmt.Errorf("upload object: %w", err)
+	}
+
+	return nil
+}
+
+func (store *s3Store) Publish(ctx context.Context, tmpKey string, key string) (string, error) {
+	tmpKey = path.Join(store.prefix, tmpKey)
+	key = path.Join(store.prefix, key)
+	location := fmt.Sprintf("s3://%s/%s", store.bucket, key)
+
+	defer store.remove(ctx, tmpKey)
+
+	_, err := store.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(key),
+	})
+	if err == nil {
+		return location, nil
+	}
+
+	if reqErr, ok := err.(awserr.RequestFailure); !ok || reqErr.StatusCode() != http.StatusNotFound {
+		return "", fmt.Errorf("find object: %w", err)
+	}
+
+	_, err = store.client.CopyObjectWithContext(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(store.bucket),
+		Key:        aws.String(key),
+		CopySource: aws.String((&url.URL{Path: path.Join(store.bucket, tmpKey)}).EscapedPath()),
+	})
+	if err != nil {
+		return "", fmt.Errorf("copy object: %w", err)
+	}
+
+	return location, nil
+}
+
+func (store *s3Store) Remove(ctx context.Context, tmpKey string) error {
+	return store.remove(ctx, path.Join(store.prefix, tmpKey))
+}
+
+func (store *s3Store) remove(ctx context.Context, key string) error {
+	_, err := store.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("remove object: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package publish_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc/publish"
+)
+
+var _ = Describe("S3", func() {
+	var (
+		server *ghttp.Server
+		store  publish.Store
+
+		location   string
+		publishErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		var err error
+		store, err = publish.Config{
+			S3: publish.S3{
+				Bucket:          "some-bucket",
+				Prefix:          "some-prefix",
+				Region:          "some-region",
+				Endpoint:        server.URL(),
+				ForcePathStyle:  true,
+				AccessKeyID:     "some-access-key-id",
+				SecretAccessKey: "some-secret-access-key",
+			},
+		}.Store()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Upload", func() {
+		var uploadErr error
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/some-bucket/some-prefix/some-team/tmp/some-upload.tgz"),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(body)).To(Equal("some-contents"))
+					},
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			// hide the reader's Seek so that it is streamed like an artifact
+			contents := struct{ io.Reader }{strings.NewReader("some-contents")}
+			uploadErr = store.Upload(context.Background(), "some-team/tmp/some-upload.tgz", contents)
+		})
+
+		It("uploads the contents under the prefixed temporary key", func() {
+			Expect(uploadErr).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("Publish", func() {
+		JustBeforeEach(func() {
+			location, publishErr = store.Publish(context.Background(), "some-team/tmp/some-upload.tgz", "some-team/some-digest.tgz")
+		})
+
+		removesUpload := ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", "/some-bucket/some-prefix/some-team/tmp/some-upload.tgz"),
+			ghttp.RespondWith(http.StatusNoContent, nil),
+		)
+
+		Context("when the object does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("HEAD", "/some-bucket/some-prefix/some-team/some-digest.tgz"),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/some-bucket/some-prefix/some-team/some-digest.tgz"),
+						func(w http.ResponseWriter, r *http.Request) {
+							Expect(r.Header.Get("X-Amz-Copy-Source")).To(Equal("some-bucket/some-prefix/some-team/tmp/some-upload.tgz"))
+						},
+						ghttp.RespondWith(http.StatusOK, "<CopyObjectResult></CopyObjectResult>"),
+					),
+					removesUpload,
+				)
+			})
+
+			It("copies the upload to the prefixed key and removes it", func() {
+				Expect(publishErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+				Expect(location).To(Equal("s3://some-bucket/some-prefix/some-team/some-digest.tgz"))
+			})
+		})
+
+		Context("when the object already exists", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("HEAD", "/some-bucket/some-prefix/some-team/some-digest.tgz"),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+					removesUpload,
+				)
+			})
+
+			It("removes the upload without copying it", func() {
+				Expect(publishErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+				Expect(location).To(Equal("s3://some-bucket/some-prefix/some-team/some-digest.tgz"))
+			})
+		})
+
+		Context("when finding the object fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("HEAD", "/some-bucket/some-prefix/some-team/some-digest.tgz"),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+					removesUpload,
+				)
+			})
+
+			It("returns an error without copying the upload", func() {
+				Expect(publishErr).To(MatchError(ContainSubstring("find object")))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("Remove", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/some-bucket/some-prefix/some-team/tmp/some-upload.tgz"),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("removes the upload", func() {
+			Expect(store.Remove(context.Background(), "some-team/tmp/some-upload.tgz")).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
+
+var _ = Describe("Config", func() {
+	It("has no store unless one is configured", func() {
+		store, err := publish.Config{}.Store()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(store).To(BeNil())
+	})
+})
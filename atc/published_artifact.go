@@ -0,0 +1,8 @@
+package atc
+
+type PublishedArtifact struct {
+	Name        string `json:"name"`
+	Location    string `json:"location"`
+	Digest      string `json:"digest"`
+	PublishedAt int64  `json:"published_at"`
+}
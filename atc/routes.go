@@ -3,16 +3,20 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig     = "SaveConfig"
+	GetConfig      = "GetConfig"
+	ValidateConfig = "ValidateConfig"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
+	GetBuildPlanStatus  = "GetBuildPlanStatus"
 	CreateBuild         = "CreateBuild"
 	ListBuilds          = "ListBuilds"
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	ApproveBuild        = "ApproveBuild"
+	RejectBuild         = "RejectBuild"
 	GetBuildPreparation = "GetBuildPreparation"
 
 	GetJob         = "GetJob"
@@ -32,6 +36,9 @@ const (
 
 	ClearTaskCache = "ClearTaskCache"
 
+	PauseJobs   = "PauseJobs"
+	UnpauseJobs = "UnpauseJobs"
+
 	ListAllResources     = "ListAllResources"
 	ListResources        = "ListResources"
 	ListResourceTypes    = "ListResourceTypes"
@@ -68,14 +75,17 @@ const (
 	ListPipelineBuilds        = "ListPipelineBuilds"
 	CreatePipelineBuild       = "CreatePipelineBuild"
 	PipelineBadge             = "PipelineBadge"
-
-	RegisterWorker  = "RegisterWorker"
-	LandWorker      = "LandWorker"
-	RetireWorker    = "RetireWorker"
-	PruneWorker     = "PruneWorker"
-	HeartbeatWorker = "HeartbeatWorker"
-	ListWorkers     = "ListWorkers"
-	DeleteWorker    = "DeleteWorker"
+	PipelineGroupBadge        = "PipelineGroupBadge"
+	ListPipelineVarSources    = "ListPipelineVarSources"
+
+	RegisterWorker    = "RegisterWorker"
+	LandWorker        = "LandWorker"
+	RetireWorker      = "RetireWorker"
+	PruneWorker       = "PruneWorker"
+	HeartbeatWorker   = "HeartbeatWorker"
+	ReportWorkerUsage = "ReportWorkerUsage"
+	ListWorkers       = "ListWorkers"
+	DeleteWorker      = "DeleteWorker"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
@@ -101,16 +111,35 @@ const (
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
 
+	ListServiceAccounts  = "ListServiceAccounts"
+	CreateServiceAccount = "CreateServiceAccount"
+	DeleteServiceAccount = "DeleteServiceAccount"
+
+	ListTeamPrunableState = "ListTeamPrunableState"
+	PruneTeamState        = "PruneTeamState"
+
+	ListTeamWebhooks  = "ListTeamWebhooks"
+	SetTeamWebhook    = "SetTeamWebhook"
+	DeleteTeamWebhook = "DeleteTeamWebhook"
+
+	ListPipelineTemplates  = "ListPipelineTemplates"
+	SetPipelineTemplate    = "SetPipelineTemplate"
+	DeletePipelineTemplate = "DeletePipelineTemplate"
+
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
 
+	ListBuildPublishedArtifacts = "ListBuildPublishedArtifacts"
+
 	GetUser              = "GetUser"
 	ListActiveUsersSince = "ListActiveUsersSince"
 
 	SetWall   = "SetWall"
 	GetWall   = "GetWall"
 	ClearWall = "ClearWall"
+
+	Search = "Search"
 )
 
 const (
@@ -121,17 +150,22 @@ const (
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/validate", Method: "POST", Name: ValidateConfig},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
 	{Path: "/api/v1/builds/:build_id", Method: "GET", Name: GetBuild},
 	{Path: "/api/v1/builds/:build_id/plan", Method: "GET", Name: GetBuildPlan},
+	{Path: "/api/v1/builds/:build_id/plan/status", Method: "GET", Name: GetBuildPlanStatus},
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/approvals/:approval_name/approve", Method: "PUT", Name: ApproveBuild},
+	{Path: "/api/v1/builds/:build_id/approvals/:approval_name/reject", Method: "PUT", Name: RejectBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/published_artifacts", Method: "GET", Name: ListBuildPublishedArtifacts},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -143,6 +177,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/pause", Method: "PUT", Name: PauseJobs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/unpause", Method: "PUT", Name: UnpauseJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/schedule", Method: "PUT", Name: ScheduleJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: MainJobBadge},
@@ -165,6 +201,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "GET", Name: ListPipelineBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "POST", Name: CreatePipelineBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/badge", Method: "GET", Name: PipelineBadge},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/groups/:group_name/badge", Method: "GET", Name: PipelineGroupBadge},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/var-sources", Method: "GET", Name: ListPipelineVarSources},
 
 	{Path: "/api/v1/resources", Method: "GET", Name: ListAllResources},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
@@ -193,6 +231,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/workers/:worker_name/retire", Method: "PUT", Name: RetireWorker},
 	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
+	{Path: "/api/v1/workers/:worker_name/usage", Method: "PUT", Name: ReportWorkerUsage},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
 
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
@@ -221,6 +260,20 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/service-accounts", Method: "GET", Name: ListServiceAccounts},
+	{Path: "/api/v1/teams/:team_name/service-accounts", Method: "POST", Name: CreateServiceAccount},
+	{Path: "/api/v1/teams/:team_name/service-accounts/:service_account_name", Method: "DELETE", Name: DeleteServiceAccount},
+
+	{Path: "/api/v1/teams/:team_name/prunable-state", Method: "GET", Name: ListTeamPrunableState},
+	{Path: "/api/v1/teams/:team_name/prunable-state", Method: "DELETE", Name: PruneTeamState},
+
+	{Path: "/api/v1/teams/:team_name/webhooks", Method: "GET", Name: ListTeamWebhooks},
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_name", Method: "PUT", Name: SetTeamWebhook},
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_name", Method: "DELETE", Name: DeleteTeamWebhook},
+
+	{Path: "/api/v1/teams/:team_name/pipeline-templates", Method: "GET", Name: ListPipelineTemplates},
+	{Path: "/api/v1/teams/:team_name/pipeline-templates/:template_name", Method: "PUT", Name: SetPipelineTemplate},
+	{Path: "/api/v1/teams/:team_name/pipeline-templates/:template_name", Method: "DELETE", Name: DeletePipelineTemplate},
 
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
@@ -228,4 +281,6 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/wall", Method: "GET", Name: GetWall},
 	{Path: "/api/v1/wall", Method: "PUT", Name: SetWall},
 	{Path: "/api/v1/wall", Method: "DELETE", Name: ClearWall},
+
+	{Path: "/api/v1/search", Method: "GET", Name: Search},
 })
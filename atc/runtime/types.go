@@ -36,6 +36,16 @@ type GetRequest struct {
 	Params atc.Params `json:"params,omitempty"`
 }
 
+// RunRequest is sent to a prototype on stdin when running one of its
+// messages.
+type RunRequest struct {
+	Object atc.Params `json:"object,omitempty"`
+}
+
+// RunResponse is the list of objects a prototype writes to stdout in
+// response to a message.
+type RunResponse []atc.Params
+
 //counterfeiter:generate . Artifact
 type Artifact interface {
 	ID() string
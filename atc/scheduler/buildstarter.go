@@ -21,7 +21,7 @@ type BuildStarter interface {
 
 //counterfeiter:generate . BuildPlanner
 type BuildPlanner interface {
-	Create(atc.StepConfig, db.SchedulerResources, atc.VersionedResourceTypes, []db.BuildInput) (atc.Plan, error)
+	Create(atc.StepConfig, atc.StepDefaults, db.SchedulerResources, atc.VersionedResourceTypes, []db.BuildInput, map[string][]atc.Version) (atc.Plan, error)
 }
 
 type Build interface {
@@ -196,7 +196,14 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return startResults{}, fmt.Errorf("config: %w", err)
 	}
 
-	plan, err := s.planner.Create(config.StepConfig(), job.Resources, job.ResourceTypes, buildInputs)
+	defaults := config.Defaults.Merge(job.PipelineDefaults)
+
+	versions, err := acrossVersions(job, config.StepConfig())
+	if err != nil {
+		return startResults{}, fmt.Errorf("across versions: %w", err)
+	}
+
+	plan, err := s.planner.Create(config.StepConfig(), defaults, job.Resources, job.ResourceTypes, buildInputs, versions)
 	if err != nil {
 		logger.Error("failed-to-create-build-plan", err)
 
@@ -211,6 +218,13 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		}, nil
 	}
 
+	for resourceName, resourceVersions := range versions {
+		err = nextPendingBuild.SaveIteratedInputs(resourceName, resourceVersions)
+		if err != nil {
+			return startResults{}, fmt.Errorf("save iterated inputs: %w", err)
+		}
+	}
+
 	started, err := nextPendingBuild.Start(plan)
 	if err != nil {
 		logger.Error("failed-to-mark-build-as-started", err)
@@ -239,3 +253,42 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		finished: true,
 	}, nil
 }
+
+// acrossVersions fetches the versions of the resources iterated over by the
+// job's `across:` steps. Each resource's versions are fetched once, up to the
+// largest limit of the vars iterating over it.
+func acrossVersions(job db.SchedulerJob, step atc.StepConfig) (map[string][]atc.Version, error) {
+	limits := map[string]int{}
+
+	err := step.Visit(atc.StepRecursor{
+		OnAcross: func(step *atc.AcrossStep) error {
+			for _, v := range step.Vars {
+				if v.Versions == nil {
+					continue
+				}
+
+				limit := v.Versions.EffectiveLimit()
+				if limit > limits[v.Versions.Resource] {
+					limits[v.Versions.Resource] = limit
+				}
+			}
+
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := map[string][]atc.Version{}
+	for resourceName, limit := range limits {
+		resourceVersions, err := job.VersionsSinceLastSuccess(resourceName, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		versions[resourceName] = resourceVersions
+	}
+
+	return versions, nil
+}
@@ -410,7 +410,8 @@ var _ = Describe("BuildStarter", func() {
 				var rerunBuild *dbfakes.FakeBuild
 
 				var jobConfig = atc.JobConfig{
-					Name: "some-job",
+					Name:     "some-job",
+					Defaults: &atc.StepDefaults{Tags: atc.Tags{"job-tag"}},
 					PlanSequence: []atc.Step{
 						{
 							Config: &atc.GetStep{
@@ -450,6 +451,10 @@ var _ = Describe("BuildStarter", func() {
 									Version: atc.Version{"some": "version"},
 								},
 							},
+							PipelineDefaults: &atc.StepDefaults{
+								Timeout: "1h",
+								Tags:    atc.Tags{"pipeline-tag"},
+							},
 						},
 						jobInputs,
 					)
@@ -661,25 +666,144 @@ var _ = Describe("BuildStarter", func() {
 									It("creates build plans for all builds", func() {
 										Expect(fakePlanner.CreateCallCount()).To(Equal(3))
 
-										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualBuildInputs := fakePlanner.CreateArgsForCall(0)
+										actualPlanConfig, actualDefaults, actualResourceConfigs, actualResourceTypes, actualBuildInputs, actualVersions := fakePlanner.CreateArgsForCall(0)
 										Expect(actualPlanConfig).To(Equal(&atc.DoStep{Steps: jobConfig.PlanSequence}))
+										Expect(actualDefaults).To(Equal(atc.StepDefaults{
+											Timeout: "1h",
+											Tags:    atc.Tags{"job-tag"},
+										}))
 										Expect(actualResourceConfigs).To(Equal(db.SchedulerResources{{Name: "some-resource"}}))
 										Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
 										Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
+										Expect(actualVersions).To(BeEmpty())
 
-										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualBuildInputs = fakePlanner.CreateArgsForCall(1)
+										actualPlanConfig, _, actualResourceConfigs, actualResourceTypes, actualBuildInputs, _ = fakePlanner.CreateArgsForCall(1)
 										Expect(actualPlanConfig).To(Equal(&atc.DoStep{Steps: jobConfig.PlanSequence}))
 										Expect(actualResourceConfigs).To(Equal(db.SchedulerResources{{Name: "some-resource"}}))
 										Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
 										Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
 
-										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualBuildInputs = fakePlanner.CreateArgsForCall(2)
+										actualPlanConfig, _, actualResourceConfigs, actualResourceTypes, actualBuildInputs, _ = fakePlanner.CreateArgsForCall(2)
 										Expect(actualPlanConfig).To(Equal(&atc.DoStep{Steps: jobConfig.PlanSequence}))
 										Expect(actualResourceConfigs).To(Equal(db.SchedulerResources{{Name: "some-resource"}}))
 										Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
 										Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
 									})
 
+									Context("when the job iterates across resource versions", func() {
+										BeforeEach(func() {
+											job.ConfigReturns(atc.JobConfig{
+												Name: "some-job",
+												PlanSequence: []atc.Step{
+													{
+														Config: &atc.AcrossStep{
+															Step: &atc.GetStep{
+																Name: "some-input",
+															},
+															Vars: []atc.AcrossVarConfig{
+																{
+																	Var:      "version",
+																	Versions: &atc.AcrossVersionsConfig{Resource: "some-input"},
+																},
+															},
+														},
+													},
+													{
+														Config: &atc.AcrossStep{
+															Step: &atc.GetStep{
+																Name: "some-input",
+															},
+															Vars: []atc.AcrossVarConfig{
+																{
+																	Var:      "version",
+																	Versions: &atc.AcrossVersionsConfig{Resource: "some-input", Limit: 100},
+																},
+															},
+														},
+													},
+												},
+											}, nil)
+										})
+
+										Context("when fetching the versions succeeds", func() {
+											BeforeEach(func() {
+												job.VersionsSinceLastSuccessReturns([]atc.Version{{"some": "version"}}, nil)
+											})
+
+											It("plans the builds with the versions since the last successful build", func() {
+												Expect(job.VersionsSinceLastSuccessCallCount()).To(Equal(3))
+
+												_, _, _, _, _, actualVersions := fakePlanner.CreateArgsForCall(0)
+												Expect(actualVersions).To(Equal(map[string][]atc.Version{
+													"some-input": {{"some": "version"}},
+												}))
+											})
+
+											It("fetches each resource's versions once, up to the largest limit", func() {
+												resourceName, limit := job.VersionsSinceLastSuccessArgsForCall(0)
+												Expect(resourceName).To(Equal("some-input"))
+												Expect(limit).To(Equal(100))
+											})
+
+											It("records the versions as inputs of the builds", func() {
+												Expect(pendingBuild1.SaveIteratedInputsCallCount()).To(Equal(1))
+												resourceName, versions := pendingBuild1.SaveIteratedInputsArgsForCall(0)
+												Expect(resourceName).To(Equal("some-input"))
+												Expect(versions).To(Equal([]atc.Version{{"some": "version"}}))
+											})
+
+											Context("when recording the versions fails", func() {
+												BeforeEach(func() {
+													pendingBuild1.SaveIteratedInputsReturns(disaster)
+												})
+
+												It("returns the error without starting the build", func() {
+													Expect(tryStartErr).To(Equal(fmt.Errorf("save iterated inputs: %w", disaster)))
+													Expect(pendingBuild1.StartCallCount()).To(BeZero())
+												})
+											})
+										})
+
+										Context("when no limit is configured", func() {
+											BeforeEach(func() {
+												job.ConfigReturns(atc.JobConfig{
+													Name: "some-job",
+													PlanSequence: []atc.Step{
+														{
+															Config: &atc.AcrossStep{
+																Step: &atc.GetStep{
+																	Name: "some-input",
+																},
+																Vars: []atc.AcrossVarConfig{
+																	{
+																		Var:      "version",
+																		Versions: &atc.AcrossVersionsConfig{Resource: "some-input"},
+																	},
+																},
+															},
+														},
+													},
+												}, nil)
+											})
+
+											It("fetches up to the default limit", func() {
+												_, limit := job.VersionsSinceLastSuccessArgsForCall(0)
+												Expect(limit).To(Equal(atc.DefaultAcrossVersionsLimit))
+											})
+										})
+
+										Context("when fetching the versions fails", func() {
+											BeforeEach(func() {
+												job.VersionsSinceLastSuccessReturns(nil, disaster)
+											})
+
+											It("returns the error", func() {
+												Expect(tryStartErr).To(Equal(fmt.Errorf("across versions: %w", disaster)))
+												Expect(fakePlanner.CreateCallCount()).To(BeZero())
+											})
+										})
+									})
+
 									Context("when starting the build fails", func() {
 										BeforeEach(func() {
 											pendingBuild1.StartReturns(false, disaster)
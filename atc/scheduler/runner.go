@@ -23,20 +23,40 @@ type BuildScheduler interface {
 	) (bool, error)
 }
 
+// TeamWeights are the shares of the scheduler given to each team's jobs, by
+// team name. Teams which aren't listed have a weight of 1.
+type TeamWeights map[string]int
+
+func (weights TeamWeights) Weight(teamName string) int {
+	weight, found := weights[teamName]
+	if !found || weight < 1 {
+		return 1
+	}
+
+	return weight
+}
+
 type Runner struct {
-	logger     lager.Logger
-	jobFactory db.JobFactory
-	scheduler  BuildScheduler
+	logger      lager.Logger
+	jobFactory  db.JobFactory
+	scheduler   BuildScheduler
+	teamWeights TeamWeights
+
+	// the number of builds the cluster runs at once before teams are held to
+	// their share of them. 0 means teams are never held back.
+	buildCapacity uint64
 
 	guardJobScheduling chan struct{}
 	running            *sync.Map
 }
 
-func NewRunner(logger lager.Logger, jobFactory db.JobFactory, scheduler BuildScheduler, maxJobs uint64) *Runner {
+func NewRunner(logger lager.Logger, jobFactory db.JobFactory, scheduler BuildScheduler, maxJobs uint64, teamWeights TeamWeights, buildCapacity uint64) *Runner {
 	return &Runner{
-		logger:     logger,
-		jobFactory: jobFactory,
-		scheduler:  scheduler,
+		logger:        logger,
+		jobFactory:    jobFactory,
+		scheduler:     scheduler,
+		teamWeights:   teamWeights,
+		buildCapacity: buildCapacity,
 
 		guardJobScheduling: make(chan struct{}, maxJobs),
 		running:            &sync.Map{},
@@ -51,12 +71,31 @@ func (s *Runner) Run(ctx context.Context) error {
 	spanCtx, span := tracing.StartSpan(ctx, "scheduler.Run", nil)
 	defer span.End()
 
+	tickStart := time.Now()
+	defer func() {
+		metric.SchedulingTickDuration{
+			Duration: time.Since(tickStart),
+		}.Emit(sLog)
+	}()
+
 	jobs, err := s.jobFactory.JobsToSchedule()
 	if err != nil {
 		return fmt.Errorf("find jobs to schedule: %w", err)
 	}
 
-	for _, j := range jobs {
+	heldBack, err := s.teamsOverShare(jobs)
+	if err != nil {
+		return fmt.Errorf("find teams over their share: %w", err)
+	}
+
+	for _, j := range s.fairOrder(jobs) {
+		if heldBack[j.TeamName()] {
+			// the job stays requested, so it's scheduled on a later tick once
+			// its team is back under its share
+			sLog.Debug("team-over-share", lager.Data{"team": j.TeamName(), "job": j.Name()})
+			continue
+		}
+
 		if _, exists := s.running.LoadOrStore(j.ID(), true); exists {
 			// already scheduling this job
 			continue
@@ -101,6 +140,116 @@ func (s *Runner) Run(ctx context.Context) error {
 	return nil
 }
 
+// teamsOverShare returns the teams whose jobs are held back from scheduling
+// because the cluster is running as many builds as its capacity and the team
+// is running at least its share of them. The capacity is shared between the
+// teams which are running builds or have jobs to schedule, in proportion to
+// their weights. Teams under their share may still start builds, so that the
+// builds of teams over their share are replaced by theirs as they finish.
+//
+// The running builds are counted across the cluster, as the scheduler only
+// runs on one ATC at a time but builds are run by all of them.
+func (s *Runner) teamsOverShare(jobs db.SchedulerJobs) (map[string]bool, error) {
+	if s.buildCapacity == 0 || len(jobs) == 0 {
+		return nil, nil
+	}
+
+	running, err := s.jobFactory.RunningBuildsByTeam()
+	if err != nil {
+		return nil, err
+	}
+
+	var total uint64
+	for _, count := range running {
+		total += uint64(count)
+	}
+
+	if total < s.buildCapacity {
+		return nil, nil
+	}
+
+	active := map[string]bool{}
+	for team := range running {
+		active[team] = true
+	}
+
+	for _, job := range jobs {
+		active[job.TeamName()] = true
+	}
+
+	var totalWeight uint64
+	for team := range active {
+		totalWeight += uint64(s.teamWeights.Weight(team))
+	}
+
+	overShare := map[string]bool{}
+	for _, job := range jobs {
+		team := job.TeamName()
+
+		// compare running/capacity with weight/totalWeight without dividing
+		if uint64(running[team])*totalWeight >= s.buildCapacity*uint64(s.teamWeights.Weight(team)) {
+			overShare[team] = true
+		}
+	}
+
+	return overShare, nil
+}
+
+// fairOrder interleaves the jobs of each team in proportion to the teams'
+// weights, so that a team with many jobs to schedule can't hold up every
+// other team's pending builds while the runner waits for free slots. The jobs
+// come ordered by priority, and a team's jobs keep that order. Teams which
+// are tied go in the order they first appear.
+func (s *Runner) fairOrder(jobs db.SchedulerJobs) db.SchedulerJobs {
+	teamJobs := map[string]db.SchedulerJobs{}
+	var teams []string
+	for _, job := range jobs {
+		if _, found := teamJobs[job.TeamName()]; !found {
+			teams = append(teams, job.TeamName())
+		}
+
+		teamJobs[job.TeamName()] = append(teamJobs[job.TeamName()], job)
+	}
+
+	ordered := make(db.SchedulerJobs, 0, len(jobs))
+	taken := map[string]int{}
+	for len(ordered) < len(jobs) {
+		var next string
+		var found bool
+		for _, team := range teams {
+			if taken[team] == len(teamJobs[team]) {
+				continue
+			}
+
+			if !found || s.goesBefore(teamJobs[team][taken[team]], taken[team], teamJobs[next][taken[next]], taken[next]) {
+				next = team
+				found = true
+			}
+		}
+
+		ordered = append(ordered, teamJobs[next][taken[next]])
+		taken[next]++
+	}
+
+	return ordered
+}
+
+// goesBefore returns whether a team's next job goes before another team's,
+// given how many of each team's jobs have already been ordered. Higher
+// priority jobs go first. Among those of the same priority, the job of the
+// team which has had the fewest jobs ordered for its weight goes first.
+func (s *Runner) goesBefore(job db.SchedulerJob, taken int, other db.SchedulerJob, otherTaken int) bool {
+	if job.Priority() != other.Priority() {
+		return job.Priority() > other.Priority()
+	}
+
+	// compare (taken+1)/weight without dividing
+	share := (taken + 1) * s.teamWeights.Weight(other.TeamName())
+	otherShare := (otherTaken + 1) * s.teamWeights.Weight(job.TeamName())
+
+	return share < otherShare
+}
+
 func (s *Runner) scheduleJob(ctx context.Context, logger lager.Logger, job db.SchedulerJob) error {
 	metric.Metrics.JobsScheduling.Inc()
 	defer metric.Metrics.JobsScheduling.Dec()
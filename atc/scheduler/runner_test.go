@@ -26,6 +26,8 @@ var _ = Describe("Runner", func() {
 		fakePipeline  *dbfakes.FakePipeline
 		fakeScheduler *schedulerfakes.FakeBuildScheduler
 		maxInFlight   uint64
+		teamWeights   TeamWeights
+		buildCapacity uint64
 
 		lock *lockfakes.FakeLock
 
@@ -46,6 +48,8 @@ var _ = Describe("Runner", func() {
 		fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
 		fakeJobFactory = new(dbfakes.FakeJobFactory)
 		maxInFlight = 1
+		teamWeights = nil
+		buildCapacity = 0
 
 		lock = new(lockfakes.FakeLock)
 	})
@@ -56,6 +60,8 @@ var _ = Describe("Runner", func() {
 			fakeJobFactory,
 			fakeScheduler,
 			maxInFlight,
+			teamWeights,
+			buildCapacity,
 		)
 
 		schedulerErr = schedulerRunner.Run(context.TODO())
@@ -540,6 +546,181 @@ var _ = Describe("Runner", func() {
 		})
 	})
 
+	Context("when several teams have jobs to schedule", func() {
+		var scheduledJobs func() []string
+
+		BeforeEach(func() {
+			// each test records into its own list, so that jobs still being
+			// scheduled by an earlier test's runner aren't counted
+			var scheduledLock sync.Mutex
+			var scheduled []string
+
+			scheduledJobs = func() []string {
+				scheduledLock.Lock()
+				defer scheduledLock.Unlock()
+
+				return append([]string{}, scheduled...)
+			}
+
+			newJob := func(id int, teamName string, priority int) db.SchedulerJob {
+				fakeJob := new(dbfakes.FakeJob)
+				fakeJob.IDReturns(id)
+				fakeJob.NameReturns(fmt.Sprintf("%s-job-%d", teamName, id))
+				fakeJob.TeamNameReturns(teamName)
+				fakeJob.PriorityReturns(priority)
+				fakeJob.ReloadReturns(true, nil)
+				fakeJob.AcquireSchedulingLockReturns(lock, true, nil)
+
+				return db.SchedulerJob{Job: fakeJob}
+			}
+
+			fakeScheduler.ScheduleStub = func(_ context.Context, _ lager.Logger, job db.SchedulerJob) (bool, error) {
+				scheduledLock.Lock()
+				scheduled = append(scheduled, job.Name())
+				scheduledLock.Unlock()
+
+				return false, nil
+			}
+
+			// jobs come ordered by priority
+			fakeJobFactory.JobsToScheduleReturns([]db.SchedulerJob{
+				newJob(6, "other-team", 1),
+				newJob(1, "busy-team", 0),
+				newJob(2, "busy-team", 0),
+				newJob(3, "busy-team", 0),
+				newJob(4, "busy-team", 0),
+				newJob(5, "other-team", 0),
+			}, nil)
+		})
+
+		It("doesn't let a team with many jobs hold up another team's jobs", func() {
+			Eventually(scheduledJobs).Should(Equal([]string{
+				"other-team-job-6",
+				"busy-team-job-1",
+				"other-team-job-5",
+				"busy-team-job-2",
+				"busy-team-job-3",
+				"busy-team-job-4",
+			}))
+		})
+
+		Context("when a team has a higher weight", func() {
+			BeforeEach(func() {
+				teamWeights = TeamWeights{"busy-team": 2}
+			})
+
+			It("schedules its jobs in proportion to its weight", func() {
+				// other-team's higher priority job counts towards its share
+				Eventually(scheduledJobs).Should(Equal([]string{
+					"other-team-job-6",
+					"busy-team-job-1",
+					"busy-team-job-2",
+					"busy-team-job-3",
+					"other-team-job-5",
+					"busy-team-job-4",
+				}))
+			})
+		})
+
+		It("doesn't count the teams' running builds", func() {
+			Expect(fakeJobFactory.RunningBuildsByTeamCallCount()).To(Equal(0))
+		})
+
+		Context("when the cluster has a build capacity", func() {
+			BeforeEach(func() {
+				maxInFlight = 6
+				buildCapacity = 4
+			})
+
+			Context("when the cluster is running fewer builds than its capacity", func() {
+				BeforeEach(func() {
+					fakeJobFactory.RunningBuildsByTeamReturns(map[string]int{"busy-team": 3}, nil)
+				})
+
+				It("schedules every team's jobs", func() {
+					Eventually(scheduledJobs).Should(HaveLen(6))
+				})
+			})
+
+			Context("when one team's backlog fills the cluster", func() {
+				BeforeEach(func() {
+					fakeJobFactory.RunningBuildsByTeamReturns(map[string]int{"busy-team": 4}, nil)
+				})
+
+				It("holds back that team's jobs and schedules the other team's", func() {
+					Eventually(scheduledJobs).Should(ConsistOf(
+						"other-team-job-6",
+						"other-team-job-5",
+					))
+					Consistently(scheduledJobs).Should(HaveLen(2))
+				})
+			})
+
+			Context("when both teams are running their share", func() {
+				BeforeEach(func() {
+					fakeJobFactory.RunningBuildsByTeamReturns(map[string]int{
+						"busy-team":  2,
+						"other-team": 2,
+					}, nil)
+				})
+
+				It("holds back both teams' jobs", func() {
+					Consistently(scheduledJobs).Should(BeEmpty())
+				})
+			})
+
+			Context("when a team with a higher weight is under its share", func() {
+				BeforeEach(func() {
+					teamWeights = TeamWeights{"busy-team": 3}
+					fakeJobFactory.RunningBuildsByTeamReturns(map[string]int{
+						"busy-team":  2,
+						"other-team": 2,
+					}, nil)
+				})
+
+				It("schedules only that team's jobs", func() {
+					Eventually(scheduledJobs).Should(ConsistOf(
+						"busy-team-job-1",
+						"busy-team-job-2",
+						"busy-team-job-3",
+						"busy-team-job-4",
+					))
+					Consistently(scheduledJobs).Should(HaveLen(4))
+				})
+			})
+
+			Context("when a team with running builds has no jobs to schedule", func() {
+				BeforeEach(func() {
+					teamWeights = TeamWeights{"busy-team": 2}
+					fakeJobFactory.RunningBuildsByTeamReturns(map[string]int{
+						"busy-team": 2,
+						"idle-team": 2,
+					}, nil)
+				})
+
+				It("gives that team a share of the capacity", func() {
+					// busy-team's share is half of the capacity, not two thirds
+					Eventually(scheduledJobs).Should(ConsistOf(
+						"other-team-job-6",
+						"other-team-job-5",
+					))
+					Consistently(scheduledJobs).Should(HaveLen(2))
+				})
+			})
+
+			Context("when counting the running builds fails", func() {
+				BeforeEach(func() {
+					fakeJobFactory.RunningBuildsByTeamReturns(nil, errors.New("disaster"))
+				})
+
+				It("returns an error and schedules nothing", func() {
+					Expect(schedulerErr).To(MatchError(ContainSubstring("disaster")))
+					Consistently(scheduledJobs).Should(BeEmpty())
+				})
+			})
+		})
+	})
+
 	Context("when finding jobs to schedule fails", func() {
 		BeforeEach(func() {
 			fakeJobFactory.JobsToScheduleReturns(nil, errors.New("disaster"))
@@ -113,6 +113,20 @@ func (s *Scheduler) ensurePendingBuildExists(
 		}
 	}
 
+	// a build of one of the jobs this job runs after has finished since the
+	// last time it was triggered by them
+	if job.AfterFinishedTime().After(job.AfterTriggeredTime()) {
+		err := job.EnsurePendingBuildExists(ctx)
+		if err != nil {
+			return fmt.Errorf("ensure pending build exists: %w", err)
+		}
+
+		err = job.UpdateAfterTriggered(job.AfterFinishedTime())
+		if err != nil {
+			return fmt.Errorf("update after triggered: %w", err)
+		}
+	}
+
 	if hasNewInputs != job.HasNewInputs() {
 		if err := job.SetHasNewInputs(hasNewInputs); err != nil {
 			return fmt.Errorf("set has new inputs: %w", err)
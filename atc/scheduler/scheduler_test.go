@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
@@ -217,6 +218,62 @@ var _ = Describe("Scheduler", func() {
 			})
 		})
 
+		Context("when the job runs after other jobs", func() {
+			var finishedTime time.Time
+
+			BeforeEach(func() {
+				finishedTime = time.Now()
+
+				fakeJob.AlgorithmInputsReturns(nil, nil)
+				fakeAlgorithm.ComputeReturns(db.InputMapping{}, true, false, nil)
+				fakeJob.GetFullNextBuildInputsReturns([]db.BuildInput{}, true, nil)
+				fakeJob.AfterTriggeredTimeReturns(finishedTime.Add(-time.Minute))
+			})
+
+			Context("when one of them has finished a build since the job was last triggered", func() {
+				BeforeEach(func() {
+					fakeJob.AfterFinishedTimeReturns(finishedTime)
+				})
+
+				It("creates a pending build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+				})
+
+				It("records that the finished build triggered the job", func() {
+					Expect(fakeJob.UpdateAfterTriggeredCallCount()).To(Equal(1))
+					Expect(fakeJob.UpdateAfterTriggeredArgsForCall(0)).To(Equal(finishedTime))
+				})
+
+				It("starts the pending builds", func() {
+					Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+				})
+
+				Context("when creating a pending build fails", func() {
+					BeforeEach(func() {
+						fakeJob.EnsurePendingBuildExistsReturns(disaster)
+					})
+
+					It("returns the error without recording the trigger", func() {
+						Expect(scheduleErr).To(Equal(fmt.Errorf("ensure pending build exists: %w", disaster)))
+						Expect(fakeJob.UpdateAfterTriggeredCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when none of them has finished a build since the job was last triggered", func() {
+				BeforeEach(func() {
+					fakeJob.AfterFinishedTimeReturns(finishedTime.Add(-time.Minute))
+				})
+
+				It("doesn't create a pending build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+					Expect(fakeJob.UpdateAfterTriggeredCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when the job has one trigger: true input", func() {
 			BeforeEach(func() {
 				fakeJob.NameReturns("some-job")
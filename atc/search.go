@@ -0,0 +1,19 @@
+package atc
+
+const (
+	SearchKindPipeline = "pipeline"
+	SearchKindJob      = "job"
+	SearchKindResource = "resource"
+	SearchKindWorker   = "worker"
+)
+
+// SearchResult is a pipeline, job, resource or worker matching a search,
+// ranked by how closely its name matched.
+type SearchResult struct {
+	Kind                 string       `json:"kind"`
+	Name                 string       `json:"name"`
+	TeamName             string       `json:"team_name,omitempty"`
+	PipelineName         string       `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	Score                int          `json:"score"`
+}
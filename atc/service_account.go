@@ -0,0 +1,29 @@
+package atc
+
+import "time"
+
+// ServiceAccount is a non-human identity owned by a team. It authenticates
+// with a token issued at creation time and is granted a single role on its
+// team, independent of the team's auth config.
+type ServiceAccount struct {
+	ID        int    `json:"id,omitempty"`
+	Name      string `json:"name"`
+	TeamName  string `json:"team_name,omitempty"`
+	Role      string `json:"role"`
+	CreatedBy string `json:"created_by,omitempty"`
+	CreatedAt int64  `json:"created_at,omitempty"`
+	ExpiresAt int64  `json:"expires_at,omitempty"`
+}
+
+type CreateServiceAccountRequest struct {
+	Name string        `json:"name"`
+	Role string        `json:"role"`
+	TTL  time.Duration `json:"ttl,omitempty"`
+}
+
+// ServiceAccountToken is only returned when the service account is created;
+// the token can not be retrieved afterwards.
+type ServiceAccountToken struct {
+	ServiceAccount ServiceAccount `json:"service_account"`
+	Token          string         `json:"token"`
+}
@@ -0,0 +1,55 @@
+package atc
+
+// StepDefaults configure the steps of a job which run in a container (get,
+// put, task and check steps) when the steps don't configure the same thing
+// themselves. They may be set for a whole pipeline and for each job, in which
+// case the job's take precedence.
+type StepDefaults struct {
+	Timeout  string `json:"timeout,omitempty"`
+	Tags     Tags   `json:"tags,omitempty"`
+	Attempts int    `json:"attempts,omitempty"`
+
+	// Arch constrains the get, put and task steps to workers of the CPU
+	// architecture, e.g. arm64.
+	Arch string `json:"arch,omitempty"`
+
+	// ImageResource is used by tasks configured inline which don't configure
+	// an image.
+	ImageResource *ImageResource `json:"image_resource,omitempty"`
+}
+
+// Merge returns the defaults with any values they leave unset taken from the
+// given defaults, e.g. a job's defaults merged with its pipeline's. Either
+// may be nil.
+func (defaults *StepDefaults) Merge(parent *StepDefaults) StepDefaults {
+	var merged StepDefaults
+	if defaults != nil {
+		merged = *defaults
+	}
+
+	if parent == nil {
+		return merged
+	}
+
+	if merged.Timeout == "" {
+		merged.Timeout = parent.Timeout
+	}
+
+	if len(merged.Tags) == 0 {
+		merged.Tags = parent.Tags
+	}
+
+	if merged.Attempts == 0 {
+		merged.Attempts = parent.Attempts
+	}
+
+	if merged.Arch == "" {
+		merged.Arch = parent.Arch
+	}
+
+	if merged.ImageResource == nil {
+		merged.ImageResource = parent.ImageResource
+	}
+
+	return merged
+}
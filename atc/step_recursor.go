@@ -17,11 +17,31 @@ type StepRecursor struct {
 	// OnPut will be invoked for any *PutStep present in the StepConfig.
 	OnPut func(*PutStep) error
 
+	// OnCheck will be invoked for any *CheckStep present in the StepConfig.
+	OnCheck func(*CheckStep) error
+
 	// OnSetPipeline will be invoked for any *SetPipelineStep present in the StepConfig.
 	OnSetPipeline func(*SetPipelineStep) error
 
 	// OnLoadVar will be invoked for any *LoadVarStep present in the StepConfig.
 	OnLoadVar func(*LoadVarStep) error
+
+	// OnLoadVars will be invoked for any *LoadVarsStep present in the StepConfig.
+	OnLoadVars func(*LoadVarsStep) error
+
+	// OnApproval will be invoked for any *ApprovalStep present in the StepConfig.
+	OnApproval func(*ApprovalStep) error
+
+	// OnPublish will be invoked for any *PublishStep present in the StepConfig.
+	OnPublish func(*PublishStep) error
+
+	// OnAcross will be invoked for any *AcrossStep present in the StepConfig,
+	// before recursing through to the wrapped step.
+	OnAcross func(*AcrossStep) error
+
+	// StepTemplates are used to recurse through the steps of any *UseStep
+	// present in the StepConfig. Without them, *UseSteps are skipped.
+	StepTemplates StepTemplateConfigs
 }
 
 // VisitTask calls the OnTask hook if configured.
@@ -51,6 +71,15 @@ func (recursor StepRecursor) VisitPut(step *PutStep) error {
 	return nil
 }
 
+// VisitCheck calls the OnCheck hook if configured.
+func (recursor StepRecursor) VisitCheck(step *CheckStep) error {
+	if recursor.OnCheck != nil {
+		return recursor.OnCheck(step)
+	}
+
+	return nil
+}
+
 // VisitSetPipeline calls the OnSetPipeline hook if configured.
 func (recursor StepRecursor) VisitSetPipeline(step *SetPipelineStep) error {
 	if recursor.OnSetPipeline != nil {
@@ -69,6 +98,50 @@ func (recursor StepRecursor) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+// VisitLoadVars calls the OnLoadVars hook if configured.
+func (recursor StepRecursor) VisitLoadVars(step *LoadVarsStep) error {
+	if recursor.OnLoadVars != nil {
+		return recursor.OnLoadVars(step)
+	}
+
+	return nil
+}
+
+// VisitApproval calls the OnApproval hook if configured.
+func (recursor StepRecursor) VisitApproval(step *ApprovalStep) error {
+	if recursor.OnApproval != nil {
+		return recursor.OnApproval(step)
+	}
+
+	return nil
+}
+
+// VisitPublish calls the OnPublish hook if configured.
+func (recursor StepRecursor) VisitPublish(step *PublishStep) error {
+	if recursor.OnPublish != nil {
+		return recursor.OnPublish(step)
+	}
+
+	return nil
+}
+
+// VisitUse recurses through to the steps of the template, if it is known.
+func (recursor StepRecursor) VisitUse(step *UseStep) error {
+	steps, err := recursor.StepTemplates.Expand(step.Config)
+	if err != nil {
+		return nil
+	}
+
+	for _, sub := range steps {
+		err := sub.Config.Visit(recursor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // VisitTry recurses through to the wrapped step.
 func (recursor StepRecursor) VisitTry(step *TryStep) error {
 	return step.Step.Config.Visit(recursor)
@@ -98,14 +171,31 @@ func (recursor StepRecursor) VisitInParallel(step *InParallelStep) error {
 	return nil
 }
 
-// VisitAcross recurses through to the wrapped step.
+// VisitAcross calls the OnAcross hook if configured and recurses through to
+// the wrapped step.
 func (recursor StepRecursor) VisitAcross(step *AcrossStep) error {
+	if recursor.OnAcross != nil {
+		err := recursor.OnAcross(step)
+		if err != nil {
+			return err
+		}
+	}
+
 	return step.Step.Visit(recursor)
 }
 
-// VisitTimeout recurses through to the wrapped step.
+// VisitTimeout recurses through to the wrapped step and soft timeout hook.
 func (recursor StepRecursor) VisitTimeout(step *TimeoutStep) error {
-	return step.Step.Visit(recursor)
+	err := step.Step.Visit(recursor)
+	if err != nil {
+		return err
+	}
+
+	if step.OnSoftTimeout != nil {
+		return step.OnSoftTimeout.Config.Visit(recursor)
+	}
+
+	return nil
 }
 
 // VisitRetry recurses through to the wrapped step.
@@ -113,6 +203,16 @@ func (recursor StepRecursor) VisitRetry(step *RetryStep) error {
 	return step.Step.Visit(recursor)
 }
 
+// VisitSemaphore recurses through to the wrapped step.
+func (recursor StepRecursor) VisitSemaphore(step *SemaphoreStep) error {
+	return step.Step.Visit(recursor)
+}
+
+// VisitWhen recurses through to the wrapped step.
+func (recursor StepRecursor) VisitWhen(step *WhenStep) error {
+	return step.Step.Visit(recursor)
+}
+
 // VisitOnSuccess recurses through to the wrapped step and hook.
 func (recursor StepRecursor) VisitOnSuccess(step *OnSuccessStep) error {
 	err := step.Step.Visit(recursor)
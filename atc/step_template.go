@@ -0,0 +1,275 @@
+package atc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/concourse/concourse/vars"
+	"sigs.k8s.io/yaml"
+)
+
+// StepTemplateConfig is a group of steps defined once for a pipeline and used
+// by its jobs with a `use:` step. The steps may refer to the template's
+// params as ((vars)), which are filled in by each use of the template.
+type StepTemplateConfig struct {
+	Name   string                    `json:"name"`
+	Params []StepTemplateParamConfig `json:"params,omitempty"`
+	Steps  []Step                    `json:"steps"`
+}
+
+// StepTemplateParamConfig declares a param of a step template. A param
+// without a default must be given by each use of the template.
+type StepTemplateParamConfig struct {
+	Name    string      `json:"name"`
+	Default interface{} `json:"default,omitempty"`
+}
+
+type StepTemplateConfigs []StepTemplateConfig
+
+func (templates StepTemplateConfigs) Lookup(name string) (StepTemplateConfig, bool) {
+	for _, template := range templates {
+		if template.Name == name {
+			return template, true
+		}
+	}
+
+	return StepTemplateConfig{}, false
+}
+
+// Expand returns the steps of the template used by the given `use:` step,
+// with the template's params interpolated. Any other vars are left as they
+// are, to be resolved when the steps run.
+func (templates StepTemplateConfigs) Expand(use UseConfig) ([]Step, error) {
+	template, found := templates.Lookup(use.Template)
+	if !found {
+		return nil, fmt.Errorf("unknown step template '%s'", use.Template)
+	}
+
+	return template.Expand(use.Params)
+}
+
+// Expand returns the template's steps with the given params, and the
+// defaults of any params which aren't given, interpolated.
+func (template StepTemplateConfig) Expand(params Params) ([]Step, error) {
+	declared := map[string]bool{}
+	values := vars.StaticVariables{}
+
+	for _, param := range template.Params {
+		declared[param.Name] = true
+
+		value, found := params[param.Name]
+		if !found {
+			if param.Default == nil {
+				return nil, fmt.Errorf("missing param '%s'", param.Name)
+			}
+
+			value = param.Default
+		}
+
+		values[param.Name] = value
+	}
+
+	for name := range params {
+		if !declared[name] {
+			return nil, fmt.Errorf("unknown param '%s'", name)
+		}
+	}
+
+	payload, err := json.Marshal(template.Steps)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err = vars.NewTemplate(payload).Evaluate(values, vars.EvaluateOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []Step
+	err = yaml.Unmarshal(payload, &steps)
+	if err != nil {
+		return nil, fmt.Errorf("malformed steps: %w", err)
+	}
+
+	return steps, nil
+}
+
+// ExpandStepTemplates replaces each `use:` step of the config's jobs with a
+// `do:` step of the template's steps, so that the rest of the ATC never sees
+// them. The config is returned without its step templates.
+//
+// The jobs' steps are modified in place.
+func ExpandStepTemplates(config Config) (Config, error) {
+	if len(config.StepTemplates) == 0 {
+		return config, nil
+	}
+
+	expander := stepTemplateExpander{config.StepTemplates}
+
+	jobs := make(JobConfigs, len(config.Jobs))
+	for i, job := range config.Jobs {
+		for j := range job.PlanSequence {
+			err := expander.expandStep(&job.PlanSequence[j])
+			if err != nil {
+				return Config{}, fmt.Errorf("job '%s': %w", job.Name, err)
+			}
+		}
+
+		for _, hook := range []*Step{job.OnSuccess, job.OnFailure, job.OnAbort, job.OnError, job.Ensure} {
+			if hook == nil {
+				continue
+			}
+
+			err := expander.expandStep(hook)
+			if err != nil {
+				return Config{}, fmt.Errorf("job '%s': %w", job.Name, err)
+			}
+		}
+
+		jobs[i] = job
+	}
+
+	config.Jobs = jobs
+	config.StepTemplates = nil
+
+	return config, nil
+}
+
+// stepTemplateExpander is a StepVisitor which replaces the `use:` steps
+// nested in the steps it visits.
+type stepTemplateExpander struct {
+	templates StepTemplateConfigs
+}
+
+func (expander stepTemplateExpander) expand(step StepConfig) (StepConfig, error) {
+	if use, ok := step.(*UseStep); ok {
+		steps, err := expander.templates.Expand(use.Config)
+		if err != nil {
+			return nil, fmt.Errorf("use %s: %w", use.Config.Template, err)
+		}
+
+		return &DoStep{Steps: steps}, nil
+	}
+
+	return step, step.Visit(expander)
+}
+
+func (expander stepTemplateExpander) expandStep(step *Step) error {
+	config, err := expander.expand(step.Config)
+	if err != nil {
+		return err
+	}
+
+	step.Config = config
+
+	return nil
+}
+
+func (expander stepTemplateExpander) expandWrapped(step StepWrapper) error {
+	config, err := expander.expand(step.Unwrap())
+	if err != nil {
+		return err
+	}
+
+	step.Wrap(config)
+
+	return nil
+}
+
+func (expander stepTemplateExpander) expandHooked(step StepWrapper, hook *Step) error {
+	err := expander.expandWrapped(step)
+	if err != nil {
+		return err
+	}
+
+	return expander.expandStep(hook)
+}
+
+func (stepTemplateExpander) VisitTask(*TaskStep) error               { return nil }
+func (stepTemplateExpander) VisitGet(*GetStep) error                 { return nil }
+func (stepTemplateExpander) VisitPut(*PutStep) error                 { return nil }
+func (stepTemplateExpander) VisitCheck(*CheckStep) error             { return nil }
+func (stepTemplateExpander) VisitSetPipeline(*SetPipelineStep) error { return nil }
+func (stepTemplateExpander) VisitLoadVar(*LoadVarStep) error         { return nil }
+func (stepTemplateExpander) VisitLoadVars(*LoadVarsStep) error       { return nil }
+func (stepTemplateExpander) VisitApproval(*ApprovalStep) error       { return nil }
+func (stepTemplateExpander) VisitPublish(*PublishStep) error         { return nil }
+
+// VisitUse is never called, as `use:` steps are replaced before they are
+// visited.
+func (stepTemplateExpander) VisitUse(*UseStep) error { return nil }
+
+func (expander stepTemplateExpander) VisitTry(step *TryStep) error {
+	return expander.expandStep(&step.Step)
+}
+
+func (expander stepTemplateExpander) VisitDo(step *DoStep) error {
+	for i := range step.Steps {
+		err := expander.expandStep(&step.Steps[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (expander stepTemplateExpander) VisitInParallel(step *InParallelStep) error {
+	for i := range step.Config.Steps {
+		err := expander.expandStep(&step.Config.Steps[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (expander stepTemplateExpander) VisitAcross(step *AcrossStep) error {
+	return expander.expandWrapped(step)
+}
+
+func (expander stepTemplateExpander) VisitTimeout(step *TimeoutStep) error {
+	err := expander.expandWrapped(step)
+	if err != nil {
+		return err
+	}
+
+	if step.OnSoftTimeout != nil {
+		return expander.expandStep(step.OnSoftTimeout)
+	}
+
+	return nil
+}
+
+func (expander stepTemplateExpander) VisitRetry(step *RetryStep) error {
+	return expander.expandWrapped(step)
+}
+
+func (expander stepTemplateExpander) VisitSemaphore(step *SemaphoreStep) error {
+	return expander.expandWrapped(step)
+}
+
+func (expander stepTemplateExpander) VisitWhen(step *WhenStep) error {
+	return expander.expandWrapped(step)
+}
+
+func (expander stepTemplateExpander) VisitOnSuccess(step *OnSuccessStep) error {
+	return expander.expandHooked(step, &step.Hook)
+}
+
+func (expander stepTemplateExpander) VisitOnFailure(step *OnFailureStep) error {
+	return expander.expandHooked(step, &step.Hook)
+}
+
+func (expander stepTemplateExpander) VisitOnAbort(step *OnAbortStep) error {
+	return expander.expandHooked(step, &step.Hook)
+}
+
+func (expander stepTemplateExpander) VisitOnError(step *OnErrorStep) error {
+	return expander.expandHooked(step, &step.Hook)
+}
+
+func (expander stepTemplateExpander) VisitEnsure(step *EnsureStep) error {
+	return expander.expandHooked(step, &step.Hook)
+}
@@ -0,0 +1,175 @@
+package atc_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("StepTemplateConfig", func() {
+	var template atc.StepTemplateConfig
+
+	BeforeEach(func() {
+		template = atc.StepTemplateConfig{
+			Name: "some-template",
+			Params: []atc.StepTemplateParamConfig{
+				{Name: "file"},
+				{Name: "format", Default: "json"},
+			},
+			Steps: []atc.Step{
+				{
+					Config: &atc.LoadVarStep{
+						Name:   "some-var",
+						File:   "((file))",
+						Format: "((format))",
+					},
+				},
+				{
+					Config: &atc.LoadVarStep{
+						Name: "some-other-var",
+						File: "((some-secret))",
+					},
+				},
+			},
+		}
+	})
+
+	Describe("Expand", func() {
+		It("interpolates the params, leaving other vars alone", func() {
+			steps, err := template.Expand(atc.Params{"file": "some-file", "format": "yaml"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(steps).To(Equal([]atc.Step{
+				{
+					Config: &atc.LoadVarStep{
+						Name:   "some-var",
+						File:   "some-file",
+						Format: "yaml",
+					},
+				},
+				{
+					Config: &atc.LoadVarStep{
+						Name: "some-other-var",
+						File: "((some-secret))",
+					},
+				},
+			}))
+		})
+
+		It("uses the defaults of params which aren't given", func() {
+			steps, err := template.Expand(atc.Params{"file": "some-file"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(steps[0].Config).To(Equal(&atc.LoadVarStep{
+				Name:   "some-var",
+				File:   "some-file",
+				Format: "json",
+			}))
+		})
+
+		It("errors when a param without a default isn't given", func() {
+			_, err := template.Expand(atc.Params{})
+			Expect(err).To(MatchError("missing param 'file'"))
+		})
+
+		It("errors when an undeclared param is given", func() {
+			_, err := template.Expand(atc.Params{"file": "some-file", "bogus": "param"})
+			Expect(err).To(MatchError("unknown param 'bogus'"))
+		})
+	})
+})
+
+var _ = Describe("ExpandStepTemplates", func() {
+	var config atc.Config
+
+	BeforeEach(func() {
+		config = atc.Config{
+			StepTemplates: atc.StepTemplateConfigs{
+				{
+					Name:   "some-template",
+					Params: []atc.StepTemplateParamConfig{{Name: "file"}},
+					Steps: []atc.Step{
+						{
+							Config: &atc.LoadVarStep{
+								Name: "some-var",
+								File: "((file))",
+							},
+						},
+					},
+				},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					PlanSequence: []atc.Step{
+						{
+							Config: &atc.RetryStep{
+								Step: &atc.UseStep{
+									Config: atc.UseConfig{
+										Template: "some-template",
+										Params:   atc.Params{"file": "some-file"},
+									},
+								},
+								Attempts: 2,
+							},
+						},
+					},
+					Ensure: &atc.Step{
+						Config: &atc.UseStep{
+							Config: atc.UseConfig{
+								Template: "some-template",
+								Params:   atc.Params{"file": "some-other-file"},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("replaces the use steps with the templates' steps", func() {
+		expanded, err := atc.ExpandStepTemplates(config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expanded.StepTemplates).To(BeEmpty())
+		Expect(expanded.Jobs).To(Equal(atc.JobConfigs{
+			{
+				Name: "some-job",
+				PlanSequence: []atc.Step{
+					{
+						Config: &atc.RetryStep{
+							Step: &atc.DoStep{
+								Steps: []atc.Step{
+									{
+										Config: &atc.LoadVarStep{
+											Name: "some-var",
+											File: "some-file",
+										},
+									},
+								},
+							},
+							Attempts: 2,
+						},
+					},
+				},
+				Ensure: &atc.Step{
+					Config: &atc.DoStep{
+						Steps: []atc.Step{
+							{
+								Config: &atc.LoadVarStep{
+									Name: "some-var",
+									File: "some-other-file",
+								},
+							},
+						},
+					},
+				},
+			},
+		}))
+	})
+
+	It("errors when a template is unknown", func() {
+		config.StepTemplates = atc.StepTemplateConfigs{{Name: "some-other-template"}}
+
+		_, err := atc.ExpandStepTemplates(config)
+		Expect(err).To(MatchError("job 'some-job': use some-template: unknown step template 'some-template'"))
+	})
+})
@@ -2,6 +2,8 @@ package atc
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -27,10 +29,18 @@ type StepValidator struct {
 
 	seenGetName    scope
 	localVarScopes []scope
+
+	// whether the steps of a step template are being validated, as they
+	// can't use other templates
+	inStepTemplate bool
 }
 
 type scope map[string]bool
 
+// validServiceName matches the names of task services which can be turned
+// into the name of an environment variable.
+var validServiceName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
 // NewStepValidator is a constructor which initializes internal data.
 //
 // The Config specified is used to validate the existence of resources and jobs
@@ -80,6 +90,8 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		validator.recordError("must specify one of `file:` or `config:`, not both")
 	}
 
+	validator.validateRegistryMirror(plan.RegistryMirror)
+
 	if plan.Config != nil && (plan.Config.RootfsURI != "" || plan.Config.ImageResource != nil) && plan.ImageArtifactName != "" {
 		validator.recordWarning(ConfigWarning{
 			Type:    "pipeline",
@@ -96,13 +108,44 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 					validator.recordError(msg)
 				}
 			} else {
-				validator.recordError(err.Error())
+				validator.recordError("%s", err)
 			}
 		}
 
 		validator.popContext()
 	}
 
+	seenServices := map[string]bool{}
+	for i, service := range plan.Services {
+		validator.pushContext(fmt.Sprintf(".services[%d]", i))
+
+		if service.Name == "" {
+			validator.recordError("no name specified")
+		} else if seenServices[service.Name] {
+			validator.recordError("repeated name")
+		} else if !validServiceName.MatchString(service.Name) {
+			validator.recordError("invalid name '%s': must start with a letter and only contain letters, digits, '-' and '_'", service.Name)
+		}
+
+		seenServices[service.Name] = true
+
+		if service.Image == "" {
+			validator.recordError("no image specified")
+		}
+
+		if service.Run.Path == "" {
+			validator.recordError("missing path to executable to run")
+		}
+
+		if service.Ready != nil && service.Ready.Path == "" {
+			validator.pushContext(".ready")
+			validator.recordError("missing path to executable to run")
+			validator.popContext()
+		}
+
+		validator.popContext()
+	}
+
 	return nil
 }
 
@@ -131,6 +174,12 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 		validator.recordError("unknown resource '%s'", resourceName)
 	}
 
+	validator.validateRegistryMirror(step.RegistryMirror)
+
+	if step.Version != nil && step.Version.NewestAfterPassed && len(step.Passed) == 0 {
+		validator.recordError("version '%s' requires passed constraints", VersionNewestAfterPassed)
+	}
+
 	validator.pushContext(".passed")
 
 	for _, job := range step.Passed {
@@ -143,6 +192,7 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 		foundResource := false
 
 		_ = jobConfig.StepConfig().Visit(StepRecursor{
+			StepTemplates: validator.config.StepTemplates,
 			OnGet: func(input *GetStep) error {
 				if input.ResourceName() == resourceName {
 					foundResource = true
@@ -186,6 +236,37 @@ func (validator *StepValidator) VisitPut(step *PutStep) error {
 		validator.recordError("unknown resource '%s'", resourceName)
 	}
 
+	validator.validateRegistryMirror(step.RegistryMirror)
+
+	if step.NoGet && step.GetParams != nil {
+		validator.recordWarning(ConfigWarning{
+			Type:    "pipeline",
+			Message: validator.annotate("specifies get_params: but also no_get: - the get_params: are ignored as the put is not followed by a get"),
+		})
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitCheck(step *CheckStep) error {
+	validator.pushContext(".check(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	resourceName := step.ResourceName()
+
+	_, found := validator.config.Resources.Lookup(resourceName)
+	if !found {
+		validator.recordError("unknown resource '%s'", resourceName)
+	}
+
 	return nil
 }
 
@@ -229,11 +310,121 @@ func (validator *StepValidator) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+func (validator *StepValidator) VisitLoadVars(step *LoadVarsStep) error {
+	validator.pushContext(".load_vars(%s)", step.Files)
+	defer validator.popContext()
+
+	if step.Files == "" {
+		validator.recordError("no files specified")
+		return nil
+	}
+
+	if !strings.Contains(step.Files, "/") {
+		validator.recordError("files must be in an artifact, e.g. some-artifact/vars/*.yml")
+	}
+
+	if _, err := path.Match(step.Files, ""); err != nil {
+		validator.recordError("invalid glob: %s", err)
+	} else if strings.ContainsAny(path.Dir(step.Files), `*?[\`) {
+		validator.recordError("wildcards are only allowed in the file name, e.g. some-artifact/vars/*.yml")
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitApproval(step *ApprovalStep) error {
+	validator.pushContext(".approval(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	for i, approver := range step.Approvers {
+		parts := strings.SplitN(approver, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			validator.pushContext(".approvers[%d]", i)
+			validator.recordError("invalid approver '%s': must be given as <connector>:<user>, e.g. github:some-user", approver)
+			validator.popContext()
+		}
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitPublish(step *PublishStep) error {
+	validator.pushContext(".publish(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitUse(step *UseStep) error {
+	validator.pushContext(".use(%s)", step.Config.Template)
+	defer validator.popContext()
+
+	if validator.inStepTemplate {
+		validator.recordError("step templates cannot use other templates")
+		return nil
+	}
+
+	steps, err := validator.config.StepTemplates.Expand(step.Config)
+	if err != nil {
+		validator.recordError("%s", err)
+		return nil
+	}
+
+	validator.inStepTemplate = true
+	defer func() { validator.inStepTemplate = false }()
+
+	for i, sub := range steps {
+		validator.pushContext(".steps[%d]", i)
+
+		err := validator.Validate(sub)
+		if err != nil {
+			return err
+		}
+
+		validator.popContext()
+	}
+
+	return nil
+}
+
 func (validator *StepValidator) VisitTry(step *TryStep) error {
 	validator.pushContext(".try")
 	defer validator.popContext()
 
-	return validator.Validate(step.Step)
+	err := validator.Validate(step.Step)
+	if err != nil {
+		return err
+	}
+
+	if step.ErrorVar != "" {
+		warning, err := ValidateIdentifier(step.ErrorVar, validator.context...)
+		if err != nil {
+			validator.recordError(err.Error())
+		}
+		if warning != nil {
+			validator.recordWarning(*warning)
+		}
+
+		validator.declareLocalVar(step.ErrorVar)
+	}
+
+	return nil
 }
 
 func (validator *StepValidator) VisitDo(step *DoStep) error {
@@ -258,6 +449,15 @@ func (validator *StepValidator) VisitInParallel(step *InParallelStep) error {
 	validator.pushContext(".in_parallel")
 	defer validator.popContext()
 
+	// a limit given as a var is only known once the step runs
+	if step.Config.Limit != "" && !strings.Contains(string(step.Config.Limit), "((") {
+		if _, err := step.Config.Limit.Int(); err != nil {
+			validator.pushContext(".limit")
+			validator.recordError("%s", err)
+			validator.popContext()
+		}
+	}
+
 	for i, sub := range step.Config.Steps {
 		validator.pushContext(".steps[%d]", i)
 
@@ -292,6 +492,43 @@ func (validator *StepValidator) VisitAcross(step *AcrossStep) error {
 
 		validator.declareLocalVar(v.Var)
 
+		if v.Versions != nil {
+			validator.pushContext(".versions")
+			if len(v.Values) > 0 || v.ValuesFile != "" {
+				validator.recordError("cannot be specified alongside values or values_file")
+			} else {
+				// versions are resolved when the build is planned, whereas a
+				// values_file is read when the step runs
+				for _, other := range step.Vars {
+					if other.ValuesFile != "" {
+						validator.recordError("cannot be combined with vars from a values_file")
+						break
+					}
+				}
+			}
+
+			if _, found := validator.config.Resources.Lookup(v.Versions.Resource); !found {
+				validator.recordError("unknown resource '%s'", v.Versions.Resource)
+			}
+
+			if v.Versions.Limit < 0 {
+				validator.recordError("limit must not be negative")
+			}
+			validator.popContext()
+		}
+
+		if v.ValuesFile != "" {
+			validator.pushContext(".values_file")
+			if len(v.Values) > 0 {
+				validator.recordError("cannot be specified alongside values")
+			}
+
+			if !strings.Contains(v.ValuesFile, "/") {
+				validator.recordError("file must be in an artifact, e.g. some-artifact/values.json")
+			}
+			validator.popContext()
+		}
+
 		validator.pushContext(".max_in_flight")
 		if v.MaxInFlight != nil && !v.MaxInFlight.All && v.MaxInFlight.Limit <= 0 {
 			validator.recordError("must be greater than 0")
@@ -309,12 +546,36 @@ func (validator *StepValidator) VisitTimeout(step *TimeoutStep) error {
 		return err
 	}
 
-	validator.pushContext(".timeout")
-	defer validator.popContext()
+	var duration time.Duration
+	if step.Duration != "" || step.SoftDuration == "" {
+		validator.pushContext(".timeout")
+		duration, err = time.ParseDuration(step.Duration)
+		if err != nil {
+			validator.recordError("invalid duration '%s'", step.Duration)
+		}
+		validator.popContext()
+	}
 
-	_, err = time.ParseDuration(step.Duration)
-	if err != nil {
-		validator.recordError("invalid duration '%s'", step.Duration)
+	if step.SoftDuration != "" {
+		validator.pushContext(".soft_timeout")
+		softDuration, err := time.ParseDuration(step.SoftDuration)
+		if err != nil {
+			validator.recordError("invalid duration '%s'", step.SoftDuration)
+		} else if duration != 0 && softDuration >= duration {
+			validator.recordError("must be less than the timeout")
+		}
+		validator.popContext()
+	}
+
+	if step.OnSoftTimeout != nil {
+		validator.pushContext(".on_soft_timeout")
+		defer validator.popContext()
+
+		if step.SoftDuration == "" {
+			validator.recordError("no soft_timeout specified")
+		}
+
+		return validator.Validate(*step.OnSoftTimeout)
 	}
 
 	return nil
@@ -327,15 +588,89 @@ func (validator *StepValidator) VisitRetry(step *RetryStep) error {
 	}
 
 	validator.pushContext(".attempts")
-	defer validator.popContext()
-
 	if step.Attempts <= 0 {
 		validator.recordError("must be greater than 0")
 	}
+	validator.popContext()
+
+	if step.Backoff != nil {
+		validator.pushContext(".backoff")
+		validator.validateRetryBackoff(*step.Backoff)
+		validator.popContext()
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitSemaphore(step *SemaphoreStep) error {
+	err := step.Step.Visit(validator)
+	if err != nil {
+		return err
+	}
+
+	validator.pushContext(".serial_semaphore")
+	validator.validateSemaphore(step.Semaphore)
+	validator.popContext()
 
 	return nil
 }
 
+func (validator *StepValidator) VisitWhen(step *WhenStep) error {
+	err := step.Step.Visit(validator)
+	if err != nil {
+		return err
+	}
+
+	validator.pushContext(".when")
+	if _, err := ParseWhenCondition(step.Condition); err != nil {
+		validator.recordError("invalid condition: %s", err)
+	}
+	validator.popContext()
+
+	return nil
+}
+
+func (validator *StepValidator) validateSemaphore(semaphore SemaphoreConfig) {
+	if semaphore.Name == "" {
+		validator.recordError("no name specified")
+	}
+
+	// a limit of 0 is the same as leaving it out, which defaults to 1
+	if semaphore.Limit < 0 {
+		validator.pushContext(".limit")
+		validator.recordError("must not be negative")
+		validator.popContext()
+	}
+}
+
+func (validator *StepValidator) validateRetryBackoff(backoff RetryBackoffConfig) {
+	validator.pushContext(".initial")
+	if _, err := time.ParseDuration(backoff.Initial); err != nil {
+		validator.recordError("invalid duration '%s'", backoff.Initial)
+	}
+	validator.popContext()
+
+	validator.pushContext(".multiplier")
+	if backoff.Multiplier != 0 && backoff.Multiplier < 1 {
+		validator.recordError("must be at least 1")
+	}
+	validator.popContext()
+
+	if backoff.Max != "" {
+		validator.pushContext(".max")
+		if _, err := time.ParseDuration(backoff.Max); err != nil {
+			validator.recordError("invalid duration '%s'", backoff.Max)
+		}
+		validator.popContext()
+	}
+
+	validator.pushContext(".jitter")
+	if backoff.Jitter < 0 || backoff.Jitter > 1 {
+		validator.recordError("must be between 0 and 1")
+	}
+	validator.popContext()
+}
+
 func (validator *StepValidator) VisitOnSuccess(step *OnSuccessStep) error {
 	err := step.Step.Visit(validator)
 	if err != nil {
@@ -437,7 +772,17 @@ func (validator *StepValidator) localVarIsDeclared(name string) bool {
 	return false
 }
 
+// ErrorVarName is the name of the local var set before running an on_error or
+// on_failure hook. No step may declare a var with this name, so the hook
+// can't confuse it with the user's own.
+const ErrorVarName = "error"
+
 func (validator *StepValidator) declareLocalVar(name string) {
+	if name == ErrorVarName {
+		validator.recordError("var name '%s' is reserved for on_error and on_failure hooks", name)
+		return
+	}
+
 	if validator.currentLocalVarScope()[name] {
 		validator.recordError("repeated var name")
 	} else if validator.localVarIsDeclared(name) {
@@ -449,3 +794,9 @@ func (validator *StepValidator) declareLocalVar(name string) {
 
 	validator.currentLocalVarScope()[name] = true
 }
+
+func (validator *StepValidator) validateRegistryMirror(mirror *RegistryMirror) {
+	if mirror != nil && mirror.Host == "" {
+		validator.recordError("registry_mirror must specify a host")
+	}
+}
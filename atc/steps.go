@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -111,7 +112,15 @@ func (step *Step) UnmarshalJSON(data []byte) error {
 // calling .Unwrap to marshal all nested steps into one big set of fields which
 // is then marshalled and returned.
 func (step Step) MarshalJSON() ([]byte, error) {
-	fields := step.UnknownFields
+	// copy the unknown fields so that marshalling doesn't add the known ones
+	// to the step's own map
+	var fields map[string]*json.RawMessage
+	if step.UnknownFields != nil {
+		fields = make(map[string]*json.RawMessage, len(step.UnknownFields))
+		for name, value := range step.UnknownFields {
+			fields[name] = value
+		}
+	}
 
 	unwrapped := step.Config
 	for unwrapped != nil {
@@ -191,14 +200,21 @@ type StepVisitor interface {
 	VisitTask(*TaskStep) error
 	VisitGet(*GetStep) error
 	VisitPut(*PutStep) error
+	VisitCheck(*CheckStep) error
 	VisitSetPipeline(*SetPipelineStep) error
 	VisitLoadVar(*LoadVarStep) error
+	VisitLoadVars(*LoadVarsStep) error
+	VisitApproval(*ApprovalStep) error
+	VisitPublish(*PublishStep) error
+	VisitUse(*UseStep) error
 	VisitTry(*TryStep) error
 	VisitDo(*DoStep) error
 	VisitInParallel(*InParallelStep) error
 	VisitAcross(*AcrossStep) error
 	VisitTimeout(*TimeoutStep) error
 	VisitRetry(*RetryStep) error
+	VisitSemaphore(*SemaphoreStep) error
+	VisitWhen(*WhenStep) error
 	VisitOnSuccess(*OnSuccessStep) error
 	VisitOnFailure(*OnFailureStep) error
 	VisitOnAbort(*OnAbortStep) error
@@ -221,6 +237,11 @@ type StepDetector struct {
 // some important inter-modifier precedence - while core step types are parsed
 // last.
 var StepPrecedence = []StepDetector{
+	{
+		// parsed first so that a skipped step's hooks are skipped too
+		Key: "when",
+		New: func() StepConfig { return &WhenStep{} },
+	},
 	{
 		Key: "ensure",
 		New: func() StepConfig { return &EnsureStep{} },
@@ -249,6 +270,16 @@ var StepPrecedence = []StepDetector{
 		Key: "attempts",
 		New: func() StepConfig { return &RetryStep{} },
 	},
+	{
+		Key: "serial_semaphore",
+		New: func() StepConfig { return &SemaphoreStep{} },
+	},
+	{
+		// a soft timeout may be given without a timeout, so it's detected here
+		// rather than being consumed by the core step types' own timeouts
+		Key: "soft_timeout",
+		New: func() StepConfig { return &TimeoutStep{} },
+	},
 	{
 		Key: "task",
 		New: func() StepConfig { return &TaskStep{} },
@@ -261,6 +292,10 @@ var StepPrecedence = []StepDetector{
 		Key: "get",
 		New: func() StepConfig { return &GetStep{} },
 	},
+	{
+		Key: "check",
+		New: func() StepConfig { return &CheckStep{} },
+	},
 	{
 		Key: "timeout",
 		New: func() StepConfig { return &TimeoutStep{} },
@@ -273,6 +308,22 @@ var StepPrecedence = []StepDetector{
 		Key: "load_var",
 		New: func() StepConfig { return &LoadVarStep{} },
 	},
+	{
+		Key: "load_vars",
+		New: func() StepConfig { return &LoadVarsStep{} },
+	},
+	{
+		Key: "approval",
+		New: func() StepConfig { return &ApprovalStep{} },
+	},
+	{
+		Key: "publish",
+		New: func() StepConfig { return &PublishStep{} },
+	},
+	{
+		Key: "use",
+		New: func() StepConfig { return &UseStep{} },
+	},
 	{
 		Key: "try",
 		New: func() StepConfig { return &TryStep{} },
@@ -296,6 +347,9 @@ type GetStep struct {
 	Trigger  bool           `json:"trigger,omitempty"`
 	Tags     Tags           `json:"tags,omitempty"`
 	Timeout  string         `json:"timeout,omitempty"`
+	Fresh    bool           `json:"fresh,omitempty"`
+
+	RegistryMirror *RegistryMirror `json:"registry_mirror,omitempty"`
 }
 
 func (step *GetStep) ResourceName() string {
@@ -317,7 +371,10 @@ type PutStep struct {
 	Inputs    *InputsConfig `json:"inputs,omitempty"`
 	Tags      Tags          `json:"tags,omitempty"`
 	GetParams Params        `json:"get_params,omitempty"`
+	NoGet     bool          `json:"no_get,omitempty"`
 	Timeout   string        `json:"timeout,omitempty"`
+
+	RegistryMirror *RegistryMirror `json:"registry_mirror,omitempty"`
 }
 
 func (step *PutStep) ResourceName() string {
@@ -332,25 +389,71 @@ func (step *PutStep) Visit(v StepVisitor) error {
 	return v.VisitPut(step)
 }
 
+type CheckStep struct {
+	Name     string `json:"check"`
+	Resource string `json:"resource,omitempty"`
+	Tags     Tags   `json:"tags,omitempty"`
+	Timeout  string `json:"timeout,omitempty"`
+}
+
+func (step *CheckStep) ResourceName() string {
+	if step.Resource != "" {
+		return step.Resource
+	}
+
+	return step.Name
+}
+
+func (step *CheckStep) Visit(v StepVisitor) error {
+	return v.VisitCheck(step)
+}
+
 type TaskStep struct {
-	Name              string            `json:"task"`
-	Privileged        bool              `json:"privileged,omitempty"`
-	ConfigPath        string            `json:"file,omitempty"`
-	Limits            *ContainerLimits  `json:"container_limits,omitempty"`
-	Config            *TaskConfig       `json:"config,omitempty"`
-	Params            TaskEnv           `json:"params,omitempty"`
-	Vars              Params            `json:"vars,omitempty"`
-	Tags              Tags              `json:"tags,omitempty"`
-	InputMapping      map[string]string `json:"input_mapping,omitempty"`
-	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
-	ImageArtifactName string            `json:"image,omitempty"`
-	Timeout           string            `json:"timeout,omitempty"`
+	Name              string              `json:"task"`
+	Privileged        bool                `json:"privileged,omitempty"`
+	ConfigPath        string              `json:"file,omitempty"`
+	Limits            *ContainerLimits    `json:"container_limits,omitempty"`
+	Config            *TaskConfig         `json:"config,omitempty"`
+	Params            TaskEnv             `json:"params,omitempty"`
+	Vars              Params              `json:"vars,omitempty"`
+	Tags              Tags                `json:"tags,omitempty"`
+	InputMapping      map[string]string   `json:"input_mapping,omitempty"`
+	OutputMapping     map[string]string   `json:"output_mapping,omitempty"`
+	ImageArtifactName string              `json:"image,omitempty"`
+	Timeout           string              `json:"timeout,omitempty"`
+	BuildToken        bool                `json:"build_token,omitempty"`
+	Memoize           bool                `json:"memoize,omitempty"`
+	Services          []TaskServiceConfig `json:"services,omitempty"`
+	RegistryMirror    *RegistryMirror     `json:"registry_mirror,omitempty"`
 }
 
 func (step *TaskStep) Visit(v StepVisitor) error {
 	return v.VisitTask(step)
 }
 
+// TaskServiceConfig configures a service, such as a database, which runs
+// alongside a task for as long as the task runs. The service shares the
+// task's network, so the task can reach it on localhost.
+type TaskServiceConfig struct {
+	// The name of the service. The task is told where to reach it by the
+	// <NAME>_HOST environment variable.
+	Name string `json:"name"`
+
+	// An artifact in the build plan to use as the service's image.
+	Image string `json:"image"`
+
+	// Parameters to pass to the service via environment variables.
+	Params TaskEnv `json:"params,omitempty"`
+
+	// The command which runs the service.
+	Run TaskRunConfig `json:"run"`
+
+	// A command which exits successfully once the service is ready to be
+	// used. It is run in the service's container until it does, before the
+	// task starts.
+	Ready *TaskRunConfig `json:"ready,omitempty"`
+}
+
 type SetPipelineStep struct {
 	Name         string       `json:"set_pipeline"`
 	File         string       `json:"file,omitempty"`
@@ -358,6 +461,7 @@ type SetPipelineStep struct {
 	Vars         Params       `json:"vars,omitempty"`
 	VarFiles     []string     `json:"var_files,omitempty"`
 	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	DryRun       bool         `json:"dry_run,omitempty"`
 }
 
 func (step *SetPipelineStep) Visit(v StepVisitor) error {
@@ -375,8 +479,59 @@ func (step *LoadVarStep) Visit(v StepVisitor) error {
 	return v.VisitLoadVar(step)
 }
 
+type LoadVarsStep struct {
+	Files  string `json:"load_vars"`
+	Format string `json:"format,omitempty"`
+	Reveal bool   `json:"reveal,omitempty"`
+}
+
+func (step *LoadVarsStep) Visit(v StepVisitor) error {
+	return v.VisitLoadVars(step)
+}
+
+// ApprovalStep pauses the build until a user approves or rejects it. If
+// Approvers is set, only the listed users may do so; otherwise any user
+// allowed to approve the team's builds may. Approvers are given as
+// <connector>:<user>, like the users of a team's auth config.
+type ApprovalStep struct {
+	Name      string   `json:"approval"`
+	Approvers []string `json:"approvers,omitempty"`
+}
+
+func (step *ApprovalStep) Visit(v StepVisitor) error {
+	return v.VisitApproval(step)
+}
+
+// PublishStep uploads an artifact of the build to the object store
+// configured for publishing, recording where it was published.
+type PublishStep struct {
+	Name string `json:"publish"`
+}
+
+func (step *PublishStep) Visit(v StepVisitor) error {
+	return v.VisitPublish(step)
+}
+
+// UseStep runs the steps of one of the pipeline's step templates. It is
+// replaced by the template's steps when the pipeline is set.
+type UseStep struct {
+	Config UseConfig `json:"use"`
+}
+
+type UseConfig struct {
+	Template string `json:"template"`
+	Params   Params `json:"params,omitempty"`
+}
+
+func (step *UseStep) Visit(v StepVisitor) error {
+	return v.VisitUse(step)
+}
+
+// TryStep runs a step, ignoring its failure. The failure may be recorded in a
+// local var named by ErrorVar, which is left empty if the step succeeds.
 type TryStep struct {
-	Step Step `json:"try"`
+	Step     Step   `json:"try"`
+	ErrorVar string `json:"error_var,omitempty"`
 }
 
 func (step *TryStep) Visit(v StepVisitor) error {
@@ -400,9 +555,55 @@ func (step *InParallelStep) Visit(v StepVisitor) error {
 }
 
 type InParallelConfig struct {
-	Steps    []Step `json:"steps,omitempty"`
-	Limit    int    `json:"limit,omitempty"`
-	FailFast bool   `json:"fail_fast,omitempty"`
+	Steps    []Step          `json:"steps,omitempty"`
+	Limit    InParallelLimit `json:"limit,omitempty"`
+	FailFast bool            `json:"fail_fast,omitempty"`
+}
+
+// InParallelLimit is the maximum number of an in_parallel step's steps which
+// run at once. Like the timeout modifier's duration it is a string, so that it
+// can be a ((var)) which is resolved when the step runs, e.g. to a local var
+// set by a load_var step. It is given as either a number or a string.
+type InParallelLimit string
+
+func (limit *InParallelLimit) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte{'"'}) {
+		var s string
+		err := json.Unmarshal(data, &s)
+		if err != nil {
+			return err
+		}
+
+		*limit = InParallelLimit(s)
+		return nil
+	}
+
+	var n int
+	err := json.Unmarshal(data, &n)
+	if err != nil {
+		return err
+	}
+
+	*limit = InParallelLimit(strconv.Itoa(n))
+	return nil
+}
+
+func (limit InParallelLimit) MarshalJSON() ([]byte, error) {
+	if n, err := strconv.Atoi(string(limit)); err == nil {
+		return json.Marshal(n)
+	}
+
+	return json.Marshal(string(limit))
+}
+
+// Int parses the limit once any vars in it have been resolved.
+func (limit InParallelLimit) Int() (int, error) {
+	n, err := strconv.Atoi(string(limit))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid limit '%s': must be a number no less than 0", limit)
+	}
+
+	return n, nil
 }
 
 func (c *InParallelConfig) UnmarshalJSON(payload []byte) error {
@@ -435,11 +636,45 @@ func (c *InParallelConfig) UnmarshalJSON(payload []byte) error {
 }
 
 type AcrossVarConfig struct {
-	Var         string             `json:"var"`
-	Values      []interface{}      `json:"values,omitempty"`
+	Var    string        `json:"var"`
+	Values []interface{} `json:"values,omitempty"`
+
+	// ValuesFile is a file within an artifact containing the values as a
+	// JSON or YAML list, read when the step runs.
+	ValuesFile string `json:"values_file,omitempty"`
+
+	// Versions iterates over versions of a resource, resolved when the build
+	// is planned. Gets of the resource within the step fetch each version.
+	Versions *AcrossVersionsConfig `json:"versions,omitempty"`
+
 	MaxInFlight *MaxInFlightConfig `json:"max_in_flight,omitempty"`
 }
 
+// AcrossVersionsConfig selects the versions of a resource which are newer than
+// the one used by the job's latest successful build, oldest first. If no
+// successful build used the resource, only its newest version is selected.
+type AcrossVersionsConfig struct {
+	Resource string `json:"resource"`
+
+	// Limit selects only the newest versions, up to the limit. Defaults to
+	// DefaultAcrossVersionsLimit.
+	Limit int `json:"limit,omitempty"`
+}
+
+// DefaultAcrossVersionsLimit caps the versions iterated over when no limit is
+// configured, as each version is planned as a substep of its own.
+const DefaultAcrossVersionsLimit = 50
+
+// EffectiveLimit returns the configured limit, or the default if there is
+// none.
+func (config AcrossVersionsConfig) EffectiveLimit() int {
+	if config.Limit > 0 {
+		return config.Limit
+	}
+
+	return DefaultAcrossVersionsLimit
+}
+
 func (config *AcrossVarConfig) UnmarshalJSON(data []byte) error {
 	// Used to avoid infinite recursion when unmarshalling.
 	type target AcrossVarConfig
@@ -476,10 +711,30 @@ func (step *AcrossStep) Unwrap() StepConfig {
 }
 
 type RetryStep struct {
-	Step     StepConfig `json:"-"`
-	Attempts int        `json:"attempts"`
+	Step     StepConfig          `json:"-"`
+	Attempts int                 `json:"attempts"`
+	Backoff  *RetryBackoffConfig `json:"backoff,omitempty"`
+}
+
+// RetryBackoffConfig configures how long an `attempts:` step waits before
+// each retry. The first retry waits Initial, and each one after it waits
+// Multiplier times longer than the last, up to Max.
+type RetryBackoffConfig struct {
+	// like the timeout modifier's, durations are strings so that they can be
+	// parameterized with ((vars))
+	Initial    string  `json:"initial"`
+	Multiplier float64 `json:"multiplier,omitempty"`
+	Max        string  `json:"max,omitempty"`
+
+	// Jitter randomizes each delay by up to this fraction of it, so that
+	// steps which failed together don't all retry at the same moment.
+	Jitter float64 `json:"jitter,omitempty"`
 }
 
+// DefaultRetryBackoffMultiplier is used when the backoff does not configure
+// a multiplier.
+const DefaultRetryBackoffMultiplier = 2
+
 func (step *RetryStep) Wrap(sub StepConfig) {
 	step.Step = sub
 }
@@ -492,12 +747,90 @@ func (step *RetryStep) Visit(v StepVisitor) error {
 	return v.VisitRetry(step)
 }
 
+type SemaphoreStep struct {
+	Step      StepConfig      `json:"-"`
+	Semaphore SemaphoreConfig `json:"serial_semaphore"`
+}
+
+func (step *SemaphoreStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *SemaphoreStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *SemaphoreStep) Visit(v StepVisitor) error {
+	return v.VisitSemaphore(step)
+}
+
+// SemaphoreConfig names a semaphore shared by every pipeline of the team.
+// At most Limit steps holding the semaphore run at once, so steps which use
+// the same external system can coordinate across pipelines. It may be given
+// as just the name; a limit which is left out or set to 0 defaults to 1.
+// Every step using the semaphore must agree on its limit.
+type SemaphoreConfig struct {
+	Name  string `json:"name"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+func (c *SemaphoreConfig) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte{'"'}) {
+		return json.Unmarshal(data, &c.Name)
+	}
+
+	// Used to avoid infinite recursion when unmarshalling.
+	type target SemaphoreConfig
+
+	var t target
+	if err := unmarshalStrict(data, &t); err != nil {
+		return err
+	}
+
+	*c = SemaphoreConfig(t)
+	return nil
+}
+
+// EffectiveLimit returns the configured limit, defaulting to 1.
+func (c SemaphoreConfig) EffectiveLimit() int {
+	if c.Limit == 0 {
+		return 1
+	}
+
+	return c.Limit
+}
+
+// WhenStep runs its step only if the condition holds when the step is
+// reached. Otherwise the step is skipped, which does not fail the build. See
+// WhenCondition for the syntax of the condition.
+type WhenStep struct {
+	Step      StepConfig `json:"-"`
+	Condition string     `json:"when"`
+}
+
+func (step *WhenStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *WhenStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *WhenStep) Visit(v StepVisitor) error {
+	return v.VisitWhen(step)
+}
+
 type TimeoutStep struct {
 	Step StepConfig `json:"-"`
 
 	// it's very tempting to make this a Duration type, but that would probably
 	// prevent using `((vars))` to parameterize it
-	Duration string `json:"timeout"`
+	Duration string `json:"timeout,omitempty"`
+
+	// SoftDuration is how long the step may run before a warning is emitted
+	// and OnSoftTimeout is run. Unlike Duration, the step is not interrupted.
+	SoftDuration  string `json:"soft_timeout,omitempty"`
+	OnSoftTimeout *Step  `json:"on_soft_timeout,omitempty"`
 }
 
 func (step *TimeoutStep) Wrap(sub StepConfig) {
@@ -647,14 +980,22 @@ func (c *MaxInFlightConfig) EffectiveLimit(numSteps int) int {
 
 // A VersionConfig represents the choice to include every version of a
 // resource, the latest version of a resource, or a pinned (specific) one.
+//
+// A get step may instead fetch the newest version since a given one, or the
+// newest version since the one which satisfied its passed constraints. These
+// are resolved when the step runs, so that a build consumes everything up to
+// the newest version at that time.
 type VersionConfig struct {
-	Every  bool
-	Latest bool
-	Pinned Version
+	Every             bool
+	Latest            bool
+	Pinned            Version
+	Since             Version
+	NewestAfterPassed bool
 }
 
 const VersionLatest = "latest"
 const VersionEvery = "every"
+const VersionNewestAfterPassed = "newest_after_passed"
 
 func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 	var data interface{}
@@ -668,16 +1009,21 @@ func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 	case string:
 		c.Every = actual == VersionEvery
 		c.Latest = actual == VersionLatest
+		c.NewestAfterPassed = actual == VersionNewestAfterPassed
 	case map[string]interface{}:
-		version := Version{}
-
-		for k, v := range actual {
-			if s, ok := v.(string); ok {
-				version[k] = s
-				continue
+		if since, ok := actual["since"].(map[string]interface{}); ok && len(actual) == 1 {
+			version, err := versionFromMap(since)
+			if err != nil {
+				return err
 			}
 
-			return fmt.Errorf("the value %v of %s is not a string", v, k)
+			c.Since = version
+			return nil
+		}
+
+		version, err := versionFromMap(actual)
+		if err != nil {
+			return err
 		}
 
 		c.Pinned = version
@@ -688,6 +1034,21 @@ func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 	return nil
 }
 
+func versionFromMap(actual map[string]interface{}) (Version, error) {
+	version := Version{}
+
+	for k, v := range actual {
+		if s, ok := v.(string); ok {
+			version[k] = s
+			continue
+		}
+
+		return nil, fmt.Errorf("the value %v of %s is not a string", v, k)
+	}
+
+	return version, nil
+}
+
 func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 	if c.Latest {
 		return json.Marshal(VersionLatest)
@@ -697,10 +1058,18 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 		return json.Marshal(VersionEvery)
 	}
 
+	if c.NewestAfterPassed {
+		return json.Marshal(VersionNewestAfterPassed)
+	}
+
 	if c.Pinned != nil {
 		return json.Marshal(c.Pinned)
 	}
 
+	if c.Since != nil {
+		return json.Marshal(map[string]Version{"since": c.Since})
+	}
+
 	return json.Marshal("")
 }
 
@@ -35,6 +35,7 @@ var factoryTests = []StepTest{
 			version: {some: version}
 			tags: [tag-1, tag-2]
 			timeout: 1h
+			fresh: true
 		`,
 		StepConfig: &atc.GetStep{
 			Name:     "some-name",
@@ -43,6 +44,7 @@ var factoryTests = []StepTest{
 			Version:  &atc.VersionConfig{Pinned: atc.Version{"some": "version"}},
 			Tags:     []string{"tag-1", "tag-2"},
 			Timeout:  "1h",
+			Fresh:    true,
 		},
 	},
 	{
@@ -67,6 +69,18 @@ var factoryTests = []StepTest{
 			Timeout:   "1h",
 		},
 	},
+	{
+		Title: "put step without get",
+
+		ConfigYAML: `
+			put: some-name
+			no_get: true
+		`,
+		StepConfig: &atc.PutStep{
+			Name:  "some-name",
+			NoGet: true,
+		},
+	},
 	{
 		Title: "task step",
 
@@ -103,6 +117,37 @@ var factoryTests = []StepTest{
 			Timeout:           "1h",
 		},
 	},
+	{
+		Title: "task step with services",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-task-file
+			services:
+			- name: postgres
+			  image: postgres-image
+			  params: {POSTGRES_PASSWORD: password}
+			  run:
+			    path: docker-entrypoint.sh
+			    args: [postgres]
+		`,
+
+		StepConfig: &atc.TaskStep{
+			Name:       "some-task",
+			ConfigPath: "some-task-file",
+			Services: []atc.TaskServiceConfig{
+				{
+					Name:   "postgres",
+					Image:  "postgres-image",
+					Params: atc.TaskEnv{"POSTGRES_PASSWORD": "password"},
+					Run: atc.TaskRunConfig{
+						Path: "docker-entrypoint.sh",
+						Args: []string{"postgres"},
+					},
+				},
+			},
+		},
+	},
 	{
 		Title: "task step with container limits",
 
@@ -174,6 +219,7 @@ var factoryTests = []StepTest{
 			vars: {some: vars}
 			var_files: [file-1, file-2]
 			instance_vars: {branch: feature/foo}
+			dry_run: true
 		`,
 
 		StepConfig: &atc.SetPipelineStep{
@@ -182,6 +228,41 @@ var factoryTests = []StepTest{
 			Vars:         atc.Params{"some": "vars"},
 			VarFiles:     []string{"file-1", "file-2"},
 			InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
+			DryRun:       true,
+		},
+	},
+	{
+		Title: "check step",
+
+		ConfigYAML: `
+			check: some-name
+			resource: some-resource
+			tags: [tag-1, tag-2]
+			timeout: 1h
+		`,
+
+		StepConfig: &atc.CheckStep{
+			Name:     "some-name",
+			Resource: "some-resource",
+			Tags:     []string{"tag-1", "tag-2"},
+			Timeout:  "1h",
+		},
+	},
+	{
+		Title: "check step with attempts",
+
+		ConfigYAML: `
+			check: some-name
+			timeout: 1h
+			attempts: 3
+		`,
+
+		StepConfig: &atc.RetryStep{
+			Step: &atc.CheckStep{
+				Name:    "some-name",
+				Timeout: "1h",
+			},
+			Attempts: 3,
 		},
 	},
 	{
@@ -201,6 +282,62 @@ var factoryTests = []StepTest{
 			Reveal: true,
 		},
 	},
+	{
+		Title: "load_vars step",
+
+		ConfigYAML: `
+			load_vars: some-artifact/vars/*.yml
+			format: yaml
+			reveal: true
+		`,
+
+		StepConfig: &atc.LoadVarsStep{
+			Files:  "some-artifact/vars/*.yml",
+			Format: "yaml",
+			Reveal: true,
+		},
+	},
+	{
+		Title: "approval step",
+
+		ConfigYAML: `
+			approval: deploy-to-prod
+			approvers: [some-user, some-other-user]
+		`,
+
+		StepConfig: &atc.ApprovalStep{
+			Name:      "deploy-to-prod",
+			Approvers: []string{"some-user", "some-other-user"},
+		},
+	},
+	{
+		Title: "publish step",
+
+		ConfigYAML: `
+			publish: some-artifact
+		`,
+
+		StepConfig: &atc.PublishStep{
+			Name: "some-artifact",
+		},
+	},
+	{
+		Title: "use step",
+
+		ConfigYAML: `
+			use:
+			  template: some-template
+			  params:
+			    some: param
+		`,
+
+		StepConfig: &atc.UseStep{
+			Config: atc.UseConfig{
+				Template: "some-template",
+				Params:   atc.Params{"some": "param"},
+			},
+		},
+	},
 	{
 		Title: "try step",
 
@@ -219,6 +356,26 @@ var factoryTests = []StepTest{
 			},
 		},
 	},
+	{
+		Title: "try step with an error var",
+
+		ConfigYAML: `
+			try:
+			  load_var: some-var
+			  file: some-file
+			error_var: try_error
+		`,
+
+		StepConfig: &atc.TryStep{
+			Step: atc.Step{
+				Config: &atc.LoadVarStep{
+					Name: "some-var",
+					File: "some-file",
+				},
+			},
+			ErrorVar: "try_error",
+		},
+	},
 	{
 		Title: "do step",
 
@@ -307,11 +464,36 @@ var factoryTests = []StepTest{
 						},
 					},
 				},
-				Limit:    3,
+				Limit:    "3",
 				FailFast: true,
 			},
 		},
 	},
+	{
+		Title: "in_parallel step with a var limit",
+
+		ConfigYAML: `
+			in_parallel:
+			  steps:
+			  - load_var: some-var
+			    file: some-file
+			  limit: ((.:width))
+		`,
+
+		StepConfig: &atc.InParallelStep{
+			Config: atc.InParallelConfig{
+				Steps: []atc.Step{
+					{
+						Config: &atc.LoadVarStep{
+							Name: "some-var",
+							File: "some-file",
+						},
+					},
+				},
+				Limit: "((.:width))",
+			},
+		},
+	},
 	{
 		Title: "across step",
 
@@ -354,6 +536,57 @@ var factoryTests = []StepTest{
 			FailFast: true,
 		},
 	},
+	{
+		Title: "across step with a values file",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			across:
+			- var: var1
+			  values_file: some-artifact/values.json
+		`,
+
+		StepConfig: &atc.AcrossStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var:        "var1",
+					ValuesFile: "some-artifact/values.json",
+				},
+			},
+		},
+	},
+	{
+		Title: "across step over resource versions",
+
+		ConfigYAML: `
+			get: some-resource
+			across:
+			- var: version
+			  versions:
+			    resource: some-resource
+			    limit: 5
+		`,
+
+		StepConfig: &atc.AcrossStep{
+			Step: &atc.GetStep{
+				Name: "some-resource",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var: "version",
+					Versions: &atc.AcrossVersionsConfig{
+						Resource: "some-resource",
+						Limit:    5,
+					},
+				},
+			},
+		},
+	},
 	{
 		Title: "across step with invalid field",
 
@@ -399,6 +632,51 @@ var factoryTests = []StepTest{
 			Duration: "1h",
 		},
 	},
+	{
+		Title: "soft timeout modifier",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			timeout: 1h
+			soft_timeout: 30m
+			on_soft_timeout:
+			  load_var: soft-var
+			  file: soft-file
+		`,
+
+		StepConfig: &atc.TimeoutStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Duration:     "1h",
+			SoftDuration: "30m",
+			OnSoftTimeout: &atc.Step{
+				Config: &atc.LoadVarStep{
+					Name: "soft-var",
+					File: "soft-file",
+				},
+			},
+		},
+	},
+	{
+		Title: "soft timeout modifier on a task",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-file
+			soft_timeout: 30m
+		`,
+
+		StepConfig: &atc.TimeoutStep{
+			Step: &atc.TaskStep{
+				Name:       "some-task",
+				ConfigPath: "some-file",
+			},
+			SoftDuration: "30m",
+		},
+	},
 	{
 		Title: "attempts modifier",
 
@@ -416,6 +694,103 @@ var factoryTests = []StepTest{
 			Attempts: 3,
 		},
 	},
+	{
+		Title: "attempts modifier with backoff",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			attempts: 3
+			backoff:
+			  initial: 10s
+			  multiplier: 1.5
+			  max: 1m
+			  jitter: 0.1
+		`,
+
+		StepConfig: &atc.RetryStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Attempts: 3,
+			Backoff: &atc.RetryBackoffConfig{
+				Initial:    "10s",
+				Multiplier: 1.5,
+				Max:        "1m",
+				Jitter:     0.1,
+			},
+		},
+	},
+	{
+		Title: "serial_semaphore modifier",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			serial_semaphore: some-semaphore
+		`,
+
+		StepConfig: &atc.SemaphoreStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Semaphore: atc.SemaphoreConfig{
+				Name: "some-semaphore",
+			},
+		},
+	},
+	{
+		Title: "serial_semaphore modifier with a limit",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			serial_semaphore:
+			  name: some-semaphore
+			  limit: 3
+		`,
+
+		StepConfig: &atc.SemaphoreStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Semaphore: atc.SemaphoreConfig{
+				Name:  "some-semaphore",
+				Limit: 3,
+			},
+		},
+	},
+	{
+		Title: "when modifier",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			when: .:deploy == true && build.trigger != "manual"
+			ensure:
+			  load_var: ensure-var
+			  file: ensure-file
+		`,
+
+		StepConfig: &atc.WhenStep{
+			Step: &atc.EnsureStep{
+				Step: &atc.LoadVarStep{
+					Name: "some-var",
+					File: "some-file",
+				},
+				Hook: atc.Step{
+					Config: &atc.LoadVarStep{
+						Name: "ensure-var",
+						File: "ensure-file",
+					},
+				},
+			},
+			Condition: `.:deploy == true && build.trigger != "manual"`,
+		},
+	},
 	{
 		Title: "precedence of all hooks and modifiers",
 
@@ -563,6 +938,7 @@ func (test StepTest) Run(s *StepsSuite) {
 
 	remarshalled, err := json.Marshal(step)
 	s.NoError(err)
+	s.Equal(test.UnknownFields, step.UnknownFields)
 
 	var reStep atc.Step
 	err = yaml.Unmarshal(remarshalled, &reStep)
@@ -13,6 +13,10 @@ type TaskConfig struct {
 	// The platform the task must run on (e.g. linux, windows).
 	Platform string `json:"platform,omitempty"`
 
+	// The CPU architecture the task must run on (e.g. amd64, arm64). If not
+	// set, the task may run on a worker of any arch.
+	Arch string `json:"arch,omitempty"`
+
 	// Optional string specifying an image to use for the build. Depending on the
 	// platform, this may or may not be required (e.g. Windows/OS X vs. Linux).
 	RootfsURI string `json:"rootfs_uri,omitempty"`
@@ -36,6 +40,10 @@ type TaskConfig struct {
 
 	// Path to cached directory that will be shared between builds for the same task.
 	Caches []TaskCacheConfig `json:"caches,omitempty"`
+
+	// Run the task without network access, so that it can only depend on its
+	// inputs and image. Only workers running containerd can run such tasks.
+	Hermetic bool `json:"hermetic,omitempty"`
 }
 
 type ImageResource struct {
@@ -47,6 +55,93 @@ type ImageResource struct {
 	Tags    Tags    `json:"tags,omitempty"`
 }
 
+// ApplyRegistryMirror configures the image to be fetched from the given
+// mirror, passing it along as the registry_mirror of the source of whatever
+// is fetched with registry-image. If the image's type is a custom resource
+// type, that is the image of the first type along the chain of types which
+// is itself fetched with registry-image, so the mirror is applied to a copy
+// of the resource types, which are returned.
+//
+// Only registry-image supports mirrors, so an error is returned if the chain
+// ends in any other base type.
+func (ir *ImageResource) ApplyRegistryMirror(mirror *RegistryMirror, resourceTypes VersionedResourceTypes) (VersionedResourceTypes, error) {
+	if ir == nil || mirror == nil {
+		return resourceTypes, nil
+	}
+
+	mirrored := make(VersionedResourceTypes, len(resourceTypes))
+	copy(mirrored, resourceTypes)
+
+	source := &ir.Source
+	remaining := mirrored
+	typeName := ir.Type
+	for {
+		resourceType, found := remaining.Lookup(typeName)
+		if !found {
+			break
+		}
+
+		for i := range mirrored {
+			if mirrored[i].Name == resourceType.Name {
+				source = &mirrored[i].Source
+			}
+		}
+
+		remaining = remaining.Without(typeName)
+		typeName = resourceType.Type
+	}
+
+	if typeName != "registry-image" {
+		return nil, RegistryMirrorNotSupportedError{Type: typeName}
+	}
+
+	*source = mirrorSource(*source, mirror)
+
+	return mirrored, nil
+}
+
+// RegistryMirrorNotSupportedError is returned when a registry mirror is
+// configured for an image which is not fetched with registry-image.
+type RegistryMirrorNotSupportedError struct {
+	Type string
+}
+
+func (err RegistryMirrorNotSupportedError) Error() string {
+	return fmt.Sprintf("registry_mirror is only supported for images fetched with registry-image, not '%s'", err.Type)
+}
+
+func mirrorSource(original Source, mirror *RegistryMirror) Source {
+	// copy the source so that the config it came from isn't modified
+	source := Source{}
+	for k, v := range original {
+		source[k] = v
+	}
+
+	registryMirror := map[string]interface{}{
+		"host": mirror.Host,
+	}
+
+	if mirror.Username != "" {
+		registryMirror["username"] = mirror.Username
+	}
+
+	if mirror.Password != "" {
+		registryMirror["password"] = mirror.Password
+	}
+
+	source["registry_mirror"] = registryMirror
+
+	return source
+}
+
+// A RegistryMirror is a registry to fetch a step's image from in place of the
+// registry the image names. The credentials are typically vars.
+type RegistryMirror struct {
+	Host     string `json:"host"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
 func (ir *ImageResource) ApplySourceDefaults(resourceTypes VersionedResourceTypes) {
 	if ir == nil {
 		return
@@ -63,6 +158,32 @@ func (ir *ImageResource) ApplySourceDefaults(resourceTypes VersionedResourceType
 	}
 }
 
+// SetPlatform configures a registry-image to resolve multi-arch manifests to
+// the given platform and arch. A platform already configured in the source is
+// left alone.
+func (ir *ImageResource) SetPlatform(platform string, arch string) {
+	if ir == nil || ir.Type != "registry-image" || arch == "" {
+		return
+	}
+
+	if _, found := ir.Source["platform"]; found {
+		return
+	}
+
+	// copy the source so that the config it came from isn't modified
+	source := Source{}
+	for k, v := range ir.Source {
+		source[k] = v
+	}
+
+	source["platform"] = map[string]interface{}{
+		"os":           platform,
+		"architecture": arch,
+	}
+
+	ir.Source = source
+}
+
 func NewTaskConfig(configBytes []byte) (TaskConfig, error) {
 	var config TaskConfig
 	err := yaml.UnmarshalStrict(configBytes, &config, yaml.DisallowUnknownFields)
@@ -100,6 +221,10 @@ func (config TaskConfig) Validate() error {
 	errors = append(errors, config.validateInputContainsNames()...)
 	errors = append(errors, config.validateOutputContainsNames()...)
 
+	if config.Limits != nil {
+		errors = append(errors, config.Limits.Validate()...)
+	}
+
 	if len(errors) > 0 {
 		return TaskValidationError{
 			Errors: errors,
@@ -281,6 +281,46 @@ run: {path: a/file}
 				})
 			})
 
+			Context("when resource requests are specified", func() {
+				It("parses them alongside the limits", func() {
+					data := []byte(`
+platform: beos
+container_limits: { cpu: 1024, memory: 1GB, cpu_request: 512, memory_request: 512MB }
+
+run: {path: a/file}
+`)
+					task, err := NewTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					cpu := CPULimit(1024)
+					memory := MemoryLimit(1024 * 1024 * 1024)
+					cpuRequest := CPULimit(512)
+					memoryRequest := MemoryLimit(512 * 1024 * 1024)
+					Expect(task.Limits).To(Equal(&ContainerLimits{
+						CPU:           &cpu,
+						Memory:        &memory,
+						CPURequest:    &cpuRequest,
+						MemoryRequest: &memoryRequest,
+					}))
+				})
+
+				It("does not allow them to exceed the limits", func() {
+					cpu := CPULimit(512)
+					cpuRequest := CPULimit(1024)
+					memory := MemoryLimit(512)
+					memoryRequest := MemoryLimit(1024)
+					validConfig.Limits = &ContainerLimits{
+						CPU:           &cpu,
+						CPURequest:    &cpuRequest,
+						Memory:        &memory,
+						MemoryRequest: &memoryRequest,
+					}
+
+					err := validConfig.Validate()
+					Expect(err).To(MatchError(ContainSubstring("cpu_request must not be greater than the cpu limit")))
+					Expect(err).To(MatchError(ContainSubstring("memory_request must not be greater than the memory limit")))
+				})
+			})
+
 			Context("when invalid memory limit value is provided", func() {
 				It("throws an error and does not continue", func() {
 					data := []byte(`
@@ -403,6 +443,167 @@ var _ = Context("ImageResource", func() {
 	var imageResource *ImageResource
 	var resourceTypes VersionedResourceTypes
 
+	Context("SetPlatform", func() {
+		var imageResource *ImageResource
+
+		BeforeEach(func() {
+			imageResource = &ImageResource{
+				Type:   "registry-image",
+				Source: Source{"repository": "busybox"},
+			}
+		})
+
+		It("resolves the image to the platform and arch", func() {
+			imageResource.SetPlatform("linux", "arm64")
+			Expect(imageResource.Source).To(Equal(Source{
+				"repository": "busybox",
+				"platform": map[string]interface{}{
+					"os":           "linux",
+					"architecture": "arm64",
+				},
+			}))
+		})
+
+		Context("when a platform is already configured", func() {
+			BeforeEach(func() {
+				imageResource.Source["platform"] = map[string]interface{}{"architecture": "amd64"}
+			})
+
+			It("leaves it alone", func() {
+				imageResource.SetPlatform("linux", "arm64")
+				Expect(imageResource.Source["platform"]).To(Equal(map[string]interface{}{"architecture": "amd64"}))
+			})
+		})
+
+		Context("when the arch is unknown", func() {
+			It("leaves the source alone", func() {
+				imageResource.SetPlatform("linux", "")
+				Expect(imageResource.Source).To(Equal(Source{"repository": "busybox"}))
+			})
+		})
+
+		Context("when the image is not a registry-image", func() {
+			BeforeEach(func() {
+				imageResource.Type = "docker-image"
+			})
+
+			It("leaves the source alone", func() {
+				imageResource.SetPlatform("linux", "arm64")
+				Expect(imageResource.Source).To(Equal(Source{"repository": "busybox"}))
+			})
+		})
+	})
+
+	Context("ApplyRegistryMirror", func() {
+		var imageResource *ImageResource
+		var mirror *RegistryMirror
+
+		var mirroredTypes VersionedResourceTypes
+		var mirrorErr error
+
+		mirroredSource := func(source Source) Source {
+			source["registry_mirror"] = map[string]interface{}{
+				"host":     "some-mirror",
+				"username": "some-user",
+			}
+			return source
+		}
+
+		BeforeEach(func() {
+			imageResource = &ImageResource{
+				Type:   "registry-image",
+				Source: Source{"repository": "busybox"},
+			}
+
+			mirror = &RegistryMirror{Host: "some-mirror", Username: "some-user"}
+
+			resourceTypes = VersionedResourceTypes{
+				{
+					ResourceType: ResourceType{
+						Name:   "some-type",
+						Type:   "registry-image",
+						Source: Source{"repository": "some-type"},
+					},
+				},
+				{
+					ResourceType: ResourceType{
+						Name:   "nested-type",
+						Type:   "some-type",
+						Source: Source{"repository": "nested-type"},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			mirroredTypes, mirrorErr = imageResource.ApplyRegistryMirror(mirror, resourceTypes)
+		})
+
+		It("fetches the image from the mirror", func() {
+			Expect(mirrorErr).ToNot(HaveOccurred())
+			Expect(imageResource.Source).To(Equal(mirroredSource(Source{"repository": "busybox"})))
+			Expect(mirroredTypes).To(Equal(resourceTypes))
+		})
+
+		Context("when the image is fetched with a custom type", func() {
+			BeforeEach(func() {
+				imageResource.Type = "nested-type"
+			})
+
+			It("fetches the image of the type fetched with registry-image from the mirror", func() {
+				Expect(mirrorErr).ToNot(HaveOccurred())
+				Expect(imageResource.Source).To(Equal(Source{"repository": "busybox"}))
+
+				nestedType, found := mirroredTypes.Lookup("nested-type")
+				Expect(found).To(BeTrue())
+				Expect(nestedType.Source).To(Equal(Source{"repository": "nested-type"}))
+
+				someType, found := mirroredTypes.Lookup("some-type")
+				Expect(found).To(BeTrue())
+				Expect(someType.Source).To(Equal(mirroredSource(Source{"repository": "some-type"})))
+			})
+
+			It("leaves the given types alone", func() {
+				someType, found := resourceTypes.Lookup("some-type")
+				Expect(found).To(BeTrue())
+				Expect(someType.Source).To(Equal(Source{"repository": "some-type"}))
+			})
+		})
+
+		Context("when the image is not fetched with registry-image", func() {
+			BeforeEach(func() {
+				imageResource.Type = "docker-image"
+			})
+
+			It("errors", func() {
+				Expect(mirrorErr).To(Equal(RegistryMirrorNotSupportedError{Type: "docker-image"}))
+			})
+		})
+
+		Context("when a custom type is not fetched with registry-image", func() {
+			BeforeEach(func() {
+				imageResource.Type = "nested-type"
+				resourceTypes[0].Type = "docker-image"
+			})
+
+			It("errors", func() {
+				Expect(mirrorErr).To(Equal(RegistryMirrorNotSupportedError{Type: "docker-image"}))
+			})
+		})
+
+		Context("when there is no mirror", func() {
+			BeforeEach(func() {
+				mirror = nil
+			})
+
+			It("leaves the image and types alone", func() {
+				Expect(mirrorErr).ToNot(HaveOccurred())
+				Expect(imageResource.Source).To(Equal(Source{"repository": "busybox"}))
+				Expect(mirroredTypes).To(Equal(resourceTypes))
+			})
+		})
+	})
+
 	Context("ApplySourceDefaults", func() {
 		BeforeEach(func() {
 			resourceTypes = VersionedResourceTypes{}
@@ -1,7 +1,10 @@
 package atc
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 )
 
 var (
@@ -10,13 +13,101 @@ var (
 )
 
 type Team struct {
-	ID   int      `json:"id,omitempty"`
-	Name string   `json:"name,omitempty"`
-	Auth TeamAuth `json:"auth,omitempty"`
+	ID           int          `json:"id,omitempty"`
+	Name         string       `json:"name,omitempty"`
+	Auth         TeamAuth     `json:"auth,omitempty"`
+	PipelineAuth PipelineAuth `json:"pipeline_auth,omitempty"`
+
+	// AccessTokenLifetime limits how long after logging in a token grants
+	// access to the team. SessionIdleTimeout revokes a token's access to the
+	// team once it has gone unused for longer than the timeout. Zero values
+	// leave the cluster-wide token lifetime in charge.
+	AccessTokenLifetime time.Duration `json:"access_token_lifetime,omitempty"`
+	SessionIdleTimeout  time.Duration `json:"session_idle_timeout,omitempty"`
+}
+
+// teamJSON shadows the team's durations so that they are encoded as strings
+// such as "1h30m" rather than as nanoseconds.
+type teamJSON struct {
+	teamTarget
+
+	AccessTokenLifetime json.RawMessage `json:"access_token_lifetime,omitempty"`
+	SessionIdleTimeout  json.RawMessage `json:"session_idle_timeout,omitempty"`
+}
+
+type teamTarget Team
+
+func (team Team) MarshalJSON() ([]byte, error) {
+	t := teamJSON{teamTarget: teamTarget(team)}
+	if team.AccessTokenLifetime != 0 {
+		t.AccessTokenLifetime, _ = json.Marshal(team.AccessTokenLifetime.String())
+	}
+
+	if team.SessionIdleTimeout != 0 {
+		t.SessionIdleTimeout, _ = json.Marshal(team.SessionIdleTimeout.String())
+	}
+
+	return json.Marshal(t)
+}
+
+func (team *Team) UnmarshalJSON(data []byte) error {
+	var t teamJSON
+	err := json.Unmarshal(data, &t)
+	if err != nil {
+		return err
+	}
+
+	*team = Team(t.teamTarget)
+
+	team.AccessTokenLifetime, err = unmarshalTeamDuration(t.AccessTokenLifetime)
+	if err != nil {
+		return fmt.Errorf("access_token_lifetime: %w", err)
+	}
+
+	team.SessionIdleTimeout, err = unmarshalTeamDuration(t.SessionIdleTimeout)
+	if err != nil {
+		return fmt.Errorf("session_idle_timeout: %w", err)
+	}
+
+	return nil
+}
+
+// unmarshalTeamDuration parses a duration string, also accepting a number of
+// nanoseconds as sent by older clients.
+func unmarshalTeamDuration(data json.RawMessage) (time.Duration, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	var str string
+	if json.Unmarshal(data, &str) == nil {
+		return time.ParseDuration(str)
+	}
+
+	var nanoseconds int64
+	err := json.Unmarshal(data, &nanoseconds)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(nanoseconds), nil
 }
 
 func (team Team) Validate() error {
-	return team.Auth.Validate()
+	err := team.Auth.Validate()
+	if err != nil {
+		return err
+	}
+
+	if team.AccessTokenLifetime < 0 {
+		return errors.New("access token lifetime must not be negative")
+	}
+
+	if team.SessionIdleTimeout < 0 {
+		return errors.New("session idle timeout must not be negative")
+	}
+
+	return team.PipelineAuth.Validate()
 }
 
 type TeamAuth map[string]map[string][]string
@@ -37,3 +128,57 @@ func (auth TeamAuth) Validate() error {
 
 	return nil
 }
+
+// PipelineAuth grants roles which only apply to a single pipeline within the
+// team. Each grant names its pipeline by its full ref, so every instance of an
+// instanced pipeline is granted separately.
+type PipelineAuth []PipelineGrant
+
+// PipelineGrant is the roles granted on one pipeline.
+type PipelineGrant struct {
+	Pipeline PipelineRef `json:"pipeline"`
+	Auth     TeamAuth    `json:"auth"`
+}
+
+func (auth PipelineAuth) Validate() error {
+	seen := map[string]bool{}
+
+	for _, grant := range auth {
+		pipeline := grant.Pipeline.String()
+		if grant.Pipeline.Name == "" {
+			return errors.New("pipeline grant does not name a pipeline")
+		}
+
+		if seen[pipeline] {
+			return fmt.Errorf("pipeline '%s': granted more than once", pipeline)
+		}
+		seen[pipeline] = true
+
+		for role, config := range grant.Auth {
+			if role == "owner" {
+				return fmt.Errorf("pipeline '%s': the owner role can only be granted for the whole team", pipeline)
+			}
+
+			if len(config["users"]) == 0 && len(config["groups"]) == 0 {
+				return fmt.Errorf("pipeline '%s': %w", pipeline, ErrAuthConfigInvalid)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Grant adds the role to the roles granted on the pipeline.
+func (auth *PipelineAuth) Grant(pipeline PipelineRef, role string, config map[string][]string) {
+	for i, grant := range *auth {
+		if grant.Pipeline.String() == pipeline.String() {
+			(*auth)[i].Auth[role] = config
+			return
+		}
+	}
+
+	*auth = append(*auth, PipelineGrant{
+		Pipeline: pipeline,
+		Auth:     TeamAuth{role: config},
+	})
+}
@@ -0,0 +1,21 @@
+package atc
+
+const (
+	PruneReasonOrphaned   = "orphaned"
+	PruneReasonStuckCheck = "stuck-check"
+	PruneReasonDangling   = "dangling"
+)
+
+// TeamPrunableState lists the containers and volumes of a team which aren't
+// used by anything, but which the garbage collector hasn't removed, e.g.
+// because their worker stalled.
+type TeamPrunableState struct {
+	Containers []PrunableObject `json:"containers"`
+	Volumes    []PrunableObject `json:"volumes"`
+}
+
+type PrunableObject struct {
+	Handle     string `json:"handle"`
+	WorkerName string `json:"worker_name"`
+	Reason     string `json:"reason"`
+}
@@ -0,0 +1,117 @@
+package atc_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team", func() {
+	Describe("JSON encoding", func() {
+		It("encodes durations as duration strings", func() {
+			payload, err := json.Marshal(atc.Team{
+				ID:                  1,
+				Name:                "some-team",
+				AccessTokenLifetime: time.Hour,
+				SessionIdleTimeout:  15 * time.Minute,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(payload).To(MatchJSON(`{
+				"id": 1,
+				"name": "some-team",
+				"access_token_lifetime": "1h0m0s",
+				"session_idle_timeout": "15m0s"
+			}`))
+		})
+
+		It("omits unset durations", func() {
+			payload, err := json.Marshal(atc.Team{ID: 1, Name: "some-team"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(payload).To(MatchJSON(`{"id": 1, "name": "some-team"}`))
+		})
+	})
+
+	Describe("JSON decoding", func() {
+		var team atc.Team
+
+		BeforeEach(func() {
+			team = atc.Team{}
+		})
+
+		It("decodes duration strings", func() {
+			err := json.Unmarshal([]byte(`{
+				"name": "some-team",
+				"access_token_lifetime": "2h",
+				"session_idle_timeout": "15m"
+			}`), &team)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(team.Name).To(Equal("some-team"))
+			Expect(team.AccessTokenLifetime).To(Equal(2 * time.Hour))
+			Expect(team.SessionIdleTimeout).To(Equal(15 * time.Minute))
+		})
+
+		It("decodes durations given in nanoseconds", func() {
+			err := json.Unmarshal([]byte(`{
+				"name": "some-team",
+				"access_token_lifetime": 3600000000000
+			}`), &team)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(team.AccessTokenLifetime).To(Equal(time.Hour))
+		})
+
+		It("errors on an invalid duration", func() {
+			err := json.Unmarshal([]byte(`{
+				"name": "some-team",
+				"session_idle_timeout": "bogus"
+			}`), &team)
+			Expect(err).To(MatchError(ContainSubstring("session_idle_timeout")))
+		})
+	})
+
+	Describe("PipelineAuth", func() {
+		var (
+			instanceRef = atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "main"}}
+			viewers     = map[string][]string{"users": {"local:someone"}}
+		)
+
+		It("grants roles on each instance of a pipeline separately", func() {
+			auth := atc.PipelineAuth{}
+			auth.Grant(instanceRef, "viewer", viewers)
+			auth.Grant(atc.PipelineRef{Name: "some-pipeline"}, "viewer", viewers)
+			auth.Grant(instanceRef, "pipeline-operator", viewers)
+
+			Expect(auth).To(Equal(atc.PipelineAuth{
+				{
+					Pipeline: instanceRef,
+					Auth:     atc.TeamAuth{"viewer": viewers, "pipeline-operator": viewers},
+				},
+				{
+					Pipeline: atc.PipelineRef{Name: "some-pipeline"},
+					Auth:     atc.TeamAuth{"viewer": viewers},
+				},
+			}))
+			Expect(auth.Validate()).To(Succeed())
+		})
+
+		It("rejects granting roles on the same pipeline twice", func() {
+			auth := atc.PipelineAuth{
+				{Pipeline: instanceRef, Auth: atc.TeamAuth{"viewer": viewers}},
+				{Pipeline: instanceRef, Auth: atc.TeamAuth{"member": viewers}},
+			}
+
+			Expect(auth.Validate()).To(MatchError("pipeline 'some-pipeline/branch:main': granted more than once"))
+		})
+
+		It("rejects granting the owner role", func() {
+			auth := atc.PipelineAuth{
+				{Pipeline: instanceRef, Auth: atc.TeamAuth{"owner": viewers}},
+			}
+
+			Expect(auth.Validate()).To(MatchError(ContainSubstring("the owner role can only be granted for the whole team")))
+		})
+	})
+})
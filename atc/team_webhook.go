@@ -0,0 +1,73 @@
+package atc
+
+const (
+	TeamWebhookEventBuildStarted    = "build-started"
+	TeamWebhookEventBuildFinished   = "build-finished"
+	TeamWebhookEventPipelineChanged = "pipeline-changed"
+)
+
+const (
+	TeamWebhookTypeGeneric = ""
+	TeamWebhookTypeSlack   = "slack"
+	TeamWebhookTypeTeams   = "teams"
+)
+
+// TeamWebhook is notified of build and pipeline events in its team, so that
+// jobs don't each need an on_failure step to report on themselves.
+//
+// Generic webhooks receive the event as JSON. Slack and Microsoft Teams
+// webhooks are incoming webhook URLs, sent a message rendered from Template.
+type TeamWebhook struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type,omitempty"`
+	URL    string            `json:"url"`
+	Secret string            `json:"secret,omitempty"`
+	Filter TeamWebhookFilter `json:"filter,omitempty"`
+
+	Channel  string `json:"channel,omitempty"`
+	Template string `json:"template,omitempty"`
+}
+
+// TeamWebhookFilter narrows down the events a webhook is notified of. An
+// empty list matches everything.
+type TeamWebhookFilter struct {
+	Events    []string `json:"events,omitempty"`
+	Pipelines []string `json:"pipelines,omitempty"`
+	Jobs      []string `json:"jobs,omitempty"`
+	Statuses  []string `json:"statuses,omitempty"`
+}
+
+// TeamWebhookEvent is the payload POSTed to a team's webhooks.
+type TeamWebhookEvent struct {
+	Type                 string       `json:"type"`
+	Time                 int64        `json:"time"`
+	TeamName             string       `json:"team_name"`
+	PipelineName         string       `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	JobName              string       `json:"job_name,omitempty"`
+	BuildID              int          `json:"build_id,omitempty"`
+	BuildName            string       `json:"build_name,omitempty"`
+	Status               string       `json:"status,omitempty"`
+}
+
+// Matches returns whether the webhook's filter lets the event through.
+func (filter TeamWebhookFilter) Matches(event TeamWebhookEvent) bool {
+	return matchesAny(filter.Events, event.Type) &&
+		matchesAny(filter.Pipelines, event.PipelineName) &&
+		matchesAny(filter.Jobs, event.JobName) &&
+		matchesAny(filter.Statuses, event.Status)
+}
+
+func matchesAny(allowed []string, value string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	for _, a := range allowed {
+		if a == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// NewCronTrigger returns a component that creates builds of jobs at the
+// times their schedule matches.
+func NewCronTrigger(jobFactory db.JobFactory, clock clock.Clock) *CronTrigger {
+	return &CronTrigger{
+		jobFactory: jobFactory,
+		clock:      clock,
+	}
+}
+
+type CronTrigger struct {
+	jobFactory db.JobFactory
+	clock      clock.Clock
+}
+
+func (t *CronTrigger) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("cron-trigger")
+
+	jobs, err := t.jobFactory.ScheduledJobs()
+	if err != nil {
+		logger.Error("failed-to-get-scheduled-jobs", err)
+		return err
+	}
+
+	now := t.clock.Now()
+	for _, job := range jobs {
+		t.trigger(logger.WithData(lager.Data{
+			"team":     job.TeamName(),
+			"pipeline": job.PipelineName(),
+			"job":      job.Name(),
+		}), job, now)
+	}
+
+	return nil
+}
+
+func (t *CronTrigger) trigger(logger lager.Logger, job db.Job, now time.Time) {
+	next, err := job.Schedule().Next(job.ScheduleAnchor())
+	if err != nil {
+		logger.Error("failed-to-compute-next-run", err)
+		return
+	}
+
+	if next.After(now) {
+		return
+	}
+
+	// the key makes sure a run is only triggered once, even if the anchor
+	// failed to be updated after triggering it
+	key := fmt.Sprintf("schedule:%s:%d", job.Name(), next.Unix())
+
+	_, err = job.CreateScheduledBuild(key)
+	if err != nil && !errors.Is(err, db.ErrIdempotencyKeyConflict) {
+		logger.Error("failed-to-create-build", err)
+		return
+	}
+
+	// runs missed while the job or its pipeline was paused, or the web node
+	// was down, are skipped rather than all triggered at once
+	err = job.UpdateScheduleAnchor(now)
+	if err != nil {
+		logger.Error("failed-to-update-schedule-anchor", err)
+	}
+}
@@ -0,0 +1,106 @@
+package trigger_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/trigger"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CronTrigger", func() {
+	var (
+		fakeJobFactory *dbfakes.FakeJobFactory
+		fakeJob        *dbfakes.FakeJob
+		fakeClock      *fakeclock.FakeClock
+
+		now    time.Time
+		runErr error
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2021, 6, 22, 10, 0, 30, 0, time.UTC)
+		fakeClock = fakeclock.NewFakeClock(now)
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.NameReturns("some-job")
+		fakeJob.ScheduleReturns(&atc.JobScheduleConfig{Cron: "0 * * * *"})
+
+		fakeJobFactory = new(dbfakes.FakeJobFactory)
+		fakeJobFactory.ScheduledJobsReturns(db.Jobs{fakeJob}, nil)
+	})
+
+	JustBeforeEach(func() {
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		runErr = trigger.NewCronTrigger(fakeJobFactory, fakeClock).Run(ctx)
+	})
+
+	Context("when the next run is due", func() {
+		BeforeEach(func() {
+			fakeJob.ScheduleAnchorReturns(now.Add(-time.Hour))
+		})
+
+		It("creates a build keyed by the run", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeJob.CreateScheduledBuildCallCount()).To(Equal(1))
+			Expect(fakeJob.CreateScheduledBuildArgsForCall(0)).To(Equal("schedule:some-job:1624356000"))
+		})
+
+		It("moves the anchor to now", func() {
+			Expect(fakeJob.UpdateScheduleAnchorCallCount()).To(Equal(1))
+			Expect(fakeJob.UpdateScheduleAnchorArgsForCall(0)).To(Equal(now))
+		})
+
+		Context("when the run was already triggered", func() {
+			BeforeEach(func() {
+				fakeJob.CreateScheduledBuildReturns(nil, db.ErrIdempotencyKeyConflict)
+			})
+
+			It("still moves the anchor", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeJob.UpdateScheduleAnchorCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when creating the build fails", func() {
+			BeforeEach(func() {
+				fakeJob.CreateScheduledBuildReturns(nil, errors.New("nope"))
+			})
+
+			It("leaves the anchor so that it is retried", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeJob.UpdateScheduleAnchorCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the next run is not due yet", func() {
+		BeforeEach(func() {
+			fakeJob.ScheduleAnchorReturns(now.Add(-time.Second))
+		})
+
+		It("does nothing", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+			Expect(fakeJob.UpdateScheduleAnchorCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the scheduled jobs can't be fetched", func() {
+		BeforeEach(func() {
+			fakeJobFactory.ScheduledJobsReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
@@ -0,0 +1,29 @@
+package atc
+
+// VarSourceStatus describes the state of one of a pipeline's var_sources:
+// whether its credential manager can be reached, and how the lookups of
+// its secrets have gone since it was last (re)created.
+type VarSourceStatus struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	Reachable bool   `json:"reachable"`
+	Error     string `json:"error,omitempty"`
+	LatencyMS int64  `json:"latency_ms"`
+
+	Lookups       int64   `json:"lookups"`
+	CacheHits     int64   `json:"cache_hits"`
+	CacheHitRate  float64 `json:"cache_hit_rate"`
+	LastError     string  `json:"last_error,omitempty"`
+	LastErrorTime int64   `json:"last_error_time,omitempty"`
+
+	Vars []VarLookupStatus `json:"vars,omitempty"`
+}
+
+// VarLookupStatus says whether a var asked about resolves in the var_source
+// it refers to. The value of the var is never included.
+type VarLookupStatus struct {
+	Name  string `json:"name"`
+	Found bool   `json:"found"`
+	Error string `json:"error,omitempty"`
+}
@@ -25,4 +25,6 @@ type Volume struct {
 	PipelineInstanceVars InstanceVars            `json:"pipeline_instance_vars"`
 	JobName              string                  `json:"job_name"`
 	StepName             string                  `json:"step_name"`
+	CreatedAt            int64                   `json:"created_at,omitempty"`
+	SizeBytes            uint64                  `json:"size_bytes,omitempty"`
 }
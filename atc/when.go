@@ -0,0 +1,456 @@
+package atc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/concourse/concourse/vars"
+)
+
+// WhenBuildFields are the fields of the build's metadata which a `when:`
+// condition may refer to as build.<field>. Fields of the pipeline's instance
+// vars are referred to as build.instance_vars.<field>.
+var WhenBuildFields = []string{
+	"team",
+	"pipeline",
+	"job",
+	"name",
+	"created_by",
+	"trigger",
+	"attempt",
+	"total_attempts",
+	"instance_vars",
+}
+
+// WhenResolver returns the value of a reference made by a `when:` condition,
+// or nil if it has no value.
+type WhenResolver func(vars.Reference) (interface{}, error)
+
+// WhenCondition is a parsed `when:` condition. A condition compares
+// build-local vars (.:name), build metadata (build.field) and the statuses of
+// the build's steps (steps.name) with each other or with literal strings,
+// numbers, booleans and null, using == and !=. Comparisons are combined with
+// &&, || and !, and may be grouped with parentheses.
+type WhenCondition struct {
+	expr whenExpr
+}
+
+// ParseWhenCondition parses the condition, returning an error if it is not
+// valid or refers to anything other than local vars, build metadata or steps.
+func ParseWhenCondition(condition string) (WhenCondition, error) {
+	tokens, err := tokenizeWhen(condition)
+	if err != nil {
+		return WhenCondition{}, err
+	}
+
+	if len(tokens) == 0 {
+		return WhenCondition{}, fmt.Errorf("empty condition")
+	}
+
+	p := &whenParser{tokens: tokens}
+
+	expr, err := p.or()
+	if err != nil {
+		return WhenCondition{}, err
+	}
+
+	if p.peek() != "" {
+		return WhenCondition{}, fmt.Errorf("unexpected '%s'", p.peek())
+	}
+
+	return WhenCondition{expr: expr}, nil
+}
+
+// StepNames returns the names of the steps whose statuses the condition
+// refers to.
+func (condition WhenCondition) StepNames() []string {
+	var names []string
+	for _, ref := range condition.expr.references() {
+		if ref.Source == "" && ref.Path == "steps" {
+			names = append(names, ref.Fields[0])
+		}
+	}
+
+	return names
+}
+
+// Evaluate returns whether the condition holds, resolving its references
+// with the given resolver. Values which are not booleans hold if they are
+// not empty, zero or null.
+func (condition WhenCondition) Evaluate(resolve WhenResolver) (bool, error) {
+	val, err := condition.expr.eval(resolve)
+	if err != nil {
+		return false, err
+	}
+
+	return whenTruthy(val), nil
+}
+
+type whenExpr interface {
+	eval(WhenResolver) (interface{}, error)
+	references() []vars.Reference
+}
+
+type whenLiteral struct {
+	val interface{}
+}
+
+func (expr whenLiteral) eval(WhenResolver) (interface{}, error) {
+	return expr.val, nil
+}
+
+func (expr whenLiteral) references() []vars.Reference {
+	return nil
+}
+
+type whenReference struct {
+	ref vars.Reference
+}
+
+func (expr whenReference) eval(resolve WhenResolver) (interface{}, error) {
+	return resolve(expr.ref)
+}
+
+func (expr whenReference) references() []vars.Reference {
+	return []vars.Reference{expr.ref}
+}
+
+type whenNot struct {
+	expr whenExpr
+}
+
+func (expr whenNot) eval(resolve WhenResolver) (interface{}, error) {
+	val, err := expr.expr.eval(resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	return !whenTruthy(val), nil
+}
+
+func (expr whenNot) references() []vars.Reference {
+	return expr.expr.references()
+}
+
+type whenBinary struct {
+	op          string
+	left, right whenExpr
+}
+
+func (expr whenBinary) eval(resolve WhenResolver) (interface{}, error) {
+	left, err := expr.left.eval(resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	// && and || short-circuit, so that e.g. a step's status is only looked
+	// up when it matters
+	switch expr.op {
+	case "&&":
+		if !whenTruthy(left) {
+			return false, nil
+		}
+	case "||":
+		if whenTruthy(left) {
+			return true, nil
+		}
+	}
+
+	right, err := expr.right.eval(resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.op {
+	case "==":
+		return whenEqual(left, right), nil
+	case "!=":
+		return !whenEqual(left, right), nil
+	default:
+		return whenTruthy(right), nil
+	}
+}
+
+func (expr whenBinary) references() []vars.Reference {
+	return append(expr.left.references(), expr.right.references()...)
+}
+
+func whenTruthy(val interface{}) bool {
+	switch v := whenNormalize(val).(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0
+	}
+
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return rv.Len() != 0
+	default:
+		return true
+	}
+}
+
+func whenEqual(left, right interface{}) bool {
+	return reflect.DeepEqual(whenNormalize(left), whenNormalize(right))
+}
+
+// whenNormalize converts numbers to float64, as vars may hold numbers of any
+// type depending on how they were loaded.
+func whenNormalize(val interface{}) interface{} {
+	switch v := val.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return v.String()
+		}
+		return f
+	case BuildStatus:
+		return string(v)
+	default:
+		return val
+	}
+}
+
+type whenParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *whenParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *whenParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *whenParser) or() (whenExpr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == "||" {
+		p.next()
+
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		left = whenBinary{op: "||", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *whenParser) and() (whenExpr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == "&&" {
+		p.next()
+
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = whenBinary{op: "&&", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *whenParser) unary() (whenExpr, error) {
+	if p.peek() == "!" {
+		p.next()
+
+		expr, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return whenNot{expr: expr}, nil
+	}
+
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek() == "==" || p.peek() == "!=" {
+		op := p.next()
+
+		right, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+
+		return whenBinary{op: op, left: left, right: right}, nil
+	}
+
+	return left, nil
+}
+
+func (p *whenParser) operand() (whenExpr, error) {
+	token := p.next()
+
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of condition")
+	case token == "(":
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.next() != ")" {
+			return nil, fmt.Errorf("expected ')'")
+		}
+
+		return expr, nil
+	case token[0] == '"' || token[0] == '\'':
+		return whenLiteral{val: token[1 : len(token)-1]}, nil
+	case !isWhenWordChar(rune(token[0])):
+		return nil, fmt.Errorf("unexpected '%s'", token)
+	}
+
+	switch token {
+	case "true":
+		return whenLiteral{val: true}, nil
+	case "false":
+		return whenLiteral{val: false}, nil
+	case "null":
+		return whenLiteral{val: nil}, nil
+	}
+
+	if num, err := strconv.ParseFloat(token, 64); err == nil {
+		return whenLiteral{val: num}, nil
+	}
+
+	ref, err := parseWhenReference(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return whenReference{ref: ref}, nil
+}
+
+func parseWhenReference(token string) (vars.Reference, error) {
+	ref, err := vars.ParseReference(token)
+	if err != nil {
+		return vars.Reference{}, err
+	}
+
+	switch {
+	case ref.Source == ".":
+		return ref, nil
+	case ref.Source != "":
+		return vars.Reference{}, fmt.Errorf("invalid reference '%s': only local vars (.:name) may be used", token)
+	case ref.Path == "build":
+		if len(ref.Fields) == 0 {
+			return vars.Reference{}, fmt.Errorf("invalid reference '%s': missing build field", token)
+		}
+
+		known := false
+		for _, field := range WhenBuildFields {
+			if ref.Fields[0] == field {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			return vars.Reference{}, fmt.Errorf("invalid reference '%s': unknown build field '%s'", token, ref.Fields[0])
+		}
+
+		if len(ref.Fields) > 1 && ref.Fields[0] != "instance_vars" {
+			return vars.Reference{}, fmt.Errorf("invalid reference '%s': build.%s has no fields", token, ref.Fields[0])
+		}
+
+		return ref, nil
+	case ref.Path == "steps":
+		if len(ref.Fields) != 1 {
+			return vars.Reference{}, fmt.Errorf("invalid reference '%s': expected steps.<name>", token)
+		}
+
+		return ref, nil
+	default:
+		return vars.Reference{}, fmt.Errorf("invalid reference '%s': must refer to a local var (.:name), build or steps", token)
+	}
+}
+
+func isWhenWordChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune("_-.:", c)
+}
+
+func tokenizeWhen(condition string) ([]string, error) {
+	var tokens []string
+
+	for i := 0; i < len(condition); {
+		c := condition[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case strings.HasPrefix(condition[i:], "==") ||
+			strings.HasPrefix(condition[i:], "!=") ||
+			strings.HasPrefix(condition[i:], "&&") ||
+			strings.HasPrefix(condition[i:], "||"):
+			tokens = append(tokens, condition[i:i+2])
+			i += 2
+		case c == '!':
+			tokens = append(tokens, "!")
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(condition[i+1:], c)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated string at: %s", condition[i:])
+			}
+
+			tokens = append(tokens, condition[i:i+end+2])
+			i += end + 2
+		case isWhenWordChar(rune(c)):
+			start := i
+			for i < len(condition) && isWhenWordChar(rune(condition[i])) {
+				i++
+			}
+
+			tokens = append(tokens, condition[start:i])
+		default:
+			return nil, fmt.Errorf("unexpected '%c' at: %s", c, condition[i:])
+		}
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,108 @@
+package atc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("WhenCondition", func() {
+	values := map[string]interface{}{
+		".:deploy":            true,
+		".:count":             json.Number("2"),
+		".:config.env":        "prod",
+		"build.trigger":       "manual",
+		"build.instance_vars": map[string]interface{}{},
+		"steps.unit":          "succeeded",
+	}
+
+	resolve := func(ref vars.Reference) (interface{}, error) {
+		return values[ref.String()], nil
+	}
+
+	DescribeTable("Evaluate",
+		func(condition string, expected bool) {
+			parsed, err := atc.ParseWhenCondition(condition)
+			Expect(err).ToNot(HaveOccurred())
+
+			holds, err := parsed.Evaluate(resolve)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(holds).To(Equal(expected))
+		},
+		Entry("a true var", ".:deploy", true),
+		Entry("a missing var", ".:missing", false),
+		Entry("an empty map", "build.instance_vars", false),
+		Entry("a var's field", `.:config.env == "prod"`, true),
+		Entry("single quoted strings", `.:config.env == 'prod'`, true),
+		Entry("numbers of different types", ".:count == 2", true),
+		Entry("inequality", `build.trigger != "manual"`, false),
+		Entry("null", ".:missing == null", true),
+		Entry("negation", "!.:deploy", false),
+		Entry("and", `.:deploy && steps.unit == "succeeded"`, true),
+		Entry("or", `.:missing || steps.unit == "failed"`, false),
+		Entry("precedence of && over ||", `.:deploy || .:missing && .:missing`, true),
+		Entry("parentheses", `(.:deploy || .:missing) && .:missing`, false),
+	)
+
+	It("short-circuits && and ||", func() {
+		parsed, err := atc.ParseWhenCondition("!.:deploy && steps.unit || .:deploy || steps.unit")
+		Expect(err).ToNot(HaveOccurred())
+
+		var resolved []string
+		holds, err := parsed.Evaluate(func(ref vars.Reference) (interface{}, error) {
+			resolved = append(resolved, ref.String())
+			return values[ref.String()], nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(holds).To(BeTrue())
+		Expect(resolved).To(Equal([]string{".:deploy", ".:deploy"}))
+	})
+
+	It("returns the resolver's errors", func() {
+		disaster := errors.New("nope")
+
+		parsed, err := atc.ParseWhenCondition(".:deploy")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = parsed.Evaluate(func(vars.Reference) (interface{}, error) {
+			return nil, disaster
+		})
+		Expect(err).To(Equal(disaster))
+	})
+
+	It("returns the names of the steps it refers to", func() {
+		parsed, err := atc.ParseWhenCondition(`steps.unit == "succeeded" && (.:deploy || steps.some-other-step)`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.StepNames()).To(Equal([]string{"unit", "some-other-step"}))
+	})
+
+	DescribeTable("invalid conditions",
+		func(condition string, message string) {
+			_, err := atc.ParseWhenCondition(condition)
+			Expect(err).To(MatchError(message))
+		},
+		Entry("empty", " ", "empty condition"),
+		Entry("unterminated string", `.:env == "prod`, `unterminated string at: "prod`),
+		Entry("unknown operator", ".:a = .:b", "unexpected '=' at: = .:b"),
+		Entry("missing operand", ".:a ==", "unexpected end of condition"),
+		Entry("trailing tokens", ".:a .:b", "unexpected '.:b'"),
+		Entry("unclosed parentheses", "(.:a", "expected ')'"),
+		Entry("other var sources", "vault:a", "invalid reference 'vault:a': only local vars (.:name) may be used"),
+		Entry("unknown references", "foo", "invalid reference 'foo': must refer to a local var (.:name), build or steps"),
+		Entry("unknown build fields", "build.foo", "invalid reference 'build.foo': unknown build field 'foo'"),
+		Entry("fields of build fields", "build.team.name", "invalid reference 'build.team.name': build.team has no fields"),
+		Entry("steps without a name", "steps", "invalid reference 'steps': expected steps.<name>"),
+	)
+
+	It("allows instance var fields", func() {
+		_, err := atc.ParseWhenCondition(fmt.Sprintf("build.instance_vars.branch == %q", "main"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -20,9 +20,16 @@ type Worker struct {
 	ActiveVolumes    int `json:"active_volumes"`
 	ActiveTasks      int `json:"active_tasks"`
 
+	// The CPU shares and bytes of memory available for steps' resource
+	// requests. 0 means the capacity is unknown and not enforced.
+	CPUCapacity    uint64 `json:"cpu_capacity,omitempty"`
+	MemoryCapacity uint64 `json:"memory_capacity,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string   `json:"platform"`
+	Arch      string   `json:"arch,omitempty"`
+	Runtime   string   `json:"runtime,omitempty"`
 	Tags      []string `json:"tags"`
 	Team      string   `json:"team"`
 	Name      string   `json:"name"`
@@ -59,3 +66,14 @@ type WorkerResourceType struct {
 type PruneWorkerResponseBody struct {
 	Stderr string `json:"stderr"`
 }
+
+// WorkerUsage is the disk usage periodically reported by a worker.
+// ContainerBytes and VolumeBytes are keyed by handle.
+type WorkerUsage struct {
+	DiskUsedBytes         uint64 `json:"disk_used_bytes"`
+	DiskFreeBytes         uint64 `json:"disk_free_bytes"`
+	ContainerScratchBytes uint64 `json:"container_scratch_bytes"`
+
+	ContainerBytes map[string]uint64 `json:"container_bytes,omitempty"`
+	VolumeBytes    map[string]uint64 `json:"volume_bytes,omitempty"`
+}
@@ -5,6 +5,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"path"
+	"sort"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -222,7 +225,20 @@ func (source *artifactSource) streamTo(
 
 	defer out.Close()
 
-	return destination.StreamIn(ctx, ".", source.compression.Encoding(), out)
+	return destination.StreamIn(ctx, ".", source.compression.Encoding(), countingReader{out})
+}
+
+// countingReader records the bytes streamed between workers. Only streams
+// relayed through the web node are counted; p2p streams never pass through
+// it.
+type countingReader struct {
+	io.Reader
+}
+
+func (r countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	metric.Metrics.VolumesStreamedSize.IncDelta(n)
+	return n, err
 }
 
 func (source *artifactSource) p2pStreamTo(
@@ -283,6 +299,86 @@ func (source *artifactSource) StreamFile(
 	}, nil
 }
 
+// globFiles streams out the directory that the pattern's wildcards start in,
+// returning the paths of the regular files within it that match the pattern.
+func (source *artifactSource) globFiles(
+	ctx context.Context,
+	pattern string,
+) ([]string, error) {
+	pattern = path.Clean(pattern)
+
+	// only the directory containing the files is streamed out, rather than
+	// everything below the first wildcard
+	dir := path.Dir(pattern)
+	if strings.ContainsAny(dir, globWildcards) {
+		return nil, GlobWildcardInDirError{pattern}
+	}
+
+	out, err := source.volume.StreamOut(ctx, dir, source.compression.Encoding())
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+
+	compressionReader, err := source.compression.NewReader(out)
+	if err != nil {
+		return nil, err
+	}
+	defer compressionReader.Close()
+
+	tarReader := tar.NewReader(compressionReader)
+
+	var matches []string
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// files in subdirectories can't match
+		name := path.Clean(header.Name)
+		if strings.Contains(name, "/") {
+			continue
+		}
+
+		filePath := path.Join(dir, name)
+
+		matched, err := path.Match(pattern, filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			matches = append(matches, filePath)
+		}
+	}
+
+	sort.Strings(matches)
+
+	return matches, nil
+}
+
+// globWildcards are the characters with a special meaning in a pattern.
+const globWildcards = `*?[\`
+
+// GlobWildcardInDirError is returned when a pattern has wildcards outside of
+// its file name, which would require streaming out every directory they
+// could match.
+type GlobWildcardInDirError struct {
+	Pattern string
+}
+
+func (err GlobWildcardInDirError) Error() string {
+	return fmt.Sprintf("wildcards are only allowed in the file name: %s", err.Pattern)
+}
+
 // Returns volume if it belongs to the worker
 //  otherwise, if the volume has a Resource Cache
 //  it checks the worker for a local volume corresponding to the Resource Cache.
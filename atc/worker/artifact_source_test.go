@@ -18,6 +18,7 @@ import (
 	"github.com/concourse/concourse/atc/compression/compressionfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimefakes"
 	"github.com/concourse/concourse/atc/worker"
@@ -162,6 +163,18 @@ var _ = Describe("StreamableArtifactSource", func() {
 			})
 
 			Context("when ArtifactSource can successfully stream to ArtifactDestination", func() {
+				var streamedIn []byte
+
+				BeforeEach(func() {
+					metric.Metrics.VolumesStreamedSize.Delta()
+
+					outStream.Write([]byte("some-bits"))
+					fakeDestination.StreamInStub = func(_ context.Context, _ string, _ baggageclaim.Encoding, in io.Reader) error {
+						var err error
+						streamedIn, err = ioutil.ReadAll(io.LimitReader(in, int64(len("some-bits"))))
+						return err
+					}
+				})
 
 				It("calls StreamOut and StreamIn with the correct params", func() {
 					Expect(fakeVolume.StreamOutCallCount()).To(Equal(1))
@@ -170,10 +183,14 @@ var _ = Describe("StreamableArtifactSource", func() {
 					Expect(actualPath).To(Equal("."))
 					Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
 
-					_, actualPath, encoding, actualStreamedOutBits := fakeDestination.StreamInArgsForCall(0)
+					_, actualPath, encoding, _ = fakeDestination.StreamInArgsForCall(0)
 					Expect(actualPath).To(Equal("."))
-					Expect(actualStreamedOutBits).To(Equal(outStream))
 					Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+					Expect(streamedIn).To(Equal([]byte("some-bits")))
+				})
+
+				It("counts the bytes streamed", func() {
+					Expect(metric.Metrics.VolumesStreamedSize.Delta()).To(Equal(float64(len("some-bits"))))
 				})
 
 				It("does not return an err", func() {
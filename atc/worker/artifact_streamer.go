@@ -13,6 +13,14 @@ import (
 //counterfeiter:generate . ArtifactStreamer
 type ArtifactStreamer interface {
 	StreamFileFromArtifact(context.Context, runtime.Artifact, string) (io.ReadCloser, error)
+
+	// GlobFilesInArtifact returns the paths of the files in the artifact
+	// which match the pattern, using the syntax of filepath.Match.
+	GlobFilesInArtifact(context.Context, runtime.Artifact, string) ([]string, error)
+
+	// StreamArtifact returns the artifact's contents as a gzipped tarball,
+	// regardless of the compression used for streaming between workers.
+	StreamArtifact(context.Context, runtime.Artifact) (io.ReadCloser, error)
 }
 
 func NewArtifactStreamer(volumeFinder VolumeFinder, compression compression.Compression) ArtifactStreamer {
@@ -32,6 +40,37 @@ func (a artifactStreamer) StreamFileFromArtifact(
 	artifact runtime.Artifact,
 	filePath string,
 ) (io.ReadCloser, error) {
+	source, err := a.source(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+	return source.StreamFile(ctx, filePath)
+}
+
+func (a artifactStreamer) GlobFilesInArtifact(
+	ctx context.Context,
+	artifact runtime.Artifact,
+	pattern string,
+) ([]string, error) {
+	source, err := a.source(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+	return source.globFiles(ctx, pattern)
+}
+
+func (a artifactStreamer) StreamArtifact(
+	ctx context.Context,
+	artifact runtime.Artifact,
+) (io.ReadCloser, error) {
+	source, err := a.source(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+	return source.volume.StreamOut(ctx, ".", baggageclaim.GzipEncoding)
+}
+
+func (a artifactStreamer) source(ctx context.Context, artifact runtime.Artifact) (*artifactSource, error) {
 	artifactVolume, found, err := a.volumeFinder.FindVolume(lagerctx.FromContext(ctx), 0, artifact.ID())
 	if err != nil {
 		return nil, err
@@ -39,10 +78,9 @@ func (a artifactStreamer) StreamFileFromArtifact(
 	if !found {
 		return nil, baggageclaim.ErrVolumeNotFound
 	}
-	source := artifactSource{
+	return &artifactSource{
 		artifact:    artifact,
 		volume:      artifactVolume,
 		compression: a.compression,
-	}
-	return source.StreamFile(ctx, filePath)
+	}, nil
 }
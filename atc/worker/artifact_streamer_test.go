@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -29,6 +30,58 @@ var _ = Describe("ArtifactStreamer", func() {
 		Expect(content).To(Equal([]byte("some file")))
 	})
 
+	It("globs files in an artifact", func() {
+		artifact := &runtime.TaskArtifact{VolumeHandle: "output"}
+		dirContent := tarGzContent(
+			file{"./b.yml", []byte("b")},
+			file{"./a.yml", []byte("a")},
+			file{"./c.json", []byte("c")},
+			file{"./nested/d.yml", []byte("d")},
+		)
+		vf := FakeVolumeFinder{Volumes: map[string]worker.Volume{
+			"output": newVolumeWithContent(content{"vars": dirContent}),
+		}}
+
+		streamer := worker.NewArtifactStreamer(vf, compression.NewGzipCompression())
+		files, err := streamer.GlobFilesInArtifact(context.Background(), artifact, "vars/*.yml")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(Equal([]string{"vars/a.yml", "vars/b.yml"}))
+
+		_, path, _ := vf.Volumes["output"].(*workerfakes.FakeVolume).StreamOutArgsForCall(0)
+		Expect(path).To(Equal("vars"))
+	})
+
+	It("does not glob files in directories matching wildcards", func() {
+		artifact := &runtime.TaskArtifact{VolumeHandle: "output"}
+		vf := FakeVolumeFinder{Volumes: map[string]worker.Volume{
+			"output": newVolumeWithContent(content{".": tarGzContent(file{"./vars/a.yml", []byte("a")})}),
+		}}
+
+		streamer := worker.NewArtifactStreamer(vf, compression.NewGzipCompression())
+		_, err := streamer.GlobFilesInArtifact(context.Background(), artifact, "*/a.yml")
+		Expect(err).To(Equal(worker.GlobWildcardInDirError{Pattern: "*/a.yml"}))
+		Expect(vf.Volumes["output"].(*workerfakes.FakeVolume).StreamOutCallCount()).To(BeZero())
+	})
+
+	It("streams an artifact as a gzipped tarball", func() {
+		artifact := &runtime.TaskArtifact{VolumeHandle: "output"}
+		expectedContent := tarGzContent(file{"file.txt", []byte("some file")})
+		vf := FakeVolumeFinder{Volumes: map[string]worker.Volume{
+			"output": newVolumeWithContent(content{".": expectedContent}),
+		}}
+
+		streamer := worker.NewArtifactStreamer(vf, compression.NewZstdCompression())
+		reader, err := streamer.StreamArtifact(context.Background(), artifact)
+		Expect(err).ToNot(HaveOccurred())
+
+		content, err := ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(content).To(Equal(expectedContent))
+
+		_, _, encoding := vf.Volumes["output"].(*workerfakes.FakeVolume).StreamOutArgsForCall(0)
+		Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+	})
+
 	Context("when the artifact is not found", func() {
 		It("errors", func() {
 			artifact := &runtime.TaskArtifact{VolumeHandle: "missing_output"}
@@ -37,6 +90,12 @@ var _ = Describe("ArtifactStreamer", func() {
 			streamer := worker.NewArtifactStreamer(vf, compression.NewGzipCompression())
 			_, err := streamer.StreamFileFromArtifact(context.Background(), artifact, "file.txt")
 			Expect(err).To(MatchError(baggageclaim.ErrVolumeNotFound))
+
+			_, err = streamer.GlobFilesInArtifact(context.Background(), artifact, "*.txt")
+			Expect(err).To(MatchError(baggageclaim.ErrVolumeNotFound))
+
+			_, err = streamer.StreamArtifact(context.Background(), artifact)
+			Expect(err).To(MatchError(baggageclaim.ErrVolumeNotFound))
 		})
 	})
 })
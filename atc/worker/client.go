@@ -3,9 +3,11 @@ package worker
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"path"
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
@@ -17,6 +19,7 @@ import (
 )
 
 const taskProcessID = "task"
+const serviceProcessID = "service"
 const taskExitStatusPropertyName = "concourse:exit-status"
 
 //counterfeiter:generate . Client
@@ -64,6 +67,16 @@ type Client interface {
 		db.UsedResourceCache,
 		resource.Resource,
 	) (GetResult, error)
+
+	RunRunStep(
+		context.Context,
+		db.ContainerOwner,
+		ContainerSpec,
+		db.ContainerMetadata,
+		runtime.ProcessSpec,
+		runtime.StartingEventDelegate,
+		runtime.RunRequest,
+	) (RunResult, error)
 }
 
 func NewClient(worker Worker) *client {
@@ -96,6 +109,12 @@ type GetResult struct {
 	GetArtifact   runtime.GetArtifact
 }
 
+type RunResult struct {
+	ExitStatus   int
+	Response     runtime.RunResponse
+	VolumeMounts []VolumeMount
+}
+
 type processStatus struct {
 	processStatus int
 	processErr    error
@@ -180,6 +199,13 @@ func (client *client) RunTaskStep(
 		}, err
 	}
 
+	services, err := client.startServices(ctx, logger, container, containerSpec.Services)
+	if err != nil {
+		return TaskResult{}, err
+	}
+
+	defer client.destroyServices(logger, services)
+
 	processIO := garden.ProcessIO{
 		Stdout: processSpec.StdoutWriter,
 		Stderr: processSpec.StderrWriter,
@@ -260,6 +286,138 @@ func (client *client) RunTaskStep(
 	}
 }
 
+// startServices runs each service in a container of its own which joins the
+// task container's network, so that the task can reach the services on
+// localhost. Services which are already running are left be. Services with
+// a readiness command are waited on before the next service is started.
+func (client *client) startServices(
+	ctx context.Context,
+	logger lager.Logger,
+	taskContainer Container,
+	serviceSpecs []ServiceSpec,
+) ([]Container, error) {
+	var services []Container
+
+	for _, service := range serviceSpecs {
+		logger := logger.Session("service", lager.Data{"service": service.Name})
+
+		containerSpec := service.ContainerSpec
+		containerSpec.NetworkOf = taskContainer.Handle()
+
+		container, err := client.worker.FindOrCreateContainer(
+			ctx,
+			logger,
+			service.Owner,
+			service.Metadata,
+			containerSpec,
+		)
+		if err != nil {
+			client.destroyServices(logger, services)
+			return nil, fmt.Errorf("create container for service %s: %w", service.Name, err)
+		}
+
+		services = append(services, container)
+
+		processIO := garden.ProcessIO{
+			Stdout: service.ProcessSpec.StdoutWriter,
+			Stderr: service.ProcessSpec.StderrWriter,
+		}
+
+		_, err = container.Attach(context.Background(), serviceProcessID, processIO)
+		if err == nil {
+			logger.Info("already-running")
+		} else {
+			logger.Info("spawning")
+
+			_, err = container.Run(
+				context.Background(),
+				garden.ProcessSpec{
+					ID: serviceProcessID,
+
+					Path: service.ProcessSpec.Path,
+					Args: service.ProcessSpec.Args,
+					Dir:  service.ProcessSpec.Dir,
+				},
+				processIO,
+			)
+			if err != nil {
+				client.destroyServices(logger, services)
+				return nil, fmt.Errorf("run service %s: %w", service.Name, err)
+			}
+		}
+
+		if service.Ready != nil {
+			err = client.waitForService(ctx, logger, container, *service.Ready)
+			if err != nil {
+				client.destroyServices(logger, services)
+				return nil, fmt.Errorf("wait for service %s to be ready: %w", service.Name, err)
+			}
+		}
+	}
+
+	return services, nil
+}
+
+// ServiceReadyPollingInterval is how often a service's readiness command is
+// run until it succeeds.
+var ServiceReadyPollingInterval = time.Second
+
+// waitForService runs the service's readiness command in its container until
+// the command exits successfully.
+func (client *client) waitForService(ctx context.Context, logger lager.Logger, container Container, ready runtime.ProcessSpec) error {
+	for {
+		process, err := container.Run(
+			ctx,
+			garden.ProcessSpec{
+				Path: ready.Path,
+				Args: ready.Args,
+				Dir:  ready.Dir,
+			},
+			garden.ProcessIO{
+				Stdout: ready.StdoutWriter,
+				Stderr: ready.StderrWriter,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		status, err := process.Wait()
+		if err != nil {
+			return err
+		}
+
+		if status == 0 {
+			logger.Info("ready")
+			return nil
+		}
+
+		logger.Debug("not-ready", lager.Data{"exit-status": status})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(ServiceReadyPollingInterval):
+		}
+	}
+}
+
+// destroyServices stops the containers of the services once the task is done
+// with them, and then destroys them.
+func (client *client) destroyServices(logger lager.Logger, services []Container) {
+	for _, container := range services {
+		err := container.Stop(false)
+		if err != nil {
+			logger.Error("failed-to-stop-service", err, lager.Data{"handle": container.Handle()})
+		}
+
+		err = container.Destroy()
+		if err != nil {
+			logger.Error("failed-to-destroy-service", err, lager.Data{"handle": container.Handle()})
+		}
+	}
+}
+
 func (client *client) RunGetStep(
 	ctx context.Context,
 	owner db.ContainerOwner,
@@ -354,6 +512,67 @@ func (client *client) RunPutStep(
 	}, nil
 }
 
+// RunRunStep sends a message to a prototype by running the message's
+// executable with the request on stdin, and reads the response from stdout.
+// Like resource scripts, the response is kept on the container so that the
+// step can pick it up again if the web node restarts.
+func (client *client) RunRunStep(
+	ctx context.Context,
+	owner db.ContainerOwner,
+	containerSpec ContainerSpec,
+	metadata db.ContainerMetadata,
+	spec runtime.ProcessSpec,
+	eventDelegate runtime.StartingEventDelegate,
+	request runtime.RunRequest,
+) (RunResult, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	container, err := client.worker.FindOrCreateContainer(
+		ctx,
+		logger,
+		owner,
+		metadata,
+		containerSpec,
+	)
+	if err != nil {
+		return RunResult{}, err
+	}
+
+	input, err := json.Marshal(request)
+	if err != nil {
+		return RunResult{}, err
+	}
+
+	eventDelegate.Starting(logger)
+
+	var response runtime.RunResponse
+	err = container.RunScript(
+		ctx,
+		spec.Path,
+		spec.Args,
+		input,
+		&response,
+		spec.StderrWriter,
+		true,
+	)
+	if err != nil {
+		if failErr, ok := err.(runtime.ErrResourceScriptFailed); ok {
+			return RunResult{
+				ExitStatus:   failErr.ExitStatus,
+				VolumeMounts: container.VolumeMounts(),
+			}, nil
+		}
+
+		return RunResult{}, err
+	}
+
+	return RunResult{
+		ExitStatus:   0,
+		Response:     response,
+		VolumeMounts: container.VolumeMounts(),
+	}, nil
+}
+
 func lockName(resourceJSON []byte, workerName string) string {
 	jsonRes := append(resourceJSON, []byte(workerName)...)
 	return fmt.Sprintf("%x", sha256.Sum256(jsonRes))
@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"path"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
@@ -825,6 +827,155 @@ var _ = Describe("Client", func() {
 				})
 			})
 		})
+
+		Context("when the task has services", func() {
+			var (
+				fakeServiceContainer *workerfakes.FakeContainer
+				fakeServiceProcess   *gardenfakes.FakeProcess
+				serviceOwner         db.ContainerOwner
+			)
+
+			BeforeEach(func() {
+				fakeContainer.PropertiesReturns(garden.Properties{}, nil)
+				fakeContainer.HandleReturns("some-task-handle")
+
+				fakeTaskProcess := new(gardenfakes.FakeProcess)
+				fakeTaskProcess.WaitReturns(0, nil)
+				fakeContainer.AttachReturns(fakeTaskProcess, nil)
+
+				fakeServiceProcess = new(gardenfakes.FakeProcess)
+				fakeServiceContainer = new(workerfakes.FakeContainer)
+				fakeServiceContainer.AttachReturns(nil, errors.New("process not found"))
+				fakeServiceContainer.RunReturns(fakeServiceProcess, nil)
+				fakeWorker.FindOrCreateContainerReturnsOnCall(1, fakeServiceContainer, nil)
+
+				serviceOwner = db.NewBuildStepContainerOwner(1234, "42/services/some-service", 123)
+				fakeContainerSpec.Services = []worker.ServiceSpec{
+					{
+						Name:  "some-service",
+						Owner: serviceOwner,
+						Metadata: db.ContainerMetadata{
+							Type:     db.ContainerTypeTask,
+							StepName: "some-step",
+						},
+						ContainerSpec: worker.ContainerSpec{
+							TeamID: 123,
+							Env:    []string{"SOME=env"},
+						},
+						ProcessSpec: runtime.ProcessSpec{
+							Path: "/some/service",
+							Args: []string{"some", "args"},
+						},
+					},
+				}
+			})
+
+			It("creates a container for each service which joins the task container's network", func() {
+				Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(2))
+				_, _, owner, metadata, containerSpec := fakeWorker.FindOrCreateContainerArgsForCall(1)
+				Expect(owner).To(Equal(serviceOwner))
+				Expect(metadata.StepName).To(Equal("some-step"))
+				Expect(containerSpec.NetworkOf).To(Equal("some-task-handle"))
+				Expect(containerSpec.Env).To(Equal([]string{"SOME=env"}))
+			})
+
+			It("runs the service's process", func() {
+				Expect(fakeServiceContainer.RunCallCount()).To(Equal(1))
+				_, spec, _ := fakeServiceContainer.RunArgsForCall(0)
+				Expect(spec).To(Equal(garden.ProcessSpec{
+					ID:   "service",
+					Path: "/some/service",
+					Args: []string{"some", "args"},
+				}))
+			})
+
+			It("stops and destroys the services once the task has exited", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status).To(Equal(0))
+				Expect(fakeServiceContainer.StopCallCount()).To(Equal(1))
+				Expect(fakeServiceContainer.StopArgsForCall(0)).To(BeFalse())
+				Expect(fakeServiceContainer.DestroyCallCount()).To(Equal(1))
+			})
+
+			Context("when the service has a readiness command", func() {
+				var fakeReadyProcess *gardenfakes.FakeProcess
+
+				BeforeEach(func() {
+					worker.ServiceReadyPollingInterval = time.Millisecond
+
+					fakeReadyProcess = new(gardenfakes.FakeProcess)
+					fakeReadyProcess.WaitReturnsOnCall(0, 1, nil)
+					fakeReadyProcess.WaitReturnsOnCall(1, 0, nil)
+					fakeServiceContainer.RunReturnsOnCall(1, fakeReadyProcess, nil)
+					fakeServiceContainer.RunReturnsOnCall(2, fakeReadyProcess, nil)
+
+					fakeContainerSpec.Services[0].Ready = &runtime.ProcessSpec{
+						Path: "/some/readiness-check",
+						Args: []string{"some", "args"},
+					}
+				})
+
+				It("runs it in the service's container until it succeeds", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(status).To(Equal(0))
+
+					Expect(fakeServiceContainer.RunCallCount()).To(Equal(3))
+					_, spec, _ := fakeServiceContainer.RunArgsForCall(1)
+					Expect(spec).To(Equal(garden.ProcessSpec{
+						Path: "/some/readiness-check",
+						Args: []string{"some", "args"},
+					}))
+					Expect(fakeReadyProcess.WaitCallCount()).To(Equal(2))
+				})
+
+				Context("when the readiness command can't be run", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeServiceContainer.RunReturnsOnCall(1, nil, disaster)
+					})
+
+					It("returns the error without running the task", func() {
+						Expect(errors.Is(err, disaster)).To(BeTrue())
+						Expect(fakeContainer.AttachCallCount()).To(BeZero())
+						Expect(fakeContainer.RunCallCount()).To(BeZero())
+					})
+
+					It("destroys the service's container", func() {
+						Expect(fakeServiceContainer.DestroyCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("when the service is already running", func() {
+				BeforeEach(func() {
+					fakeServiceContainer.AttachReturns(fakeServiceProcess, nil)
+				})
+
+				It("does not run it again", func() {
+					Expect(fakeServiceContainer.RunCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when running the service fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeServiceContainer.RunReturns(nil, disaster)
+				})
+
+				It("returns the error without running the task", func() {
+					Expect(errors.Is(err, disaster)).To(BeTrue())
+					Expect(fakeContainer.AttachCallCount()).To(BeZero())
+					Expect(fakeContainer.RunCallCount()).To(BeZero())
+				})
+
+				It("stops and destroys the service's container", func() {
+					Expect(fakeServiceContainer.StopCallCount()).To(Equal(1))
+					Expect(fakeServiceContainer.DestroyCallCount()).To(Equal(1))
+				})
+			})
+		})
 	})
 
 	Describe("RunPutStep", func() {
@@ -1003,4 +1154,145 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Describe("RunRunStep", func() {
+		var (
+			ctx               context.Context
+			owner             db.ContainerOwner
+			containerSpec     worker.ContainerSpec
+			fakeEventDelegate *runtimefakes.FakeStartingEventDelegate
+			fakeContainer     *workerfakes.FakeContainer
+			processSpec       runtime.ProcessSpec
+			request           runtime.RunRequest
+			volumeMounts      []worker.VolumeMount
+
+			result worker.RunResult
+			err    error
+
+			disasterErr error
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			owner = new(dbfakes.FakeContainerOwner)
+			containerSpec = worker.ContainerSpec{
+				TeamID: 123,
+				ImageSpec: worker.ImageSpec{
+					ResourceType: "some-prototype",
+				},
+				Dir: "some-artifact-root",
+			}
+			fakeEventDelegate = new(runtimefakes.FakeStartingEventDelegate)
+
+			volumeMounts = []worker.VolumeMount{
+				{MountPath: "some-artifact-root/some-output/"},
+			}
+
+			fakeContainer = new(workerfakes.FakeContainer)
+			fakeContainer.VolumeMountsReturns(volumeMounts)
+			fakeWorker.FindOrCreateContainerReturns(fakeContainer, nil)
+
+			disasterErr = errors.New("oh no")
+
+			processSpec = runtime.ProcessSpec{
+				Path:         "/usr/bin/run",
+				Args:         []string{"some-artifact-root"},
+				StderrWriter: new(gbytes.Buffer),
+			}
+
+			request = runtime.RunRequest{
+				Object: atc.Params{"some": "param"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			result, err = client.RunRunStep(
+				ctx,
+				owner,
+				containerSpec,
+				metadata,
+				processSpec,
+				fakeEventDelegate,
+				request,
+			)
+		})
+
+		It("finds or creates a container on the worker", func() {
+			Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+			_, _, actualOwner, actualMetadata, actualContainerSpec := fakeWorker.FindOrCreateContainerArgsForCall(0)
+
+			Expect(actualContainerSpec).To(Equal(containerSpec))
+			Expect(actualOwner).To(Equal(owner))
+			Expect(actualMetadata).To(Equal(metadata))
+		})
+
+		It("invokes the Starting Event on the delegate", func() {
+			Expect(fakeEventDelegate.StartingCallCount()).To(Equal(1))
+		})
+
+		It("runs the message with the request on stdin", func() {
+			Expect(fakeContainer.RunScriptCallCount()).To(Equal(1))
+			_, path, args, input, _, logDest, recoverable := fakeContainer.RunScriptArgsForCall(0)
+			Expect(path).To(Equal("/usr/bin/run"))
+			Expect(args).To(Equal([]string{"some-artifact-root"}))
+			Expect(input).To(MatchJSON(`{"object":{"some":"param"}}`))
+			Expect(logDest).To(Equal(processSpec.StderrWriter))
+			Expect(recoverable).To(BeTrue())
+		})
+
+		Context("when the message responds", func() {
+			BeforeEach(func() {
+				fakeContainer.RunScriptStub = func(_ context.Context, _ string, _ []string, _ []byte, output interface{}, _ io.Writer, _ bool) error {
+					*output.(*runtime.RunResponse) = runtime.RunResponse{
+						{"some": "object"},
+					}
+					return nil
+				}
+			})
+
+			It("returns the response and the container's volume mounts", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(Equal(worker.RunResult{
+					ExitStatus:   0,
+					Response:     runtime.RunResponse{{"some": "object"}},
+					VolumeMounts: volumeMounts,
+				}))
+			})
+		})
+
+		Context("when the message exits nonzero", func() {
+			BeforeEach(func() {
+				fakeContainer.RunScriptReturns(runtime.ErrResourceScriptFailed{
+					ExitStatus: 10,
+				})
+			})
+
+			It("returns the exit status", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.ExitStatus).To(Equal(10))
+				Expect(result.Response).To(BeNil())
+			})
+		})
+
+		Context("when running the message errors", func() {
+			BeforeEach(func() {
+				fakeContainer.RunScriptReturns(disasterErr)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(Equal(disasterErr))
+			})
+		})
+
+		Context("worker.FindOrCreateContainer errored", func() {
+			BeforeEach(func() {
+				fakeWorker.FindOrCreateContainerReturns(nil, disasterErr)
+			})
+
+			It("returns the error without starting", func() {
+				Expect(err).To(Equal(disasterErr))
+				Expect(fakeEventDelegate.StartingCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -22,7 +22,8 @@ type Container interface {
 	gclient.Container
 	runtime.Runner
 
-	// TODO: get rid of this, its not used anywhere
+	// Destroy marks the container as destroying and destroys it on the
+	// worker.
 	Destroy() error
 
 	VolumeMounts() []VolumeMount
@@ -92,6 +93,13 @@ func newGardenWorkerContainer(
 }
 
 func (container *gardenWorkerContainer) Destroy() error {
+	// marking the container as destroying means it is no longer found for its
+	// owner, and that the worker destroys it in case this fails
+	_, err := container.dbContainer.Destroying()
+	if err != nil {
+		return err
+	}
+
 	return container.gardenClient.Destroy(container.Handle())
 }
 
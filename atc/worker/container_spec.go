@@ -5,15 +5,30 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
 	"go.opentelemetry.io/otel/propagation"
 )
 
 type WorkerSpec struct {
 	Platform     string
+	Arch         string
 	ResourceType string
 	Tags         []string
 	TeamID       int
+
+	// Hermetic requires a worker which can cut containers off from the
+	// network.
+	Hermetic bool
+
+	// Services requires a worker which can run service containers in the
+	// task container's network.
+	Services bool
+
+	// Priority orders the steps waiting for a worker. Higher priority steps
+	// are given the first turn to find a worker.
+	Priority int
 }
 
 type ContainerSpec struct {
@@ -36,11 +51,41 @@ type ContainerSpec struct {
 	// Resource limits to be set on the container when creating in garden.
 	Limits ContainerLimits
 
+	// Resources to reserve on the chosen worker for as long as the container
+	// is in use. Workers without enough capacity left are not chosen.
+	Requests ContainerRequests
+
 	// Local volumes to bind mount directly to the container when creating in garden.
 	BindMounts []BindMountSource
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Cut the container off from every network but its own loopback interface.
+	Hermetic bool
+
+	// Handle of a container whose network to join, rather than the container
+	// being given a network of its own.
+	NetworkOf string
+
+	// Services to run in containers of their own, sharing the container's
+	// network, while a task runs in it.
+	Services []ServiceSpec
+}
+
+// ServiceSpec describes a service run alongside a task, and the container
+// it runs in.
+type ServiceSpec struct {
+	Name string
+
+	Owner         db.ContainerOwner
+	Metadata      db.ContainerMetadata
+	ContainerSpec ContainerSpec
+	ProcessSpec   runtime.ProcessSpec
+
+	// A command which exits successfully once the service is ready, run in
+	// the service's container until it does.
+	Ready *runtime.ProcessSpec
 }
 
 // ContainerSpec must implement propagation.TextMapCarrier so that it can be
@@ -103,6 +148,13 @@ type ImageSpec struct {
 	ImageURL            string
 	ImageArtifactSource StreamableArtifactSource
 	Privileged          bool
+
+	// A registry to pull a docker:// ImageURL from instead.
+	RegistryMirror *atc.RegistryMirror
+
+	// The resource cache of an image fetched from an image_resource, which
+	// identifies the version of the image.
+	ResourceCacheID int
 }
 
 type ContainerLimits struct {
@@ -110,6 +162,21 @@ type ContainerLimits struct {
 	Memory *uint64
 }
 
+type ContainerRequests struct {
+	CPU    uint64
+	Memory uint64
+
+	// The step the resources are reserved for. Reservations are released
+	// along with the worker, or once the build completes.
+	BuildID int
+	PlanID  atc.PlanID
+}
+
+// IsEmpty returns true if no resources are requested.
+func (requests ContainerRequests) IsEmpty() bool {
+	return requests.CPU == 0 && requests.Memory == 0
+}
+
 type inputSource struct {
 	source ArtifactSource
 	path   string
@@ -151,6 +218,14 @@ func (spec WorkerSpec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("platform '%s'", spec.Platform))
 	}
 
+	if spec.Arch != "" {
+		attrs = append(attrs, fmt.Sprintf("arch '%s'", spec.Arch))
+	}
+
+	if spec.Hermetic || spec.Services {
+		attrs = append(attrs, "runtime 'containerd'")
+	}
+
 	for _, tag := range spec.Tags {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
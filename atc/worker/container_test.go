@@ -468,4 +468,36 @@ var _ = Describe("RunScript", func() {
 			})
 		})
 	})
+
+	Context("destroying", func() {
+		var destroyErr error
+
+		BeforeEach(func() {
+			fakeGClientContainer.HandleReturns("some-handle")
+		})
+
+		JustBeforeEach(func() {
+			destroyErr = workerContainer.Destroy()
+		})
+
+		It("marks the container as destroying and destroys it on the worker", func() {
+			Expect(destroyErr).ToNot(HaveOccurred())
+			Expect(fakeCreatedContainer.DestroyingCallCount()).To(Equal(1))
+			Expect(fakeGClient.DestroyCallCount()).To(Equal(1))
+			Expect(fakeGClient.DestroyArgsForCall(0)).To(Equal("some-handle"))
+		})
+
+		Context("when marking the container as destroying fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeCreatedContainer.DestroyingReturns(nil, disaster)
+			})
+
+			It("does not destroy it on the worker", func() {
+				Expect(destroyErr).To(Equal(disaster))
+				Expect(fakeGClient.DestroyCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -207,8 +207,11 @@ func (i *imageFromBaseResourceType) FetchForContainer(
 	return worker.FetchedImage{}, ErrUnsupportedResourceType
 }
 
+const DockerRootFSScheme = "docker"
+
 type imageFromRootfsURI struct {
-	url string
+	url    string
+	mirror *atc.RegistryMirror
 }
 
 func (i *imageFromRootfsURI) FetchForContainer(
@@ -216,8 +219,26 @@ func (i *imageFromRootfsURI) FetchForContainer(
 	logger lager.Logger,
 	container db.CreatingContainer,
 ) (worker.FetchedImage, error) {
+	if i.mirror == nil {
+		return worker.FetchedImage{
+			URL: i.url,
+		}, nil
+	}
+
+	imageURL, err := url.Parse(i.url)
+	if err != nil || imageURL.Scheme != DockerRootFSScheme {
+		// only images pulled from a registry can be mirrored
+		return worker.FetchedImage{
+			URL: i.url,
+		}, nil
+	}
+
+	imageURL.Host = i.mirror.Host
+
 	return worker.FetchedImage{
-		URL: i.url,
+		URL:      imageURL.String(),
+		Username: i.mirror.Username,
+		Password: i.mirror.Password,
 	}, nil
 }
 
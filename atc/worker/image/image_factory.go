@@ -55,6 +55,7 @@ func (f *imageFactory) GetImage(
 	}
 
 	return &imageFromRootfsURI{
-		url: imageSpec.ImageURL,
+		url:    imageSpec.ImageURL,
+		mirror: imageSpec.RegistryMirror,
 	}, nil
 }
@@ -378,5 +378,58 @@ var _ = Describe("Image", func() {
 				URL: "some-image-url",
 			}))
 		})
+
+		Context("when a registry mirror is specified", func() {
+			var imageURL string
+
+			BeforeEach(func() {
+				imageURL = "docker:///some-repo#some-tag"
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				img, err = imageFactory.GetImage(
+					logger,
+					fakeWorker,
+					fakeVolumeClient,
+					worker.ImageSpec{
+						ImageURL: imageURL,
+						RegistryMirror: &atc.RegistryMirror{
+							Host:     "some-mirror:5000",
+							Username: "some-user",
+							Password: "some-password",
+						},
+					},
+					42,
+				)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("pulls the image from the mirror with its credentials", func() {
+				fetchedImage, err := img.FetchForContainer(ctx, logger, fakeContainer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fetchedImage).To(Equal(worker.FetchedImage{
+					URL:      "docker://some-mirror:5000/some-repo#some-tag",
+					Username: "some-user",
+					Password: "some-password",
+				}))
+			})
+
+			Context("when the image is not pulled from a registry", func() {
+				BeforeEach(func() {
+					imageURL = "raw:///some-rootfs"
+				})
+
+				It("leaves it alone", func() {
+					fetchedImage, err := img.FetchForContainer(ctx, logger, fakeContainer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fetchedImage).To(Equal(worker.FetchedImage{
+						URL: "raw:///some-rootfs",
+					}))
+				})
+			})
+		})
 	})
 })
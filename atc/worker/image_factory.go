@@ -25,6 +25,10 @@ type FetchedImage struct {
 	Version    atc.Version
 	URL        string
 	Privileged bool
+
+	// Credentials for pulling the image from its URL.
+	Username string
+	Password string
 }
 
 //counterfeiter:generate . Image
@@ -22,6 +22,7 @@ var (
 	ErrTooManyActiveTasks = errors.New("worker has too many active tasks")
 	ErrTooManyContainers  = errors.New("worker has too many containers")
 	ErrTooManyVolumes     = errors.New("worker has too many volumes")
+	ErrNotEnoughResources = errors.New("worker does not have enough cpu or memory left for the requests")
 )
 
 type NoWorkerFitContainerPlacementStrategyError struct {
@@ -100,6 +101,11 @@ func NewChainPlacementStrategy(opts ContainerPlacementStrategyOptions) (*ChainPl
 		}
 	}
 
+	// Resource requests are always honoured, regardless of the configured
+	// strategies. Being the last node, it only filters the candidates and
+	// leaves their order to the others.
+	cps.nodes = append(cps.nodes, newResourceRequestsStrategy("resource-requests"))
+
 	return cps, nil
 }
 
@@ -400,3 +406,77 @@ func (strategy *LimitActiveVolumesStrategy) Approve(logger lager.Logger, worker
 
 func (strategy *LimitActiveVolumesStrategy) Release(logger lager.Logger, worker Worker, spec ContainerSpec) {
 }
+
+// Strategy which reserves the container's resource requests on the worker,
+// rejecting workers without enough capacity left to satisfy them
+type ResourceRequestsStrategy struct {
+	NamedPlacementStrategy
+}
+
+func newResourceRequestsStrategy(name string) ContainerPlacementStrategy {
+	return &ResourceRequestsStrategy{
+		NamedPlacementStrategy{name},
+	}
+}
+
+func (strategy *ResourceRequestsStrategy) Order(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	if spec.Requests.IsEmpty() {
+		return workers, nil
+	}
+
+	candidates := []Worker{}
+	for _, worker := range workers {
+		reservedCPU, reservedMemory, err := worker.ReservedResources()
+		if err != nil {
+			logger.Error("Cannot retrieve reserved resources on worker. Skipping.", err)
+			continue
+		}
+
+		if exceedsCapacity(reservedCPU+spec.Requests.CPU, worker.CPUCapacity()) ||
+			exceedsCapacity(reservedMemory+spec.Requests.Memory, worker.MemoryCapacity()) {
+			continue
+		}
+
+		candidates = append(candidates, worker)
+	}
+
+	return candidates, nil
+}
+
+func (strategy *ResourceRequestsStrategy) Approve(logger lager.Logger, worker Worker, spec ContainerSpec) error {
+	if spec.Requests.IsEmpty() {
+		return nil
+	}
+
+	reserved, err := worker.ReserveResources(
+		spec.Requests.BuildID,
+		spec.Requests.PlanID,
+		spec.Requests.CPU,
+		spec.Requests.Memory,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !reserved {
+		return ErrNotEnoughResources
+	}
+
+	return nil
+}
+
+func (strategy *ResourceRequestsStrategy) Release(logger lager.Logger, worker Worker, spec ContainerSpec) {
+	if spec.Requests.IsEmpty() {
+		return
+	}
+
+	err := worker.ReleaseResources(spec.Requests.BuildID, spec.Requests.PlanID)
+	if err != nil {
+		logger.Error("failed-to-release-resources", err)
+	}
+}
+
+// A capacity of 0 is unknown, and so never exceeded.
+func exceedsCapacity(request uint64, capacity uint64) bool {
+	return capacity != 0 && request > capacity
+}
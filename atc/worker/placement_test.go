@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 
@@ -676,6 +677,127 @@ var _ = Describe("ContainerPlacementStrategy", func() {
 		})
 	})
 
+	Describe("resource requests", func() {
+		JustBeforeEach(func() {
+			strategy, strategyErr = NewChainPlacementStrategy(ContainerPlacementStrategyOptions{
+				ContainerPlacementStrategy: []string{"fewest-build-containers"},
+			})
+			Expect(strategyErr).ToNot(HaveOccurred())
+		})
+
+		Context("when the container has no requests", func() {
+			JustBeforeEach(func() {
+				order(true)
+				pickAndRelease()
+			})
+
+			It("does not reserve anything", func() {
+				Expect(orderedWorkers).To(HaveLen(3))
+				for _, worker := range workerFakes {
+					Expect(worker.ReserveResourcesCallCount()).To(BeZero())
+					Expect(worker.ReleaseResourcesCallCount()).To(BeZero())
+				}
+			})
+		})
+
+		Context("when the container has requests", func() {
+			BeforeEach(func() {
+				containerSpec.Requests = ContainerRequests{
+					CPU:     512,
+					Memory:  1024,
+					BuildID: 42,
+					PlanID:  "some-plan-id",
+				}
+
+				workerFakes[0].CPUCapacityReturns(256)
+				workerFakes[1].MemoryCapacityReturns(2048)
+				workerFakes[1].BuildContainersReturns(1)
+				workerFakes[2].BuildContainersReturns(2)
+			})
+
+			Describe("strategy.Order", func() {
+				It("leaves out workers which could never satisfy them", func() {
+					Expect(order(true)).To(Equal([]Worker{workers[1], workers[2]}))
+				})
+
+				Context("when a worker does not have enough left after its reservations", func() {
+					BeforeEach(func() {
+						workerFakes[1].ReservedResourcesReturns(0, 1536, nil)
+					})
+
+					It("leaves it out", func() {
+						Expect(order(true)).To(Equal([]Worker{workers[2]}))
+					})
+				})
+
+				Context("when a worker's reservations cannot be retrieved", func() {
+					BeforeEach(func() {
+						workerFakes[2].ReservedResourcesReturns(0, 0, errors.New("nope"))
+					})
+
+					It("leaves it out", func() {
+						Expect(order(true)).To(Equal([]Worker{workers[1]}))
+					})
+				})
+
+				Context("when no worker could ever satisfy them", func() {
+					BeforeEach(func() {
+						workerFakes[1].CPUCapacityReturns(256)
+						workerFakes[2].MemoryCapacityReturns(512)
+					})
+
+					It("errors", func() {
+						order(false)
+						Expect(orderErr).To(Equal(NoWorkerFitContainerPlacementStrategyError{Strategy: "resource-requests"}))
+					})
+				})
+			})
+
+			Describe("strategy.Approve and strategy.Release", func() {
+				BeforeEach(func() {
+					workerFakes[1].ReserveResourcesReturns(false, nil)
+					workerFakes[2].ReserveResourcesReturns(true, nil)
+
+					orderedWorkers = workers[1:]
+				})
+
+				JustBeforeEach(func() {
+					pickAndRelease()
+				})
+
+				It("picks the first worker which can reserve them", func() {
+					Expect(pickedWorker).To(Equal(workers[2]))
+
+					buildID, planID, cpu, memory := workerFakes[2].ReserveResourcesArgsForCall(0)
+					Expect(buildID).To(Equal(42))
+					Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+					Expect(cpu).To(Equal(uint64(512)))
+					Expect(memory).To(Equal(uint64(1024)))
+				})
+
+				It("releases them", func() {
+					Expect(workerFakes[2].ReleaseResourcesCallCount()).To(Equal(1))
+					buildID, planID := workerFakes[2].ReleaseResourcesArgsForCall(0)
+					Expect(buildID).To(Equal(42))
+					Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+
+					Expect(workerFakes[1].ReleaseResourcesCallCount()).To(BeZero())
+				})
+
+				Context("when no worker can reserve them", func() {
+					BeforeEach(func() {
+						workerFakes[2].ReserveResourcesReturns(false, nil)
+					})
+
+					It("picks no worker", func() {
+						Expect(pickedWorker).To(BeNil())
+						Expect(pickErr).To(Equal(ErrNotEnoughResources))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("Chained placement strategy", func() {
 		Describe("strategy.Order", func() {
 			Context("fewest-build-containers,volume-locality", func() {
@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -18,6 +19,10 @@ import (
 
 const WorkerPollingInterval = 5 * time.Second
 
+// workerReleasedChannel is notified whenever a worker is released, so that
+// steps waiting for a worker on any ATC try again.
+const workerReleasedChannel = "worker_released"
+
 type NoCompatibleWorkersError struct {
 	Spec WorkerSpec
 }
@@ -68,13 +73,37 @@ type VolumeFinder interface {
 
 type pool struct {
 	provider WorkerProvider
-	waker    chan bool
+	bus      db.NotificationsBus
+
+	waitingLock sync.Mutex
+	waiting     map[*waitingStep]bool
+	waitingSeq  int
+
+	// starts the one listener for released workers once steps first wait
+	startRoundsOnce sync.Once
+}
+
+// waitingStep is a step in the queue of steps waiting for a worker on this
+// ATC.
+//
+// A step tries to find a worker as soon as it arrives, unless a step ahead of
+// it is waiting for its turn. It only joins the queue if it can't be placed.
+// A round starts whenever a worker is released on any ATC, and when the pool
+// is polled. In each round, the steps try to find a worker in queue order.
+// A step only tries once every step ahead of it has been passed over in the
+// round because it still couldn't be placed.
+type waitingStep struct {
+	priority int
+	seq      int
+	passed   bool
+	turn     chan struct{}
 }
 
-func NewPool(provider WorkerProvider) Pool {
+func NewPool(provider WorkerProvider, bus db.NotificationsBus) Pool {
 	return &pool{
 		provider: provider,
-		waker:    make(chan bool),
+		bus:      bus,
+		waiting:  map[*waitingStep]bool{},
 	}
 }
 
@@ -286,22 +315,40 @@ func (pool *pool) SelectWorker(
 	}
 
 	var worker Client
-	var pollingTicker *time.Ticker
+	var startedWaiting bool
+
+	step := pool.arrive(workerSpec)
+
+	// a step which is waiting only tries when it's given a turn
+	inRound := false
+
 	for {
-		var err error
-		worker, err = pool.findWorker(ctx, owner, containerSpec, workerSpec, strategy)
+		if pool.isNext(step) {
+			var err error
+			worker, err = pool.findWorker(ctx, owner, containerSpec, workerSpec, strategy)
 
-		if err != nil {
-			return nil, 0, err
-		}
+			if err != nil {
+				pool.stopWaiting(step)
+				return nil, 0, err
+			}
+
+			if worker != nil {
+				pool.stopWaiting(step)
+				break
+			}
 
-		if worker != nil {
-			break
+			if inRound {
+				pool.pass(step)
+			}
+		} else if inRound {
+			// the queue has changed since the step was given its turn
+			pool.giveTurn()
 		}
 
-		if pollingTicker == nil {
-			pollingTicker = time.NewTicker(WorkerPollingInterval)
-			defer pollingTicker.Stop()
+		if !startedWaiting {
+			startedWaiting = true
+
+			pool.startWaiting(logger, step)
 
 			logger.Debug("waiting-for-available-worker")
 
@@ -321,10 +368,12 @@ func (pool *pool) SelectWorker(
 		select {
 		case <-ctx.Done():
 			logger.Info("aborted-waiting-for-worker")
+			pool.stopWaiting(step)
 			return nil, 0, ctx.Err()
-		case <-pollingTicker.C:
-		case <-pool.waker:
+		case <-step.turn:
 		}
+
+		inRound = true
 	}
 
 	elapsed := time.Since(started)
@@ -345,15 +394,160 @@ func (pool *pool) ReleaseWorker(
 	logger := lagerctx.FromContext(ctx)
 	strategy.Release(logger, client.Worker(), containerSpec)
 
-	// Attempt to wake a random waiting step to see if it can be
-	// scheduled on the recently released worker.
+	// Let the steps waiting on every ATC know, so that they can see if they
+	// can be scheduled on the released worker.
+	err := pool.bus.Notify(workerReleasedChannel)
+	if err != nil {
+		logger.Error("failed-to-notify-released-worker", err)
+		pool.startRound()
+	}
+}
+
+// arrive returns a step for the worker spec, ordered after the steps which
+// arrived before it. The step only joins the queue once it has to wait.
+func (pool *pool) arrive(workerSpec WorkerSpec) *waitingStep {
+	pool.waitingLock.Lock()
+	defer pool.waitingLock.Unlock()
+
+	pool.waitingSeq++
+
+	return &waitingStep{
+		priority: workerSpec.Priority,
+		seq:      pool.waitingSeq,
+		turn:     make(chan struct{}, 1),
+	}
+}
+
+func (pool *pool) startWaiting(logger lager.Logger, step *waitingStep) {
+	pool.startRoundsOnce.Do(func() {
+		go pool.startRounds(logger.Session("start-rounds"))
+	})
+
+	pool.waitingLock.Lock()
+	defer pool.waitingLock.Unlock()
+
+	pool.waiting[step] = true
+}
+
+// stopWaiting removes the step from the queue. If it was waiting, the next
+// step is given a turn, as the worker may have room for more steps.
+func (pool *pool) stopWaiting(step *waitingStep) {
+	pool.waitingLock.Lock()
+	defer pool.waitingLock.Unlock()
+
+	if pool.waiting[step] {
+		delete(pool.waiting, step)
+		pool.giveTurnLocked()
+	}
+}
+
+// startRounds starts a round whenever a worker is released on any ATC, and
+// every WorkerPollingInterval. It runs for as long as the pool is in use.
+func (pool *pool) startRounds(logger lager.Logger) {
+	released, err := pool.bus.Listen(workerReleasedChannel)
+	if err != nil {
+		// keep polling for a worker
+		logger.Error("failed-to-listen-for-released-workers", err)
+	}
+
+	ticker := time.NewTicker(WorkerPollingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-released:
+		}
+
+		pool.startRound()
+	}
+}
+
+func (pool *pool) startRound() {
+	pool.waitingLock.Lock()
+	defer pool.waitingLock.Unlock()
+
+	for step := range pool.waiting {
+		step.passed = false
+	}
+
+	pool.giveTurnLocked()
+}
+
+// isNext returns whether every step ahead of the given one in the queue has
+// been passed over in the current round. A step which hasn't joined the queue
+// is next unless a step ahead of it is waiting for its turn.
+func (pool *pool) isNext(step *waitingStep) bool {
+	pool.waitingLock.Lock()
+	defer pool.waitingLock.Unlock()
+
+	next := pool.nextLocked()
+	if pool.waiting[step] {
+		return next == step
+	}
+
+	return next == nil || pool.wakesBefore(step, next)
+}
+
+// pass passes over the step for the rest of the round, and gives the next
+// step a turn.
+func (pool *pool) pass(step *waitingStep) {
+	pool.waitingLock.Lock()
+	defer pool.waitingLock.Unlock()
+
+	step.passed = true
+
+	pool.giveTurnLocked()
+}
+
+func (pool *pool) giveTurn() {
+	pool.waitingLock.Lock()
+	defer pool.waitingLock.Unlock()
+
+	pool.giveTurnLocked()
+}
+
+func (pool *pool) giveTurnLocked() {
+	next := pool.nextLocked()
+	if next == nil {
+		return
+	}
+
+	// the step may not have taken an earlier turn yet, in which case it'll
+	// try again anyway
 	select {
-	case pool.waker <- true:
-		logger.Debug("attempted-to-wake-waiting-step")
+	case next.turn <- struct{}{}:
 	default:
 	}
 }
 
+// nextLocked returns the first step in the queue which hasn't been passed
+// over in the current round.
+func (pool *pool) nextLocked() *waitingStep {
+	var next *waitingStep
+	for step := range pool.waiting {
+		if step.passed {
+			continue
+		}
+
+		if next == nil || pool.wakesBefore(step, next) {
+			next = step
+		}
+	}
+
+	return next
+}
+
+// wakesBefore orders the queue. Higher priority steps go first, and among
+// those of the same priority, the step which arrived first.
+func (pool *pool) wakesBefore(step, other *waitingStep) bool {
+	if step.priority != other.priority {
+		return step.priority > other.priority
+	}
+
+	return step.seq < other.seq
+}
+
 func (pool *pool) chooseRandomWorkerForVolume(
 	logger lager.Logger,
 	workerSpec WorkerSpec,
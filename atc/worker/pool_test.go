@@ -1,7 +1,10 @@
 package worker_test
 
 import (
+	"database/sql"
 	"fmt"
+	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
@@ -15,6 +18,7 @@ import (
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/lib/pq"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -23,6 +27,8 @@ var _ = Describe("Pool", func() {
 	var (
 		logger       *lagertest.TestLogger
 		fakeProvider *workerfakes.FakeWorkerProvider
+		fakeListener *dbfakes.FakeListener
+		bus          db.NotificationsBus
 
 		pool Pool
 	)
@@ -31,7 +37,21 @@ var _ = Describe("Pool", func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 
-		pool = NewPool(fakeProvider)
+		// deliver notifications to the bus's listeners as the database would
+		notifications := make(chan *pq.Notification, 100)
+
+		fakeListener = new(dbfakes.FakeListener)
+		fakeListener.NotificationChannelReturns(notifications)
+
+		fakeExecutor := new(dbfakes.FakeExecutor)
+		fakeExecutor.ExecStub = func(statement string, _ ...interface{}) (sql.Result, error) {
+			notifications <- &pq.Notification{Channel: strings.TrimPrefix(statement, "NOTIFY ")}
+			return nil, nil
+		}
+
+		bus = db.NewNotificationsBus(fakeListener, fakeExecutor)
+
+		pool = NewPool(fakeProvider, bus)
 	})
 
 	Describe("FindContainer", func() {
@@ -578,6 +598,192 @@ var _ = Describe("Pool", func() {
 				})
 			})
 		})
+
+		Context("when several steps are waiting for a worker", func() {
+			var waitCtx context.Context
+			var cancel context.CancelFunc
+			var fakeClient *workerfakes.FakeClient
+
+			// the number of steps the worker has room for
+			var capacityLock sync.Mutex
+			var capacity int
+
+			BeforeEach(func() {
+				waitCtx, cancel = context.WithCancel(lagerctx.NewContext(context.Background(), logger))
+
+				workerFakes[0].SatisfiesReturns(true)
+				fakeProvider.RunningWorkersReturns([]Worker{}, nil)
+
+				capacity = 0
+				fakeStrategy.ApproveStub = func(lager.Logger, Worker, ContainerSpec) error {
+					capacityLock.Lock()
+					defer capacityLock.Unlock()
+
+					if capacity == 0 {
+						return errors.New("full")
+					}
+
+					capacity--
+					return nil
+				}
+
+				fakeClient = new(workerfakes.FakeClient)
+				fakeClient.WorkerReturns(workerFakes[0])
+			})
+
+			AfterEach(func() {
+				cancel()
+			})
+
+			specFor := func(priority int) WorkerSpec {
+				spec := workerSpec
+				spec.Priority = priority
+				return spec
+			}
+
+			addCapacity := func() {
+				capacityLock.Lock()
+				capacity++
+				capacityLock.Unlock()
+			}
+
+			waitFor := func(spec WorkerSpec) <-chan Client {
+				callbacks := new(workerfakes.FakePoolCallbacks)
+
+				selected := make(chan Client, 1)
+				go func() {
+					defer GinkgoRecover()
+
+					client, _, err := pool.SelectWorker(waitCtx, fakeOwner, containerSpec, spec, fakeStrategy, callbacks)
+					if err == nil {
+						selected <- client
+					}
+				}()
+
+				Eventually(callbacks.WaitingForWorkerCallCount).Should(Equal(1))
+
+				return selected
+			}
+
+			release := func() {
+				addCapacity()
+				fakeProvider.RunningWorkersReturns(workers[:1], nil)
+
+				pool.ReleaseWorker(waitCtx, containerSpec, fakeClient, fakeStrategy)
+			}
+
+			It("wakes the highest priority step when a worker is released", func() {
+				lowSelected := waitFor(specFor(-1))
+				highSelected := waitFor(specFor(1))
+
+				release()
+
+				var selected Client
+				Eventually(highSelected).Should(Receive(&selected))
+				Expect(selected.Name()).To(Equal("worker-0"))
+
+				Consistently(lowSelected).ShouldNot(Receive())
+			})
+
+			It("wakes the step which has waited longest among equals", func() {
+				firstSelected := waitFor(specFor(0))
+				secondSelected := waitFor(specFor(0))
+
+				release()
+
+				Eventually(firstSelected).Should(Receive())
+				Consistently(secondSelected).ShouldNot(Receive())
+			})
+
+			It("doesn't place a step ahead of a higher priority step waiting for its turn", func() {
+				highSelected := waitFor(specFor(1))
+
+				addCapacity()
+				fakeProvider.RunningWorkersReturns(workers[:1], nil)
+				lowSelected := waitFor(specFor(-1))
+
+				Consistently(lowSelected).ShouldNot(Receive())
+				Consistently(highSelected).ShouldNot(Receive())
+
+				// the room taken by the low priority step's arrival is
+				// given to the high priority step on its next turn
+				pool.ReleaseWorker(waitCtx, containerSpec, fakeClient, fakeStrategy)
+
+				Eventually(highSelected).Should(Receive())
+				Consistently(lowSelected).ShouldNot(Receive())
+
+				release()
+
+				Eventually(lowSelected).Should(Receive())
+			})
+
+			It("gives the next step a turn when the woken step still can't be placed", func() {
+				workerFakes[0].SatisfiesStub = func(_ lager.Logger, spec WorkerSpec) bool {
+					return spec.Priority < 1
+				}
+
+				highSelected := waitFor(specFor(1))
+				lowSelected := waitFor(specFor(-1))
+
+				release()
+
+				Eventually(lowSelected).Should(Receive())
+				Consistently(highSelected).ShouldNot(Receive())
+			})
+
+			It("places a step straight away when no step ahead of it is waiting", func() {
+				workerFakes[0].SatisfiesStub = func(_ lager.Logger, spec WorkerSpec) bool {
+					return spec.Priority > -1
+				}
+
+				lowSelected := waitFor(specFor(-1))
+
+				addCapacity()
+				fakeProvider.RunningWorkersReturns(workers[:1], nil)
+
+				callbacks := new(workerfakes.FakePoolCallbacks)
+				_, _, err := pool.SelectWorker(waitCtx, fakeOwner, containerSpec, specFor(0), fakeStrategy, callbacks)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(callbacks.WaitingForWorkerCallCount()).To(Equal(0))
+
+				Consistently(lowSelected).ShouldNot(Receive())
+			})
+
+			It("waits behind a higher priority step which is waiting", func() {
+				highSelected := waitFor(specFor(1))
+				normalSelected := waitFor(specFor(0))
+
+				release()
+
+				Eventually(highSelected).Should(Receive())
+				Consistently(normalSelected).ShouldNot(Receive())
+			})
+
+			It("keeps listening for released workers while no steps are waiting", func() {
+				firstSelected := waitFor(specFor(0))
+				release()
+				Eventually(firstSelected).Should(Receive())
+
+				secondSelected := waitFor(specFor(0))
+				release()
+				Eventually(secondSelected).Should(Receive())
+
+				Expect(fakeListener.ListenCallCount()).To(Equal(1))
+				Expect(fakeListener.UnlistenCallCount()).To(Equal(0))
+			})
+
+			It("wakes steps waiting on other ATCs", func() {
+				otherPool := NewPool(fakeProvider, bus)
+
+				selected := waitFor(specFor(0))
+
+				addCapacity()
+				fakeProvider.RunningWorkersReturns(workers[:1], nil)
+				otherPool.ReleaseWorker(waitCtx, containerSpec, fakeClient, fakeStrategy)
+
+				Eventually(selected).Should(Receive())
+			})
+		})
 	})
 
 	Describe("FindWorkersForResourceCache", func() {
@@ -26,12 +26,26 @@ import (
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker/gclient"
 	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 const userPropertyName = "user"
 
+// hermeticPropertyName marks containers which must not be given network
+// access by the worker's runtime.
+const hermeticPropertyName = "concourse.hermetic"
+
+// containerdRuntime is the runtime of workers which run their containers with
+// containerd, the only runtime which honours hermeticPropertyName and
+// networkOfPropertyName.
+const containerdRuntime = "containerd"
+
+// networkOfPropertyName holds the handle of the container whose network
+// namespace the container joins.
+const networkOfPropertyName = "concourse.network-of"
+
 var ErrResourceConfigCheckSessionExpired = errors.New("no db container was found for owner")
 
 //counterfeiter:generate . Worker
@@ -40,6 +54,7 @@ type Worker interface {
 
 	Description() string
 	Name() string
+	Arch() string
 	ResourceTypes() []atc.WorkerResourceType
 	Tags() atc.Tags
 	Uptime() time.Duration
@@ -85,6 +100,12 @@ type Worker interface {
 
 	ActiveContainers() int
 	ActiveVolumes() int
+
+	CPUCapacity() uint64
+	MemoryCapacity() uint64
+	ReservedResources() (uint64, uint64, error)
+	ReserveResources(buildID int, planID atc.PlanID, cpu uint64, memory uint64) (bool, error)
+	ReleaseResources(buildID int, planID atc.PlanID) error
 }
 
 type gardenWorker struct {
@@ -221,6 +242,12 @@ func (worker *gardenWorker) FindOrCreateContainer(
 	if err != nil {
 		return c, fmt.Errorf("find or create container on worker %s: %w", worker.Name(), err)
 	}
+
+	tracing.FromContext(ctx).SetAttributes(
+		attribute.String("worker", worker.Name()),
+		attribute.String("container", c.Handle()),
+	)
+
 	return c, err
 }
 
@@ -776,6 +803,10 @@ func (worker *gardenWorker) Tags() atc.Tags {
 	return worker.dbWorker.Tags()
 }
 
+func (worker *gardenWorker) Arch() string {
+	return worker.dbWorker.Arch()
+}
+
 func (worker *gardenWorker) Ephemeral() bool {
 	return worker.dbWorker.Ephemeral()
 }
@@ -812,6 +843,22 @@ func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool
 		}
 	}
 
+	// workers which registered without an arch can't be assumed to satisfy
+	// an arch constraint
+	if spec.Arch != "" {
+		if spec.Arch != worker.dbWorker.Arch() {
+			return false
+		}
+	}
+
+	// Guardian and Houdini would give a hermetic container network access,
+	// and service containers a network of their own
+	if spec.Hermetic || spec.Services {
+		if worker.dbWorker.Runtime() != containerdRuntime {
+			return false
+		}
+	}
+
 	if !worker.tagsMatch(spec.Tags) {
 		return false
 	}
@@ -824,6 +871,10 @@ func (worker *gardenWorker) Description() string {
 		fmt.Sprintf("platform '%s'", worker.dbWorker.Platform()),
 	}
 
+	if worker.dbWorker.Arch() != "" {
+		messages = append(messages, fmt.Sprintf("arch '%s'", worker.dbWorker.Arch()))
+	}
+
 	for _, tag := range worker.dbWorker.Tags() {
 		messages = append(messages, fmt.Sprintf("tag '%s'", tag))
 	}
@@ -883,3 +934,23 @@ func (worker *gardenWorker) ActiveContainers() int {
 func (worker *gardenWorker) ActiveVolumes() int {
 	return worker.dbWorker.ActiveVolumes()
 }
+
+func (worker *gardenWorker) CPUCapacity() uint64 {
+	return worker.dbWorker.CPUCapacity()
+}
+
+func (worker *gardenWorker) MemoryCapacity() uint64 {
+	return worker.dbWorker.MemoryCapacity()
+}
+
+func (worker *gardenWorker) ReservedResources() (uint64, uint64, error) {
+	return worker.dbWorker.ReservedResources()
+}
+
+func (worker *gardenWorker) ReserveResources(buildID int, planID atc.PlanID, cpu uint64, memory uint64) (bool, error) {
+	return worker.dbWorker.ReserveResources(buildID, planID, cpu, memory)
+}
+
+func (worker *gardenWorker) ReleaseResources(buildID int, planID atc.PlanID) error {
+	return worker.dbWorker.ReleaseResources(buildID, planID)
+}
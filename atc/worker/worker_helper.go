@@ -33,6 +33,14 @@ func (w workerHelper) createGardenContainer(
 		gardenProperties[userPropertyName] = fetchedImage.Metadata.User
 	}
 
+	if containerSpec.Hermetic {
+		gardenProperties[hermeticPropertyName] = "true"
+	}
+
+	if containerSpec.NetworkOf != "" {
+		gardenProperties[networkOfPropertyName] = containerSpec.NetworkOf
+	}
+
 	env := append(fetchedImage.Metadata.Env, containerSpec.Env...)
 
 	if w.dbWorker.HTTPProxyURL() != "" {
@@ -47,16 +55,27 @@ func (w workerHelper) createGardenContainer(
 		env = append(env, fmt.Sprintf("no_proxy=%s", w.dbWorker.NoProxy()))
 	}
 
-	return w.gardenClient.Create(
-		garden.ContainerSpec{
-			Handle:     handleToCreate,
-			RootFSPath: fetchedImage.URL,
-			Privileged: fetchedImage.Privileged,
-			BindMounts: bindMounts,
-			Limits:     containerSpec.Limits.ToGardenLimits(),
-			Env:        env,
-			Properties: gardenProperties,
-		})
+	gardenSpec := garden.ContainerSpec{
+		Handle:     handleToCreate,
+		RootFSPath: fetchedImage.URL,
+		Privileged: fetchedImage.Privileged,
+		BindMounts: bindMounts,
+		Limits:     containerSpec.Limits.ToGardenLimits(),
+		Env:        env,
+		Properties: gardenProperties,
+	}
+
+	if fetchedImage.Username != "" || fetchedImage.Password != "" {
+		// credentials can only be given alongside the image's URI
+		gardenSpec.RootFSPath = ""
+		gardenSpec.Image = garden.ImageRef{
+			URI:      fetchedImage.URL,
+			Username: fetchedImage.Username,
+			Password: fetchedImage.Password,
+		}
+	}
+
+	return w.gardenClient.Create(gardenSpec)
 }
 
 func (w workerHelper) constructGardenWorkerContainer(
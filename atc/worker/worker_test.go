@@ -32,6 +32,8 @@ var _ = Describe("Worker", func() {
 		activeContainers         int
 		resourceTypes            []atc.WorkerResourceType
 		platform                 string
+		arch                     string
+		workerRuntime            string
 		tags                     atc.Tags
 		teamID                   int
 		ephemeral                bool
@@ -91,6 +93,8 @@ var _ = Describe("Worker", func() {
 			},
 		}
 		platform = "some-platform"
+		arch = "arm64"
+		workerRuntime = "guardian"
 		tags = atc.Tags{"some", "tags"}
 		teamID = 17
 		ephemeral = true
@@ -232,6 +236,8 @@ var _ = Describe("Worker", func() {
 		fakeDBWorker.ActiveContainersReturns(activeContainers)
 		fakeDBWorker.ResourceTypesReturns(resourceTypes)
 		fakeDBWorker.PlatformReturns(platform)
+		fakeDBWorker.ArchReturns(arch)
+		fakeDBWorker.RuntimeReturns(workerRuntime)
 		fakeDBWorker.TagsReturns(tags)
 		fakeDBWorker.EphemeralReturns(ephemeral)
 		fakeDBWorker.TeamIDReturns(teamID)
@@ -672,6 +678,91 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when the arch is compatible", func() {
+			BeforeEach(func() {
+				spec.Arch = "arm64"
+			})
+
+			It("returns true", func() {
+				Expect(satisfies).To(BeTrue())
+			})
+		})
+
+		Context("when the arch is incompatible", func() {
+			BeforeEach(func() {
+				spec.Arch = "amd64"
+			})
+
+			It("returns false", func() {
+				Expect(satisfies).To(BeFalse())
+			})
+		})
+
+		Context("when an arch is required and the worker did not register one", func() {
+			BeforeEach(func() {
+				arch = ""
+				spec.Arch = "amd64"
+			})
+
+			It("returns false", func() {
+				Expect(satisfies).To(BeFalse())
+			})
+		})
+
+		Context("when the spec has services", func() {
+			BeforeEach(func() {
+				spec.Services = true
+			})
+
+			Context("when the worker runs containerd", func() {
+				BeforeEach(func() {
+					workerRuntime = "containerd"
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+
+			Context("when the worker runs another runtime", func() {
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the spec is hermetic", func() {
+			BeforeEach(func() {
+				spec.Hermetic = true
+			})
+
+			Context("when the worker runs containerd", func() {
+				BeforeEach(func() {
+					workerRuntime = "containerd"
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+
+			Context("when the worker runs another runtime", func() {
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+
+			Context("when the worker did not register its runtime", func() {
+				BeforeEach(func() {
+					workerRuntime = ""
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when the resource type is supported by the worker", func() {
 			BeforeEach(func() {
 				spec.ResourceType = "some-base-type"
@@ -928,6 +1019,57 @@ var _ = Describe("Worker", func() {
 					}))
 				})
 
+				Context("when the container is hermetic", func() {
+					BeforeEach(func() {
+						containerSpec.Hermetic = true
+					})
+
+					It("marks the container as hermetic for the runtime", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(Equal(garden.Properties{
+							"user":               "some-user",
+							"concourse.hermetic": "true",
+						}))
+					})
+				})
+
+				Context("when the image is pulled with credentials", func() {
+					BeforeEach(func() {
+						fakeImage.FetchForContainerReturns(FetchedImage{
+							Metadata: ImageMetadata{
+								Env: []string{"IMAGE=ENV"},
+							},
+							URL:      "docker://some-mirror/some-repo",
+							Username: "some-user",
+							Password: "some-password",
+						}, nil)
+					})
+
+					It("gives them to the runtime along with the image", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.RootFSPath).To(BeEmpty())
+						Expect(actualSpec.Image).To(Equal(garden.ImageRef{
+							URI:      "docker://some-mirror/some-repo",
+							Username: "some-user",
+							Password: "some-password",
+						}))
+					})
+				})
+
+				Context("when the container joins the network of another container", func() {
+					BeforeEach(func() {
+						containerSpec.NetworkOf = "some-task-handle"
+					})
+
+					It("tells the runtime whose network to join", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(Equal(garden.Properties{
+							"user":                 "some-user",
+							"concourse.network-of": "some-task-handle",
+						}))
+					})
+				})
+
 				Context("when the input and output destination paths overlap", func() {
 					var (
 						fakeRemoteInputUnderInput    *workerfakes.FakeInputSource
@@ -11,6 +11,35 @@ import (
 )
 
 type FakeArtifactStreamer struct {
+	GlobFilesInArtifactStub        func(context.Context, runtime.Artifact, string) ([]string, error)
+	globFilesInArtifactMutex       sync.RWMutex
+	globFilesInArtifactArgsForCall []struct {
+		arg1 context.Context
+		arg2 runtime.Artifact
+		arg3 string
+	}
+	globFilesInArtifactReturns struct {
+		result1 []string
+		result2 error
+	}
+	globFilesInArtifactReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	StreamArtifactStub        func(context.Context, runtime.Artifact) (io.ReadCloser, error)
+	streamArtifactMutex       sync.RWMutex
+	streamArtifactArgsForCall []struct {
+		arg1 context.Context
+		arg2 runtime.Artifact
+	}
+	streamArtifactReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	streamArtifactReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	StreamFileFromArtifactStub        func(context.Context, runtime.Artifact, string) (io.ReadCloser, error)
 	streamFileFromArtifactMutex       sync.RWMutex
 	streamFileFromArtifactArgsForCall []struct {
@@ -30,6 +59,137 @@ type FakeArtifactStreamer struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeArtifactStreamer) GlobFilesInArtifact(arg1 context.Context, arg2 runtime.Artifact, arg3 string) ([]string, error) {
+	fake.globFilesInArtifactMutex.Lock()
+	ret, specificReturn := fake.globFilesInArtifactReturnsOnCall[len(fake.globFilesInArtifactArgsForCall)]
+	fake.globFilesInArtifactArgsForCall = append(fake.globFilesInArtifactArgsForCall, struct {
+		arg1 context.Context
+		arg2 runtime.Artifact
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GlobFilesInArtifactStub
+	fakeReturns := fake.globFilesInArtifactReturns
+	fake.recordInvocation("GlobFilesInArtifact", []interface{}{arg1, arg2, arg3})
+	fake.globFilesInArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArtifactStreamer) GlobFilesInArtifactCallCount() int {
+	fake.globFilesInArtifactMutex.RLock()
+	defer fake.globFilesInArtifactMutex.RUnlock()
+	return len(fake.globFilesInArtifactArgsForCall)
+}
+
+func (fake *FakeArtifactStreamer) GlobFilesInArtifactCalls(stub func(context.Context, runtime.Artifact, string) ([]string, error)) {
+	fake.globFilesInArtifactMutex.Lock()
+	defer fake.globFilesInArtifactMutex.Unlock()
+	fake.GlobFilesInArtifactStub = stub
+}
+
+func (fake *FakeArtifactStreamer) GlobFilesInArtifactArgsForCall(i int) (context.Context, runtime.Artifact, string) {
+	fake.globFilesInArtifactMutex.RLock()
+	defer fake.globFilesInArtifactMutex.RUnlock()
+	argsForCall := fake.globFilesInArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeArtifactStreamer) GlobFilesInArtifactReturns(result1 []string, result2 error) {
+	fake.globFilesInArtifactMutex.Lock()
+	defer fake.globFilesInArtifactMutex.Unlock()
+	fake.GlobFilesInArtifactStub = nil
+	fake.globFilesInArtifactReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactStreamer) GlobFilesInArtifactReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.globFilesInArtifactMutex.Lock()
+	defer fake.globFilesInArtifactMutex.Unlock()
+	fake.GlobFilesInArtifactStub = nil
+	if fake.globFilesInArtifactReturnsOnCall == nil {
+		fake.globFilesInArtifactReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.globFilesInArtifactReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactStreamer) StreamArtifact(arg1 context.Context, arg2 runtime.Artifact) (io.ReadCloser, error) {
+	fake.streamArtifactMutex.Lock()
+	ret, specificReturn := fake.streamArtifactReturnsOnCall[len(fake.streamArtifactArgsForCall)]
+	fake.streamArtifactArgsForCall = append(fake.streamArtifactArgsForCall, struct {
+		arg1 context.Context
+		arg2 runtime.Artifact
+	}{arg1, arg2})
+	stub := fake.StreamArtifactStub
+	fakeReturns := fake.streamArtifactReturns
+	fake.recordInvocation("StreamArtifact", []interface{}{arg1, arg2})
+	fake.streamArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArtifactStreamer) StreamArtifactCallCount() int {
+	fake.streamArtifactMutex.RLock()
+	defer fake.streamArtifactMutex.RUnlock()
+	return len(fake.streamArtifactArgsForCall)
+}
+
+func (fake *FakeArtifactStreamer) StreamArtifactCalls(stub func(context.Context, runtime.Artifact) (io.ReadCloser, error)) {
+	fake.streamArtifactMutex.Lock()
+	defer fake.streamArtifactMutex.Unlock()
+	fake.StreamArtifactStub = stub
+}
+
+func (fake *FakeArtifactStreamer) StreamArtifactArgsForCall(i int) (context.Context, runtime.Artifact) {
+	fake.streamArtifactMutex.RLock()
+	defer fake.streamArtifactMutex.RUnlock()
+	argsForCall := fake.streamArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeArtifactStreamer) StreamArtifactReturns(result1 io.ReadCloser, result2 error) {
+	fake.streamArtifactMutex.Lock()
+	defer fake.streamArtifactMutex.Unlock()
+	fake.StreamArtifactStub = nil
+	fake.streamArtifactReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactStreamer) StreamArtifactReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.streamArtifactMutex.Lock()
+	defer fake.streamArtifactMutex.Unlock()
+	fake.StreamArtifactStub = nil
+	if fake.streamArtifactReturnsOnCall == nil {
+		fake.streamArtifactReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.streamArtifactReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeArtifactStreamer) StreamFileFromArtifact(arg1 context.Context, arg2 runtime.Artifact, arg3 string) (io.ReadCloser, error) {
 	fake.streamFileFromArtifactMutex.Lock()
 	ret, specificReturn := fake.streamFileFromArtifactReturnsOnCall[len(fake.streamFileFromArtifactArgsForCall)]
@@ -99,6 +259,10 @@ func (fake *FakeArtifactStreamer) StreamFileFromArtifactReturnsOnCall(i int, res
 func (fake *FakeArtifactStreamer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.globFilesInArtifactMutex.RLock()
+	defer fake.globFilesInArtifactMutex.RUnlock()
+	fake.streamArtifactMutex.RLock()
+	defer fake.streamArtifactMutex.RUnlock()
 	fake.streamFileFromArtifactMutex.RLock()
 	defer fake.streamFileFromArtifactMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
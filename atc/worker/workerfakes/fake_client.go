@@ -80,6 +80,25 @@ type FakeClient struct {
 		result1 worker.PutResult
 		result2 error
 	}
+	RunRunStepStub        func(context.Context, db.ContainerOwner, worker.ContainerSpec, db.ContainerMetadata, runtime.ProcessSpec, runtime.StartingEventDelegate, runtime.RunRequest) (worker.RunResult, error)
+	runRunStepMutex       sync.RWMutex
+	runRunStepArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ContainerOwner
+		arg3 worker.ContainerSpec
+		arg4 db.ContainerMetadata
+		arg5 runtime.ProcessSpec
+		arg6 runtime.StartingEventDelegate
+		arg7 runtime.RunRequest
+	}
+	runRunStepReturns struct {
+		result1 worker.RunResult
+		result2 error
+	}
+	runRunStepReturnsOnCall map[int]struct {
+		result1 worker.RunResult
+		result2 error
+	}
 	RunTaskStepStub        func(context.Context, db.ContainerOwner, worker.ContainerSpec, db.ContainerMetadata, runtime.ProcessSpec, runtime.StartingEventDelegate) (worker.TaskResult, error)
 	runTaskStepMutex       sync.RWMutex
 	runTaskStepArgsForCall []struct {
@@ -376,6 +395,76 @@ func (fake *FakeClient) RunPutStepReturnsOnCall(i int, result1 worker.PutResult,
 	}{result1, result2}
 }
 
+func (fake *FakeClient) RunRunStep(arg1 context.Context, arg2 db.ContainerOwner, arg3 worker.ContainerSpec, arg4 db.ContainerMetadata, arg5 runtime.ProcessSpec, arg6 runtime.StartingEventDelegate, arg7 runtime.RunRequest) (worker.RunResult, error) {
+	fake.runRunStepMutex.Lock()
+	ret, specificReturn := fake.runRunStepReturnsOnCall[len(fake.runRunStepArgsForCall)]
+	fake.runRunStepArgsForCall = append(fake.runRunStepArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ContainerOwner
+		arg3 worker.ContainerSpec
+		arg4 db.ContainerMetadata
+		arg5 runtime.ProcessSpec
+		arg6 runtime.StartingEventDelegate
+		arg7 runtime.RunRequest
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	stub := fake.RunRunStepStub
+	fakeReturns := fake.runRunStepReturns
+	fake.recordInvocation("RunRunStep", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.runRunStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RunRunStepCallCount() int {
+	fake.runRunStepMutex.RLock()
+	defer fake.runRunStepMutex.RUnlock()
+	return len(fake.runRunStepArgsForCall)
+}
+
+func (fake *FakeClient) RunRunStepCalls(stub func(context.Context, db.ContainerOwner, worker.ContainerSpec, db.ContainerMetadata, runtime.ProcessSpec, runtime.StartingEventDelegate, runtime.RunRequest) (worker.RunResult, error)) {
+	fake.runRunStepMutex.Lock()
+	defer fake.runRunStepMutex.Unlock()
+	fake.RunRunStepStub = stub
+}
+
+func (fake *FakeClient) RunRunStepArgsForCall(i int) (context.Context, db.ContainerOwner, worker.ContainerSpec, db.ContainerMetadata, runtime.ProcessSpec, runtime.StartingEventDelegate, runtime.RunRequest) {
+	fake.runRunStepMutex.RLock()
+	defer fake.runRunStepMutex.RUnlock()
+	argsForCall := fake.runRunStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+}
+
+func (fake *FakeClient) RunRunStepReturns(result1 worker.RunResult, result2 error) {
+	fake.runRunStepMutex.Lock()
+	defer fake.runRunStepMutex.Unlock()
+	fake.RunRunStepStub = nil
+	fake.runRunStepReturns = struct {
+		result1 worker.RunResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RunRunStepReturnsOnCall(i int, result1 worker.RunResult, result2 error) {
+	fake.runRunStepMutex.Lock()
+	defer fake.runRunStepMutex.Unlock()
+	fake.RunRunStepStub = nil
+	if fake.runRunStepReturnsOnCall == nil {
+		fake.runRunStepReturnsOnCall = make(map[int]struct {
+			result1 worker.RunResult
+			result2 error
+		})
+	}
+	fake.runRunStepReturnsOnCall[i] = struct {
+		result1 worker.RunResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) RunTaskStep(arg1 context.Context, arg2 db.ContainerOwner, arg3 worker.ContainerSpec, arg4 db.ContainerMetadata, arg5 runtime.ProcessSpec, arg6 runtime.StartingEventDelegate) (worker.TaskResult, error) {
 	fake.runTaskStepMutex.Lock()
 	ret, specificReturn := fake.runTaskStepReturnsOnCall[len(fake.runTaskStepArgsForCall)]
@@ -509,6 +598,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.runGetStepMutex.RUnlock()
 	fake.runPutStepMutex.RLock()
 	defer fake.runPutStepMutex.RUnlock()
+	fake.runRunStepMutex.RLock()
+	defer fake.runRunStepMutex.RUnlock()
 	fake.runTaskStepMutex.RLock()
 	defer fake.runTaskStepMutex.RUnlock()
 	fake.workerMutex.RLock()
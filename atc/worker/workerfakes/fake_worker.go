@@ -49,6 +49,16 @@ type FakeWorker struct {
 	activeVolumesReturnsOnCall map[int]struct {
 		result1 int
 	}
+	ArchStub        func() string
+	archMutex       sync.RWMutex
+	archArgsForCall []struct {
+	}
+	archReturns struct {
+		result1 string
+	}
+	archReturnsOnCall map[int]struct {
+		result1 string
+	}
 	BuildContainersStub        func() int
 	buildContainersMutex       sync.RWMutex
 	buildContainersArgsForCall []struct {
@@ -59,6 +69,16 @@ type FakeWorker struct {
 	buildContainersReturnsOnCall map[int]struct {
 		result1 int
 	}
+	CPUCapacityStub        func() uint64
+	cPUCapacityMutex       sync.RWMutex
+	cPUCapacityArgsForCall []struct {
+	}
+	cPUCapacityReturns struct {
+		result1 uint64
+	}
+	cPUCapacityReturnsOnCall map[int]struct {
+		result1 uint64
+	}
 	CertsVolumeStub        func(lager.Logger) (worker.Volume, bool, error)
 	certsVolumeMutex       sync.RWMutex
 	certsVolumeArgsForCall []struct {
@@ -290,6 +310,16 @@ type FakeWorker struct {
 		result2 bool
 		result3 error
 	}
+	MemoryCapacityStub        func() uint64
+	memoryCapacityMutex       sync.RWMutex
+	memoryCapacityArgsForCall []struct {
+	}
+	memoryCapacityReturns struct {
+		result1 uint64
+	}
+	memoryCapacityReturnsOnCall map[int]struct {
+		result1 uint64
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -300,6 +330,48 @@ type FakeWorker struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ReleaseResourcesStub        func(int, atc.PlanID) error
+	releaseResourcesMutex       sync.RWMutex
+	releaseResourcesArgsForCall []struct {
+		arg1 int
+		arg2 atc.PlanID
+	}
+	releaseResourcesReturns struct {
+		result1 error
+	}
+	releaseResourcesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReserveResourcesStub        func(int, atc.PlanID, uint64, uint64) (bool, error)
+	reserveResourcesMutex       sync.RWMutex
+	reserveResourcesArgsForCall []struct {
+		arg1 int
+		arg2 atc.PlanID
+		arg3 uint64
+		arg4 uint64
+	}
+	reserveResourcesReturns struct {
+		result1 bool
+		result2 error
+	}
+	reserveResourcesReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ReservedResourcesStub        func() (uint64, uint64, error)
+	reservedResourcesMutex       sync.RWMutex
+	reservedResourcesArgsForCall []struct {
+	}
+	reservedResourcesReturns struct {
+		result1 uint64
+		result2 uint64
+		result3 error
+	}
+	reservedResourcesReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 uint64
+		result3 error
+	}
 	ResourceTypesStub        func() []atc.WorkerResourceType
 	resourceTypesMutex       sync.RWMutex
 	resourceTypesArgsForCall []struct {
@@ -508,6 +580,59 @@ func (fake *FakeWorker) ActiveVolumesReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Arch() string {
+	fake.archMutex.Lock()
+	ret, specificReturn := fake.archReturnsOnCall[len(fake.archArgsForCall)]
+	fake.archArgsForCall = append(fake.archArgsForCall, struct {
+	}{})
+	stub := fake.ArchStub
+	fakeReturns := fake.archReturns
+	fake.recordInvocation("Arch", []interface{}{})
+	fake.archMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ArchCallCount() int {
+	fake.archMutex.RLock()
+	defer fake.archMutex.RUnlock()
+	return len(fake.archArgsForCall)
+}
+
+func (fake *FakeWorker) ArchCalls(stub func() string) {
+	fake.archMutex.Lock()
+	defer fake.archMutex.Unlock()
+	fake.ArchStub = stub
+}
+
+func (fake *FakeWorker) ArchReturns(result1 string) {
+	fake.archMutex.Lock()
+	defer fake.archMutex.Unlock()
+	fake.ArchStub = nil
+	fake.archReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) ArchReturnsOnCall(i int, result1 string) {
+	fake.archMutex.Lock()
+	defer fake.archMutex.Unlock()
+	fake.ArchStub = nil
+	if fake.archReturnsOnCall == nil {
+		fake.archReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.archReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeWorker) BuildContainers() int {
 	fake.buildContainersMutex.Lock()
 	ret, specificReturn := fake.buildContainersReturnsOnCall[len(fake.buildContainersArgsForCall)]
@@ -561,6 +686,59 @@ func (fake *FakeWorker) BuildContainersReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) CPUCapacity() uint64 {
+	fake.cPUCapacityMutex.Lock()
+	ret, specificReturn := fake.cPUCapacityReturnsOnCall[len(fake.cPUCapacityArgsForCall)]
+	fake.cPUCapacityArgsForCall = append(fake.cPUCapacityArgsForCall, struct {
+	}{})
+	stub := fake.CPUCapacityStub
+	fakeReturns := fake.cPUCapacityReturns
+	fake.recordInvocation("CPUCapacity", []interface{}{})
+	fake.cPUCapacityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CPUCapacityCallCount() int {
+	fake.cPUCapacityMutex.RLock()
+	defer fake.cPUCapacityMutex.RUnlock()
+	return len(fake.cPUCapacityArgsForCall)
+}
+
+func (fake *FakeWorker) CPUCapacityCalls(stub func() uint64) {
+	fake.cPUCapacityMutex.Lock()
+	defer fake.cPUCapacityMutex.Unlock()
+	fake.CPUCapacityStub = stub
+}
+
+func (fake *FakeWorker) CPUCapacityReturns(result1 uint64) {
+	fake.cPUCapacityMutex.Lock()
+	defer fake.cPUCapacityMutex.Unlock()
+	fake.CPUCapacityStub = nil
+	fake.cPUCapacityReturns = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *FakeWorker) CPUCapacityReturnsOnCall(i int, result1 uint64) {
+	fake.cPUCapacityMutex.Lock()
+	defer fake.cPUCapacityMutex.Unlock()
+	fake.CPUCapacityStub = nil
+	if fake.cPUCapacityReturnsOnCall == nil {
+		fake.cPUCapacityReturnsOnCall = make(map[int]struct {
+			result1 uint64
+		})
+	}
+	fake.cPUCapacityReturnsOnCall[i] = struct {
+		result1 uint64
+	}{result1}
+}
+
 func (fake *FakeWorker) CertsVolume(arg1 lager.Logger) (worker.Volume, bool, error) {
 	fake.certsVolumeMutex.Lock()
 	ret, specificReturn := fake.certsVolumeReturnsOnCall[len(fake.certsVolumeArgsForCall)]
@@ -1568,6 +1746,59 @@ func (fake *FakeWorker) LookupVolumeReturnsOnCall(i int, result1 worker.Volume,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeWorker) MemoryCapacity() uint64 {
+	fake.memoryCapacityMutex.Lock()
+	ret, specificReturn := fake.memoryCapacityReturnsOnCall[len(fake.memoryCapacityArgsForCall)]
+	fake.memoryCapacityArgsForCall = append(fake.memoryCapacityArgsForCall, struct {
+	}{})
+	stub := fake.MemoryCapacityStub
+	fakeReturns := fake.memoryCapacityReturns
+	fake.recordInvocation("MemoryCapacity", []interface{}{})
+	fake.memoryCapacityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) MemoryCapacityCallCount() int {
+	fake.memoryCapacityMutex.RLock()
+	defer fake.memoryCapacityMutex.RUnlock()
+	return len(fake.memoryCapacityArgsForCall)
+}
+
+func (fake *FakeWorker) MemoryCapacityCalls(stub func() uint64) {
+	fake.memoryCapacityMutex.Lock()
+	defer fake.memoryCapacityMutex.Unlock()
+	fake.MemoryCapacityStub = stub
+}
+
+func (fake *FakeWorker) MemoryCapacityReturns(result1 uint64) {
+	fake.memoryCapacityMutex.Lock()
+	defer fake.memoryCapacityMutex.Unlock()
+	fake.MemoryCapacityStub = nil
+	fake.memoryCapacityReturns = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *FakeWorker) MemoryCapacityReturnsOnCall(i int, result1 uint64) {
+	fake.memoryCapacityMutex.Lock()
+	defer fake.memoryCapacityMutex.Unlock()
+	fake.MemoryCapacityStub = nil
+	if fake.memoryCapacityReturnsOnCall == nil {
+		fake.memoryCapacityReturnsOnCall = make(map[int]struct {
+			result1 uint64
+		})
+	}
+	fake.memoryCapacityReturnsOnCall[i] = struct {
+		result1 uint64
+	}{result1}
+}
+
 func (fake *FakeWorker) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1621,6 +1852,194 @@ func (fake *FakeWorker) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ReleaseResources(arg1 int, arg2 atc.PlanID) error {
+	fake.releaseResourcesMutex.Lock()
+	ret, specificReturn := fake.releaseResourcesReturnsOnCall[len(fake.releaseResourcesArgsForCall)]
+	fake.releaseResourcesArgsForCall = append(fake.releaseResourcesArgsForCall, struct {
+		arg1 int
+		arg2 atc.PlanID
+	}{arg1, arg2})
+	stub := fake.ReleaseResourcesStub
+	fakeReturns := fake.releaseResourcesReturns
+	fake.recordInvocation("ReleaseResources", []interface{}{arg1, arg2})
+	fake.releaseResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ReleaseResourcesCallCount() int {
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
+	return len(fake.releaseResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) ReleaseResourcesCalls(stub func(int, atc.PlanID) error) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = stub
+}
+
+func (fake *FakeWorker) ReleaseResourcesArgsForCall(i int) (int, atc.PlanID) {
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
+	argsForCall := fake.releaseResourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorker) ReleaseResourcesReturns(result1 error) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = nil
+	fake.releaseResourcesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) ReleaseResourcesReturnsOnCall(i int, result1 error) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = nil
+	if fake.releaseResourcesReturnsOnCall == nil {
+		fake.releaseResourcesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseResourcesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) ReserveResources(arg1 int, arg2 atc.PlanID, arg3 uint64, arg4 uint64) (bool, error) {
+	fake.reserveResourcesMutex.Lock()
+	ret, specificReturn := fake.reserveResourcesReturnsOnCall[len(fake.reserveResourcesArgsForCall)]
+	fake.reserveResourcesArgsForCall = append(fake.reserveResourcesArgsForCall, struct {
+		arg1 int
+		arg2 atc.PlanID
+		arg3 uint64
+		arg4 uint64
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ReserveResourcesStub
+	fakeReturns := fake.reserveResourcesReturns
+	fake.recordInvocation("ReserveResources", []interface{}{arg1, arg2, arg3, arg4})
+	fake.reserveResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) ReserveResourcesCallCount() int {
+	fake.reserveResourcesMutex.RLock()
+	defer fake.reserveResourcesMutex.RUnlock()
+	return len(fake.reserveResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) ReserveResourcesCalls(stub func(int, atc.PlanID, uint64, uint64) (bool, error)) {
+	fake.reserveResourcesMutex.Lock()
+	defer fake.reserveResourcesMutex.Unlock()
+	fake.ReserveResourcesStub = stub
+}
+
+func (fake *FakeWorker) ReserveResourcesArgsForCall(i int) (int, atc.PlanID, uint64, uint64) {
+	fake.reserveResourcesMutex.RLock()
+	defer fake.reserveResourcesMutex.RUnlock()
+	argsForCall := fake.reserveResourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeWorker) ReserveResourcesReturns(result1 bool, result2 error) {
+	fake.reserveResourcesMutex.Lock()
+	defer fake.reserveResourcesMutex.Unlock()
+	fake.ReserveResourcesStub = nil
+	fake.reserveResourcesReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReserveResourcesReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.reserveResourcesMutex.Lock()
+	defer fake.reserveResourcesMutex.Unlock()
+	fake.ReserveResourcesStub = nil
+	if fake.reserveResourcesReturnsOnCall == nil {
+		fake.reserveResourcesReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.reserveResourcesReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReservedResources() (uint64, uint64, error) {
+	fake.reservedResourcesMutex.Lock()
+	ret, specificReturn := fake.reservedResourcesReturnsOnCall[len(fake.reservedResourcesArgsForCall)]
+	fake.reservedResourcesArgsForCall = append(fake.reservedResourcesArgsForCall, struct {
+	}{})
+	stub := fake.ReservedResourcesStub
+	fakeReturns := fake.reservedResourcesReturns
+	fake.recordInvocation("ReservedResources", []interface{}{})
+	fake.reservedResourcesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorker) ReservedResourcesCallCount() int {
+	fake.reservedResourcesMutex.RLock()
+	defer fake.reservedResourcesMutex.RUnlock()
+	return len(fake.reservedResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) ReservedResourcesCalls(stub func() (uint64, uint64, error)) {
+	fake.reservedResourcesMutex.Lock()
+	defer fake.reservedResourcesMutex.Unlock()
+	fake.ReservedResourcesStub = stub
+}
+
+func (fake *FakeWorker) ReservedResourcesReturns(result1 uint64, result2 uint64, result3 error) {
+	fake.reservedResourcesMutex.Lock()
+	defer fake.reservedResourcesMutex.Unlock()
+	fake.ReservedResourcesStub = nil
+	fake.reservedResourcesReturns = struct {
+		result1 uint64
+		result2 uint64
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) ReservedResourcesReturnsOnCall(i int, result1 uint64, result2 uint64, result3 error) {
+	fake.reservedResourcesMutex.Lock()
+	defer fake.reservedResourcesMutex.Unlock()
+	fake.ReservedResourcesStub = nil
+	if fake.reservedResourcesReturnsOnCall == nil {
+		fake.reservedResourcesReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 uint64
+			result3 error
+		})
+	}
+	fake.reservedResourcesReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 uint64
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeWorker) ResourceTypes() []atc.WorkerResourceType {
 	fake.resourceTypesMutex.Lock()
 	ret, specificReturn := fake.resourceTypesReturnsOnCall[len(fake.resourceTypesArgsForCall)]
@@ -1851,8 +2270,12 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.activeTasksMutex.RUnlock()
 	fake.activeVolumesMutex.RLock()
 	defer fake.activeVolumesMutex.RUnlock()
+	fake.archMutex.RLock()
+	defer fake.archMutex.RUnlock()
 	fake.buildContainersMutex.RLock()
 	defer fake.buildContainersMutex.RUnlock()
+	fake.cPUCapacityMutex.RLock()
+	defer fake.cPUCapacityMutex.RUnlock()
 	fake.certsVolumeMutex.RLock()
 	defer fake.certsVolumeMutex.RUnlock()
 	fake.createVolumeMutex.RLock()
@@ -1885,8 +2308,16 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.isVersionCompatibleMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
+	fake.memoryCapacityMutex.RLock()
+	defer fake.memoryCapacityMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
+	fake.reserveResourcesMutex.RLock()
+	defer fake.reserveResourcesMutex.RUnlock()
+	fake.reservedResourcesMutex.RLock()
+	defer fake.reservedResourcesMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.satisfiesMutex.RLock()
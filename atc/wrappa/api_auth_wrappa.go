@@ -47,11 +47,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
-			atc.ListBuildArtifacts:
+			atc.GetBuildPlanStatus,
+			atc.ListBuildArtifacts,
+			atc.ListBuildPublishedArtifacts:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.ApproveBuild,
+			atc.RejectBuild:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -61,13 +65,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListDestroyingVolumes,
 			atc.ListDestroyingContainers,
 			atc.ReportWorkerContainers,
-			atc.ReportWorkerVolumes:
+			atc.ReportWorkerVolumes,
+			atc.ReportWorkerUsage:
 			newHandler = wrappa.checkWorkerTeamAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// pipeline is public or authorized
 		case atc.GetPipeline,
 			atc.GetJobBuild,
 			atc.PipelineBadge,
+			atc.PipelineGroupBadge,
 			atc.JobBadge,
 			atc.ListJobs,
 			atc.GetJob,
@@ -103,7 +109,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListAllResources,
 			atc.ListBuilds,
 			atc.MainJobBadge,
-			atc.GetWall:
+			atc.GetWall,
+			atc.Search:
 			newHandler = auth.CheckAuthenticationIfProvidedHandler(handler, rejector)
 
 		// admin
@@ -113,7 +120,9 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.SetLogLevel,
 			atc.GetInfoCreds,
 			atc.SetWall,
-			atc.ClearWall:
+			atc.ClearWall,
+			atc.ListTeamPrunableState,
+			atc.PruneTeamState:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team and has required role, or is admin)
@@ -139,6 +148,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetConfig,
 			atc.GetCC,
 			atc.GetVersionsDB,
+			atc.ListPipelineVarSources,
 			atc.ListJobInputs,
 			atc.OrderPipelines,
 			atc.OrderPipelinesWithinGroup,
@@ -146,15 +156,27 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.PausePipeline,
 			atc.RenamePipeline,
 			atc.UnpauseJob,
+			atc.PauseJobs,
+			atc.UnpauseJobs,
 			atc.UnpausePipeline,
 			atc.ExposePipeline,
 			atc.HidePipeline,
 			atc.SaveConfig,
+			atc.ValidateConfig,
 			atc.ArchivePipeline,
 			atc.ClearTaskCache,
 			atc.CreateArtifact,
 			atc.ScheduleJob,
-			atc.GetArtifact:
+			atc.GetArtifact,
+			atc.ListServiceAccounts,
+			atc.CreateServiceAccount,
+			atc.DeleteServiceAccount,
+			atc.ListTeamWebhooks,
+			atc.SetTeamWebhook,
+			atc.DeleteTeamWebhook,
+			atc.ListPipelineTemplates,
+			atc.SetPipelineTemplate,
+			atc.DeletePipelineTemplate:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
@@ -0,0 +1,225 @@
+package wrappa
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/golang/groupcache/lru"
+	"github.com/tedsuo/rata"
+	"golang.org/x/time/rate"
+)
+
+type RateLimitClass string
+
+const (
+	// RateLimitRead covers cheap reads, i.e. GET requests which respond
+	// immediately.
+	RateLimitRead RateLimitClass = "read"
+
+	// RateLimitStream covers long-lived requests such as event streams,
+	// hijacking and artifact downloads.
+	RateLimitStream RateLimitClass = "stream"
+
+	// RateLimitWrite covers every request which mutates state.
+	RateLimitWrite RateLimitClass = "write"
+)
+
+type RateLimits struct {
+	Read      float64 `long:"api-rate-limit-read"         description:"Maximum sustained rate of read requests per second for each client (authenticated user, or IP address for unauthenticated requests). A value of 0 disables the limit."`
+	ReadBurst int     `long:"api-rate-limit-read-burst"   default:"100" description:"Number of read requests a client may make in a burst above the sustained rate."`
+
+	Stream      float64 `long:"api-rate-limit-stream"       description:"Maximum sustained rate of streaming requests (build events, hijacking, artifacts) per second for each client. A value of 0 disables the limit."`
+	StreamBurst int     `long:"api-rate-limit-stream-burst" default:"10" description:"Number of streaming requests a client may make in a burst above the sustained rate."`
+
+	Write      float64 `long:"api-rate-limit-write"        description:"Maximum sustained rate of mutating requests per second for each client. A value of 0 disables the limit."`
+	WriteBurst int     `long:"api-rate-limit-write-burst"  default:"20" description:"Number of mutating requests a client may make in a burst above the sustained rate."`
+
+	MaxClients int `long:"api-rate-limit-max-clients" default:"10000" description:"Maximum number of clients to track. The least recently seen clients are forgotten first."`
+}
+
+const defaultRateLimitMaxClients = 10000
+
+func (limits RateLimits) limit(class RateLimitClass) (rate.Limit, int) {
+	switch class {
+	case RateLimitRead:
+		return rate.Limit(limits.Read), limits.ReadBurst
+	case RateLimitStream:
+		return rate.Limit(limits.Stream), limits.StreamBurst
+	default:
+		return rate.Limit(limits.Write), limits.WriteBurst
+	}
+}
+
+type RateLimitWrappa struct {
+	logger  lager.Logger
+	limits  RateLimits
+	clients *rateLimitedClients
+}
+
+func NewRateLimitWrappa(
+	logger lager.Logger,
+	limits RateLimits,
+) Wrappa {
+	return RateLimitWrappa{
+		logger:  logger,
+		limits:  limits,
+		clients: newRateLimitedClients(limits.MaxClients),
+	}
+}
+
+func (wrappa RateLimitWrappa) Wrap(
+	handlers rata.Handlers,
+) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for action, handler := range handlers {
+		class, limited := rateLimitClass(action)
+		if !limited {
+			wrapped[action] = handler
+			continue
+		}
+
+		limit, burst := wrappa.limits.limit(class)
+		if limit == 0 {
+			wrapped[action] = handler
+			continue
+		}
+
+		if burst < 1 {
+			burst = 1
+		}
+
+		limitHit := &metric.Counter{}
+		metric.Metrics.RateLimitHit[action] = limitHit
+
+		wrapped[action] = wrappa.wrap(action, class, limit, burst, handler, limitHit)
+	}
+
+	return wrapped
+}
+
+func (wrappa RateLimitWrappa) wrap(
+	action string,
+	class RateLimitClass,
+	limit rate.Limit,
+	burst int,
+	handler http.Handler,
+	limitHit *metric.Counter,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limiter := wrappa.clients.limiter(string(class)+":"+rateLimitClientKey(r), limit, burst)
+
+		reservation := limiter.Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			reservation.Cancel()
+
+			wrappa.logger.Debug("rate-limit-reached", lager.Data{
+				"action": action,
+				"class":  class,
+			})
+			limitHit.Inc()
+
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// rateLimitClass determines which class of limits applies to the action.
+// Requests made by workers are never limited, as many workers may share the
+// same credentials and throttling their heartbeats would stall the cluster.
+func rateLimitClass(action string) (RateLimitClass, bool) {
+	switch action {
+	case atc.RegisterWorker,
+		atc.LandWorker,
+		atc.RetireWorker,
+		atc.PruneWorker,
+		atc.HeartbeatWorker,
+		atc.ReportWorkerUsage,
+		atc.DeleteWorker,
+		atc.ListDestroyingContainers,
+		atc.ReportWorkerContainers,
+		atc.ListDestroyingVolumes,
+		atc.ReportWorkerVolumes:
+		return "", false
+	case atc.BuildEvents,
+		atc.HijackContainer,
+		atc.GetArtifact,
+		atc.DownloadCLI:
+		return RateLimitStream, true
+	}
+
+	for _, route := range atc.Routes {
+		if route.Name == action {
+			if route.Method == http.MethodGet {
+				return RateLimitRead, true
+			}
+
+			return RateLimitWrite, true
+		}
+	}
+
+	return RateLimitWrite, true
+}
+
+// rateLimitClientKey identifies the client making the request by the subject
+// of its verified access token, falling back to its IP address. Unverified
+// tokens are ignored, as otherwise a client could send a new bogus token with
+// each request to escape its limit.
+func rateLimitClientKey(r *http.Request) string {
+	acc := accessor.GetAccessor(r)
+	if acc.IsAuthenticated() {
+		if sub := acc.Claims().Sub; sub != "" {
+			return "sub:" + sub
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+type rateLimitedClients struct {
+	cache *lru.Cache
+	mu    sync.Mutex // lru.Cache is not safe for concurrent access
+}
+
+func newRateLimitedClients(maxClients int) *rateLimitedClients {
+	// an lru.Cache without a maximum never evicts, so the clients would be
+	// tracked without bound
+	if maxClients < 1 {
+		maxClients = defaultRateLimitMaxClients
+	}
+
+	return &rateLimitedClients{
+		cache: lru.New(maxClients),
+	}
+}
+
+func (c *rateLimitedClients) limiter(key string, limit rate.Limit, burst int) *rate.Limiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	limiter, found := c.cache.Get(key)
+	if found {
+		return limiter.(*rate.Limiter)
+	}
+
+	newLimiter := rate.NewLimiter(limit, burst)
+	c.cache.Add(key, newLimiter)
+
+	return newLimiter
+}
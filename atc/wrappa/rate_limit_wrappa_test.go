@@ -0,0 +1,192 @@
+package wrappa_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/auditor/auditorfakes"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/concourse/concourse/atc/wrappa/wrappafakes"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rate Limit Wrappa", func() {
+	var (
+		fakeHandler *wrappafakes.FakeHandler
+		limits      wrappa.RateLimits
+		handlers    rata.Handlers
+	)
+
+	BeforeEach(func() {
+		fakeHandler = new(wrappafakes.FakeHandler)
+		limits = wrappa.RateLimits{
+			MaxClients: 10,
+		}
+	})
+
+	JustBeforeEach(func() {
+		// tokens of the form "valid:<subject>" are treated as verified
+		fakeAccessFactory := new(accessorfakes.FakeAccessFactory)
+		fakeAccessFactory.CreateStub = func(r *http.Request, role string) (accessor.Access, error) {
+			fakeAccess := new(accessorfakes.FakeAccess)
+
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if strings.HasPrefix(token, "valid:") {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.ClaimsReturns(accessor.Claims{Sub: strings.TrimPrefix(token, "valid:")})
+			}
+
+			return fakeAccess, nil
+		}
+
+		handlers = wrappa.MultiWrappa{
+			wrappa.NewRateLimitWrappa(lagertest.NewTestLogger("test"), limits),
+			wrappa.NewAccessorWrappa(lagertest.NewTestLogger("test"), fakeAccessFactory, new(auditorfakes.FakeAuditor), nil),
+		}.Wrap(rata.Handlers{
+			atc.ListAllJobs:     fakeHandler,
+			atc.BuildEvents:     fakeHandler,
+			atc.SaveConfig:      fakeHandler,
+			atc.HeartbeatWorker: fakeHandler,
+		})
+	})
+
+	AfterEach(func() {
+		metric.Metrics.RateLimitHit = map[string]*metric.Counter{}
+	})
+
+	serve := func(action string, token string, remoteAddr string) *httptest.ResponseRecorder {
+		request, err := http.NewRequest("GET", "localhost:8080", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		request.RemoteAddr = remoteAddr
+
+		recorder := httptest.NewRecorder()
+		handlers[action].ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	Context("when no limits are configured", func() {
+		It("does not limit any requests", func() {
+			for i := 0; i < 100; i++ {
+				Expect(serve(atc.ListAllJobs, "", "1.2.3.4:1234").Code).To(Equal(http.StatusOK))
+			}
+
+			Expect(fakeHandler.ServeHTTPCallCount()).To(Equal(100))
+		})
+	})
+
+	Context("when a limit is configured for reads", func() {
+		BeforeEach(func() {
+			limits.Read = 0.001
+			limits.ReadBurst = 2
+		})
+
+		It("allows requests up to the burst", func() {
+			Expect(serve(atc.ListAllJobs, "", "1.2.3.4:1234").Code).To(Equal(http.StatusOK))
+			Expect(serve(atc.ListAllJobs, "", "1.2.3.4:1234").Code).To(Equal(http.StatusOK))
+			Expect(fakeHandler.ServeHTTPCallCount()).To(Equal(2))
+		})
+
+		It("responds with a 429 and a retry hint once the limit is reached", func() {
+			serve(atc.ListAllJobs, "", "1.2.3.4:1234")
+			serve(atc.ListAllJobs, "", "1.2.3.4:1234")
+
+			recorder := serve(atc.ListAllJobs, "", "1.2.3.4:1234")
+			Expect(recorder.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(recorder.Header().Get("Retry-After")).ToNot(BeEmpty())
+			Expect(fakeHandler.ServeHTTPCallCount()).To(Equal(2))
+		})
+
+		It("increments the 'rate limit hit' counter", func() {
+			for i := 0; i < 4; i++ {
+				serve(atc.ListAllJobs, "", "1.2.3.4:1234")
+			}
+
+			Expect(metric.Metrics.RateLimitHit[atc.ListAllJobs].Delta()).To(Equal(float64(2)))
+		})
+
+		It("limits each IP address separately", func() {
+			serve(atc.ListAllJobs, "", "1.2.3.4:1234")
+			serve(atc.ListAllJobs, "", "1.2.3.4:1234")
+
+			Expect(serve(atc.ListAllJobs, "", "5.6.7.8:1234").Code).To(Equal(http.StatusOK))
+		})
+
+		It("limits each authenticated user separately, regardless of IP address", func() {
+			serve(atc.ListAllJobs, "valid:some-user", "1.2.3.4:1234")
+			serve(atc.ListAllJobs, "valid:some-user", "5.6.7.8:1234")
+
+			Expect(serve(atc.ListAllJobs, "valid:some-user", "9.9.9.9:1234").Code).To(Equal(http.StatusTooManyRequests))
+			Expect(serve(atc.ListAllJobs, "valid:some-other-user", "1.2.3.4:1234").Code).To(Equal(http.StatusOK))
+		})
+
+		It("limits requests with unverified tokens by IP address", func() {
+			serve(atc.ListAllJobs, "bogus-token-1", "1.2.3.4:1234")
+			serve(atc.ListAllJobs, "bogus-token-2", "1.2.3.4:1234")
+
+			Expect(serve(atc.ListAllJobs, "bogus-token-3", "1.2.3.4:1234").Code).To(Equal(http.StatusTooManyRequests))
+		})
+
+		It("does not limit other classes of requests", func() {
+			for i := 0; i < 5; i++ {
+				Expect(serve(atc.BuildEvents, "", "1.2.3.4:1234").Code).To(Equal(http.StatusOK))
+				Expect(serve(atc.SaveConfig, "", "1.2.3.4:1234").Code).To(Equal(http.StatusOK))
+			}
+		})
+	})
+
+	Context("when the number of clients to track is not set", func() {
+		BeforeEach(func() {
+			limits.MaxClients = 0
+			limits.Read = 0.001
+			limits.ReadBurst = 1
+		})
+
+		It("still limits requests", func() {
+			Expect(serve(atc.ListAllJobs, "", "1.2.3.4:1234").Code).To(Equal(http.StatusOK))
+			Expect(serve(atc.ListAllJobs, "", "1.2.3.4:1234").Code).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+
+	Context("when a limit is configured for streams", func() {
+		BeforeEach(func() {
+			limits.Stream = 0.001
+			limits.StreamBurst = 1
+		})
+
+		It("limits event streams", func() {
+			Expect(serve(atc.BuildEvents, "", "1.2.3.4:1234").Code).To(Equal(http.StatusOK))
+			Expect(serve(atc.BuildEvents, "", "1.2.3.4:1234").Code).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+
+	Context("when a limit is configured for writes", func() {
+		BeforeEach(func() {
+			limits.Write = 0.001
+			limits.WriteBurst = 1
+		})
+
+		It("limits mutations", func() {
+			Expect(serve(atc.SaveConfig, "", "1.2.3.4:1234").Code).To(Equal(http.StatusOK))
+			Expect(serve(atc.SaveConfig, "", "1.2.3.4:1234").Code).To(Equal(http.StatusTooManyRequests))
+		})
+
+		It("never limits requests made by workers", func() {
+			for i := 0; i < 5; i++ {
+				Expect(serve(atc.HeartbeatWorker, "", "1.2.3.4:1234").Code).To(Equal(http.StatusOK))
+			}
+		})
+	})
+})
@@ -46,19 +46,25 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.BuildResources,
 			atc.BuildEvents,
 			atc.ListBuildArtifacts,
+			atc.ListBuildPublishedArtifacts,
 			atc.GetBuildPreparation,
 			atc.GetBuildPlan,
+			atc.GetBuildPlanStatus,
 			atc.AbortBuild,
+			atc.ApproveBuild,
+			atc.RejectBuild,
 			atc.PruneWorker,
 			atc.LandWorker,
 			atc.ReportWorkerContainers,
 			atc.ReportWorkerVolumes,
+			atc.ReportWorkerUsage,
 			atc.RetireWorker,
 			atc.ListDestroyingContainers,
 			atc.ListDestroyingVolumes,
 			atc.GetPipeline,
 			atc.GetJobBuild,
 			atc.PipelineBadge,
+			atc.PipelineGroupBadge,
 			atc.JobBadge,
 			atc.ListJobs,
 			atc.GetJob,
@@ -98,6 +104,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListTeams,
 			atc.MainJobBadge,
 			atc.GetWall,
+			atc.Search,
 			atc.GetLogLevel,
 			atc.SetLogLevel,
 			atc.GetInfoCreds,
@@ -107,6 +114,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DeletePipeline,
 			atc.GetCC,
 			atc.GetVersionsDB,
+			atc.ListPipelineVarSources,
 			atc.ListJobInputs,
 			atc.OrderPipelines,
 			atc.OrderPipelinesWithinGroup,
@@ -114,13 +122,27 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ArchivePipeline,
 			atc.RenamePipeline,
 			atc.SaveConfig,
+			atc.ValidateConfig,
 			atc.UnpauseJob,
+			atc.PauseJobs,
+			atc.UnpauseJobs,
 			atc.ExposePipeline,
 			atc.HidePipeline,
 			atc.CreatePipelineBuild,
 			atc.ClearTaskCache,
 			atc.CreateArtifact,
-			atc.GetArtifact:
+			atc.GetArtifact,
+			atc.ListServiceAccounts,
+			atc.CreateServiceAccount,
+			atc.DeleteServiceAccount,
+			atc.ListTeamWebhooks,
+			atc.SetTeamWebhook,
+			atc.DeleteTeamWebhook,
+			atc.ListPipelineTemplates,
+			atc.SetPipelineTemplate,
+			atc.DeletePipelineTemplate,
+			atc.ListTeamPrunableState,
+			atc.PruneTeamState:
 
 		default:
 			panic("how do archived pipelines affect your endpoint?")
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type ApproveBuildCommand struct {
+	Job      flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of the job of the build"`
+	Build    string              `short:"b" long:"build" required:"true" description:"If job is specified: build number. If job not specified: build id"`
+	Approval string              `short:"a" long:"approval" required:"true" description:"Name of the approval step to approve"`
+}
+
+func (command *ApproveBuildCommand) Execute([]string) error {
+	err := decideApproval(command.Job, command.Build, command.Approval, true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("approved")
+	return nil
+}
+
+type RejectBuildCommand struct {
+	Job      flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of the job of the build"`
+	Build    string              `short:"b" long:"build" required:"true" description:"If job is specified: build number. If job not specified: build id"`
+	Approval string              `short:"a" long:"approval" required:"true" description:"Name of the approval step to reject"`
+}
+
+func (command *RejectBuildCommand) Execute([]string) error {
+	err := decideApproval(command.Job, command.Build, command.Approval, false)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("rejected")
+	return nil
+}
+
+func decideApproval(job flaghelpers.JobFlag, buildName string, approvalName string, approve bool) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if job.PipelineRef.Name == "" && job.JobName == "" {
+		build, exists, err = target.Client().Build(buildName)
+	} else {
+		build, exists, err = target.Team().JobBuild(job.PipelineRef, job.JobName, buildName)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	var found bool
+	if approve {
+		found, err = target.Client().ApproveBuild(strconv.Itoa(build.ID), approvalName)
+	} else {
+		found, err = target.Client().RejectBuild(strconv.Itoa(build.ID), approvalName)
+	}
+	if err != nil {
+		if errors.Is(err, concourse.ErrForbidden) {
+			return fmt.Errorf("you are not allowed to decide approval '%s'", approvalName)
+		}
+
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("build has no pending approval named '%s'", approvalName)
+	}
+
+	return nil
+}
@@ -25,11 +25,21 @@ type BuildsCommand struct {
 	Count       int                       `short:"c" long:"count" default:"50" description:"Number of builds you want to limit the return to"`
 	CurrentTeam bool                      `long:"current-team" description:"Show builds for the currently targeted team"`
 	Job         flaghelpers.JobFlag       `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of a job to get builds for"`
-	Json        bool                      `long:"json" description:"Print command result as JSON"`
 	Pipeline    *flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Name of a pipeline to get builds for"`
 	Teams       []string                  `short:"n"  long:"team" description:"Show builds for these teams"`
 	Since       string                    `long:"since" description:"Start of the range to filter builds"`
 	Until       string                    `long:"until" description:"End of the range to filter builds"`
+
+	Statuses     []string      `long:"status" value-name:"STATUS" choice:"pending" choice:"started" choice:"succeeded" choice:"failed" choice:"errored" choice:"aborted" description:"Only show builds with this status (can be specified multiple times)"`
+	PipelineGlob string        `long:"pipeline-glob" value-name:"GLOB" description:"Only show builds of pipelines whose name matches the glob"`
+	JobGlob      string        `long:"job-glob" value-name:"GLOB" description:"Only show builds of jobs whose name matches the glob"`
+	MinDuration  time.Duration `long:"min-duration" value-name:"DURATION" description:"Only show builds which ran for at least this long (e.g. 30m)"`
+	Worker       string        `long:"worker" value-name:"NAME" description:"Only show builds with containers on this worker; builds whose containers have been garbage collected are not found"`
+
+	SortBy  string `long:"sort-by" choice:"id" choice:"name" choice:"status" choice:"start" choice:"end" choice:"duration" default:"id" description:"Sort builds by this field; ids, times and durations are sorted largest first, names and statuses alphabetically. Sorted builds are the first --count builds in that order across all builds"`
+	Reverse bool   `long:"reverse" description:"Reverse the sort order"`
+
+	displayhelpers.OutputFlags
 }
 
 func (command *BuildsCommand) Execute([]string) error {
@@ -90,6 +100,11 @@ func (command *BuildsCommand) getBuilds(builds []atc.Build, currentTeam concours
 		}
 	} else if len(command.Teams) > 0 || command.CurrentTeam {
 		teams = command.validateCurrentTeam(teams, currentTeam, client)
+	} else if command.filtered() || command.sorted() {
+		builds, _, err = client.FilteredBuilds(page, command.buildFilter())
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		builds, _, err = client.Builds(page)
 		if err != nil {
@@ -175,8 +190,8 @@ func (command *BuildsCommand) validatePipelineBuilds(builds []atc.Build, current
 
 func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 	var err error
-	if command.Json {
-		err = displayhelpers.JsonPrint(builds)
+	if command.Structured() {
+		err = command.PrintStructured(builds)
 		if err != nil {
 			return err
 		}
@@ -200,29 +215,7 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 	for _, b := range builds[:buildCap] {
 		startTimeCell, endTimeCell, durationCell := populateTimeCells(time.Unix(b.StartTime, 0), time.Unix(b.EndTime, 0))
 
-		var nameCell ui.TableCell
-
-		var names []string
-		if b.PipelineName != "" {
-			pipelineRef := atc.PipelineRef{
-				Name:         b.PipelineName,
-				InstanceVars: b.PipelineInstanceVars,
-			}
-
-			names = append(names, pipelineRef.String())
-		}
-
-		if b.JobName != "" {
-			names = append(names, b.JobName)
-		}
-
-		if b.ResourceName != "" {
-			names = append(names, b.ResourceName)
-		}
-
-		names = append(names, b.Name)
-
-		nameCell.Contents = strings.Join(names, "/")
+		nameCell := ui.TableCell{Contents: buildDisplayName(b)}
 
 		createdBy := "system"
 		if b.CreatedBy != nil {
@@ -243,6 +236,30 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 	return table.Render(os.Stdout, Fly.PrintTableHeaders)
 }
 
+func buildDisplayName(b atc.Build) string {
+	var names []string
+	if b.PipelineName != "" {
+		pipelineRef := atc.PipelineRef{
+			Name:         b.PipelineName,
+			InstanceVars: b.PipelineInstanceVars,
+		}
+
+		names = append(names, pipelineRef.String())
+	}
+
+	if b.JobName != "" {
+		names = append(names, b.JobName)
+	}
+
+	if b.ResourceName != "" {
+		names = append(names, b.ResourceName)
+	}
+
+	names = append(names, b.Name)
+
+	return strings.Join(names, "/")
+}
+
 func (command *BuildsCommand) validateBuildArguments(timeSince time.Time, page concourse.Page, timeUntil time.Time) (concourse.Page, error) {
 	var err error
 	if command.Since != "" {
@@ -271,9 +288,44 @@ func (command *BuildsCommand) validateBuildArguments(timeSince time.Time, page c
 	if len(command.Teams) > 0 && command.AllTeams {
 		return page, errors.New("Cannot specify both --all-teams and --team")
 	}
+	if command.filtered() && (command.pipelineFlag() || command.jobFlag() || command.AllTeams || command.CurrentTeam || len(command.Teams) > 0) {
+		return page, errors.New("Cannot filter by --status, --pipeline-glob, --job-glob, --min-duration or --worker along with --pipeline, --job, --team, --all-teams or --current-team")
+	}
+	if command.sorted() && (command.pipelineFlag() || command.jobFlag() || command.AllTeams || command.CurrentTeam || len(command.Teams) > 0) {
+		return page, errors.New("Cannot sort with --sort-by or --reverse along with --pipeline, --job, --team, --all-teams or --current-team")
+	}
 	return page, err
 }
 
+func (command *BuildsCommand) filtered() bool {
+	return len(command.Statuses) > 0 ||
+		command.PipelineGlob != "" ||
+		command.JobGlob != "" ||
+		command.MinDuration > 0 ||
+		command.Worker != ""
+}
+
+func (command *BuildsCommand) sorted() bool {
+	return command.buildFilter().Sorted()
+}
+
+func (command *BuildsCommand) buildFilter() atc.BuildFilter {
+	filter := atc.BuildFilter{
+		Pipeline:    command.PipelineGlob,
+		Job:         command.JobGlob,
+		MinDuration: command.MinDuration,
+		Worker:      command.Worker,
+		SortBy:      atc.BuildSortField(command.SortBy),
+		Reverse:     command.Reverse,
+	}
+
+	for _, status := range command.Statuses {
+		filter.Statuses = append(filter.Statuses, atc.BuildStatus(status))
+	}
+
+	return filter
+}
+
 func populateTimeCells(startTime time.Time, endTime time.Time) (ui.TableCell, ui.TableCell, ui.TableCell) {
 	var startTimeCell ui.TableCell
 	var endTimeCell ui.TableCell
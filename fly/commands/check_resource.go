@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -17,6 +18,7 @@ type CheckResourceCommand struct {
 	Version  *atc.Version             `short:"f" long:"from"                     value-name:"VERSION"           description:"Version of the resource to check from, e.g. ref:abcd or path:thing-1.2.3.tgz"`
 	Async    bool                     `short:"a" long:"async"                    value-name:"ASYNC"             description:"Return the check without waiting for its result"`
 	Shallow  bool                     `long:"shallow"                          value-name:"SHALLOW"         description:"Check the resource itself only"`
+	Watch    bool                     `short:"w" long:"watch"                                                   description:"Stream the check's output and exit with its status (the default unless --async is given)"`
 }
 
 func (command *CheckResourceCommand) Execute(args []string) error {
@@ -30,6 +32,10 @@ func (command *CheckResourceCommand) Execute(args []string) error {
 		return err
 	}
 
+	if command.Watch && command.Async {
+		return errors.New("--watch cannot be combined with --async")
+	}
+
 	var version atc.Version
 	if command.Version != nil {
 		version = *command.Version
@@ -1,19 +1,33 @@
 package commands
 
 import (
+	"fmt"
 	"os"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
 	"github.com/fatih/color"
 )
 
 type ContainersCommand struct {
-	Json bool `long:"json" description:"Print command result as JSON"`
+	Team     string                    `          long:"team"     description:"Name of the team whose containers to list, if different from the target default"`
+	Worker   string                    `short:"w" long:"worker"   description:"Only list containers on this worker"`
+	Type     string                    `          long:"type"     description:"Only list containers of this type (e.g. check, get, put, task)"`
+	Pipeline *flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Only list containers belonging to this pipeline"`
+
+	SortBy  string `long:"sort-by" choice:"handle" choice:"worker" choice:"age" choice:"size" description:"Sort containers by this field (default: handle); ages are sorted oldest first, sizes largest first"`
+	Reverse bool   `long:"reverse" description:"Reverse the sort order"`
+
+	Details string `long:"details" value-name:"HANDLE" description:"Show the owning step, build and volumes of a single container"`
+
+	displayhelpers.OutputFlags
 }
 
 func (command *ContainersCommand) Execute([]string) error {
@@ -27,13 +41,40 @@ func (command *ContainersCommand) Execute([]string) error {
 		return err
 	}
 
-	containers, err := target.Team().ListContainers(map[string]string{})
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	if command.Details != "" {
+		return command.showDetails(target, team)
+	}
+
+	allContainers, err := team.ListContainers(map[string]string{})
 	if err != nil {
 		return err
 	}
 
-	if command.Json {
-		err = displayhelpers.JsonPrint(containers)
+	containers := []atc.Container{}
+	for _, c := range allContainers {
+		if command.matches(c) {
+			containers = append(containers, c)
+		}
+	}
+
+	// structured output is left in the order the API returned it in, unless
+	// a sort is asked for
+	if command.sorted() || !command.Structured() {
+		command.sortContainers(containers)
+	}
+
+	if command.Structured() {
+		err = command.PrintStructured(containers)
 		if err != nil {
 			return err
 		}
@@ -74,11 +115,205 @@ func (command *ContainersCommand) Execute([]string) error {
 		table.Data = append(table.Data, row)
 	}
 
-	sort.Sort(table.Data)
-
 	return table.Render(os.Stdout, Fly.PrintTableHeaders)
 }
 
+func (command *ContainersCommand) matches(c atc.Container) bool {
+	if command.Worker != "" && c.WorkerName != command.Worker {
+		return false
+	}
+
+	if command.Type != "" && c.Type != command.Type {
+		return false
+	}
+
+	if command.Pipeline != nil {
+		return pipelineMatches(command.Pipeline, c.PipelineName, c.PipelineInstanceVars)
+	}
+
+	return true
+}
+
+func (command *ContainersCommand) sorted() bool {
+	return command.SortBy != "" || command.Reverse
+}
+
+func (command *ContainersCommand) sortContainers(containers []atc.Container) {
+	var less func(a, b atc.Container) bool
+	switch command.SortBy {
+	case "worker":
+		less = func(a, b atc.Container) bool {
+			if a.WorkerName == b.WorkerName {
+				return a.ID < b.ID
+			}
+
+			return a.WorkerName < b.WorkerName
+		}
+	case "age":
+		less = func(a, b atc.Container) bool { return a.CreatedAt < b.CreatedAt }
+	case "size":
+		less = func(a, b atc.Container) bool { return a.SizeBytes > b.SizeBytes }
+	default:
+		less = func(a, b atc.Container) bool { return a.ID < b.ID }
+	}
+
+	if command.Reverse {
+		forward := less
+		less = func(a, b atc.Container) bool { return forward(b, a) }
+	}
+
+	sort.SliceStable(containers, func(i, j int) bool {
+		return less(containers[i], containers[j])
+	})
+}
+
+func (command *ContainersCommand) showDetails(target rc.Target, team concourse.Team) error {
+	container, err := team.GetContainer(command.Details)
+	if err != nil {
+		return err
+	}
+
+	volumes, err := team.ListVolumes()
+	if err != nil {
+		return err
+	}
+
+	volumesByHandle := map[string]atc.Volume{}
+	var containerVolumes []atc.Volume
+	for _, v := range volumes {
+		volumesByHandle[v.ID] = v
+
+		if v.ContainerHandle == container.ID {
+			containerVolumes = append(containerVolumes, v)
+		}
+	}
+
+	if command.Structured() {
+		return command.PrintStructured(container)
+	}
+
+	pipelineRef := atc.PipelineRef{
+		Name:         container.PipelineName,
+		InstanceVars: container.PipelineInstanceVars,
+	}
+
+	details := ui.Table{
+		Data: []ui.TableRow{
+			{{Contents: "handle"}, {Contents: container.ID}},
+			{{Contents: "worker"}, {Contents: container.WorkerName}},
+			{{Contents: "state"}, stringOrDefault(container.State)},
+			{{Contents: "age"}, ageOrUnknown(container.CreatedAt)},
+			{{Contents: "size"}, sizeOrUnknown(container.SizeBytes)},
+			{{Contents: "type"}, {Contents: container.Type}},
+			{{Contents: "step"}, stringOrDefault(container.StepName + container.ResourceName)},
+			{{Contents: "attempt"}, stringOrDefault(container.Attempt, "n/a")},
+			{{Contents: "pipeline"}, stringOrDefault(pipelineRef.String())},
+			{{Contents: "job"}, stringOrDefault(container.JobName)},
+			{{Contents: "build #"}, stringOrDefault(container.BuildName)},
+			{{Contents: "build"}, buildURLOrNone(target, container.BuildID)},
+		},
+	}
+
+	err = details.Render(os.Stdout, false)
+	if err != nil {
+		return err
+	}
+
+	if len(containerVolumes) == 0 {
+		return nil
+	}
+
+	sort.Slice(containerVolumes, func(i, j int) bool {
+		return containerVolumes[i].Path < containerVolumes[j].Path
+	})
+
+	fmt.Println()
+
+	mounts := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "volume", Color: color.New(color.Bold)},
+			{Contents: "path", Color: color.New(color.Bold)},
+			{Contents: "parent", Color: color.New(color.Bold)},
+			{Contents: "parent type", Color: color.New(color.Bold)},
+			{Contents: "parent identifier", Color: color.New(color.Bold)},
+			{Contents: "size", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, v := range containerVolumes {
+		row := ui.TableRow{
+			{Contents: v.ID},
+			{Contents: v.Path},
+			stringOrDefault(v.ParentHandle),
+		}
+
+		parent, found := volumesByHandle[v.ParentHandle]
+		if found {
+			row = append(row,
+				ui.TableCell{Contents: parent.Type},
+				ui.TableCell{Contents: volumeIdentifier(parent, false)},
+			)
+		} else {
+			row = append(row, stringOrDefault(""), stringOrDefault(""))
+		}
+
+		row = append(row, sizeOrUnknown(v.SizeBytes))
+
+		mounts.Data = append(mounts.Data, row)
+	}
+
+	return mounts.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+// pipelineMatches reports whether the pipeline identified by name and
+// instance vars is the one given by the flag. Without instance vars, the
+// flag matches every instance of the pipeline.
+func pipelineMatches(flag *flaghelpers.PipelineFlag, name string, instanceVars atc.InstanceVars) bool {
+	if flag.InstanceVars == nil {
+		return name == flag.Name
+	}
+
+	return atc.PipelineRef{Name: name, InstanceVars: instanceVars}.String() == flag.Ref().String()
+}
+
+func ageOrUnknown(createdAt int64) ui.TableCell {
+	if createdAt == 0 {
+		return ui.TableCell{Contents: "unknown", Color: ui.OffColor}
+	}
+
+	age := time.Since(time.Unix(createdAt, 0)).Round(time.Second)
+	return ui.TableCell{Contents: age.String()}
+}
+
+// sizeOrUnknown formats the size reported by a worker. Sizes are only
+// reported periodically, so a new container or volume has none yet.
+func sizeOrUnknown(bytes uint64) ui.TableCell {
+	if bytes == 0 {
+		return ui.TableCell{Contents: "unknown", Color: ui.OffColor}
+	}
+
+	const unit = 1024
+	if bytes < unit {
+		return ui.TableCell{Contents: fmt.Sprintf("%dB", bytes)}
+	}
+
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return ui.TableCell{Contents: fmt.Sprintf("%.1f%ciB", float64(bytes)/float64(div), "KMGTPE"[exp])}
+}
+
+func buildURLOrNone(target rc.Target, id int) ui.TableCell {
+	if id == 0 {
+		return ui.TableCell{Contents: "none", Color: ui.OffColor}
+	}
+
+	return ui.TableCell{Contents: fmt.Sprintf("%s/builds/%d", target.URL(), id)}
+}
+
 func buildIDOrNone(id int) ui.TableCell {
 	var column ui.TableCell
 
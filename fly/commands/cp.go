@@ -0,0 +1,296 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/hijacker"
+	"github.com/concourse/concourse/fly/commands/internal/hijackhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/concourse/go-archive/tarfs"
+	"github.com/tedsuo/rata"
+)
+
+// containerPathRegexp matches BUILD/STEP:PATH, where BUILD is a global build
+// ID or, with --job, the name of one of the job's builds (e.g. 12 or 12.1).
+var containerPathRegexp = regexp.MustCompile(`^([0-9][0-9.]*)/([^:]+):(.+)$`)
+
+// untarIntoScript extracts the tar stream on stdin to $1. If $1 is an
+// existing directory the copy is placed inside it, otherwise the copy (named
+// $2 in the stream) becomes $1.
+const untarIntoScript = `set -e
+if [ -d "$1" ]; then
+  exec tar -xf - -C "$1"
+fi
+tmp=$(mktemp -d "$(dirname "$1")/.fly-cp.XXXXXX")
+tar -xf - -C "$tmp"
+mv "$tmp/$2" "$1"
+rmdir "$tmp"
+`
+
+type CopyCommand struct {
+	Job     flaghelpers.JobFlag `short:"j" long:"job"     value-name:"PIPELINE/JOB" description:"Name of the job the build belongs to, if BUILD is a job build name rather than a global build ID"`
+	Attempt string              `short:"a" long:"attempt" value-name:"N[,N,...]"    description:"Attempt number of the step"`
+	Team    string              `          long:"team"                              description:"Name of the team to which the container belongs, if different from the target default"`
+
+	PositionalArgs struct {
+		Source      string `positional-arg-name:"SOURCE"      required:"true" description:"Local path, or BUILD/STEP:PATH in a step's container"`
+		Destination string `positional-arg-name:"DESTINATION" required:"true" description:"Local path, or BUILD/STEP:PATH in a step's container"`
+	} `positional-args:"yes"`
+}
+
+type containerPath struct {
+	build string
+	step  string
+	path  string
+}
+
+func parseContainerPath(arg string) (containerPath, bool) {
+	matches := containerPathRegexp.FindStringSubmatch(arg)
+	if matches == nil {
+		return containerPath{}, false
+	}
+
+	return containerPath{
+		build: matches[1],
+		step:  matches[2],
+		path:  matches[3],
+	}, true
+}
+
+func (command *CopyCommand) Execute([]string) error {
+	source, sourceIsRemote := parseContainerPath(command.PositionalArgs.Source)
+	destination, destinationIsRemote := parseContainerPath(command.PositionalArgs.Destination)
+
+	if sourceIsRemote == destinationIsRemote {
+		return errors.New("exactly one of SOURCE and DESTINATION must be of the form BUILD/STEP:PATH")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	if sourceIsRemote {
+		container, err := command.findContainer(target, team, source)
+		if err != nil {
+			return err
+		}
+
+		return command.download(target, team, container, source.path, command.PositionalArgs.Destination)
+	}
+
+	container, err := command.findContainer(target, team, destination)
+	if err != nil {
+		return err
+	}
+
+	return command.upload(target, team, container, command.PositionalArgs.Source, destination.path)
+}
+
+func (command *CopyCommand) findContainer(target rc.Target, team concourse.Team, cp containerPath) (atc.Container, error) {
+	fingerprint := &containerFingerprint{
+		pipelineName:  command.Job.PipelineRef.Name,
+		jobName:       command.Job.JobName,
+		buildNameOrID: cp.build,
+		stepName:      cp.step,
+		attempt:       command.Attempt,
+	}
+
+	if command.Job.PipelineRef.InstanceVars != nil {
+		instanceVarsJSON, _ := json.Marshal(command.Job.PipelineRef.InstanceVars)
+		fingerprint.pipelineInstanceVars = string(instanceVarsJSON)
+	}
+
+	reqValues, err := locateContainer(target.Client(), fingerprint)
+	if err != nil {
+		return atc.Container{}, err
+	}
+
+	containers, err := team.ListContainers(reqValues)
+	if err != nil {
+		return atc.Container{}, err
+	}
+
+	sort.Sort(hijackhelpers.ContainerSorter(containers))
+
+	var candidates []atc.Container
+	for _, container := range containers {
+		if container.State == atc.ContainerStateCreated || container.State == atc.ContainerStateFailed {
+			candidates = append(candidates, container)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		displayhelpers.Failf("no containers matched your search parameters!\n\nthey may have expired if your build hasn't recently finished.")
+	case 1:
+	default:
+		return atc.Container{}, fmt.Errorf("%d containers matched; use --attempt to choose one", len(candidates))
+	}
+
+	return candidates[0], nil
+}
+
+// download streams a tarball of the remote path out of the container and
+// extracts it to the local destination.
+func (command *CopyCommand) download(target rc.Target, team concourse.Team, container atc.Container, remotePath string, localPath string) error {
+	spec := atc.HijackProcessSpec{
+		Path: "tar",
+		Args: []string{"-cf", "-", "-C", path.Dir(remotePath), path.Base(remotePath)},
+		User: container.User,
+		Dir:  container.WorkingDirectory,
+	}
+
+	extractDir := localPath
+
+	info, err := os.Stat(localPath)
+	isDir := err == nil && info.IsDir()
+	if !isDir {
+		extractDir, err = ioutil.TempDir(filepath.Dir(localPath), ".fly-cp")
+		if err != nil {
+			return err
+		}
+
+		defer os.RemoveAll(extractDir)
+	}
+
+	archive, archiveWriter := io.Pipe()
+
+	extracted := make(chan error, 1)
+	go func() {
+		err := tarfs.Extract(archive, extractDir)
+		archive.CloseWithError(err)
+		extracted <- err
+	}()
+
+	inputs := make(chan atc.HijackInput, 1)
+	inputs <- atc.HijackInput{Closed: true}
+
+	exitStatus, err := command.run(target, team, container, spec, hijacker.ProcessIO{
+		In:  inputs,
+		Out: archiveWriter,
+		Err: os.Stderr,
+	})
+	archiveWriter.Close()
+
+	extractErr := <-extracted
+	if err != nil {
+		return err
+	}
+
+	if exitStatus != 0 {
+		return fmt.Errorf("copying from container failed with exit status %d", exitStatus)
+	}
+
+	if extractErr != nil {
+		return extractErr
+	}
+
+	if !isDir {
+		return os.Rename(filepath.Join(extractDir, path.Base(remotePath)), localPath)
+	}
+
+	return nil
+}
+
+// upload streams a tarball of the local path into the container and extracts
+// it to the remote destination.
+func (command *CopyCommand) upload(target rc.Target, team concourse.Team, container atc.Container, localPath string, remotePath string) error {
+	absPath, err := filepath.Abs(localPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stat(absPath)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(absPath)
+
+	spec := atc.HijackProcessSpec{
+		Path: "sh",
+		Args: []string{"-c", untarIntoScript, "sh", remotePath, name},
+		User: container.User,
+		Dir:  container.WorkingDirectory,
+	}
+
+	inputs := make(chan atc.HijackInput, 1)
+
+	compressed := make(chan error, 1)
+	go func() {
+		err := tarfs.Compress(&stdinWriter{inputs}, filepath.Dir(absPath), name)
+		inputs <- atc.HijackInput{Closed: true}
+		compressed <- err
+	}()
+
+	exitStatus, err := command.run(target, team, container, spec, hijacker.ProcessIO{
+		In:  inputs,
+		Out: os.Stdout,
+		Err: os.Stderr,
+	})
+	if err != nil {
+		return err
+	}
+
+	if exitStatus != 0 {
+		// a local failure truncates the stream, which is what the remote tar
+		// will have choked on
+		select {
+		case err := <-compressed:
+			if err != nil {
+				return err
+			}
+		default:
+		}
+
+		return fmt.Errorf("copying to container failed with exit status %d", exitStatus)
+	}
+
+	return <-compressed
+}
+
+func (command *CopyCommand) run(target rc.Target, team concourse.Team, container atc.Container, spec atc.HijackProcessSpec, pio hijacker.ProcessIO) (int, error) {
+	reqGenerator := rata.NewRequestGenerator(target.URL(), atc.Routes)
+
+	h := hijacker.New(target.TLSConfig(), reqGenerator, target.Token())
+	exitStatus, exeNotFound, err := h.Hijack(context.Background(), team.Name(), container.ID, spec, pio)
+	if err != nil {
+		return -1, err
+	}
+
+	if exeNotFound {
+		return -1, fmt.Errorf("%s not found in the container; copying requires tar", spec.Path)
+	}
+
+	return exitStatus, nil
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type CreateServiceAccountCommand struct {
+	Name string        `short:"n" long:"name" required:"true" description:"Name of the service account"`
+	Role string        `short:"r" long:"role" default:"member" description:"Role granted to the service account on its team (owner, member, pipeline-operator, viewer)"`
+	TTL  time.Duration `long:"ttl" description:"How long the service account's token is valid for (default and maximum: one year, or the team's access token lifetime if shorter)"`
+	Team string        `long:"team" description:"Name of the team owning the service account, if different from the target default"`
+
+	displayhelpers.OutputFlags
+}
+
+func (command *CreateServiceAccountCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	created, err := team.CreateServiceAccount(command.Name, command.Role, command.TTL)
+	if err != nil {
+		return err
+	}
+
+	if command.Structured() {
+		return command.PrintStructured(created)
+	}
+
+	fmt.Printf("service account '%s' created with role '%s' on team '%s'\n\n", created.ServiceAccount.Name, created.ServiceAccount.Role, team.Name())
+	fmt.Println("token (this will not be shown again):")
+	fmt.Println(created.Token)
+
+	return nil
+}
@@ -1,13 +1,20 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
 	"strings"
 
 	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/peterhellberg/link"
 )
 
 type CurlCommand struct {
@@ -16,6 +23,8 @@ type CurlCommand struct {
 		Rest []string `positional-arg-name:"curl flags" description:"To pass flags to curl, pass a -- argument, so that fly can distinguish them from its own flags"`
 	} `positional-args:"yes"`
 	PrintAndExit bool `long:"print-and-exit" description:"Print curl command and exit"`
+	Paginate     bool `long:"paginate"       description:"Follow the response's pages and print them as a single JSON array, instead of running curl"`
+	Pretty       bool `long:"pretty"         description:"Pretty-print the JSON response, instead of running curl"`
 }
 
 func (command *CurlCommand) Execute([]string) error {
@@ -34,6 +43,14 @@ func (command *CurlCommand) Execute([]string) error {
 		return err
 	}
 
+	if command.Paginate || command.Pretty {
+		if len(command.Args.Rest) > 0 || command.PrintAndExit {
+			return errors.New("--paginate and --pretty cannot be combined with curl flags or --print-and-exit")
+		}
+
+		return command.fetch(target, fullUrl)
+	}
+
 	argsList := command.makeArgsList(target.Token(), fullUrl, command.Args.Rest)
 
 	cmd := exec.Command("curl", argsList...)
@@ -53,6 +70,94 @@ func (command *CurlCommand) Execute([]string) error {
 	return nil
 }
 
+// fetch GETs the URL with the target's own client rather than running curl,
+// following the Link headers of paginated endpoints when asked to.
+func (command *CurlCommand) fetch(target rc.Target, fullUrl string) error {
+	client := target.Client().HTTPClient()
+
+	var pages []json.RawMessage
+	for {
+		body, next, err := command.fetchPage(client, fullUrl)
+		if err != nil {
+			return err
+		}
+
+		if !command.Paginate {
+			return command.print(body)
+		}
+
+		var page []json.RawMessage
+		err = json.Unmarshal(body, &page)
+		if err != nil {
+			return fmt.Errorf("cannot paginate %s: response is not a JSON array", fullUrl)
+		}
+
+		pages = append(pages, page...)
+
+		if next == "" {
+			break
+		}
+
+		fullUrl = next
+	}
+
+	if pages == nil {
+		pages = []json.RawMessage{}
+	}
+
+	body, err := json.Marshal(pages)
+	if err != nil {
+		return err
+	}
+
+	return command.print(body)
+}
+
+// fetchPage returns the body of the response along with the absolute URL of
+// the next page, if there is one.
+func (command *CurlCommand) fetchPage(client *http.Client, pageUrl string) ([]byte, string, error) {
+	response, err := client.Get(pageUrl)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		fmt.Fprintln(ui.Stderr, strings.TrimSpace(string(body)))
+		return nil, "", fmt.Errorf("unexpected response code: %s", response.Status)
+	}
+
+	nextLink := link.ParseResponse(response)["next"]
+	if nextLink == nil {
+		return body, "", nil
+	}
+
+	next, err := response.Request.URL.Parse(nextLink.URI)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return body, next.String(), nil
+}
+
+func (command *CurlCommand) print(body []byte) error {
+	if command.Pretty {
+		var indented bytes.Buffer
+		if json.Indent(&indented, body, "", "  ") == nil {
+			body = append(indented.Bytes(), '\n')
+		}
+	}
+
+	_, err := os.Stdout.Write(body)
+	return err
+}
+
 func (command *CurlCommand) makeFullUrl(host, path string) (string, error) {
 	u, err := url.Parse(host)
 	if err != nil {
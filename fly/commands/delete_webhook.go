@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type DeleteWebhookCommand struct {
+	Name string `short:"n" long:"name" required:"true" description:"Name of the webhook"`
+	Team string `long:"team" description:"Name of the team owning the webhook, if different from the target default"`
+}
+
+func (command *DeleteWebhookCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	found, err := team.DeleteWebhook(command.Name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("webhook '%s' not found", command.Name)
+	}
+
+	fmt.Printf("webhook '%s' deleted\n", command.Name)
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/fly/version"
+	"github.com/concourse/concourse/skymarshal/token"
+	"github.com/fatih/color"
+)
+
+// doctorTokenExpiryWarning is how close to expiring a token has to be for
+// fly doctor to warn about it.
+const doctorTokenExpiryWarning = 24 * time.Hour
+
+type doctorLevel int
+
+const (
+	doctorOK doctorLevel = iota
+	doctorWarn
+	doctorFail
+)
+
+type doctorFinding struct {
+	level   doctorLevel
+	check   string
+	message string
+}
+
+type DoctorCommand struct{}
+
+func (command *DoctorCommand) Execute([]string) error {
+	targets, err := rc.LoadTargets()
+	if err != nil {
+		return err
+	}
+
+	props, found := targets[Fly.Target]
+	if !found {
+		return rc.UnknownTargetError{TargetName: Fly.Target}
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	findings := doctorTargetConfig(props)
+
+	info, err := target.Client().GetInfo()
+	if err != nil {
+		findings = append(findings, doctorFinding{doctorFail, "api", fmt.Sprintf("could not reach %s: %s", props.API, err)})
+		return printDoctorFindings(findings)
+	}
+
+	apiMessage := fmt.Sprintf("reachable, running concourse %s", info.Version)
+	if info.ClusterName != "" {
+		apiMessage += fmt.Sprintf(" (cluster '%s')", info.ClusterName)
+	}
+
+	findings = append(findings, doctorFinding{doctorOK, "api", apiMessage})
+
+	if info.ExternalURL != "" && strings.TrimRight(info.ExternalURL, "/") != strings.TrimRight(props.API, "/") {
+		findings = append(findings, doctorFinding{doctorWarn, "api", fmt.Sprintf("the target url differs from the cluster's external url %s; logins and links may not work as expected", info.ExternalURL)})
+	}
+
+	findings = append(findings, doctorFlyVersion(info))
+
+	tokenFinding := doctorToken(target, props)
+	findings = append(findings, tokenFinding)
+
+	if tokenFinding.level == doctorFail {
+		return printDoctorFindings(findings)
+	}
+
+	findings = append(findings, doctorWorkers(target)...)
+
+	return printDoctorFindings(findings)
+}
+
+func doctorTargetConfig(props rc.TargetProps) []doctorFinding {
+	var findings []doctorFinding
+
+	apiURL, err := url.Parse(props.API)
+	if err == nil && apiURL.Scheme == "http" {
+		host := apiURL.Hostname()
+		if host != "localhost" && host != "127.0.0.1" && host != "::1" {
+			findings = append(findings, doctorFinding{doctorWarn, "target", "the api is reached over plain http, so your token is sent unencrypted; use https"})
+		}
+	}
+
+	if props.Insecure {
+		findings = append(findings, doctorFinding{doctorWarn, "target", "TLS verification is disabled; log in with --ca-cert instead of --insecure"})
+	}
+
+	return findings
+}
+
+func doctorFlyVersion(info atc.Info) doctorFinding {
+	if info.Version == rc.LocalVersion || version.IsDev(rc.LocalVersion) {
+		return doctorFinding{doctorOK, "fly version", fmt.Sprintf("%s matches the api", rc.LocalVersion)}
+	}
+
+	atcMajor, atcMinor, atcPatch, err := version.GetSemver(info.Version)
+	if err != nil {
+		return doctorFinding{doctorWarn, "fly version", fmt.Sprintf("could not parse the api version '%s'", info.Version)}
+	}
+
+	flyMajor, flyMinor, flyPatch, err := version.GetSemver(rc.LocalVersion)
+	if err != nil {
+		return doctorFinding{doctorWarn, "fly version", fmt.Sprintf("could not parse the fly version '%s'", rc.LocalVersion)}
+	}
+
+	mismatch := fmt.Sprintf("fly is %s but the api is %s; run 'fly -t %s sync'", rc.LocalVersion, info.Version, Fly.Target)
+
+	if atcMajor != flyMajor || atcMinor != flyMinor {
+		return doctorFinding{doctorFail, "fly version", mismatch}
+	}
+
+	if atcPatch != flyPatch {
+		return doctorFinding{doctorWarn, "fly version", mismatch}
+	}
+
+	return doctorFinding{doctorOK, "fly version", fmt.Sprintf("%s matches the api", rc.LocalVersion)}
+}
+
+func doctorToken(target rc.Target, props rc.TargetProps) doctorFinding {
+	login := fmt.Sprintf("run 'fly -t %s login'", Fly.Target)
+
+	tToken := target.Token()
+	if tToken == nil || tToken.Value == "" {
+		return doctorFinding{doctorFail, "token", "not logged in; " + login}
+	}
+
+	expiry, expiryErr := token.Factory{}.ParseExpiry(tToken.Value)
+	if expiryErr == nil {
+		if time.Now().After(expiry) {
+			return doctorFinding{doctorFail, "token", fmt.Sprintf("expired at %s; %s", expiry.UTC().Format(time.RFC1123), login)}
+		}
+	}
+
+	userInfo, err := target.Client().UserInfo()
+	if err != nil {
+		return doctorFinding{doctorFail, "token", fmt.Sprintf("rejected by the api (%s); %s", err, login)}
+	}
+
+	if _, member := userInfo.Teams[props.TeamName]; !member && !userInfo.IsAdmin {
+		return doctorFinding{doctorWarn, "token", fmt.Sprintf("valid, but '%s' is not a member of team '%s'", userInfo.UserName, props.TeamName)}
+	}
+
+	if expiryErr == nil && time.Until(expiry) < doctorTokenExpiryWarning {
+		return doctorFinding{doctorWarn, "token", fmt.Sprintf("expires in %s; %s to renew it", roundSecondsOffDuration(time.Until(expiry)), login)}
+	}
+
+	return doctorFinding{doctorOK, "token", fmt.Sprintf("valid for '%s' on team '%s'", userInfo.UserName, props.TeamName)}
+}
+
+func doctorWorkers(target rc.Target) []doctorFinding {
+	workers, err := target.Client().ListWorkers()
+	if err != nil {
+		return []doctorFinding{{doctorFail, "workers", fmt.Sprintf("could not list workers: %s", err)}}
+	}
+
+	if len(workers) == 0 {
+		return []doctorFinding{{doctorFail, "workers", "no workers are registered, so no builds can run"}}
+	}
+
+	var running, stalled, outdated []string
+	runningPlatforms := map[string]bool{}
+	for _, w := range workers {
+		if w.State == "stalled" {
+			stalled = append(stalled, w.Name)
+			continue
+		}
+
+		compatible, err := target.IsWorkerVersionCompatible(w.Version)
+		if err == nil && !compatible {
+			outdated = append(outdated, w.Name)
+		}
+
+		if w.State == "running" {
+			running = append(running, w.Name)
+			runningPlatforms[w.Platform] = true
+		}
+	}
+
+	sort.Strings(stalled)
+	sort.Strings(outdated)
+
+	var findings []doctorFinding
+	if len(running) == 0 {
+		findings = append(findings, doctorFinding{doctorFail, "workers", fmt.Sprintf("none of the %d workers are running, so no builds can run", len(workers))})
+	} else {
+		findings = append(findings, doctorFinding{doctorOK, "workers", fmt.Sprintf("%d of %d running", len(running), len(workers))})
+
+		if !runningPlatforms["linux"] {
+			findings = append(findings, doctorFinding{doctorWarn, "workers", "no linux workers are running; most resource types need one"})
+		}
+	}
+
+	if len(stalled) > 0 {
+		findings = append(findings, doctorFinding{doctorWarn, "workers", fmt.Sprintf("stalled: %s; restart them or remove them with 'fly prune-worker'", strings.Join(stalled, ", "))})
+	}
+
+	if len(outdated) > 0 {
+		findings = append(findings, doctorFinding{doctorWarn, "workers", fmt.Sprintf("outdated: %s; upgrade them to match the web nodes", strings.Join(outdated, ", "))})
+	}
+
+	return findings
+}
+
+func printDoctorFindings(findings []doctorFinding) error {
+	failed := 0
+	for _, finding := range findings {
+		var label string
+		switch finding.level {
+		case doctorOK:
+			label = ui.SucceededColor.Sprint("ok  ")
+		case doctorWarn:
+			label = ui.StartedColor.Sprint("warn")
+		case doctorFail:
+			label = ui.FailedColor.Sprint("FAIL")
+			failed++
+		}
+
+		fmt.Printf("%s  %s: %s\n", label, color.New(color.Bold).Sprint(finding.check), finding.message)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d checks failed", failed)
+	}
+
+	return nil
+}
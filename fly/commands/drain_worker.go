@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+// drainWorkerPollInterval is how often the worker is checked on while it
+// lands.
+const drainWorkerPollInterval = 5 * time.Second
+
+type DrainWorkerCommand struct {
+	Worker   flaghelpers.WorkerFlag `short:"w" long:"worker"   required:"true" description:"Worker to drain"`
+	Deadline time.Duration          `          long:"deadline"                 description:"Give up waiting if the worker hasn't drained after this long, e.g. 30m. The worker keeps landing regardless"`
+}
+
+func (command *DrainWorkerCommand) Execute(args []string) error {
+	workerName := command.Worker.Name()
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	client := target.Client()
+
+	err = client.LandWorker(workerName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("landing '%s'; waiting for its builds to finish\n", workerName)
+
+	var deadline time.Time
+	if command.Deadline > 0 {
+		deadline = time.Now().Add(command.Deadline)
+	}
+
+	lastProgress := ""
+	for {
+		worker, found, err := findWorker(client, workerName)
+		if err != nil {
+			return err
+		}
+
+		if !found || worker.State == "landed" {
+			fmt.Printf("drained '%s'\n", workerName)
+			return nil
+		}
+
+		builds, err := runningBuildsOnWorker(client, workerName)
+		if err != nil {
+			return err
+		}
+
+		progress := fmt.Sprintf("%s: %d running builds, %d containers, %d volumes", worker.State, builds, worker.ActiveContainers, worker.ActiveVolumes)
+		if progress != lastProgress {
+			fmt.Printf("  %s\n", progress)
+			lastProgress = progress
+		}
+
+		wait := drainWorkerPollInterval
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return fmt.Errorf("worker '%s' did not drain within %s; it is still landing", workerName, command.Deadline)
+			}
+
+			if remaining < wait {
+				wait = remaining
+			}
+		}
+
+		time.Sleep(wait)
+	}
+}
+
+func findWorker(client concourse.Client, workerName string) (atc.Worker, bool, error) {
+	workers, err := client.ListWorkers()
+	if err != nil {
+		return atc.Worker{}, false, err
+	}
+
+	for _, worker := range workers {
+		if worker.Name == workerName {
+			return worker, true, nil
+		}
+	}
+
+	return atc.Worker{}, false, nil
+}
+
+func runningBuildsOnWorker(client concourse.Client, workerName string) (int, error) {
+	page := &concourse.Page{Limit: 100}
+
+	running := 0
+	for page != nil {
+		builds, pagination, err := client.FilteredBuilds(*page, atc.BuildFilter{
+			Statuses: []atc.BuildStatus{atc.StatusPending, atc.StatusStarted},
+			Worker:   workerName,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		running += len(builds)
+		page = pagination.Next
+	}
+
+	return running, nil
+}
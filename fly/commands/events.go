@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type EventsCommand struct {
+	Job     flaghelpers.JobFlag `short:"j" long:"job"      value-name:"PIPELINE/JOB" description:"Name of the job the build belongs to, in which case --build is the build's name"`
+	Build   string              `short:"b" long:"build"    required:"true"           description:"Build to stream the events of"`
+	JSON    bool                `          long:"json"                               description:"Print each event as a JSON object on its own line"`
+	SinceID *int                `          long:"since-id" value-name:"ID"           description:"Only stream events with an ID greater than this, e.g. to resume an earlier stream"`
+}
+
+func (command *EventsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	client := target.Client()
+
+	var buildID int
+	if command.Job.JobName != "" {
+		build, err := GetBuild(client, target.Team(), command.Job.JobName, command.Build, command.Job.PipelineRef)
+		if err != nil {
+			return err
+		}
+		buildID = build.ID
+	} else {
+		buildID, err = strconv.Atoi(command.Build)
+		if err != nil {
+			return fmt.Errorf("invalid build ID '%s'; use --job to refer to a build by name", command.Build)
+		}
+	}
+
+	events, err := client.RawBuildEvents(strconv.Itoa(buildID), command.SinceID)
+	if err != nil {
+		return err
+	}
+
+	defer events.Close()
+
+	encoder := json.NewEncoder(os.Stdout)
+
+	for {
+		envelope, err := events.NextEnvelope()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+
+		if command.JSON {
+			err = encoder.Encode(envelope)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		var data []byte
+		if envelope.Data != nil {
+			data = *envelope.Data
+		}
+
+		fmt.Printf("%s %s %s\n", envelope.EventID, envelope.Event, data)
+	}
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -24,18 +25,20 @@ import (
 )
 
 type ExecuteCommand struct {
-	TaskConfig     atc.PathFlag                       `short:"c" long:"config" required:"true"                description:"The task config to execute"`
-	Privileged     bool                               `short:"p" long:"privileged"                            description:"Run the task with full privileges"`
-	IncludeIgnored bool                               `          long:"include-ignored"                       description:"Including .gitignored paths. Disregards .gitignore entries and uploads everything"`
-	Inputs         []flaghelpers.InputPairFlag        `short:"i" long:"input"       value-name:"NAME=PATH"    description:"An input to provide to the task (can be specified multiple times)"`
-	InputMappings  []flaghelpers.InputMappingPairFlag `short:"m" long:"input-mapping"       value-name:"[NAME=STRING]"    description:"Map a resource to a different name as task input"`
-	InputsFrom     flaghelpers.JobFlag                `short:"j" long:"inputs-from" value-name:"PIPELINE/JOB" description:"A job to base the inputs on"`
-	Outputs        []flaghelpers.OutputPairFlag       `short:"o" long:"output"      value-name:"NAME=PATH"    description:"An output to fetch from the task (can be specified multiple times)"`
-	Image          string                             `long:"image" description:"Image resource for the one-off build"`
-	Tags           []string                           `          long:"tag"         value-name:"TAG"          description:"A tag for a specific environment (can be specified multiple times)"`
-	Var            []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       value-name:"[NAME=STRING]"  unquote:"false"  description:"Specify a string value to set for a variable in the pipeline"`
-	YAMLVar        []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  value-name:"[NAME=YAML]"    unquote:"false"  description:"Specify a YAML value to set for a variable in the pipeline"`
-	VarsFrom       []atc.PathFlag                     `short:"l"  long:"load-vars-from"  description:"Variable flag that can be used for filling in template values in configuration from a YAML file"`
+	TaskConfig      atc.PathFlag                       `short:"c" long:"config" required:"true"                description:"The task config to execute"`
+	Privileged      bool                               `short:"p" long:"privileged"                            description:"Run the task with full privileges"`
+	IncludeIgnored  bool                               `          long:"include-ignored"                       description:"Including .gitignored paths. Disregards .gitignore entries and uploads everything"`
+	Inputs          []flaghelpers.InputPairFlag        `short:"i" long:"input"       value-name:"NAME=PATH"    description:"An input to provide to the task, either a local path or git+URI[#REF] to be fetched by the build (can be specified multiple times)"`
+	InputMappings   []flaghelpers.InputMappingPairFlag `short:"m" long:"input-mapping"       value-name:"[NAME=STRING]"    description:"Map a resource to a different name as task input"`
+	InputsFrom      flaghelpers.JobFlag                `short:"j" long:"inputs-from" value-name:"PIPELINE/JOB" description:"A job to base the inputs on"`
+	Outputs         []flaghelpers.OutputPairFlag       `short:"o" long:"output"      value-name:"NAME=PATH"    description:"An output to fetch from the task (can be specified multiple times)"`
+	Image           string                             `long:"image" description:"Image resource for the one-off build"`
+	ImageFromDocker string                             `long:"image-from-docker" value-name:"NAME" description:"Image from the local Docker daemon to use for the one-off build, uploaded with the inputs"`
+	ImageTar        atc.PathFlag                       `long:"image-tar" value-name:"FILE" description:"Image tarball, in 'docker save' or OCI layout format, to use for the one-off build, uploaded with the inputs"`
+	Tags            []string                           `          long:"tag"         value-name:"TAG"          description:"A tag for a specific environment (can be specified multiple times)"`
+	Var             []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       value-name:"[NAME=STRING]"  unquote:"false"  description:"Specify a string value to set for a variable in the pipeline"`
+	YAMLVar         []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  value-name:"[NAME=YAML]"    unquote:"false"  description:"Specify a YAML value to set for a variable in the pipeline"`
+	VarsFrom        []atc.PathFlag                     `short:"l"  long:"load-vars-from"  description:"Variable flag that can be used for filling in template values in configuration from a YAML file"`
 }
 
 func (command *ExecuteCommand) Execute(args []string) error {
@@ -49,6 +52,11 @@ func (command *ExecuteCommand) Execute(args []string) error {
 		return err
 	}
 
+	err = command.validateImageFlags()
+	if err != nil {
+		return err
+	}
+
 	taskConfig, err := command.CreateTaskConfig(args)
 	if err != nil {
 		return err
@@ -76,6 +84,18 @@ func (command *ExecuteCommand) Execute(args []string) error {
 		taskConfig.ImageResource = imageResource
 	}
 
+	var imageArtifactName string
+	if command.ImageFromDocker != "" || command.ImageTar != "" {
+		imageInput, err := command.uploadImage(planFactory, target.Team(), taskConfig.Platform)
+		if err != nil {
+			return err
+		}
+
+		inputs = append(inputs, imageInput)
+		imageArtifactName = imageInput.Name
+		taskConfig.ImageResource = nil
+	}
+
 	outputs, err := executehelpers.DetermineOutputs(
 		planFactory,
 		taskConfig.Outputs,
@@ -94,6 +114,7 @@ func (command *ExecuteCommand) Execute(args []string) error {
 		resourceTypes,
 		outputs,
 		taskConfig,
+		imageArtifactName,
 		command.Tags,
 	)
 
@@ -201,6 +222,38 @@ func (command *ExecuteCommand) CreateTaskConfig(args []string) (atc.TaskConfig,
 	return config.OverrideTaskParams(taskTemplateEvaluated, args)
 }
 
+func (command *ExecuteCommand) validateImageFlags() error {
+	imageFlags := 0
+	for _, set := range []bool{command.Image != "", command.ImageFromDocker != "", command.ImageTar != ""} {
+		if set {
+			imageFlags++
+		}
+	}
+
+	if imageFlags > 1 {
+		return errors.New("only one of --image, --image-from-docker and --image-tar may be specified")
+	}
+
+	return nil
+}
+
+// uploadImage uploads the image given by --image-tar or --image-from-docker
+// as an input to be used as the task's image.
+func (command *ExecuteCommand) uploadImage(fact atc.PlanFactory, team concourse.Team, platform string) (executehelpers.Input, error) {
+	tarPath := string(command.ImageTar)
+	if command.ImageFromDocker != "" {
+		var err error
+		tarPath, err = executehelpers.SaveDockerImage(command.ImageFromDocker)
+		if err != nil {
+			return executehelpers.Input{}, err
+		}
+
+		defer os.Remove(tarPath)
+	}
+
+	return executehelpers.GenerateImageInput(fact, team, tarPath, platform, command.Tags)
+}
+
 func abortOnSignal(
 	client concourse.Client,
 	terminate <-chan os.Signal,
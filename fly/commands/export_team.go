@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+// teamExportFile lists a team's pipelines, in order, along with the state
+// that isn't part of their config. Each pipeline's config is written to its
+// own file, relative to the export directory.
+const teamExportFile = "pipelines.yml"
+
+type teamExport struct {
+	Team      string           `json:"team"`
+	Pipelines []pipelineExport `json:"pipelines"`
+}
+
+type pipelineExport struct {
+	Name         string           `json:"name"`
+	InstanceVars atc.InstanceVars `json:"instance_vars,omitempty"`
+	Config       string           `json:"config"`
+	Paused       bool             `json:"paused,omitempty"`
+	Public       bool             `json:"public,omitempty"`
+	Pins         []pinExport      `json:"pins,omitempty"`
+}
+
+type pinExport struct {
+	Resource string      `json:"resource"`
+	Version  atc.Version `json:"version"`
+	Comment  string      `json:"comment,omitempty"`
+}
+
+type ExportTeamCommand struct {
+	Dir  string `short:"d" long:"dir"  required:"true" description:"Directory to export the team's pipelines to"`
+	Team string `          long:"team"                 description:"Name of the team to export, if different from the target default"`
+}
+
+func (command *ExportTeamCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	pipelines, err := team.ListPipelines()
+	if err != nil {
+		return err
+	}
+
+	export := teamExport{
+		Team:      team.Name(),
+		Pipelines: []pipelineExport{},
+	}
+
+	instances := map[string]int{}
+	for _, pipeline := range pipelines {
+		// archived pipelines can't be set without being unarchived
+		if pipeline.Archived {
+			continue
+		}
+
+		configPath := filepath.Join("pipelines", pipeline.Name+".yml")
+		if pipeline.InstanceVars != nil {
+			instances[pipeline.Name]++
+			configPath = filepath.Join("pipelines", pipeline.Name, strconv.Itoa(instances[pipeline.Name])+".yml")
+		}
+
+		config, _, found, err := team.PipelineConfig(pipeline.Ref())
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return fmt.Errorf("pipeline '%s' disappeared during export", pipeline.Ref())
+		}
+
+		err = writeYAML(filepath.Join(command.Dir, configPath), config)
+		if err != nil {
+			return err
+		}
+
+		resources, err := team.ListResources(pipeline.Ref())
+		if err != nil {
+			return err
+		}
+
+		var pins []pinExport
+		for _, resource := range resources {
+			// pins in the config are exported along with it
+			if resource.PinnedVersion == nil || resource.PinnedInConfig {
+				continue
+			}
+
+			pins = append(pins, pinExport{
+				Resource: resource.Name,
+				Version:  resource.PinnedVersion,
+				Comment:  resource.PinComment,
+			})
+		}
+
+		export.Pipelines = append(export.Pipelines, pipelineExport{
+			Name:         pipeline.Name,
+			InstanceVars: pipeline.InstanceVars,
+			Config:       filepath.ToSlash(configPath),
+			Paused:       pipeline.Paused,
+			Public:       pipeline.Public,
+			Pins:         pins,
+		})
+
+		fmt.Printf("exported '%s'\n", pipeline.Ref())
+	}
+
+	err = writeYAML(filepath.Join(command.Dir, teamExportFile), export)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nexported %d pipelines of team '%s' to %s\n", len(export.Pipelines), team.Name(), command.Dir)
+
+	return nil
+}
+
+func writeYAML(path string, value interface{}) error {
+	payload, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, payload, 0644)
+}
@@ -21,6 +21,7 @@ type FlyCommand struct {
 	Login  LoginCommand  `command:"login" alias:"l" description:"Authenticate with the target"`
 	Logout LogoutCommand `command:"logout" alias:"o" description:"Release authentication with the target"`
 	Status StatusCommand `command:"status" description:"Login status"`
+	Doctor DoctorCommand `command:"doctor" description:"Check the target and the cluster for common problems"`
 	Sync   SyncCommand   `command:"sync"  alias:"s" description:"Download and replace the current fly from the target"`
 
 	ActiveUsers ActiveUsersCommand `command:"active-users" alias:"au" description:"List the active users since a date or for the past 2 months"`
@@ -31,14 +32,32 @@ type FlyCommand struct {
 	SetTeam     SetTeamCommand     `command:"set-team"  alias:"st" description:"Create or modify a team to have the given credentials"`
 	RenameTeam  RenameTeamCommand  `command:"rename-team"   alias:"rt" description:"Rename a team"`
 	DestroyTeam DestroyTeamCommand `command:"destroy-team"  alias:"dt" description:"Destroy a team and delete all of its data"`
+	ExportTeam  ExportTeamCommand  `command:"export-team"   alias:"et" description:"Export a team's pipelines, their ordering and pins to a directory"`
+	ImportTeam  ImportTeamCommand  `command:"import-team"   alias:"it" description:"Import a team's pipelines from a directory written by export-team"`
+
+	ServiceAccounts      ServiceAccountsCommand      `command:"service-accounts"       alias:"sas" description:"List the service accounts of a team"`
+	CreateServiceAccount CreateServiceAccountCommand `command:"create-service-account" alias:"csa" description:"Create a service account and print its token"`
+	DeleteServiceAccount DeleteServiceAccountCommand `command:"delete-service-account" alias:"dsa" description:"Delete a service account and revoke its token"`
+
+	Webhooks      WebhooksCommand      `command:"webhooks"       alias:"whs" description:"List the webhooks notified of a team's build and pipeline events"`
+	SetWebhook    SetWebhookCommand    `command:"set-webhook"    alias:"swh" description:"Create or update a webhook notified of a team's build and pipeline events"`
+	DeleteWebhook DeleteWebhookCommand `command:"delete-webhook" alias:"dwh" description:"Delete a team webhook"`
+
+	PipelineTemplates      PipelineTemplatesCommand      `command:"pipeline-templates"       alias:"pts" description:"List the pipeline templates registered with a team"`
+	SetPipelineTemplate    SetPipelineTemplateCommand    `command:"set-pipeline-template"    alias:"spt" description:"Create or update a pipeline template that the team's pipelines can include"`
+	DeletePipelineTemplate DeletePipelineTemplateCommand `command:"delete-pipeline-template" alias:"dpt" description:"Delete a pipeline template"`
 
 	Checklist ChecklistCommand `command:"checklist" alias:"cl" description:"Print a Checkfile of the given pipeline"`
 
+	Search SearchCommand `command:"search" alias:"se" description:"Search pipelines, jobs, resources and workers across teams"`
+
 	Execute ExecuteCommand `command:"execute" alias:"e" description:"Execute a one-off build using local bits"`
 	Watch   WatchCommand   `command:"watch"   alias:"w" description:"Stream a build's output"`
+	Events  EventsCommand  `command:"events"            description:"Stream a build's raw events"`
 
 	Containers ContainersCommand `command:"containers" alias:"cs" description:"Print the active containers"`
 	Hijack     HijackCommand     `command:"hijack"     alias:"intercept" alias:"i" description:"Execute a command in a container"`
+	Copy       CopyCommand       `command:"cp"                                     description:"Copy files to or from a step's container"`
 
 	Jobs        JobsCommand        `command:"jobs"      alias:"js" description:"List the jobs in the pipelines"`
 	PauseJob    PauseJobCommand    `command:"pause-job" alias:"pj" description:"Pause a job"`
@@ -70,20 +89,32 @@ type FlyCommand struct {
 
 	CheckResourceType CheckResourceTypeCommand `command:"check-resource-type" alias:"crt"  description:"Check a resource-type"`
 
+	VarSources VarSourcesCommand `command:"var-sources" alias:"vss" description:"List the var sources of a pipeline and whether they can be reached"`
+
 	ClearTaskCache ClearTaskCacheCommand `command:"clear-task-cache" alias:"ctc" description:"Clears cache from a task container"`
 
 	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
 	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
 	RerunBuild RerunBuildCommand `command:"rerun-build" alias:"rb" description:"Rerun a build"`
 
+	ApproveBuild ApproveBuildCommand `command:"approve-build" alias:"apb" description:"Approve a build's pending approval step"`
+	RejectBuild  RejectBuildCommand  `command:"reject-build"  alias:"rjb" description:"Reject a build's pending approval step"`
+
+	PublishedArtifacts PublishedArtifactsCommand `command:"published-artifacts" alias:"pas" description:"List the artifacts published by a build"`
+
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
 	Volumes VolumesCommand `command:"volumes" alias:"vs" description:"List the active volumes"`
 
+	PruneTeamState PruneTeamStateCommand `command:"prune-team-state" description:"Remove a team's orphaned containers, stuck check containers and dangling volumes"`
+
 	Workers     WorkersCommand     `command:"workers" alias:"ws" description:"List the registered workers"`
 	LandWorker  LandWorkerCommand  `command:"land-worker" alias:"lw" description:"Land a worker"`
+	DrainWorker DrainWorkerCommand `command:"drain-worker" alias:"dw" description:"Land a worker and wait for its builds to finish"`
 	PruneWorker PruneWorkerCommand `command:"prune-worker" alias:"pw" description:"Prune a stalled, landing, landed, or retiring worker"`
 
+	Top TopCommand `command:"top" description:"Show a live view of worker utilization and the busiest builds"`
+
 	Curl CurlCommand `command:"curl" alias:"c" description:"curl the api"`
 
 	Completion CompletionCommand `command:"completion" description:"generate shell completion code"`
@@ -15,6 +15,8 @@ import (
 type FormatPipelineCommand struct {
 	Config atc.PathFlag `short:"c" long:"config" required:"true" description:"Pipeline configuration file"`
 	Write  bool         `short:"w" long:"write" description:"Do not print to stdout; overwrite the file in place"`
+
+	StripDefaults bool `long:"strip-defaults" description:"Remove fields which are set to the value Concourse would use anyway"`
 }
 
 func (command *FormatPipelineCommand) Execute(args []string) error {
@@ -33,6 +35,10 @@ func (command *FormatPipelineCommand) Execute(args []string) error {
 		displayhelpers.FailWithErrorf("could not unmarshal config", err)
 	}
 
+	if command.StripDefaults {
+		stripConfigDefaults(&config)
+	}
+
 	formattedBytes, err := yaml.Marshal(config)
 	if err != nil {
 		displayhelpers.FailWithErrorf("could not marshal config", err)
@@ -59,3 +65,48 @@ func (command *FormatPipelineCommand) Execute(args []string) error {
 
 	return nil
 }
+
+// stripConfigDefaults clears fields which are explicitly set to their default,
+// so that configs which only differ in spelling them out format the same.
+func stripConfigDefaults(config *atc.Config) {
+	for i := range config.Jobs {
+		job := &config.Jobs[i]
+
+		// max_in_flight is ignored for serial jobs
+		if job.Serial || len(job.SerialGroups) > 0 {
+			job.RawMaxInFlight = 0
+		}
+
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			OnGet: func(step *atc.GetStep) error {
+				if step.Resource == step.Name {
+					step.Resource = ""
+				}
+
+				if step.Version != nil && step.Version.Latest {
+					step.Version = nil
+				}
+
+				return nil
+			},
+			OnCheck: func(step *atc.CheckStep) error {
+				if step.Resource == step.Name {
+					step.Resource = ""
+				}
+
+				return nil
+			},
+			OnPut: func(step *atc.PutStep) error {
+				if step.Resource == step.Name {
+					step.Resource = ""
+				}
+
+				if step.Inputs != nil && step.Inputs.All {
+					step.Inputs = nil
+				}
+
+				return nil
+			},
+		})
+	}
+}
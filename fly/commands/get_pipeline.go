@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 
 	"sigs.k8s.io/yaml"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/concourse/concourse/vars"
 	"github.com/mattn/go-isatty"
 )
 
@@ -21,11 +23,21 @@ type GetPipelineCommand struct {
 	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Get configuration of this pipeline"`
 	JSON     bool                     `short:"j" long:"json"                     description:"Print config as json instead of yaml"`
 	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+
+	AnnotateVars bool `long:"annotate-vars" description:"List the ((vars)) in the config, which var_source resolves each of them, and whether it currently does"`
 }
 
 func (command *GetPipelineCommand) Validate() error {
 	_, err := command.Pipeline.Validate()
-	return err
+	if err != nil {
+		return err
+	}
+
+	if command.AnnotateVars && command.JSON {
+		return errors.New("--annotate-vars cannot be used with --json")
+	}
+
+	return nil
 }
 
 func (command *GetPipelineCommand) Execute(args []string) error {
@@ -64,6 +76,10 @@ func (command *GetPipelineCommand) Execute(args []string) error {
 		return errors.New("pipeline not found")
 	}
 
+	if command.AnnotateVars {
+		return dumpAnnotated(config, team, command.Pipeline.Ref())
+	}
+
 	return dump(config, command.JSON)
 }
 
@@ -84,6 +100,120 @@ func dump(config atc.Config, asJSON bool) error {
 	return err
 }
 
+// dumpAnnotated prints the config as yaml, followed by a comment for each of
+// its ((vars)) saying where it is resolved from. Vars referring to one of the
+// pipeline's var_sources are looked up in it by the ATC, just as they are
+// when the pipeline runs.
+func dumpAnnotated(config atc.Config, team concourse.Team, pipelineRef atc.PipelineRef) error {
+	payload, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Printf("%s", payload)
+	if err != nil {
+		return err
+	}
+
+	varNames := map[string]bool{}
+	for _, name := range vars.NewTemplate(payload).ExtraVarNames() {
+		varNames[name] = true
+	}
+
+	if len(varNames) == 0 {
+		return nil
+	}
+
+	sortedNames := make([]string, 0, len(varNames))
+	for name := range varNames {
+		sortedNames = append(sortedNames, name)
+	}
+
+	sort.Strings(sortedNames)
+
+	varSourceTypes := map[string]string{}
+	for _, varSource := range config.VarSources {
+		varSourceTypes[varSource.Name] = varSource.Type
+	}
+
+	var lookupNames []string
+	for _, name := range sortedNames {
+		ref, err := vars.ParseReference(name)
+		if err != nil {
+			continue
+		}
+
+		if _, declared := varSourceTypes[ref.Source]; declared {
+			lookupNames = append(lookupNames, ref.String())
+		}
+	}
+
+	lookedUp := true
+	lookups := map[string]atc.VarLookupStatus{}
+	if len(lookupNames) > 0 {
+		statuses, found, err := team.PipelineVarSources(pipelineRef, lookupNames)
+		if err == nil && !found {
+			err = errors.New("pipeline not found")
+		}
+
+		if err != nil {
+			lookedUp = false
+			fmt.Fprintf(ui.Stderr, "failed to look up vars: %s\n", err)
+		}
+
+		for _, status := range statuses {
+			for _, lookup := range status.Vars {
+				lookups[lookup.Name] = lookup
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("# vars:")
+
+	for _, name := range sortedNames {
+		fmt.Printf("#   ((%s)): %s\n", name, annotateVar(name, varSourceTypes, lookups, lookedUp))
+	}
+
+	return nil
+}
+
+func annotateVar(name string, varSourceTypes map[string]string, lookups map[string]atc.VarLookupStatus, lookedUp bool) string {
+	ref, err := vars.ParseReference(name)
+	if err != nil {
+		return fmt.Sprintf("invalid reference: %s", err)
+	}
+
+	switch ref.Source {
+	case "":
+		return "cluster credential manager (not checked)"
+	case ".":
+		return "local var, set by the build"
+	}
+
+	varSourceType, declared := varSourceTypes[ref.Source]
+	if !declared {
+		return fmt.Sprintf("undeclared var_source '%s'", ref.Source)
+	}
+
+	annotation := fmt.Sprintf("var_source '%s' (%s)", ref.Source, varSourceType)
+
+	lookup, checked := lookups[ref.String()]
+	if !lookedUp || !checked {
+		return fmt.Sprintf("%s, not checked", annotation)
+	}
+
+	if lookup.Error != "" {
+		return fmt.Sprintf("%s, could not check: %s", annotation, lookup.Error)
+	}
+
+	if !lookup.Found {
+		return fmt.Sprintf("%s, not found", annotation)
+	}
+
+	return fmt.Sprintf("%s, found", annotation)
+}
+
 func (command *GetPipelineCommand) showConfigWarning() {
 	if isatty.IsTerminal(os.Stdout.Fd()) {
 		fmt.Fprintln(ui.Stderr, "")
@@ -16,6 +16,7 @@ import (
 type GetTeamCommand struct {
 	Team flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"Get configuration of this team"`
 	JSON bool                 `short:"j" long:"json" description:"Print command result as JSON"`
+	YAML bool                 `long:"yaml" description:"Print command result as YAML"`
 }
 
 func (command *GetTeamCommand) Execute(args []string) error {
@@ -34,8 +35,9 @@ func (command *GetTeamCommand) Execute(args []string) error {
 		return err
 	}
 
-	if command.JSON {
-		err := displayhelpers.JsonPrint(team.ATCTeam())
+	output := displayhelpers.OutputFlags{Json: command.JSON, Yaml: command.YAML}
+	if output.Structured() {
+		err := output.PrintStructured(team.ATCTeam())
 		if err != nil {
 			return err
 		}
@@ -32,6 +32,7 @@ type HijackCommand struct {
 	StepName       string                   `short:"s" long:"step"                              description:"Name of step to hijack (e.g. build, unit, resource name)"`
 	StepType       string                   `          long:"step-type"                         description:"Type of step to hijack (e.g. get, put, task)"`
 	Attempt        string                   `short:"a" long:"attempt" value-name:"N[,N,...]"    description:"Attempt number of step to hijack."`
+	Session        string                   `          long:"session" value-name:"NAME"         description:"Name of a session to start, or to reattach to if it's still running. The session survives disconnects for a while"`
 	PositionalArgs struct {
 		Command []string `positional-arg-name:"command" description:"The command to run in the container (default: bash)"`
 	} `positional-args:"yes"`
@@ -187,6 +188,8 @@ func (command *HijackCommand) Execute([]string) error {
 
 		Privileged: privileged,
 		TTY:        ttySpec,
+
+		Session: command.Session,
 	}
 
 	result, err := func() (int, error) { // so the term.Restore() can run before the os.Exit()
@@ -231,6 +234,10 @@ func (command *HijackCommand) Execute([]string) error {
 		return result, err
 	}()
 
+	if err == hijacker.ErrDetached {
+		return fmt.Errorf("connection lost; session '%s' is still running and can be reattached to with:\n\n  fly -t %s intercept --team %s --handle %s --session %s", command.Session, Fly.Target, team.Name(), chosenContainer.ID, command.Session)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -473,8 +480,9 @@ type stdinWriter struct {
 }
 
 func (w *stdinWriter) Write(d []byte) (int, error) {
+	// copy, as the caller is free to reuse d once Write returns
 	w.inputs <- atc.HijackInput{
-		Stdin: d,
+		Stdin: append([]byte(nil), d...),
 	}
 
 	return len(d), nil
@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/vito/go-interact/interact"
+)
+
+type ImportTeamCommand struct {
+	Dir             string `short:"d" long:"dir"             required:"true" description:"Directory containing a team export"`
+	Team            string `          long:"team"                            description:"Name of the team to import into, if different from the target default"`
+	SkipInteractive bool   `short:"n" long:"non-interactive"                 description:"Import the pipelines without confirmation"`
+}
+
+func (command *ImportTeamCommand) Execute([]string) error {
+	payload, err := ioutil.ReadFile(filepath.Join(command.Dir, teamExportFile))
+	if err != nil {
+		return err
+	}
+
+	var export teamExport
+	err = yaml.Unmarshal(payload, &export)
+	if err != nil {
+		return fmt.Errorf("malformed %s: %w", teamExportFile, err)
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	fmt.Printf("importing %d pipelines exported from team '%s' into team '%s':\n", len(export.Pipelines), export.Team, team.Name())
+	for _, pipeline := range export.Pipelines {
+		fmt.Printf("  %s\n", pipelineExportRef(pipeline))
+	}
+
+	confirm := command.SkipInteractive
+	if !confirm {
+		err = interact.NewInteraction("\nimport pipelines?").Resolve(&confirm)
+		if err != nil || !confirm {
+			fmt.Println("bailing out")
+			return err
+		}
+	}
+
+	fmt.Println()
+
+	var warnings []string
+	for _, pipeline := range export.Pipelines {
+		pinWarnings, err := command.importPipeline(team, pipeline)
+		if err != nil {
+			return fmt.Errorf("failed to import '%s': %w", pipelineExportRef(pipeline), err)
+		}
+
+		warnings = append(warnings, pinWarnings...)
+
+		fmt.Printf("imported '%s'\n", pipelineExportRef(pipeline))
+	}
+
+	err = orderImportedPipelines(team, export.Pipelines)
+	if err != nil {
+		return err
+	}
+
+	if len(warnings) > 0 {
+		fmt.Fprintln(ui.Stderr, "")
+		displayhelpers.PrintWarningHeader()
+		for _, warning := range warnings {
+			fmt.Fprintf(ui.Stderr, "  - %s\n", warning)
+		}
+	}
+
+	return nil
+}
+
+// importPipeline sets the pipeline's config and state. Pins which can't be
+// restored, usually because the resource hasn't found the version yet, are
+// returned as warnings rather than failing the import.
+func (command *ImportTeamCommand) importPipeline(team concourse.Team, pipeline pipelineExport) ([]string, error) {
+	ref := pipelineExportRef(pipeline)
+
+	config, err := ioutil.ReadFile(filepath.Join(command.Dir, filepath.FromSlash(pipeline.Config)))
+	if err != nil {
+		return nil, err
+	}
+
+	_, existingConfigVersion, _, err := team.PipelineConfig(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, configWarnings, err := team.CreateOrUpdatePipelineConfig(ref, existingConfigVersion, config, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, warning := range configWarnings {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", ref, warning.Message))
+	}
+
+	if pipeline.Paused {
+		_, err = team.PausePipeline(ref, "")
+	} else {
+		_, err = team.UnpausePipeline(ref)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if pipeline.Public {
+		_, err = team.ExposePipeline(ref)
+	} else {
+		_, err = team.HidePipeline(ref)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pin := range pipeline.Pins {
+		resource := flaghelpers.ResourceFlag{
+			PipelineRef:  ref,
+			ResourceName: pin.Resource,
+		}
+
+		version, err := GetLatestResourceVersion(team, resource, pin.Version)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("could not pin '%s/%s': %s; pin it with fly pin-resource once the resource has been checked", ref, pin.Resource, err))
+			continue
+		}
+
+		_, err = team.PinResourceVersion(ref, pin.Resource, version.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if pin.Comment != "" {
+			_, err = team.SetPinComment(ref, pin.Resource, pin.Comment)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+func orderImportedPipelines(team concourse.Team, pipelines []pipelineExport) error {
+	var names []string
+	instanceVars := map[string][]atc.InstanceVars{}
+	for _, pipeline := range pipelines {
+		if _, seen := instanceVars[pipeline.Name]; !seen {
+			names = append(names, pipeline.Name)
+			instanceVars[pipeline.Name] = nil
+		}
+
+		if pipeline.InstanceVars != nil {
+			instanceVars[pipeline.Name] = append(instanceVars[pipeline.Name], pipeline.InstanceVars)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	err := team.OrderingPipelines(names)
+	if err != nil {
+		return errors.New("failed to order pipelines: " + err.Error())
+	}
+
+	for _, name := range names {
+		if len(instanceVars[name]) == 0 {
+			continue
+		}
+
+		err = team.OrderingPipelinesWithinGroup(name, instanceVars[name])
+		if err != nil {
+			return fmt.Errorf("failed to order instanced pipelines of '%s': %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func pipelineExportRef(pipeline pipelineExport) atc.PipelineRef {
+	return atc.PipelineRef{
+		Name:         pipeline.Name,
+		InstanceVars: pipeline.InstanceVars,
+	}
+}
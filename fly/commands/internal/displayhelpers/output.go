@@ -0,0 +1,46 @@
+package displayhelpers
+
+import (
+	"fmt"
+	"reflect"
+
+	"sigs.k8s.io/yaml"
+)
+
+// OutputFlags gives a command --json and --yaml flags. Both print the same
+// schema: that of the API's JSON responses, which doesn't change between
+// releases the way table columns do.
+type OutputFlags struct {
+	Json bool `long:"json" description:"Print command result as JSON"`
+	Yaml bool `long:"yaml" description:"Print command result as YAML"`
+}
+
+// Structured returns whether the command should print machine-readable
+// output rather than a table.
+func (flags OutputFlags) Structured() bool {
+	return flags.Json || flags.Yaml
+}
+
+// PrintStructured prints the result in the requested format. Empty lists
+// are printed as such rather than as null.
+func (flags OutputFlags) PrintStructured(result interface{}) error {
+	value := reflect.ValueOf(result)
+	if value.Kind() == reflect.Slice && value.IsNil() {
+		result = reflect.MakeSlice(value.Type(), 0, 0).Interface()
+	}
+
+	if flags.Yaml {
+		return YamlPrint(result)
+	}
+
+	return JsonPrint(result)
+}
+
+func YamlPrint(yamlObj interface{}) error {
+	yamlBytes, err := yaml.Marshal(yamlObj)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(yamlBytes))
+	return nil
+}
@@ -14,6 +14,7 @@ func CreateBuildPlan(
 	versionedResourceTypes atc.VersionedResourceTypes,
 	outputs []Output,
 	config atc.TaskConfig,
+	imageArtifactName string,
 	tags []string,
 ) (atc.Plan, error) {
 	if err := config.Validate(); err != nil {
@@ -31,6 +32,7 @@ func CreateBuildPlan(
 		Config:                 &config,
 		InputMapping:           inputMappings,
 		VersionedResourceTypes: versionedResourceTypes,
+		ImageArtifactName:      imageArtifactName,
 	})
 
 	if len(tags) != 0 {
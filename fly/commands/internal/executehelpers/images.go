@@ -0,0 +1,532 @@
+package executehelpers
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui/progress"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/vbauerster/mpb/v4"
+)
+
+// ImageInputName is the name of the input holding an image provided with
+// --image-tar or --image-from-docker.
+const ImageInputName = "one-off-image"
+
+const (
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+// SaveDockerImage writes the image from the local Docker daemon to a
+// temporary tarball, returning its path. The caller is responsible for
+// removing it.
+func SaveDockerImage(name string) (string, error) {
+	tmp, err := ioutil.TempFile("", "fly-image")
+	if err != nil {
+		return "", err
+	}
+
+	tmp.Close()
+
+	stderr := new(bytes.Buffer)
+
+	save := exec.Command("docker", "save", "-o", tmp.Name(), name)
+	save.Stderr = stderr
+
+	err = save.Run()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to save image '%s' from docker: %s: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return tmp.Name(), nil
+}
+
+// GenerateImageInput uploads the image tarball, in either 'docker save' or
+// OCI layout format, as an artifact laid out the way the task step expects
+// an image: a metadata.json alongside the flattened rootfs.
+func GenerateImageInput(
+	fact atc.PlanFactory,
+	team concourse.Team,
+	tarPath string,
+	platform string,
+	tags []string,
+) (Input, error) {
+	var artifact atc.WorkerArtifact
+
+	prog := progress.New()
+
+	prog.Go("uploading image", func(bar *mpb.Bar) error {
+		var err error
+		artifact, err = UploadImage(bar, team, tarPath, platform, tags)
+		return err
+	})
+
+	err := prog.Wait()
+	if err != nil {
+		return Input{}, err
+	}
+
+	return Input{
+		Name: ImageInputName,
+		Plan: fact.NewPlan(atc.ArtifactInputPlan{
+			ArtifactID: artifact.ID,
+			Name:       ImageInputName,
+		}),
+	}, nil
+}
+
+func UploadImage(bar *mpb.Bar, team concourse.Team, tarPath string, platform string, tags []string) (atc.WorkerArtifact, error) {
+	imageDir, err := ioutil.TempDir("", "fly-image")
+	if err != nil {
+		return atc.WorkerArtifact{}, err
+	}
+
+	defer os.RemoveAll(imageDir)
+
+	err = extractFiles(tarPath, imageDir)
+	if err != nil {
+		return atc.WorkerArtifact{}, err
+	}
+
+	image, err := readImage(imageDir, platform)
+	if err != nil {
+		return atc.WorkerArtifact{}, err
+	}
+
+	archiveStream, archiveWriter := io.Pipe()
+
+	go func() {
+		archiveWriter.CloseWithError(image.writeArtifact(archiveWriter))
+	}()
+
+	return team.CreateArtifact(bar.ProxyReader(archiveStream), platform, tags)
+}
+
+type localImage struct {
+	layers   []string
+	metadata imageMetadata
+}
+
+// imageMetadata is written to the artifact's metadata.json, to be applied to
+// containers using the image.
+type imageMetadata struct {
+	Env  []string `json:"env"`
+	User string   `json:"user"`
+}
+
+type imageConfig struct {
+	Config struct {
+		Env  []string `json:"Env"`
+		User string   `json:"User"`
+	} `json:"config"`
+}
+
+type dockerManifest struct {
+	Config string   `json:"Config"`
+	Layers []string `json:"Layers"`
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Platform  *struct {
+		OS string `json:"os"`
+	} `json:"platform,omitempty"`
+}
+
+type ociIndex struct {
+	Manifests []ociDescriptor `json:"manifests"`
+}
+
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []ociDescriptor `json:"manifests"`
+	Config    ociDescriptor   `json:"config"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// extractFiles extracts the regular files of the tarball, which is all that
+// either image format is made of.
+func extractFiles(tarPath string, dest string) error {
+	file, err := os.Open(tarPath)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	tr := tar.NewReader(file)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read image tarball: %s", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean("/" + hdr.Name)
+		filePath := filepath.Join(dest, filepath.FromSlash(name))
+
+		err = os.MkdirAll(filepath.Dir(filePath), 0755)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(filePath)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(f, tr)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func readImage(dir string, platform string) (localImage, error) {
+	var image localImage
+	var configPath string
+
+	var manifests []dockerManifest
+	err := readJSON(filepath.Join(dir, "manifest.json"), &manifests)
+	if err == nil {
+		if len(manifests) != 1 {
+			return localImage{}, fmt.Errorf("expected the image tarball to contain one image, found %d", len(manifests))
+		}
+
+		configPath = filepath.Join(dir, filepath.FromSlash(manifests[0].Config))
+		for _, layer := range manifests[0].Layers {
+			image.layers = append(image.layers, filepath.Join(dir, filepath.FromSlash(layer)))
+		}
+	} else if os.IsNotExist(err) {
+		manifest, err := readOCIManifest(dir, platform)
+		if err != nil {
+			return localImage{}, err
+		}
+
+		configPath = blobPath(dir, manifest.Config.Digest)
+		for _, layer := range manifest.Layers {
+			image.layers = append(image.layers, blobPath(dir, layer.Digest))
+		}
+	} else {
+		return localImage{}, err
+	}
+
+	var config imageConfig
+	err = readJSON(configPath, &config)
+	if err != nil {
+		return localImage{}, fmt.Errorf("failed to read image config: %s", err)
+	}
+
+	image.metadata = imageMetadata{
+		Env:  config.Config.Env,
+		User: config.Config.User,
+	}
+
+	return image, nil
+}
+
+// readOCIManifest finds the image manifest in an OCI layout, choosing the
+// manifest for the task's platform if the image is multi-platform.
+func readOCIManifest(dir string, platform string) (ociManifest, error) {
+	var index ociIndex
+	err := readJSON(filepath.Join(dir, "index.json"), &index)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ociManifest{}, errors.New("image tarball contains neither manifest.json nor index.json")
+		}
+
+		return ociManifest{}, err
+	}
+
+	descriptors := index.Manifests
+	for {
+		descriptor, found := chooseDescriptor(descriptors, platform)
+		if !found {
+			return ociManifest{}, errors.New("no image found in OCI layout")
+		}
+
+		var manifest ociManifest
+		err = readJSON(blobPath(dir, descriptor.Digest), &manifest)
+		if err != nil {
+			return ociManifest{}, err
+		}
+
+		if manifest.Manifests == nil {
+			return manifest, nil
+		}
+
+		descriptors = manifest.Manifests
+	}
+}
+
+func chooseDescriptor(descriptors []ociDescriptor, platform string) (ociDescriptor, bool) {
+	for _, descriptor := range descriptors {
+		if descriptor.Platform == nil || descriptor.Platform.OS == platform {
+			return descriptor, true
+		}
+	}
+
+	return ociDescriptor{}, false
+}
+
+func blobPath(dir string, digest string) string {
+	return filepath.Join(dir, "blobs", strings.Replace(digest, ":", string(filepath.Separator), 1))
+}
+
+func readJSON(filePath string, dest interface{}) error {
+	payload, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, dest)
+}
+
+// writeArtifact writes a gzipped tarball containing metadata.json and the
+// layers flattened into rootfs/.
+func (image localImage) writeArtifact(w io.Writer) error {
+	surviving, dirHeaders, err := image.survivingEntries()
+	if err != nil {
+		return err
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	metadata, err := json.Marshal(image.metadata)
+	if err != nil {
+		return err
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:     "metadata.json",
+		Typeflag: tar.TypeReg,
+		Mode:     0644,
+		Size:     int64(len(metadata)),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tw.Write(metadata)
+	if err != nil {
+		return err
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:     "rootfs/",
+		Typeflag: tar.TypeDir,
+		Mode:     0755,
+	})
+	if err != nil {
+		return err
+	}
+
+	written := map[string]bool{}
+	for i, layer := range image.layers {
+		err = eachLayerEntry(layer, func(index int, name string, hdr *tar.Header, r io.Reader) error {
+			if !surviving[i][index] {
+				return nil
+			}
+
+			if hdr.Typeflag == tar.TypeDir {
+				// directories are written where they first appear so that their
+				// contents in every layer follow them, but with the metadata
+				// of the topmost layer
+				if written[name] {
+					return nil
+				}
+
+				written[name] = true
+
+				dirHeader := *dirHeaders[name]
+				dirHeader.Name = "rootfs/" + name + "/"
+				dirHeader.Format = tar.FormatUnknown
+
+				return tw.WriteHeader(&dirHeader)
+			}
+
+			hdr.Name = "rootfs/" + name
+			hdr.Format = tar.FormatUnknown
+
+			if hdr.Typeflag == tar.TypeLink {
+				hdr.Linkname = "rootfs/" + cleanEntryName(hdr.Linkname)
+			}
+
+			err := tw.WriteHeader(hdr)
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(tw, r)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+// survivingEntries walks the layers from the top down to find, for each
+// layer, the entries which are neither replaced nor whited out by the layers
+// above it.
+func (image localImage) survivingEntries() ([]map[int]bool, map[string]*tar.Header, error) {
+	surviving := make([]map[int]bool, len(image.layers))
+	dirHeaders := map[string]*tar.Header{}
+
+	present := map[string]bool{}
+	nonDirs := map[string]bool{}
+	removed := map[string]bool{}
+	opaque := map[string]bool{}
+
+	for i := len(image.layers) - 1; i >= 0; i-- {
+		surviving[i] = map[int]bool{}
+
+		layerRemoved := []string{}
+		layerOpaque := []string{}
+		layerPresent := []string{}
+
+		err := eachLayerEntry(image.layers[i], func(index int, name string, hdr *tar.Header, _ io.Reader) error {
+			base := path.Base(name)
+			if base == whiteoutOpaque {
+				layerOpaque = append(layerOpaque, path.Dir(name))
+				return nil
+			}
+
+			if strings.HasPrefix(base, whiteoutPrefix) {
+				layerRemoved = append(layerRemoved, path.Join(path.Dir(name), strings.TrimPrefix(base, whiteoutPrefix)))
+				return nil
+			}
+
+			if removed[name] {
+				return nil
+			}
+
+			for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+				if removed[dir] || opaque[dir] || nonDirs[dir] {
+					return nil
+				}
+			}
+
+			isDir := hdr.Typeflag == tar.TypeDir
+			if present[name] && (!isDir || nonDirs[name]) {
+				return nil
+			}
+
+			surviving[i][index] = true
+
+			if isDir {
+				if _, found := dirHeaders[name]; !found {
+					dirHeader := *hdr
+					dirHeaders[name] = &dirHeader
+				}
+			} else {
+				nonDirs[name] = true
+			}
+
+			layerPresent = append(layerPresent, name)
+
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, name := range layerRemoved {
+			removed[name] = true
+		}
+
+		for _, name := range layerOpaque {
+			opaque[name] = true
+		}
+
+		for _, name := range layerPresent {
+			present[name] = true
+		}
+	}
+
+	return surviving, dirHeaders, nil
+}
+
+// eachLayerEntry calls the callback with each entry of the layer, which may
+// be gzipped, along with its index and its cleaned name. The root of the
+// layer is skipped.
+func eachLayerEntry(layerPath string, cb func(int, string, *tar.Header, io.Reader) error) error {
+	file, err := os.Open(layerPath)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	var layer io.Reader = bufio.NewReader(file)
+
+	magic, err := layer.(*bufio.Reader).Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(layer)
+		if err != nil {
+			return err
+		}
+
+		defer gr.Close()
+
+		layer = gr
+	}
+
+	tr := tar.NewReader(layer)
+	for index := 0; ; index++ {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read image layer: %s", err)
+		}
+
+		name := cleanEntryName(hdr.Name)
+		if name == "" {
+			continue
+		}
+
+		err = cb(index, name, hdr, tr)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func cleanEntryName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
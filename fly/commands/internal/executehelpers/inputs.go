@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sync"
 
 	"github.com/concourse/concourse/atc"
@@ -14,6 +15,8 @@ import (
 	"github.com/vbauerster/mpb/v4"
 )
 
+var commitSHARegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
 type Input struct {
 	Name string
 	Path string
@@ -80,6 +83,10 @@ func DetermineInputs(
 		return nil, nil, nil, nil, err
 	}
 
+	for name, input := range GenerateGitInputs(fact, localInputMappings, tags) {
+		inputsFromLocal[name] = input
+	}
+
 	inputsFromJob, imageResourceFromJob, resourceTypes, err := FetchInputsFromJob(fact, team, inputsFrom, jobInputImage)
 	if err != nil {
 		return nil, nil, nil, nil, err
@@ -168,6 +175,10 @@ func GenerateLocalInputs(
 	prog := progress.New()
 
 	for _, mapping := range inputMappings {
+		if mapping.GitURI != "" {
+			continue
+		}
+
 		name := mapping.Name
 		path := mapping.Path
 
@@ -189,6 +200,10 @@ func GenerateLocalInputs(
 	}
 
 	for _, mapping := range inputMappings {
+		if mapping.GitURI != "" {
+			continue
+		}
+
 		val, _ := artifacts.Load(mapping.Name)
 
 		inputs[mapping.Name] = Input{
@@ -204,6 +219,61 @@ func GenerateLocalInputs(
 	return inputs, nil
 }
 
+// GenerateGitInputs creates plans fetching the inputs given as git URIs. A
+// ref which is a commit SHA is fetched as-is, otherwise it is treated as a
+// branch (or the default branch, if empty) and its latest commit is fetched.
+func GenerateGitInputs(fact atc.PlanFactory, inputMappings []flaghelpers.InputPairFlag, tags []string) map[string]Input {
+	inputs := map[string]Input{}
+
+	for _, mapping := range inputMappings {
+		if mapping.GitURI == "" {
+			continue
+		}
+
+		source := atc.Source{"uri": mapping.GitURI}
+
+		var plan atc.Plan
+		if commitSHARegexp.MatchString(mapping.GitRef) {
+			plan = fact.NewPlan(atc.GetPlan{
+				Name:    mapping.Name,
+				Type:    "git",
+				Source:  source,
+				Version: &atc.Version{"ref": mapping.GitRef},
+				Tags:    tags,
+			})
+		} else {
+			if mapping.GitRef != "" {
+				source["branch"] = mapping.GitRef
+			}
+
+			checkPlan := fact.NewPlan(atc.CheckPlan{
+				Name:   mapping.Name,
+				Type:   "git",
+				Source: source,
+				Tags:   tags,
+			})
+
+			plan = fact.NewPlan(atc.DoPlan{
+				checkPlan,
+				fact.NewPlan(atc.GetPlan{
+					Name:        mapping.Name,
+					Type:        "git",
+					Source:      source,
+					VersionFrom: &checkPlan.ID,
+					Tags:        tags,
+				}),
+			})
+		}
+
+		inputs[mapping.Name] = Input{
+			Name: mapping.Name,
+			Plan: plan,
+		}
+	}
+
+	return inputs
+}
+
 func FetchInputsFromJob(fact atc.PlanFactory, team concourse.Team, inputsFrom flaghelpers.JobFlag, imageName string) (map[string]Input, *atc.ImageResource, atc.VersionedResourceTypes, error) {
 	kvMap := map[string]Input{}
 
@@ -1,13 +1,26 @@
 package flaghelpers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/jessevdk/go-flags"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
 )
 
+// completionCacheTTL is how long the names fetched for completion are reused,
+// so that completing a flag a few times in a row only hits the API once.
+const completionCacheTTL = time.Minute
+
 type flyCommand struct {
 	Target rc.TargetName `short:"t" long:"target" description:"Concourse target name"`
 }
@@ -29,3 +42,162 @@ func parseFlags() flyCommand {
 
 	return fly
 }
+
+type completionCache struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Names     []string  `json:"names"`
+}
+
+// cachedNames returns the names cached for the target under the given key,
+// calling fetch when they are missing or older than completionCacheTTL.
+// Failing to read or write the cache only means fetching again next time.
+func cachedNames(target rc.Target, key string, fetch func() ([]string, error)) ([]string, error) {
+	cachePath := completionCachePath(target, key)
+
+	if cachePath != "" {
+		payload, err := ioutil.ReadFile(cachePath)
+		if err == nil {
+			var cache completionCache
+			err = json.Unmarshal(payload, &cache)
+			if err == nil && time.Since(cache.FetchedAt) < completionCacheTTL {
+				return cache.Names, nil
+			}
+		}
+	}
+
+	names, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if cachePath != "" {
+		payload, err := json.Marshal(completionCache{FetchedAt: time.Now(), Names: names})
+		if err == nil && os.MkdirAll(filepath.Dir(cachePath), 0700) == nil {
+			_ = ioutil.WriteFile(cachePath, payload, 0600)
+		}
+	}
+
+	return names, nil
+}
+
+func completionCachePath(target rc.Target, key string) string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(target.URL() + "\n" + target.Team().Name() + "\n" + key))
+
+	return filepath.Join(cacheDir, "fly", "completion", hex.EncodeToString(sum[:])+".json")
+}
+
+func loadCompletionTarget() (rc.Target, bool) {
+	fly := parseFlags()
+
+	target, err := rc.LoadTarget(fly.Target, false)
+	if err != nil {
+		return nil, false
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return nil, false
+	}
+
+	return target, true
+}
+
+// pipelineRefs returns the string form of the refs of the team's pipelines.
+func pipelineRefs(target rc.Target) ([]string, error) {
+	return cachedNames(target, "pipelines", func() ([]string, error) {
+		pipelines, err := target.Team().ListPipelines()
+		if err != nil {
+			return nil, err
+		}
+
+		refs := make([]string, len(pipelines))
+		for i, pipeline := range pipelines {
+			refs[i] = pipeline.Ref().String()
+		}
+
+		return refs, nil
+	})
+}
+
+// completePipelineChildren completes PIPELINE/NAME values, such as jobs and
+// resources, listing the children of a pipeline with listChildren.
+func completePipelineChildren(match string, kind string, listChildren func(concourse.Team, atc.PipelineRef) ([]string, error)) []flags.Completion {
+	target, ok := loadCompletionTarget()
+	if !ok {
+		return []flags.Completion{}
+	}
+
+	children := func(pipelineRef atc.PipelineRef) ([]string, error) {
+		return cachedNames(target, kind+"\n"+pipelineRef.String(), func() ([]string, error) {
+			return listChildren(target.Team(), pipelineRef)
+		})
+	}
+
+	var comps []flags.Completion
+	vs := strings.SplitN(match, "/", 3)
+
+	if len(vs) == 1 {
+		refs, err := pipelineRefs(target)
+		if err != nil {
+			return comps
+		}
+
+		seen := map[string]bool{}
+		for _, ref := range refs {
+			name := strings.SplitN(ref, "/", 2)[0]
+			if !seen[name] && strings.HasPrefix(name, vs[0]) {
+				seen[name] = true
+				comps = append(comps, flags.Completion{Item: name + "/"})
+			}
+		}
+	} else if len(vs) == 2 {
+		refs, err := pipelineRefs(target)
+		if err != nil {
+			return comps
+		}
+
+		pipelineRef, err := parsePipelineRef(vs[0], vs[1])
+		if err == nil {
+			for _, ref := range refs {
+				if strings.HasPrefix(ref, pipelineRef.String()) {
+					comps = append(comps, flags.Completion{Item: ref + "/"})
+				}
+			}
+		} else {
+			pipelineRef := atc.PipelineRef{Name: vs[0]}
+			names, err := children(pipelineRef)
+			if err != nil {
+				return comps
+			}
+
+			for _, name := range names {
+				if strings.HasPrefix(name, vs[1]) {
+					comps = append(comps, flags.Completion{Item: pipelineRef.String() + "/" + name})
+				}
+			}
+		}
+	} else if len(vs) == 3 {
+		pipelineRef, err := parsePipelineRef(vs[0], vs[1])
+		if err != nil {
+			return comps
+		}
+
+		names, err := children(pipelineRef)
+		if err != nil {
+			return comps
+		}
+
+		for _, name := range names {
+			if strings.HasPrefix(name, vs[2]) {
+				comps = append(comps, flags.Completion{Item: pipelineRef.String() + "/" + name})
+			}
+		}
+	}
+
+	return comps
+}
@@ -6,9 +6,16 @@ import (
 	"strings"
 )
 
+const gitURIPrefix = "git+"
+
 type InputPairFlag struct {
 	Name string
 	Path string
+
+	// GitURI and GitRef are set instead of Path for inputs given as
+	// git+URI[#REF], which are fetched by the build rather than uploaded.
+	GitURI string
+	GitRef string
 }
 
 func (pair *InputPairFlag) UnmarshalFlag(value string) error {
@@ -17,6 +24,25 @@ func (pair *InputPairFlag) UnmarshalFlag(value string) error {
 		return fmt.Errorf("invalid input pair '%s' (must be name=path)", value)
 	}
 
+	if strings.HasPrefix(path, gitURIPrefix) {
+		uri := strings.TrimPrefix(path, gitURIPrefix)
+
+		var ref string
+		if i := strings.LastIndex(uri, "#"); i != -1 {
+			uri, ref = uri[:i], uri[i+1:]
+		}
+
+		if uri == "" {
+			return fmt.Errorf("invalid git uri '%s' for input '%s'", path, name)
+		}
+
+		pair.Name = name
+		pair.GitURI = uri
+		pair.GitRef = ref
+
+		return nil
+	}
+
 	matches, err := filepath.Glob(path)
 	if err != nil {
 		return fmt.Errorf("failed to expand path '%s': %s", path, err)
@@ -0,0 +1,74 @@
+package flaghelpers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+)
+
+var _ = Describe("InputPairFlag", func() {
+	Describe("UnmarshalFlag", func() {
+		var flag *flaghelpers.InputPairFlag
+
+		BeforeEach(func() {
+			flag = &flaghelpers.InputPairFlag{}
+		})
+
+		for _, tt := range []struct {
+			desc     string
+			flag     string
+			expected flaghelpers.InputPairFlag
+			err      string
+		}{
+			{
+				desc:     "local path",
+				flag:     "some-input=.",
+				expected: flaghelpers.InputPairFlag{Name: "some-input", Path: "."},
+			},
+			{
+				desc: "git uri",
+				flag: "some-input=git+https://example.com/some/repo.git",
+				expected: flaghelpers.InputPairFlag{
+					Name:   "some-input",
+					GitURI: "https://example.com/some/repo.git",
+				},
+			},
+			{
+				desc: "git uri with a ref",
+				flag: "some-input=git+git@example.com:some/repo.git#some-branch",
+				expected: flaghelpers.InputPairFlag{
+					Name:   "some-input",
+					GitURI: "git@example.com:some/repo.git",
+					GitRef: "some-branch",
+				},
+			},
+			{
+				desc: "errors if there is no '='",
+				flag: "some-input",
+				err:  "invalid input pair 'some-input' (must be name=path)",
+			},
+			{
+				desc: "errors if the path does not exist",
+				flag: "some-input=bogus-path",
+				err:  "path 'bogus-path' does not exist",
+			},
+			{
+				desc: "errors if the git uri is empty",
+				flag: "some-input=git+#some-branch",
+				err:  "invalid git uri 'git+#some-branch' for input 'some-input'",
+			},
+		} {
+			tt := tt
+			It(tt.desc, func() {
+				err := flag.UnmarshalFlag(tt.flag)
+				if tt.err == "" {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(*flag).To(Equal(tt.expected))
+				} else {
+					Expect(err).To(MatchError(tt.err))
+				}
+			})
+		}
+	})
+})
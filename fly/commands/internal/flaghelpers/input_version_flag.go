@@ -0,0 +1,29 @@
+package flaghelpers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+)
+
+type InputVersionFlag struct {
+	Name    string
+	Version atc.Version
+}
+
+func (flag *InputVersionFlag) UnmarshalFlag(value string) error {
+	name, version, ok := parseKeyValuePair(value)
+	if !ok || name == "" {
+		return fmt.Errorf("invalid input version '%s' (must be name=version-json)", value)
+	}
+
+	flag.Name = name
+
+	err := json.Unmarshal([]byte(version), &flag.Version)
+	if err != nil {
+		return fmt.Errorf("invalid version for input '%s': %s", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package flaghelpers_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+)
+
+var _ = Describe("InputVersionFlag", func() {
+	Describe("UnmarshalFlag", func() {
+		var flag *flaghelpers.InputVersionFlag
+
+		BeforeEach(func() {
+			flag = &flaghelpers.InputVersionFlag{}
+		})
+
+		for _, tt := range []struct {
+			desc    string
+			flag    string
+			name    string
+			version atc.Version
+			err     string
+		}{
+			{
+				desc:    "basic",
+				flag:    `some-input={"ref":"abc"}`,
+				name:    "some-input",
+				version: atc.Version{"ref": "abc"},
+			},
+			{
+				desc:    "allows '=' in the version",
+				flag:    `some-input={"ref":"a=b"}`,
+				name:    "some-input",
+				version: atc.Version{"ref": "a=b"},
+			},
+			{
+				desc: "errors if there is no '='",
+				flag: `some-input`,
+				err:  `invalid input version 'some-input' (must be name=version-json)`,
+			},
+			{
+				desc: "errors if the version is not a JSON object of strings",
+				flag: `some-input={"ref":1}`,
+				err:  `invalid version for input 'some-input': json: cannot unmarshal number into Go struct field Version.ref of type string`,
+			},
+		} {
+			tt := tt
+			It(tt.desc, func() {
+				err := flag.UnmarshalFlag(tt.flag)
+				if tt.err == "" {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(flag.Name).To(Equal(tt.name))
+					Expect(flag.Version).To(Equal(tt.version))
+				} else {
+					Expect(err).To(MatchError(tt.err))
+				}
+			})
+		}
+	})
+})
@@ -8,7 +8,7 @@ import (
 	"github.com/jessevdk/go-flags"
 
 	"github.com/concourse/concourse/atc"
-	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
 )
 
 type JobFlag struct {
@@ -47,75 +47,19 @@ func (flag *JobFlag) UnmarshalFlag(value string) error {
 }
 
 func (flag *JobFlag) Complete(match string) []flags.Completion {
-	fly := parseFlags()
-
-	target, err := rc.LoadTarget(fly.Target, false)
-	if err != nil {
-		return []flags.Completion{}
-	}
-
-	err = target.Validate()
-	if err != nil {
-		return []flags.Completion{}
-	}
-
-	team := target.Team()
-	var comps []flags.Completion
-	vs := strings.SplitN(match, "/", 3)
-
-	if len(vs) == 1 {
-		pipelines, err := team.ListPipelines()
-		if err != nil {
-			return comps
-		}
-
-		for _, pipeline := range pipelines {
-			if strings.HasPrefix(pipeline.Name, vs[0]) {
-				comps = append(comps, flags.Completion{Item: pipeline.Name + "/"})
-			}
-		}
-	} else if len(vs) == 2 {
-		pipelines, err := team.ListPipelines()
-		if err != nil {
-			return comps
-		}
-
-		pipelineRef, err := parsePipelineRef(vs[0], vs[1])
-		if err == nil {
-			for _, pipeline := range pipelines {
-				if strings.HasPrefix(pipeline.Ref().String(), pipelineRef.String()) {
-					comps = append(comps, flags.Completion{Item: pipeline.Ref().String() + "/"})
-				}
-			}
-		} else {
-			pipelineRef := atc.PipelineRef{Name: vs[0]}
-			jobs, err := team.ListJobs(pipelineRef)
-			if err != nil {
-				return comps
-			}
-			for _, job := range jobs {
-				if strings.HasPrefix(job.Name, vs[1]) {
-					comps = append(comps, flags.Completion{Item: fmt.Sprintf("%s/%s", pipelineRef.String(), job.Name)})
-				}
-			}
-		}
-	} else if len(vs) == 3 {
-		pipelineRef, err := parsePipelineRef(vs[0], vs[1])
-		if err != nil {
-			return comps
-		}
+	return completePipelineChildren(match, "jobs", func(team concourse.Team, pipelineRef atc.PipelineRef) ([]string, error) {
 		jobs, err := team.ListJobs(pipelineRef)
 		if err != nil {
-			return comps
+			return nil, err
 		}
-		for _, job := range jobs {
-			if strings.HasPrefix(job.Name, vs[2]) {
-				comps = append(comps, flags.Completion{Item: fmt.Sprintf("%s/%s", pipelineRef.String(), job.Name)})
-			}
+
+		names := make([]string, len(jobs))
+		for i, job := range jobs {
+			names[i] = job.Name
 		}
-	}
 
-	return comps
+		return names, nil
+	})
 }
 
 func parsePipelineRef(pipelineName, rawInstanceVars string) (atc.PipelineRef, error) {
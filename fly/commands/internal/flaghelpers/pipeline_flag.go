@@ -7,7 +7,6 @@ import (
 	"github.com/jessevdk/go-flags"
 
 	"github.com/concourse/concourse/atc"
-	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/go-concourse/concourse"
 )
 
@@ -60,27 +59,20 @@ func (flag *PipelineFlag) UnmarshalFlag(value string) error {
 }
 
 func (flag *PipelineFlag) Complete(match string) []flags.Completion {
-	fly := parseFlags()
-
-	target, err := rc.LoadTarget(fly.Target, false)
-	if err != nil {
-		return []flags.Completion{}
-	}
-
-	err = target.Validate()
-	if err != nil {
+	target, ok := loadCompletionTarget()
+	if !ok {
 		return []flags.Completion{}
 	}
 
-	pipelines, err := target.Team().ListPipelines()
+	refs, err := pipelineRefs(target)
 	if err != nil {
 		return []flags.Completion{}
 	}
 
 	comps := []flags.Completion{}
-	for _, pipeline := range pipelines {
-		if strings.HasPrefix(pipeline.Ref().String(), match) {
-			comps = append(comps, flags.Completion{Item: pipeline.Ref().String()})
+	for _, ref := range refs {
+		if strings.HasPrefix(ref, match) {
+			comps = append(comps, flags.Completion{Item: ref})
 		}
 	}
 
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/jessevdk/go-flags"
+
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
 )
 
 type ResourceFlag struct {
@@ -42,3 +45,19 @@ func (flag *ResourceFlag) UnmarshalFlag(value string) error {
 
 	return nil
 }
+
+func (flag *ResourceFlag) Complete(match string) []flags.Completion {
+	return completePipelineChildren(match, "resources", func(team concourse.Team, pipelineRef atc.PipelineRef) ([]string, error) {
+		resources, err := team.ListResources(pipelineRef)
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, len(resources))
+		for i, resource := range resources {
+			names[i] = resource.Name
+		}
+
+		return names, nil
+	})
+}
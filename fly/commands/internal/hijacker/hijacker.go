@@ -3,6 +3,7 @@ package hijacker
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,6 +19,11 @@ import (
 	"github.com/tedsuo/rata"
 )
 
+// ErrDetached is returned when the connection to a named session is lost
+// before its process exits. The process keeps running, so it can be
+// reattached to.
+var ErrDetached = errors.New("detached from session")
+
 type ProcessIO struct {
 	In  chan atc.HijackInput
 	Out io.Writer
@@ -72,7 +78,11 @@ func (h *Hijacker) Hijack(ctx context.Context, teamName, handle string, spec atc
 	go h.monitorTTYSize(ctx, pio.In)
 	go h.handleInput(ctx, conn, pio.In)
 
-	exitStatus, exeNotFound := h.handleOutput(conn, pio)
+	exitStatus, exeNotFound, finished := h.handleOutput(conn, pio)
+
+	if spec.Session != "" && !finished && !exeNotFound {
+		return -1, false, ErrDetached
+	}
 
 	return exitStatus, exeNotFound, nil
 }
@@ -103,9 +113,10 @@ func (h *Hijacker) hijackRequestParts(teamName, handle string) (string, http.Hea
 	return wsUrl.String(), hijackReq.Header, nil
 }
 
-func (h *Hijacker) handleOutput(conn *websocket.Conn, pio ProcessIO) (int, bool) {
+func (h *Hijacker) handleOutput(conn *websocket.Conn, pio ProcessIO) (int, bool, bool) {
 	var exitStatus int
 	var exeNotFound bool
+	var finished bool
 	for {
 		var output atc.HijackOutput
 		err := conn.ReadJSON(&output)
@@ -118,11 +129,13 @@ func (h *Hijacker) handleOutput(conn *websocket.Conn, pio ProcessIO) (int, bool)
 
 		if output.ExitStatus != nil {
 			exitStatus = *output.ExitStatus
+			finished = true
 		} else if output.ExecutableNotFound {
 			exeNotFound = true
 		} else if len(output.Error) > 0 {
 			fmt.Fprintf(ui.Stderr, "%s\n", ansi.Color(output.Error, "red+b"))
 			exitStatus = 255
+			finished = true
 		} else if len(output.Stdout) > 0 {
 			pio.Out.Write(output.Stdout)
 		} else if len(output.Stderr) > 0 {
@@ -130,7 +143,7 @@ func (h *Hijacker) handleOutput(conn *websocket.Conn, pio ProcessIO) (int, bool)
 		}
 	}
 
-	return exitStatus, exeNotFound
+	return exitStatus, exeNotFound, finished
 }
 
 func (h *Hijacker) handleInput(ctx context.Context, conn *websocket.Conn, inputs <-chan atc.HijackInput) {
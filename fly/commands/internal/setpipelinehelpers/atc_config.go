@@ -1,9 +1,14 @@
 package setpipelinehelpers
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -29,6 +34,9 @@ type ATCConfig struct {
 	CheckCredentials bool
 	CommandWarnings  []concourse.ConfigWarning
 	GivenTeamName    string
+	ShowSecrets      bool
+	ApproveSections  bool
+	ExpectedDiffHash string
 }
 
 func (atcConfig ATCConfig) ApplyConfigInteraction() bool {
@@ -70,17 +78,40 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 		})
 	}
 
-	diffExists := diff(existingConfig, newConfig)
+	// the ATC saves the steps of step templates in place of the steps which
+	// use them, so they're expanded here too in order to diff against the
+	// existing config
+	expandedConfig, err := atc.ExpandStepTemplates(newConfig)
+	if err == nil {
+		newConfig = expandedConfig
+	}
+
+	sections := existingConfig.SectionDiffs(newConfig)
+	diffOptions := atc.DiffOptions{RedactSecrets: !atcConfig.ShowSecrets}
+
+	stdout, _ := ui.ForTTY(os.Stdout)
+	if !atcConfig.ApproveSections {
+		for _, section := range sections {
+			section.Render(stdout, diffOptions)
+		}
+	}
 
 	if len(atcConfig.CommandWarnings) > 0 {
 		displayhelpers.ShowWarnings(atcConfig.CommandWarnings)
 	}
 
-	if !diffExists {
+	if len(sections) == 0 {
 		fmt.Println("no changes to apply")
 		return nil
 	}
 
+	hash := DiffHash(sections)
+	fmt.Println(bold("diff hash: ") + hash)
+
+	if atcConfig.ExpectedDiffHash != "" && atcConfig.ExpectedDiffHash != hash {
+		return fmt.Errorf("diff hash %s does not match the expected hash %s", hash, atcConfig.ExpectedDiffHash)
+	}
+
 	fmt.Println(bold("pipeline name: ") + atcConfig.PipelineRef.Name)
 	if len(atcConfig.PipelineRef.InstanceVars) != 0 {
 		fmt.Println(bold("pipeline instance vars:"))
@@ -101,7 +132,19 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 		fmt.Println()
 	}
 
-	if !atcConfig.ApplyConfigInteraction() {
+	if atcConfig.ApproveSections {
+		config, approved, err := atcConfig.approveSections(stdout, sections, existingConfig, newConfig, diffOptions)
+		if err != nil {
+			return err
+		}
+
+		if !approved {
+			fmt.Println("bailing out")
+			return nil
+		}
+
+		evaluatedTemplate = config
+	} else if atcConfig.ExpectedDiffHash == "" && !atcConfig.ApplyConfigInteraction() {
 		fmt.Println("bailing out")
 		return nil
 	}
@@ -175,9 +218,54 @@ func (atcConfig ATCConfig) showPipelineUpdateResult(pipeline atc.Pipeline, creat
 	}
 }
 
-func diff(existingConfig atc.Config, newConfig atc.Config) bool {
-	stdout, _ := ui.ForTTY(os.Stdout)
-	return existingConfig.Diff(stdout, newConfig)
+// approveSections asks whether to apply each section of the diff in turn,
+// returning the existing config with only the approved sections replaced.
+func (atcConfig ATCConfig) approveSections(out io.Writer, sections []atc.ConfigSectionDiff, existingConfig atc.Config, newConfig atc.Config, diffOptions atc.DiffOptions) ([]byte, bool, error) {
+	config := existingConfig
+
+	approved := false
+	for _, section := range sections {
+		section.Render(out, diffOptions)
+
+		confirm := false
+		err := interact.NewInteraction(fmt.Sprintf("apply changes to %s?", section.Section)).Resolve(&confirm)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if confirm {
+			section.Apply(&config, newConfig)
+			approved = true
+		}
+
+		fmt.Println()
+	}
+
+	if !approved {
+		return nil, false, nil
+	}
+
+	payload, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return payload, true, nil
+}
+
+var ansiEscapeRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// DiffHash returns a hash of the full, unredacted diff, which can be reviewed
+// ahead of time and given to --expected-diff-hash. Secrets are included so
+// that changing one changes the hash.
+func DiffHash(sections []atc.ConfigSectionDiff) string {
+	buf := new(bytes.Buffer)
+	for _, section := range sections {
+		section.Render(buf, atc.DiffOptions{})
+	}
+
+	sum := sha256.Sum256(ansiEscapeRegexp.ReplaceAll(buf.Bytes(), nil))
+	return hex.EncodeToString(sum[:])
 }
 
 func indent(text, indent string) string {
@@ -0,0 +1,54 @@
+package templatehelpers
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/vars"
+	"sigs.k8s.io/yaml"
+)
+
+// LoadVars collects the vars given on the command line without a template to
+// apply them to. Values in files specified later take precedence over the
+// same values in files specified earlier, and vars given as flags take
+// precedence over all files.
+func LoadVars(
+	templateVariablesFiles []atc.PathFlag,
+	templateVariables []flaghelpers.VariablePairFlag,
+	yamlTemplateVariables []flaghelpers.YAMLVariablePairFlag,
+) (vars.StaticVariables, error) {
+	result := vars.StaticVariables{}
+
+	for _, path := range templateVariablesFiles {
+		templateVars, err := ioutil.ReadFile(string(path))
+		if err != nil {
+			return nil, fmt.Errorf("could not read template variables file (%s): %s", string(path), err.Error())
+		}
+
+		var staticVars vars.StaticVariables
+		err = yaml.Unmarshal(templateVars, &staticVars)
+		if err != nil {
+			return nil, fmt.Errorf("could not unmarshal template variables (%s): %s", string(path), err.Error())
+		}
+
+		for name, val := range staticVars {
+			result[name] = val
+		}
+	}
+
+	var flagVarPairs vars.KVPairs
+	for _, f := range templateVariables {
+		flagVarPairs = append(flagVarPairs, vars.KVPair(f))
+	}
+	for _, f := range yamlTemplateVariables {
+		flagVarPairs = append(flagVarPairs, vars.KVPair(f))
+	}
+
+	for name, val := range flagVarPairs.Expand() {
+		result[name] = val
+	}
+
+	return result, nil
+}
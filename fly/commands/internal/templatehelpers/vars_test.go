@@ -0,0 +1,72 @@
+package templatehelpers_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LoadVars", func() {
+	var tmpdir string
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "load-vars-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = ioutil.WriteFile(filepath.Join(tmpdir, "first.yml"), []byte("target: windows\narch: amd64\n"), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = ioutil.WriteFile(filepath.Join(tmpdir, "second.yml"), []byte("target: darwin\n"), 0644)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpdir)
+	})
+
+	It("returns no vars when none are given", func() {
+		result, err := templatehelpers.LoadVars(nil, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeEmpty())
+	})
+
+	It("prefers later files, and flags over files", func() {
+		files := []atc.PathFlag{
+			atc.PathFlag(filepath.Join(tmpdir, "first.yml")),
+			atc.PathFlag(filepath.Join(tmpdir, "second.yml")),
+		}
+
+		result, err := templatehelpers.LoadVars(files, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(vars.StaticVariables{
+			"target": "darwin",
+			"arch":   "amd64",
+		}))
+
+		result, err = templatehelpers.LoadVars(files, []flaghelpers.VariablePairFlag{
+			{Ref: vars.Reference{Path: "target"}, Value: "linux"},
+		}, []flaghelpers.YAMLVariablePairFlag{
+			{Ref: vars.Reference{Path: "matrix", Fields: []string{"arch"}}, Value: "arm64"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(vars.StaticVariables{
+			"target": "linux",
+			"arch":   "amd64",
+			"matrix": map[string]interface{}{"arch": "arm64"},
+		}))
+	})
+
+	It("errors when a file can't be read", func() {
+		_, err := templatehelpers.LoadVars([]atc.PathFlag{atc.PathFlag(filepath.Join(tmpdir, "missing.yml"))}, nil, nil)
+		Expect(err).To(MatchError(ContainSubstring("could not read template variables file")))
+	})
+})
@@ -0,0 +1,128 @@
+package validatepipelinehelpers
+
+import (
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/vars"
+)
+
+// CredsCheck enables looking up the ((vars)) left in a pipeline after
+// templating in the pipeline's var_sources. The team and pipeline names are
+// used to build the lookup paths, just as they are by the ATC.
+type CredsCheck struct {
+	TeamName     string
+	PipelineName string
+}
+
+// checkCreds returns the ((vars)) referring to a var_source that could not be
+// found in it, and the ones without a var_source. The latter are resolved by
+// the cluster's credential manager, so they cannot be checked here. Vars with
+// a default value are not checked, since they do not need to be found.
+func checkCreds(check CredsCheck, config atc.Config, evaluatedTemplate []byte) ([]string, []string, error) {
+	namedVars, closeVarSources, err := newVarSourceVariables(config, check.TeamName, check.PipelineName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer closeVarSources()
+
+	var missing, unchecked []string
+	seen := map[string]bool{}
+	for _, name := range vars.NewTemplate(evaluatedTemplate).RequiredVarNames() {
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+
+		ref, err := vars.ParseReference(name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch ref.Source {
+		case "":
+			unchecked = append(unchecked, name)
+			continue
+		case ".":
+			// local vars are set by the build itself, e.g. by load_var
+			continue
+		}
+
+		_, found, err := namedVars.Get(ref)
+		if err != nil {
+			missing = append(missing, fmt.Sprintf("%s: %s", name, err))
+		} else if !found {
+			missing = append(missing, fmt.Sprintf("%s: not found", name))
+		}
+	}
+
+	sort.Strings(missing)
+	sort.Strings(unchecked)
+
+	return missing, unchecked, nil
+}
+
+// newVarSourceVariables initializes the pipeline's var_sources, in order of
+// their dependencies, returning their variables by name. The returned func
+// closes them.
+func newVarSourceVariables(config atc.Config, teamName string, pipelineName string) (vars.NamedVariables, func(), error) {
+	logger := lager.NewLogger("var-sources")
+
+	namedVars := vars.NamedVariables{}
+	allVars := vars.NewMultiVars([]vars.Variables{namedVars})
+
+	var managers []creds.Manager
+	closeAll := func() {
+		for _, manager := range managers {
+			manager.Close(logger)
+		}
+	}
+
+	orderedVarSources, err := config.VarSources.OrderByDependency()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, cm := range orderedVarSources {
+		factory := creds.ManagerFactories()[cm.Type]
+		if factory == nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("unknown credential manager type: %s", cm.Type)
+		}
+
+		newConfig, err := creds.NewParams(allVars, atc.Params{"config": cm.Config}).Evaluate()
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("evaluate var_source '%s' error: %s", cm.Name, err)
+		}
+
+		manager, err := factory.NewInstance(newConfig["config"])
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("create var_source '%s' error: %s", cm.Name, err)
+		}
+
+		err = manager.Init(logger)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("create var_source '%s' error: %s", cm.Name, err)
+		}
+
+		managers = append(managers, manager)
+
+		secretsFactory, err := manager.NewSecretsFactory(logger)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("create var_source '%s' error: %s", cm.Name, err)
+		}
+
+		namedVars[cm.Name] = creds.NewVariables(secretsFactory.NewSecrets(), teamName, pipelineName, true)
+	}
+
+	return namedVars, closeAll, nil
+}
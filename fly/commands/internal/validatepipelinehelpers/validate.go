@@ -3,17 +3,19 @@ package validatepipelinehelpers
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/concourse/concourse/atc"
 
 	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
+	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse"
 	"sigs.k8s.io/yaml"
 )
 
-func Validate(yamlTemplate templatehelpers.YamlTemplateWithParams, strict bool, output bool, enableAcrossStep bool) error {
+func Validate(yamlTemplate templatehelpers.YamlTemplateWithParams, strict bool, output bool, enableAcrossStep bool, credsCheck *CredsCheck) error {
 	evaluatedTemplate, err := yamlTemplate.Evaluate(true, strict)
 	if err != nil {
 		return err
@@ -54,6 +56,22 @@ func Validate(yamlTemplate templatehelpers.YamlTemplateWithParams, strict bool,
 		return errors.New("configuration invalid")
 	}
 
+	if credsCheck != nil {
+		missing, unchecked, err := checkCreds(*credsCheck, unmarshalledTemplate, evaluatedTemplate)
+		if err != nil {
+			return err
+		}
+
+		if len(unchecked) > 0 {
+			fmt.Fprintf(ui.Stderr, "not checking vars without a var_source, as they are resolved by the cluster's credential manager: %s\n", strings.Join(unchecked, ", "))
+		}
+
+		if len(missing) > 0 {
+			displayhelpers.ShowErrors("Error checking credentials", missing)
+			return errors.New("credentials invalid")
+		}
+	}
+
 	if output {
 		fmt.Println(string(evaluatedTemplate))
 	} else {
@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/fly/commands/internal/validatepipelinehelpers"
 
 	"github.com/concourse/concourse/atc"
+	_ "github.com/concourse/concourse/atc/creds/dummy"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -133,32 +134,101 @@ jobs:
 		})
 
 		It("validates a good pipeline", func() {
-			err := validatepipelinehelpers.Validate(goodPipeline, false, false, false)
+			err := validatepipelinehelpers.Validate(goodPipeline, false, false, false, nil)
 			Expect(err).To(BeNil())
 		})
 		It("validates a good pipeline with strict", func() {
-			err := validatepipelinehelpers.Validate(goodPipeline, true, false, false)
+			err := validatepipelinehelpers.Validate(goodPipeline, true, false, false, nil)
 			Expect(err).To(BeNil())
 		})
 		It("validates a good pipeline with output", func() {
-			err := validatepipelinehelpers.Validate(goodPipeline, true, true, false)
+			err := validatepipelinehelpers.Validate(goodPipeline, true, true, false, nil)
 			Expect(err).To(BeNil())
 		})
 		It("do not fail validating a pipeline with repeated resource types (probably should but for compat doesn't)", func() {
-			err := validatepipelinehelpers.Validate(dupkeyPipeline, false, false, false)
+			err := validatepipelinehelpers.Validate(dupkeyPipeline, false, false, false, nil)
 			Expect(err).To(BeNil())
 		})
 		It("fail validating a pipeline with repeated resource types with strict", func() {
-			err := validatepipelinehelpers.Validate(dupkeyPipeline, true, false, false)
+			err := validatepipelinehelpers.Validate(dupkeyPipeline, true, false, false, nil)
 			Expect(err).ToNot(BeNil())
 		})
 		It("fail validating a pipeline using experimental `across` without the command flag enabling it", func() {
-			err := validatepipelinehelpers.Validate(goodAcrossPipeline, false, false, false)
+			err := validatepipelinehelpers.Validate(goodAcrossPipeline, false, false, false, nil)
 			Expect(err).ToNot(BeNil())
 		})
 		It("validates a pipeline using experimental `across` when the command flag enabling it is present", func() {
-			err := validatepipelinehelpers.Validate(goodAcrossPipeline, false, false, true)
+			err := validatepipelinehelpers.Validate(goodAcrossPipeline, false, false, true, nil)
 			Expect(err).To(BeNil())
 		})
+
+		Describe("checking credentials", func() {
+			var credsPipeline templatehelpers.YamlTemplateWithParams
+
+			writeCredsPipeline := func(secretVar string) {
+				err := ioutil.WriteFile(
+					filepath.Join(tmpdir, "creds-pipeline.yml"),
+					[]byte(`---
+var_sources:
+- name: some-source
+  type: dummy
+  config:
+    vars:
+      repository: ubuntu
+jobs:
+- name: hello-world
+  plan:
+  - task: say-hello
+    config:
+      platform: linux
+      image_resource:
+        type: registry-image
+        source: {repository: ((`+secretVar+`))}
+      run:
+        path: echo
+        args: ["((cluster-var))"]
+`),
+					0644,
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				credsPipeline = templatehelpers.NewYamlTemplateWithParams(atc.PathFlag(filepath.Join(tmpdir, "creds-pipeline.yml")), nil, nil, nil, nil)
+			}
+
+			It("validates a pipeline whose vars are found in its var_sources", func() {
+				writeCredsPipeline("some-source:repository")
+
+				err := validatepipelinehelpers.Validate(credsPipeline, false, false, false, &validatepipelinehelpers.CredsCheck{})
+				Expect(err).To(BeNil())
+			})
+
+			It("fails validating a pipeline with a var missing from its var_source", func() {
+				writeCredsPipeline("some-source:missing")
+
+				err := validatepipelinehelpers.Validate(credsPipeline, false, false, false, &validatepipelinehelpers.CredsCheck{})
+				Expect(err).To(MatchError("credentials invalid"))
+			})
+
+			It("validates a pipeline whose var missing from its var_source has a default", func() {
+				writeCredsPipeline(`some-source:missing // "ubuntu"`)
+
+				err := validatepipelinehelpers.Validate(credsPipeline, false, false, false, &validatepipelinehelpers.CredsCheck{})
+				Expect(err).To(BeNil())
+			})
+
+			It("fails validating a pipeline with a var from an undeclared var_source", func() {
+				writeCredsPipeline("other-source:repository")
+
+				err := validatepipelinehelpers.Validate(credsPipeline, false, false, false, &validatepipelinehelpers.CredsCheck{})
+				Expect(err).To(MatchError("credentials invalid"))
+			})
+
+			It("does not check credentials unless asked to", func() {
+				writeCredsPipeline("some-source:missing")
+
+				err := validatepipelinehelpers.Validate(credsPipeline, false, false, false, nil)
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 })
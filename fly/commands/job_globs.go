@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/vito/go-interact/interact"
+)
+
+// isJobGlob returns whether the job name given to a -j flag is a glob rather
+// than the name of a single job.
+func isJobGlob(jobName string) bool {
+	return strings.ContainsAny(jobName, "*?[")
+}
+
+// pauseJobsMatching lists the pipeline's jobs matching the glob in the job
+// flag and, after confirmation, passes them all to the bulk pause or unpause
+// call in a single request.
+func pauseJobsMatching(
+	team concourse.Team,
+	job flaghelpers.JobFlag,
+	verb string,
+	dryRun bool,
+	skipInteractive bool,
+	bulkPause func([]string) ([]string, bool, error),
+) error {
+	pipelineRef := job.PipelineRef
+
+	jobs, err := team.ListJobs(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	var matched []string
+	for _, j := range jobs {
+		match, err := path.Match(job.JobName, j.Name)
+		if err != nil {
+			return fmt.Errorf("invalid job glob '%s': %s", job.JobName, err)
+		}
+
+		if match {
+			matched = append(matched, j.Name)
+		}
+	}
+
+	if len(matched) == 0 {
+		return fmt.Errorf("no jobs in %s match '%s'", pipelineRef.String(), job.JobName)
+	}
+
+	fmt.Printf("jobs in %s matching '%s':\n", pipelineRef.String(), job.JobName)
+	for _, name := range matched {
+		fmt.Printf("  %s\n", name)
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	confirm := skipInteractive
+	if !confirm {
+		err = interact.NewInteraction(fmt.Sprintf("\n%s %d jobs?", verb, len(matched))).Resolve(&confirm)
+		if err != nil || !confirm {
+			fmt.Println("bailing out")
+			return err
+		}
+	}
+
+	updated, found, err := bulkPause(matched)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("%s not found on team %s", pipelineRef.String(), team.Name())
+	}
+
+	fmt.Println()
+	for _, name := range updated {
+		fmt.Printf("%sd '%s'\n", verb, name)
+	}
+
+	return nil
+}
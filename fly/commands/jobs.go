@@ -14,8 +14,9 @@ import (
 
 type JobsCommand struct {
 	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Get jobs in this pipeline"`
-	Json     bool                     `long:"json" description:"Print command result as JSON"`
 	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+
+	displayhelpers.OutputFlags
 }
 
 func (command *JobsCommand) Execute([]string) error {
@@ -49,8 +50,8 @@ func (command *JobsCommand) Execute([]string) error {
 		return err
 	}
 
-	if command.Json {
-		err = displayhelpers.JsonPrint(jobs)
+	if command.Structured() {
+		err = command.PrintStructured(jobs)
 		if err != nil {
 			return err
 		}
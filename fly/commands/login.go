@@ -9,8 +9,10 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/pty"
@@ -33,6 +35,7 @@ type LoginCommand struct {
 	ClientCertPath atc.PathFlag `long:"client-cert" description:"Path to a PEM-encoded client certificate file."`
 	ClientKeyPath  atc.PathFlag `long:"client-key" description:"Path to a PEM-encoded client key file."`
 	OpenBrowser    bool         `short:"b" long:"open-browser" description:"Open browser to the auth endpoint"`
+	Device         bool         `long:"device" description:"Log in by approving a code in a browser on any machine, e.g. when logging in from a headless machine"`
 
 	BrowserOnly bool
 }
@@ -121,7 +124,7 @@ func (command *LoginCommand) Execute(args []string) error {
 		return err
 	}
 
-	isRawMode := pty.IsTerminal() && !command.BrowserOnly
+	isRawMode := pty.IsTerminal() && !command.BrowserOnly && !command.Device
 	if isRawMode {
 		state, err := terminal.MakeRaw(int(os.Stdin.Fd()))
 		if err != nil {
@@ -140,6 +143,8 @@ func (command *LoginCommand) Execute(args []string) error {
 	} else {
 		if command.Username != "" && command.Password != "" {
 			tokenType, tokenValue, err = command.passwordGrant(client, command.Username, command.Password)
+		} else if command.Device {
+			tokenType, tokenValue, err = command.deviceGrant(client)
 		} else {
 			tokenType, tokenValue, err = command.authCodeGrant(client.URL(), command.BrowserOnly, isRawMode)
 		}
@@ -196,6 +201,104 @@ func (command *LoginCommand) passwordGrant(client concourse.Client, username, pa
 	return token.TokenType, token.AccessToken, nil
 }
 
+type deviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type deviceTokenResponse struct {
+	TokenType   string `json:"token_type"`
+	AccessToken string `json:"access_token"`
+	Error       string `json:"error"`
+}
+
+// deviceGrant logs in using the device authorization flow: the user approves
+// a code in a browser, which need not be on this machine, while fly polls
+// for the token.
+func (command *LoginCommand) deviceGrant(client concourse.Client) (string, string, error) {
+	response, err := client.HTTPClient().PostForm(client.URL()+"/sky/device/code", url.Values{
+		"client_id": {"fly"},
+		"scope":     {"openid profile email federated:id groups"},
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("device login is not available: %s", response.Status)
+	}
+
+	var authorization deviceAuthorization
+	err = json.NewDecoder(response.Body).Decode(&authorization)
+	if err != nil {
+		return "", "", err
+	}
+
+	fmt.Println("navigate to the following URL in a browser on any machine:")
+	fmt.Println("")
+	fmt.Printf("  %s\n", authorization.VerificationURIComplete)
+	fmt.Println("")
+	fmt.Printf("and confirm that it shows the code %s\n", authorization.UserCode)
+
+	if command.OpenBrowser {
+		// try to open the browser window, but don't get all hung up if it
+		// fails, since we already printed about it.
+		_ = open.Start(authorization.VerificationURIComplete)
+	}
+
+	interval := time.Duration(authorization.Interval) * time.Second
+	deadline := time.Now().Add(time.Duration(authorization.ExpiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		token, err := pollDeviceToken(client, authorization.DeviceCode)
+		if err != nil {
+			return "", "", err
+		}
+
+		switch token.Error {
+		case "":
+			return token.TokenType, token.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "expired_token":
+			return "", "", errors.New("the code expired before it was confirmed")
+		default:
+			return "", "", fmt.Errorf("device login failed: %s", token.Error)
+		}
+	}
+
+	return "", "", errors.New("the code expired before it was confirmed")
+}
+
+func pollDeviceToken(client concourse.Client, deviceCode string) (deviceTokenResponse, error) {
+	response, err := client.HTTPClient().PostForm(client.URL()+"/sky/device/token", url.Values{
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		"device_code": {deviceCode},
+	})
+	if err != nil {
+		return deviceTokenResponse{}, err
+	}
+
+	defer response.Body.Close()
+
+	var token deviceTokenResponse
+	err = json.NewDecoder(response.Body).Decode(&token)
+	if err != nil {
+		return deviceTokenResponse{}, fmt.Errorf("unexpected response polling for token: %s", response.Status)
+	}
+
+	return token, nil
+}
+
 func (command *LoginCommand) authCodeGrant(targetUrl string, browserOnly bool, isRawMode bool) (string, string, error) {
 	var tokenStr string
 
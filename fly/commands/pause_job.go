@@ -9,8 +9,12 @@ import (
 )
 
 type PauseJobCommand struct {
-	Job  flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to pause"`
-	Team string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+	Job    flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to pause, or a glob matching several jobs, e.g. 'pipeline/test-*'"`
+	Reason string              `short:"r" long:"reason" description:"Why the job is being paused, shown to everyone who views it"`
+	Team   string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+
+	DryRun          bool `          long:"dry-run"         description:"Only list the jobs matching the glob"`
+	SkipInteractive bool `short:"n" long:"non-interactive" description:"Pause the jobs matching the glob without confirmation"`
 }
 
 func (command *PauseJobCommand) Execute(args []string) error {
@@ -36,7 +40,13 @@ func (command *PauseJobCommand) Execute(args []string) error {
 		team = target.Team()
 	}
 
-	found, err := team.PauseJob(pipelineRef, jobName)
+	if isJobGlob(jobName) {
+		return pauseJobsMatching(team, command.Job, "pause", command.DryRun, command.SkipInteractive, func(jobNames []string) ([]string, bool, error) {
+			return team.PauseJobs(pipelineRef, jobNames, command.Reason)
+		})
+	}
+
+	found, err := team.PauseJob(pipelineRef, jobName, command.Reason)
 	if err != nil {
 		return err
 	}
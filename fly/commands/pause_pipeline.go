@@ -13,6 +13,7 @@ import (
 type PausePipelineCommand struct {
 	Pipeline *flaghelpers.PipelineFlag `short:"p"   long:"pipeline" description:"Pipeline to pause"`
 	All      bool                      `short:"a"   long:"all"      description:"Pause all pipelines"`
+	Reason   string                    `short:"r"   long:"reason"   description:"Why the pipeline is being paused, shown to everyone who views it"`
 	Team     string                    `long:"team"                 description:"Name of the team to which the pipeline belongs, if different from the target default"`
 }
 
@@ -72,7 +73,7 @@ func (command *PausePipelineCommand) Execute(args []string) error {
 	}
 
 	for _, pipelineRef := range pipelineRefs {
-		found, err := team.PausePipeline(pipelineRef)
+		found, err := team.PausePipeline(pipelineRef, command.Reason)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type PipelineTemplatesCommand struct {
+	Team string `long:"team" description:"Name of the team owning the pipeline templates, if different from the target default"`
+
+	displayhelpers.OutputFlags
+}
+
+func (command *PipelineTemplatesCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	templates, err := team.ListPipelineTemplates()
+	if err != nil {
+		return err
+	}
+
+	if command.Structured() {
+		err = command.PrintStructured(templates)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, template := range templates {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: template.Name},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -15,7 +15,8 @@ import (
 type PipelinesCommand struct {
 	All             bool `short:"a"  long:"all" description:"Show pipelines across all teams"`
 	IncludeArchived bool `long:"include-archived" description:"Show archived pipelines"`
-	Json            bool `long:"json" description:"Print command result as JSON"`
+
+	displayhelpers.OutputFlags
 }
 
 func (command *PipelinesCommand) Execute([]string) error {
@@ -43,8 +44,8 @@ func (command *PipelinesCommand) Execute([]string) error {
 	headers := command.buildHeader()
 	pipelines := command.filterPipelines(unfilteredPipelines)
 
-	if command.Json {
-		err = displayhelpers.JsonPrint(pipelines)
+	if command.Structured() {
+		err = command.PrintStructured(pipelines)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+	"github.com/vito/go-interact/interact"
+)
+
+type PruneTeamStateCommand struct {
+	Team            string `          long:"team"            description:"Name of the team whose state to prune, if different from the target default"`
+	DryRun          bool   `          long:"dry-run"         description:"Only list what would be pruned"`
+	SkipInteractive bool   `short:"n" long:"non-interactive" description:"Prune without confirmation"`
+}
+
+func (command *PruneTeamStateCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	state, err := team.PrunableState()
+	if err != nil {
+		return err
+	}
+
+	if len(state.Containers) == 0 && len(state.Volumes) == 0 {
+		fmt.Printf("nothing to prune for team '%s'\n", team.Name())
+		return nil
+	}
+
+	err = renderPrunableState(state)
+	if err != nil {
+		return err
+	}
+
+	if command.DryRun {
+		return nil
+	}
+
+	confirm := command.SkipInteractive
+	if !confirm {
+		err = interact.NewInteraction(fmt.Sprintf("\nprune %d containers and %d volumes of team '%s'?", len(state.Containers), len(state.Volumes), team.Name())).Resolve(&confirm)
+		if err != nil || !confirm {
+			fmt.Println("bailing out")
+			return err
+		}
+	}
+
+	pruned, err := team.PruneState()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\npruned %d containers and %d volumes; they will be destroyed once their workers report in\n", len(pruned.Containers), len(pruned.Volumes))
+
+	return nil
+}
+
+func renderPrunableState(state atc.TeamPrunableState) error {
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "kind", Color: color.New(color.Bold)},
+			{Contents: "handle", Color: color.New(color.Bold)},
+			{Contents: "worker", Color: color.New(color.Bold)},
+			{Contents: "reason", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, c := range state.Containers {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: "container"},
+			{Contents: c.Handle},
+			{Contents: c.WorkerName},
+			{Contents: c.Reason},
+		})
+	}
+
+	for _, v := range state.Volumes {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: "volume"},
+			{Contents: v.Handle},
+			{Contents: v.WorkerName},
+			{Contents: v.Reason},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type PublishedArtifactsCommand struct {
+	Job   flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of the job of the build"`
+	Build string              `short:"b" long:"build" required:"true" description:"If job is specified: build number. If job not specified: build id"`
+
+	displayhelpers.OutputFlags
+}
+
+func (command *PublishedArtifactsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if command.Job.PipelineRef.Name == "" && command.Job.JobName == "" {
+		build, exists, err = target.Client().Build(command.Build)
+	} else {
+		build, exists, err = target.Team().JobBuild(command.Job.PipelineRef, command.Job.JobName, command.Build)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	artifacts, err := target.Client().ListBuildPublishedArtifacts(strconv.Itoa(build.ID))
+	if err != nil {
+		return err
+	}
+
+	if command.Structured() {
+		return command.PrintStructured(artifacts)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "location", Color: color.New(color.Bold)},
+			{Contents: "digest", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, artifact := range artifacts {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: artifact.Name},
+			{Contents: artifact.Location},
+			{Contents: artifact.Digest},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
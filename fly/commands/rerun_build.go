@@ -6,7 +6,9 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
 	"github.com/concourse/concourse/fly/eventstream"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
@@ -16,6 +18,10 @@ type RerunBuildCommand struct {
 	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of the job that you want to rerun a build for"`
 	Build string              `short:"b" long:"build" required:"true" description:"The number of the build to rerun"`
 	Watch bool                `short:"w" long:"watch" description:"Start watching the rerun build output"`
+
+	Var      []flaghelpers.VariablePairFlag     `short:"v"  long:"var"             unquote:"false"  value-name:"[NAME=STRING]"  description:"Override a var resolved when the build runs with a string value"`
+	YAMLVar  []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"        unquote:"false"  value-name:"[NAME=YAML]"    description:"Override a var resolved when the build runs with a YAML value"`
+	VarsFrom []atc.PathFlag                     `short:"l"  long:"load-vars-from"                                               description:"Override vars resolved when the build runs with those in a YAML file"`
 }
 
 func (command *RerunBuildCommand) Execute(args []string) error {
@@ -32,7 +38,17 @@ func (command *RerunBuildCommand) Execute(args []string) error {
 		return err
 	}
 
-	build, err := target.Team().RerunJobBuild(pipelineRef, jobName, buildName)
+	buildVars, err := templatehelpers.LoadVars(command.VarsFrom, command.Var, command.YAMLVar)
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	if len(buildVars) > 0 {
+		build, err = target.Team().RerunJobBuildWithVars(pipelineRef, jobName, buildName, buildVars)
+	} else {
+		build, err = target.Team().RerunJobBuild(pipelineRef, jobName, buildName)
+	}
 	if err != nil {
 		return err
 	}
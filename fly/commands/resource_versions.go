@@ -18,7 +18,8 @@ import (
 type ResourceVersionsCommand struct {
 	Count    int                      `short:"c" long:"count" default:"50" description:"Number of versions you want to limit the return to"`
 	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of a resource to get versions for"`
-	Json     bool                     `long:"json" description:"Print command result as JSON"`
+
+	displayhelpers.OutputFlags
 }
 
 func (command *ResourceVersionsCommand) Execute([]string) error {
@@ -41,8 +42,8 @@ func (command *ResourceVersionsCommand) Execute([]string) error {
 		return err
 	}
 
-	if command.Json {
-		err = displayhelpers.JsonPrint(versions)
+	if command.Structured() {
+		err = command.PrintStructured(versions)
 		if err != nil {
 			return err
 		}
@@ -13,7 +13,8 @@ import (
 
 type ResourcesCommand struct {
 	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Get resources in this pipeline"`
-	Json     bool                     `long:"json" description:"Print command result as JSON"`
+
+	displayhelpers.OutputFlags
 }
 
 func (command *ResourcesCommand) Execute([]string) error {
@@ -35,8 +36,8 @@ func (command *ResourcesCommand) Execute([]string) error {
 		return err
 	}
 
-	if command.Json {
-		err = displayhelpers.JsonPrint(resources)
+	if command.Structured() {
+		err = command.PrintStructured(resources)
 		if err != nil {
 			return err
 		}
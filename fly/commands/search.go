@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"os"
+	"strings"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type SearchCommand struct {
+	Limit int `long:"limit" description:"Maximum number of results to show"`
+
+	Query struct {
+		Terms []string `positional-arg-name:"query" required:"true" description:"Terms to search for, and filters such as kind:job, team:main or label:prod"`
+	} `positional-args:"yes"`
+
+	displayhelpers.OutputFlags
+}
+
+func (command *SearchCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	results, err := target.Client().Search(strings.Join(command.Query.Terms, " "), command.Limit)
+	if err != nil {
+		return err
+	}
+
+	if command.Structured() {
+		err = command.PrintStructured(results)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "kind", Color: color.New(color.Bold)},
+			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "name", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, result := range results {
+		name := result.Name
+		if result.PipelineName != "" {
+			name = result.PipelineName + "/" + name
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: result.Kind},
+			stringOrDefault(result.TeamName),
+			{Contents: name},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
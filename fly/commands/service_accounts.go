@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type ServiceAccountsCommand struct {
+	Team string `long:"team" description:"Name of the team owning the service accounts, if different from the target default"`
+
+	displayhelpers.OutputFlags
+}
+
+func (command *ServiceAccountsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	accounts, err := team.ListServiceAccounts()
+	if err != nil {
+		return err
+	}
+
+	if command.Structured() {
+		err = command.PrintStructured(accounts)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "role", Color: color.New(color.Bold)},
+			{Contents: "created by", Color: color.New(color.Bold)},
+			{Contents: "expires", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, account := range accounts {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: account.Name},
+			{Contents: account.Role},
+			stringOrDefault(account.CreatedBy),
+			{Contents: time.Unix(account.ExpiresAt, 0).Format(time.RFC3339)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -21,6 +21,10 @@ type SetPipelineCommand struct {
 
 	CheckCredentials bool `long:"check-creds"  description:"Validate credential variables against credential manager"`
 
+	ShowSecrets      bool   `long:"show-secrets"        description:"Show the values of credential-like fields in the diff rather than redacting them"`
+	ApproveSections  bool   `long:"approve-sections"    description:"Ask whether to apply each section of the diff (groups, resources, jobs, ...) separately, applying only the approved ones"`
+	ExpectedDiffHash string `long:"expected-diff-hash"  value-name:"HASH"  description:"Apply the configuration without asking, but only if the hash of the diff matches the given one"`
+
 	PipelineName string       `short:"p"  long:"pipeline"  required:"true"  description:"Pipeline to configure"`
 	Config       atc.PathFlag `short:"c"  long:"config"    required:"true"  description:"Pipeline configuration file, \"-\" stands for stdin"`
 
@@ -48,6 +52,9 @@ func (command *SetPipelineCommand) Validate() ([]concourse.ConfigWarning, error)
 			})
 		}
 	}
+	if err == nil && command.ApproveSections && (command.SkipInteractive || command.ExpectedDiffHash != "") {
+		err = errors.New("--approve-sections cannot be combined with --non-interactive or --expected-diff-hash")
+	}
 	return warnings, err
 }
 
@@ -104,6 +111,9 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 		CheckCredentials: command.CheckCredentials,
 		CommandWarnings:  warnings,
 		GivenTeamName:    command.Team,
+		ShowSecrets:      command.ShowSecrets,
+		ApproveSections:  command.ApproveSections,
+		ExpectedDiffHash: command.ExpectedDiffHash,
 	}
 
 	yamlTemplateWithParams := templatehelpers.NewYamlTemplateWithParams(configPath, templateVariablesFiles, command.Var, command.YAMLVar, instanceVars)
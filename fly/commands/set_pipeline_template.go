@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type SetPipelineTemplateCommand struct {
+	Name   string       `short:"n" long:"name"   required:"true" description:"Name of the pipeline template, used in the include: of the pipelines merging it"`
+	Config atc.PathFlag `short:"c" long:"config" required:"true" description:"Partial pipeline configuration file to register as the template"`
+	Team   string       `long:"team" description:"Name of the team owning the pipeline template, if different from the target default"`
+}
+
+func (command *SetPipelineTemplateCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	config, err := ioutil.ReadFile(string(command.Config))
+	if err != nil {
+		return err
+	}
+
+	created, err := team.SetPipelineTemplate(atc.PipelineTemplate{
+		Name:   command.Name,
+		Config: string(config),
+	})
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Printf("pipeline template '%s' created\n", command.Name)
+	} else {
+		fmt.Printf("pipeline template '%s' updated\n", command.Name)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
@@ -29,6 +30,9 @@ type SetTeamCommand struct {
 	Team            flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
 	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
 	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
+
+	AccessTokenLifetime time.Duration `long:"access-token-lifetime" description:"Revoke access to the team for tokens older than this, e.g. 1h. Must not exceed the cluster's token lifetime."`
+	SessionIdleTimeout  time.Duration `long:"session-idle-timeout" description:"Revoke access to the team for tokens which go unused for longer than this, e.g. 30m."`
 }
 
 func (command *SetTeamCommand) Validate() ([]concourse.ConfigWarning, error) {
@@ -62,44 +66,36 @@ func (command *SetTeamCommand) Execute([]string) error {
 		return err
 	}
 
-	authRoles, err := command.AuthFlags.Format()
+	authRoles, pipelineAuth, err := command.AuthFlags.FormatWithPipelines()
 	if err != nil {
 		fmt.Fprintln(ui.Stderr, "error:", err)
 		os.Exit(1)
 	}
 
-	roles := []string{}
-	for role := range authRoles {
-		roles = append(roles, role)
-	}
-	sort.Strings(roles)
-
 	teamName := command.Team.Name()
 	fmt.Println("setting team:", ui.Embolden("%s", teamName))
 
-	for _, role := range roles {
-		authUsers := authRoles[role]["users"]
-		authGroups := authRoles[role]["groups"]
+	for _, role := range sortedRoles(authRoles) {
+		displayRole(ui.Embolden(role), authRoles[role])
+	}
 
-		fmt.Println()
-		fmt.Printf("role %s:\n", ui.Embolden(role))
-		fmt.Printf("  users:\n")
-		if len(authUsers) > 0 {
-			for _, user := range authUsers {
-				fmt.Printf("  - %s\n", user)
-			}
-		} else {
-			fmt.Printf("    %s\n", ui.OffColor.Sprint("none"))
+	for _, grant := range pipelineAuth {
+		for _, role := range sortedRoles(grant.Auth) {
+			displayRole(
+				fmt.Sprintf("%s on pipeline %s", ui.Embolden(role), ui.Embolden("%s", grant.Pipeline.String())),
+				grant.Auth[role],
+			)
 		}
+	}
 
+	if command.AccessTokenLifetime > 0 || command.SessionIdleTimeout > 0 {
 		fmt.Println()
-		fmt.Printf("  groups:\n")
-		if len(authGroups) > 0 {
-			for _, group := range authGroups {
-				fmt.Printf("  - %s\n", group)
-			}
-		} else {
-			fmt.Printf("    %s\n", ui.OffColor.Sprint("none"))
+		fmt.Println("sessions:")
+		if command.AccessTokenLifetime > 0 {
+			fmt.Printf("  access token lifetime: %s\n", command.AccessTokenLifetime)
+		}
+		if command.SessionIdleTimeout > 0 {
+			fmt.Printf("  session idle timeout: %s\n", command.SessionIdleTimeout)
 		}
 	}
 
@@ -120,7 +116,12 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: authRoles}
+	team := atc.Team{
+		Auth:                authRoles,
+		PipelineAuth:        pipelineAuth,
+		AccessTokenLifetime: command.AccessTokenLifetime,
+		SessionIdleTimeout:  command.SessionIdleTimeout,
+	}
 
 	_, created, updated, warnings, err := target.Client().Team(teamName).CreateOrUpdate(team)
 	if err != nil {
@@ -139,3 +140,39 @@ func (command *SetTeamCommand) Execute([]string) error {
 
 	return nil
 }
+
+func sortedRoles(auth atc.TeamAuth) []string {
+	roles := []string{}
+	for role := range auth {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	return roles
+}
+
+func displayRole(title string, config map[string][]string) {
+	authUsers := config["users"]
+	authGroups := config["groups"]
+
+	fmt.Println()
+	fmt.Printf("role %s:\n", title)
+	fmt.Printf("  users:\n")
+	if len(authUsers) > 0 {
+		for _, user := range authUsers {
+			fmt.Printf("  - %s\n", user)
+		}
+	} else {
+		fmt.Printf("    %s\n", ui.OffColor.Sprint("none"))
+	}
+
+	fmt.Println()
+	fmt.Printf("  groups:\n")
+	if len(authGroups) > 0 {
+		for _, group := range authGroups {
+			fmt.Printf("  - %s\n", group)
+		}
+	} else {
+		fmt.Printf("    %s\n", ui.OffColor.Sprint("none"))
+	}
+}
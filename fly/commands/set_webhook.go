@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type SetWebhookCommand struct {
+	Name      string   `short:"n" long:"name" required:"true" description:"Name of the webhook"`
+	Type      string   `long:"type" choice:"generic" choice:"slack" choice:"teams" default:"generic" description:"Kind of webhook: generic webhooks receive each event as JSON, slack and teams webhooks receive a message"`
+	URL       string   `short:"u" long:"url" required:"true" description:"URL that events are POSTed to; for slack and teams, the incoming webhook URL"`
+	Channel   string   `long:"channel" description:"Slack channel to post to, if different from the incoming webhook's default"`
+	Template  string   `long:"template" description:"Go template for slack and teams messages, given the event's fields and a URL to the build or pipeline"`
+	Secret    string   `long:"secret" description:"Secret used to sign each payload with HMAC-SHA256, sent in the X-Concourse-Signature header"`
+	Events    []string `long:"event" description:"Only notify of this event (build-started, build-finished, pipeline-changed). Can be specified multiple times"`
+	Pipelines []string `long:"pipeline" description:"Only notify of events in this pipeline. Can be specified multiple times"`
+	Jobs      []string `long:"job" description:"Only notify of events for this job. Can be specified multiple times"`
+	Statuses  []string `long:"status" description:"Only notify of builds with this status. Can be specified multiple times"`
+	Team      string   `long:"team" description:"Name of the team owning the webhook, if different from the target default"`
+}
+
+func (command *SetWebhookCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	webhookType := command.Type
+	if webhookType == "generic" {
+		webhookType = atc.TeamWebhookTypeGeneric
+	}
+
+	created, err := team.SetWebhook(atc.TeamWebhook{
+		Name:     command.Name,
+		Type:     webhookType,
+		URL:      command.URL,
+		Secret:   command.Secret,
+		Channel:  command.Channel,
+		Template: command.Template,
+		Filter: atc.TeamWebhookFilter{
+			Events:    command.Events,
+			Pipelines: command.Pipelines,
+			Jobs:      command.Jobs,
+			Statuses:  command.Statuses,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Printf("webhook '%s' created\n", command.Name)
+	} else {
+		fmt.Printf("webhook '%s' updated\n", command.Name)
+	}
+
+	return nil
+}
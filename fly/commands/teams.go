@@ -14,8 +14,9 @@ import (
 )
 
 type TeamsCommand struct {
-	Json    bool `long:"json" description:"Print command result as JSON"`
 	Details bool `short:"d" long:"details" description:"Print authentication configuration"`
+
+	displayhelpers.OutputFlags
 }
 
 func (command *TeamsCommand) Execute([]string) error {
@@ -34,8 +35,8 @@ func (command *TeamsCommand) Execute([]string) error {
 		return err
 	}
 
-	if command.Json {
-		err = displayhelpers.JsonPrint(teams)
+	if command.Structured() {
+		err = command.PrintStructured(teams)
 		if err != nil {
 			return err
 		}
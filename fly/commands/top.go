@@ -0,0 +1,247 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+// clearScreen moves the cursor to the top left and clears the terminal.
+const clearScreen = "\x1b[H\x1b[2J"
+
+type TopCommand struct {
+	Interval time.Duration `long:"interval" default:"5s" description:"How often to refresh the view"`
+	Builds   int           `long:"builds"   default:"10" description:"How many of the busiest builds to show"`
+	Once     bool          `long:"once"                  description:"Print the view once instead of refreshing it. Implied when not writing to a terminal"`
+}
+
+type topBuild struct {
+	build      atc.Build
+	containers int
+	counted    bool
+}
+
+func (command *TopCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	client := target.Client()
+
+	_, isTTY := ui.ForTTY(os.Stdout)
+	if command.Once || !isTTY {
+		return command.render(client, &bytes.Buffer{}, false)
+	}
+
+	buf := &bytes.Buffer{}
+	for {
+		err = command.render(client, buf, true)
+		if err != nil {
+			return err
+		}
+
+		time.Sleep(command.Interval)
+	}
+}
+
+// render builds the whole view in buf before printing it, so that refreshing
+// it doesn't flicker.
+func (command *TopCommand) render(client concourse.Client, buf *bytes.Buffer, clear bool) error {
+	buf.Reset()
+
+	workers, err := client.ListWorkers()
+	if err != nil {
+		return err
+	}
+
+	builds, err := busiestBuilds(client)
+	if err != nil {
+		return err
+	}
+
+	sort.Sort(byWorkerName(workers))
+
+	var containers, volumes, stalled int
+	for _, w := range workers {
+		containers += w.ActiveContainers
+		volumes += w.ActiveVolumes
+		if w.State == "stalled" {
+			stalled++
+		}
+	}
+
+	if clear {
+		fmt.Fprint(buf, clearScreen)
+	}
+
+	fmt.Fprintf(buf, "%s  workers: %d (%d stalled)  containers: %d  volumes: %d  running builds: %d\n\n",
+		time.Now().Format("15:04:05"), len(workers), stalled, containers, volumes, len(builds))
+
+	workersTable := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "worker", Color: color.New(color.Bold)},
+			{Contents: "state", Color: color.New(color.Bold)},
+			{Contents: "containers", Color: color.New(color.Bold)},
+			{Contents: "volumes", Color: color.New(color.Bold)},
+			{Contents: "tasks", Color: color.New(color.Bold)},
+			{Contents: "team", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, w := range workers {
+		stateCell := ui.TableCell{Contents: w.State}
+		if w.State == "stalled" {
+			stateCell.Color = color.New(color.FgRed)
+		}
+
+		teamCell := ui.TableCell{Contents: w.Team}
+		if w.Team == "" {
+			teamCell = ui.TableCell{Contents: "none", Color: color.New(color.Faint)}
+		}
+
+		workersTable.Data = append(workersTable.Data, ui.TableRow{
+			{Contents: w.Name},
+			stateCell,
+			{Contents: strconv.Itoa(w.ActiveContainers)},
+			{Contents: strconv.Itoa(w.ActiveVolumes)},
+			{Contents: strconv.Itoa(w.ActiveTasks)},
+			teamCell,
+		})
+	}
+
+	err = workersTable.Render(buf, true)
+	if err != nil {
+		return err
+	}
+
+	if len(builds) > command.Builds {
+		builds = builds[:command.Builds]
+	}
+
+	fmt.Fprintln(buf)
+
+	buildsTable := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "containers", Color: color.New(color.Bold)},
+			{Contents: "duration", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, b := range builds {
+		containersCell := ui.TableCell{Contents: strconv.Itoa(b.containers)}
+		if !b.counted {
+			containersCell = ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		}
+
+		buildsTable.Data = append(buildsTable.Data, ui.TableRow{
+			{Contents: strconv.Itoa(b.build.ID)},
+			{Contents: b.build.TeamName},
+			{Contents: buildDisplayName(b.build)},
+			containersCell,
+			{Contents: fmt.Sprintf("%v+", roundSecondsOffDuration(buildDuration(b.build)))},
+		})
+	}
+
+	err = buildsTable.Render(buf, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = buf.WriteTo(os.Stdout)
+	return err
+}
+
+// busiestBuilds returns the running builds, ordered by how many containers
+// they have and then by how long they have been running. Containers can only
+// be counted for the teams the user can see the containers of.
+func busiestBuilds(client concourse.Client) ([]topBuild, error) {
+	page := &concourse.Page{Limit: 100}
+
+	var builds []atc.Build
+	for page != nil {
+		pageBuilds, pagination, err := client.FilteredBuilds(*page, atc.BuildFilter{
+			Statuses: []atc.BuildStatus{atc.StatusStarted},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, pageBuilds...)
+		page = pagination.Next
+	}
+
+	containersByTeam := map[string]map[int]int{}
+	for _, b := range builds {
+		if _, fetched := containersByTeam[b.TeamName]; fetched {
+			continue
+		}
+
+		containers, err := client.Team(b.TeamName).ListContainers(map[string]string{})
+		if err != nil {
+			containersByTeam[b.TeamName] = nil
+			continue
+		}
+
+		counts := map[int]int{}
+		for _, c := range containers {
+			if c.BuildID != 0 {
+				counts[c.BuildID]++
+			}
+		}
+
+		containersByTeam[b.TeamName] = counts
+	}
+
+	topBuilds := make([]topBuild, len(builds))
+	for i, b := range builds {
+		counts := containersByTeam[b.TeamName]
+		topBuilds[i] = topBuild{
+			build:      b,
+			containers: counts[b.ID],
+			counted:    counts != nil,
+		}
+	}
+
+	sort.SliceStable(topBuilds, func(i, j int) bool {
+		if topBuilds[i].containers != topBuilds[j].containers {
+			return topBuilds[i].containers > topBuilds[j].containers
+		}
+
+		return buildDuration(topBuilds[i].build) > buildDuration(topBuilds[j].build)
+	})
+
+	return topBuilds, nil
+}
+
+// buildDuration returns how long the build ran for, or has been running for
+// so far.
+func buildDuration(build atc.Build) time.Duration {
+	if build.StartTime == 0 {
+		return 0
+	}
+
+	endTime := time.Now()
+	if build.EndTime != 0 {
+		endTime = time.Unix(build.EndTime, 0)
+	}
+
+	return endTime.Sub(time.Unix(build.StartTime, 0))
+}
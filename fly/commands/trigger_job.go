@@ -15,9 +15,10 @@ import (
 )
 
 type TriggerJobCommand struct {
-	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
-	Watch bool                `short:"w" long:"watch" description:"Start watching the build output"`
-	Team  string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+	Job    flaghelpers.JobFlag            `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
+	Inputs []flaghelpers.InputVersionFlag `long:"input" value-name:"NAME=VERSION-JSON" description:"Run the build with the latest version of an input matching the given (partial) version, without pinning its resource. Can be specified multiple times."`
+	Watch  bool                           `short:"w" long:"watch" description:"Start watching the build output"`
+	Team   string                         `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
 }
 
 func (command *TriggerJobCommand) Execute(args []string) error {
@@ -47,7 +48,16 @@ func (command *TriggerJobCommand) Execute(args []string) error {
 		team = target.Team()
 	}
 
-	build, err = team.CreateJobBuild(pipelineRef, jobName)
+	if len(command.Inputs) > 0 {
+		inputs := map[string]atc.Version{}
+		for _, input := range command.Inputs {
+			inputs[input.Name] = input.Version
+		}
+
+		build, err = team.CreateJobBuildWithInputs(pipelineRef, jobName, inputs)
+	} else {
+		build, err = team.CreateJobBuild(pipelineRef, jobName)
+	}
 	if err != nil {
 		return err
 	} else {
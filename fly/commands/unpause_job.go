@@ -9,8 +9,11 @@ import (
 )
 
 type UnpauseJobCommand struct {
-	Job  flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to unpause"`
+	Job  flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to unpause, or a glob matching several jobs, e.g. 'pipeline/test-*'"`
 	Team string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+
+	DryRun          bool `          long:"dry-run"         description:"Only list the jobs matching the glob"`
+	SkipInteractive bool `short:"n" long:"non-interactive" description:"Unpause the jobs matching the glob without confirmation"`
 }
 
 func (command *UnpauseJobCommand) Execute(args []string) error {
@@ -36,6 +39,12 @@ func (command *UnpauseJobCommand) Execute(args []string) error {
 		team = target.Team()
 	}
 
+	if isJobGlob(jobName) {
+		return pauseJobsMatching(team, command.Job, "unpause", command.DryRun, command.SkipInteractive, func(jobNames []string) ([]string, bool, error) {
+			return team.UnpauseJobs(pipelineRef, jobNames)
+		})
+	}
+
 	found, err := team.UnpauseJob(pipelineRef, jobName)
 	if err != nil {
 		return err
@@ -12,7 +12,7 @@ import (
 )
 
 type UserinfoCommand struct {
-	Json bool `long:"json" description:"Print command result as JSON"`
+	displayhelpers.OutputFlags
 }
 
 func (command *UserinfoCommand) Execute([]string) error {
@@ -31,8 +31,8 @@ func (command *UserinfoCommand) Execute([]string) error {
 		return err
 	}
 
-	if command.Json {
-		err = displayhelpers.JsonPrint(userinfo)
+	if command.Structured() {
+		err = command.PrintStructured(userinfo)
 		if err != nil {
 			return err
 		}
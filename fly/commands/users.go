@@ -15,7 +15,8 @@ const inputDateLayout = "2006-01-02"
 
 type ActiveUsersCommand struct {
 	Since string `long:"since" description:"Start date range of returned users' last login, defaults to 2 months from today'"`
-	Json  bool   `long:"json" description:"Print command result as JSON"`
+
+	displayhelpers.OutputFlags
 }
 
 func (command *ActiveUsersCommand) Execute([]string) error {
@@ -47,8 +48,8 @@ func (command *ActiveUsersCommand) Execute([]string) error {
 		return err
 	}
 
-	if command.Json {
-		err = displayhelpers.JsonPrint(users)
+	if command.Structured() {
+		err = command.PrintStructured(users)
 		if err != nil {
 			return err
 		}
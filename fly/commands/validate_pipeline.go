@@ -10,6 +10,7 @@ import (
 	_ "github.com/concourse/concourse/atc/creds/conjur"
 	_ "github.com/concourse/concourse/atc/creds/credhub"
 	_ "github.com/concourse/concourse/atc/creds/dummy"
+	_ "github.com/concourse/concourse/atc/creds/keyvault"
 	_ "github.com/concourse/concourse/atc/creds/kubernetes"
 	_ "github.com/concourse/concourse/atc/creds/secretsmanager"
 	_ "github.com/concourse/concourse/atc/creds/ssm"
@@ -22,6 +23,10 @@ type ValidatePipelineCommand struct {
 	Output           bool         `short:"o" long:"output"                  description:"Output templated pipeline to stdout"`
 	EnableAcrossStep bool         `long:"enable-across-step"                description:"Enable the experimental across step to be used in jobs. The API is subject to change."`
 
+	CheckCredentials bool   `long:"check-creds"  description:"Look up the vars left after templating in the pipeline's var_sources, failing if any are not found"`
+	Team             string `long:"team"         description:"Name of the team the pipeline belongs to, used to look up vars with --check-creds"`
+	Pipeline         string `long:"pipeline"     description:"Name of the pipeline, used to look up vars with --check-creds"`
+
 	Var     []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       unquote:"false"  value-name:"[NAME=STRING]"  description:"Specify a string value to set for a variable in the pipeline"`
 	YAMLVar []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  unquote:"false"  value-name:"[NAME=YAML]"    description:"Specify a YAML value to set for a variable in the pipeline"`
 
@@ -30,5 +35,14 @@ type ValidatePipelineCommand struct {
 
 func (command *ValidatePipelineCommand) Execute(args []string) error {
 	yamlTemplate := templatehelpers.NewYamlTemplateWithParams(command.Config, command.VarsFrom, command.Var, command.YAMLVar, nil)
-	return validatepipelinehelpers.Validate(yamlTemplate, command.Strict, command.Output, command.EnableAcrossStep)
+
+	var credsCheck *validatepipelinehelpers.CredsCheck
+	if command.CheckCredentials {
+		credsCheck = &validatepipelinehelpers.CredsCheck{
+			TeamName:     command.Team,
+			PipelineName: command.Pipeline,
+		}
+	}
+
+	return validatepipelinehelpers.Validate(yamlTemplate, command.Strict, command.Output, command.EnableAcrossStep, credsCheck)
 }
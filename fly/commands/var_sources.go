@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type VarSourcesCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Get the var sources of this pipeline"`
+
+	displayhelpers.OutputFlags
+}
+
+func (command *VarSourcesCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	statuses, found, err := target.Team().PipelineVarSources(command.Pipeline.Ref(), nil)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	if command.Structured() {
+		return command.PrintStructured(statuses)
+	}
+
+	headers := []string{"name", "type", "reachable", "latency", "lookups", "cache hit rate", "error"}
+	table := ui.Table{Headers: ui.TableRow{}}
+	for _, h := range headers {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
+	}
+
+	for _, status := range statuses {
+		reachableColumn := ui.TableCell{Contents: "yes", Color: ui.OnColor}
+		if !status.Reachable {
+			reachableColumn = ui.TableCell{Contents: "no", Color: ui.FailedColor}
+		}
+
+		var latencyColumn, hitRateColumn ui.TableCell
+		if status.Reachable {
+			latencyColumn.Contents = fmt.Sprintf("%dms", status.LatencyMS)
+		} else {
+			latencyColumn = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		if status.Lookups > 0 {
+			hitRateColumn.Contents = fmt.Sprintf("%.0f%%", status.CacheHitRate*100)
+		} else {
+			hitRateColumn = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		// the current error explains why the var source is unreachable; the
+		// last error is the most recent failure to fetch a var from it
+		var errorColumn ui.TableCell
+		switch {
+		case status.Error != "":
+			errorColumn = ui.TableCell{Contents: status.Error, Color: ui.FailedColor}
+		case status.LastError != "":
+			errorColumn = ui.TableCell{Contents: status.LastError, Color: color.New(color.FgYellow)}
+		default:
+			errorColumn = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			ui.TableCell{Contents: status.Name},
+			ui.TableCell{Contents: status.Type},
+			reachableColumn,
+			latencyColumn,
+			ui.TableCell{Contents: strconv.FormatInt(status.Lookups, 10)},
+			hitRateColumn,
+			errorColumn,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -10,14 +10,25 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
 	"github.com/fatih/color"
 )
 
 type VolumesCommand struct {
 	Details bool `short:"d" long:"details" description:"Print additional information for each volume"`
-	Json    bool `long:"json" description:"Print command result as JSON"`
+
+	Team     string                    `          long:"team"     description:"Name of the team whose volumes to list, if different from the target default"`
+	Worker   string                    `short:"w" long:"worker"   description:"Only list volumes on this worker"`
+	Type     string                    `          long:"type"     description:"Only list volumes of this type (e.g. container, resource, task-cache)"`
+	Pipeline *flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Only list task cache volumes belonging to this pipeline"`
+
+	SortBy  string `long:"sort-by" choice:"worker" choice:"handle" choice:"age" choice:"size" description:"Sort volumes by this field (default: worker); ages are sorted oldest first, sizes largest first"`
+	Reverse bool   `long:"reverse" description:"Reverse the sort order"`
+
+	displayhelpers.OutputFlags
 }
 
 func (command *VolumesCommand) Execute([]string) error {
@@ -31,13 +42,36 @@ func (command *VolumesCommand) Execute([]string) error {
 		return err
 	}
 
-	volumes, err := target.Team().ListVolumes()
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	allVolumes, err := team.ListVolumes()
 	if err != nil {
 		return err
 	}
 
-	if command.Json {
-		err = displayhelpers.JsonPrint(volumes)
+	volumes := []atc.Volume{}
+	for _, v := range allVolumes {
+		if command.matches(v) {
+			volumes = append(volumes, v)
+		}
+	}
+
+	// structured output is left in the order the API returned it in, unless
+	// a sort is asked for
+	if command.sorted() || !command.Structured() {
+		command.sortVolumes(volumes)
+	}
+
+	if command.Structured() {
+		err = command.PrintStructured(volumes)
 		if err != nil {
 			return err
 		}
@@ -53,14 +87,12 @@ func (command *VolumesCommand) Execute([]string) error {
 		},
 	}
 
-	sort.Sort(volumesByWorkerAndHandle(volumes))
-
 	for _, c := range volumes {
 		row := ui.TableRow{
 			{Contents: c.ID},
 			{Contents: c.WorkerName},
 			{Contents: c.Type},
-			{Contents: command.volumeIdentifier(c)},
+			{Contents: volumeIdentifier(c, command.Details)},
 		}
 
 		table.Data = append(table.Data, row)
@@ -69,10 +101,59 @@ func (command *VolumesCommand) Execute([]string) error {
 	return table.Render(os.Stdout, Fly.PrintTableHeaders)
 }
 
-func (command *VolumesCommand) volumeIdentifier(volume atc.Volume) string {
+func (command *VolumesCommand) matches(v atc.Volume) bool {
+	if command.Worker != "" && v.WorkerName != command.Worker {
+		return false
+	}
+
+	if command.Type != "" && v.Type != command.Type {
+		return false
+	}
+
+	if command.Pipeline != nil {
+		return pipelineMatches(command.Pipeline, v.PipelineName, v.PipelineInstanceVars)
+	}
+
+	return true
+}
+
+func (command *VolumesCommand) sorted() bool {
+	return command.SortBy != "" || command.Reverse
+}
+
+func (command *VolumesCommand) sortVolumes(volumes []atc.Volume) {
+	var less func(a, b atc.Volume) bool
+	switch command.SortBy {
+	case "handle":
+		less = func(a, b atc.Volume) bool { return a.ID < b.ID }
+	case "age":
+		less = func(a, b atc.Volume) bool { return a.CreatedAt < b.CreatedAt }
+	case "size":
+		less = func(a, b atc.Volume) bool { return a.SizeBytes > b.SizeBytes }
+	default:
+		less = func(a, b atc.Volume) bool {
+			if a.WorkerName == b.WorkerName {
+				return a.ID < b.ID
+			}
+
+			return a.WorkerName < b.WorkerName
+		}
+	}
+
+	if command.Reverse {
+		forward := less
+		less = func(a, b atc.Volume) bool { return forward(b, a) }
+	}
+
+	sort.SliceStable(volumes, func(i, j int) bool {
+		return less(volumes[i], volumes[j])
+	})
+}
+
+func volumeIdentifier(volume atc.Volume, details bool) string {
 	switch volume.Type {
 	case "container":
-		if command.Details {
+		if details {
 			identifier := fmt.Sprintf("container:%s,path:%s", volume.ContainerHandle, volume.Path)
 			if volume.ParentHandle != "" {
 				identifier = fmt.Sprintf("%s,parent:%s", identifier, volume.ParentHandle)
@@ -84,12 +165,12 @@ func (command *VolumesCommand) volumeIdentifier(volume atc.Volume) string {
 	case "task-cache":
 		return fmt.Sprintf("%s/%s/%s", volume.PipelineName, volume.JobName, volume.StepName)
 	case "resource":
-		if command.Details {
+		if details {
 			return presentResourceType(volume.ResourceType)
 		}
 		return presentMap(volume.ResourceType.Version)
 	case "resource-type":
-		if command.Details {
+		if details {
 			return presentMap(volume.BaseResourceType)
 		}
 		return volume.BaseResourceType.Name
@@ -117,15 +198,3 @@ func presentResourceType(resourceType *atc.VolumeResourceType) string {
 
 	return ""
 }
-
-type volumesByWorkerAndHandle []atc.Volume
-
-func (cs volumesByWorkerAndHandle) Len() int          { return len(cs) }
-func (cs volumesByWorkerAndHandle) Swap(i int, j int) { cs[i], cs[j] = cs[j], cs[i] }
-func (cs volumesByWorkerAndHandle) Less(i int, j int) bool {
-	if cs[i].WorkerName == cs[j].WorkerName {
-		return cs[i].ID < cs[j].ID
-	}
-
-	return cs[i].WorkerName < cs[j].WorkerName
-}
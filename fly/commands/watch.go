@@ -1,21 +1,30 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/eventstream"
 	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
 )
 
+// watchFollowInterval is how often the job is polled for a new build while
+// following it.
+const watchFollowInterval = 5 * time.Second
+
 type WatchCommand struct {
 	Job                      flaghelpers.JobFlag `short:"j" long:"job"         value-name:"PIPELINE/JOB"  description:"Watches builds of the given job"`
-	Build                    string              `short:"b" long:"build"                                  description:"Watches a specific build"`
+	Build                    []string            `short:"b" long:"build"                                  description:"Watches a specific build. Can be specified multiple times to watch builds at once, prefixing each line with its build"`
 	Url                      string              `short:"u" long:"url"                                    description:"URL for the build or job to watch"`
+	Follow                   bool                `          long:"follow"                                 description:"Keep watching the job, attaching to each new build as it starts"`
 	Timestamp                bool                `short:"t" long:"timestamps"                             description:"Print with local timestamp"`
 	IgnoreEventParsingErrors bool                `long:"ignore-event-parsing-errors"                      description:"Ignore event parsing errors"`
 }
@@ -83,16 +92,47 @@ func (command *WatchCommand) Execute(args []string) error {
 		return err
 	}
 
+	renderOptions := eventstream.RenderOptions{
+		ShowTimestamp:            command.Timestamp,
+		IgnoreEventParsingErrors: command.IgnoreEventParsingErrors,
+	}
+
+	if command.Follow {
+		if command.Job.JobName == "" {
+			return errors.New("--follow requires --job")
+		}
+
+		if len(command.Build) != 0 || command.Url != "" {
+			return errors.New("--follow cannot be combined with --build or --url")
+		}
+
+		return command.follow(target, renderOptions)
+	}
+
+	if len(command.Build) > 1 {
+		exitCode, err := command.watchAll(target, renderOptions)
+		if err != nil {
+			return err
+		}
+
+		os.Exit(exitCode)
+	}
+
+	var buildName string
+	if len(command.Build) == 1 {
+		buildName = command.Build[0]
+	}
+
 	var buildId int
 	client := target.Client()
-	if command.Job.JobName != "" || command.Build == "" && command.Url == "" {
-		build, err := GetBuild(client, target.Team(), command.Job.JobName, command.Build, command.Job.PipelineRef)
+	if command.Job.JobName != "" || buildName == "" && command.Url == "" {
+		build, err := GetBuild(client, target.Team(), command.Job.JobName, buildName, command.Job.PipelineRef)
 		if err != nil {
 			return err
 		}
 		buildId = build.ID
-	} else if command.Build != "" {
-		buildId, err = strconv.Atoi(command.Build)
+	} else if buildName != "" {
+		buildId, err = strconv.Atoi(buildName)
 
 		if err != nil {
 			return err
@@ -110,11 +150,6 @@ func (command *WatchCommand) Execute(args []string) error {
 		return err
 	}
 
-	renderOptions := eventstream.RenderOptions{
-		ShowTimestamp:            command.Timestamp,
-		IgnoreEventParsingErrors: command.IgnoreEventParsingErrors,
-	}
-
 	exitCode := eventstream.Render(os.Stdout, eventSource, renderOptions)
 
 	eventSource.Close()
@@ -123,3 +158,128 @@ func (command *WatchCommand) Execute(args []string) error {
 
 	return nil
 }
+
+// watchAll renders the given builds at once, prefixing each line with the
+// build it came from. The highest exit code of the builds is returned.
+func (command *WatchCommand) watchAll(target rc.Target, renderOptions eventstream.RenderOptions) (int, error) {
+	client := target.Client()
+
+	buildIDs := make([]int, len(command.Build))
+	for i, buildName := range command.Build {
+		var err error
+		if command.Job.JobName != "" {
+			var build atc.Build
+			build, err = GetBuild(client, target.Team(), command.Job.JobName, buildName, command.Job.PipelineRef)
+			buildIDs[i] = build.ID
+		} else {
+			buildIDs[i], err = strconv.Atoi(buildName)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	width := 0
+	for _, buildName := range command.Build {
+		if len(buildName) > width {
+			width = len(buildName)
+		}
+	}
+
+	lock := new(sync.Mutex)
+	exitCodes := make([]int, len(buildIDs))
+
+	wg := new(sync.WaitGroup)
+	for i, buildID := range buildIDs {
+		prefix := fmt.Sprintf("%-*s ", width+2, "["+command.Build[i]+"]")
+		writer := eventstream.NewPrefixedWriter(os.Stdout, lock, prefix)
+
+		wg.Add(1)
+		go func(i int, buildID int) {
+			defer wg.Done()
+
+			eventSource, err := client.BuildEvents(strconv.Itoa(buildID))
+			if err != nil {
+				fmt.Fprintf(writer, "failed to watch build: %s\n", err)
+				writer.Flush()
+				exitCodes[i] = 255
+				return
+			}
+
+			exitCodes[i] = eventstream.Render(writer, eventSource, renderOptions)
+
+			eventSource.Close()
+			writer.Flush()
+		}(i, buildID)
+	}
+
+	wg.Wait()
+
+	exitCode := 0
+	for _, code := range exitCodes {
+		if code > exitCode {
+			exitCode = code
+		}
+	}
+
+	return exitCode, nil
+}
+
+// follow renders each build of the job in turn, waiting for the next one to
+// start once the current one finishes. It only returns on error.
+func (command *WatchCommand) follow(target rc.Target, renderOptions eventstream.RenderOptions) error {
+	client := target.Client()
+	team := target.Team()
+
+	pipelineRef := command.Job.PipelineRef
+	jobName := command.Job.JobName
+
+	lastBuildID := 0
+	for {
+		job, found, err := team.Job(pipelineRef, jobName)
+		if err != nil {
+			return fmt.Errorf("failed to get job %s", err)
+		}
+
+		if !found {
+			return errors.New("job not found")
+		}
+
+		build, ok := nextBuildToFollow(job, lastBuildID)
+		if !ok {
+			time.Sleep(watchFollowInterval)
+			continue
+		}
+
+		if lastBuildID != 0 {
+			fmt.Println("")
+		}
+
+		fmt.Println(ui.Embolden("watching %s/%s #%s", pipelineRef.String(), jobName, build.Name))
+
+		eventSource, err := client.BuildEvents(strconv.Itoa(build.ID))
+		if err != nil {
+			return err
+		}
+
+		eventstream.Render(os.Stdout, eventSource, renderOptions)
+
+		eventSource.Close()
+
+		lastBuildID = build.ID
+	}
+}
+
+// nextBuildToFollow returns the job's current build if it is newer than the
+// last one followed.
+func nextBuildToFollow(job atc.Job, lastBuildID int) (atc.Build, bool) {
+	if job.NextBuild != nil && job.NextBuild.ID > lastBuildID {
+		return *job.NextBuild, true
+	}
+
+	if job.FinishedBuild != nil && job.FinishedBuild.ID > lastBuildID {
+		return *job.FinishedBuild, true
+	}
+
+	return atc.Build{}, false
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"os"
+	"strings"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type WebhooksCommand struct {
+	Team string `long:"team" description:"Name of the team owning the webhooks, if different from the target default"`
+
+	displayhelpers.OutputFlags
+}
+
+func (command *WebhooksCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	webhooks, err := team.ListWebhooks()
+	if err != nil {
+		return err
+	}
+
+	if command.Structured() {
+		err = command.PrintStructured(webhooks)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "type", Color: color.New(color.Bold)},
+			{Contents: "url", Color: color.New(color.Bold)},
+			{Contents: "events", Color: color.New(color.Bold)},
+			{Contents: "pipelines", Color: color.New(color.Bold)},
+			{Contents: "jobs", Color: color.New(color.Bold)},
+			{Contents: "statuses", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, webhook := range webhooks {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: webhook.Name},
+			stringOrDefault(webhook.Type, "generic"),
+			{Contents: webhook.URL},
+			stringOrDefault(strings.Join(webhook.Filter.Events, ","), "all"),
+			stringOrDefault(strings.Join(webhook.Filter.Pipelines, ","), "all"),
+			stringOrDefault(strings.Join(webhook.Filter.Jobs, ","), "all"),
+			stringOrDefault(strings.Join(webhook.Filter.Statuses, ","), "all"),
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -17,7 +17,8 @@ import (
 
 type WorkersCommand struct {
 	Details bool `short:"d" long:"details" description:"Print additional information for each worker"`
-	Json    bool `long:"json" description:"Print command result as JSON"`
+
+	displayhelpers.OutputFlags
 }
 
 func (command *WorkersCommand) Execute([]string) error {
@@ -36,8 +37,8 @@ func (command *WorkersCommand) Execute([]string) error {
 		return err
 	}
 
-	if command.Json {
-		err = displayhelpers.JsonPrint(workers)
+	if command.Structured() {
+		err = command.PrintStructured(workers)
 		if err != nil {
 			return err
 		}
@@ -141,7 +142,7 @@ func (command *WorkersCommand) tableFor(workers []worker) ui.Table {
 		row := ui.TableRow{
 			{Contents: w.Name},
 			{Contents: strconv.Itoa(w.ActiveContainers)},
-			{Contents: w.Platform},
+			w.platformCell(),
 			stringOrDefault(strings.Join(w.Tags, ", ")),
 			stringOrDefault(w.Team),
 			{Contents: w.State},
@@ -179,6 +180,14 @@ type worker struct {
 	outdated bool
 }
 
+func (w *worker) platformCell() ui.TableCell {
+	if w.Arch == "" {
+		return ui.TableCell{Contents: w.Platform}
+	}
+
+	return ui.TableCell{Contents: w.Platform + "/" + w.Arch}
+}
+
 func (w *worker) versionCell() ui.TableCell {
 	var column ui.TableCell
 	if w.Version != "" {
@@ -0,0 +1,65 @@
+package eventstream
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// PrefixedWriter writes each complete line to the underlying writer with a
+// prefix prepended, so that the output of several builds can be interleaved.
+// Writers sharing a lock never interleave within a line.
+type PrefixedWriter struct {
+	lock   sync.Locker
+	writer io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func NewPrefixedWriter(writer io.Writer, lock sync.Locker, prefix string) *PrefixedWriter {
+	return &PrefixedWriter{
+		lock:   lock,
+		writer: writer,
+		prefix: []byte(prefix),
+	}
+}
+
+func (w *PrefixedWriter) Write(b []byte) (int, error) {
+	w.buf = append(w.buf, b...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i == -1 {
+			break
+		}
+
+		err := w.writeLine(w.buf[:i+1])
+		if err != nil {
+			return 0, err
+		}
+
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(b), nil
+}
+
+// Flush writes out any incomplete line, terminating it.
+func (w *PrefixedWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	line := append(w.buf, '\n')
+	w.buf = nil
+
+	return w.writeLine(line)
+}
+
+func (w *PrefixedWriter) writeLine(line []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	_, err := w.writer.Write(append(append([]byte{}, w.prefix...), line...))
+	return err
+}
@@ -0,0 +1,64 @@
+package eventstream_test
+
+import (
+	"bytes"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/fly/eventstream"
+)
+
+var _ = Describe("PrefixedWriter", func() {
+	var (
+		out  *bytes.Buffer
+		lock *sync.Mutex
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		lock = new(sync.Mutex)
+	})
+
+	It("prefixes each line", func() {
+		writer := eventstream.NewPrefixedWriter(out, lock, "[a] ")
+
+		_, err := writer.Write([]byte("hello\nworld\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(out.String()).To(Equal("[a] hello\n[a] world\n"))
+	})
+
+	It("holds back partial lines until they are complete", func() {
+		writer := eventstream.NewPrefixedWriter(out, lock, "[a] ")
+		other := eventstream.NewPrefixedWriter(out, lock, "[b] ")
+
+		_, err := writer.Write([]byte("hel"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(BeEmpty())
+
+		_, err = other.Write([]byte("interrupting\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = writer.Write([]byte("lo\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(out.String()).To(Equal("[b] interrupting\n[a] hello\n"))
+	})
+
+	Describe("Flush", func() {
+		It("terminates any partial line", func() {
+			writer := eventstream.NewPrefixedWriter(out, lock, "[a] ")
+
+			_, err := writer.Write([]byte("no newline"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(writer.Flush()).To(Succeed())
+			Expect(out.String()).To(Equal("[a] no newline\n"))
+
+			Expect(writer.Flush()).To(Succeed())
+			Expect(out.String()).To(Equal("[a] no newline\n"))
+		})
+	})
+})
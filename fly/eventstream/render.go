@@ -48,6 +48,22 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 			dstImpl.SetTimestamp(e.Time)
 			fmt.Fprintf(dstImpl, "\x1b[1mselected worker:\x1b[0m %s\n", e.WorkerName)
 
+		case event.WaitingToRetry:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mwaiting %s before attempt %d...\x1b[0m\n", e.Delay, e.Attempt)
+
+		case event.WaitingForSemaphore:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mwaiting for semaphore %s (limit %d)...\x1b[0m\n", e.Name, e.Limit)
+
+		case event.Skipped:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mskipped: %s does not hold\x1b[0m\n", e.Condition)
+
+		case event.SoftTimeout:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1;33mstill running after soft timeout of %s\x1b[0m\n", e.Duration)
+
 		case event.InitializeTask:
 			dstImpl.SetTimestamp(e.Time)
 			fmt.Fprintf(dstImpl, "\x1b[1minitializing\x1b[0m\n")
@@ -59,6 +75,10 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 			dstImpl.SetTimestamp(e.Time)
 			fmt.Fprintf(dstImpl, "\x1b[1mrunning %s\x1b[0m\n", argv)
 
+			if buildConfig.Hermetic {
+				fmt.Fprintf(dstImpl, "\x1b[1mwithout network access (hermetic)\x1b[0m\n")
+			}
+
 		case event.FinishTask:
 			exitStatus = e.ExitStatus
 
@@ -137,6 +137,10 @@ var _ = Describe("V1.0 Renderer", func() {
 			Expect(out.Contents()).To(ContainSubstring("\x1b[1mrunning /some/script arg1 arg2\x1b[0m\n"))
 		})
 
+		It("does not say the task is hermetic", func() {
+			Expect(out.Contents()).ToNot(ContainSubstring("hermetic"))
+		})
+
 		Context("and time configuration enabled", func() {
 			BeforeEach(func() {
 				options.ShowTimestamp = true
@@ -148,6 +152,24 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("and a StartTask event is received for a hermetic task", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.StartTask{
+				Time: time.Now().Unix(),
+				TaskConfig: event.TaskConfig{
+					Run: event.TaskRunConfig{
+						Path: "/some/script",
+					},
+					Hermetic: true,
+				},
+			}
+		})
+
+		It("says the task runs without network access", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mrunning /some/script\x1b[0m\n\x1b[1mwithout network access (hermetic)\x1b[0m\n"))
+		})
+	})
+
 	Context("when a FinishTask event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.FinishTask{
@@ -318,6 +340,60 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("when a WaitingToRetry event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.WaitingToRetry{
+				Time:    time.Now().Unix(),
+				Attempt: 2,
+				Delay:   "10s",
+			}
+		})
+
+		It("prints the delay before the next attempt", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mwaiting 10s before attempt 2...\x1b[0m\n"))
+		})
+	})
+
+	Context("when a WaitingForSemaphore event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.WaitingForSemaphore{
+				Time:  time.Now().Unix(),
+				Name:  "some-semaphore",
+				Limit: 2,
+			}
+		})
+
+		It("prints the semaphore being waited for", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mwaiting for semaphore some-semaphore (limit 2)...\x1b[0m\n"))
+		})
+	})
+
+	Context("when a Skipped event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.Skipped{
+				Time:      time.Now().Unix(),
+				Condition: ".:deploy",
+			}
+		})
+
+		It("prints the condition which did not hold", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mskipped: .:deploy does not hold\x1b[0m\n"))
+		})
+	})
+
+	Context("when a SoftTimeout event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.SoftTimeout{
+				Time:     time.Now().Unix(),
+				Duration: "30m0s",
+			}
+		})
+
+		It("prints a warning with the soft timeout", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1;33mstill running after soft timeout of 30m0s\x1b[0m\n"))
+		})
+	})
+
 	Context("when a SelectedWorker event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.SelectedWorker{
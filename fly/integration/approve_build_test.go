@@ -0,0 +1,95 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("ApproveBuild", func() {
+	var expectedBuild = atc.Build{
+		ID:      23,
+		Name:    "42",
+		Status:  "started",
+		JobName: "my-job",
+		APIURL:  "api/v1/builds/23",
+	}
+
+	decide := func(expectedDecideURL string, status int) {
+		atcServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/my-pipeline/jobs/my-job/builds/42"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", expectedDecideURL),
+				ghttp.RespondWith(status, ""),
+			),
+		)
+	}
+
+	run := func(args ...string) *gexec.Session {
+		flyCmd := exec.Command(flyPath, append([]string{"-t", targetName}, args...)...)
+
+		sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		return sess
+	}
+
+	It("approves the build's approval", func() {
+		decide("/api/v1/builds/23/approvals/deploy/approve", http.StatusNoContent)
+
+		sess := run("approve-build", "-j", "my-pipeline/my-job", "-b", "42", "-a", "deploy")
+		Eventually(sess).Should(gexec.Exit(0))
+		Expect(sess.Out).To(gbytes.Say("approved"))
+	})
+
+	It("rejects the build's approval", func() {
+		decide("/api/v1/builds/23/approvals/deploy/reject", http.StatusNoContent)
+
+		sess := run("reject-build", "-j", "my-pipeline/my-job", "-b", "42", "-a", "deploy")
+		Eventually(sess).Should(gexec.Exit(0))
+		Expect(sess.Out).To(gbytes.Say("rejected"))
+	})
+
+	Context("when the build has no pending approval with the name", func() {
+		BeforeEach(func() {
+			decide("/api/v1/builds/23/approvals/deploy/approve", http.StatusNotFound)
+		})
+
+		It("returns a helpful error message", func() {
+			sess := run("approve-build", "-j", "my-pipeline/my-job", "-b", "42", "-a", "deploy")
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("error: build has no pending approval named 'deploy'"))
+		})
+	})
+
+	Context("when the user is not an approver", func() {
+		BeforeEach(func() {
+			decide("/api/v1/builds/23/approvals/deploy/approve", http.StatusForbidden)
+		})
+
+		It("returns a helpful error message", func() {
+			sess := run("approve-build", "-j", "my-pipeline/my-job", "-b", "42", "-a", "deploy")
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("error: you are not allowed to decide approval 'deploy'"))
+		})
+	})
+
+	Context("when the approval name is not specified", func() {
+		It("asks the user to specify it", func() {
+			sess := run("approve-build", "-b", "23")
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("a", "approval") + "' was not specified"))
+		})
+	})
+})
@@ -903,6 +903,152 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Context("when passing filters", func() {
+			BeforeEach(func() {
+				expectedURL = "/api/v1/builds"
+				queryParams = []string{
+					"limit=50",
+					"status=failed",
+					"status=errored",
+					"pipeline=some-*",
+					"job=unit-?",
+					"min_duration=1h0m0s",
+					"worker=some-worker",
+				}
+				returnedStatusCode = http.StatusOK
+				returnedBuilds = []atc.Build{
+					{
+						ID:           3,
+						PipelineName: "some-pipeline",
+						JobName:      "unit-1",
+						Name:         "63",
+						Status:       "errored",
+						StartTime:    erroredBuildStartTime.Unix(),
+						EndTime:      erroredBuildEndTime.Unix(),
+						TeamName:     "team1",
+					},
+				}
+
+				cmdArgs = append(cmdArgs,
+					"--status", "failed",
+					"--status", "errored",
+					"--pipeline-glob", "some-*",
+					"--job-glob", "unit-?",
+					"--min-duration", "1h",
+					"--worker", "some-worker",
+				)
+			})
+
+			It("passes them to the api", func() {
+				Eventually(session.Out).Should(PrintTable(ui.Table{
+					Headers: expectedHeaders,
+					Data: []ui.TableRow{
+						{
+							{Contents: "3"},
+							{Contents: "some-pipeline/unit-1/63"},
+							{Contents: "errored"},
+							{Contents: erroredBuildStartTime.Local().Format(timeDateLayout)},
+							{Contents: erroredBuildEndTime.Local().Format(timeDateLayout)},
+							{Contents: "2h45m0s"},
+							{Contents: "team1"},
+							{Contents: "system"},
+						},
+					},
+				}))
+				Eventually(session).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when filtering along with --job", func() {
+			BeforeEach(func() {
+				cmdArgs = append(cmdArgs, "--status", "failed", "-j", "some-pipeline/some-job")
+			})
+
+			It("errors", func() {
+				Eventually(session.Err).Should(gbytes.Say("Cannot filter by --status, --pipeline-glob, --job-glob, --min-duration or --worker along with --pipeline, --job, --team, --all-teams or --current-team"))
+				Eventually(session).Should(gexec.Exit(1))
+			})
+		})
+
+		Context("when sorting", func() {
+			BeforeEach(func() {
+				expectedURL = "/api/v1/builds"
+				returnedStatusCode = http.StatusOK
+				returnedBuilds = []atc.Build{
+					{
+						ID:        2,
+						Name:      "long",
+						Status:    "errored",
+						StartTime: erroredBuildStartTime.Unix(),
+						EndTime:   erroredBuildEndTime.Unix(),
+						TeamName:  "team1",
+					},
+					{
+						ID:        3,
+						Name:      "short",
+						Status:    "succeeded",
+						StartTime: succeededBuildStartTime.Unix(),
+						EndTime:   succeededBuildEndTime.Unix(),
+						TeamName:  "team1",
+					},
+				}
+			})
+
+			listedIDs := func() []string {
+				Eventually(session).Should(gexec.Exit(0))
+
+				var ids []string
+				for _, line := range strings.Split(strings.TrimSpace(string(session.Out.Contents())), "\n") {
+					ids = append(ids, strings.Fields(line)[0])
+				}
+				return ids
+			}
+
+			Context("by duration", func() {
+				BeforeEach(func() {
+					queryParams = []string{"limit=50", "sort_by=duration"}
+					cmdArgs = append(cmdArgs, "--sort-by", "duration")
+				})
+
+				It("asks the api to sort the builds and keeps its order", func() {
+					Expect(listedIDs()).To(Equal([]string{"2", "3"}))
+				})
+			})
+
+			Context("by name in reverse", func() {
+				BeforeEach(func() {
+					queryParams = []string{"limit=50", "sort_by=name", "reverse=true"}
+					cmdArgs = append(cmdArgs, "--sort-by", "name", "--reverse")
+				})
+
+				It("asks the api to sort the builds in reverse", func() {
+					Expect(listedIDs()).To(Equal([]string{"2", "3"}))
+				})
+			})
+
+			Context("by id in reverse", func() {
+				BeforeEach(func() {
+					queryParams = []string{"limit=50", "reverse=true"}
+					cmdArgs = append(cmdArgs, "--reverse")
+				})
+
+				It("asks the api for the oldest builds first", func() {
+					Expect(listedIDs()).To(Equal([]string{"2", "3"}))
+				})
+			})
+		})
+
+		Context("when sorting along with --job", func() {
+			BeforeEach(func() {
+				cmdArgs = append(cmdArgs, "--sort-by", "duration", "-j", "some-pipeline/some-job")
+			})
+
+			It("errors", func() {
+				Eventually(session.Err).Should(gbytes.Say("Cannot sort with --sort-by or --reverse along with --pipeline, --job, --team, --all-teams or --current-team"))
+				Eventually(session).Should(gexec.Exit(1))
+			})
+		})
+
 		Context("when passing the pipeline argument", func() {
 			BeforeEach(func() {
 				cmdArgs = append(cmdArgs, "-p")
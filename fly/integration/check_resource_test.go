@@ -2,6 +2,7 @@ package integration_test
 
 import (
 	"net/http"
+	"os"
 	"os/exec"
 
 	"github.com/concourse/concourse/atc"
@@ -127,6 +128,26 @@ var _ = Describe("CheckResource", func() {
 				return len(atcServer.ReceivedRequests())
 			}).By(3))
 		})
+
+		It("exits with the status of the check when watching", func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource", "-r", "mypipeline/branch:master/myresource", "--shallow", "--watch")
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess.Out).Should(gbytes.Say("checking mypipeline/branch:master/myresource in build 123"))
+
+			AssertErrorEvents(sess, streaming, events)
+		})
+	})
+
+	Context("when combining --watch with --async", func() {
+		It("fails with error", func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource", "-r", "mypipeline/myresource", "--watch", "--async")
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("--watch cannot be combined with --async"))
+		})
 	})
 
 	Context("when recursive check succeeds", func() {
@@ -311,4 +332,61 @@ var _ = Describe("CheckResource", func() {
 
 		})
 	})
+
+	Context("completion", func() {
+		complete := func(value string) *gexec.Session {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource", "-r", value)
+			flyCmd.Env = append(os.Environ(), "GO_FLAGS_COMPLETION=1")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			return sess
+		}
+
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines"),
+					ghttp.RespondWithJSONEncoded(200, []atc.Pipeline{
+						{Name: "mypipeline"},
+						{Name: "other-pipeline"},
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/mypipeline/resources"),
+					ghttp.RespondWithJSONEncoded(200, []atc.Resource{
+						{Name: "myresource"},
+						{Name: "my-other-resource"},
+						{Name: "another-resource"},
+					}),
+				),
+			)
+		})
+
+		It("returns all matching resources", func() {
+			sess := complete("mypipeline/my")
+			Expect(sess.Out).To(gbytes.Say("mypipeline/my-other-resource"))
+			Expect(sess.Out).To(gbytes.Say("mypipeline/myresource"))
+			Expect(sess.Out).NotTo(gbytes.Say("another-resource"))
+		})
+
+		It("reuses the names it fetched for a while", func() {
+			Expect(func() {
+				complete("mypipeline/my")
+			}).To(Change(func() int {
+				return len(atcServer.ReceivedRequests())
+			}).By(3))
+
+			atcServer.AppendHandlers(infoHandler())
+
+			Expect(func() {
+				sess := complete("mypipeline/an")
+				Expect(sess.Out).To(gbytes.Say("mypipeline/another-resource"))
+			}).To(Change(func() int {
+				return len(atcServer.ReceivedRequests())
+			}).By(1))
+		})
+	})
 })
@@ -1,7 +1,9 @@
 package integration_test
 
 import (
+	"encoding/json"
 	"os/exec"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/ui"
@@ -152,6 +154,181 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Context("when filtering and sorting", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/containers"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Container{
+							{ID: "handle-a", WorkerName: "worker-1", PipelineName: "pipeline-1", Type: "check", ResourceName: "repo", CreatedAt: 300, SizeBytes: 20},
+							{ID: "handle-b", WorkerName: "worker-2", PipelineName: "pipeline-1", Type: "task", StepName: "unit", CreatedAt: 100},
+							{ID: "handle-c", WorkerName: "worker-1", PipelineName: "pipeline-2", PipelineInstanceVars: atc.InstanceVars{"branch": "feature"}, Type: "task", StepName: "unit", CreatedAt: 200, SizeBytes: 30},
+						}),
+					),
+				)
+			})
+
+			handles := func(args ...string) []string {
+				flyCmd.Args = append(flyCmd.Args, args...)
+				flyCmd.Args = append(flyCmd.Args, "--json")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				var containers []atc.Container
+				err = json.Unmarshal(sess.Out.Contents(), &containers)
+				Expect(err).NotTo(HaveOccurred())
+
+				ids := []string{}
+				for _, c := range containers {
+					ids = append(ids, c.ID)
+				}
+
+				return ids
+			}
+
+			It("filters by worker", func() {
+				Expect(handles("--worker", "worker-1")).To(Equal([]string{"handle-a", "handle-c"}))
+			})
+
+			It("filters by type", func() {
+				Expect(handles("--type", "task")).To(Equal([]string{"handle-b", "handle-c"}))
+			})
+
+			It("filters by pipeline", func() {
+				Expect(handles("--pipeline", "pipeline-1")).To(Equal([]string{"handle-a", "handle-b"}))
+			})
+
+			It("filters by pipeline instance", func() {
+				Expect(handles("--pipeline", "pipeline-2/branch:feature")).To(Equal([]string{"handle-c"}))
+			})
+
+			It("does not match other instances of the pipeline", func() {
+				Expect(handles("--pipeline", "pipeline-2/branch:master")).To(BeEmpty())
+			})
+
+			It("sorts by age, oldest first", func() {
+				Expect(handles("--sort-by", "age")).To(Equal([]string{"handle-b", "handle-c", "handle-a"}))
+			})
+
+			It("sorts by worker", func() {
+				Expect(handles("--sort-by", "worker", "--reverse")).To(Equal([]string{"handle-b", "handle-c", "handle-a"}))
+			})
+
+			It("sorts by size, largest first", func() {
+				Expect(handles("--sort-by", "size")).To(Equal([]string{"handle-c", "handle-a", "handle-b"}))
+			})
+		})
+
+		Context("when --team is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--team", "other-team")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/other-team"),
+						ghttp.RespondWithJSONEncoded(200, atc.Team{Name: "other-team"}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/other-team/containers"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Container{
+							{ID: "other-handle", WorkerName: "worker-1", Type: "check"},
+						}),
+					),
+				)
+			})
+
+			It("lists the team's containers", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("other-handle"))
+			})
+		})
+
+		Context("when --details is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--details", "handle-b")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/containers/handle-b"),
+						ghttp.RespondWithJSONEncoded(200, atc.Container{
+							ID:           "handle-b",
+							WorkerName:   "worker-2",
+							State:        atc.ContainerStateCreated,
+							PipelineName: "pipeline-1",
+							JobName:      "job-1",
+							BuildName:    "7",
+							BuildID:      77,
+							Type:         "task",
+							StepName:     "unit",
+							Attempt:      "1",
+							CreatedAt:    time.Now().Add(-time.Hour).Unix(),
+							SizeBytes:    3 * 1024 * 1024,
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/volumes"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Volume{
+							{
+								ID:              "cow-volume",
+								WorkerName:      "worker-2",
+								Type:            "container",
+								ContainerHandle: "handle-b",
+								Path:            "/tmp/build/get/repo",
+								ParentHandle:    "cache-volume",
+							},
+							{
+								ID:         "cache-volume",
+								WorkerName: "worker-2",
+								Type:       "resource",
+								ResourceType: &atc.VolumeResourceType{
+									BaseResourceType: &atc.VolumeBaseResourceType{Name: "git"},
+									Version:          atc.Version{"ref": "abc"},
+								},
+							},
+							{
+								ID:              "scratch-volume",
+								WorkerName:      "worker-2",
+								Type:            "container",
+								ContainerHandle: "handle-b",
+								Path:            "/scratch",
+								SizeBytes:       2048,
+							},
+							{
+								ID:              "unrelated-volume",
+								WorkerName:      "worker-2",
+								Type:            "container",
+								ContainerHandle: "handle-a",
+								Path:            "/scratch",
+							},
+						}),
+					),
+				)
+			})
+
+			It("shows the owning step, build link, and the caches its volumes were copied from", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`handle\s+handle-b`))
+				Expect(sess.Out).To(gbytes.Say(`worker\s+worker-2`))
+				Expect(sess.Out).To(gbytes.Say(`age\s+1h0m`))
+				Expect(sess.Out).To(gbytes.Say(`size\s+3.0MiB`))
+				Expect(sess.Out).To(gbytes.Say(`step\s+unit`))
+				Expect(sess.Out).To(gbytes.Say(`pipeline\s+pipeline-1`))
+				Expect(sess.Out).To(gbytes.Say(`job\s+job-1`))
+				Expect(sess.Out).To(gbytes.Say(`build\s+` + atcServer.URL() + `/builds/77`))
+				Expect(sess.Out).To(gbytes.Say(`scratch-volume\s+/scratch\s+none\s+none\s+none\s+2.0KiB`))
+				Expect(sess.Out).To(gbytes.Say(`cow-volume\s+/tmp/build/get/repo\s+cache-volume\s+resource\s+ref:abc\s+unknown`))
+				Expect(sess.Out.Contents()).NotTo(ContainSubstring("unrelated-volume"))
+			})
+		})
+
 		Context("and the api returns an internal server error", func() {
 			BeforeEach(func() {
 				atcServer.AppendHandlers(
@@ -0,0 +1,221 @@
+package integration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("cp", func() {
+		var (
+			upgrader websocket.Upgrader
+			tmpDir   string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "fly-cp")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpDir)
+		})
+
+		containersHandler := ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/teams/main/containers", "build_id=42&step_name=unit"),
+			ghttp.RespondWithJSONEncoded(200, []atc.Container{
+				{ID: "container-id-1", State: atc.ContainerStateCreated, BuildID: 42, Type: "task", StepName: "unit", User: "root", WorkingDirectory: "/tmp/build/guid"},
+			}),
+		)
+
+		exitWith := func(conn *websocket.Conn, status int) {
+			err := conn.WriteJSON(atc.HijackOutput{ExitStatus: &status})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Context("when copying from a container", func() {
+			var exitStatus int
+
+			BeforeEach(func() {
+				exitStatus = 0
+
+				atcServer.AppendHandlers(
+					containersHandler,
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/containers/container-id-1/hijack"),
+						func(w http.ResponseWriter, r *http.Request) {
+							defer GinkgoRecover()
+
+							conn, err := upgrader.Upgrade(w, r, nil)
+							Expect(err).NotTo(HaveOccurred())
+
+							defer conn.Close()
+
+							var spec atc.HijackProcessSpec
+							err = conn.ReadJSON(&spec)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(spec.Path).To(Equal("tar"))
+							Expect(spec.Args).To(Equal([]string{"-cf", "-", "-C", "reports", "junit.xml"}))
+							Expect(spec.User).To(Equal("root"))
+							Expect(spec.Dir).To(Equal("/tmp/build/guid"))
+
+							buf := new(bytes.Buffer)
+							tw := tar.NewWriter(buf)
+							err = tw.WriteHeader(&tar.Header{Name: "junit.xml", Mode: 0644, Size: 7})
+							Expect(err).NotTo(HaveOccurred())
+							_, err = tw.Write([]byte("<tests>"))
+							Expect(err).NotTo(HaveOccurred())
+							Expect(tw.Close()).To(Succeed())
+
+							err = conn.WriteJSON(atc.HijackOutput{Stdout: buf.Bytes()})
+							Expect(err).NotTo(HaveOccurred())
+
+							exitWith(conn, exitStatus)
+						},
+					),
+				)
+			})
+
+			It("extracts the remote path into a local directory", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "cp", "42/unit:reports/junit.xml", tmpDir)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(ioutil.ReadFile(filepath.Join(tmpDir, "junit.xml"))).To(Equal([]byte("<tests>")))
+			})
+
+			It("renames the copy when the destination is not a directory", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "cp", "42/unit:reports/junit.xml", filepath.Join(tmpDir, "report.xml"))
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(ioutil.ReadFile(filepath.Join(tmpDir, "report.xml"))).To(Equal([]byte("<tests>")))
+
+				entries, err := ioutil.ReadDir(tmpDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(1))
+			})
+
+			Context("when the remote tar fails", func() {
+				BeforeEach(func() {
+					exitStatus = 2
+				})
+
+				It("errors", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "cp", "42/unit:reports/junit.xml", tmpDir)
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(1))
+					Expect(sess.Err).To(gbytes.Say("copying from container failed with exit status 2"))
+				})
+			})
+		})
+
+		Context("when copying to a container", func() {
+			var uploaded chan []byte
+
+			BeforeEach(func() {
+				uploaded = make(chan []byte, 1)
+
+				err := ioutil.WriteFile(filepath.Join(tmpDir, "debug.sh"), []byte("echo hi"), 0755)
+				Expect(err).NotTo(HaveOccurred())
+
+				atcServer.AppendHandlers(
+					containersHandler,
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/containers/container-id-1/hijack"),
+						func(w http.ResponseWriter, r *http.Request) {
+							defer GinkgoRecover()
+
+							conn, err := upgrader.Upgrade(w, r, nil)
+							Expect(err).NotTo(HaveOccurred())
+
+							defer conn.Close()
+
+							var spec atc.HijackProcessSpec
+							err = conn.ReadJSON(&spec)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(spec.Path).To(Equal("sh"))
+							Expect(spec.Args).To(HaveLen(5))
+							Expect(spec.Args[0]).To(Equal("-c"))
+							Expect(spec.Args[2:]).To(Equal([]string{"sh", "/tmp/", "debug.sh"}))
+
+							stdin := new(bytes.Buffer)
+							for {
+								var input atc.HijackInput
+								err = conn.ReadJSON(&input)
+								Expect(err).NotTo(HaveOccurred())
+
+								if input.Closed {
+									break
+								}
+
+								stdin.Write(input.Stdin)
+							}
+
+							uploaded <- stdin.Bytes()
+
+							exitWith(conn, 0)
+						},
+					),
+				)
+			})
+
+			It("streams a tarball of the local path to the container", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "cp", filepath.Join(tmpDir, "debug.sh"), "42/unit:/tmp/")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				var archive []byte
+				Eventually(uploaded).Should(Receive(&archive))
+
+				tr := tar.NewReader(bytes.NewReader(archive))
+				hdr, err := tr.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hdr.Name).To(Equal("debug.sh"))
+				Expect(ioutil.ReadAll(tr)).To(Equal([]byte("echo hi")))
+
+				_, err = tr.Next()
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+
+		Context("when neither path is in a container", func() {
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "cp", "a", "b")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("exactly one of SOURCE and DESTINATION must be of the form BUILD/STEP:PATH"))
+			})
+		})
+	})
+})
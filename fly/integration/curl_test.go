@@ -1,11 +1,14 @@
 package integration_test
 
 import (
+	"net/http"
 	"os/exec"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
 )
 
 var _ = Describe("Fly CLI", func() {
@@ -41,5 +44,69 @@ var _ = Describe("Fly CLI", func() {
 				Expect(string(sess.Out.Contents())).To(ContainSubstring("-X PUT"))
 			})
 		})
+
+		Context("when paginating", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds", "limit=2"),
+						func(w http.ResponseWriter, r *http.Request) {
+							Expect(r.Header.Get("Authorization")).To(HavePrefix("Bearer "))
+						},
+						ghttp.RespondWith(http.StatusOK, `[{"id":4},{"id":3}]`, http.Header{
+							"Link": {`</api/v1/builds?to=2&limit=2>; rel="next"`},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds", "to=2&limit=2"),
+						ghttp.RespondWith(http.StatusOK, `[{"id":2}]`),
+					),
+				)
+			})
+
+			It("prints every page as one pretty-printed array", func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "curl", "--paginate", "--pretty", "/api/v1/builds?limit=2")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(string(sess.Out.Contents())).To(Equal("[\n  {\n    \"id\": 4\n  },\n  {\n    \"id\": 3\n  },\n  {\n    \"id\": 2\n  }\n]\n"))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/bogus"),
+						ghttp.RespondWith(http.StatusNotFound, "not found"),
+					),
+				)
+			})
+
+			It("prints the response and errors", func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "curl", "--pretty", "/api/v1/bogus")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("not found"))
+				Expect(sess.Err).To(gbytes.Say("unexpected response code: 404 Not Found"))
+			})
+		})
+
+		Context("when combining --pretty with curl flags", func() {
+			It("errors", func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "curl", "--pretty", "some-path", "--", "-X", "PUT")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("cannot be combined with curl flags"))
+			})
+		})
 	})
 })
@@ -0,0 +1,189 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/rc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("doctor", func() {
+		var (
+			userInfoHandler http.HandlerFunc
+			workers         []atc.Worker
+			tokenExpiry     time.Time
+		)
+
+		BeforeEach(func() {
+			tokenExpiry = time.Now().Add(7 * 24 * time.Hour)
+			userInfoHandler = ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/user"),
+				ghttp.RespondWithJSONEncoded(200, atc.UserInfo{
+					UserName: "user",
+					Teams:    map[string][]string{"main": {"owner"}},
+				}),
+			)
+
+			workers = []atc.Worker{
+				{Name: "worker-1", State: "running", Platform: "linux", Version: workerVersion},
+				{Name: "worker-2", State: "running", Platform: "linux", Version: workerVersion},
+			}
+		})
+
+		JustBeforeEach(func() {
+			createFlyRc(rc.Targets{
+				targetName: {
+					API:      atcServer.URL(),
+					TeamName: "main",
+					Token:    &rc.TargetToken{Type: "Bearer", Value: validAccessToken(tokenExpiry)},
+				},
+			})
+		})
+
+		run := func() *gexec.Session {
+			atcServer.AppendHandlers(
+				userInfoHandler,
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/workers"),
+					ghttp.RespondWithJSONEncoded(200, workers),
+				),
+				infoHandler(),
+			)
+
+			flyCmd := exec.Command(flyPath, "-t", targetName, "doctor")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			<-sess.Exited
+			return sess
+		}
+
+		Context("when everything is healthy", func() {
+			It("reports each check as ok", func() {
+				sess := run()
+				Expect(sess.ExitCode()).To(Equal(0))
+
+				Expect(sess.Out).To(gbytes.Say(`ok\s+api: reachable, running concourse ` + atcVersion))
+				Expect(sess.Out).To(gbytes.Say(`ok\s+fly version: `))
+				Expect(sess.Out).To(gbytes.Say(`ok\s+token: valid for 'user' on team 'main'`))
+				Expect(sess.Out).To(gbytes.Say(`ok\s+workers: 2 of 2 running`))
+			})
+		})
+
+		Context("when workers are stalled or outdated", func() {
+			BeforeEach(func() {
+				workers = []atc.Worker{
+					{Name: "worker-1", State: "running", Platform: "linux", Version: workerVersion},
+					{Name: "worker-2", State: "stalled", Platform: "linux", Version: workerVersion},
+					{Name: "worker-3", State: "running", Platform: "linux", Version: "3.0.0"},
+				}
+			})
+
+			It("warns about them", func() {
+				sess := run()
+				Expect(sess.ExitCode()).To(Equal(0))
+
+				Expect(sess.Out).To(gbytes.Say(`ok\s+workers: 2 of 3 running`))
+				Expect(sess.Out).To(gbytes.Say(`warn\s+workers: stalled: worker-2; restart them or remove them with 'fly prune-worker'`))
+				Expect(sess.Out).To(gbytes.Say(`warn\s+workers: outdated: worker-3; upgrade them to match the web nodes`))
+			})
+		})
+
+		Context("when no linux workers are running", func() {
+			BeforeEach(func() {
+				workers = []atc.Worker{
+					{Name: "worker-1", State: "running", Platform: "windows", Version: workerVersion},
+				}
+			})
+
+			It("warns about it", func() {
+				sess := run()
+				Expect(sess.ExitCode()).To(Equal(0))
+
+				Expect(sess.Out).To(gbytes.Say(`warn\s+workers: no linux workers are running`))
+			})
+		})
+
+		Context("when there are no workers", func() {
+			BeforeEach(func() {
+				workers = []atc.Worker{}
+			})
+
+			It("fails", func() {
+				sess := run()
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Out).To(gbytes.Say(`FAIL\s+workers: no workers are registered, so no builds can run`))
+				Expect(sess.Err).To(gbytes.Say(`1 checks failed`))
+			})
+		})
+
+		Context("when the token expires soon", func() {
+			BeforeEach(func() {
+				tokenExpiry = time.Now().Add(3 * time.Hour)
+			})
+
+			It("warns about it", func() {
+				sess := run()
+				Expect(sess.ExitCode()).To(Equal(0))
+
+				Expect(sess.Out).To(gbytes.Say(`warn\s+token: expires in 2h59m\d+s; run 'fly -t ` + targetName + ` login' to renew it`))
+			})
+		})
+
+		Context("when the token has expired", func() {
+			BeforeEach(func() {
+				tokenExpiry = time.Now().Add(-time.Hour)
+			})
+
+			It("fails without asking the api", func() {
+				sess := run()
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Out).To(gbytes.Say(`FAIL\s+token: expired at .*; run 'fly -t ` + targetName + ` login'`))
+			})
+		})
+
+		Context("when the token is rejected", func() {
+			BeforeEach(func() {
+				userInfoHandler = ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/user"),
+					ghttp.RespondWith(http.StatusUnauthorized, nil),
+				)
+			})
+
+			It("fails and suggests logging in again, without checking the workers", func() {
+				sess := run()
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Out).To(gbytes.Say(`FAIL\s+token: rejected by the api \(.*\); run 'fly -t ` + targetName + ` login'`))
+				Expect(sess.Out).NotTo(gbytes.Say(`workers:`))
+			})
+		})
+
+		Context("when the api cannot be reached", func() {
+			It("fails", func() {
+				atcServer.Close()
+
+				flyCmd := exec.Command(flyPath, "-t", targetName, "doctor")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Out).To(gbytes.Say(`FAIL\s+api: could not reach http://127.0.0.1:\d+: `))
+			})
+		})
+	})
+})
@@ -0,0 +1,97 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("drain-worker", func() {
+		var (
+			landingWorker atc.Worker
+			listBuilds    http.HandlerFunc
+		)
+
+		BeforeEach(func() {
+			landingWorker = atc.Worker{
+				Name:             "some-worker",
+				State:            "landing",
+				ActiveContainers: 3,
+				ActiveVolumes:    5,
+			}
+
+			listBuilds = ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/builds", "limit=100&status=pending&status=started&worker=some-worker"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Build{{ID: 1}, {ID: 2}}),
+			)
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/land"),
+					ghttp.RespondWith(http.StatusOK, nil),
+				),
+			)
+		})
+
+		Context("when the worker lands", func() {
+			BeforeEach(func() {
+				landedWorker := landingWorker
+				landedWorker.State = "landed"
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/workers"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Worker{landedWorker}),
+					),
+				)
+			})
+
+			It("reports the worker as drained", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "drain-worker", "-w", "some-worker")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("landing 'some-worker'"))
+				Expect(sess.Out).To(gbytes.Say("drained 'some-worker'"))
+			})
+		})
+
+		Context("when the worker doesn't land before the deadline", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/workers"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Worker{landingWorker}),
+					),
+					listBuilds,
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/workers"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Worker{landingWorker}),
+					),
+					listBuilds,
+				)
+			})
+
+			It("reports its progress and gives up", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "drain-worker", "-w", "some-worker", "--deadline", "1s")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess, 5).Should(gexec.Exit(1))
+				Expect(sess.Out).To(gbytes.Say("landing: 2 running builds, 3 containers, 5 volumes"))
+				Expect(sess.Out).NotTo(gbytes.Say("landing: 2 running builds"))
+				Expect(sess.Err).To(gbytes.Say("worker 'some-worker' did not drain within 1s; it is still landing"))
+			})
+		})
+	})
+})
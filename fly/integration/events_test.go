@@ -0,0 +1,90 @@
+package integration_test
+
+import (
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("events", func() {
+		var (
+			streaming chan struct{}
+			events    chan atc.Event
+		)
+
+		BeforeEach(func() {
+			streaming = make(chan struct{})
+			events = make(chan atc.Event, 3)
+			events <- event.Log{Payload: "sup"}
+			events <- event.FinishGet{Origin: event.Origin{ID: "some-get"}, ExitStatus: 0}
+			events <- event.Status{Status: atc.StatusSucceeded}
+			close(events)
+
+			atcServer.AppendHandlers(
+				BuildEventsHandler(3, streaming, events),
+			)
+		})
+
+		It("prints each event with its ID and type", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "events", "-b", "3")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say(`0 log {.*"payload":"sup".*}`))
+			Expect(sess.Out).To(gbytes.Say(`1 finish-get {.*"id":"some-get".*}`))
+			Expect(sess.Out).To(gbytes.Say(`2 status {.*"status":"succeeded".*}`))
+		})
+
+		Context("with --json", func() {
+			It("prints each event as a JSON object", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "events", "-b", "3", "--json")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say(`{"data":{.*"payload":"sup".*},"event":"log","version":"[0-9.]+","event_id":"0"}\n`))
+				Expect(sess.Out).To(gbytes.Say(`"event":"finish-get"`))
+				Expect(sess.Out).To(gbytes.Say(`"event":"status"`))
+			})
+		})
+
+		Context("with --since-id", func() {
+			It("asks the ATC to resume the stream after the ID", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "events", "-b", "3", "--since-id", "1")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				requests := atcServer.ReceivedRequests()
+				Expect(requests[len(requests)-1].Header.Get("Last-Event-ID")).To(Equal("1"))
+			})
+		})
+
+		Context("without --since-id", func() {
+			It("streams the events from the beginning", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "events", "-b", "3")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				requests := atcServer.ReceivedRequests()
+				Expect(requests[len(requests)-1].Header.Get("Last-Event-ID")).To(BeEmpty())
+			})
+		})
+	})
+})
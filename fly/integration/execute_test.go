@@ -690,6 +690,73 @@ run:
 		})
 	})
 
+	Context("when an input is a git uri", func() {
+		itFetchesTheInput := func(uri string) {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "e", "-c", taskConfigPath, "-i", "fixture="+uri)
+			flyCmd.Dir = buildDir
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(streaming).Should(BeClosed())
+
+			close(events)
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+
+			Expect(uploadedBits).To(HaveLen(0))
+		}
+
+		Context("when the ref is a branch", func() {
+			BeforeEach(func() {
+				source := atc.Source{
+					"uri":    "https://example.com/some/repo.git",
+					"branch": "some-branch",
+				}
+
+				checkPlan := planFactory.NewPlan(atc.CheckPlan{
+					Name:   "fixture",
+					Type:   "git",
+					Source: source,
+				})
+
+				(*expectedPlan.Do)[0].InParallel.Steps = []atc.Plan{
+					planFactory.NewPlan(atc.DoPlan{
+						checkPlan,
+						planFactory.NewPlan(atc.GetPlan{
+							Name:        "fixture",
+							Type:        "git",
+							Source:      source,
+							VersionFrom: &checkPlan.ID,
+						}),
+					}),
+				}
+			})
+
+			It("checks the branch and fetches its latest commit instead of uploading", func() {
+				itFetchesTheInput("git+https://example.com/some/repo.git#some-branch")
+			})
+		})
+
+		Context("when the ref is a commit", func() {
+			BeforeEach(func() {
+				(*expectedPlan.Do)[0].InParallel.Steps = []atc.Plan{
+					planFactory.NewPlan(atc.GetPlan{
+						Name:    "fixture",
+						Type:    "git",
+						Source:  atc.Source{"uri": "https://example.com/some/repo.git"},
+						Version: &atc.Version{"ref": "0123456789abcdef0123456789abcdef01234567"},
+					}),
+				}
+			})
+
+			It("fetches the commit instead of uploading", func() {
+				itFetchesTheInput("git+https://example.com/some/repo.git#0123456789abcdef0123456789abcdef01234567")
+			})
+		})
+	})
+
 	Context("when arguments are passed through", func() {
 		BeforeEach(func() {
 			(*expectedPlan.Do)[1].Task.Config.Run.Args = []string{".", "-name", `foo "bar" baz`}
@@ -1031,6 +1098,190 @@ run:
 		})
 	})
 
+	Context("when an image is provided locally", func() {
+		var imageTarPath string
+		var uploadedImage chan map[string]string
+
+		writeTarEntries := func(tw *tar.Writer, entries [][2]string) {
+			for _, entry := range entries {
+				name, contents := entry[0], entry[1]
+
+				hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg}
+				if strings.HasSuffix(name, "/") {
+					hdr = &tar.Header{Name: name, Mode: 0755, Typeflag: tar.TypeDir}
+				}
+
+				Expect(tw.WriteHeader(hdr)).To(Succeed())
+
+				_, err := tw.Write([]byte(contents))
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+
+		layerTar := func(entries ...[2]string) string {
+			buf := new(strings.Builder)
+			tw := tar.NewWriter(buf)
+			writeTarEntries(tw, entries)
+			Expect(tw.Close()).To(Succeed())
+			return buf.String()
+		}
+
+		BeforeEach(func() {
+			uploadedImage = make(chan map[string]string, 1)
+
+			imageTarPath = filepath.Join(tmpdir, "image.tar")
+
+			imageTar, err := os.Create(imageTarPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			tw := tar.NewWriter(imageTar)
+			writeTarEntries(tw, [][2]string{
+				{"manifest.json", `[{"Config":"config.json","Layers":["base/layer.tar","top/layer.tar"]}]`},
+				{"config.json", `{"config":{"Env":["PATH=/bin"],"User":"someone"}}`},
+				{"base/layer.tar", layerTar(
+					[2]string{"etc/", ""},
+					[2]string{"etc/os-release", "base"},
+					[2]string{"bin/", ""},
+					[2]string{"bin/old", "old"},
+				)},
+				{"top/layer.tar", layerTar(
+					[2]string{"etc/os-release", "top"},
+					[2]string{"bin/.wh.old", ""},
+				)},
+			})
+			Expect(tw.Close()).To(Succeed())
+			Expect(imageTar.Close()).To(Succeed())
+
+			(*expectedPlan.Do)[0].InParallel.Steps = append(
+				(*expectedPlan.Do)[0].InParallel.Steps,
+				planFactory.NewPlan(atc.ArtifactInputPlan{
+					ArtifactID: 126,
+					Name:       "one-off-image",
+				}),
+			)
+
+			(*expectedPlan.Do)[1].Task.Config.ImageResource = nil
+			(*expectedPlan.Do)[1].Task.ImageArtifactName = "one-off-image"
+		})
+
+		JustBeforeEach(func() {
+			atcServer.RouteToHandler("POST", "/api/v1/teams/main/artifacts",
+				func(w http.ResponseWriter, req *http.Request) {
+					gr, err := gzip.NewReader(req.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					tr := tar.NewReader(gr)
+
+					hdr, err := tr.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					if hdr.Name != "metadata.json" {
+						uploadedBits <- struct{}{}
+						w.WriteHeader(201)
+						w.Write([]byte(`{"id":125}`))
+						return
+					}
+
+					files := map[string]string{}
+					for ; err == nil; hdr, err = tr.Next() {
+						contents, err := ioutil.ReadAll(tr)
+						Expect(err).NotTo(HaveOccurred())
+
+						files[hdr.Name] = string(contents)
+					}
+
+					uploadedImage <- files
+
+					w.WriteHeader(201)
+					w.Write([]byte(`{"id":126}`))
+				},
+			)
+		})
+
+		itUsesTheImage := func(args ...string) {
+			flyCmd := exec.Command(flyPath, append([]string{"-t", targetName, "e", "-c", taskConfigPath}, args...)...)
+			flyCmd.Dir = buildDir
+			flyCmd.Env = append(os.Environ(), "PATH="+filepath.Join(tmpdir, "bin")+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(streaming).Should(BeClosed())
+
+			close(events)
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+
+			Expect(uploadedBits).To(HaveLen(1))
+
+			var files map[string]string
+			Expect(uploadedImage).To(Receive(&files))
+			Expect(files).To(Equal(map[string]string{
+				"metadata.json":         `{"env":["PATH=/bin"],"user":"someone"}`,
+				"rootfs/":               "",
+				"rootfs/etc/":           "",
+				"rootfs/etc/os-release": "top",
+				"rootfs/bin/":           "",
+			}))
+		}
+
+		Context("with --image-tar", func() {
+			It("uploads the flattened image and uses it as the task's image", func() {
+				itUsesTheImage("--image-tar", imageTarPath)
+			})
+		})
+
+		Context("with --image-from-docker", func() {
+			BeforeEach(func() {
+				err := os.Mkdir(filepath.Join(tmpdir, "bin"), 0755)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ioutil.WriteFile(filepath.Join(tmpdir, "bin", "docker"), []byte(`#!/bin/sh
+if [ "$1 $2 $4" != "save -o some-image" ]; then
+  echo "no such image: $4" >&2
+  exit 1
+fi
+cp `+imageTarPath+` "$3"
+`), 0755)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("saves the image from docker, uploads it and uses it as the task's image", func() {
+				itUsesTheImage("--image-from-docker", "some-image")
+			})
+
+			Context("when docker fails to save the image", func() {
+				It("prints an error", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "e", "-c", taskConfigPath, "--image-from-docker", "bogus-image")
+					flyCmd.Dir = buildDir
+					flyCmd.Env = append(os.Environ(), "PATH="+filepath.Join(tmpdir, "bin")+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(1))
+					Expect(sess.Err).To(gbytes.Say("failed to save image 'bogus-image' from docker: exit status 1: no such image: bogus-image"))
+				})
+			})
+		})
+
+		Context("when more than one image is specified", func() {
+			It("prints an error", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "e", "-c", taskConfigPath, "--image-tar", imageTarPath, "--image-from-docker", "some-image")
+				flyCmd.Dir = buildDir
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+				Expect(sess.Err).To(gbytes.Say("only one of --image, --image-from-docker and --image-tar may be specified"))
+			})
+		})
+	})
+
 	Context("when running with bogus flags", func() {
 		It("exits 1", func() {
 			flyCmd := exec.Command(flyPath, "-t", targetName, "e", "-c", taskConfigPath, "--bogus-flag")
@@ -0,0 +1,123 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("export-team", func() {
+		var (
+			exportDir string
+
+			config         atc.Config
+			instanceConfig atc.Config
+			instanceRef    atc.PipelineRef
+		)
+
+		BeforeEach(func() {
+			var err error
+			exportDir, err = ioutil.TempDir("", "fly-export-team")
+			Expect(err).NotTo(HaveOccurred())
+
+			config = atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-job"}},
+			}
+
+			instanceConfig = atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-instanced-job"}},
+			}
+
+			instanceRef = atc.PipelineRef{
+				Name:         "branches",
+				InstanceVars: atc.InstanceVars{"branch": "feature"},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines"),
+					ghttp.RespondWithJSONEncoded(200, []atc.Pipeline{
+						{Name: "some-pipeline", Paused: true, Public: true},
+						{Name: "archived-pipeline", Archived: true},
+						{Name: "branches", InstanceVars: instanceRef.InstanceVars},
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+					ghttp.RespondWithJSONEncoded(200, atc.ConfigResponse{Config: config}, http.Header{atc.ConfigVersionHeader: {"1"}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/resources"),
+					ghttp.RespondWithJSONEncoded(200, []atc.Resource{
+						{Name: "unpinned"},
+						{Name: "pinned", PinnedVersion: atc.Version{"ref": "abc"}, PinComment: "hold it"},
+						{Name: "pinned-in-config", PinnedVersion: atc.Version{"ref": "def"}, PinnedInConfig: true},
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/branches/config", instanceRef.QueryParams().Encode()),
+					ghttp.RespondWithJSONEncoded(200, atc.ConfigResponse{Config: instanceConfig}, http.Header{atc.ConfigVersionHeader: {"2"}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/branches/resources", instanceRef.QueryParams().Encode()),
+					ghttp.RespondWithJSONEncoded(200, []atc.Resource{}),
+				),
+			)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(exportDir)
+		})
+
+		It("writes each pipeline's config and an index of their order and state", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "export-team", "-d", exportDir)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("exported 2 pipelines of team 'main'"))
+
+			var exportedConfig atc.Config
+			payload, err := ioutil.ReadFile(filepath.Join(exportDir, "pipelines", "some-pipeline.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(yaml.Unmarshal(payload, &exportedConfig)).To(Succeed())
+			Expect(exportedConfig).To(Equal(config))
+
+			payload, err = ioutil.ReadFile(filepath.Join(exportDir, "pipelines", "branches", "1.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(yaml.Unmarshal(payload, &exportedConfig)).To(Succeed())
+			Expect(exportedConfig).To(Equal(instanceConfig))
+
+			index, err := ioutil.ReadFile(filepath.Join(exportDir, "pipelines.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(index).To(MatchYAML(`
+team: main
+pipelines:
+- name: some-pipeline
+  config: pipelines/some-pipeline.yml
+  paused: true
+  public: true
+  pins:
+  - resource: pinned
+    version: {ref: abc}
+    comment: hold it
+- name: branches
+  instance_vars: {branch: feature}
+  config: pipelines/branches/1.yml
+`))
+		})
+	})
+})
@@ -83,6 +83,114 @@ var _ = Describe("Fly CLI", func() {
 			Expect(newYaml).To(Equal(inputYaml))
 		})
 
+		Context("when the config uses anchors and aliases", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(configFile.Name(), []byte(`
+common: &common
+  type: git
+  source: {uri: https://example.com/repo.git}
+resources:
+- <<: *common
+  name: repo
+- name: other-repo
+  <<: *common
+jobs:
+- name: some-job
+  plan:
+  - get: repo
+    params: &params {depth: 1}
+  - get: other-repo
+    params: *params
+`), 0644)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("expands them", func() {
+				flyCmd := exec.Command(flyPath, "format-pipeline", "-c", configFile.Name())
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+
+				Expect(sess.Out.Contents()).To(MatchYAML(`
+resources:
+- name: repo
+  type: git
+  source: {uri: https://example.com/repo.git}
+- name: other-repo
+  type: git
+  source: {uri: https://example.com/repo.git}
+jobs:
+- name: some-job
+  plan:
+  - get: repo
+    params: {depth: 1}
+  - get: other-repo
+    params: {depth: 1}
+`))
+			})
+		})
+
+		Context("when given the --strip-defaults option", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(configFile.Name(), []byte(`
+resources:
+- name: repo
+  type: git
+  source: {}
+jobs:
+- name: some-job
+  serial: true
+  max_in_flight: 3
+  plan:
+  - get: repo
+    resource: repo
+    version: latest
+  - get: other
+    resource: repo
+    version: every
+  - put: repo
+    inputs: all
+  ensure:
+    put: repo
+    resource: repo
+    inputs: detect
+`), 0644)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("removes fields set to their default", func() {
+				flyCmd := exec.Command(flyPath, "format-pipeline", "-c", configFile.Name(), "--strip-defaults")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+
+				Expect(sess.Out.Contents()).To(MatchYAML(`
+resources:
+- name: repo
+  type: git
+  source: {}
+jobs:
+- name: some-job
+  serial: true
+  plan:
+  - get: repo
+  - get: other
+    resource: repo
+    version: every
+  - put: repo
+  ensure:
+    put: repo
+    inputs: detect
+`))
+			})
+		})
+
 		Context("when given the -w option", func() {
 			It("overwrites the file in-place", func() {
 				flyCmd := exec.Command(
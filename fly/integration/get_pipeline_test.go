@@ -226,6 +226,110 @@ var _ = Describe("Fly CLI", func() {
 						})
 					})
 				})
+				Context("when --annotate-vars is given", func() {
+					var varSourcesResponse http.HandlerFunc
+
+					BeforeEach(func() {
+						varSourcesResponse = ghttp.RespondWithJSONEncoded(200, []atc.VarSourceStatus{
+							{
+								Name:      "some-dummy",
+								Type:      "dummy",
+								Reachable: true,
+								Vars: []atc.VarLookupStatus{
+									{Name: "some-dummy:missing", Found: false},
+									{Name: "some-dummy:some-secret", Found: true},
+								},
+							},
+						})
+
+						config.VarSources = atc.VarSourceConfigs{
+							{
+								Name: "some-dummy",
+								Type: "dummy",
+								Config: map[string]interface{}{
+									"vars": map[string]interface{}{"some-secret": "shh"},
+								},
+							},
+						}
+						config.Resources[0].Source = atc.Source{
+							"found":      "((some-dummy:some-secret))",
+							"not-found":  "((some-dummy:missing))",
+							"undeclared": "((other:some-secret))",
+							"cluster":    "((cluster-secret))",
+							"local":      "((.:some-local))",
+						}
+					})
+
+					JustBeforeEach(func() {
+						atcServer.AppendHandlers(
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("GET", path),
+								ghttp.RespondWithJSONEncoded(200, atc.ConfigResponse{Config: config}, http.Header{atc.ConfigVersionHeader: {"42"}}),
+							),
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/var-sources", "var=some-dummy:missing&var=some-dummy:some-secret"),
+								varSourcesResponse,
+							),
+						)
+					})
+
+					It("prints the config followed by where each var is resolved from", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "get-pipeline", "--pipeline", "some-pipeline", "--annotate-vars")
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(0))
+
+						var printedConfig atc.Config
+						err = yaml.Unmarshal(sess.Out.Contents(), &printedConfig)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(printedConfig).To(Equal(config))
+
+						Expect(sess.Out).To(gbytes.Say(`# vars:
+#   \(\(\.:some-local\)\): local var, set by the build
+#   \(\(cluster-secret\)\): cluster credential manager \(not checked\)
+#   \(\(other:some-secret\)\): undeclared var_source 'other'
+#   \(\(some-dummy:missing\)\): var_source 'some-dummy' \(dummy\), not found
+#   \(\(some-dummy:some-secret\)\): var_source 'some-dummy' \(dummy\), found
+`))
+					})
+
+					Context("when the vars cannot be looked up", func() {
+						BeforeEach(func() {
+							varSourcesResponse = ghttp.RespondWith(500, "boom")
+						})
+
+						It("says so, and leaves the var_source vars unchecked", func() {
+							flyCmd := exec.Command(flyPath, "-t", targetName, "get-pipeline", "--pipeline", "some-pipeline", "--annotate-vars")
+
+							sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+							Expect(err).NotTo(HaveOccurred())
+
+							<-sess.Exited
+							Expect(sess.ExitCode()).To(Equal(0))
+
+							Expect(sess.Err).To(gbytes.Say(`failed to look up vars: .*`))
+							Expect(sess.Err).To(gbytes.Say(`boom`))
+							Expect(sess.Out).To(gbytes.Say(`\(\(some-dummy:missing\)\): var_source 'some-dummy' \(dummy\), not checked`))
+						})
+					})
+				})
+
+				Context("when --annotate-vars and -j are both given", func() {
+					It("fails", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "get-pipeline", "--pipeline", "some-pipeline", "--annotate-vars", "-j")
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(1))
+
+						Expect(sess.Err).To(gbytes.Say("--annotate-vars cannot be used with --json"))
+					})
+				})
 			})
 
 			Context("with a custom team", func() {
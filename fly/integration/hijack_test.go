@@ -1048,6 +1048,87 @@ var _ = Describe("Hijacking", func() {
 		})
 	})
 
+	Context("when hijacking a named session", func() {
+		var sessionEnds func(conn *websocket.Conn)
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/containers/container-id"),
+					ghttp.RespondWithJSONEncoded(200, atc.Container{
+						ID:   "container-id",
+						User: user,
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/containers/container-id/hijack"),
+					func(w http.ResponseWriter, r *http.Request) {
+						defer GinkgoRecover()
+
+						conn, err := upgrader.Upgrade(w, r, nil)
+						Expect(err).NotTo(HaveOccurred())
+
+						defer conn.Close()
+
+						var processSpec atc.HijackProcessSpec
+						err = conn.ReadJSON(&processSpec)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(processSpec.Session).To(Equal("debug"))
+
+						err = conn.WriteJSON(atc.HijackOutput{
+							Stdout: []byte("some stdout"),
+						})
+						Expect(err).NotTo(HaveOccurred())
+
+						sessionEnds(conn)
+					},
+				),
+			)
+		})
+
+		Context("when the connection is lost", func() {
+			BeforeEach(func() {
+				sessionEnds = func(conn *websocket.Conn) {}
+			})
+
+			It("explains how to reattach", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "hijack", "--handle", "container-id", "--session", "debug")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("some stdout"))
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("session 'debug' is still running"))
+				Expect(sess.Err).To(gbytes.Say("fly -t " + targetName + " intercept --team main --handle container-id --session debug"))
+			})
+		})
+
+		Context("when the process exits", func() {
+			BeforeEach(func() {
+				sessionEnds = func(conn *websocket.Conn) {
+					exitStatus := 3
+					err := conn.WriteJSON(atc.HijackOutput{
+						ExitStatus: &exitStatus,
+					})
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("exits with its status", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "hijack", "--handle", "container-id", "--session", "debug")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(3))
+				Expect(sess.Err).NotTo(gbytes.Say("still running"))
+			})
+		})
+	})
+
 	Context("when passing a URL that doesn't match the target", func() {
 		It("errors out when wrong team is specified", func() {
 			flyCmd := exec.Command(flyPath, "-t", targetName, "hijack", "--url", atcServer.URL()+"/teams/wrongteam/pipelines/a-pipeline/resources/some-resource-name")
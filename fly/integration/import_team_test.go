@@ -0,0 +1,170 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("import-team", func() {
+		var (
+			exportDir   string
+			instanceRef atc.PipelineRef
+		)
+
+		BeforeEach(func() {
+			var err error
+			exportDir, err = ioutil.TempDir("", "fly-import-team")
+			Expect(err).NotTo(HaveOccurred())
+
+			instanceRef = atc.PipelineRef{
+				Name:         "branches",
+				InstanceVars: atc.InstanceVars{"branch": "feature"},
+			}
+
+			err = os.MkdirAll(filepath.Join(exportDir, "pipelines", "branches"), 0755)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(exportDir, "pipelines", "some-pipeline.yml"), []byte("jobs: [{name: some-job}]\n"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(exportDir, "pipelines", "branches", "1.yml"), []byte("jobs: [{name: some-instanced-job}]\n"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(exportDir, "pipelines.yml"), []byte(`
+team: other-team
+pipelines:
+- name: some-pipeline
+  config: pipelines/some-pipeline.yml
+  paused: true
+  public: true
+  pins:
+  - resource: pinned
+    version: {ref: abc}
+    comment: hold it
+  - resource: unchecked
+    version: {ref: def}
+- name: branches
+  instance_vars: {branch: feature}
+  config: pipelines/branches/1.yml
+`), 0644)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(exportDir)
+		})
+
+		Context("when the pipelines are imported", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+						ghttp.VerifyBody([]byte("jobs: [{name: some-job}]\n")),
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.SaveConfigResponse{}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/pause"),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/expose"),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/resources/pinned/versions", "filter=ref:abc"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.ResourceVersion{
+							{ID: 7, Version: atc.Version{"ref": "abc"}},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/resources/pinned/versions/7/pin"),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/resources/pinned/pin_comment"),
+						ghttp.VerifyJSONRepresenting(atc.SetPinCommentRequestBody{PinComment: "hold it"}),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/resources/unchecked/versions", "filter=ref:def"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.ResourceVersion{}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/branches/config", instanceRef.QueryParams().Encode()),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{}, http.Header{atc.ConfigVersionHeader: {"42"}}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/branches/config", instanceRef.QueryParams().Encode()),
+						ghttp.VerifyHeaderKV(atc.ConfigVersionHeader, "42"),
+						ghttp.VerifyBody([]byte("jobs: [{name: some-instanced-job}]\n")),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.SaveConfigResponse{}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/branches/unpause", instanceRef.QueryParams().Encode()),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/branches/hide", instanceRef.QueryParams().Encode()),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/ordering"),
+						ghttp.VerifyJSONRepresenting([]string{"some-pipeline", "branches"}),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/branches/ordering"),
+						ghttp.VerifyJSONRepresenting([]atc.InstanceVars{{"branch": "feature"}}),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+				)
+			})
+
+			It("sets, configures and orders each pipeline", func() {
+				Expect(func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "import-team", "-d", exportDir, "-n")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+
+					Expect(sess.Out).To(gbytes.Say("importing 2 pipelines exported from team 'other-team' into team 'main'"))
+					Expect(sess.Out).To(gbytes.Say("imported 'some-pipeline'"))
+					Expect(sess.Out).To(gbytes.Say(`imported 'branches/branch:feature'`))
+
+					Expect(sess.Err).To(gbytes.Say("could not pin 'some-pipeline/unchecked'"))
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(15))
+			})
+		})
+
+		Context("when the export is missing", func() {
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "import-team", "-d", filepath.Join(exportDir, "bogus"), "-n")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("pipelines.yml"))
+			})
+		})
+	})
+})
@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -621,6 +622,95 @@ var _ = Describe("login Command", func() {
 			})
 		})
 
+		Context("with device authorization", func() {
+			BeforeEach(func() {
+				loginATCServer.AppendHandlers(
+					infoHandler(),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/sky/device/code"),
+						ghttp.VerifyFormKV("client_id", "fly"),
+						ghttp.VerifyFormKV("scope", "openid profile email federated:id groups"),
+						ghttp.RespondWithJSONEncoded(200, map[string]interface{}{
+							"device_code":               "some-device-code",
+							"user_code":                 "BCDF-GHJK",
+							"verification_uri":          loginATCServer.URL() + "/sky/device",
+							"verification_uri_complete": loginATCServer.URL() + "/sky/device?user_code=BCDF-GHJK",
+							"expires_in":                300,
+							"interval":                  1,
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/sky/device/token"),
+						ghttp.VerifyFormKV("grant_type", "urn:ietf:params:oauth:grant-type:device_code"),
+						ghttp.VerifyFormKV("device_code", "some-device-code"),
+						ghttp.RespondWithJSONEncoded(400, map[string]string{
+							"error": "authorization_pending",
+						}),
+					),
+				)
+			})
+
+			Context("when the code is confirmed", func() {
+				BeforeEach(func() {
+					loginATCServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("POST", "/sky/device/token"),
+							ghttp.VerifyFormKV("device_code", "some-device-code"),
+							ghttp.RespondWithJSONEncoded(200, map[string]interface{}{
+								"token_type":   "bearer",
+								"access_token": "access-token",
+								"expires_in":   3600,
+							}),
+						),
+						userInfoHandler(),
+					)
+				})
+
+				It("prints the code and url, then saves the token once it's confirmed", func() {
+					flyCmd = exec.Command(flyPath, "-t", "some-target", "login", "-c", loginATCServer.URL(), "--device")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess.Out).Should(gbytes.Say(regexp.QuoteMeta(loginATCServer.URL() + "/sky/device?user_code=BCDF-GHJK")))
+					Eventually(sess.Out).Should(gbytes.Say("BCDF-GHJK"))
+					Eventually(sess.Out, 10*time.Second).Should(gbytes.Say("target saved"))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+
+					flyRcContents, err := ioutil.ReadFile(homeDir + "/.flyrc")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(flyRcContents)).To(ContainSubstring("value: access-token"))
+				})
+			})
+
+			Context("when the code expires", func() {
+				BeforeEach(func() {
+					loginATCServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("POST", "/sky/device/token"),
+							ghttp.RespondWithJSONEncoded(400, map[string]string{
+								"error": "expired_token",
+							}),
+						),
+					)
+				})
+
+				It("errors", func() {
+					flyCmd = exec.Command(flyPath, "-t", "some-target", "login", "-c", loginATCServer.URL(), "--device")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess.Err, 10*time.Second).Should(gbytes.Say("the code expired before it was confirmed"))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(1))
+				})
+			})
+		})
+
 		Context("cannot successfully login", func() {
 			Context("team does not exist", func() {
 				It("returns a warning", func() {
@@ -2,6 +2,7 @@ package integration_test
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"os/exec"
 
@@ -54,6 +55,26 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Context("when a reason is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", apiPath, queryParams),
+						ghttp.VerifyJSONRepresenting(atc.PauseRequest{Reason: "prod freeze"}),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+				)
+			})
+
+			It("sends the reason along", func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "pause-job", "-j", fullJobName, "--reason", "prod freeze")
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+			})
+		})
+
 		Context("user is NOT on the same team as the given pipeline/job's team", func() {
 			BeforeEach(func() {
 				atcServer.AppendHandlers(
@@ -140,5 +161,93 @@ var _ = Describe("Fly CLI", func() {
 				Expect(sess.ExitCode()).To(Equal(1))
 			})
 		})
+
+		Context("when the job is a glob", func() {
+			var (
+				stdin io.WriteCloser
+				sess  *gexec.Session
+				args  []string
+			)
+
+			BeforeEach(func() {
+				args = []string{"-j", pipelineRef.String() + "/test-*"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/jobs", queryParams),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Job{
+							{Name: "build"},
+							{Name: "test-unit"},
+							{Name: "test-integration"},
+						}),
+					),
+				)
+			})
+
+			JustBeforeEach(func() {
+				var err error
+
+				flyCmd = exec.Command(flyPath, append([]string{"-t", targetName, "pause-job"}, args...)...)
+				stdin, err = flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err = gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("lists the matching jobs and bails out if the user says no", func() {
+				Eventually(sess).Should(gbytes.Say(`jobs in pipeline/branch:master matching 'test-\*':\n  test-unit\n  test-integration\n`))
+				Eventually(sess).Should(gbytes.Say(`pause 2 jobs\? \[yN\]: `))
+				fmt.Fprintf(stdin, "n\n")
+
+				Eventually(sess).Should(gbytes.Say("bailing out"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when the user confirms", func() {
+				BeforeEach(func() {
+					args = append(args, "--reason", "flaky")
+
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/pipeline/jobs/pause", queryParams),
+							ghttp.VerifyJSONRepresenting(atc.PauseJobsRequest{Jobs: []string{"test-unit", "test-integration"}, Reason: "flaky"}),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, []string{"test-integration", "test-unit"}),
+						),
+					)
+				})
+
+				It("pauses them all in one request", func() {
+					Eventually(sess).Should(gbytes.Say(`pause 2 jobs\? \[yN\]: `))
+					fmt.Fprintf(stdin, "y\n")
+
+					Eventually(sess).Should(gbytes.Say("paused 'test-integration'\npaused 'test-unit'\n"))
+					Eventually(sess).Should(gexec.Exit(0))
+				})
+			})
+
+			Context("when --dry-run is given", func() {
+				BeforeEach(func() {
+					args = append(args, "--dry-run")
+				})
+
+				It("only lists the matching jobs", func() {
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out).To(gbytes.Say(`  test-unit\n  test-integration\n`))
+					Expect(atcServer.ReceivedRequests()).To(HaveLen(5))
+				})
+			})
+
+			Context("when no jobs match", func() {
+				BeforeEach(func() {
+					args = []string{"-j", pipelineRef.String() + "/deploy-*"}
+				})
+
+				It("errors", func() {
+					Eventually(sess).Should(gexec.Exit(1))
+					Expect(sess.Err).To(gbytes.Say(`no jobs in pipeline/branch:master match 'deploy-\*'`))
+				})
+			})
+		})
 	})
 })
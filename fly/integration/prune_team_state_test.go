@@ -0,0 +1,105 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("prune-team-state", func() {
+		var state atc.TeamPrunableState
+
+		BeforeEach(func() {
+			state = atc.TeamPrunableState{
+				Containers: []atc.PrunableObject{
+					{Handle: "some-container", WorkerName: "some-worker", Reason: atc.PruneReasonOrphaned},
+					{Handle: "some-check-container", WorkerName: "some-worker", Reason: atc.PruneReasonStuckCheck},
+				},
+				Volumes: []atc.PrunableObject{
+					{Handle: "some-volume", WorkerName: "other-worker", Reason: atc.PruneReasonDangling},
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/prunable-state"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, state),
+				),
+			)
+		})
+
+		Context("with --dry-run", func() {
+			It("lists what would be pruned without pruning it", func() {
+				Expect(func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "prune-team-state", "--dry-run")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out).To(PrintTable(ui.Table{
+						Headers: ui.TableRow{
+							{Contents: "kind", Color: color.New(color.Bold)},
+							{Contents: "handle", Color: color.New(color.Bold)},
+							{Contents: "worker", Color: color.New(color.Bold)},
+							{Contents: "reason", Color: color.New(color.Bold)},
+						},
+						Data: []ui.TableRow{
+							{{Contents: "container"}, {Contents: "some-container"}, {Contents: "some-worker"}, {Contents: "orphaned"}},
+							{{Contents: "container"}, {Contents: "some-check-container"}, {Contents: "some-worker"}, {Contents: "stuck-check"}},
+							{{Contents: "volume"}, {Contents: "some-volume"}, {Contents: "other-worker"}, {Contents: "dangling"}},
+						},
+					}))
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(2))
+			})
+		})
+
+		Context("when confirmed", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/prunable-state"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, state),
+					),
+				)
+			})
+
+			It("prunes the team's state", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "prune-team-state", "-n")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("pruned 2 containers and 1 volumes"))
+			})
+		})
+
+		Context("when the target isn't an admin", func() {
+			BeforeEach(func() {
+				atcServer.SetHandler(4, ghttp.RespondWith(http.StatusForbidden, ""))
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "prune-team-state", "--dry-run")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("forbidden"))
+			})
+		})
+	})
+})
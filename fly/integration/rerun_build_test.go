@@ -0,0 +1,96 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("rerun-build", func() {
+		var (
+			path         string
+			expectedBody string
+		)
+
+		BeforeEach(func() {
+			var err error
+			path, err = atc.Routes.CreatePathForRoute(atc.RerunJobBuild, rata.Params{"pipeline_name": "awesome-pipeline", "job_name": "awesome-job", "build_name": "3", "team_name": "main"})
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedBody = ""
+		})
+
+		JustBeforeEach(func() {
+			verifyBody := ghttp.VerifyBody([]byte{})
+			if expectedBody != "" {
+				verifyBody = ghttp.VerifyJSON(expectedBody)
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", path),
+					verifyBody,
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 57, Name: "3.1"}),
+				),
+			)
+		})
+
+		It("reruns the build", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "rerun-build", "-j", "awesome-pipeline/awesome-job", "-b", "3")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/awesome-job #3.1`))
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+		})
+
+		Context("when vars are given", func() {
+			var tmpdir, varsFile string
+
+			BeforeEach(func() {
+				var err error
+				tmpdir, err = ioutil.TempDir("", "fly-rerun-build")
+				Expect(err).NotTo(HaveOccurred())
+
+				varsFile = filepath.Join(tmpdir, "vars.yml")
+				err = ioutil.WriteFile(varsFile, []byte("target: windows\narch: amd64\n"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedBody = `{"vars":{"target":"linux","arch":"amd64","retries":3}}`
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(tmpdir)
+			})
+
+			It("reruns the build with the vars overridden", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "rerun-build", "-j", "awesome-pipeline/awesome-job", "-b", "3",
+					"-l", varsFile,
+					"-v", "target=linux",
+					"-y", "retries=3",
+				)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+			})
+		})
+	})
+})
@@ -1059,6 +1059,122 @@ this is super secure
 					}).By(3))
 				})
 
+				Context("when given the expected diff hash", func() {
+					var diffHash string
+
+					JustBeforeEach(func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", configFile.Name())
+
+						stdin, err := flyCmd.StdinPipe()
+						Expect(err).NotTo(HaveOccurred())
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess).Should(gbytes.Say(`apply configuration\? \[yN\]: `))
+						no(stdin)
+
+						<-sess.Exited
+
+						matches := regexp.MustCompile(`diff hash: \S*?([0-9a-f]{64})`).FindSubmatch(sess.Out.Contents())
+						Expect(matches).ToNot(BeNil())
+						diffHash = string(matches[1])
+
+						atcServer.AppendHandlers(infoHandler())
+					})
+
+					It("applies the configuration without asking when the hash matches", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", configFile.Name(), "--expected-diff-hash", diffHash)
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess).Should(gbytes.Say("configuration updated"))
+						Expect(sess.Out.Contents()).ToNot(ContainSubstring("apply configuration?"))
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(0))
+					})
+
+					It("refuses to apply the configuration when the hash does not match", func() {
+						Expect(func() {
+							flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", configFile.Name(), "--expected-diff-hash", "bogus")
+
+							sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+							Expect(err).NotTo(HaveOccurred())
+
+							<-sess.Exited
+							Expect(sess.ExitCode()).To(Equal(1))
+							Expect(sess.Err).To(gbytes.Say("does not match the expected hash bogus"))
+						}).To(Change(func() int {
+							return len(atcServer.ReceivedRequests())
+						}).By(2))
+					})
+				})
+
+				Context("when approving each section", func() {
+					BeforeEach(func() {
+						path, err := atc.Routes.CreatePathForRoute(atc.SaveConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+						Expect(err).NotTo(HaveOccurred())
+
+						atcServer.RouteToHandler("PUT", path,
+							ghttp.CombineHandlers(
+								ghttp.VerifyHeaderKV(atc.ConfigVersionHeader, "42"),
+								func(w http.ResponseWriter, r *http.Request) {
+									var received atc.Config
+									err := yaml.Unmarshal(getConfig(r), &received)
+									Expect(err).NotTo(HaveOccurred())
+
+									var resourceNames []string
+									for _, resource := range received.Resources {
+										resourceNames = append(resourceNames, resource.Name)
+									}
+									Expect(resourceNames).To(ContainElement("some-other-resource"))
+									Expect(resourceNames).ToNot(ContainElement("some-new-resource"))
+
+									var jobNames []string
+									for _, job := range received.Jobs {
+										jobNames = append(jobNames, job.Name)
+									}
+									Expect(jobNames).To(ContainElement("some-new-job"))
+									Expect(jobNames).ToNot(ContainElement("some-other-job"))
+								},
+								ghttp.RespondWith(http.StatusOK, "{}"),
+							),
+						)
+					})
+
+					It("applies only the approved sections", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", configFile.Name(), "--approve-sections")
+
+						stdin, err := flyCmd.StdinPipe()
+						Expect(err).NotTo(HaveOccurred())
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess).Should(gbytes.Say("group some-group has changed"))
+						Eventually(sess).Should(gbytes.Say(`apply changes to groups\? \[yN\]: `))
+						no(stdin)
+
+						Eventually(sess).Should(gbytes.Say("resource some-resource has changed"))
+						Eventually(sess).Should(gbytes.Say(`apply changes to resources\? \[yN\]: `))
+						no(stdin)
+
+						Eventually(sess).Should(gbytes.Say(`apply changes to resource types\? \[yN\]: `))
+						no(stdin)
+
+						Eventually(sess).Should(gbytes.Say("job some-new-job has been added"))
+						Eventually(sess).Should(gbytes.Say(`apply changes to jobs\? \[yN\]: `))
+						yes(stdin)
+
+						Eventually(sess).Should(gbytes.Say("configuration updated"))
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(0))
+					})
+				})
+
 				It("parses the config from stdin and sends it to the ATC", func() {
 					Expect(func() {
 						flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", "-")
@@ -158,6 +158,39 @@ var _ = Describe("Fly CLI", func() {
 				})
 			})
 
+			Context("when --yaml is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--yaml")
+				})
+
+				It("prints the same schema as --json, in yaml", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchYAML(`
+- id: 1
+  name: main
+  auth:
+    owner: {groups: [], users: []}
+- id: 2
+  name: a-team
+  auth:
+    owner: {groups: ["github:github-org"], users: []}
+- id: 3
+  name: b-team
+  auth:
+    member: {users: ["github:github-user"], groups: []}
+- id: 4
+  name: c-team
+  auth:
+    owner: {groups: ["github:github-org"], users: ["github:github-user"]}
+    member: {groups: ["github:github-org"], users: ["github:github-user"]}
+    viewer: {groups: ["github:github-org"], users: ["github:github-user"]}
+`))
+				})
+			})
+
 			Context("when the details flag is specified", func() {
 				BeforeEach(func() {
 					flyCmd.Args = append(flyCmd.Args, "--details")
@@ -0,0 +1,131 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("top", func() {
+		var (
+			startTime     int64
+			buildHandlers []http.HandlerFunc
+		)
+
+		BeforeEach(func() {
+			startTime = time.Now().Add(-time.Hour).Unix()
+
+			buildHandlers = []http.HandlerFunc{
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds", "limit=100&status=started"),
+					ghttp.RespondWithJSONEncoded(200, []atc.Build{
+						{ID: 1, Name: "1", TeamName: "main", PipelineName: "pipeline", JobName: "quiet", StartTime: startTime},
+						{ID: 2, Name: "4", TeamName: "main", PipelineName: "pipeline", JobName: "busy", StartTime: startTime},
+						{ID: 3, Name: "9", TeamName: "other-team", PipelineName: "other", JobName: "hidden", StartTime: startTime},
+					}),
+				),
+			}
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/workers"),
+					ghttp.RespondWithJSONEncoded(200, []atc.Worker{
+						{Name: "worker-b", State: "running", ActiveContainers: 7, ActiveVolumes: 20, ActiveTasks: 2},
+						{Name: "worker-a", State: "stalled", ActiveContainers: 1, ActiveVolumes: 3, Team: "main"},
+					}),
+				),
+			)
+			atcServer.AppendHandlers(buildHandlers...)
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/containers"),
+					ghttp.RespondWithJSONEncoded(200, []atc.Container{
+						{ID: "a", BuildID: 2},
+						{ID: "b", BuildID: 2},
+						{ID: "c", BuildID: 1},
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/other-team/containers"),
+					ghttp.RespondWith(403, nil),
+				),
+			)
+		})
+
+		It("prints the workers and the busiest builds once when not on a terminal", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "top", "--builds", "2")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say(`workers: 2 \(1 stalled\)  containers: 8  volumes: 23  running builds: 3`))
+			Expect(sess.Out).To(gbytes.Say(`worker\s+state\s+containers\s+volumes\s+tasks\s+team`))
+			Expect(sess.Out).To(gbytes.Say(`worker-a\s+stalled\s+1\s+3\s+0\s+main`))
+			Expect(sess.Out).To(gbytes.Say(`worker-b\s+running\s+7\s+20\s+2\s+none`))
+			Expect(sess.Out).To(gbytes.Say(`id\s+team\s+name\s+containers\s+duration`))
+			Expect(sess.Out).To(gbytes.Say(`2\s+main\s+pipeline/busy/4\s+2\s+1h0m\d+s\+`))
+			Expect(sess.Out).To(gbytes.Say(`1\s+main\s+pipeline/quiet/1\s+1\s+1h0m\d+s\+`))
+			Expect(sess.Out).NotTo(gbytes.Say(`other/hidden`))
+		})
+
+		Context("when containers cannot be listed for a team", func() {
+			It("shows their builds without a container count", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "top")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say(`3\s+other-team\s+other/hidden/9\s+n/a`))
+			})
+		})
+
+		Context("when the running builds span more than one page", func() {
+			BeforeEach(func() {
+				buildHandlers = []http.HandlerFunc{
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds", "limit=100&status=started"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Build{
+							{ID: 2, Name: "4", TeamName: "main", PipelineName: "pipeline", JobName: "busy", StartTime: startTime},
+							{ID: 3, Name: "9", TeamName: "other-team", PipelineName: "other", JobName: "hidden", StartTime: startTime},
+						}, http.Header{
+							"Link": {`</api/v1/builds?to=1&limit=100&status=started>; rel="next"`},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds", "to=1&limit=100&status=started"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Build{
+							{ID: 1, Name: "1", TeamName: "main", PipelineName: "pipeline", JobName: "quiet", StartTime: startTime},
+						}),
+					),
+				}
+			})
+
+			It("counts and shows the builds from every page", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "top")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say(`running builds: 3`))
+				Expect(sess.Out).To(gbytes.Say(`2\s+main\s+pipeline/busy/4\s+2`))
+				Expect(sess.Out).To(gbytes.Say(`1\s+main\s+pipeline/quiet/1\s+1`))
+			})
+		})
+	})
+})
@@ -97,6 +97,33 @@ var _ = Describe("Fly CLI", func() {
 					})
 				})
 
+				Context("when --input is provided", func() {
+					BeforeEach(func() {
+						atcServer.AppendHandlers(
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("POST", mainPath),
+								ghttp.VerifyJSON(`{"inputs":{"some-input":{"ref":"abc"},"other-input":{"version":"1.2.3"}}}`),
+								ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 57, Name: "42"}),
+							),
+						)
+					})
+
+					It("starts the build with the given input versions", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "trigger-job", "-j", "awesome-pipeline/awesome-job",
+							"--input", `some-input={"ref":"abc"}`,
+							"--input", `other-input={"version":"1.2.3"}`,
+						)
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/awesome-job #42`))
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(0))
+					})
+				})
+
 				Context("when -w option is provided", func() {
 					var streaming chan struct{}
 					var events chan atc.Event
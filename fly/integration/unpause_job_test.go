@@ -125,5 +125,33 @@ var _ = Describe("Fly CLI", func() {
 				Expect(sess.ExitCode()).To(Equal(1))
 			})
 		})
+
+		Context("when the job is a glob and -n is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/jobs", queryParams),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Job{
+							{Name: "build"},
+							{Name: "test-unit"},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/pipeline/jobs/unpause", queryParams),
+						ghttp.VerifyJSONRepresenting(atc.PauseJobsRequest{Jobs: []string{"test-unit"}}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []string{"test-unit"}),
+					),
+				)
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "unpause-job", "-j", pipelineRef.String()+"/test-*", "-n")
+			})
+
+			It("unpauses the matching jobs without confirmation", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("unpaused 'test-unit'\n"))
+			})
+		})
 	})
 })
@@ -0,0 +1,148 @@
+package integration_test
+
+import (
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("var-sources", func() {
+		var (
+			flyCmd *exec.Cmd
+		)
+
+		Context("when pipeline name is not specified", func() {
+			It("fails and says pipeline name is required", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "var-sources")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("p", "pipeline") + "' was not specified"))
+			})
+		})
+
+		Context("when var sources are returned from the API", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "var-sources", "--pipeline", "pipeline/branch:master")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/var-sources", "vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(200, []atc.VarSourceStatus{
+							{
+								Name:         "some-vault",
+								Type:         "vault",
+								Reachable:    true,
+								LatencyMS:    12,
+								Lookups:      4,
+								CacheHits:    3,
+								CacheHitRate: 0.75,
+								LastError:    "secret not found",
+							},
+							{
+								Name:  "team-vault",
+								Type:  "vault",
+								Error: "permission denied",
+							},
+							{
+								Name:      "some-ssm",
+								Type:      "ssm",
+								Reachable: true,
+								LatencyMS: 30,
+							},
+						}),
+					),
+				)
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`[
+						{
+							"name": "some-vault",
+							"type": "vault",
+							"reachable": true,
+							"latency_ms": 12,
+							"lookups": 4,
+							"cache_hits": 3,
+							"cache_hit_rate": 0.75,
+							"last_error": "secret not found"
+						},
+						{
+							"name": "team-vault",
+							"type": "vault",
+							"reachable": false,
+							"error": "permission denied",
+							"latency_ms": 0,
+							"lookups": 0,
+							"cache_hits": 0,
+							"cache_hit_rate": 0
+						},
+						{
+							"name": "some-ssm",
+							"type": "ssm",
+							"reachable": true,
+							"latency_ms": 30,
+							"lookups": 0,
+							"cache_hits": 0,
+							"cache_hit_rate": 0
+						}
+					]`))
+				})
+			})
+
+			It("shows the pipeline's var sources", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Data: []ui.TableRow{
+						{{Contents: "some-vault"}, {Contents: "vault"}, {Contents: "yes", Color: color.New(color.FgCyan)}, {Contents: "12ms"}, {Contents: "4"}, {Contents: "75%"}, {Contents: "secret not found", Color: color.New(color.FgYellow)}},
+						{{Contents: "team-vault"}, {Contents: "vault"}, {Contents: "no", Color: color.New(color.FgRed)}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: "0"}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: "permission denied", Color: color.New(color.FgRed)}},
+						{{Contents: "some-ssm"}, {Contents: "ssm"}, {Contents: "yes", Color: color.New(color.FgCyan)}, {Contents: "30ms"}, {Contents: "0"}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: "n/a", Color: color.New(color.Faint)}},
+					},
+				}))
+			})
+		})
+
+		Context("when the pipeline is not found", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "var-sources", "-p", "pipeline")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/var-sources"),
+						ghttp.RespondWith(404, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("pipeline not found"))
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package integration_test
 
 import (
+	"encoding/json"
 	"os/exec"
 
 	"github.com/concourse/concourse/atc"
@@ -332,6 +333,92 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Context("when filtering and sorting", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/volumes"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Volume{
+							{ID: "volume-a", WorkerName: "worker-2", Type: "container", ContainerHandle: "container-a", CreatedAt: 300, SizeBytes: 10},
+							{ID: "volume-b", WorkerName: "worker-1", Type: "task-cache", PipelineName: "pipeline-1", JobName: "job", StepName: "task", CreatedAt: 100, SizeBytes: 30},
+							{ID: "volume-c", WorkerName: "worker-1", Type: "container", ContainerHandle: "container-c", CreatedAt: 200, SizeBytes: 20},
+						}),
+					),
+				)
+			})
+
+			handles := func(args ...string) []string {
+				flyCmd.Args = append(flyCmd.Args, args...)
+				flyCmd.Args = append(flyCmd.Args, "--json")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				var volumes []atc.Volume
+				err = json.Unmarshal(sess.Out.Contents(), &volumes)
+				Expect(err).NotTo(HaveOccurred())
+
+				ids := []string{}
+				for _, v := range volumes {
+					ids = append(ids, v.ID)
+				}
+
+				return ids
+			}
+
+			It("filters by worker", func() {
+				Expect(handles("--worker", "worker-1")).To(Equal([]string{"volume-b", "volume-c"}))
+			})
+
+			It("filters by type", func() {
+				Expect(handles("--type", "container")).To(Equal([]string{"volume-a", "volume-c"}))
+			})
+
+			It("filters by pipeline", func() {
+				Expect(handles("--pipeline", "pipeline-1")).To(Equal([]string{"volume-b"}))
+			})
+
+			It("sorts by age, oldest first", func() {
+				Expect(handles("--sort-by", "age")).To(Equal([]string{"volume-b", "volume-c", "volume-a"}))
+			})
+
+			It("sorts by handle", func() {
+				Expect(handles("--sort-by", "handle", "--reverse")).To(Equal([]string{"volume-c", "volume-b", "volume-a"}))
+			})
+
+			It("sorts by size, largest first", func() {
+				Expect(handles("--sort-by", "size")).To(Equal([]string{"volume-b", "volume-c", "volume-a"}))
+			})
+		})
+
+		Context("when --team is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--team", "other-team")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/other-team"),
+						ghttp.RespondWithJSONEncoded(200, atc.Team{Name: "other-team"}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/other-team/volumes"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Volume{
+							{ID: "other-volume", WorkerName: "worker-1", Type: "container", ContainerHandle: "container-a"},
+						}),
+					),
+				)
+			})
+
+			It("lists the team's volumes", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("other-volume"))
+			})
+		})
+
 		Context("and the api returns an internal server error", func() {
 			BeforeEach(func() {
 				atcServer.AppendHandlers(
@@ -127,6 +127,127 @@ var _ = Describe("Watching", func() {
 		})
 	})
 
+	Context("with multiple builds", func() {
+		var (
+			otherStreaming chan struct{}
+			otherEvents    chan atc.Event
+		)
+
+		BeforeEach(func() {
+			otherStreaming = make(chan struct{})
+			otherEvents = make(chan atc.Event)
+
+			atcServer.RouteToHandler("GET", "/api/v1/builds/3/events", BuildEventsHandler(3, streaming, events))
+			atcServer.RouteToHandler("GET", "/api/v1/builds/12/events", BuildEventsHandler(12, otherStreaming, otherEvents))
+		})
+
+		It("watches the builds at once, prefixing each line with its build", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "watch", "-b", "3", "-b", "12")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(streaming).Should(BeClosed())
+			Eventually(otherStreaming).Should(BeClosed())
+
+			events <- event.Log{Payload: "sup from 3\n"}
+			Eventually(sess.Out).Should(gbytes.Say(`\[3\]  sup from 3\n`))
+
+			otherEvents <- event.Log{Payload: "sup from 12\n"}
+			Eventually(sess.Out).Should(gbytes.Say(`\[12\] sup from 12\n`))
+
+			events <- event.Status{Status: atc.StatusSucceeded}
+			close(events)
+
+			otherEvents <- event.Status{Status: atc.StatusFailed}
+			close(otherEvents)
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(1))
+		})
+	})
+
+	Context("when following a job", func() {
+		var (
+			jobResponses   chan atc.Job
+			nextStreaming  chan struct{}
+			nextEvents     chan atc.Event
+			expectedJobURL string
+		)
+
+		BeforeEach(func() {
+			jobResponses = make(chan atc.Job, 3)
+			nextStreaming = make(chan struct{})
+			nextEvents = make(chan atc.Event)
+
+			expectedJobURL = "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job"
+
+			jobResponses <- atc.Job{
+				NextBuild:     &atc.Build{ID: 3, Name: "3", Status: "started", JobName: "some-job"},
+				FinishedBuild: &atc.Build{ID: 2, Name: "2", Status: "succeeded", JobName: "some-job"},
+			}
+			jobResponses <- atc.Job{
+				NextBuild:     &atc.Build{ID: 4, Name: "4", Status: "started", JobName: "some-job"},
+				FinishedBuild: &atc.Build{ID: 3, Name: "3", Status: "succeeded", JobName: "some-job"},
+			}
+
+			atcServer.RouteToHandler("GET", expectedJobURL, func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case job := <-jobResponses:
+					ghttp.RespondWithJSONEncoded(http.StatusOK, job)(w, r)
+				default:
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Job{
+						FinishedBuild: &atc.Build{ID: 4, Name: "4", Status: "succeeded", JobName: "some-job"},
+					})(w, r)
+				}
+			})
+			atcServer.RouteToHandler("GET", "/api/v1/builds/3/events", BuildEventsHandler(3, streaming, events))
+			atcServer.RouteToHandler("GET", "/api/v1/builds/4/events", BuildEventsHandler(4, nextStreaming, nextEvents))
+		})
+
+		It("attaches to each new build of the job", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "watch", "-j", "some-pipeline/some-job", "--follow")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess.Out).Should(gbytes.Say(`watching some-pipeline/some-job #3`))
+			Eventually(streaming).Should(BeClosed())
+
+			events <- event.Log{Payload: "first build\n"}
+			Eventually(sess.Out).Should(gbytes.Say("first build"))
+
+			events <- event.Status{Status: atc.StatusSucceeded}
+			close(events)
+
+			Eventually(sess.Out).Should(gbytes.Say(`watching some-pipeline/some-job #4`))
+			Eventually(nextStreaming).Should(BeClosed())
+
+			nextEvents <- event.Log{Payload: "second build\n"}
+			Eventually(sess.Out).Should(gbytes.Say("second build"))
+
+			nextEvents <- event.Status{Status: atc.StatusFailed}
+			close(nextEvents)
+
+			Eventually(sess.Out).Should(gbytes.Say("failed"))
+			Consistently(sess).ShouldNot(gexec.Exit())
+
+			sess.Interrupt()
+			<-sess.Exited
+		})
+
+		It("requires a job", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "watch", "--follow")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess.Err).Should(gbytes.Say("--follow requires --job"))
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(1))
+		})
+	})
+
 	Context("with a specific job and pipeline", func() {
 
 		var (
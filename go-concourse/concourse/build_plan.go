@@ -30,3 +30,26 @@ func (client *client) BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
 		return buildPlan, false, err
 	}
 }
+
+func (client *client) BuildPlanStatus(buildID int) (atc.BuildPlanStatus, bool, error) {
+	params := rata.Params{
+		"build_id": strconv.Itoa(buildID),
+	}
+
+	var status atc.BuildPlanStatus
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetBuildPlanStatus,
+		Params:      params,
+	}, &internal.Response{
+		Result: &status,
+	})
+
+	switch err.(type) {
+	case nil:
+		return status, true, nil
+	case internal.ResourceNotFoundError:
+		return status, false, nil
+	default:
+		return status, false, err
+	}
+}
@@ -57,4 +57,51 @@ var _ = Describe("ATC Handler Build Plans", func() {
 			})
 		})
 	})
+
+	Describe("BuildPlanStatus", func() {
+		expectedURL := "/api/v1/builds/1234/plan/status"
+
+		Context("when build exists and has a plan", func() {
+			expectedStatus := atc.BuildPlanStatus{
+				BuildID: 1234,
+				Status:  atc.StatusStarted,
+				Steps: []atc.PlanNodeStatus{
+					{ID: "some-id", Type: "task", Name: "some-task", State: atc.PlanNodeStateRunning, StartTime: 1},
+				},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedStatus),
+					),
+				)
+			})
+
+			It("returns the status of the plan", func() {
+				status, found, err := client.BuildPlanStatus(1234)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(status).To(Equal(expectedStatus))
+			})
+		})
+
+		Context("when build does not exist or has no plan", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildPlanStatus(1234)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
 })
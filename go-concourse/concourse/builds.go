@@ -54,6 +54,33 @@ func (team *team) CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (a
 	return build, err
 }
 
+func (team *team) CreateJobBuildWithInputs(pipelineRef atc.PipelineRef, jobName string, inputs map[string]atc.Version) (atc.Build, error) {
+	params := rata.Params{
+		"job_name":      jobName,
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	var build atc.Build
+
+	jsonBytes, err := json.Marshal(atc.CreateJobBuildRequestBody{Inputs: inputs})
+	if err != nil {
+		return build, err
+	}
+
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.CreateJobBuild,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, &internal.Response{
+		Result: &build,
+	})
+
+	return build, err
+}
+
 func (team *team) RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error) {
 	params := rata.Params{
 		"build_name":    buildName,
@@ -74,6 +101,34 @@ func (team *team) RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, bui
 	return build, err
 }
 
+func (team *team) RerunJobBuildWithVars(pipelineRef atc.PipelineRef, jobName string, buildName string, vars map[string]interface{}) (atc.Build, error) {
+	params := rata.Params{
+		"build_name":    buildName,
+		"job_name":      jobName,
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	var build atc.Build
+
+	jsonBytes, err := json.Marshal(atc.RerunBuildRequestBody{Vars: vars})
+	if err != nil {
+		return build, err
+	}
+
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.RerunJobBuild,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, &internal.Response{
+		Result: &build,
+	})
+
+	return build, err
+}
+
 func (team *team) JobBuild(pipelineRef atc.PipelineRef, jobName, buildName string) (atc.Build, bool, error) {
 	params := rata.Params{
 		"job_name":      jobName,
@@ -125,12 +180,21 @@ func (client *client) Build(buildID string) (atc.Build, bool, error) {
 }
 
 func (client *client) Builds(page Page) ([]atc.Build, Pagination, error) {
+	return client.FilteredBuilds(page, atc.BuildFilter{})
+}
+
+func (client *client) FilteredBuilds(page Page, filter atc.BuildFilter) ([]atc.Build, Pagination, error) {
 	var builds []atc.Build
 
+	query := page.QueryParams()
+	for name, values := range filter.QueryParams() {
+		query[name] = values
+	}
+
 	headers := http.Header{}
 	err := client.connection.Send(internal.Request{
 		RequestName: atc.ListBuilds,
-		Query:       page.QueryParams(),
+		Query:       query,
 	}, &internal.Response{
 		Result:  &builds,
 		Headers: &headers,
@@ -160,6 +224,37 @@ func (client *client) AbortBuild(buildID string) error {
 	}, nil)
 }
 
+// ApproveBuild approves the build's pending approvals with the given name,
+// returning false if there are none.
+func (client *client) ApproveBuild(buildID string, approvalName string) (bool, error) {
+	return client.decideApproval(atc.ApproveBuild, buildID, approvalName)
+}
+
+// RejectBuild rejects the build's pending approvals with the given name,
+// returning false if there are none.
+func (client *client) RejectBuild(buildID string, approvalName string) (bool, error) {
+	return client.decideApproval(atc.RejectBuild, buildID, approvalName)
+}
+
+func (client *client) decideApproval(requestName string, buildID string, approvalName string) (bool, error) {
+	err := client.connection.Send(internal.Request{
+		RequestName: requestName,
+		Params: rata.Params{
+			"build_id":      buildID,
+			"approval_name": approvalName,
+		},
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
 func (team *team) Builds(page Page) ([]atc.Build, Pagination, error) {
 	var builds []atc.Build
 
@@ -207,3 +302,20 @@ func (client *client) ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact,
 
 	return artifacts, err
 }
+
+func (client *client) ListBuildPublishedArtifacts(buildID string) ([]atc.PublishedArtifact, error) {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	var artifacts []atc.PublishedArtifact
+
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListBuildPublishedArtifacts,
+		Params:      params,
+	}, &internal.Response{
+		Result: &artifacts,
+	})
+
+	return artifacts, err
+}
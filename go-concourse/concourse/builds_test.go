@@ -3,6 +3,7 @@ package concourse_test
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse"
@@ -98,6 +99,45 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("CreateJobBuildWithInputs", func() {
+		var (
+			pipelineRef   atc.PipelineRef
+			queryParams   string
+			jobName       string
+			expectedBuild atc.Build
+		)
+
+		BeforeEach(func() {
+			queryParams = "vars.branch=%22master%22"
+			pipelineRef = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+			jobName = "myjob"
+
+			expectedBuild = atc.Build{
+				ID:      123,
+				Name:    "mybuild",
+				Status:  "succeeded",
+				JobName: "myjob",
+				APIURL:  "api/v1/builds/123",
+			}
+			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds"
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL, queryParams),
+					ghttp.VerifyJSON(`{"inputs":{"some-input":{"ref":"abc"}}}`),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
+				),
+			)
+		})
+
+		It("creates the build with the input versions in the request body", func() {
+			build, err := team.CreateJobBuildWithInputs(pipelineRef, jobName, map[string]atc.Version{"some-input": {"ref": "abc"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
 	Describe("RerunJobBuild", func() {
 		var (
 			pipelineRef   atc.PipelineRef
@@ -137,6 +177,47 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("RerunJobBuildWithVars", func() {
+		var (
+			pipelineRef   atc.PipelineRef
+			queryParams   string
+			jobName       string
+			buildName     string
+			expectedBuild atc.Build
+		)
+
+		BeforeEach(func() {
+			queryParams = "vars.branch=%22master%22"
+			pipelineRef = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+			jobName = "myjob"
+			buildName = "mybuild"
+
+			expectedBuild = atc.Build{
+				ID:      123,
+				Name:    "myrerunbuild",
+				Status:  "succeeded",
+				JobName: "myjob",
+				APIURL:  "api/v1/builds/123",
+			}
+			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds/mybuild"
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL, queryParams),
+					ghttp.VerifyJSON(`{"vars":{"target":"linux"}}`),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
+				),
+			)
+		})
+
+		It("reruns the build with the vars overridden", func() {
+			build, err := team.RerunJobBuildWithVars(pipelineRef, jobName, buildName, map[string]interface{}{"target": "linux"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
 	Describe("JobBuild", func() {
 		var (
 			expectedBuild atc.Build
@@ -243,6 +324,32 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("ListBuildPublishedArtifacts", func() {
+		expectedArtifacts := []atc.PublishedArtifact{
+			{
+				Name:        "some-artifact",
+				Location:    "s3://some-bucket/some-team/abc.tgz",
+				Digest:      "sha256:abc",
+				PublishedAt: 100,
+			},
+		}
+
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/123/published_artifacts"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedArtifacts),
+				),
+			)
+		})
+
+		It("returns the artifacts published by the build", func() {
+			artifacts, err := client.ListBuildPublishedArtifacts("123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifacts).To(Equal(expectedArtifacts))
+		})
+	})
+
 	Describe("client.Builds", func() {
 		expectedURL := "/api/v1/builds"
 
@@ -446,6 +553,31 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("client.FilteredBuilds", func() {
+		It("sends the filter along with the page", func() {
+			expectedBuilds := []atc.Build{{ID: 123, Name: "mybuild1", Status: "failed"}}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds", "job=unit-%2A&limit=10&min_duration=1h0m0s&pipeline=some-pipeline&reverse=true&sort_by=duration&status=failed&status=errored&worker=some-worker"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuilds),
+				),
+			)
+
+			builds, _, err := client.FilteredBuilds(concourse.Page{Limit: 10}, atc.BuildFilter{
+				Statuses:    []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored},
+				Pipeline:    "some-pipeline",
+				Job:         "unit-*",
+				MinDuration: time.Hour,
+				Worker:      "some-worker",
+				SortBy:      atc.BuildSortByDuration,
+				Reverse:     true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(Equal(expectedBuilds))
+		})
+	})
+
 	Describe("AbortBuild", func() {
 		BeforeEach(func() {
 			expectedURL := "/api/v1/builds/123/abort"
@@ -468,6 +600,75 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("ApproveBuild", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/approvals/some-approval/approve"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sends an approve request to ATC", func() {
+			found, err := client.ApproveBuild("123", "some-approval")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("ApproveBuild when there is no pending approval", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/approvals/some-approval/approve"),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("returns false", func() {
+			found, err := client.ApproveBuild("123", "some-approval")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("ApproveBuild when the user may not approve", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/approvals/some-approval/approve"),
+					ghttp.RespondWith(http.StatusForbidden, ""),
+				),
+			)
+		})
+
+		It("returns ErrForbidden", func() {
+			_, err := client.ApproveBuild("123", "some-approval")
+			Expect(err).To(Equal(concourse.ErrForbidden))
+		})
+	})
+
+	Describe("RejectBuild", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/approvals/some-approval/reject"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sends a reject request to ATC", func() {
+			found, err := client.RejectBuild("123", "some-approval")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
 	Describe("team.Builds", func() {
 		expectedURL := "/api/v1/teams/some-team/builds"
 
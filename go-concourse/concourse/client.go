@@ -16,12 +16,19 @@ type Client interface {
 	URL() string
 	HTTPClient() *http.Client
 	Builds(Page) ([]atc.Build, Pagination, error)
+	FilteredBuilds(Page, atc.BuildFilter) ([]atc.Build, Pagination, error)
 	Build(buildID string) (atc.Build, bool, error)
 	BuildEvents(buildID string) (Events, error)
+	RawBuildEvents(buildID string, sinceID *int) (RawEvents, error)
 	BuildResources(buildID int) (atc.BuildInputsOutputs, bool, error)
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
+	ListBuildPublishedArtifacts(buildID string) ([]atc.PublishedArtifact, error)
 	AbortBuild(buildID string) error
+	ApproveBuild(buildID string, approvalName string) (bool, error)
+	RejectBuild(buildID string, approvalName string) (bool, error)
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
+	BuildPlanStatus(buildID int) (atc.BuildPlanStatus, bool, error)
+	Search(query string, limit int) ([]atc.SearchResult, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
 	PruneWorker(workerName string) error
@@ -23,6 +23,20 @@ type FakeClient struct {
 	abortBuildReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ApproveBuildStub        func(string, string) (bool, error)
+	approveBuildMutex       sync.RWMutex
+	approveBuildArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	approveBuildReturns struct {
+		result1 bool
+		result2 error
+	}
+	approveBuildReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	BuildStub        func(string) (atc.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -66,6 +80,21 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildPlanStatusStub        func(int) (atc.BuildPlanStatus, bool, error)
+	buildPlanStatusMutex       sync.RWMutex
+	buildPlanStatusArgsForCall []struct {
+		arg1 int
+	}
+	buildPlanStatusReturns struct {
+		result1 atc.BuildPlanStatus
+		result2 bool
+		result3 error
+	}
+	buildPlanStatusReturnsOnCall map[int]struct {
+		result1 atc.BuildPlanStatus
+		result2 bool
+		result3 error
+	}
 	BuildResourcesStub        func(int) (atc.BuildInputsOutputs, bool, error)
 	buildResourcesMutex       sync.RWMutex
 	buildResourcesArgsForCall []struct {
@@ -96,6 +125,22 @@ type FakeClient struct {
 		result2 concourse.Pagination
 		result3 error
 	}
+	FilteredBuildsStub        func(concourse.Page, atc.BuildFilter) ([]atc.Build, concourse.Pagination, error)
+	filteredBuildsMutex       sync.RWMutex
+	filteredBuildsArgsForCall []struct {
+		arg1 concourse.Page
+		arg2 atc.BuildFilter
+	}
+	filteredBuildsReturns struct {
+		result1 []atc.Build
+		result2 concourse.Pagination
+		result3 error
+	}
+	filteredBuildsReturnsOnCall map[int]struct {
+		result1 []atc.Build
+		result2 concourse.Pagination
+		result3 error
+	}
 	FindTeamStub        func(string) (concourse.Team, error)
 	findTeamMutex       sync.RWMutex
 	findTeamArgsForCall []struct {
@@ -196,6 +241,19 @@ type FakeClient struct {
 		result1 []atc.WorkerArtifact
 		result2 error
 	}
+	ListBuildPublishedArtifactsStub        func(string) ([]atc.PublishedArtifact, error)
+	listBuildPublishedArtifactsMutex       sync.RWMutex
+	listBuildPublishedArtifactsArgsForCall []struct {
+		arg1 string
+	}
+	listBuildPublishedArtifactsReturns struct {
+		result1 []atc.PublishedArtifact
+		result2 error
+	}
+	listBuildPublishedArtifactsReturnsOnCall map[int]struct {
+		result1 []atc.PublishedArtifact
+		result2 error
+	}
 	ListPipelinesStub        func() ([]atc.Pipeline, error)
 	listPipelinesMutex       sync.RWMutex
 	listPipelinesArgsForCall []struct {
@@ -243,6 +301,34 @@ type FakeClient struct {
 	pruneWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RawBuildEventsStub        func(string, *int) (concourse.RawEvents, error)
+	rawBuildEventsMutex       sync.RWMutex
+	rawBuildEventsArgsForCall []struct {
+		arg1 string
+		arg2 *int
+	}
+	rawBuildEventsReturns struct {
+		result1 concourse.RawEvents
+		result2 error
+	}
+	rawBuildEventsReturnsOnCall map[int]struct {
+		result1 concourse.RawEvents
+		result2 error
+	}
+	RejectBuildStub        func(string, string) (bool, error)
+	rejectBuildMutex       sync.RWMutex
+	rejectBuildArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	rejectBuildReturns struct {
+		result1 bool
+		result2 error
+	}
+	rejectBuildReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SaveWorkerStub        func(atc.Worker, *time.Duration) (*atc.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -257,6 +343,20 @@ type FakeClient struct {
 		result1 *atc.Worker
 		result2 error
 	}
+	SearchStub        func(string, int) ([]atc.SearchResult, error)
+	searchMutex       sync.RWMutex
+	searchArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	searchReturns struct {
+		result1 []atc.SearchResult
+		result2 error
+	}
+	searchReturnsOnCall map[int]struct {
+		result1 []atc.SearchResult
+		result2 error
+	}
 	TeamStub        func(string) concourse.Team
 	teamMutex       sync.RWMutex
 	teamArgsForCall []struct {
@@ -355,6 +455,71 @@ func (fake *FakeClient) AbortBuildReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) ApproveBuild(arg1 string, arg2 string) (bool, error) {
+	fake.approveBuildMutex.Lock()
+	ret, specificReturn := fake.approveBuildReturnsOnCall[len(fake.approveBuildArgsForCall)]
+	fake.approveBuildArgsForCall = append(fake.approveBuildArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ApproveBuildStub
+	fakeReturns := fake.approveBuildReturns
+	fake.recordInvocation("ApproveBuild", []interface{}{arg1, arg2})
+	fake.approveBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ApproveBuildCallCount() int {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	return len(fake.approveBuildArgsForCall)
+}
+
+func (fake *FakeClient) ApproveBuildCalls(stub func(string, string) (bool, error)) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = stub
+}
+
+func (fake *FakeClient) ApproveBuildArgsForCall(i int) (string, string) {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	argsForCall := fake.approveBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ApproveBuildReturns(result1 bool, result2 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	fake.approveBuildReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ApproveBuildReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	if fake.approveBuildReturnsOnCall == nil {
+		fake.approveBuildReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.approveBuildReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Build(arg1 string) (atc.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -553,6 +718,73 @@ func (fake *FakeClient) BuildPlanReturnsOnCall(i int, result1 atc.PublicBuildPla
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildPlanStatus(arg1 int) (atc.BuildPlanStatus, bool, error) {
+	fake.buildPlanStatusMutex.Lock()
+	ret, specificReturn := fake.buildPlanStatusReturnsOnCall[len(fake.buildPlanStatusArgsForCall)]
+	fake.buildPlanStatusArgsForCall = append(fake.buildPlanStatusArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.BuildPlanStatusStub
+	fakeReturns := fake.buildPlanStatusReturns
+	fake.recordInvocation("BuildPlanStatus", []interface{}{arg1})
+	fake.buildPlanStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildPlanStatusCallCount() int {
+	fake.buildPlanStatusMutex.RLock()
+	defer fake.buildPlanStatusMutex.RUnlock()
+	return len(fake.buildPlanStatusArgsForCall)
+}
+
+func (fake *FakeClient) BuildPlanStatusCalls(stub func(int) (atc.BuildPlanStatus, bool, error)) {
+	fake.buildPlanStatusMutex.Lock()
+	defer fake.buildPlanStatusMutex.Unlock()
+	fake.BuildPlanStatusStub = stub
+}
+
+func (fake *FakeClient) BuildPlanStatusArgsForCall(i int) int {
+	fake.buildPlanStatusMutex.RLock()
+	defer fake.buildPlanStatusMutex.RUnlock()
+	argsForCall := fake.buildPlanStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildPlanStatusReturns(result1 atc.BuildPlanStatus, result2 bool, result3 error) {
+	fake.buildPlanStatusMutex.Lock()
+	defer fake.buildPlanStatusMutex.Unlock()
+	fake.BuildPlanStatusStub = nil
+	fake.buildPlanStatusReturns = struct {
+		result1 atc.BuildPlanStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildPlanStatusReturnsOnCall(i int, result1 atc.BuildPlanStatus, result2 bool, result3 error) {
+	fake.buildPlanStatusMutex.Lock()
+	defer fake.buildPlanStatusMutex.Unlock()
+	fake.BuildPlanStatusStub = nil
+	if fake.buildPlanStatusReturnsOnCall == nil {
+		fake.buildPlanStatusReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildPlanStatus
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildPlanStatusReturnsOnCall[i] = struct {
+		result1 atc.BuildPlanStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) BuildResources(arg1 int) (atc.BuildInputsOutputs, bool, error) {
 	fake.buildResourcesMutex.Lock()
 	ret, specificReturn := fake.buildResourcesReturnsOnCall[len(fake.buildResourcesArgsForCall)]
@@ -687,6 +919,74 @@ func (fake *FakeClient) BuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) FilteredBuilds(arg1 concourse.Page, arg2 atc.BuildFilter) ([]atc.Build, concourse.Pagination, error) {
+	fake.filteredBuildsMutex.Lock()
+	ret, specificReturn := fake.filteredBuildsReturnsOnCall[len(fake.filteredBuildsArgsForCall)]
+	fake.filteredBuildsArgsForCall = append(fake.filteredBuildsArgsForCall, struct {
+		arg1 concourse.Page
+		arg2 atc.BuildFilter
+	}{arg1, arg2})
+	stub := fake.FilteredBuildsStub
+	fakeReturns := fake.filteredBuildsReturns
+	fake.recordInvocation("FilteredBuilds", []interface{}{arg1, arg2})
+	fake.filteredBuildsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) FilteredBuildsCallCount() int {
+	fake.filteredBuildsMutex.RLock()
+	defer fake.filteredBuildsMutex.RUnlock()
+	return len(fake.filteredBuildsArgsForCall)
+}
+
+func (fake *FakeClient) FilteredBuildsCalls(stub func(concourse.Page, atc.BuildFilter) ([]atc.Build, concourse.Pagination, error)) {
+	fake.filteredBuildsMutex.Lock()
+	defer fake.filteredBuildsMutex.Unlock()
+	fake.FilteredBuildsStub = stub
+}
+
+func (fake *FakeClient) FilteredBuildsArgsForCall(i int) (concourse.Page, atc.BuildFilter) {
+	fake.filteredBuildsMutex.RLock()
+	defer fake.filteredBuildsMutex.RUnlock()
+	argsForCall := fake.filteredBuildsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) FilteredBuildsReturns(result1 []atc.Build, result2 concourse.Pagination, result3 error) {
+	fake.filteredBuildsMutex.Lock()
+	defer fake.filteredBuildsMutex.Unlock()
+	fake.FilteredBuildsStub = nil
+	fake.filteredBuildsReturns = struct {
+		result1 []atc.Build
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) FilteredBuildsReturnsOnCall(i int, result1 []atc.Build, result2 concourse.Pagination, result3 error) {
+	fake.filteredBuildsMutex.Lock()
+	defer fake.filteredBuildsMutex.Unlock()
+	fake.FilteredBuildsStub = nil
+	if fake.filteredBuildsReturnsOnCall == nil {
+		fake.filteredBuildsReturnsOnCall = make(map[int]struct {
+			result1 []atc.Build
+			result2 concourse.Pagination
+			result3 error
+		})
+	}
+	fake.filteredBuildsReturnsOnCall[i] = struct {
+		result1 []atc.Build
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) FindTeam(arg1 string) (concourse.Team, error) {
 	fake.findTeamMutex.Lock()
 	ret, specificReturn := fake.findTeamReturnsOnCall[len(fake.findTeamArgsForCall)]
@@ -1173,6 +1473,70 @@ func (fake *FakeClient) ListBuildArtifactsReturnsOnCall(i int, result1 []atc.Wor
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListBuildPublishedArtifacts(arg1 string) ([]atc.PublishedArtifact, error) {
+	fake.listBuildPublishedArtifactsMutex.Lock()
+	ret, specificReturn := fake.listBuildPublishedArtifactsReturnsOnCall[len(fake.listBuildPublishedArtifactsArgsForCall)]
+	fake.listBuildPublishedArtifactsArgsForCall = append(fake.listBuildPublishedArtifactsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListBuildPublishedArtifactsStub
+	fakeReturns := fake.listBuildPublishedArtifactsReturns
+	fake.recordInvocation("ListBuildPublishedArtifacts", []interface{}{arg1})
+	fake.listBuildPublishedArtifactsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListBuildPublishedArtifactsCallCount() int {
+	fake.listBuildPublishedArtifactsMutex.RLock()
+	defer fake.listBuildPublishedArtifactsMutex.RUnlock()
+	return len(fake.listBuildPublishedArtifactsArgsForCall)
+}
+
+func (fake *FakeClient) ListBuildPublishedArtifactsCalls(stub func(string) ([]atc.PublishedArtifact, error)) {
+	fake.listBuildPublishedArtifactsMutex.Lock()
+	defer fake.listBuildPublishedArtifactsMutex.Unlock()
+	fake.ListBuildPublishedArtifactsStub = stub
+}
+
+func (fake *FakeClient) ListBuildPublishedArtifactsArgsForCall(i int) string {
+	fake.listBuildPublishedArtifactsMutex.RLock()
+	defer fake.listBuildPublishedArtifactsMutex.RUnlock()
+	argsForCall := fake.listBuildPublishedArtifactsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ListBuildPublishedArtifactsReturns(result1 []atc.PublishedArtifact, result2 error) {
+	fake.listBuildPublishedArtifactsMutex.Lock()
+	defer fake.listBuildPublishedArtifactsMutex.Unlock()
+	fake.ListBuildPublishedArtifactsStub = nil
+	fake.listBuildPublishedArtifactsReturns = struct {
+		result1 []atc.PublishedArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListBuildPublishedArtifactsReturnsOnCall(i int, result1 []atc.PublishedArtifact, result2 error) {
+	fake.listBuildPublishedArtifactsMutex.Lock()
+	defer fake.listBuildPublishedArtifactsMutex.Unlock()
+	fake.ListBuildPublishedArtifactsStub = nil
+	if fake.listBuildPublishedArtifactsReturnsOnCall == nil {
+		fake.listBuildPublishedArtifactsReturnsOnCall = make(map[int]struct {
+			result1 []atc.PublishedArtifact
+			result2 error
+		})
+	}
+	fake.listBuildPublishedArtifactsReturnsOnCall[i] = struct {
+		result1 []atc.PublishedArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListPipelines() ([]atc.Pipeline, error) {
 	fake.listPipelinesMutex.Lock()
 	ret, specificReturn := fake.listPipelinesReturnsOnCall[len(fake.listPipelinesArgsForCall)]
@@ -1402,6 +1766,136 @@ func (fake *FakeClient) PruneWorkerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) RawBuildEvents(arg1 string, arg2 *int) (concourse.RawEvents, error) {
+	fake.rawBuildEventsMutex.Lock()
+	ret, specificReturn := fake.rawBuildEventsReturnsOnCall[len(fake.rawBuildEventsArgsForCall)]
+	fake.rawBuildEventsArgsForCall = append(fake.rawBuildEventsArgsForCall, struct {
+		arg1 string
+		arg2 *int
+	}{arg1, arg2})
+	stub := fake.RawBuildEventsStub
+	fakeReturns := fake.rawBuildEventsReturns
+	fake.recordInvocation("RawBuildEvents", []interface{}{arg1, arg2})
+	fake.rawBuildEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RawBuildEventsCallCount() int {
+	fake.rawBuildEventsMutex.RLock()
+	defer fake.rawBuildEventsMutex.RUnlock()
+	return len(fake.rawBuildEventsArgsForCall)
+}
+
+func (fake *FakeClient) RawBuildEventsCalls(stub func(string, *int) (concourse.RawEvents, error)) {
+	fake.rawBuildEventsMutex.Lock()
+	defer fake.rawBuildEventsMutex.Unlock()
+	fake.RawBuildEventsStub = stub
+}
+
+func (fake *FakeClient) RawBuildEventsArgsForCall(i int) (string, *int) {
+	fake.rawBuildEventsMutex.RLock()
+	defer fake.rawBuildEventsMutex.RUnlock()
+	argsForCall := fake.rawBuildEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) RawBuildEventsReturns(result1 concourse.RawEvents, result2 error) {
+	fake.rawBuildEventsMutex.Lock()
+	defer fake.rawBuildEventsMutex.Unlock()
+	fake.RawBuildEventsStub = nil
+	fake.rawBuildEventsReturns = struct {
+		result1 concourse.RawEvents
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RawBuildEventsReturnsOnCall(i int, result1 concourse.RawEvents, result2 error) {
+	fake.rawBuildEventsMutex.Lock()
+	defer fake.rawBuildEventsMutex.Unlock()
+	fake.RawBuildEventsStub = nil
+	if fake.rawBuildEventsReturnsOnCall == nil {
+		fake.rawBuildEventsReturnsOnCall = make(map[int]struct {
+			result1 concourse.RawEvents
+			result2 error
+		})
+	}
+	fake.rawBuildEventsReturnsOnCall[i] = struct {
+		result1 concourse.RawEvents
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RejectBuild(arg1 string, arg2 string) (bool, error) {
+	fake.rejectBuildMutex.Lock()
+	ret, specificReturn := fake.rejectBuildReturnsOnCall[len(fake.rejectBuildArgsForCall)]
+	fake.rejectBuildArgsForCall = append(fake.rejectBuildArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RejectBuildStub
+	fakeReturns := fake.rejectBuildReturns
+	fake.recordInvocation("RejectBuild", []interface{}{arg1, arg2})
+	fake.rejectBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RejectBuildCallCount() int {
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
+	return len(fake.rejectBuildArgsForCall)
+}
+
+func (fake *FakeClient) RejectBuildCalls(stub func(string, string) (bool, error)) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = stub
+}
+
+func (fake *FakeClient) RejectBuildArgsForCall(i int) (string, string) {
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
+	argsForCall := fake.rejectBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) RejectBuildReturns(result1 bool, result2 error) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = nil
+	fake.rejectBuildReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RejectBuildReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = nil
+	if fake.rejectBuildReturnsOnCall == nil {
+		fake.rejectBuildReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.rejectBuildReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) SaveWorker(arg1 atc.Worker, arg2 *time.Duration) (*atc.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1467,6 +1961,71 @@ func (fake *FakeClient) SaveWorkerReturnsOnCall(i int, result1 *atc.Worker, resu
 	}{result1, result2}
 }
 
+func (fake *FakeClient) Search(arg1 string, arg2 int) ([]atc.SearchResult, error) {
+	fake.searchMutex.Lock()
+	ret, specificReturn := fake.searchReturnsOnCall[len(fake.searchArgsForCall)]
+	fake.searchArgsForCall = append(fake.searchArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.SearchStub
+	fakeReturns := fake.searchReturns
+	fake.recordInvocation("Search", []interface{}{arg1, arg2})
+	fake.searchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) SearchCallCount() int {
+	fake.searchMutex.RLock()
+	defer fake.searchMutex.RUnlock()
+	return len(fake.searchArgsForCall)
+}
+
+func (fake *FakeClient) SearchCalls(stub func(string, int) ([]atc.SearchResult, error)) {
+	fake.searchMutex.Lock()
+	defer fake.searchMutex.Unlock()
+	fake.SearchStub = stub
+}
+
+func (fake *FakeClient) SearchArgsForCall(i int) (string, int) {
+	fake.searchMutex.RLock()
+	defer fake.searchMutex.RUnlock()
+	argsForCall := fake.searchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) SearchReturns(result1 []atc.SearchResult, result2 error) {
+	fake.searchMutex.Lock()
+	defer fake.searchMutex.Unlock()
+	fake.SearchStub = nil
+	fake.searchReturns = struct {
+		result1 []atc.SearchResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SearchReturnsOnCall(i int, result1 []atc.SearchResult, result2 error) {
+	fake.searchMutex.Lock()
+	defer fake.searchMutex.Unlock()
+	fake.SearchStub = nil
+	if fake.searchReturnsOnCall == nil {
+		fake.searchReturnsOnCall = make(map[int]struct {
+			result1 []atc.SearchResult
+			result2 error
+		})
+	}
+	fake.searchReturnsOnCall[i] = struct {
+		result1 []atc.SearchResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Team(arg1 string) concourse.Team {
 	fake.teamMutex.Lock()
 	ret, specificReturn := fake.teamReturnsOnCall[len(fake.teamArgsForCall)]
@@ -1642,16 +2201,22 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.abortBuildMutex.RLock()
 	defer fake.abortBuildMutex.RUnlock()
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
 	fake.buildEventsMutex.RLock()
 	defer fake.buildEventsMutex.RUnlock()
 	fake.buildPlanMutex.RLock()
 	defer fake.buildPlanMutex.RUnlock()
+	fake.buildPlanStatusMutex.RLock()
+	defer fake.buildPlanStatusMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
+	fake.filteredBuildsMutex.RLock()
+	defer fake.filteredBuildsMutex.RUnlock()
 	fake.findTeamMutex.RLock()
 	defer fake.findTeamMutex.RUnlock()
 	fake.getCLIReaderMutex.RLock()
@@ -1668,6 +2233,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listAllJobsMutex.RUnlock()
 	fake.listBuildArtifactsMutex.RLock()
 	defer fake.listBuildArtifactsMutex.RUnlock()
+	fake.listBuildPublishedArtifactsMutex.RLock()
+	defer fake.listBuildPublishedArtifactsMutex.RUnlock()
 	fake.listPipelinesMutex.RLock()
 	defer fake.listPipelinesMutex.RUnlock()
 	fake.listTeamsMutex.RLock()
@@ -1676,8 +2243,14 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listWorkersMutex.RUnlock()
 	fake.pruneWorkerMutex.RLock()
 	defer fake.pruneWorkerMutex.RUnlock()
+	fake.rawBuildEventsMutex.RLock()
+	defer fake.rawBuildEventsMutex.RUnlock()
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.searchMutex.RLock()
+	defer fake.searchMutex.RUnlock()
 	fake.teamMutex.RLock()
 	defer fake.teamMutex.RUnlock()
 	fake.uRLMutex.RLock()
@@ -4,6 +4,7 @@ package concoursefakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse"
@@ -200,6 +201,21 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	CreateJobBuildWithInputsStub        func(atc.PipelineRef, string, map[string]atc.Version) (atc.Build, error)
+	createJobBuildWithInputsMutex       sync.RWMutex
+	createJobBuildWithInputsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 map[string]atc.Version
+	}
+	createJobBuildWithInputsReturns struct {
+		result1 atc.Build
+		result2 error
+	}
+	createJobBuildWithInputsReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 error
+	}
 	CreateOrUpdateStub        func(atc.Team) (atc.Team, bool, bool, []concourse.ConfigWarning, error)
 	createOrUpdateMutex       sync.RWMutex
 	createOrUpdateArgsForCall []struct {
@@ -253,6 +269,21 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	CreateServiceAccountStub        func(string, string, time.Duration) (atc.ServiceAccountToken, error)
+	createServiceAccountMutex       sync.RWMutex
+	createServiceAccountArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 time.Duration
+	}
+	createServiceAccountReturns struct {
+		result1 atc.ServiceAccountToken
+		result2 error
+	}
+	createServiceAccountReturnsOnCall map[int]struct {
+		result1 atc.ServiceAccountToken
+		result2 error
+	}
 	DeletePipelineStub        func(atc.PipelineRef) (bool, error)
 	deletePipelineMutex       sync.RWMutex
 	deletePipelineArgsForCall []struct {
@@ -266,6 +297,45 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	DeletePipelineTemplateStub        func(string) (bool, error)
+	deletePipelineTemplateMutex       sync.RWMutex
+	deletePipelineTemplateArgsForCall []struct {
+		arg1 string
+	}
+	deletePipelineTemplateReturns struct {
+		result1 bool
+		result2 error
+	}
+	deletePipelineTemplateReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	DeleteServiceAccountStub        func(string) (bool, error)
+	deleteServiceAccountMutex       sync.RWMutex
+	deleteServiceAccountArgsForCall []struct {
+		arg1 string
+	}
+	deleteServiceAccountReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteServiceAccountReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	DeleteWebhookStub        func(string) (bool, error)
+	deleteWebhookMutex       sync.RWMutex
+	deleteWebhookArgsForCall []struct {
+		arg1 string
+	}
+	deleteWebhookReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteWebhookReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DestroyTeamStub        func(string) error
 	destroyTeamMutex       sync.RWMutex
 	destroyTeamArgsForCall []struct {
@@ -447,6 +517,18 @@ type FakeTeam struct {
 		result1 []atc.Job
 		result2 error
 	}
+	ListPipelineTemplatesStub        func() ([]atc.PipelineTemplate, error)
+	listPipelineTemplatesMutex       sync.RWMutex
+	listPipelineTemplatesArgsForCall []struct {
+	}
+	listPipelineTemplatesReturns struct {
+		result1 []atc.PipelineTemplate
+		result2 error
+	}
+	listPipelineTemplatesReturnsOnCall map[int]struct {
+		result1 []atc.PipelineTemplate
+		result2 error
+	}
 	ListPipelinesStub        func() ([]atc.Pipeline, error)
 	listPipelinesMutex       sync.RWMutex
 	listPipelinesArgsForCall []struct {
@@ -472,6 +554,18 @@ type FakeTeam struct {
 		result1 []atc.Resource
 		result2 error
 	}
+	ListServiceAccountsStub        func() ([]atc.ServiceAccount, error)
+	listServiceAccountsMutex       sync.RWMutex
+	listServiceAccountsArgsForCall []struct {
+	}
+	listServiceAccountsReturns struct {
+		result1 []atc.ServiceAccount
+		result2 error
+	}
+	listServiceAccountsReturnsOnCall map[int]struct {
+		result1 []atc.ServiceAccount
+		result2 error
+	}
 	ListVolumesStub        func() ([]atc.Volume, error)
 	listVolumesMutex       sync.RWMutex
 	listVolumesArgsForCall []struct {
@@ -484,6 +578,18 @@ type FakeTeam struct {
 		result1 []atc.Volume
 		result2 error
 	}
+	ListWebhooksStub        func() ([]atc.TeamWebhook, error)
+	listWebhooksMutex       sync.RWMutex
+	listWebhooksArgsForCall []struct {
+	}
+	listWebhooksReturns struct {
+		result1 []atc.TeamWebhook
+		result2 error
+	}
+	listWebhooksReturnsOnCall map[int]struct {
+		result1 []atc.TeamWebhook
+		result2 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -517,11 +623,12 @@ type FakeTeam struct {
 	orderingPipelinesWithinGroupReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PauseJobStub        func(atc.PipelineRef, string) (bool, error)
+	PauseJobStub        func(atc.PipelineRef, string, string) (bool, error)
 	pauseJobMutex       sync.RWMutex
 	pauseJobArgsForCall []struct {
 		arg1 atc.PipelineRef
 		arg2 string
+		arg3 string
 	}
 	pauseJobReturns struct {
 		result1 bool
@@ -531,10 +638,28 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	PausePipelineStub        func(atc.PipelineRef) (bool, error)
+	PauseJobsStub        func(atc.PipelineRef, []string, string) ([]string, bool, error)
+	pauseJobsMutex       sync.RWMutex
+	pauseJobsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 []string
+		arg3 string
+	}
+	pauseJobsReturns struct {
+		result1 []string
+		result2 bool
+		result3 error
+	}
+	pauseJobsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 bool
+		result3 error
+	}
+	PausePipelineStub        func(atc.PipelineRef, string) (bool, error)
 	pausePipelineMutex       sync.RWMutex
 	pausePipelineArgsForCall []struct {
 		arg1 atc.PipelineRef
+		arg2 string
 	}
 	pausePipelineReturns struct {
 		result1 bool
@@ -609,6 +734,46 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	PipelineVarSourcesStub        func(atc.PipelineRef, []string) ([]atc.VarSourceStatus, bool, error)
+	pipelineVarSourcesMutex       sync.RWMutex
+	pipelineVarSourcesArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 []string
+	}
+	pipelineVarSourcesReturns struct {
+		result1 []atc.VarSourceStatus
+		result2 bool
+		result3 error
+	}
+	pipelineVarSourcesReturnsOnCall map[int]struct {
+		result1 []atc.VarSourceStatus
+		result2 bool
+		result3 error
+	}
+	PrunableStateStub        func() (atc.TeamPrunableState, error)
+	prunableStateMutex       sync.RWMutex
+	prunableStateArgsForCall []struct {
+	}
+	prunableStateReturns struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}
+	prunableStateReturnsOnCall map[int]struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}
+	PruneStateStub        func() (atc.TeamPrunableState, error)
+	pruneStateMutex       sync.RWMutex
+	pruneStateArgsForCall []struct {
+	}
+	pruneStateReturns struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}
+	pruneStateReturnsOnCall map[int]struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}
 	RenamePipelineStub        func(string, string) (bool, []concourse.ConfigWarning, error)
 	renamePipelineMutex       sync.RWMutex
 	renamePipelineArgsForCall []struct {
@@ -656,6 +821,22 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	RerunJobBuildWithVarsStub        func(atc.PipelineRef, string, string, map[string]interface{}) (atc.Build, error)
+	rerunJobBuildWithVarsMutex       sync.RWMutex
+	rerunJobBuildWithVarsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+		arg4 map[string]interface{}
+	}
+	rerunJobBuildWithVarsReturns struct {
+		result1 atc.Build
+		result2 error
+	}
+	rerunJobBuildWithVarsReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 error
+	}
 	ResourceStub        func(atc.PipelineRef, string) (atc.Resource, bool, error)
 	resourceMutex       sync.RWMutex
 	resourceArgsForCall []struct {
@@ -721,6 +902,32 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	SetPipelineTemplateStub        func(atc.PipelineTemplate) (bool, error)
+	setPipelineTemplateMutex       sync.RWMutex
+	setPipelineTemplateArgsForCall []struct {
+		arg1 atc.PipelineTemplate
+	}
+	setPipelineTemplateReturns struct {
+		result1 bool
+		result2 error
+	}
+	setPipelineTemplateReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SetWebhookStub        func(atc.TeamWebhook) (bool, error)
+	setWebhookMutex       sync.RWMutex
+	setWebhookArgsForCall []struct {
+		arg1 atc.TeamWebhook
+	}
+	setWebhookReturns struct {
+		result1 bool
+		result2 error
+	}
+	setWebhookReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	UnpauseJobStub        func(atc.PipelineRef, string) (bool, error)
 	unpauseJobMutex       sync.RWMutex
 	unpauseJobArgsForCall []struct {
@@ -735,6 +942,22 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	UnpauseJobsStub        func(atc.PipelineRef, []string) ([]string, bool, error)
+	unpauseJobsMutex       sync.RWMutex
+	unpauseJobsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 []string
+	}
+	unpauseJobsReturns struct {
+		result1 []string
+		result2 bool
+		result3 error
+	}
+	unpauseJobsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 bool
+		result3 error
+	}
 	UnpausePipelineStub        func(atc.PipelineRef) (bool, error)
 	unpausePipelineMutex       sync.RWMutex
 	unpausePipelineArgsForCall []struct {
@@ -1629,6 +1852,72 @@ func (fake *FakeTeam) CreateJobBuildReturnsOnCall(i int, result1 atc.Build, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateJobBuildWithInputs(arg1 atc.PipelineRef, arg2 string, arg3 map[string]atc.Version) (atc.Build, error) {
+	fake.createJobBuildWithInputsMutex.Lock()
+	ret, specificReturn := fake.createJobBuildWithInputsReturnsOnCall[len(fake.createJobBuildWithInputsArgsForCall)]
+	fake.createJobBuildWithInputsArgsForCall = append(fake.createJobBuildWithInputsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 map[string]atc.Version
+	}{arg1, arg2, arg3})
+	stub := fake.CreateJobBuildWithInputsStub
+	fakeReturns := fake.createJobBuildWithInputsReturns
+	fake.recordInvocation("CreateJobBuildWithInputs", []interface{}{arg1, arg2, arg3})
+	fake.createJobBuildWithInputsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateJobBuildWithInputsCallCount() int {
+	fake.createJobBuildWithInputsMutex.RLock()
+	defer fake.createJobBuildWithInputsMutex.RUnlock()
+	return len(fake.createJobBuildWithInputsArgsForCall)
+}
+
+func (fake *FakeTeam) CreateJobBuildWithInputsCalls(stub func(atc.PipelineRef, string, map[string]atc.Version) (atc.Build, error)) {
+	fake.createJobBuildWithInputsMutex.Lock()
+	defer fake.createJobBuildWithInputsMutex.Unlock()
+	fake.CreateJobBuildWithInputsStub = stub
+}
+
+func (fake *FakeTeam) CreateJobBuildWithInputsArgsForCall(i int) (atc.PipelineRef, string, map[string]atc.Version) {
+	fake.createJobBuildWithInputsMutex.RLock()
+	defer fake.createJobBuildWithInputsMutex.RUnlock()
+	argsForCall := fake.createJobBuildWithInputsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) CreateJobBuildWithInputsReturns(result1 atc.Build, result2 error) {
+	fake.createJobBuildWithInputsMutex.Lock()
+	defer fake.createJobBuildWithInputsMutex.Unlock()
+	fake.CreateJobBuildWithInputsStub = nil
+	fake.createJobBuildWithInputsReturns = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateJobBuildWithInputsReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.createJobBuildWithInputsMutex.Lock()
+	defer fake.createJobBuildWithInputsMutex.Unlock()
+	fake.CreateJobBuildWithInputsStub = nil
+	if fake.createJobBuildWithInputsReturnsOnCall == nil {
+		fake.createJobBuildWithInputsReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 error
+		})
+	}
+	fake.createJobBuildWithInputsReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateOrUpdate(arg1 atc.Team) (atc.Team, bool, bool, []concourse.ConfigWarning, error) {
 	fake.createOrUpdateMutex.Lock()
 	ret, specificReturn := fake.createOrUpdateReturnsOnCall[len(fake.createOrUpdateArgsForCall)]
@@ -1845,6 +2134,72 @@ func (fake *FakeTeam) CreatePipelineBuildReturnsOnCall(i int, result1 atc.Build,
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateServiceAccount(arg1 string, arg2 string, arg3 time.Duration) (atc.ServiceAccountToken, error) {
+	fake.createServiceAccountMutex.Lock()
+	ret, specificReturn := fake.createServiceAccountReturnsOnCall[len(fake.createServiceAccountArgsForCall)]
+	fake.createServiceAccountArgsForCall = append(fake.createServiceAccountArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.CreateServiceAccountStub
+	fakeReturns := fake.createServiceAccountReturns
+	fake.recordInvocation("CreateServiceAccount", []interface{}{arg1, arg2, arg3})
+	fake.createServiceAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateServiceAccountCallCount() int {
+	fake.createServiceAccountMutex.RLock()
+	defer fake.createServiceAccountMutex.RUnlock()
+	return len(fake.createServiceAccountArgsForCall)
+}
+
+func (fake *FakeTeam) CreateServiceAccountCalls(stub func(string, string, time.Duration) (atc.ServiceAccountToken, error)) {
+	fake.createServiceAccountMutex.Lock()
+	defer fake.createServiceAccountMutex.Unlock()
+	fake.CreateServiceAccountStub = stub
+}
+
+func (fake *FakeTeam) CreateServiceAccountArgsForCall(i int) (string, string, time.Duration) {
+	fake.createServiceAccountMutex.RLock()
+	defer fake.createServiceAccountMutex.RUnlock()
+	argsForCall := fake.createServiceAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) CreateServiceAccountReturns(result1 atc.ServiceAccountToken, result2 error) {
+	fake.createServiceAccountMutex.Lock()
+	defer fake.createServiceAccountMutex.Unlock()
+	fake.CreateServiceAccountStub = nil
+	fake.createServiceAccountReturns = struct {
+		result1 atc.ServiceAccountToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateServiceAccountReturnsOnCall(i int, result1 atc.ServiceAccountToken, result2 error) {
+	fake.createServiceAccountMutex.Lock()
+	defer fake.createServiceAccountMutex.Unlock()
+	fake.CreateServiceAccountStub = nil
+	if fake.createServiceAccountReturnsOnCall == nil {
+		fake.createServiceAccountReturnsOnCall = make(map[int]struct {
+			result1 atc.ServiceAccountToken
+			result2 error
+		})
+	}
+	fake.createServiceAccountReturnsOnCall[i] = struct {
+		result1 atc.ServiceAccountToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) DeletePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.deletePipelineMutex.Lock()
 	ret, specificReturn := fake.deletePipelineReturnsOnCall[len(fake.deletePipelineArgsForCall)]
@@ -1909,81 +2264,82 @@ func (fake *FakeTeam) DeletePipelineReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) DestroyTeam(arg1 string) error {
-	fake.destroyTeamMutex.Lock()
-	ret, specificReturn := fake.destroyTeamReturnsOnCall[len(fake.destroyTeamArgsForCall)]
-	fake.destroyTeamArgsForCall = append(fake.destroyTeamArgsForCall, struct {
+func (fake *FakeTeam) DeletePipelineTemplate(arg1 string) (bool, error) {
+	fake.deletePipelineTemplateMutex.Lock()
+	ret, specificReturn := fake.deletePipelineTemplateReturnsOnCall[len(fake.deletePipelineTemplateArgsForCall)]
+	fake.deletePipelineTemplateArgsForCall = append(fake.deletePipelineTemplateArgsForCall, struct {
 		arg1 string
 	}{arg1})
-	stub := fake.DestroyTeamStub
-	fakeReturns := fake.destroyTeamReturns
-	fake.recordInvocation("DestroyTeam", []interface{}{arg1})
-	fake.destroyTeamMutex.Unlock()
+	stub := fake.DeletePipelineTemplateStub
+	fakeReturns := fake.deletePipelineTemplateReturns
+	fake.recordInvocation("DeletePipelineTemplate", []interface{}{arg1})
+	fake.deletePipelineTemplateMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeTeam) DestroyTeamCallCount() int {
-	fake.destroyTeamMutex.RLock()
-	defer fake.destroyTeamMutex.RUnlock()
-	return len(fake.destroyTeamArgsForCall)
+func (fake *FakeTeam) DeletePipelineTemplateCallCount() int {
+	fake.deletePipelineTemplateMutex.RLock()
+	defer fake.deletePipelineTemplateMutex.RUnlock()
+	return len(fake.deletePipelineTemplateArgsForCall)
 }
 
-func (fake *FakeTeam) DestroyTeamCalls(stub func(string) error) {
-	fake.destroyTeamMutex.Lock()
-	defer fake.destroyTeamMutex.Unlock()
-	fake.DestroyTeamStub = stub
+func (fake *FakeTeam) DeletePipelineTemplateCalls(stub func(string) (bool, error)) {
+	fake.deletePipelineTemplateMutex.Lock()
+	defer fake.deletePipelineTemplateMutex.Unlock()
+	fake.DeletePipelineTemplateStub = stub
 }
 
-func (fake *FakeTeam) DestroyTeamArgsForCall(i int) string {
-	fake.destroyTeamMutex.RLock()
-	defer fake.destroyTeamMutex.RUnlock()
-	argsForCall := fake.destroyTeamArgsForCall[i]
+func (fake *FakeTeam) DeletePipelineTemplateArgsForCall(i int) string {
+	fake.deletePipelineTemplateMutex.RLock()
+	defer fake.deletePipelineTemplateMutex.RUnlock()
+	argsForCall := fake.deletePipelineTemplateArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeTeam) DestroyTeamReturns(result1 error) {
-	fake.destroyTeamMutex.Lock()
-	defer fake.destroyTeamMutex.Unlock()
-	fake.DestroyTeamStub = nil
-	fake.destroyTeamReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeTeam) DeletePipelineTemplateReturns(result1 bool, result2 error) {
+	fake.deletePipelineTemplateMutex.Lock()
+	defer fake.deletePipelineTemplateMutex.Unlock()
+	fake.DeletePipelineTemplateStub = nil
+	fake.deletePipelineTemplateReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTeam) DestroyTeamReturnsOnCall(i int, result1 error) {
-	fake.destroyTeamMutex.Lock()
-	defer fake.destroyTeamMutex.Unlock()
-	fake.DestroyTeamStub = nil
-	if fake.destroyTeamReturnsOnCall == nil {
-		fake.destroyTeamReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeTeam) DeletePipelineTemplateReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deletePipelineTemplateMutex.Lock()
+	defer fake.deletePipelineTemplateMutex.Unlock()
+	fake.DeletePipelineTemplateStub = nil
+	if fake.deletePipelineTemplateReturnsOnCall == nil {
+		fake.deletePipelineTemplateReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
 		})
 	}
-	fake.destroyTeamReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.deletePipelineTemplateReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTeam) DisableResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
-	fake.disableResourceVersionMutex.Lock()
-	ret, specificReturn := fake.disableResourceVersionReturnsOnCall[len(fake.disableResourceVersionArgsForCall)]
-	fake.disableResourceVersionArgsForCall = append(fake.disableResourceVersionArgsForCall, struct {
-		arg1 atc.PipelineRef
-		arg2 string
-		arg3 int
-	}{arg1, arg2, arg3})
-	stub := fake.DisableResourceVersionStub
-	fakeReturns := fake.disableResourceVersionReturns
-	fake.recordInvocation("DisableResourceVersion", []interface{}{arg1, arg2, arg3})
-	fake.disableResourceVersionMutex.Unlock()
+func (fake *FakeTeam) DeleteServiceAccount(arg1 string) (bool, error) {
+	fake.deleteServiceAccountMutex.Lock()
+	ret, specificReturn := fake.deleteServiceAccountReturnsOnCall[len(fake.deleteServiceAccountArgsForCall)]
+	fake.deleteServiceAccountArgsForCall = append(fake.deleteServiceAccountArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteServiceAccountStub
+	fakeReturns := fake.deleteServiceAccountReturns
+	fake.recordInvocation("DeleteServiceAccount", []interface{}{arg1})
+	fake.deleteServiceAccountMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1991,22 +2347,213 @@ func (fake *FakeTeam) DisableResourceVersion(arg1 atc.PipelineRef, arg2 string,
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeTeam) DisableResourceVersionCallCount() int {
-	fake.disableResourceVersionMutex.RLock()
-	defer fake.disableResourceVersionMutex.RUnlock()
-	return len(fake.disableResourceVersionArgsForCall)
+func (fake *FakeTeam) DeleteServiceAccountCallCount() int {
+	fake.deleteServiceAccountMutex.RLock()
+	defer fake.deleteServiceAccountMutex.RUnlock()
+	return len(fake.deleteServiceAccountArgsForCall)
 }
 
-func (fake *FakeTeam) DisableResourceVersionCalls(stub func(atc.PipelineRef, string, int) (bool, error)) {
-	fake.disableResourceVersionMutex.Lock()
-	defer fake.disableResourceVersionMutex.Unlock()
-	fake.DisableResourceVersionStub = stub
+func (fake *FakeTeam) DeleteServiceAccountCalls(stub func(string) (bool, error)) {
+	fake.deleteServiceAccountMutex.Lock()
+	defer fake.deleteServiceAccountMutex.Unlock()
+	fake.DeleteServiceAccountStub = stub
 }
 
-func (fake *FakeTeam) DisableResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int) {
-	fake.disableResourceVersionMutex.RLock()
-	defer fake.disableResourceVersionMutex.RUnlock()
-	argsForCall := fake.disableResourceVersionArgsForCall[i]
+func (fake *FakeTeam) DeleteServiceAccountArgsForCall(i int) string {
+	fake.deleteServiceAccountMutex.RLock()
+	defer fake.deleteServiceAccountMutex.RUnlock()
+	argsForCall := fake.deleteServiceAccountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) DeleteServiceAccountReturns(result1 bool, result2 error) {
+	fake.deleteServiceAccountMutex.Lock()
+	defer fake.deleteServiceAccountMutex.Unlock()
+	fake.DeleteServiceAccountStub = nil
+	fake.deleteServiceAccountReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteServiceAccountReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteServiceAccountMutex.Lock()
+	defer fake.deleteServiceAccountMutex.Unlock()
+	fake.DeleteServiceAccountStub = nil
+	if fake.deleteServiceAccountReturnsOnCall == nil {
+		fake.deleteServiceAccountReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteServiceAccountReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteWebhook(arg1 string) (bool, error) {
+	fake.deleteWebhookMutex.Lock()
+	ret, specificReturn := fake.deleteWebhookReturnsOnCall[len(fake.deleteWebhookArgsForCall)]
+	fake.deleteWebhookArgsForCall = append(fake.deleteWebhookArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteWebhookStub
+	fakeReturns := fake.deleteWebhookReturns
+	fake.recordInvocation("DeleteWebhook", []interface{}{arg1})
+	fake.deleteWebhookMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DeleteWebhookCallCount() int {
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
+	return len(fake.deleteWebhookArgsForCall)
+}
+
+func (fake *FakeTeam) DeleteWebhookCalls(stub func(string) (bool, error)) {
+	fake.deleteWebhookMutex.Lock()
+	defer fake.deleteWebhookMutex.Unlock()
+	fake.DeleteWebhookStub = stub
+}
+
+func (fake *FakeTeam) DeleteWebhookArgsForCall(i int) string {
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
+	argsForCall := fake.deleteWebhookArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) DeleteWebhookReturns(result1 bool, result2 error) {
+	fake.deleteWebhookMutex.Lock()
+	defer fake.deleteWebhookMutex.Unlock()
+	fake.DeleteWebhookStub = nil
+	fake.deleteWebhookReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteWebhookReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteWebhookMutex.Lock()
+	defer fake.deleteWebhookMutex.Unlock()
+	fake.DeleteWebhookStub = nil
+	if fake.deleteWebhookReturnsOnCall == nil {
+		fake.deleteWebhookReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteWebhookReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DestroyTeam(arg1 string) error {
+	fake.destroyTeamMutex.Lock()
+	ret, specificReturn := fake.destroyTeamReturnsOnCall[len(fake.destroyTeamArgsForCall)]
+	fake.destroyTeamArgsForCall = append(fake.destroyTeamArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DestroyTeamStub
+	fakeReturns := fake.destroyTeamReturns
+	fake.recordInvocation("DestroyTeam", []interface{}{arg1})
+	fake.destroyTeamMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) DestroyTeamCallCount() int {
+	fake.destroyTeamMutex.RLock()
+	defer fake.destroyTeamMutex.RUnlock()
+	return len(fake.destroyTeamArgsForCall)
+}
+
+func (fake *FakeTeam) DestroyTeamCalls(stub func(string) error) {
+	fake.destroyTeamMutex.Lock()
+	defer fake.destroyTeamMutex.Unlock()
+	fake.DestroyTeamStub = stub
+}
+
+func (fake *FakeTeam) DestroyTeamArgsForCall(i int) string {
+	fake.destroyTeamMutex.RLock()
+	defer fake.destroyTeamMutex.RUnlock()
+	argsForCall := fake.destroyTeamArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) DestroyTeamReturns(result1 error) {
+	fake.destroyTeamMutex.Lock()
+	defer fake.destroyTeamMutex.Unlock()
+	fake.DestroyTeamStub = nil
+	fake.destroyTeamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) DestroyTeamReturnsOnCall(i int, result1 error) {
+	fake.destroyTeamMutex.Lock()
+	defer fake.destroyTeamMutex.Unlock()
+	fake.DestroyTeamStub = nil
+	if fake.destroyTeamReturnsOnCall == nil {
+		fake.destroyTeamReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.destroyTeamReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) DisableResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
+	fake.disableResourceVersionMutex.Lock()
+	ret, specificReturn := fake.disableResourceVersionReturnsOnCall[len(fake.disableResourceVersionArgsForCall)]
+	fake.disableResourceVersionArgsForCall = append(fake.disableResourceVersionArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.DisableResourceVersionStub
+	fakeReturns := fake.disableResourceVersionReturns
+	fake.recordInvocation("DisableResourceVersion", []interface{}{arg1, arg2, arg3})
+	fake.disableResourceVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DisableResourceVersionCallCount() int {
+	fake.disableResourceVersionMutex.RLock()
+	defer fake.disableResourceVersionMutex.RUnlock()
+	return len(fake.disableResourceVersionArgsForCall)
+}
+
+func (fake *FakeTeam) DisableResourceVersionCalls(stub func(atc.PipelineRef, string, int) (bool, error)) {
+	fake.disableResourceVersionMutex.Lock()
+	defer fake.disableResourceVersionMutex.Unlock()
+	fake.DisableResourceVersionStub = stub
+}
+
+func (fake *FakeTeam) DisableResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int) {
+	fake.disableResourceVersionMutex.RLock()
+	defer fake.disableResourceVersionMutex.RUnlock()
+	argsForCall := fake.disableResourceVersionArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
@@ -2748,6 +3295,62 @@ func (fake *FakeTeam) ListJobsReturnsOnCall(i int, result1 []atc.Job, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ListPipelineTemplates() ([]atc.PipelineTemplate, error) {
+	fake.listPipelineTemplatesMutex.Lock()
+	ret, specificReturn := fake.listPipelineTemplatesReturnsOnCall[len(fake.listPipelineTemplatesArgsForCall)]
+	fake.listPipelineTemplatesArgsForCall = append(fake.listPipelineTemplatesArgsForCall, struct {
+	}{})
+	stub := fake.ListPipelineTemplatesStub
+	fakeReturns := fake.listPipelineTemplatesReturns
+	fake.recordInvocation("ListPipelineTemplates", []interface{}{})
+	fake.listPipelineTemplatesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ListPipelineTemplatesCallCount() int {
+	fake.listPipelineTemplatesMutex.RLock()
+	defer fake.listPipelineTemplatesMutex.RUnlock()
+	return len(fake.listPipelineTemplatesArgsForCall)
+}
+
+func (fake *FakeTeam) ListPipelineTemplatesCalls(stub func() ([]atc.PipelineTemplate, error)) {
+	fake.listPipelineTemplatesMutex.Lock()
+	defer fake.listPipelineTemplatesMutex.Unlock()
+	fake.ListPipelineTemplatesStub = stub
+}
+
+func (fake *FakeTeam) ListPipelineTemplatesReturns(result1 []atc.PipelineTemplate, result2 error) {
+	fake.listPipelineTemplatesMutex.Lock()
+	defer fake.listPipelineTemplatesMutex.Unlock()
+	fake.ListPipelineTemplatesStub = nil
+	fake.listPipelineTemplatesReturns = struct {
+		result1 []atc.PipelineTemplate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListPipelineTemplatesReturnsOnCall(i int, result1 []atc.PipelineTemplate, result2 error) {
+	fake.listPipelineTemplatesMutex.Lock()
+	defer fake.listPipelineTemplatesMutex.Unlock()
+	fake.ListPipelineTemplatesStub = nil
+	if fake.listPipelineTemplatesReturnsOnCall == nil {
+		fake.listPipelineTemplatesReturnsOnCall = make(map[int]struct {
+			result1 []atc.PipelineTemplate
+			result2 error
+		})
+	}
+	fake.listPipelineTemplatesReturnsOnCall[i] = struct {
+		result1 []atc.PipelineTemplate
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ListPipelines() ([]atc.Pipeline, error) {
 	fake.listPipelinesMutex.Lock()
 	ret, specificReturn := fake.listPipelinesReturnsOnCall[len(fake.listPipelinesArgsForCall)]
@@ -2868,6 +3471,62 @@ func (fake *FakeTeam) ListResourcesReturnsOnCall(i int, result1 []atc.Resource,
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ListServiceAccounts() ([]atc.ServiceAccount, error) {
+	fake.listServiceAccountsMutex.Lock()
+	ret, specificReturn := fake.listServiceAccountsReturnsOnCall[len(fake.listServiceAccountsArgsForCall)]
+	fake.listServiceAccountsArgsForCall = append(fake.listServiceAccountsArgsForCall, struct {
+	}{})
+	stub := fake.ListServiceAccountsStub
+	fakeReturns := fake.listServiceAccountsReturns
+	fake.recordInvocation("ListServiceAccounts", []interface{}{})
+	fake.listServiceAccountsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ListServiceAccountsCallCount() int {
+	fake.listServiceAccountsMutex.RLock()
+	defer fake.listServiceAccountsMutex.RUnlock()
+	return len(fake.listServiceAccountsArgsForCall)
+}
+
+func (fake *FakeTeam) ListServiceAccountsCalls(stub func() ([]atc.ServiceAccount, error)) {
+	fake.listServiceAccountsMutex.Lock()
+	defer fake.listServiceAccountsMutex.Unlock()
+	fake.ListServiceAccountsStub = stub
+}
+
+func (fake *FakeTeam) ListServiceAccountsReturns(result1 []atc.ServiceAccount, result2 error) {
+	fake.listServiceAccountsMutex.Lock()
+	defer fake.listServiceAccountsMutex.Unlock()
+	fake.ListServiceAccountsStub = nil
+	fake.listServiceAccountsReturns = struct {
+		result1 []atc.ServiceAccount
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListServiceAccountsReturnsOnCall(i int, result1 []atc.ServiceAccount, result2 error) {
+	fake.listServiceAccountsMutex.Lock()
+	defer fake.listServiceAccountsMutex.Unlock()
+	fake.ListServiceAccountsStub = nil
+	if fake.listServiceAccountsReturnsOnCall == nil {
+		fake.listServiceAccountsReturnsOnCall = make(map[int]struct {
+			result1 []atc.ServiceAccount
+			result2 error
+		})
+	}
+	fake.listServiceAccountsReturnsOnCall[i] = struct {
+		result1 []atc.ServiceAccount
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ListVolumes() ([]atc.Volume, error) {
 	fake.listVolumesMutex.Lock()
 	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
@@ -2924,6 +3583,62 @@ func (fake *FakeTeam) ListVolumesReturnsOnCall(i int, result1 []atc.Volume, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ListWebhooks() ([]atc.TeamWebhook, error) {
+	fake.listWebhooksMutex.Lock()
+	ret, specificReturn := fake.listWebhooksReturnsOnCall[len(fake.listWebhooksArgsForCall)]
+	fake.listWebhooksArgsForCall = append(fake.listWebhooksArgsForCall, struct {
+	}{})
+	stub := fake.ListWebhooksStub
+	fakeReturns := fake.listWebhooksReturns
+	fake.recordInvocation("ListWebhooks", []interface{}{})
+	fake.listWebhooksMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ListWebhooksCallCount() int {
+	fake.listWebhooksMutex.RLock()
+	defer fake.listWebhooksMutex.RUnlock()
+	return len(fake.listWebhooksArgsForCall)
+}
+
+func (fake *FakeTeam) ListWebhooksCalls(stub func() ([]atc.TeamWebhook, error)) {
+	fake.listWebhooksMutex.Lock()
+	defer fake.listWebhooksMutex.Unlock()
+	fake.ListWebhooksStub = stub
+}
+
+func (fake *FakeTeam) ListWebhooksReturns(result1 []atc.TeamWebhook, result2 error) {
+	fake.listWebhooksMutex.Lock()
+	defer fake.listWebhooksMutex.Unlock()
+	fake.ListWebhooksStub = nil
+	fake.listWebhooksReturns = struct {
+		result1 []atc.TeamWebhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListWebhooksReturnsOnCall(i int, result1 []atc.TeamWebhook, result2 error) {
+	fake.listWebhooksMutex.Lock()
+	defer fake.listWebhooksMutex.Unlock()
+	fake.ListWebhooksStub = nil
+	if fake.listWebhooksReturnsOnCall == nil {
+		fake.listWebhooksReturnsOnCall = make(map[int]struct {
+			result1 []atc.TeamWebhook
+			result2 error
+		})
+	}
+	fake.listWebhooksReturnsOnCall[i] = struct {
+		result1 []atc.TeamWebhook
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -3110,19 +3825,20 @@ func (fake *FakeTeam) OrderingPipelinesWithinGroupReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
-func (fake *FakeTeam) PauseJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
+func (fake *FakeTeam) PauseJob(arg1 atc.PipelineRef, arg2 string, arg3 string) (bool, error) {
 	fake.pauseJobMutex.Lock()
 	ret, specificReturn := fake.pauseJobReturnsOnCall[len(fake.pauseJobArgsForCall)]
 	fake.pauseJobArgsForCall = append(fake.pauseJobArgsForCall, struct {
 		arg1 atc.PipelineRef
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.PauseJobStub
 	fakeReturns := fake.pauseJobReturns
-	fake.recordInvocation("PauseJob", []interface{}{arg1, arg2})
+	fake.recordInvocation("PauseJob", []interface{}{arg1, arg2, arg3})
 	fake.pauseJobMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -3136,17 +3852,17 @@ func (fake *FakeTeam) PauseJobCallCount() int {
 	return len(fake.pauseJobArgsForCall)
 }
 
-func (fake *FakeTeam) PauseJobCalls(stub func(atc.PipelineRef, string) (bool, error)) {
+func (fake *FakeTeam) PauseJobCalls(stub func(atc.PipelineRef, string, string) (bool, error)) {
 	fake.pauseJobMutex.Lock()
 	defer fake.pauseJobMutex.Unlock()
 	fake.PauseJobStub = stub
 }
 
-func (fake *FakeTeam) PauseJobArgsForCall(i int) (atc.PipelineRef, string) {
+func (fake *FakeTeam) PauseJobArgsForCall(i int) (atc.PipelineRef, string, string) {
 	fake.pauseJobMutex.RLock()
 	defer fake.pauseJobMutex.RUnlock()
 	argsForCall := fake.pauseJobArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeTeam) PauseJobReturns(result1 bool, result2 error) {
@@ -3175,18 +3891,93 @@ func (fake *FakeTeam) PauseJobReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) PausePipeline(arg1 atc.PipelineRef) (bool, error) {
+func (fake *FakeTeam) PauseJobs(arg1 atc.PipelineRef, arg2 []string, arg3 string) ([]string, bool, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.pauseJobsMutex.Lock()
+	ret, specificReturn := fake.pauseJobsReturnsOnCall[len(fake.pauseJobsArgsForCall)]
+	fake.pauseJobsArgsForCall = append(fake.pauseJobsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 []string
+		arg3 string
+	}{arg1, arg2Copy, arg3})
+	stub := fake.PauseJobsStub
+	fakeReturns := fake.pauseJobsReturns
+	fake.recordInvocation("PauseJobs", []interface{}{arg1, arg2Copy, arg3})
+	fake.pauseJobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PauseJobsCallCount() int {
+	fake.pauseJobsMutex.RLock()
+	defer fake.pauseJobsMutex.RUnlock()
+	return len(fake.pauseJobsArgsForCall)
+}
+
+func (fake *FakeTeam) PauseJobsCalls(stub func(atc.PipelineRef, []string, string) ([]string, bool, error)) {
+	fake.pauseJobsMutex.Lock()
+	defer fake.pauseJobsMutex.Unlock()
+	fake.PauseJobsStub = stub
+}
+
+func (fake *FakeTeam) PauseJobsArgsForCall(i int) (atc.PipelineRef, []string, string) {
+	fake.pauseJobsMutex.RLock()
+	defer fake.pauseJobsMutex.RUnlock()
+	argsForCall := fake.pauseJobsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) PauseJobsReturns(result1 []string, result2 bool, result3 error) {
+	fake.pauseJobsMutex.Lock()
+	defer fake.pauseJobsMutex.Unlock()
+	fake.PauseJobsStub = nil
+	fake.pauseJobsReturns = struct {
+		result1 []string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PauseJobsReturnsOnCall(i int, result1 []string, result2 bool, result3 error) {
+	fake.pauseJobsMutex.Lock()
+	defer fake.pauseJobsMutex.Unlock()
+	fake.PauseJobsStub = nil
+	if fake.pauseJobsReturnsOnCall == nil {
+		fake.pauseJobsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pauseJobsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PausePipeline(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.pausePipelineMutex.Lock()
 	ret, specificReturn := fake.pausePipelineReturnsOnCall[len(fake.pausePipelineArgsForCall)]
 	fake.pausePipelineArgsForCall = append(fake.pausePipelineArgsForCall, struct {
 		arg1 atc.PipelineRef
-	}{arg1})
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.PausePipelineStub
 	fakeReturns := fake.pausePipelineReturns
-	fake.recordInvocation("PausePipeline", []interface{}{arg1})
+	fake.recordInvocation("PausePipeline", []interface{}{arg1, arg2})
 	fake.pausePipelineMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -3200,17 +3991,17 @@ func (fake *FakeTeam) PausePipelineCallCount() int {
 	return len(fake.pausePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) PausePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
+func (fake *FakeTeam) PausePipelineCalls(stub func(atc.PipelineRef, string) (bool, error)) {
 	fake.pausePipelineMutex.Lock()
 	defer fake.pausePipelineMutex.Unlock()
 	fake.PausePipelineStub = stub
 }
 
-func (fake *FakeTeam) PausePipelineArgsForCall(i int) atc.PipelineRef {
+func (fake *FakeTeam) PausePipelineArgsForCall(i int) (atc.PipelineRef, string) {
 	fake.pausePipelineMutex.RLock()
 	defer fake.pausePipelineMutex.RUnlock()
 	argsForCall := fake.pausePipelineArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeTeam) PausePipelineReturns(result1 bool, result2 error) {
@@ -3513,6 +4304,191 @@ func (fake *FakeTeam) PipelineConfigReturnsOnCall(i int, result1 atc.Config, res
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) PipelineVarSources(arg1 atc.PipelineRef, arg2 []string) ([]atc.VarSourceStatus, bool, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.pipelineVarSourcesMutex.Lock()
+	ret, specificReturn := fake.pipelineVarSourcesReturnsOnCall[len(fake.pipelineVarSourcesArgsForCall)]
+	fake.pipelineVarSourcesArgsForCall = append(fake.pipelineVarSourcesArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.PipelineVarSourcesStub
+	fakeReturns := fake.pipelineVarSourcesReturns
+	fake.recordInvocation("PipelineVarSources", []interface{}{arg1, arg2Copy})
+	fake.pipelineVarSourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineVarSourcesCallCount() int {
+	fake.pipelineVarSourcesMutex.RLock()
+	defer fake.pipelineVarSourcesMutex.RUnlock()
+	return len(fake.pipelineVarSourcesArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineVarSourcesCalls(stub func(atc.PipelineRef, []string) ([]atc.VarSourceStatus, bool, error)) {
+	fake.pipelineVarSourcesMutex.Lock()
+	defer fake.pipelineVarSourcesMutex.Unlock()
+	fake.PipelineVarSourcesStub = stub
+}
+
+func (fake *FakeTeam) PipelineVarSourcesArgsForCall(i int) (atc.PipelineRef, []string) {
+	fake.pipelineVarSourcesMutex.RLock()
+	defer fake.pipelineVarSourcesMutex.RUnlock()
+	argsForCall := fake.pipelineVarSourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) PipelineVarSourcesReturns(result1 []atc.VarSourceStatus, result2 bool, result3 error) {
+	fake.pipelineVarSourcesMutex.Lock()
+	defer fake.pipelineVarSourcesMutex.Unlock()
+	fake.PipelineVarSourcesStub = nil
+	fake.pipelineVarSourcesReturns = struct {
+		result1 []atc.VarSourceStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineVarSourcesReturnsOnCall(i int, result1 []atc.VarSourceStatus, result2 bool, result3 error) {
+	fake.pipelineVarSourcesMutex.Lock()
+	defer fake.pipelineVarSourcesMutex.Unlock()
+	fake.PipelineVarSourcesStub = nil
+	if fake.pipelineVarSourcesReturnsOnCall == nil {
+		fake.pipelineVarSourcesReturnsOnCall = make(map[int]struct {
+			result1 []atc.VarSourceStatus
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineVarSourcesReturnsOnCall[i] = struct {
+		result1 []atc.VarSourceStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PrunableState() (atc.TeamPrunableState, error) {
+	fake.prunableStateMutex.Lock()
+	ret, specificReturn := fake.prunableStateReturnsOnCall[len(fake.prunableStateArgsForCall)]
+	fake.prunableStateArgsForCall = append(fake.prunableStateArgsForCall, struct {
+	}{})
+	stub := fake.PrunableStateStub
+	fakeReturns := fake.prunableStateReturns
+	fake.recordInvocation("PrunableState", []interface{}{})
+	fake.prunableStateMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PrunableStateCallCount() int {
+	fake.prunableStateMutex.RLock()
+	defer fake.prunableStateMutex.RUnlock()
+	return len(fake.prunableStateArgsForCall)
+}
+
+func (fake *FakeTeam) PrunableStateCalls(stub func() (atc.TeamPrunableState, error)) {
+	fake.prunableStateMutex.Lock()
+	defer fake.prunableStateMutex.Unlock()
+	fake.PrunableStateStub = stub
+}
+
+func (fake *FakeTeam) PrunableStateReturns(result1 atc.TeamPrunableState, result2 error) {
+	fake.prunableStateMutex.Lock()
+	defer fake.prunableStateMutex.Unlock()
+	fake.PrunableStateStub = nil
+	fake.prunableStateReturns = struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PrunableStateReturnsOnCall(i int, result1 atc.TeamPrunableState, result2 error) {
+	fake.prunableStateMutex.Lock()
+	defer fake.prunableStateMutex.Unlock()
+	fake.PrunableStateStub = nil
+	if fake.prunableStateReturnsOnCall == nil {
+		fake.prunableStateReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamPrunableState
+			result2 error
+		})
+	}
+	fake.prunableStateReturnsOnCall[i] = struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PruneState() (atc.TeamPrunableState, error) {
+	fake.pruneStateMutex.Lock()
+	ret, specificReturn := fake.pruneStateReturnsOnCall[len(fake.pruneStateArgsForCall)]
+	fake.pruneStateArgsForCall = append(fake.pruneStateArgsForCall, struct {
+	}{})
+	stub := fake.PruneStateStub
+	fakeReturns := fake.pruneStateReturns
+	fake.recordInvocation("PruneState", []interface{}{})
+	fake.pruneStateMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PruneStateCallCount() int {
+	fake.pruneStateMutex.RLock()
+	defer fake.pruneStateMutex.RUnlock()
+	return len(fake.pruneStateArgsForCall)
+}
+
+func (fake *FakeTeam) PruneStateCalls(stub func() (atc.TeamPrunableState, error)) {
+	fake.pruneStateMutex.Lock()
+	defer fake.pruneStateMutex.Unlock()
+	fake.PruneStateStub = stub
+}
+
+func (fake *FakeTeam) PruneStateReturns(result1 atc.TeamPrunableState, result2 error) {
+	fake.pruneStateMutex.Lock()
+	defer fake.pruneStateMutex.Unlock()
+	fake.PruneStateStub = nil
+	fake.pruneStateReturns = struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PruneStateReturnsOnCall(i int, result1 atc.TeamPrunableState, result2 error) {
+	fake.pruneStateMutex.Lock()
+	defer fake.pruneStateMutex.Unlock()
+	fake.PruneStateStub = nil
+	if fake.pruneStateReturnsOnCall == nil {
+		fake.pruneStateReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamPrunableState
+			result2 error
+		})
+	}
+	fake.pruneStateReturnsOnCall[i] = struct {
+		result1 atc.TeamPrunableState
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) RenamePipeline(arg1 string, arg2 string) (bool, []concourse.ConfigWarning, error) {
 	fake.renamePipelineMutex.Lock()
 	ret, specificReturn := fake.renamePipelineReturnsOnCall[len(fake.renamePipelineArgsForCall)]
@@ -3715,6 +4691,73 @@ func (fake *FakeTeam) RerunJobBuildReturnsOnCall(i int, result1 atc.Build, resul
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) RerunJobBuildWithVars(arg1 atc.PipelineRef, arg2 string, arg3 string, arg4 map[string]interface{}) (atc.Build, error) {
+	fake.rerunJobBuildWithVarsMutex.Lock()
+	ret, specificReturn := fake.rerunJobBuildWithVarsReturnsOnCall[len(fake.rerunJobBuildWithVarsArgsForCall)]
+	fake.rerunJobBuildWithVarsArgsForCall = append(fake.rerunJobBuildWithVarsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+		arg4 map[string]interface{}
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.RerunJobBuildWithVarsStub
+	fakeReturns := fake.rerunJobBuildWithVarsReturns
+	fake.recordInvocation("RerunJobBuildWithVars", []interface{}{arg1, arg2, arg3, arg4})
+	fake.rerunJobBuildWithVarsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RerunJobBuildWithVarsCallCount() int {
+	fake.rerunJobBuildWithVarsMutex.RLock()
+	defer fake.rerunJobBuildWithVarsMutex.RUnlock()
+	return len(fake.rerunJobBuildWithVarsArgsForCall)
+}
+
+func (fake *FakeTeam) RerunJobBuildWithVarsCalls(stub func(atc.PipelineRef, string, string, map[string]interface{}) (atc.Build, error)) {
+	fake.rerunJobBuildWithVarsMutex.Lock()
+	defer fake.rerunJobBuildWithVarsMutex.Unlock()
+	fake.RerunJobBuildWithVarsStub = stub
+}
+
+func (fake *FakeTeam) RerunJobBuildWithVarsArgsForCall(i int) (atc.PipelineRef, string, string, map[string]interface{}) {
+	fake.rerunJobBuildWithVarsMutex.RLock()
+	defer fake.rerunJobBuildWithVarsMutex.RUnlock()
+	argsForCall := fake.rerunJobBuildWithVarsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTeam) RerunJobBuildWithVarsReturns(result1 atc.Build, result2 error) {
+	fake.rerunJobBuildWithVarsMutex.Lock()
+	defer fake.rerunJobBuildWithVarsMutex.Unlock()
+	fake.RerunJobBuildWithVarsStub = nil
+	fake.rerunJobBuildWithVarsReturns = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RerunJobBuildWithVarsReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.rerunJobBuildWithVarsMutex.Lock()
+	defer fake.rerunJobBuildWithVarsMutex.Unlock()
+	fake.RerunJobBuildWithVarsStub = nil
+	if fake.rerunJobBuildWithVarsReturnsOnCall == nil {
+		fake.rerunJobBuildWithVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 error
+		})
+	}
+	fake.rerunJobBuildWithVarsReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Resource(arg1 atc.PipelineRef, arg2 string) (atc.Resource, bool, error) {
 	fake.resourceMutex.Lock()
 	ret, specificReturn := fake.resourceReturnsOnCall[len(fake.resourceArgsForCall)]
@@ -3987,6 +5030,134 @@ func (fake *FakeTeam) SetPinCommentReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SetPipelineTemplate(arg1 atc.PipelineTemplate) (bool, error) {
+	fake.setPipelineTemplateMutex.Lock()
+	ret, specificReturn := fake.setPipelineTemplateReturnsOnCall[len(fake.setPipelineTemplateArgsForCall)]
+	fake.setPipelineTemplateArgsForCall = append(fake.setPipelineTemplateArgsForCall, struct {
+		arg1 atc.PipelineTemplate
+	}{arg1})
+	stub := fake.SetPipelineTemplateStub
+	fakeReturns := fake.setPipelineTemplateReturns
+	fake.recordInvocation("SetPipelineTemplate", []interface{}{arg1})
+	fake.setPipelineTemplateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SetPipelineTemplateCallCount() int {
+	fake.setPipelineTemplateMutex.RLock()
+	defer fake.setPipelineTemplateMutex.RUnlock()
+	return len(fake.setPipelineTemplateArgsForCall)
+}
+
+func (fake *FakeTeam) SetPipelineTemplateCalls(stub func(atc.PipelineTemplate) (bool, error)) {
+	fake.setPipelineTemplateMutex.Lock()
+	defer fake.setPipelineTemplateMutex.Unlock()
+	fake.SetPipelineTemplateStub = stub
+}
+
+func (fake *FakeTeam) SetPipelineTemplateArgsForCall(i int) atc.PipelineTemplate {
+	fake.setPipelineTemplateMutex.RLock()
+	defer fake.setPipelineTemplateMutex.RUnlock()
+	argsForCall := fake.setPipelineTemplateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SetPipelineTemplateReturns(result1 bool, result2 error) {
+	fake.setPipelineTemplateMutex.Lock()
+	defer fake.setPipelineTemplateMutex.Unlock()
+	fake.SetPipelineTemplateStub = nil
+	fake.setPipelineTemplateReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetPipelineTemplateReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.setPipelineTemplateMutex.Lock()
+	defer fake.setPipelineTemplateMutex.Unlock()
+	fake.SetPipelineTemplateStub = nil
+	if fake.setPipelineTemplateReturnsOnCall == nil {
+		fake.setPipelineTemplateReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.setPipelineTemplateReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetWebhook(arg1 atc.TeamWebhook) (bool, error) {
+	fake.setWebhookMutex.Lock()
+	ret, specificReturn := fake.setWebhookReturnsOnCall[len(fake.setWebhookArgsForCall)]
+	fake.setWebhookArgsForCall = append(fake.setWebhookArgsForCall, struct {
+		arg1 atc.TeamWebhook
+	}{arg1})
+	stub := fake.SetWebhookStub
+	fakeReturns := fake.setWebhookReturns
+	fake.recordInvocation("SetWebhook", []interface{}{arg1})
+	fake.setWebhookMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SetWebhookCallCount() int {
+	fake.setWebhookMutex.RLock()
+	defer fake.setWebhookMutex.RUnlock()
+	return len(fake.setWebhookArgsForCall)
+}
+
+func (fake *FakeTeam) SetWebhookCalls(stub func(atc.TeamWebhook) (bool, error)) {
+	fake.setWebhookMutex.Lock()
+	defer fake.setWebhookMutex.Unlock()
+	fake.SetWebhookStub = stub
+}
+
+func (fake *FakeTeam) SetWebhookArgsForCall(i int) atc.TeamWebhook {
+	fake.setWebhookMutex.RLock()
+	defer fake.setWebhookMutex.RUnlock()
+	argsForCall := fake.setWebhookArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SetWebhookReturns(result1 bool, result2 error) {
+	fake.setWebhookMutex.Lock()
+	defer fake.setWebhookMutex.Unlock()
+	fake.SetWebhookStub = nil
+	fake.setWebhookReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetWebhookReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.setWebhookMutex.Lock()
+	defer fake.setWebhookMutex.Unlock()
+	fake.SetWebhookStub = nil
+	if fake.setWebhookReturnsOnCall == nil {
+		fake.setWebhookReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.setWebhookReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) UnpauseJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.unpauseJobMutex.Lock()
 	ret, specificReturn := fake.unpauseJobReturnsOnCall[len(fake.unpauseJobArgsForCall)]
@@ -4052,6 +5223,79 @@ func (fake *FakeTeam) UnpauseJobReturnsOnCall(i int, result1 bool, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) UnpauseJobs(arg1 atc.PipelineRef, arg2 []string) ([]string, bool, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.unpauseJobsMutex.Lock()
+	ret, specificReturn := fake.unpauseJobsReturnsOnCall[len(fake.unpauseJobsArgsForCall)]
+	fake.unpauseJobsArgsForCall = append(fake.unpauseJobsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.UnpauseJobsStub
+	fakeReturns := fake.unpauseJobsReturns
+	fake.recordInvocation("UnpauseJobs", []interface{}{arg1, arg2Copy})
+	fake.unpauseJobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) UnpauseJobsCallCount() int {
+	fake.unpauseJobsMutex.RLock()
+	defer fake.unpauseJobsMutex.RUnlock()
+	return len(fake.unpauseJobsArgsForCall)
+}
+
+func (fake *FakeTeam) UnpauseJobsCalls(stub func(atc.PipelineRef, []string) ([]string, bool, error)) {
+	fake.unpauseJobsMutex.Lock()
+	defer fake.unpauseJobsMutex.Unlock()
+	fake.UnpauseJobsStub = stub
+}
+
+func (fake *FakeTeam) UnpauseJobsArgsForCall(i int) (atc.PipelineRef, []string) {
+	fake.unpauseJobsMutex.RLock()
+	defer fake.unpauseJobsMutex.RUnlock()
+	argsForCall := fake.unpauseJobsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) UnpauseJobsReturns(result1 []string, result2 bool, result3 error) {
+	fake.unpauseJobsMutex.Lock()
+	defer fake.unpauseJobsMutex.Unlock()
+	fake.UnpauseJobsStub = nil
+	fake.unpauseJobsReturns = struct {
+		result1 []string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) UnpauseJobsReturnsOnCall(i int, result1 []string, result2 bool, result3 error) {
+	fake.unpauseJobsMutex.Lock()
+	defer fake.unpauseJobsMutex.Unlock()
+	fake.UnpauseJobsStub = nil
+	if fake.unpauseJobsReturnsOnCall == nil {
+		fake.unpauseJobsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.unpauseJobsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) UnpausePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.unpausePipelineMutex.Lock()
 	ret, specificReturn := fake.unpausePipelineReturnsOnCall[len(fake.unpausePipelineArgsForCall)]
@@ -4277,14 +5521,24 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createBuildMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createJobBuildWithInputsMutex.RLock()
+	defer fake.createJobBuildWithInputsMutex.RUnlock()
 	fake.createOrUpdateMutex.RLock()
 	defer fake.createOrUpdateMutex.RUnlock()
 	fake.createOrUpdatePipelineConfigMutex.RLock()
 	defer fake.createOrUpdatePipelineConfigMutex.RUnlock()
 	fake.createPipelineBuildMutex.RLock()
 	defer fake.createPipelineBuildMutex.RUnlock()
+	fake.createServiceAccountMutex.RLock()
+	defer fake.createServiceAccountMutex.RUnlock()
 	fake.deletePipelineMutex.RLock()
 	defer fake.deletePipelineMutex.RUnlock()
+	fake.deletePipelineTemplateMutex.RLock()
+	defer fake.deletePipelineTemplateMutex.RUnlock()
+	fake.deleteServiceAccountMutex.RLock()
+	defer fake.deleteServiceAccountMutex.RUnlock()
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
 	fake.destroyTeamMutex.RLock()
 	defer fake.destroyTeamMutex.RUnlock()
 	fake.disableResourceVersionMutex.RLock()
@@ -4311,12 +5565,18 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.listContainersMutex.RUnlock()
 	fake.listJobsMutex.RLock()
 	defer fake.listJobsMutex.RUnlock()
+	fake.listPipelineTemplatesMutex.RLock()
+	defer fake.listPipelineTemplatesMutex.RUnlock()
 	fake.listPipelinesMutex.RLock()
 	defer fake.listPipelinesMutex.RUnlock()
 	fake.listResourcesMutex.RLock()
 	defer fake.listResourcesMutex.RUnlock()
+	fake.listServiceAccountsMutex.RLock()
+	defer fake.listServiceAccountsMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
+	fake.listWebhooksMutex.RLock()
+	defer fake.listWebhooksMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.orderingPipelinesMutex.RLock()
@@ -4325,6 +5585,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.orderingPipelinesWithinGroupMutex.RUnlock()
 	fake.pauseJobMutex.RLock()
 	defer fake.pauseJobMutex.RUnlock()
+	fake.pauseJobsMutex.RLock()
+	defer fake.pauseJobsMutex.RUnlock()
 	fake.pausePipelineMutex.RLock()
 	defer fake.pausePipelineMutex.RUnlock()
 	fake.pinResourceVersionMutex.RLock()
@@ -4335,12 +5597,20 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.pipelineBuildsMutex.RUnlock()
 	fake.pipelineConfigMutex.RLock()
 	defer fake.pipelineConfigMutex.RUnlock()
+	fake.pipelineVarSourcesMutex.RLock()
+	defer fake.pipelineVarSourcesMutex.RUnlock()
+	fake.prunableStateMutex.RLock()
+	defer fake.prunableStateMutex.RUnlock()
+	fake.pruneStateMutex.RLock()
+	defer fake.pruneStateMutex.RUnlock()
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
 	fake.renameTeamMutex.RLock()
 	defer fake.renameTeamMutex.RUnlock()
 	fake.rerunJobBuildMutex.RLock()
 	defer fake.rerunJobBuildMutex.RUnlock()
+	fake.rerunJobBuildWithVarsMutex.RLock()
+	defer fake.rerunJobBuildWithVarsMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceVersionsMutex.RLock()
@@ -4349,8 +5619,14 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.scheduleJobMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
 	defer fake.setPinCommentMutex.RUnlock()
+	fake.setPipelineTemplateMutex.RLock()
+	defer fake.setPipelineTemplateMutex.RUnlock()
+	fake.setWebhookMutex.RLock()
+	defer fake.setWebhookMutex.RUnlock()
 	fake.unpauseJobMutex.RLock()
 	defer fake.unpauseJobMutex.RUnlock()
+	fake.unpauseJobsMutex.RLock()
+	defer fake.unpauseJobsMutex.RUnlock()
 	fake.unpausePipelineMutex.RLock()
 	defer fake.unpausePipelineMutex.RUnlock()
 	fake.unpinResourceMutex.RLock()
@@ -1,7 +1,11 @@
 package concourse
 
 import (
+	"net/http"
+	"strconv"
+
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/go-concourse/concourse/eventstream"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
 	"github.com/tedsuo/rata"
@@ -12,6 +16,13 @@ type Events interface {
 	Close() error
 }
 
+// RawEvents yields build events without decoding them, so that events this
+// client doesn't know of are passed along too.
+type RawEvents interface {
+	NextEnvelope() (event.Envelope, error)
+	Close() error
+}
+
 func (client *client) BuildEvents(buildID string) (Events, error) {
 	sseEvents, err := client.connection.ConnectToEventStream(internal.Request{
 		RequestName: atc.BuildEvents,
@@ -25,3 +36,23 @@ func (client *client) BuildEvents(buildID string) (Events, error) {
 
 	return eventstream.NewSSEEventStream(sseEvents), nil
 }
+
+func (client *client) RawBuildEvents(buildID string, sinceID *int) (RawEvents, error) {
+	header := http.Header{}
+	if sinceID != nil {
+		header.Set("Last-Event-ID", strconv.Itoa(*sinceID))
+	}
+
+	sseEvents, err := client.connection.ConnectToEventStream(internal.Request{
+		RequestName: atc.BuildEvents,
+		Params: rata.Params{
+			"build_id": buildID,
+		},
+		Header: header,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return eventstream.NewSSEEventStream(sseEvents), nil
+}
@@ -106,6 +106,58 @@ var _ = Describe("ATC Handler Events", func() {
 			})
 		})
 
+		Context("when the server returns events to be read raw", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					eventsHandler(),
+				)
+			})
+
+			It("returns the events undecoded, along with their IDs", func() {
+				stream, err := client.RawBuildEvents(buildID, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				next, err := stream.NextEnvelope()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(next.EventID).To(Equal("0"))
+				Expect(next.Event).To(Equal(event.EventTypeStatus))
+				Expect(string(*next.Data)).To(MatchJSON(`{"status":"started","time":0}`))
+
+				next, err = stream.NextEnvelope()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(next.EventID).To(Equal("1"))
+
+				_, err = stream.NextEnvelope()
+				Expect(err).To(Equal(io.EOF))
+
+				err = stream.Close()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when resuming the raw events from an ID", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyHeaderKV("Last-Event-ID", "0"),
+						eventsHandler(),
+					),
+				)
+			})
+
+			It("asks the server for the events after it", func() {
+				sinceID := 0
+				stream, err := client.RawBuildEvents(buildID, &sinceID)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = stream.NextEnvelope()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = stream.Close()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
 		Context("when the server returns 401", func() {
 			BeforeEach(func() {
 				atcServer.AppendHandlers(ghttp.RespondWith(http.StatusUnauthorized, ""))
@@ -50,6 +50,34 @@ func (s *SSEEventStream) NextEvent() (atc.Event, error) {
 	}
 }
 
+// NextEnvelope returns the next event as it was sent, along with its ID,
+// without decoding it.
+func (s *SSEEventStream) NextEnvelope() (event.Envelope, error) {
+	se, err := s.sseReader.Next()
+	if err != nil {
+		return event.Envelope{}, err
+	}
+
+	switch se.Name {
+	case "event":
+		var envelope event.Envelope
+		err = json.Unmarshal(se.Data, &envelope)
+		if err != nil {
+			return event.Envelope{}, err
+		}
+
+		envelope.EventID = se.ID
+
+		return envelope, nil
+
+	case "end":
+		return event.Envelope{}, io.EOF
+
+	default:
+		return event.Envelope{}, fmt.Errorf("unknown event name: %s", se.Name)
+	}
+}
+
 func (s *SSEEventStream) Close() error {
 	return s.sseReader.Close()
 }
@@ -131,7 +131,22 @@ func (connection *connection) send(req *http.Request, returnResponseBody bool, p
 
 // Deprecated
 func (connection *connection) ConnectToEventStream(passedRequest Request) (*sse.EventSource, error) {
-	source, err := sse.Connect(connection.httpClient, time.Second, func() *http.Request {
+	httpClient := connection.httpClient
+
+	// the event source always sends the ID of the last event it received
+	// itself, so a stream is resumed from a given ID by sending it in place of
+	// the initial, empty one
+	if lastEventID := passedRequest.Header.Get("Last-Event-ID"); lastEventID != "" {
+		resumingClient := *httpClient
+		resumingClient.Transport = lastEventIDTransport{
+			base:        httpClient.Transport,
+			lastEventID: lastEventID,
+		}
+
+		httpClient = &resumingClient
+	}
+
+	source, err := sse.Connect(httpClient, time.Second, func() *http.Request {
 		request, reqErr := connection.createHTTPRequest(passedRequest)
 		if reqErr != nil {
 			panic("unexpected error creating request: " + reqErr.Error())
@@ -155,6 +170,25 @@ func (connection *connection) ConnectToEventStream(passedRequest Request) (*sse.
 	return source, nil
 }
 
+type lastEventIDTransport struct {
+	base        http.RoundTripper
+	lastEventID string
+}
+
+func (transport lastEventIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Last-Event-ID") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Last-Event-ID", transport.lastEventID)
+	}
+
+	base := transport.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return base.RoundTrip(req)
+}
+
 // Deprecated
 func (connection *connection) createHTTPRequest(passedRequest Request) (*http.Request, error) {
 	body := connection.getBody(passedRequest)
@@ -96,18 +96,19 @@ func (team *team) JobBuilds(pipelineRef atc.PipelineRef, jobName string, page Pa
 	}
 }
 
-func (team *team) PauseJob(pipelineRef atc.PipelineRef, jobName string) (bool, error) {
+func (team *team) PauseJob(pipelineRef atc.PipelineRef, jobName string, reason string) (bool, error) {
 	params := rata.Params{
 		"pipeline_name": pipelineRef.Name,
 		"job_name":      jobName,
 		"team_name":     team.Name(),
 	}
 
-	err := team.connection.Send(internal.Request{
-		RequestName: atc.PauseJob,
-		Params:      params,
-		Query:       pipelineRef.QueryParams(),
-	}, &internal.Response{})
+	request, err := pauseRequest(atc.PauseJob, params, pipelineRef, reason)
+	if err != nil {
+		return false, err
+	}
+
+	err = team.connection.Send(request, &internal.Response{})
 
 	switch err.(type) {
 	case nil:
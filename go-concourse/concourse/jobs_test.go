@@ -372,7 +372,7 @@ var _ = Describe("ATC Handler Jobs", func() {
 
 			It("calls the pause job and returns no error", func() {
 				Expect(func() {
-					paused, err := team.PauseJob(pipelineRef, jobName)
+					paused, err := team.PauseJob(pipelineRef, jobName, "")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(paused).To(BeTrue())
 				}).To(Change(func() int {
@@ -388,7 +388,7 @@ var _ = Describe("ATC Handler Jobs", func() {
 
 			It("calls the pause job and returns an error", func() {
 				Expect(func() {
-					paused, err := team.PauseJob(pipelineRef, jobName)
+					paused, err := team.PauseJob(pipelineRef, jobName, "")
 					Expect(err).To(HaveOccurred())
 					Expect(paused).To(BeFalse())
 				}).To(Change(func() int {
@@ -404,7 +404,7 @@ var _ = Describe("ATC Handler Jobs", func() {
 
 			It("calls the pause job and returns an error", func() {
 				Expect(func() {
-					paused, err := team.PauseJob(pipelineRef, jobName)
+					paused, err := team.PauseJob(pipelineRef, jobName, "")
 					Expect(err).ToNot(HaveOccurred())
 					Expect(paused).To(BeFalse())
 				}).To(Change(func() int {
@@ -0,0 +1,61 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+// PauseJobs pauses the named jobs of the pipeline in one request, returning
+// the names of the jobs which were found.
+func (team *team) PauseJobs(pipelineRef atc.PipelineRef, jobNames []string, reason string) ([]string, bool, error) {
+	return team.sendPauseJobs(atc.PauseJobs, pipelineRef, atc.PauseJobsRequest{
+		Jobs:   jobNames,
+		Reason: reason,
+	})
+}
+
+// UnpauseJobs unpauses the named jobs of the pipeline in one request,
+// returning the names of the jobs which were found.
+func (team *team) UnpauseJobs(pipelineRef atc.PipelineRef, jobNames []string) ([]string, bool, error) {
+	return team.sendPauseJobs(atc.UnpauseJobs, pipelineRef, atc.PauseJobsRequest{
+		Jobs: jobNames,
+	})
+}
+
+func (team *team) sendPauseJobs(requestName string, pipelineRef atc.PipelineRef, req atc.PauseJobsRequest) ([]string, bool, error) {
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var jobNames []string
+	err = team.connection.Send(internal.Request{
+		RequestName: requestName,
+		Params: rata.Params{
+			"pipeline_name": pipelineRef.Name,
+			"team_name":     team.Name(),
+		},
+		Query: pipelineRef.QueryParams(),
+		Body:  buffer,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, &internal.Response{
+		Result: &jobNames,
+	})
+
+	switch err.(type) {
+	case nil:
+		return jobNames, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
@@ -0,0 +1,87 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Pause Jobs", func() {
+	var pipelineRef = atc.PipelineRef{Name: "banana", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+	Describe("PauseJobs", func() {
+		var (
+			expectedStatus   int
+			expectedResponse interface{}
+		)
+
+		BeforeEach(func() {
+			expectedStatus = http.StatusOK
+			expectedResponse = []string{"test-unit"}
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/pipelines/banana/jobs/pause", "vars.branch=%22master%22"),
+					ghttp.VerifyJSONRepresenting(atc.PauseJobsRequest{Jobs: []string{"test-unit", "test-integration"}, Reason: "flaky"}),
+					ghttp.RespondWithJSONEncoded(expectedStatus, expectedResponse),
+				),
+			)
+		})
+
+		It("returns the jobs which were paused", func() {
+			paused, found, err := team.PauseJobs(pipelineRef, []string{"test-unit", "test-integration"}, "flaky")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(paused).To(Equal([]string{"test-unit"}))
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+				expectedResponse = nil
+			})
+
+			It("returns false", func() {
+				_, found, err := team.PauseJobs(pipelineRef, []string{"test-unit", "test-integration"}, "flaky")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the call fails", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusInternalServerError
+				expectedResponse = nil
+			})
+
+			It("returns an error", func() {
+				_, _, err := team.PauseJobs(pipelineRef, []string{"test-unit", "test-integration"}, "flaky")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("UnpauseJobs", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/pipelines/banana/jobs/unpause", "vars.branch=%22master%22"),
+					ghttp.VerifyJSONRepresenting(atc.PauseJobsRequest{Jobs: []string{"test-unit"}}),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []string{"test-unit"}),
+				),
+			)
+		})
+
+		It("returns the jobs which were unpaused", func() {
+			unpaused, found, err := team.UnpauseJobs(pipelineRef, []string{"test-unit"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(unpaused).To(Equal([]string{"test-unit"}))
+		})
+	})
+})
@@ -0,0 +1,65 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ListPipelineTemplates() ([]atc.PipelineTemplate, error) {
+	var templates []atc.PipelineTemplate
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListPipelineTemplates,
+		Params:      rata.Params{"team_name": team.Name()},
+	}, &internal.Response{
+		Result: &templates,
+	})
+
+	return templates, err
+}
+
+func (team *team) SetPipelineTemplate(template atc.PipelineTemplate) (bool, error) {
+	jsonBytes, err := json.Marshal(template)
+	if err != nil {
+		return false, err
+	}
+
+	response := internal.Response{}
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.SetPipelineTemplate,
+		Params: rata.Params{
+			"team_name":     team.Name(),
+			"template_name": template.Name,
+		},
+		Body:   bytes.NewBuffer(jsonBytes),
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+	}, &response)
+	if err != nil {
+		return false, err
+	}
+
+	return response.Created, nil
+}
+
+func (team *team) DeletePipelineTemplate(name string) (bool, error) {
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.DeletePipelineTemplate,
+		Params: rata.Params{
+			"team_name":     team.Name(),
+			"template_name": name,
+		},
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -37,6 +38,40 @@ func (team *team) Pipeline(pipelineRef atc.PipelineRef) (atc.Pipeline, bool, err
 	}
 }
 
+func (team *team) PipelineVarSources(pipelineRef atc.PipelineRef, varNames []string) ([]atc.VarSourceStatus, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	queryParams := pipelineRef.QueryParams()
+	if queryParams == nil {
+		queryParams = url.Values{}
+	}
+
+	for _, name := range varNames {
+		queryParams.Add("var", name)
+	}
+
+	var statuses []atc.VarSourceStatus
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListPipelineVarSources,
+		Params:      params,
+		Query:       queryParams,
+	}, &internal.Response{
+		Result: &statuses,
+	})
+
+	switch err.(type) {
+	case nil:
+		return statuses, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
+
 func (team *team) OrderingPipelines(pipelineNames []string) error {
 	params := rata.Params{
 		"team_name": team.Name(),
@@ -181,8 +216,49 @@ func (team *team) DeletePipeline(pipelineRef atc.PipelineRef) (bool, error) {
 	return team.managePipeline(pipelineRef, atc.DeletePipeline)
 }
 
-func (team *team) PausePipeline(pipelineRef atc.PipelineRef) (bool, error) {
-	return team.managePipeline(pipelineRef, atc.PausePipeline)
+func (team *team) PausePipeline(pipelineRef atc.PipelineRef, reason string) (bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	request, err := pauseRequest(atc.PausePipeline, params, pipelineRef, reason)
+	if err != nil {
+		return false, err
+	}
+
+	err = team.connection.Send(request, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// pauseRequest only sends a body when there is a reason, so that pausing
+// still works against web nodes which do not know about reasons.
+func pauseRequest(requestName string, params rata.Params, pipelineRef atc.PipelineRef, reason string) (internal.Request, error) {
+	request := internal.Request{
+		RequestName: requestName,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}
+
+	if reason != "" {
+		jsonBytes, err := json.Marshal(atc.PauseRequest{Reason: reason})
+		if err != nil {
+			return internal.Request{}, err
+		}
+
+		request.Body = bytes.NewBuffer(jsonBytes)
+		request.Header = http.Header{"Content-Type": []string{"application/json"}}
+	}
+
+	return request, nil
 }
 
 func (team *team) ArchivePipeline(pipelineRef atc.PipelineRef) (bool, error) {
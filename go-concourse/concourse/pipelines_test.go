@@ -30,7 +30,25 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("return true and no error", func() {
-				found, err := team.PausePipeline(pipelineRef)
+				found, err := team.PausePipeline(pipelineRef, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when a reason is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, queryParams),
+						ghttp.VerifyJSONRepresenting(atc.PauseRequest{Reason: "prod freeze"}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("sends the reason", func() {
+				found, err := team.PausePipeline(pipelineRef, "prod freeze")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 			})
@@ -46,7 +64,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 				)
 			})
 			It("returns false and no error", func() {
-				found, err := team.PausePipeline(pipelineRef)
+				found, err := team.PausePipeline(pipelineRef, "")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -355,6 +373,74 @@ var _ = Describe("ATC Handler Pipelines", func() {
 		})
 	})
 
+	Describe("PipelineVarSources", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/var-sources"
+		queryParams := "vars.branch=%22master%22"
+		pipelineRef := atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+		Context("when the pipeline is found", func() {
+			var expectedStatuses []atc.VarSourceStatus
+
+			BeforeEach(func() {
+				expectedStatuses = []atc.VarSourceStatus{
+					{Name: "some-vault", Type: "vault", Reachable: true, Lookups: 4, CacheHits: 3, CacheHitRate: 0.75},
+					{Name: "other-vault", Type: "vault", Error: "permission denied"},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedStatuses),
+					),
+				)
+			})
+
+			It("returns the status of each var source", func() {
+				statuses, found, err := team.PipelineVarSources(pipelineRef, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(statuses).To(Equal(expectedStatuses))
+			})
+		})
+
+		Context("when vars are given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams+"&var=some-vault:some-secret&var=other-vault:other-secret"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.VarSourceStatus{
+							{Name: "some-vault", Type: "vault", Vars: []atc.VarLookupStatus{{Name: "some-vault:some-secret", Found: true}}},
+						}),
+					),
+				)
+			})
+
+			It("asks for them to be looked up", func() {
+				statuses, found, err := team.PipelineVarSources(pipelineRef, []string{"some-vault:some-secret", "other-vault:other-secret"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(statuses[0].Vars).To(Equal([]atc.VarLookupStatus{{Name: "some-vault:some-secret", Found: true}}))
+			})
+		})
+
+		Context("when the pipeline is not found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.PipelineVarSources(pipelineRef, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("team.ListPipelines", func() {
 		var expectedPipelines []atc.Pipeline
 
@@ -0,0 +1,31 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) PrunableState() (atc.TeamPrunableState, error) {
+	var state atc.TeamPrunableState
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListTeamPrunableState,
+		Params:      rata.Params{"team_name": team.Name()},
+	}, &internal.Response{
+		Result: &state,
+	})
+
+	return state, err
+}
+
+func (team *team) PruneState() (atc.TeamPrunableState, error) {
+	var state atc.TeamPrunableState
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.PruneTeamState,
+		Params:      rata.Params{"team_name": team.Name()},
+	}, &internal.Response{
+		Result: &state,
+	})
+
+	return state, err
+}
@@ -0,0 +1,26 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+)
+
+func (client *client) Search(query string, limit int) ([]atc.SearchResult, error) {
+	params := url.Values{"q": {query}}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var results []atc.SearchResult
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.Search,
+		Query:       params,
+	}, &internal.Response{
+		Result: &results,
+	})
+
+	return results, err
+}
@@ -0,0 +1,33 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Search", func() {
+	Describe("Search", func() {
+		expectedResults := []atc.SearchResult{
+			{Kind: "job", Name: "deploy", TeamName: "main", PipelineName: "some-pipeline", Score: 101},
+		}
+
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/search", "limit=10&q=deploy+kind%3Ajob"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedResults),
+				),
+			)
+		})
+
+		It("returns the results", func() {
+			results, err := client.Search("deploy kind:job", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal(expectedResults))
+		})
+	})
+})
@@ -0,0 +1,66 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ListServiceAccounts() ([]atc.ServiceAccount, error) {
+	var accounts []atc.ServiceAccount
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListServiceAccounts,
+		Params:      rata.Params{"team_name": team.Name()},
+	}, &internal.Response{
+		Result: &accounts,
+	})
+
+	return accounts, err
+}
+
+func (team *team) CreateServiceAccount(name string, role string, ttl time.Duration) (atc.ServiceAccountToken, error) {
+	jsonBytes, err := json.Marshal(atc.CreateServiceAccountRequest{
+		Name: name,
+		Role: role,
+		TTL:  ttl,
+	})
+	if err != nil {
+		return atc.ServiceAccountToken{}, err
+	}
+
+	var created atc.ServiceAccountToken
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.CreateServiceAccount,
+		Params:      rata.Params{"team_name": team.Name()},
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, &internal.Response{
+		Result: &created,
+	})
+
+	return created, err
+}
+
+func (team *team) DeleteServiceAccount(name string) (bool, error) {
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.DeleteServiceAccount,
+		Params: rata.Params{
+			"team_name":            team.Name(),
+			"service_account_name": name,
+		},
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -2,6 +2,7 @@ package concourse
 
 import (
 	"io"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -18,10 +19,26 @@ type Team interface {
 	RenameTeam(teamName, name string) (bool, []ConfigWarning, error)
 	DestroyTeam(teamName string) error
 
+	ListServiceAccounts() ([]atc.ServiceAccount, error)
+	CreateServiceAccount(name string, role string, ttl time.Duration) (atc.ServiceAccountToken, error)
+	DeleteServiceAccount(name string) (bool, error)
+
+	PrunableState() (atc.TeamPrunableState, error)
+	PruneState() (atc.TeamPrunableState, error)
+
+	ListWebhooks() ([]atc.TeamWebhook, error)
+	SetWebhook(webhook atc.TeamWebhook) (bool, error)
+	DeleteWebhook(name string) (bool, error)
+
+	ListPipelineTemplates() ([]atc.PipelineTemplate, error)
+	SetPipelineTemplate(template atc.PipelineTemplate) (bool, error)
+	DeletePipelineTemplate(name string) (bool, error)
+
 	Pipeline(pipelineRef atc.PipelineRef) (atc.Pipeline, bool, error)
 	PipelineBuilds(pipelineRef atc.PipelineRef, page Page) ([]atc.Build, Pagination, bool, error)
+	PipelineVarSources(pipelineRef atc.PipelineRef, varNames []string) ([]atc.VarSourceStatus, bool, error)
 	DeletePipeline(pipelineRef atc.PipelineRef) (bool, error)
-	PausePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	PausePipeline(pipelineRef atc.PipelineRef, reason string) (bool, error)
 	ArchivePipeline(pipelineRef atc.PipelineRef) (bool, error)
 	UnpausePipeline(pipelineRef atc.PipelineRef) (bool, error)
 	ExposePipeline(pipelineRef atc.PipelineRef) (bool, error)
@@ -39,12 +56,16 @@ type Team interface {
 	JobBuild(pipelineRef atc.PipelineRef, jobName, buildName string) (atc.Build, bool, error)
 	JobBuilds(pipelineRef atc.PipelineRef, jobName string, page Page) ([]atc.Build, Pagination, bool, error)
 	CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (atc.Build, error)
+	CreateJobBuildWithInputs(pipelineRef atc.PipelineRef, jobName string, inputs map[string]atc.Version) (atc.Build, error)
 	RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error)
+	RerunJobBuildWithVars(pipelineRef atc.PipelineRef, jobName string, buildName string, vars map[string]interface{}) (atc.Build, error)
 	ListJobs(pipelineRef atc.PipelineRef) ([]atc.Job, error)
 	ScheduleJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
 
-	PauseJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
+	PauseJob(pipelineRef atc.PipelineRef, jobName string, reason string) (bool, error)
 	UnpauseJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
+	PauseJobs(pipelineRef atc.PipelineRef, jobNames []string, reason string) ([]string, bool, error)
+	UnpauseJobs(pipelineRef atc.PipelineRef, jobNames []string) ([]string, bool, error)
 
 	ClearTaskCache(pipelineRef atc.PipelineRef, jobName string, stepName string, cachePath string) (int64, error)
 
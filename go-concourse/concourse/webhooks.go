@@ -0,0 +1,65 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ListWebhooks() ([]atc.TeamWebhook, error) {
+	var webhooks []atc.TeamWebhook
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListTeamWebhooks,
+		Params:      rata.Params{"team_name": team.Name()},
+	}, &internal.Response{
+		Result: &webhooks,
+	})
+
+	return webhooks, err
+}
+
+func (team *team) SetWebhook(webhook atc.TeamWebhook) (bool, error) {
+	jsonBytes, err := json.Marshal(webhook)
+	if err != nil {
+		return false, err
+	}
+
+	response := internal.Response{}
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.SetTeamWebhook,
+		Params: rata.Params{
+			"team_name":    team.Name(),
+			"webhook_name": webhook.Name,
+		},
+		Body:   bytes.NewBuffer(jsonBytes),
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+	}, &response)
+	if err != nil {
+		return false, err
+	}
+
+	return response.Created, nil
+}
+
+func (team *team) DeleteWebhook(name string) (bool, error) {
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.DeleteTeamWebhook,
+		Params: rata.Params{
+			"team_name":    team.Name(),
+			"webhook_name": name,
+		},
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,8 @@
+// Package logging provides the lager sink and flags used by each Concourse
+// command to emit structured JSON logs.
+//
+// Every log line is attributed to a component (api, scheduler, gc, tsa or
+// worker) whose minimum level can be set independently of the command-wide
+// --log-level, both on startup and at runtime through the web node's
+// /api/v1/log-level endpoint.
+package logging
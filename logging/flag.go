@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// Lager configures the logger of a command. It replaces flag.Lager, adding
+// per-component levels and emitting each log as a JSON Entry.
+type Lager struct {
+	LogLevel        string              `long:"log-level" default:"info" choice:"debug" choice:"info" choice:"error" choice:"fatal" description:"Minimum level of logs to see."`
+	ComponentLevels map[Component]Level `long:"log-component-level" value-name:"COMPONENT:LEVEL" description:"Minimum level of logs to see for a component (api, scheduler, gc, tsa or worker), overriding --log-level. Can be specified multiple times."`
+
+	writerSink io.Writer
+}
+
+func (f *Lager) SetWriterSink(writer io.Writer) {
+	f.writerSink = writer
+}
+
+func (f Lager) Logger(source string) (lager.Logger, *Sink) {
+	minLevel, err := lager.LogLevelFromString(f.LogLevel)
+	if err != nil {
+		panic(fmt.Sprintf("unknown log level: %s", f.LogLevel))
+	}
+
+	for component, level := range f.ComponentLevels {
+		// validated when parsing the flag
+		_ = Levels.Set(string(component), lager.LogLevel(level))
+	}
+
+	writer := f.writerSink
+	if writer == nil {
+		writer = os.Stdout
+	}
+
+	sink := NewSink(writer, minLevel, Levels)
+
+	logger := lager.NewLogger(source)
+	logger.RegisterSink(sink)
+
+	return logger, sink
+}
+
+type Component string
+
+func (c *Component) UnmarshalFlag(value string) error {
+	if !IsComponent(value) {
+		return fmt.Errorf("unknown log component '%s', must be one of %v", value, Components)
+	}
+
+	*c = Component(value)
+
+	return nil
+}
+
+type Level lager.LogLevel
+
+func (l *Level) UnmarshalFlag(value string) error {
+	level, err := lager.LogLevelFromString(value)
+	if err != nil {
+		return err
+	}
+
+	*l = Level(level)
+
+	return nil
+}
+
+func (l Level) MarshalFlag() (string, error) {
+	return lager.LogLevel(l).String(), nil
+}
@@ -0,0 +1,48 @@
+package logging_test
+
+import (
+	"bytes"
+
+	"code.cloudfoundry.org/lager"
+	flags "github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/logging"
+)
+
+var _ = Describe("Lager", func() {
+	var cmd struct {
+		Logger logging.Lager
+	}
+
+	parse := func(args ...string) error {
+		_, err := flags.NewParser(&cmd, flags.Default&^flags.PrintErrors).ParseArgs(args)
+		return err
+	}
+
+	It("configures per-component levels", func() {
+		Expect(parse("--log-level", "error", "--log-component-level", "scheduler:debug")).To(Succeed())
+
+		buffer := new(bytes.Buffer)
+		cmd.Logger.SetWriterSink(buffer)
+
+		logger, sink := cmd.Logger.Logger("atc")
+		Expect(sink.GetMinLevel()).To(Equal(lager.ERROR))
+		Expect(sink.ComponentLevel(logging.ComponentScheduler)).To(Equal(lager.DEBUG))
+
+		logger.Info("hidden")
+		logger.WithData(lager.Data{logging.ComponentKey: logging.ComponentScheduler}).Debug("shown")
+
+		Expect(buffer.String()).ToNot(ContainSubstring("hidden"))
+		Expect(buffer.String()).To(ContainSubstring(`"component":"scheduler"`))
+	})
+
+	It("rejects unknown components", func() {
+		Expect(parse("--log-component-level", "bogus:debug")).To(MatchError(ContainSubstring("unknown log component 'bogus'")))
+	})
+
+	It("rejects unknown levels", func() {
+		Expect(parse("--log-component-level", "gc:loud")).To(MatchError(ContainSubstring("invalid log level: loud")))
+	})
+})
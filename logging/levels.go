@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"errors"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	ComponentAPI       = "api"
+	ComponentScheduler = "scheduler"
+	ComponentGC        = "gc"
+	ComponentTSA       = "tsa"
+	ComponentWorker    = "worker"
+)
+
+// Components lists every component whose level can be configured.
+var Components = []string{
+	ComponentAPI,
+	ComponentScheduler,
+	ComponentGC,
+	ComponentTSA,
+	ComponentWorker,
+}
+
+// ComponentKey is the data field a logger is tagged with to attribute its
+// logs to a component. Untagged logs are attributed to the logger's source.
+const ComponentKey = "component"
+
+var ErrUnknownComponent = errors.New("unknown log component")
+
+// Levels holds the component levels of the whole process. It is shared by
+// every sink so that, for example, the TSA running as part of `concourse
+// web` can be reconfigured through the ATC's API.
+var Levels = NewComponentLevels()
+
+type ComponentLevels struct {
+	levelsL sync.RWMutex
+	levels  map[string]lager.LogLevel
+}
+
+func NewComponentLevels() *ComponentLevels {
+	return &ComponentLevels{
+		levels: map[string]lager.LogLevel{},
+	}
+}
+
+// Get returns the level configured for the component, if any.
+func (l *ComponentLevels) Get(component string) (lager.LogLevel, bool) {
+	l.levelsL.RLock()
+	defer l.levelsL.RUnlock()
+
+	level, found := l.levels[component]
+	return level, found
+}
+
+func (l *ComponentLevels) Set(component string, level lager.LogLevel) error {
+	if !IsComponent(component) {
+		return ErrUnknownComponent
+	}
+
+	l.levelsL.Lock()
+	l.levels[component] = level
+	l.levelsL.Unlock()
+
+	return nil
+}
+
+func IsComponent(component string) bool {
+	for _, c := range Components {
+		if c == component {
+			return true
+		}
+	}
+
+	return false
+}
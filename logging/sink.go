@@ -0,0 +1,139 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// Entry is the JSON form of each log line. Its field names are relied upon
+// by log pipelines and must not change.
+type Entry struct {
+	Timestamp string     `json:"timestamp"`
+	Level     string     `json:"level"`
+	Source    string     `json:"source"`
+	Component string     `json:"component"`
+	Message   string     `json:"message"`
+	Data      lager.Data `json:"data"`
+}
+
+// Sink writes each log as an Entry, filtering on the level configured for
+// the log's component or, failing that, the sink's own minimum level.
+type Sink struct {
+	writer  io.Writer
+	writerL sync.Mutex
+
+	minLevel int32
+	levels   *ComponentLevels
+}
+
+func NewSink(writer io.Writer, minLevel lager.LogLevel, levels *ComponentLevels) *Sink {
+	return &Sink{
+		writer:   writer,
+		minLevel: int32(minLevel),
+		levels:   levels,
+	}
+}
+
+func (sink *Sink) GetMinLevel() lager.LogLevel {
+	return lager.LogLevel(atomic.LoadInt32(&sink.minLevel))
+}
+
+func (sink *Sink) SetMinLevel(level lager.LogLevel) {
+	atomic.StoreInt32(&sink.minLevel, int32(level))
+}
+
+// ComponentLevel returns the level in effect for the component.
+func (sink *Sink) ComponentLevel(component string) (lager.LogLevel, error) {
+	if !IsComponent(component) {
+		return 0, ErrUnknownComponent
+	}
+
+	level, found := sink.levels.Get(component)
+	if !found {
+		return sink.GetMinLevel(), nil
+	}
+
+	return level, nil
+}
+
+func (sink *Sink) SetComponentLevel(component string, level lager.LogLevel) error {
+	return sink.levels.Set(component, level)
+}
+
+func (sink *Sink) Log(log lager.LogFormat) {
+	data := lager.Data{}
+	component := log.Source
+	for k, v := range log.Data {
+		if k == ComponentKey {
+			if c, ok := v.(string); ok {
+				component = c
+				continue
+			}
+		}
+
+		data[k] = v
+	}
+
+	minLevel, found := sink.levels.Get(component)
+	if !found {
+		minLevel = sink.GetMinLevel()
+	}
+
+	if log.LogLevel < minLevel {
+		return
+	}
+
+	entry := Entry{
+		Timestamp: parseTimestamp(log.Timestamp).UTC().Format(time.RFC3339Nano),
+		Level:     log.LogLevel.String(),
+		Source:    log.Source,
+		Component: component,
+		Message:   log.Message,
+		Data:      data,
+	}
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		entry.Data = lager.Data{
+			"lager serialisation error": err.Error(),
+			"data_dump":                 fmt.Sprintf("%#v", data),
+		}
+
+		content, err = json.Marshal(entry)
+		if err != nil {
+			return
+		}
+	}
+
+	sink.writerL.Lock()
+	sink.writer.Write(append(content, '\n'))
+	sink.writerL.Unlock()
+}
+
+// parseTimestamp reverses lager's "<seconds>.<nanoseconds>" formatting.
+func parseTimestamp(timestamp string) time.Time {
+	parts := strings.SplitN(timestamp, ".", 2)
+	if len(parts) != 2 {
+		return time.Now()
+	}
+
+	sec, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Now()
+	}
+
+	nsec, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return time.Now()
+	}
+
+	return time.Unix(sec, nsec)
+}
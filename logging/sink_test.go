@@ -0,0 +1,139 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/logging"
+)
+
+var _ = Describe("Sink", func() {
+	var (
+		buffer *bytes.Buffer
+		levels *logging.ComponentLevels
+		sink   *logging.Sink
+		logger lager.Logger
+	)
+
+	BeforeEach(func() {
+		buffer = new(bytes.Buffer)
+		levels = logging.NewComponentLevels()
+		sink = logging.NewSink(buffer, lager.INFO, levels)
+
+		logger = lager.NewLogger("atc")
+		logger.RegisterSink(sink)
+	})
+
+	entries := func() []map[string]interface{} {
+		var entries []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+			if line == "" {
+				continue
+			}
+
+			var entry map[string]interface{}
+			Expect(json.Unmarshal([]byte(line), &entry)).To(Succeed())
+
+			entries = append(entries, entry)
+		}
+
+		return entries
+	}
+
+	It("writes one JSON object per line with stable field names", func() {
+		logger.Session("scheduler").Info("tick", lager.Data{"job": "unit"})
+		logger.Error("failed", errors.New("nope"))
+
+		logs := entries()
+		Expect(logs).To(HaveLen(2))
+
+		Expect(logs[0]).To(HaveLen(6))
+		Expect(logs[0]["level"]).To(Equal("info"))
+		Expect(logs[0]["source"]).To(Equal("atc"))
+		Expect(logs[0]["component"]).To(Equal("atc"))
+		Expect(logs[0]["message"]).To(Equal("atc.scheduler.tick"))
+		Expect(logs[0]["data"]).To(HaveKeyWithValue("job", "unit"))
+
+		timestamp, err := time.Parse(time.RFC3339Nano, logs[0]["timestamp"].(string))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(timestamp).To(BeTemporally("~", time.Now(), time.Minute))
+
+		Expect(logs[1]["level"]).To(Equal("error"))
+		Expect(logs[1]["data"]).To(HaveKeyWithValue("error", "nope"))
+	})
+
+	It("lifts the component out of the data", func() {
+		logger.WithData(lager.Data{logging.ComponentKey: logging.ComponentAPI}).Info("request")
+
+		logs := entries()
+		Expect(logs).To(HaveLen(1))
+		Expect(logs[0]["component"]).To(Equal("api"))
+		Expect(logs[0]["data"]).To(BeEmpty())
+	})
+
+	It("filters on the sink's minimum level", func() {
+		logger.Debug("hidden")
+		logger.Info("shown")
+
+		sink.SetMinLevel(lager.DEBUG)
+		logger.Debug("now-shown")
+
+		logs := entries()
+		Expect(logs).To(HaveLen(2))
+		Expect(logs[0]["message"]).To(Equal("atc.shown"))
+		Expect(logs[1]["message"]).To(Equal("atc.now-shown"))
+	})
+
+	Context("when a component has its own level", func() {
+		var gcLogger lager.Logger
+
+		BeforeEach(func() {
+			gcLogger = logger.WithData(lager.Data{logging.ComponentKey: logging.ComponentGC})
+
+			Expect(sink.SetComponentLevel(logging.ComponentGC, lager.ERROR)).To(Succeed())
+		})
+
+		It("filters the component's logs on its level instead", func() {
+			gcLogger.Info("hidden")
+			gcLogger.Error("shown", errors.New("disaster"))
+			logger.Info("also-shown")
+
+			logs := entries()
+			Expect(logs).To(HaveLen(2))
+			Expect(logs[0]["message"]).To(Equal("atc.shown"))
+			Expect(logs[1]["message"]).To(Equal("atc.also-shown"))
+		})
+
+		It("reports the component's level", func() {
+			Expect(sink.ComponentLevel(logging.ComponentGC)).To(Equal(lager.ERROR))
+			Expect(sink.ComponentLevel(logging.ComponentAPI)).To(Equal(lager.INFO))
+		})
+
+		It("applies to other sinks sharing the levels", func() {
+			other := logging.NewSink(buffer, lager.DEBUG, levels)
+
+			tsaLogger := lager.NewLogger("tsa")
+			tsaLogger.RegisterSink(other)
+
+			Expect(other.SetComponentLevel(logging.ComponentTSA, lager.ERROR)).To(Succeed())
+			tsaLogger.Info("hidden")
+
+			Expect(buffer.String()).To(BeEmpty())
+			Expect(sink.ComponentLevel(logging.ComponentTSA)).To(Equal(lager.ERROR))
+		})
+	})
+
+	It("rejects unknown components", func() {
+		Expect(sink.SetComponentLevel("bogus", lager.DEBUG)).To(Equal(logging.ErrUnknownComponent))
+
+		_, err := sink.ComponentLevel("bogus")
+		Expect(err).To(Equal(logging.ErrUnknownComponent))
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"reflect"
 	"strings"
 	"time"
@@ -57,13 +58,23 @@ type AuthTeamFlags struct {
 }
 
 func (flag *AuthTeamFlags) Format() (atc.TeamAuth, error) {
+	auth, _, err := flag.FormatWithPipelines()
+	return auth, err
+}
+
+// FormatWithPipelines is like Format, but additionally returns the roles
+// which are only granted on individual pipelines. These can only be
+// configured through a configuration file, by listing the pipelines a role
+// applies to under 'pipelines'.
+func (flag *AuthTeamFlags) FormatWithPipelines() (atc.TeamAuth, atc.PipelineAuth, error) {
 
 	if path := flag.Config.Path(); path != "" {
 		return flag.formatFromFile()
 
 	}
-	return flag.formatFromFlags()
 
+	auth, err := flag.formatFromFlags()
+	return auth, nil, err
 }
 
 // When formatting from a configuration file we iterate over each connector
@@ -75,21 +86,22 @@ func (flag *AuthTeamFlags) Format() (atc.TeamAuth, error) {
 // The github connector has configuration for: users, teams, orgs
 // The cf conncetor has configuration for: users, orgs, spaces
 
-func (flag *AuthTeamFlags) formatFromFile() (atc.TeamAuth, error) {
+func (flag *AuthTeamFlags) formatFromFile() (atc.TeamAuth, atc.PipelineAuth, error) {
 
 	content, err := ioutil.ReadFile(flag.Config.Path())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var data struct {
 		Roles []map[string]interface{} `json:"roles"`
 	}
 	if err = yaml.Unmarshal(content, &data); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	auth := atc.TeamAuth{}
+	pipelineAuth := atc.PipelineAuth{}
 
 	for _, role := range data.Roles {
 		roleName := role["name"].(string)
@@ -105,12 +117,12 @@ func (flag *AuthTeamFlags) formatFromFile() (atc.TeamAuth, error) {
 
 			teamConfig, err := connector.newTeamConfig()
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			err = mapstructure.Decode(config, &teamConfig)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			for _, user := range teamConfig.GetUsers() {
@@ -138,17 +150,90 @@ func (flag *AuthTeamFlags) formatFromFile() (atc.TeamAuth, error) {
 			continue
 		}
 
-		auth[roleName] = map[string][]string{
-			"users":  users,
-			"groups": groups,
+		pipelines, err := rolePipelines(role)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(pipelines) == 0 {
+			auth[roleName] = map[string][]string{
+				"users":  users,
+				"groups": groups,
+			}
+			continue
+		}
+
+		for _, pipeline := range pipelines {
+			pipelineAuth.Grant(pipeline, roleName, map[string][]string{
+				"users":  users,
+				"groups": groups,
+			})
 		}
 	}
 
 	if err := auth.Validate(); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if err := pipelineAuth.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return auth, pipelineAuth, nil
+}
+
+// rolePipelines returns the pipelines a role is scoped to. Each is either
+// given by name, or as a map with its name and instance vars to pick out a
+// single instance of an instanced pipeline.
+func rolePipelines(role map[string]interface{}) ([]atc.PipelineRef, error) {
+	config, ok := role["pipelines"]
+	if !ok {
+		return nil, nil
+	}
+
+	invalid := fmt.Errorf("role '%v': pipelines must be a list of pipeline names, or of pipelines with their instance vars", role["name"])
+
+	list, ok := config.([]interface{})
+	if !ok {
+		return nil, invalid
+	}
+
+	pipelines := []atc.PipelineRef{}
+	for _, pipeline := range list {
+		switch p := pipeline.(type) {
+		case string:
+			if p == "" {
+				return nil, invalid
+			}
+
+			pipelines = append(pipelines, atc.PipelineRef{Name: p})
+
+		case map[string]interface{}:
+			name, _ := p["name"].(string)
+			if name == "" {
+				return nil, invalid
+			}
+
+			ref := atc.PipelineRef{Name: name}
+			if vars, found := p["instance_vars"]; found {
+				instanceVars, ok := vars.(map[string]interface{})
+				if !ok {
+					return nil, invalid
+				}
+
+				if len(instanceVars) > 0 {
+					ref.InstanceVars = instanceVars
+				}
+			}
+
+			pipelines = append(pipelines, ref)
+
+		default:
+			return nil, invalid
+		}
 	}
 
-	return auth, nil
+	return pipelines, nil
 }
 
 // When formatting team config from the command line flags, the connector's
@@ -205,6 +290,89 @@ type TeamConfig interface {
 	GetGroups() []string
 }
 
+// GroupRolesConfig is implemented by connector configs which can grant team
+// roles to the members of upstream groups, on top of each team's own auth
+// config.
+type GroupRolesConfig interface {
+	GroupRoles() (map[string]atc.TeamAuth, error)
+}
+
+// NestedGroupsConfig is implemented by connector configs which can look up
+// the groups that a user's groups are themselves members of.
+type NestedGroupsConfig interface {
+	NestedGroupsEndpoint() (urlTemplate string, key string, maxDepth int)
+	NestedGroupsClient() (*http.Client, error)
+}
+
+// parseGroupRoles parses TEAM:ROLE:GROUP mappings into the team auth config
+// they grant, keyed by team name.
+func parseGroupRoles(connectorID string, mappings []string) (map[string]atc.TeamAuth, error) {
+	groupRoles := map[string]atc.TeamAuth{}
+
+	for _, mapping := range mappings {
+		// the group goes last as group names may themselves contain colons
+		parts := strings.SplitN(mapping, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid group-role '%s': must be of the form TEAM:ROLE:GROUP", mapping)
+		}
+
+		team, role, group := parts[0], parts[1], connectorID+":"+strings.ToLower(parts[2])
+
+		if groupRoles[team] == nil {
+			groupRoles[team] = atc.TeamAuth{}
+		}
+
+		if groupRoles[team][role] == nil {
+			groupRoles[team][role] = map[string][]string{
+				"users":  {},
+				"groups": {},
+			}
+		}
+
+		groupRoles[team][role]["groups"] = append(groupRoles[team][role]["groups"], group)
+	}
+
+	return groupRoles, nil
+}
+
+// GroupRoles collects the team roles granted to upstream groups by every
+// connector, keyed by team name.
+func GroupRoles() (map[string]atc.TeamAuth, error) {
+	groupRoles := map[string]atc.TeamAuth{}
+
+	for _, connector := range connectors {
+		config, ok := connector.config.(GroupRolesConfig)
+		if !ok {
+			continue
+		}
+
+		connectorRoles, err := config.GroupRoles()
+		if err != nil {
+			return nil, err
+		}
+
+		for team, auth := range connectorRoles {
+			if groupRoles[team] == nil {
+				groupRoles[team] = atc.TeamAuth{}
+			}
+
+			for role, config := range auth {
+				if groupRoles[team][role] == nil {
+					groupRoles[team][role] = map[string][]string{
+						"users":  {},
+						"groups": {},
+					}
+				}
+
+				groupRoles[team][role]["users"] = append(groupRoles[team][role]["users"], config["users"]...)
+				groupRoles[team][role]["groups"] = append(groupRoles[team][role]["groups"], config["groups"]...)
+			}
+		}
+	}
+
+	return groupRoles, nil
+}
+
 type Connector struct {
 	id         string
 	config     Config
@@ -219,6 +387,10 @@ func (con *Connector) Name() string {
 	return con.config.Name()
 }
 
+func (con *Connector) Config() Config {
+	return con.config
+}
+
 func (con *Connector) Serialize(redirectURI string) ([]byte, error) {
 	return con.config.Serialize(redirectURI)
 }
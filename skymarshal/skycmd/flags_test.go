@@ -1,10 +1,16 @@
 package skycmd_test
 
 import (
+	"encoding/json"
 	"errors"
+	"io/ioutil"
+	"os"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/skymarshal/skycmd"
+	"github.com/concourse/dex/connector/ldap"
+	"github.com/concourse/dex/connector/saml"
+	"github.com/concourse/flag"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -72,3 +78,342 @@ var _ = Describe("skyDisplayUserIdGenerator", func() {
 		})
 	})
 })
+
+var _ = Describe("AuthTeamFlags", func() {
+	var (
+		configFile string
+		authFlags  skycmd.AuthTeamFlags
+	)
+
+	BeforeEach(func() {
+		file, err := ioutil.TempFile("", "team-config")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		configFile = file.Name()
+		authFlags = skycmd.AuthTeamFlags{Config: flag.File(configFile)}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(configFile)).To(Succeed())
+	})
+
+	writeConfig := func(config string) {
+		Expect(ioutil.WriteFile(configFile, []byte(config), 0644)).To(Succeed())
+	}
+
+	Describe("FormatWithPipelines", func() {
+		Context("when roles are scoped to pipelines", func() {
+			BeforeEach(func() {
+				writeConfig(`
+roles:
+- name: owner
+  local:
+    users: [admin]
+- name: pipeline-operator
+  pipelines: [deploy, release]
+  local:
+    users: [Releaser]
+`)
+			})
+
+			It("grants the team-wide roles on the team", func() {
+				auth, _, err := authFlags.FormatWithPipelines()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(auth).To(Equal(atc.TeamAuth{
+					"owner": {"users": {"local:admin"}, "groups": {}},
+				}))
+			})
+
+			It("grants the scoped roles on each pipeline", func() {
+				_, pipelineAuth, err := authFlags.FormatWithPipelines()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pipelineAuth).To(Equal(atc.PipelineAuth{
+					{
+						Pipeline: atc.PipelineRef{Name: "deploy"},
+						Auth: atc.TeamAuth{
+							"pipeline-operator": {"users": {"local:releaser"}, "groups": {}},
+						},
+					},
+					{
+						Pipeline: atc.PipelineRef{Name: "release"},
+						Auth: atc.TeamAuth{
+							"pipeline-operator": {"users": {"local:releaser"}, "groups": {}},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when roles are scoped to instances of a pipeline", func() {
+			BeforeEach(func() {
+				writeConfig(`
+roles:
+- name: owner
+  local:
+    users: [admin]
+- name: pipeline-operator
+  pipelines:
+  - name: deploy
+    instance_vars: {env: staging}
+  local:
+    users: [releaser]
+- name: viewer
+  pipelines:
+  - name: deploy
+    instance_vars: {env: staging}
+  - name: deploy
+    instance_vars: {env: production}
+  local:
+    users: [watcher]
+`)
+			})
+
+			It("grants the roles on each instance separately", func() {
+				_, pipelineAuth, err := authFlags.FormatWithPipelines()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pipelineAuth).To(Equal(atc.PipelineAuth{
+					{
+						Pipeline: atc.PipelineRef{Name: "deploy", InstanceVars: atc.InstanceVars{"env": "staging"}},
+						Auth: atc.TeamAuth{
+							"pipeline-operator": {"users": {"local:releaser"}, "groups": {}},
+							"viewer":            {"users": {"local:watcher"}, "groups": {}},
+						},
+					},
+					{
+						Pipeline: atc.PipelineRef{Name: "deploy", InstanceVars: atc.InstanceVars{"env": "production"}},
+						Auth: atc.TeamAuth{
+							"viewer": {"users": {"local:watcher"}, "groups": {}},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when the owner role is scoped to a pipeline", func() {
+			BeforeEach(func() {
+				writeConfig(`
+roles:
+- name: owner
+  local:
+    users: [admin]
+- name: owner
+  pipelines: [deploy]
+  local:
+    users: [someone]
+`)
+			})
+
+			It("errors", func() {
+				_, _, err := authFlags.FormatWithPipelines()
+				Expect(err).To(MatchError(ContainSubstring("the owner role can only be granted for the whole team")))
+			})
+		})
+
+		Context("when pipelines is not a list", func() {
+			BeforeEach(func() {
+				writeConfig(`
+roles:
+- name: owner
+  local:
+    users: [admin]
+- name: viewer
+  pipelines: deploy
+  local:
+    users: [someone]
+`)
+			})
+
+			It("errors", func() {
+				_, _, err := authFlags.FormatWithPipelines()
+				Expect(err).To(MatchError("role 'viewer': pipelines must be a list of pipeline names, or of pipelines with their instance vars"))
+			})
+		})
+	})
+})
+
+var _ = Describe("OIDCFlags", func() {
+	Describe("GroupRoles", func() {
+		It("grants each role on its team to the group", func() {
+			flags := skycmd.OIDCFlags{
+				GroupRoleMappings: []string{
+					"some-team:member:Some-Group",
+					"some-team:member:other-group",
+					"other-team:viewer:scoped:group",
+				},
+			}
+
+			groupRoles, err := flags.GroupRoles()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(groupRoles).To(Equal(map[string]atc.TeamAuth{
+				"some-team": {
+					"member": {"users": {}, "groups": {"oidc:some-group", "oidc:other-group"}},
+				},
+				"other-team": {
+					"viewer": {"users": {}, "groups": {"oidc:scoped:group"}},
+				},
+			}))
+		})
+
+		It("errors when a mapping is malformed", func() {
+			flags := skycmd.OIDCFlags{
+				GroupRoleMappings: []string{"some-team:some-group"},
+			}
+
+			_, err := flags.GroupRoles()
+			Expect(err).To(MatchError("invalid group-role 'some-team:some-group': must be of the form TEAM:ROLE:GROUP"))
+		})
+	})
+})
+
+var _ = Describe("SAMLFlags", func() {
+	Describe("GroupRoles", func() {
+		It("grants each role on its team to the group", func() {
+			flags := skycmd.SAMLFlags{
+				GroupRoleMappings: []string{"some-team:pipeline-operator:Deployers"},
+			}
+
+			groupRoles, err := flags.GroupRoles()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(groupRoles).To(Equal(map[string]atc.TeamAuth{
+				"some-team": {
+					"pipeline-operator": {"users": {}, "groups": {"saml:deployers"}},
+				},
+			}))
+		})
+	})
+
+	Describe("Validate", func() {
+		It("rejects malformed group-role mappings", func() {
+			flags := skycmd.SAMLFlags{
+				SsoURL:            "https://idp.example.com/sso",
+				CACert:            "/some/ca.crt",
+				GroupRoleMappings: []string{"some-team"},
+			}
+
+			Expect(flags.Validate()).To(MatchError(ContainSubstring("invalid group-role 'some-team'")))
+		})
+
+		It("requires a CA cert to verify signed responses with", func() {
+			flags := skycmd.SAMLFlags{
+				SsoURL: "https://idp.example.com/sso",
+			}
+
+			Expect(flags.Validate()).To(MatchError(ContainSubstring("Missing ca-cert")))
+		})
+	})
+
+	Describe("Serialize", func() {
+		var flags *skycmd.SAMLFlags
+
+		BeforeEach(func() {
+			flags = &skycmd.SAMLFlags{
+				SsoURL:       "https://idp.example.com/sso",
+				CACert:       "/some/ca.crt",
+				SsoIssuer:    "https://idp.example.com",
+				UsernameAttr: "uid",
+				EmailAttr:    "mail",
+				GroupsAttr:   "memberOf",
+				GroupsDelim:  ",",
+			}
+		})
+
+		serialize := func() saml.Config {
+			payload, err := flags.Serialize("https://example.com/sky/issuer/callback")
+			Expect(err).ToNot(HaveOccurred())
+
+			var config saml.Config
+			Expect(json.Unmarshal(payload, &config)).To(Succeed())
+
+			return config
+		}
+
+		It("verifies the signatures on responses from the issuer with the CA cert", func() {
+			config := serialize()
+			Expect(config.InsecureSkipSignatureValidation).To(BeFalse())
+			Expect(config.CA).To(Equal("/some/ca.crt"))
+			Expect(config.SSOIssuer).To(Equal("https://idp.example.com"))
+		})
+
+		It("maps the configured attributes to the user's name, email and groups", func() {
+			config := serialize()
+			Expect(config.UsernameAttr).To(Equal("uid"))
+			Expect(config.EmailAttr).To(Equal("mail"))
+			Expect(config.GroupsAttr).To(Equal("memberOf"))
+			Expect(config.GroupsDelim).To(Equal(","))
+		})
+
+		It("redirects back to the callback from the SSO URL", func() {
+			config := serialize()
+			Expect(config.SSOURL).To(Equal("https://idp.example.com/sso"))
+			Expect(config.RedirectURI).To(Equal("https://example.com/sky/issuer/callback"))
+		})
+	})
+})
+
+var _ = Describe("LDAPFlags", func() {
+	var flags *skycmd.LDAPFlags
+
+	BeforeEach(func() {
+		flags = &skycmd.LDAPFlags{
+			Host:   "ldap.example.com:389",
+			BindDN: "cn=admin,dc=example,dc=com",
+			BindPW: "secret",
+		}
+		flags.GroupSearch.BaseDN = "ou=groups,dc=example,dc=com"
+	})
+
+	serialize := func() ldap.Config {
+		payload, err := flags.Serialize("https://example.com/sky/issuer/callback")
+		Expect(err).ToNot(HaveOccurred())
+
+		var config ldap.Config
+		Expect(json.Unmarshal(payload, &config)).To(Succeed())
+
+		return config
+	}
+
+	Context("when resolving nested groups", func() {
+		BeforeEach(func() {
+			flags.GroupSearch.Nested = true
+		})
+
+		It("matches the user's DN against the chain of group members", func() {
+			config := serialize()
+			Expect(config.GroupSearch.UserAttr).To(Equal("DN"))
+			Expect(config.GroupSearch.GroupAttr).To(Equal("member:1.2.840.113556.1.4.1941:"))
+		})
+
+		It("respects a configured group attribute", func() {
+			flags.GroupSearch.GroupAttr = "uniqueMember"
+			Expect(serialize().GroupSearch.GroupAttr).To(Equal("uniqueMember:1.2.840.113556.1.4.1941:"))
+		})
+
+		It("requires a group search base DN", func() {
+			flags.GroupSearch.BaseDN = ""
+			Expect(flags.Validate()).To(MatchError(ContainSubstring("Missing group-search-base-dn")))
+		})
+	})
+
+	Context("when not resolving nested groups", func() {
+		It("passes the group attributes through as-is", func() {
+			flags.GroupSearch.GroupAttr = "member"
+			Expect(serialize().GroupSearch.GroupAttr).To(Equal("member"))
+		})
+	})
+
+	It("rejects combining start-tls with insecure-no-ssl", func() {
+		flags.StartTLS = true
+		flags.InsecureNoSSL = true
+		Expect(flags.Validate()).To(MatchError(ContainSubstring("Cannot use start-tls with insecure-no-ssl")))
+	})
+
+	It("passes start-tls and the CA cert through", func() {
+		flags.StartTLS = true
+		flags.CACert = "/some/ca.crt"
+
+		config := serialize()
+		Expect(config.StartTLS).To(BeTrue())
+		Expect(config.RootCA).To(Equal("/some/ca.crt"))
+	})
+})
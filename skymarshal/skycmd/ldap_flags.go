@@ -3,6 +3,7 @@ package skycmd
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 
 	"github.com/concourse/dex/connector/ldap"
 	"github.com/concourse/flag"
@@ -17,6 +18,10 @@ func init() {
 	})
 }
 
+// ldapMatchingRuleInChain is the OID of Active Directory's extensible match
+// rule for following group membership transitively.
+const ldapMatchingRuleInChain = "1.2.840.113556.1.4.1941"
+
 type LDAPFlags struct {
 	DisplayName        string    `long:"display-name" description:"The auth provider name displayed to users on the login page"`
 	Host               string    `long:"host" description:"(Required) The host and optional port of the LDAP server. If port isn't supplied, it will be guessed based on the TLS configuration. 389 or 636."`
@@ -45,6 +50,7 @@ type LDAPFlags struct {
 		UserAttr  string `long:"group-search-user-attr" description:"Adds an additional requirement to the filter that an attribute in the group match the user's attribute value. The exact filter being added is: (<groupAttr>=<userAttr value>)"`
 		GroupAttr string `long:"group-search-group-attr" description:"Adds an additional requirement to the filter that an attribute in the group match the user's attribute value. The exact filter being added is: (<groupAttr>=<userAttr value>)"`
 		NameAttr  string `long:"group-search-name-attr" description:"The attribute of the group that represents its name."`
+		Nested    bool   `long:"group-search-nested" description:"Also find the groups that the user's groups are nested in, by matching the group attribute against the user's DN with Active Directory's LDAP_MATCHING_RULE_IN_CHAIN. Defaults the user attribute to 'DN' and the group attribute to 'member'."`
 	}
 }
 
@@ -70,6 +76,14 @@ func (flag *LDAPFlags) Validate() error {
 		errs = multierror.Append(errs, errors.New("Missing bind-pw"))
 	}
 
+	if flag.StartTLS && flag.InsecureNoSSL {
+		errs = multierror.Append(errs, errors.New("Cannot use start-tls with insecure-no-ssl"))
+	}
+
+	if flag.GroupSearch.Nested && flag.GroupSearch.BaseDN == "" {
+		errs = multierror.Append(errs, errors.New("Missing group-search-base-dn, which is required for group-search-nested"))
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -104,6 +118,22 @@ func (flag *LDAPFlags) Serialize(redirectURI string) ([]byte, error) {
 	ldapConfig.GroupSearch.GroupAttr = flag.GroupSearch.GroupAttr
 	ldapConfig.GroupSearch.NameAttr = flag.GroupSearch.NameAttr
 
+	if flag.GroupSearch.Nested {
+		if ldapConfig.GroupSearch.UserAttr == "" {
+			ldapConfig.GroupSearch.UserAttr = "DN"
+		}
+
+		if ldapConfig.GroupSearch.GroupAttr == "" {
+			ldapConfig.GroupSearch.GroupAttr = "member"
+		}
+
+		// the group search filter becomes (member:1.2.840.113556.1.4.1941:=<user dn>),
+		// which walks the whole chain of nested groups on the server
+		if !strings.Contains(ldapConfig.GroupSearch.GroupAttr, ":") {
+			ldapConfig.GroupSearch.GroupAttr += ":" + ldapMatchingRuleInChain + ":"
+		}
+	}
+
 	return json.Marshal(ldapConfig)
 }
 
@@ -1,12 +1,23 @@
 package skycmd
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/dex/connector/oidc"
 	"github.com/concourse/flag"
 	"github.com/hashicorp/go-multierror"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 func init() {
@@ -30,6 +41,13 @@ type OIDCFlags struct {
 	InsecureSkipVerify        bool        `long:"skip-ssl-validation" description:"Skip SSL validation"`
 	DisableGroups             bool        `long:"disable-groups" description:"Disable OIDC groups claims"`
 	InsecureSkipEmailVerified bool        `long:"skip-email-verified-validation" description:"Ignore the email_verified claim from the upstream provider, treating all users as if email_verified were true."`
+	GroupRoleMappings         []string    `long:"group-role" description:"Grant a role on a team to every member of an OIDC group, in addition to the roles configured on the team itself. Can be specified multiple times." value-name:"TEAM:ROLE:GROUP"`
+
+	NestedGroups struct {
+		URL      string `long:"nested-groups-url" description:"Endpoint for looking up the groups that a group belongs to, with '{group}' in place of the group name. When set, users are also considered members of every parent group of their groups. Requests are authenticated using the client credentials grant."`
+		Key      string `long:"nested-groups-key" default:"groups" description:"The key in the nested groups endpoint's response which lists the parent groups."`
+		MaxDepth int    `long:"nested-groups-max-depth" default:"5" description:"How many levels of parent groups to resolve."`
+	}
 }
 
 func (flag *OIDCFlags) Name() string {
@@ -54,9 +72,132 @@ func (flag *OIDCFlags) Validate() error {
 		errs = multierror.Append(errs, errors.New("Missing client-secret"))
 	}
 
+	if _, err := flag.GroupRoles(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if flag.NestedGroups.URL != "" && !strings.Contains(flag.NestedGroups.URL, "{group}") {
+		errs = multierror.Append(errs, errors.New("nested-groups-url must contain '{group}'"))
+	}
+
 	return errs.ErrorOrNil()
 }
 
+// GroupRoles parses the group-role flags into the team auth config they
+// grant, keyed by team name.
+func (flag *OIDCFlags) GroupRoles() (map[string]atc.TeamAuth, error) {
+	return parseGroupRoles("oidc", flag.GroupRoleMappings)
+}
+
+func (flag *OIDCFlags) NestedGroupsEndpoint() (string, string, int) {
+	return flag.NestedGroups.URL, flag.NestedGroups.Key, flag.NestedGroups.MaxDepth
+}
+
+// NestedGroupsClient returns a client for querying the nested groups
+// endpoint, authenticated as the configured OIDC client. The token endpoint
+// is discovered from the issuer on first use.
+func (flag *OIDCFlags) NestedGroupsClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: flag.InsecureSkipVerify,
+	}
+
+	if len(flag.CACerts) > 0 {
+		pool := x509.NewCertPool()
+		for _, file := range flag.CACerts {
+			cert, err := ioutil.ReadFile(file.Path())
+			if err != nil {
+				return nil, err
+			}
+
+			if !pool.AppendCertsFromPEM(cert) {
+				return nil, fmt.Errorf("no certificates found in %s", file.Path())
+			}
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	baseClient := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, baseClient)
+
+	return oauth2.NewClient(ctx, oauth2.ReuseTokenSource(nil, &discoveringTokenSource{
+		ctx:          ctx,
+		client:       baseClient,
+		issuer:       flag.Issuer,
+		clientID:     flag.ClientID,
+		clientSecret: flag.ClientSecret,
+	})), nil
+}
+
+type discoveringTokenSource struct {
+	ctx          context.Context
+	client       *http.Client
+	issuer       string
+	clientID     string
+	clientSecret string
+
+	lock   sync.Mutex
+	source oauth2.TokenSource
+}
+
+func (s *discoveringTokenSource) Token() (*oauth2.Token, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.source == nil {
+		tokenURL, err := s.discoverTokenURL()
+		if err != nil {
+			return nil, err
+		}
+
+		config := clientcredentials.Config{
+			ClientID:     s.clientID,
+			ClientSecret: s.clientSecret,
+			TokenURL:     tokenURL,
+		}
+
+		s.source = config.TokenSource(s.ctx)
+	}
+
+	return s.source.Token()
+}
+
+func (s *discoveringTokenSource) discoverTokenURL() (string, error) {
+	wellKnown := strings.TrimSuffix(s.issuer, "/") + "/.well-known/openid-configuration"
+
+	resp, err := s.client.Get(wellKnown)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("discover token endpoint: %s returned %s", wellKnown, resp.Status)
+	}
+
+	var discovery struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&discovery)
+	if err != nil {
+		return "", err
+	}
+
+	if discovery.TokenEndpoint == "" {
+		return "", fmt.Errorf("discover token endpoint: %s has no token_endpoint", wellKnown)
+	}
+
+	return discovery.TokenEndpoint, nil
+}
+
 func (flag *OIDCFlags) Serialize(redirectURI string) ([]byte, error) {
 	if err := flag.Validate(); err != nil {
 		return nil, err
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/dex/connector/saml"
 	"github.com/concourse/flag"
 	multierror "github.com/hashicorp/go-multierror"
@@ -18,17 +19,18 @@ func init() {
 }
 
 type SAMLFlags struct {
-	DisplayName        string      `long:"display-name" description:"The auth provider name displayed to users on the login page"`
-	SsoURL             string      `long:"sso-url" description:"(Required) SSO URL used for POST value"`
-	CACert             flag.File   `long:"ca-cert" description:"(Required) CA Certificate"`
-	EntityIssuer       string      `long:"entity-issuer" description:"Manually specify dex's Issuer value."`
-	SsoIssuer          string      `long:"sso-issuer" description:"Issuer value expected in the SAML response."`
-	UsernameAttr       string      `long:"username-attr" default:"name" description:"The user name indicates which claim to use to map an external user name to a Concourse user name."`
-	EmailAttr          string      `long:"email-attr" default:"email" description:"The email indicates which claim to use to map an external user email to a Concourse user email."`
-	GroupsAttr         string      `long:"groups-attr" default:"groups" description:"The groups key indicates which attribute to use to map external groups to Concourse teams."`
-	GroupsDelim        string      `long:"groups-delim" description:"If specified, groups are returned as string, this delimiter will be used to split the group string."`
-	NameIDPolicyFormat string      `long:"name-id-policy-format" description:"Requested format of the NameID. The NameID value is is mapped to the ID Token 'sub' claim."`
-	InsecureSkipVerify bool        `long:"skip-ssl-validation" description:"Skip SSL validation"`
+	DisplayName        string    `long:"display-name" description:"The auth provider name displayed to users on the login page"`
+	SsoURL             string    `long:"sso-url" description:"(Required) SSO URL used for POST value"`
+	CACert             flag.File `long:"ca-cert" description:"(Required) CA Certificate"`
+	EntityIssuer       string    `long:"entity-issuer" description:"Manually specify dex's Issuer value."`
+	SsoIssuer          string    `long:"sso-issuer" description:"Issuer value expected in the SAML response."`
+	UsernameAttr       string    `long:"username-attr" default:"name" description:"The user name indicates which claim to use to map an external user name to a Concourse user name."`
+	EmailAttr          string    `long:"email-attr" default:"email" description:"The email indicates which claim to use to map an external user email to a Concourse user email."`
+	GroupsAttr         string    `long:"groups-attr" default:"groups" description:"The groups key indicates which attribute to use to map external groups to Concourse teams."`
+	GroupsDelim        string    `long:"groups-delim" description:"If specified, groups are returned as string, this delimiter will be used to split the group string."`
+	NameIDPolicyFormat string    `long:"name-id-policy-format" description:"Requested format of the NameID. The NameID value is is mapped to the ID Token 'sub' claim."`
+	InsecureSkipVerify bool      `long:"skip-ssl-validation" description:"Skip validation of the signatures on SAML responses. Never use this outside of testing."`
+	GroupRoleMappings  []string  `long:"group-role" description:"Grant a role on a team to every user whose groups attribute contains the group, in addition to the roles configured on the team itself. Can be specified multiple times." value-name:"TEAM:ROLE:GROUP"`
 }
 
 func (flag *SAMLFlags) Name() string {
@@ -49,9 +51,19 @@ func (flag *SAMLFlags) Validate() error {
 		errs = multierror.Append(errs, errors.New("Missing ca-cert"))
 	}
 
+	if _, err := flag.GroupRoles(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
 	return errs.ErrorOrNil()
 }
 
+// GroupRoles parses the group-role flags into the team auth config they
+// grant, keyed by team name.
+func (flag *SAMLFlags) GroupRoles() (map[string]atc.TeamAuth, error) {
+	return parseGroupRoles("saml", flag.GroupRoleMappings)
+}
+
 func (flag *SAMLFlags) Serialize(redirectURI string) ([]byte, error) {
 	if err := flag.Validate(); err != nil {
 		return nil, err
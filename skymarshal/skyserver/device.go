@@ -0,0 +1,281 @@
+package skyserver
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/dex/storage"
+)
+
+// The device authorization flow (RFC 8628) lets clients which can't receive
+// a browser redirect, like fly on a headless machine, log in. The client asks
+// for a user code, the user approves it in their browser using their web
+// session, and the client polls until it's given that session's token.
+
+const (
+	deviceCodeValidFor  = 5 * time.Minute
+	devicePollInterval  = 5
+	grantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+	deviceTokenPending  = "pending"
+	deviceTokenComplete = "complete"
+)
+
+type deviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type deviceTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type deviceErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *SkyServer) DeviceCode(w http.ResponseWriter, r *http.Request) {
+	logger := s.config.Logger.Session("device-code")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	deviceCode := storage.NewDeviceCode()
+	userCode := storage.NewUserCode()
+	expiry := time.Now().Add(deviceCodeValidFor)
+
+	err := s.config.Storage.CreateDeviceRequest(storage.DeviceRequest{
+		UserCode:   userCode,
+		DeviceCode: deviceCode,
+		ClientID:   r.FormValue("client_id"),
+		Scopes:     strings.Fields(r.FormValue("scope")),
+		Expiry:     expiry,
+	})
+	if err != nil {
+		logger.Error("failed-to-create-device-request", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	err = s.config.Storage.CreateDeviceToken(storage.DeviceToken{
+		DeviceCode:          deviceCode,
+		Status:              deviceTokenPending,
+		Expiry:              expiry,
+		LastRequestTime:     time.Now(),
+		PollIntervalSeconds: devicePollInterval,
+	})
+	if err != nil {
+		logger.Error("failed-to-create-device-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	json.NewEncoder(w).Encode(deviceCodeResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         s.config.DeviceURL,
+		VerificationURIComplete: s.config.DeviceURL + "?" + url.Values{"user_code": {userCode}}.Encode(),
+		ExpiresIn:               int(deviceCodeValidFor.Seconds()),
+		Interval:                devicePollInterval,
+	})
+}
+
+// Device shows the page on which the user approves a device. Approval is
+// always an explicit, CSRF-protected POST so that following a link alone
+// can't hand out the user's token.
+func (s *SkyServer) Device(w http.ResponseWriter, r *http.Request) {
+	logger := s.config.Logger.Session("device")
+
+	userCode := strings.ToUpper(strings.TrimSpace(r.FormValue("user_code")))
+	if userCode == "" {
+		s.renderDevicePage(w, http.StatusOK, devicePage{})
+		return
+	}
+
+	deviceRequest, err := s.config.Storage.GetDeviceRequest(userCode)
+	if err != nil || time.Now().After(deviceRequest.Expiry) {
+		if err != nil && err != storage.ErrNotFound {
+			logger.Error("failed-to-get-device-request", err)
+		}
+
+		s.renderDevicePage(w, http.StatusBadRequest, devicePage{Invalid: true})
+		return
+	}
+
+	oauth2Token, ok := s.authToken(logger, r)
+	if !ok {
+		redirectURI := "/sky/device?" + url.Values{"user_code": {userCode}}.Encode()
+		http.Redirect(w, r, "/sky/login?"+url.Values{"redirect_uri": {redirectURI}}.Encode(), http.StatusTemporaryRedirect)
+		return
+	}
+
+	csrfToken := s.config.TokenMiddleware.GetCSRFToken(r)
+
+	if r.Method != http.MethodPost {
+		s.renderDevicePage(w, http.StatusOK, devicePage{
+			UserCode:  userCode,
+			CSRFToken: csrfToken,
+		})
+		return
+	}
+
+	if csrfToken == "" || r.FormValue("csrf_token") != csrfToken {
+		logger.Info("csrf-token-mismatch")
+		http.Error(w, "invalid csrf token", http.StatusBadRequest)
+		return
+	}
+
+	tokenJSON, err := json.Marshal(deviceTokenResponse{
+		AccessToken: oauth2Token.AccessToken,
+		TokenType:   oauth2Token.TokenType,
+		ExpiresIn:   int(time.Until(oauth2Token.Expiry).Seconds()),
+	})
+	if err != nil {
+		logger.Error("failed-to-marshal-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	err = s.config.Storage.UpdateDeviceToken(deviceRequest.DeviceCode, func(old storage.DeviceToken) (storage.DeviceToken, error) {
+		if old.Status == deviceTokenComplete {
+			return old, errors.New("device already approved")
+		}
+
+		old.Token = string(tokenJSON)
+		old.Status = deviceTokenComplete
+		return old, nil
+	})
+	if err != nil {
+		logger.Error("failed-to-update-device-token", err)
+		s.renderDevicePage(w, http.StatusBadRequest, devicePage{Invalid: true})
+		return
+	}
+
+	s.renderDevicePage(w, http.StatusOK, devicePage{Approved: true})
+}
+
+// DeviceToken is polled by the device until the user has approved it.
+func (s *SkyServer) DeviceToken(w http.ResponseWriter, r *http.Request) {
+	logger := s.config.Logger.Session("device-token")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.FormValue("grant_type") != grantTypeDeviceCode {
+		writeDeviceError(w, "unsupported_grant_type")
+		return
+	}
+
+	deviceCode := r.FormValue("device_code")
+
+	deviceToken, err := s.config.Storage.GetDeviceToken(deviceCode)
+	if err != nil {
+		if err != storage.ErrNotFound {
+			logger.Error("failed-to-get-device-token", err)
+		}
+
+		writeDeviceError(w, "invalid_grant")
+		return
+	}
+
+	now := time.Now()
+	if now.After(deviceToken.Expiry) {
+		writeDeviceError(w, "expired_token")
+		return
+	}
+
+	if deviceToken.Status == deviceTokenComplete {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte(deviceToken.Token))
+		return
+	}
+
+	slowDown := now.Before(deviceToken.LastRequestTime.Add(time.Duration(deviceToken.PollIntervalSeconds) * time.Second))
+
+	err = s.config.Storage.UpdateDeviceToken(deviceCode, func(old storage.DeviceToken) (storage.DeviceToken, error) {
+		old.LastRequestTime = now
+		return old, nil
+	})
+	if err != nil {
+		logger.Error("failed-to-update-device-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if slowDown {
+		writeDeviceError(w, "slow_down")
+		return
+	}
+
+	writeDeviceError(w, "authorization_pending")
+}
+
+func writeDeviceError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(deviceErrorResponse{Error: code})
+}
+
+type devicePage struct {
+	UserCode  string
+	CSRFToken string
+	Invalid   bool
+	Approved  bool
+}
+
+var devicePageTemplate = template.Must(template.New("device").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Concourse - Log in a device</title></head>
+<body>
+{{if .Approved}}
+<p>Your device has been logged in. You may now close this window.</p>
+{{else if .UserCode}}
+<form method="post" action="/sky/device">
+<p>Log in the device showing the code <strong>{{.UserCode}}</strong>?</p>
+<p>Only continue if you started this login yourself.</p>
+<input type="hidden" name="user_code" value="{{.UserCode}}">
+<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+<button type="submit">Log in</button>
+</form>
+{{else}}
+<form method="get" action="/sky/device">
+{{if .Invalid}}<p>That code is invalid or has expired.</p>{{end}}
+<p>Enter the code shown on your device:</p>
+<input type="text" name="user_code" autofocus>
+<button type="submit">Continue</button>
+</form>
+{{end}}
+</body>
+</html>
+`))
+
+func (s *SkyServer) renderDevicePage(w http.ResponseWriter, status int, page devicePage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+
+	err := devicePageTemplate.Execute(w, page)
+	if err != nil {
+		s.config.Logger.Error("failed-to-render-device-page", err, lager.Data{"status": status})
+	}
+}
@@ -0,0 +1,234 @@
+package skyserver_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/concourse/dex/storage"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Device authorization", func() {
+	var client *http.Client
+
+	BeforeEach(func() {
+		skyServer.Start()
+
+		client = skyServer.Client()
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	})
+
+	requestDeviceCode := func() map[string]interface{} {
+		response, err := client.PostForm(skyServer.URL+"/sky/device/code", url.Values{
+			"client_id": {"fly"},
+			"scope":     {"openid groups"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(response.Header.Get("Cache-Control")).To(Equal("no-store"))
+
+		var code map[string]interface{}
+		err = json.NewDecoder(response.Body).Decode(&code)
+		Expect(err).NotTo(HaveOccurred())
+
+		return code
+	}
+
+	pollForToken := func(deviceCode string) (int, map[string]interface{}) {
+		response, err := client.PostForm(skyServer.URL+"/sky/device/token", url.Values{
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"device_code": {deviceCode},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var body map[string]interface{}
+		err = json.NewDecoder(response.Body).Decode(&body)
+		Expect(err).NotTo(HaveOccurred())
+
+		return response.StatusCode, body
+	}
+
+	// skip the poll interval, as if the device had waited
+	waitForInterval := func(deviceCode string) {
+		err := dexStorage.UpdateDeviceToken(deviceCode, func(old storage.DeviceToken) (storage.DeviceToken, error) {
+			old.LastRequestTime = time.Now().Add(-time.Minute)
+			return old, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	approve := func(userCode string, csrfToken string) *http.Response {
+		response, err := client.PostForm(skyServer.URL+"/sky/device", url.Values{
+			"user_code":  {userCode},
+			"csrf_token": {csrfToken},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return response
+	}
+
+	Describe("POST /sky/device/code", func() {
+		It("returns a device code, user code and verification uris", func() {
+			code := requestDeviceCode()
+			Expect(code["device_code"]).NotTo(BeEmpty())
+			Expect(code["user_code"]).NotTo(BeEmpty())
+			Expect(code["verification_uri"]).To(Equal("https://example.com/sky/device"))
+			Expect(code["verification_uri_complete"]).To(Equal("https://example.com/sky/device?user_code=" + url.QueryEscape(code["user_code"].(string))))
+			Expect(code["expires_in"]).To(BeNumerically(">", 0))
+			Expect(code["interval"]).To(BeNumerically(">", 0))
+		})
+	})
+
+	Describe("POST /sky/device/token", func() {
+		var deviceCode, userCode string
+
+		BeforeEach(func() {
+			code := requestDeviceCode()
+			deviceCode = code["device_code"].(string)
+			userCode = code["user_code"].(string)
+		})
+
+		Context("before the device has been approved", func() {
+			BeforeEach(func() {
+				waitForInterval(deviceCode)
+			})
+
+			It("tells the device to keep polling", func() {
+				status, body := pollForToken(deviceCode)
+				Expect(status).To(Equal(http.StatusBadRequest))
+				Expect(body["error"]).To(Equal("authorization_pending"))
+			})
+
+			It("tells the device to slow down if it polls too often", func() {
+				pollForToken(deviceCode)
+
+				status, body := pollForToken(deviceCode)
+				Expect(status).To(Equal(http.StatusBadRequest))
+				Expect(body["error"]).To(Equal("slow_down"))
+			})
+		})
+
+		Context("after the device has been approved", func() {
+			BeforeEach(func() {
+				fakeTokenParser.ParseExpiryReturns(time.Now().Add(time.Hour), nil)
+				fakeTokenMiddleware.GetAuthTokenReturns("bearer some-token")
+				fakeTokenMiddleware.GetCSRFTokenReturns("some-csrf-token")
+
+				response := approve(userCode, "some-csrf-token")
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns the user's token", func() {
+				status, body := pollForToken(deviceCode)
+				Expect(status).To(Equal(http.StatusOK))
+				Expect(body["access_token"]).To(Equal("some-token"))
+				Expect(body["token_type"]).To(Equal("bearer"))
+				Expect(body["expires_in"]).To(BeNumerically("~", time.Hour.Seconds(), 5))
+			})
+		})
+
+		Context("when the device code is unknown", func() {
+			It("errors", func() {
+				status, body := pollForToken("bogus")
+				Expect(status).To(Equal(http.StatusBadRequest))
+				Expect(body["error"]).To(Equal("invalid_grant"))
+			})
+		})
+
+		Context("when the grant type is wrong", func() {
+			It("errors", func() {
+				response, err := client.PostForm(skyServer.URL+"/sky/device/token", url.Values{
+					"grant_type":  {"authorization_code"},
+					"device_code": {deviceCode},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("/sky/device", func() {
+		var userCode string
+
+		BeforeEach(func() {
+			userCode = requestDeviceCode()["user_code"].(string)
+		})
+
+		Context("without a user code", func() {
+			It("asks for one", func() {
+				response, err := client.Get(skyServer.URL + "/sky/device")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(body)).To(ContainSubstring(`name="user_code"`))
+			})
+		})
+
+		Context("with an unknown user code", func() {
+			It("says the code is invalid", func() {
+				response, err := client.Get(skyServer.URL + "/sky/device?user_code=BOGUS")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(body)).To(ContainSubstring("invalid or has expired"))
+			})
+		})
+
+		Context("when the user is not logged in", func() {
+			It("logs them in and comes back", func() {
+				response, err := client.Get(skyServer.URL + "/sky/device?user_code=" + strings.ToLower(userCode))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusTemporaryRedirect))
+
+				location, err := response.Location()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(location.Path).To(Equal("/sky/login"))
+				Expect(location.Query().Get("redirect_uri")).To(Equal("/sky/device?user_code=" + url.QueryEscape(userCode)))
+			})
+		})
+
+		Context("when the user is logged in", func() {
+			BeforeEach(func() {
+				fakeTokenParser.ParseExpiryReturns(time.Now().Add(time.Hour), nil)
+				fakeTokenMiddleware.GetAuthTokenReturns("bearer some-token")
+				fakeTokenMiddleware.GetCSRFTokenReturns("some-csrf-token")
+			})
+
+			It("asks them to confirm the code rather than approving it straight away", func() {
+				response, err := client.Get(skyServer.URL + "/sky/device?user_code=" + userCode)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(body)).To(ContainSubstring(userCode))
+				Expect(string(body)).To(ContainSubstring(`method="post"`))
+				Expect(string(body)).To(ContainSubstring(`value="some-csrf-token"`))
+			})
+
+			It("rejects approvals without the csrf token", func() {
+				response := approve(userCode, "wrong-csrf-token")
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+
+			It("can only approve a device once", func() {
+				response := approve(userCode, "some-csrf-token")
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				response = approve(userCode, "some-csrf-token")
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
@@ -15,6 +15,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/skymarshal/token"
+	"github.com/concourse/dex/storage"
 	"golang.org/x/oauth2"
 	"gopkg.in/square/go-jose.v2/jwt"
 )
@@ -25,6 +26,8 @@ type SkyConfig struct {
 	TokenParser     token.Parser
 	OAuthConfig     *oauth2.Config
 	HTTPClient      *http.Client
+	Storage         storage.Storage
+	DeviceURL       string
 }
 
 func NewSkyHandler(server *SkyServer) http.Handler {
@@ -32,6 +35,9 @@ func NewSkyHandler(server *SkyServer) http.Handler {
 	handler.HandleFunc("/sky/login", server.Login)
 	handler.HandleFunc("/sky/logout", server.Logout)
 	handler.HandleFunc("/sky/callback", server.Callback)
+	handler.HandleFunc("/sky/device", server.Device)
+	handler.HandleFunc("/sky/device/code", server.DeviceCode)
+	handler.HandleFunc("/sky/device/token", server.DeviceToken)
 	return handler
 }
 
@@ -47,8 +53,8 @@ func (s *SkyServer) Login(w http.ResponseWriter, r *http.Request) {
 
 	logger := s.config.Logger.Session("login")
 
-	tokenString := s.config.TokenMiddleware.GetAuthToken(r)
-	if tokenString == "" {
+	oauth2Token, ok := s.authToken(logger, r)
+	if !ok {
 		s.NewLogin(w, r)
 		return
 	}
@@ -58,34 +64,40 @@ func (s *SkyServer) Login(w http.ResponseWriter, r *http.Request) {
 		redirectURI = "/"
 	}
 
+	s.Redirect(w, r, oauth2Token, redirectURI)
+}
+
+// authToken returns the token from the user's auth cookie, if they have one
+// and it hasn't expired.
+func (s *SkyServer) authToken(logger lager.Logger, r *http.Request) (*oauth2.Token, bool) {
+	tokenString := s.config.TokenMiddleware.GetAuthToken(r)
+	if tokenString == "" {
+		return nil, false
+	}
+
 	parts := strings.Split(tokenString, " ")
 
 	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
 		logger.Info("failed-to-parse-cookie")
-		s.NewLogin(w, r)
-		return
+		return nil, false
 	}
 
 	expiry, err := s.config.TokenParser.ParseExpiry(parts[1])
 	if err != nil {
 		logger.Error("failed-to-parse-expiration", err)
-		s.NewLogin(w, r)
-		return
+		return nil, false
 	}
 	nowWithLeeway := time.Now().Add(-jwt.DefaultLeeway)
 	if expiry.Before(nowWithLeeway) {
 		logger.Info("token-is-expired")
-		s.NewLogin(w, r)
-		return
+		return nil, false
 	}
 
-	oauth2Token := &oauth2.Token{
+	return &oauth2.Token{
 		TokenType:   parts[0],
 		AccessToken: parts[1],
 		Expiry:      expiry,
-	}
-
-	s.Redirect(w, r, oauth2Token, redirectURI)
+	}, true
 }
 
 func (s *SkyServer) NewLogin(w http.ResponseWriter, r *http.Request) {
@@ -10,8 +10,11 @@ import (
 	. "github.com/onsi/gomega"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/skymarshal/logger"
 	"github.com/concourse/concourse/skymarshal/skyserver"
 	"github.com/concourse/concourse/skymarshal/token/tokenfakes"
+	"github.com/concourse/dex/storage"
+	"github.com/concourse/dex/storage/memory"
 	"github.com/onsi/gomega/ghttp"
 	"golang.org/x/oauth2"
 )
@@ -19,6 +22,7 @@ import (
 var (
 	fakeTokenMiddleware *tokenfakes.FakeMiddleware
 	fakeTokenParser     *tokenfakes.FakeParser
+	dexStorage          storage.Storage
 	skyServer           *httptest.Server
 	dexServer           *ghttp.Server
 	signingKey          *rsa.PrivateKey
@@ -54,12 +58,16 @@ var _ = BeforeEach(func() {
 		Scopes:       []string{"some-scope"},
 	}
 
+	dexStorage = memory.New(logger.New(lagertest.NewTestLogger("dex")))
+
 	config = &skyserver.SkyConfig{
 		Logger:          lagertest.NewTestLogger("sky"),
 		TokenMiddleware: fakeTokenMiddleware,
 		TokenParser:     fakeTokenParser,
 		OAuthConfig:     oauthConfig,
 		HTTPClient:      dexServer.HTTPTestServer.Client(),
+		Storage:         dexStorage,
+		DeviceURL:       "https://example.com/sky/device",
 	}
 
 	server, err := skyserver.NewSkyServer(config)
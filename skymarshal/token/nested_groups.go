@@ -0,0 +1,177 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//counterfeiter:generate . GroupResolver
+type GroupResolver interface {
+	ParentGroups(group string) ([]string, error)
+}
+
+// NewNestedGroupsClaimsParser wraps a ClaimsParser, expanding the groups
+// claim of users logging in through the given connector to include every
+// group that their groups are nested in.
+//
+// If the groups cannot be resolved the user is let in with the groups that
+// the identity provider reported, as that grants no more than it did before.
+func NewNestedGroupsClaimsParser(
+	logger lager.Logger,
+	parser ClaimsParser,
+	connectorID string,
+	resolver GroupResolver,
+	maxDepth int,
+) ClaimsParser {
+	return nestedGroupsClaimsParser{
+		logger:      logger,
+		parser:      parser,
+		connectorID: connectorID,
+		resolver:    resolver,
+		maxDepth:    maxDepth,
+	}
+}
+
+type nestedGroupsClaimsParser struct {
+	logger      lager.Logger
+	parser      ClaimsParser
+	connectorID string
+	resolver    GroupResolver
+	maxDepth    int
+}
+
+func (p nestedGroupsClaimsParser) ParseClaims(idToken string) (db.Claims, error) {
+	claims, err := p.parser.ParseClaims(idToken)
+	if err != nil {
+		return db.Claims{}, err
+	}
+
+	if claims.Connector != p.connectorID {
+		return claims, nil
+	}
+
+	rawGroups, ok := claims.RawClaims["groups"].([]interface{})
+	if !ok || len(rawGroups) == 0 {
+		return claims, nil
+	}
+
+	groups := []string{}
+	for _, rawGroup := range rawGroups {
+		if group, ok := rawGroup.(string); ok {
+			groups = append(groups, group)
+		}
+	}
+
+	resolved, err := p.resolve(groups)
+	if err != nil {
+		p.logger.Error("failed-to-resolve-nested-groups", err, lager.Data{"user": claims.UserID})
+		return claims, nil
+	}
+
+	expanded := []interface{}{}
+	for _, group := range resolved {
+		expanded = append(expanded, group)
+	}
+
+	claims.RawClaims["groups"] = expanded
+
+	return claims, nil
+}
+
+func (p nestedGroupsClaimsParser) resolve(groups []string) ([]string, error) {
+	seen := map[string]bool{}
+	resolved := []string{}
+
+	for _, group := range groups {
+		if !seen[group] {
+			seen[group] = true
+			resolved = append(resolved, group)
+		}
+	}
+
+	current := resolved
+	for depth := 0; depth < p.maxDepth && len(current) > 0; depth++ {
+		next := []string{}
+
+		for _, group := range current {
+			parents, err := p.resolver.ParentGroups(group)
+			if err != nil {
+				return nil, fmt.Errorf("resolve parents of group '%s': %w", group, err)
+			}
+
+			for _, parent := range parents {
+				if parent != "" && !seen[parent] {
+					seen[parent] = true
+					resolved = append(resolved, parent)
+					next = append(next, parent)
+				}
+			}
+		}
+
+		current = next
+	}
+
+	return resolved, nil
+}
+
+// NewHTTPGroupResolver looks up the parent groups of a group by substituting
+// it for '{group}' in the URL template and reading the list under the given
+// key from the JSON response.
+func NewHTTPGroupResolver(client *http.Client, urlTemplate string, key string) GroupResolver {
+	return httpGroupResolver{
+		client:      client,
+		urlTemplate: urlTemplate,
+		key:         key,
+	}
+}
+
+type httpGroupResolver struct {
+	client      *http.Client
+	urlTemplate string
+	key         string
+}
+
+func (r httpGroupResolver) ParentGroups(group string) ([]string, error) {
+	groupURL := strings.ReplaceAll(r.urlTemplate, "{group}", url.PathEscape(group))
+
+	resp, err := r.client.Get(groupURL)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	var body map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return nil, err
+	}
+
+	rawParents, ok := body[r.key].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	parents := []string{}
+	for _, rawParent := range rawParents {
+		if parent, ok := rawParent.(string); ok {
+			parents = append(parents, parent)
+		}
+	}
+
+	return parents, nil
+}
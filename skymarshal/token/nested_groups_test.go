@@ -0,0 +1,153 @@
+package token_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/skymarshal/token"
+	"github.com/concourse/concourse/skymarshal/token/tokenfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Nested Groups", func() {
+	Describe("NestedGroupsClaimsParser", func() {
+		var (
+			claimsParser  *tokenfakes.FakeClaimsParser
+			groupResolver *tokenfakes.FakeGroupResolver
+			parser        token.ClaimsParser
+
+			claims db.Claims
+			err    error
+		)
+
+		BeforeEach(func() {
+			claimsParser = new(tokenfakes.FakeClaimsParser)
+			claimsParser.ParseClaimsReturns(db.Claims{
+				FederatedClaims: db.FederatedClaims{Connector: "oidc", UserID: "some-user"},
+				RawClaims: map[string]interface{}{
+					"groups": []interface{}{"team-a"},
+				},
+			}, nil)
+
+			groupResolver = new(tokenfakes.FakeGroupResolver)
+			groupResolver.ParentGroupsStub = func(group string) ([]string, error) {
+				switch group {
+				case "team-a":
+					return []string{"engineering"}, nil
+				case "engineering":
+					return []string{"everyone", "team-a"}, nil
+				case "everyone":
+					return []string{"the-world"}, nil
+				}
+				return nil, nil
+			}
+
+			parser = token.NewNestedGroupsClaimsParser(
+				lagertest.NewTestLogger("test"),
+				claimsParser,
+				"oidc",
+				groupResolver,
+				2,
+			)
+		})
+
+		JustBeforeEach(func() {
+			claims, err = parser.ParseClaims("a.b.c")
+		})
+
+		It("adds the parent groups up to the max depth", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claims.RawClaims["groups"]).To(Equal([]interface{}{"team-a", "engineering", "everyone"}))
+		})
+
+		It("passes the id token through", func() {
+			Expect(claimsParser.ParseClaimsArgsForCall(0)).To(Equal("a.b.c"))
+		})
+
+		Context("when the user logged in through another connector", func() {
+			BeforeEach(func() {
+				claimsParser.ParseClaimsReturns(db.Claims{
+					FederatedClaims: db.FederatedClaims{Connector: "github"},
+					RawClaims: map[string]interface{}{
+						"groups": []interface{}{"team-a"},
+					},
+				}, nil)
+			})
+
+			It("leaves the groups alone", func() {
+				Expect(claims.RawClaims["groups"]).To(Equal([]interface{}{"team-a"}))
+				Expect(groupResolver.ParentGroupsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when resolving the groups fails", func() {
+			BeforeEach(func() {
+				groupResolver.ParentGroupsStub = nil
+				groupResolver.ParentGroupsReturns(nil, errors.New("nope"))
+			})
+
+			It("keeps the groups reported by the identity provider", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claims.RawClaims["groups"]).To(Equal([]interface{}{"team-a"}))
+			})
+		})
+
+		Context("when parsing the claims fails", func() {
+			BeforeEach(func() {
+				claimsParser.ParseClaimsReturns(db.Claims{}, errors.New("nope"))
+			})
+
+			It("errors", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("HTTPGroupResolver", func() {
+		var (
+			server   *ghttp.Server
+			resolver token.GroupResolver
+		)
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			resolver = token.NewHTTPGroupResolver(http.DefaultClient, server.URL()+"/groups/{group}/parents", "parents")
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("returns the parent groups listed under the key", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/groups/some-group/parents"),
+					ghttp.RespondWith(http.StatusOK, `{"parents":["parent-a","parent-b"]}`),
+				),
+			)
+
+			parents, err := resolver.ParentGroups("some-group")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parents).To(Equal([]string{"parent-a", "parent-b"}))
+		})
+
+		It("returns no parents when the group is not found", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, ""))
+
+			parents, err := resolver.ParentGroups("some-group")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parents).To(BeEmpty())
+		})
+
+		It("errors on any other failure", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+			_, err := resolver.ParentGroups("some-group")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -2,7 +2,9 @@ package tracing
 
 import (
 	"fmt"
+	"net/http"
 
+	"github.com/concourse/flag"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/trace/jaeger"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -15,6 +17,11 @@ type Jaeger struct {
 	Endpoint string            `long:"jaeger-endpoint" description:"jaeger http-based thrift collector"`
 	Tags     map[string]string `long:"jaeger-tags"     description:"tags to add to the components"`
 	Service  string            `long:"jaeger-service"  description:"jaeger process service name" default:"web"`
+
+	Headers    map[string]string `long:"jaeger-header"      description:"headers to attach to each request to the collector"`
+	CACert     flag.File         `long:"jaeger-ca-cert"     description:"file containing the CA certificate used to verify the jaeger collector"`
+	ClientCert flag.File         `long:"jaeger-client-cert" description:"file containing the client certificate used for mTLS with the jaeger collector"`
+	ClientKey  flag.File         `long:"jaeger-client-key"  description:"file containing the client private key used for mTLS with the jaeger collector"`
 }
 
 // IsConfigured identifies if an endpoint has been set
@@ -25,8 +32,16 @@ func (j Jaeger) IsConfigured() bool {
 // Exporter returns a SpanExporter to sync spans to Jaeger
 func (j Jaeger) Exporter() (sdktrace.SpanExporter, []sdktrace.TracerProviderOption, error) {
 	attributes := append([]attribute.KeyValue{semconv.ServiceNameKey.String(j.Service)}, keyValueSlice(j.Tags)...)
+	client, err := j.httpClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	exporter, err := jaeger.NewRawExporter(
-		jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(j.Endpoint)),
+		jaeger.WithCollectorEndpoint(
+			jaeger.WithEndpoint(j.Endpoint),
+			jaeger.WithHTTPClient(client),
+		),
 	)
 	extraOptions := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(resource.NewWithAttributes(attributes...)),
@@ -38,3 +53,23 @@ func (j Jaeger) Exporter() (sdktrace.SpanExporter, []sdktrace.TracerProviderOpti
 
 	return exporter, extraOptions, nil
 }
+
+func (j Jaeger) httpClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if j.CACert != "" || j.ClientCert != "" || j.ClientKey != "" {
+		config, err := tlsConfig(j.CACert, j.ClientCert, j.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+
+		transport.TLSClientConfig = config
+	}
+
+	return &http.Client{
+		Transport: headerTransport{
+			headers: j.Headers,
+			base:    transport,
+		},
+	}, nil
+}
@@ -3,6 +3,7 @@ package tracing
 import (
 	"context"
 
+	"github.com/concourse/flag"
 	"go.opentelemetry.io/otel/exporters/otlp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -11,9 +12,12 @@ import (
 
 // OTLP service to export traces to
 type OTLP struct {
-	Address string            `long:"otlp-address" description:"otlp address to send traces to"`
-	Headers map[string]string `long:"otlp-header" description:"headers to attach to each tracing message"`
-	UseTLS  bool              `long:"otlp-use-tls" description:"whether to use tls or not"`
+	Address    string            `long:"otlp-address" description:"otlp address to send traces to"`
+	Headers    map[string]string `long:"otlp-header" description:"headers to attach to each tracing message"`
+	UseTLS     bool              `long:"otlp-use-tls" description:"whether to use tls or not"`
+	CACert     flag.File         `long:"otlp-ca-cert" description:"file containing the CA certificate used to verify the otlp collector (implies --tracing-otlp-use-tls)"`
+	ClientCert flag.File         `long:"otlp-client-cert" description:"file containing the client certificate used for mTLS with the otlp collector"`
+	ClientKey  flag.File         `long:"otlp-client-key" description:"file containing the client private key used for mTLS with the otlp collector"`
 }
 
 // IsConfigured identifies if an Address has been set
@@ -21,20 +25,34 @@ func (s OTLP) IsConfigured() bool {
 	return s.Address != ""
 }
 
-func (s OTLP) security() otlpgrpc.Option {
+func (s OTLP) security() (otlpgrpc.Option, error) {
+	if s.CACert != "" || s.ClientCert != "" || s.ClientKey != "" {
+		config, err := tlsConfig(s.CACert, s.ClientCert, s.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return otlpgrpc.WithTLSCredentials(credentials.NewTLS(config)), nil
+	}
+
 	if s.UseTLS {
-		return otlpgrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, ""))
+		return otlpgrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")), nil
 	}
 
-	return otlpgrpc.WithInsecure()
+	return otlpgrpc.WithInsecure(), nil
 }
 
 // Exporter returns a SpanExporter to sync spans to OTLP
 func (s OTLP) Exporter() (sdktrace.SpanExporter, []sdktrace.TracerProviderOption, error) {
+	security, err := s.security()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	driver := otlpgrpc.NewDriver(
 		otlpgrpc.WithEndpoint(s.Address),
 		otlpgrpc.WithHeaders(s.Headers),
-		s.security(),
+		security,
 	)
 	exporter, err := otlp.NewExporter(context.TODO(), driver)
 	if err != nil {
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/flag"
+)
+
+// tlsConfig builds the client TLS configuration used to reach a collector.
+// The CA certificate is optional and falls back to the system pool; the
+// client certificate and key enable mTLS and must be given together.
+func tlsConfig(caCert, clientCert, clientKey flag.File) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caCert != "" {
+		pem, err := ioutil.ReadFile(caCert.Path())
+		if err != nil {
+			return nil, fmt.Errorf("read ca cert: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caCert.Path())
+		}
+
+		config.RootCAs = pool
+	}
+
+	if clientCert != "" || clientKey != "" {
+		if clientCert == "" || clientKey == "" {
+			return nil, fmt.Errorf("client cert and client key must be specified together")
+		}
+
+		cert, err := tls.LoadX509KeyPair(clientCert.Path(), clientKey.Path())
+		if err != nil {
+			return nil, fmt.Errorf("load client cert: %w", err)
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// headerTransport attaches a fixed set of headers to every request.
+type headerTransport struct {
+	headers map[string]string
+	base    http.RoundTripper
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -24,6 +24,9 @@ var Configured bool
 type Config struct {
 	ServiceName string            `long:"service-name"  description:"service name to attach to traces as metadata" default:"concourse-web"`
 	Attributes  map[string]string `long:"attribute"  description:"attributes to attach to traces as metadata"`
+	Sampler     string            `long:"sampler" default:"always" choice:"always" choice:"never" choice:"ratio" description:"which root spans to sample"`
+	SampleRatio float64           `long:"sample-ratio" default:"1" description:"fraction of traces to sample when using the ratio sampler"`
+	ParentBased bool              `long:"parent-based" description:"follow the sampling decision of the parent span, applying the sampler only to root spans"`
 	Honeycomb   Honeycomb
 	Jaeger      Jaeger
 	Stackdriver Stackdriver
@@ -44,6 +47,26 @@ func (c Config) resource() *resource.Resource {
 	return resource.NewWithAttributes(attributes...)
 }
 
+// TraceSampler returns the sampler described by the sampling flags. An
+// unset sampler samples everything, matching the default.
+func (c Config) TraceSampler() sdktrace.Sampler {
+	var sampler sdktrace.Sampler
+	switch c.Sampler {
+	case "never":
+		sampler = sdktrace.NeverSample()
+	case "ratio":
+		sampler = sdktrace.TraceIDRatioBased(c.SampleRatio)
+	default:
+		sampler = sdktrace.AlwaysSample()
+	}
+
+	if c.ParentBased {
+		sampler = sdktrace.ParentBased(sampler)
+	}
+
+	return sampler
+}
+
 func (c Config) TraceProvider(exporter func() (sdktrace.SpanExporter, []sdktrace.TracerProviderOption, error)) (trace.TracerProvider, error) {
 	exp, exporterOptions, err := exporter()
 	if err != nil {
@@ -51,7 +74,7 @@ func (c Config) TraceProvider(exporter func() (sdktrace.SpanExporter, []sdktrace
 	}
 
 	options := append([]sdktrace.TracerProviderOption{
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSampler(c.TraceSampler()),
 		sdktrace.WithSyncer(exp),
 		sdktrace.WithResource(c.resource()),
 	}, exporterOptions...)
@@ -155,6 +178,32 @@ func StartSpanFollowing(
 	return startSpan(ctx, component, attrs)
 }
 
+// StartSpanFollowingLinked is like StartSpanFollowing, but additionally links
+// the new span to the span context carried by linked, if there is one. This
+// ties together traces which are related without being nested, such as a
+// rerun build and the build it was rerun from.
+func StartSpanFollowingLinked(
+	ctx context.Context,
+	following WithSpanContext,
+	linked propagation.TextMapCarrier,
+	component string,
+	attrs Attrs,
+) (context.Context, trace.Span) {
+	if supplier := following.SpanContext(); supplier != nil {
+		ctx = propagation.TraceContext{}.Extract(ctx, supplier)
+	}
+
+	var opts []trace.SpanOption
+	if linked != nil {
+		linkedCtx := propagation.TraceContext{}.Extract(context.Background(), linked)
+		if linkedSpanContext := trace.SpanContextFromContext(linkedCtx); linkedSpanContext.IsValid() {
+			opts = append(opts, trace.WithLinks(trace.Link{SpanContext: linkedSpanContext}))
+		}
+	}
+
+	return startSpan(ctx, component, attrs, opts...)
+}
+
 func StartSpanLinkedToFollowing(
 	linked context.Context,
 	following WithSpanContext,
@@ -2,14 +2,17 @@ package tracing_test
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/concourse/concourse/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/oteltest"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 )
 
 var _ = Describe("Tracer", func() {
@@ -59,6 +62,34 @@ var _ = Describe("Tracer", func() {
 		})
 	})
 
+	Describe("StartSpanFollowingLinked", func() {
+		It("links the span to the given span context", func() {
+			linkedCtx, linkedSpan := tracing.StartSpan(context.Background(), "original", nil)
+			linkedSpan.End()
+
+			linked := propagation.HeaderCarrier{}
+			tracing.Inject(linkedCtx, linked)
+
+			_, span := tracing.StartSpanFollowingLinked(context.Background(), noSpanContext{}, linked, "rerun", nil)
+			span.End()
+
+			spans := spanRecorder.Completed()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[1].Links()).To(HaveLen(1))
+			Expect(spans[1].Links()[0].SpanContext.TraceID()).To(Equal(linkedSpan.SpanContext().TraceID()))
+			Expect(spans[1].Links()[0].SpanContext.SpanID()).To(Equal(linkedSpan.SpanContext().SpanID()))
+		})
+
+		It("does not link the span without a span context", func() {
+			_, span := tracing.StartSpanFollowingLinked(context.Background(), noSpanContext{}, propagation.HeaderCarrier{}, "build", nil)
+			span.End()
+
+			spans := spanRecorder.Completed()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Links()).To(BeEmpty())
+		})
+	})
+
 	Describe("Prepare", func() {
 		BeforeEach(func() {
 			tracing.Configured = false
@@ -90,5 +121,76 @@ var _ = Describe("Tracer", func() {
 			c.Prepare()
 			Expect(tracing.Configured).To(BeFalse())
 		})
+
+		It("attaches the configured headers to jaeger requests", func() {
+			collector := ghttp.NewServer()
+			defer collector.Close()
+
+			collector.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/api/traces"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+				ghttp.RespondWith(http.StatusAccepted, nil),
+			))
+
+			c := tracing.Config{
+				Jaeger: tracing.Jaeger{
+					Endpoint: collector.URL() + "/api/traces",
+					Headers:  map[string]string{"Authorization": "Bearer some-token"},
+				},
+			}
+			Expect(c.Prepare()).To(Succeed())
+
+			_, span := tracing.StartSpan(context.Background(), "a", nil)
+			span.End()
+
+			Expect(collector.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("errors if only one half of the client key pair is given", func() {
+			c := tracing.Config{
+				OTLP: tracing.OTLP{
+					Address:    "ingest.example.com:443",
+					ClientCert: "/some/cert.pem",
+				},
+			}
+			Expect(c.Prepare()).To(MatchError(ContainSubstring("client cert and client key must be specified together")))
+			Expect(tracing.Configured).To(BeFalse())
+		})
+
+		It("errors if the CA certificate cannot be read", func() {
+			c := tracing.Config{
+				Jaeger: tracing.Jaeger{
+					Endpoint: "http://jaeger:14268/api/traces",
+					CACert:   "/does/not/exist.pem",
+				},
+			}
+			Expect(c.Prepare()).To(MatchError(ContainSubstring("read ca cert")))
+			Expect(tracing.Configured).To(BeFalse())
+		})
+	})
+
+	Describe("TraceSampler", func() {
+		It("samples everything by default", func() {
+			Expect(tracing.Config{}.TraceSampler().Description()).To(Equal("AlwaysOnSampler"))
+		})
+
+		It("samples nothing with the never sampler", func() {
+			c := tracing.Config{Sampler: "never"}
+			Expect(c.TraceSampler().Description()).To(Equal("AlwaysOffSampler"))
+		})
+
+		It("samples a ratio of traces", func() {
+			c := tracing.Config{Sampler: "ratio", SampleRatio: 0.25}
+			Expect(c.TraceSampler().Description()).To(Equal("TraceIDRatioBased{0.25}"))
+		})
+
+		It("defers to the parent span when parent-based", func() {
+			c := tracing.Config{Sampler: "ratio", SampleRatio: 0.25, ParentBased: true}
+			Expect(c.TraceSampler().Description()).To(HavePrefix("ParentBased{root:TraceIDRatioBased{0.25}"))
+		})
 	})
 })
+
+type noSpanContext struct{}
+
+func (noSpanContext) SpanContext() propagation.TextMapCarrier { return nil }
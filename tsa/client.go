@@ -12,6 +12,7 @@ import (
 	"net"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -323,6 +324,49 @@ func (client *Client) ReportVolumes(ctx context.Context, handles []string) error
 	return client.run(ctx, sshClient, strings.Join(command, " "), os.Stdout)
 }
 
+// ReportUsage invokes the 'report-usage' command, sending the worker's disk
+// usage to Concourse.
+func (client *Client) ReportUsage(ctx context.Context, usage atc.WorkerUsage) error {
+	logger := lagerctx.FromContext(ctx)
+
+	sshClient, _, err := client.dial(ctx, 0)
+	if err != nil {
+		logger.Error("failed-to-dial", err)
+		return err
+	}
+
+	defer sshClient.Close()
+
+	command := []string{
+		"report-usage",
+		"--disk-used", strconv.FormatUint(usage.DiskUsedBytes, 10),
+		"--disk-free", strconv.FormatUint(usage.DiskFreeBytes, 10),
+		"--container-scratch", strconv.FormatUint(usage.ContainerScratchBytes, 10),
+	}
+
+	command = append(command, sizeFlags("--container", usage.ContainerBytes)...)
+	command = append(command, sizeFlags("--volume", usage.VolumeBytes)...)
+
+	return client.run(ctx, sshClient, strings.Join(command, " "), os.Stdout)
+}
+
+// sizeFlags returns a HANDLE=BYTES flag for each handle, in order.
+func sizeFlags(flag string, sizes map[string]uint64) []string {
+	handles := make([]string, 0, len(sizes))
+	for handle := range sizes {
+		handles = append(handles, handle)
+	}
+
+	sort.Strings(handles)
+
+	flags := []string{}
+	for _, handle := range handles {
+		flags = append(flags, flag, handle+"="+strconv.FormatUint(sizes[handle], 10))
+	}
+
+	return flags
+}
+
 func (client *Client) dial(ctx context.Context, idleTimeout time.Duration) (*ssh.Client, *net.TCPConn, error) {
 	logger := lagerctx.WithSession(ctx, "dial")
 
@@ -0,0 +1,69 @@
+package main_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ReportUsage", func() {
+	var reportErr error
+
+	JustBeforeEach(func() {
+		reportErr = tsaClient.ReportUsage(context.TODO(), atc.WorkerUsage{
+			DiskUsedBytes:         1024,
+			DiskFreeBytes:         2048,
+			ContainerScratchBytes: 512,
+			ContainerBytes:        map[string]uint64{"some-container": 512},
+			VolumeBytes:           map[string]uint64{"some-volume": 256, "other-volume": 0},
+		})
+	})
+
+	Context("when the worker is registered for a team", func() {
+		BeforeEach(func() {
+			tsaClient.Worker.Team = "some-team"
+		})
+
+		Context("with the team key", func() {
+			BeforeEach(func() {
+				tsaClient.PrivateKey = teamKey
+			})
+
+			Context("when the ATC is working", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/usage"),
+						ghttp.VerifyJSONRepresenting(atc.WorkerUsage{
+							DiskUsedBytes:         1024,
+							DiskFreeBytes:         2048,
+							ContainerScratchBytes: 512,
+							ContainerBytes:        map[string]uint64{"some-container": 512},
+							VolumeBytes:           map[string]uint64{"some-volume": 256, "other-volume": 0},
+						}),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					))
+				})
+
+				It("sends the usage to the ATC", func() {
+					Expect(reportErr).ToNot(HaveOccurred())
+					Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+				})
+			})
+		})
+
+		Context("with some other team's key", func() {
+			BeforeEach(func() {
+				tsaClient.PrivateKey = otherTeamKey
+			})
+
+			It("fails", func() {
+				Expect(reportErr).To(HaveOccurred())
+				Expect(atcServer.ReceivedRequests()).To(HaveLen(0))
+			})
+		})
+	})
+})
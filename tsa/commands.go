@@ -9,5 +9,6 @@ const (
 
 	ReportContainers      = "report-containers"
 	ReportVolumes         = "report-volumes"
+	ReportUsage           = "report-usage"
 	ResourceActionMissing = "resource-type-missing"
 )
@@ -18,6 +18,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/logging"
 	"github.com/concourse/concourse/tsa"
 	"github.com/concourse/flag"
 	"github.com/tedsuo/ifrit"
@@ -30,7 +31,7 @@ import (
 )
 
 type TSACommand struct {
-	Logger flag.Lager
+	Logger logging.Lager
 
 	BindIP      flag.IP `long:"bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for SSH."`
 	PeerAddress string  `long:"peer-address" default:"127.0.0.1" description:"Network address of this web node, reachable by other web nodes. Used for forwarded worker addresses."`
@@ -185,7 +186,7 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 	return serverRunner{logger, server, listenAddr}, nil
 }
 
-func (cmd *TSACommand) constructLogger() (lager.Logger, *lager.ReconfigurableSink) {
+func (cmd *TSACommand) constructLogger() (lager.Logger, *logging.Sink) {
 	logger, reconfigurableSink := cmd.Logger.Logger("tsa")
 	if cmd.LogClusterName {
 		logger = logger.WithData(lager.Data{
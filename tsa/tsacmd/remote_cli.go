@@ -327,6 +327,29 @@ func (req reportVolumesRequest) Handle(ctx context.Context, state ConnState, cha
 	}).WorkerStatus(ctx, worker, tsa.ReportVolumes)
 }
 
+type reportUsageRequest struct {
+	server *server
+	usage  atc.WorkerUsage
+}
+
+func (req reportUsageRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+	var worker atc.Worker
+	err := json.NewDecoder(channel).Decode(&worker)
+	if err != nil {
+		return err
+	}
+
+	if err := checkTeam(state, worker); err != nil {
+		return err
+	}
+
+	return (&tsa.WorkerStatus{
+		ATCEndpoint: req.server.atcEndpointPicker.Pick(),
+		HTTPClient:  req.server.httpClient,
+		Usage:       req.usage,
+	}).WorkerStatus(ctx, worker, tsa.ReportUsage)
+}
+
 func gardenURL(addr string) string {
 	return fmt.Sprintf("http://%s", addr)
 }
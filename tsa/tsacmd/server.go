@@ -8,12 +8,14 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/tsa"
 	"golang.org/x/crypto/ssh"
 )
@@ -538,9 +540,57 @@ func (server *server) parseRequest(cli string) (request, string, error) {
 			server:        server,
 			volumeHandles: args,
 		}
+	case tsa.ReportUsage:
+		var fs = flag.NewFlagSet(command, flag.ContinueOnError)
+
+		var diskUsed = fs.Uint64("disk-used", 0, "bytes used on the worker's volumes disk")
+		var diskFree = fs.Uint64("disk-free", 0, "bytes free on the worker's volumes disk")
+		var containerScratch = fs.Uint64("container-scratch", 0, "bytes used by containers outside of volumes")
+
+		var containerBytes, volumeBytes = sizesFlag{}, sizesFlag{}
+		fs.Var(containerBytes, "container", "HANDLE=BYTES used by a container outside of its volumes (can be specified multiple times)")
+		fs.Var(volumeBytes, "volume", "HANDLE=BYTES used by a volume (can be specified multiple times)")
+
+		err := fs.Parse(args)
+		if err != nil {
+			return nil, "", err
+		}
+
+		req = reportUsageRequest{
+			server: server,
+			usage: atc.WorkerUsage{
+				DiskUsedBytes:         *diskUsed,
+				DiskFreeBytes:         *diskFree,
+				ContainerScratchBytes: *containerScratch,
+				ContainerBytes:        containerBytes,
+				VolumeBytes:           volumeBytes,
+			},
+		}
 	default:
 		return nil, "", fmt.Errorf("unknown command: %s", command)
 	}
 
 	return req, command, nil
 }
+
+// sizesFlag collects HANDLE=BYTES flags.
+type sizesFlag map[string]uint64
+
+func (sizes sizesFlag) String() string {
+	return fmt.Sprintf("%v", map[string]uint64(sizes))
+}
+
+func (sizes sizesFlag) Set(value string) error {
+	handle, bytes := value, ""
+	if i := strings.LastIndex(value, "="); i != -1 {
+		handle, bytes = value[:i], value[i+1:]
+	}
+
+	size, err := strconv.ParseUint(bytes, 10, 64)
+	if handle == "" || err != nil {
+		return fmt.Errorf("invalid size '%s', expected HANDLE=BYTES", value)
+	}
+
+	sizes[handle] = size
+	return nil
+}
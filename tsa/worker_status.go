@@ -23,6 +23,7 @@ type WorkerStatus struct {
 	HTTPClient       *http.Client
 	ContainerHandles []string
 	VolumeHandles    []string
+	Usage            atc.WorkerUsage
 }
 
 func (l *WorkerStatus) WorkerStatus(ctx context.Context, worker atc.Worker, resourceAction string) error {
@@ -60,6 +61,22 @@ func (l *WorkerStatus) WorkerStatus(ctx context.Context, worker atc.Worker, reso
 
 		request, err = l.ATCEndpoint.CreateRequest(atc.ReportWorkerVolumes, nil, bytes.NewBuffer(handlesBytes))
 
+		if err != nil {
+			logger.Error("failed-to-construct-request", err)
+			return err
+		}
+	case ReportUsage:
+		var usageBytes []byte
+		usageBytes, err = json.Marshal(l.Usage)
+		if err != nil {
+			logger.Error("failed-to-encode-request-body", err)
+			return err
+		}
+
+		request, err = l.ATCEndpoint.CreateRequest(atc.ReportWorkerUsage, rata.Params{
+			"worker_name": worker.Name,
+		}, bytes.NewBuffer(usageBytes))
+
 		if err != nil {
 			logger.Error("failed-to-construct-request", err)
 			return err
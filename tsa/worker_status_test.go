@@ -259,4 +259,44 @@ var _ = Describe("Worker Status Test", func() {
 			})
 		})
 	})
+	Context("Usage", func() {
+		BeforeEach(func() {
+			workerStatus.Usage = atc.WorkerUsage{
+				DiskUsedBytes:         1024,
+				DiskFreeBytes:         2048,
+				ContainerScratchBytes: 512,
+				ContainerBytes:        map[string]uint64{"some-container": 512},
+				VolumeBytes:           map[string]uint64{"some-volume": 256},
+			}
+
+			fakeATC.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/usage"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer yo"),
+				ghttp.VerifyJSON(`{"disk_used_bytes":1024,"disk_free_bytes":2048,"container_scratch_bytes":512,"container_bytes":{"some-container":512},"volume_bytes":{"some-volume":256}}`),
+				ghttp.RespondWith(204, nil, nil),
+			))
+		})
+
+		It("reports the worker's usage to the ATC", func() {
+			err := workerStatus.WorkerStatus(ctx, worker, tsa.ReportUsage)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeATC.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		Context("when the ATC responds with non 204", func() {
+			BeforeEach(func() {
+				fakeATC.Reset()
+				fakeATC.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/usage"),
+					ghttp.RespondWith(500, nil, nil),
+				))
+			})
+
+			It("errors", func() {
+				err := workerStatus.WorkerStatus(ctx, worker, tsa.ReportUsage)
+				Expect(err).To(MatchError(ContainSubstring("bad-response (500)")))
+			})
+		})
+	})
 })
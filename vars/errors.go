@@ -57,3 +57,12 @@ type InvalidInterpolationError struct {
 func (err InvalidInterpolationError) Error() string {
 	return fmt.Sprintf("cannot interpolate non-primitive value (%T) from var: %s", err.Value, err.Name)
 }
+
+type FunctionError struct {
+	Function string
+	Err      error
+}
+
+func (err FunctionError) Error() string {
+	return fmt.Sprintf("function '%s' failed: %s", err.Function, err.Err)
+}
@@ -0,0 +1,341 @@
+package vars
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Functions are the functions which can be called in a var, e.g.
+// ((base64_decode(vault:ssh.key))). Arguments are either vars, function calls
+// or literal strings in single quotes.
+var Functions = map[string]func(args []interface{}) (interface{}, error){
+	"base64_encode": stringFunction(func(s string) (string, error) {
+		return base64.StdEncoding.EncodeToString([]byte(s)), nil
+	}),
+	"base64_decode": stringFunction(func(s string) (string, error) {
+		decoded, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return "", err
+		}
+
+		return string(decoded), nil
+	}),
+	"trim": stringFunction(func(s string) (string, error) {
+		return strings.TrimSpace(s), nil
+	}),
+	"to_json": toJSON,
+	"to_yaml": toYAML,
+	"concat":  concat,
+}
+
+// DerivedVarsTracker is implemented by Variables which track the values they
+// return so that they can be redacted. The values computed from them by
+// functions are tracked too, so that e.g. a decoded secret is also redacted.
+type DerivedVarsTracker interface {
+	TrackDerived(name string, val interface{})
+}
+
+func stringFunction(fn func(string) (string, error)) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+		}
+
+		s, err := stringArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return fn(s)
+	}
+}
+
+func concat(args []interface{}) (interface{}, error) {
+	var result strings.Builder
+	for _, arg := range args {
+		s, err := stringArg(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		result.WriteString(s)
+	}
+
+	return result.String(), nil
+}
+
+func toJSON(args []interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+	}
+
+	payload, err := json.Marshal(stringKeys(args[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(payload), nil
+}
+
+func toYAML(args []interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+	}
+
+	payload, err := yaml.Marshal(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.TrimSuffix(string(payload), "\n"), nil
+}
+
+func stringArg(arg interface{}) (string, error) {
+	switch arg.(type) {
+	case string, bool, int, int16, int32, int64, uint, uint16, uint32, uint64, float32, float64, json.Number:
+		return fmt.Sprintf("%v", arg), nil
+	default:
+		return "", fmt.Errorf("expected a string, got %T", arg)
+	}
+}
+
+// stringKeys converts the maps unmarshalled from YAML so that they can be
+// marshalled to JSON.
+func stringKeys(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		converted := map[string]interface{}{}
+		for k, vv := range v {
+			converted[fmt.Sprintf("%v", k)] = stringKeys(vv)
+		}
+		return converted
+	case map[string]interface{}:
+		converted := map[string]interface{}{}
+		for k, vv := range v {
+			converted[k] = stringKeys(vv)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, vv := range v {
+			converted[i] = stringKeys(vv)
+		}
+		return converted
+	default:
+		return val
+	}
+}
+
+// functionCall is a call parsed from a var such as ((concat(a, '-', b))).
+type functionCall struct {
+	name string
+	args []functionArg
+}
+
+// functionArg is set to exactly one of a literal, a var or a nested call.
+type functionArg struct {
+	literal *string
+	varName string
+	call    *functionCall
+}
+
+func (call functionCall) varNames() []string {
+	var names []string
+	for _, arg := range call.args {
+		switch {
+		case arg.call != nil:
+			names = append(names, arg.call.varNames()...)
+		case arg.literal == nil:
+			names = append(names, arg.varName)
+		}
+	}
+
+	return names
+}
+
+// evaluate calls the function with its arguments. It returns false if any of
+// the vars it refers to are not found.
+func (call functionCall) evaluate(tracker varsTracker) (interface{}, bool, bool, error) {
+	args := make([]interface{}, len(call.args))
+	allFound := true
+	usesVars := false
+
+	for i, arg := range call.args {
+		switch {
+		case arg.literal != nil:
+			args[i] = *arg.literal
+		case arg.call != nil:
+			val, found, nestedUsesVars, err := arg.call.evaluate(tracker)
+			if err != nil {
+				return nil, false, false, err
+			}
+
+			allFound = allFound && found
+			usesVars = usesVars || nestedUsesVars
+			args[i] = val
+		default:
+			val, found, err := tracker.Get(arg.varName)
+			if err != nil {
+				return nil, false, false, err
+			}
+
+			allFound = allFound && found
+			usesVars = true
+			args[i] = val
+		}
+	}
+
+	if !allFound {
+		return nil, false, usesVars, nil
+	}
+
+	val, err := Functions[call.name](args)
+	if err != nil {
+		return nil, false, false, FunctionError{Function: call.name, Err: err}
+	}
+
+	return val, true, usesVars, nil
+}
+
+// parseFunctionCall parses the call at the start of s, following the '(('
+// of a var, returning its length. It returns false if s does not start with
+// a call to a known function, in which case it is not treated as a var.
+func parseFunctionCall(s string) (functionCall, int, bool, error) {
+	p := &functionParser{input: s}
+
+	name, ok := p.functionName()
+	if !ok {
+		return functionCall{}, 0, false, nil
+	}
+
+	call, err := p.call(name)
+	if err != nil {
+		return functionCall{}, 0, true, err
+	}
+
+	return call, p.pos, true, nil
+}
+
+type functionParser struct {
+	input string
+	pos   int
+}
+
+// functionName reads the name of a known function followed by '('.
+func (p *functionParser) functionName() (string, bool) {
+	end := p.pos
+	for end < len(p.input) && (p.input[end] == '_' || unicode.IsLetter(rune(p.input[end])) || unicode.IsDigit(rune(p.input[end]))) {
+		end++
+	}
+
+	if end == p.pos || end >= len(p.input) || p.input[end] != '(' {
+		return "", false
+	}
+
+	name := p.input[p.pos:end]
+	if _, found := Functions[name]; !found {
+		return "", false
+	}
+
+	p.pos = end
+	return name, true
+}
+
+func (p *functionParser) call(name string) (functionCall, error) {
+	call := functionCall{name: name}
+
+	// skip the '('
+	p.pos++
+
+	p.skipSpaces()
+	if p.peek() == ')' {
+		p.pos++
+		return call, nil
+	}
+
+	for {
+		arg, err := p.arg()
+		if err != nil {
+			return functionCall{}, err
+		}
+
+		call.args = append(call.args, arg)
+
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return call, nil
+		default:
+			return functionCall{}, fmt.Errorf("expected ',' or ')' in call to %s", name)
+		}
+	}
+}
+
+func (p *functionParser) arg() (functionArg, error) {
+	p.skipSpaces()
+
+	if p.peek() == '\'' {
+		end := strings.IndexByte(p.input[p.pos+1:], '\'')
+		if end == -1 {
+			return functionArg{}, fmt.Errorf("unterminated string at: %s", p.input[p.pos:])
+		}
+
+		literal := p.input[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return functionArg{literal: &literal}, nil
+	}
+
+	if name, ok := p.functionName(); ok {
+		call, err := p.call(name)
+		if err != nil {
+			return functionArg{}, err
+		}
+
+		return functionArg{call: &call}, nil
+	}
+
+	start := p.pos
+	quoted := false
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c == '"' {
+			quoted = !quoted
+		} else if !quoted && (c == ',' || c == ')') {
+			break
+		}
+		p.pos++
+	}
+
+	varName := strings.TrimSpace(p.input[start:p.pos])
+	if varName == "" {
+		return functionArg{}, fmt.Errorf("empty argument at: %s", p.input[start:])
+	}
+
+	if _, err := ParseReference(varName); err != nil {
+		return functionArg{}, err
+	}
+
+	return functionArg{varName: varName}, nil
+}
+
+func (p *functionParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *functionParser) peek() byte {
+	if p.pos >= len(p.input) {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
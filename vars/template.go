@@ -25,7 +25,12 @@ func NewTemplate(bytes []byte) Template {
 }
 
 func (t Template) ExtraVarNames() []string {
-	return interpolator{}.extractVarNames(string(t.bytes))
+	return interpolator{}.extractVarNames(string(t.bytes), true)
+}
+
+// RequiredVarNames returns the names of the vars which have no default value.
+func (t Template) RequiredVarNames() []string {
+	return interpolator{}.extractVarNames(string(t.bytes), false)
 }
 
 func (t Template) Evaluate(vars Variables, opts EvaluateOpts) ([]byte, error) {
@@ -62,10 +67,21 @@ func (t Template) interpolateRoot(obj interface{}, tracker varsTracker) (interfa
 type interpolator struct{}
 
 var (
-	interpolationRegex         = regexp.MustCompile(`\(\((([-/\.\w\pL]+\:)?[-/\.:@"\w\pL]+)\)\)`)
-	interpolationAnchoredRegex = regexp.MustCompile("\\A" + interpolationRegex.String() + "\\z")
+	interpolationNameRegex = regexp.MustCompile(`\A([-/\.\w\pL]+\:)?[-/\.:@"\w\pL]+`)
+	defaultSeparatorRegex  = regexp.MustCompile(`\A\s+//\s*`)
 )
 
+// expression is a var found in a template, either referring to a var by name
+// or calling a function, with an optional default value.
+type expression struct {
+	raw     string
+	varName string
+	call    *functionCall
+
+	hasDefault bool
+	defaultVal interface{}
+}
+
 func (i interpolator) Interpolate(node interface{}, tracker varsTracker) (interface{}, error) {
 	switch typedNode := node.(type) {
 	case map[interface{}]interface{}:
@@ -94,25 +110,30 @@ func (i interpolator) Interpolate(node interface{}, tracker varsTracker) (interf
 		}
 
 	case string:
-		for _, name := range i.extractVarNames(typedNode) {
-			foundVal, found, err := tracker.Get(name)
+		exprs, err := i.extractExpressions(typedNode)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, expr := range exprs {
+			foundVal, found, err := i.evaluate(expr, tracker)
 			if err != nil {
 				return nil, err
 			}
 
 			if found {
 				// ensure that value type is preserved when replacing the entire field
-				if interpolationAnchoredRegex.MatchString(typedNode) {
+				if typedNode == expr.raw {
 					return foundVal, nil
 				}
 
 				switch foundVal.(type) {
 				case string, int, int16, int32, int64, uint, uint16, uint32, uint64, json.Number:
 					foundValStr := fmt.Sprintf("%v", foundVal)
-					typedNode = strings.Replace(typedNode, fmt.Sprintf("((%s))", name), foundValStr, -1)
+					typedNode = strings.Replace(typedNode, expr.raw, foundValStr, -1)
 				default:
 					return nil, InvalidInterpolationError{
-						Name:  name,
+						Name:  expr.name(),
 						Value: foundVal,
 					}
 				}
@@ -125,16 +146,171 @@ func (i interpolator) Interpolate(node interface{}, tracker varsTracker) (interf
 	return node, nil
 }
 
-func (i interpolator) extractVarNames(value string) []string {
+func (i interpolator) evaluate(expr expression, tracker varsTracker) (interface{}, bool, error) {
+	if expr.hasDefault {
+		// vars with a default are not missing when they are not found
+		tracker.missing = map[string]struct{}{}
+	}
+
+	var val interface{}
+	var found, usesVars bool
+	var err error
+	if expr.call == nil {
+		val, found, err = tracker.Get(expr.varName)
+	} else {
+		val, found, usesVars, err = expr.call.evaluate(tracker)
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		// defaults are only used when the vars are expected to be complete;
+		// otherwise the var is left to be resolved later, e.g. by a var
+		// source when a pipeline set with only some of its vars is run
+		if expr.hasDefault && tracker.expectAllFound {
+			return expr.defaultVal, true, nil
+		}
+
+		return nil, false, nil
+	}
+
+	if derivedTracker, ok := tracker.vars.(DerivedVarsTracker); ok && expr.call != nil && usesVars {
+		derivedTracker.TrackDerived(expr.name(), val)
+	}
+
+	return val, true, nil
+}
+
+func (i interpolator) extractVarNames(value string, withDefaults bool) []string {
 	var names []string
 
-	for _, match := range interpolationRegex.FindAllSubmatch([]byte(value), -1) {
-		names = append(names, string(match[1]))
+	// a malformed function call is reported when the template is evaluated
+	exprs, _ := i.extractExpressions(value)
+	for _, expr := range exprs {
+		if expr.hasDefault && !withDefaults {
+			continue
+		}
+
+		if expr.call != nil {
+			names = append(names, expr.call.varNames()...)
+		} else {
+			names = append(names, expr.varName)
+		}
 	}
 
 	return names
 }
 
+// extractExpressions finds each var in the value, in order. It returns the
+// vars found before any malformed function call or default along with the
+// error.
+func (i interpolator) extractExpressions(value string) ([]expression, error) {
+	var exprs []expression
+
+	pos := 0
+	for {
+		start := strings.Index(value[pos:], "((")
+		if start == -1 {
+			return exprs, nil
+		}
+
+		start += pos
+
+		expr, length, ok, err := i.parseExpression(value[start:])
+		if err != nil {
+			return exprs, fmt.Errorf("invalid var '%s': %s", value[start:], err)
+		}
+
+		if ok {
+			exprs = append(exprs, expr)
+			pos = start + length
+			continue
+		}
+
+		pos = start + 1
+	}
+}
+
+// parseExpression parses the var at the start of s, which starts with '(('.
+// It returns false if s does not start with a var.
+func (i interpolator) parseExpression(s string) (expression, int, bool, error) {
+	var expr expression
+
+	pos := 2
+	call, length, ok, err := parseFunctionCall(s[pos:])
+	if err != nil {
+		return expression{}, 0, false, err
+	}
+
+	if ok {
+		expr.call = &call
+		pos += length
+	} else if name := interpolationNameRegex.FindString(s[pos:]); name != "" {
+		expr.varName = name
+		pos += len(name)
+	} else {
+		return expression{}, 0, false, nil
+	}
+
+	if separator := defaultSeparatorRegex.FindString(s[pos:]); separator != "" {
+		pos += len(separator)
+
+		length := defaultLength(s[pos:])
+		if length == -1 {
+			return expression{}, 0, false, nil
+		}
+
+		err := yaml.Unmarshal([]byte(s[pos:pos+length]), &expr.defaultVal)
+		if err != nil {
+			return expression{}, 0, false, fmt.Errorf("invalid default: %s", err)
+		}
+
+		expr.hasDefault = true
+		pos += length
+	}
+
+	if !strings.HasPrefix(s[pos:], "))") {
+		if expr.call != nil {
+			return expression{}, 0, false, fmt.Errorf("expected '))' after call to %s", expr.call.name)
+		}
+
+		return expression{}, 0, false, nil
+	}
+
+	pos += 2
+	expr.raw = s[:pos]
+
+	return expr, pos, true, nil
+}
+
+// defaultLength returns the length of the default value at the start of s,
+// up to the '))' closing the var, or -1 if the var is not closed.
+func defaultLength(s string) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case strings.HasPrefix(s[i:], "))"):
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (expr expression) name() string {
+	return strings.TrimSuffix(strings.TrimPrefix(expr.raw, "(("), "))")
+}
+
 type varsTracker struct {
 	vars Variables
 
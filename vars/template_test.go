@@ -410,4 +410,56 @@ dup-key: ((key3))
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(ContainSubstring("fake-err"))
 	})
+
+	Describe("default values", func() {
+		evaluate := func(template string, vars Variables) (string, error) {
+			result, err := NewTemplate([]byte(template)).Evaluate(vars, EvaluateOpts{ExpectAllKeys: true})
+			return string(result), err
+		}
+
+		It("uses the default when the var is not found", func() {
+			Expect(evaluate(`key: ((missing // "default"))`, StaticVariables{})).To(Equal("key: default\n"))
+		})
+
+		It("uses the var when it is found", func() {
+			Expect(evaluate(`key: ((found // "default"))`, StaticVariables{"found": "value"})).To(Equal("key: value\n"))
+		})
+
+		It("preserves the type of the default", func() {
+			Expect(evaluate(`replicas: ((missing // 3))`, StaticVariables{})).To(Equal("replicas: 3\n"))
+		})
+
+		It("interpolates the default into the middle of a string", func() {
+			Expect(evaluate(`url: http://((host // "localhost")):8080`, StaticVariables{})).To(Equal("url: http://localhost:8080\n"))
+		})
+
+		It("allows the default to contain closing parentheses", func() {
+			Expect(evaluate(`key: ((missing // "a))b"))`, StaticVariables{})).To(Equal("key: a))b\n"))
+		})
+
+		It("allows a default for a var from a var source", func() {
+			Expect(evaluate(`key: ((source:missing // "default"))`, NamedVariables{"source": StaticVariables{}})).To(Equal("key: default\n"))
+		})
+
+		It("allows a default for a function call", func() {
+			Expect(evaluate(`key: ((trim(missing) // "default"))`, StaticVariables{})).To(Equal("key: default\n"))
+		})
+
+		It("returns an error if the default is invalid", func() {
+			_, err := evaluate(`key: ((missing // [))`, StaticVariables{})
+			Expect(err).To(MatchError(ContainSubstring("invalid default")))
+		})
+
+		It("leaves the var to be resolved later when not all vars are expected", func() {
+			result, err := NewTemplate([]byte(`key: ((missing // "default"))`)).Evaluate(StaticVariables{}, EvaluateOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal("key: ((missing // \"default\"))\n"))
+		})
+
+		It("returns vars with defaults as extra but not required var names", func() {
+			template := NewTemplate([]byte(`{optional: ((optional // "default")), required: ((required))}`))
+			Expect(template.ExtraVarNames()).To(ConsistOf("optional", "required"))
+			Expect(template.RequiredVarNames()).To(ConsistOf("required"))
+		})
+	})
 })
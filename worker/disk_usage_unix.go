@@ -0,0 +1,19 @@
+// +build !windows
+
+package worker
+
+import "syscall"
+
+func diskUsage(path string) (uint64, uint64, error) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	blockSize := uint64(stat.Bsize)
+	used := (uint64(stat.Blocks) - uint64(stat.Bfree)) * blockSize
+	free := uint64(stat.Bavail) * blockSize
+
+	return used, free, nil
+}
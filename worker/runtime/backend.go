@@ -16,6 +16,7 @@ import (
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/cio"
 	"github.com/containerd/containerd/errdefs"
+	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -237,6 +238,14 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 
 	oci.Mounts = append(oci.Mounts, netMounts...)
 
+	networkOf := gdnSpec.Properties[NetworkOfKey]
+	if networkOf != "" {
+		err = b.joinNetworkOf(ctx, oci, networkOf)
+		if err != nil {
+			return nil, fmt.Errorf("joining network of %s: %w", networkOf, err)
+		}
+	}
+
 	return b.client.NewContainer(ctx, gdnSpec.Handle, gdnSpec.Properties, oci)
 }
 
@@ -246,14 +255,93 @@ func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container
 		return fmt.Errorf("new task: %w", err)
 	}
 
-	err = b.network.Add(ctx, task)
+	err = b.addToNetwork(ctx, cont, task)
 	if err != nil {
-		return fmt.Errorf("network add: %w", err)
+		return err
 	}
 
 	return task.Start(ctx)
 }
 
+// joinNetworkOf has the container share the network namespace of the
+// running task of the container with the given handle, rather than being
+// given one of its own.
+//
+func (b *GardenBackend) joinNetworkOf(ctx context.Context, oci *specs.Spec, handle string) error {
+	cont, err := b.client.GetContainer(ctx, handle)
+	if err != nil {
+		return fmt.Errorf("get container: %w", err)
+	}
+
+	task, err := cont.Task(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("task lookup: %w", err)
+	}
+
+	// the namespaces may be shared with other specs, so they're copied
+	// rather than modified in place.
+	namespaces := make([]specs.LinuxNamespace, len(oci.Linux.Namespaces))
+	for i, namespace := range oci.Linux.Namespaces {
+		if namespace.Type == specs.NetworkNamespace {
+			namespace.Path = netNsPath(task)
+		}
+
+		namespaces[i] = namespace
+	}
+
+	oci.Linux.Namespaces = namespaces
+
+	return nil
+}
+
+// addToNetwork sets up the network of the container's task according to the
+// properties the container was created with. Containers which joined the
+// network of another container are left alone, as is their network.
+//
+func (b *GardenBackend) addToNetwork(ctx context.Context, cont containerd.Container, task containerd.Task) error {
+	labels, err := cont.Labels(ctx)
+	if err != nil {
+		return fmt.Errorf("labels retrieval: %w", err)
+	}
+
+	switch {
+	case labels[NetworkOfKey] != "":
+		return nil
+	case labels[HermeticKey] == "true":
+		err = b.network.AddLoopback(ctx, task)
+	default:
+		err = b.network.Add(ctx, task)
+	}
+	if err != nil {
+		return fmt.Errorf("network add: %w", err)
+	}
+
+	return nil
+}
+
+// removeFromNetwork tears down what addToNetwork set up.
+//
+func (b *GardenBackend) removeFromNetwork(ctx context.Context, cont containerd.Container, task containerd.Task) error {
+	labels, err := cont.Labels(ctx)
+	if err != nil {
+		return fmt.Errorf("labels retrieval: %w", err)
+	}
+
+	switch {
+	case labels[NetworkOfKey] != "":
+		return nil
+	case labels[HermeticKey] == "true":
+		err = b.network.RemoveLoopback(ctx, task)
+	default:
+		err = b.network.Remove(ctx, task)
+	}
+	if err != nil {
+		return fmt.Errorf("network remove: %w", err)
+	}
+
+	return nil
+}
+
 // Destroy gracefully destroys a container.
 //
 func (b *GardenBackend) Destroy(handle string) error {
@@ -287,9 +375,9 @@ func (b *GardenBackend) Destroy(handle string) error {
 		return fmt.Errorf("gracefully killing task: %w", err)
 	}
 
-	err = b.network.Remove(ctx, task)
+	err = b.removeFromNetwork(ctx, container, task)
 	if err != nil {
-		return fmt.Errorf("network remove: %w", err)
+		return err
 	}
 
 	_, err = task.Delete(ctx, containerd.WithProcessKill)
@@ -146,6 +146,103 @@ func (s *BackendSuite) TestCreateContainerSetsHandle() {
 	s.Equal("handle", cont.Handle())
 }
 
+func (s *BackendSuite) TestCreateContainerAddsNetwork() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	s.Equal(1, s.network.AddCallCount())
+	s.Equal(0, s.network.AddLoopbackCallCount())
+}
+
+func (s *BackendSuite) TestCreateHermeticContainerAddsLoopbackNetwork() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	fakeContainer.LabelsReturns(map[string]string{runtime.HermeticKey: "true"}, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	s.Equal(0, s.network.AddCallCount())
+	s.Equal(1, s.network.AddLoopbackCallCount())
+
+	_, task := s.network.AddLoopbackArgsForCall(0)
+	s.Equal(fakeTask, task)
+}
+
+func (s *BackendSuite) TestCreateContainerJoiningNetworkOfAnother() {
+	otherTask := new(libcontainerdfakes.FakeTask)
+	otherTask.PidReturns(123)
+	otherContainer := new(libcontainerdfakes.FakeContainer)
+	otherContainer.TaskReturns(otherTask, nil)
+	s.client.GetContainerReturns(otherContainer, nil)
+
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	fakeContainer.LabelsReturns(map[string]string{runtime.NetworkOfKey: "other-handle"}, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	gdnSpec := garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{runtime.NetworkOfKey: "other-handle"},
+	}
+
+	_, err := s.backend.Create(gdnSpec)
+	s.NoError(err)
+
+	_, handle := s.client.GetContainerArgsForCall(0)
+	s.Equal("other-handle", handle)
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Contains(oci.Linux.Namespaces, specs.LinuxNamespace{
+		Type: specs.NetworkNamespace,
+		Path: "/proc/123/ns/net",
+	})
+
+	s.Equal(0, s.network.AddCallCount())
+	s.Equal(0, s.network.AddLoopbackCallCount())
+	s.Equal(1, fakeTask.StartCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerJoiningNetworkOfMissingContainer() {
+	s.client.GetContainerReturns(nil, errors.New("not-found"))
+
+	gdnSpec := garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{runtime.NetworkOfKey: "other-handle"},
+	}
+
+	_, err := s.backend.Create(gdnSpec)
+	s.Error(err)
+
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerLabelsFailure() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	fakeContainer.LabelsReturns(nil, errors.New("labels-err"))
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.EqualError(errors.Unwrap(errors.Unwrap(err)), "labels-err")
+
+	s.Equal(0, fakeTask.StartCallCount())
+}
+
 func (s *BackendSuite) TestCreateMaxContainersReached() {
 	backend, err := runtime.NewGardenBackend(s.client,
 		runtime.WithKiller(s.killer),
@@ -484,6 +581,35 @@ func (s *BackendSuite) TestDestroySucceeds() {
 	s.NoError(err)
 }
 
+func (s *BackendSuite) TestDestroyHermeticContainerRemovesLoopbackNetwork() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	s.client.GetContainerReturns(fakeContainer, nil)
+	fakeContainer.TaskReturns(fakeTask, nil)
+	fakeContainer.LabelsReturns(map[string]string{runtime.HermeticKey: "true"}, nil)
+
+	err := s.backend.Destroy("some handle")
+	s.NoError(err)
+
+	s.Equal(0, s.network.RemoveCallCount())
+	s.Equal(1, s.network.RemoveLoopbackCallCount())
+}
+
+func (s *BackendSuite) TestDestroyContainerJoiningNetworkOfAnotherLeavesNetwork() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	s.client.GetContainerReturns(fakeContainer, nil)
+	fakeContainer.TaskReturns(fakeTask, nil)
+	fakeContainer.LabelsReturns(map[string]string{runtime.NetworkOfKey: "other-handle"}, nil)
+
+	err := s.backend.Destroy("some handle")
+	s.NoError(err)
+
+	s.Equal(0, s.network.RemoveCallCount())
+	s.Equal(0, s.network.RemoveLoopbackCallCount())
+	s.Equal(1, fakeTask.DeleteCallCount())
+}
+
 func (s *BackendSuite) TestStartInitsClientAndSetsUpRestrictedNetworks() {
 	err := s.backend.Start()
 	s.NoError(err)
@@ -134,6 +134,15 @@ func WithCNIClient(c cni.CNI) CNINetworkOpt {
 	}
 }
 
+// WithLoopbackCNIClient is an implementor of the CNI interface used for
+// setting up the loopback-only network of hermetic containers.
+//
+func WithLoopbackCNIClient(c cni.CNI) CNINetworkOpt {
+	return func(n *cniNetwork) {
+		n.loopbackClient = c
+	}
+}
+
 // WithCNINetworkConfig provides a custom CNINetworkConfig to be used by the CNI
 // client at startup time.
 //
@@ -170,6 +179,7 @@ func WithIptables(ipt iptables.Iptables) CNINetworkOpt {
 
 type cniNetwork struct {
 	client             cni.CNI
+	loopbackClient     cni.CNI
 	store              FileStore
 	config             CNINetworkConfig
 	nameServers        []string
@@ -214,6 +224,18 @@ func NewCNINetwork(opts ...CNINetworkOpt) (*cniNetwork, error) {
 		}
 	}
 
+	if n.loopbackClient == nil {
+		n.loopbackClient, err = cni.New(cni.WithPluginDir([]string{n.binariesDir}))
+		if err != nil {
+			return nil, fmt.Errorf("loopback cni init: %w", err)
+		}
+
+		err = n.loopbackClient.Load(cni.WithLoNetwork)
+		if err != nil {
+			return nil, fmt.Errorf("loopback cni configuration loading: %w", err)
+		}
+	}
+
 	if n.ipt == nil {
 		n.ipt, err = iptables.New()
 
@@ -333,6 +355,36 @@ func (n cniNetwork) Remove(ctx context.Context, task containerd.Task) error {
 	return nil
 }
 
+func (n cniNetwork) AddLoopback(ctx context.Context, task containerd.Task) error {
+	if task == nil {
+		return ErrInvalidInput("nil task")
+	}
+
+	id, netns := netId(task), netNsPath(task)
+
+	_, err := n.loopbackClient.Setup(ctx, id, netns)
+	if err != nil {
+		return fmt.Errorf("cni loopback setup: %w", err)
+	}
+
+	return nil
+}
+
+func (n cniNetwork) RemoveLoopback(ctx context.Context, task containerd.Task) error {
+	if task == nil {
+		return ErrInvalidInput("nil task")
+	}
+
+	id, netns := netId(task), netNsPath(task)
+
+	err := n.loopbackClient.Remove(ctx, id, netns)
+	if err != nil {
+		return fmt.Errorf("cni loopback teardown: %w", err)
+	}
+
+	return nil
+}
+
 func netId(task containerd.Task) string {
 	return task.ID()
 }
@@ -18,10 +18,11 @@ type CNINetworkSuite struct {
 	suite.Suite
 	*require.Assertions
 
-	network  runtime.Network
-	cni      *runtimefakes.FakeCNI
-	store    *runtimefakes.FakeFileStore
-	iptables *iptablesfakes.FakeIptables
+	network     runtime.Network
+	cni         *runtimefakes.FakeCNI
+	loopbackCNI *runtimefakes.FakeCNI
+	store       *runtimefakes.FakeFileStore
+	iptables    *iptablesfakes.FakeIptables
 }
 
 func (s *CNINetworkSuite) SetupTest() {
@@ -29,11 +30,13 @@ func (s *CNINetworkSuite) SetupTest() {
 
 	s.store = new(runtimefakes.FakeFileStore)
 	s.cni = new(runtimefakes.FakeCNI)
+	s.loopbackCNI = new(runtimefakes.FakeCNI)
 	s.iptables = new(iptablesfakes.FakeIptables)
 
 	s.network, err = runtime.NewCNINetwork(
 		runtime.WithCNIFileStore(s.store),
 		runtime.WithCNIClient(s.cni),
+		runtime.WithLoopbackCNIClient(s.loopbackCNI),
 		runtime.WithIptables(s.iptables),
 	)
 	s.NoError(err)
@@ -221,3 +224,38 @@ func (s *CNINetworkSuite) TestRemove() {
 	s.Equal("id", id)
 	s.Equal("/proc/123/ns/net", netns)
 }
+
+func (s *CNINetworkSuite) TestAddLoopbackNilTask() {
+	err := s.network.AddLoopback(context.Background(), nil)
+	s.EqualError(err, "nil task")
+}
+
+func (s *CNINetworkSuite) TestAddLoopback() {
+	task := new(libcontainerdfakes.FakeTask)
+	task.PidReturns(123)
+	task.IDReturns("id")
+
+	err := s.network.AddLoopback(context.Background(), task)
+	s.NoError(err)
+
+	s.Equal(0, s.cni.SetupCallCount())
+	s.Equal(1, s.loopbackCNI.SetupCallCount())
+	_, id, netns, _ := s.loopbackCNI.SetupArgsForCall(0)
+	s.Equal("id", id)
+	s.Equal("/proc/123/ns/net", netns)
+}
+
+func (s *CNINetworkSuite) TestRemoveLoopback() {
+	task := new(libcontainerdfakes.FakeTask)
+	task.PidReturns(123)
+	task.IDReturns("id")
+
+	err := s.network.RemoveLoopback(context.Background(), task)
+	s.NoError(err)
+
+	s.Equal(0, s.cni.RemoveCallCount())
+	s.Equal(1, s.loopbackCNI.RemoveCallCount())
+	_, id, netns, _ := s.loopbackCNI.RemoveArgsForCall(0)
+	s.Equal("id", id)
+	s.Equal("/proc/123/ns/net", netns)
+}
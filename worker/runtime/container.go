@@ -19,6 +19,16 @@ const (
 	Path          = "PATH=/usr/local/bin:/usr/bin:/bin"
 
 	GraceTimeKey = "garden.grace-time"
+
+	// HermeticKey is the property set on containers which must not be
+	// given access to any network.
+	//
+	HermeticKey = "concourse.hermetic"
+
+	// NetworkOfKey is the property holding the handle of the container
+	// whose network namespace a container joins.
+	//
+	NetworkOfKey = "concourse.network-of"
 )
 
 type UserNotFoundError struct {
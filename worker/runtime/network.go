@@ -26,4 +26,14 @@ type Network interface {
 	// Removes a task from the network.
 	//
 	Remove(ctx context.Context, task containerd.Task) (err error)
+
+	// AddLoopback adds a hermetic task to a network made up of nothing but
+	// its loopback interface, so that it can't reach anything outside of
+	// the container.
+	//
+	AddLoopback(ctx context.Context, task containerd.Task) (err error)
+
+	// RemoveLoopback removes a hermetic task from its loopback network.
+	//
+	RemoveLoopback(ctx context.Context, task containerd.Task) (err error)
 }
@@ -23,6 +23,18 @@ type FakeNetwork struct {
 	addReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AddLoopbackStub        func(context.Context, containerd.Task) error
+	addLoopbackMutex       sync.RWMutex
+	addLoopbackArgsForCall []struct {
+		arg1 context.Context
+		arg2 containerd.Task
+	}
+	addLoopbackReturns struct {
+		result1 error
+	}
+	addLoopbackReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RemoveStub        func(context.Context, containerd.Task) error
 	removeMutex       sync.RWMutex
 	removeArgsForCall []struct {
@@ -35,6 +47,18 @@ type FakeNetwork struct {
 	removeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RemoveLoopbackStub        func(context.Context, containerd.Task) error
+	removeLoopbackMutex       sync.RWMutex
+	removeLoopbackArgsForCall []struct {
+		arg1 context.Context
+		arg2 containerd.Task
+	}
+	removeLoopbackReturns struct {
+		result1 error
+	}
+	removeLoopbackReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetupMountsStub        func(string) ([]specs.Mount, error)
 	setupMountsMutex       sync.RWMutex
 	setupMountsArgsForCall []struct {
@@ -124,6 +148,68 @@ func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeNetwork) AddLoopback(arg1 context.Context, arg2 containerd.Task) error {
+	fake.addLoopbackMutex.Lock()
+	ret, specificReturn := fake.addLoopbackReturnsOnCall[len(fake.addLoopbackArgsForCall)]
+	fake.addLoopbackArgsForCall = append(fake.addLoopbackArgsForCall, struct {
+		arg1 context.Context
+		arg2 containerd.Task
+	}{arg1, arg2})
+	stub := fake.AddLoopbackStub
+	fakeReturns := fake.addLoopbackReturns
+	fake.recordInvocation("AddLoopback", []interface{}{arg1, arg2})
+	fake.addLoopbackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNetwork) AddLoopbackCallCount() int {
+	fake.addLoopbackMutex.RLock()
+	defer fake.addLoopbackMutex.RUnlock()
+	return len(fake.addLoopbackArgsForCall)
+}
+
+func (fake *FakeNetwork) AddLoopbackCalls(stub func(context.Context, containerd.Task) error) {
+	fake.addLoopbackMutex.Lock()
+	defer fake.addLoopbackMutex.Unlock()
+	fake.AddLoopbackStub = stub
+}
+
+func (fake *FakeNetwork) AddLoopbackArgsForCall(i int) (context.Context, containerd.Task) {
+	fake.addLoopbackMutex.RLock()
+	defer fake.addLoopbackMutex.RUnlock()
+	argsForCall := fake.addLoopbackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNetwork) AddLoopbackReturns(result1 error) {
+	fake.addLoopbackMutex.Lock()
+	defer fake.addLoopbackMutex.Unlock()
+	fake.AddLoopbackStub = nil
+	fake.addLoopbackReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) AddLoopbackReturnsOnCall(i int, result1 error) {
+	fake.addLoopbackMutex.Lock()
+	defer fake.addLoopbackMutex.Unlock()
+	fake.AddLoopbackStub = nil
+	if fake.addLoopbackReturnsOnCall == nil {
+		fake.addLoopbackReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addLoopbackReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNetwork) Remove(arg1 context.Context, arg2 containerd.Task) error {
 	fake.removeMutex.Lock()
 	ret, specificReturn := fake.removeReturnsOnCall[len(fake.removeArgsForCall)]
@@ -186,6 +272,68 @@ func (fake *FakeNetwork) RemoveReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeNetwork) RemoveLoopback(arg1 context.Context, arg2 containerd.Task) error {
+	fake.removeLoopbackMutex.Lock()
+	ret, specificReturn := fake.removeLoopbackReturnsOnCall[len(fake.removeLoopbackArgsForCall)]
+	fake.removeLoopbackArgsForCall = append(fake.removeLoopbackArgsForCall, struct {
+		arg1 context.Context
+		arg2 containerd.Task
+	}{arg1, arg2})
+	stub := fake.RemoveLoopbackStub
+	fakeReturns := fake.removeLoopbackReturns
+	fake.recordInvocation("RemoveLoopback", []interface{}{arg1, arg2})
+	fake.removeLoopbackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNetwork) RemoveLoopbackCallCount() int {
+	fake.removeLoopbackMutex.RLock()
+	defer fake.removeLoopbackMutex.RUnlock()
+	return len(fake.removeLoopbackArgsForCall)
+}
+
+func (fake *FakeNetwork) RemoveLoopbackCalls(stub func(context.Context, containerd.Task) error) {
+	fake.removeLoopbackMutex.Lock()
+	defer fake.removeLoopbackMutex.Unlock()
+	fake.RemoveLoopbackStub = stub
+}
+
+func (fake *FakeNetwork) RemoveLoopbackArgsForCall(i int) (context.Context, containerd.Task) {
+	fake.removeLoopbackMutex.RLock()
+	defer fake.removeLoopbackMutex.RUnlock()
+	argsForCall := fake.removeLoopbackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNetwork) RemoveLoopbackReturns(result1 error) {
+	fake.removeLoopbackMutex.Lock()
+	defer fake.removeLoopbackMutex.Unlock()
+	fake.RemoveLoopbackStub = nil
+	fake.removeLoopbackReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) RemoveLoopbackReturnsOnCall(i int, result1 error) {
+	fake.removeLoopbackMutex.Lock()
+	defer fake.removeLoopbackMutex.Unlock()
+	fake.RemoveLoopbackStub = nil
+	if fake.removeLoopbackReturnsOnCall == nil {
+		fake.removeLoopbackReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeLoopbackReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNetwork) SetupMounts(arg1 string) ([]specs.Mount, error) {
 	fake.setupMountsMutex.Lock()
 	ret, specificReturn := fake.setupMountsReturnsOnCall[len(fake.setupMountsArgsForCall)]
@@ -308,8 +456,12 @@ func (fake *FakeNetwork) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.addMutex.RLock()
 	defer fake.addMutex.RUnlock()
+	fake.addLoopbackMutex.RLock()
+	defer fake.addLoopbackMutex.RUnlock()
 	fake.removeMutex.RLock()
 	defer fake.removeMutex.RUnlock()
+	fake.removeLoopbackMutex.RLock()
+	defer fake.removeLoopbackMutex.RUnlock()
 	fake.setupMountsMutex.RLock()
 	defer fake.setupMountsMutex.RUnlock()
 	fake.setupRestrictedNetworksMutex.RLock()
@@ -0,0 +1,178 @@
+package worker
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/worker/gclient"
+)
+
+// UsageReporter is an ifrit.Runner that periodically reports the disk usage
+// of a worker's volumes and containers
+type UsageReporter struct {
+	logger       lager.Logger
+	interval     time.Duration
+	tsaClient    TSAClient
+	gardenClient gclient.Client
+	volumesDir   string
+}
+
+func NewUsageReporter(
+	logger lager.Logger,
+	reportInterval time.Duration,
+	tsaClient TSAClient,
+	gardenClient gclient.Client,
+	volumesDir string,
+) *UsageReporter {
+	return &UsageReporter{
+		logger:       logger,
+		interval:     reportInterval,
+		tsaClient:    tsaClient,
+		gardenClient: gardenClient,
+		volumesDir:   volumesDir,
+	}
+}
+
+func (reporter *UsageReporter) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	timer := time.NewTicker(reporter.interval)
+
+	close(ready)
+
+	for {
+		select {
+		case <-timer.C:
+			reporter.report(reporter.logger.Session("tick"))
+
+		case sig := <-signals:
+			reporter.logger.Info("report-cancelled-by-signal", lager.Data{"signal": sig})
+			return nil
+		}
+	}
+}
+
+func (reporter *UsageReporter) report(logger lager.Logger) {
+	ctx := lagerctx.NewContext(context.Background(), logger)
+
+	used, free, err := diskUsage(reporter.volumesDir)
+	if err != nil {
+		logger.Error("failed-to-get-disk-usage", err)
+		return
+	}
+
+	scratch, containerBytes, err := reporter.containerScratch()
+	if err != nil {
+		logger.Error("failed-to-get-container-scratch", err)
+		return
+	}
+
+	volumeBytes, err := reporter.volumeSizes(logger)
+	if err != nil {
+		logger.Error("failed-to-get-volume-sizes", err)
+		return
+	}
+
+	err = reporter.tsaClient.ReportUsage(ctx, atc.WorkerUsage{
+		DiskUsedBytes:         used,
+		DiskFreeBytes:         free,
+		ContainerScratchBytes: scratch,
+		ContainerBytes:        containerBytes,
+		VolumeBytes:           volumeBytes,
+	})
+	if err != nil {
+		logger.Error("failed-to-report-usage", err)
+	}
+}
+
+// containerScratch sums the bytes written by each container to its own
+// filesystem, i.e. not to its volumes or its image, returning the bytes
+// written by each container along with the total.
+func (reporter *UsageReporter) containerScratch() (uint64, map[string]uint64, error) {
+	containers, err := reporter.gardenClient.Containers(garden.Properties{})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(containers) == 0 {
+		return 0, nil, nil
+	}
+
+	handles := []string{}
+	for _, container := range containers {
+		handles = append(handles, container.Handle())
+	}
+
+	metrics, err := reporter.gardenClient.BulkMetrics(handles)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var scratch uint64
+	sizes := map[string]uint64{}
+	for handle, entry := range metrics {
+		// containers may be destroyed while gathering metrics
+		if entry.Err != nil {
+			continue
+		}
+
+		scratch += entry.Metrics.DiskStat.ExclusiveBytesUsed
+		sizes[handle] = entry.Metrics.DiskStat.ExclusiveBytesUsed
+	}
+
+	return scratch, sizes, nil
+}
+
+// volumeSizes returns the apparent size of the data in each of baggageclaim's
+// live volumes, keyed by handle. Copy-on-write volumes are counted in full, so
+// the sizes may add up to more than the disk usage.
+func (reporter *UsageReporter) volumeSizes(logger lager.Logger) (map[string]uint64, error) {
+	liveDir := filepath.Join(reporter.volumesDir, "live")
+
+	entries, err := ioutil.ReadDir(liveDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	sizes := map[string]uint64{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		var size uint64
+		err := filepath.Walk(filepath.Join(liveDir, entry.Name(), "volume"), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				// volumes may be destroyed while walking them
+				if os.IsNotExist(err) {
+					return nil
+				}
+
+				return err
+			}
+
+			if info.Mode().IsRegular() {
+				size += uint64(info.Size())
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Error("failed-to-get-volume-size", err, lager.Data{"handle": entry.Name()})
+			continue
+		}
+
+		sizes[entry.Name()] = size
+	}
+
+	return sizes, nil
+}
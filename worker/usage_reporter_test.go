@@ -0,0 +1,149 @@
+package worker_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/worker/gclient"
+	"github.com/concourse/concourse/atc/worker/gclient/gclientfakes"
+	"github.com/concourse/concourse/worker"
+	"github.com/concourse/concourse/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Usage Reporter", func() {
+	const reportInterval = 10 * time.Millisecond
+
+	var (
+		testLogger = lagertest.NewTestLogger("usage-reporter")
+
+		fakeTSAClient    *workerfakes.FakeTSAClient
+		fakeGardenClient *gclientfakes.FakeClient
+		volumesDir       string
+
+		reporter *worker.UsageReporter
+
+		osSignal chan os.Signal
+		exited   chan struct{}
+	)
+
+	BeforeEach(func() {
+		fakeTSAClient = new(workerfakes.FakeTSAClient)
+		fakeGardenClient = new(gclientfakes.FakeClient)
+		var err error
+		volumesDir, err = ioutil.TempDir("", "usage-reporter")
+		Expect(err).NotTo(HaveOccurred())
+
+		volumeDir := filepath.Join(volumesDir, "live", "some-volume", "volume")
+		Expect(os.MkdirAll(filepath.Join(volumeDir, "some-dir"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(volumeDir, "some-file"), make([]byte, 100), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(volumeDir, "some-dir", "other-file"), make([]byte, 50), 0644)).To(Succeed())
+
+		Expect(os.MkdirAll(filepath.Join(volumesDir, "live", "empty-volume", "volume"), 0755)).To(Succeed())
+
+		container1 := new(gclientfakes.FakeContainer)
+		container1.HandleReturns("container-1")
+		container2 := new(gclientfakes.FakeContainer)
+		container2.HandleReturns("container-2")
+		container3 := new(gclientfakes.FakeContainer)
+		container3.HandleReturns("container-3")
+
+		fakeGardenClient.ContainersReturns([]gclient.Container{container1, container2, container3}, nil)
+		fakeGardenClient.BulkMetricsReturns(map[string]garden.ContainerMetricsEntry{
+			"container-1": {Metrics: garden.Metrics{DiskStat: garden.ContainerDiskStat{ExclusiveBytesUsed: 100}}},
+			"container-2": {Metrics: garden.Metrics{DiskStat: garden.ContainerDiskStat{ExclusiveBytesUsed: 200}}},
+			"container-3": {Err: &garden.Error{Err: errors.New("container-3 is gone")}},
+		}, nil)
+
+		osSignal = make(chan os.Signal)
+		exited = make(chan struct{})
+	})
+
+	JustBeforeEach(func() {
+		reporter = worker.NewUsageReporter(testLogger, reportInterval, fakeTSAClient, fakeGardenClient, volumesDir)
+
+		go func() {
+			_ = reporter.Run(osSignal, make(chan struct{}))
+			close(exited)
+		}()
+	})
+
+	AfterEach(func() {
+		close(osSignal)
+		<-exited
+
+		_ = os.RemoveAll(volumesDir)
+	})
+
+	It("reports the disk usage of the volumes directory and the containers", func() {
+		Eventually(fakeTSAClient.ReportUsageCallCount).Should(BeNumerically(">=", 1))
+
+		_, usage := fakeTSAClient.ReportUsageArgsForCall(0)
+		Expect(usage.DiskUsedBytes).To(BeNumerically(">", 0))
+		Expect(usage.DiskFreeBytes).To(BeNumerically(">", 0))
+		Expect(usage.ContainerScratchBytes).To(Equal(uint64(300)))
+		Expect(usage.ContainerBytes).To(Equal(map[string]uint64{
+			"container-1": 100,
+			"container-2": 200,
+		}))
+		Expect(usage.VolumeBytes).To(Equal(map[string]uint64{
+			"some-volume":  150,
+			"empty-volume": 0,
+		}))
+
+		Expect(fakeGardenClient.BulkMetricsArgsForCall(0)).To(ConsistOf("container-1", "container-2", "container-3"))
+	})
+
+	Context("when there are no containers", func() {
+		BeforeEach(func() {
+			fakeGardenClient.ContainersReturns(nil, nil)
+		})
+
+		It("reports no container scratch without fetching metrics", func() {
+			Eventually(fakeTSAClient.ReportUsageCallCount).Should(BeNumerically(">=", 1))
+
+			_, usage := fakeTSAClient.ReportUsageArgsForCall(0)
+			Expect(usage.ContainerScratchBytes).To(BeZero())
+			Expect(fakeGardenClient.BulkMetricsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when listing containers fails", func() {
+		BeforeEach(func() {
+			fakeGardenClient.ContainersReturns(nil, errors.New("garden is down"))
+		})
+
+		It("does not report usage", func() {
+			Eventually(fakeGardenClient.ContainersCallCount).Should(BeNumerically(">=", 2))
+			Expect(fakeTSAClient.ReportUsageCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the volumes directory does not exist", func() {
+		BeforeEach(func() {
+			Expect(os.RemoveAll(volumesDir)).To(Succeed())
+			volumesDir = "/does/not/exist"
+		})
+
+		It("does not report usage", func() {
+			Consistently(fakeTSAClient.ReportUsageCallCount, 5*reportInterval).Should(BeZero())
+		})
+	})
+
+	Context("when reporting fails", func() {
+		BeforeEach(func() {
+			fakeTSAClient.ReportUsageReturns(errors.New("tsa is down"))
+		})
+
+		It("keeps reporting", func() {
+			Eventually(fakeTSAClient.ReportUsageCallCount).Should(BeNumerically(">=", 2))
+		})
+	})
+})
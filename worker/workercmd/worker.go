@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse"
 	"github.com/concourse/concourse/atc/worker/gclient"
 	concourseCmd "github.com/concourse/concourse/cmd"
+	"github.com/concourse/concourse/logging"
 	"github.com/concourse/concourse/worker"
 	"github.com/concourse/flag"
 	"github.com/tedsuo/ifrit"
@@ -44,6 +45,8 @@ type WorkerCommand struct {
 	VolumeSweeperMaxInFlight    uint16        `long:"volume-sweeper-max-in-flight" default:"3" description:"Maximum number of volumes which can be swept in parallel."`
 	ContainerSweeperMaxInFlight uint16        `long:"container-sweeper-max-in-flight" default:"5" description:"Maximum number of containers which can be swept in parallel."`
 
+	UsageReportInterval time.Duration `long:"usage-report-interval" default:"1m" description:"Interval on which the disk usage of the worker's volumes and containers will be reported."`
+
 	RebalanceInterval time.Duration `long:"rebalance-interval" default:"4h" description:"Duration after which the registration should be swapped to another random SSH gateway."`
 
 	ConnectionDrainTimeout time.Duration `long:"connection-drain-timeout" default:"1h" description:"Duration after which a worker should give up draining forwarded connections on shutdown."`
@@ -62,7 +65,7 @@ type WorkerCommand struct {
 
 	ResourceTypes flag.Dir `long:"resource-types" description:"Path to directory containing resource types the worker should advertise."`
 
-	Logger flag.Lager
+	Logger logging.Lager
 }
 
 func (cmd *WorkerCommand) Execute(args []string) error {
@@ -153,6 +156,14 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		cmd.VolumeSweeperMaxInFlight,
 	)
 
+	usageReporter := worker.NewUsageReporter(
+		logger.Session("usage-reporter"),
+		cmd.UsageReportInterval,
+		tsaClient,
+		gardenClient,
+		cmd.Baggageclaim.VolumesDir.Path(),
+	)
+
 	var members grouper.Members
 
 	if !cmd.gardenServerIsExternal() {
@@ -208,6 +219,13 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 				volumeSweeper,
 			),
 		},
+		{
+			Name: "usage-reporter",
+			Runner: concourseCmd.NewLoggingRunner(
+				logger.Session("usage-reporter"),
+				usageReporter,
+			),
+		},
 	}...)
 
 	return grouper.NewParallel(os.Interrupt, members), nil
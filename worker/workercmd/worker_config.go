@@ -1,6 +1,7 @@
 package workercmd
 
 import (
+	"runtime"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -17,11 +18,15 @@ type WorkerConfig struct {
 
 	Ephemeral bool `long:"ephemeral" description:"If set, the worker will be immediately removed upon stalling."`
 
+	CPUCapacity    uint64          `long:"cpu-capacity"    description:"CPU shares available for steps' cpu_request. 0 means no limit."`
+	MemoryCapacity atc.MemoryLimit `long:"memory-capacity" description:"Memory available for steps' memory_request, e.g. 16GB. 0 means no limit."`
+
 	Version string `long:"version" hidden:"true" description:"Version of the worker. This is normally baked in to the binary, so this flag is hidden."`
 }
 
 func (c WorkerConfig) Worker() atc.Worker {
 	return atc.Worker{
+		Arch:          runtime.GOARCH,
 		Tags:          c.Tags,
 		Team:          c.TeamName,
 		Name:          c.Name,
@@ -31,5 +36,8 @@ func (c WorkerConfig) Worker() atc.Worker {
 		HTTPSProxyURL: c.HTTPSProxy,
 		NoProxy:       c.NoProxy,
 		Ephemeral:     c.Ephemeral,
+
+		CPUCapacity:    c.CPUCapacity,
+		MemoryCapacity: uint64(c.MemoryCapacity),
 	}
 }
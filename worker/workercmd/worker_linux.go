@@ -87,6 +87,7 @@ func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, i
 
 	worker := cmd.Worker.Worker()
 	worker.Platform = "linux"
+	worker.Runtime = cmd.Runtime
 
 	if cmd.Certs.Dir != "" {
 		worker.CertsPath = &cmd.Certs.Dir
@@ -5,6 +5,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/tsa"
 	"github.com/concourse/concourse/worker"
 )
@@ -69,6 +70,18 @@ type FakeTSAClient struct {
 	reportContainersReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ReportUsageStub        func(context.Context, atc.WorkerUsage) error
+	reportUsageMutex       sync.RWMutex
+	reportUsageArgsForCall []struct {
+		arg1 context.Context
+		arg2 atc.WorkerUsage
+	}
+	reportUsageReturns struct {
+		result1 error
+	}
+	reportUsageReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReportVolumesStub        func(context.Context, []string) error
 	reportVolumesMutex       sync.RWMutex
 	reportVolumesArgsForCall []struct {
@@ -424,6 +437,68 @@ func (fake *FakeTSAClient) ReportContainersReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTSAClient) ReportUsage(arg1 context.Context, arg2 atc.WorkerUsage) error {
+	fake.reportUsageMutex.Lock()
+	ret, specificReturn := fake.reportUsageReturnsOnCall[len(fake.reportUsageArgsForCall)]
+	fake.reportUsageArgsForCall = append(fake.reportUsageArgsForCall, struct {
+		arg1 context.Context
+		arg2 atc.WorkerUsage
+	}{arg1, arg2})
+	stub := fake.ReportUsageStub
+	fakeReturns := fake.reportUsageReturns
+	fake.recordInvocation("ReportUsage", []interface{}{arg1, arg2})
+	fake.reportUsageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTSAClient) ReportUsageCallCount() int {
+	fake.reportUsageMutex.RLock()
+	defer fake.reportUsageMutex.RUnlock()
+	return len(fake.reportUsageArgsForCall)
+}
+
+func (fake *FakeTSAClient) ReportUsageCalls(stub func(context.Context, atc.WorkerUsage) error) {
+	fake.reportUsageMutex.Lock()
+	defer fake.reportUsageMutex.Unlock()
+	fake.ReportUsageStub = stub
+}
+
+func (fake *FakeTSAClient) ReportUsageArgsForCall(i int) (context.Context, atc.WorkerUsage) {
+	fake.reportUsageMutex.RLock()
+	defer fake.reportUsageMutex.RUnlock()
+	argsForCall := fake.reportUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTSAClient) ReportUsageReturns(result1 error) {
+	fake.reportUsageMutex.Lock()
+	defer fake.reportUsageMutex.Unlock()
+	fake.ReportUsageStub = nil
+	fake.reportUsageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTSAClient) ReportUsageReturnsOnCall(i int, result1 error) {
+	fake.reportUsageMutex.Lock()
+	defer fake.reportUsageMutex.Unlock()
+	fake.ReportUsageStub = nil
+	if fake.reportUsageReturnsOnCall == nil {
+		fake.reportUsageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reportUsageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTSAClient) ReportVolumes(arg1 context.Context, arg2 []string) error {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -629,6 +704,8 @@ func (fake *FakeTSAClient) Invocations() map[string][][]interface{} {
 	defer fake.registerMutex.RUnlock()
 	fake.reportContainersMutex.RLock()
 	defer fake.reportContainersMutex.RUnlock()
+	fake.reportUsageMutex.RLock()
+	defer fake.reportUsageMutex.RUnlock()
 	fake.reportVolumesMutex.RLock()
 	defer fake.reportVolumesMutex.RUnlock()
 	fake.retireMutex.RLock()